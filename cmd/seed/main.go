@@ -0,0 +1,84 @@
+// Command seed fills a local or staging database with synthetic organizations, teachers,
+// students, question banks, published assessments and graded attempts, so analytics and
+// performance work can be tested without production data.
+//
+// It reads the same environment as the service and refuses to run in production:
+//
+//	go run ./cmd/seed -orgs 3 -students 500 -owner <your-user-id>
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/SAP-F-2025/assessment-service/internal/config"
+	"github.com/SAP-F-2025/assessment-service/internal/seed"
+	"github.com/SAP-F-2025/assessment-service/pkg"
+)
+
+func main() {
+	var (
+		cfg        seed.Config
+		jsonOutput bool
+	)
+
+	flag.StringVar(&cfg.Prefix, "prefix", "seed", "prefix of seeded user IDs and titles")
+	flag.StringVar(&cfg.Term, "term", "", "academic term of seeded assessments (default \"Seed Term\")")
+	flag.IntVar(&cfg.Orgs, "orgs", 0, "number of organizations (default 3)")
+	flag.IntVar(&cfg.TeachersPerOrg, "teachers", 0, "teachers per organization (default 4)")
+	flag.IntVar(&cfg.StudentsPerOrg, "students", 0, "students per organization (default 200)")
+	flag.IntVar(&cfg.BanksPerTeacher, "banks", 0, "question banks per teacher (default 2)")
+	flag.IntVar(&cfg.QuestionsPerBank, "bank-questions", 0, "questions per bank (default 25)")
+	flag.IntVar(&cfg.AssessmentsPerTeacher, "assessments", 0, "published assessments per teacher (default 3)")
+	flag.IntVar(&cfg.QuestionsPerAssessment, "assessment-questions", 0, "questions per assessment (default 15)")
+	flag.Float64Var(&cfg.AttemptRate, "attempt-rate", 0, "share of students taking each assessment (default 0.8)")
+	flag.StringVar(&cfg.OwnerID, "owner", "", "existing user ID that owns the first teacher's content")
+	flag.Int64Var(&cfg.Seed, "seed", 0, "random seed for reproducible data sets")
+	flag.IntVar(&cfg.BatchSize, "batch-size", 0, "rows per insert (default 500)")
+	flag.BoolVar(&jsonOutput, "json", false, "print the summary as JSON")
+	flag.Parse()
+
+	appConfig, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	if appConfig.Environment == "production" {
+		log.Fatal("Refusing to seed a production database")
+	}
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	db, err := pkg.InitDatabase(appConfig)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+
+	seeder, err := seed.NewSeeder(cfg, db, logger)
+	if err != nil {
+		log.Fatalf("Invalid seed options: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	summary, err := seeder.Run(ctx)
+	if err != nil {
+		log.Fatalf("Seeding failed, nothing was written: %v", err)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(summary); err != nil {
+			log.Fatalf("Failed to encode summary: %v", err)
+		}
+		return
+	}
+	summary.WriteText(os.Stdout)
+}
@@ -0,0 +1,116 @@
+// Command simulate runs virtual students against a deployed assessment to check
+// performance and concurrency safety before a real exam day.
+//
+// Every line of the tokens file is the bearer token of one student account:
+//
+//	go run ./cmd/simulate -base-url http://localhost:8080/api/v1 -assessment 42 -tokens students.txt
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/SAP-F-2025/assessment-service/internal/simulation"
+)
+
+func main() {
+	var (
+		cfg        simulation.Config
+		tokensFile string
+		students   int
+		jsonOutput bool
+		verbose    bool
+	)
+
+	flag.StringVar(&cfg.BaseURL, "base-url", "http://localhost:8080/api/v1", "API base URL")
+	flag.UintVar(&cfg.AssessmentID, "assessment", 0, "ID of the assessment to take")
+	flag.StringVar(&tokensFile, "tokens", "", "file with one student bearer token per line")
+	flag.IntVar(&students, "students", 0, "number of virtual students (default: one per token)")
+	flag.IntVar(&cfg.Concurrency, "concurrency", 0, "students in progress at once (default: all)")
+	flag.DurationVar(&cfg.RampUp, "ramp-up", 0, "period over which students arrive")
+	flag.DurationVar(&cfg.ThinkMin, "think-min", 0, "shortest pause before answering a question (default 2s)")
+	flag.DurationVar(&cfg.ThinkMax, "think-max", 0, "longest pause before answering a question (default 20s)")
+	flag.DurationVar(&cfg.Timeout, "timeout", 0, "per-request timeout (default 30s)")
+	flag.Int64Var(&cfg.Seed, "seed", 0, "random seed for reproducible runs")
+	flag.BoolVar(&cfg.DuplicateStart, "duplicate-start", false, "race two start requests per student")
+	flag.BoolVar(&cfg.DuplicateSubmit, "duplicate-submit", false, "race two submit requests per student")
+	flag.BoolVar(&jsonOutput, "json", false, "print the report as JSON")
+	flag.BoolVar(&verbose, "v", false, "log every failing student")
+	flag.Parse()
+
+	tokens, err := readTokens(tokensFile)
+	if err != nil {
+		log.Fatalf("Failed to read tokens: %v", err)
+	}
+	if students > 0 {
+		if students > len(tokens) {
+			log.Fatalf("Requested %d students but only %d tokens are available", students, len(tokens))
+		}
+		tokens = tokens[:students]
+	}
+	cfg.Tokens = tokens
+
+	level := slog.LevelInfo
+	if verbose {
+		level = slog.LevelDebug
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+
+	runner, err := simulation.NewRunner(cfg, logger)
+	if err != nil {
+		log.Fatalf("Invalid simulation: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	report, err := runner.Run(ctx)
+	if err != nil {
+		logger.Warn("Simulation interrupted", "error", err)
+	}
+
+	if jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Failed to encode report: %v", err)
+		}
+	} else {
+		report.WriteText(os.Stdout)
+	}
+
+	// A non-zero exit lets CI treat failed students and anomalies as a failed rehearsal
+	if report.Failed > 0 || len(report.Anomalies) > 0 {
+		os.Exit(1)
+	}
+}
+
+func readTokens(path string) ([]string, error) {
+	if path == "" {
+		return nil, fmt.Errorf("-tokens is required")
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var tokens []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if token := strings.TrimSpace(scanner.Text()); token != "" && !strings.HasPrefix(token, "#") {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, scanner.Err()
+}
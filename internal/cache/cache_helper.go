@@ -4,19 +4,24 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+
+	"github.com/SAP-F-2025/assessment-service/internal/residency"
 )
 
 // CacheHelper provides common caching operations for repositories
 type CacheHelper struct {
-	client *redis.Client
-	prefix string
+	client     *Client
+	prefix     string
+	repository string // Repository the helper caches for; empty when it is not tied to one
 }
 
 // NewCacheHelper creates a new cache helper instance
-func NewCacheHelper(client *redis.Client, prefix string) *CacheHelper {
+func NewCacheHelper(client *Client, prefix string) *CacheHelper {
 	return &CacheHelper{
 		client: client,
 		prefix: prefix,
@@ -29,7 +34,8 @@ type CacheConfig struct {
 	Prefix string
 }
 
-// Default cache configurations based on docs.txt performance requirements
+// Default cache configurations based on docs.txt performance requirements. Running
+// instances read TTLs from the client's Settings, which start out with these.
 var (
 	// Short-lived cache for frequently accessed data
 	FastCacheConfig = CacheConfig{
@@ -62,27 +68,48 @@ var (
 	}
 )
 
+// TTL returns the configured TTL of the helper's key family
+func (c *CacheHelper) TTL() time.Duration {
+	return c.client.Settings().TTL(strings.TrimSuffix(c.prefix, ":"))
+}
+
+// enabled reports whether reads and writes should reach Redis. Deletes and invalidations
+// still run while a repository's caching is off, so nothing stale is left for when it is on.
+func (c *CacheHelper) enabled() bool {
+	return c.client.Available() && c.client.Settings().RepositoryEnabled(c.repository)
+}
+
 // GetCacheKey generates a cache key with prefix
 func (c *CacheHelper) GetCacheKey(key string) string {
 	return fmt.Sprintf("%s%s", c.prefix, key)
 }
 
+// key builds the full cache key, namespaced by the data residency region of the context
+func (c *CacheHelper) key(ctx context.Context, key string) string {
+	return residency.Key(ctx, c.GetCacheKey(key))
+}
+
 // Get retrieves and unmarshals data from cache
 func (c *CacheHelper) Get(ctx context.Context, key string, dest interface{}) error {
-	if c.client == nil {
+	if !c.enabled() {
 		return ErrCacheNotAvailable
 	}
 
-	cacheKey := c.GetCacheKey(key)
+	cacheKey := c.key(ctx, key)
 	data, err := c.client.Get(ctx, cacheKey).Result()
 	if err != nil {
 		if err == redis.Nil {
 			return ErrCacheNotFound
 		}
+		c.client.ReportError(err)
 		return fmt.Errorf("cache get error: %w", err)
 	}
 
-	if err := json.Unmarshal([]byte(data), dest); err != nil {
+	raw, err := c.client.Compressor().Decompress(CompressionStoreCache, []byte(data))
+	if err != nil {
+		return fmt.Errorf("cache decompress error: %w", err)
+	}
+	if err := json.Unmarshal(raw, dest); err != nil {
 		return fmt.Errorf("cache unmarshal error: %w", err)
 	}
 
@@ -91,7 +118,7 @@ func (c *CacheHelper) Get(ctx context.Context, key string, dest interface{}) err
 
 // Set marshals and stores data in cache
 func (c *CacheHelper) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
-	if c.client == nil {
+	if !c.enabled() {
 		return nil // Graceful degradation when cache not available
 	}
 
@@ -99,33 +126,35 @@ func (c *CacheHelper) Set(ctx context.Context, key string, value interface{}, tt
 	if err != nil {
 		return fmt.Errorf("cache marshal error: %w", err)
 	}
+	data = c.client.Compressor().Compress(CompressionStoreCache, data)
 
-	cacheKey := c.GetCacheKey(key)
-	return c.client.Set(ctx, cacheKey, data, ttl).Err()
+	cacheKey := c.key(ctx, key)
+	return c.report(c.client.Set(ctx, cacheKey, data, ttl).Err())
 }
 
 // SetString stores string data in cache
 func (c *CacheHelper) SetString(ctx context.Context, key string, value string, ttl time.Duration) error {
-	if c.client == nil {
+	if !c.enabled() {
 		return nil
 	}
 
-	cacheKey := c.GetCacheKey(key)
-	return c.client.Set(ctx, cacheKey, value, ttl).Err()
+	cacheKey := c.key(ctx, key)
+	return c.report(c.client.Set(ctx, cacheKey, value, ttl).Err())
 }
 
 // GetString retrieves string data from cache
 func (c *CacheHelper) GetString(ctx context.Context, key string) (string, error) {
-	if c.client == nil {
+	if !c.enabled() {
 		return "", ErrCacheNotAvailable
 	}
 
-	cacheKey := c.GetCacheKey(key)
+	cacheKey := c.key(ctx, key)
 	result, err := c.client.Get(ctx, cacheKey).Result()
 	if err != nil {
 		if err == redis.Nil {
 			return "", ErrCacheNotFound
 		}
+		c.client.ReportError(err)
 		return "", fmt.Errorf("cache get string error: %w", err)
 	}
 
@@ -134,7 +163,7 @@ func (c *CacheHelper) GetString(ctx context.Context, key string) (string, error)
 
 // Delete removes data from cache
 func (c *CacheHelper) Delete(ctx context.Context, keys ...string) error {
-	if c.client == nil {
+	if !c.client.Available() {
 		return nil
 	}
 
@@ -144,21 +173,22 @@ func (c *CacheHelper) Delete(ctx context.Context, keys ...string) error {
 
 	cacheKeys := make([]string, len(keys))
 	for i, key := range keys {
-		cacheKeys[i] = c.GetCacheKey(key)
+		cacheKeys[i] = c.key(ctx, key)
 	}
 
-	return c.client.Del(ctx, cacheKeys...).Err()
+	return c.deleteKeys(ctx, cacheKeys)
 }
 
 // Exists checks if a key exists in cache
 func (c *CacheHelper) Exists(ctx context.Context, key string) (bool, error) {
-	if c.client == nil {
+	if !c.enabled() {
 		return false, ErrCacheNotAvailable
 	}
 
-	cacheKey := c.GetCacheKey(key)
+	cacheKey := c.key(ctx, key)
 	count, err := c.client.Exists(ctx, cacheKey).Result()
 	if err != nil {
+		c.client.ReportError(err)
 		return false, fmt.Errorf("cache exists error: %w", err)
 	}
 
@@ -167,7 +197,7 @@ func (c *CacheHelper) Exists(ctx context.Context, key string) (bool, error) {
 
 // SetMultiple stores multiple key-value pairs in a pipeline
 func (c *CacheHelper) SetMultiple(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
-	if c.client == nil {
+	if !c.enabled() {
 		return nil
 	}
 
@@ -183,17 +213,17 @@ func (c *CacheHelper) SetMultiple(ctx context.Context, items map[string]interfac
 			return fmt.Errorf("cache marshal error for key %s: %w", key, err)
 		}
 
-		cacheKey := c.GetCacheKey(key)
+		cacheKey := c.key(ctx, key)
 		pipe.Set(ctx, cacheKey, data, ttl)
 	}
 
 	_, err := pipe.Exec(ctx)
-	return err
+	return c.report(err)
 }
 
 // GetMultiple retrieves multiple values from cache
 func (c *CacheHelper) GetMultiple(ctx context.Context, keys []string) (map[string]string, error) {
-	if c.client == nil {
+	if !c.enabled() {
 		return nil, ErrCacheNotAvailable
 	}
 
@@ -203,20 +233,24 @@ func (c *CacheHelper) GetMultiple(ctx context.Context, keys []string) (map[strin
 
 	cacheKeys := make([]string, len(keys))
 	for i, key := range keys {
-		cacheKeys[i] = c.GetCacheKey(key)
+		cacheKeys[i] = c.key(ctx, key)
 	}
 
-	values, err := c.client.MGet(ctx, cacheKeys...).Result()
-	if err != nil {
+	// Pipelined GETs instead of MGET, which fails across cluster hash slots
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.StringCmd, len(cacheKeys))
+	for i, cacheKey := range cacheKeys {
+		cmds[i] = pipe.Get(ctx, cacheKey)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		c.client.ReportError(err)
 		return nil, fmt.Errorf("cache mget error: %w", err)
 	}
 
 	result := make(map[string]string)
-	for i, value := range values {
-		if value != nil {
-			if str, ok := value.(string); ok {
-				result[keys[i]] = str
-			}
+	for i, cmd := range cmds {
+		if value, err := cmd.Result(); err == nil {
+			result[keys[i]] = value
 		}
 	}
 
@@ -225,13 +259,14 @@ func (c *CacheHelper) GetMultiple(ctx context.Context, keys []string) (map[strin
 
 // InvalidatePattern removes all keys matching a pattern
 func (c *CacheHelper) InvalidatePattern(ctx context.Context, pattern string) error {
-	if c.client == nil {
+	if !c.client.Available() {
 		return nil
 	}
 
-	fullPattern := c.GetCacheKey(pattern)
-	keys, err := c.client.Keys(ctx, fullPattern).Result()
+	fullPattern := c.key(ctx, pattern)
+	keys, err := c.scanKeys(ctx, fullPattern)
 	if err != nil {
+		c.client.ReportError(err)
 		return fmt.Errorf("cache keys pattern error: %w", err)
 	}
 
@@ -239,7 +274,54 @@ func (c *CacheHelper) InvalidatePattern(ctx context.Context, pattern string) err
 		return nil
 	}
 
-	return c.client.Del(ctx, keys...).Err()
+	return c.deleteKeys(ctx, keys)
+}
+
+// scanKeys collects keys matching a pattern, visiting every master in cluster mode
+func (c *CacheHelper) scanKeys(ctx context.Context, pattern string) ([]string, error) {
+	scan := func(ctx context.Context, client redis.UniversalClient) ([]string, error) {
+		var keys []string
+		iter := client.Scan(ctx, 0, pattern, 100).Iterator()
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+		}
+		return keys, iter.Err()
+	}
+
+	cluster, ok := c.client.UniversalClient.(*redis.ClusterClient)
+	if !ok {
+		return scan(ctx, c.client)
+	}
+
+	var mu sync.Mutex
+	var keys []string
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+		nodeKeys, err := scan(ctx, node)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		keys = append(keys, nodeKeys...)
+		mu.Unlock()
+		return nil
+	})
+	return keys, err
+}
+
+// deleteKeys removes keys one by one in a pipeline; a multi-key DEL fails across cluster hash slots
+func (c *CacheHelper) deleteKeys(ctx context.Context, keys []string) error {
+	pipe := c.client.Pipeline()
+	for _, key := range keys {
+		pipe.Del(ctx, key)
+	}
+	_, err := pipe.Exec(ctx)
+	return c.report(err)
+}
+
+// report flags Redis as unavailable on command failures and passes the error on
+func (c *CacheHelper) report(err error) error {
+	c.client.ReportError(err)
+	return err
 }
 
 // SetWithConfig stores data using predefined config
@@ -301,8 +383,9 @@ type CacheManager struct {
 	Fast       *CacheHelper
 }
 
-// NewCacheManager creates cache manager with all cache helpers
-func NewCacheManager(client *redis.Client) *CacheManager {
+// NewCacheManager creates cache manager with all cache helpers for a repository, whose
+// caching can be turned off at runtime; an empty repository is always cached
+func NewCacheManager(client *Client, repository string) *CacheManager {
 	if client == nil {
 		return &CacheManager{
 			Assessment: NewCacheHelper(nil, ""),
@@ -314,7 +397,7 @@ func NewCacheManager(client *redis.Client) *CacheManager {
 		}
 	}
 
-	return &CacheManager{
+	cm := &CacheManager{
 		Assessment: NewCacheHelper(client, AssessmentCacheConfig.Prefix),
 		Question:   NewCacheHelper(client, QuestionCacheConfig.Prefix),
 		User:       NewCacheHelper(client, "user:"),
@@ -322,6 +405,10 @@ func NewCacheManager(client *redis.Client) *CacheManager {
 		Exists:     NewCacheHelper(client, ExistsCacheConfig.Prefix),
 		Fast:       NewCacheHelper(client, FastCacheConfig.Prefix),
 	}
+	for _, helper := range []*CacheHelper{cm.Assessment, cm.Question, cm.User, cm.Stats, cm.Exists, cm.Fast} {
+		helper.repository = repository
+	}
+	return cm
 }
 
 // HealthCheck verifies cache connectivity
@@ -330,8 +417,7 @@ func (cm *CacheManager) HealthCheck(ctx context.Context) error {
 		return ErrCacheNotAvailable
 	}
 
-	_, err := cm.Fast.client.Ping(ctx).Result()
-	if err != nil {
+	if err := cm.Fast.client.CheckHealth(ctx); err != nil {
 		return fmt.Errorf("cache health check failed: %w", err)
 	}
 
@@ -340,10 +426,16 @@ func (cm *CacheManager) HealthCheck(ctx context.Context) error {
 
 // ClearAll clears all caches (use with caution)
 func (cm *CacheManager) ClearAll(ctx context.Context) error {
-	if cm.Fast.client == nil {
+	if !cm.Fast.client.Available() {
 		return nil
 	}
 
+	if cluster, ok := cm.Fast.client.UniversalClient.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return node.FlushAll(ctx).Err()
+		})
+	}
+
 	return cm.Fast.client.FlushAll(ctx).Err()
 }
 
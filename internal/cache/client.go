@@ -0,0 +1,149 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Client wraps a standalone, cluster or sentinel Redis client and tracks its health.
+// While Redis is unavailable cache operations are skipped so reads fall through to
+// the database instead of failing or waiting on connection timeouts.
+type Client struct {
+	redis.UniversalClient
+	logger     *slog.Logger
+	available  atomic.Bool
+	compressor *Compressor
+	settings   *Settings
+}
+
+// NewClient wraps a Redis client; it starts out available until a check says otherwise
+func NewClient(client redis.UniversalClient, logger *slog.Logger) *Client {
+	c := &Client{
+		UniversalClient: client,
+		logger:          logger,
+		settings:        NewSettings(),
+	}
+	c.available.Store(true)
+	return c
+}
+
+// Available reports whether cache operations should be attempted. Safe on a nil client.
+func (c *Client) Available() bool {
+	return c != nil && c.UniversalClient != nil && c.available.Load()
+}
+
+// CheckHealth pings Redis and updates availability
+func (c *Client) CheckHealth(ctx context.Context) error {
+	if c == nil || c.UniversalClient == nil {
+		return ErrCacheNotAvailable
+	}
+
+	err := c.Ping(ctx).Err()
+	c.setAvailable(err == nil, err)
+	return err
+}
+
+// SetCompressor compresses large cached values from now on. Call it before the client is used.
+func (c *Client) SetCompressor(compressor *Compressor) {
+	if c != nil {
+		c.compressor = compressor
+	}
+}
+
+// Compressor returns the compressor for large values, nil when values are stored as they are
+func (c *Client) Compressor() *Compressor {
+	if c == nil {
+		return nil
+	}
+	return c.compressor
+}
+
+// Settings returns the cache TTLs and per-repository switches, nil on a nil client
+func (c *Client) Settings() *Settings {
+	if c == nil {
+		return nil
+	}
+	return c.settings
+}
+
+// ReportError marks Redis unavailable after a command failed to reach it. Cache misses,
+// cancelled callers and replies from a reachable server are not failures.
+func (c *Client) ReportError(err error) {
+	if c == nil || !isConnectionError(err) {
+		return
+	}
+	c.setAvailable(false, err)
+}
+
+// isConnectionError reports whether err means Redis could not be reached: a network
+// failure or an exhausted, timed out or closed connection pool
+func isConnectionError(err error) bool {
+	if err == nil || errors.Is(err, redis.Nil) {
+		return false
+	}
+	// Checked before net.Error, which context.DeadlineExceeded also satisfies
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	// Server replies, cluster MOVED/ASK redirects included, come from a reachable node
+	var reply redis.Error
+	if errors.As(err, &reply) {
+		return false
+	}
+
+	switch {
+	case errors.Is(err, redis.ErrPoolTimeout), errors.Is(err, redis.ErrPoolExhausted), errors.Is(err, redis.ErrClosed):
+		return true
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// Monitor checks Redis health every interval until the context is cancelled,
+// restoring cache usage once Redis is reachable again and picking up the cache
+// settings other instances shared
+func (c *Client) Monitor(ctx context.Context, interval time.Duration) {
+	if c == nil || c.UniversalClient == nil || interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkCtx, cancel := context.WithTimeout(ctx, interval/2)
+			if c.CheckHealth(checkCtx) == nil {
+				c.syncSettings(checkCtx)
+			}
+			cancel()
+		}
+	}
+}
+
+func (c *Client) setAvailable(available bool, err error) {
+	if c.available.Swap(available) == available {
+		return
+	}
+
+	if c.logger == nil {
+		return
+	}
+	if available {
+		c.logger.Info("Redis is available again, cache re-enabled")
+	} else {
+		c.logger.Warn("Redis unavailable, skipping cache until it recovers", "error", err)
+	}
+}
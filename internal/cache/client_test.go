@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisReply stands in for an error reply from a reachable Redis server
+type redisReply string
+
+func (r redisReply) Error() string { return string(r) }
+func (redisReply) RedisError()     {}
+
+func TestIsConnectionError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"cache miss", redis.Nil, false},
+		{"caller cancelled", context.Canceled, false},
+		{"caller deadline", fmt.Errorf("get: %w", context.DeadlineExceeded), false},
+		{"cluster moved", redisReply("MOVED 3999 10.0.0.2:6379"), false},
+		{"cluster ask", redisReply("ASK 3999 10.0.0.2:6379"), false},
+		{"wrong type", redisReply("WRONGTYPE Operation against a key holding the wrong kind of value"), false},
+		{"unknown", errors.New("failed to decode cached value"), false},
+		{"dial refused", &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{"connection dropped", io.EOF, true},
+		{"pool timeout", redis.ErrPoolTimeout, true},
+		{"pool exhausted", fmt.Errorf("set: %w", redis.ErrPoolExhausted), true},
+		{"client closed", redis.ErrClosed, true},
+	}
+
+	for _, tt := range tests {
+		if got := isConnectionError(tt.err); got != tt.want {
+			t.Errorf("%s: isConnectionError = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReportErrorKeepsCacheOnReplies(t *testing.T) {
+	client := NewClient(redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"}), nil)
+	defer client.Close()
+
+	client.ReportError(redisReply("MOVED 3999 10.0.0.2:6379"))
+	client.ReportError(context.Canceled)
+	if !client.Available() {
+		t.Fatal("redirects and cancelled callers should not disable the cache")
+	}
+
+	client.ReportError(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")})
+	if client.Available() {
+		t.Error("a network failure should disable the cache")
+	}
+}
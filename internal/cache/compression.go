@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// DefaultCompressionThreshold is the payload size from which payloads are compressed
+	DefaultCompressionThreshold = 4 << 10
+
+	// Decompressed payloads larger than this are rejected instead of filling memory
+	maxDecompressedSize = 64 << 20
+)
+
+// Stores whose compression is reported separately
+const (
+	CompressionStoreAnswers  = "answers"
+	CompressionStoreCache    = "cache"
+	CompressionStoreAutosave = "autosave"
+)
+
+// zstdMagic starts every zstd frame. JSON never starts with it, so payloads written
+// before compression was turned on are told apart without a marker.
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+var ErrCompressionNotConfigured = errors.New("payload is compressed but no compressor is configured")
+
+// Compressor zstd-compresses payloads from a size threshold on and keeps per-store
+// statistics on how much space it saves. A nil Compressor passes payloads through.
+type Compressor struct {
+	threshold int
+	encoder   *zstd.Encoder
+	decoder   *zstd.Decoder
+
+	mu    sync.Mutex
+	stats map[string]*CompressionStats
+}
+
+// CompressionStats counts the payloads of one store since startup
+type CompressionStats struct {
+	Payloads     uint64 `json:"payloads"`     // Payloads considered for compression
+	Compressed   uint64 `json:"compressed"`   // Payloads stored compressed
+	RawBytes     uint64 `json:"raw_bytes"`    // Size of the compressed payloads before compression
+	StoredBytes  uint64 `json:"stored_bytes"` // Size of the compressed payloads as stored
+	Decompressed uint64 `json:"decompressed"` // Compressed payloads read back
+	Errors       uint64 `json:"errors"`       // Compressed payloads that could not be read
+}
+
+// Ratio is the raw size of compressed payloads over their stored size, 0 before any
+func (s CompressionStats) Ratio() float64 {
+	if s.StoredBytes == 0 {
+		return 0
+	}
+	return float64(s.RawBytes) / float64(s.StoredBytes)
+}
+
+// NewCompressor creates a compressor for payloads of at least threshold bytes. A
+// threshold of zero or less only reads compressed payloads and never writes new ones.
+func NewCompressor(threshold int) (*Compressor, error) {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+	}
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecoderMaxMemory(maxDecompressedSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+	}
+
+	return &Compressor{
+		threshold: threshold,
+		encoder:   encoder,
+		decoder:   decoder,
+		stats:     make(map[string]*CompressionStats),
+	}, nil
+}
+
+// IsCompressed reports whether a payload was written by a Compressor
+func IsCompressed(data []byte) bool {
+	return bytes.HasPrefix(data, zstdMagic)
+}
+
+// Compress returns the payload compressed when it is large enough and compression
+// saves space, otherwise the payload itself
+func (c *Compressor) Compress(store string, data []byte) []byte {
+	if c == nil || c.threshold <= 0 || len(data) < c.threshold {
+		return data
+	}
+
+	compressed := c.encoder.EncodeAll(data, make([]byte, 0, len(data)/2))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.storeStats(store)
+	stats.Payloads++
+	if len(compressed) >= len(data) {
+		return data
+	}
+	stats.Compressed++
+	stats.RawBytes += uint64(len(data))
+	stats.StoredBytes += uint64(len(compressed))
+	return compressed
+}
+
+// Decompress returns the original payload. Payloads that were never compressed are
+// returned as they are.
+func (c *Compressor) Decompress(store string, data []byte) ([]byte, error) {
+	if !IsCompressed(data) {
+		return data, nil
+	}
+	if c == nil {
+		return nil, ErrCompressionNotConfigured
+	}
+
+	decompressed, err := c.decoder.DecodeAll(data, nil)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.storeStats(store)
+	if err != nil {
+		stats.Errors++
+		return nil, fmt.Errorf("failed to decompress payload: %w", err)
+	}
+	stats.Decompressed++
+	return decompressed, nil
+}
+
+// Stats returns a copy of the statistics of every store
+func (c *Compressor) Stats() map[string]CompressionStats {
+	stats := make(map[string]CompressionStats)
+	if c == nil {
+		return stats
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for store, s := range c.stats {
+		stats[store] = *s
+	}
+	return stats
+}
+
+// WritePrometheus writes the statistics in the Prometheus text format
+func (c *Compressor) WritePrometheus(w io.Writer) error {
+	stats := c.Stats()
+	stores := make([]string, 0, len(stats))
+	for store := range stats {
+		stores = append(stores, store)
+	}
+	sort.Strings(stores)
+
+	metrics := []struct {
+		name, kind, help string
+		value            func(CompressionStats) float64
+	}{
+		{"payload_compression_payloads_total", "counter", "Payloads large enough to be compressed.", func(s CompressionStats) float64 {
+			return float64(s.Payloads)
+		}},
+		{"payload_compression_compressed_total", "counter", "Payloads stored compressed.", func(s CompressionStats) float64 {
+			return float64(s.Compressed)
+		}},
+		{"payload_compression_raw_bytes_total", "counter", "Size of compressed payloads before compression.", func(s CompressionStats) float64 {
+			return float64(s.RawBytes)
+		}},
+		{"payload_compression_stored_bytes_total", "counter", "Size of compressed payloads as stored.", func(s CompressionStats) float64 {
+			return float64(s.StoredBytes)
+		}},
+		{"payload_compression_ratio", "gauge", "Raw over stored size of compressed payloads.", func(s CompressionStats) float64 {
+			return s.Ratio()
+		}},
+		{"payload_compression_decompressed_total", "counter", "Compressed payloads read back.", func(s CompressionStats) float64 {
+			return float64(s.Decompressed)
+		}},
+		{"payload_compression_errors_total", "counter", "Compressed payloads that could not be read.", func(s CompressionStats) float64 {
+			return float64(s.Errors)
+		}},
+	}
+	for _, metric := range metrics {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.kind); err != nil {
+			return err
+		}
+		for _, store := range stores {
+			value := strconv.FormatFloat(metric.value(stats[store]), 'g', -1, 64)
+			if _, err := fmt.Fprintf(w, "%s{store=%q} %s\n", metric.name, store, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Compressor) storeStats(store string) *CompressionStats {
+	stats, ok := c.stats[store]
+	if !ok {
+		stats = &CompressionStats{}
+		c.stats[store] = stats
+	}
+	return stats
+}
@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func largeEssay() []byte {
+	return []byte(`{"text":"` + strings.Repeat("The mitochondria is the powerhouse of the cell. ", 200) + `","word_count":1600}`)
+}
+
+func TestCompressorRoundTrip(t *testing.T) {
+	compressor, err := NewCompressor(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	essay := largeEssay()
+	packed := compressor.Compress(CompressionStoreAnswers, essay)
+	if !IsCompressed(packed) {
+		t.Fatal("expected a large essay to be compressed")
+	}
+	if len(packed) >= len(essay) {
+		t.Errorf("expected compression to save space, got %d of %d bytes", len(packed), len(essay))
+	}
+
+	unpacked, err := compressor.Decompress(CompressionStoreAnswers, packed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(unpacked, essay) {
+		t.Error("expected the essay back unchanged")
+	}
+}
+
+func TestCompressorLeavesSmallPayloads(t *testing.T) {
+	compressor, err := NewCompressor(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	small := []byte(`{"selected_options":["a"]}`)
+	if packed := compressor.Compress(CompressionStoreAnswers, small); !bytes.Equal(packed, small) {
+		t.Error("expected a payload below the threshold to be stored as is")
+	}
+	if stats := compressor.Stats()[CompressionStoreAnswers]; stats.Payloads != 0 {
+		t.Errorf("expected payloads below the threshold not to be counted, got %d", stats.Payloads)
+	}
+}
+
+func TestCompressorKeepsIncompressiblePayloads(t *testing.T) {
+	compressor, err := NewCompressor(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	noise := make([]byte, 4096)
+	if _, err := rand.Read(noise); err != nil {
+		t.Fatal(err)
+	}
+	noise[0] = '{'
+	if packed := compressor.Compress(CompressionStoreCache, noise); IsCompressed(packed) {
+		t.Error("expected a payload that does not shrink to be stored as is")
+	}
+
+	stats := compressor.Stats()[CompressionStoreCache]
+	if stats.Payloads != 1 || stats.Compressed != 0 {
+		t.Errorf("expected 1 payload and none compressed, got %d and %d", stats.Payloads, stats.Compressed)
+	}
+}
+
+func TestCompressorReadsUncompressedPayloads(t *testing.T) {
+	compressor, err := NewCompressor(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legacy := largeEssay()
+	data, err := compressor.Decompress(CompressionStoreAnswers, legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, legacy) {
+		t.Error("expected a payload written before compression to be read as is")
+	}
+	if stats := compressor.Stats()[CompressionStoreAnswers]; stats.Decompressed != 0 {
+		t.Errorf("expected no decompression, got %d", stats.Decompressed)
+	}
+}
+
+func TestCompressorDisabled(t *testing.T) {
+	enabled, err := NewCompressor(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	disabled, err := NewCompressor(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	essay := largeEssay()
+	if packed := disabled.Compress(CompressionStoreAnswers, essay); IsCompressed(packed) {
+		t.Error("expected a disabled compressor to store payloads as is")
+	}
+
+	// Payloads compressed before compression was turned off still read
+	packed := enabled.Compress(CompressionStoreAnswers, essay)
+	data, err := disabled.Decompress(CompressionStoreAnswers, packed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, essay) {
+		t.Error("expected the essay back unchanged")
+	}
+}
+
+func TestNilCompressor(t *testing.T) {
+	var compressor *Compressor
+
+	essay := largeEssay()
+	if packed := compressor.Compress(CompressionStoreCache, essay); !bytes.Equal(packed, essay) {
+		t.Error("expected a nil compressor to pass payloads through")
+	}
+	if data, err := compressor.Decompress(CompressionStoreCache, essay); err != nil || !bytes.Equal(data, essay) {
+		t.Errorf("expected a nil compressor to read plain payloads, got error %v", err)
+	}
+
+	enabled, err := NewCompressor(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := compressor.Decompress(CompressionStoreCache, enabled.Compress(CompressionStoreCache, essay)); !errors.Is(err, ErrCompressionNotConfigured) {
+		t.Errorf("expected ErrCompressionNotConfigured, got %v", err)
+	}
+}
+
+func TestCompressorCorruptPayload(t *testing.T) {
+	compressor, err := NewCompressor(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	corrupt := append(append([]byte{}, zstdMagic...), 0xff, 0xff, 0xff)
+	if _, err := compressor.Decompress(CompressionStoreAutosave, corrupt); err == nil {
+		t.Error("expected a corrupt payload to fail")
+	}
+	if stats := compressor.Stats()[CompressionStoreAutosave]; stats.Errors != 1 {
+		t.Errorf("expected 1 error, got %d", stats.Errors)
+	}
+}
+
+func TestCompressionMetrics(t *testing.T) {
+	compressor, err := NewCompressor(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	essay := largeEssay()
+	packed := compressor.Compress(CompressionStoreAnswers, essay)
+	if _, err := compressor.Decompress(CompressionStoreAnswers, packed); err != nil {
+		t.Fatal(err)
+	}
+
+	stats := compressor.Stats()[CompressionStoreAnswers]
+	if stats.RawBytes != uint64(len(essay)) || stats.StoredBytes != uint64(len(packed)) {
+		t.Errorf("expected %d raw and %d stored bytes, got %d and %d", len(essay), len(packed), stats.RawBytes, stats.StoredBytes)
+	}
+	if stats.Ratio() <= 1 {
+		t.Errorf("expected a ratio above 1, got %v", stats.Ratio())
+	}
+
+	var out strings.Builder
+	if err := compressor.WritePrometheus(&out); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"# TYPE payload_compression_ratio gauge",
+		`payload_compression_compressed_total{store="answers"} 1`,
+		`payload_compression_decompressed_total{store="answers"} 1`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Key families; each is named after the prefix of the keys it holds
+const (
+	FamilyFast       = "fast"
+	FamilyAssessment = "assessment"
+	FamilyQuestion   = "question"
+	FamilyExists     = "exists"
+	FamilyStats      = "stats"
+	FamilyUser       = "user"
+)
+
+// Repositories that read through the cache
+const (
+	RepositoryAssessment         = "assessment"
+	RepositoryAssessmentQuestion = "assessment_question"
+	RepositoryQuestion           = "question"
+	RepositoryAttempt            = "attempt"
+	RepositoryAnswer             = "answer"
+	RepositoryUser               = "user"
+)
+
+// Bounds for a family's TTL
+const (
+	minSettingsTTL = time.Second
+	maxSettingsTTL = 24 * time.Hour
+)
+
+// settingsKey holds the runtime overrides shared by every instance. It is not region
+// namespaced and sits outside every family, so flushing a family keeps it.
+const settingsKey = "cache:settings"
+
+// defaultTTLs are the TTLs used until configuration or an admin changes them
+var defaultTTLs = map[string]time.Duration{
+	FamilyFast:       FastCacheConfig.TTL,
+	FamilyAssessment: AssessmentCacheConfig.TTL,
+	FamilyQuestion:   QuestionCacheConfig.TTL,
+	FamilyExists:     ExistsCacheConfig.TTL,
+	FamilyStats:      StatsCacheConfig.TTL,
+	FamilyUser:       15 * time.Minute,
+}
+
+var repositoryNames = []string{
+	RepositoryAssessment,
+	RepositoryAssessmentQuestion,
+	RepositoryQuestion,
+	RepositoryAttempt,
+	RepositoryAnswer,
+	RepositoryUser,
+}
+
+// SettingsUpdate changes the TTLs of the listed families and turns caching on or off for the
+// listed repositories. Families and repositories left out keep their settings.
+type SettingsUpdate struct {
+	TTLSeconds   map[string]int  `json:"ttl_seconds"`
+	Repositories map[string]bool `json:"repositories"` // false stops the repository reading and writing the cache
+}
+
+// Settings holds the cache TTL of each key family and whether each repository uses the cache.
+// Methods are safe on a nil Settings, which reports the defaults.
+type Settings struct {
+	mu       sync.RWMutex
+	ttls     map[string]time.Duration
+	disabled map[string]bool
+}
+
+// NewSettings starts out with the default TTLs and caching on for every repository
+func NewSettings() *Settings {
+	return &Settings{
+		ttls:     maps.Clone(defaultTTLs),
+		disabled: map[string]bool{},
+	}
+}
+
+// TTL returns how long keys of the family are kept
+func (s *Settings) TTL(family string) time.Duration {
+	if s == nil {
+		return defaultTTLs[family]
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ttls[family]
+}
+
+// RepositoryEnabled reports whether the repository reads and writes the cache. Managers not
+// tied to a repository are always enabled.
+func (s *Settings) RepositoryEnabled(repository string) bool {
+	if s == nil || repository == "" {
+		return true
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return !s.disabled[repository]
+}
+
+// Snapshot returns every family's TTL and every repository's state
+func (s *Settings) Snapshot() SettingsUpdate {
+	snapshot := SettingsUpdate{
+		TTLSeconds:   make(map[string]int, len(defaultTTLs)),
+		Repositories: make(map[string]bool, len(repositoryNames)),
+	}
+	for family := range defaultTTLs {
+		snapshot.TTLSeconds[family] = int(s.TTL(family) / time.Second)
+	}
+	for _, repository := range repositoryNames {
+		snapshot.Repositories[repository] = s.RepositoryEnabled(repository)
+	}
+	return snapshot
+}
+
+// Apply validates the whole update before changing anything
+func (s *Settings) Apply(update SettingsUpdate) error {
+	if err := update.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for family, seconds := range update.TTLSeconds {
+		s.ttls[family] = time.Duration(seconds) * time.Second
+	}
+	for repository, enabled := range update.Repositories {
+		s.disabled[repository] = !enabled
+	}
+	return nil
+}
+
+// Validate rejects unknown families and repositories and TTLs out of bounds
+func (u SettingsUpdate) Validate() error {
+	for family, seconds := range u.TTLSeconds {
+		if _, ok := defaultTTLs[family]; !ok {
+			return fmt.Errorf("unknown cache family %q", family)
+		}
+		ttl := time.Duration(seconds) * time.Second
+		if ttl < minSettingsTTL || ttl > maxSettingsTTL {
+			return fmt.Errorf("TTL of cache family %q must be between %s and %s", family, minSettingsTTL, maxSettingsTTL)
+		}
+	}
+	for repository := range u.Repositories {
+		if !slices.Contains(repositoryNames, repository) {
+			return fmt.Errorf("unknown cache repository %q", repository)
+		}
+	}
+	return nil
+}
+
+// Families returns the key families in alphabetical order
+func Families() []string {
+	return slices.Sorted(maps.Keys(defaultTTLs))
+}
+
+// ValidFamily reports whether a key family exists
+func ValidFamily(family string) bool {
+	_, ok := defaultTTLs[family]
+	return ok
+}
+
+// ===== SHARED OVERRIDES =====
+
+// UpdateSettings applies an update on this instance and stores the resulting settings in
+// Redis, where the other instances pick them up on their next health check
+func (c *Client) UpdateSettings(ctx context.Context, update SettingsUpdate) (SettingsUpdate, error) {
+	settings := c.Settings()
+	if settings == nil {
+		return SettingsUpdate{}, ErrCacheNotAvailable
+	}
+	if err := settings.Apply(update); err != nil {
+		return SettingsUpdate{}, err
+	}
+
+	snapshot := settings.Snapshot()
+	if !c.Available() {
+		return snapshot, nil
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return snapshot, fmt.Errorf("failed to encode cache settings: %w", err)
+	}
+	if err := c.Set(ctx, settingsKey, data, 0).Err(); err != nil {
+		c.ReportError(err)
+		return snapshot, fmt.Errorf("failed to share cache settings: %w", err)
+	}
+	return snapshot, nil
+}
+
+// syncSettings applies the overrides another instance stored, if any
+func (c *Client) syncSettings(ctx context.Context) {
+	data, err := c.Get(ctx, settingsKey).Bytes()
+	if err != nil {
+		if err != redis.Nil {
+			c.ReportError(err)
+		}
+		return
+	}
+
+	var update SettingsUpdate
+	if err := json.Unmarshal(data, &update); err != nil {
+		return
+	}
+	if err := c.settings.Apply(update); err != nil && c.logger != nil {
+		c.logger.Warn("Ignoring invalid shared cache settings", "error", err)
+	}
+}
+
+// FlushFamilies deletes every key of the families in every region and returns how many went
+func (c *Client) FlushFamilies(ctx context.Context, families []string) (int, error) {
+	for _, family := range families {
+		if !ValidFamily(family) {
+			return 0, fmt.Errorf("unknown cache family %q", family)
+		}
+	}
+	if !c.Available() {
+		return 0, ErrCacheNotAvailable
+	}
+
+	helper := NewCacheHelper(c, "")
+	deleted := 0
+	for _, family := range families {
+		for _, pattern := range []string{family + ":*", "region:*:" + family + ":*"} {
+			keys, err := helper.scanKeys(ctx, pattern)
+			if err != nil {
+				c.ReportError(err)
+				return deleted, fmt.Errorf("cache keys pattern error: %w", err)
+			}
+			if len(keys) == 0 {
+				continue
+			}
+			if err := helper.deleteKeys(ctx, keys); err != nil {
+				return deleted, fmt.Errorf("cache delete error: %w", err)
+			}
+			deleted += len(keys)
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSettingsDefaults(t *testing.T) {
+	settings := NewSettings()
+	if got := settings.TTL(FamilyFast); got != 5*time.Minute {
+		t.Errorf("fast TTL = %v, want 5m", got)
+	}
+	if !settings.RepositoryEnabled(RepositoryAttempt) {
+		t.Error("repositories should use the cache by default")
+	}
+
+	var unset *Settings
+	if got := unset.TTL(FamilyExists); got != 2*time.Minute {
+		t.Errorf("nil settings exists TTL = %v, want the 2m default", got)
+	}
+	if !unset.RepositoryEnabled(RepositoryAttempt) {
+		t.Error("nil settings should leave caching on")
+	}
+}
+
+func TestSettingsApply(t *testing.T) {
+	settings := NewSettings()
+	err := settings.Apply(SettingsUpdate{
+		TTLSeconds:   map[string]int{FamilyFast: 30},
+		Repositories: map[string]bool{RepositoryAttempt: false},
+	})
+	if err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if got := settings.TTL(FamilyFast); got != 30*time.Second {
+		t.Errorf("fast TTL = %v, want 30s", got)
+	}
+	if got := settings.TTL(FamilyQuestion); got != 30*time.Minute {
+		t.Errorf("question TTL = %v, want it untouched at 30m", got)
+	}
+	if settings.RepositoryEnabled(RepositoryAttempt) {
+		t.Error("attempt repository should skip the cache")
+	}
+	if !settings.RepositoryEnabled("") {
+		t.Error("managers without a repository should always cache")
+	}
+
+	snapshot := settings.Snapshot()
+	if snapshot.TTLSeconds[FamilyFast] != 30 || snapshot.Repositories[RepositoryAttempt] {
+		t.Errorf("snapshot = %+v", snapshot)
+	}
+	if len(snapshot.TTLSeconds) != len(Families()) {
+		t.Errorf("snapshot lists %d families, want %d", len(snapshot.TTLSeconds), len(Families()))
+	}
+}
+
+func TestSettingsApplyRejectsInvalidUpdates(t *testing.T) {
+	// Each update also turns the answer repository off, which must not happen when it fails
+	tests := []struct {
+		name   string
+		update SettingsUpdate
+	}{
+		{"unknown family", SettingsUpdate{TTLSeconds: map[string]int{"session": 60}, Repositories: map[string]bool{RepositoryAnswer: false}}},
+		{"zero TTL", SettingsUpdate{TTLSeconds: map[string]int{FamilyFast: 0}, Repositories: map[string]bool{RepositoryAnswer: false}}},
+		{"TTL over a day", SettingsUpdate{TTLSeconds: map[string]int{FamilyFast: 86401}, Repositories: map[string]bool{RepositoryAnswer: false}}},
+		{"unknown repository", SettingsUpdate{Repositories: map[string]bool{"grading": false, RepositoryAnswer: false}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := NewSettings()
+			if err := settings.Apply(tt.update); err == nil {
+				t.Fatal("Apply accepted an invalid update")
+			}
+			if !settings.RepositoryEnabled(RepositoryAnswer) {
+				t.Error("an invalid update was partly applied")
+			}
+		})
+	}
+}
+
+func TestCacheHelperTTLFollowsSettings(t *testing.T) {
+	client := &Client{settings: NewSettings()}
+	manager := NewCacheManager(client, RepositoryAttempt)
+	if err := client.Settings().Apply(SettingsUpdate{TTLSeconds: map[string]int{FamilyFast: 45}}); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	if got := manager.Fast.TTL(); got != 45*time.Second {
+		t.Errorf("fast helper TTL = %v, want 45s", got)
+	}
+	if got := manager.Exists.TTL(); got != 2*time.Minute {
+		t.Errorf("exists helper TTL = %v, want 2m", got)
+	}
+}
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+)
+
+// loadCacheConfig reads CACHE_TTL_<FAMILY>, e.g. CACHE_TTL_FAST=2m, for the key families whose
+// TTL should differ from the default, and CACHE_DISABLED_REPOSITORIES, e.g. "attempt,answer",
+// for repositories that should skip the cache. Admins can change both at runtime.
+func loadCacheConfig() (cache.SettingsUpdate, error) {
+	update := cache.SettingsUpdate{
+		TTLSeconds:   make(map[string]int),
+		Repositories: make(map[string]bool),
+	}
+	for _, family := range cache.Families() {
+		key := "CACHE_TTL_" + strings.ToUpper(family)
+		value := getEnv(key, "")
+		if value == "" {
+			continue
+		}
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return update, fmt.Errorf("invalid %s %q: %w", key, value, err)
+		}
+		update.TTLSeconds[family] = int(ttl / time.Second)
+	}
+	for _, name := range strings.Split(getEnv("CACHE_DISABLED_REPOSITORIES", ""), ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			update.Repositories[name] = false
+		}
+	}
+
+	if err := update.Validate(); err != nil {
+		return update, fmt.Errorf("invalid cache configuration: %w", err)
+	}
+	return update, nil
+}
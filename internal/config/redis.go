@@ -0,0 +1,67 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Redis deployment modes
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeCluster    = "cluster"
+	RedisModeSentinel   = "sentinel"
+)
+
+// RedisConfig holds configuration for the Redis cache connection
+type RedisConfig struct {
+	Mode                string        `env:"REDIS_MODE" envDefault:"standalone"` // standalone, cluster or sentinel
+	URL                 string        `env:"REDIS_URL" envDefault:"redis://localhost:6379"`
+	Addrs               string        `env:"REDIS_ADDRS"`       // Comma-separated cluster nodes or sentinels
+	MasterName          string        `env:"REDIS_MASTER_NAME"` // Sentinel master name
+	Username            string        `env:"REDIS_USERNAME"`
+	Password            string        `env:"REDIS_PASSWORD"`
+	DB                  int           `env:"REDIS_DB" envDefault:"0"` // Sentinel only; standalone reads the DB from REDIS_URL
+	HealthCheckInterval time.Duration `env:"REDIS_HEALTH_CHECK_INTERVAL" envDefault:"15s"`
+}
+
+// Enabled reports whether any Redis connection is configured
+func (c *RedisConfig) Enabled() bool {
+	if c.Mode == RedisModeStandalone || c.Mode == "" {
+		return c.URL != ""
+	}
+	return len(c.GetAddrs()) > 0
+}
+
+// GetAddrs returns cluster node or sentinel addresses as a slice
+func (c *RedisConfig) GetAddrs() []string {
+	var addrs []string
+	for _, addr := range strings.Split(c.Addrs, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
+func loadRedisConfig() RedisConfig {
+	db, err := strconv.Atoi(getEnv("REDIS_DB", "0"))
+	if err != nil {
+		db = 0
+	}
+	interval, err := time.ParseDuration(getEnv("REDIS_HEALTH_CHECK_INTERVAL", "15s"))
+	if err != nil {
+		interval = 15 * time.Second
+	}
+
+	return RedisConfig{
+		Mode:                strings.ToLower(getEnv("REDIS_MODE", RedisModeStandalone)),
+		URL:                 getEnv("REDIS_URL", "redis://localhost:6379"),
+		Addrs:               getEnv("REDIS_ADDRS", ""),
+		MasterName:          getEnv("REDIS_MASTER_NAME", ""),
+		Username:            getEnv("REDIS_USERNAME", ""),
+		Password:            getEnv("REDIS_PASSWORD", ""),
+		DB:                  db,
+		HealthCheckInterval: interval,
+	}
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var regionNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// ResidencyConfig lists the regions organizations can be pinned to at provisioning. The data of
+// a pinned organization, attempts and answers included, is stored in its region's database,
+// and files uploaded with answers in its region's directory.
+type ResidencyConfig struct {
+	Regions map[string]RegionConfig
+}
+
+type RegionConfig struct {
+	DatabaseURL   string
+	AttachmentDir string
+}
+
+// HasRegion reports whether the region is configured
+func (c *ResidencyConfig) HasRegion(region string) bool {
+	_, ok := c.Regions[region]
+	return ok
+}
+
+// Names returns the configured regions in alphabetical order
+func (c *ResidencyConfig) Names() []string {
+	names := make([]string, 0, len(c.Regions))
+	for name := range c.Regions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadResidencyConfig reads RESIDENCY_REGIONS, e.g. "eu,apac", and for each region
+// RESIDENCY_<REGION>_DATABASE_URL and RESIDENCY_<REGION>_ATTACHMENT_DIR. Both are required,
+// so a pinned organization's data can never fall back to the primary storage.
+func loadResidencyConfig() (ResidencyConfig, error) {
+	cfg := ResidencyConfig{Regions: make(map[string]RegionConfig)}
+	for _, name := range strings.Split(getEnv("RESIDENCY_REGIONS", ""), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !regionNamePattern.MatchString(name) {
+			return cfg, fmt.Errorf("invalid residency region %q: use lowercase letters, digits and dashes", name)
+		}
+
+		prefix := "RESIDENCY_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		region := RegionConfig{
+			DatabaseURL:   getEnv(prefix+"DATABASE_URL", ""),
+			AttachmentDir: getEnv(prefix+"ATTACHMENT_DIR", ""),
+		}
+		if region.DatabaseURL == "" || region.AttachmentDir == "" {
+			return cfg, fmt.Errorf("residency region %q needs %sDATABASE_URL and %sATTACHMENT_DIR", name, prefix, prefix)
+		}
+		cfg.Regions[name] = region
+	}
+	return cfg, nil
+}
@@ -12,6 +12,8 @@ type ValidationError struct {
 	Message string      `json:"message"`
 	Value   interface{} `json:"value,omitempty"`
 	Rule    string      `json:"rule,omitempty"`
+	// Accepted alternatives to the rejected value, e.g. free titles
+	Suggestions []string `json:"suggestions,omitempty"`
 }
 
 // ValidationErrors is a collection of validation errors
@@ -15,18 +15,34 @@ const (
 	EventAssessmentExpiring  EventType = "assessment.expiring"
 	EventAssessmentExpired   EventType = "assessment.expired"
 
+	// Sent to the owner while the assessment is live, so a faulty question can be fixed
+	EventQuestionFlagsEscalated EventType = "assessment.question_flags_escalated"
+
 	// Attempt events
 	EventAttemptStarted     EventType = "attempt.started"
 	EventAttemptSubmitted   EventType = "attempt.submitted"
 	EventAttemptGraded      EventType = "attempt.graded"
 	EventAttemptTimeWarning EventType = "attempt.time_warning"
+	EventAttemptSlotOpened  EventType = "attempt.slot_opened"
+
+	// Extension request events
+	EventExtensionRequested EventType = "extension.requested"
+	EventExtensionDecided   EventType = "extension.decided"
 
 	// Grading events
 	EventGradingCompleted      EventType = "grading.completed"
 	EventManualGradingRequired EventType = "grading.manual_required"
+	EventResultsReleased       EventType = "grading.results_released"
+
+	// Question bank events
+	EventQuestionsReviewDue EventType = "question_bank.review_due"
 
 	// System events
 	EventBulkNotification EventType = "system.bulk_notification"
+	EventWebhookTest      EventType = "system.webhook_test"
+
+	// Report events
+	EventReportDelivery EventType = "report.delivery"
 )
 
 // NotificationEvent is the base event structure for all notification events
@@ -38,6 +54,18 @@ type NotificationEvent struct {
 	Version   string                 `json:"version"`
 	Data      interface{}            `json:"data"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
+
+	// Deliveries honours the recipients' notification preferences: the channels to use for
+	// each recipient and, during their quiet hours, when to deliver. Recipients who opted out
+	// are left out. Events without deliveries go to every recipient on the default channels.
+	Deliveries []NotificationDelivery `json:"deliveries,omitempty"`
+}
+
+// NotificationDelivery is how one recipient is to be notified
+type NotificationDelivery struct {
+	UserID       string     `json:"user_id"`
+	Channels     []string   `json:"channels"`
+	DeliverAfter *time.Time `json:"deliver_after,omitempty"`
 }
 
 // Assessment notification event payloads
@@ -67,6 +95,18 @@ type AssessmentExpiredEvent struct {
 	CreatorID       string    `json:"creator_id"`
 }
 
+type QuestionFlagsEscalatedEvent struct {
+	EscalationID    uint      `json:"escalation_id"`
+	AssessmentID    uint      `json:"assessment_id"`
+	AssessmentTitle string    `json:"assessment_title"`
+	QuestionID      uint      `json:"question_id"`
+	QuestionText    string    `json:"question_text"`
+	OwnerID         string    `json:"owner_id"`
+	FlagCount       int       `json:"flag_count"`
+	ActiveAttempts  int       `json:"active_attempts"`
+	EscalatedAt     time.Time `json:"escalated_at"`
+}
+
 // Attempt notification event payloads
 
 type AttemptStartedEvent struct {
@@ -89,6 +129,41 @@ type AttemptSubmittedEvent struct {
 	GradingRequired bool      `json:"grading_required"`
 }
 
+type AttemptSlotOpenedEvent struct {
+	AssessmentID    uint      `json:"assessment_id"`
+	AssessmentTitle string    `json:"assessment_title"`
+	StudentID       string    `json:"student_id"`
+	HeldUntil       time.Time `json:"held_until"` // The slot goes to the next student if not used by then
+}
+
+// Extension request notification event payloads; both go to the student and the teacher
+
+type ExtensionRequestedEvent struct {
+	RequestID        uint                        `json:"request_id"`
+	AssessmentID     uint                        `json:"assessment_id"`
+	AssessmentTitle  string                      `json:"assessment_title"`
+	StudentID        string                      `json:"student_id"`
+	TeacherID        string                      `json:"teacher_id"`
+	Kind             models.ExtensionRequestKind `json:"kind"`
+	Reason           string                      `json:"reason"`
+	RequestedDueDate *time.Time                  `json:"requested_due_date,omitempty"`
+	HasAttachment    bool                        `json:"has_attachment"`
+}
+
+type ExtensionDecidedEvent struct {
+	RequestID       uint                          `json:"request_id"`
+	AssessmentID    uint                          `json:"assessment_id"`
+	AssessmentTitle string                        `json:"assessment_title"`
+	StudentID       string                        `json:"student_id"`
+	TeacherID       string                        `json:"teacher_id"`
+	Kind            models.ExtensionRequestKind   `json:"kind"`
+	Status          models.ExtensionRequestStatus `json:"status"`             // approved or denied
+	DueDate         *time.Time                    `json:"due_date,omitempty"` // Granted due date
+	ExtraAttempts   int                           `json:"extra_attempts,omitempty"`
+	DecidedBy       string                        `json:"decided_by"`
+	Note            *string                       `json:"note,omitempty"`
+}
+
 type AttemptGradedEvent struct {
 	AttemptID       uint      `json:"attempt_id"`
 	AssessmentID    uint      `json:"assessment_id"`
@@ -133,18 +208,61 @@ type ManualGradingRequiredEvent struct {
 	GraderIDs         []string  `json:"grader_ids"`
 }
 
+type ResultsReleasedEvent struct {
+	AssessmentID    uint      `json:"assessment_id"`
+	AssessmentTitle string    `json:"assessment_title"`
+	ReleasedAt      time.Time `json:"released_at"`
+	ReleasedBy      string    `json:"released_by"` // User ID, or "scheduler" for scheduled releases
+	StudentIDs      []string  `json:"student_ids"`
+}
+
+// Question bank notification event payloads
+
+type QuestionsReviewDueEvent struct {
+	BankID    uint                `json:"bank_id"`
+	BankName  string              `json:"bank_name"`
+	OwnerID   string              `json:"owner_id"`
+	Questions []ReviewDueQuestion `json:"questions"`
+}
+
+type ReviewDueQuestion struct {
+	QuestionID        uint       `json:"question_id"`
+	Text              string     `json:"text"`
+	Reasons           []string   `json:"reasons"` // "review_overdue", "declining_correct_rate"
+	LastReviewedAt    *time.Time `json:"last_reviewed_at,omitempty"`
+	ReviewDueAt       time.Time  `json:"review_due_at"`
+	RecentCorrectRate *float64   `json:"recent_correct_rate,omitempty"`
+	PriorCorrectRate  *float64   `json:"prior_correct_rate,omitempty"`
+}
+
 // System notification event payload
 
 type BulkNotificationEvent struct {
-	RecipientIDs []uint                      `json:"recipient_ids"`
-	Type         models.NotificationType     `json:"type"`
-	Title        string                      `json:"title"`
-	Message      string                      `json:"message"`
-	Priority     models.NotificationPriority `json:"priority"`
-	ActionURL    *string                     `json:"action_url,omitempty"`
-	Metadata     map[string]interface{}      `json:"metadata,omitempty"`
-	ScheduledAt  *time.Time                  `json:"scheduled_at,omitempty"`
-	SenderID     string                      `json:"sender_id"`
+	RecipientIDs     []uint                      `json:"recipient_ids"`
+	RecipientUserIDs []string                    `json:"recipient_user_ids,omitempty"` // Recipients picked by audience filter
+	Type             models.NotificationType     `json:"type"`
+	Title            string                      `json:"title"`
+	Message          string                      `json:"message"`
+	Priority         models.NotificationPriority `json:"priority"`
+	ActionURL        *string                     `json:"action_url,omitempty"`
+	Metadata         map[string]interface{}      `json:"metadata,omitempty"`
+	ScheduledAt      *time.Time                  `json:"scheduled_at,omitempty"`
+	SenderID         string                      `json:"sender_id"`
+}
+
+// Report event payload
+
+type ReportDeliveryEvent struct {
+	SubscriptionID uint      `json:"subscription_id"`
+	ReportType     string    `json:"report_type"`
+	RecipientID    string    `json:"recipient_id"`
+	RecipientEmail string    `json:"recipient_email"`
+	Channel        string    `json:"channel"` // "email"
+	Subject        string    `json:"subject"`
+	Body           string    `json:"body"`
+	ContentType    string    `json:"content_type"`
+	PeriodStart    time.Time `json:"period_start"`
+	PeriodEnd      time.Time `json:"period_end"`
 }
 
 // Event factory functions
@@ -206,6 +324,17 @@ func NewBulkNotificationEvent(recipientIDs []uint, notificationType models.Notif
 	}
 }
 
+func NewReportDeliveryEvent(data ReportDeliveryEvent) *NotificationEvent {
+	return &NotificationEvent{
+		ID:        generateEventID(),
+		Type:      EventReportDelivery,
+		Timestamp: time.Now(),
+		Source:    "assessment-service",
+		Version:   "1.0",
+		Data:      data,
+	}
+}
+
 // Helper function to generate unique event IDs
 func generateEventID() string {
 	// You can use UUID library here
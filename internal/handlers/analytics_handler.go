@@ -0,0 +1,614 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AnalyticsHandler struct {
+	BaseHandler
+	analyticsService services.AnalyticsService
+}
+
+func NewAnalyticsHandler(
+	analyticsService services.AnalyticsService,
+	logger utils.Logger,
+) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		BaseHandler:      NewBaseHandler(logger),
+		analyticsService: analyticsService,
+	}
+}
+
+// GetDistractorAnalysis returns option effectiveness for a multiple choice question
+// @Summary Get distractor analysis
+// @Description Shows how often each option is chosen overall and by the lowest and highest scoring students, flagging distractors chosen by fewer than 5% of students
+// @Tags analytics
+// @Produce json
+// @Param question_id path uint true "Question ID"
+// @Success 200 {object} services.DistractorAnalysis
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analytics/questions/{question_id}/distractors [get]
+func (h *AnalyticsHandler) GetDistractorAnalysis(c *gin.Context) {
+	questionID := h.parseIDParam(c, "question_id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting distractor analysis", "question_id", questionID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	analysis, err := h.analyticsService.GetDistractorAnalysis(c.Request.Context(), questionID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, analysis)
+}
+
+// GetAssessmentDashboard returns an assessment's analytics as ready-to-render charts
+// @Summary Get assessment dashboard
+// @Description Returns a dashboard descriptor with a score histogram, a completion funnel and per-question correctness, for the frontend and embedded LMS widgets
+// @Tags analytics
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {object} services.AssessmentDashboard
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analytics/assessments/{id}/dashboard [get]
+func (h *AnalyticsHandler) GetAssessmentDashboard(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting assessment dashboard", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	dashboard, err := h.analyticsService.GetAssessmentDashboard(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dashboard)
+}
+
+// GetAssessmentAnalytics returns an assessment's score statistics and item analysis
+// @Summary Get assessment analytics
+// @Description Returns score statistics with per-question difficulty, discrimination and point-biserial correlation, and the assessment's KR-20 reliability
+// @Tags analytics
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {object} services.AssessmentAnalyticsReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analytics/assessments/{id} [get]
+func (h *AnalyticsHandler) GetAssessmentAnalytics(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting assessment analytics", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	analytics, err := h.analyticsService.GetAssessmentAnalytics(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// GetConfidenceCalibration compares students' confidence ratings with their scores
+// @Summary Get confidence calibration
+// @Description Compares the confidence students gave their answers with how the answers scored, overall, per student and per question, from fully graded attempts
+// @Tags analytics
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {object} services.ConfidenceCalibrationReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analytics/assessments/{id}/calibration [get]
+func (h *AnalyticsHandler) GetConfidenceCalibration(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting confidence calibration", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	report, err := h.analyticsService.GetConfidenceCalibration(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetPeakUsageTimes shows when students take an assessment
+// @Summary Get peak usage times
+// @Description Counts attempts started per hour and weekday with the average score of each, and finds the most attempts running at once. Start times are read in the given timezone, or the due date's timezone.
+// @Tags analytics
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param timezone query string false "IANA timezone, e.g. Europe/Berlin"
+// @Success 200 {object} services.PeakUsageTimes
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analytics/assessments/{id}/usage-times [get]
+func (h *AnalyticsHandler) GetPeakUsageTimes(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting peak usage times", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	usage, err := h.analyticsService.GetPeakUsageTimes(c.Request.Context(), assessmentID, c.Query("timezone"), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// GetNavigationAnalytics shows how students move between an assessment's questions
+// @Summary Get navigation analytics
+// @Description Reads the question navigation log of submitted attempts: average visits and revisits per question, the most common jumps back to earlier questions, and the questions students most often return to at the end.
+// @Tags analytics
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {object} services.NavigationAnalytics
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analytics/assessments/{id}/navigation [get]
+func (h *AnalyticsHandler) GetNavigationAnalytics(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting navigation analytics", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	analytics, err := h.analyticsService.GetNavigationAnalytics(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+// RecomputeQuestionStats queues a rebuild of stored question statistics
+// @Summary Recompute question statistics
+// @Description Admins only. Queues a background job that discards the stored statistics of a question, or of every question in a bank, and rebuilds them from the raw answers. A job already queued or running for the same question or bank is returned instead.
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param request body services.RecomputeQuestionStatsRequest true "Question or bank"
+// @Success 202 {object} models.QuestionStatsJob
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analytics/question-stats/recompute [post]
+func (h *AnalyticsHandler) RecomputeQuestionStats(c *gin.Context) {
+	h.LogRequest(c, "Scheduling question stats recompute")
+
+	var req services.RecomputeQuestionStatsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	job, err := h.analyticsService.ScheduleQuestionStatsRecompute(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetQuestionStatsJob returns the progress of a question statistics recompute
+// @Summary Get question statistics job
+// @Description Admins only. Returns the status and progress of a question statistics recompute job
+// @Tags analytics
+// @Produce json
+// @Param job_id path uint true "Job ID"
+// @Success 200 {object} models.QuestionStatsJob
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analytics/question-stats/jobs/{job_id} [get]
+func (h *AnalyticsHandler) GetQuestionStatsJob(c *gin.Context) {
+	jobID := h.parseIDParam(c, "job_id")
+	if jobID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting question stats job", "job_id", jobID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	job, err := h.analyticsService.GetQuestionStatsJob(c.Request.Context(), jobID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// CreateMasteryTarget sets a mastery goal for a skill
+// @Summary Create mastery target
+// @Description Sets the score a student must reach on questions tagged with a skill, measured on the teacher's own assessments
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param target body services.CreateMasteryTargetRequest true "Mastery target"
+// @Success 201 {object} models.MasteryTarget
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analytics/mastery-targets [post]
+func (h *AnalyticsHandler) CreateMasteryTarget(c *gin.Context) {
+	h.LogRequest(c, "Creating mastery target")
+
+	var req services.CreateMasteryTargetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	target, err := h.analyticsService.CreateMasteryTarget(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, target)
+}
+
+// ListMasteryTargets lists the current user's mastery targets
+// @Summary List mastery targets
+// @Description Lists the mastery targets the current teacher has set
+// @Tags analytics
+// @Produce json
+// @Success 200 {array} models.MasteryTarget
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analytics/mastery-targets [get]
+func (h *AnalyticsHandler) ListMasteryTargets(c *gin.Context) {
+	h.LogRequest(c, "Listing mastery targets")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	targets, err := h.analyticsService.ListMasteryTargets(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, targets)
+}
+
+// UpdateMasteryTarget changes a mastery target's threshold
+// @Summary Update mastery target
+// @Description Changes the target percentage or the number of graded answers needed before mastery counts
+// @Tags analytics
+// @Accept json
+// @Produce json
+// @Param id path uint true "Mastery target ID"
+// @Param target body services.UpdateMasteryTargetRequest true "Mastery target updates"
+// @Success 200 {object} models.MasteryTarget
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analytics/mastery-targets/{id} [put]
+func (h *AnalyticsHandler) UpdateMasteryTarget(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Updating mastery target", "target_id", id)
+
+	var req services.UpdateMasteryTargetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	target, err := h.analyticsService.UpdateMasteryTarget(c.Request.Context(), id, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, target)
+}
+
+// DeleteMasteryTarget removes a mastery target
+// @Summary Delete mastery target
+// @Description Removes a mastery target; it no longer appears in student progress reports
+// @Tags analytics
+// @Param id path uint true "Mastery target ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analytics/mastery-targets/{id} [delete]
+func (h *AnalyticsHandler) DeleteMasteryTarget(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Deleting mastery target", "target_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.analyticsService.DeleteMasteryTarget(c.Request.Context(), id, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetStudentProgress reports a student's attainment of skill mastery targets
+// @Summary Get student progress report
+// @Description Computes mastery per skill from graded answers, with the milestones reached so far. Students may only view their own report.
+// @Tags analytics
+// @Produce json
+// @Param student_id path string true "Student ID"
+// @Success 200 {object} services.ProgressReport
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /students/{student_id}/progress [get]
+func (h *AnalyticsHandler) GetStudentProgress(c *gin.Context) {
+	studentID := c.Param("student_id")
+
+	h.LogRequest(c, "Getting student progress report", "student_id", studentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	report, err := h.analyticsService.GetStudentProgress(c.Request.Context(), studentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Helper methods
+
+func (h *AnalyticsHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *AnalyticsHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var validationError *services.ValidationError
+	if errors.As(err, &validationError) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationError,
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrQuestionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Question not found",
+		})
+	case errors.Is(err, services.ErrAssessmentNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Assessment not found",
+		})
+	case errors.Is(err, services.ErrQuestionBankNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Question bank not found",
+		})
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Resource not found",
+		})
+	case errors.Is(err, services.ErrUnauthorized):
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "Unauthorized access",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
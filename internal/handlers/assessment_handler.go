@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
@@ -153,9 +154,11 @@ func (h *AssessmentHandler) GetAssessmentWithDetails(c *gin.Context) {
 // @Produce json
 // @Param id path uint true "Assessment ID"
 // @Param assessment body services.UpdateAssessmentRequest true "Assessment update data"
+// @Param override_lock query bool false "Admins only: apply the change while attempts are in progress, voiding them"
 // @Success 200 {object} SuccessResponse{data=services.AssessmentResponse}
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /assessments/{id} [put]
 func (h *AssessmentHandler) UpdateAssessment(c *gin.Context) {
@@ -183,7 +186,7 @@ func (h *AssessmentHandler) UpdateAssessment(c *gin.Context) {
 		return
 	}
 
-	assessment, err := h.assessmentService.Update(c.Request.Context(), id, &req, userID.(string))
+	assessment, err := h.assessmentService.Update(contentLockContext(c), id, &req, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
@@ -231,7 +234,7 @@ func (h *AssessmentHandler) DeleteAssessment(c *gin.Context) {
 
 // ListAssessments lists assessments with filters
 // @Summary List assessments
-// @Description Lists assessments with optional filtering
+// @Description Lists assessments with optional filtering. Filter on the organization's custom fields with cf.<key>=value, e.g. cf.cost_center=4100.
 // @Tags assessments
 // @Accept json
 // @Produce json
@@ -464,7 +467,7 @@ func (h *AssessmentHandler) ArchiveAssessment(c *gin.Context) {
 
 // AddQuestionToAssessment adds a question to an assessment
 // @Summary Add question to assessment
-// @Description Adds a question to an assessment with specified order and points
+// @Description Adds a question to an assessment with specified order and points. The response data lists warnings where the question mix goes against the exam policy.
 // @Tags assessments
 // @Accept json
 // @Produce json
@@ -472,9 +475,11 @@ func (h *AssessmentHandler) ArchiveAssessment(c *gin.Context) {
 // @Param question_id path uint true "Question ID"
 // @Param order query int false "Question order"
 // @Param points query int false "Question points"
-// @Success 200 {object} SuccessResponse
+// @Param override_lock query bool false "Admins only: apply the change while attempts are in progress, voiding them"
+// @Success 200 {object} SuccessResponse{data=services.AssemblyCheck}
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /assessments/{id}/questions/{question_id} [post]
 func (h *AssessmentHandler) AddQuestionToAssessment(c *gin.Context) {
@@ -500,7 +505,7 @@ func (h *AssessmentHandler) AddQuestionToAssessment(c *gin.Context) {
 		})
 		return
 	}
-	err := h.assessmentService.AddQuestion(c.Request.Context(), assessmentID, questionID, order, points, userID.(string))
+	check, err := h.assessmentService.AddQuestion(contentLockContext(c), assessmentID, questionID, order, points, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
@@ -508,6 +513,7 @@ func (h *AssessmentHandler) AddQuestionToAssessment(c *gin.Context) {
 
 	c.JSON(http.StatusOK, SuccessResponse{
 		Message: "Question added to assessment successfully",
+		Data:    check,
 	})
 }
 
@@ -519,9 +525,11 @@ func (h *AssessmentHandler) AddQuestionToAssessment(c *gin.Context) {
 // @Produce json
 // @Param id path uint true "Assessment ID"
 // @Param question_id path uint true "Question ID"
+// @Param override_lock query bool false "Admins only: apply the change while attempts are in progress, voiding them"
 // @Success 200 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /assessments/{id}/questions/{question_id} [delete]
 func (h *AssessmentHandler) RemoveQuestionFromAssessment(c *gin.Context) {
@@ -544,56 +552,515 @@ func (h *AssessmentHandler) RemoveQuestionFromAssessment(c *gin.Context) {
 		})
 		return
 	}
-	err := h.assessmentService.RemoveQuestion(c.Request.Context(), assessmentID, questionID, userID.(string))
+	err := h.assessmentService.RemoveQuestion(contentLockContext(c), assessmentID, questionID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Question removed from assessment successfully",
+	})
+}
+
+// ReorderAssessmentQuestions reorders questions in an assessment
+// @Summary Reorder assessment questions
+// @Description Reorders questions within an assessment
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param orders body []repositories.QuestionOrder true "Question order data"
+// @Param override_lock query bool false "Admins only: apply the change while attempts are in progress, voiding them"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/questions/reorder [put]
+func (h *AssessmentHandler) ReorderAssessmentQuestions(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Reordering assessment questions", "assessment_id", id)
+
+	var ordersRequest services.ReorderQuestionsRequest
+	if err := c.ShouldBindJSON(&ordersRequest); err != nil {
+		h.RespondWithError(c, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if len(ordersRequest.QuestionOrders) == 0 {
+		h.RespondWithError(c, http.StatusBadRequest, "No question orders provided", errors.New("empty order list"))
+		return
+	}
+
+	var orders []repositories.QuestionOrder
+	for _, o := range ordersRequest.QuestionOrders {
+		orders = append(orders, repositories.QuestionOrder{
+			QuestionID: o.QuestionID,
+			Order:      o.Order,
+		})
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	err := h.assessmentService.ReorderQuestions(contentLockContext(c), id, orders, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Questions reordered successfully",
+	})
+}
+
+// GetAssessmentStats retrieves assessment statistics
+// @Summary Get assessment statistics
+// @Description Retrieves statistics for an assessment
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {object} SuccessResponse{data=repositories.AssessmentStats}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/stats [get]
+func (h *AssessmentHandler) GetAssessmentStats(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting assessment stats", "assessment_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	stats, err := h.assessmentService.GetStats(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetCreatorStats retrieves creator statistics
+// @Summary Get creator statistics
+// @Description Retrieves statistics for a creator
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param creator_id path uint true "Creator ID"
+// @Success 200 {object} SuccessResponse{data=repositories.CreatorStats}
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/creator/{creator_id}/stats [get]
+func (h *AssessmentHandler) GetCreatorStats(c *gin.Context) {
+	creatorID := ParseStringIDParam(c, "creator_id")
+	if creatorID == "" {
+		return
+	}
+
+	h.LogRequest(c, "Getting creator stats", "creator_id", creatorID)
+
+	stats, err := h.assessmentService.GetCreatorStats(c.Request.Context(), creatorID)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// BuildAssessment proposes a question set for a new assessment
+// @Summary Build assessment proposal
+// @Description Proposes questions from the teacher's accessible banks matching the target points, difficulty distribution, tags and duration
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param request body services.BuildAssessmentRequest true "Builder criteria"
+// @Success 200 {object} services.AssessmentBuildProposal
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/build [post]
+func (h *AssessmentHandler) BuildAssessment(c *gin.Context) {
+	h.LogRequest(c, "Building assessment proposal")
+
+	var req services.BuildAssessmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	proposal, err := h.assessmentService.BuildProposal(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, proposal)
+}
+
+// GenerateSeries creates a series of scheduled quizzes from a question bank
+// @Summary Generate assessment series
+// @Description Creates one draft quiz per cadence from the bank's questions matching the tags, with no question repeated across quizzes; each quiz is published on schedule
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param request body services.GenerateSeriesRequest true "Series definition"
+// @Success 201 {object} services.AssessmentSeriesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/series [post]
+func (h *AssessmentHandler) GenerateSeries(c *gin.Context) {
+	h.LogRequest(c, "Generating assessment series")
+
+	var req services.GenerateSeriesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	series, err := h.assessmentService.GenerateSeries(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, series)
+}
+
+// EnrollStudents enrolls students in an assessment
+// @Summary Enroll students
+// @Description Enrolls students in an assessment; IDs that are not students are returned as invalid
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param request body services.EnrollStudentsRequest true "Student IDs"
+// @Success 200 {object} services.EnrollStudentsResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/enrollments [post]
+func (h *AssessmentHandler) EnrollStudents(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Enrolling students", "assessment_id", id)
+
+	var req services.EnrollStudentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	result, err := h.assessmentService.EnrollStudents(c.Request.Context(), id, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// UnenrollStudent removes a student's enrollment from an assessment
+// @Summary Unenroll student
+// @Description Removes a student's enrollment from an assessment
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param student_id path string true "Student ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/enrollments/{student_id} [delete]
+func (h *AssessmentHandler) UnenrollStudent(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+	studentID := h.parseStringIDParam(c, "student_id")
+	if studentID == "" {
+		return
+	}
+
+	h.LogRequest(c, "Unenrolling student", "assessment_id", id, "student_id", studentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.assessmentService.UnenrollStudent(c.Request.Context(), id, studentID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Student unenrolled successfully",
+	})
+}
+
+// GetAccessAudit reports which students viewed, started or never accessed an assessment
+// @Summary Get assessment access audit
+// @Description Combines enrollments, detail views and attempts to show each student's access status
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {object} services.AssessmentAccessAudit
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/access-audit [get]
+func (h *AssessmentHandler) GetAccessAudit(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting assessment access audit", "assessment_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	audit, err := h.assessmentService.GetAccessAudit(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, audit)
+}
+
+// GetTimeline returns the lifecycle history of an assessment
+// @Summary Get assessment timeline
+// @Description Lists creation, edits, publishing, the first attempt, grading completion, results release and archiving in chronological order, with who caused each event
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {object} services.AssessmentTimeline
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/timeline [get]
+func (h *AssessmentHandler) GetTimeline(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting assessment timeline", "assessment_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	timeline, err := h.assessmentService.GetTimeline(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, timeline)
+}
+
+// ListClassOverrides lists the per-class overrides of an assessment
+// @Summary List class overrides
+// @Description Lists the due dates, availability windows and attempt limits set for individual classes
+// @Tags assessments
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {array} models.AssessmentClassOverride
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/class-overrides [get]
+func (h *AssessmentHandler) ListClassOverrides(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Listing class overrides", "assessment_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	overrides, err := h.assessmentService.ListClassOverrides(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, overrides)
+}
+
+// SetClassOverride sets a class's due date, availability window and attempt limit
+// @Summary Set class override
+// @Description Replaces the override for students enrolled with the class; unset fields keep the assessment's values
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param class_id path string true "Class ID"
+// @Param request body services.SetClassOverrideRequest true "Override"
+// @Success 200 {object} models.AssessmentClassOverride
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/class-overrides/{class_id} [put]
+func (h *AssessmentHandler) SetClassOverride(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+	classID := h.parseStringIDParam(c, "class_id")
+	if classID == "" {
+		return
+	}
+
+	h.LogRequest(c, "Setting class override", "assessment_id", id, "class_id", classID)
+
+	var req services.SetClassOverrideRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	override, err := h.assessmentService.SetClassOverride(c.Request.Context(), id, classID, &req, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, SuccessResponse{
-		Message: "Question removed from assessment successfully",
-	})
+	c.JSON(http.StatusOK, override)
 }
 
-// ReorderAssessmentQuestions reorders questions in an assessment
-// @Summary Reorder assessment questions
-// @Description Reorders questions within an assessment
+// DeleteClassOverride removes a class's override
+// @Summary Delete class override
+// @Description Students enrolled with the class go back to the assessment's own due date and attempt limit
 // @Tags assessments
-// @Accept json
 // @Produce json
 // @Param id path uint true "Assessment ID"
-// @Param orders body []repositories.QuestionOrder true "Question order data"
+// @Param class_id path string true "Class ID"
 // @Success 200 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /assessments/{id}/questions/reorder [put]
-func (h *AssessmentHandler) ReorderAssessmentQuestions(c *gin.Context) {
+// @Router /assessments/{id}/class-overrides/{class_id} [delete]
+func (h *AssessmentHandler) DeleteClassOverride(c *gin.Context) {
 	id := h.parseIDParam(c, "id")
 	if id == 0 {
 		return
 	}
-
-	h.LogRequest(c, "Reordering assessment questions", "assessment_id", id)
-
-	var ordersRequest services.ReorderQuestionsRequest
-	if err := c.ShouldBindJSON(&ordersRequest); err != nil {
-		h.RespondWithError(c, http.StatusBadRequest, "Invalid request body", err)
-		return
-	}
-
-	if len(ordersRequest.QuestionOrders) == 0 {
-		h.RespondWithError(c, http.StatusBadRequest, "No question orders provided", errors.New("empty order list"))
+	classID := h.parseStringIDParam(c, "class_id")
+	if classID == "" {
 		return
 	}
 
-	var orders []repositories.QuestionOrder
-	for _, o := range ordersRequest.QuestionOrders {
-		orders = append(orders, repositories.QuestionOrder{
-			QuestionID: o.QuestionID,
-			Order:      o.Order,
-		})
-	}
+	h.LogRequest(c, "Deleting class override", "assessment_id", id, "class_id", classID)
 
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -602,36 +1069,38 @@ func (h *AssessmentHandler) ReorderAssessmentQuestions(c *gin.Context) {
 		})
 		return
 	}
-	err := h.assessmentService.ReorderQuestions(c.Request.Context(), id, orders, userID.(string))
-	if err != nil {
+
+	if err := h.assessmentService.DeleteClassOverride(c.Request.Context(), id, classID, userID.(string)); err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, SuccessResponse{
-		Message: "Questions reordered successfully",
+		Message: "Class override deleted successfully",
 	})
 }
 
-// GetAssessmentStats retrieves assessment statistics
-// @Summary Get assessment statistics
-// @Description Retrieves statistics for an assessment
+// GetReadinessReport checks whether an assessment can be published and estimates its difficulty
+// @Summary Get assessment readiness report
+// @Description Lists publish blockers and predicts the expected average score and completion time from question history
 // @Tags assessments
 // @Accept json
 // @Produce json
 // @Param id path uint true "Assessment ID"
-// @Success 200 {object} SuccessResponse{data=repositories.AssessmentStats}
+// @Success 200 {object} services.AssessmentReadinessReport
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /assessments/{id}/stats [get]
-func (h *AssessmentHandler) GetAssessmentStats(c *gin.Context) {
+// @Router /assessments/{id}/readiness [get]
+func (h *AssessmentHandler) GetReadinessReport(c *gin.Context) {
 	id := h.parseIDParam(c, "id")
 	if id == 0 {
 		return
 	}
 
-	h.LogRequest(c, "Getting assessment stats", "assessment_id", id)
+	h.LogRequest(c, "Getting assessment readiness report", "assessment_id", id)
 
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -640,41 +1109,54 @@ func (h *AssessmentHandler) GetAssessmentStats(c *gin.Context) {
 		})
 		return
 	}
-	stats, err := h.assessmentService.GetStats(c.Request.Context(), id, userID.(string))
+
+	report, err := h.assessmentService.GetReadinessReport(c.Request.Context(), id, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, stats)
+	c.JSON(http.StatusOK, report)
 }
 
-// GetCreatorStats retrieves creator statistics
-// @Summary Get creator statistics
-// @Description Retrieves statistics for a creator
+// GetDeadline returns the assessment's due date with its timezone context
+// @Summary Get assessment deadline
+// @Description Returns the due date in UTC, in the assessment's timezone and in the viewer's, plus the server-enforced cut-offs for starting and submitting attempts
 // @Tags assessments
 // @Accept json
 // @Produce json
-// @Param creator_id path uint true "Creator ID"
-// @Success 200 {object} SuccessResponse{data=repositories.CreatorStats}
+// @Param id path uint true "Assessment ID"
+// @Param timezone query string false "Viewer's IANA timezone; defaults to the profile timezone"
+// @Success 200 {object} services.AssessmentDeadline
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /assessments/creator/{creator_id}/stats [get]
-func (h *AssessmentHandler) GetCreatorStats(c *gin.Context) {
-	creatorID := ParseStringIDParam(c, "creator_id")
-	if creatorID == "" {
+// @Router /assessments/{id}/deadline [get]
+func (h *AssessmentHandler) GetDeadline(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
 		return
 	}
 
-	h.LogRequest(c, "Getting creator stats", "creator_id", creatorID)
+	h.LogRequest(c, "Getting assessment deadline", "assessment_id", id)
 
-	stats, err := h.assessmentService.GetCreatorStats(c.Request.Context(), creatorID)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	deadline, err := h.assessmentService.GetDeadline(c.Request.Context(), id, userID.(string), c.Query("timezone"))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, stats)
+	c.JSON(http.StatusOK, deadline)
 }
 
 // Helper methods
@@ -757,21 +1239,24 @@ func (h *AssessmentHandler) parseAssessmentFilters(c *gin.Context) repositories.
 	if creatorIDStr := c.Query("creator_id"); creatorIDStr != "" {
 		filters.CreatedBy = &creatorIDStr
 	}
+	filters.CustomFields = parseCustomFieldFilters(c)
 
 	return filters
 }
 
 // AddQuestionsToAssessment adds multiple questions to an assessment
 // @Summary Add multiple questions to assessment
-// @Description Adds multiple questions to an assessment in batch
+// @Description Adds multiple questions to an assessment in batch. The response data lists warnings where the question mix goes against the exam policy.
 // @Tags assessments
 // @Accept json
 // @Produce json
 // @Param id path uint true "Assessment ID"
 // @Param question_ids body object{question_ids=[]uint} true "Question IDs"
-// @Success 200 {object} SuccessResponse
+// @Param override_lock query bool false "Admins only: apply the change while attempts are in progress, voiding them"
+// @Success 200 {object} SuccessResponse{data=services.AssemblyCheck}
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /assessments/{id}/questions/batch [post]
 func (h *AssessmentHandler) AddQuestionsToAssessment(c *gin.Context) {
@@ -798,7 +1283,7 @@ func (h *AssessmentHandler) AddQuestionsToAssessment(c *gin.Context) {
 		return
 	}
 
-	err := h.assessmentService.AddQuestions(c.Request.Context(), assessmentID, req.QuestionIDs, userID.(string))
+	check, err := h.assessmentService.AddQuestions(contentLockContext(c), assessmentID, req.QuestionIDs, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
@@ -806,6 +1291,7 @@ func (h *AssessmentHandler) AddQuestionsToAssessment(c *gin.Context) {
 
 	c.JSON(http.StatusOK, SuccessResponse{
 		Message: "Questions added to assessment successfully",
+		Data:    check,
 	})
 }
 
@@ -817,9 +1303,11 @@ func (h *AssessmentHandler) AddQuestionsToAssessment(c *gin.Context) {
 // @Produce json
 // @Param id path uint true "Assessment ID"
 // @Param question_ids body object{question_ids=[]uint} true "Question IDs"
-// @Success 200 {object} SuccessResponse
+// @Param override_lock query bool false "Admins only: apply the change while attempts are in progress, voiding them"
+// @Success 200 {object} SuccessResponse{data=services.AssemblyCheck}
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /assessments/{id}/questions/batch [delete]
 func (h *AssessmentHandler) RemoveQuestionsFromAssessment(c *gin.Context) {
@@ -846,7 +1334,7 @@ func (h *AssessmentHandler) RemoveQuestionsFromAssessment(c *gin.Context) {
 		return
 	}
 
-	err := h.assessmentService.RemoveQuestions(c.Request.Context(), assessmentID, req.QuestionIDs, userID.(string))
+	err := h.assessmentService.RemoveQuestions(contentLockContext(c), assessmentID, req.QuestionIDs, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
@@ -859,16 +1347,18 @@ func (h *AssessmentHandler) RemoveQuestionsFromAssessment(c *gin.Context) {
 
 // UpdateAssessmentQuestion updates a question's settings in an assessment
 // @Summary Update assessment question
-// @Description Updates points and time limit for a question in an assessment
+// @Description Updates points, time limit and answer change limit for a question in an assessment. A max_answer_changes of -1 lifts the limit.
 // @Tags assessments
 // @Accept json
 // @Produce json
 // @Param id path uint true "Assessment ID"
 // @Param question_id path uint true "Question ID"
 // @Param update body services.UpdateAssessmentQuestionRequest true "Update data"
+// @Param override_lock query bool false "Admins only: apply the change while attempts are in progress, voiding them"
 // @Success 200 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /assessments/{id}/questions/{question_id} [put]
 func (h *AssessmentHandler) UpdateAssessmentQuestion(c *gin.Context) {
@@ -906,7 +1396,7 @@ func (h *AssessmentHandler) UpdateAssessmentQuestion(c *gin.Context) {
 		return
 	}
 
-	err := h.assessmentService.UpdateAssessmentQuestion(c.Request.Context(), assessmentID, questionID, &req, userID.(string))
+	err := h.assessmentService.UpdateAssessmentQuestion(contentLockContext(c), assessmentID, questionID, &req, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
@@ -917,6 +1407,262 @@ func (h *AssessmentHandler) UpdateAssessmentQuestion(c *gin.Context) {
 	})
 }
 
+// SetQuestionBranching replaces a question's branching rules
+// @Summary Set question branching rules
+// @Description Sets where an answer to a multiple choice or true/false question routes the student, or which conditional questions it unlocks. Rules that point outside the assessment or create a loop are rejected.
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param question_id path uint true "Question ID"
+// @Param branching body services.SetQuestionBranchingRequest true "Branching rules"
+// @Param override_lock query bool false "Admins only: apply the change while attempts are in progress, voiding them"
+// @Success 200 {object} models.AssessmentQuestion
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/questions/{question_id}/branching [put]
+func (h *AssessmentHandler) SetQuestionBranching(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	questionID := h.parseIDParam(c, "question_id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Setting question branching", "assessment_id", assessmentID, "question_id", questionID)
+
+	var req services.SetQuestionBranchingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.RespondWithError(c, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		h.RespondWithError(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	link, err := h.assessmentService.SetQuestionBranching(contentLockContext(c), assessmentID, questionID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// StartQuestionTrial pilots a revised question alongside the original
+// @Summary Start a question trial
+// @Description Serves a revision of one of the assessment's questions to the given fraction of new attempts, in the original's place. Answers are graded against the version served and item statistics are kept apart, so the two can be compared before the revision is promoted or discarded.
+// @Tags assessments
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param trial body services.StartQuestionTrialRequest true "Original, revision and fraction"
+// @Success 201 {object} models.QuestionTrial
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/trials [post]
+func (h *AssessmentHandler) StartQuestionTrial(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Starting question trial", "assessment_id", assessmentID)
+
+	var req services.StartQuestionTrialRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.RespondWithError(c, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		h.RespondWithError(c, http.StatusBadRequest, "Validation failed", err)
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	trial, err := h.assessmentService.StartQuestionTrial(c.Request.Context(), assessmentID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, trial)
+}
+
+// ListQuestionTrials lists an assessment's question trials
+// @Summary List question trials
+// @Description Returns the assessment's question trials, running and ended, newest first
+// @Tags assessments
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {array} models.QuestionTrial
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/trials [get]
+func (h *AssessmentHandler) ListQuestionTrials(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Listing question trials", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	trials, err := h.assessmentService.ListQuestionTrials(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trials)
+}
+
+// CompareQuestionTrial compares a trial's revision with the original
+// @Summary Compare a question trial
+// @Description Item statistics of the original and the revision from attempts started while the trial ran: correct rate, discrimination, average score and time. The recommendation is collect_more until each side has 30 responses.
+// @Tags assessments
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param trial_id path uint true "Trial ID"
+// @Success 200 {object} services.QuestionTrialComparison
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/trials/{trial_id} [get]
+func (h *AssessmentHandler) CompareQuestionTrial(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	trialID := h.parseIDParam(c, "trial_id")
+	if trialID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Comparing question trial", "assessment_id", assessmentID, "trial_id", trialID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	comparison, err := h.assessmentService.CompareQuestionTrial(c.Request.Context(), assessmentID, trialID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comparison)
+}
+
+// PromoteQuestionTrial makes a trial's revision the question
+// @Summary Promote a question trial
+// @Description Ends the trial and copies the revision's text, content, answer key, points and time limit onto the original question. Answers already given keep their grades.
+// @Tags assessments
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param trial_id path uint true "Trial ID"
+// @Param override_lock query bool false "Admins only: apply the change while attempts are in progress, voiding them"
+// @Success 200 {object} models.QuestionTrial
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/trials/{trial_id}/promote [post]
+func (h *AssessmentHandler) PromoteQuestionTrial(c *gin.Context) {
+	h.endQuestionTrial(c, "Promoting question trial", h.assessmentService.PromoteQuestionTrial)
+}
+
+// DiscardQuestionTrial ends a trial and keeps the original
+// @Summary Discard a question trial
+// @Description Ends the trial; new attempts get the original question again. Attempts already served the revision keep it until they finish.
+// @Tags assessments
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param trial_id path uint true "Trial ID"
+// @Success 200 {object} models.QuestionTrial
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/trials/{trial_id}/discard [post]
+func (h *AssessmentHandler) DiscardQuestionTrial(c *gin.Context) {
+	h.endQuestionTrial(c, "Discarding question trial", h.assessmentService.DiscardQuestionTrial)
+}
+
+func (h *AssessmentHandler) endQuestionTrial(c *gin.Context, message string, end func(ctx context.Context, assessmentID, trialID uint, userID string) (*models.QuestionTrial, error)) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	trialID := h.parseIDParam(c, "trial_id")
+	if trialID == 0 {
+		return
+	}
+
+	h.LogRequest(c, message, "assessment_id", assessmentID, "trial_id", trialID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	trial, err := end(contentLockContext(c), assessmentID, trialID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trial)
+}
+
 // UpdateAssessmentQuestionsBatch updates multiple questions' settings in an assessment
 // @Summary Update multiple assessment questions
 // @Description Updates points and time limits for multiple questions in an assessment
@@ -925,9 +1671,11 @@ func (h *AssessmentHandler) UpdateAssessmentQuestion(c *gin.Context) {
 // @Produce json
 // @Param id path uint true "Assessment ID"
 // @Param updates body []services.UpdateAssessmentQuestionRequest true "Update data"
+// @Param override_lock query bool false "Admins only: apply the change while attempts are in progress, voiding them"
 // @Success 200 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /assessments/{id}/questions/batch [put]
 func (h *AssessmentHandler) UpdateAssessmentQuestionsBatch(c *gin.Context) {
@@ -965,7 +1713,7 @@ func (h *AssessmentHandler) UpdateAssessmentQuestionsBatch(c *gin.Context) {
 		return
 	}
 
-	err := h.assessmentService.UpdateAssessmentQuestionBatch(c.Request.Context(), assessmentID, reqs, userID.(string))
+	err := h.assessmentService.UpdateAssessmentQuestionBatch(contentLockContext(c), assessmentID, reqs, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
@@ -1012,6 +1760,16 @@ func (h *AssessmentHandler) handleServiceError(c *gin.Context, err error) {
 		return
 	}
 
+	var contentLockedError *services.ContentLockedError
+	if errors.As(err, &contentLockedError) {
+		c.JSON(http.StatusLocked, ErrorResponse{
+			Message: "Content is locked while attempts are in progress",
+			Details: contentLockedError,
+			Code:    "content_locked",
+		})
+		return
+	}
+
 	// Handle specific assessment errors
 	switch {
 	case errors.Is(err, services.ErrAssessmentNotFound):
@@ -1046,6 +1804,10 @@ func (h *AssessmentHandler) handleServiceError(c *gin.Context, err error) {
 		c.JSON(http.StatusForbidden, ErrorResponse{
 			Message: "Assessment is not published",
 		})
+	case errors.Is(err, services.ErrQuestionBankNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Question bank not found",
+		})
 	// Generic errors
 	case errors.Is(err, services.ErrValidationFailed):
 		c.JSON(http.StatusBadRequest, ErrorResponse{
@@ -1072,6 +1834,10 @@ func (h *AssessmentHandler) handleServiceError(c *gin.Context, err error) {
 		c.JSON(http.StatusNotFound, ErrorResponse{
 			Message: "User not found",
 		})
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Resource not found",
+		})
 	default:
 		h.LogError(c, err, "Unexpected service error")
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
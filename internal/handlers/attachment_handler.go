@@ -0,0 +1,534 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Largest accepted photo of handwritten work
+const maxAttachmentFileSize = 10 << 20
+
+type AttachmentHandler struct {
+	BaseHandler
+	attachmentService services.AttachmentService
+}
+
+func NewAttachmentHandler(
+	attachmentService services.AttachmentService,
+	logger utils.Logger,
+) *AttachmentHandler {
+	return &AttachmentHandler{
+		BaseHandler:       NewBaseHandler(logger),
+		attachmentService: attachmentService,
+	}
+}
+
+// UploadAttachment uploads a photo of handwritten work for an answer
+// @Summary Upload answer attachment
+// @Description Stores an image of handwritten work for an essay or short answer question; text is recognised in the background for search and similarity checks
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param question_id path uint true "Question ID"
+// @Param file formData file true "JPEG, PNG, TIFF or WebP image"
+// @Success 201 {object} models.AnswerAttachment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/questions/{question_id}/attachments [post]
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+	questionID := h.parseIDParam(c, "question_id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Uploading answer attachment", "attempt_id", attemptID, "question_id", questionID)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+	if fileHeader.Size > maxAttachmentFileSize {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "File too large",
+			Details: map[string]interface{}{
+				"max_size": maxAttachmentFileSize,
+			},
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.UploadAttachment(c.Request.Context(), attemptID, questionID, file, fileHeader.Filename, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// ListAttachments lists files uploaded for an answer
+// @Summary List answer attachments
+// @Description Lists files uploaded for a question in an attempt with their text recognition status
+// @Tags attachments
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param question_id path uint true "Question ID"
+// @Success 200 {array} models.AnswerAttachment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/questions/{question_id}/attachments [get]
+func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+	questionID := h.parseIDParam(c, "question_id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Listing answer attachments", "attempt_id", attemptID, "question_id", questionID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	attachments, err := h.attachmentService.ListAttachments(c.Request.Context(), attemptID, questionID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+// GetAttachmentFile serves an uploaded file
+// @Summary Get answer attachment file
+// @Description Returns the uploaded image to the student who took the attempt and to its graders
+// @Tags attachments
+// @Produce image/jpeg,image/png,image/tiff,image/webp
+// @Param id path uint true "Attachment ID"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attachments/{id}/file [get]
+func (h *AttachmentHandler) GetAttachmentFile(c *gin.Context) {
+	attachmentID := h.parseIDParam(c, "id")
+	if attachmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting answer attachment file", "attachment_id", attachmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	attachment, err := h.attachmentService.GetAttachmentFile(c.Request.Context(), attachmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", attachment.ContentType)
+	c.File(attachment.FilePath)
+}
+
+// GetAttachmentsForGrading returns an answer's uploads with their recognised text
+// @Summary Get answer attachments for grading
+// @Description Returns each uploaded image's URL next to its recognised text, the typed answer, and uploads from other attempts with overlapping text
+// @Tags grading
+// @Produce json
+// @Param attempt_id path uint true "Attempt ID"
+// @Param question_id path uint true "Question ID"
+// @Success 200 {object} services.AnswerAttachmentsForGrading
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/attempts/{attempt_id}/questions/{question_id}/attachments [get]
+func (h *AttachmentHandler) GetAttachmentsForGrading(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "attempt_id")
+	if attemptID == 0 {
+		return
+	}
+	questionID := h.parseIDParam(c, "question_id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting answer attachments for grading", "attempt_id", attemptID, "question_id", questionID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	result, err := h.attachmentService.GetAttachmentsForGrading(c.Request.Context(), attemptID, questionID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// SearchAttachments searches text recognised in an assessment's uploads
+// @Summary Search answer attachments
+// @Description Finds uploads on an assessment whose recognised text contains the search text
+// @Tags grading
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Param q query string true "Search text (at least 2 characters)"
+// @Success 200 {array} services.AttachmentSearchResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/assessments/{assessment_id}/attachments/search [get]
+func (h *AttachmentHandler) SearchAttachments(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Searching answer attachments", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	results, err := h.attachmentService.SearchAttachments(c.Request.Context(), assessmentID, c.Query("q"), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// RerunOCR queues an upload for text recognition again
+// @Summary Re-run text recognition
+// @Description Queues an uploaded image for text recognition again, e.g. after it failed
+// @Tags grading
+// @Produce json
+// @Param attachment_id path uint true "Attachment ID"
+// @Success 202 {object} models.AnswerAttachment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/attachments/{attachment_id}/ocr [post]
+func (h *AttachmentHandler) RerunOCR(c *gin.Context) {
+	attachmentID := h.parseIDParam(c, "attachment_id")
+	if attachmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Re-running text recognition", "attachment_id", attachmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	attachment, err := h.attachmentService.RerunOCR(c.Request.Context(), attachmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, attachment)
+}
+
+// UploadQuestionImage attaches an image to a question
+// @Summary Upload question image
+// @Description Stores an image shown with a question along with web-optimized thumbnail, standard and retina copies; the question's delivery payload links the copies on the media CDN
+// @Tags questions
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Param file formData file true "JPEG, PNG or GIF image"
+// @Param alt formData string false "Alternative text"
+// @Param caption formData string false "Caption"
+// @Success 201 {object} models.QuestionAttachment
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/attachments [post]
+func (h *AttachmentHandler) UploadQuestionImage(c *gin.Context) {
+	questionID := h.parseIDParam(c, "id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Uploading question image", "question_id", questionID)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+	if fileHeader.Size > maxAttachmentFileSize {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "File too large",
+			Details: map[string]interface{}{
+				"max_size": maxAttachmentFileSize,
+			},
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	var req services.UploadQuestionImageRequest
+	if alt := c.PostForm("alt"); alt != "" {
+		req.Alt = &alt
+	}
+	if caption := c.PostForm("caption"); caption != "" {
+		req.Caption = &caption
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentService.UploadQuestionImage(c.Request.Context(), questionID, file, fileHeader.Filename, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// DeleteQuestionImage removes an image from a question
+// @Summary Delete question image
+// @Description Removes an image from a question along with its resized copies
+// @Tags questions
+// @Param id path uint true "Question ID"
+// @Param attachment_id path uint true "Attachment ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/attachments/{attachment_id} [delete]
+func (h *AttachmentHandler) DeleteQuestionImage(c *gin.Context) {
+	questionID := h.parseIDParam(c, "id")
+	if questionID == 0 {
+		return
+	}
+	attachmentID := h.parseIDParam(c, "attachment_id")
+	if attachmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Deleting question image", "question_id", questionID, "attachment_id", attachmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.attachmentService.DeleteQuestionImage(c.Request.Context(), questionID, attachmentID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ServeQuestionMedia serves a stored question image to the media CDN
+// @Summary Get question media
+// @Description Serves a question image or one of its resized copies. Files are immutable, so they may be cached indefinitely; no authentication is needed so a CDN can pull them.
+// @Tags questions
+// @Produce image/jpeg,image/png,image/gif
+// @Param key path string true "Media key"
+// @Success 200 {file} file
+// @Failure 404 {object} ErrorResponse
+// @Router /media/{key} [get]
+func (h *AttachmentHandler) ServeQuestionMedia(c *gin.Context) {
+	filePath, err := h.attachmentService.QuestionMediaFile(c.Param("key"))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.File(filePath)
+}
+
+// Helper methods
+
+func (h *AttachmentHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *AttachmentHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var validationError *services.ValidationError
+	if errors.As(err, &validationError) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationError,
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrAttemptNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Attempt not found",
+		})
+	case errors.Is(err, services.ErrQuestionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Question not found",
+		})
+	case errors.Is(err, services.ErrAttemptNotActive):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Message: "Attempt is not active",
+		})
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Resource not found",
+		})
+	case errors.Is(err, services.ErrUnauthorized):
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "Unauthorized access",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
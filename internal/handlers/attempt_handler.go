@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
@@ -109,6 +111,43 @@ func (h *AttemptHandler) ResumeAttempt(c *gin.Context) {
 	c.JSON(http.StatusOK, attempt)
 }
 
+// SaveAndExit parks an attempt so the student can come back later
+// @Summary Save and exit assessment attempt
+// @Description Stores the attempt's answers and stops its clock until the student resumes it. Only assessments allowing save-and-exit; a saved attempt is submitted automatically when the due date passes.
+// @Tags attempts
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} services.AttemptResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/save-and-exit [post]
+func (h *AttemptHandler) SaveAndExit(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Saving and exiting assessment attempt", "attempt_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	attempt, err := h.attemptService.SaveAndExit(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attempt)
+}
+
 // SubmitAttempt submits an assessment attempt
 // @Summary Submit assessment attempt
 // @Description Submits an assessment attempt with all answers
@@ -202,36 +241,1230 @@ func (h *AttemptHandler) SubmitAnswer(c *gin.Context) {
 		})
 		return
 	}
-	err := h.attemptService.SubmitAnswer(c.Request.Context(), attemptID, &req, userID.(string))
+	err := h.attemptService.SubmitAnswer(c.Request.Context(), attemptID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Answer submitted successfully",
+	})
+}
+
+// GetAttempt retrieves an attempt by ID
+// @Summary Get attempt
+// @Description Retrieves an attempt by its ID
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} SuccessResponse{data=services.AttemptResponse}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id} [get]
+func (h *AttemptHandler) GetAttempt(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting attempt", "attempt_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	attempt, err := h.attemptService.GetByID(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attempt)
+}
+
+// GetAttemptWithDetails retrieves an attempt with full details
+// @Summary Get attempt with details
+// @Description Retrieves an attempt with full details including questions and answers
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} SuccessResponse{data=services.AttemptResponse}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/details [get]
+func (h *AttemptHandler) GetAttemptWithDetails(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting attempt with details", "attempt_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	attempt, err := h.attemptService.GetByIDWithDetails(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attempt)
+}
+
+// GetAttemptBreakdown returns earned vs possible points of an attempt grouped by question attributes
+// @Summary Get attempt score breakdown
+// @Description Returns earned vs possible points grouped by question type, difficulty, section and skill
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} services.AttemptScoreBreakdown
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/breakdown [get]
+func (h *AttemptHandler) GetAttemptBreakdown(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting attempt score breakdown", "attempt_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	breakdown, err := h.attemptService.GetScoreBreakdown(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, breakdown)
+}
+
+// ExportAttemptTranscript downloads a finished attempt as a transcript
+// @Summary Export attempt transcript
+// @Description Downloads the attempt as delivered with the student's answers, scores, feedback, rubric comments and an integrity summary. Students only get the parts the assessment's result settings let them see.
+// @Tags attempts
+// @Produce application/pdf
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param format query string false "pdf (default) or json"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/export [get]
+func (h *AttemptHandler) ExportAttemptTranscript(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	format := c.DefaultQuery("format", "pdf")
+	h.LogRequest(c, "Exporting attempt transcript", "attempt_id", id, "format", format)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	document, err := h.attemptService.ExportTranscript(c.Request.Context(), id, format, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.FileName))
+	c.Data(http.StatusOK, document.ContentType, document.Content)
+}
+
+// ExportOfflineBundle packages an attempt for an offline proctor app
+// @Summary Export offline attempt bundle
+// @Description Packages an in-progress attempt for a proctor app in an exam hall without internet: questions, answers given so far, the attempt deadline and a per-bundle signing key. Exporting again supersedes bundles that have not been imported yet.
+// @Tags attempts
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} services.OfflineBundleExport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/offline-bundles [post]
+func (h *AttemptHandler) ExportOfflineBundle(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Exporting offline bundle", "attempt_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	bundle, err := h.attemptService.ExportOfflineBundle(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bundle)
+}
+
+// ImportOfflineBundle applies a signed offline bundle to its attempt
+// @Summary Import offline attempt bundle
+// @Description Verifies the bundle's HMAC-SHA256 signature and applies its answers. Answers to questions not in the attempt, given outside the attempt window or to questions changed online since the export are reported as conflicts and not applied. A submitted_at in the payload submits the attempt. Bundles for attempts already submitted online are rejected.
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param bundle_id path uint true "Offline bundle ID"
+// @Param request body services.ImportOfflineBundleRequest true "Signed payload"
+// @Success 200 {object} services.OfflineBundleImportResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/offline-bundles/{bundle_id}/import [post]
+func (h *AttemptHandler) ImportOfflineBundle(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+	bundleID := h.parseIDParam(c, "bundle_id")
+	if bundleID == 0 {
+		return
+	}
+
+	var req services.ImportOfflineBundleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.LogRequest(c, "Importing offline bundle", "attempt_id", id, "bundle_id", bundleID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	result, err := h.attemptService.ImportOfflineBundle(c.Request.Context(), id, bundleID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetSubmissionSummary lists unanswered and flagged questions before final submission
+// @Summary Get submission summary
+// @Description Lists unanswered and flagged questions and whether the assessment's submission gates allow submitting or require confirmation
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} services.SubmissionSummary
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/submission-summary [get]
+func (h *AttemptHandler) GetSubmissionSummary(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting submission summary", "attempt_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	summary, err := h.attemptService.GetSubmissionSummary(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// GetCurrentQuestion gets the question on the clock of a per-question timed attempt
+// @Summary Get current question
+// @Description Returns the only question a student can see and answer in per-question timing mode, with its deadline. Questions whose time ran out are skipped automatically.
+// @Tags attempts
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} services.CurrentQuestion
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/current-question [get]
+func (h *AttemptHandler) GetCurrentQuestion(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting current question", "attempt_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	current, err := h.attemptService.GetCurrentQuestion(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, current)
+}
+
+// AdvanceQuestion moves a per-question timed attempt on to its next question
+// @Summary Advance to next question
+// @Description Locks the current question and opens the next one in per-question timing mode. There is no going back.
+// @Tags attempts
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} services.CurrentQuestion
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/advance [post]
+func (h *AttemptHandler) AdvanceQuestion(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Advancing to next question", "attempt_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	current, err := h.attemptService.AdvanceQuestion(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, current)
+}
+
+// GetNextQuestion returns where branching rules send the student after a question
+// @Summary Get next question
+// @Description Evaluates the assessment's branching rules against the stored answers and returns the question after the given one on the student's route. Without after, the first question is returned.
+// @Tags attempts
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param after query uint false "Question the student is leaving"
+// @Success 200 {object} services.NextQuestion
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/next-question [get]
+func (h *AttemptHandler) GetNextQuestion(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	var after uint
+	if afterStr := c.Query("after"); afterStr != "" {
+		parsed, err := strconv.ParseUint(afterStr, 10, 32)
+		if err != nil || parsed == 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid after value",
+				Details: "after must be a question ID",
+			})
+			return
+		}
+		after = uint(parsed)
+	}
+
+	h.LogRequest(c, "Getting next question", "attempt_id", id, "after", after)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	next, err := h.attemptService.GetNextQuestion(c.Request.Context(), id, after, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, next)
+}
+
+// GetSections lists the attempt's sections and which of them are locked
+// @Summary Get attempt sections
+// @Description Lists the sections of an attempt in delivery order with their questions. With anti-backtracking, the questions of a locked section are read-only.
+// @Tags attempts
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} services.AttemptSections
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/sections [get]
+func (h *AttemptHandler) GetSections(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting attempt sections", "attempt_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	sections, err := h.attemptService.GetSections(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sections)
+}
+
+// CompleteSection finishes a section before the student moves on
+// @Summary Complete section
+// @Description Locks a section, and every section before it, so its answers can no longer be changed. Only assessments locking completed sections. Answering a question of a later section locks the earlier ones as well.
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param section body services.CompleteSectionRequest true "Section to complete"
+// @Success 200 {object} services.AttemptSections
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/sections/complete [post]
+func (h *AttemptHandler) CompleteSection(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	var req services.CompleteSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.LogRequest(c, "Completing section", "attempt_id", id, "section", req.Section)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	sections, err := h.attemptService.CompleteSection(c.Request.Context(), id, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sections)
+}
+
+// FlagQuestion flags or unflags a question for review within an attempt
+// @Summary Flag question for review
+// @Description Marks a question of an in-progress attempt as flagged for review, or clears the flag
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param question_id path uint true "Question ID"
+// @Param flag body services.FlagQuestionRequest true "Flag state"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/questions/{question_id}/flag [put]
+func (h *AttemptHandler) FlagQuestion(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+	questionID := h.parseIDParam(c, "question_id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Flagging question", "attempt_id", attemptID, "question_id", questionID)
+
+	var req services.FlagQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.attemptService.FlagQuestion(c.Request.Context(), attemptID, questionID, &req, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Question flag updated successfully",
+	})
+}
+
+// BookmarkQuestion bookmarks or unbookmarks a question within an attempt
+// @Summary Bookmark question
+// @Description Sets a personal bookmark on a question of an in-progress attempt. Bookmarks are separate from review flags and are not shown to graders.
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param question_id path uint true "Question ID"
+// @Param bookmark body services.BookmarkQuestionRequest true "Bookmark state"
+// @Success 200 {object} services.AttemptBookmarks
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/questions/{question_id}/bookmark [put]
+func (h *AttemptHandler) BookmarkQuestion(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+	questionID := h.parseIDParam(c, "question_id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Bookmarking question", "attempt_id", attemptID, "question_id", questionID)
+
+	var req services.BookmarkQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	bookmarks, err := h.attemptService.BookmarkQuestion(c.Request.Context(), attemptID, questionID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bookmarks)
+}
+
+// SpellCheckAnswer suggests spelling corrections for an essay answer
+// @Summary Spell check essay answer
+// @Description Suggests corrections for misspelled words in the draft of an essay answer, when the assessment offers spell check. The answer is not corrected; it is marked as having been shown suggestions and is graded as written.
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param question_id path uint true "Question ID"
+// @Param draft body services.SpellCheckRequest true "Essay draft"
+// @Success 200 {object} services.SpellCheckResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/questions/{question_id}/spell-check [post]
+func (h *AttemptHandler) SpellCheckAnswer(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+	questionID := h.parseIDParam(c, "question_id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Spell checking answer", "attempt_id", attemptID, "question_id", questionID)
+
+	var req services.SpellCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	result, err := h.attemptService.SpellCheckAnswer(c.Request.Context(), attemptID, questionID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RecordQuestionVisit logs that the student opened a question
+// @Summary Record question visit
+// @Description Logs that the student opened a question of an in-progress attempt, for the assessment's navigation analytics. Clients call it each time a question is shown.
+// @Tags attempts
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param question_id path uint true "Question ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/questions/{question_id}/visit [post]
+func (h *AttemptHandler) RecordQuestionVisit(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+	questionID := h.parseIDParam(c, "question_id")
+	if questionID == 0 {
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.attemptService.RecordQuestionVisit(c.Request.Context(), attemptID, questionID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CompareAttempts compares a student's attempts at an assessment question by question
+// @Summary Compare a student's attempts
+// @Description Lines up each question's result across the student's finished attempts and marks it improved, regressed or unchanged since the previous attempt. Students may only compare their own attempts, once results are released.
+// @Tags attempts
+// @Produce json
+// @Param student_id path string true "Student ID"
+// @Param assessment_id path uint true "Assessment ID"
+// @Success 200 {object} services.AttemptComparison
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /students/{student_id}/assessments/{assessment_id}/attempt-comparison [get]
+func (h *AttemptHandler) CompareAttempts(c *gin.Context) {
+	studentID := ParseStringIDParam(c, "student_id")
+	if studentID == "" {
+		return
+	}
+
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Comparing attempts", "student_id", studentID, "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	comparison, err := h.attemptService.CompareAttempts(c.Request.Context(), studentID, assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comparison)
+}
+
+// GetBookmarks lists the questions the student bookmarked in an attempt
+// @Summary Get attempt bookmarks
+// @Tags attempts
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} services.AttemptBookmarks
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/bookmarks [get]
+func (h *AttemptHandler) GetBookmarks(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	bookmarks, err := h.attemptService.GetBookmarks(c.Request.Context(), attemptID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bookmarks)
+}
+
+// GetCurrentAttempt retrieves the current active attempt for an assessment
+// @Summary Get current attempt
+// @Description Retrieves the current active attempt for a specific assessment
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Success 200 {object} SuccessResponse{data=services.AttemptResponse}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/current/{assessment_id} [get]
+func (h *AttemptHandler) GetCurrentAttempt(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting current attempt", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	attempt, err := h.attemptService.GetCurrentAttempt(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attempt)
+}
+
+// ListAttempts lists attempts with filters
+// @Summary List attempts
+// @Description Lists attempts with optional filtering and sorting. Filter on the organization's custom fields with cf.<key>=value, e.g. cf.room=B12.
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param cursor query string false "Continue after a previous page's next_cursor instead of using page"
+// @Param status query string false "Attempt status"
+// @Param assessment_id query uint false "Assessment ID"
+// @Param min_score query number false "Minimum score"
+// @Param max_score query number false "Maximum score"
+// @Param min_percentage query number false "Minimum percentage"
+// @Param max_percentage query number false "Maximum percentage"
+// @Param passed query bool false "Only passed (true) or failed (false) attempts"
+// @Param completed_from query string false "Completed at or after (RFC3339)"
+// @Param completed_to query string false "Completed at or before (RFC3339)"
+// @Param has_proctoring_flags query bool false "Only attempts with (true) or without (false) undismissed proctoring events"
+// @Param grading_status query string false "Comma-separated answer grading statuses; attempts with any answer in one of them"
+// @Param search query string false "Student name or email"
+// @Param sort query string false "Comma-separated sort keys, '-' prefix for descending: created_at, started_at, completed_at, score, percentage, time_spent, student_name"
+// @Success 200 {object} PaginatedResponse{items=[]services.AttemptResponse}
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts [get]
+func (h *AttemptHandler) ListAttempts(c *gin.Context) {
+	h.LogRequest(c, "Listing attempts")
+
+	filters, ok := h.parseAttemptFilters(c)
+	if !ok {
+		return
+	}
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	attempts, total, err := h.attemptService.List(c.Request.Context(), filters, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newAttemptPage(attempts, total, filters))
+}
+
+// GetAttemptsByStudent lists attempts by student
+// @Summary Get attempts by student
+// @Description Lists attempts made by a specific student
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param student_id path uint true "Student ID"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param cursor query string false "Continue after a previous page's next_cursor instead of using page"
+// @Param min_score query number false "Minimum score"
+// @Param max_score query number false "Maximum score"
+// @Param min_percentage query number false "Minimum percentage"
+// @Param max_percentage query number false "Maximum percentage"
+// @Param passed query bool false "Only passed (true) or failed (false) attempts"
+// @Param completed_from query string false "Completed at or after (RFC3339)"
+// @Param completed_to query string false "Completed at or before (RFC3339)"
+// @Param has_proctoring_flags query bool false "Only attempts with (true) or without (false) undismissed proctoring events"
+// @Param grading_status query string false "Comma-separated answer grading statuses; attempts with any answer in one of them"
+// @Param search query string false "Student name or email"
+// @Param sort query string false "Comma-separated sort keys, '-' prefix for descending: created_at, started_at, completed_at, score, percentage, time_spent, student_name"
+// @Success 200 {object} PaginatedResponse{items=[]services.AttemptResponse}
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/student/{student_id} [get]
+func (h *AttemptHandler) GetAttemptsByStudent(c *gin.Context) {
+	studentID := ParseStringIDParam(c, "student_id")
+	if studentID == "" {
+		return
+	}
+
+	h.LogRequest(c, "Getting attempts by student", "student_id", studentID)
+
+	filters, ok := h.parseAttemptFilters(c)
+	if !ok {
+		return
+	}
+	attempts, total, err := h.attemptService.GetByStudent(c.Request.Context(), studentID, filters)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newAttemptPage(attempts, total, filters))
+}
+
+// GetAttemptsByAssessment lists attempts by assessment
+// @Summary Get attempts by assessment
+// @Description Lists attempts for a specific assessment
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(10)
+// @Param cursor query string false "Continue after a previous page's next_cursor instead of using page"
+// @Param min_score query number false "Minimum score"
+// @Param max_score query number false "Maximum score"
+// @Param min_percentage query number false "Minimum percentage"
+// @Param max_percentage query number false "Maximum percentage"
+// @Param passed query bool false "Only passed (true) or failed (false) attempts"
+// @Param completed_from query string false "Completed at or after (RFC3339)"
+// @Param completed_to query string false "Completed at or before (RFC3339)"
+// @Param has_proctoring_flags query bool false "Only attempts with (true) or without (false) undismissed proctoring events"
+// @Param grading_status query string false "Comma-separated answer grading statuses; attempts with any answer in one of them"
+// @Param search query string false "Student name or email"
+// @Param sort query string false "Comma-separated sort keys, '-' prefix for descending: created_at, started_at, completed_at, score, percentage, time_spent, student_name"
+// @Success 200 {object} PaginatedResponse{items=[]services.AttemptResponse}
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/assessment/{assessment_id} [get]
+func (h *AttemptHandler) GetAttemptsByAssessment(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting attempts by assessment", "assessment_id", assessmentID)
+
+	filters, ok := h.parseAttemptFilters(c)
+	if !ok {
+		return
+	}
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	attempts, total, err := h.attemptService.GetByAssessment(c.Request.Context(), assessmentID, filters, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, newAttemptPage(attempts, total, filters))
+}
+
+// GetTimeRemaining gets the remaining time for an attempt
+// @Summary Get time remaining
+// @Description Gets the remaining time for an active attempt
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} SuccessResponse{data=int}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/time-remaining [get]
+func (h *AttemptHandler) GetTimeRemaining(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting time remaining", "attempt_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	timeRemaining, err := h.attemptService.GetTimeRemaining(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Time remaining retrieved successfully",
+		Data:    timeRemaining,
+	})
+}
+
+// UpdateCustomFields records values of the organization's custom fields on an attempt
+// @Summary Update attempt custom fields
+// @Description Sets the given custom fields of an attempt, such as the proctor or room; null removes a field. Values are checked against the attempt fields of the assessment owner's organization.
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param fields body services.UpdateAttemptCustomFieldsRequest true "Changed fields"
+// @Success 200 {object} SuccessResponse{data=services.AttemptResponse}
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/custom-fields [put]
+func (h *AttemptHandler) UpdateCustomFields(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Updating attempt custom fields", "attempt_id", id)
+
+	var req services.UpdateAttemptCustomFieldsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	attempt, err := h.attemptService.UpdateCustomFields(c.Request.Context(), id, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Custom fields updated successfully",
+		Data:    attempt,
+	})
+}
+
+// ExtendTime extends time for an attempt
+// @Summary Extend attempt time
+// @Description Extends the time limit for an active attempt
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Param minutes query int false "Minutes to extend; defaults to the assessment's accommodation extra time"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/extend [post]
+func (h *AttemptHandler) ExtendTime(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	// Without minutes the assessment's accommodation extra time is granted
+	minutes := 0
+	if minutesStr := c.Query("minutes"); minutesStr != "" {
+		parsed, err := strconv.Atoi(minutesStr)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid minutes value",
+				Details: "minutes must be a positive whole number",
+			})
+			return
+		}
+		minutes = parsed
+	}
+
+	h.LogRequest(c, "Extending attempt time", "attempt_id", id, "minutes", minutes)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	if err := h.attemptService.ExtendTime(c.Request.Context(), id, minutes, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Time extended successfully",
+	})
+}
+
+// HandleTimeout handles attempt timeout
+// @Summary Handle attempt timeout
+// @Description Handles timeout for an attempt (system endpoint)
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/{id}/timeout [post]
+func (h *AttemptHandler) HandleTimeout(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Handling attempt timeout", "attempt_id", id)
+
+	err := h.attemptService.HandleTimeout(c.Request.Context(), id)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Timeout handled successfully",
+	})
+}
+
+// CanStartAttempt checks if user can start an attempt
+// @Summary Check if can start attempt
+// @Description Checks if a user can start a new attempt for an assessment
+// @Tags attempts
+// @Accept json
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Success 200 {object} SuccessResponse{data=bool}
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempts/can-start/{assessment_id} [get]
+func (h *AttemptHandler) CanStartAttempt(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Checking if can start attempt", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	canStart, err := h.attemptService.CanStart(c.Request.Context(), assessmentID, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, SuccessResponse{
-		Message: "Answer submitted successfully",
+		Message: "Can start check completed",
+		Data:    canStart,
 	})
 }
 
-// GetAttempt retrieves an attempt by ID
-// @Summary Get attempt
-// @Description Retrieves an attempt by its ID
+// JoinAttemptQueue queues the student for an assessment whose concurrent attempts are capped
+// @Summary Join attempt queue
+// @Description Puts the student in line for a free attempt slot. When a slot frees up it is held for the student at the head of the line for 5 minutes and they are notified; starting the attempt uses the held slot.
 // @Tags attempts
-// @Accept json
 // @Produce json
-// @Param id path uint true "Attempt ID"
-// @Success 200 {object} SuccessResponse{data=services.AttemptResponse}
+// @Param assessment_id path uint true "Assessment ID"
+// @Success 200 {object} SuccessResponse{data=services.AttemptQueueStatus}
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /attempts/{id} [get]
-func (h *AttemptHandler) GetAttempt(c *gin.Context) {
-	id := h.parseIDParam(c, "id")
-	if id == 0 {
+// @Router /attempts/queue/{assessment_id} [post]
+func (h *AttemptHandler) JoinAttemptQueue(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
 		return
 	}
 
-	h.LogRequest(c, "Getting attempt", "attempt_id", id)
+	h.LogRequest(c, "Joining attempt queue", "assessment_id", assessmentID)
 
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -240,34 +1473,38 @@ func (h *AttemptHandler) GetAttempt(c *gin.Context) {
 		})
 		return
 	}
-	attempt, err := h.attemptService.GetByID(c.Request.Context(), id, userID.(string))
+
+	status, err := h.attemptService.JoinAttemptQueue(c.Request.Context(), assessmentID, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, attempt)
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Joined attempt queue",
+		Data:    status,
+	})
 }
 
-// GetAttemptWithDetails retrieves an attempt with full details
-// @Summary Get attempt with details
-// @Description Retrieves an attempt with full details including questions and answers
+// GetAttemptQueueStatus returns the student's place in the attempt queue
+// @Summary Get attempt queue status
+// @Description Shows the attempt cap, active attempts and the student's position in line, or until when a slot is held for them
 // @Tags attempts
-// @Accept json
 // @Produce json
-// @Param id path uint true "Attempt ID"
-// @Success 200 {object} SuccessResponse{data=services.AttemptResponse}
+// @Param assessment_id path uint true "Assessment ID"
+// @Success 200 {object} SuccessResponse{data=services.AttemptQueueStatus}
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /attempts/{id}/details [get]
-func (h *AttemptHandler) GetAttemptWithDetails(c *gin.Context) {
-	id := h.parseIDParam(c, "id")
-	if id == 0 {
+// @Router /attempts/queue/{assessment_id} [get]
+func (h *AttemptHandler) GetAttemptQueueStatus(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
 		return
 	}
 
-	h.LogRequest(c, "Getting attempt with details", "attempt_id", id)
+	h.LogRequest(c, "Getting attempt queue status", "assessment_id", assessmentID)
 
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -276,34 +1513,38 @@ func (h *AttemptHandler) GetAttemptWithDetails(c *gin.Context) {
 		})
 		return
 	}
-	attempt, err := h.attemptService.GetByIDWithDetails(c.Request.Context(), id, userID.(string))
+
+	status, err := h.attemptService.GetAttemptQueueStatus(c.Request.Context(), assessmentID, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, attempt)
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Attempt queue status retrieved successfully",
+		Data:    status,
+	})
 }
 
-// GetCurrentAttempt retrieves the current active attempt for an assessment
-// @Summary Get current attempt
-// @Description Retrieves the current active attempt for a specific assessment
+// LeaveAttemptQueue removes the student from the attempt queue
+// @Summary Leave attempt queue
+// @Description Gives up the student's place in line, or the slot held for them
 // @Tags attempts
-// @Accept json
 // @Produce json
 // @Param assessment_id path uint true "Assessment ID"
-// @Success 200 {object} SuccessResponse{data=services.AttemptResponse}
+// @Success 200 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /attempts/current/{assessment_id} [get]
-func (h *AttemptHandler) GetCurrentAttempt(c *gin.Context) {
+// @Router /attempts/queue/{assessment_id} [delete]
+func (h *AttemptHandler) LeaveAttemptQueue(c *gin.Context) {
 	assessmentID := h.parseIDParam(c, "assessment_id")
 	if assessmentID == 0 {
 		return
 	}
 
-	h.LogRequest(c, "Getting current attempt", "assessment_id", assessmentID)
+	h.LogRequest(c, "Leaving attempt queue", "assessment_id", assessmentID)
 
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -312,32 +1553,45 @@ func (h *AttemptHandler) GetCurrentAttempt(c *gin.Context) {
 		})
 		return
 	}
-	attempt, err := h.attemptService.GetCurrentAttempt(c.Request.Context(), assessmentID, userID.(string))
-	if err != nil {
+
+	if err := h.attemptService.LeaveAttemptQueue(c.Request.Context(), assessmentID, userID.(string)); err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, attempt)
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Left attempt queue",
+	})
 }
 
-// ListAttempts lists attempts with filters
-// @Summary List attempts
-// @Description Lists attempts with optional filtering
+// GetAttemptQuotaStatus returns how much of each attempt quota the student has used
+// @Summary Get attempt quota status
+// @Description Lists the active attempt quotas that apply to the student with the attempts used in the current rolling window, the attempts left and, once a quota is used up, when the next attempt frees up
 // @Tags attempts
-// @Accept json
 // @Produce json
-// @Param page query int false "Page number" default(1)
-// @Param size query int false "Page size" default(10)
-// @Param status query string false "Attempt status"
-// @Param assessment_id query uint false "Assessment ID"
-// @Success 200 {object} SuccessResponse{data=[]services.AttemptResponse}
+// @Param assessment_id query uint false "Only quotas covering this assessment"
+// @Success 200 {object} SuccessResponse{data=[]services.AttemptQuotaStatus}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /attempts [get]
-func (h *AttemptHandler) ListAttempts(c *gin.Context) {
-	h.LogRequest(c, "Listing attempts")
+// @Router /attempts/quota-status [get]
+func (h *AttemptHandler) GetAttemptQuotaStatus(c *gin.Context) {
+	var assessmentID *uint
+	if raw := c.Query("assessment_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid assessment_id",
+				Details: err.Error(),
+			})
+			return
+		}
+		value := uint(id)
+		assessmentID = &value
+	}
+
+	h.LogRequest(c, "Getting attempt quota status")
 
-	filters := h.parseAttemptFilters(c)
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, ErrorResponse{
@@ -346,84 +1600,76 @@ func (h *AttemptHandler) ListAttempts(c *gin.Context) {
 		return
 	}
 
-	attempts, total, err := h.attemptService.List(c.Request.Context(), filters, userID.(string))
+	statuses, err := h.attemptService.GetAttemptQuotaStatus(c.Request.Context(), assessmentID, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
-	page := (filters.Offset / filters.Limit) + 1
-	response := map[string]interface{}{
-		"attempts": attempts,
-		"total":    total,
-		"page":     page,
-		"size":     filters.Limit,
-	}
-
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Attempt quota status retrieved successfully",
+		Data:    statuses,
+	})
 }
 
-// GetAttemptsByStudent lists attempts by student
-// @Summary Get attempts by student
-// @Description Lists attempts made by a specific student
+// CreateAttemptQuota limits the attempts students may start on a bank's assessments
+// @Summary Create attempt quota
+// @Description Caps the attempts each student may start, within a rolling window, across every assessment drawing questions from the bank, e.g. 20 practice attempts per week. A class limits the quota to students enrolled with it. Admins only.
 // @Tags attempts
 // @Accept json
 // @Produce json
-// @Param student_id path uint true "Student ID"
-// @Param page query int false "Page number" default(1)
-// @Param size query int false "Page size" default(10)
-// @Success 200 {object} SuccessResponse{data=[]services.AttemptResponse}
+// @Param quota body services.CreateAttemptQuotaRequest true "Quota"
+// @Success 201 {object} SuccessResponse{data=models.AttemptQuota}
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /attempts/student/{student_id} [get]
-func (h *AttemptHandler) GetAttemptsByStudent(c *gin.Context) {
-	studentID := ParseStringIDParam(c, "student_id")
-	if studentID == "" {
+// @Router /attempt-quotas [post]
+func (h *AttemptHandler) CreateAttemptQuota(c *gin.Context) {
+	h.LogRequest(c, "Creating attempt quota")
+
+	var req services.CreateAttemptQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
 		return
 	}
 
-	h.LogRequest(c, "Getting attempts by student", "student_id", studentID)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
 
-	filters := h.parseAttemptFilters(c)
-	attempts, total, err := h.attemptService.GetByStudent(c.Request.Context(), studentID, filters)
+	quota, err := h.attemptService.CreateAttemptQuota(c.Request.Context(), &req, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
-	page := (filters.Offset / filters.Limit) + 1
-	response := map[string]interface{}{
-		"attempts": attempts,
-		"total":    total,
-		"page":     page,
-		"size":     filters.Limit,
-	}
-
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Attempt quota created successfully",
+		Data:    quota,
+	})
 }
 
-// GetAttemptsByAssessment lists attempts by assessment
-// @Summary Get attempts by assessment
-// @Description Lists attempts for a specific assessment
+// ListAttemptQuotas lists every attempt quota
+// @Summary List attempt quotas
+// @Description Lists attempt quotas, active or not. Admins only.
 // @Tags attempts
-// @Accept json
 // @Produce json
-// @Param assessment_id path uint true "Assessment ID"
-// @Param page query int false "Page number" default(1)
-// @Param size query int false "Page size" default(10)
-// @Success 200 {object} SuccessResponse{data=[]services.AttemptResponse}
-// @Failure 400 {object} ErrorResponse
+// @Success 200 {object} SuccessResponse{data=[]models.AttemptQuota}
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /attempts/assessment/{assessment_id} [get]
-func (h *AttemptHandler) GetAttemptsByAssessment(c *gin.Context) {
-	assessmentID := h.parseIDParam(c, "assessment_id")
-	if assessmentID == 0 {
-		return
-	}
+// @Router /attempt-quotas [get]
+func (h *AttemptHandler) ListAttemptQuotas(c *gin.Context) {
+	h.LogRequest(c, "Listing attempt quotas")
 
-	h.LogRequest(c, "Getting attempts by assessment", "assessment_id", assessmentID)
-
-	filters := h.parseAttemptFilters(c)
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, ErrorResponse{
@@ -432,42 +1678,49 @@ func (h *AttemptHandler) GetAttemptsByAssessment(c *gin.Context) {
 		return
 	}
 
-	attempts, total, err := h.attemptService.GetByAssessment(c.Request.Context(), assessmentID, filters, userID.(string))
+	quotas, err := h.attemptService.ListAttemptQuotas(c.Request.Context(), userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
-	page := (filters.Offset / filters.Limit) + 1
-	response := map[string]interface{}{
-		"attempts": attempts,
-		"total":    total,
-		"page":     page,
-		"size":     filters.Limit,
-	}
-
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Attempt quotas retrieved successfully",
+		Data:    quotas,
+	})
 }
 
-// GetTimeRemaining gets the remaining time for an attempt
-// @Summary Get time remaining
-// @Description Gets the remaining time for an active attempt
+// UpdateAttemptQuota changes an attempt quota
+// @Summary Update attempt quota
+// @Description Changes a quota's name, class, limit or window, or turns it off. Its bank cannot change. Admins only.
 // @Tags attempts
 // @Accept json
 // @Produce json
-// @Param id path uint true "Attempt ID"
-// @Success 200 {object} SuccessResponse{data=int}
+// @Param id path uint true "Quota ID"
+// @Param quota body services.UpdateAttemptQuotaRequest true "Fields to change"
+// @Success 200 {object} SuccessResponse{data=models.AttemptQuota}
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /attempts/{id}/time-remaining [get]
-func (h *AttemptHandler) GetTimeRemaining(c *gin.Context) {
-	id := h.parseIDParam(c, "id")
-	if id == 0 {
+// @Router /attempt-quotas/{id} [put]
+func (h *AttemptHandler) UpdateAttemptQuota(c *gin.Context) {
+	quotaID := h.parseIDParam(c, "id")
+	if quotaID == 0 {
 		return
 	}
 
-	h.LogRequest(c, "Getting time remaining", "attempt_id", id)
+	h.LogRequest(c, "Updating attempt quota", "quota_id", quotaID)
+
+	var req services.UpdateAttemptQuotaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -476,55 +1729,83 @@ func (h *AttemptHandler) GetTimeRemaining(c *gin.Context) {
 		})
 		return
 	}
-	timeRemaining, err := h.attemptService.GetTimeRemaining(c.Request.Context(), id, userID.(string))
+
+	quota, err := h.attemptService.UpdateAttemptQuota(c.Request.Context(), quotaID, &req, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, SuccessResponse{
-		Message: "Time remaining retrieved successfully",
-		Data:    timeRemaining,
+		Message: "Attempt quota updated successfully",
+		Data:    quota,
 	})
 }
 
-// ExtendTime extends time for an attempt
-// @Summary Extend attempt time
-// @Description Extends the time limit for an active attempt
+// DeleteAttemptQuota removes an attempt quota
+// @Summary Delete attempt quota
+// @Description Removes an attempt quota; students are no longer limited by it. Admins only.
 // @Tags attempts
-// @Accept json
 // @Produce json
-// @Param id path uint true "Attempt ID"
-// @Param minutes query int true "Minutes to extend"
+// @Param id path uint true "Quota ID"
 // @Success 200 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /attempts/{id}/extend [post]
-func (h *AttemptHandler) ExtendTime(c *gin.Context) {
-	id := h.parseIDParam(c, "id")
-	if id == 0 {
+// @Router /attempt-quotas/{id} [delete]
+func (h *AttemptHandler) DeleteAttemptQuota(c *gin.Context) {
+	quotaID := h.parseIDParam(c, "id")
+	if quotaID == 0 {
 		return
 	}
 
-	minutesStr := c.Query("minutes")
-	if minutesStr == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Message: "Minutes parameter is required",
+	h.LogRequest(c, "Deleting attempt quota", "quota_id", quotaID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
 		})
 		return
 	}
 
-	minutes, err := strconv.Atoi(minutesStr)
-	if err != nil || minutes <= 0 {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Message: "Invalid minutes value",
-			Details: err.Error(),
-		})
+	if err := h.attemptService.DeleteAttemptQuota(c.Request.Context(), quotaID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
 		return
 	}
 
-	h.LogRequest(c, "Extending attempt time", "attempt_id", id, "minutes", minutes)
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Attempt quota deleted successfully",
+	})
+}
+
+// FindDuplicateAttempts lists students with several attempts in progress on one assessment
+// @Summary Find duplicate attempts
+// @Description Lists every student with more than one attempt in progress on the same assessment, with the merge that would repair it. Admins only.
+// @Tags attempts
+// @Produce json
+// @Param assessment_id query uint false "Only this assessment"
+// @Success 200 {object} SuccessResponse{data=[]services.DuplicateAttemptGroup}
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attempt-repair/duplicates [get]
+func (h *AttemptHandler) FindDuplicateAttempts(c *gin.Context) {
+	var assessmentID *uint
+	if raw := c.Query("assessment_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid assessment_id",
+				Details: err.Error(),
+			})
+			return
+		}
+		value := uint(id)
+		assessmentID = &value
+	}
 
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -533,67 +1814,80 @@ func (h *AttemptHandler) ExtendTime(c *gin.Context) {
 		})
 		return
 	}
-	err = h.attemptService.ExtendTime(c.Request.Context(), id, minutes, userID.(string))
+
+	groups, err := h.attemptService.FindDuplicateAttempts(c.Request.Context(), assessmentID, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, SuccessResponse{
-		Message: "Time extended successfully",
+		Message: "Duplicate attempts retrieved successfully",
+		Data:    groups,
 	})
 }
 
-// HandleTimeout handles attempt timeout
-// @Summary Handle attempt timeout
-// @Description Handles timeout for an attempt (system endpoint)
+// MergeDuplicateAttempts merges a student's duplicate attempts into one
+// @Summary Merge duplicate attempts
+// @Description Keeps one of the student's attempts in progress on the assessment and voids the others. For every question the most recently modified answer is kept in the surviving attempt. The repair is audit-logged. Admins only.
 // @Tags attempts
 // @Accept json
 // @Produce json
-// @Param id path uint true "Attempt ID"
-// @Success 200 {object} SuccessResponse
+// @Param merge body services.MergeDuplicateAttemptsRequest true "Merge"
+// @Success 200 {object} SuccessResponse{data=services.AttemptMergeResult}
 // @Failure 400 {object} ErrorResponse
-// @Failure 404 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /attempts/{id}/timeout [post]
-func (h *AttemptHandler) HandleTimeout(c *gin.Context) {
-	id := h.parseIDParam(c, "id")
-	if id == 0 {
+// @Router /attempt-repair/merge [post]
+func (h *AttemptHandler) MergeDuplicateAttempts(c *gin.Context) {
+	var req services.MergeDuplicateAttemptsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
 		return
 	}
 
-	h.LogRequest(c, "Handling attempt timeout", "attempt_id", id)
+	h.LogRequest(c, "Merging duplicate attempts", "student_id", req.StudentID, "assessment_id", req.AssessmentID)
 
-	err := h.attemptService.HandleTimeout(c.Request.Context(), id)
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	result, err := h.attemptService.MergeDuplicateAttempts(c.Request.Context(), &req, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, SuccessResponse{
-		Message: "Timeout handled successfully",
+		Message: "Duplicate attempts merged successfully",
+		Data:    result,
 	})
 }
 
-// CanStartAttempt checks if user can start an attempt
-// @Summary Check if can start attempt
-// @Description Checks if a user can start a new attempt for an assessment
+// GetAttemptRepairLog lists the merges into an attempt
+// @Summary Get attempt repair log
 // @Tags attempts
-// @Accept json
 // @Produce json
-// @Param assessment_id path uint true "Assessment ID"
-// @Success 200 {object} SuccessResponse{data=bool}
+// @Param id path uint true "Attempt ID"
+// @Success 200 {object} SuccessResponse{data=[]models.AuditLog}
 // @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
-// @Router /attempts/can-start/{assessment_id} [get]
-func (h *AttemptHandler) CanStartAttempt(c *gin.Context) {
-	assessmentID := h.parseIDParam(c, "assessment_id")
-	if assessmentID == 0 {
+// @Router /attempt-repair/attempts/{id}/log [get]
+func (h *AttemptHandler) GetAttemptRepairLog(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "id")
+	if attemptID == 0 {
 		return
 	}
 
-	h.LogRequest(c, "Checking if can start attempt", "assessment_id", assessmentID)
-
 	userID, exists := c.Get("user_id")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, ErrorResponse{
@@ -601,15 +1895,16 @@ func (h *AttemptHandler) CanStartAttempt(c *gin.Context) {
 		})
 		return
 	}
-	canStart, err := h.attemptService.CanStart(c.Request.Context(), assessmentID, userID.(string))
+
+	logs, err := h.attemptService.GetAttemptRepairLog(c.Request.Context(), attemptID, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, SuccessResponse{
-		Message: "Can start check completed",
-		Data:    canStart,
+		Message: "Attempt repair log retrieved successfully",
+		Data:    logs,
 	})
 }
 
@@ -758,7 +2053,7 @@ func (h *AttemptHandler) parseIntQuery(c *gin.Context, param string, defaultValu
 	return value
 }
 
-func (h *AttemptHandler) parseAttemptFilters(c *gin.Context) repositories.AttemptFilters {
+func (h *AttemptHandler) parseAttemptFilters(c *gin.Context) (repositories.AttemptFilters, bool) {
 	page := h.parseIntQuery(c, "page", 1)
 	size := h.parseIntQuery(c, "size", 10)
 
@@ -767,6 +2062,15 @@ func (h *AttemptHandler) parseAttemptFilters(c *gin.Context) repositories.Attemp
 		Offset: (page - 1) * size,
 	}
 
+	cursor, ok := parseCursorQuery(c)
+	if !ok {
+		return filters, false
+	}
+	if cursor != nil {
+		filters.After = cursor
+		filters.Offset = 0
+	}
+
 	if status := c.Query("status"); status != "" {
 		attemptStatus := models.AttemptStatus(status)
 		filters.Status = &attemptStatus
@@ -776,8 +2080,139 @@ func (h *AttemptHandler) parseAttemptFilters(c *gin.Context) repositories.Attemp
 		studentIDStr = strings.TrimSpace(studentIDStr)
 		filters.StudentID = &studentIDStr
 	}
+	filters.CustomFields = parseCustomFieldFilters(c)
+
+	if !h.parseResultFilters(c, &filters) {
+		return filters, false
+	}
+	filters.Search = strings.TrimSpace(c.Query("search"))
+	if raw := c.Query("grading_status"); raw != "" {
+		for _, status := range strings.Split(raw, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				filters.GradingStatuses = append(filters.GradingStatuses, models.AnswerGradingStatus(status))
+			}
+		}
+	}
+	if raw := c.Query("sort"); raw != "" {
+		filters.Sort = parseAttemptSort(raw)
+	}
+
+	return filters, true
+}
+
+// parseResultFilters reads the score, outcome and completion filters, answering 400 on a
+// malformed value
+func (h *AttemptHandler) parseResultFilters(c *gin.Context, filters *repositories.AttemptFilters) bool {
+	invalid := func(param string, err error) bool {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return false
+	}
+
+	if raw := c.Query("assessment_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return invalid("assessment_id", err)
+		}
+		assessmentID := uint(id)
+		filters.AssessmentID = &assessmentID
+	}
+
+	floats := []struct {
+		param  string
+		target **float64
+	}{
+		{"min_score", &filters.MinScore},
+		{"max_score", &filters.MaxScore},
+		{"min_percentage", &filters.MinPercentage},
+		{"max_percentage", &filters.MaxPercentage},
+	}
+	for _, f := range floats {
+		if raw := c.Query(f.param); raw != "" {
+			value, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return invalid(f.param, err)
+			}
+			*f.target = &value
+		}
+	}
+
+	bools := []struct {
+		param  string
+		target **bool
+	}{
+		{"passed", &filters.Passed},
+		{"has_proctoring_flags", &filters.HasProctoringFlags},
+	}
+	for _, b := range bools {
+		if raw := c.Query(b.param); raw != "" {
+			value, err := strconv.ParseBool(raw)
+			if err != nil {
+				return invalid(b.param, err)
+			}
+			*b.target = &value
+		}
+	}
+
+	times := []struct {
+		param  string
+		target **time.Time
+	}{
+		{"completed_from", &filters.CompletedFrom},
+		{"completed_to", &filters.CompletedTo},
+	}
+	for _, t := range times {
+		if raw := c.Query(t.param); raw != "" {
+			value, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				return invalid(t.param, err)
+			}
+			*t.target = &value
+		}
+	}
+	return true
+}
+
+// parseAttemptSort reads a comma-separated sort such as "-percentage,student_name", where a
+// leading "-" sorts that key descending
+func parseAttemptSort(raw string) []repositories.AttemptSort {
+	var sorts []repositories.AttemptSort
+	for _, key := range strings.Split(raw, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		sort := repositories.AttemptSort{Field: key}
+		if strings.HasPrefix(key, "-") {
+			sort = repositories.AttemptSort{Field: strings.TrimPrefix(key, "-"), Desc: true}
+		}
+		sorts = append(sorts, sort)
+	}
+	return sorts
+}
+
+// newAttemptPage wraps attempts in the pagination envelope. A full page carries a cursor
+// to the next one unless a custom sort is used; page numbers are only reported for offset
+// pagination.
+func newAttemptPage(attempts []*services.AttemptResponse, total int64, filters repositories.AttemptFilters) PaginatedResponse {
+	response := PaginatedResponse{
+		Items: attempts,
+		Total: total,
+		Size:  filters.Limit,
+	}
+	if filters.After == nil && filters.Limit > 0 {
+		response.Page = (filters.Offset / filters.Limit) + 1
+	}
+
+	if filters.Limit > 0 && len(attempts) == filters.Limit && len(filters.Sort) == 0 {
+		last := attempts[len(attempts)-1]
+		next := repositories.PageCursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+		response.NextCursor = &next
+	}
 
-	return filters
+	return response
 }
 
 func (h *AttemptHandler) handleServiceError(c *gin.Context, err error) {
@@ -850,6 +2285,11 @@ func (h *AttemptHandler) handleServiceError(c *gin.Context, err error) {
 		c.JSON(http.StatusConflict, ErrorResponse{
 			Message: "Cannot start new attempt",
 		})
+	case errors.Is(err, services.ErrAutosavesUnavailable):
+		c.Header("Retry-After", "5")
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Message: "Autosaved answers are temporarily unavailable; try again shortly",
+		})
 	// Assessment related errors
 	case errors.Is(err, services.ErrAssessmentNotFound):
 		c.JSON(http.StatusNotFound, ErrorResponse{
@@ -889,6 +2329,10 @@ func (h *AttemptHandler) handleServiceError(c *gin.Context, err error) {
 		c.JSON(http.StatusNotFound, ErrorResponse{
 			Message: "User not found",
 		})
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Resource not found",
+		})
 	default:
 		h.LogError(c, err, "Unexpected service error")
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// attemptRepository serves one attempt of an assessment owned by the teacher
+type attemptRepository struct {
+	repositories.Repository
+	attempt  *models.AssessmentAttempt
+	settings *models.AssessmentSettings
+}
+
+func (r *attemptRepository) Attempt() repositories.AttemptRepository {
+	return attemptStore{attempt: r.attempt}
+}
+func (r *attemptRepository) Assessment() repositories.AssessmentRepository {
+	return assessmentStore{}
+}
+func (r *attemptRepository) AssessmentSettings() repositories.AssessmentSettingsRepository {
+	return settingsStore{settings: r.settings}
+}
+func (r *attemptRepository) AnswerAnnotation() repositories.AnswerAnnotationRepository {
+	return annotationStore{}
+}
+func (r *attemptRepository) User() repositories.UserRepository { return userStore{} }
+
+type attemptStore struct {
+	repositories.AttemptRepository
+	attempt *models.AssessmentAttempt
+}
+
+func (s attemptStore) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AssessmentAttempt, error) {
+	attempt := *s.attempt
+	return &attempt, nil
+}
+
+type assessmentStore struct {
+	repositories.AssessmentRepository
+}
+
+func (s assessmentStore) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.Assessment, error) {
+	return &models.Assessment{ID: id, CreatedBy: "teacher-1", Status: models.StatusActive}, nil
+}
+
+type settingsStore struct {
+	repositories.AssessmentSettingsRepository
+	settings *models.AssessmentSettings
+}
+
+func (s settingsStore) GetByAssessmentID(ctx context.Context, tx *gorm.DB, assessmentID uint) (*models.AssessmentSettings, error) {
+	if s.settings == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return s.settings, nil
+}
+
+type annotationStore struct {
+	repositories.AnswerAnnotationRepository
+}
+
+func (annotationStore) GetByAttempt(ctx context.Context, tx *gorm.DB, attemptID uint) ([]*models.AnswerAnnotation, error) {
+	return nil, nil
+}
+
+type userStore struct {
+	repositories.UserRepository
+}
+
+func (userStore) GetByID(ctx context.Context, id string) (*models.User, error) {
+	role := models.RoleTeacher
+	if strings.HasPrefix(id, "student") {
+		role = models.RoleStudent
+	}
+	return &models.User{ID: id, Role: role}, nil
+}
+
+func getAttemptAs(t *testing.T, repo *attemptRepository, userID string) map[string]interface{} {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	logger := slog.New(slog.DiscardHandler)
+	attemptService := services.NewAttemptService(repo, nil, logger, nil, 0, nil, nil)
+	handler := NewAttemptHandler(attemptService, nil, utils.NewSlogLogger(logger))
+
+	router := gin.New()
+	router.GET("/attempts/:id", func(c *gin.Context) {
+		c.Set("user_id", userID)
+		handler.GetAttempt(c)
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/attempts/7", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /attempts/7 as %s: status %d: %s", userID, recorder.Code, recorder.Body.String())
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return body
+}
+
+func TestGetAttemptHidesStudentWhileGradingAnonymously(t *testing.T) {
+	ip := "10.0.0.8"
+	repo := &attemptRepository{
+		attempt: &models.AssessmentAttempt{
+			ID:           7,
+			AssessmentID: 3,
+			StudentID:    "student-1",
+			Status:       models.AttemptCompleted,
+			IPAddress:    &ip,
+			Student:      models.User{ID: "student-1", FullName: "Ada Lovelace", Email: "ada@example.com"},
+		},
+		settings: &models.AssessmentSettings{AssessmentID: 3, AnonymousGrading: true, AnonymousGradingSalt: "salt"},
+	}
+
+	body := getAttemptAs(t, repo, "teacher-1")
+	if body["student_id"] != "" || body["ip_address"] != nil {
+		t.Errorf("teacher saw student_id %v and ip_address %v while grading anonymously", body["student_id"], body["ip_address"])
+	}
+	student, _ := body["student"].(map[string]interface{})
+	if name, _ := student["full_name"].(string); !strings.HasPrefix(name, "Candidate ") || student["email"] != "" {
+		t.Errorf("teacher saw student %v, want only the candidate pseudonym", student)
+	}
+
+	// The student still sees their own attempt as it is
+	body = getAttemptAs(t, repo, "student-1")
+	if body["student_id"] != "student-1" {
+		t.Errorf("student saw student_id %v on their own attempt", body["student_id"])
+	}
+
+	// Without anonymous grading the teacher sees who took it
+	repo.settings = nil
+	body = getAttemptAs(t, repo, "teacher-1")
+	if body["student_id"] != "student-1" {
+		t.Errorf("teacher saw student_id %v without anonymous grading", body["student_id"])
+	}
+}
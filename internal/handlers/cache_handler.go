@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type CacheHandler struct {
+	BaseHandler
+	client *cache.Client // nil when Redis is not configured
+}
+
+func NewCacheHandler(client *cache.Client, logger utils.Logger) *CacheHandler {
+	return &CacheHandler{
+		BaseHandler: NewBaseHandler(logger),
+		client:      client,
+	}
+}
+
+// CacheSettingsResponse is the cache configuration in effect
+type CacheSettingsResponse struct {
+	Available bool `json:"available"` // Whether Redis is reachable
+	cache.SettingsUpdate
+}
+
+// FlushCacheRequest lists the key families to flush
+type FlushCacheRequest struct {
+	Families []string `json:"families" binding:"required,min=1"`
+}
+
+// GetCacheSettings shows the cache TTLs and which repositories use the cache
+// @Summary Get cache settings
+// @Description TTL in seconds of each key family, and whether each repository reads and writes the cache. Families: assessment, exists, fast, question, stats, user. Repositories: answer, assessment, assessment_question, attempt, question, user.
+// @Tags cache
+// @Produce json
+// @Success 200 {object} CacheSettingsResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /cache/settings [get]
+func (h *CacheHandler) GetCacheSettings(c *gin.Context) {
+	h.LogRequest(c, "Getting cache settings")
+
+	if h.client == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Cache is not configured",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, CacheSettingsResponse{
+		Available:      h.client.Available(),
+		SettingsUpdate: h.client.Settings().Snapshot(),
+	})
+}
+
+// UpdateCacheSettings tunes the cache without a redeploy
+// @Summary Update cache settings
+// @Description Changes the TTLs of the listed key families and turns caching on or off for the listed repositories; the rest keep their settings. Keys already cached keep their old TTL. Every instance picks the change up at its next Redis health check, and it outlives restarts.
+// @Tags cache
+// @Accept json
+// @Produce json
+// @Param request body cache.SettingsUpdate true "TTLs in seconds and repository switches"
+// @Success 200 {object} CacheSettingsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /cache/settings [put]
+func (h *CacheHandler) UpdateCacheSettings(c *gin.Context) {
+	h.LogRequest(c, "Updating cache settings")
+
+	if h.client == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Cache is not configured",
+		})
+		return
+	}
+
+	var req cache.SettingsUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	settings, err := h.client.UpdateSettings(c.Request.Context(), req)
+	if err != nil {
+		// Applied here, but the other instances could not be told
+		h.LogError(c, err, "Failed to share cache settings")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Cache settings changed on this instance only",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, CacheSettingsResponse{
+		Available:      h.client.Available(),
+		SettingsUpdate: settings,
+	})
+}
+
+// FlushCache deletes the keys of some key families
+// @Summary Flush cache families
+// @Description Deletes every key of the listed families in every region, e.g. ["fast", "exists"] after turning down their TTLs. Reads fall through to the database until the keys are cached again.
+// @Tags cache
+// @Accept json
+// @Produce json
+// @Param request body FlushCacheRequest true "Key families to flush"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /cache/flush [post]
+func (h *CacheHandler) FlushCache(c *gin.Context) {
+	h.LogRequest(c, "Flushing cache")
+
+	if h.client == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Cache is not configured",
+		})
+		return
+	}
+
+	var req FlushCacheRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+	for _, family := range req.Families {
+		if !cache.ValidFamily(family) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Unknown cache family",
+				Details: family,
+			})
+			return
+		}
+	}
+
+	deleted, err := h.client.FlushFamilies(c.Request.Context(), req.Families)
+	if err != nil {
+		if errors.Is(err, cache.ErrCacheNotAvailable) {
+			c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+				Message: "Cache is unavailable",
+			})
+			return
+		}
+		h.LogError(c, err, "Failed to flush cache")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Failed to flush cache",
+			Details: map[string]interface{}{"deleted": deleted},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Cache flushed",
+		Data:    map[string]interface{}{"families": req.Families, "deleted": deleted},
+	})
+}
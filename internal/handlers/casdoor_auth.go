@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,17 +15,24 @@ import (
 	"github.com/SAP-F-2025/assessment-service/internal/config"
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/residency"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
 )
 
+// Header carrying the token of an admin's impersonation session
+const impersonationHeader = "X-Impersonation-Token"
+
 // CasdoorAuthMiddleware provides authentication using Casdoor SDK
 type CasdoorAuthMiddleware struct {
-	client   *casdoorsdk.Client
-	userRepo repositories.UserRepository
-	config   config.CasdoorConfig
+	client        *casdoorsdk.Client
+	userRepo      repositories.UserRepository
+	impersonation services.ImpersonationService
+	residency     services.ResidencyService
+	config        config.CasdoorConfig
 }
 
 // NewCasdoorAuthMiddleware creates a new Casdoor authentication middleware
-func NewCasdoorAuthMiddleware(cfg config.CasdoorConfig, userRepo repositories.UserRepository) *CasdoorAuthMiddleware {
+func NewCasdoorAuthMiddleware(cfg config.CasdoorConfig, userRepo repositories.UserRepository, impersonation services.ImpersonationService, residency services.ResidencyService) *CasdoorAuthMiddleware {
 	client := casdoorsdk.NewClient(
 		cfg.Endpoint,
 		cfg.ClientID,
@@ -34,9 +43,11 @@ func NewCasdoorAuthMiddleware(cfg config.CasdoorConfig, userRepo repositories.Us
 	)
 
 	return &CasdoorAuthMiddleware{
-		client:   client,
-		userRepo: userRepo,
-		config:   cfg,
+		client:        client,
+		userRepo:      userRepo,
+		impersonation: impersonation,
+		residency:     residency,
+		config:        cfg,
 	}
 }
 
@@ -95,11 +106,103 @@ func (cam *CasdoorAuthMiddleware) AuthMiddleware() gin.HandlerFunc {
 		c.Set("user_role", user.Role)
 		c.Set("user_email", user.Email)
 
+		// Storage is routed to the region of the user's organization
+		if !cam.routeToRegion(c, user) {
+			return
+		}
+
+		// Admins may act as another user through an impersonation session. The session is
+		// kept in the admin's region; the request itself runs in the target user's.
+		if token := c.GetHeader(impersonationHeader); token != "" {
+			if !cam.impersonate(c, user, token) {
+				return
+			}
+			if target, err := GetUserFromContext(c); err == nil && target.ID != user.ID {
+				if !cam.routeToRegion(c, target) {
+					return
+				}
+			}
+		}
+
 		// Continue with the request
 		c.Next()
 	}
 }
 
+// impersonate switches the request to the user behind an impersonation session. Every
+// request, including refused writes, is audit-logged before it runs; if it cannot be
+// logged it is refused. Returns false when the request was aborted.
+func (cam *CasdoorAuthMiddleware) impersonate(c *gin.Context, admin *models.User, token string) bool {
+	ctx := c.Request.Context()
+	session, target, err := cam.impersonation.Authorize(ctx, token, admin, c.Request.Method)
+
+	if session != nil {
+		requestID := c.GetString("request_id")
+		record := &services.ImpersonatedRequest{
+			Method:    c.Request.Method,
+			Path:      c.FullPath(),
+			Blocked:   errors.Is(err, services.ErrImpersonationReadOnly),
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			RequestID: &requestID,
+		}
+		if auditErr := cam.impersonation.RecordRequest(ctx, session, admin, record); auditErr != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error":   "impersonation_unavailable",
+				"message": "impersonated request could not be audit-logged",
+			})
+			c.Abort()
+			return false
+		}
+	}
+
+	if err != nil {
+		status := http.StatusForbidden
+		if !errors.Is(err, services.ErrImpersonationReadOnly) && !errors.Is(err, services.ErrImpersonationInvalid) {
+			status = http.StatusInternalServerError
+		}
+		c.JSON(status, gin.H{
+			"error":   "forbidden",
+			"message": err.Error(),
+		})
+		c.Abort()
+		return false
+	}
+
+	// Handlers and permission checks see the impersonated user; the admin stays on record
+	c.Set("user_id", target.ID)
+	c.Set("user", target)
+	c.Set("user_role", target.Role)
+	c.Set("user_email", target.Email)
+	c.Set("impersonator", admin)
+	c.Set("impersonation_session_id", session.ID)
+
+	c.Header("X-Impersonated-By", admin.ID)
+	c.Header("X-Impersonation-Session", strconv.FormatUint(uint64(session.ID), 10))
+	return true
+}
+
+// routeToRegion routes the request's storage to the region of the user's organization.
+// Returns false when the request was aborted because the region is unavailable.
+func (cam *CasdoorAuthMiddleware) routeToRegion(c *gin.Context, user *models.User) bool {
+	ctx := residency.WithRegion(c.Request.Context(), "")
+	region, err := cam.residency.ResolveRegion(ctx, user)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, services.ErrResidencyUnavailable) {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, gin.H{
+			"error":   "residency_unavailable",
+			"message": "the data residency region of your organization is unavailable",
+		})
+		c.Abort()
+		return false
+	}
+	c.Request = c.Request.WithContext(residency.WithRegion(c.Request.Context(), region))
+	return true
+}
+
 // OptionalAuthMiddleware provides optional authentication (user info if token present)
 func (cam *CasdoorAuthMiddleware) OptionalAuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -133,6 +236,9 @@ func (cam *CasdoorAuthMiddleware) OptionalAuthMiddleware() gin.HandlerFunc {
 			c.Set("user", user)
 			c.Set("user_role", user.Role)
 			c.Set("user_email", user.Email)
+			if !cam.routeToRegion(c, user) {
+				return
+			}
 		}
 
 		c.Next()
@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
 	"github.com/SAP-F-2025/assessment-service/internal/utils"
 	"github.com/gin-gonic/gin"
 )
@@ -23,6 +26,35 @@ type SuccessResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// PaginatedResponse is the envelope for paginated listings. Clients page either with
+// page/size or by sending next_cursor back as the cursor query parameter.
+type PaginatedResponse struct {
+	Items      interface{} `json:"items"`
+	Total      int64       `json:"total"`
+	Page       int         `json:"page,omitempty"` // Omitted for cursor pages
+	Size       int         `json:"size"`
+	NextCursor *string     `json:"next_cursor"` // Nil on the last page
+}
+
+// parseCursorQuery decodes the cursor query parameter; it answers 400 on a malformed cursor.
+// No cursor yields nil.
+func parseCursorQuery(c *gin.Context) (*repositories.PageCursor, bool) {
+	token := strings.TrimSpace(c.Query("cursor"))
+	if token == "" {
+		return nil, true
+	}
+
+	cursor, err := repositories.DecodePageCursor(token)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid cursor",
+			Details: err.Error(),
+		})
+		return nil, false
+	}
+	return cursor, true
+}
+
 // ValidationErrorResponse represents validation error details
 type ValidationErrorResponse struct {
 	Field   string `json:"field"`
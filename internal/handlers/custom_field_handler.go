@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type CustomFieldHandler struct {
+	BaseHandler
+	customFieldService services.CustomFieldService
+}
+
+func NewCustomFieldHandler(
+	customFieldService services.CustomFieldService,
+	logger utils.Logger,
+) *CustomFieldHandler {
+	return &CustomFieldHandler{
+		BaseHandler:        NewBaseHandler(logger),
+		customFieldService: customFieldService,
+	}
+}
+
+// CreateDefinition adds a custom field to an organization's assessments or attempts
+// @Summary Create custom field
+// @Description Defines a field the organization records on its assessments or attempts. Values are kept with the record, so no migration is needed. Only assessment fields can be required.
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Param field body services.CreateCustomFieldRequest true "Field definition"
+// @Success 201 {object} models.CustomFieldDefinition
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /custom-fields [post]
+func (h *CustomFieldHandler) CreateDefinition(c *gin.Context) {
+	h.LogRequest(c, "Creating custom field")
+
+	var req services.CreateCustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	definition, err := h.customFieldService.CreateDefinition(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, definition)
+}
+
+// ListDefinitions lists an organization's custom fields
+// @Summary List custom fields
+// @Description Lists the custom fields of an organization, optionally of assessments or attempts only
+// @Tags custom-fields
+// @Produce json
+// @Param organization query string true "Organization"
+// @Param entity query string false "assessment or attempt"
+// @Success 200 {array} models.CustomFieldDefinition
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /custom-fields [get]
+func (h *CustomFieldHandler) ListDefinitions(c *gin.Context) {
+	h.LogRequest(c, "Listing custom fields")
+
+	var entity *models.CustomFieldEntity
+	if value := c.Query("entity"); value != "" {
+		fieldEntity := models.CustomFieldEntity(value)
+		switch fieldEntity {
+		case models.CustomFieldEntityAssessment, models.CustomFieldEntityAttempt:
+			entity = &fieldEntity
+		default:
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid entity",
+				Details: value,
+			})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	definitions, err := h.customFieldService.ListDefinitions(c.Request.Context(), c.Query("organization"), entity, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, definitions)
+}
+
+// UpdateDefinition changes a custom field's label, options, limit or whether it is required
+// @Summary Update custom field
+// @Description Updates a custom field. Its organization, entity, key and type cannot change. Values already recorded are checked again when the record's custom fields next change.
+// @Tags custom-fields
+// @Accept json
+// @Produce json
+// @Param id path uint true "Custom field ID"
+// @Param field body services.UpdateCustomFieldRequest true "Fields to change"
+// @Success 200 {object} models.CustomFieldDefinition
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /custom-fields/{id} [put]
+func (h *CustomFieldHandler) UpdateDefinition(c *gin.Context) {
+	definitionID := h.parseIDParam(c, "id")
+	if definitionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Updating custom field", "custom_field_id", definitionID)
+
+	var req services.UpdateCustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	definition, err := h.customFieldService.UpdateDefinition(c.Request.Context(), definitionID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, definition)
+}
+
+// DeleteDefinition removes a custom field
+// @Summary Delete custom field
+// @Description Deletes a custom field. Values already recorded stay on the records but are no longer exported or accepted.
+// @Tags custom-fields
+// @Param id path uint true "Custom field ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /custom-fields/{id} [delete]
+func (h *CustomFieldHandler) DeleteDefinition(c *gin.Context) {
+	definitionID := h.parseIDParam(c, "id")
+	if definitionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Deleting custom field", "custom_field_id", definitionID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.customFieldService.DeleteDefinition(c.Request.Context(), definitionID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Helper methods
+
+func (h *CustomFieldHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *CustomFieldHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Custom field not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
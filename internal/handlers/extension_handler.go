@@ -0,0 +1,553 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Largest accepted supporting document for an extension request
+const maxExtensionAttachmentSize = 10 << 20
+
+type ExtensionHandler struct {
+	BaseHandler
+	extensionService services.ExtensionService
+}
+
+func NewExtensionHandler(
+	extensionService services.ExtensionService,
+	logger utils.Logger,
+) *ExtensionHandler {
+	return &ExtensionHandler{
+		BaseHandler:      NewBaseHandler(logger),
+		extensionService: extensionService,
+	}
+}
+
+// RequestExtension asks the assessment's teacher for a later due date or an extra attempt
+// @Summary Request an extension
+// @Description Students ask for a later due date (kind deadline, with requested_due_date) or one more attempt (kind extra_attempt). Send JSON, or a multipart form with a file field to attach a supporting document. The teacher is notified.
+// @Tags extensions
+// @Accept json,multipart/form-data
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param request body services.CreateExtensionRequest true "Kind, reason and requested due date"
+// @Param file formData file false "PDF, JPEG or PNG supporting document"
+// @Success 201 {object} models.ExtensionRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/extension-requests [post]
+func (h *ExtensionHandler) RequestExtension(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Requesting extension", "assessment_id", assessmentID)
+
+	var req services.CreateExtensionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	var attachment *services.ExtensionAttachment
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		fileHeader, err := c.FormFile("file")
+		if err != nil && !errors.Is(err, http.ErrMissingFile) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid request payload",
+				Details: err.Error(),
+			})
+			return
+		}
+		if fileHeader != nil {
+			if fileHeader.Size > maxExtensionAttachmentSize {
+				c.JSON(http.StatusBadRequest, ErrorResponse{
+					Message: "File too large",
+					Details: map[string]interface{}{
+						"max_size": maxExtensionAttachmentSize,
+					},
+				})
+				return
+			}
+
+			file, err := fileHeader.Open()
+			if err != nil {
+				c.JSON(http.StatusBadRequest, ErrorResponse{
+					Message: "Invalid request payload",
+					Details: err.Error(),
+				})
+				return
+			}
+			defer file.Close()
+			attachment = &services.ExtensionAttachment{File: file, FileName: fileHeader.Filename}
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	request, err := h.extensionService.RequestExtension(c.Request.Context(), assessmentID, &req, attachment, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, request)
+}
+
+// ListMyRequests lists the current student's extension requests
+// @Summary List my extension requests
+// @Description Lists the student's extension requests with their status and the teacher's decision note
+// @Tags extensions
+// @Produce json
+// @Success 200 {array} models.ExtensionRequest
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /extension-requests/mine [get]
+func (h *ExtensionHandler) ListMyRequests(c *gin.Context) {
+	h.LogRequest(c, "Listing own extension requests")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	requests, err := h.extensionService.ListMyRequests(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// WithdrawRequest cancels a pending extension request
+// @Summary Withdraw extension request
+// @Description Students withdraw their own request while it waits for a decision
+// @Tags extensions
+// @Produce json
+// @Param id path uint true "Extension request ID"
+// @Success 200 {object} models.ExtensionRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /extension-requests/{id}/withdraw [post]
+func (h *ExtensionHandler) WithdrawRequest(c *gin.Context) {
+	requestID := h.parseIDParam(c, "id")
+	if requestID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Withdrawing extension request", "request_id", requestID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	request, err := h.extensionService.Withdraw(c.Request.Context(), requestID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// GetQueue lists extension requests waiting for the current teacher
+// @Summary Get extension request queue
+// @Description Lists requests for the teacher's assessments, or all assessments for admins, oldest first
+// @Tags extensions
+// @Produce json
+// @Param assessment_id query uint false "Limit to one assessment"
+// @Param status query string false "pending (default), approved, denied or withdrawn"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size, at most 100" default(20)
+// @Success 200 {object} services.ExtensionRequestListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /extension-requests [get]
+func (h *ExtensionHandler) GetQueue(c *gin.Context) {
+	h.LogRequest(c, "Getting extension request queue")
+
+	filter := services.ExtensionQueueFilter{
+		Page: h.parseIntQuery(c, "page", 1),
+		Size: h.parseIntQuery(c, "size", 20),
+	}
+
+	if value := c.Query("assessment_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || id == 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid assessment_id",
+				Details: "assessment_id must be a positive integer",
+			})
+			return
+		}
+		parsed := uint(id)
+		filter.AssessmentID = &parsed
+	}
+
+	switch status := models.ExtensionRequestStatus(c.Query("status")); status {
+	case "", models.ExtensionPending, models.ExtensionApproved, models.ExtensionDenied, models.ExtensionWithdrawn:
+		filter.Status = status
+	default:
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid status",
+			Details: "status must be pending, approved, denied or withdrawn",
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	queue, err := h.extensionService.GetQueue(c.Request.Context(), &filter, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// GetRequest returns an extension request
+// @Summary Get extension request
+// @Description Returns a request to the student who made it, the assessment's teacher and admins
+// @Tags extensions
+// @Produce json
+// @Param id path uint true "Extension request ID"
+// @Success 200 {object} models.ExtensionRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /extension-requests/{id} [get]
+func (h *ExtensionHandler) GetRequest(c *gin.Context) {
+	requestID := h.parseIDParam(c, "id")
+	if requestID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting extension request", "request_id", requestID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	request, err := h.extensionService.GetRequest(c.Request.Context(), requestID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// GetAttachment serves the supporting document of an extension request
+// @Summary Get extension request attachment
+// @Description Returns the supporting document to the student who made the request, the assessment's teacher and admins
+// @Tags extensions
+// @Produce application/pdf,image/jpeg,image/png
+// @Param id path uint true "Extension request ID"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /extension-requests/{id}/attachment [get]
+func (h *ExtensionHandler) GetAttachment(c *gin.Context) {
+	requestID := h.parseIDParam(c, "id")
+	if requestID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting extension request attachment", "request_id", requestID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	request, err := h.extensionService.GetAttachment(c.Request.Context(), requestID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", *request.AttachmentType)
+	c.FileAttachment(*request.AttachmentPath, *request.AttachmentName)
+}
+
+// ApproveRequest grants an extension request
+// @Summary Approve extension request
+// @Description Grants the request as an accommodation for the student: the requested due date, or another one given here, or one extra attempt. The student is notified.
+// @Tags extensions
+// @Accept json
+// @Produce json
+// @Param id path uint true "Extension request ID"
+// @Param decision body services.ApproveExtensionRequest false "Due date to grant instead of the requested one, and a note"
+// @Success 200 {object} models.ExtensionRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /extension-requests/{id}/approve [post]
+func (h *ExtensionHandler) ApproveRequest(c *gin.Context) {
+	requestID := h.parseIDParam(c, "id")
+	if requestID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Approving extension request", "request_id", requestID)
+
+	var req services.ApproveExtensionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid request payload",
+				Details: err.Error(),
+			})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	request, err := h.extensionService.Approve(c.Request.Context(), requestID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// DenyRequest refuses an extension request
+// @Summary Deny extension request
+// @Description Refuses the request with a note explaining why. The student is notified.
+// @Tags extensions
+// @Accept json
+// @Produce json
+// @Param id path uint true "Extension request ID"
+// @Param decision body services.DenyExtensionRequest true "Note for the student"
+// @Success 200 {object} models.ExtensionRequest
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /extension-requests/{id}/deny [post]
+func (h *ExtensionHandler) DenyRequest(c *gin.Context) {
+	requestID := h.parseIDParam(c, "id")
+	if requestID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Denying extension request", "request_id", requestID)
+
+	var req services.DenyExtensionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	request, err := h.extensionService.Deny(c.Request.Context(), requestID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// ListAccommodations lists the accommodations granted for an assessment
+// @Summary List accommodations
+// @Description Lists the later due dates and extra attempts granted to individual students, with the request each came from
+// @Tags extensions
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {array} models.StudentAccommodation
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/accommodations [get]
+func (h *ExtensionHandler) ListAccommodations(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Listing accommodations", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	accommodations, err := h.extensionService.ListAccommodations(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, accommodations)
+}
+
+// Helper methods
+
+func (h *ExtensionHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *ExtensionHandler) parseIntQuery(c *gin.Context, param string, defaultValue int) int {
+	valueStr := c.Query(param)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func (h *ExtensionHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var validationError *services.ValidationError
+	if errors.As(err, &validationError) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationError,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrAssessmentNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Assessment not found",
+		})
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Extension request not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
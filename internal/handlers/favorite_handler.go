@@ -0,0 +1,417 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type FavoriteHandler struct {
+	BaseHandler
+	favoriteService services.FavoriteService
+	validator       *validator.Validator
+}
+
+func NewFavoriteHandler(
+	favoriteService services.FavoriteService,
+	validator *validator.Validator,
+	logger utils.Logger,
+) *FavoriteHandler {
+	return &FavoriteHandler{
+		BaseHandler:     NewBaseHandler(logger),
+		favoriteService: favoriteService,
+		validator:       validator,
+	}
+}
+
+// AddFavorite stars a question or assessment for the current user
+// @Summary Add favorite
+// @Description Stars a question or assessment, optionally with a note
+// @Tags favorites
+// @Accept json
+// @Produce json
+// @Param item_type path string true "Item type (question or assessment)"
+// @Param item_id path uint true "Item ID"
+// @Param request body services.AddFavoriteRequest false "Favorite note"
+// @Success 201 {object} models.Favorite
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /favorites/items/{item_type}/{item_id} [post]
+func (h *FavoriteHandler) AddFavorite(c *gin.Context) {
+	itemID := h.parseIDParam(c, "item_id")
+	if itemID == 0 {
+		return
+	}
+	itemType := models.FavoriteItemType(c.Param("item_type"))
+
+	h.LogRequest(c, "Adding favorite", "item_type", itemType, "item_id", itemID)
+
+	var req services.AddFavoriteRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid request payload",
+				Details: err.Error(),
+			})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	favorite, err := h.favoriteService.AddFavorite(c.Request.Context(), itemType, itemID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, favorite)
+}
+
+// RemoveFavorite unstars a question or assessment
+// @Summary Remove favorite
+// @Description Removes a question or assessment from the current user's favorites
+// @Tags favorites
+// @Param item_type path string true "Item type (question or assessment)"
+// @Param item_id path uint true "Item ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /favorites/items/{item_type}/{item_id} [delete]
+func (h *FavoriteHandler) RemoveFavorite(c *gin.Context) {
+	itemID := h.parseIDParam(c, "item_id")
+	if itemID == 0 {
+		return
+	}
+	itemType := models.FavoriteItemType(c.Param("item_type"))
+
+	h.LogRequest(c, "Removing favorite", "item_type", itemType, "item_id", itemID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.favoriteService.RemoveFavorite(c.Request.Context(), itemType, itemID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListFavorites lists the current user's starred items
+// @Summary List favorites
+// @Description Lists the current user's starred questions and assessments, newest first
+// @Tags favorites
+// @Produce json
+// @Param type query string false "Item type filter (question or assessment)"
+// @Success 200 {object} services.FavoriteList
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /favorites [get]
+func (h *FavoriteHandler) ListFavorites(c *gin.Context) {
+	h.LogRequest(c, "Listing favorites")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	list, err := h.favoriteService.ListFavorites(c.Request.Context(), h.parseItemTypeQuery(c), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// ShareFavorites shares the current user's favorites with a colleague
+// @Summary Share favorites
+// @Description Lets another teacher browse the current user's favorites
+// @Tags favorites
+// @Accept json
+// @Produce json
+// @Param request body services.ShareFavoritesRequest true "Colleague to share with"
+// @Success 201 {object} models.FavoriteListShare
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /favorites/shares [post]
+func (h *FavoriteHandler) ShareFavorites(c *gin.Context) {
+	h.LogRequest(c, "Sharing favorites")
+
+	var req services.ShareFavoritesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	share, err := h.favoriteService.ShareFavorites(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, share)
+}
+
+// UnshareFavorites stops sharing favorites with a colleague
+// @Summary Unshare favorites
+// @Description Revokes a colleague's access to the current user's favorites
+// @Tags favorites
+// @Param user_id path string true "Colleague user ID"
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /favorites/shares/{user_id} [delete]
+func (h *FavoriteHandler) UnshareFavorites(c *gin.Context) {
+	sharedWith := c.Param("user_id")
+
+	h.LogRequest(c, "Unsharing favorites", "shared_with", sharedWith)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.favoriteService.UnshareFavorites(c.Request.Context(), sharedWith, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListShares lists colleagues the current user shares favorites with
+// @Summary List favorite shares
+// @Description Lists colleagues who can browse the current user's favorites
+// @Tags favorites
+// @Produce json
+// @Success 200 {array} models.FavoriteListShare
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /favorites/shares [get]
+func (h *FavoriteHandler) ListShares(c *gin.Context) {
+	h.LogRequest(c, "Listing favorite shares")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	shares, err := h.favoriteService.ListShares(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shares)
+}
+
+// ListSharedWithMe lists favorite lists colleagues shared with the current user
+// @Summary List favorites shared with me
+// @Description Lists colleagues whose favorites the current user can browse
+// @Tags favorites
+// @Produce json
+// @Success 200 {array} models.FavoriteListShare
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /favorites/shared [get]
+func (h *FavoriteHandler) ListSharedWithMe(c *gin.Context) {
+	h.LogRequest(c, "Listing favorites shared with user")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	shares, err := h.favoriteService.ListSharedWithMe(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, shares)
+}
+
+// GetSharedFavorites lists a colleague's shared favorites
+// @Summary Get shared favorites
+// @Description Lists the starred items of a colleague who shared their favorites
+// @Tags favorites
+// @Produce json
+// @Param owner_id path string true "Colleague user ID"
+// @Param type query string false "Item type filter (question or assessment)"
+// @Success 200 {object} services.FavoriteList
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /favorites/shared/{owner_id} [get]
+func (h *FavoriteHandler) GetSharedFavorites(c *gin.Context) {
+	ownerID := c.Param("owner_id")
+
+	h.LogRequest(c, "Getting shared favorites", "owner_id", ownerID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	list, err := h.favoriteService.GetSharedFavorites(c.Request.Context(), ownerID, h.parseItemTypeQuery(c), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// Helper methods
+
+func (h *FavoriteHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *FavoriteHandler) parseItemTypeQuery(c *gin.Context) *models.FavoriteItemType {
+	value := c.Query("type")
+	if value == "" {
+		return nil
+	}
+	itemType := models.FavoriteItemType(value)
+	return &itemType
+}
+
+func (h *FavoriteHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var validationError *services.ValidationError
+	if errors.As(err, &validationError) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationError,
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrQuestionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Question not found",
+		})
+	case errors.Is(err, services.ErrAssessmentNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Assessment not found",
+		})
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "User not found",
+		})
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Resource not found",
+		})
+	case errors.Is(err, services.ErrUnauthorized):
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "Unauthorized access",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
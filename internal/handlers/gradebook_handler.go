@@ -0,0 +1,385 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type GradebookHandler struct {
+	BaseHandler
+	gradebookService services.GradebookService
+}
+
+func NewGradebookHandler(
+	gradebookService services.GradebookService,
+	logger utils.Logger,
+) *GradebookHandler {
+	return &GradebookHandler{
+		BaseHandler:      NewBaseHandler(logger),
+		gradebookService: gradebookService,
+	}
+}
+
+// CreateIntegration connects an assessment to an external gradebook column
+// @Summary Create gradebook integration
+// @Description Pushes released grades of the assessment to a column of a generic REST gradebook or a Google Classroom course work
+// @Tags gradebooks
+// @Accept json
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Param integration body services.CreateGradebookIntegrationRequest true "Gradebook integration and column mapping"
+// @Success 201 {object} models.GradebookIntegration
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /gradebooks/assessments/{assessment_id}/integrations [post]
+func (h *GradebookHandler) CreateIntegration(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Creating gradebook integration", "assessment_id", assessmentID)
+
+	var req services.CreateGradebookIntegrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	integration, err := h.gradebookService.CreateIntegration(c.Request.Context(), assessmentID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, integration)
+}
+
+// ListIntegrations lists an assessment's gradebook integrations
+// @Summary List gradebook integrations
+// @Description Lists the external gradebooks an assessment's grades are pushed to
+// @Tags gradebooks
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Success 200 {array} models.GradebookIntegration
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /gradebooks/assessments/{assessment_id}/integrations [get]
+func (h *GradebookHandler) ListIntegrations(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Listing gradebook integrations", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	integrations, err := h.gradebookService.ListIntegrations(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, integrations)
+}
+
+// UpdateIntegration changes a gradebook integration's connection or column mapping
+// @Summary Update gradebook integration
+// @Description Updates the connection, column mapping or enabled state of a gradebook integration
+// @Tags gradebooks
+// @Accept json
+// @Produce json
+// @Param id path uint true "Integration ID"
+// @Param integration body services.UpdateGradebookIntegrationRequest true "Fields to change"
+// @Success 200 {object} models.GradebookIntegration
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /gradebooks/integrations/{id} [put]
+func (h *GradebookHandler) UpdateIntegration(c *gin.Context) {
+	integrationID := h.parseIDParam(c, "id")
+	if integrationID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Updating gradebook integration", "integration_id", integrationID)
+
+	var req services.UpdateGradebookIntegrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	integration, err := h.gradebookService.UpdateIntegration(c.Request.Context(), integrationID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, integration)
+}
+
+// DeleteIntegration disconnects a gradebook
+// @Summary Delete gradebook integration
+// @Description Stops pushing grades to the gradebook and removes its delivery history
+// @Tags gradebooks
+// @Param id path uint true "Integration ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /gradebooks/integrations/{id} [delete]
+func (h *GradebookHandler) DeleteIntegration(c *gin.Context) {
+	integrationID := h.parseIDParam(c, "id")
+	if integrationID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Deleting gradebook integration", "integration_id", integrationID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.gradebookService.DeleteIntegration(c.Request.Context(), integrationID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListPassbacks lists grade deliveries of an assessment
+// @Summary List grade passbacks
+// @Description Lists grade deliveries to external gradebooks with their status, tries and last error; filter by dead_letter to see grades that need a resync
+// @Tags gradebooks
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Param status query string false "pending, processing, delivered or dead_letter"
+// @Success 200 {array} models.GradePassback
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /gradebooks/assessments/{assessment_id}/passbacks [get]
+func (h *GradebookHandler) ListPassbacks(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Listing grade passbacks", "assessment_id", assessmentID)
+
+	var status *models.PassbackStatus
+	if value := c.Query("status"); value != "" {
+		passbackStatus := models.PassbackStatus(value)
+		switch passbackStatus {
+		case models.PassbackPending, models.PassbackProcessing, models.PassbackDelivered, models.PassbackDeadLetter:
+			status = &passbackStatus
+		default:
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid status",
+				Details: value,
+			})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	passbacks, err := h.gradebookService.ListPassbacks(c.Request.Context(), assessmentID, status, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, passbacks)
+}
+
+// Resync pushes an assessment's grades to its gradebooks again
+// @Summary Resync gradebook grades
+// @Description Retries dead-lettered and pending grades right away and queues released grades not pushed yet; include_delivered pushes every grade again
+// @Tags gradebooks
+// @Accept json
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Param resync body services.ResyncGradesRequest false "Resync options"
+// @Success 202 {object} services.ResyncGradesResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /gradebooks/assessments/{assessment_id}/resync [post]
+func (h *GradebookHandler) Resync(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Resyncing gradebook grades", "assessment_id", assessmentID)
+
+	var req services.ResyncGradesRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid request payload",
+				Details: err.Error(),
+			})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	result, err := h.gradebookService.Resync(c.Request.Context(), assessmentID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, result)
+}
+
+// Helper methods
+
+func (h *GradebookHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *GradebookHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var validationError *services.ValidationError
+	if errors.As(err, &validationError) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationError,
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrAssessmentNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Assessment not found",
+		})
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Gradebook integration not found",
+		})
+	case errors.Is(err, services.ErrUnauthorized):
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "Unauthorized access",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
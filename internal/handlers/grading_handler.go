@@ -5,6 +5,8 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
@@ -14,6 +16,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// answerPageSize is the default page size of answer listings
+const answerPageSize = 50
+
 type GradingHandler struct {
 	BaseHandler
 	gradingService services.GradingService
@@ -95,6 +100,62 @@ func (h *GradingHandler) GradeAnswer(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 }
 
+// GradeAnswerPart grades one part of a multi-part answer manually
+// @Summary Grade answer part
+// @Description Manually grades one part of an answer to a multi-part question; the answer is graded once all its parts are
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param answer_id path uint true "Answer ID"
+// @Param part_id path string true "Part ID"
+// @Param grade body GradeAnswerRequest true "Grading data"
+// @Success 200 {object} SuccessResponse{data=services.GradingResult}
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/answers/{answer_id}/parts/{part_id} [post]
+func (h *GradingHandler) GradeAnswerPart(c *gin.Context) {
+	answerID := h.parseIDParam(c, "answer_id")
+	if answerID == 0 {
+		return
+	}
+	partID := c.Param("part_id")
+
+	h.LogRequest(c, "Grading answer part", "answer_id", answerID, "part_id", partID)
+
+	var req GradeAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	result, err := h.gradingService.GradeAnswerPart(c.Request.Context(), answerID, partID, req.Score, req.Feedback, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // GradeAttempt grades an entire attempt manually
 // @Summary Grade attempt
 // @Description Manually grades an entire assessment attempt
@@ -513,100 +574,1465 @@ func (h *GradingHandler) GetGradingOverview(c *gin.Context) {
 	c.JSON(http.StatusOK, overview)
 }
 
-// Helper methods
+// GetAnswersByGradingStatus lists an assessment's answers by grading workflow state
+// @Summary List answers by grading status
+// @Description Lists the answers of an assessment in the given grading states: ungraded, auto_graded, manually_graded, pending_regrade, regraded or overridden
+// @Tags grading
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Param grading_status query []string false "Grading states to include; repeat or comma-separate, omit for all" collectionFormat(multi)
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(50)
+// @Param cursor query string false "next_cursor of the previous page; takes precedence over page"
+// @Success 200 {object} PaginatedResponse{items=[]services.AnswerGradingState}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/assessments/{assessment_id}/answers [get]
+func (h *GradingHandler) GetAnswersByGradingStatus(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	page := h.parseIntQuery(c, "page", 1)
+	size := h.parseIntQuery(c, "size", answerPageSize)
+	filters := repositories.AnswerFilters{
+		Limit:  size,
+		Offset: (page - 1) * size,
+	}
+	cursor, ok := parseCursorQuery(c)
+	if !ok {
+		return
+	}
+	if cursor != nil {
+		filters.After = cursor
+		filters.Offset = 0
+	}
+
+	for _, value := range c.QueryArray("grading_status") {
+		for _, status := range strings.Split(value, ",") {
+			if status = strings.TrimSpace(status); status != "" {
+				filters.GradingStatuses = append(filters.GradingStatuses, models.AnswerGradingStatus(status))
+			}
+		}
+	}
+
+	h.LogRequest(c, "Listing answers by grading status", "assessment_id", assessmentID, "statuses", filters.GradingStatuses)
 
-func (h *GradingHandler) getUserID(c *gin.Context) string {
 	userID, exists := c.Get("user_id")
 	if !exists {
-		return ""
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
 	}
-	if id, ok := userID.(string); ok {
-		return id
+
+	answers, total, err := h.gradingService.GetAnswersByGradingStatus(c.Request.Context(), assessmentID, filters, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
 	}
-	return ""
+
+	c.JSON(http.StatusOK, newAnswerPage(answers, total, filters))
 }
 
-func (h *GradingHandler) parseIDParam(c *gin.Context, param string) uint {
-	idStr := c.Param(param)
-	id, err := strconv.ParseUint(idStr, 10, 32)
-	if err != nil {
+// SampleAnswersForReview samples graded answers for second-reviewer moderation
+// @Summary Sample answers for review
+// @Description Samples N manually graded answers per grader for a second reviewer
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Param sample body services.CreateReviewSampleRequest true "Sampling options"
+// @Success 201 {object} services.ReviewSampleResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/assessments/{assessment_id}/review-samples [post]
+func (h *GradingHandler) SampleAnswersForReview(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Sampling answers for review", "assessment_id", assessmentID)
+
+	var req services.CreateReviewSampleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Message: "Invalid " + param,
+			Message: "Invalid request payload",
 			Details: err.Error(),
 		})
-		return 0
+		return
 	}
-	return uint(id)
-}
 
-func (h *GradingHandler) handleServiceError(c *gin.Context, err error) {
-	// Handle custom error types first
-	var validationErrors services.ValidationErrors
-	if errors.As(err, &validationErrors) {
+	if err := h.validator.Validate(&req); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Message: "Validation failed",
-			Details: validationErrors,
+			Details: err.Error(),
 		})
 		return
 	}
 
-	var businessRuleError *services.BusinessRuleError
-	if errors.As(err, &businessRuleError) {
-		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
-			Message: businessRuleError.Message,
-			Details: map[string]interface{}{
-				"rule":    businessRuleError.Rule,
-				"context": businessRuleError.Context,
-			},
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
 		})
 		return
 	}
+	result, err := h.gradingService.SampleAnswersForReview(c.Request.Context(), assessmentID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
 
-	var permissionError *services.PermissionError
-	if errors.As(err, &permissionError) {
-		c.JSON(http.StatusForbidden, ErrorResponse{
-			Message: "Access denied",
-			Details: map[string]interface{}{
-				"resource": permissionError.Resource,
-				"action":   permissionError.Action,
-				"reason":   permissionError.Reason,
-			},
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetPendingReviews lists review samples assigned to the current user
+// @Summary Get pending reviews
+// @Description Lists moderation review samples awaiting the current reviewer
+// @Tags grading
+// @Produce json
+// @Success 200 {array} models.AnswerReview
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/reviews/pending [get]
+func (h *GradingHandler) GetPendingReviews(c *gin.Context) {
+	h.LogRequest(c, "Getting pending reviews")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
 		})
 		return
 	}
+	reviews, err := h.gradingService.GetPendingReviews(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
 
-	// Handle specific grading errors
-	switch {
-	case errors.Is(err, services.ErrGradingNotAllowed):
-		c.JSON(http.StatusForbidden, ErrorResponse{
-			Message: "Grading not allowed for this question type",
-		})
-	case errors.Is(err, services.ErrGradingAlreadyCompleted):
-		c.JSON(http.StatusConflict, ErrorResponse{
-			Message: "Answer already graded",
+	c.JSON(http.StatusOK, reviews)
+}
+
+// SubmitAnswerReview records the second reviewer's score for a sampled answer
+// @Summary Submit answer review
+// @Description Records the reviewer's score and whether it agrees with the original grade
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param review_id path uint true "Review ID"
+// @Param review body services.SubmitAnswerReviewRequest true "Review data"
+// @Success 200 {object} models.AnswerReview
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/reviews/{review_id} [post]
+func (h *GradingHandler) SubmitAnswerReview(c *gin.Context) {
+	reviewID := h.parseIDParam(c, "review_id")
+	if reviewID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Submitting answer review", "review_id", reviewID)
+
+	var req services.SubmitAnswerReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
 		})
-	case errors.Is(err, services.ErrGradingInvalidScore):
+		return
+	}
+
+	if err := h.validator.Validate(&req); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Message: "Invalid score value",
+			Message: "Validation failed",
+			Details: err.Error(),
 		})
-	case errors.Is(err, services.ErrGradingPermissionDenied):
-		c.JSON(http.StatusForbidden, ErrorResponse{
-			Message: "Permission denied for grading",
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
 		})
-	// Related entity errors
-	case errors.Is(err, services.ErrAttemptNotFound):
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Message: "Attempt not found",
+		return
+	}
+	review, err := h.gradingService.SubmitAnswerReview(c.Request.Context(), reviewID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// GetGraderReliabilityReport gets the grader reliability report for an assessment
+// @Summary Get grader reliability report
+// @Description Reports second-reviewer agreement per grader for moderation evidence
+// @Tags grading
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Success 200 {object} services.GraderReliabilityReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/assessments/{assessment_id}/reliability [get]
+func (h *GradingHandler) GetGraderReliabilityReport(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting grader reliability report", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
 		})
-	case errors.Is(err, services.ErrQuestionNotFound):
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Message: "Question not found",
+		return
+	}
+	report, err := h.gradingService.GetGraderReliabilityReport(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetGraderStats summarizes a grader's workload and agreement with second markers
+// @Summary Get grader statistics
+// @Description Reports answers graded, average score given, grading time, backlog and second-marker agreement for one grader
+// @Tags grading
+// @Produce json
+// @Param grader_id path string true "Grader user ID"
+// @Param assessment_id query uint false "Limit to one assessment"
+// @Param since query string false "Only grades given from this time (RFC3339)"
+// @Success 200 {object} services.GraderStats
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/graders/{grader_id}/stats [get]
+func (h *GradingHandler) GetGraderStats(c *gin.Context) {
+	graderID := c.Param("grader_id")
+	if graderID == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Grader ID is required",
 		})
-	case errors.Is(err, services.ErrAssessmentNotFound):
-		c.JSON(http.StatusNotFound, ErrorResponse{
-			Message: "Assessment not found",
+		return
+	}
+
+	var assessmentID *uint
+	if value := c.Query("assessment_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || id == 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid assessment_id",
+				Details: "assessment_id must be a positive integer",
+			})
+			return
+		}
+		parsed := uint(id)
+		assessmentID = &parsed
+	}
+
+	var since *time.Time
+	if value := c.Query("since"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid since",
+				Details: "since must be an RFC3339 timestamp",
+			})
+			return
+		}
+		since = &parsed
+	}
+
+	h.LogRequest(c, "Getting grader statistics", "grader_id", graderID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	stats, err := h.gradingService.GetGraderStats(c.Request.Context(), graderID, assessmentID, since, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// SearchFeedback full-text searches the feedback the current user gave as a grader
+// @Summary Search own grading feedback
+// @Description Searches answer feedback and annotation comments written by the current user, best match first. The query accepts web search syntax: quoted phrases, or, and -excluded words.
+// @Tags grading
+// @Produce json
+// @Param q query string true "Search query, at least 2 characters"
+// @Param assessment_id query uint false "Limit to one assessment"
+// @Param question_type query string false "Limit to one question type"
+// @Param from query string false "Only feedback written from this time (RFC3339)"
+// @Param to query string false "Only feedback written before this time (RFC3339)"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size, at most 100" default(20)
+// @Success 200 {object} PaginatedResponse{items=[]repositories.FeedbackSearchHit}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/feedback/search [get]
+func (h *GradingHandler) SearchFeedback(c *gin.Context) {
+	page := h.parseIntQuery(c, "page", 1)
+	if page < 1 {
+		page = 1
+	}
+	size := h.parseIntQuery(c, "size", 20)
+	if size < 1 {
+		size = 20
+	}
+	if size > 100 {
+		size = 100
+	}
+
+	filters := repositories.FeedbackSearchFilters{
+		Query:  c.Query("q"),
+		Limit:  size,
+		Offset: (page - 1) * size,
+	}
+
+	if value := c.Query("assessment_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil || id == 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid assessment_id",
+				Details: "assessment_id must be a positive integer",
+			})
+			return
+		}
+		parsed := uint(id)
+		filters.AssessmentID = &parsed
+	}
+
+	if value := c.Query("question_type"); value != "" {
+		questionType := models.QuestionType(value)
+		filters.QuestionType = &questionType
+	}
+
+	for _, param := range []string{"from", "to"} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid " + param,
+				Details: param + " must be an RFC3339 timestamp",
+			})
+			return
+		}
+		if param == "from" {
+			filters.From = &parsed
+		} else {
+			filters.To = &parsed
+		}
+	}
+
+	h.LogRequest(c, "Searching grading feedback", "query", filters.Query)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	hits, total, err := h.gradingService.SearchFeedback(c.Request.Context(), filters, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Items: hits,
+		Total: total,
+		Page:  page,
+		Size:  size,
+	})
+}
+
+// GetPendingGrading lists answers waiting for manual grading
+// @Summary Get pending grading queue
+// @Description Lists ungraded answers of the grader's assessments; student identities are replaced by pseudonyms under anonymous grading
+// @Tags grading
+// @Produce json
+// @Success 200 {array} services.PendingGradingItem
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/pending [get]
+func (h *GradingHandler) GetPendingGrading(c *gin.Context) {
+	h.LogRequest(c, "Getting pending grading queue")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	items, err := h.gradingService.GetPendingGrading(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// GetCommentBank gets the comment bank of a question
+// @Summary Get question comment bank
+// @Description Lists curated comments and feedback graders gave repeatedly on the question, pinned entries first then by frequency
+// @Tags grading
+// @Produce json
+// @Param question_id path uint true "Question ID"
+// @Success 200 {object} services.QuestionCommentBank
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/questions/{question_id}/comments [get]
+func (h *GradingHandler) GetCommentBank(c *gin.Context) {
+	questionID := h.parseIDParam(c, "question_id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting comment bank", "question_id", questionID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	bank, err := h.gradingService.GetCommentBank(c.Request.Context(), questionID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, bank)
+}
+
+// AddFeedbackComment adds a curated entry to a question's comment bank
+// @Summary Add comment bank entry
+// @Description Adds a teacher-written comment, pins a suggested one, or hides a suggestion from the bank
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param question_id path uint true "Question ID"
+// @Param comment body services.CreateFeedbackCommentRequest true "Comment data"
+// @Success 201 {object} models.FeedbackComment
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/questions/{question_id}/comments [post]
+func (h *GradingHandler) AddFeedbackComment(c *gin.Context) {
+	questionID := h.parseIDParam(c, "question_id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Adding comment bank entry", "question_id", questionID)
+
+	var req services.CreateFeedbackCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	comment, err := h.gradingService.AddFeedbackComment(c.Request.Context(), questionID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+// UpdateFeedbackComment edits, pins or hides a comment bank entry
+// @Summary Update comment bank entry
+// @Description Edits the text of a curated comment or changes whether it is pinned or hidden
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param comment_id path uint true "Comment ID"
+// @Param comment body services.UpdateFeedbackCommentRequest true "Comment updates"
+// @Success 200 {object} models.FeedbackComment
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/comments/{comment_id} [put]
+func (h *GradingHandler) UpdateFeedbackComment(c *gin.Context) {
+	commentID := h.parseIDParam(c, "comment_id")
+	if commentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Updating comment bank entry", "comment_id", commentID)
+
+	var req services.UpdateFeedbackCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	comment, err := h.gradingService.UpdateFeedbackComment(c.Request.Context(), commentID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+// DeleteFeedbackComment removes a curated comment bank entry
+// @Summary Delete comment bank entry
+// @Description Removes a curated comment; feedback mined from grading history is hidden instead
+// @Tags grading
+// @Param comment_id path uint true "Comment ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/comments/{comment_id} [delete]
+func (h *GradingHandler) DeleteFeedbackComment(c *gin.Context) {
+	commentID := h.parseIDParam(c, "comment_id")
+	if commentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Deleting comment bank entry", "comment_id", commentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	if err := h.gradingService.DeleteFeedbackComment(c.Request.Context(), commentID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetAnswerAnnotations lists a grader's inline annotations on an answer
+// @Summary Get answer annotations
+// @Description Lists the highlighted spans of an essay answer with their comments, in text order
+// @Tags grading
+// @Produce json
+// @Param answer_id path uint true "Answer ID"
+// @Success 200 {array} models.AnswerAnnotation
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/answers/{answer_id}/annotations [get]
+func (h *GradingHandler) GetAnswerAnnotations(c *gin.Context) {
+	answerID := h.parseIDParam(c, "answer_id")
+	if answerID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting answer annotations", "answer_id", answerID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	annotations, err := h.gradingService.GetAnswerAnnotations(c.Request.Context(), answerID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, annotations)
+}
+
+// AddAnswerAnnotation highlights a span of an essay answer with a comment
+// @Summary Annotate answer
+// @Description Highlights characters [start_offset, end_offset) of an essay answer, or of an essay part of a multi-part answer, and attaches a comment optionally linked to a rubric criterion
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param answer_id path uint true "Answer ID"
+// @Param annotation body services.CreateAnswerAnnotationRequest true "Annotation data"
+// @Success 201 {object} models.AnswerAnnotation
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/answers/{answer_id}/annotations [post]
+func (h *GradingHandler) AddAnswerAnnotation(c *gin.Context) {
+	answerID := h.parseIDParam(c, "answer_id")
+	if answerID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Annotating answer", "answer_id", answerID)
+
+	var req services.CreateAnswerAnnotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	annotation, err := h.gradingService.AddAnswerAnnotation(c.Request.Context(), answerID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, annotation)
+}
+
+// UpdateAnswerAnnotation edits an annotation's span, comment or rubric criterion
+// @Summary Update answer annotation
+// @Description Moves the highlighted span or changes the comment or linked rubric criterion; an empty criterion unlinks it
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param annotation_id path uint true "Annotation ID"
+// @Param annotation body services.UpdateAnswerAnnotationRequest true "Annotation updates"
+// @Success 200 {object} models.AnswerAnnotation
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/annotations/{annotation_id} [put]
+func (h *GradingHandler) UpdateAnswerAnnotation(c *gin.Context) {
+	annotationID := h.parseIDParam(c, "annotation_id")
+	if annotationID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Updating answer annotation", "annotation_id", annotationID)
+
+	var req services.UpdateAnswerAnnotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	annotation, err := h.gradingService.UpdateAnswerAnnotation(c.Request.Context(), annotationID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, annotation)
+}
+
+// DeleteAnswerAnnotation removes an annotation
+// @Summary Delete answer annotation
+// @Description Removes a highlighted span and its comment from an answer
+// @Tags grading
+// @Param annotation_id path uint true "Annotation ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/annotations/{annotation_id} [delete]
+func (h *GradingHandler) DeleteAnswerAnnotation(c *gin.Context) {
+	annotationID := h.parseIDParam(c, "annotation_id")
+	if annotationID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Deleting answer annotation", "annotation_id", annotationID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	if err := h.gradingService.DeleteAnswerAnnotation(c.Request.Context(), annotationID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// OverrideAttemptScore overrides the final score of an attempt
+// @Summary Override attempt score
+// @Description Sets the final score of a finished attempt, keeping the computed score alongside it. A justification is required and the override is audited; a null score reverts to the computed score.
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param attempt_id path uint true "Attempt ID"
+// @Param override body services.OverrideAttemptScoreRequest true "Override"
+// @Success 200 {object} services.ScoreOverrideHistory
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/attempts/{attempt_id}/score-override [put]
+func (h *GradingHandler) OverrideAttemptScore(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "attempt_id")
+	if attemptID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Overriding attempt score", "attempt_id", attemptID)
+
+	var req services.OverrideAttemptScoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	history, err := h.gradingService.OverrideAttemptScore(c.Request.Context(), attemptID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetScoreOverrideHistory lists the score overrides of an attempt
+// @Summary Get score override history
+// @Description Returns the attempt's current and computed score with every override and revert, oldest first
+// @Tags grading
+// @Produce json
+// @Param attempt_id path uint true "Attempt ID"
+// @Success 200 {object} services.ScoreOverrideHistory
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/attempts/{attempt_id}/score-overrides [get]
+func (h *GradingHandler) GetScoreOverrideHistory(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "attempt_id")
+	if attemptID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting score override history", "attempt_id", attemptID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	history, err := h.gradingService.GetScoreOverrideHistory(c.Request.Context(), attemptID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetAnswerGradeHistory lists every change of an answer's grade
+// @Summary Get answer grade history
+// @Description Returns the answer's current grade and every change of its score, correctness, feedback, grader or grading status, oldest first. Each change records what it was (auto, manual, regrade or override), who made it and when, with the grade before and after.
+// @Tags grading
+// @Produce json
+// @Param answer_id path uint true "Answer ID"
+// @Success 200 {object} services.AnswerGradeHistory
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/answers/{answer_id}/grade-history [get]
+func (h *GradingHandler) GetAnswerGradeHistory(c *gin.Context) {
+	answerID := h.parseIDParam(c, "answer_id")
+	if answerID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting answer grade history", "answer_id", answerID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	history, err := h.gradingService.GetAnswerGradeHistory(c.Request.Context(), answerID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetAttemptGradeHistory lists the grade history of each answer of an attempt
+// @Summary Get attempt grade history
+// @Description Returns the grade history of every answer of the attempt, for resolving grade disputes
+// @Tags grading
+// @Produce json
+// @Param attempt_id path uint true "Attempt ID"
+// @Success 200 {array} services.AnswerGradeHistory
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/attempts/{attempt_id}/grade-history [get]
+func (h *GradingHandler) GetAttemptGradeHistory(c *gin.Context) {
+	attemptID := h.parseIDParam(c, "attempt_id")
+	if attemptID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting attempt grade history", "attempt_id", attemptID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	histories, err := h.gradingService.GetAttemptGradeHistory(c.Request.Context(), attemptID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, histories)
+}
+
+// CreateGradingDelegation lets another teacher grade an assessment for a limited time
+// @Summary Delegate grading
+// @Description Lets another teacher grade the assessment between starts_at (default now) and expires_at, for at most 90 days. The delegate can only grade, and every grading action they take is audited under the delegation. Only the owner or an admin may delegate.
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Param delegation body services.CreateGradingDelegationRequest true "Delegation"
+// @Success 201 {object} models.GradingDelegation
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/assessments/{assessment_id}/delegations [post]
+func (h *GradingHandler) CreateGradingDelegation(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Delegating grading", "assessment_id", assessmentID)
+
+	var req services.CreateGradingDelegationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	delegation, err := h.gradingService.CreateGradingDelegation(c.Request.Context(), assessmentID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, delegation)
+}
+
+// ListGradingDelegations lists the grading delegations of an assessment
+// @Summary List grading delegations
+// @Tags grading
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Success 200 {array} models.GradingDelegation
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/assessments/{assessment_id}/delegations [get]
+func (h *GradingHandler) ListGradingDelegations(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	delegations, err := h.gradingService.ListGradingDelegations(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, delegations)
+}
+
+// ListMyGradingDelegations lists the grading delegations granted to the current user
+// @Summary List my grading delegations
+// @Tags grading
+// @Produce json
+// @Success 200 {array} models.GradingDelegation
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/delegations/mine [get]
+func (h *GradingHandler) ListMyGradingDelegations(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	delegations, err := h.gradingService.ListMyGradingDelegations(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, delegations)
+}
+
+// RevokeGradingDelegation ends a grading delegation before it expires
+// @Summary Revoke grading delegation
+// @Tags grading
+// @Produce json
+// @Param delegation_id path uint true "Delegation ID"
+// @Success 200 {object} models.GradingDelegation
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/delegations/{delegation_id}/revoke [post]
+func (h *GradingHandler) RevokeGradingDelegation(c *gin.Context) {
+	delegationID := h.parseIDParam(c, "delegation_id")
+	if delegationID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Revoking grading delegation", "delegation_id", delegationID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	delegation, err := h.gradingService.RevokeGradingDelegation(c.Request.Context(), delegationID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, delegation)
+}
+
+// GetGradingDelegationAudit lists what happened under a grading delegation
+// @Summary Get grading delegation audit trail
+// @Description Returns the grant, the revocation and every grading action the delegate took under the delegation, newest first. Visible to the owner, admins and the delegate.
+// @Tags grading
+// @Produce json
+// @Param delegation_id path uint true "Delegation ID"
+// @Success 200 {array} models.AuditLog
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/delegations/{delegation_id}/audit [get]
+func (h *GradingHandler) GetGradingDelegationAudit(c *gin.Context) {
+	delegationID := h.parseIDParam(c, "delegation_id")
+	if delegationID == 0 {
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	logs, err := h.gradingService.GetGradingDelegationAudit(c.Request.Context(), delegationID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, logs)
+}
+
+// GetAnswerKeyChanges lists the answer key changes of a question
+// @Summary Get answer key changes
+// @Description Returns every edit that changed how the question is scored, newest first
+// @Tags grading
+// @Produce json
+// @Param question_id path uint true "Question ID"
+// @Success 200 {array} models.AnswerKeyChange
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/questions/{question_id}/answer-key-changes [get]
+func (h *GradingHandler) GetAnswerKeyChanges(c *gin.Context) {
+	questionID := h.parseIDParam(c, "question_id")
+	if questionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting answer key changes", "question_id", questionID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	changes, err := h.gradingService.GetAnswerKeyChanges(c.Request.Context(), questionID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, changes)
+}
+
+// GetAnswerKeyChangeReport lists the assessments and attempts graded with an outdated answer key
+// @Summary Get answer key change report
+// @Description Lists every assessment with completed attempts graded before the change, with the latest regrade job of each. Attempts are only listed for assessments the user can regrade.
+// @Tags grading
+// @Produce json
+// @Param change_id path uint true "Answer key change ID"
+// @Success 200 {object} services.AnswerKeyChangeReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/answer-key-changes/{change_id}/report [get]
+func (h *GradingHandler) GetAnswerKeyChangeReport(c *gin.Context) {
+	changeID := h.parseIDParam(c, "change_id")
+	if changeID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting answer key change report", "change_id", changeID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	report, err := h.gradingService.GetAnswerKeyChangeReport(c.Request.Context(), changeID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ScheduleRegrade queues a regrade of an assessment affected by an answer key change
+// @Summary Schedule regrade
+// @Description Queues a background regrade of the assessment's answers with the current key. Answers graded by a teacher are kept. A job already waiting or running is returned instead.
+// @Tags grading
+// @Produce json
+// @Param change_id path uint true "Answer key change ID"
+// @Param assessment_id path uint true "Assessment ID"
+// @Success 202 {object} models.RegradeJob
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/answer-key-changes/{change_id}/assessments/{assessment_id}/regrade [post]
+func (h *GradingHandler) ScheduleRegrade(c *gin.Context) {
+	changeID := h.parseIDParam(c, "change_id")
+	if changeID == 0 {
+		return
+	}
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Scheduling regrade", "change_id", changeID, "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	job, err := h.gradingService.ScheduleRegrade(c.Request.Context(), changeID, assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetRegradeJob returns the status of a regrade job
+// @Summary Get regrade job
+// @Tags grading
+// @Produce json
+// @Param job_id path uint true "Regrade job ID"
+// @Success 200 {object} models.RegradeJob
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/regrade-jobs/{job_id} [get]
+func (h *GradingHandler) GetRegradeJob(c *gin.Context) {
+	jobID := h.parseIDParam(c, "job_id")
+	if jobID == 0 {
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	job, err := h.gradingService.GetRegradeJob(c.Request.Context(), jobID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// GetFlagEscalations lists the questions of an assessment escalated after many students flagged them
+// @Summary List flag escalations
+// @Description Lists the questions escalated because many students flagged them within a few minutes, newest first. Filter with status open, dismissed or remediated.
+// @Tags grading
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Param status query string false "open, dismissed or remediated"
+// @Success 200 {array} models.QuestionFlagEscalation
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/assessments/{assessment_id}/flag-escalations [get]
+func (h *GradingHandler) GetFlagEscalations(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	var status *models.FlagEscalationStatus
+	switch value := models.FlagEscalationStatus(c.Query("status")); value {
+	case "":
+	case models.FlagEscalationOpen, models.FlagEscalationDismissed, models.FlagEscalationRemediated:
+		status = &value
+	default:
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid status",
+			Details: "status must be open, dismissed or remediated",
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	escalations, err := h.gradingService.GetFlagEscalations(c.Request.Context(), assessmentID, status, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, escalations)
+}
+
+// ResolveFlagEscalation accepts more answers, voids the question or dismisses the escalation
+// @Summary Resolve flag escalation
+// @Description Remediates an escalated question in its assessment only. accept_answers counts the given options as correct too (single answer multiple choice only); void gives every answer full credit. Both regrade finished attempts in the background. dismiss keeps the key.
+// @Tags grading
+// @Accept json
+// @Produce json
+// @Param escalation_id path uint true "Flag escalation ID"
+// @Param resolution body services.ResolveFlagEscalationRequest true "Remediation"
+// @Success 200 {object} models.QuestionFlagEscalation
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /grading/flag-escalations/{escalation_id}/resolve [post]
+func (h *GradingHandler) ResolveFlagEscalation(c *gin.Context) {
+	escalationID := h.parseIDParam(c, "escalation_id")
+	if escalationID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Resolving flag escalation", "escalation_id", escalationID)
+
+	var req services.ResolveFlagEscalationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	escalation, err := h.gradingService.ResolveFlagEscalation(c.Request.Context(), escalationID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, escalation)
+}
+
+// Helper methods
+
+func (h *GradingHandler) getUserID(c *gin.Context) string {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		return ""
+	}
+	if id, ok := userID.(string); ok {
+		return id
+	}
+	return ""
+}
+
+// newAnswerPage wraps a page of answers; a full page gets a cursor to the next one
+func newAnswerPage(answers []services.AnswerGradingState, total int64, filters repositories.AnswerFilters) PaginatedResponse {
+	response := PaginatedResponse{
+		Items: answers,
+		Total: total,
+		Size:  filters.Limit,
+	}
+	if filters.After == nil && filters.Limit > 0 {
+		response.Page = (filters.Offset / filters.Limit) + 1
+	}
+
+	if filters.Limit > 0 && len(answers) == filters.Limit {
+		last := answers[len(answers)-1]
+		next := repositories.PageCursor{CreatedAt: last.CreatedAt, ID: last.AnswerID}.Encode()
+		response.NextCursor = &next
+	}
+
+	return response
+}
+
+func (h *GradingHandler) parseIntQuery(c *gin.Context, param string, defaultValue int) int {
+	valueStr := c.Query(param)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func (h *GradingHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *GradingHandler) handleServiceError(c *gin.Context, err error) {
+	// Handle custom error types first
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	// Handle specific grading errors
+	switch {
+	case errors.Is(err, services.ErrGradingNotAllowed):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Grading not allowed for this question type",
+		})
+	case errors.Is(err, services.ErrGradingAlreadyCompleted):
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Message: "Answer already graded",
+		})
+	case errors.Is(err, services.ErrGradingInvalidScore):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid score value",
+		})
+	case errors.Is(err, services.ErrGradingPermissionDenied):
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Permission denied for grading",
+		})
+	// Related entity errors
+	case errors.Is(err, services.ErrAttemptNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Attempt not found",
+		})
+	case errors.Is(err, services.ErrQuestionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Question not found",
+		})
+	case errors.Is(err, services.ErrAssessmentNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Assessment not found",
+		})
+	// Generic errors
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Resource not found",
 		})
-	// Generic errors
 	case errors.Is(err, services.ErrValidationFailed):
 		c.JSON(http.StatusBadRequest, ErrorResponse{
 			Message: "Validation failed",
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
+	"github.com/SAP-F-2025/assessment-service/internal/services"
 	"github.com/gin-gonic/gin"
 )
 
@@ -19,3 +21,31 @@ func ParseStringIDParam(c *gin.Context, param string) string {
 	}
 	return idStr
 }
+
+// contentLockContext is the request context, asking for an override of the content lock when
+// the request has override_lock=true; the service allows it for admins only
+func contentLockContext(c *gin.Context) context.Context {
+	if c.Query("override_lock") == "true" {
+		return services.WithContentLockOverride(c.Request.Context())
+	}
+	return c.Request.Context()
+}
+
+// customFieldFilterPrefix marks query parameters filtering on custom field values, e.g. cf.cost_center=42
+const customFieldFilterPrefix = "cf."
+
+// parseCustomFieldFilters reads cf.<key>=value query parameters; values match as text
+func parseCustomFieldFilters(c *gin.Context) map[string]string {
+	var filters map[string]string
+	for name, values := range c.Request.URL.Query() {
+		key := strings.TrimPrefix(name, customFieldFilterPrefix)
+		if key == name || key == "" || len(values) == 0 {
+			continue
+		}
+		if filters == nil {
+			filters = make(map[string]string)
+		}
+		filters[key] = values[0]
+	}
+	return filters
+}
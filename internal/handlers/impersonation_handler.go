@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ImpersonationHandler struct {
+	BaseHandler
+	impersonationService services.ImpersonationService
+}
+
+func NewImpersonationHandler(
+	impersonationService services.ImpersonationService,
+	logger utils.Logger,
+) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		BaseHandler:          NewBaseHandler(logger),
+		impersonationService: impersonationService,
+	}
+}
+
+// StartImpersonation opens a support session as another user
+// @Summary Start impersonation
+// @Description Issues a time-limited token letting an admin see exactly what a teacher or student sees. Send it as the X-Impersonation-Token header alongside the admin's own bearer token. Sessions are read-only unless allow_writes is set, and every request made under them is audit-logged. The token is shown only once.
+// @Tags impersonation
+// @Accept json
+// @Produce json
+// @Param session body services.StartImpersonationRequest true "User to impersonate and why"
+// @Success 201 {object} services.ImpersonationGrant
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /impersonation [post]
+func (h *ImpersonationHandler) StartImpersonation(c *gin.Context) {
+	var req services.StartImpersonationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.LogRequest(c, "Starting impersonation", "target_user_id", req.TargetUserID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	grant, err := h.impersonationService.Start(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, grant)
+}
+
+// ListImpersonations lists the admin's live impersonation sessions
+// @Summary List impersonation sessions
+// @Description Lists the caller's impersonation sessions that have neither ended nor expired
+// @Tags impersonation
+// @Produce json
+// @Success 200 {array} models.ImpersonationSession
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /impersonation [get]
+func (h *ImpersonationHandler) ListImpersonations(c *gin.Context) {
+	h.LogRequest(c, "Listing impersonation sessions")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	sessions, err := h.impersonationService.ListActive(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// EndImpersonation ends an impersonation session early
+// @Summary End impersonation
+// @Description Revokes the session's token; further requests made with it are refused
+// @Tags impersonation
+// @Param id path uint true "Session ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /impersonation/{id} [delete]
+func (h *ImpersonationHandler) EndImpersonation(c *gin.Context) {
+	sessionID := h.parseIDParam(c, "id")
+	if sessionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Ending impersonation", "session_id", sessionID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.impersonationService.End(c.Request.Context(), sessionID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetImpersonationAudit lists everything done under an impersonation session
+// @Summary Get impersonation audit trail
+// @Description Lists the start, end and every request of the session, newest first, including writes refused by a read-only session
+// @Tags impersonation
+// @Produce json
+// @Param id path uint true "Session ID"
+// @Success 200 {array} models.AuditLog
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /impersonation/{id}/audit [get]
+func (h *ImpersonationHandler) GetImpersonationAudit(c *gin.Context) {
+	sessionID := h.parseIDParam(c, "id")
+	if sessionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting impersonation audit trail", "session_id", sessionID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	entries, err := h.impersonationService.GetAuditTrail(c.Request.Context(), sessionID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// Helper methods
+
+func (h *ImpersonationHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *ImpersonationHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "User not found",
+		})
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Impersonation session not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Largest accepted question import upload
+const maxImportFileSize = 20 << 20
+
+type ImportHandler struct {
+	BaseHandler
+	importService services.ImportExportService
+}
+
+func NewImportHandler(
+	importService services.ImportExportService,
+	logger utils.Logger,
+) *ImportHandler {
+	return &ImportHandler{
+		BaseHandler:   NewBaseHandler(logger),
+		importService: importService,
+	}
+}
+
+// CreateImportJob uploads a question file and starts importing it in the background
+// @Summary Create question import job
+// @Description Stores a CSV or Excel question file and imports it in resumable chunks; poll the job for progress
+// @Tags import
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV or Excel file"
+// @Param lint formData bool false "Report spelling and readability warnings"
+// @Param target_grade_level formData number false "Highest acceptable Flesch-Kincaid grade (1-18)"
+// @Success 202 {object} models.ImportJob
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /import-jobs [post]
+func (h *ImportHandler) CreateImportJob(c *gin.Context) {
+	h.LogRequest(c, "Creating import job")
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+	if fileHeader.Size > maxImportFileSize {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "File too large",
+			Details: map[string]interface{}{
+				"max_size": maxImportFileSize,
+			},
+		})
+		return
+	}
+
+	opts, err := h.parseImportJobOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+	defer file.Close()
+
+	job, err := h.importService.CreateImportJob(c.Request.Context(), file, fileHeader.Filename, userID.(string), opts)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetImportJob gets the status and progress of an import job
+// @Summary Get import job
+// @Description Returns status, progress, row counts and row errors of an import job
+// @Tags import
+// @Produce json
+// @Param id path string true "Import job ID"
+// @Success 200 {object} models.ImportJob
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /import-jobs/{id} [get]
+func (h *ImportHandler) GetImportJob(c *gin.Context) {
+	jobID := c.Param("id")
+
+	h.LogRequest(c, "Getting import job", "job_id", jobID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	job, err := h.importService.GetImportJob(c.Request.Context(), jobID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// CancelImportJob cancels a pending or running import job
+// @Summary Cancel import job
+// @Description Stops an import at its next checkpoint; questions imported before the cancellation are kept
+// @Tags import
+// @Produce json
+// @Param id path string true "Import job ID"
+// @Success 200 {object} models.ImportJob
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /import-jobs/{id} [delete]
+func (h *ImportHandler) CancelImportJob(c *gin.Context) {
+	jobID := c.Param("id")
+
+	h.LogRequest(c, "Cancelling import job", "job_id", jobID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	job, err := h.importService.CancelImportJob(c.Request.Context(), jobID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// Helper methods
+
+func (h *ImportHandler) parseImportJobOptions(c *gin.Context) (services.ImportJobOptions, error) {
+	var opts services.ImportJobOptions
+
+	if lint := c.PostForm("lint"); lint != "" {
+		enabled, err := strconv.ParseBool(lint)
+		if err != nil {
+			return opts, fmt.Errorf("invalid lint: %w", err)
+		}
+		opts.Lint = enabled
+	}
+
+	if grade := c.PostForm("target_grade_level"); grade != "" {
+		level, err := strconv.ParseFloat(grade, 64)
+		if err != nil || level < 1 || level > 18 {
+			return opts, fmt.Errorf("target_grade_level must be a number between 1 and 18")
+		}
+		opts.TargetGradeLevel = &level
+	}
+
+	return opts, nil
+}
+
+func (h *ImportHandler) handleServiceError(c *gin.Context, err error) {
+	var validationError *services.ValidationError
+	if errors.As(err, &validationError) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationError,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case services.IsNotFound(err):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Resource not found",
+		})
+	case errors.Is(err, services.ErrUnauthorized):
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "Unauthorized access",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type JobHandler struct {
+	BaseHandler
+	jobService services.JobService
+}
+
+func NewJobHandler(
+	jobService services.JobService,
+	logger utils.Logger,
+) *JobHandler {
+	return &JobHandler{
+		BaseHandler: NewBaseHandler(logger),
+		jobService:  jobService,
+	}
+}
+
+// ListJobs lists background jobs
+// @Summary List background jobs
+// @Description Lists imports, regrades, statistics recomputes and other background jobs, newest first. Users see the jobs they started and admins every job. Failed jobs make up the dead-letter queue.
+// @Tags jobs
+// @Produce json
+// @Param type query string false "import, regrade or question_stats"
+// @Param status query string false "queued, running, succeeded or failed"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} PaginatedResponse{items=[]models.Job}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /jobs [get]
+func (h *JobHandler) ListJobs(c *gin.Context) {
+	h.LogRequest(c, "Listing jobs")
+
+	page := h.parseIntQuery(c, "page", 1)
+	size := h.parseIntQuery(c, "size", 20)
+	if page < 1 || size < 1 || size > 100 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid pagination",
+			Details: "page must be at least 1 and size between 1 and 100",
+		})
+		return
+	}
+
+	filters := repositories.JobFilters{
+		Type:   c.Query("type"),
+		Limit:  size,
+		Offset: (page - 1) * size,
+	}
+	if value := c.Query("status"); value != "" {
+		status := models.JobStatus(value)
+		switch status {
+		case models.JobQueued, models.JobRunning, models.JobSucceeded, models.JobFailed:
+			filters.Status = &status
+		default:
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid status",
+				Details: value,
+			})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	jobs, total, err := h.jobService.List(c.Request.Context(), filters, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Items: jobs,
+		Total: total,
+		Page:  page,
+		Size:  size,
+	})
+}
+
+// GetJob gets a background job
+// @Summary Get background job
+// @Description Gets a job's status, attempts and last error. The job's ref names its record, such as the import job, which holds the details.
+// @Tags jobs
+// @Produce json
+// @Param id path uint true "Job ID"
+// @Success 200 {object} models.Job
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /jobs/{id} [get]
+func (h *JobHandler) GetJob(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting job", "job_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	job, err := h.jobService.Get(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// RetryJob runs a failed job again
+// @Summary Retry background job
+// @Description Takes a failed job off the dead-letter queue and queues it again with fresh attempts. Admins only.
+// @Tags jobs
+// @Produce json
+// @Param id path uint true "Job ID"
+// @Success 200 {object} models.Job
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /jobs/{id}/retry [post]
+func (h *JobHandler) RetryJob(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Retrying job", "job_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	job, err := h.jobService.Retry(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// Helper methods
+
+func (h *JobHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *JobHandler) parseIntQuery(c *gin.Context, param string, defaultValue int) int {
+	value, err := strconv.Atoi(c.Query(param))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func (h *JobHandler) handleServiceError(c *gin.Context, err error) {
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Job not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
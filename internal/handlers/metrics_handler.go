@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type MetricsHandler struct {
+	BaseHandler
+	liveMetrics *services.LiveMetrics
+	compressor  *cache.Compressor
+}
+
+func NewMetricsHandler(liveMetrics *services.LiveMetrics, compressor *cache.Compressor, logger utils.Logger) *MetricsHandler {
+	return &MetricsHandler{
+		BaseHandler: NewBaseHandler(logger),
+		liveMetrics: liveMetrics,
+		compressor:  compressor,
+	}
+}
+
+// GetMetrics exposes per-assessment live metrics for Prometheus scrapes
+// @Summary Get live metrics
+// @Description Active attempts, submissions per minute, autosave errors and average answer save latency per assessment, in the Prometheus text format. Assessments beyond the label limit are reported as assessment_id="other". Compression ratios of stored answers, cached values and autosaves are reported per store.
+// @Tags metrics
+// @Produce plain
+// @Success 200 {string} string
+// @Failure 404 {object} ErrorResponse
+// @Router /metrics [get]
+func (h *MetricsHandler) GetMetrics(c *gin.Context) {
+	if h.liveMetrics == nil && h.compressor == nil {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Metrics are disabled",
+		})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.liveMetrics.WritePrometheus(c.Request.Context(), &buf); err != nil {
+		// The remaining metrics are still worth scraping
+		h.logger.Warn("Live metrics written without active attempts", "error", err)
+	}
+	if h.compressor != nil {
+		if err := h.compressor.WritePrometheus(&buf); err != nil {
+			h.logger.Warn("Failed to write compression metrics", "error", err)
+		}
+	}
+
+	c.Data(http.StatusOK, prometheusContentType, buf.Bytes())
+}
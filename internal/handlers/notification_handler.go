@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	BaseHandler
+	notificationService services.NotificationEventService
+}
+
+func NewNotificationHandler(
+	notificationService services.NotificationEventService,
+	logger utils.Logger,
+) *NotificationHandler {
+	return &NotificationHandler{
+		BaseHandler:         NewBaseHandler(logger),
+		notificationService: notificationService,
+	}
+}
+
+// PreviewAudience counts who an audience notification would reach
+// @Summary Preview notification audience
+// @Description Resolves audience filters (students with incomplete attempts on an assessment, graders with more than min_backlog answers waiting, members of a group such as a class) and returns recipient counts per audience and overall, without sending anything. Teachers may only target students of assessments they created.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param request body services.AudienceNotificationRequest true "Audiences to resolve; the notification is ignored"
+// @Success 200 {object} services.AudiencePreview
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notifications/bulk/preview [post]
+func (h *NotificationHandler) PreviewAudience(c *gin.Context) {
+	var req services.AudienceNotificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.LogRequest(c, "Previewing notification audience", "audiences", len(req.Audiences))
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	preview, err := h.notificationService.PreviewAudience(c.Request.Context(), req.Audiences, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// SendAudienceNotification notifies everyone the audience filters resolve to
+// @Summary Send notification to an audience
+// @Description Resolves the audience filters server-side and sends the notification once to each recipient. Returns the same counts as the preview.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param request body services.AudienceNotificationRequest true "Audiences and notification"
+// @Success 202 {object} services.AudiencePreview
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notifications/bulk [post]
+func (h *NotificationHandler) SendAudienceNotification(c *gin.Context) {
+	var req services.AudienceNotificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.LogRequest(c, "Sending audience notification", "audiences", len(req.Audiences))
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	sent, err := h.notificationService.SendAudienceNotification(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, sent)
+}
+
+// GetNotificationPreferences returns the signed-in user's notification preferences
+// @Summary Get notification preferences
+// @Description Returns the channels used for each notification type, with defaults filled in, the quiet hours and timezone, and the notification types for the settings screen.
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} services.NotificationPreferences
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notification-preferences [get]
+func (h *NotificationHandler) GetNotificationPreferences(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	preferences, err := h.notificationService.GetNotificationPreferences(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
+
+// UpdateNotificationPreferences replaces the signed-in user's notification preferences
+// @Summary Update notification preferences
+// @Description Types left out of channels use their default channels. An empty list turns a low-priority type off. Notifications that are not urgent wait until quiet hours end; quiet hours may run past midnight.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param request body services.UpdateNotificationPreferencesRequest true "Notification preferences"
+// @Success 200 {object} services.NotificationPreferences
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /notification-preferences [put]
+func (h *NotificationHandler) UpdateNotificationPreferences(c *gin.Context) {
+	var req services.UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.LogRequest(c, "Updating notification preferences")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	preferences, err := h.notificationService.UpdateNotificationPreferences(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
+
+// Helper methods
+
+func (h *NotificationHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var validationError *services.ValidationError
+	if errors.As(err, &validationError) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationError,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case services.IsNotFound(err):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Resource not found",
+		})
+	case errors.Is(err, services.ErrUnauthorized):
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "Unauthorized access",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
@@ -0,0 +1,493 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type PracticeHandler struct {
+	BaseHandler
+	practiceService services.PracticeService
+}
+
+func NewPracticeHandler(
+	practiceService services.PracticeService,
+	logger utils.Logger,
+) *PracticeHandler {
+	return &PracticeHandler{
+		BaseHandler:     NewBaseHandler(logger),
+		practiceService: practiceService,
+	}
+}
+
+// CreatePracticeSet exposes part of a bank as a self-assessment
+// @Summary Create practice set
+// @Description Curates questions from a bank into an always-available self-assessment. Each session draws questions_per_quiz of them. Only questions graded automatically qualify, and licensed banks cannot be used. Give a class_id to open the set only to students enrolled with that class.
+// @Tags practice
+// @Accept json
+// @Produce json
+// @Param id path uint true "Question bank ID"
+// @Param request body services.CreatePracticeSetRequest true "Title, questions and quiz length"
+// @Success 201 {object} models.PracticeSet
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /question-banks/{id}/practice-sets [post]
+func (h *PracticeHandler) CreatePracticeSet(c *gin.Context) {
+	bankID := h.parseIDParam(c, "id")
+	if bankID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Creating practice set", "bank_id", bankID)
+
+	var req services.CreatePracticeSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	set, err := h.practiceService.CreateSet(c.Request.Context(), bankID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, set)
+}
+
+// ListBankPracticeSets lists a bank's practice sets
+// @Summary List bank practice sets
+// @Description Lists the practice sets curated from a bank, including closed ones
+// @Tags practice
+// @Produce json
+// @Param id path uint true "Question bank ID"
+// @Success 200 {array} models.PracticeSet
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /question-banks/{id}/practice-sets [get]
+func (h *PracticeHandler) ListBankPracticeSets(c *gin.Context) {
+	bankID := h.parseIDParam(c, "id")
+	if bankID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Listing practice sets", "bank_id", bankID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	sets, err := h.practiceService.ListBankSets(c.Request.Context(), bankID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sets)
+}
+
+// UpdatePracticeSet changes a practice set
+// @Summary Update practice set
+// @Description Changes a practice set's questions, quiz length or class, or closes it with is_active false. Sessions already started keep their questions.
+// @Tags practice
+// @Accept json
+// @Produce json
+// @Param id path uint true "Practice set ID"
+// @Param request body services.UpdatePracticeSetRequest true "Fields to change"
+// @Success 200 {object} models.PracticeSet
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /practice/sets/{id} [put]
+func (h *PracticeHandler) UpdatePracticeSet(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Updating practice set", "practice_set_id", id)
+
+	var req services.UpdatePracticeSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	set, err := h.practiceService.UpdateSet(c.Request.Context(), id, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, set)
+}
+
+// GetPracticeSetStats shows how students do on a practice set
+// @Summary Get practice set statistics
+// @Description Sessions, students, average score and per-question correct rates of a practice set. Practice is tracked apart from assessment analytics.
+// @Tags practice
+// @Produce json
+// @Param id path uint true "Practice set ID"
+// @Success 200 {object} services.PracticeSetStats
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /practice/sets/{id}/stats [get]
+func (h *PracticeHandler) GetPracticeSetStats(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting practice set stats", "practice_set_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	stats, err := h.practiceService.GetSetStats(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// ListAvailablePracticeSets lists the practice sets a student can take
+// @Summary List available practice sets
+// @Description Lists the open practice sets available to the student's classes
+// @Tags practice
+// @Produce json
+// @Success 200 {array} models.PracticeSet
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /practice/sets [get]
+func (h *PracticeHandler) ListAvailablePracticeSets(c *gin.Context) {
+	h.LogRequest(c, "Listing available practice sets")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	sets, err := h.practiceService.ListAvailableSets(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sets)
+}
+
+// StartPracticeSession starts a practice quiz
+// @Summary Start practice session
+// @Description Draws a short quiz from the practice set. Students may practice as often as they like.
+// @Tags practice
+// @Produce json
+// @Param id path uint true "Practice set ID"
+// @Success 201 {object} services.PracticeSessionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /practice/sets/{id}/sessions [post]
+func (h *PracticeHandler) StartPracticeSession(c *gin.Context) {
+	setID := h.parseIDParam(c, "id")
+	if setID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Starting practice session", "practice_set_id", setID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	session, err := h.practiceService.StartSession(c.Request.Context(), setID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// ListPracticeSessions lists the student's practice history
+// @Summary List practice sessions
+// @Description Lists the student's practice sessions, newest first
+// @Tags practice
+// @Produce json
+// @Param practice_set_id query uint false "Only sessions of this practice set"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} PaginatedResponse{items=[]models.PracticeSession}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /practice/sessions [get]
+func (h *PracticeHandler) ListPracticeSessions(c *gin.Context) {
+	h.LogRequest(c, "Listing practice sessions")
+
+	page := h.parseIntQuery(c, "page", 1)
+	size := h.parseIntQuery(c, "size", 20)
+	if page < 1 || size < 1 || size > 100 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid pagination",
+			Details: "page must be at least 1 and size between 1 and 100",
+		})
+		return
+	}
+
+	var setID *uint
+	if value := c.Query("practice_set_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid practice_set_id",
+				Details: err.Error(),
+			})
+			return
+		}
+		parsed := uint(id)
+		setID = &parsed
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	sessions, total, err := h.practiceService.ListSessions(c.Request.Context(), setID, userID.(string), size, (page-1)*size)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, PaginatedResponse{
+		Items: sessions,
+		Total: total,
+		Page:  page,
+		Size:  size,
+	})
+}
+
+// GetPracticeSession gets a practice session
+// @Summary Get practice session
+// @Description Gets one of the student's practice sessions with its questions and the answers given so far
+// @Tags practice
+// @Produce json
+// @Param id path uint true "Practice session ID"
+// @Success 200 {object} services.PracticeSessionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /practice/sessions/{id} [get]
+func (h *PracticeHandler) GetPracticeSession(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting practice session", "session_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	session, err := h.practiceService.GetSession(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// AnswerPracticeQuestion grades a practice answer straight away
+// @Summary Answer practice question
+// @Description Grades the answer and returns the score, feedback, explanation and correct answer. Each question is answered once per session.
+// @Tags practice
+// @Accept json
+// @Produce json
+// @Param id path uint true "Practice session ID"
+// @Param request body services.PracticeAnswerRequest true "Question and answer, in the format students submit"
+// @Success 200 {object} services.PracticeAnswerResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /practice/sessions/{id}/answers [post]
+func (h *PracticeHandler) AnswerPracticeQuestion(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Answering practice question", "session_id", id)
+
+	var req services.PracticeAnswerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	result, err := h.practiceService.AnswerQuestion(c.Request.Context(), id, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Helper methods
+
+func (h *PracticeHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *PracticeHandler) parseIntQuery(c *gin.Context, param string, defaultValue int) int {
+	value, err := strconv.Atoi(c.Query(param))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func (h *PracticeHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Not found",
+		})
+	case errors.Is(err, services.ErrQuestionNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Question not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
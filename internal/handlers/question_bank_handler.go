@@ -702,6 +702,8 @@ func (h *QuestionBankHandler) RemoveQuestionsFromBank(c *gin.Context) {
 // @Param type query string false "Filter by question type"
 // @Param difficulty query string false "Filter by difficulty level"
 // @Param category_id query int false "Filter by category ID"
+// @Param locale query string false "Filter by translation locale"
+// @Param translation_status query string false "Filter by translation status (pending_review, approved, changes_requested, stale, missing)"
 // @Param limit query int false "Number of results to return (default: 10)"
 // @Param offset query int false "Number of results to skip (default: 0)"
 // @Param sort_by query string false "Sort field (created_at, text) (default: created_at)"
@@ -971,6 +973,307 @@ func (h *QuestionBankHandler) GetQuestionBanksByCreator(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// ===== MERGE ENDPOINTS =====
+
+// MergeQuestionBanks merges another question bank into this one
+// @Summary Merge question banks
+// @Description Moves questions from the source bank into this bank, collapsing duplicates onto existing questions, remapping references in unattempted assessments the caller can edit and merging tags and categories
+// @Tags question-banks
+// @Accept json
+// @Produce json
+// @Param id path int true "Target Question Bank ID"
+// @Param request body services.MergeQuestionBanksRequest true "Merge request"
+// @Success 200 {object} services.QuestionBankMergeReport
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden - no edit access"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /question-banks/{id}/merge [post]
+func (h *QuestionBankHandler) MergeQuestionBanks(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid question bank ID",
+		})
+		return
+	}
+
+	var req services.MergeQuestionBanksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	report, err := h.service.Merge(c.Request.Context(), uint(id), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ===== LICENSING ENDPOINTS =====
+
+// UpdateQuestionBankLicense sets the license terms of a question bank
+// @Summary Update question bank license
+// @Description Marks a question bank as licensed content with an optional provider, seat limit and expiry date. Seats count distinct students exposed to the bank's questions.
+// @Tags question-banks
+// @Accept json
+// @Produce json
+// @Param id path int true "Question Bank ID"
+// @Param request body services.UpdateBankLicenseRequest true "License terms"
+// @Success 200 {object} services.QuestionBankResponse
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden - not owner or admin"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /question-banks/{id}/license [put]
+func (h *QuestionBankHandler) UpdateQuestionBankLicense(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid question bank ID",
+		})
+		return
+	}
+
+	var req services.UpdateBankLicenseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	response, err := h.service.UpdateLicense(c.Request.Context(), uint(id), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetQuestionBankLicenseUsage reports usage of a licensed question bank
+// @Summary Get question bank license usage
+// @Description Reports seats used by students exposed to the bank's questions, remaining seats, days until expiry and alerts when the license nears its limits
+// @Tags question-banks
+// @Accept json
+// @Produce json
+// @Param id path int true "Question Bank ID"
+// @Success 200 {object} services.BankLicenseUsage
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden - not owner or admin"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 422 {object} ErrorResponse "Question bank is not licensed"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /question-banks/{id}/license-usage [get]
+func (h *QuestionBankHandler) GetQuestionBankLicenseUsage(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid question bank ID",
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	usage, err := h.service.GetLicenseUsage(c.Request.Context(), uint(id), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// ===== CHANGE FEED ENDPOINTS =====
+
+// GetQuestionBankChanges reads the bank's change feed
+// @Summary Get question bank change feed
+// @Description Lists questions added to, updated in and retired from the bank, oldest first, with the question version each change produced. Pass the previous page's next_cursor to continue; an empty page returns the same cursor to poll with.
+// @Tags question-banks
+// @Produce json
+// @Param id path int true "Question Bank ID"
+// @Param cursor query string false "Continue after a previous page's next_cursor"
+// @Param limit query int false "Entries per page (default 100, max 1000)"
+// @Success 200 {object} services.QuestionBankChangeFeed
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /question-banks/{id}/changes [get]
+func (h *QuestionBankHandler) GetQuestionBankChanges(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid question bank ID",
+		})
+		return
+	}
+
+	var after *repositories.PageCursor
+	if token := c.Query("cursor"); token != "" {
+		after, err = repositories.DecodePageCursor(token)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid cursor",
+				Details: err.Error(),
+			})
+			return
+		}
+	}
+
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid limit",
+			})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	feed, err := h.service.GetChangeFeed(c.Request.Context(), uint(id), after, limit, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+// ===== CONTENT REVIEW ENDPOINTS =====
+
+// GetStaleQuestions lists the bank's questions that are due for review
+// @Summary Get stale questions
+// @Description Lists questions not reviewed within their review interval (12 months unless set on the question) and questions whose correct rate over the last 90 days dropped 15 points or more against the 90 days before. Bank owners get the same list as a weekly reminder.
+// @Tags question-banks
+// @Produce json
+// @Param id path int true "Question Bank ID"
+// @Success 200 {object} services.StaleQuestionReport
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /question-banks/{id}/stale-questions [get]
+func (h *QuestionBankHandler) GetStaleQuestions(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid question bank ID",
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	report, err := h.service.GetStaleQuestions(c.Request.Context(), uint(id), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// MarkQuestionsReviewed stamps questions of the bank as reviewed
+// @Summary Mark questions reviewed
+// @Description Sets the last-reviewed date of the given questions of the bank to now, restarting their review interval. An empty question_ids list marks every question in the bank.
+// @Tags question-banks
+// @Accept json
+// @Produce json
+// @Param id path int true "Question Bank ID"
+// @Param request body services.MarkQuestionsReviewedRequest true "Questions to mark"
+// @Success 200 {object} services.MarkQuestionsReviewedResponse
+// @Failure 400 {object} ErrorResponse "Bad request"
+// @Failure 401 {object} ErrorResponse "Unauthorized"
+// @Failure 403 {object} ErrorResponse "Forbidden"
+// @Failure 404 {object} ErrorResponse "Not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /question-banks/{id}/questions/reviewed [post]
+func (h *QuestionBankHandler) MarkQuestionsReviewed(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid question bank ID",
+		})
+		return
+	}
+
+	var req services.MarkQuestionsReviewedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	response, err := h.service.MarkQuestionsReviewed(c.Request.Context(), uint(id), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // ===== HELPER METHODS =====
 
 func (h *QuestionBankHandler) parseQuestionBankFilters(c *gin.Context) repositories.QuestionBankFilters {
@@ -1070,6 +1373,14 @@ func (h *QuestionBankHandler) parseQuestionFilters(c *gin.Context) repositories.
 		}
 	}
 
+	// Parse translation filters
+	if locale := c.Query("locale"); locale != "" {
+		filters.Locale = &locale
+	}
+	if status := c.Query("translation_status"); status != "" {
+		filters.TranslationStatus = (*models.TranslationStatus)(&status)
+	}
+
 	// Parse pagination
 	if limit := c.Query("limit"); limit != "" {
 		if l, err := strconv.Atoi(limit); err == nil && l > 0 && l <= 100 {
@@ -1106,6 +1417,40 @@ func (h *QuestionBankHandler) parseQuestionFilters(c *gin.Context) repositories.
 }
 
 func (h *QuestionBankHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
 	// Map service errors to HTTP status codes
 	switch {
 	case errors.Is(err, services.ErrQuestionBankNotFound):
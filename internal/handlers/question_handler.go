@@ -100,6 +100,87 @@ func (h *QuestionHandler) CreateQuestion(c *gin.Context) {
 	c.JSON(http.StatusCreated, question)
 }
 
+// CreateQuestionDraft saves an unfinished question
+// @Summary Create draft question
+// @Description Saves a question that is still being written. Only the type is required; the full validation runs when the draft is finalized. Drafts are private to their creator and cannot be added to banks or assessments. Keep editing a draft with PUT /questions/{id}.
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param question body services.CreateQuestionRequest true "Question data, possibly incomplete"
+// @Success 201 {object} services.QuestionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/drafts [post]
+func (h *QuestionHandler) CreateQuestionDraft(c *gin.Context) {
+	h.LogRequest(c, "Creating draft question")
+
+	var req services.CreateQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	question, err := h.questionService.CreateDraft(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, question)
+}
+
+// FinalizeQuestionDraft validates a draft question and makes it usable
+// @Summary Finalize draft question
+// @Description Runs the full create-time validation on a draft. On success the question can be added to banks and assessments; otherwise every validation error is returned and the question stays a draft.
+// @Tags questions
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Success 200 {object} services.QuestionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/finalize [post]
+func (h *QuestionHandler) FinalizeQuestionDraft(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Finalizing draft question", "question_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	question, err := h.questionService.FinalizeDraft(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
 // GetQuestion retrieves a question by ID
 // @Summary Get question
 // @Description Retrieves a question by its ID
@@ -182,9 +263,11 @@ func (h *QuestionHandler) GetQuestionWithDetails(c *gin.Context) {
 // @Produce json
 // @Param id path uint true "Question ID"
 // @Param question body services.UpdateQuestionRequest true "Question update data"
+// @Param override_lock query bool false "Admins only: apply the change while attempts are in progress, voiding them"
 // @Success 200 {object} SuccessResponse{data=services.QuestionResponse}
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 423 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /questions/{id} [put]
 func (h *QuestionHandler) UpdateQuestion(c *gin.Context) {
@@ -220,7 +303,7 @@ func (h *QuestionHandler) UpdateQuestion(c *gin.Context) {
 		return
 	}
 
-	question, err := h.questionService.Update(c.Request.Context(), id, &req, userID.(string))
+	question, err := h.questionService.Update(contentLockContext(c), id, &req, userID.(string))
 	if err != nil {
 		h.handleServiceError(c, err)
 		return
@@ -279,6 +362,9 @@ func (h *QuestionHandler) DeleteQuestion(c *gin.Context) {
 // @Param type query string false "Question type"
 // @Param difficulty query string false "Difficulty level"
 // @Param creator_id query uint false "Creator ID"
+// @Param locale query string false "Has a translation in this locale"
+// @Param translation_status query string false "Translation status (pending_review, approved, changes_requested, stale, missing)"
+// @Param draft query bool false "Only drafts (true) or only finalized questions (false)"
 // @Success 200 {object} SuccessResponse{data=services.QuestionListResponse}
 // @Failure 500 {object} ErrorResponse
 // @Router /questions [get]
@@ -477,6 +563,7 @@ func (h *QuestionHandler) CreateQuestionsBatch(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param updates body map[uint]services.UpdateQuestionRequest true "Question updates map"
+// @Param override_lock query bool false "Admins only: apply the change while attempts are in progress, voiding them"
 // @Success 200 {object} SuccessResponse{data=map[uint]services.QuestionResponse}
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
@@ -508,7 +595,7 @@ func (h *QuestionHandler) UpdateQuestionsBatch(c *gin.Context) {
 		})
 		return
 	}
-	results, errors := h.questionService.UpdateBatch(c.Request.Context(), updates, userID.(string))
+	results, errors := h.questionService.UpdateBatch(contentLockContext(c), updates, userID.(string))
 
 	// Check if there are any errors
 	hasErrors := false
@@ -530,6 +617,90 @@ func (h *QuestionHandler) UpdateQuestionsBatch(c *gin.Context) {
 	c.JSON(http.StatusOK, results)
 }
 
+// PreviewBulkReassignQuestions counts what a bulk retag or recategorize would change
+// @Summary Preview bulk question reassignment
+// @Description Selects questions by ID and/or filter and reports how many would gain or lose each tag or move category, without changing anything
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param request body services.BulkReassignQuestionsRequest true "Questions to select and changes to make"
+// @Success 200 {object} services.BulkReassignResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/bulk-reassign/preview [post]
+func (h *QuestionHandler) PreviewBulkReassignQuestions(c *gin.Context) {
+	var req services.BulkReassignQuestionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	result, err := h.questionService.PreviewBulkReassign(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// BulkReassignQuestions retags or recategorizes many questions at once
+// @Summary Bulk reassign questions
+// @Description Adds and removes tags and sets or clears the category of the selected questions in one transaction; nothing changes if any question fails
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param request body services.BulkReassignQuestionsRequest true "Questions to select and changes to make"
+// @Success 200 {object} services.BulkReassignResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/bulk-reassign [post]
+func (h *QuestionHandler) BulkReassignQuestions(c *gin.Context) {
+	h.LogRequest(c, "Bulk reassigning questions")
+
+	var req services.BulkReassignQuestionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	result, err := h.questionService.BulkReassign(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // GetQuestionsByBank gets questions by question bank
 // @Summary Get questions by bank
 // @Description Gets questions from a specific question bank
@@ -539,6 +710,9 @@ func (h *QuestionHandler) UpdateQuestionsBatch(c *gin.Context) {
 // @Param bank_id path uint true "Bank ID"
 // @Param page query int false "Page number" default(1)
 // @Param size query int false "Page size" default(10)
+// @Param locale query string false "Has a translation in this locale"
+// @Param translation_status query string false "Translation status (pending_review, approved, changes_requested, stale, missing)"
+// @Param draft query bool false "Only drafts (true) or only finalized questions (false)"
 // @Success 200 {object} SuccessResponse{data=services.QuestionListResponse}
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -693,6 +867,52 @@ func (h *QuestionHandler) GetQuestionStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// TestGradeQuestion grades a hypothetical answer without storing it
+// @Summary Test-grade a question
+// @Description Runs the grading engine on a hypothetical student answer and returns the score and feedback it would get, to check answer keys, tolerances and normalization before the question goes live. Nothing is stored.
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Param request body services.TestGradeQuestionRequest true "Hypothetical answer, in the format students submit"
+// @Success 200 {object} services.SampleGradingResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/test-grade [post]
+func (h *QuestionHandler) TestGradeQuestion(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	var req services.TestGradeQuestionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	result, err := h.questionService.TestGrade(c.Request.Context(), id, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
 // GetQuestionUsageStats retrieves question usage statistics
 // @Summary Get question usage statistics
 // @Description Retrieves usage statistics for questions by creator
@@ -721,6 +941,430 @@ func (h *QuestionHandler) GetQuestionUsageStats(c *gin.Context) {
 	c.JSON(http.StatusOK, stats)
 }
 
+// GetQuestionTranslations lists a question's translations
+// @Summary Get question translations
+// @Description Lists the localized variants of a question with their review status
+// @Tags questions
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Success 200 {array} models.QuestionTranslation
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/translations [get]
+func (h *QuestionHandler) GetQuestionTranslations(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting question translations", "question_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	translations, err := h.questionService.GetTranslations(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, translations)
+}
+
+// UpsertQuestionTranslation creates or replaces a question's translation in a locale
+// @Summary Save question translation
+// @Description Saves the translation in a locale and queues it for review; when its wording changed, the question's other translations are marked stale
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Param locale path string true "BCP 47 locale, e.g. vi or pt-BR"
+// @Param translation body services.UpsertQuestionTranslationRequest true "Translation"
+// @Success 200 {object} models.QuestionTranslation
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/translations/{locale} [put]
+func (h *QuestionHandler) UpsertQuestionTranslation(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+	locale := c.Param("locale")
+
+	h.LogRequest(c, "Saving question translation", "question_id", id, "locale", locale)
+
+	var req services.UpsertQuestionTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	translation, err := h.questionService.UpsertTranslation(c.Request.Context(), id, locale, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, translation)
+}
+
+// DeleteQuestionTranslation removes a question's translation in a locale
+// @Summary Delete question translation
+// @Description Removes the localized variant of a question
+// @Tags questions
+// @Param id path uint true "Question ID"
+// @Param locale path string true "BCP 47 locale"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/translations/{locale} [delete]
+func (h *QuestionHandler) DeleteQuestionTranslation(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+	locale := c.Param("locale")
+
+	h.LogRequest(c, "Deleting question translation", "question_id", id, "locale", locale)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	if err := h.questionService.DeleteTranslation(c.Request.Context(), id, locale, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReviewQuestionTranslation approves a translation or requests changes
+// @Summary Review question translation
+// @Description Approves the translation against the current source, or sends it back with a note
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Param locale path string true "BCP 47 locale"
+// @Param review body services.ReviewQuestionTranslationRequest true "Review"
+// @Success 200 {object} models.QuestionTranslation
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/translations/{locale}/review [post]
+func (h *QuestionHandler) ReviewQuestionTranslation(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+	locale := c.Param("locale")
+
+	h.LogRequest(c, "Reviewing question translation", "question_id", id, "locale", locale)
+
+	var req services.ReviewQuestionTranslationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	translation, err := h.questionService.ReviewTranslation(c.Request.Context(), id, locale, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, translation)
+}
+
+// GetTranslationReviewQueue lists translations waiting for review
+// @Summary Get translation review queue
+// @Description Lists edited and stale translations on the user's questions, longest waiting first
+// @Tags questions
+// @Produce json
+// @Param locale query string false "Locale"
+// @Param status query string false "Status (pending_review, stale, changes_requested, approved); defaults to pending_review and stale"
+// @Param page query int false "Page number" default(1)
+// @Param size query int false "Page size" default(20)
+// @Success 200 {object} services.TranslationReviewQueue
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/translations/review-queue [get]
+func (h *QuestionHandler) GetTranslationReviewQueue(c *gin.Context) {
+	h.LogRequest(c, "Getting translation review queue")
+
+	page := h.parseIntQuery(c, "page", 1)
+	size := h.parseIntQuery(c, "size", 20)
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 || size > 100 {
+		size = 20
+	}
+
+	filters := repositories.TranslationReviewFilters{
+		Limit:  size,
+		Offset: (page - 1) * size,
+	}
+	if locale := c.Query("locale"); locale != "" {
+		filters.Locale = &locale
+	}
+	if status := c.Query("status"); status != "" {
+		translationStatus := models.TranslationStatus(status)
+		filters.Status = &translationStatus
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	queue, err := h.questionService.GetTranslationReviewQueue(c.Request.Context(), filters, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, queue)
+}
+
+// GetQuestionExternalRefs lists the IDs content providers give a question
+// @Summary Get question external refs
+// @Description Lists the question's ID at each content provider it was imported from or mapped to
+// @Tags questions
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Success 200 {array} models.QuestionExternalRef
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/external-refs [get]
+func (h *QuestionHandler) GetQuestionExternalRefs(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting question external refs", "question_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	refs, err := h.questionService.GetExternalRefs(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, refs)
+}
+
+// SetQuestionExternalRef sets the ID a content provider gives a question
+// @Summary Set question external ref
+// @Description Maps the question to a provider's ID, replacing its earlier ID from that provider. A provider's ID maps to one question per owner.
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param id path uint true "Question ID"
+// @Param provider path string true "Content provider, e.g. a publisher"
+// @Param ref body services.SetQuestionExternalRefRequest true "External ID"
+// @Success 200 {object} models.QuestionExternalRef
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/external-refs/{provider} [put]
+func (h *QuestionHandler) SetQuestionExternalRef(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+	provider := c.Param("provider")
+
+	h.LogRequest(c, "Setting question external ref", "question_id", id, "provider", provider)
+
+	var req services.SetQuestionExternalRefRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	ref, err := h.questionService.SetExternalRef(c.Request.Context(), id, provider, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ref)
+}
+
+// DeleteQuestionExternalRef removes a question's ID at a content provider
+// @Summary Delete question external ref
+// @Description Unmaps the question from the provider; a later import of the provider's content creates a new question
+// @Tags questions
+// @Param id path uint true "Question ID"
+// @Param provider path string true "Content provider"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/{id}/external-refs/{provider} [delete]
+func (h *QuestionHandler) DeleteQuestionExternalRef(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+	provider := c.Param("provider")
+
+	h.LogRequest(c, "Deleting question external ref", "question_id", id, "provider", provider)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	if err := h.questionService.DeleteExternalRef(c.Request.Context(), id, provider, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// LookupQuestionByExternalRef finds the question a provider's ID maps to
+// @Summary Look up question by external ref
+// @Description Finds the caller's question with the provider's ID; admins may look in another owner's questions
+// @Tags questions
+// @Produce json
+// @Param provider query string true "Content provider"
+// @Param external_id query string true "The provider's ID of the question"
+// @Param owner_id query string false "Owner of the question (admins only)"
+// @Success 200 {object} services.QuestionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/external-refs/lookup [get]
+func (h *QuestionHandler) LookupQuestionByExternalRef(c *gin.Context) {
+	provider := c.Query("provider")
+	externalID := c.Query("external_id")
+
+	h.LogRequest(c, "Looking up question by external ref", "provider", provider, "external_id", externalID)
+
+	var ownerID *string
+	if owner := c.Query("owner_id"); owner != "" {
+		ownerID = &owner
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	question, err := h.questionService.LookupExternalRef(c.Request.Context(), provider, externalID, ownerID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, question)
+}
+
+// ResolveQuestionExternalRefs maps many of a provider's IDs to questions
+// @Summary Resolve question external refs
+// @Description Maps up to 500 of a provider's IDs to the IDs of the caller's questions and lists those without a question
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param request body services.ResolveExternalRefsRequest true "Provider and IDs"
+// @Success 200 {object} services.ExternalRefResolution
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /questions/external-refs/resolve [post]
+func (h *QuestionHandler) ResolveQuestionExternalRefs(c *gin.Context) {
+	h.LogRequest(c, "Resolving question external refs")
+
+	var req services.ResolveExternalRefsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+	resolution, err := h.questionService.ResolveExternalRefs(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resolution)
+}
+
 // Helper methods
 
 func (h *QuestionHandler) getUserID(c *gin.Context) string {
@@ -789,6 +1433,21 @@ func (h *QuestionHandler) parseQuestionFilters(c *gin.Context) repositories.Ques
 		}
 	}
 
+	if locale := c.Query("locale"); locale != "" {
+		filters.Locale = &locale
+	}
+
+	if status := c.Query("translation_status"); status != "" {
+		translationStatus := models.TranslationStatus(status)
+		filters.TranslationStatus = &translationStatus
+	}
+
+	if draftStr := c.Query("draft"); draftStr != "" {
+		if draft, err := strconv.ParseBool(draftStr); err == nil {
+			filters.IsDraft = &draft
+		}
+	}
+
 	return filters
 }
 
@@ -828,6 +1487,16 @@ func (h *QuestionHandler) handleServiceError(c *gin.Context, err error) {
 		return
 	}
 
+	var contentLockedError *services.ContentLockedError
+	if errors.As(err, &contentLockedError) {
+		c.JSON(http.StatusLocked, ErrorResponse{
+			Message: "Content is locked while attempts are in progress",
+			Details: contentLockedError,
+			Code:    "content_locked",
+		})
+		return
+	}
+
 	// Handle specific question errors
 	switch {
 	case errors.Is(err, services.ErrQuestionNotFound):
@@ -905,6 +1574,10 @@ func (h *QuestionHandler) handleServiceError(c *gin.Context, err error) {
 		c.JSON(http.StatusNotFound, ErrorResponse{
 			Message: "User not found",
 		})
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Resource not found",
+		})
 	default:
 		h.LogError(c, err, "Unexpected service error")
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type ReportHandler struct {
+	BaseHandler
+	reportService services.ReportService
+	validator     *validator.Validator
+}
+
+func NewReportHandler(
+	reportService services.ReportService,
+	validator *validator.Validator,
+	logger utils.Logger,
+) *ReportHandler {
+	return &ReportHandler{
+		BaseHandler:   NewBaseHandler(logger),
+		reportService: reportService,
+		validator:     validator,
+	}
+}
+
+// CreateSubscription subscribes the current user to a scheduled report
+// @Summary Subscribe to report
+// @Description Subscribes the current teacher to a scheduled emailed report
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param request body services.CreateReportSubscriptionRequest true "Subscription data"
+// @Success 201 {object} models.ReportSubscription
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reports/subscriptions [post]
+func (h *ReportHandler) CreateSubscription(c *gin.Context) {
+	h.LogRequest(c, "Creating report subscription")
+
+	var req services.CreateReportSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	subscription, err := h.reportService.Subscribe(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, subscription)
+}
+
+// ListSubscriptions lists the current user's report subscriptions
+// @Summary List report subscriptions
+// @Description Lists scheduled report subscriptions of the current user
+// @Tags reports
+// @Produce json
+// @Success 200 {array} models.ReportSubscription
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reports/subscriptions [get]
+func (h *ReportHandler) ListSubscriptions(c *gin.Context) {
+	h.LogRequest(c, "Listing report subscriptions")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	subscriptions, err := h.reportService.ListSubscriptions(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscriptions)
+}
+
+// UpdateSubscription changes the schedule or state of a report subscription
+// @Summary Update report subscription
+// @Description Updates delivery day, hour, email or active state of a subscription
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param id path uint true "Subscription ID"
+// @Param request body services.UpdateReportSubscriptionRequest true "Subscription changes"
+// @Success 200 {object} models.ReportSubscription
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reports/subscriptions/{id} [put]
+func (h *ReportHandler) UpdateSubscription(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Updating report subscription", "subscription_id", id)
+
+	var req services.UpdateReportSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	subscription, err := h.reportService.UpdateSubscription(c.Request.Context(), id, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// DeleteSubscription unsubscribes from a scheduled report
+// @Summary Delete report subscription
+// @Description Removes a scheduled report subscription
+// @Tags reports
+// @Param id path uint true "Subscription ID"
+// @Success 204 "No content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reports/subscriptions/{id} [delete]
+func (h *ReportHandler) DeleteSubscription(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Deleting report subscription", "subscription_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.reportService.Unsubscribe(c.Request.Context(), id, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PreviewSubscription renders the report a subscription would deliver now
+// @Summary Preview report
+// @Description Renders the current report for a subscription without sending it
+// @Tags reports
+// @Produce json
+// @Param id path uint true "Subscription ID"
+// @Success 200 {object} services.RenderedReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reports/subscriptions/{id}/preview [get]
+func (h *ReportHandler) PreviewSubscription(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Previewing report", "subscription_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	report, err := h.reportService.PreviewReport(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Helper methods
+
+func (h *ReportHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *ReportHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Resource not found",
+		})
+	case errors.Is(err, services.ErrUnauthorized):
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "Unauthorized access",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type ResidencyHandler struct {
+	BaseHandler
+	residencyService services.ResidencyService
+}
+
+func NewResidencyHandler(
+	residencyService services.ResidencyService,
+	logger utils.Logger,
+) *ResidencyHandler {
+	return &ResidencyHandler{
+		BaseHandler:      NewBaseHandler(logger),
+		residencyService: residencyService,
+	}
+}
+
+// ProvisionOrganization pins an organization's data to a region
+// @Summary Provision organization residency
+// @Description Stores all of the organization's data, attempts and answers included, in the region's database and its uploads in the region's directory. Do this before the organization's users sign in; data they created earlier stays in the primary database. The region cannot be changed afterwards.
+// @Tags residency
+// @Accept json
+// @Produce json
+// @Param residency body services.ProvisionResidencyRequest true "Organization and region"
+// @Success 201 {object} models.OrganizationResidency
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /residency/organizations [post]
+func (h *ResidencyHandler) ProvisionOrganization(c *gin.Context) {
+	h.LogRequest(c, "Provisioning organization residency")
+
+	var req services.ProvisionResidencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	assignment, err := h.residencyService.ProvisionOrganization(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, assignment)
+}
+
+// ListOrganizationResidency lists the configured regions and the organizations pinned to them
+// @Summary List organization residency
+// @Description Organizations not listed are stored in the primary database
+// @Tags residency
+// @Produce json
+// @Success 200 {object} services.ResidencyOverview
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /residency/organizations [get]
+func (h *ResidencyHandler) ListOrganizationResidency(c *gin.Context) {
+	h.LogRequest(c, "Listing organization residency")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	overview, err := h.residencyService.ListOrganizationResidency(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, overview)
+}
+
+func (h *ResidencyHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	h.LogError(c, err, "Unexpected service error")
+	c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Message: "Internal server error",
+	})
+}
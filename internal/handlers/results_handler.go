@@ -0,0 +1,457 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"github.com/gin-gonic/gin"
+)
+
+type ResultsHandler struct {
+	BaseHandler
+	resultsService services.ResultsService
+	validator      *validator.Validator
+}
+
+func NewResultsHandler(
+	resultsService services.ResultsService,
+	validator *validator.Validator,
+	logger utils.Logger,
+) *ResultsHandler {
+	return &ResultsHandler{
+		BaseHandler:    NewBaseHandler(logger),
+		resultsService: resultsService,
+		validator:      validator,
+	}
+}
+
+// GetReleaseStatus reports whether results of an assessment are visible to students
+// @Summary Get results release status
+// @Description Returns the release mode, scheduled time and current release state of an assessment's results
+// @Tags results
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Success 200 {object} services.ResultsReleaseStatus
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /results/assessments/{assessment_id}/release [get]
+func (h *ResultsHandler) GetReleaseStatus(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting results release status", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	status, err := h.resultsService.GetReleaseStatus(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// ReleaseResults releases results of an assessment to students
+// @Summary Release results
+// @Description Makes scores and reviews visible to students, notifies them and records the release in the audit log
+// @Tags results
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Success 200 {object} services.ResultsReleaseStatus
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /results/assessments/{assessment_id}/release [post]
+func (h *ResultsHandler) ReleaseResults(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Releasing results", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	status, err := h.resultsService.ReleaseResults(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// GetFinalGrades returns each student's final grade
+// @Summary Get final grades
+// @Description Returns each student's final grade on the assessment under its grade policy: highest attempt, latest attempt or average of attempts
+// @Tags results
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Success 200 {object} services.FinalGradesReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /results/assessments/{assessment_id}/final-grades [get]
+func (h *ResultsHandler) GetFinalGrades(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Getting final grades", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	report, err := h.resultsService.GetFinalGrades(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportResults downloads an assessment's results or answers as CSV
+// @Summary Export results
+// @Description Downloads the finished attempts of an assessment, or their answers, as CSV. With redact=true student IDs and names are replaced by aliases that stay the same across exports of the assessment, and the student's name and email are also replaced in answers and feedback. Exports for external examiners or researchers must be redacted. Every export is audit logged.
+// @Tags results
+// @Produce text/csv
+// @Param assessment_id path uint true "Assessment ID"
+// @Param content query string false "results (one row per attempt, default) or answers (one row per answer)"
+// @Param recipient query string false "internal (default), external_examiner or researcher"
+// @Param redact query bool false "Pseudonymize student identities"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /results/assessments/{assessment_id}/export [get]
+func (h *ResultsHandler) ExportResults(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	req := services.ResultsExportRequest{
+		Content:   services.ResultsExportContent(c.Query("content")),
+		Recipient: services.ExportRecipient(c.Query("recipient")),
+	}
+	if redact := c.Query("redact"); redact != "" {
+		enabled, err := strconv.ParseBool(redact)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid redact",
+				Details: err.Error(),
+			})
+			return
+		}
+		req.Redact = enabled
+	}
+
+	h.LogRequest(c, "Exporting results", "assessment_id", assessmentID, "content", req.Content, "recipient", req.Recipient, "redact", req.Redact)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	document, err := h.resultsService.ExportResults(c.Request.Context(), assessmentID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.FileName))
+	c.Data(http.StatusOK, document.ContentType, document.Content)
+}
+
+// GetStudentTranscript returns a student's consolidated transcript
+// @Summary Get student transcript
+// @Description Lists the student's final grade on each assessment with released results, under each assessment's grade policy, with a letter grade and an unweighted average. Students see their own transcript, admins any student's, and teachers the entries of their own assessments. Use format=pdf or format=csv to download it.
+// @Tags results
+// @Produce json
+// @Produce application/pdf
+// @Produce text/csv
+// @Param student_id path string true "Student ID"
+// @Param term query string false "Only assessments of this term"
+// @Param class_id query string false "Only assessments the student took with this class"
+// @Param format query string false "json (default), pdf or csv"
+// @Success 200 {object} services.StudentTranscript
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /students/{student_id}/transcript [get]
+func (h *ResultsHandler) GetStudentTranscript(c *gin.Context) {
+	studentID := ParseStringIDParam(c, "student_id")
+	if studentID == "" {
+		return
+	}
+
+	var filters services.StudentTranscriptFilters
+	if term := strings.TrimSpace(c.Query("term")); term != "" {
+		filters.Term = &term
+	}
+	if classID := strings.TrimSpace(c.Query("class_id")); classID != "" {
+		filters.ClassID = &classID
+	}
+	format := c.DefaultQuery("format", "json")
+
+	h.LogRequest(c, "Getting student transcript", "student_id", studentID, "format", format)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if format == "json" {
+		transcript, err := h.resultsService.GetStudentTranscript(c.Request.Context(), studentID, filters, userID.(string))
+		if err != nil {
+			h.handleServiceError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, transcript)
+		return
+	}
+
+	document, err := h.resultsService.ExportStudentTranscript(c.Request.Context(), studentID, filters, format, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.FileName))
+	c.Data(http.StatusOK, document.ContentType, document.Content)
+}
+
+// SampleForResearch draws an anonymized research sample of an assessment's students
+// @Summary Sample results for research
+// @Description Draws a random sample of the students who finished the assessment, stratified by the score of their latest finished attempt. Students appear under aliases that are new in every sample. Needs a research policy of the assessment owner's organization with sampling enabled, consent, and an ethics approval that has not ended. Score bands with fewer students than the policy's minimum are left out, and answer texts are included only where the policy allows them. Every sample is audit logged.
+// @Tags results
+// @Accept json
+// @Produce json
+// @Param assessment_id path uint true "Assessment ID"
+// @Param request body services.ResearchSampleRequest true "Sample size, score bands and seed"
+// @Success 200 {object} services.ResearchSample
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /results/assessments/{assessment_id}/research-sample [post]
+func (h *ResultsHandler) SampleForResearch(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "assessment_id")
+	if assessmentID == 0 {
+		return
+	}
+
+	var req services.ResearchSampleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.LogRequest(c, "Sampling results for research", "assessment_id", assessmentID, "sample_size", req.SampleSize)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	sample, err := h.resultsService.SampleForResearch(c.Request.Context(), assessmentID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sample)
+}
+
+// SetResearchPolicy sets whether an organization's data may be sampled for research
+// @Summary Set research policy
+// @Description Creates or replaces an organization's research policy. Enabling sampling needs consent_obtained, an ethics_approval reference and an approved_until in the future.
+// @Tags results
+// @Accept json
+// @Produce json
+// @Param request body services.SetResearchPolicyRequest true "Research policy"
+// @Success 200 {object} models.ResearchPolicy
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /research/policies [put]
+func (h *ResultsHandler) SetResearchPolicy(c *gin.Context) {
+	var req services.SetResearchPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.LogRequest(c, "Setting research policy", "organization", req.Organization)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	policy, err := h.resultsService.SetResearchPolicy(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// ListResearchPolicies lists the organizations' research policies
+// @Summary List research policies
+// @Description Lists every organization's research policy
+// @Tags results
+// @Produce json
+// @Success 200 {array} models.ResearchPolicy
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /research/policies [get]
+func (h *ResultsHandler) ListResearchPolicies(c *gin.Context) {
+	h.LogRequest(c, "Listing research policies")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	policies, err := h.resultsService.ListResearchPolicies(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policies)
+}
+
+// Helper methods
+
+func (h *ResultsHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *ResultsHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case services.IsNotFound(err):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Resource not found",
+		})
+	case errors.Is(err, services.ErrUnauthorized):
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "Unauthorized access",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
 	"github.com/SAP-F-2025/assessment-service/internal/config"
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
@@ -12,12 +13,32 @@ import (
 )
 
 type HandlerManager struct {
-	assessmentHandler   *AssessmentHandler
-	questionHandler     *QuestionHandler
-	questionBankHandler *QuestionBankHandler
-	attemptHandler      *AttemptHandler
-	gradingHandler      *GradingHandler
-	authMiddleware      *CasdoorAuthMiddleware
+	assessmentHandler    *AssessmentHandler
+	questionHandler      *QuestionHandler
+	questionBankHandler  *QuestionBankHandler
+	attemptHandler       *AttemptHandler
+	gradingHandler       *GradingHandler
+	resultsHandler       *ResultsHandler
+	reportHandler        *ReportHandler
+	favoriteHandler      *FavoriteHandler
+	attachmentHandler    *AttachmentHandler
+	gradebookHandler     *GradebookHandler
+	extensionHandler     *ExtensionHandler
+	shareLinkHandler     *ShareLinkHandler
+	importHandler        *ImportHandler
+	analyticsHandler     *AnalyticsHandler
+	metricsHandler       *MetricsHandler
+	impersonationHandler *ImpersonationHandler
+	notificationHandler  *NotificationHandler
+	usageHandler         *UsageHandler
+	warehouseHandler     *WarehouseHandler
+	residencyHandler     *ResidencyHandler
+	webhookHandler       *WebhookHandler
+	customFieldHandler   *CustomFieldHandler
+	jobHandler           *JobHandler
+	practiceHandler      *PracticeHandler
+	cacheHandler         *CacheHandler
+	authMiddleware       *CasdoorAuthMiddleware
 }
 
 func NewHandlerManager(
@@ -26,16 +47,38 @@ func NewHandlerManager(
 	logger utils.Logger,
 	casdoorConfig config.CasdoorConfig,
 	userRepo repositories.UserRepository,
+	compressor *cache.Compressor,
+	redisClient *cache.Client,
 ) *HandlerManager {
-	authMiddleware := NewCasdoorAuthMiddleware(casdoorConfig, userRepo)
+	authMiddleware := NewCasdoorAuthMiddleware(casdoorConfig, userRepo, serviceManager.Impersonation(), serviceManager.Residency())
 
 	return &HandlerManager{
-		assessmentHandler:   NewAssessmentHandler(serviceManager.Assessment(), validator, logger),
-		questionHandler:     NewQuestionHandler(serviceManager.Question(), validator, logger),
-		questionBankHandler: NewQuestionBankHandler(serviceManager.QuestionBank(), logger),
-		attemptHandler:      NewAttemptHandler(serviceManager.Attempt(), validator, logger),
-		gradingHandler:      NewGradingHandler(serviceManager.Grading(), validator, logger),
-		authMiddleware:      authMiddleware,
+		assessmentHandler:    NewAssessmentHandler(serviceManager.Assessment(), validator, logger),
+		questionHandler:      NewQuestionHandler(serviceManager.Question(), validator, logger),
+		questionBankHandler:  NewQuestionBankHandler(serviceManager.QuestionBank(), logger),
+		attemptHandler:       NewAttemptHandler(serviceManager.Attempt(), validator, logger),
+		gradingHandler:       NewGradingHandler(serviceManager.Grading(), validator, logger),
+		resultsHandler:       NewResultsHandler(serviceManager.Results(), validator, logger),
+		reportHandler:        NewReportHandler(serviceManager.Report(), validator, logger),
+		favoriteHandler:      NewFavoriteHandler(serviceManager.Favorite(), validator, logger),
+		attachmentHandler:    NewAttachmentHandler(serviceManager.Attachment(), logger),
+		gradebookHandler:     NewGradebookHandler(serviceManager.Gradebook(), logger),
+		extensionHandler:     NewExtensionHandler(serviceManager.Extension(), logger),
+		shareLinkHandler:     NewShareLinkHandler(serviceManager.ShareLink(), logger),
+		importHandler:        NewImportHandler(serviceManager.ImportExport(), logger),
+		analyticsHandler:     NewAnalyticsHandler(serviceManager.Analytics(), logger),
+		metricsHandler:       NewMetricsHandler(serviceManager.LiveMetrics(), compressor, logger),
+		impersonationHandler: NewImpersonationHandler(serviceManager.Impersonation(), logger),
+		notificationHandler:  NewNotificationHandler(serviceManager.NotificationEvents(), logger),
+		usageHandler:         NewUsageHandler(serviceManager.Usage(), logger),
+		warehouseHandler:     NewWarehouseHandler(serviceManager.Warehouse(), logger),
+		residencyHandler:     NewResidencyHandler(serviceManager.Residency(), logger),
+		webhookHandler:       NewWebhookHandler(serviceManager.Webhook(), logger),
+		customFieldHandler:   NewCustomFieldHandler(serviceManager.CustomField(), logger),
+		jobHandler:           NewJobHandler(serviceManager.Jobs(), logger),
+		practiceHandler:      NewPracticeHandler(serviceManager.Practice(), logger),
+		cacheHandler:         NewCacheHandler(redisClient, logger),
+		authMiddleware:       authMiddleware,
 	}
 }
 
@@ -44,6 +87,18 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 	// Health check endpoint
 	// router.GET("/health", HealthCheck)
 
+	// Prometheus scrape endpoint; kept outside the API so scrapers need no user token
+	router.GET("/metrics", hm.metricsHandler.GetMetrics)
+
+	// Shared assessments for external reviewers; the share link token stands in for a user token
+	shared := router.Group("/api/v1/shared")
+	{
+		shared.GET("/assessments/:token", hm.shareLinkHandler.ShareTokenMiddleware(), hm.shareLinkHandler.GetSharedAssessment)
+	}
+
+	// Question images, pulled by the media CDN; file names are unguessable and never reused
+	router.GET("/media/*key", hm.attachmentHandler.ServeQuestionMedia)
+
 	// API v1 routes with authentication
 	v1 := router.Group("/api/v1")
 	v1.Use(hm.authMiddleware.AuthMiddleware()) // Apply authentication to all API routes
@@ -53,26 +108,57 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 		{
 			// Create/modify assessments - Teachers and Admins only
 			assessments.POST("", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.CreateAssessment)
+			assessments.POST("/build", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.BuildAssessment)
+			assessments.POST("/series", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.GenerateSeries)
 			assessments.PUT("/:id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.UpdateAssessment)
 			assessments.DELETE("/:id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.DeleteAssessment)
 			assessments.PUT("/:id/status", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.UpdateAssessmentStatus)
 			assessments.POST("/:id/publish", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.PublishAssessment)
 			assessments.POST("/:id/archive", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.ArchiveAssessment)
+			assessments.GET("/:id/readiness", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.GetReadinessReport)
 
 			// View assessments - All authenticated users
 			assessments.GET("", hm.assessmentHandler.ListAssessments)
 			assessments.GET("/search", hm.assessmentHandler.SearchAssessments)
 			assessments.GET("/:id", hm.assessmentHandler.GetAssessment)
 			assessments.GET("/:id/details", hm.assessmentHandler.GetAssessmentWithDetails)
+			assessments.GET("/:id/deadline", hm.assessmentHandler.GetDeadline)
 
 			// Stats - Teachers and Admins only
 			assessments.GET("/:id/stats", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.GetAssessmentStats)
 
+			// Enrollment and access audit - Teachers and Admins only
+			assessments.POST("/:id/enrollments", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.EnrollStudents)
+			assessments.DELETE("/:id/enrollments/:student_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.UnenrollStudent)
+			assessments.GET("/:id/access-audit", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.GetAccessAudit)
+			assessments.GET("/:id/timeline", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.GetTimeline)
+
+			// Per-class overrides - Teachers and Admins only
+			assessments.GET("/:id/class-overrides", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.ListClassOverrides)
+			assessments.PUT("/:id/class-overrides/:class_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.SetClassOverride)
+			assessments.DELETE("/:id/class-overrides/:class_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.DeleteClassOverride)
+
+			// Extension requests - Students ask, Teachers and Admins see what was granted
+			assessments.POST("/:id/extension-requests", hm.authMiddleware.RequireRoleMiddleware(models.RoleStudent), hm.extensionHandler.RequestExtension)
+			assessments.GET("/:id/accommodations", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.extensionHandler.ListAccommodations)
+
+			// Share links for external reviewers - Teachers and Admins only
+			assessments.POST("/:id/share-links", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.shareLinkHandler.CreateShareLink)
+			assessments.GET("/:id/share-links", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.shareLinkHandler.ListShareLinks)
+
 			// Assessment question management - Teachers and Admins only
 			// Single question operations
 			assessments.POST("/:id/questions/:question_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.AddQuestionToAssessment)
 			assessments.DELETE("/:id/questions/:question_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.RemoveQuestionFromAssessment)
 			assessments.PUT("/:id/questions/:question_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.UpdateAssessmentQuestion)
+			assessments.PUT("/:id/questions/:question_id/branching", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.SetQuestionBranching)
+
+			// Question trials
+			assessments.POST("/:id/trials", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.StartQuestionTrial)
+			assessments.GET("/:id/trials", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.ListQuestionTrials)
+			assessments.GET("/:id/trials/:trial_id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.CompareQuestionTrial)
+			assessments.POST("/:id/trials/:trial_id/promote", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.PromoteQuestionTrial)
+			assessments.POST("/:id/trials/:trial_id/discard", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.DiscardQuestionTrial)
 
 			// Batch operations
 			assessments.POST("/:id/questions/batch", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.assessmentHandler.AddQuestionsToAssessment)
@@ -91,8 +177,11 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 		questions := v1.Group("/questions")
 		{
 			questions.POST("", hm.questionHandler.CreateQuestion)
+			questions.POST("/drafts", hm.questionHandler.CreateQuestionDraft)
 			questions.POST("/batch", hm.questionHandler.CreateQuestionsBatch)
 			questions.PUT("/batch", hm.questionHandler.UpdateQuestionsBatch)
+			questions.POST("/bulk-reassign/preview", hm.questionHandler.PreviewBulkReassignQuestions)
+			questions.POST("/bulk-reassign", hm.questionHandler.BulkReassignQuestions)
 			questions.GET("", hm.questionHandler.ListQuestions)
 			questions.GET("/search", hm.questionHandler.SearchQuestions)
 			questions.GET("/random", hm.questionHandler.GetRandomQuestions)
@@ -101,6 +190,26 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 			questions.PUT("/:id", hm.questionHandler.UpdateQuestion)
 			questions.DELETE("/:id", hm.questionHandler.DeleteQuestion)
 			questions.GET("/:id/stats", hm.questionHandler.GetQuestionStats)
+			questions.POST("/:id/test-grade", hm.questionHandler.TestGradeQuestion)
+			questions.POST("/:id/finalize", hm.questionHandler.FinalizeQuestionDraft)
+
+			// Question images
+			questions.POST("/:id/attachments", hm.attachmentHandler.UploadQuestionImage)
+			questions.DELETE("/:id/attachments/:attachment_id", hm.attachmentHandler.DeleteQuestionImage)
+
+			// Translations and their review
+			questions.GET("/translations/review-queue", hm.questionHandler.GetTranslationReviewQueue)
+			questions.GET("/:id/translations", hm.questionHandler.GetQuestionTranslations)
+			questions.PUT("/:id/translations/:locale", hm.questionHandler.UpsertQuestionTranslation)
+			questions.DELETE("/:id/translations/:locale", hm.questionHandler.DeleteQuestionTranslation)
+			questions.POST("/:id/translations/:locale/review", hm.questionHandler.ReviewQuestionTranslation)
+
+			// IDs content providers give questions
+			questions.GET("/external-refs/lookup", hm.questionHandler.LookupQuestionByExternalRef)
+			questions.POST("/external-refs/resolve", hm.questionHandler.ResolveQuestionExternalRefs)
+			questions.GET("/:id/external-refs", hm.questionHandler.GetQuestionExternalRefs)
+			questions.PUT("/:id/external-refs/:provider", hm.questionHandler.SetQuestionExternalRef)
+			questions.DELETE("/:id/external-refs/:provider", hm.questionHandler.DeleteQuestionExternalRef)
 
 			// Question bank management
 			questions.GET("/bank/:bank_id", hm.questionHandler.GetQuestionsByBank)
@@ -137,6 +246,20 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 			questionBanks.POST("/:id/questions", hm.questionBankHandler.AddQuestionsToBank)
 			questionBanks.DELETE("/:id/questions", hm.questionBankHandler.RemoveQuestionsFromBank)
 			questionBanks.GET("/:id/questions", hm.questionBankHandler.GetBankQuestions)
+			questionBanks.POST("/:id/questions/reviewed", hm.questionBankHandler.MarkQuestionsReviewed)
+			questionBanks.GET("/:id/stale-questions", hm.questionBankHandler.GetStaleQuestions)
+			questionBanks.GET("/:id/changes", hm.questionBankHandler.GetQuestionBankChanges)
+
+			// Merge and deduplication
+			questionBanks.POST("/:id/merge", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.questionBankHandler.MergeQuestionBanks)
+
+			// Licensing
+			questionBanks.PUT("/:id/license", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.questionBankHandler.UpdateQuestionBankLicense)
+			questionBanks.GET("/:id/license-usage", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.questionBankHandler.GetQuestionBankLicenseUsage)
+
+			// Practice sets
+			questionBanks.POST("/:id/practice-sets", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.practiceHandler.CreatePracticeSet)
+			questionBanks.GET("/:id/practice-sets", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.practiceHandler.ListBankPracticeSets)
 
 			// Creator-specific routes
 			questionBanks.GET("/creator/:creator_id", hm.questionBankHandler.GetQuestionBanksByCreator)
@@ -150,16 +273,39 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 			attempts.GET("", hm.attemptHandler.ListAttempts)
 			attempts.GET("/:id", hm.attemptHandler.GetAttempt)
 			attempts.GET("/:id/details", hm.attemptHandler.GetAttemptWithDetails)
+			attempts.GET("/:id/breakdown", hm.attemptHandler.GetAttemptBreakdown)
+			attempts.GET("/:id/export", hm.attemptHandler.ExportAttemptTranscript)
+			attempts.POST("/:id/offline-bundles", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.attemptHandler.ExportOfflineBundle)
+			attempts.POST("/:id/offline-bundles/:bundle_id/import", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.attemptHandler.ImportOfflineBundle)
 			attempts.POST("/:id/resume", hm.attemptHandler.ResumeAttempt)
+			attempts.POST("/:id/save-and-exit", hm.attemptHandler.SaveAndExit)
 			attempts.POST("/:id/answer", hm.attemptHandler.SubmitAnswer)
+			attempts.PUT("/:id/questions/:question_id/flag", hm.attemptHandler.FlagQuestion)
+			attempts.PUT("/:id/questions/:question_id/bookmark", hm.attemptHandler.BookmarkQuestion)
+			attempts.GET("/:id/bookmarks", hm.attemptHandler.GetBookmarks)
+			attempts.POST("/:id/questions/:question_id/visit", hm.attemptHandler.RecordQuestionVisit)
+			attempts.POST("/:id/questions/:question_id/spell-check", hm.attemptHandler.SpellCheckAnswer)
+			attempts.POST("/:id/questions/:question_id/attachments", hm.attachmentHandler.UploadAttachment)
+			attempts.GET("/:id/questions/:question_id/attachments", hm.attachmentHandler.ListAttachments)
+			attempts.GET("/:id/current-question", hm.attemptHandler.GetCurrentQuestion)
+			attempts.POST("/:id/advance", hm.attemptHandler.AdvanceQuestion)
+			attempts.GET("/:id/next-question", hm.attemptHandler.GetNextQuestion)
+			attempts.GET("/:id/sections", hm.attemptHandler.GetSections)
+			attempts.POST("/:id/sections/complete", hm.attemptHandler.CompleteSection)
+			attempts.GET("/:id/submission-summary", hm.attemptHandler.GetSubmissionSummary)
 			attempts.GET("/:id/time-remaining", hm.attemptHandler.GetTimeRemaining)
 			attempts.POST("/:id/extend", hm.attemptHandler.ExtendTime)
+			attempts.PUT("/:id/custom-fields", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.attemptHandler.UpdateCustomFields)
 			attempts.POST("/:id/timeout", hm.attemptHandler.HandleTimeout)
 			attempts.GET("/:id/is-active", hm.attemptHandler.IsAttemptActive)
 
 			// Assessment-specific routes
 			attempts.GET("/current/:assessment_id", hm.attemptHandler.GetCurrentAttempt)
 			attempts.GET("/can-start/:assessment_id", hm.attemptHandler.CanStartAttempt)
+			attempts.POST("/queue/:assessment_id", hm.attemptHandler.JoinAttemptQueue)
+			attempts.GET("/queue/:assessment_id", hm.attemptHandler.GetAttemptQueueStatus)
+			attempts.DELETE("/queue/:assessment_id", hm.attemptHandler.LeaveAttemptQueue)
+			attempts.GET("/quota-status", hm.attemptHandler.GetAttemptQuotaStatus)
 			attempts.GET("/count/:assessment_id", hm.attemptHandler.GetAttemptCount)
 			attempts.GET("/assessment/:assessment_id", hm.attemptHandler.GetAttemptsByAssessment)
 			attempts.GET("/stats/:assessment_id", hm.attemptHandler.GetAttemptStats)
@@ -168,6 +314,34 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 			attempts.GET("/student/:student_id", hm.attemptHandler.GetAttemptsByStudent)
 		}
 
+		// Answer attachment files - the student who uploaded them and the attempt's graders
+		attachments := v1.Group("/attachments")
+		{
+			attachments.GET("/:id/file", hm.attachmentHandler.GetAttachmentFile)
+		}
+
+		// Extension requests - the student who asked, the assessment's teacher and admins
+		extensionRequests := v1.Group("/extension-requests")
+		{
+			extensionRequests.GET("/mine", hm.authMiddleware.RequireRoleMiddleware(models.RoleStudent), hm.extensionHandler.ListMyRequests)
+			extensionRequests.POST("/:id/withdraw", hm.authMiddleware.RequireRoleMiddleware(models.RoleStudent), hm.extensionHandler.WithdrawRequest)
+			extensionRequests.GET("/:id", hm.extensionHandler.GetRequest)
+			extensionRequests.GET("/:id/attachment", hm.extensionHandler.GetAttachment)
+
+			// Decision queue - Teachers and Admins only
+			extensionRequests.GET("", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.extensionHandler.GetQueue)
+			extensionRequests.POST("/:id/approve", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.extensionHandler.ApproveRequest)
+			extensionRequests.POST("/:id/deny", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.extensionHandler.DenyRequest)
+		}
+
+		// Share link management - Teachers and Admins only
+		shareLinks := v1.Group("/share-links")
+		shareLinks.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin))
+		{
+			shareLinks.POST("/:id/revoke", hm.shareLinkHandler.RevokeShareLink)
+			shareLinks.GET("/:id/views", hm.shareLinkHandler.GetShareLinkViews)
+		}
+
 		// Grading routes - Teachers, Proctors and Admins only
 		grading := v1.Group("/grading")
 		grading.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleProctor, models.RoleAdmin))
@@ -175,6 +349,7 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 			// Manual grading
 			grading.POST("/answers/:answer_id", hm.gradingHandler.GradeAnswer)
 			grading.POST("/answers/batch", hm.gradingHandler.GradeMultipleAnswers)
+			grading.POST("/answers/:answer_id/parts/:part_id", hm.gradingHandler.GradeAnswerPart)
 			grading.POST("/attempts/:attempt_id", hm.gradingHandler.GradeAttempt)
 
 			// Auto grading
@@ -190,8 +365,289 @@ func (hm *HandlerManager) SetupRoutes(router *gin.Engine) {
 			grading.POST("/questions/:question_id/regrade", hm.gradingHandler.ReGradeQuestion)
 			grading.POST("/assessments/:assessment_id/regrade", hm.gradingHandler.ReGradeAssessment)
 
+			// Answer key changes
+			grading.GET("/questions/:question_id/answer-key-changes", hm.gradingHandler.GetAnswerKeyChanges)
+			grading.GET("/answer-key-changes/:change_id/report", hm.gradingHandler.GetAnswerKeyChangeReport)
+			grading.POST("/answer-key-changes/:change_id/assessments/:assessment_id/regrade", hm.gradingHandler.ScheduleRegrade)
+			grading.GET("/regrade-jobs/:job_id", hm.gradingHandler.GetRegradeJob)
+
+			// Questions many students flagged during the assessment
+			grading.GET("/assessments/:assessment_id/flag-escalations", hm.gradingHandler.GetFlagEscalations)
+			grading.POST("/flag-escalations/:escalation_id/resolve", hm.gradingHandler.ResolveFlagEscalation)
+
 			// Grading overview
 			grading.GET("/assessments/:assessment_id/overview", hm.gradingHandler.GetGradingOverview)
+			grading.GET("/assessments/:assessment_id/answers", hm.gradingHandler.GetAnswersByGradingStatus)
+
+			// Grading queue
+			grading.GET("/pending", hm.gradingHandler.GetPendingGrading)
+
+			// Feedback search
+			grading.GET("/feedback/search", hm.gradingHandler.SearchFeedback)
+
+			// Moderation review sampling
+			grading.POST("/assessments/:assessment_id/review-samples", hm.gradingHandler.SampleAnswersForReview)
+			grading.GET("/assessments/:assessment_id/reliability", hm.gradingHandler.GetGraderReliabilityReport)
+			grading.GET("/graders/:grader_id/stats", hm.gradingHandler.GetGraderStats)
+			grading.GET("/reviews/pending", hm.gradingHandler.GetPendingReviews)
+			grading.POST("/reviews/:review_id", hm.gradingHandler.SubmitAnswerReview)
+
+			// Comment bank
+			grading.GET("/questions/:question_id/comments", hm.gradingHandler.GetCommentBank)
+			grading.POST("/questions/:question_id/comments", hm.gradingHandler.AddFeedbackComment)
+			grading.PUT("/comments/:comment_id", hm.gradingHandler.UpdateFeedbackComment)
+			grading.DELETE("/comments/:comment_id", hm.gradingHandler.DeleteFeedbackComment)
+
+			// Answer annotations
+			grading.GET("/answers/:answer_id/annotations", hm.gradingHandler.GetAnswerAnnotations)
+			grading.POST("/answers/:answer_id/annotations", hm.gradingHandler.AddAnswerAnnotation)
+			grading.PUT("/annotations/:annotation_id", hm.gradingHandler.UpdateAnswerAnnotation)
+			grading.DELETE("/annotations/:annotation_id", hm.gradingHandler.DeleteAnswerAnnotation)
+
+			// Final score overrides
+			grading.PUT("/attempts/:attempt_id/score-override", hm.gradingHandler.OverrideAttemptScore)
+			grading.GET("/attempts/:attempt_id/score-overrides", hm.gradingHandler.GetScoreOverrideHistory)
+
+			// Grade change audit
+			grading.GET("/answers/:answer_id/grade-history", hm.gradingHandler.GetAnswerGradeHistory)
+			grading.GET("/attempts/:attempt_id/grade-history", hm.gradingHandler.GetAttemptGradeHistory)
+
+			// Grading delegation
+			grading.POST("/assessments/:assessment_id/delegations", hm.gradingHandler.CreateGradingDelegation)
+			grading.GET("/assessments/:assessment_id/delegations", hm.gradingHandler.ListGradingDelegations)
+			grading.GET("/delegations/mine", hm.gradingHandler.ListMyGradingDelegations)
+			grading.POST("/delegations/:delegation_id/revoke", hm.gradingHandler.RevokeGradingDelegation)
+			grading.GET("/delegations/:delegation_id/audit", hm.gradingHandler.GetGradingDelegationAudit)
+
+			// Handwritten answer uploads
+			grading.GET("/attempts/:attempt_id/questions/:question_id/attachments", hm.attachmentHandler.GetAttachmentsForGrading)
+			grading.GET("/assessments/:assessment_id/attachments/search", hm.attachmentHandler.SearchAttachments)
+			grading.POST("/attachments/:attachment_id/ocr", hm.attachmentHandler.RerunOCR)
+		}
+
+		// Question import jobs - Teachers and Admins only
+		importJobs := v1.Group("/import-jobs")
+		importJobs.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin))
+		{
+			importJobs.POST("", hm.importHandler.CreateImportJob)
+			importJobs.GET("/:id", hm.importHandler.GetImportJob)
+			importJobs.DELETE("/:id", hm.importHandler.CancelImportJob)
+		}
+
+		// Results release routes - Teachers and Admins only
+		results := v1.Group("/results")
+		results.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin))
+		{
+			results.GET("/assessments/:assessment_id/release", hm.resultsHandler.GetReleaseStatus)
+			results.POST("/assessments/:assessment_id/release", hm.resultsHandler.ReleaseResults)
+			results.GET("/assessments/:assessment_id/final-grades", hm.resultsHandler.GetFinalGrades)
+			results.GET("/assessments/:assessment_id/export", hm.resultsHandler.ExportResults)
+			results.POST("/assessments/:assessment_id/research-sample", hm.resultsHandler.SampleForResearch)
+		}
+
+		// Grade passback to external gradebooks - Teachers and Admins only
+		gradebooks := v1.Group("/gradebooks")
+		gradebooks.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin))
+		{
+			gradebooks.POST("/assessments/:assessment_id/integrations", hm.gradebookHandler.CreateIntegration)
+			gradebooks.GET("/assessments/:assessment_id/integrations", hm.gradebookHandler.ListIntegrations)
+			gradebooks.PUT("/integrations/:id", hm.gradebookHandler.UpdateIntegration)
+			gradebooks.DELETE("/integrations/:id", hm.gradebookHandler.DeleteIntegration)
+			gradebooks.GET("/assessments/:assessment_id/passbacks", hm.gradebookHandler.ListPassbacks)
+			gradebooks.POST("/assessments/:assessment_id/resync", hm.gradebookHandler.Resync)
+		}
+
+		// Bulk notifications by audience filter - Teachers and Admins only
+		notifications := v1.Group("/notifications")
+		notifications.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin))
+		{
+			notifications.POST("/bulk/preview", hm.notificationHandler.PreviewAudience)
+			notifications.POST("/bulk", hm.notificationHandler.SendAudienceNotification)
+		}
+
+		// Notification preferences of the signed-in user
+		notificationPreferences := v1.Group("/notification-preferences")
+		{
+			notificationPreferences.GET("", hm.notificationHandler.GetNotificationPreferences)
+			notificationPreferences.PUT("", hm.notificationHandler.UpdateNotificationPreferences)
+		}
+
+		// Support impersonation - Admins only. Requests made while impersonating carry the
+		// impersonated user's role, so these routes are out of reach until the admin stops.
+		impersonation := v1.Group("/impersonation")
+		impersonation.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleAdmin))
+		{
+			impersonation.POST("", hm.impersonationHandler.StartImpersonation)
+			impersonation.GET("", hm.impersonationHandler.ListImpersonations)
+			impersonation.DELETE("/:id", hm.impersonationHandler.EndImpersonation)
+			impersonation.GET("/:id/audit", hm.impersonationHandler.GetImpersonationAudit)
+		}
+
+		// Duplicate attempt repair - Admins only
+		attemptRepair := v1.Group("/attempt-repair")
+		attemptRepair.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleAdmin))
+		{
+			attemptRepair.GET("/duplicates", hm.attemptHandler.FindDuplicateAttempts)
+			attemptRepair.POST("/merge", hm.attemptHandler.MergeDuplicateAttempts)
+			attemptRepair.GET("/attempts/:id/log", hm.attemptHandler.GetAttemptRepairLog)
+		}
+
+		// Attempt quotas across a bank's assessments - Admins only
+		attemptQuotas := v1.Group("/attempt-quotas")
+		attemptQuotas.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleAdmin))
+		{
+			attemptQuotas.POST("", hm.attemptHandler.CreateAttemptQuota)
+			attemptQuotas.GET("", hm.attemptHandler.ListAttemptQuotas)
+			attemptQuotas.PUT("/:id", hm.attemptHandler.UpdateAttemptQuota)
+			attemptQuotas.DELETE("/:id", hm.attemptHandler.DeleteAttemptQuota)
+		}
+
+		// Billable usage per organization, for invoicing - Admins only
+		usage := v1.Group("/usage")
+		usage.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleAdmin))
+		{
+			usage.GET("", hm.usageHandler.GetUsageReport)
+			usage.GET("/export", hm.usageHandler.ExportUsageReport)
+		}
+
+		// Nightly export of organization facts to data warehouses - Admins only
+		warehouse := v1.Group("/warehouse")
+		warehouse.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleAdmin))
+		{
+			warehouse.GET("/schema", hm.warehouseHandler.GetSchema)
+			warehouse.POST("/connectors", hm.warehouseHandler.CreateConnector)
+			warehouse.GET("/connectors", hm.warehouseHandler.ListConnectors)
+			warehouse.PUT("/connectors/:id", hm.warehouseHandler.UpdateConnector)
+			warehouse.DELETE("/connectors/:id", hm.warehouseHandler.DeleteConnector)
+			warehouse.GET("/connectors/:id/runs", hm.warehouseHandler.ListRuns)
+			warehouse.POST("/connectors/:id/backfill", hm.warehouseHandler.RequestBackfill)
+		}
+
+		// Event webhooks for integration partners, with a test and replay console - Admins only
+		webhooks := v1.Group("/webhooks")
+		webhooks.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleAdmin))
+		{
+			webhooks.POST("", hm.webhookHandler.CreateSubscription)
+			webhooks.GET("", hm.webhookHandler.ListSubscriptions)
+			webhooks.PUT("/:id", hm.webhookHandler.UpdateSubscription)
+			webhooks.DELETE("/:id", hm.webhookHandler.DeleteSubscription)
+			webhooks.POST("/:id/test", hm.webhookHandler.SendTestEvent)
+			webhooks.GET("/:id/deliveries", hm.webhookHandler.ListDeliveries)
+			webhooks.POST("/deliveries/:delivery_id/replay", hm.webhookHandler.ReplayDelivery)
+		}
+
+		// Data residency regions organizations are pinned to - Admins only
+		residency := v1.Group("/residency")
+		residency.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleAdmin))
+		{
+			residency.POST("/organizations", hm.residencyHandler.ProvisionOrganization)
+			residency.GET("/organizations", hm.residencyHandler.ListOrganizationResidency)
+		}
+
+		// Per-organization consent and ethics approval for research samples - Admins only
+		research := v1.Group("/research")
+		research.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleAdmin))
+		{
+			research.PUT("/policies", hm.resultsHandler.SetResearchPolicy)
+			research.GET("/policies", hm.resultsHandler.ListResearchPolicies)
+		}
+
+		// Per-organization custom fields on assessments and attempts - Admins only
+		customFields := v1.Group("/custom-fields")
+		customFields.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleAdmin))
+		{
+			customFields.POST("", hm.customFieldHandler.CreateDefinition)
+			customFields.GET("", hm.customFieldHandler.ListDefinitions)
+			customFields.PUT("/:id", hm.customFieldHandler.UpdateDefinition)
+			customFields.DELETE("/:id", hm.customFieldHandler.DeleteDefinition)
+		}
+
+		// Cache tuning during load - Admins only
+		cacheGroup := v1.Group("/cache")
+		cacheGroup.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleAdmin))
+		{
+			cacheGroup.GET("/settings", hm.cacheHandler.GetCacheSettings)
+			cacheGroup.PUT("/settings", hm.cacheHandler.UpdateCacheSettings)
+			cacheGroup.POST("/flush", hm.cacheHandler.FlushCache)
+		}
+
+		// Practice - Students practice, Teachers and Admins curate sets and see how they go
+		practice := v1.Group("/practice")
+		{
+			practice.GET("/sets", hm.authMiddleware.RequireRoleMiddleware(models.RoleStudent), hm.practiceHandler.ListAvailablePracticeSets)
+			practice.POST("/sets/:id/sessions", hm.authMiddleware.RequireRoleMiddleware(models.RoleStudent), hm.practiceHandler.StartPracticeSession)
+			practice.GET("/sessions", hm.authMiddleware.RequireRoleMiddleware(models.RoleStudent), hm.practiceHandler.ListPracticeSessions)
+			practice.GET("/sessions/:id", hm.authMiddleware.RequireRoleMiddleware(models.RoleStudent), hm.practiceHandler.GetPracticeSession)
+			practice.POST("/sessions/:id/answers", hm.authMiddleware.RequireRoleMiddleware(models.RoleStudent), hm.practiceHandler.AnswerPracticeQuestion)
+
+			practice.PUT("/sets/:id", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.practiceHandler.UpdatePracticeSet)
+			practice.GET("/sets/:id/stats", hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin), hm.practiceHandler.GetPracticeSetStats)
+		}
+
+		// Background jobs - users see the jobs they started; retrying is for admins
+		jobs := v1.Group("/jobs")
+		{
+			jobs.GET("", hm.jobHandler.ListJobs)
+			jobs.GET("/:id", hm.jobHandler.GetJob)
+			jobs.POST("/:id/retry", hm.authMiddleware.RequireRoleMiddleware(models.RoleAdmin), hm.jobHandler.RetryJob)
+		}
+
+		// Analytics routes - Teachers and Admins only
+		analytics := v1.Group("/analytics")
+		analytics.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin))
+		{
+			analytics.GET("/questions/:question_id/distractors", hm.analyticsHandler.GetDistractorAnalysis)
+			analytics.GET("/assessments/:id", hm.analyticsHandler.GetAssessmentAnalytics)
+			analytics.GET("/assessments/:id/dashboard", hm.analyticsHandler.GetAssessmentDashboard)
+			analytics.GET("/assessments/:id/calibration", hm.analyticsHandler.GetConfidenceCalibration)
+			analytics.GET("/assessments/:id/usage-times", hm.analyticsHandler.GetPeakUsageTimes)
+			analytics.GET("/assessments/:id/navigation", hm.analyticsHandler.GetNavigationAnalytics)
+
+			// Question statistics recompute - admins only, checked by the service
+			analytics.POST("/question-stats/recompute", hm.analyticsHandler.RecomputeQuestionStats)
+			analytics.GET("/question-stats/jobs/:job_id", hm.analyticsHandler.GetQuestionStatsJob)
+
+			// Skill mastery targets
+			analytics.POST("/mastery-targets", hm.analyticsHandler.CreateMasteryTarget)
+			analytics.GET("/mastery-targets", hm.analyticsHandler.ListMasteryTargets)
+			analytics.PUT("/mastery-targets/:id", hm.analyticsHandler.UpdateMasteryTarget)
+			analytics.DELETE("/mastery-targets/:id", hm.analyticsHandler.DeleteMasteryTarget)
+		}
+
+		// Student progress, retake comparison and transcripts - students see their own, teachers and admins any student's
+		students := v1.Group("/students")
+		{
+			students.GET("/:student_id/progress", hm.analyticsHandler.GetStudentProgress)
+			students.GET("/:student_id/assessments/:assessment_id/attempt-comparison", hm.attemptHandler.CompareAttempts)
+			students.GET("/:student_id/transcript", hm.resultsHandler.GetStudentTranscript)
+		}
+
+		// Report routes - Teachers and Admins only
+		reports := v1.Group("/reports")
+		reports.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin))
+		{
+			// Scheduled report subscriptions
+			reports.POST("/subscriptions", hm.reportHandler.CreateSubscription)
+			reports.GET("/subscriptions", hm.reportHandler.ListSubscriptions)
+			reports.PUT("/subscriptions/:id", hm.reportHandler.UpdateSubscription)
+			reports.DELETE("/subscriptions/:id", hm.reportHandler.DeleteSubscription)
+			reports.GET("/subscriptions/:id/preview", hm.reportHandler.PreviewSubscription)
+		}
+
+		// Favorite routes - Teachers and Admins only
+		favorites := v1.Group("/favorites")
+		favorites.Use(hm.authMiddleware.RequireRoleMiddleware(models.RoleTeacher, models.RoleAdmin))
+		{
+			favorites.GET("", hm.favoriteHandler.ListFavorites)
+			favorites.POST("/items/:item_type/:item_id", hm.favoriteHandler.AddFavorite)
+			favorites.DELETE("/items/:item_type/:item_id", hm.favoriteHandler.RemoveFavorite)
+
+			// Sharing with colleagues
+			favorites.POST("/shares", hm.favoriteHandler.ShareFavorites)
+			favorites.GET("/shares", hm.favoriteHandler.ListShares)
+			favorites.DELETE("/shares/:user_id", hm.favoriteHandler.UnshareFavorites)
+			favorites.GET("/shared", hm.favoriteHandler.ListSharedWithMe)
+			favorites.GET("/shared/:owner_id", hm.favoriteHandler.GetSharedFavorites)
 		}
 	}
 
@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// Context key holding the share link a token-authenticated request was made with
+const shareLinkContextKey = "share_link"
+
+type ShareLinkHandler struct {
+	BaseHandler
+	shareLinkService services.ShareLinkService
+}
+
+func NewShareLinkHandler(
+	shareLinkService services.ShareLinkService,
+	logger utils.Logger,
+) *ShareLinkHandler {
+	return &ShareLinkHandler{
+		BaseHandler:      NewBaseHandler(logger),
+		shareLinkService: shareLinkService,
+	}
+}
+
+// CreateShareLink issues a read-only link to an assessment for an external reviewer
+// @Summary Create share link
+// @Description Issues an expiring link that shows the assessment's content and settings, without student data, to someone without an account, such as an external moderator. The token is shown only once.
+// @Tags share-links
+// @Accept json
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Param link body services.CreateShareLinkRequest true "Who the link is for and how long it lasts"
+// @Success 201 {object} services.ShareLinkGrant
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/share-links [post]
+func (h *ShareLinkHandler) CreateShareLink(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	var req services.CreateShareLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.LogRequest(c, "Creating share link", "assessment_id", assessmentID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	grant, err := h.shareLinkService.CreateLink(c.Request.Context(), assessmentID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, grant)
+}
+
+// ListShareLinks lists an assessment's share links
+// @Summary List share links
+// @Description Lists every share link of the assessment, including expired and revoked ones, with how often each was viewed
+// @Tags share-links
+// @Produce json
+// @Param id path uint true "Assessment ID"
+// @Success 200 {array} models.AssessmentShareLink
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /assessments/{id}/share-links [get]
+func (h *ShareLinkHandler) ListShareLinks(c *gin.Context) {
+	assessmentID := h.parseIDParam(c, "id")
+	if assessmentID == 0 {
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	links, err := h.shareLinkService.ListLinks(c.Request.Context(), assessmentID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+// RevokeShareLink closes a share link before it expires
+// @Summary Revoke share link
+// @Description Refuses the link from now on
+// @Tags share-links
+// @Produce json
+// @Param id path uint true "Share link ID"
+// @Success 200 {object} models.AssessmentShareLink
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /share-links/{id}/revoke [post]
+func (h *ShareLinkHandler) RevokeShareLink(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Revoking share link", "share_link_id", id)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	link, err := h.shareLinkService.RevokeLink(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// GetShareLinkViews lists when a share link was opened
+// @Summary Get share link views
+// @Description Lists every opening of the link with the viewer's IP address and user agent, newest first
+// @Tags share-links
+// @Produce json
+// @Param id path uint true "Share link ID"
+// @Success 200 {array} models.AssessmentShareView
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /share-links/{id}/views [get]
+func (h *ShareLinkHandler) GetShareLinkViews(c *gin.Context) {
+	id := h.parseIDParam(c, "id")
+	if id == 0 {
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	views, err := h.shareLinkService.GetViews(c.Request.Context(), id, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, views)
+}
+
+// ShareTokenMiddleware authenticates a request by the share link token in the path instead
+// of a user token. Only read requests are let through.
+func (h *ShareLinkHandler) ShareTokenMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Keep the token out of caches and referrers
+		c.Header("Cache-Control", "no-store")
+		c.Header("Referrer-Policy", "no-referrer")
+
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.AbortWithStatusJSON(http.StatusMethodNotAllowed, ErrorResponse{
+				Message: "Share links are read-only",
+			})
+			return
+		}
+
+		link, err := h.shareLinkService.Authorize(c.Request.Context(), c.Param("token"))
+		if err != nil {
+			h.handleServiceError(c, err)
+			c.Abort()
+			return
+		}
+
+		c.Set(shareLinkContextKey, link)
+		c.Next()
+	}
+}
+
+// GetSharedAssessment shows an assessment to the holder of a share link
+// @Summary Get shared assessment
+// @Description Returns the assessment's content and settings, including answer keys, without attempts or statistics. Needs no account; the view is logged.
+// @Tags share-links
+// @Produce json
+// @Param token path string true "Share link token"
+// @Success 200 {object} services.SharedAssessment
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shared/assessments/{token} [get]
+func (h *ShareLinkHandler) GetSharedAssessment(c *gin.Context) {
+	value, exists := c.Get(shareLinkContextKey)
+	if !exists {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Share link not found or no longer valid",
+		})
+		return
+	}
+	link := value.(*models.AssessmentShareLink)
+
+	h.LogRequest(c, "Viewing shared assessment", "share_link_id", link.ID, "assessment_id", link.AssessmentID)
+
+	viewer := &services.ShareViewer{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+	assessment, err := h.shareLinkService.GetSharedAssessment(c.Request.Context(), link, viewer)
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assessment)
+}
+
+// Helper methods
+
+func (h *ShareLinkHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *ShareLinkHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var validationError *services.ValidationError
+	if errors.As(err, &validationError) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationError,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrShareLinkInvalid):
+		// Unknown, expired and revoked links look the same to the holder
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Share link not found or no longer valid",
+		})
+	case errors.Is(err, services.ErrAssessmentNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Assessment not found",
+		})
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Share link not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type UsageHandler struct {
+	BaseHandler
+	usageService services.UsageService
+}
+
+func NewUsageHandler(
+	usageService services.UsageService,
+	logger utils.Logger,
+) *UsageHandler {
+	return &UsageHandler{
+		BaseHandler:  NewBaseHandler(logger),
+		usageService: usageService,
+	}
+}
+
+// GetUsageReport returns billable usage per organization and month
+// @Summary Get usage report
+// @Description Returns attempts started, AI grading calls, proctoring minutes and attachment storage per organization and month, from the monthly rollups. The current month is refreshed hourly. Storage is the total stored at the end of each month.
+// @Tags usage
+// @Produce json
+// @Param from query string false "First month, YYYY-MM (defaults to 11 months before to)"
+// @Param to query string false "Last month, YYYY-MM (defaults to the current month)"
+// @Param organization query string false "Limit to one organization"
+// @Success 200 {object} services.UsageReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /usage [get]
+func (h *UsageHandler) GetUsageReport(c *gin.Context) {
+	filter := usageFilter(c)
+	h.LogRequest(c, "Getting usage report", "from", filter.From, "to", filter.To, "organization", filter.Organization)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	report, err := h.usageService.GetReport(c.Request.Context(), filter, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportUsageReport downloads the usage report as CSV for invoicing
+// @Summary Export usage report
+// @Description Downloads the usage report as CSV with one row per organization and month
+// @Tags usage
+// @Produce text/csv
+// @Param from query string false "First month, YYYY-MM (defaults to 11 months before to)"
+// @Param to query string false "Last month, YYYY-MM (defaults to the current month)"
+// @Param organization query string false "Limit to one organization"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /usage/export [get]
+func (h *UsageHandler) ExportUsageReport(c *gin.Context) {
+	filter := usageFilter(c)
+	h.LogRequest(c, "Exporting usage report", "from", filter.From, "to", filter.To, "organization", filter.Organization)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	document, err := h.usageService.ExportReport(c.Request.Context(), filter, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.FileName))
+	c.Data(http.StatusOK, document.ContentType, document.Content)
+}
+
+func usageFilter(c *gin.Context) *services.UsageReportFilter {
+	return &services.UsageReportFilter{
+		From:         c.Query("from"),
+		To:           c.Query("to"),
+		Organization: c.Query("organization"),
+	}
+}
+
+func (h *UsageHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	h.LogError(c, err, "Unexpected service error")
+	c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Message: "Internal server error",
+	})
+}
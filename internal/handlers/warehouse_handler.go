@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type WarehouseHandler struct {
+	BaseHandler
+	warehouseService services.WarehouseExportService
+}
+
+func NewWarehouseHandler(
+	warehouseService services.WarehouseExportService,
+	logger utils.Logger,
+) *WarehouseHandler {
+	return &WarehouseHandler{
+		BaseHandler:      NewBaseHandler(logger),
+		warehouseService: warehouseService,
+	}
+}
+
+// GetSchema describes the exported fact tables
+// @Summary Get warehouse schema
+// @Description Lists the fact tables of the current schema version with their columns, for creating them in BigQuery or Snowflake. Table names carry the version, e.g. attempt_facts_v2.
+// @Tags warehouse
+// @Produce json
+// @Success 200 {array} services.WarehouseTable
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /warehouse/schema [get]
+func (h *WarehouseHandler) GetSchema(c *gin.Context) {
+	h.LogRequest(c, "Getting warehouse schema")
+
+	c.JSON(http.StatusOK, h.warehouseService.GetSchema())
+}
+
+// CreateConnector connects an organization to its data warehouse
+// @Summary Create warehouse connector
+// @Description Exports the organization's assessment, attempt and answer facts every night to BigQuery, Snowflake or Parquet files on S3. The first export starts within the hour and covers all history.
+// @Tags warehouse
+// @Accept json
+// @Produce json
+// @Param connector body services.CreateWarehouseConnectorRequest true "Organization and warehouse target"
+// @Success 201 {object} models.WarehouseConnector
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /warehouse/connectors [post]
+func (h *WarehouseHandler) CreateConnector(c *gin.Context) {
+	h.LogRequest(c, "Creating warehouse connector")
+
+	var req services.CreateWarehouseConnectorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	connector, err := h.warehouseService.CreateConnector(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, connector)
+}
+
+// ListConnectors lists the warehouse connectors of all organizations
+// @Summary List warehouse connectors
+// @Description Lists warehouse connectors with how far their nightly export has reached and its last error
+// @Tags warehouse
+// @Produce json
+// @Success 200 {array} models.WarehouseConnector
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /warehouse/connectors [get]
+func (h *WarehouseHandler) ListConnectors(c *gin.Context) {
+	h.LogRequest(c, "Listing warehouse connectors")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	connectors, err := h.warehouseService.ListConnectors(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, connectors)
+}
+
+// UpdateConnector changes a warehouse connector's connection or enabled state
+// @Summary Update warehouse connector
+// @Description Updates the connection, credentials or enabled state of a warehouse connector
+// @Tags warehouse
+// @Accept json
+// @Produce json
+// @Param id path uint true "Connector ID"
+// @Param connector body services.UpdateWarehouseConnectorRequest true "Fields to change"
+// @Success 200 {object} models.WarehouseConnector
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /warehouse/connectors/{id} [put]
+func (h *WarehouseHandler) UpdateConnector(c *gin.Context) {
+	connectorID := h.parseIDParam(c, "id")
+	if connectorID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Updating warehouse connector", "connector_id", connectorID)
+
+	var req services.UpdateWarehouseConnectorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	connector, err := h.warehouseService.UpdateConnector(c.Request.Context(), connectorID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, connector)
+}
+
+// DeleteConnector stops exporting an organization's facts
+// @Summary Delete warehouse connector
+// @Description Deletes a warehouse connector and its run history. Data already in the warehouse is kept.
+// @Tags warehouse
+// @Param id path uint true "Connector ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /warehouse/connectors/{id} [delete]
+func (h *WarehouseHandler) DeleteConnector(c *gin.Context) {
+	connectorID := h.parseIDParam(c, "id")
+	if connectorID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Deleting warehouse connector", "connector_id", connectorID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.warehouseService.DeleteConnector(c.Request.Context(), connectorID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListRuns lists a connector's export runs
+// @Summary List warehouse export runs
+// @Description Lists the connector's most recent nightly and backfill runs, newest first, with the facts each exported
+// @Tags warehouse
+// @Produce json
+// @Param id path uint true "Connector ID"
+// @Success 200 {array} models.WarehouseExportRun
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /warehouse/connectors/{id}/runs [get]
+func (h *WarehouseHandler) ListRuns(c *gin.Context) {
+	connectorID := h.parseIDParam(c, "id")
+	if connectorID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Listing warehouse export runs", "connector_id", connectorID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	runs, err := h.warehouseService.ListRuns(c.Request.Context(), connectorID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, runs)
+}
+
+// RequestBackfill queues a re-export of facts changed within a window
+// @Summary Backfill warehouse facts
+// @Description Queues a run exporting the facts changed between from and to again, e.g. after the warehouse lost data. It defaults to all history up to what the nightly export has reached, and does not move the nightly watermark.
+// @Tags warehouse
+// @Accept json
+// @Produce json
+// @Param id path uint true "Connector ID"
+// @Param backfill body services.WarehouseBackfillRequest false "Window to export"
+// @Success 202 {object} models.WarehouseExportRun
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /warehouse/connectors/{id}/backfill [post]
+func (h *WarehouseHandler) RequestBackfill(c *gin.Context) {
+	connectorID := h.parseIDParam(c, "id")
+	if connectorID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Requesting warehouse backfill", "connector_id", connectorID)
+
+	var req services.WarehouseBackfillRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid request payload",
+				Details: err.Error(),
+			})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	run, err := h.warehouseService.RequestBackfill(c.Request.Context(), connectorID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, run)
+}
+
+// Helper methods
+
+func (h *WarehouseHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *WarehouseHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Warehouse connector not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
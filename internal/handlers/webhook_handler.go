@@ -0,0 +1,378 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/services"
+	"github.com/SAP-F-2025/assessment-service/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	BaseHandler
+	webhookService services.WebhookService
+}
+
+func NewWebhookHandler(
+	webhookService services.WebhookService,
+	logger utils.Logger,
+) *WebhookHandler {
+	return &WebhookHandler{
+		BaseHandler:    NewBaseHandler(logger),
+		webhookService: webhookService,
+	}
+}
+
+// CreateSubscription registers an endpoint for events
+// @Summary Create webhook subscription
+// @Description Posts published events of the chosen types, or of every type, to the URL. Each post is signed with the returned secret in the X-Webhook-Signature header; the secret is not shown again.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param subscription body services.CreateWebhookSubscriptionRequest true "Endpoint and event types"
+// @Success 201 {object} services.WebhookSubscriptionGrant
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks [post]
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	h.LogRequest(c, "Creating webhook subscription")
+
+	var req services.CreateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	grant, err := h.webhookService.CreateSubscription(c.Request.Context(), &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, grant)
+}
+
+// ListSubscriptions lists the registered webhook subscriptions
+// @Summary List webhook subscriptions
+// @Description Lists webhook subscriptions with their endpoints, event types and enabled state
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} models.WebhookSubscription
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks [get]
+func (h *WebhookHandler) ListSubscriptions(c *gin.Context) {
+	h.LogRequest(c, "Listing webhook subscriptions")
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	subscriptions, err := h.webhookService.ListSubscriptions(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscriptions)
+}
+
+// UpdateSubscription changes a webhook subscription's endpoint, event types or enabled state
+// @Summary Update webhook subscription
+// @Description Updates the name, URL, event types or enabled state of a webhook subscription
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path uint true "Subscription ID"
+// @Param subscription body services.UpdateWebhookSubscriptionRequest true "Fields to change"
+// @Success 200 {object} models.WebhookSubscription
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks/{id} [put]
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	subscriptionID := h.parseIDParam(c, "id")
+	if subscriptionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Updating webhook subscription", "subscription_id", subscriptionID)
+
+	var req services.UpdateWebhookSubscriptionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid request payload",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	subscription, err := h.webhookService.UpdateSubscription(c.Request.Context(), subscriptionID, &req, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// DeleteSubscription stops posting events to an endpoint
+// @Summary Delete webhook subscription
+// @Description Deletes a webhook subscription and its delivery history
+// @Tags webhooks
+// @Param id path uint true "Subscription ID"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	subscriptionID := h.parseIDParam(c, "id")
+	if subscriptionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Deleting webhook subscription", "subscription_id", subscriptionID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(c.Request.Context(), subscriptionID, userID.(string)); err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SendTestEvent posts a test event to a webhook endpoint
+// @Summary Send webhook test event
+// @Description Posts a system.webhook_test event to the subscription's URL right away, even when the subscription is disabled, and returns the delivery with the endpoint's response code and body
+// @Tags webhooks
+// @Produce json
+// @Param id path uint true "Subscription ID"
+// @Success 200 {object} models.WebhookDelivery
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks/{id}/test [post]
+func (h *WebhookHandler) SendTestEvent(c *gin.Context) {
+	subscriptionID := h.parseIDParam(c, "id")
+	if subscriptionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Sending webhook test event", "subscription_id", subscriptionID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	delivery, err := h.webhookService.SendTestEvent(c.Request.Context(), subscriptionID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// ListDeliveries lists a subscription's recent deliveries
+// @Summary List webhook deliveries
+// @Description Lists the subscription's most recent deliveries, newest first, with their payloads, response codes, response bodies and errors
+// @Tags webhooks
+// @Produce json
+// @Param id path uint true "Subscription ID"
+// @Param status query string false "pending, processing, succeeded or failed"
+// @Success 200 {array} models.WebhookDelivery
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	subscriptionID := h.parseIDParam(c, "id")
+	if subscriptionID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Listing webhook deliveries", "subscription_id", subscriptionID)
+
+	var status *models.WebhookDeliveryStatus
+	if value := c.Query("status"); value != "" {
+		deliveryStatus := models.WebhookDeliveryStatus(value)
+		switch deliveryStatus {
+		case models.WebhookDeliveryPending, models.WebhookDeliveryProcessing, models.WebhookDeliverySucceeded, models.WebhookDeliveryFailed:
+			status = &deliveryStatus
+		default:
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Message: "Invalid status",
+				Details: value,
+			})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c.Request.Context(), subscriptionID, status, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// ReplayDelivery posts a failed delivery's payload again
+// @Summary Replay webhook delivery
+// @Description Posts the payload of a failed delivery again right away, as a new delivery linked to the original through replay_of, and returns it with the endpoint's response
+// @Tags webhooks
+// @Produce json
+// @Param delivery_id path uint true "Delivery ID"
+// @Success 200 {object} models.WebhookDelivery
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /webhooks/deliveries/{delivery_id}/replay [post]
+func (h *WebhookHandler) ReplayDelivery(c *gin.Context) {
+	deliveryID := h.parseIDParam(c, "delivery_id")
+	if deliveryID == 0 {
+		return
+	}
+
+	h.LogRequest(c, "Replaying webhook delivery", "delivery_id", deliveryID)
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	delivery, err := h.webhookService.ReplayDelivery(c.Request.Context(), deliveryID, userID.(string))
+	if err != nil {
+		h.handleServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// Helper methods
+
+func (h *WebhookHandler) parseIDParam(c *gin.Context, param string) uint {
+	idStr := c.Param(param)
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Invalid " + param,
+			Details: err.Error(),
+		})
+		return 0
+	}
+	return uint(id)
+}
+
+func (h *WebhookHandler) handleServiceError(c *gin.Context, err error) {
+	var validationErrors services.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Message: "Validation failed",
+			Details: validationErrors,
+		})
+		return
+	}
+
+	var businessRuleError *services.BusinessRuleError
+	if errors.As(err, &businessRuleError) {
+		c.JSON(http.StatusUnprocessableEntity, ErrorResponse{
+			Message: businessRuleError.Message,
+			Details: map[string]interface{}{
+				"rule":    businessRuleError.Rule,
+				"context": businessRuleError.Context,
+			},
+		})
+		return
+	}
+
+	var permissionError *services.PermissionError
+	if errors.As(err, &permissionError) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Message: "Access denied",
+			Details: map[string]interface{}{
+				"resource": permissionError.Resource,
+				"action":   permissionError.Action,
+				"reason":   permissionError.Reason,
+			},
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Message: "Webhook subscription or delivery not found",
+		})
+	default:
+		h.LogError(c, err, "Unexpected service error")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Message: "Internal server error",
+		})
+	}
+}
@@ -35,6 +35,15 @@ type AssessmentAnalytics struct {
 	ScoreDistribution datatypes.JSON `json:"score_distribution" gorm:"type:jsonb"` // []ScoreBucket
 	TimeDistribution  datatypes.JSON `json:"time_distribution" gorm:"type:jsonb"`  // []TimeBucket
 
+	// Item analysis (classical test theory) over fully graded attempts
+	ScoredAttempts int            `json:"scored_attempts"`
+	ItemCount      int            `json:"item_count"`
+	Reliability    *float64       `json:"reliability"`                  // KR-20; nil with fewer than two items or no score variance
+	ItemStats      datatypes.JSON `json:"item_stats" gorm:"type:jsonb"` // []ItemStat
+
+	// Finished attempt percentages, highest first, for each attempt's percentile and rank
+	RankedScores datatypes.JSON `json:"-" gorm:"type:jsonb"` // []RankedScore
+
 	LastCalculatedAt time.Time `json:"last_calculated_at"`
 	CreatedAt        time.Time `json:"created_at"`
 	UpdatedAt        time.Time `json:"updated_at"`
@@ -73,3 +82,62 @@ type QuestionAnalytics struct {
 	// Relations
 	Question Question `json:"question" gorm:"foreignKey:QuestionID"`
 }
+
+type ReportType string
+type ReportFrequency string
+
+const (
+	ReportTeacherWeeklySummary ReportType = "teacher_weekly_summary"
+
+	ReportFrequencyWeekly ReportFrequency = "weekly"
+)
+
+// ReportSubscription schedules a recurring emailed report for a user
+type ReportSubscription struct {
+	ID         uint            `json:"id" gorm:"primaryKey"`
+	UserID     string          `json:"user_id" gorm:"not null;size:255;uniqueIndex:idx_report_subscription_user_type"`
+	ReportType ReportType      `json:"report_type" gorm:"not null;size:50;uniqueIndex:idx_report_subscription_user_type"`
+	Frequency  ReportFrequency `json:"frequency" gorm:"not null;size:20;default:weekly"`
+
+	// Delivery schedule (UTC)
+	DayOfWeek int    `json:"day_of_week" gorm:"default:1"` // 0 = Sunday
+	HourOfDay int    `json:"hour_of_day" gorm:"default:8"`
+	Email     string `json:"email" gorm:"size:255"` // overrides the account email when set
+
+	IsActive   bool       `json:"is_active" gorm:"default:true"`
+	LastSentAt *time.Time `json:"last_sent_at"`
+	NextRunAt  time.Time  `json:"next_run_at" gorm:"index"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type QuestionStatsJobStatus string
+
+const (
+	QuestionStatsJobPending   QuestionStatsJobStatus = "pending"
+	QuestionStatsJobRunning   QuestionStatsJobStatus = "running"
+	QuestionStatsJobCompleted QuestionStatsJobStatus = "completed"
+	QuestionStatsJobFailed    QuestionStatsJobStatus = "failed"
+)
+
+// QuestionStatsJob rebuilds the stored statistics of one question, or of every question in a
+// bank, from the raw answers
+type QuestionStatsJob struct {
+	ID          uint                   `json:"id" gorm:"primaryKey"`
+	QuestionID  *uint                  `json:"question_id" gorm:"index"`
+	BankID      *uint                  `json:"bank_id" gorm:"index"`
+	Status      QuestionStatsJobStatus `json:"status" gorm:"not null;default:pending;index"`
+	RequestedBy string                 `json:"requested_by" gorm:"not null;size:255"`
+
+	// Progress
+	TotalQuestions     int     `json:"total_questions"`
+	ProcessedQuestions int     `json:"processed_questions"`
+	FailedQuestions    int     `json:"failed_questions"` // Logged and skipped; the job carries on
+	Error              *string `json:"error" gorm:"type:text"`
+
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"index"` // Heartbeat; stale running jobs are picked up again
+}
@@ -1,5 +1,7 @@
 package models
 
+import "gorm.io/datatypes"
+
 type MultipleChoiceAnswer struct {
 	SelectedOptions []string `json:"selected_options"`
 	TimeSpent       int      `json:"time_spent"`
@@ -35,3 +37,6 @@ type ShortAnswers struct {
 	Text      string `json:"text"`
 	TimeSpent int    `json:"time_spent"`
 }
+
+// MultiPartAnswer maps part IDs to each part's answer, in the format of the part's type
+type MultiPartAnswer map[string]datatypes.JSON
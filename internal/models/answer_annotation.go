@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// AnswerAnnotation is a grader's inline comment on a highlighted span of an essay answer.
+// Offsets count characters (runes) of the answer text, end exclusive; on multi-part
+// questions PartID names the essay part the span belongs to.
+type AnswerAnnotation struct {
+	ID              uint    `json:"id" gorm:"primaryKey"`
+	AnswerID        uint    `json:"answer_id" gorm:"not null;index"`
+	AttemptID       uint    `json:"attempt_id" gorm:"not null;index"`
+	PartID          *string `json:"part_id" gorm:"size:50"`
+	StartOffset     int     `json:"start_offset" gorm:"not null"`
+	EndOffset       int     `json:"end_offset" gorm:"not null"`
+	Quote           string  `json:"quote" gorm:"type:text"` // Highlighted text when annotated
+	Comment         string  `json:"comment" gorm:"type:text;not null"`
+	RubricCriterion *string `json:"rubric_criterion" gorm:"size:255"` // One of the question's rubric criteria
+	CreatedBy       string  `json:"created_by" gorm:"not null;size:255"`
+
+	// Full-text index of the comment, maintained by the database
+	CommentSearch string `json:"-" gorm:"->:false;<-:false;type:tsvector GENERATED ALWAYS AS (to_tsvector('english', comment)) STORED;index:idx_answer_annotations_comment_search,type:gin"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+type OCRStatus string
+
+const (
+	OCRPending    OCRStatus = "pending"
+	OCRProcessing OCRStatus = "processing"
+	OCRCompleted  OCRStatus = "completed"
+	OCRFailed     OCRStatus = "failed"
+)
+
+// AnswerAttachment is a file a student uploaded with an answer, typically a photo of
+// handwritten work. Text recognised in the image is kept for search and similarity checks.
+type AnswerAttachment struct {
+	ID         uint `json:"id" gorm:"primaryKey"`
+	AttemptID  uint `json:"attempt_id" gorm:"not null;index:idx_answer_attachment_question"`
+	QuestionID uint `json:"question_id" gorm:"not null;index:idx_answer_attachment_question"`
+
+	// File info
+	FileName    string `json:"file_name" gorm:"not null;size:255"`
+	ContentType string `json:"content_type" gorm:"not null;size:100"`
+	FileSize    int64  `json:"file_size" gorm:"not null"`
+	FilePath    string `json:"-" gorm:"not null;size:500"`
+
+	// Text recognition
+	OCRStatus      OCRStatus  `json:"ocr_status" gorm:"default:pending;index"`
+	OCRText        *string    `json:"ocr_text" gorm:"type:text"`
+	OCRError       *string    `json:"ocr_error" gorm:"type:text"`
+	OCRAttempts    int        `json:"ocr_attempts" gorm:"default:0"`
+	OCRProcessedAt *time.Time `json:"ocr_processed_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relations
+	Attempt AssessmentAttempt `json:"-" gorm:"foreignKey:AttemptID"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+type AnswerReviewStatus string
+
+const (
+	AnswerReviewPending   AnswerReviewStatus = "pending"
+	AnswerReviewCompleted AnswerReviewStatus = "completed"
+)
+
+// AnswerReview is a second-reviewer check of a manually graded answer,
+// sampled for moderation evidence and grader reliability reporting
+type AnswerReview struct {
+	ID           uint               `json:"id" gorm:"primaryKey"`
+	AssessmentID uint               `json:"assessment_id" gorm:"not null;index"`
+	AnswerID     uint               `json:"answer_id" gorm:"not null;uniqueIndex"`
+	GraderID     string             `json:"grader_id" gorm:"not null;index;size:255"`   // Original grader
+	ReviewerID   string             `json:"reviewer_id" gorm:"not null;index;size:255"` // Second reviewer
+	Status       AnswerReviewStatus `json:"status" gorm:"not null;default:pending;index"`
+
+	// Scoring comparison
+	OriginalScore float64  `json:"original_score"`
+	ReviewScore   *float64 `json:"review_score"`
+	MaxScore      float64  `json:"max_score"`
+	Tolerance     float64  `json:"tolerance" gorm:"default:0"` // Max point difference still counted as agreement
+	Agrees        *bool    `json:"agrees"`                     // null until reviewed
+
+	Comment    *string    `json:"comment" gorm:"type:text"`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+	CreatedBy  string     `json:"created_by" gorm:"not null;size:255"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relations
+	Answer StudentAnswer `json:"answer" gorm:"foreignKey:AnswerID"`
+}
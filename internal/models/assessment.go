@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -15,6 +16,49 @@ const (
 	StatusArchived AssessmentStatus = "Archived"
 )
 
+// ResultsReleaseMode controls when students may see scores and reviews
+type ResultsReleaseMode string
+
+const (
+	ResultsReleaseImmediate ResultsReleaseMode = "immediate"
+	ResultsReleaseManual    ResultsReleaseMode = "manual"
+	ResultsReleaseScheduled ResultsReleaseMode = "scheduled"
+)
+
+type TimingMode string
+
+const (
+	TimingModeTotal       TimingMode = "total"        // One duration for the whole attempt
+	TimingModePerQuestion TimingMode = "per_question" // Fixed budget per question, no going back
+)
+
+// GradePolicy selects which attempts make up a student's final grade when retakes are allowed
+type GradePolicy string
+
+const (
+	GradePolicyHighest GradePolicy = "highest" // Best scoring attempt
+	GradePolicyLatest  GradePolicy = "latest"  // Most recent attempt
+	GradePolicyAverage GradePolicy = "average" // Mean of all attempts
+)
+
+type CalculatorType string
+
+const (
+	CalculatorNone       CalculatorType = "none"
+	CalculatorBasic      CalculatorType = "basic"
+	CalculatorScientific CalculatorType = "scientific"
+	CalculatorGraphing   CalculatorType = "graphing"
+)
+
+// AllowedResources lists the tools a student may use during an attempt. It is
+// delivered to the attempt client and snapshotted on the attempt at start.
+type AllowedResources struct {
+	Calculator      CalculatorType `json:"calculator"`
+	FormulaSheetURL *string        `json:"formula_sheet_url,omitempty"`
+	Dictionary      bool           `json:"dictionary"`
+	SpellCheck      bool           `json:"spell_check"` // Spelling suggestions on essay answers
+}
+
 type Assessment struct {
 	ID           uint             `json:"id" gorm:"primaryKey"`
 	Title        string           `json:"title" gorm:"not null;size:200;index" validate:"required,min=1,max=200"`
@@ -25,6 +69,15 @@ type Assessment struct {
 	MaxAttempts  int              `json:"max_attempts" gorm:"default:1" validate:"min=1,max=10"`
 	TimeWarning  int              `json:"time_warning" gorm:"default:300"` // Warning time in seconds
 	DueDate      *time.Time       `json:"due_date"`
+	DueTimezone  string           `json:"due_timezone" gorm:"size:64;default:UTC"` // IANA zone the due date was set in
+	Term         *string          `json:"term" gorm:"size:50;index"`               // Academic term, e.g. "2025 Fall"
+
+	// Values of the organization's custom fields, keyed by field key; see CustomFieldDefinition
+	CustomFields datatypes.JSON `json:"custom_fields" gorm:"type:jsonb"`
+
+	// Scheduled publishing, set for assessments generated as part of a series
+	SeriesID  *uint      `json:"series_id,omitempty" gorm:"index"`
+	PublishAt *time.Time `json:"publish_at,omitempty" gorm:"index"` // Draft is published by the scheduler at this time
 
 	// Metadata
 	CreatedBy string         `json:"created_by" gorm:"not null;index;size:255"`
@@ -48,6 +101,20 @@ type Assessment struct {
 	AvgScore       float64 `json:"avg_score" gorm:"-"`
 }
 
+// AssessmentSeries records a set of quizzes generated from one bank on a fixed cadence
+type AssessmentSeries struct {
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	Title            string         `json:"title" gorm:"not null;size:200"`
+	BankID           uint           `json:"bank_id" gorm:"not null;index"`
+	Tags             datatypes.JSON `json:"tags" gorm:"type:jsonb"`
+	QuizCount        int            `json:"quiz_count" gorm:"not null"`
+	QuestionsPerQuiz int            `json:"questions_per_quiz" gorm:"not null"`
+	CadenceDays      int            `json:"cadence_days" gorm:"not null;default:7"`
+	FirstPublishAt   time.Time      `json:"first_publish_at" gorm:"not null"`
+	CreatedBy        string         `json:"created_by" gorm:"not null;index;size:255"`
+	CreatedAt        time.Time      `json:"created_at"`
+}
+
 type AssessmentSettings struct {
 	AssessmentID uint      `json:"assessment_id" gorm:"primaryKey;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 	CreatedAt    time.Time `json:"created_at" gorm:"not null"`
@@ -63,32 +130,91 @@ type AssessmentSettings struct {
 	ShowResults        bool `json:"show_results" gorm:"not null;default:true;comment:Show results after completion"`
 	ShowCorrectAnswers bool `json:"show_correct_answers" gorm:"not null;default:true;comment:Show correct answers in results"`
 	ShowScoreBreakdown bool `json:"show_score_breakdown" gorm:"not null;default:true;comment:Show detailed score breakdown"`
+	ShowRank           bool `json:"show_rank" gorm:"not null;default:false;comment:Show students their percentile and rank among finished attempts"`
+
+	// Grading Settings
+	AnonymousGrading     bool   `json:"anonymous_grading" gorm:"not null;default:false;comment:Hide student identities from graders until results are released"`
+	AnonymousGradingSalt string `json:"-" gorm:"size:64;comment:Secret used to derive pseudonymous student labels"`
+	ExportAliasSalt      string `json:"-" gorm:"size:64;comment:Secret used to derive student aliases in redacted exports"`
+
+	// Results Release Settings
+	ResultsReleaseMode ResultsReleaseMode `json:"results_release_mode" gorm:"not null;default:immediate;size:20;comment:immediate, manual or scheduled"`
+	ResultsReleaseAt   *time.Time         `json:"results_release_at" gorm:"index;comment:Scheduled release time"`
+	ResultsReleasedAt  *time.Time         `json:"results_released_at" gorm:"comment:When results were released to students"`
+	ResultsReleasedBy  *string            `json:"results_released_by" gorm:"size:255;comment:User who released results"`
 
 	// Attempt Settings
-	AllowRetake bool `json:"allow_retake" gorm:"not null;default:false;comment:Allow multiple attempts"`
-	RetakeDelay int  `json:"retake_delay" gorm:"not null;default:0;check:retake_delay >= 0 AND retake_delay <= 1440;comment:Delay between retakes in minutes"`
+	AllowRetake bool        `json:"allow_retake" gorm:"not null;default:false;comment:Allow multiple attempts"`
+	RetakeDelay int         `json:"retake_delay" gorm:"not null;default:0;check:retake_delay >= 0 AND retake_delay <= 1440;comment:Delay between retakes in minutes"`
+	GradePolicy GradePolicy `json:"grade_policy" gorm:"not null;default:highest;size:20;comment:Attempts counted for the final grade: highest, latest or average"`
+
+	// Save-and-exit Settings
+	AllowSaveAndExit bool `json:"allow_save_and_exit" gorm:"not null;default:false;comment:Students may leave an attempt and return until the due date"`
+
+	// Navigation Settings
+	LockCompletedSections bool `json:"lock_completed_sections" gorm:"not null;default:false;comment:Questions of a section become read-only once the student moves on"`
+
+	// Capacity Settings
+	MaxConcurrentAttempts int `json:"max_concurrent_attempts" gorm:"not null;default:0;check:max_concurrent_attempts >= 0;comment:Attempts in progress at once, e.g. lab seats; 0 means unlimited"`
+
+	// Submission Settings
+	RequireAllAnswered        bool `json:"require_all_answered" gorm:"not null;default:false;comment:Block submission while questions are unanswered"`
+	RequireFlaggedResolved    bool `json:"require_flagged_resolved" gorm:"not null;default:false;comment:Block submission while questions are flagged for review"`
+	RequireSubmitConfirmation bool `json:"require_submit_confirmation" gorm:"not null;default:false;comment:Student must acknowledge unanswered and flagged questions"`
 
 	// Time Settings
-	TimeLimitEnforced   bool `json:"time_limit_enforced" gorm:"not null;default:true;comment:Enforce time limits"`
-	AutoSubmitOnTimeout bool `json:"auto_submit_on_timeout" gorm:"not null;default:true;comment:Auto-submit when time expires"`
+	TimeLimitEnforced   bool       `json:"time_limit_enforced" gorm:"not null;default:true;comment:Enforce time limits"`
+	AutoSubmitOnTimeout bool       `json:"auto_submit_on_timeout" gorm:"not null;default:true;comment:Auto-submit when time expires"`
+	TimingMode          TimingMode `json:"timing_mode" gorm:"not null;default:total;size:20;comment:total or per_question"`
+
+	// Allowed Resources
+	CalculatorType  CalculatorType `json:"calculator_type" gorm:"not null;default:none;size:20;comment:none, basic, scientific or graphing"`
+	FormulaSheetURL *string        `json:"formula_sheet_url" gorm:"size:500;comment:Formula sheet students may consult"`
+	AllowDictionary bool           `json:"allow_dictionary" gorm:"not null;default:false;comment:Allow a dictionary during the attempt"`
+	AllowSpellCheck bool           `json:"allow_spell_check" gorm:"not null;default:false;comment:Offer spelling suggestions on essay answers; answers are never corrected"`
 
 	// Proctoring Settings
-	RequireWebcam               bool `json:"require_webcam" gorm:"not null;default:false;comment:Require webcam for proctoring"`
-	PreventTabSwitching         bool `json:"prevent_tab_switching" gorm:"not null;default:false;comment:Prevent switching browser tabs"`
-	PreventRightClick           bool `json:"prevent_right_click" gorm:"not null;default:false;comment:Disable right-click context menu"`
-	PreventCopyPaste            bool `json:"prevent_copy_paste" gorm:"not null;default:false;comment:Disable copy/paste functionality"`
-	RequireIdentityVerification bool `json:"require_identity_verification" gorm:"not null;default:false;comment:Require identity verification"`
-	RequireFullScreen           bool `json:"require_full_screen" gorm:"not null;default:false;comment:Force fullscreen mode"`
+	ProctoringProvider          string `json:"proctoring_provider" gorm:"not null;default:browser;size:50;comment:Proctoring provider enforcing the settings below"`
+	RequireWebcam               bool   `json:"require_webcam" gorm:"not null;default:false;comment:Require webcam for proctoring"`
+	RequireScreenRecording      bool   `json:"require_screen_recording" gorm:"not null;default:false;comment:Record the student's screen during the attempt"`
+	PreventTabSwitching         bool   `json:"prevent_tab_switching" gorm:"not null;default:false;comment:Prevent switching browser tabs"`
+	PreventRightClick           bool   `json:"prevent_right_click" gorm:"not null;default:false;comment:Disable right-click context menu"`
+	PreventCopyPaste            bool   `json:"prevent_copy_paste" gorm:"not null;default:false;comment:Disable copy/paste functionality"`
+	RequireIdentityVerification bool   `json:"require_identity_verification" gorm:"not null;default:false;comment:Require identity verification"`
+	RequireFullScreen           bool   `json:"require_full_screen" gorm:"not null;default:false;comment:Force fullscreen mode"`
 
 	// Accessibility Settings
 	AllowScreenReader  bool `json:"allow_screen_reader" gorm:"not null;default:false;comment:Enable screen reader support"`
 	FontSizeAdjustment int  `json:"font_size_adjustment" gorm:"not null;default:0;check:font_size_adjustment >= -2 AND font_size_adjustment <= 2;comment:Font size adjustment (-2 to +2)"`
 	HighContrastMode   bool `json:"high_contrast_mode" gorm:"not null;default:false;comment:Enable high contrast display mode"`
 
+	// Metacognition Settings
+	AskConfidence bool `json:"ask_confidence" gorm:"not null;default:false;comment:Ask students to rate their confidence (1-5) in each answer"`
+
+	// Language Settings
+	ExpectedLanguage *string `json:"expected_language" gorm:"size:10;comment:ISO 639-1 code essay answers are expected in; others are flagged"`
+
+	// Accommodation Settings
+	AccommodationExtraTime int `json:"accommodation_extra_time" gorm:"not null;default:0;check:accommodation_extra_time >= 0 AND accommodation_extra_time <= 300;comment:Extra time granted to students with a timing accommodation, in percent of the time limit"`
+
 	// Relations
 	// Assessment Assessment `json:"assessment" gorm:"foreignKey:AssessmentID;references:ID"`
 }
 
+// AllowedResources returns the tools students may use under these settings
+func (s *AssessmentSettings) AllowedResources() AllowedResources {
+	calculator := s.CalculatorType
+	if calculator == "" {
+		calculator = CalculatorNone
+	}
+	return AllowedResources{
+		Calculator:      calculator,
+		FormulaSheetURL: s.FormulaSheetURL,
+		Dictionary:      s.AllowDictionary,
+		SpellCheck:      s.AllowSpellCheck,
+	}
+}
+
 func (Assessment) TableName() string {
 	return "assessments"
 }
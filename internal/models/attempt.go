@@ -14,10 +14,13 @@ const (
 	AttemptCompleted  AttemptStatus = "completed"
 	AttemptAbandoned  AttemptStatus = "abandoned"
 	AttemptTimeOut    AttemptStatus = "timeout"
+	AttemptVoided     AttemptStatus = "voided" // Merged into another attempt, or its content changed, by an admin
 )
 
 const (
-	AttemptEndReasonTimeout = "time_out"
+	AttemptEndReasonTimeout         = "time_out"
+	AttemptEndReasonMergedDuplicate = "merged_duplicate"
+	AttemptEndReasonContentChanged  = "content_changed"
 )
 
 type AssessmentAttempt struct {
@@ -34,25 +37,52 @@ type AssessmentAttempt struct {
 	TimeSpent     int        `json:"time_spent"`     // seconds
 	TimeRemaining int        `json:"time_remaining"` // seconds
 
+	// Save-and-exit: while paused the clock is stopped and EndedAt is the due date, if any
+	PausedAt         *time.Time `json:"paused_at"`
+	SessionStartedAt *time.Time `json:"session_started_at"` // Set once the student returned
+	Sessions         int        `json:"sessions" gorm:"not null;default:1"`
+
 	// Scoring
 	Score      float64 `json:"score"`
 	MaxScore   int     `json:"max_score"`
 	Percentage float64 `json:"percentage"`
 	Passed     bool    `json:"passed"`
 
+	// Score override by the assessment owner; the computed result is kept alongside
+	ScoreOverridden    bool       `json:"score_overridden" gorm:"default:false"`
+	ComputedScore      *float64   `json:"computed_score"` // Set only while overridden
+	ComputedPercentage *float64   `json:"computed_percentage"`
+	ComputedPassed     *bool      `json:"computed_passed"`
+	OverrideReason     *string    `json:"override_reason" gorm:"type:text"`
+	OverriddenBy       *string    `json:"overridden_by" gorm:"size:255"`
+	OverriddenAt       *time.Time `json:"overridden_at"`
+
 	// Progress tracking
 	CurrentQuestionIndex int  `json:"current_question_index"`
 	QuestionsAnswered    int  `json:"questions_answered"`
 	TotalQuestions       int  `json:"total_questions"`
 	IsReview             bool `json:"is_review"` // Review mode before submit
 
+	// Per-question timing mode: the clock of the question at CurrentQuestionIndex
+	QuestionStartedAt *time.Time `json:"question_started_at"`
+	QuestionDeadline  *time.Time `json:"question_deadline"`
+
+	// Sections the student finished; their questions are read-only from then on
+	LockedSections datatypes.JSON `json:"locked_sections" gorm:"type:jsonb"` // []string
+
 	// Metadata
 	IPAddress   *string        `json:"ip_address" gorm:"size:45"`
 	UserAgent   *string        `json:"user_agent" gorm:"type:text"`
 	SessionData datatypes.JSON `json:"session_data" gorm:"type:jsonb"` // Browser info, screen resolution, etc.
 	EndReason   *string        `json:"end_reason" gorm:"type:text"`    // e.g., "time_out", "abandoned", "completed"
 
-	CreatedAt time.Time `json:"created_at"`
+	// Resources allowed when the attempt started, kept for audit
+	AllowedResources datatypes.JSON `json:"allowed_resources" gorm:"type:jsonb"` // AllowedResources
+
+	// Values of the organization's custom fields, keyed by field key; see CustomFieldDefinition
+	CustomFields datatypes.JSON `json:"custom_fields" gorm:"type:jsonb"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"index"` // Keyset pagination orders by (created_at, id)
 	UpdatedAt time.Time `json:"updated_at"`
 
 	// Relations
@@ -65,13 +95,31 @@ type AssessmentAttempt struct {
 	gorm.Model `gorm:"uniqueIndex:idx_student_assessment_attempt"`
 }
 
+// AnswerGradingStatus is where an answer is in the grading workflow
+type AnswerGradingStatus string
+
+const (
+	GradingStatusUngraded       AnswerGradingStatus = "ungraded"
+	GradingStatusAutoGraded     AnswerGradingStatus = "auto_graded"
+	GradingStatusProvisional    AnswerGradingStatus = "provisional" // Auto-graded, waiting for a teacher to confirm the score
+	GradingStatusManuallyGraded AnswerGradingStatus = "manually_graded"
+	GradingStatusPendingRegrade AnswerGradingStatus = "pending_regrade" // The grade is stale, e.g. the answer key changed
+	GradingStatusRegraded       AnswerGradingStatus = "regraded"
+	GradingStatusOverridden     AnswerGradingStatus = "overridden" // A teacher replaced an earlier grade
+)
+
 type StudentAnswer struct {
 	ID         uint `json:"id" gorm:"primaryKey"`
 	AttemptID  uint `json:"attempt_id" gorm:"not null;index"`
 	QuestionID uint `json:"question_id" gorm:"not null;index"`
+	// Set when a question trial served the attempt a revision of the question instead
+	VariantQuestionID *uint `json:"variant_question_id,omitempty" gorm:"index"`
 
 	// Answer content (polymorphic based on question type)
 	Answer datatypes.JSON `json:"answer" gorm:"type:jsonb"`
+	// Large answers are stored here zstd-compressed instead, with Answer left empty. The
+	// repository layer compresses and decompresses transparently.
+	AnswerCompressed []byte `json:"-" gorm:"type:bytea"`
 
 	// Grading
 	Score     float64    `json:"score"`
@@ -80,6 +128,13 @@ type StudentAnswer struct {
 	GradedBy  *string    `json:"graded_by" gorm:"size:255"` // Teacher ID for manual grading
 	GradedAt  *time.Time `json:"graded_at"`
 	Feedback  *string    `json:"feedback" gorm:"type:text"`
+	// Workflow state, managed by the grading service
+	GradingStatus AnswerGradingStatus `json:"grading_status" gorm:"not null;default:ungraded;size:20;index"`
+
+	// Full-text index of the feedback, maintained by the database
+	FeedbackSearch string `json:"-" gorm:"->:false;<-:false;type:tsvector GENERATED ALWAYS AS (to_tsvector('english', coalesce(feedback, ''))) STORED;index:idx_student_answers_feedback_search,type:gin"`
+
+	PartScores datatypes.JSON `json:"part_scores" gorm:"type:jsonb"` // []PartScore, multi-part questions only
 
 	// Timing
 	TimeSpent       int        `json:"time_spent"` // seconds
@@ -88,8 +143,19 @@ type StudentAnswer struct {
 
 	// Metadata
 	AnswerHistory datatypes.JSON `json:"answer_history" gorm:"type:jsonb"` // Track changes
+	ChangeCount   int            `json:"change_count" gorm:"default:0"`    // Times a given answer was replaced
 	Flagged       bool           `json:"flagged"`                          // Student flagged for review
 	IsGraded      bool           `json:"is_graded"`                        // Whether the answer has been graded
+	// When the answer was last flagged; many flags on one question within minutes are escalated
+	FlaggedAt *time.Time `json:"flagged_at,omitempty"`
+	// Student's self-rated confidence from 1 (guessing) to 5 (certain), when the assessment asks for it
+	Confidence *int `json:"confidence,omitempty" gorm:"check:confidence >= 1 AND confidence <= 5"`
+	// Language detected in an essay answer, and whether it differs from the one the assessment expects
+	DetectedLanguage *string `json:"detected_language,omitempty" gorm:"size:10"`
+	LanguageMismatch bool    `json:"language_mismatch" gorm:"not null;default:false;index"`
+	// Spelling suggestions were shown on this essay answer before submission. The answer is
+	// graded as written; researchers exclude these from spelling-related rubric analysis.
+	SpellCheckShown bool `json:"spell_check_shown" gorm:"not null;default:false"`
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -99,3 +165,15 @@ type StudentAnswer struct {
 	Question Question          `json:"question" gorm:"foreignKey:QuestionID"`
 	Grader   *User             `json:"grader" gorm:"foreignKey:GradedBy"`
 }
+
+// PartScore is the grade of one part of a multi-part question's answer
+type PartScore struct {
+	PartID    string     `json:"part_id"`
+	Score     float64    `json:"score"`
+	MaxScore  int        `json:"max_score"`
+	IsCorrect *bool      `json:"is_correct"`
+	IsGraded  bool       `json:"is_graded"`
+	GradedBy  *string    `json:"graded_by"` // nil when auto-graded
+	GradedAt  *time.Time `json:"graded_at"`
+	Feedback  *string    `json:"feedback"`
+}
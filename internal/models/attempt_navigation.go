@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+// AttemptNavigationEvent records a student opening a question during an attempt. The
+// sequence of events of an attempt is the path the student took through the assessment.
+type AttemptNavigationEvent struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	AttemptID  uint      `json:"attempt_id" gorm:"not null;index:idx_navigation_attempt_visited"`
+	QuestionID uint      `json:"question_id" gorm:"not null"`
+	VisitedAt  time.Time `json:"visited_at" gorm:"not null;index:idx_navigation_attempt_visited"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	Attempt AssessmentAttempt `json:"-" gorm:"foreignKey:AttemptID;constraint:OnDelete:CASCADE"`
+}
+
+func (AttemptNavigationEvent) TableName() string {
+	return "attempt_navigation_events"
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+type AttemptQueueStatus string
+
+const (
+	AttemptQueueWaiting  AttemptQueueStatus = "waiting"
+	AttemptQueueAdmitted AttemptQueueStatus = "admitted" // A slot is held until AdmittedUntil
+)
+
+// AttemptQueueEntry is a student waiting for a free slot on an assessment whose concurrent
+// attempts are capped. Entries are removed once the student starts, leaves or lets an
+// admission lapse.
+type AttemptQueueEntry struct {
+	ID            uint               `json:"id" gorm:"primaryKey"`
+	AssessmentID  uint               `json:"assessment_id" gorm:"not null;uniqueIndex:idx_attempt_queue_student"`
+	StudentID     string             `json:"student_id" gorm:"not null;size:255;uniqueIndex:idx_attempt_queue_student"`
+	Status        AttemptQueueStatus `json:"status" gorm:"not null;default:waiting;size:20;index"`
+	AdmittedAt    *time.Time         `json:"admitted_at"`
+	AdmittedUntil *time.Time         `json:"admitted_until" gorm:"index"`
+
+	CreatedAt time.Time `json:"created_at"` // When the student joined the queue
+	UpdatedAt time.Time `json:"updated_at"`
+}
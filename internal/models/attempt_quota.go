@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// AttemptQuota caps the attempts a student may start on the assessments drawing questions
+// from a bank within a rolling window, e.g. 20 practice attempts per student per week. It
+// applies to the students enrolled with ClassID, or to every student when ClassID is nil.
+type AttemptQuota struct {
+	ID          uint    `json:"id" gorm:"primaryKey"`
+	Name        string  `json:"name" gorm:"not null;size:100"`
+	BankID      uint    `json:"bank_id" gorm:"not null;index"`
+	ClassID     *string `json:"class_id" gorm:"size:100;index"` // Student group the quota applies to
+	MaxAttempts int     `json:"max_attempts" gorm:"not null"`
+	WindowHours int     `json:"window_hours" gorm:"not null"` // Rolling window attempts are counted in
+	IsActive    bool    `json:"is_active" gorm:"not null;default:true;index"`
+
+	CreatedBy string    `json:"created_by" gorm:"not null;size:255"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Window is how far back attempts count against the quota
+func (q *AttemptQuota) Window() time.Duration {
+	return time.Duration(q.WindowHours) * time.Hour
+}
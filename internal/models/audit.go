@@ -13,6 +13,7 @@ const (
 	AuditAssessmentUpdated   AuditEventType = "assessment_updated"
 	AuditAssessmentDeleted   AuditEventType = "assessment_deleted"
 	AuditAssessmentPublished AuditEventType = "assessment_published"
+	AuditAssessmentArchived  AuditEventType = "assessment_archived"
 	AuditQuestionCreated     AuditEventType = "question_created"
 	AuditQuestionUpdated     AuditEventType = "question_updated"
 	AuditQuestionDeleted     AuditEventType = "question_deleted"
@@ -20,11 +21,21 @@ const (
 	AuditAttemptCompleted    AuditEventType = "attempt_completed"
 	AuditAnswerSubmitted     AuditEventType = "answer_submitted"
 	AuditGradeUpdated        AuditEventType = "grade_updated"
+	AuditScoreOverridden     AuditEventType = "score_overridden"
+	AuditResultsReleased     AuditEventType = "results_released"
 	AuditUserLogin           AuditEventType = "user_login"
 	AuditUserLogout          AuditEventType = "user_logout"
 	AuditPermissionChanged   AuditEventType = "permission_changed"
 	AuditDataExported        AuditEventType = "data_exported"
 	AuditProctoringViolation AuditEventType = "proctoring_violation"
+	AuditImpersonationStart  AuditEventType = "impersonation_started"
+	AuditImpersonationEnd    AuditEventType = "impersonation_ended"
+	AuditImpersonatedRequest AuditEventType = "impersonated_request"
+	AuditGradingDelegated    AuditEventType = "grading_delegated"
+	AuditDelegationRevoked   AuditEventType = "grading_delegation_revoked"
+	AuditDelegatedGrading    AuditEventType = "delegated_grading"
+	AuditAttemptsMerged      AuditEventType = "attempts_merged"
+	AuditContentLockOverride AuditEventType = "content_lock_overridden"
 )
 
 type AuditLog struct {
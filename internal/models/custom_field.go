@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// CustomFieldEntity is the kind of record a custom field is attached to
+type CustomFieldEntity string
+
+const (
+	CustomFieldEntityAssessment CustomFieldEntity = "assessment"
+	CustomFieldEntityAttempt    CustomFieldEntity = "attempt"
+)
+
+type CustomFieldType string
+
+const (
+	CustomFieldText    CustomFieldType = "text"
+	CustomFieldNumber  CustomFieldType = "number"
+	CustomFieldBoolean CustomFieldType = "boolean"
+	CustomFieldDate    CustomFieldType = "date"   // YYYY-MM-DD
+	CustomFieldSelect  CustomFieldType = "select" // One of Options
+)
+
+// CustomFieldDefinition is an admin-defined field an organization records on its assessments
+// or attempts, such as a cost center or a proctor name. Values are kept in the record's
+// custom_fields JSON column, keyed by Key, so adding a field needs no schema migration.
+type CustomFieldDefinition struct {
+	ID           uint              `json:"id" gorm:"primaryKey"`
+	Organization string            `json:"organization" gorm:"not null;size:255;uniqueIndex:idx_custom_field_key"`
+	Entity       CustomFieldEntity `json:"entity" gorm:"not null;size:20;uniqueIndex:idx_custom_field_key"`
+	Key          string            `json:"key" gorm:"not null;size:50;uniqueIndex:idx_custom_field_key"`
+	Label        string            `json:"label" gorm:"not null;size:100"`
+	Type         CustomFieldType   `json:"type" gorm:"not null;size:20"`
+	Options      datatypes.JSON    `json:"options" gorm:"type:jsonb"` // Allowed values of a select field
+	Required     bool              `json:"required" gorm:"not null;default:false"`
+	MaxLength    *int              `json:"max_length"` // Text fields only
+
+	CreatedBy string    `json:"created_by" gorm:"not null;size:255"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
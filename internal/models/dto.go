@@ -175,12 +175,33 @@ type TimeBucket struct {
 	Count int    `json:"count"`
 }
 
+// RankedScore is one finished attempt's percentage, as stored for ranking attempts
+type RankedScore struct {
+	AttemptID  uint    `json:"attempt_id"`
+	Percentage float64 `json:"percentage"`
+}
+
+// ItemStat is the classical test theory analysis of one question within an assessment
+type ItemStat struct {
+	QuestionID          uint     `json:"question_id"`
+	Responses           int      `json:"responses"`
+	DifficultyIndex     float64  `json:"difficulty_index"`     // Average share of the maximum score earned (p-value)
+	DiscriminationIndex float64  `json:"discrimination_index"` // Upper minus lower 27% of attempts by total score
+	PointBiserial       *float64 `json:"point_biserial"`       // Correlation with the rest of the test; nil without variance
+}
+
 type OptionStat struct {
 	OptionID       string  `json:"option_id"`
 	OptionText     string  `json:"option_text"`
 	SelectionCount int     `json:"selection_count"`
 	SelectionRate  float64 `json:"selection_rate"`
 	IsCorrect      bool    `json:"is_correct"`
+
+	// Distractor analysis: selection rates in the lowest and highest scoring quartiles
+	LowGroupRate   float64 `json:"low_group_rate"`
+	HighGroupRate  float64 `json:"high_group_rate"`
+	Discrimination float64 `json:"discrimination"`  // High minus low group rate; distractors should be negative
+	NonFunctioning bool    `json:"non_functioning"` // Distractor chosen too rarely to be plausible
 }
 
 type AssessmentStats struct {
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+// AssessmentEnrollment assigns a student to an assessment
+type AssessmentEnrollment struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	AssessmentID uint      `json:"assessment_id" gorm:"not null;uniqueIndex:idx_enrollment_assessment_student"`
+	StudentID    string    `json:"student_id" gorm:"not null;uniqueIndex:idx_enrollment_assessment_student;size:255"`
+	ClassID      *string   `json:"class_id" gorm:"size:100;index"` // Class or section the student takes the assessment with
+	EnrolledBy   string    `json:"enrolled_by" gorm:"not null;size:255"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AssessmentClassOverride replaces the assessment's due date, availability window or attempt
+// limit for the students enrolled with one class. Unset fields keep the assessment's values.
+type AssessmentClassOverride struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	AssessmentID   uint       `json:"assessment_id" gorm:"not null;uniqueIndex:idx_class_override_assessment_class"`
+	ClassID        string     `json:"class_id" gorm:"not null;uniqueIndex:idx_class_override_assessment_class;size:100"`
+	DueDate        *time.Time `json:"due_date"`
+	AvailableFrom  *time.Time `json:"available_from"`
+	AvailableUntil *time.Time `json:"available_until"`
+	MaxAttempts    *int       `json:"max_attempts"`
+	UpdatedBy      string     `json:"updated_by" gorm:"not null;size:255"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// AssessmentView records that a student opened an assessment's details
+type AssessmentView struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	AssessmentID  uint      `json:"assessment_id" gorm:"not null;uniqueIndex:idx_view_assessment_student"`
+	StudentID     string    `json:"student_id" gorm:"not null;uniqueIndex:idx_view_assessment_student;size:255"`
+	ViewCount     int       `json:"view_count" gorm:"default:1"`
+	FirstViewedAt time.Time `json:"first_viewed_at"`
+	LastViewedAt  time.Time `json:"last_viewed_at"`
+}
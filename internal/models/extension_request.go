@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+)
+
+type ExtensionRequestKind string
+
+const (
+	ExtensionDeadline     ExtensionRequestKind = "deadline"      // A later due date
+	ExtensionExtraAttempt ExtensionRequestKind = "extra_attempt" // One more attempt than the limit
+)
+
+type ExtensionRequestStatus string
+
+const (
+	ExtensionPending   ExtensionRequestStatus = "pending"
+	ExtensionApproved  ExtensionRequestStatus = "approved"
+	ExtensionDenied    ExtensionRequestStatus = "denied"
+	ExtensionWithdrawn ExtensionRequestStatus = "withdrawn"
+)
+
+// ExtensionRequest is a student's request for a later due date or an extra attempt, decided
+// by the assessment's teacher. Approving it grants a StudentAccommodation.
+type ExtensionRequest struct {
+	ID               uint                   `json:"id" gorm:"primaryKey"`
+	AssessmentID     uint                   `json:"assessment_id" gorm:"not null;index:idx_extension_request_assessment_student"`
+	StudentID        string                 `json:"student_id" gorm:"not null;index:idx_extension_request_assessment_student;size:255"`
+	Kind             ExtensionRequestKind   `json:"kind" gorm:"not null;size:20"`
+	Status           ExtensionRequestStatus `json:"status" gorm:"not null;default:pending;size:20;index"`
+	Reason           string                 `json:"reason" gorm:"not null;type:text"`
+	RequestedDueDate *time.Time             `json:"requested_due_date"` // Deadline requests only
+
+	// Supporting document, e.g. a doctor's note
+	AttachmentName *string `json:"attachment_name"`
+	AttachmentType *string `json:"attachment_type" gorm:"size:100"`
+	AttachmentSize int64   `json:"attachment_size"`
+	AttachmentPath *string `json:"-" gorm:"size:500"`
+
+	// Decision
+	DecidedBy       *string    `json:"decided_by" gorm:"size:255"`
+	DecidedAt       *time.Time `json:"decided_at"`
+	DecisionNote    *string    `json:"decision_note" gorm:"type:text"`
+	AccommodationID *uint      `json:"accommodation_id"` // Granted on approval
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relations
+	Assessment *Assessment `json:"assessment,omitempty" gorm:"foreignKey:AssessmentID"`
+}
+
+// StudentAccommodation changes one student's due date or attempt limit for an assessment,
+// on top of the override of their class
+type StudentAccommodation struct {
+	ID                 uint       `json:"id" gorm:"primaryKey"`
+	AssessmentID       uint       `json:"assessment_id" gorm:"not null;index:idx_accommodation_assessment_student"`
+	StudentID          string     `json:"student_id" gorm:"not null;index:idx_accommodation_assessment_student;size:255"`
+	DueDate            *time.Time `json:"due_date"`                                 // Later due date, if granted
+	ExtraAttempts      int        `json:"extra_attempts" gorm:"not null;default:0"` // Added to the attempt limit
+	ExtensionRequestID *uint      `json:"extension_request_id"`
+	GrantedBy          string     `json:"granted_by" gorm:"not null;size:255"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+type FavoriteItemType string
+
+const (
+	FavoriteQuestion   FavoriteItemType = "question"
+	FavoriteAssessment FavoriteItemType = "assessment"
+)
+
+// Favorite is a question or assessment a teacher starred for quick access
+type Favorite struct {
+	ID       uint             `json:"id" gorm:"primaryKey"`
+	UserID   string           `json:"user_id" gorm:"not null;size:255;uniqueIndex:idx_favorite_user_item"`
+	ItemType FavoriteItemType `json:"item_type" gorm:"not null;size:20;uniqueIndex:idx_favorite_user_item"`
+	ItemID   uint             `json:"item_id" gorm:"not null;uniqueIndex:idx_favorite_user_item"`
+	Note     *string          `json:"note" gorm:"type:text"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FavoriteListShare lets a colleague browse a teacher's favorites
+type FavoriteListShare struct {
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	OwnerID    string `json:"owner_id" gorm:"not null;size:255;uniqueIndex:idx_favorite_share"`
+	SharedWith string `json:"shared_with" gorm:"not null;size:255;uniqueIndex:idx_favorite_share;index"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	Owner User `json:"owner" gorm:"foreignKey:OwnerID"`
+}
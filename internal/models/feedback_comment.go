@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// FeedbackComment is a curated entry of a question's comment bank. Entries are either
+// written by a teacher or pinned from feedback mined out of earlier grading, and hidden
+// entries suppress a mined suggestion that should no longer be offered.
+type FeedbackComment struct {
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	QuestionID uint   `json:"question_id" gorm:"not null;index"`
+	Text       string `json:"text" gorm:"type:text;not null"`
+	Pinned     bool   `json:"pinned" gorm:"default:false"`
+	Hidden     bool   `json:"hidden" gorm:"default:false"`
+	CreatedBy  string `json:"created_by" gorm:"not null;size:255"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type FlagEscalationStatus string
+
+const (
+	FlagEscalationOpen       FlagEscalationStatus = "open"
+	FlagEscalationDismissed  FlagEscalationStatus = "dismissed"
+	FlagEscalationRemediated FlagEscalationStatus = "remediated"
+)
+
+// FlagRemediation is how the owner corrected a question students flagged
+type FlagRemediation string
+
+const (
+	FlagRemediationAcceptAnswers FlagRemediation = "accept_answers" // More options count as correct
+	FlagRemediationVoid          FlagRemediation = "void"           // Every answer gets full credit
+)
+
+// QuestionFlagEscalation is raised when many students taking an assessment flag the same
+// question within a few minutes, which usually means the item is wrong. A question is
+// escalated at most once per assessment. The remediation only applies to that assessment.
+type QuestionFlagEscalation struct {
+	ID           uint                 `json:"id" gorm:"primaryKey"`
+	AssessmentID uint                 `json:"assessment_id" gorm:"not null;uniqueIndex:idx_flag_escalation_question"`
+	QuestionID   uint                 `json:"question_id" gorm:"not null;uniqueIndex:idx_flag_escalation_question"`
+	Status       FlagEscalationStatus `json:"status" gorm:"not null;default:open;size:20;index"`
+
+	// What triggered it
+	FlagCount      int       `json:"flag_count"`      // Flags raised within the window
+	ActiveAttempts int       `json:"active_attempts"` // Attempts in progress at the time
+	EscalatedAt    time.Time `json:"escalated_at"`
+
+	// Resolution
+	Remediation       *FlagRemediation `json:"remediation" gorm:"size:20"`
+	AcceptedAnswers   datatypes.JSON   `json:"accepted_answers" gorm:"type:jsonb"` // []string option IDs, accept_answers only
+	AnswerKeyChangeID *uint            `json:"answer_key_change_id"`
+	RegradeJobID      *uint            `json:"regrade_job_id"` // Unset when no attempt had finished yet
+	ResolvedBy        *string          `json:"resolved_by" gorm:"size:255"`
+	ResolvedAt        *time.Time       `json:"resolved_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+)
+
+type GradebookProvider string
+
+const (
+	GradebookProviderREST            GradebookProvider = "rest"
+	GradebookProviderGoogleClassroom GradebookProvider = "google_classroom"
+)
+
+// GradebookStudentKey selects how students are identified in the external gradebook
+type GradebookStudentKey string
+
+const (
+	GradebookStudentKeyID    GradebookStudentKey = "id"
+	GradebookStudentKeyEmail GradebookStudentKey = "email"
+)
+
+// GradebookScoreField selects which attempt result is written to the gradebook column
+type GradebookScoreField string
+
+const (
+	GradebookScoreFieldScore      GradebookScoreField = "score"
+	GradebookScoreFieldPercentage GradebookScoreField = "percentage"
+)
+
+type PassbackStatus string
+
+const (
+	PassbackPending    PassbackStatus = "pending"
+	PassbackProcessing PassbackStatus = "processing"
+	PassbackDelivered  PassbackStatus = "delivered"
+	PassbackDeadLetter PassbackStatus = "dead_letter" // Out of retries, waits for a resync
+)
+
+// GradebookIntegration pushes released grades of an assessment to an external gradebook
+// column. Each assessment maps to its own column, so one gradebook can have several.
+type GradebookIntegration struct {
+	ID           uint              `json:"id" gorm:"primaryKey"`
+	AssessmentID uint              `json:"assessment_id" gorm:"not null;index"`
+	Provider     GradebookProvider `json:"provider" gorm:"not null;size:30"`
+	Name         string            `json:"name" gorm:"not null;size:100"`
+	Enabled      bool              `json:"enabled" gorm:"not null;default:true"`
+
+	// Connection
+	Endpoint  string `json:"endpoint" gorm:"size:500"`           // REST: URL grades are posted to; Classroom: API base override
+	AuthToken string `json:"-" gorm:"type:text"`                 // Sent as a bearer token
+	CourseID  string `json:"course_id" gorm:"size:255"`          // Classroom course
+	ColumnID  string `json:"column_id" gorm:"not null;size:255"` // REST column, Classroom course work ID
+
+	// Column mapping
+	StudentKey GradebookStudentKey `json:"student_key" gorm:"not null;default:id;size:20"`
+	ScoreField GradebookScoreField `json:"score_field" gorm:"not null;default:percentage;size:20"`
+	MaxPoints  *float64            `json:"max_points"` // Rescales the score to the column's maximum
+
+	CreatedBy string    `json:"created_by" gorm:"not null;size:255"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GradePassback tracks delivery of one student's final grade through one integration
+type GradePassback struct {
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	IntegrationID uint           `json:"integration_id" gorm:"not null;uniqueIndex:idx_grade_passback_student"`
+	StudentID     string         `json:"student_id" gorm:"not null;size:255;uniqueIndex:idx_grade_passback_student"`
+	AssessmentID  uint           `json:"assessment_id" gorm:"not null;index"`
+	AttemptID     uint           `json:"attempt_id" gorm:"not null"` // Latest graded attempt when queued
+	Status        PassbackStatus `json:"status" gorm:"not null;default:pending;index"`
+
+	// Delivery
+	Attempts       int        `json:"attempts" gorm:"default:0"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at" gorm:"index"`
+	LastError      *string    `json:"last_error" gorm:"type:text"`
+	DeliveredScore *float64   `json:"delivered_score"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relations
+	Integration GradebookIntegration `json:"-" gorm:"foreignKey:IntegrationID"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// GradingDelegation lets another teacher grade an assessment for a limited time. The delegate
+// may only grade; each grading action taken under the delegation is audit-logged against it.
+type GradingDelegation struct {
+	ID           uint   `json:"id" gorm:"primaryKey"`
+	AssessmentID uint   `json:"assessment_id" gorm:"not null;index"`
+	OwnerID      string `json:"owner_id" gorm:"not null;size:255"` // Who delegated
+	DelegateID   string `json:"delegate_id" gorm:"not null;index;size:255"`
+	Note         string `json:"note" gorm:"type:text"`
+
+	StartsAt  time.Time  `json:"starts_at" gorm:"not null"`
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null;index"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	RevokedBy *string    `json:"revoked_by" gorm:"size:255"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relations
+	Assessment Assessment `json:"-" gorm:"foreignKey:AssessmentID;constraint:OnDelete:CASCADE"`
+}
+
+// IsActive reports whether the delegate may grade under the delegation at the given time
+func (d *GradingDelegation) IsActive(now time.Time) bool {
+	return d.RevokedAt == nil && !now.Before(d.StartsAt) && now.Before(d.ExpiresAt)
+}
+
+func (GradingDelegation) TableName() string {
+	return "grading_delegations"
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// ImpersonationSession lets a support admin act as another user for a limited time.
+// Only a hash of the session token is stored; requests made under it are audit-logged.
+type ImpersonationSession struct {
+	ID           uint   `json:"id" gorm:"primaryKey"`
+	TokenHash    string `json:"-" gorm:"not null;uniqueIndex;size:64"`
+	AdminID      string `json:"admin_id" gorm:"not null;index;size:255"`
+	TargetUserID string `json:"target_user_id" gorm:"not null;index;size:255"`
+	Reason       string `json:"reason" gorm:"not null;type:text"`
+	AllowWrites  bool   `json:"allow_writes" gorm:"not null;default:false"` // Read-only unless granted explicitly
+
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null;index"`
+	EndedAt   *time.Time `json:"ended_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// IsActive reports whether requests may still be made under the session
+func (s *ImpersonationSession) IsActive(now time.Time) bool {
+	return s.EndedAt == nil && now.Before(s.ExpiresAt)
+}
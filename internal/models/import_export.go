@@ -14,6 +14,7 @@ const (
 	ImportCompleted        ImportJobStatus = "completed"
 	ImportFailed           ImportJobStatus = "failed"
 	ImportValidationFailed ImportJobStatus = "validation_failed"
+	ImportCancelled        ImportJobStatus = "cancelled"
 )
 
 type ImportJob struct {
@@ -32,20 +33,28 @@ type ImportJob struct {
 	Status   ImportJobStatus `json:"status" gorm:"default:pending;index"`
 	Progress int             `json:"progress" gorm:"default:0"` // 0-100
 
-	// Processing info
+	// Processing info. ProcessedRows is the checkpoint a resumed import continues from.
 	TotalRows     int `json:"total_rows"`
 	ProcessedRows int `json:"processed_rows"`
 	SuccessCount  int `json:"success_count"`
+	UpdatedCount  int `json:"updated_count"` // Successful rows that updated a question imported before
 	ErrorCount    int `json:"error_count"`
 
+	// Optional content linting of imported questions
+	LintEnabled      bool     `json:"lint_enabled" gorm:"default:false"`
+	TargetGradeLevel *float64 `json:"target_grade_level"`
+
 	// Results
-	Errors  datatypes.JSON `json:"errors" gorm:"type:jsonb"` // []ImportValidationError
-	Summary datatypes.JSON `json:"summary" gorm:"type:jsonb"`
+	Errors   datatypes.JSON `json:"errors" gorm:"type:jsonb"`   // []ImportValidationError
+	Warnings datatypes.JSON `json:"warnings" gorm:"type:jsonb"` // []ImportValidationError from linting; rows are still imported
+	Summary  datatypes.JSON `json:"summary" gorm:"type:jsonb"`
 
 	// Timestamps
 	StartedAt   *time.Time `json:"started_at"`
 	CompletedAt *time.Time `json:"completed_at"`
+	CancelledAt *time.Time `json:"cancelled_at"`
 	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"index"` // Heartbeat; stale processing jobs are resumed
 
 	// Relations
 	Assessment *Assessment   `json:"assessment" gorm:"foreignKey:AssessmentID"`
@@ -53,6 +62,14 @@ type ImportJob struct {
 	User       User          `json:"user" gorm:"foreignKey:UserID"`
 }
 
+// ImportJobFile holds the uploaded file of an import until its job finishes, so whichever
+// replica picks the job up, or resumes it after a crash, can read it
+type ImportJobFile struct {
+	JobID     string    `json:"job_id" gorm:"primaryKey;size:36"`
+	Data      []byte    `json:"-" gorm:"type:bytea;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type ImportValidationError struct {
 	Row     int    `json:"row"`
 	Column  string `json:"column"`
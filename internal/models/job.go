@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed" // Failed for good or out of attempts; failed jobs are the dead-letter queue
+)
+
+// Job is a unit of background work. Ref names the record the work is about, such as an
+// import job, which keeps the details and progress specific to the job type.
+type Job struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Type        string    `json:"type" gorm:"not null;size:50;index:idx_job_type_ref"`
+	Ref         string    `json:"ref" gorm:"not null;size:64;index:idx_job_type_ref"`
+	Status      JobStatus `json:"status" gorm:"not null;default:queued;size:20;index"`
+	Attempts    int       `json:"attempts" gorm:"not null;default:0"`
+	MaxAttempts int       `json:"max_attempts" gorm:"not null"`
+	RunAt       time.Time `json:"run_at" gorm:"not null;index"` // Not started before; pushed back after a failed attempt
+	LastError   *string   `json:"last_error" gorm:"type:text"`
+	CreatedBy   string    `json:"created_by" gorm:"not null;size:255;index"`
+
+	StartedAt  *time.Time `json:"started_at"` // Of the latest attempt
+	FinishedAt *time.Time `json:"finished_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"index"` // Heartbeat; stale running jobs are picked up again
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+)
+
+// MasteryTarget is a teacher's goal for a skill. Skills follow question tags, and a target
+// is measured on graded answers from the owning teacher's assessments.
+type MasteryTarget struct {
+	ID               uint    `json:"id" gorm:"primaryKey"`
+	Skill            string  `json:"skill" gorm:"not null;size:100;uniqueIndex:idx_mastery_target_skill"` // Normalized tag
+	Label            string  `json:"label" gorm:"not null;size:100"`
+	TargetPercentage float64 `json:"target_percentage" gorm:"not null"`
+	MinAnswers       int     `json:"min_answers" gorm:"default:3"` // Graded answers needed before milestones and mastery count
+	CreatedBy        string  `json:"created_by" gorm:"not null;size:255;uniqueIndex:idx_mastery_target_skill"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
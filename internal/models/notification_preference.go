@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Channels notifications can be delivered on
+const (
+	NotificationChannelInApp = "in_app"
+	NotificationChannelPush  = "push"
+	NotificationChannelEmail = "email"
+)
+
+// NotificationPreference is how a user wants to be notified. Types without an entry in
+// Channels use their default channels; an empty list opts out of a low-priority type.
+type NotificationPreference struct {
+	ID       uint           `json:"id" gorm:"primaryKey"`
+	UserID   string         `json:"user_id" gorm:"not null;size:255;uniqueIndex"`
+	Channels datatypes.JSON `json:"channels" gorm:"type:jsonb"` // map[event type][]channel
+
+	// Quiet hours in the user's timezone, "HH:MM"; a window may run past midnight.
+	// Notifications that are not urgent wait until the window ends.
+	QuietHoursStart *string `json:"quiet_hours_start" gorm:"size:5"`
+	QuietHoursEnd   *string `json:"quiet_hours_end" gorm:"size:5"`
+	Timezone        string  `json:"timezone" gorm:"not null;size:50;default:UTC"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type OfflineBundleStatus string
+
+const (
+	OfflineBundleExported OfflineBundleStatus = "exported"
+	OfflineBundleImported OfflineBundleStatus = "imported"
+	OfflineBundleRejected OfflineBundleStatus = "rejected"
+)
+
+// OfflineBundle is an attempt handed to a proctor app for an exam hall without internet.
+// The app signs the answers it collects with the bundle's key, so only that app can
+// return them.
+type OfflineBundle struct {
+	ID         uint                `json:"id" gorm:"primaryKey"`
+	AttemptID  uint                `json:"attempt_id" gorm:"not null;index"`
+	Status     OfflineBundleStatus `json:"status" gorm:"not null;default:exported;size:20;index"`
+	SigningKey string              `json:"-" gorm:"not null;size:64"` // Hex HMAC-SHA256 key
+	ExportedBy string              `json:"exported_by" gorm:"not null;size:255"`
+	ExportedAt time.Time           `json:"exported_at"`
+
+	// Set when the signed bundle comes back
+	ImportedBy      *string        `json:"imported_by" gorm:"size:255"`
+	ImportedAt      *time.Time     `json:"imported_at"`
+	DeviceInfo      datatypes.JSON `json:"device_info" gorm:"type:jsonb"`
+	AnswersApplied  int            `json:"answers_applied"`
+	Conflicts       datatypes.JSON `json:"conflicts" gorm:"type:jsonb"` // []OfflineAnswerConflict
+	RejectionReason *string        `json:"rejection_reason" gorm:"type:text"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// PracticeSet exposes a curated subset of a bank's questions as an always-available
+// self-assessment. Every session draws a short quiz from the subset and grades each answer as
+// soon as it is given. Sessions are kept apart from assessment attempts and their analytics.
+type PracticeSet struct {
+	ID               uint           `json:"id" gorm:"primaryKey"`
+	BankID           uint           `json:"bank_id" gorm:"not null;index"`
+	Title            string         `json:"title" gorm:"not null;size:200"`
+	Description      *string        `json:"description" gorm:"type:text"`
+	QuestionIDs      datatypes.JSON `json:"question_ids" gorm:"type:jsonb"` // []uint, the curated subset
+	QuestionsPerQuiz int            `json:"questions_per_quiz" gorm:"not null"`
+	ClassID          *string        `json:"class_id" gorm:"size:100;index"` // Students it is open to, nil = every student
+	IsActive         bool           `json:"is_active" gorm:"not null;default:true;index"`
+
+	CreatedBy string    `json:"created_by" gorm:"not null;size:255"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PracticeSession is one quiz a student took from a practice set
+type PracticeSession struct {
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	PracticeSetID uint           `json:"practice_set_id" gorm:"not null;index"`
+	StudentID     string         `json:"student_id" gorm:"not null;index;size:255"`
+	QuestionIDs   datatypes.JSON `json:"question_ids" gorm:"type:jsonb"` // []uint, in the order served
+	Answered      int            `json:"answered" gorm:"not null;default:0"`
+	Correct       int            `json:"correct" gorm:"not null;default:0"`
+	Score         float64        `json:"score" gorm:"not null;default:0"`
+	MaxScore      float64        `json:"max_score" gorm:"not null;default:0"` // Points of the questions answered so far
+	CompletedAt   *time.Time     `json:"completed_at"`                        // Set once every question is answered
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// PracticeAnswer is a graded answer to one question of a practice session. Each question is
+// answered once per session, since its feedback gives the answer away.
+type PracticeAnswer struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	SessionID  uint           `json:"session_id" gorm:"not null;uniqueIndex:idx_practice_answer_session_question"`
+	QuestionID uint           `json:"question_id" gorm:"not null;uniqueIndex:idx_practice_answer_session_question;index"`
+	Answer     datatypes.JSON `json:"answer" gorm:"type:jsonb"`
+	Score      float64        `json:"score" gorm:"not null"`
+	MaxScore   float64        `json:"max_score" gorm:"not null"`
+	IsCorrect  bool           `json:"is_correct" gorm:"not null"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
@@ -17,6 +17,15 @@ const (
 	Matching       QuestionType = "matching"
 	Ordering       QuestionType = "ordering"
 	ShortAnswer    QuestionType = "short_answer"
+	MultiPart      QuestionType = "multi_part"
+)
+
+// PartGradingMode decides whether a part of a multi-part question is graded automatically
+type PartGradingMode string
+
+const (
+	PartGradingAuto   PartGradingMode = "auto"
+	PartGradingManual PartGradingMode = "manual"
 )
 
 type DifficultyLevel string
@@ -44,6 +53,21 @@ type Question struct {
 	Difficulty DifficultyLevel `json:"difficulty" gorm:"default:medium;index"`
 	Tags       datatypes.JSON  `json:"tags" gorm:"type:jsonb"` // []string
 
+	// Answers are auto-graded provisionally and still wait for a teacher, e.g. code checked by a regex.
+	// Multi-part questions use their parts' grading modes instead.
+	RequireManualReview bool `json:"require_manual_review" gorm:"not null;default:false"`
+
+	// Drafts are saved without full validation and cannot be added to banks or assessments
+	// until they are finalized
+	IsDraft bool `json:"is_draft" gorm:"not null;default:false;index"`
+
+	// Content review; bank owners are reminded once a question is not reviewed for its interval
+	LastReviewedAt       *time.Time `json:"last_reviewed_at"`
+	ReviewIntervalMonths *int       `json:"review_interval_months"` // null = 12 months
+
+	// Raised on every edit; bank change feeds record the version each change produced
+	Version int `json:"version" gorm:"not null;default:1"`
+
 	// Metadata
 	Explanation *string        `json:"explanation" gorm:"type:text"`
 	CreatedBy   string         `json:"created_by" gorm:"not null;index;size:255"`
@@ -74,6 +98,17 @@ type AssessmentQuestion struct {
 	TimeLimit *int `json:"time_limit"` // Override question time limit
 	Required  bool `json:"required" gorm:"default:true"`
 
+	// How many times a student may change a given answer; 0 locks it after the first one.
+	// Nil means no limit.
+	MaxAnswerChanges *int `json:"max_answer_changes"`
+
+	// Branching: after this question the first matching rule decides where the student goes
+	BranchRules datatypes.JSON `json:"branch_rules" gorm:"type:jsonb"`   // []BranchRule
+	Conditional bool           `json:"conditional" gorm:"default:false"` // Skipped unless a rule unlocks it
+
+	// Section the question belongs to; sections follow the order of their first question
+	Section *string `json:"section" gorm:"size:100"`
+
 	CreatedAt time.Time `json:"created_at"`
 
 	// Relations
@@ -84,6 +119,15 @@ type AssessmentQuestion struct {
 	gorm.Model `gorm:"uniqueIndex:idx_assessment_question"`
 }
 
+// BranchRule routes a student by their answer to the question it is attached to. A rule
+// matches on a multiple choice option or a true/false value.
+type BranchRule struct {
+	OptionID string `json:"option_id,omitempty"` // Multiple choice: the option is selected
+	Value    *bool  `json:"value,omitempty"`     // True/false: the answer given
+	GoTo     *uint  `json:"go_to,omitempty"`     // Question to continue with; questions in between are skipped
+	Unlock   []uint `json:"unlock,omitempty"`    // Conditional questions to include later on
+}
+
 type QuestionCategory struct {
 	ID          uint    `json:"id" gorm:"primaryKey"`
 	Name        string  `json:"name" gorm:"not null;size:100" validate:"required,max=100"`
@@ -126,6 +170,11 @@ type QuestionAttachment struct {
 	URL          string  `json:"url" gorm:"not null;size:500"`
 	ThumbnailURL *string `json:"thumbnail_url" gorm:"size:500"`
 
+	// Images: original dimensions and the web-optimized copies served to students
+	Width    int            `json:"width,omitempty"`
+	Height   int            `json:"height,omitempty"`
+	Variants datatypes.JSON `json:"variants,omitempty" gorm:"type:jsonb"` // []ImageVariant
+
 	// Metadata
 	Alt     *string `json:"alt" gorm:"size:255"` // For images
 	Caption *string `json:"caption" gorm:"type:text"`
@@ -137,6 +186,16 @@ type QuestionAttachment struct {
 	Question Question `json:"question" gorm:"foreignKey:QuestionID"`
 }
 
+// ImageVariant is a resized copy of a question image, served from the media CDN
+type ImageVariant struct {
+	Name   string `json:"name"` // thumbnail, standard or retina
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Size   int64  `json:"size"`
+	Key    string `json:"key"` // Path under the media root
+	URL    string `json:"url"`
+}
+
 // ===== QUESTION CONTENT SCHEMAS =====
 
 type MultipleChoiceContent struct {
@@ -158,6 +217,10 @@ type TrueFalseContent struct {
 	CorrectAnswer bool    `json:"correct_answer"`
 	TrueLabel     *string `json:"true_label"` // Custom labels
 	FalseLabel    *string `json:"false_label"`
+
+	// Shown to students after grading in place of the generated feedback
+	CorrectFeedback   *string `json:"correct_feedback,omitempty"`
+	IncorrectFeedback *string `json:"incorrect_feedback,omitempty"`
 }
 
 type EssayContent struct {
@@ -168,6 +231,19 @@ type EssayContent struct {
 	SampleAnswer    *string  `json:"sample_answer"`
 	AutoGrade       bool     `json:"auto_grade"`
 	KeyWords        []string `json:"key_words"` // For auto-grading
+
+	// Scoring levels of the rubric criteria, in the same order; empty when criteria are names only
+	Rubric []RubricCriterion `json:"rubric,omitempty"`
+}
+
+type RubricCriterion struct {
+	Name   string        `json:"name"`
+	Levels []RubricLevel `json:"levels"`
+}
+
+type RubricLevel struct {
+	Name   string `json:"name"`
+	Points int    `json:"points"`
 }
 
 type FillBlankContent struct {
@@ -203,11 +279,21 @@ type MatchPair struct {
 	RightID string `json:"right_id"`
 }
 
+// OrderingScoring is how an ordering answer earns partial credit
+type OrderingScoring string
+
+const (
+	OrderingScoringPosition      OrderingScoring = "position"       // Share of items in their correct position
+	OrderingScoringKendallTau    OrderingScoring = "kendall_tau"    // Kendall's tau between the answer and the correct order, 0 when no better than chance
+	OrderingScoringAdjacentPairs OrderingScoring = "adjacent_pairs" // Share of correctly adjacent pairs that are adjacent in the answer
+)
+
 type OrderingContent struct {
-	Items         []OrderItem `json:"items" validate:"min=2,max=10"`
-	CorrectOrder  []string    `json:"correct_order"`
-	RandomizeInit bool        `json:"randomize_initial"`
-	PartialCredit bool        `json:"partial_credit"`
+	Items         []OrderItem     `json:"items" validate:"min=2,max=10"`
+	CorrectOrder  []string        `json:"correct_order"`
+	RandomizeInit bool            `json:"randomize_initial"`
+	PartialCredit bool            `json:"partial_credit"`
+	Scoring       OrderingScoring `json:"scoring,omitempty"` // Only used with partial credit; unset keeps the original position credit
 }
 
 type OrderItem struct {
@@ -224,3 +310,29 @@ type ShortAnswerContent struct {
 	PlaceholderText *string  `json:"placeholder_text"`
 	FuzzyMatching   bool     `json:"fuzzy_matching"`
 }
+
+// MultiPartContent is a composite question whose parts (1a, 1b, ...) are answered and graded
+// separately; the question is worth the sum of its parts' points
+type MultiPartContent struct {
+	Parts []QuestionPart `json:"parts" validate:"min=2,max=10"`
+}
+
+// QuestionPart is one sub-question of a multi-part question, in the listed order. Content
+// follows the schema of the part's own type.
+type QuestionPart struct {
+	ID          string          `json:"id"`
+	Label       string          `json:"label"` // "a", "b", ...
+	Text        string          `json:"text"`
+	Type        QuestionType    `json:"type"`
+	Points      int             `json:"points"`
+	Content     datatypes.JSON  `json:"content"`
+	GradingMode PartGradingMode `json:"grading_mode"` // Defaults to auto; essay parts are always manual
+}
+
+// EffectiveGradingMode returns how the part is graded once defaults apply
+func (p *QuestionPart) EffectiveGradingMode() PartGradingMode {
+	if p.Type == Essay || p.GradingMode == PartGradingManual {
+		return PartGradingManual
+	}
+	return PartGradingAuto
+}
@@ -15,6 +15,15 @@ type QuestionBank struct {
 	IsPublic bool `json:"is_public" gorm:"default:false"`
 	IsShared bool `json:"is_shared" gorm:"default:false"`
 
+	// Licensing (third-party content with seat limits)
+	IsLicensed       bool       `json:"is_licensed" gorm:"default:false;index"`
+	LicenseProvider  *string    `json:"license_provider" gorm:"size:200"`
+	LicenseSeats     *int       `json:"license_seats"` // Distinct students who may see the bank's questions, nil = unlimited
+	LicenseExpiresAt *time.Time `json:"license_expires_at"`
+
+	// Last reminder to the owner about questions due for review
+	ReviewReminderSentAt *time.Time `json:"review_reminder_sent_at"`
+
 	// Metadata
 	CreatedBy string         `json:"created_by" gorm:"not null;index;size:255"`
 	CreatedAt time.Time      `json:"created_at"`
@@ -51,3 +60,23 @@ type QuestionBankShare struct {
 
 	gorm.Model `gorm:"uniqueIndex:idx_bank_user_share"`
 }
+
+type QuestionBankChangeType string
+
+const (
+	QuestionBankChangeAdded   QuestionBankChangeType = "added"
+	QuestionBankChangeUpdated QuestionBankChangeType = "updated"
+	QuestionBankChangeRetired QuestionBankChangeType = "retired" // Removed from the bank or deleted
+)
+
+// QuestionBankChange is one entry of a bank's change feed. Entries are written with the change
+// and never edited, so content pipelines and sync clients can read the feed from a cursor.
+type QuestionBankChange struct {
+	ID              uint                   `json:"id" gorm:"primaryKey"`
+	BankID          uint                   `json:"bank_id" gorm:"not null;index:idx_question_bank_changes_feed"`
+	QuestionID      uint                   `json:"question_id" gorm:"not null;index"`
+	Change          QuestionBankChangeType `json:"change" gorm:"not null;size:20"`
+	QuestionVersion int                    `json:"question_version" gorm:"not null"`
+	ChangedBy       string                 `json:"changed_by" gorm:"not null;size:255"`
+	CreatedAt       time.Time              `json:"created_at" gorm:"index:idx_question_bank_changes_feed"`
+}
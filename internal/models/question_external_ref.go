@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// QuestionExternalRef maps a question to the ID a content provider, e.g. a publisher, gives it.
+// An external ID belongs to at most one question per provider among one owner's questions, so
+// re-importing the provider's content updates the questions imported before.
+type QuestionExternalRef struct {
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	QuestionID uint   `json:"question_id" gorm:"not null;uniqueIndex:idx_question_external_ref_provider"`
+	OwnerID    string `json:"owner_id" gorm:"not null;size:255;uniqueIndex:idx_question_external_ref_id"` // Creator of the question
+	Provider   string `json:"provider" gorm:"not null;size:100;uniqueIndex:idx_question_external_ref_provider;uniqueIndex:idx_question_external_ref_id"`
+	ExternalID string `json:"external_id" gorm:"not null;size:255;uniqueIndex:idx_question_external_ref_id"`
+	CreatedBy  string `json:"created_by" gorm:"not null;size:255"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// TranslationStatus tracks whether a localized variant still says what the question says
+type TranslationStatus string
+
+const (
+	TranslationPendingReview    TranslationStatus = "pending_review"    // Edited, waiting for a reviewer
+	TranslationApproved         TranslationStatus = "approved"          // Reviewed against the current source
+	TranslationChangesRequested TranslationStatus = "changes_requested" // Reviewer sent it back
+	TranslationStale            TranslationStatus = "stale"             // Another locale changed since the last edit
+
+	// TranslationMissing only filters listings: questions without a translation in the locale
+	TranslationMissing TranslationStatus = "missing"
+)
+
+// TranslationSourceLocale names the question's own text as the cause of a stale translation
+const TranslationSourceLocale = "source"
+
+// QuestionTranslation is a localized variant of a question. Content mirrors the question's
+// content structure with localized labels; empty content means the source content is used.
+type QuestionTranslation struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	QuestionID  uint           `json:"question_id" gorm:"not null;uniqueIndex:idx_question_translation_locale"`
+	Locale      string         `json:"locale" gorm:"not null;size:20;uniqueIndex:idx_question_translation_locale;index"`
+	Text        string         `json:"text" gorm:"type:text;not null"`
+	Content     datatypes.JSON `json:"content" gorm:"type:jsonb"`
+	Explanation *string        `json:"explanation" gorm:"type:text"`
+
+	// Review workflow
+	Status       TranslationStatus `json:"status" gorm:"not null;default:pending_review;size:20;index"`
+	StaleSince   *time.Time        `json:"stale_since"`
+	StaleBecause *string           `json:"stale_because" gorm:"size:20"` // Locale whose edit made it stale, or "source"
+	UpdatedBy    string            `json:"updated_by" gorm:"not null;size:255"`
+	ReviewedBy   *string           `json:"reviewed_by" gorm:"size:255"`
+	ReviewedAt   *time.Time        `json:"reviewed_at"`
+	ReviewNote   *string           `json:"review_note" gorm:"type:text"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relations
+	Question *Question `json:"question,omitempty" gorm:"foreignKey:QuestionID"`
+}
+
+// LocaleStatus is the review state of one of a question's translations
+type LocaleStatus struct {
+	QuestionID uint              `json:"-"`
+	Locale     string            `json:"locale"`
+	Status     TranslationStatus `json:"status"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+type QuestionTrialStatus string
+
+const (
+	QuestionTrialRunning   QuestionTrialStatus = "running"
+	QuestionTrialPromoted  QuestionTrialStatus = "promoted"
+	QuestionTrialDiscarded QuestionTrialStatus = "discarded"
+)
+
+// QuestionTrial pilots a revised question alongside the original on one assessment. While it
+// runs, a share of new attempts is served the revision in the original's place; their answers
+// keep the original's question ID and record the revision in StudentAnswer.VariantQuestionID.
+type QuestionTrial struct {
+	ID                uint                `json:"id" gorm:"primaryKey"`
+	AssessmentID      uint                `json:"assessment_id" gorm:"not null;index"`
+	QuestionID        uint                `json:"question_id" gorm:"not null;index"`         // Original
+	VariantQuestionID uint                `json:"variant_question_id" gorm:"not null;index"` // Revision
+	Fraction          float64             `json:"fraction" gorm:"not null"`                  // Share of attempts served the revision
+	Status            QuestionTrialStatus `json:"status" gorm:"not null;default:running;index"`
+	CreatedBy         string              `json:"created_by" gorm:"not null;size:255"`
+	EndedBy           *string             `json:"ended_by" gorm:"size:255"`
+	EndedAt           *time.Time          `json:"ended_at"`
+	CreatedAt         time.Time           `json:"created_at"`
+	UpdatedAt         time.Time           `json:"updated_at"`
+}
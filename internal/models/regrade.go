@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// AnswerKeyChange records an edit that changed how a question is scored, so attempts
+// graded with the old key can be found and regraded
+type AnswerKeyChange struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	QuestionID  uint           `json:"question_id" gorm:"not null;index"`
+	ChangedBy   string         `json:"changed_by" gorm:"not null;size:255"`
+	PreviousKey datatypes.JSON `json:"previous_key" gorm:"type:jsonb"`
+	NewKey      datatypes.JSON `json:"new_key" gorm:"type:jsonb"`
+	CreatedAt   time.Time      `json:"created_at" gorm:"index"` // Attempts finished before this used the old key
+	// Set when the change only applies to one assessment, like a remediation of flagged questions
+	AssessmentID *uint `json:"assessment_id,omitempty" gorm:"index"`
+}
+
+type RegradeJobStatus string
+
+const (
+	RegradePending   RegradeJobStatus = "pending"
+	RegradeRunning   RegradeJobStatus = "running"
+	RegradeCompleted RegradeJobStatus = "completed"
+	RegradeFailed    RegradeJobStatus = "failed"
+)
+
+// RegradeJob regrades one assessment's answers to a question after its answer key changed
+type RegradeJob struct {
+	ID           uint             `json:"id" gorm:"primaryKey"`
+	ChangeID     uint             `json:"change_id" gorm:"not null;index"`
+	AssessmentID uint             `json:"assessment_id" gorm:"not null;index"`
+	Status       RegradeJobStatus `json:"status" gorm:"not null;default:pending;index"`
+	RequestedBy  string           `json:"requested_by" gorm:"not null;size:255"`
+
+	// Results
+	AnswersRegraded      int     `json:"answers_regraded"`
+	AttemptsRegraded     int     `json:"attempts_regraded"`
+	ManualAnswersSkipped int     `json:"manual_answers_skipped"` // Teacher grades are kept and need a manual look
+	Error                *string `json:"error" gorm:"type:text"`
+
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" gorm:"index"` // Heartbeat; stale running jobs are picked up again
+}
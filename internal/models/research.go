@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+// ResearchPolicy records whether an organization allows its assessment data to be sampled for
+// learning-science research, and under which consent and ethics approval. Without a policy,
+// or once the approval lapses, no samples are drawn.
+type ResearchPolicy struct {
+	ID                 uint       `json:"id" gorm:"primaryKey"`
+	Organization       string     `json:"organization" gorm:"not null;size:255;uniqueIndex"`
+	SamplingEnabled    bool       `json:"sampling_enabled" gorm:"not null;default:false"`
+	ConsentObtained    bool       `json:"consent_obtained" gorm:"not null;default:false"` // Students agreed to research use, e.g. in the enrollment terms
+	EthicsApproval     string     `json:"ethics_approval" gorm:"size:255"`                // Reference of the ethics board approval, such as an IRB protocol number
+	ApprovedUntil      *time.Time `json:"approved_until"`
+	AllowAnswerText    bool       `json:"allow_answer_text" gorm:"not null;default:false"` // Whether samples may carry what students wrote
+	MinStudentsPerBand int        `json:"min_students_per_band" gorm:"not null;default:5"` // Smaller score bands are left out of samples
+
+	UpdatedBy string    `json:"updated_by" gorm:"not null;size:255"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// OrganizationResidency pins an organization's data to a data residency region. It is set when
+// the organization is provisioned, cannot change afterwards, and is kept in the primary database.
+type OrganizationResidency struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	Organization  string    `json:"organization" gorm:"not null;size:255;uniqueIndex"`
+	Region        string    `json:"region" gorm:"not null;size:50"`
+	ProvisionedBy string    `json:"provisioned_by" gorm:"not null;size:255"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func (OrganizationResidency) TableName() string {
+	return "organization_residencies"
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// AssessmentShareLink gives an external reviewer, such as a moderator, read-only access to
+// an assessment's content and settings without an account. Only a hash of the link's
+// token is stored; every view is logged.
+type AssessmentShareLink struct {
+	ID           uint   `json:"id" gorm:"primaryKey"`
+	AssessmentID uint   `json:"assessment_id" gorm:"not null;index"`
+	TokenHash    string `json:"-" gorm:"not null;uniqueIndex;size:64"`
+	Label        string `json:"label" gorm:"not null;size:200"` // Who the link was given to, e.g. "External moderator"
+	CreatedBy    string `json:"created_by" gorm:"not null;index;size:255"`
+
+	ExpiresAt    time.Time  `json:"expires_at" gorm:"not null;index"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	RevokedBy    *string    `json:"revoked_by" gorm:"size:255"`
+	ViewCount    int        `json:"view_count" gorm:"not null;default:0"`
+	LastViewedAt *time.Time `json:"last_viewed_at"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IsActive reports whether the link still opens the assessment
+func (l *AssessmentShareLink) IsActive(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt)
+}
+
+// AssessmentShareView records one opening of a share link
+type AssessmentShareView struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	ShareLinkID uint      `json:"share_link_id" gorm:"not null;index"`
+	IPAddress   string    `json:"ip_address" gorm:"size:45"`
+	UserAgent   string    `json:"user_agent" gorm:"size:500"`
+	ViewedAt    time.Time `json:"viewed_at" gorm:"not null;index"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+type UsageMetric string
+
+const (
+	UsageAttemptsStarted   UsageMetric = "attempts_started"
+	UsageAIGradingCalls    UsageMetric = "ai_grading_calls"
+	UsageProctoringMinutes UsageMetric = "proctoring_minutes"
+	UsageAttachmentStorage UsageMetric = "attachment_storage_bytes" // Bytes uploaded; rollups report the running total
+)
+
+// UsageEvent is one billable event, attributed to the organization of the user who caused it
+type UsageEvent struct {
+	ID           uint        `json:"id" gorm:"primaryKey"`
+	Organization string      `json:"organization" gorm:"not null;size:255;index:idx_usage_event_org_time"`
+	Metric       UsageMetric `json:"metric" gorm:"not null;size:50;index"`
+	Quantity     int64       `json:"quantity" gorm:"not null"`
+	UserID       string      `json:"user_id" gorm:"not null;size:255"`
+	AssessmentID *uint       `json:"assessment_id" gorm:"index"`
+	AttemptID    *uint       `json:"attempt_id"`
+	OccurredAt   time.Time   `json:"occurred_at" gorm:"not null;index:idx_usage_event_org_time"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// UsageRollup totals one metric of one organization over a calendar month (UTC)
+type UsageRollup struct {
+	ID           uint        `json:"id" gorm:"primaryKey"`
+	Organization string      `json:"organization" gorm:"not null;size:255;uniqueIndex:idx_usage_rollup"`
+	Period       string      `json:"period" gorm:"not null;size:7;uniqueIndex:idx_usage_rollup"` // YYYY-MM
+	Metric       UsageMetric `json:"metric" gorm:"not null;size:50;uniqueIndex:idx_usage_rollup"`
+	Quantity     int64       `json:"quantity"`
+	Events       int         `json:"events"`
+	CalculatedAt time.Time   `json:"calculated_at"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
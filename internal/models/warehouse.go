@@ -0,0 +1,79 @@
+package models
+
+import (
+	"time"
+)
+
+type WarehouseTarget string
+
+const (
+	WarehouseTargetBigQuery  WarehouseTarget = "bigquery"
+	WarehouseTargetSnowflake WarehouseTarget = "snowflake"
+	WarehouseTargetS3Parquet WarehouseTarget = "s3_parquet"
+)
+
+type WarehouseRunKind string
+
+const (
+	WarehouseRunIncremental WarehouseRunKind = "incremental" // Nightly, picks up where the last run stopped
+	WarehouseRunBackfill    WarehouseRunKind = "backfill"    // Requested, re-exports a window without moving the watermark back
+)
+
+type WarehouseRunStatus string
+
+const (
+	WarehouseRunQueued    WarehouseRunStatus = "queued"
+	WarehouseRunRunning   WarehouseRunStatus = "running"
+	WarehouseRunCompleted WarehouseRunStatus = "completed"
+	WarehouseRunFailed    WarehouseRunStatus = "failed"
+)
+
+// WarehouseConnector exports an organization's assessment, attempt and answer facts to its
+// data warehouse every night
+type WarehouseConnector struct {
+	ID           uint            `json:"id" gorm:"primaryKey"`
+	Organization string          `json:"organization" gorm:"not null;size:255;uniqueIndex"`
+	Target       WarehouseTarget `json:"target" gorm:"not null;size:30"`
+	Enabled      bool            `json:"enabled" gorm:"not null;default:true"`
+
+	// Connection
+	Endpoint    string `json:"endpoint" gorm:"size:500"`         // Snowflake: account URL; BigQuery, S3: API base override
+	AuthToken   string `json:"-" gorm:"type:text"`               // BigQuery, Snowflake: bearer token; S3: secret access key
+	AccessKeyID string `json:"access_key_id" gorm:"size:255"`    // S3 only
+	Region      string `json:"region" gorm:"size:50"`            // S3 only
+	Dataset     string `json:"dataset" gorm:"not null;size:255"` // BigQuery: project.dataset; Snowflake: database.schema; S3: bucket/prefix
+
+	// Export state
+	SchemaVersion   int        `json:"schema_version" gorm:"not null;default:0"` // Version of the tables last written; 0 before the first export
+	ExportedThrough *time.Time `json:"exported_through"`                         // Facts changed up to here are in the warehouse
+	LastRunAt       *time.Time `json:"last_run_at"`
+	LastError       *string    `json:"last_error" gorm:"type:text"`
+
+	CreatedBy string    `json:"created_by" gorm:"not null;size:255"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WarehouseExportRun is one export of the facts changed within a window
+type WarehouseExportRun struct {
+	ID            uint               `json:"id" gorm:"primaryKey"`
+	ConnectorID   uint               `json:"connector_id" gorm:"not null;index;uniqueIndex:idx_warehouse_run_open_incremental,where:kind = 'incremental' AND (status = 'queued' OR status = 'running')"`
+	Kind          WarehouseRunKind   `json:"kind" gorm:"not null;size:20;uniqueIndex:idx_warehouse_run_open_incremental"`
+	Status        WarehouseRunStatus `json:"status" gorm:"not null;default:queued;size:20;index"`
+	SchemaVersion int                `json:"schema_version" gorm:"not null"`
+
+	// Facts changed after WindowStart (from the beginning when nil) up to WindowEnd
+	WindowStart *time.Time `json:"window_start"`
+	WindowEnd   time.Time  `json:"window_end" gorm:"not null"`
+
+	AssessmentFacts int     `json:"assessment_facts"`
+	AttemptFacts    int     `json:"attempt_facts"`
+	AnswerFacts     int     `json:"answer_facts"`
+	Error           *string `json:"error" gorm:"type:text"`
+
+	RequestedBy string     `json:"requested_by" gorm:"not null;size:255"`
+	StartedAt   *time.Time `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending    WebhookDeliveryStatus = "pending"
+	WebhookDeliveryProcessing WebhookDeliveryStatus = "processing"
+	WebhookDeliverySucceeded  WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed     WebhookDeliveryStatus = "failed" // Not retried on its own; replay it once the endpoint is fixed
+)
+
+// WebhookSubscription posts the service's events to an integration partner's endpoint
+type WebhookSubscription struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	Name       string         `json:"name" gorm:"not null;size:100"`
+	URL        string         `json:"url" gorm:"not null;size:500"`
+	Secret     string         `json:"-" gorm:"not null;size:64"`     // Signs every payload, see X-Webhook-Signature
+	EventTypes datatypes.JSON `json:"event_types" gorm:"type:jsonb"` // Event types delivered; every type when empty
+	Enabled    bool           `json:"enabled" gorm:"not null;default:true"`
+
+	CreatedBy string    `json:"created_by" gorm:"not null;size:255"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookDelivery is one post of an event to a subscription. The payload and the endpoint's
+// answer are kept so partners can see what was sent and send it again.
+type WebhookDelivery struct {
+	ID             uint                  `json:"id" gorm:"primaryKey"`
+	SubscriptionID uint                  `json:"subscription_id" gorm:"not null;index"`
+	EventID        string                `json:"event_id" gorm:"not null;size:100;index"`
+	EventType      string                `json:"event_type" gorm:"not null;size:100"`
+	Payload        datatypes.JSON        `json:"payload" gorm:"type:jsonb;not null"`
+	Test           bool                  `json:"test" gorm:"not null;default:false"`
+	ReplayOf       *uint                 `json:"replay_of"` // Delivery this one sends again
+	Status         WebhookDeliveryStatus `json:"status" gorm:"not null;default:pending;size:20;index"`
+
+	// Endpoint's answer
+	ResponseCode *int       `json:"response_code"`
+	ResponseBody *string    `json:"response_body" gorm:"type:text"` // Truncated
+	Error        *string    `json:"error" gorm:"type:text"`
+	DurationMs   int        `json:"duration_ms"`
+	DeliveredAt  *time.Time `json:"delivered_at"`
+
+	RequestedBy *string   `json:"requested_by" gorm:"size:255"` // Set for test events and replays
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
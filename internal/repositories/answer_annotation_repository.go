@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AnswerAnnotationRepository interface for grader annotations on essay answers
+type AnswerAnnotationRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, tx *gorm.DB, annotation *models.AnswerAnnotation) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AnswerAnnotation, error)
+	Update(ctx context.Context, tx *gorm.DB, annotation *models.AnswerAnnotation) error
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+
+	// Query operations, in text order
+	GetByAnswer(ctx context.Context, tx *gorm.DB, answerID uint) ([]*models.AnswerAnnotation, error)
+	GetByAttempt(ctx context.Context, tx *gorm.DB, attemptID uint) ([]*models.AnswerAnnotation, error)
+}
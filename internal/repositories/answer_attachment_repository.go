@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AnswerAttachmentRepository interface for files uploaded with student answers
+type AnswerAttachmentRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, tx *gorm.DB, attachment *models.AnswerAttachment) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AnswerAttachment, error) // Includes the attempt
+	Update(ctx context.Context, tx *gorm.DB, attachment *models.AnswerAttachment) error
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+
+	// Query operations
+	GetByAttemptAndQuestion(ctx context.Context, tx *gorm.DB, attemptID, questionID uint) ([]*models.AnswerAttachment, error)
+	CountByAttemptAndQuestion(ctx context.Context, tx *gorm.DB, attemptID, questionID uint) (int, error)
+	// GetRecognizedByQuestion returns attachments with recognised text on the question across
+	// all attempts on the assessment
+	GetRecognizedByQuestion(ctx context.Context, tx *gorm.DB, assessmentID, questionID uint) ([]*models.AnswerAttachment, error)
+	// SearchText matches recognised text on an assessment's attachments, case-insensitively
+	SearchText(ctx context.Context, tx *gorm.DB, assessmentID uint, query string, limit int) ([]*models.AnswerAttachment, error)
+
+	// Text recognition queue
+	// ClaimForOCR marks an attachment as processing and counts the try. Pending attachments,
+	// failed ones with tries left and ones left processing before staleBefore can be claimed;
+	// it reports false when another worker holds the attachment.
+	ClaimForOCR(ctx context.Context, tx *gorm.DB, id uint, staleBefore time.Time, maxAttempts int) (bool, error)
+	GetPendingOCR(ctx context.Context, tx *gorm.DB, staleBefore time.Time, maxAttempts, limit int) ([]uint, error)
+}
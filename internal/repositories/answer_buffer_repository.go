@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// AnswerBufferRepository interface for coalescing rapid autosaves before they reach the database,
+// and the write-ahead queue for answer writes the database refused. Only the latest write per
+// attempt and question, or question part, is kept.
+type AnswerBufferRepository interface {
+	// Available reports whether answers can be buffered; callers write through when it is false
+	Available() bool
+
+	Buffer(ctx context.Context, answer *BufferedAnswer) error
+	GetByAttempt(ctx context.Context, attemptID uint) ([]*BufferedAnswer, error)
+	// Clear removes flushed answers unless a newer write replaced them in the meantime
+	Clear(ctx context.Context, attemptID uint, answers []*BufferedAnswer) error
+
+	// GetDueAttempts returns attempts holding answers buffered before the given time
+	GetDueAttempts(ctx context.Context, bufferedBefore time.Time, limit int) ([]uint, error)
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AffectedAnswer is a finished attempt's answer to a question, graded before its answer key changed
+type AffectedAnswer struct {
+	AnswerID       uint       `json:"answer_id"`
+	AttemptID      uint       `json:"attempt_id"`
+	AssessmentID   uint       `json:"assessment_id"`
+	StudentID      string     `json:"student_id"`
+	AnswerScore    float64    `json:"answer_score"`
+	AttemptScore   float64    `json:"attempt_score"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	ManuallyGraded bool       `json:"manually_graded"`
+}
+
+// AnswerKeyChangeRepository interface for answer key changes and the regrade jobs they lead to
+type AnswerKeyChangeRepository interface {
+	// Changes
+	CreateChange(ctx context.Context, tx *gorm.DB, change *models.AnswerKeyChange) error
+	GetChangeByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AnswerKeyChange, error)
+	GetChangesByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) ([]*models.AnswerKeyChange, error) // Newest first
+	// GetAffectedAnswers returns answers to the question from completed or timed-out attempts
+	// finished by changedAt, optionally limited to one assessment
+	GetAffectedAnswers(ctx context.Context, tx *gorm.DB, questionID uint, changedAt time.Time, assessmentID *uint) ([]AffectedAnswer, error)
+
+	// Regrade jobs
+	CreateJob(ctx context.Context, tx *gorm.DB, job *models.RegradeJob) error
+	GetJobByID(ctx context.Context, tx *gorm.DB, id uint) (*models.RegradeJob, error)
+	UpdateJob(ctx context.Context, tx *gorm.DB, job *models.RegradeJob) error
+	GetJobsByChange(ctx context.Context, tx *gorm.DB, changeID uint) ([]*models.RegradeJob, error) // Newest first
+	// Claim marks a job as running if it is pending or its heartbeat is older than staleBefore;
+	// it reports false when another worker owns the job
+	ClaimJob(ctx context.Context, tx *gorm.DB, id uint, staleBefore time.Time) (bool, error)
+	// GetRunnableJobs returns pending jobs and running jobs whose heartbeat is older than staleBefore
+	GetRunnableJobs(ctx context.Context, tx *gorm.DB, staleBefore time.Time, limit int) ([]*models.RegradeJob, error)
+}
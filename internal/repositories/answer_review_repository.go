@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AnswerReviewRepository interface for moderation review sample operations
+type AnswerReviewRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, tx *gorm.DB, review *models.AnswerReview) error
+	CreateBatch(ctx context.Context, tx *gorm.DB, reviews []*models.AnswerReview) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AnswerReview, error)
+	Update(ctx context.Context, tx *gorm.DB, review *models.AnswerReview) error
+
+	// Query operations
+	GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AnswerReview, error)
+	GetPendingByReviewer(ctx context.Context, tx *gorm.DB, reviewerID string) ([]*models.AnswerReview, error)
+	GetByGrader(ctx context.Context, tx *gorm.DB, graderID string, filter GraderStatsFilter) ([]*models.AnswerReview, error)
+	CountPendingByReviewer(ctx context.Context, tx *gorm.DB, reviewerID string, filter GraderStatsFilter) (int, error)
+
+	// Sampling
+	// GetSampleCandidates returns manually graded answers of an assessment that have not
+	// been sampled yet, excluding answers graded by excludeGraderID
+	GetSampleCandidates(ctx context.Context, tx *gorm.DB, assessmentID uint, excludeGraderID string) ([]*models.StudentAnswer, error)
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AssessmentAnalyticsRepository interface for stored per-assessment statistics and item analysis
+type AssessmentAnalyticsRepository interface {
+	// Upsert replaces the stored analytics for the assessment
+	Upsert(ctx context.Context, tx *gorm.DB, analytics *models.AssessmentAnalytics) error
+	GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) (*models.AssessmentAnalytics, error)
+
+	// Analysis inputs
+	// GetItemScores returns every answer of completed or timed out attempts with nothing left to grade
+	GetItemScores(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]ItemScore, error)
+	// GetConfidenceResponses returns the rated answers of the same attempts
+	GetConfidenceResponses(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]ConfidenceResponse, error)
+	// GetStaleAssessmentIDs returns assessments with attempts finished or regraded since their last analysis
+	GetStaleAssessmentIDs(ctx context.Context, tx *gorm.DB, limit int) ([]uint, error)
+}
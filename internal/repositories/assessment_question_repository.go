@@ -40,6 +40,9 @@ type AssessmentQuestionRepository interface {
 	UpdateBatch(ctx context.Context, tx *gorm.DB, assessmentQuestions []*models.AssessmentQuestion) error
 	DeleteByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) error
 	DeleteByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) error
+	// ReplaceQuestion points the given assessments from oldQuestionID at newQuestionID, dropping
+	// the old link where the assessment already contains the new question; returns assessments remapped
+	ReplaceQuestion(ctx context.Context, tx *gorm.DB, oldQuestionID, newQuestionID uint, assessmentIDs []uint) (int, error)
 
 	// Validation and checks
 	Exists(ctx context.Context, tx *gorm.DB, assessmentID, questionID uint) (bool, error)
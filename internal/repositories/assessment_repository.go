@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"gorm.io/gorm"
@@ -38,6 +39,10 @@ type AssessmentRepository interface {
 
 	// Validation helpers
 	ExistsByTitle(ctx context.Context, tx *gorm.DB, title string, creatorID string, excludeID *uint) (bool, error)
+	GetByTitlePrefix(ctx context.Context, tx *gorm.DB, prefix string, creatorID string, excludeID *uint) ([]*models.Assessment, error)
+	// Other unarchived assessments, of any creator, sharing a question with the assessment or
+	// whose title reduced to lowercase letters equals a non-empty titleKey
+	GetOverlapping(ctx context.Context, tx *gorm.DB, assessmentID uint, titleKey string) ([]AssessmentOverlap, error)
 	HasAttempts(ctx context.Context, tx *gorm.DB, id uint) (bool, error)
 	HasActiveAttempts(ctx context.Context, tx *gorm.DB, id uint) (bool, error)
 
@@ -47,6 +52,11 @@ type AssessmentRepository interface {
 
 	UpdateDuration(ctx context.Context, tx *gorm.DB, assessmentID uint, duration int) error
 	UpdateMaxAttempts(ctx context.Context, tx *gorm.DB, assessmentID uint, maxAttempts int) error
+
+	// Series and scheduled publishing
+	CreateSeries(ctx context.Context, tx *gorm.DB, series *models.AssessmentSeries) error
+	GetDuePublishes(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]*models.Assessment, error)
+	ClearPublishAt(ctx context.Context, tx *gorm.DB, assessmentID uint) error
 }
 
 // AssessmentSettingsRepository interface for assessment settings operations
@@ -59,4 +69,14 @@ type AssessmentSettingsRepository interface {
 	// Bulk operations
 	CreateDefault(ctx context.Context, tx *gorm.DB, assessmentID uint) error
 	GetMultiple(ctx context.Context, tx *gorm.DB, assessmentIDs []uint) (map[uint]*models.AssessmentSettings, error)
+
+	// Results release
+	GetDueResultsReleases(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]*models.AssessmentSettings, error)
+	// ReleaseResults records the release unless the results were already released; it reports
+	// false when another release got there first
+	ReleaseResults(ctx context.Context, tx *gorm.DB, assessmentID uint, releasedAt time.Time, releasedBy string) (bool, error)
+
+	// SetExportAliasSalt stores the salt unless the assessment already has one; it reports
+	// false when another export set it first
+	SetExportAliasSalt(ctx context.Context, tx *gorm.DB, assessmentID uint, salt string) (bool, error)
 }
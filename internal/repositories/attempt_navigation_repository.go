@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AttemptNavigationRepository interface for the question navigation log of attempts
+type AttemptNavigationRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, event *models.AttemptNavigationEvent) error
+	// ListSubmittedByAssessment returns the events of the assessment's completed and timed out
+	// attempts, grouped by attempt and in the order they happened
+	ListSubmittedByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AttemptNavigationEvent, error)
+}
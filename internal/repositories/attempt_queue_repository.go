@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AttemptQueueRepository interface for students waiting on capped assessments
+type AttemptQueueRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, tx *gorm.DB, entry *models.AttemptQueueEntry) error
+	GetByStudent(ctx context.Context, tx *gorm.DB, assessmentID uint, studentID string) (*models.AttemptQueueEntry, error)
+	Update(ctx context.Context, tx *gorm.DB, entry *models.AttemptQueueEntry) error
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+
+	// LockAssessment serializes slot changes of an assessment until the transaction ends
+	LockAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) error
+
+	// Queue operations; waiting entries are served in the order they joined
+	GetWaiting(ctx context.Context, tx *gorm.DB, assessmentID uint, limit int) ([]*models.AttemptQueueEntry, error)
+	CountWaiting(ctx context.Context, tx *gorm.DB, assessmentID uint) (int, error)
+	CountWaitingAhead(ctx context.Context, tx *gorm.DB, assessmentID uint, entryID uint) (int, error)
+	CountHeld(ctx context.Context, tx *gorm.DB, assessmentID uint, now time.Time) (int, error)
+	DeleteExpiredHolds(ctx context.Context, tx *gorm.DB, assessmentID uint, now time.Time) error
+}
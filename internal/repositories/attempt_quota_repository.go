@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AttemptQuotaRepository interface for attempt quotas on question banks
+type AttemptQuotaRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, quota *models.AttemptQuota) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AttemptQuota, error)
+	Update(ctx context.Context, tx *gorm.DB, quota *models.AttemptQuota) error
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+	List(ctx context.Context, tx *gorm.DB) ([]*models.AttemptQuota, error)
+
+	// GetActive returns the active quotas, or only those on banks supplying the assessment's
+	// questions when assessmentID is set
+	GetActive(ctx context.Context, tx *gorm.DB, assessmentID *uint) ([]*models.AttemptQuota, error)
+	// GetAttemptStarts returns when the student started attempts on assessments drawing from
+	// the bank since the given time, oldest first
+	GetAttemptStarts(ctx context.Context, tx *gorm.DB, studentID string, bankID uint, since time.Time) ([]time.Time, error)
+	// LockStudent serializes the student's attempt starts counted by the quota until the
+	// transaction ends
+	LockStudent(ctx context.Context, tx *gorm.DB, quotaID uint, studentID string) error
+}
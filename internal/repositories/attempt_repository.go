@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -22,11 +23,24 @@ type AttemptRepository interface {
 	GetByStudent(ctx context.Context, tx *gorm.DB, studentID string, filters AttemptFilters) ([]*models.AssessmentAttempt, int64, error)
 	GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint, filters AttemptFilters) ([]*models.AssessmentAttempt, int64, error)
 	GetByStudentAndAssessment(ctx context.Context, tx *gorm.DB, studentID string, assessmentID uint) ([]*models.AssessmentAttempt, error)
+	GetStudentIDsByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]string, error)
+	// GetStudentIDsWithIncompleteAttempts returns the students with an attempt on the assessment
+	// still in progress, saved ones included
+	GetStudentIDsWithIncompleteAttempts(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]string, error)
+	GetStudentActivityByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]StudentAttemptActivity, error)
 
 	// Active attempt management
 	GetActiveAttempt(ctx context.Context, tx *gorm.DB, studentID string, assessmentID uint) (*models.AssessmentAttempt, error)
 	HasActiveAttempt(ctx context.Context, tx *gorm.DB, studentID string, assessmentID uint) (bool, error)
 	GetActiveAttempts(ctx context.Context, tx *gorm.DB, studentID string) ([]*models.AssessmentAttempt, error)
+	// GetDuplicateActiveAttempts returns the attempts in progress of every student with more than
+	// one in progress on the same assessment, ordered by student, assessment and ID. A nil
+	// assessment ID searches all assessments.
+	GetDuplicateActiveAttempts(ctx context.Context, tx *gorm.DB, assessmentID *uint) ([]*models.AssessmentAttempt, error)
+	// CountActive counts the assessment's attempts in progress and not yet past their end
+	CountActive(ctx context.Context, tx *gorm.DB, assessmentID uint, now time.Time) (int, error)
+	// CountActiveByAssessment counts attempts in progress and not yet past their end, per assessment
+	CountActiveByAssessment(ctx context.Context, tx *gorm.DB, now time.Time) (map[uint]int, error)
 
 	// Status management
 	UpdateStatus(ctx context.Context, tx *gorm.DB, id uint, status models.AttemptStatus) error
@@ -36,13 +50,23 @@ type AttemptRepository interface {
 	// Time management
 	UpdateTimeRemaining(ctx context.Context, tx *gorm.DB, id uint, timeRemaining int) error
 	GetInProgressAttempts(ctx context.Context, tx *gorm.DB) ([]*models.AssessmentAttempt, error)
+	// GetInProgressByAssessments returns the attempts in progress, paused ones included, on any of the assessments
+	GetInProgressByAssessments(ctx context.Context, tx *gorm.DB, assessmentIDs []uint) ([]*models.AssessmentAttempt, error)
 	GetTimedOutAttempts(ctx context.Context, tx *gorm.DB) ([]*models.AssessmentAttempt, error)
 	GetExpiredAttempts(ctx context.Context, tx *gorm.DB, cutoffTime time.Time) ([]*models.AssessmentAttempt, error)
+	// GetOverdue returns attempts still in progress whose end passed before endedBefore, oldest first
+	GetOverdue(ctx context.Context, tx *gorm.DB, endedBefore time.Time, limit int) ([]*models.AssessmentAttempt, error)
 
 	// Progress tracking
 	UpdateProgress(ctx context.Context, tx *gorm.DB, id uint, currentQuestionIndex, questionsAnswered int) error
 	GetProgress(ctx context.Context, tx *gorm.DB, id uint) (*AttemptProgress, error)
 
+	// Organization custom fields
+	UpdateCustomFields(ctx context.Context, tx *gorm.DB, id uint, customFields datatypes.JSON) error
+
+	// Sections completed under anti-backtracking
+	UpdateLockedSections(ctx context.Context, tx *gorm.DB, id uint, lockedSections datatypes.JSON) error
+
 	// Scoring and completion
 	UpdateScore(ctx context.Context, tx *gorm.DB, id uint, score, percentage float64, passed bool) error
 	CompleteAttempt(ctx context.Context, tx *gorm.DB, id uint, completedAt time.Time, finalScore float64) error
@@ -52,6 +76,16 @@ type AttemptRepository interface {
 	GetAssessmentAttemptStats(ctx context.Context, tx *gorm.DB, assessmentID uint) (*AttemptStats, error)
 	GetStudentAttemptStats(ctx context.Context, tx *gorm.DB, studentID string) (*StudentAttemptStats, error)
 	GetAttemptsByDateRange(ctx context.Context, tx *gorm.DB, from, to time.Time) ([]*models.AssessmentAttempt, error)
+	GetAttemptFunnel(ctx context.Context, tx *gorm.DB, assessmentID uint) (*AttemptFunnel, error)
+	// GetFinishedPercentages returns the percentage of every completed or timed out attempt
+	GetFinishedPercentages(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]float64, error)
+	// GetFinishedByAssessment returns every completed or timed out attempt, oldest first
+	GetFinishedByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentAttempt, error)
+	// GetFinishedByStudent returns the student's completed or timed out attempts on assessments
+	// that are not deleted, with the assessment loaded, oldest first
+	GetFinishedByStudent(ctx context.Context, tx *gorm.DB, studentID string) ([]*models.AssessmentAttempt, error)
+	// GetAttemptTimings returns when every started attempt ran, earliest start first
+	GetAttemptTimings(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]AttemptTiming, error)
 
 	// Validation and checks
 	CanStartAttempt(ctx context.Context, tx *gorm.DB, studentID string, assessmentID uint) (*AttemptValidation, error)
@@ -79,19 +113,43 @@ type AnswerRepository interface {
 	// Query operations
 	GetByAttempt(ctx context.Context, tx *gorm.DB, attemptID uint) ([]*models.StudentAnswer, error)
 	GetByAttemptAndQuestion(ctx context.Context, tx *gorm.DB, attemptID, questionID uint) (*models.StudentAnswer, error)
+	// GetTrialVariants maps each question a question trial swapped in the attempt to the revision served
+	GetTrialVariants(ctx context.Context, tx *gorm.DB, attemptID uint) (map[uint]uint, error)
 	GetByQuestion(ctx context.Context, tx *gorm.DB, questionID uint, filters AnswerFilters) ([]*models.StudentAnswer, error)
 	GetByStudent(ctx context.Context, tx *gorm.DB, studentID string, filters AnswerFilters) ([]*models.StudentAnswer, error)
+	GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint, filters AnswerFilters) ([]*models.StudentAnswer, int64, error)
 
 	// Grading operations
 	UpdateGrade(ctx context.Context, tx *gorm.DB, id uint, score float64, isCorrect *bool, feedback *string, graderID string) error
 	BulkGrade(ctx context.Context, tx *gorm.DB, grades []AnswerGrade) error
+	// MarkPendingRegrade moves graded answers among the given ones to pending_regrade and
+	// returns how many moved
+	MarkPendingRegrade(ctx context.Context, tx *gorm.DB, answerIDs []uint) (int64, error)
 	GetPendingGrading(ctx context.Context, tx *gorm.DB, teacherID string) ([]*models.StudentAnswer, error)
 	GetGradedAnswers(ctx context.Context, tx *gorm.DB, graderID string, filters AnswerFilters) ([]*models.StudentAnswer, error)
+	// GetGraderActivity returns the answers a grader graded by hand, oldest grade first
+	GetGraderActivity(ctx context.Context, tx *gorm.DB, graderID string, filter GraderStatsFilter) ([]GradedAnswerRecord, error)
+	// CountGradingBacklog counts ungraded answers of finished attempts in assessments the grader
+	// owns or has graded answers in
+	CountGradingBacklog(ctx context.Context, tx *gorm.DB, graderID string, filter GraderStatsFilter) (int, error)
+	// GetManualGradeTimes returns the grades given by hand since a time on assessments the owner
+	// created, ordered by grader and then grading time, at most limit of them
+	GetManualGradeTimes(ctx context.Context, tx *gorm.DB, ownerID string, since time.Time, limit int) ([]ManualGradeRecord, error)
+	// GetGraderBacklogs returns the grading backlog, counted as in CountGradingBacklog, of every
+	// grader with more than minBacklog answers waiting
+	GetGraderBacklogs(ctx context.Context, tx *gorm.DB, minBacklog int) (map[string]int, error)
+	// SearchFeedback full-text searches the feedback and annotation comments a grader wrote,
+	// best match first
+	SearchFeedback(ctx context.Context, tx *gorm.DB, graderID string, filters FeedbackSearchFilters) ([]FeedbackSearchHit, int64, error)
 
 	// Answer tracking
 	UpdateAnswerHistory(ctx context.Context, tx *gorm.DB, id uint, newAnswer interface{}) error
 	GetAnswerHistory(ctx context.Context, tx *gorm.DB, id uint) ([]AnswerHistoryEntry, error)
 	FlagAnswer(ctx context.Context, tx *gorm.DB, id uint, flagged bool) error
+	// SetLanguage records the language detected in an answer and whether it was unexpected
+	SetLanguage(ctx context.Context, tx *gorm.DB, id uint, language *string, mismatch bool) error
+	// MarkSpellCheckShown records that spelling suggestions were shown on an answer
+	MarkSpellCheckShown(ctx context.Context, tx *gorm.DB, answer *models.StudentAnswer) error
 	GetFlaggedAnswers(ctx context.Context, tx *gorm.DB, attemptID uint) ([]*models.StudentAnswer, error)
 
 	// Time tracking
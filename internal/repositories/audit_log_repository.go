@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository interface for audit trail operations
+type AuditLogRepository interface {
+	// Basic operations (audit entries are append-only)
+	Create(ctx context.Context, tx *gorm.DB, entry *models.AuditLog) error
+
+	// Query operations
+	GetByTarget(ctx context.Context, tx *gorm.DB, targetType string, targetID uint) ([]*models.AuditLog, error)
+	GetByTargets(ctx context.Context, tx *gorm.DB, targetType string, targetIDs []uint) ([]*models.AuditLog, error)
+}
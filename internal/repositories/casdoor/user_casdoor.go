@@ -12,6 +12,7 @@ import (
 	"github.com/casdoor/casdoor-go-sdk/casdoorsdk"
 	"github.com/redis/go-redis/v9"
 
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
 )
@@ -28,15 +29,14 @@ type CasdoorConfig struct {
 
 type UserCasdoor struct {
 	client *casdoorsdk.Client
-	redis  *redis.Client
+	redis  *cache.Client
 	config CasdoorConfig
 
-	// Cache settings
+	// Cache settings; the TTL is the client's, for the user family
 	cachePrefix string
-	cacheTTL    time.Duration
 }
 
-func NewUserCasdoor(config CasdoorConfig, redisClient *redis.Client) repositories.UserRepository {
+func NewUserCasdoor(config CasdoorConfig, redisClient *cache.Client) repositories.UserRepository {
 	// Initialize Casdoor client
 	client := casdoorsdk.NewClient(
 		config.Endpoint,
@@ -52,12 +52,16 @@ func NewUserCasdoor(config CasdoorConfig, redisClient *redis.Client) repositorie
 		redis:       redisClient,
 		config:      config,
 		cachePrefix: "user:",
-		cacheTTL:    15 * time.Minute, // Cache for 15 minutes
 	}
 }
 
 // ===== CACHE METHODS =====
 
+// cacheEnabled reports whether user lookups should go through Redis
+func (u *UserCasdoor) cacheEnabled() bool {
+	return u.redis.Available() && u.redis.Settings().RepositoryEnabled(cache.RepositoryUser)
+}
+
 // getCacheKey generates cache key for user data
 func (u *UserCasdoor) getCacheKey(key string) string {
 	return fmt.Sprintf("%s%s", u.cachePrefix, key)
@@ -65,7 +69,7 @@ func (u *UserCasdoor) getCacheKey(key string) string {
 
 // getUserFromCache retrieves user from cache
 func (u *UserCasdoor) getUserFromCache(ctx context.Context, key string) (*models.User, error) {
-	if u.redis == nil {
+	if !u.cacheEnabled() {
 		return nil, nil // Cache not available
 	}
 
@@ -75,7 +79,9 @@ func (u *UserCasdoor) getUserFromCache(ctx context.Context, key string) (*models
 		if errors.Is(err, redis.Nil) {
 			return nil, nil // Not found in cache
 		}
-		return nil, fmt.Errorf("failed to get from cache: %w", err)
+		// Treat cache failures as a miss so lookups fall back to Casdoor
+		u.redis.ReportError(err)
+		return nil, nil
 	}
 
 	var user models.User
@@ -88,7 +94,7 @@ func (u *UserCasdoor) getUserFromCache(ctx context.Context, key string) (*models
 
 // setUserCache stores user in cache
 func (u *UserCasdoor) setUserCache(ctx context.Context, key string, user *models.User) error {
-	if u.redis == nil {
+	if !u.cacheEnabled() {
 		return nil // Cache not available
 	}
 
@@ -98,21 +104,25 @@ func (u *UserCasdoor) setUserCache(ctx context.Context, key string, user *models
 	}
 
 	cacheKey := u.getCacheKey(key)
-	return u.redis.Set(ctx, cacheKey, data, u.cacheTTL).Err()
+	err = u.redis.Set(ctx, cacheKey, data, u.redis.Settings().TTL(cache.FamilyUser)).Err()
+	u.redis.ReportError(err)
+	return err
 }
 
 // invalidateUserCache removes user from cache
 func (u *UserCasdoor) invalidateUserCache(ctx context.Context, keys ...string) error {
-	if u.redis == nil {
+	if !u.redis.Available() {
 		return nil
 	}
 
-	cacheKeys := make([]string, len(keys))
-	for i, key := range keys {
-		cacheKeys[i] = u.getCacheKey(key)
+	// Deleted one by one in a pipeline; a multi-key DEL fails across cluster hash slots
+	pipe := u.redis.Pipeline()
+	for _, key := range keys {
+		pipe.Del(ctx, u.getCacheKey(key))
 	}
-
-	return u.redis.Del(ctx, cacheKeys...).Err()
+	_, err := pipe.Exec(ctx)
+	u.redis.ReportError(err)
+	return err
 }
 
 // ===== CONVERSION METHODS =====
@@ -310,13 +320,35 @@ func (u *UserCasdoor) GetByIDs(ctx context.Context, ids []string) ([]*models.Use
 	return users, nil
 }
 
+// GetIDsByGroup retrieves the active users in a Casdoor group. Groups match by name or by
+// their full owner/name ID.
+func (u *UserCasdoor) GetIDsByGroup(ctx context.Context, group string) ([]string, error) {
+	casdoorUsers, err := u.client.GetUsers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users from Casdoor: %w", err)
+	}
+
+	ids := make([]string, 0)
+	for _, casdoorUser := range casdoorUsers {
+		if casdoorUser == nil || casdoorUser.IsForbidden {
+			continue
+		}
+		if slices.ContainsFunc(casdoorUser.Groups, func(g string) bool {
+			return g == group || strings.TrimPrefix(g, casdoorUser.Owner+"/") == group
+		}) {
+			ids = append(ids, casdoorUser.Id)
+		}
+	}
+	return ids, nil
+}
+
 // ===== VALIDATION AND CHECKS =====
 
 // ExistsByID checks if a user exists by ID
 func (u *UserCasdoor) ExistsByID(ctx context.Context, id string) (bool, error) {
 	// Check cache first
 	cacheKey := fmt.Sprintf("exists:id:%s", id)
-	if u.redis != nil {
+	if u.cacheEnabled() {
 		exists, err := u.redis.Get(ctx, cacheKey).Result()
 		if err == nil {
 			return exists == "true", nil
@@ -332,8 +364,8 @@ func (u *UserCasdoor) ExistsByID(ctx context.Context, id string) (bool, error) {
 	exists := user != nil
 
 	// Cache the result for a shorter time
-	if u.redis != nil {
-		u.redis.Set(ctx, cacheKey, fmt.Sprintf("%t", exists), 5*time.Minute)
+	if u.cacheEnabled() {
+		u.redis.Set(ctx, cacheKey, fmt.Sprintf("%t", exists), u.redis.Settings().TTL(cache.FamilyExists))
 	}
 
 	return exists, nil
@@ -343,7 +375,7 @@ func (u *UserCasdoor) ExistsByID(ctx context.Context, id string) (bool, error) {
 func (u *UserCasdoor) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	// Check cache first
 	cacheKey := fmt.Sprintf("exists:email:%s", email)
-	if u.redis != nil {
+	if u.cacheEnabled() {
 		exists, err := u.redis.Get(ctx, cacheKey).Result()
 		if err == nil {
 			return exists == "true", nil
@@ -359,8 +391,8 @@ func (u *UserCasdoor) ExistsByEmail(ctx context.Context, email string) (bool, er
 	exists := user != nil
 
 	// Cache the result
-	if u.redis != nil {
-		u.redis.Set(ctx, cacheKey, fmt.Sprintf("%t", exists), 5*time.Minute)
+	if u.cacheEnabled() {
+		u.redis.Set(ctx, cacheKey, fmt.Sprintf("%t", exists), u.redis.Settings().TTL(cache.FamilyExists))
 	}
 
 	return exists, nil
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// CustomFieldRepository interface for organizations' custom field definitions
+type CustomFieldRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, definition *models.CustomFieldDefinition) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.CustomFieldDefinition, error)
+	Update(ctx context.Context, tx *gorm.DB, definition *models.CustomFieldDefinition) error
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error // Values already recorded are kept
+	// List returns an organization's definitions, optionally of one entity, ordered by key
+	List(ctx context.Context, tx *gorm.DB, organization string, entity *models.CustomFieldEntity) ([]*models.CustomFieldDefinition, error)
+}
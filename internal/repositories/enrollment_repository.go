@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// EnrollmentRepository interface for assessment enrollment and access tracking
+type EnrollmentRepository interface {
+	// Enrollment
+	// Enroll adds enrollments; students already enrolled move to the class of their new enrollment
+	Enroll(ctx context.Context, tx *gorm.DB, enrollments []*models.AssessmentEnrollment) error
+	Unenroll(ctx context.Context, tx *gorm.DB, assessmentID uint, studentID string) error
+	GetEnrollment(ctx context.Context, tx *gorm.DB, assessmentID uint, studentID string) (*models.AssessmentEnrollment, error)
+	GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentEnrollment, error)
+	GetByStudent(ctx context.Context, tx *gorm.DB, studentID string) ([]*models.AssessmentEnrollment, error)
+	GetStudentIDs(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]string, error)
+
+	// Class overrides
+	// SaveClassOverride creates the class's override or replaces the existing one
+	SaveClassOverride(ctx context.Context, tx *gorm.DB, override *models.AssessmentClassOverride) error
+	GetClassOverride(ctx context.Context, tx *gorm.DB, assessmentID uint, classID string) (*models.AssessmentClassOverride, error)
+	GetClassOverrides(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentClassOverride, error)
+	DeleteClassOverride(ctx context.Context, tx *gorm.DB, assessmentID uint, classID string) error
+
+	// Access tracking
+	RecordView(ctx context.Context, tx *gorm.DB, assessmentID uint, studentID string, viewedAt time.Time) error
+	GetViews(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentView, error)
+}
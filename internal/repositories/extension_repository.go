@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// ExtensionRequestFilter selects extension requests; zero fields match everything
+type ExtensionRequestFilter struct {
+	AssessmentID *uint
+	StudentID    string
+	CreatorID    string // Creator of the assessment, for a teacher's queue
+	Status       models.ExtensionRequestStatus
+	Limit        int
+	Offset       int
+}
+
+// ExtensionRepository interface for extension requests and the accommodations they grant
+type ExtensionRepository interface {
+	// Requests
+	Create(ctx context.Context, tx *gorm.DB, request *models.ExtensionRequest) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.ExtensionRequest, error)
+	Update(ctx context.Context, tx *gorm.DB, request *models.ExtensionRequest) error
+	// List returns matching requests oldest first, with their total count
+	List(ctx context.Context, tx *gorm.DB, filter ExtensionRequestFilter) ([]*models.ExtensionRequest, int64, error)
+	HasPending(ctx context.Context, tx *gorm.DB, assessmentID uint, studentID string, kind models.ExtensionRequestKind) (bool, error)
+
+	// Accommodations
+	CreateAccommodation(ctx context.Context, tx *gorm.DB, accommodation *models.StudentAccommodation) error
+	GetAccommodations(ctx context.Context, tx *gorm.DB, assessmentID uint, studentID string) ([]*models.StudentAccommodation, error)
+	GetAccommodationsByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.StudentAccommodation, error)
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// FavoriteRepository interface for starred questions and assessments
+type FavoriteRepository interface {
+	// Basic operations
+	Create(ctx context.Context, tx *gorm.DB, favorite *models.Favorite) error
+	Delete(ctx context.Context, tx *gorm.DB, userID string, itemType models.FavoriteItemType, itemID uint) error
+	Exists(ctx context.Context, tx *gorm.DB, userID string, itemType models.FavoriteItemType, itemID uint) (bool, error)
+
+	// Query operations
+	// GetEntries returns the user's favorites with item titles, newest first; deleted items are skipped
+	GetEntries(ctx context.Context, tx *gorm.DB, userID string, itemType *models.FavoriteItemType) ([]FavoriteEntry, error)
+	// GetFavoritedIDs reports which of the given items the user starred in a single query
+	GetFavoritedIDs(ctx context.Context, tx *gorm.DB, userID string, itemType models.FavoriteItemType, itemIDs []uint) (map[uint]bool, error)
+
+	// Sharing operations
+	Share(ctx context.Context, tx *gorm.DB, share *models.FavoriteListShare) error
+	Unshare(ctx context.Context, tx *gorm.DB, ownerID, sharedWith string) error
+	IsShared(ctx context.Context, tx *gorm.DB, ownerID, sharedWith string) (bool, error)
+	GetSharesByOwner(ctx context.Context, tx *gorm.DB, ownerID string) ([]*models.FavoriteListShare, error)
+	GetSharedWith(ctx context.Context, tx *gorm.DB, userID string) ([]*models.FavoriteListShare, error)
+}
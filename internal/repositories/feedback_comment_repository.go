@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// FeedbackFrequency is one distinct piece of grader feedback and how often it was given
+type FeedbackFrequency struct {
+	Text  string `json:"text"`
+	Count int    `json:"count"`
+}
+
+// FeedbackCommentRepository interface for question comment bank operations
+type FeedbackCommentRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, tx *gorm.DB, comment *models.FeedbackComment) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.FeedbackComment, error)
+	Update(ctx context.Context, tx *gorm.DB, comment *models.FeedbackComment) error
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+
+	// Query operations
+	GetByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) ([]*models.FeedbackComment, error)
+
+	// Mining
+	// GetFeedbackFrequencies groups the feedback graders wrote on a question's answers,
+	// ignoring case and surrounding whitespace, most frequent first
+	GetFeedbackFrequencies(ctx context.Context, tx *gorm.DB, questionID uint, limit int) ([]FeedbackFrequency, error)
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// FlagEscalationRepository interface for escalations of questions many students flagged
+type FlagEscalationRepository interface {
+	// Create stores the escalation unless the question was already escalated in the
+	// assessment, and reports whether it did
+	Create(ctx context.Context, tx *gorm.DB, escalation *models.QuestionFlagEscalation) (bool, error)
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.QuestionFlagEscalation, error)
+	GetByQuestion(ctx context.Context, tx *gorm.DB, assessmentID, questionID uint) (*models.QuestionFlagEscalation, error)
+	// GetByAssessment lists the assessment's escalations, newest first, optionally of one status
+	GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint, status *models.FlagEscalationStatus) ([]*models.QuestionFlagEscalation, error)
+	Update(ctx context.Context, tx *gorm.DB, escalation *models.QuestionFlagEscalation) error
+
+	// CountRecentFlags counts attempts of the assessment whose answer to the question was
+	// flagged since the given time and is still flagged
+	CountRecentFlags(ctx context.Context, tx *gorm.DB, assessmentID, questionID uint, since time.Time) (int, error)
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// GradebookRepository interface for external gradebook integrations and their grade deliveries
+type GradebookRepository interface {
+	// Integrations
+	CreateIntegration(ctx context.Context, tx *gorm.DB, integration *models.GradebookIntegration) error
+	GetIntegrationByID(ctx context.Context, tx *gorm.DB, id uint) (*models.GradebookIntegration, error)
+	UpdateIntegration(ctx context.Context, tx *gorm.DB, integration *models.GradebookIntegration) error
+	DeleteIntegration(ctx context.Context, tx *gorm.DB, id uint) error // Removes its passbacks too
+	GetIntegrationsByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.GradebookIntegration, error)
+	GetEnabledIntegrations(ctx context.Context, tx *gorm.DB) ([]*models.GradebookIntegration, error)
+
+	// Passback queue
+	// GetAttemptsNeedingPassback returns the most recently changed finished attempt of each
+	// student whose final grade was never queued or may have changed since it was delivered,
+	// e.g. by a regrade or a late attempt. Students with attempts still being graded are skipped.
+	GetAttemptsNeedingPassback(ctx context.Context, tx *gorm.DB, integration *models.GradebookIntegration, limit int) ([]*models.AssessmentAttempt, error)
+	// QueuePassbacks creates pending passbacks, resetting existing ones for the same students
+	QueuePassbacks(ctx context.Context, tx *gorm.DB, passbacks []*models.GradePassback) error
+	// RequeueByAssessment resets an assessment's passbacks to pending; delivered ones only when includeDelivered
+	RequeueByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint, includeDelivered bool) (int, error)
+	// ClaimPassback marks a due passback as processing and counts the try. Pending passbacks due
+	// by now and ones left processing before staleBefore can be claimed; it reports false
+	// when another worker holds the passback.
+	ClaimPassback(ctx context.Context, tx *gorm.DB, id uint, now, staleBefore time.Time) (bool, error)
+	GetDuePassbacks(ctx context.Context, tx *gorm.DB, now, staleBefore time.Time, limit int) ([]uint, error)
+	GetPassbackByID(ctx context.Context, tx *gorm.DB, id uint) (*models.GradePassback, error) // Includes the integration
+	UpdatePassback(ctx context.Context, tx *gorm.DB, passback *models.GradePassback) error
+	// ListPassbacks returns an assessment's passbacks, optionally filtered by status, newest first
+	ListPassbacks(ctx context.Context, tx *gorm.DB, assessmentID uint, status *models.PassbackStatus, limit int) ([]*models.GradePassback, error)
+}
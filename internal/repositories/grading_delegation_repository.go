@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// GradingDelegationRepository interface for time-boxed grading delegations
+type GradingDelegationRepository interface {
+	// Basic operations
+	Create(ctx context.Context, tx *gorm.DB, delegation *models.GradingDelegation) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.GradingDelegation, error)
+	Update(ctx context.Context, tx *gorm.DB, delegation *models.GradingDelegation) error
+
+	// Query operations
+	ListByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.GradingDelegation, error)
+	ListByDelegate(ctx context.Context, tx *gorm.DB, delegateID string) ([]*models.GradingDelegation, error)
+	// GetActive returns the delegation letting the delegate grade the assessment at the given time
+	GetActive(ctx context.Context, tx *gorm.DB, assessmentID uint, delegateID string, at time.Time) (*models.GradingDelegation, error)
+}
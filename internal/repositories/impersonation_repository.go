@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// ImpersonationRepository interface for support impersonation sessions
+type ImpersonationRepository interface {
+	// Basic operations
+	Create(ctx context.Context, tx *gorm.DB, session *models.ImpersonationSession) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.ImpersonationSession, error)
+	GetByTokenHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*models.ImpersonationSession, error)
+	Update(ctx context.Context, tx *gorm.DB, session *models.ImpersonationSession) error
+
+	// Query operations
+	ListActiveByAdmin(ctx context.Context, tx *gorm.DB, adminID string, now time.Time) ([]*models.ImpersonationSession, error)
+}
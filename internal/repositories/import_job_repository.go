@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// ImportJobRepository interface for question import job operations
+type ImportJobRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, tx *gorm.DB, job *models.ImportJob) error
+	GetByID(ctx context.Context, tx *gorm.DB, id string) (*models.ImportJob, error)
+	Update(ctx context.Context, tx *gorm.DB, job *models.ImportJob) error
+
+	// Processing
+	// Claim marks a job as processing if it is pending or its processing heartbeat is older
+	// than staleBefore; it reports false when another worker owns the job
+	Claim(ctx context.Context, tx *gorm.DB, id string, staleBefore time.Time) (bool, error)
+	// SaveProgress checkpoints a processing job, including its final status; it reports
+	// false once the job was cancelled
+	SaveProgress(ctx context.Context, tx *gorm.DB, job *models.ImportJob) (bool, error)
+	// Cancel stops a pending or processing job; it reports false when the job already finished
+	Cancel(ctx context.Context, tx *gorm.DB, id string, cancelledAt time.Time) (bool, error)
+	// GetResumable returns pending jobs and processing jobs whose heartbeat is older than staleBefore
+	GetResumable(ctx context.Context, tx *gorm.DB, staleBefore time.Time, limit int) ([]*models.ImportJob, error)
+
+	// Uploaded files, stored with the job so every replica can process it
+	SaveFile(ctx context.Context, tx *gorm.DB, jobID string, data []byte) error
+	GetFile(ctx context.Context, tx *gorm.DB, jobID string) ([]byte, error)
+	DeleteFile(ctx context.Context, tx *gorm.DB, jobID string) error
+}
@@ -1,9 +1,11 @@
 package repositories
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
 )
 
 // ===== SHARED FILTER STRUCTS =====
@@ -17,6 +19,9 @@ type AssessmentFilters struct {
 	Offset    int                      `json:"offset"`
 	SortBy    string                   `json:"sort_by"`    // "created_at", "title", "due_date"
 	SortOrder string                   `json:"sort_order"` // "asc", "desc"
+
+	// Only assessments whose custom field values equal these, by field key
+	CustomFields map[string]string `json:"custom_fields"`
 }
 
 type QuestionFilters struct {
@@ -29,6 +34,11 @@ type QuestionFilters struct {
 	Offset     int                     `json:"offset"`
 	SortBy     string                  `json:"sort_by"`
 	SortOrder  string                  `json:"sort_order"`
+	IsDraft    *bool                   `json:"is_draft"`
+
+	// Translations: questions with a translation in Locale, in TranslationStatus, or both
+	Locale            *string                   `json:"locale"`
+	TranslationStatus *models.TranslationStatus `json:"translation_status"`
 }
 
 type RandomQuestionFilters struct {
@@ -40,27 +50,136 @@ type RandomQuestionFilters struct {
 }
 
 type AttemptFilters struct {
-	Status    *models.AttemptStatus `json:"status"`
-	StudentID *string               `json:"student_id"`
-	DateFrom  *time.Time            `json:"date_from"`
-	DateTo    *time.Time            `json:"date_to"`
-	Limit     int                   `json:"limit"`
-	Offset    int                   `json:"offset"`
-	SortBy    string                `json:"sort_by"`    // "created_at", "title", "due_date"
-	SortOrder string                `json:"sort_order"` // "asc", "desc"
+	Status       *models.AttemptStatus `json:"status"`
+	StudentID    *string               `json:"student_id"`
+	AssessmentID *uint                 `json:"assessment_id"`
+	DateFrom     *time.Time            `json:"date_from"`
+	DateTo       *time.Time            `json:"date_to"`
+	Limit        int                   `json:"limit"`
+	Offset       int                   `json:"offset"`
+	After        *PageCursor           `json:"after"`      // Keyset pagination; takes precedence over Offset and sorting
+	SortBy       string                `json:"sort_by"`    // "created_at", "title", "due_date"
+	SortOrder    string                `json:"sort_order"` // "asc", "desc"
+
+	// Results, inclusive on both ends
+	MinScore      *float64   `json:"min_score"`
+	MaxScore      *float64   `json:"max_score"`
+	MinPercentage *float64   `json:"min_percentage"`
+	MaxPercentage *float64   `json:"max_percentage"`
+	Passed        *bool      `json:"passed"`
+	CompletedFrom *time.Time `json:"completed_from"`
+	CompletedTo   *time.Time `json:"completed_to"`
+
+	// Attempts with proctoring events that were not dismissed, or without any such events
+	HasProctoringFlags *bool `json:"has_proctoring_flags"`
+	// Only attempts with at least one answer in one of these grading states
+	GradingStatuses []models.AnswerGradingStatus `json:"grading_statuses"`
+	// Matched against the student's name and email
+	Search string `json:"search"`
+
+	// Sort orders by each key in turn, ties broken newest first; takes precedence over SortBy
+	Sort []AttemptSort `json:"sort"`
+
+	// Only attempts whose custom field values equal these, by field key
+	CustomFields map[string]string `json:"custom_fields"`
+}
+
+// AttemptSort is one key of a combined attempt sort; Field is one of AttemptSortFields
+type AttemptSort struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc"`
 }
 
+// AttemptSortFields are the keys attempts can be sorted by
+var AttemptSortFields = []string{"created_at", "started_at", "completed_at", "score", "percentage", "time_spent", "student_name"}
+
 type AnswerFilters struct {
-	IsGraded *bool      `json:"is_graded"`
-	GradedBy *string    `json:"graded_by"`
-	DateFrom *time.Time `json:"date_from"`
-	DateTo   *time.Time `json:"date_to"`
-	Limit    int        `json:"limit"`
-	Offset   int        `json:"offset"`
+	IsGraded *bool       `json:"is_graded"`
+	GradedBy *string     `json:"graded_by"`
+	DateFrom *time.Time  `json:"date_from"`
+	DateTo   *time.Time  `json:"date_to"`
+	Limit    int         `json:"limit"`
+	Offset   int         `json:"offset"`
+	After    *PageCursor `json:"after"` // Keyset pagination; takes precedence over Offset
+
+	// Only answers in one of these grading states
+	GradingStatuses []models.AnswerGradingStatus `json:"grading_statuses"`
 }
 
 // ===== SHARED HELPER STRUCTS =====
 
+// StudentAttemptActivity summarizes one student's attempts on an assessment
+type StudentAttemptActivity struct {
+	StudentID      string     `json:"student_id"`
+	AttemptCount   int        `json:"attempt_count"`
+	FirstStartedAt *time.Time `json:"first_started_at"`
+	LastActivityAt *time.Time `json:"last_activity_at"`
+	Submitted      bool       `json:"submitted"` // At least one attempt was finished
+}
+
+// ScoredResponse is one finished attempt's answer to a question alongside the attempt's overall result
+type ScoredResponse struct {
+	AttemptID         uint           `json:"attempt_id"`
+	Answer            datatypes.JSON `json:"answer"`
+	AnswerCompressed  []byte         `json:"-"`
+	IsCorrect         *bool          `json:"is_correct"`
+	Score             float64        `json:"score"`
+	TimeSpent         int            `json:"time_spent"`
+	AttemptPercentage float64        `json:"attempt_percentage"`
+}
+
+// ItemScore is one graded answer of a finished attempt, used for item analysis
+type ItemScore struct {
+	AttemptID  uint    `json:"attempt_id"`
+	QuestionID uint    `json:"question_id"`
+	Score      float64 `json:"score"`
+	MaxScore   int     `json:"max_score"`
+}
+
+// ConfidenceResponse is one graded answer of a finished attempt with the student's
+// confidence rating, used for calibration analytics
+type ConfidenceResponse struct {
+	StudentID  string  `json:"student_id"`
+	QuestionID uint    `json:"question_id"`
+	Confidence int     `json:"confidence"`
+	Score      float64 `json:"score"`
+	MaxScore   int     `json:"max_score"`
+}
+
+// QuestionHistoricalStats aggregates a question's answers across finished attempts
+type QuestionHistoricalStats struct {
+	QuestionID       uint    `json:"question_id"`
+	Responses        int     `json:"responses"`
+	ScoredResponses  int     `json:"scored_responses"`   // Responses with a known maximum score
+	CorrectRate      float64 `json:"correct_rate"`       // Over responses graded correct or incorrect
+	ScoreRate        float64 `json:"score_rate"`         // Average share of the maximum score earned
+	AverageTimeSpent float64 `json:"average_time_spent"` // seconds, over responses with recorded time
+}
+
+// SkillEvidence is one graded answer used to measure skill mastery
+type SkillEvidence struct {
+	QuestionID        uint           `json:"question_id"`
+	Tags              datatypes.JSON `json:"tags"`
+	AssessmentCreator string         `json:"assessment_creator"`
+	Score             float64        `json:"score"`
+	MaxScore          int            `json:"max_score"`
+	GradedAt          time.Time      `json:"graded_at"`
+}
+
+// BufferedAnswer is an autosaved answer waiting to be written to the database
+type BufferedAnswer struct {
+	AttemptID  uint            `json:"attempt_id"`
+	QuestionID uint            `json:"question_id"`
+	PartID     string          `json:"part_id,omitempty"` // Answers one part of a multi-part question
+	Answer     json.RawMessage `json:"answer"`
+	TimeSpent  *int            `json:"time_spent"`
+	Confidence *int            `json:"confidence,omitempty"`
+	BufferedAt time.Time       `json:"buffered_at"`
+
+	// Stored form, used to detect whether a newer write replaced this one
+	Raw string `json:"-"`
+}
+
 type QuestionOrder struct {
 	QuestionID uint `json:"question_id"`
 	Order      int  `json:"order"`
@@ -85,6 +204,17 @@ type AssessmentStats struct {
 	TotalPoints       int     `json:"total_points"`
 }
 
+// AssessmentOverlap is another assessment that may duplicate a given one
+type AssessmentOverlap struct {
+	AssessmentID    uint                    `json:"assessment_id"`
+	Title           string                  `json:"title"`
+	Status          models.AssessmentStatus `json:"status"`
+	CreatedBy       string                  `json:"created_by"`
+	CreatorName     string                  `json:"creator_name"`
+	QuestionCount   int                     `json:"question_count"`
+	SharedQuestions int                     `json:"shared_questions"` // Questions both assessments contain
+}
+
 type CreatorStats struct {
 	TotalAssessments  int `json:"total_assessments"`
 	ActiveAssessments int `json:"active_assessments"`
@@ -124,6 +254,24 @@ type AttemptStats struct {
 	CompletionRate   float64                      `json:"completion_rate"`
 }
 
+// AttemptFunnel counts how far attempts on an assessment got; each stage is a subset of the one before
+type AttemptFunnel struct {
+	Started   int `json:"started"`
+	Answered  int `json:"answered"`  // At least one question answered
+	Submitted int `json:"submitted"` // Completed or timed out
+	Graded    int `json:"graded"`    // Submitted with no answer left to grade
+	Passed    int `json:"passed"`
+}
+
+// AttemptTiming is when an attempt ran. EndedAt is nil while the attempt is in progress;
+// Percentage counts only when Finished.
+type AttemptTiming struct {
+	StartedAt  time.Time  `json:"started_at"`
+	EndedAt    *time.Time `json:"ended_at"`
+	Finished   bool       `json:"finished"` // Completed or timed out
+	Percentage float64    `json:"percentage"`
+}
+
 type GradingStats struct {
 	TotalAnswers   int     `json:"total_answers"`
 	GradedAnswers  int     `json:"graded_answers"`
@@ -131,6 +279,62 @@ type GradingStats struct {
 	AutoGraded     int     `json:"auto_graded"`
 	ManualGraded   int     `json:"manual_graded"`
 	AverageScore   float64 `json:"average_score"`
+	// Most recent grade given, nil while nothing is graded
+	LastGradedAt *time.Time `json:"last_graded_at"`
+	// Answers per grading workflow state
+	ByStatus map[models.AnswerGradingStatus]int `json:"by_status"`
+}
+
+// GraderStatsFilter scopes per-grader statistics
+type GraderStatsFilter struct {
+	AssessmentID *uint      `json:"assessment_id"`
+	OwnerID      *string    `json:"owner_id"` // Only assessments created by this user
+	Since        *time.Time `json:"since"`    // Only grades given from this time on
+}
+
+// GradedAnswerRecord is one answer graded by hand, with the submission time of its attempt
+type GradedAnswerRecord struct {
+	AnswerID     uint       `json:"answer_id"`
+	AssessmentID uint       `json:"assessment_id"`
+	Score        float64    `json:"score"`
+	MaxScore     int        `json:"max_score"`
+	GradedAt     time.Time  `json:"graded_at"`
+	SubmittedAt  *time.Time `json:"submitted_at"`
+}
+
+// ManualGradeRecord is a grade given by hand, used to time how long grading takes
+type ManualGradeRecord struct {
+	GraderID   string    `json:"grader_id"`
+	QuestionID uint      `json:"question_id"`
+	GradedAt   time.Time `json:"graded_at"`
+}
+
+// FeedbackSearchFilters narrows a search of a grader's feedback
+type FeedbackSearchFilters struct {
+	Query        string               `json:"query"` // Web search syntax: words, "phrases", or, -excluded
+	AssessmentID *uint                `json:"assessment_id"`
+	QuestionType *models.QuestionType `json:"question_type"`
+	From         *time.Time           `json:"from"` // Written at or after
+	To           *time.Time           `json:"to"`   // Written before
+	Limit        int                  `json:"limit"`
+	Offset       int                  `json:"offset"`
+}
+
+// FeedbackSearchHit is answer feedback or an annotation comment matching a search
+type FeedbackSearchHit struct {
+	Source          string              `json:"source"` // "answer" or "annotation"
+	AnswerID        uint                `json:"answer_id"`
+	AnnotationID    *uint               `json:"annotation_id"`
+	AttemptID       uint                `json:"attempt_id"`
+	AssessmentID    uint                `json:"assessment_id"`
+	AssessmentTitle string              `json:"assessment_title"`
+	QuestionID      uint                `json:"question_id"`
+	QuestionType    models.QuestionType `json:"question_type"`
+	QuestionText    string              `json:"question_text"`
+	Feedback        string              `json:"feedback"`
+	Snippet         string              `json:"snippet"` // Matching words wrapped in <b></b>
+	Rank            float64             `json:"rank"`
+	WrittenAt       time.Time           `json:"written_at"`
 }
 
 type QuestionBankFilters struct {
@@ -162,6 +366,41 @@ type QuestionBankShareFilters struct {
 	Offset    int     `json:"offset"`
 }
 
+// JobFilters narrows a job listing; an empty Type or nil pointer matches every job
+type JobFilters struct {
+	Type      string            `json:"type"`
+	Status    *models.JobStatus `json:"status"`
+	CreatedBy *string           `json:"created_by"`
+	Limit     int               `json:"limit"`
+	Offset    int               `json:"offset"`
+}
+
+// PracticeSessionFilters narrows a practice session listing; nil pointers match every session
+type PracticeSessionFilters struct {
+	PracticeSetID *uint   `json:"practice_set_id"`
+	StudentID     *string `json:"student_id"`
+	Limit         int     `json:"limit"`
+	Offset        int     `json:"offset"`
+}
+
+// PracticeSetStats sums up the sessions taken from a practice set
+type PracticeSetStats struct {
+	Sessions          int64   `json:"sessions"`
+	CompletedSessions int64   `json:"completed_sessions"`
+	Students          int64   `json:"students"`
+	Score             float64 `json:"score"`     // Points scored over all answers
+	MaxScore          float64 `json:"max_score"` // Points available over all answers
+}
+
+// PracticeQuestionStats sums up the practice answers to one question
+type PracticeQuestionStats struct {
+	QuestionID uint    `json:"question_id"`
+	Answers    int64   `json:"answers"`
+	Correct    int64   `json:"correct"`
+	Score      float64 `json:"score"`
+	MaxScore   float64 `json:"max_score"`
+}
+
 type QuestionBankStats struct {
 	QuestionCount   int                            `json:"question_count"`
 	QuestionsByType map[models.QuestionType]int    `json:"questions_by_type"`
@@ -170,3 +409,44 @@ type QuestionBankStats struct {
 	ShareCount      int                            `json:"share_count"`
 	LastUsed        *time.Time                     `json:"last_used"`
 }
+
+// FavoriteEntry is a starred item together with its title
+type FavoriteEntry struct {
+	ID        uint                    `json:"id"`
+	ItemType  models.FavoriteItemType `json:"item_type"`
+	ItemID    uint                    `json:"item_id"`
+	Title     string                  `json:"title"`
+	Note      *string                 `json:"note"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+// BankLicenseExposure counts who has seen a licensed bank's questions through attempts
+type BankLicenseExposure struct {
+	StudentsExposed  int        `json:"students_exposed"`
+	QuestionsExposed int        `json:"questions_exposed"`
+	AssessmentsUsing int        `json:"assessments_using"`
+	LastExposedAt    *time.Time `json:"last_exposed_at"`
+}
+
+// QuestionReviewState is a bank question with what decides whether it is due for review
+type QuestionReviewState struct {
+	BankID               uint       `json:"bank_id"`
+	BankName             string     `json:"bank_name"`
+	OwnerID              string     `json:"owner_id"`
+	ReminderSentAt       *time.Time `json:"reminder_sent_at"`
+	QuestionID           uint       `json:"question_id"`
+	QuestionText         string     `json:"question_text"`
+	CreatedAt            time.Time  `json:"created_at"`
+	LastReviewedAt       *time.Time `json:"last_reviewed_at"`
+	ReviewIntervalMonths *int       `json:"review_interval_months"`
+}
+
+// CorrectRateWindows counts a question's graded answers in a recent window and the
+// window of the same length before it
+type CorrectRateWindows struct {
+	QuestionID      uint `json:"question_id"`
+	RecentResponses int  `json:"recent_responses"`
+	RecentCorrect   int  `json:"recent_correct"`
+	PriorResponses  int  `json:"prior_responses"`
+	PriorCorrect    int  `json:"prior_correct"`
+}
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// JobRepository interface for the background job queue
+type JobRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, job *models.Job) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.Job, error)
+	Update(ctx context.Context, tx *gorm.DB, job *models.Job) error
+	List(ctx context.Context, tx *gorm.DB, filters JobFilters) ([]*models.Job, int64, error)
+
+	// GetActive returns the queued or running job of the type for the record
+	GetActive(ctx context.Context, tx *gorm.DB, jobType, ref string) (*models.Job, error)
+	// GetRunnable returns due queued jobs of the given types, and running ones without a
+	// heartbeat since staleBefore, oldest due first
+	GetRunnable(ctx context.Context, tx *gorm.DB, types []string, now, staleBefore time.Time, limit int) ([]*models.Job, error)
+	// Claim marks a runnable job running and counts the attempt; false when it is no longer runnable
+	Claim(ctx context.Context, tx *gorm.DB, id uint, now, staleBefore time.Time) (bool, error)
+	Heartbeat(ctx context.Context, tx *gorm.DB, id uint) error
+}
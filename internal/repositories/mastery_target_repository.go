@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// MasteryTargetRepository interface for skill mastery target operations
+type MasteryTargetRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, tx *gorm.DB, target *models.MasteryTarget) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.MasteryTarget, error)
+	Update(ctx context.Context, tx *gorm.DB, target *models.MasteryTarget) error
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+
+	// Query operations
+	GetByCreator(ctx context.Context, tx *gorm.DB, creatorID string) ([]*models.MasteryTarget, error)
+	GetByCreators(ctx context.Context, tx *gorm.DB, creatorIDs []string) ([]*models.MasteryTarget, error)
+	ExistsBySkill(ctx context.Context, tx *gorm.DB, creatorID, skill string, excludeID *uint) (bool, error)
+
+	// Evidence
+	// GetSkillEvidence returns the student's graded answers from finished attempts, oldest first
+	GetSkillEvidence(ctx context.Context, tx *gorm.DB, studentID string) ([]SkillEvidence, error)
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// NotificationPreferenceRepository interface for users' notification preferences
+type NotificationPreferenceRepository interface {
+	GetByUser(ctx context.Context, tx *gorm.DB, userID string) (*models.NotificationPreference, error)
+	// GetByUsers returns the preferences of those users who have set any, keyed by user
+	GetByUsers(ctx context.Context, tx *gorm.DB, userIDs []string) (map[string]*models.NotificationPreference, error)
+	Upsert(ctx context.Context, tx *gorm.DB, preference *models.NotificationPreference) error
+}
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// OfflineBundleRepository interface for attempts exported to offline proctor apps
+type OfflineBundleRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, bundle *models.OfflineBundle) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.OfflineBundle, error)
+	GetByAttempt(ctx context.Context, tx *gorm.DB, attemptID uint) ([]*models.OfflineBundle, error)
+	Update(ctx context.Context, tx *gorm.DB, bundle *models.OfflineBundle) error
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type AnswerAnnotationPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewAnswerAnnotationPostgreSQL(db *gorm.DB) repositories.AnswerAnnotationRepository {
+	return &AnswerAnnotationPostgreSQL{db: db}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (r *AnswerAnnotationPostgreSQL) Create(ctx context.Context, tx *gorm.DB, annotation *models.AnswerAnnotation) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(annotation).Error; err != nil {
+		return fmt.Errorf("failed to create answer annotation: %w", err)
+	}
+	return nil
+}
+
+func (r *AnswerAnnotationPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AnswerAnnotation, error) {
+	db := r.getDB(tx)
+	var annotation models.AnswerAnnotation
+	if err := db.WithContext(ctx).First(&annotation, id).Error; err != nil {
+		return nil, err
+	}
+	return &annotation, nil
+}
+
+func (r *AnswerAnnotationPostgreSQL) Update(ctx context.Context, tx *gorm.DB, annotation *models.AnswerAnnotation) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(annotation).Error; err != nil {
+		return fmt.Errorf("failed to update answer annotation: %w", err)
+	}
+	return nil
+}
+
+func (r *AnswerAnnotationPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Delete(&models.AnswerAnnotation{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete answer annotation: %w", err)
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (r *AnswerAnnotationPostgreSQL) GetByAnswer(ctx context.Context, tx *gorm.DB, answerID uint) ([]*models.AnswerAnnotation, error) {
+	db := r.getDB(tx)
+	var annotations []*models.AnswerAnnotation
+	if err := db.WithContext(ctx).
+		Where("answer_id = ?", answerID).
+		Order("part_id ASC NULLS FIRST, start_offset ASC, id ASC").
+		Find(&annotations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get answer annotations: %w", err)
+	}
+	return annotations, nil
+}
+
+func (r *AnswerAnnotationPostgreSQL) GetByAttempt(ctx context.Context, tx *gorm.DB, attemptID uint) ([]*models.AnswerAnnotation, error) {
+	db := r.getDB(tx)
+	var annotations []*models.AnswerAnnotation
+	if err := db.WithContext(ctx).
+		Where("attempt_id = ?", attemptID).
+		Order("answer_id ASC, part_id ASC NULLS FIRST, start_offset ASC, id ASC").
+		Find(&annotations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attempt annotations: %w", err)
+	}
+	return annotations, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *AnswerAnnotationPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type AnswerAttachmentPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewAnswerAttachmentPostgreSQL(db *gorm.DB) repositories.AnswerAttachmentRepository {
+	return &AnswerAttachmentPostgreSQL{db: db}
+}
+
+// ===== BASIC OPERATIONS =====
+
+func (r *AnswerAttachmentPostgreSQL) Create(ctx context.Context, tx *gorm.DB, attachment *models.AnswerAttachment) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(attachment).Error; err != nil {
+		return fmt.Errorf("failed to create answer attachment: %w", err)
+	}
+	return nil
+}
+
+func (r *AnswerAttachmentPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AnswerAttachment, error) {
+	db := r.getDB(tx)
+	var attachment models.AnswerAttachment
+	if err := db.WithContext(ctx).
+		Preload("Attempt").
+		First(&attachment, id).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *AnswerAttachmentPostgreSQL) Update(ctx context.Context, tx *gorm.DB, attachment *models.AnswerAttachment) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Attempt").Save(attachment).Error; err != nil {
+		return fmt.Errorf("failed to update answer attachment: %w", err)
+	}
+	return nil
+}
+
+func (r *AnswerAttachmentPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).Delete(&models.AnswerAttachment{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete answer attachment: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (r *AnswerAttachmentPostgreSQL) GetByAttemptAndQuestion(ctx context.Context, tx *gorm.DB, attemptID, questionID uint) ([]*models.AnswerAttachment, error) {
+	db := r.getDB(tx)
+	var attachments []*models.AnswerAttachment
+	if err := db.WithContext(ctx).
+		Where("attempt_id = ? AND question_id = ?", attemptID, questionID).
+		Order("created_at ASC, id ASC").
+		Find(&attachments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get answer attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+func (r *AnswerAttachmentPostgreSQL) CountByAttemptAndQuestion(ctx context.Context, tx *gorm.DB, attemptID, questionID uint) (int, error) {
+	db := r.getDB(tx)
+	var count int64
+	if err := db.WithContext(ctx).
+		Model(&models.AnswerAttachment{}).
+		Where("attempt_id = ? AND question_id = ?", attemptID, questionID).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count answer attachments: %w", err)
+	}
+	return int(count), nil
+}
+
+func (r *AnswerAttachmentPostgreSQL) GetRecognizedByQuestion(ctx context.Context, tx *gorm.DB, assessmentID, questionID uint) ([]*models.AnswerAttachment, error) {
+	db := r.getDB(tx)
+	var attachments []*models.AnswerAttachment
+	if err := db.WithContext(ctx).
+		Joins("JOIN assessment_attempts aa ON aa.id = answer_attachments.attempt_id").
+		Where("aa.assessment_id = ? AND answer_attachments.question_id = ?", assessmentID, questionID).
+		Where("answer_attachments.ocr_status = ? AND answer_attachments.ocr_text IS NOT NULL", models.OCRCompleted).
+		Find(&attachments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get recognized attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+func (r *AnswerAttachmentPostgreSQL) SearchText(ctx context.Context, tx *gorm.DB, assessmentID uint, query string, limit int) ([]*models.AnswerAttachment, error) {
+	db := r.getDB(tx)
+	var attachments []*models.AnswerAttachment
+	if err := db.WithContext(ctx).
+		Joins("JOIN assessment_attempts aa ON aa.id = answer_attachments.attempt_id").
+		Where("aa.assessment_id = ?", assessmentID).
+		Where("answer_attachments.ocr_text ILIKE ?", "%"+query+"%").
+		Order("answer_attachments.attempt_id ASC, answer_attachments.id ASC").
+		Limit(limit).
+		Find(&attachments).Error; err != nil {
+		return nil, fmt.Errorf("failed to search answer attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// ===== TEXT RECOGNITION QUEUE =====
+
+func (r *AnswerAttachmentPostgreSQL) ClaimForOCR(ctx context.Context, tx *gorm.DB, id uint, staleBefore time.Time, maxAttempts int) (bool, error) {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Model(&models.AnswerAttachment{}).
+		Where("id = ?", id).
+		Where(r.claimableCondition(), models.OCRPending, models.OCRFailed, maxAttempts, models.OCRProcessing, staleBefore).
+		Updates(map[string]interface{}{
+			"ocr_status":   models.OCRProcessing,
+			"ocr_attempts": gorm.Expr("ocr_attempts + 1"),
+			"updated_at":   time.Now(),
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim answer attachment: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *AnswerAttachmentPostgreSQL) GetPendingOCR(ctx context.Context, tx *gorm.DB, staleBefore time.Time, maxAttempts, limit int) ([]uint, error) {
+	db := r.getDB(tx)
+	var ids []uint
+	if err := db.WithContext(ctx).
+		Model(&models.AnswerAttachment{}).
+		Where(r.claimableCondition(), models.OCRPending, models.OCRFailed, maxAttempts, models.OCRProcessing, staleBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to get pending answer attachments: %w", err)
+	}
+	return ids, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *AnswerAttachmentPostgreSQL) claimableCondition() string {
+	return "ocr_status = ? OR (ocr_status = ? AND ocr_attempts < ?) OR (ocr_status = ? AND updated_at < ?)"
+}
+
+func (r *AnswerAttachmentPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
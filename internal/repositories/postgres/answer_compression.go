@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+const answerCompressionOriginals = "answer_compression:originals"
+
+var studentAnswerType = reflect.TypeOf(models.StudentAnswer{})
+
+// answerCompression moves large answers to the compressed column on write and back on
+// read. It runs as gorm callbacks so preloaded answers are covered as well; answers
+// stored before compression was enabled are read as they are.
+type answerCompression struct {
+	compressor *cache.Compressor
+}
+
+type compressedAnswer struct {
+	answer   *models.StudentAnswer
+	original []byte
+}
+
+// registerAnswerCompression installs the answer compression callbacks on the database
+func registerAnswerCompression(db *gorm.DB, compressor *cache.Compressor) error {
+	if compressor == nil {
+		return nil
+	}
+
+	codec := &answerCompression{compressor: compressor}
+	callbacks := db.Callback()
+	if err := callbacks.Create().Before("gorm:create").Register("answer_compression:compress_create", codec.compress); err != nil {
+		return err
+	}
+	if err := callbacks.Create().After("gorm:create").Register("answer_compression:restore_create", codec.restore); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("answer_compression:compress_update", codec.compress); err != nil {
+		return err
+	}
+	if err := callbacks.Update().After("gorm:update").Register("answer_compression:restore_update", codec.restore); err != nil {
+		return err
+	}
+	return callbacks.Query().After("gorm:query").Register("answer_compression:decompress", codec.decompress)
+}
+
+// compress swaps large answers for their compressed form until the write is done
+func (c *answerCompression) compress(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	// Column updates never write the answer
+	if _, ok := db.Statement.Dest.(map[string]interface{}); ok {
+		return
+	}
+
+	var pending []compressedAnswer
+	for _, answer := range statementAnswers(db) {
+		if len(answer.Answer) == 0 {
+			continue
+		}
+		packed := c.compressor.Compress(cache.CompressionStoreAnswers, answer.Answer)
+		if !cache.IsCompressed(packed) {
+			answer.AnswerCompressed = nil
+			continue
+		}
+		pending = append(pending, compressedAnswer{answer: answer, original: answer.Answer})
+		answer.AnswerCompressed = packed
+		answer.Answer = nil
+	}
+	if len(pending) > 0 {
+		db.InstanceSet(answerCompressionOriginals, pending)
+	}
+}
+
+// restore gives callers back the answers they saved, whether or not the write succeeded
+func (c *answerCompression) restore(db *gorm.DB) {
+	value, ok := db.InstanceGet(answerCompressionOriginals)
+	if !ok {
+		return
+	}
+	for _, entry := range value.([]compressedAnswer) {
+		entry.answer.Answer = entry.original
+		entry.answer.AnswerCompressed = nil
+	}
+}
+
+// decompress restores compressed answers after they are read
+func (c *answerCompression) decompress(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+
+	for _, answer := range statementAnswers(db) {
+		if len(answer.AnswerCompressed) == 0 {
+			continue
+		}
+		data, err := c.compressor.Decompress(cache.CompressionStoreAnswers, answer.AnswerCompressed)
+		if err != nil {
+			db.AddError(fmt.Errorf("failed to decompress answer %d: %w", answer.ID, err))
+			return
+		}
+		answer.Answer = data
+		answer.AnswerCompressed = nil
+	}
+}
+
+// statementAnswers returns the student answers a statement writes or reads
+func statementAnswers(db *gorm.DB) []*models.StudentAnswer {
+	if db.Statement.Schema == nil || db.Statement.Schema.ModelType != studentAnswerType {
+		return nil
+	}
+
+	value := reflect.Indirect(db.Statement.ReflectValue)
+	switch value.Kind() {
+	case reflect.Struct:
+		if answer, ok := addressableAnswer(value); ok {
+			return []*models.StudentAnswer{answer}
+		}
+	case reflect.Slice, reflect.Array:
+		answers := make([]*models.StudentAnswer, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			if answer, ok := addressableAnswer(reflect.Indirect(value.Index(i))); ok {
+				answers = append(answers, answer)
+			}
+		}
+		return answers
+	}
+	return nil
+}
+
+func addressableAnswer(value reflect.Value) (*models.StudentAnswer, bool) {
+	if value.Kind() != reflect.Struct || !value.CanAddr() {
+		return nil, false
+	}
+	answer, ok := value.Addr().Interface().(*models.StudentAnswer)
+	return answer, ok
+}
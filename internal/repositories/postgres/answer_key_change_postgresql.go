@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type AnswerKeyChangePostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewAnswerKeyChangePostgreSQL(db *gorm.DB) repositories.AnswerKeyChangeRepository {
+	return &AnswerKeyChangePostgreSQL{db: db}
+}
+
+// ===== CHANGES =====
+
+func (r *AnswerKeyChangePostgreSQL) CreateChange(ctx context.Context, tx *gorm.DB, change *models.AnswerKeyChange) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(change).Error; err != nil {
+		return fmt.Errorf("failed to create answer key change: %w", err)
+	}
+	return nil
+}
+
+func (r *AnswerKeyChangePostgreSQL) GetChangeByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AnswerKeyChange, error) {
+	db := r.getDB(tx)
+	var change models.AnswerKeyChange
+	if err := db.WithContext(ctx).Where("id = ?", id).First(&change).Error; err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (r *AnswerKeyChangePostgreSQL) GetChangesByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) ([]*models.AnswerKeyChange, error) {
+	db := r.getDB(tx)
+	var changes []*models.AnswerKeyChange
+	if err := db.WithContext(ctx).
+		Where("question_id = ?", questionID).
+		Order("created_at DESC").
+		Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("failed to get answer key changes: %w", err)
+	}
+	return changes, nil
+}
+
+func (r *AnswerKeyChangePostgreSQL) GetAffectedAnswers(ctx context.Context, tx *gorm.DB, questionID uint, changedAt time.Time, assessmentID *uint) ([]repositories.AffectedAnswer, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).
+		Table("student_answers sa").
+		Select(`sa.id AS answer_id, sa.attempt_id, aa.assessment_id, aa.student_id,
+			sa.score AS answer_score, aa.score AS attempt_score, aa.completed_at,
+			sa.graded_by IS NOT NULL AS manually_graded`).
+		Joins("JOIN assessment_attempts aa ON aa.id = sa.attempt_id").
+		Where("COALESCE(sa.variant_question_id, sa.question_id) = ?", questionID). // Graded against the question served
+		Where("aa.status IN ?", []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Where("aa.completed_at <= ?", changedAt)
+	if assessmentID != nil {
+		query = query.Where("aa.assessment_id = ?", *assessmentID)
+	}
+
+	var answers []repositories.AffectedAnswer
+	if err := query.Order("aa.assessment_id, aa.completed_at").Scan(&answers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get affected answers: %w", err)
+	}
+	return answers, nil
+}
+
+// ===== REGRADE JOBS =====
+
+func (r *AnswerKeyChangePostgreSQL) CreateJob(ctx context.Context, tx *gorm.DB, job *models.RegradeJob) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(job).Error; err != nil {
+		return fmt.Errorf("failed to create regrade job: %w", err)
+	}
+	return nil
+}
+
+func (r *AnswerKeyChangePostgreSQL) GetJobByID(ctx context.Context, tx *gorm.DB, id uint) (*models.RegradeJob, error) {
+	db := r.getDB(tx)
+	var job models.RegradeJob
+	if err := db.WithContext(ctx).Where("id = ?", id).First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *AnswerKeyChangePostgreSQL) UpdateJob(ctx context.Context, tx *gorm.DB, job *models.RegradeJob) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(job).Error; err != nil {
+		return fmt.Errorf("failed to update regrade job: %w", err)
+	}
+	return nil
+}
+
+func (r *AnswerKeyChangePostgreSQL) GetJobsByChange(ctx context.Context, tx *gorm.DB, changeID uint) ([]*models.RegradeJob, error) {
+	db := r.getDB(tx)
+	var jobs []*models.RegradeJob
+	if err := db.WithContext(ctx).
+		Where("change_id = ?", changeID).
+		Order("created_at DESC, id DESC").
+		Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get regrade jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+func (r *AnswerKeyChangePostgreSQL) ClaimJob(ctx context.Context, tx *gorm.DB, id uint, staleBefore time.Time) (bool, error) {
+	db := r.getDB(tx)
+	now := time.Now()
+	result := db.WithContext(ctx).
+		Model(&models.RegradeJob{}).
+		Where("id = ?", id).
+		Where("status = ? OR (status = ? AND updated_at < ?)", models.RegradePending, models.RegradeRunning, staleBefore).
+		Updates(map[string]interface{}{
+			"status":     models.RegradeRunning,
+			"started_at": gorm.Expr("COALESCE(started_at, ?)", now),
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim regrade job: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *AnswerKeyChangePostgreSQL) GetRunnableJobs(ctx context.Context, tx *gorm.DB, staleBefore time.Time, limit int) ([]*models.RegradeJob, error) {
+	db := r.getDB(tx)
+	var jobs []*models.RegradeJob
+	if err := db.WithContext(ctx).
+		Where("status = ? OR (status = ? AND updated_at < ?)", models.RegradePending, models.RegradeRunning, staleBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get runnable regrade jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *AnswerKeyChangePostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type AnswerReviewPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewAnswerReviewPostgreSQL(db *gorm.DB) repositories.AnswerReviewRepository {
+	return &AnswerReviewPostgreSQL{db: db}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (r *AnswerReviewPostgreSQL) Create(ctx context.Context, tx *gorm.DB, review *models.AnswerReview) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(review).Error; err != nil {
+		return fmt.Errorf("failed to create answer review: %w", err)
+	}
+	return nil
+}
+
+func (r *AnswerReviewPostgreSQL) CreateBatch(ctx context.Context, tx *gorm.DB, reviews []*models.AnswerReview) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).CreateInBatches(reviews, 100).Error; err != nil {
+		return fmt.Errorf("failed to create answer reviews: %w", err)
+	}
+	return nil
+}
+
+func (r *AnswerReviewPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AnswerReview, error) {
+	db := r.getDB(tx)
+	var review models.AnswerReview
+	if err := db.WithContext(ctx).
+		Preload("Answer").
+		Preload("Answer.Question").
+		First(&review, id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *AnswerReviewPostgreSQL) Update(ctx context.Context, tx *gorm.DB, review *models.AnswerReview) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Answer").Save(review).Error; err != nil {
+		return fmt.Errorf("failed to update answer review: %w", err)
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (r *AnswerReviewPostgreSQL) GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AnswerReview, error) {
+	db := r.getDB(tx)
+	var reviews []*models.AnswerReview
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ?", assessmentID).
+		Order("grader_id ASC, created_at ASC").
+		Find(&reviews).Error; err != nil {
+		return nil, fmt.Errorf("failed to get answer reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+func (r *AnswerReviewPostgreSQL) GetPendingByReviewer(ctx context.Context, tx *gorm.DB, reviewerID string) ([]*models.AnswerReview, error) {
+	db := r.getDB(tx)
+	var reviews []*models.AnswerReview
+	if err := db.WithContext(ctx).
+		Where("reviewer_id = ? AND status = ?", reviewerID, models.AnswerReviewPending).
+		Preload("Answer").
+		Preload("Answer.Question").
+		Order("created_at ASC").
+		Find(&reviews).Error; err != nil {
+		return nil, fmt.Errorf("failed to get pending reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+func (r *AnswerReviewPostgreSQL) GetByGrader(ctx context.Context, tx *gorm.DB, graderID string, filter repositories.GraderStatsFilter) ([]*models.AnswerReview, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).Where("grader_id = ?", graderID)
+	query = applyGraderStatsScope(query, filter, "assessment_id")
+	if filter.Since != nil {
+		query = query.Where("created_at >= ?", *filter.Since)
+	}
+
+	var reviews []*models.AnswerReview
+	if err := query.Order("created_at ASC").Find(&reviews).Error; err != nil {
+		return nil, fmt.Errorf("failed to get answer reviews by grader: %w", err)
+	}
+	return reviews, nil
+}
+
+func (r *AnswerReviewPostgreSQL) CountPendingByReviewer(ctx context.Context, tx *gorm.DB, reviewerID string, filter repositories.GraderStatsFilter) (int, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).
+		Model(&models.AnswerReview{}).
+		Where("reviewer_id = ? AND status = ?", reviewerID, models.AnswerReviewPending)
+	query = applyGraderStatsScope(query, filter, "assessment_id")
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count pending reviews: %w", err)
+	}
+	return int(count), nil
+}
+
+// ===== SAMPLING =====
+
+func (r *AnswerReviewPostgreSQL) GetSampleCandidates(ctx context.Context, tx *gorm.DB, assessmentID uint, excludeGraderID string) ([]*models.StudentAnswer, error) {
+	db := r.getDB(tx)
+	var answers []*models.StudentAnswer
+	if err := db.WithContext(ctx).
+		Joins("JOIN assessment_attempts aa ON aa.id = student_answers.attempt_id").
+		Where("aa.assessment_id = ?", assessmentID).
+		Where("student_answers.graded_at IS NOT NULL AND student_answers.graded_by IS NOT NULL").
+		Where("student_answers.graded_by <> ?", excludeGraderID).
+		Where("NOT EXISTS (SELECT 1 FROM answer_reviews ar WHERE ar.answer_id = student_answers.id)").
+		Preload("Question").
+		Find(&answers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get sample candidates: %w", err)
+	}
+	return answers, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *AnswerReviewPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AssessmentAnalyticsPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewAssessmentAnalyticsPostgreSQL(db *gorm.DB) repositories.AssessmentAnalyticsRepository {
+	return &AssessmentAnalyticsPostgreSQL{db: db}
+}
+
+// ===== BASIC OPERATIONS =====
+
+func (r *AssessmentAnalyticsPostgreSQL) Upsert(ctx context.Context, tx *gorm.DB, analytics *models.AssessmentAnalytics) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).
+		Omit("Assessment").
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "assessment_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"total_attempts", "completed_attempts", "abandoned_attempts",
+				"average_score", "median_score", "highest_score", "lowest_score", "standard_deviation",
+				"average_time_spent", "median_time_spent", "pass_rate", "passed_count", "failed_count",
+				"score_distribution", "time_distribution",
+				"scored_attempts", "item_count", "reliability", "item_stats", "ranked_scores",
+				"last_calculated_at", "updated_at",
+			}),
+		}).
+		Create(analytics).Error; err != nil {
+		return fmt.Errorf("failed to save assessment analytics: %w", err)
+	}
+	return nil
+}
+
+func (r *AssessmentAnalyticsPostgreSQL) GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) (*models.AssessmentAnalytics, error) {
+	db := r.getDB(tx)
+	var analytics models.AssessmentAnalytics
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ?", assessmentID).
+		First(&analytics).Error; err != nil {
+		return nil, err
+	}
+	return &analytics, nil
+}
+
+// ===== ANALYSIS INPUTS =====
+
+func (r *AssessmentAnalyticsPostgreSQL) GetItemScores(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]repositories.ItemScore, error) {
+	db := r.getDB(tx)
+	var scores []repositories.ItemScore
+	if err := db.WithContext(ctx).
+		Table("student_answers").
+		Select("student_answers.attempt_id, student_answers.question_id, student_answers.score, student_answers.max_score").
+		Joins("JOIN assessment_attempts aa ON aa.id = student_answers.attempt_id").
+		Where("aa.assessment_id = ?", assessmentID).
+		Where("aa.status IN ?", []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Where("NOT EXISTS (SELECT 1 FROM student_answers pending WHERE pending.attempt_id = aa.id AND NOT pending.is_graded)").
+		Order("student_answers.attempt_id ASC, student_answers.question_id ASC").
+		Scan(&scores).Error; err != nil {
+		return nil, fmt.Errorf("failed to get item scores: %w", err)
+	}
+	return scores, nil
+}
+
+func (r *AssessmentAnalyticsPostgreSQL) GetConfidenceResponses(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]repositories.ConfidenceResponse, error) {
+	db := r.getDB(tx)
+	var responses []repositories.ConfidenceResponse
+	if err := db.WithContext(ctx).
+		Table("student_answers").
+		Select("aa.student_id, student_answers.question_id, student_answers.confidence, student_answers.score, student_answers.max_score").
+		Joins("JOIN assessment_attempts aa ON aa.id = student_answers.attempt_id").
+		Where("aa.assessment_id = ?", assessmentID).
+		Where("aa.status IN ?", []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Where("student_answers.confidence IS NOT NULL").
+		Where("NOT EXISTS (SELECT 1 FROM student_answers pending WHERE pending.attempt_id = aa.id AND NOT pending.is_graded)").
+		Order("aa.student_id ASC, student_answers.question_id ASC").
+		Scan(&responses).Error; err != nil {
+		return nil, fmt.Errorf("failed to get confidence responses: %w", err)
+	}
+	return responses, nil
+}
+
+func (r *AssessmentAnalyticsPostgreSQL) GetStaleAssessmentIDs(ctx context.Context, tx *gorm.DB, limit int) ([]uint, error) {
+	db := r.getDB(tx)
+	var assessmentIDs []uint
+	if err := db.WithContext(ctx).
+		Table("assessment_attempts").
+		Select("assessment_attempts.assessment_id").
+		Joins("LEFT JOIN assessment_analytics an ON an.assessment_id = assessment_attempts.assessment_id").
+		Where("assessment_attempts.status IN ?", []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Group("assessment_attempts.assessment_id").
+		Having("MAX(an.last_calculated_at) IS NULL OR MAX(assessment_attempts.updated_at) > MAX(an.last_calculated_at)").
+		Order("MAX(assessment_attempts.updated_at) ASC").
+		Limit(limit).
+		Pluck("assessment_attempts.assessment_id", &assessmentIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get stale assessments: %w", err)
+	}
+	return assessmentIDs, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *AssessmentAnalyticsPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
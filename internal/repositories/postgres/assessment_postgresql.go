@@ -8,7 +8,6 @@ import (
 	"github.com/SAP-F-2025/assessment-service/internal/cache"
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
-	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
@@ -18,11 +17,11 @@ type AssessmentPostgreSQL struct {
 	cacheManager *cache.CacheManager
 }
 
-func NewAssessmentPostgreSQL(db *gorm.DB, redisClient *redis.Client) repositories.AssessmentRepository {
+func NewAssessmentPostgreSQL(db *gorm.DB, redisClient *cache.Client) repositories.AssessmentRepository {
 	return &AssessmentPostgreSQL{
 		db:           db,
 		helpers:      NewSharedHelpers(db),
-		cacheManager: cache.NewCacheManager(redisClient),
+		cacheManager: cache.NewCacheManager(redisClient, cache.RepositoryAssessment),
 	}
 }
 
@@ -52,7 +51,7 @@ func (a *AssessmentPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint
 	cacheKey := fmt.Sprintf("id:%d", id)
 	var assessment models.Assessment
 
-	err := a.cacheManager.Assessment.CacheOrExecute(ctx, cacheKey, &assessment, cache.AssessmentCacheConfig.TTL, func() (interface{}, error) {
+	err := a.cacheManager.Assessment.CacheOrExecute(ctx, cacheKey, &assessment, a.cacheManager.Assessment.TTL(), func() (interface{}, error) {
 		var dbAssessment models.Assessment
 		err := tx.WithContext(ctx).
 			Preload("Creator").
@@ -149,6 +148,8 @@ func (a *AssessmentPostgreSQL) Update(ctx context.Context, tx *gorm.DB, assessme
 		"passing_score": assessment.PassingScore,
 		"time_warning":  assessment.TimeWarning,
 		"due_date":      assessment.DueDate,
+		"due_timezone":  assessment.DueTimezone,
+		"custom_fields": assessment.CustomFields,
 		"status":        assessment.Status,
 		"version":       assessment.Version,
 		"updated_at":    assessment.UpdatedAt,
@@ -509,6 +510,51 @@ func (a *AssessmentPostgreSQL) ExistsByTitle(ctx context.Context, tx *gorm.DB, t
 	return count > 0, err
 }
 
+// GetByTitlePrefix lists the creator's assessments whose title starts with prefix, loading
+// only their titles and terms
+func (a *AssessmentPostgreSQL) GetByTitlePrefix(ctx context.Context, tx *gorm.DB, prefix string, creatorID string, excludeID *uint) ([]*models.Assessment, error) {
+	// left() rather than LIKE so titles containing % or _ need no escaping
+	query := a.getDB(tx).WithContext(ctx).
+		Select("id", "title", "term").
+		Where("created_by = ? AND left(title, ?) = ?", creatorID, len([]rune(prefix)), prefix)
+
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+
+	var assessments []*models.Assessment
+	if err := query.Find(&assessments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get assessments by title prefix: %w", err)
+	}
+	return assessments, nil
+}
+
+// GetOverlapping retrieves possible duplicates of an assessment across all creators
+func (a *AssessmentPostgreSQL) GetOverlapping(ctx context.Context, tx *gorm.DB, assessmentID uint, titleKey string) ([]repositories.AssessmentOverlap, error) {
+	shared := "SELECT x.question_id FROM assessment_questions x WHERE x.assessment_id = ?"
+
+	candidate := a.getDB(tx).Where("EXISTS (SELECT 1 FROM assessment_questions aq WHERE aq.assessment_id = a.id AND aq.question_id IN ("+shared+"))", assessmentID)
+	if titleKey != "" {
+		candidate = candidate.Or("lower(regexp_replace(a.title, '[^[:alpha:]]+', '', 'g')) = ?", titleKey)
+	}
+
+	var overlaps []repositories.AssessmentOverlap
+	err := a.getDB(tx).WithContext(ctx).
+		Table("assessments a").
+		Select(`a.id AS assessment_id, a.title, a.status, a.created_by, COALESCE(u.full_name, '') AS creator_name,
+			(SELECT COUNT(*) FROM assessment_questions aq WHERE aq.assessment_id = a.id) AS question_count,
+			(SELECT COUNT(DISTINCT aq.question_id) FROM assessment_questions aq WHERE aq.assessment_id = a.id AND aq.question_id IN (`+shared+`)) AS shared_questions`, assessmentID).
+		Joins("LEFT JOIN users u ON u.id = a.created_by").
+		Where("a.id <> ? AND a.deleted_at IS NULL AND a.status <> ?", assessmentID, models.StatusArchived).
+		Where(candidate).
+		Order("a.id").
+		Scan(&overlaps).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get overlapping assessments: %w", err)
+	}
+	return overlaps, nil
+}
+
 // HasAttempts checks if an assessment has any attempts
 func (a *AssessmentPostgreSQL) HasAttempts(ctx context.Context, tx *gorm.DB, id uint) (bool, error) {
 	count, err := a.helpers.CountAttempts(ctx, id)
@@ -531,10 +577,14 @@ func (a *AssessmentPostgreSQL) HasActiveAttempts(ctx context.Context, tx *gorm.D
 func (a *AssessmentPostgreSQL) UpdateSettings(ctx context.Context, tx *gorm.DB, assessmentID uint, settings *models.AssessmentSettings) error {
 	db := a.getDB(tx)
 	settings.AssessmentID = assessmentID
-	return db.WithContext(ctx).
+	if err := db.WithContext(ctx).
 		Model(&models.AssessmentSettings{}).
 		Where("assessment_id = ?", assessmentID).
-		Updates(settings).Error
+		Updates(settings).Error; err != nil {
+		return err
+	}
+	a.cacheManager.Fast.Delete(ctx, settingsCacheKey(assessmentID))
+	return nil
 }
 
 // GetSettings retrieves assessment settings
@@ -616,6 +666,34 @@ func (a *AssessmentPostgreSQL) UpdateMaxAttempts(ctx context.Context, tx *gorm.D
 		Update("max_attempts", maxAttempts).Error
 }
 
+// CreateSeries stores the record of a generated quiz series
+func (a *AssessmentPostgreSQL) CreateSeries(ctx context.Context, tx *gorm.DB, series *models.AssessmentSeries) error {
+	db := a.getDB(tx)
+	return db.WithContext(ctx).Create(series).Error
+}
+
+// GetDuePublishes retrieves draft assessments whose scheduled publish time has passed
+func (a *AssessmentPostgreSQL) GetDuePublishes(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]*models.Assessment, error) {
+	db := a.getDB(tx)
+	var assessments []*models.Assessment
+	err := db.WithContext(ctx).
+		Where("status = ? AND publish_at IS NOT NULL AND publish_at <= ?", models.StatusDraft, now).
+		Order("publish_at ASC").
+		Limit(limit).
+		Find(&assessments).Error
+
+	return assessments, err
+}
+
+// ClearPublishAt removes an assessment's scheduled publish time
+func (a *AssessmentPostgreSQL) ClearPublishAt(ctx context.Context, tx *gorm.DB, assessmentID uint) error {
+	db := a.getDB(tx)
+	return db.WithContext(ctx).
+		Model(&models.Assessment{}).
+		Where("id = ?", assessmentID).
+		Update("publish_at", nil).Error
+}
+
 // Helper methods
 
 // applyFilters applies common filters to a query
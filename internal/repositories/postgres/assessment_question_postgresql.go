@@ -9,7 +9,6 @@ import (
 	"github.com/SAP-F-2025/assessment-service/internal/cache"
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
-	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
@@ -19,11 +18,11 @@ type AssessmentQuestionPostgreSQL struct {
 	cacheManager *cache.CacheManager
 }
 
-func NewAssessmentQuestionPostgreSQL(db *gorm.DB, redisClient *redis.Client) repositories.AssessmentQuestionRepository {
+func NewAssessmentQuestionPostgreSQL(db *gorm.DB, redisClient *cache.Client) repositories.AssessmentQuestionRepository {
 	return &AssessmentQuestionPostgreSQL{
 		db:           db,
 		helpers:      NewSharedHelpers(db),
-		cacheManager: cache.NewCacheManager(redisClient),
+		cacheManager: cache.NewCacheManager(redisClient, cache.RepositoryAssessmentQuestion),
 	}
 }
 
@@ -37,12 +36,32 @@ func (aq *AssessmentQuestionPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
 	return aq.db
 }
 
+// orderedCacheKey names the cached ordered links of an assessment
+func orderedCacheKey(assessmentID uint) string {
+	return fmt.Sprintf("assessment_question:assessment:%d:ordered", assessmentID)
+}
+
+// invalidate drops the cached links of the given assessments, or of every assessment when
+// none are given
+func (aq *AssessmentQuestionPostgreSQL) invalidate(ctx context.Context, assessmentIDs ...uint) {
+	if len(assessmentIDs) == 0 {
+		aq.cacheManager.Fast.InvalidatePattern(ctx, "assessment_question:assessment:*")
+		return
+	}
+	keys := make([]string, len(assessmentIDs))
+	for i, id := range assessmentIDs {
+		keys[i] = orderedCacheKey(id)
+	}
+	aq.cacheManager.Fast.Delete(ctx, keys...)
+}
+
 // Create creates a new assessment-question relationship
 func (aq *AssessmentQuestionPostgreSQL) Create(ctx context.Context, tx *gorm.DB, assessmentQuestion *models.AssessmentQuestion) error {
 	db := aq.getDB(tx)
 	if err := db.WithContext(ctx).Create(assessmentQuestion).Error; err != nil {
 		return fmt.Errorf("failed to create assessment question: %w", err)
 	}
+	aq.invalidate(ctx, assessmentQuestion.AssessmentID)
 	return nil
 }
 
@@ -68,6 +87,7 @@ func (aq *AssessmentQuestionPostgreSQL) Update(ctx context.Context, tx *gorm.DB,
 	if err := db.WithContext(ctx).Save(assessmentQuestion).Error; err != nil {
 		return fmt.Errorf("failed to update assessment question: %w", err)
 	}
+	aq.invalidate(ctx, assessmentQuestion.AssessmentID)
 	return nil
 }
 
@@ -77,6 +97,7 @@ func (aq *AssessmentQuestionPostgreSQL) Delete(ctx context.Context, tx *gorm.DB,
 	if err := db.WithContext(ctx).Delete(&models.AssessmentQuestion{}, id).Error; err != nil {
 		return fmt.Errorf("failed to delete assessment question: %w", err)
 	}
+	aq.invalidate(ctx)
 	return nil
 }
 
@@ -127,6 +148,7 @@ func (aq *AssessmentQuestionPostgreSQL) RemoveQuestion(ctx context.Context, tx *
 		return fmt.Errorf("no relationship found between assessment %d and question %d", assessmentID, questionID)
 	}
 
+	aq.invalidate(ctx, assessmentID)
 	return nil
 }
 
@@ -214,6 +236,7 @@ func (aq *AssessmentQuestionPostgreSQL) RemoveQuestions(ctx context.Context, tx
 		return nil
 	}
 
+	defer aq.invalidate(ctx, assessmentID)
 	if tx != nil {
 		return execFunc(db)
 	}
@@ -232,6 +255,7 @@ func (aq *AssessmentQuestionPostgreSQL) UpdateOrder(ctx context.Context, tx *gor
 	}
 
 	db := aq.getDB(tx)
+	defer aq.invalidate(ctx, assessmentID)
 	return db.WithContext(ctx).Transaction(func(txInner *gorm.DB) error {
 		for _, qo := range questionOrders {
 			result := txInner.Model(&models.AssessmentQuestion{}).
@@ -300,15 +324,23 @@ func (aq *AssessmentQuestionPostgreSQL) GetByAssessment(ctx context.Context, tx
 	return assessmentQuestions, nil
 }
 
-// GetByAssessmentOrdered retrieves assessment-question relationships ordered by order field
+// GetByAssessmentOrdered retrieves assessment-question relationships ordered by order field.
+// The links are cached so answer checks keep working while the database is unavailable.
 func (aq *AssessmentQuestionPostgreSQL) GetByAssessmentOrdered(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentQuestion, error) {
 	db := aq.getDB(tx)
 	var assessmentQuestions []*models.AssessmentQuestion
-	if err := db.WithContext(ctx).
-		Where("assessment_id = ?", assessmentID).
-		Order("\"order\" ASC").
-		Find(&assessmentQuestions).Error; err != nil {
-		return nil, fmt.Errorf("failed to get ordered assessment questions: %w", err)
+	err := aq.cacheManager.Fast.CacheOrExecute(ctx, orderedCacheKey(assessmentID), &assessmentQuestions, aq.cacheManager.Fast.TTL(), func() (interface{}, error) {
+		var dbQuestions []*models.AssessmentQuestion
+		if err := db.WithContext(ctx).
+			Where("assessment_id = ?", assessmentID).
+			Order("\"order\" ASC").
+			Find(&dbQuestions).Error; err != nil {
+			return nil, fmt.Errorf("failed to get ordered assessment questions: %w", err)
+		}
+		return dbQuestions, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return assessmentQuestions, nil
 }
@@ -334,6 +366,7 @@ func (aq *AssessmentQuestionPostgreSQL) GetQuestionsForAssessment(ctx context.Co
 		Joins("JOIN assessment_questions aq ON aq.question_id = questions.id").
 		Where("aq.assessment_id = ?", assessmentID).
 		Order("aq.\"order\" ASC").
+		Preload("Attachments").
 		Find(&questions).Error; err != nil {
 		return nil, fmt.Errorf("failed to get questions for assessment: %w", err)
 	}
@@ -365,6 +398,7 @@ func (aq *AssessmentQuestionPostgreSQL) CreateBatch(ctx context.Context, tx *gor
 	if err := tx.WithContext(ctx).CreateInBatches(assessmentQuestions, 100).Error; err != nil {
 		return fmt.Errorf("failed to create assessment questions batch: %w", err)
 	}
+	aq.invalidate(ctx, linkAssessmentIDs(assessmentQuestions)...)
 	return nil
 }
 
@@ -375,6 +409,7 @@ func (aq *AssessmentQuestionPostgreSQL) UpdateBatch(ctx context.Context, tx *gor
 	}
 
 	db := aq.getDB(tx)
+	defer aq.invalidate(ctx, linkAssessmentIDs(assessmentQuestions)...)
 	return db.WithContext(ctx).Transaction(func(txInner *gorm.DB) error {
 		for _, assessmentQuestion := range assessmentQuestions {
 			if err := txInner.Save(assessmentQuestion).Error; err != nil {
@@ -393,6 +428,7 @@ func (aq *AssessmentQuestionPostgreSQL) DeleteByAssessment(ctx context.Context,
 		Delete(&models.AssessmentQuestion{}).Error; err != nil {
 		return fmt.Errorf("failed to delete assessment questions by assessment: %w", err)
 	}
+	aq.invalidate(ctx, assessmentID)
 	return nil
 }
 
@@ -404,9 +440,38 @@ func (aq *AssessmentQuestionPostgreSQL) DeleteByQuestion(ctx context.Context, tx
 		Delete(&models.AssessmentQuestion{}).Error; err != nil {
 		return fmt.Errorf("failed to delete assessment questions by question: %w", err)
 	}
+	aq.invalidate(ctx)
 	return nil
 }
 
+// ReplaceQuestion remaps the given assessments' links from one question to another
+func (aq *AssessmentQuestionPostgreSQL) ReplaceQuestion(ctx context.Context, tx *gorm.DB, oldQuestionID, newQuestionID uint, assessmentIDs []uint) (int, error) {
+	if len(assessmentIDs) == 0 {
+		return 0, nil
+	}
+	db := aq.getDB(tx)
+	defer aq.invalidate(ctx, assessmentIDs...)
+
+	// Assessments that already contain the new question just lose the old link
+	if err := db.WithContext(ctx).
+		Where("question_id = ? AND assessment_id IN ?", oldQuestionID, assessmentIDs).
+		Where("assessment_id IN (?)",
+			db.Model(&models.AssessmentQuestion{}).Select("assessment_id").Where("question_id = ?", newQuestionID)).
+		Delete(&models.AssessmentQuestion{}).Error; err != nil {
+		return 0, fmt.Errorf("failed to drop duplicate assessment questions: %w", err)
+	}
+
+	result := db.WithContext(ctx).
+		Model(&models.AssessmentQuestion{}).
+		Where("question_id = ? AND assessment_id IN ?", oldQuestionID, assessmentIDs).
+		Update("question_id", newQuestionID)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to replace assessment question: %w", result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
 // ===== VALIDATION AND CHECKS =====
 
 // Exists checks if an assessment-question relationship exists
@@ -474,6 +539,7 @@ func (aq *AssessmentQuestionPostgreSQL) UpdatePoints(ctx context.Context, tx *go
 		return fmt.Errorf("no relationship found between assessment %d and question %d", assessmentID, questionID)
 	}
 
+	aq.invalidate(ctx, assessmentID)
 	return nil
 }
 
@@ -708,3 +774,16 @@ func (aq *AssessmentQuestionPostgreSQL) GetQuestionAssessmentByAssessmentIdAndQu
 
 	return &assessmentQuestion, nil
 }
+
+// linkAssessmentIDs returns the distinct assessments of the given links
+func linkAssessmentIDs(links []*models.AssessmentQuestion) []uint {
+	seen := make(map[uint]bool, len(links))
+	ids := make([]uint, 0, len(links))
+	for _, link := range links {
+		if !seen[link.AssessmentID] {
+			seen[link.AssessmentID] = true
+			ids = append(ids, link.AssessmentID)
+		}
+	}
+	return ids
+}
@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/cache"
 	"github.com/SAP-F-2025/assessment-service/internal/models"
@@ -14,32 +16,68 @@ type AssessmentSettingsPostgreSQL struct {
 	cacheManager *cache.CacheManager
 }
 
+// cachedSettings keeps the salts AssessmentSettings leaves out of its JSON, so a cached copy
+// does not read as settings without them
+type cachedSettings struct {
+	Settings             models.AssessmentSettings `json:"settings"`
+	AnonymousGradingSalt string                    `json:"anonymous_grading_salt"`
+	ExportAliasSalt      string                    `json:"export_alias_salt"`
+}
+
 func NewAssessmentSettingsPostgreSQL(db *gorm.DB, cacheManager *cache.CacheManager) repositories.AssessmentSettingsRepository {
 	return &AssessmentSettingsPostgreSQL{db: db, cacheManager: cacheManager}
 }
 
 func (a AssessmentSettingsPostgreSQL) Create(ctx context.Context, tx *gorm.DB, settings *models.AssessmentSettings) error {
 	db := a.getDB(tx)
-	return db.WithContext(ctx).Create(settings).Error
+	if err := db.WithContext(ctx).Create(settings).Error; err != nil {
+		return err
+	}
+	a.invalidate(ctx, settings.AssessmentID)
+	return nil
 }
 
+// GetByAssessmentID is cached so answer checks that read the settings keep working while
+// the database is unavailable
 func (a AssessmentSettingsPostgreSQL) GetByAssessmentID(ctx context.Context, tx *gorm.DB, assessmentID uint) (*models.AssessmentSettings, error) {
 	db := a.getDB(tx)
-	var settings models.AssessmentSettings
-	if err := db.WithContext(ctx).Where("assessment_id = ?", assessmentID).First(&settings).Error; err != nil {
+	var cached cachedSettings
+	err := a.cacheManager.Fast.CacheOrExecute(ctx, settingsCacheKey(assessmentID), &cached, a.cacheManager.Fast.TTL(), func() (interface{}, error) {
+		var dbSettings models.AssessmentSettings
+		if err := db.WithContext(ctx).Where("assessment_id = ?", assessmentID).First(&dbSettings).Error; err != nil {
+			return nil, err
+		}
+		return cachedSettings{
+			Settings:             dbSettings,
+			AnonymousGradingSalt: dbSettings.AnonymousGradingSalt,
+			ExportAliasSalt:      dbSettings.ExportAliasSalt,
+		}, nil
+	})
+	if err != nil {
 		return nil, err
 	}
+	settings := cached.Settings
+	settings.AnonymousGradingSalt = cached.AnonymousGradingSalt
+	settings.ExportAliasSalt = cached.ExportAliasSalt
 	return &settings, nil
 }
 
 func (a AssessmentSettingsPostgreSQL) Update(ctx context.Context, tx *gorm.DB, settings *models.AssessmentSettings) error {
 	db := a.getDB(tx)
-	return db.WithContext(ctx).Save(settings).Error
+	if err := db.WithContext(ctx).Save(settings).Error; err != nil {
+		return err
+	}
+	a.invalidate(ctx, settings.AssessmentID)
+	return nil
 }
 
 func (a AssessmentSettingsPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, assessmentID uint) error {
 	db := a.getDB(tx)
-	return db.WithContext(ctx).Where("assessment_id = ?", assessmentID).Delete(&models.AssessmentSettings{}).Error
+	if err := db.WithContext(ctx).Where("assessment_id = ?", assessmentID).Delete(&models.AssessmentSettings{}).Error; err != nil {
+		return err
+	}
+	a.invalidate(ctx, assessmentID)
+	return nil
 }
 
 func (a AssessmentSettingsPostgreSQL) CreateDefault(ctx context.Context, tx *gorm.DB, assessmentID uint) error {
@@ -48,7 +86,11 @@ func (a AssessmentSettingsPostgreSQL) CreateDefault(ctx context.Context, tx *gor
 		AssessmentID: assessmentID,
 		// Set other default fields as necessary
 	}
-	return db.WithContext(ctx).Create(&defaultSettings).Error
+	if err := db.WithContext(ctx).Create(&defaultSettings).Error; err != nil {
+		return err
+	}
+	a.invalidate(ctx, assessmentID)
+	return nil
 }
 
 func (a AssessmentSettingsPostgreSQL) GetMultiple(ctx context.Context, tx *gorm.DB, assessmentIDs []uint) (map[uint]*models.AssessmentSettings, error) {
@@ -67,6 +109,61 @@ func (a AssessmentSettingsPostgreSQL) GetMultiple(ctx context.Context, tx *gorm.
 	return settingsMap, nil
 }
 
+// GetDueResultsReleases retrieves settings whose scheduled results release has passed but was not recorded yet
+func (a AssessmentSettingsPostgreSQL) GetDueResultsReleases(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]*models.AssessmentSettings, error) {
+	db := a.getDB(tx)
+	var settings []*models.AssessmentSettings
+	query := db.WithContext(ctx).
+		Where("results_release_mode = ? AND results_release_at <= ? AND results_released_at IS NULL",
+			models.ResultsReleaseScheduled, now).
+		Order("results_release_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&settings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get due results releases: %w", err)
+	}
+	return settings, nil
+}
+
+func (a AssessmentSettingsPostgreSQL) ReleaseResults(ctx context.Context, tx *gorm.DB, assessmentID uint, releasedAt time.Time, releasedBy string) (bool, error) {
+	db := a.getDB(tx)
+	result := db.WithContext(ctx).
+		Model(&models.AssessmentSettings{}).
+		Where("assessment_id = ? AND results_released_at IS NULL", assessmentID).
+		Updates(map[string]interface{}{
+			"results_released_at": releasedAt,
+			"results_released_by": releasedBy,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to release results: %w", result.Error)
+	}
+	a.invalidate(ctx, assessmentID)
+	return result.RowsAffected > 0, nil
+}
+
+func (a AssessmentSettingsPostgreSQL) SetExportAliasSalt(ctx context.Context, tx *gorm.DB, assessmentID uint, salt string) (bool, error) {
+	db := a.getDB(tx)
+	result := db.WithContext(ctx).
+		Model(&models.AssessmentSettings{}).
+		Where("assessment_id = ? AND (export_alias_salt IS NULL OR export_alias_salt = '')", assessmentID).
+		UpdateColumn("export_alias_salt", salt)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to set export alias salt: %w", result.Error)
+	}
+	a.invalidate(ctx, assessmentID)
+	return result.RowsAffected > 0, nil
+}
+
+func (a AssessmentSettingsPostgreSQL) invalidate(ctx context.Context, assessmentID uint) {
+	a.cacheManager.Fast.Delete(ctx, settingsCacheKey(assessmentID))
+}
+
+// settingsCacheKey is shared with AssessmentPostgreSQL.UpdateSettings, which writes the same rows
+func settingsCacheKey(assessmentID uint) string {
+	return fmt.Sprintf("settings:assessment:%d", assessmentID)
+}
+
 func (a AssessmentSettingsPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
 	if tx != nil {
 		return tx
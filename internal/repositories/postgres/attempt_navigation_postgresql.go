@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type AttemptNavigationPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewAttemptNavigationPostgreSQL(db *gorm.DB) repositories.AttemptNavigationRepository {
+	return &AttemptNavigationPostgreSQL{db: db}
+}
+
+func (r *AttemptNavigationPostgreSQL) Create(ctx context.Context, tx *gorm.DB, event *models.AttemptNavigationEvent) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(event).Error; err != nil {
+		return fmt.Errorf("failed to record navigation event: %w", err)
+	}
+	return nil
+}
+
+func (r *AttemptNavigationPostgreSQL) ListSubmittedByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AttemptNavigationEvent, error) {
+	db := r.getDB(tx)
+	var events []*models.AttemptNavigationEvent
+	if err := db.WithContext(ctx).
+		Joins("JOIN assessment_attempts ON assessment_attempts.id = attempt_navigation_events.attempt_id").
+		Where("assessment_attempts.assessment_id = ? AND assessment_attempts.status IN ?", assessmentID,
+			[]models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Order("attempt_navigation_events.attempt_id ASC, attempt_navigation_events.visited_at ASC, attempt_navigation_events.id ASC").
+		Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to list navigation events: %w", err)
+	}
+	return events, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *AttemptNavigationPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
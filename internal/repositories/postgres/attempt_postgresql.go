@@ -9,7 +9,7 @@ import (
 	"github.com/SAP-F-2025/assessment-service/internal/cache"
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
-	"github.com/redis/go-redis/v9"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -19,11 +19,11 @@ type AttemptPostgreSQL struct {
 	cacheManager *cache.CacheManager
 }
 
-func NewAttemptPostgreSQL(db *gorm.DB, redisClient *redis.Client) repositories.AttemptRepository {
+func NewAttemptPostgreSQL(db *gorm.DB, redisClient *cache.Client) repositories.AttemptRepository {
 	return &AttemptPostgreSQL{
 		db:           db,
 		helpers:      NewSharedHelpers(db),
-		cacheManager: cache.NewCacheManager(redisClient),
+		cacheManager: cache.NewCacheManager(redisClient, cache.RepositoryAttempt),
 	}
 }
 
@@ -38,7 +38,7 @@ func (a *AttemptPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (
 	cacheKey := fmt.Sprintf("id:%d", id)
 	var attempt models.AssessmentAttempt
 
-	err := a.cacheManager.Fast.CacheOrExecute(ctx, cacheKey, &attempt, cache.FastCacheConfig.TTL, func() (interface{}, error) {
+	err := a.cacheManager.Fast.CacheOrExecute(ctx, cacheKey, &attempt, a.cacheManager.Fast.TTL(), func() (interface{}, error) {
 		var dbAttempt models.AssessmentAttempt
 		if err := db.WithContext(ctx).First(&dbAttempt, id).Error; err != nil {
 			return nil, fmt.Errorf("failed to get attempt: %w", err)
@@ -147,6 +147,54 @@ func (a *AttemptPostgreSQL) GetByStudentAndAssessment(ctx context.Context, tx *g
 	return attempts, nil
 }
 
+// GetStudentIDsByAssessment retrieves the distinct students who attempted an assessment
+func (a *AttemptPostgreSQL) GetStudentIDsByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]string, error) {
+	db := a.getDB(tx)
+	var studentIDs []string
+	if err := db.WithContext(ctx).
+		Model(&models.AssessmentAttempt{}).
+		Where("assessment_id = ?", assessmentID).
+		Distinct().
+		Pluck("student_id", &studentIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get students by assessment: %w", err)
+	}
+	return studentIDs, nil
+}
+
+// GetStudentIDsWithIncompleteAttempts retrieves the distinct students with an attempt in progress
+func (a *AttemptPostgreSQL) GetStudentIDsWithIncompleteAttempts(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]string, error) {
+	db := a.getDB(tx)
+	var studentIDs []string
+	if err := db.WithContext(ctx).
+		Model(&models.AssessmentAttempt{}).
+		Where("assessment_id = ? AND status = ?", assessmentID, models.AttemptInProgress).
+		Distinct().
+		Pluck("student_id", &studentIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get students with incomplete attempts: %w", err)
+	}
+	return studentIDs, nil
+}
+
+// GetStudentActivityByAssessment aggregates attempts on an assessment per student
+func (a *AttemptPostgreSQL) GetStudentActivityByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]repositories.StudentAttemptActivity, error) {
+	db := a.getDB(tx)
+	var activity []repositories.StudentAttemptActivity
+	if err := db.WithContext(ctx).
+		Model(&models.AssessmentAttempt{}).
+		Select(`student_id,
+			COUNT(*) AS attempt_count,
+			MIN(started_at) AS first_started_at,
+			MAX(updated_at) AS last_activity_at,
+			BOOL_OR(status IN ?) AS submitted`,
+			[]models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Where("assessment_id = ?", assessmentID).
+		Group("student_id").
+		Scan(&activity).Error; err != nil {
+		return nil, fmt.Errorf("failed to get student activity: %w", err)
+	}
+	return activity, nil
+}
+
 func (a *AttemptPostgreSQL) GetActiveAttempt(ctx context.Context, tx *gorm.DB, studentID string, assessmentID uint) (*models.AssessmentAttempt, error) {
 	db := a.getDB(tx)
 	var attempt models.AssessmentAttempt
@@ -193,6 +241,63 @@ func (a *AttemptPostgreSQL) GetActiveAttempts(ctx context.Context, tx *gorm.DB,
 	return attempts, nil
 }
 
+func (a *AttemptPostgreSQL) GetDuplicateActiveAttempts(ctx context.Context, tx *gorm.DB, assessmentID *uint) ([]*models.AssessmentAttempt, error) {
+	db := a.getDB(tx)
+	duplicated := db.Model(&models.AssessmentAttempt{}).
+		Select("student_id, assessment_id").
+		Where("status = ?", models.AttemptInProgress).
+		Group("student_id, assessment_id").
+		Having("COUNT(*) > 1")
+	if assessmentID != nil {
+		duplicated = duplicated.Where("assessment_id = ?", *assessmentID)
+	}
+
+	var attempts []*models.AssessmentAttempt
+	if err := db.WithContext(ctx).
+		Where("status = ? AND (student_id, assessment_id) IN (?)", models.AttemptInProgress, duplicated).
+		Order("student_id ASC, assessment_id ASC, id ASC").
+		Find(&attempts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get duplicate attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+func (a *AttemptPostgreSQL) CountActive(ctx context.Context, tx *gorm.DB, assessmentID uint, now time.Time) (int, error) {
+	db := a.getDB(tx)
+	var count int64
+	if err := db.WithContext(ctx).
+		Model(&models.AssessmentAttempt{}).
+		Where("assessment_id = ? AND status = ?", assessmentID, models.AttemptInProgress).
+		Where("ended_at IS NULL OR ended_at > ?", now).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count active attempts: %w", err)
+	}
+	return int(count), nil
+}
+
+func (a *AttemptPostgreSQL) CountActiveByAssessment(ctx context.Context, tx *gorm.DB, now time.Time) (map[uint]int, error) {
+	db := a.getDB(tx)
+	var rows []struct {
+		AssessmentID uint
+		Count        int
+	}
+	if err := db.WithContext(ctx).
+		Model(&models.AssessmentAttempt{}).
+		Select("assessment_id, COUNT(*) AS count").
+		Where("status = ?", models.AttemptInProgress).
+		Where("ended_at IS NULL OR ended_at > ?", now).
+		Group("assessment_id").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count active attempts: %w", err)
+	}
+
+	counts := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		counts[row.AssessmentID] = row.Count
+	}
+	return counts, nil
+}
+
 func (a *AttemptPostgreSQL) UpdateStatus(ctx context.Context, tx *gorm.DB, id uint, status models.AttemptStatus) error {
 	db := a.getDB(tx)
 	return db.WithContext(ctx).Model(&models.AssessmentAttempt{}).Where("id = ?", id).Update("status", status).Error
@@ -241,6 +346,22 @@ func (a *AttemptPostgreSQL) GetInProgressAttempts(ctx context.Context, tx *gorm.
 	return attempts, nil
 }
 
+func (a *AttemptPostgreSQL) GetInProgressByAssessments(ctx context.Context, tx *gorm.DB, assessmentIDs []uint) ([]*models.AssessmentAttempt, error) {
+	var attempts []*models.AssessmentAttempt
+	if len(assessmentIDs) == 0 {
+		return attempts, nil
+	}
+
+	db := a.getDB(tx)
+	if err := db.WithContext(ctx).
+		Where("assessment_id IN ? AND status = ?", assessmentIDs, models.AttemptInProgress).
+		Order("id ASC").
+		Find(&attempts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attempts in progress: %w", err)
+	}
+	return attempts, nil
+}
+
 func (a *AttemptPostgreSQL) GetTimedOutAttempts(ctx context.Context, tx *gorm.DB) ([]*models.AssessmentAttempt, error) {
 	db := a.getDB(tx)
 	var attempts []*models.AssessmentAttempt
@@ -269,6 +390,20 @@ func (a *AttemptPostgreSQL) GetExpiredAttempts(ctx context.Context, tx *gorm.DB,
 	return attempts, nil
 }
 
+func (a *AttemptPostgreSQL) GetOverdue(ctx context.Context, tx *gorm.DB, endedBefore time.Time, limit int) ([]*models.AssessmentAttempt, error) {
+	db := a.getDB(tx)
+	var attempts []*models.AssessmentAttempt
+	if err := db.WithContext(ctx).
+		Where("status = ? AND ended_at < ?", models.AttemptInProgress, endedBefore).
+		Order("ended_at ASC").
+		Limit(limit).
+		Find(&attempts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get overdue attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
 func (a *AttemptPostgreSQL) UpdateProgress(ctx context.Context, tx *gorm.DB, id uint, currentQuestionIndex, questionsAnswered int) error {
 	db := a.getDB(tx)
 	return db.WithContext(ctx).Model(&models.AssessmentAttempt{}).
@@ -279,6 +414,26 @@ func (a *AttemptPostgreSQL) UpdateProgress(ctx context.Context, tx *gorm.DB, id
 		}).Error
 }
 
+func (a *AttemptPostgreSQL) UpdateCustomFields(ctx context.Context, tx *gorm.DB, id uint, customFields datatypes.JSON) error {
+	db := a.getDB(tx)
+	if err := db.WithContext(ctx).Model(&models.AssessmentAttempt{}).
+		Where("id = ?", id).
+		Update("custom_fields", customFields).Error; err != nil {
+		return fmt.Errorf("failed to update attempt custom fields: %w", err)
+	}
+	return nil
+}
+
+func (a *AttemptPostgreSQL) UpdateLockedSections(ctx context.Context, tx *gorm.DB, id uint, lockedSections datatypes.JSON) error {
+	db := a.getDB(tx)
+	if err := db.WithContext(ctx).Model(&models.AssessmentAttempt{}).
+		Where("id = ?", id).
+		Update("locked_sections", lockedSections).Error; err != nil {
+		return fmt.Errorf("failed to update attempt locked sections: %w", err)
+	}
+	return nil
+}
+
 func (a *AttemptPostgreSQL) GetProgress(ctx context.Context, tx *gorm.DB, id uint) (*repositories.AttemptProgress, error) {
 	db := a.getDB(tx)
 	var attempt models.AssessmentAttempt
@@ -323,7 +478,7 @@ func (a *AttemptPostgreSQL) CompleteAttempt(ctx context.Context, tx *gorm.DB, id
 }
 
 func (a *AttemptPostgreSQL) GetAttemptCount(ctx context.Context, tx *gorm.DB, studentID string, assessmentID uint) (int, error) {
-	count, err := a.helpers.CountAttemptsByStudent(ctx, assessmentID, studentID)
+	count, err := a.helpers.CountUsedAttemptsByStudent(ctx, assessmentID, studentID)
 	return int(count), err
 }
 
@@ -498,6 +653,85 @@ func (a *AttemptPostgreSQL) GetAttemptsByDateRange(ctx context.Context, tx *gorm
 	return attempts, nil
 }
 
+// GetAttemptFunnel counts attempts reaching each stage from start to pass in a single query
+func (a *AttemptPostgreSQL) GetAttemptFunnel(ctx context.Context, tx *gorm.DB, assessmentID uint) (*repositories.AttemptFunnel, error) {
+	db := a.getDB(tx)
+	finished := []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}
+	var funnel repositories.AttemptFunnel
+	if err := db.WithContext(ctx).
+		Table("assessment_attempts aa").
+		Select(`COUNT(*) AS started,
+			COUNT(CASE WHEN aa.questions_answered > 0 OR aa.status IN ? THEN 1 END) AS answered,
+			COUNT(CASE WHEN aa.status IN ? THEN 1 END) AS submitted,
+			COUNT(CASE WHEN aa.status IN ? AND NOT EXISTS (
+				SELECT 1 FROM student_answers sa WHERE sa.attempt_id = aa.id AND sa.is_graded = false
+			) THEN 1 END) AS graded,
+			COUNT(CASE WHEN aa.status IN ? AND aa.passed THEN 1 END) AS passed`,
+			finished, finished, finished, finished).
+		Where("aa.assessment_id = ?", assessmentID).
+		Scan(&funnel).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attempt funnel: %w", err)
+	}
+	return &funnel, nil
+}
+
+func (a *AttemptPostgreSQL) GetFinishedPercentages(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]float64, error) {
+	db := a.getDB(tx)
+	var percentages []float64
+	if err := db.WithContext(ctx).
+		Model(&models.AssessmentAttempt{}).
+		Where("assessment_id = ? AND status IN ?", assessmentID, []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Pluck("percentage", &percentages).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attempt percentages: %w", err)
+	}
+	return percentages, nil
+}
+
+func (a *AttemptPostgreSQL) GetFinishedByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentAttempt, error) {
+	db := a.getDB(tx)
+	var attempts []*models.AssessmentAttempt
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ? AND status IN ?", assessmentID, []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Order("created_at ASC").
+		Find(&attempts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get finished attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+func (a *AttemptPostgreSQL) GetFinishedByStudent(ctx context.Context, tx *gorm.DB, studentID string) ([]*models.AssessmentAttempt, error) {
+	db := a.getDB(tx)
+	var attempts []*models.AssessmentAttempt
+	if err := db.WithContext(ctx).
+		Joins("JOIN assessments ON assessments.id = assessment_attempts.assessment_id AND assessments.deleted_at IS NULL").
+		Preload("Assessment").
+		Where("assessment_attempts.student_id = ? AND assessment_attempts.status IN ?", studentID, []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Order("assessment_attempts.created_at ASC").
+		Find(&attempts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get finished attempts: %w", err)
+	}
+	return attempts, nil
+}
+
+func (a *AttemptPostgreSQL) GetAttemptTimings(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]repositories.AttemptTiming, error) {
+	db := a.getDB(tx)
+	var timings []repositories.AttemptTiming
+	// Attempts closed without a completion time, such as abandoned ones, last until their last update
+	if err := db.WithContext(ctx).
+		Model(&models.AssessmentAttempt{}).
+		Select(`started_at,
+			CASE WHEN status = ? THEN NULL ELSE COALESCE(completed_at, updated_at) END AS ended_at,
+			status IN ? AS finished,
+			percentage`,
+			models.AttemptInProgress, []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Where("assessment_id = ? AND started_at IS NOT NULL", assessmentID).
+		Order("started_at ASC").
+		Scan(&timings).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attempt timings: %w", err)
+	}
+	return timings, nil
+}
+
 func (a *AttemptPostgreSQL) CanStartAttempt(ctx context.Context, tx *gorm.DB, studentID string, assessmentID uint) (*repositories.AttemptValidation, error) {
 	return a.helpers.ValidateAttemptEligibility(ctx, assessmentID, studentID)
 }
@@ -557,9 +791,32 @@ func (a *AttemptPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
 	return a.db
 }
 
-// applyPaginationAndSortAttempt applies pagination and sorting to a query
+// applyPaginationAndSortAttempt applies pagination and sorting to a query. The default
+// newest-first order breaks ties by id so that it can be continued with a keyset cursor.
 func (a *AttemptPostgreSQL) applyPaginationAndSortAttempt(query *gorm.DB, filters repositories.AttemptFilters) *gorm.DB {
-	return a.helpers.ApplyPaginationAndSort(query, filters.SortBy, filters.SortOrder, filters.Limit, filters.Offset)
+	if len(filters.Sort) > 0 && filters.After == nil {
+		if filters.Limit > 0 {
+			query = query.Limit(filters.Limit)
+		}
+		if filters.Offset > 0 {
+			query = query.Offset(filters.Offset)
+		}
+		return a.helpers.ApplyAttemptSort(query, filters.Sort)
+	}
+	if filters.SortBy != "" && filters.After == nil {
+		return a.helpers.ApplyPaginationAndSort(query, filters.SortBy, filters.SortOrder, filters.Limit, filters.Offset)
+	}
+
+	if filters.After != nil {
+		query = query.Where("(assessment_attempts.created_at, assessment_attempts.id) < (?, ?)", filters.After.CreatedAt, filters.After.ID)
+	} else if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+
+	return query.Order("assessment_attempts.created_at DESC, assessment_attempts.id DESC")
 }
 
 // ===== ANSWER REPOSITORY IMPLEMENTATION =====
@@ -587,11 +844,11 @@ func (ar *AnswerPostgreSQL) AreAllAnswersGraded(ctx context.Context, tx *gorm.DB
 }
 
 // NewAnswerPostgreSQL creates a new answer repository instance
-func NewAnswerPostgreSQL(db *gorm.DB, redisClient *redis.Client) repositories.AnswerRepository {
+func NewAnswerPostgreSQL(db *gorm.DB, redisClient *cache.Client) repositories.AnswerRepository {
 	return &AnswerPostgreSQL{
 		db:           db,
 		helpers:      NewSharedHelpers(db),
-		cacheManager: cache.NewCacheManager(redisClient),
+		cacheManager: cache.NewCacheManager(redisClient, cache.RepositoryAnswer),
 	}
 }
 
@@ -619,7 +876,7 @@ func (ar *AnswerPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (
 	cacheKey := fmt.Sprintf("answer:id:%d", id)
 	var answer models.StudentAnswer
 
-	err := ar.cacheManager.Fast.CacheOrExecute(ctx, cacheKey, &answer, cache.FastCacheConfig.TTL, func() (interface{}, error) {
+	err := ar.cacheManager.Fast.CacheOrExecute(ctx, cacheKey, &answer, ar.cacheManager.Fast.TTL(), func() (interface{}, error) {
 		var dbAnswer models.StudentAnswer
 		if err := db.WithContext(ctx).First(&dbAnswer, id).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -771,7 +1028,7 @@ func (ar *AnswerPostgreSQL) GetByAttempt(ctx context.Context, tx *gorm.DB, attem
 	cacheKey := fmt.Sprintf("attempt:%d:answers", attemptID)
 	var answers []*models.StudentAnswer
 
-	err := ar.cacheManager.Fast.CacheOrExecute(ctx, cacheKey, &answers, cache.FastCacheConfig.TTL, func() (interface{}, error) {
+	err := ar.cacheManager.Fast.CacheOrExecute(ctx, cacheKey, &answers, ar.cacheManager.Fast.TTL(), func() (interface{}, error) {
 		var dbAnswers []*models.StudentAnswer
 		if err := db.WithContext(ctx).
 			Where("attempt_id = ?", attemptID).
@@ -791,7 +1048,7 @@ func (ar *AnswerPostgreSQL) GetByAttemptAndQuestion(ctx context.Context, tx *gor
 	cacheKey := fmt.Sprintf("attempt:%d:question:%d", attemptID, questionID)
 	var answer models.StudentAnswer
 
-	err := ar.cacheManager.Fast.CacheOrExecute(ctx, cacheKey, &answer, cache.FastCacheConfig.TTL, func() (interface{}, error) {
+	err := ar.cacheManager.Fast.CacheOrExecute(ctx, cacheKey, &answer, ar.cacheManager.Fast.TTL(), func() (interface{}, error) {
 		var dbAnswer models.StudentAnswer
 		if err := db.WithContext(ctx).
 			Where("attempt_id = ? AND question_id = ?", attemptID, questionID).
@@ -841,6 +1098,28 @@ func (ar *AnswerPostgreSQL) GetByStudent(ctx context.Context, tx *gorm.DB, stude
 	return answers, nil
 }
 
+// GetByAssessment retrieves the answers given in an assessment's attempts
+func (ar *AnswerPostgreSQL) GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint, filters repositories.AnswerFilters) ([]*models.StudentAnswer, int64, error) {
+	db := ar.getDB(tx)
+	query := db.WithContext(ctx).
+		Model(&models.StudentAnswer{}).
+		Joins("JOIN assessment_attempts aa ON aa.id = student_answers.attempt_id").
+		Where("aa.assessment_id = ?", assessmentID)
+	query = ar.applyAnswerConditions(query, filters)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count answers by assessment: %w", err)
+	}
+
+	var answers []*models.StudentAnswer
+	if err := ar.applyAnswerPagination(query, filters).Find(&answers).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get answers by assessment: %w", err)
+	}
+
+	return answers, total, nil
+}
+
 // ===== GRADING OPERATIONS =====
 
 // UpdateGrade updates the grade for an answer
@@ -908,14 +1187,42 @@ func (ar *AnswerPostgreSQL) BulkGrade(ctx context.Context, tx *gorm.DB, grades [
 	})
 }
 
-// GetPendingGrading retrieves answers pending manual grading
+// MarkPendingRegrade moves graded answers to pending_regrade
+func (ar *AnswerPostgreSQL) MarkPendingRegrade(ctx context.Context, tx *gorm.DB, answerIDs []uint) (int64, error) {
+	if len(answerIDs) == 0 {
+		return 0, nil
+	}
+
+	db := ar.getDB(tx)
+	result := db.WithContext(ctx).
+		Model(&models.StudentAnswer{}).
+		Where("id IN ? AND grading_status <> ?", answerIDs, models.GradingStatusUngraded).
+		Update("grading_status", models.GradingStatusPendingRegrade)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark answers pending regrade: %w", result.Error)
+	}
+
+	for _, id := range answerIDs {
+		ar.cacheManager.Fast.Delete(ctx, fmt.Sprintf("answer:id:%d", id))
+	}
+
+	return result.RowsAffected, nil
+}
+
+// GetPendingGrading retrieves answers pending manual grading on the teacher's own assessments
+// and on those delegated to them for grading right now
 func (ar *AnswerPostgreSQL) GetPendingGrading(ctx context.Context, tx *gorm.DB, teacherID string) ([]*models.StudentAnswer, error) {
 	db := ar.getDB(tx)
+	now := time.Now()
+	delegated := db.Model(&models.GradingDelegation{}).
+		Select("assessment_id").
+		Where("delegate_id = ? AND revoked_at IS NULL AND starts_at <= ? AND expires_at > ?", teacherID, now, now)
 	var answers []*models.StudentAnswer
 	if err := db.WithContext(ctx).
 		Joins("JOIN assessment_attempts aa ON aa.id = student_answers.attempt_id").
 		Joins("JOIN assessments a ON a.id = aa.assessment_id").
-		Where("a.created_by = ? AND student_answers.graded_at IS NULL", teacherID).
+		Where("(a.created_by = ? OR a.id IN (?))", teacherID, delegated).
+		Where("(student_answers.graded_at IS NULL OR student_answers.grading_status = ?)", models.GradingStatusPendingRegrade).
 		Preload("Attempt").
 		Preload("Question").
 		Find(&answers).Error; err != nil {
@@ -939,6 +1246,153 @@ func (ar *AnswerPostgreSQL) GetGradedAnswers(ctx context.Context, tx *gorm.DB, g
 	return answers, nil
 }
 
+func (ar *AnswerPostgreSQL) GetGraderActivity(ctx context.Context, tx *gorm.DB, graderID string, filter repositories.GraderStatsFilter) ([]repositories.GradedAnswerRecord, error) {
+	db := ar.getDB(tx)
+	query := db.WithContext(ctx).
+		Table("student_answers sa").
+		Select("sa.id AS answer_id, aa.assessment_id, sa.score, sa.max_score, sa.graded_at, aa.completed_at AS submitted_at").
+		Joins("JOIN assessment_attempts aa ON aa.id = sa.attempt_id").
+		Where("sa.graded_by = ? AND sa.graded_at IS NOT NULL", graderID)
+	query = applyGraderStatsScope(query, filter, "aa.assessment_id")
+	if filter.Since != nil {
+		query = query.Where("sa.graded_at >= ?", *filter.Since)
+	}
+
+	var records []repositories.GradedAnswerRecord
+	if err := query.Order("sa.graded_at ASC").Scan(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to get grader activity: %w", err)
+	}
+	return records, nil
+}
+
+func (ar *AnswerPostgreSQL) GetManualGradeTimes(ctx context.Context, tx *gorm.DB, ownerID string, since time.Time, limit int) ([]repositories.ManualGradeRecord, error) {
+	db := ar.getDB(tx)
+	var records []repositories.ManualGradeRecord
+	if err := db.WithContext(ctx).
+		Table("student_answers sa").
+		Select("sa.graded_by AS grader_id, sa.question_id, sa.graded_at").
+		Joins("JOIN assessment_attempts aa ON aa.id = sa.attempt_id").
+		Joins("JOIN assessments a ON a.id = aa.assessment_id").
+		Where("a.created_by = ? AND sa.graded_by IS NOT NULL AND sa.graded_at >= ?", ownerID, since).
+		Order("sa.graded_by ASC, sa.graded_at ASC").
+		Limit(limit).
+		Scan(&records).Error; err != nil {
+		return nil, fmt.Errorf("failed to get manual grade times: %w", err)
+	}
+	return records, nil
+}
+
+func (ar *AnswerPostgreSQL) CountGradingBacklog(ctx context.Context, tx *gorm.DB, graderID string, filter repositories.GraderStatsFilter) (int, error) {
+	db := ar.getDB(tx)
+	query := db.WithContext(ctx).
+		Table("student_answers sa").
+		Joins("JOIN assessment_attempts aa ON aa.id = sa.attempt_id").
+		Where("sa.graded_at IS NULL").
+		Where("aa.status IN ?", []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Where(`aa.assessment_id IN (SELECT id FROM assessments WHERE created_by = ?)
+			OR aa.assessment_id IN (
+				SELECT ga.assessment_id FROM student_answers gs
+				JOIN assessment_attempts ga ON ga.id = gs.attempt_id
+				WHERE gs.graded_by = ?)`, graderID, graderID)
+	query = applyGraderStatsScope(query, filter, "aa.assessment_id")
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count grading backlog: %w", err)
+	}
+	return int(count), nil
+}
+
+func (ar *AnswerPostgreSQL) GetGraderBacklogs(ctx context.Context, tx *gorm.DB, minBacklog int) (map[string]int, error) {
+	db := ar.getDB(tx)
+
+	var rows []struct {
+		GraderID string
+		Backlog  int
+	}
+	if err := db.WithContext(ctx).Raw(`
+		WITH grader_assessments AS (
+			SELECT created_by AS grader_id, id AS assessment_id FROM assessments
+			UNION
+			SELECT gs.graded_by, ga.assessment_id FROM student_answers gs
+			JOIN assessment_attempts ga ON ga.id = gs.attempt_id
+			WHERE gs.graded_by IS NOT NULL
+		), pending AS (
+			SELECT aa.assessment_id, COUNT(*) AS answers FROM student_answers sa
+			JOIN assessment_attempts aa ON aa.id = sa.attempt_id
+			WHERE sa.graded_at IS NULL AND aa.status IN ?
+			GROUP BY aa.assessment_id
+		)
+		SELECT g.grader_id, SUM(p.answers) AS backlog
+		FROM grader_assessments g
+		JOIN pending p ON p.assessment_id = g.assessment_id
+		GROUP BY g.grader_id
+		HAVING SUM(p.answers) > ?`,
+		[]models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}, minBacklog).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get grader backlogs: %w", err)
+	}
+
+	backlogs := make(map[string]int, len(rows))
+	for _, row := range rows {
+		backlogs[row.GraderID] = row.Backlog
+	}
+	return backlogs, nil
+}
+
+// feedbackHeadlineOptions keeps search snippets to a couple of short fragments
+const feedbackHeadlineOptions = "MaxFragments=2, MaxWords=25, MinWords=8, FragmentDelimiter=\" ... \""
+
+func (ar *AnswerPostgreSQL) SearchFeedback(ctx context.Context, tx *gorm.DB, graderID string, filters repositories.FeedbackSearchFilters) ([]repositories.FeedbackSearchHit, int64, error) {
+	db := ar.getDB(tx).WithContext(ctx)
+	const tsQuery = "websearch_to_tsquery('english', ?)"
+
+	answers := db.Table("student_answers sa").
+		Select(`'answer' AS source, sa.id AS answer_id, NULL::bigint AS annotation_id, sa.attempt_id,
+			aa.assessment_id, a.title AS assessment_title, sa.question_id, q.type AS question_type,
+			q.text AS question_text, sa.feedback AS feedback,
+			ts_headline('english', sa.feedback, `+tsQuery+`, ?) AS snippet,
+			ts_rank(sa.feedback_search, `+tsQuery+`) AS rank, sa.graded_at AS written_at`,
+			filters.Query, feedbackHeadlineOptions, filters.Query).
+		Joins("JOIN assessment_attempts aa ON aa.id = sa.attempt_id").
+		Joins("JOIN assessments a ON a.id = aa.assessment_id").
+		Joins("JOIN questions q ON q.id = sa.question_id").
+		Where("sa.graded_by = ?", graderID).
+		Where("sa.feedback_search @@ "+tsQuery, filters.Query)
+	answers = applyFeedbackSearchFilters(answers, filters, "sa.graded_at")
+
+	annotations := db.Table("answer_annotations an").
+		Select(`'annotation' AS source, an.answer_id, an.id AS annotation_id, an.attempt_id,
+			aa.assessment_id, a.title AS assessment_title, sa.question_id, q.type AS question_type,
+			q.text AS question_text, an.comment AS feedback,
+			ts_headline('english', an.comment, `+tsQuery+`, ?) AS snippet,
+			ts_rank(an.comment_search, `+tsQuery+`) AS rank, an.created_at AS written_at`,
+			filters.Query, feedbackHeadlineOptions, filters.Query).
+		Joins("JOIN student_answers sa ON sa.id = an.answer_id").
+		Joins("JOIN assessment_attempts aa ON aa.id = an.attempt_id").
+		Joins("JOIN assessments a ON a.id = aa.assessment_id").
+		Joins("JOIN questions q ON q.id = sa.question_id").
+		Where("an.created_by = ?", graderID).
+		Where("an.comment_search @@ "+tsQuery, filters.Query)
+	annotations = applyFeedbackSearchFilters(annotations, filters, "an.created_at")
+
+	hits := db.Table("((?) UNION ALL (?)) AS hits", answers, annotations)
+
+	var total int64
+	if err := hits.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count feedback search results: %w", err)
+	}
+
+	var results []repositories.FeedbackSearchHit
+	if err := hits.Order("rank DESC, written_at DESC").
+		Limit(filters.Limit).
+		Offset(filters.Offset).
+		Scan(&results).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to search feedback: %w", err)
+	}
+	return results, total, nil
+}
+
 // ===== ANSWER TRACKING =====
 
 // UpdateAnswerHistory updates the history of answer changes
@@ -986,7 +1440,10 @@ func (ar *AnswerPostgreSQL) FlagAnswer(ctx context.Context, tx *gorm.DB, id uint
 	if err := db.WithContext(ctx).
 		Model(&models.StudentAnswer{}).
 		Where("id = ?", id).
-		Update("is_flagged", flagged).Error; err != nil {
+		Updates(map[string]interface{}{
+			"flagged":    flagged,
+			"flagged_at": flaggedAt(flagged),
+		}).Error; err != nil {
 		return fmt.Errorf("failed to flag answer: %w", err)
 	}
 
@@ -996,6 +1453,54 @@ func (ar *AnswerPostgreSQL) FlagAnswer(ctx context.Context, tx *gorm.DB, id uint
 	return nil
 }
 
+func flaggedAt(flagged bool) *time.Time {
+	if !flagged {
+		return nil
+	}
+	now := time.Now()
+	return &now
+}
+
+// SetLanguage records the language detected in an answer and whether it was unexpected
+func (ar *AnswerPostgreSQL) SetLanguage(ctx context.Context, tx *gorm.DB, id uint, language *string, mismatch bool) error {
+	db := ar.getDB(tx)
+	if err := db.WithContext(ctx).
+		Model(&models.StudentAnswer{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"detected_language": language,
+			"language_mismatch": mismatch,
+		}).Error; err != nil {
+		return fmt.Errorf("failed to set answer language: %w", err)
+	}
+
+	// Invalidate cache
+	ar.cacheManager.Fast.Delete(ctx, fmt.Sprintf("answer:id:%d", id))
+
+	return nil
+}
+
+// MarkSpellCheckShown records that spelling suggestions were shown on an answer
+func (ar *AnswerPostgreSQL) MarkSpellCheckShown(ctx context.Context, tx *gorm.DB, answer *models.StudentAnswer) error {
+	db := ar.getDB(tx)
+	if err := db.WithContext(ctx).
+		Model(&models.StudentAnswer{}).
+		Where("id = ?", answer.ID).
+		Update("spell_check_shown", true).Error; err != nil {
+		return fmt.Errorf("failed to mark spell check shown: %w", err)
+	}
+	answer.SpellCheckShown = true
+
+	// Invalidate caches; answer saves start from the cached answer and would clear the mark
+	ar.cacheManager.Fast.Delete(ctx,
+		fmt.Sprintf("answer:id:%d", answer.ID),
+		fmt.Sprintf("attempt:%d:answers", answer.AttemptID),
+		fmt.Sprintf("attempt:%d:question:%d", answer.AttemptID, answer.QuestionID),
+	)
+
+	return nil
+}
+
 // GetFlaggedAnswers retrieves flagged answers for an attempt
 func (ar *AnswerPostgreSQL) GetFlaggedAnswers(ctx context.Context, tx *gorm.DB, attemptID uint) ([]*models.StudentAnswer, error) {
 	db := ar.getDB(tx)
@@ -1009,6 +1514,28 @@ func (ar *AnswerPostgreSQL) GetFlaggedAnswers(ctx context.Context, tx *gorm.DB,
 	return answers, nil
 }
 
+// GetTrialVariants maps each question a question trial swapped in the attempt to the revision served
+func (ar *AnswerPostgreSQL) GetTrialVariants(ctx context.Context, tx *gorm.DB, attemptID uint) (map[uint]uint, error) {
+	db := ar.getDB(tx)
+	var rows []struct {
+		QuestionID        uint
+		VariantQuestionID uint
+	}
+	if err := db.WithContext(ctx).
+		Model(&models.StudentAnswer{}).
+		Select("question_id, variant_question_id").
+		Where("attempt_id = ? AND variant_question_id IS NOT NULL", attemptID).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get trial variants: %w", err)
+	}
+
+	variants := make(map[uint]uint, len(rows))
+	for _, row := range rows {
+		variants[row.QuestionID] = row.VariantQuestionID
+	}
+	return variants, nil
+}
+
 // ===== TIME TRACKING =====
 
 // UpdateTimeSpent updates the time spent on an answer
@@ -1241,17 +1768,42 @@ func (ar *AnswerPostgreSQL) GetGradingStats(ctx context.Context, tx *gorm.DB, as
 	stats.AutoGraded = int(autoGraded)
 	stats.ManualGraded = int(gradedAnswers - autoGraded)
 
-	// Get average score
-	var avgScore float64
+	// Get average score and the time of the latest grade
+	var graded struct {
+		AvgScore     *float64
+		LastGradedAt *time.Time
+	}
 	if err := db.WithContext(ctx).
 		Table("student_answers sa").
 		Joins("JOIN assessment_attempts aa ON aa.id = sa.attempt_id").
 		Where("aa.assessment_id = ? AND sa.graded_at IS NOT NULL", assessmentID).
-		Select("AVG(sa.score)").
-		Scan(&avgScore).Error; err != nil {
+		Select("AVG(sa.score) AS avg_score, MAX(sa.graded_at) AS last_graded_at").
+		Scan(&graded).Error; err != nil {
 		return nil, fmt.Errorf("failed to get average score: %w", err)
 	}
-	stats.AverageScore = avgScore
+	if graded.AvgScore != nil {
+		stats.AverageScore = *graded.AvgScore
+	}
+	stats.LastGradedAt = graded.LastGradedAt
+
+	// Count answers per grading state
+	var statusCounts []struct {
+		GradingStatus models.AnswerGradingStatus
+		Count         int
+	}
+	if err := db.WithContext(ctx).
+		Table("student_answers sa").
+		Joins("JOIN assessment_attempts aa ON aa.id = sa.attempt_id").
+		Where("aa.assessment_id = ?", assessmentID).
+		Select("sa.grading_status, COUNT(*) AS count").
+		Group("sa.grading_status").
+		Scan(&statusCounts).Error; err != nil {
+		return nil, fmt.Errorf("failed to count answers by grading status: %w", err)
+	}
+	stats.ByStatus = make(map[models.AnswerGradingStatus]int, len(statusCounts))
+	for _, row := range statusCounts {
+		stats.ByStatus[row.GradingStatus] = row.Count
+	}
 
 	return stats, nil
 }
@@ -1278,7 +1830,7 @@ func (ar *AnswerPostgreSQL) HasAnswer(ctx context.Context, tx *gorm.DB, attemptI
 
 	hasAnswer := count > 0
 	// Cache with short TTL
-	ar.cacheManager.Exists.SetString(ctx, cacheKey, fmt.Sprintf("%t", hasAnswer), cache.ExistsCacheConfig.TTL)
+	ar.cacheManager.Exists.SetString(ctx, cacheKey, fmt.Sprintf("%t", hasAnswer), ar.cacheManager.Exists.TTL())
 
 	return hasAnswer, nil
 }
@@ -1343,8 +1895,13 @@ func (ar *AnswerPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
 	return ar.db
 }
 
-// applyAnswerFilters applies common answer filters to a query
+// applyAnswerFilters applies common answer filters and pagination to a query
 func (ar *AnswerPostgreSQL) applyAnswerFilters(query *gorm.DB, filters repositories.AnswerFilters) *gorm.DB {
+	return ar.applyAnswerPagination(ar.applyAnswerConditions(query, filters), filters)
+}
+
+// applyAnswerConditions narrows a query to the answers matching the filters
+func (ar *AnswerPostgreSQL) applyAnswerConditions(query *gorm.DB, filters repositories.AnswerFilters) *gorm.DB {
 	if filters.IsGraded != nil {
 		if *filters.IsGraded {
 			query = query.Where("graded_at IS NOT NULL")
@@ -1355,20 +1912,31 @@ func (ar *AnswerPostgreSQL) applyAnswerFilters(query *gorm.DB, filters repositor
 	if filters.GradedBy != nil {
 		query = query.Where("graded_by = ?", *filters.GradedBy)
 	}
+	if len(filters.GradingStatuses) > 0 {
+		query = query.Where("student_answers.grading_status IN ?", filters.GradingStatuses)
+	}
 	if filters.DateFrom != nil {
 		query = query.Where("created_at >= ?", *filters.DateFrom)
 	}
 	if filters.DateTo != nil {
 		query = query.Where("created_at <= ?", *filters.DateTo)
 	}
+	return query
+}
 
-	// Apply pagination
-	if filters.Limit > 0 {
-		query = query.Limit(filters.Limit)
+// applyAnswerPagination pages a query; paged listings run oldest first so they can continue from a cursor
+func (ar *AnswerPostgreSQL) applyAnswerPagination(query *gorm.DB, filters repositories.AnswerFilters) *gorm.DB {
+	if filters.Limit > 0 || filters.After != nil {
+		query = query.Order("student_answers.created_at ASC, student_answers.id ASC")
 	}
-	if filters.Offset > 0 {
+	if filters.After != nil {
+		query = query.Where("(student_answers.created_at, student_answers.id) > (?, ?)", filters.After.CreatedAt, filters.After.ID)
+	} else if filters.Offset > 0 {
 		query = query.Offset(filters.Offset)
 	}
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
 
 	return query
 }
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// Namespace of the advisory locks taken per assessment
+const attemptQueueLockClass = 1184
+
+type AttemptQueuePostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewAttemptQueuePostgreSQL(db *gorm.DB) repositories.AttemptQueueRepository {
+	return &AttemptQueuePostgreSQL{db: db}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (r *AttemptQueuePostgreSQL) Create(ctx context.Context, tx *gorm.DB, entry *models.AttemptQueueEntry) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to create attempt queue entry: %w", err)
+	}
+	return nil
+}
+
+func (r *AttemptQueuePostgreSQL) GetByStudent(ctx context.Context, tx *gorm.DB, assessmentID uint, studentID string) (*models.AttemptQueueEntry, error) {
+	db := r.getDB(tx)
+	var entry models.AttemptQueueEntry
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ? AND student_id = ?", assessmentID, studentID).
+		First(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *AttemptQueuePostgreSQL) Update(ctx context.Context, tx *gorm.DB, entry *models.AttemptQueueEntry) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(entry).Error; err != nil {
+		return fmt.Errorf("failed to update attempt queue entry: %w", err)
+	}
+	return nil
+}
+
+func (r *AttemptQueuePostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Delete(&models.AttemptQueueEntry{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete attempt queue entry: %w", err)
+	}
+	return nil
+}
+
+func (r *AttemptQueuePostgreSQL) LockAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(?, ?)", attemptQueueLockClass, int32(assessmentID)).Error; err != nil {
+		return fmt.Errorf("failed to lock assessment slots: %w", err)
+	}
+	return nil
+}
+
+// ===== QUEUE OPERATIONS =====
+
+func (r *AttemptQueuePostgreSQL) GetWaiting(ctx context.Context, tx *gorm.DB, assessmentID uint, limit int) ([]*models.AttemptQueueEntry, error) {
+	db := r.getDB(tx)
+	var entries []*models.AttemptQueueEntry
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ? AND status = ?", assessmentID, models.AttemptQueueWaiting).
+		Order("id ASC").
+		Limit(limit).
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get waiting students: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *AttemptQueuePostgreSQL) CountWaiting(ctx context.Context, tx *gorm.DB, assessmentID uint) (int, error) {
+	db := r.getDB(tx)
+	var count int64
+	if err := db.WithContext(ctx).
+		Model(&models.AttemptQueueEntry{}).
+		Where("assessment_id = ? AND status = ?", assessmentID, models.AttemptQueueWaiting).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count waiting students: %w", err)
+	}
+	return int(count), nil
+}
+
+func (r *AttemptQueuePostgreSQL) CountWaitingAhead(ctx context.Context, tx *gorm.DB, assessmentID uint, entryID uint) (int, error) {
+	db := r.getDB(tx)
+	var count int64
+	if err := db.WithContext(ctx).
+		Model(&models.AttemptQueueEntry{}).
+		Where("assessment_id = ? AND status = ? AND id < ?", assessmentID, models.AttemptQueueWaiting, entryID).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count students ahead: %w", err)
+	}
+	return int(count), nil
+}
+
+func (r *AttemptQueuePostgreSQL) CountHeld(ctx context.Context, tx *gorm.DB, assessmentID uint, now time.Time) (int, error) {
+	db := r.getDB(tx)
+	var count int64
+	if err := db.WithContext(ctx).
+		Model(&models.AttemptQueueEntry{}).
+		Where("assessment_id = ? AND status = ? AND admitted_until > ?", assessmentID, models.AttemptQueueAdmitted, now).
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count held slots: %w", err)
+	}
+	return int(count), nil
+}
+
+func (r *AttemptQueuePostgreSQL) DeleteExpiredHolds(ctx context.Context, tx *gorm.DB, assessmentID uint, now time.Time) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ? AND status = ? AND admitted_until <= ?", assessmentID, models.AttemptQueueAdmitted, now).
+		Delete(&models.AttemptQueueEntry{}).Error; err != nil {
+		return fmt.Errorf("failed to delete lapsed admissions: %w", err)
+	}
+	return nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *AttemptQueuePostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// Namespace of the advisory locks taken per student and quota
+const attemptQuotaLockClass = 1239
+
+type AttemptQuotaPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewAttemptQuotaPostgreSQL(db *gorm.DB) repositories.AttemptQuotaRepository {
+	return &AttemptQuotaPostgreSQL{db: db}
+}
+
+func (r *AttemptQuotaPostgreSQL) Create(ctx context.Context, tx *gorm.DB, quota *models.AttemptQuota) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(quota).Error; err != nil {
+		return fmt.Errorf("failed to create attempt quota: %w", err)
+	}
+	return nil
+}
+
+func (r *AttemptQuotaPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AttemptQuota, error) {
+	db := r.getDB(tx)
+	var quota models.AttemptQuota
+	if err := db.WithContext(ctx).First(&quota, id).Error; err != nil {
+		return nil, err
+	}
+	return &quota, nil
+}
+
+func (r *AttemptQuotaPostgreSQL) Update(ctx context.Context, tx *gorm.DB, quota *models.AttemptQuota) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(quota).Error; err != nil {
+		return fmt.Errorf("failed to update attempt quota: %w", err)
+	}
+	return nil
+}
+
+func (r *AttemptQuotaPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Delete(&models.AttemptQuota{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete attempt quota: %w", err)
+	}
+	return nil
+}
+
+func (r *AttemptQuotaPostgreSQL) List(ctx context.Context, tx *gorm.DB) ([]*models.AttemptQuota, error) {
+	db := r.getDB(tx)
+	var quotas []*models.AttemptQuota
+	if err := db.WithContext(ctx).Order("name ASC, id ASC").Find(&quotas).Error; err != nil {
+		return nil, fmt.Errorf("failed to list attempt quotas: %w", err)
+	}
+	return quotas, nil
+}
+
+func (r *AttemptQuotaPostgreSQL) GetActive(ctx context.Context, tx *gorm.DB, assessmentID *uint) ([]*models.AttemptQuota, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).Where("is_active = ?", true)
+	if assessmentID != nil {
+		query = query.Where("bank_id IN (?)", db.Table("question_bank_questions qbq").
+			Select("qbq.question_bank_id").
+			Joins("INNER JOIN assessment_questions aq ON aq.question_id = qbq.question_id").
+			Where("aq.assessment_id = ?", *assessmentID))
+	}
+	var quotas []*models.AttemptQuota
+	if err := query.Order("name ASC, id ASC").Find(&quotas).Error; err != nil {
+		return nil, fmt.Errorf("failed to get active attempt quotas: %w", err)
+	}
+	return quotas, nil
+}
+
+func (r *AttemptQuotaPostgreSQL) GetAttemptStarts(ctx context.Context, tx *gorm.DB, studentID string, bankID uint, since time.Time) ([]time.Time, error) {
+	db := r.getDB(tx)
+	var starts []time.Time
+	if err := db.WithContext(ctx).
+		Model(&models.AssessmentAttempt{}).
+		Where("student_id = ? AND started_at >= ?", studentID, since).
+		Where("assessment_id IN (?)", db.Table("assessment_questions aq").
+			Select("aq.assessment_id").
+			Joins("INNER JOIN question_bank_questions qbq ON qbq.question_id = aq.question_id").
+			Where("qbq.question_bank_id = ?", bankID)).
+		Order("started_at ASC").
+		Pluck("started_at", &starts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attempt starts: %w", err)
+	}
+	return starts, nil
+}
+
+func (r *AttemptQuotaPostgreSQL) LockStudent(ctx context.Context, tx *gorm.DB, quotaID uint, studentID string) error {
+	db := r.getDB(tx)
+	key := fmt.Sprintf("%d:%s", quotaID, studentID)
+	if err := db.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(?, hashtext(?))", attemptQuotaLockClass, key).Error; err != nil {
+		return fmt.Errorf("failed to lock attempt quota: %w", err)
+	}
+	return nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *AttemptQuotaPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
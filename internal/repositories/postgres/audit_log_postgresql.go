@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type AuditLogPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewAuditLogPostgreSQL(db *gorm.DB) repositories.AuditLogRepository {
+	return &AuditLogPostgreSQL{db: db}
+}
+
+// ===== BASIC OPERATIONS =====
+
+func (r *AuditLogPostgreSQL) Create(ctx context.Context, tx *gorm.DB, entry *models.AuditLog) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(entry).Error; err != nil {
+		return fmt.Errorf("failed to create audit log: %w", err)
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (r *AuditLogPostgreSQL) GetByTarget(ctx context.Context, tx *gorm.DB, targetType string, targetID uint) ([]*models.AuditLog, error) {
+	db := r.getDB(tx)
+	var entries []*models.AuditLog
+	if err := db.WithContext(ctx).
+		Where("target_type = ? AND target_id = ?", targetType, targetID).
+		Order("created_at DESC").
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get audit logs: %w", err)
+	}
+	return entries, nil
+}
+
+func (r *AuditLogPostgreSQL) GetByTargets(ctx context.Context, tx *gorm.DB, targetType string, targetIDs []uint) ([]*models.AuditLog, error) {
+	if len(targetIDs) == 0 {
+		return nil, nil
+	}
+	db := r.getDB(tx)
+	var entries []*models.AuditLog
+	if err := db.WithContext(ctx).
+		Where("target_type = ? AND target_id IN ?", targetType, targetIDs).
+		Order("created_at DESC").
+		Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get audit logs: %w", err)
+	}
+	return entries, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *AuditLogPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
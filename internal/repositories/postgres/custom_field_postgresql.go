@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type CustomFieldPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewCustomFieldPostgreSQL(db *gorm.DB) repositories.CustomFieldRepository {
+	return &CustomFieldPostgreSQL{db: db}
+}
+
+func (r *CustomFieldPostgreSQL) Create(ctx context.Context, tx *gorm.DB, definition *models.CustomFieldDefinition) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(definition).Error; err != nil {
+		return fmt.Errorf("failed to create custom field definition: %w", err)
+	}
+	return nil
+}
+
+func (r *CustomFieldPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.CustomFieldDefinition, error) {
+	db := r.getDB(tx)
+	var definition models.CustomFieldDefinition
+	if err := db.WithContext(ctx).First(&definition, id).Error; err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func (r *CustomFieldPostgreSQL) Update(ctx context.Context, tx *gorm.DB, definition *models.CustomFieldDefinition) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(definition).Error; err != nil {
+		return fmt.Errorf("failed to update custom field definition: %w", err)
+	}
+	return nil
+}
+
+func (r *CustomFieldPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Delete(&models.CustomFieldDefinition{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete custom field definition: %w", err)
+	}
+	return nil
+}
+
+func (r *CustomFieldPostgreSQL) List(ctx context.Context, tx *gorm.DB, organization string, entity *models.CustomFieldEntity) ([]*models.CustomFieldDefinition, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).Where("organization = ?", organization)
+	if entity != nil {
+		query = query.Where("entity = ?", *entity)
+	}
+	var definitions []*models.CustomFieldDefinition
+	if err := query.Order("entity ASC, key ASC").Find(&definitions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list custom field definitions: %w", err)
+	}
+	return definitions, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *CustomFieldPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
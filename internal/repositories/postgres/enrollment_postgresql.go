@@ -0,0 +1,199 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EnrollmentPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewEnrollmentPostgreSQL(db *gorm.DB) repositories.EnrollmentRepository {
+	return &EnrollmentPostgreSQL{db: db}
+}
+
+// ===== ENROLLMENT =====
+
+func (r *EnrollmentPostgreSQL) Enroll(ctx context.Context, tx *gorm.DB, enrollments []*models.AssessmentEnrollment) error {
+	if len(enrollments) == 0 {
+		return nil
+	}
+
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "assessment_id"}, {Name: "student_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"class_id"}),
+		}).
+		CreateInBatches(enrollments, 100).Error; err != nil {
+		return fmt.Errorf("failed to enroll students: %w", err)
+	}
+	return nil
+}
+
+func (r *EnrollmentPostgreSQL) Unenroll(ctx context.Context, tx *gorm.DB, assessmentID uint, studentID string) error {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Where("assessment_id = ? AND student_id = ?", assessmentID, studentID).
+		Delete(&models.AssessmentEnrollment{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to unenroll student: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *EnrollmentPostgreSQL) GetEnrollment(ctx context.Context, tx *gorm.DB, assessmentID uint, studentID string) (*models.AssessmentEnrollment, error) {
+	db := r.getDB(tx)
+	var enrollment models.AssessmentEnrollment
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ? AND student_id = ?", assessmentID, studentID).
+		First(&enrollment).Error; err != nil {
+		return nil, err
+	}
+	return &enrollment, nil
+}
+
+func (r *EnrollmentPostgreSQL) GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentEnrollment, error) {
+	db := r.getDB(tx)
+	var enrollments []*models.AssessmentEnrollment
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ?", assessmentID).
+		Order("created_at ASC").
+		Find(&enrollments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get enrollments: %w", err)
+	}
+	return enrollments, nil
+}
+
+func (r *EnrollmentPostgreSQL) GetByStudent(ctx context.Context, tx *gorm.DB, studentID string) ([]*models.AssessmentEnrollment, error) {
+	db := r.getDB(tx)
+	var enrollments []*models.AssessmentEnrollment
+	if err := db.WithContext(ctx).
+		Where("student_id = ?", studentID).
+		Order("created_at ASC").
+		Find(&enrollments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get student enrollments: %w", err)
+	}
+	return enrollments, nil
+}
+
+func (r *EnrollmentPostgreSQL) GetStudentIDs(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]string, error) {
+	db := r.getDB(tx)
+	var studentIDs []string
+	if err := db.WithContext(ctx).
+		Model(&models.AssessmentEnrollment{}).
+		Where("assessment_id = ?", assessmentID).
+		Pluck("student_id", &studentIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get enrolled students: %w", err)
+	}
+	return studentIDs, nil
+}
+
+// ===== CLASS OVERRIDES =====
+
+func (r *EnrollmentPostgreSQL) SaveClassOverride(ctx context.Context, tx *gorm.DB, override *models.AssessmentClassOverride) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "assessment_id"}, {Name: "class_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"due_date", "available_from", "available_until", "max_attempts", "updated_by", "updated_at",
+			}),
+		}).
+		Create(override).Error; err != nil {
+		return fmt.Errorf("failed to save class override: %w", err)
+	}
+	return nil
+}
+
+func (r *EnrollmentPostgreSQL) GetClassOverride(ctx context.Context, tx *gorm.DB, assessmentID uint, classID string) (*models.AssessmentClassOverride, error) {
+	db := r.getDB(tx)
+	var override models.AssessmentClassOverride
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ? AND class_id = ?", assessmentID, classID).
+		First(&override).Error; err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+func (r *EnrollmentPostgreSQL) GetClassOverrides(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentClassOverride, error) {
+	db := r.getDB(tx)
+	var overrides []*models.AssessmentClassOverride
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ?", assessmentID).
+		Order("class_id ASC").
+		Find(&overrides).Error; err != nil {
+		return nil, fmt.Errorf("failed to get class overrides: %w", err)
+	}
+	return overrides, nil
+}
+
+func (r *EnrollmentPostgreSQL) DeleteClassOverride(ctx context.Context, tx *gorm.DB, assessmentID uint, classID string) error {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Where("assessment_id = ? AND class_id = ?", assessmentID, classID).
+		Delete(&models.AssessmentClassOverride{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete class override: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ===== ACCESS TRACKING =====
+
+func (r *EnrollmentPostgreSQL) RecordView(ctx context.Context, tx *gorm.DB, assessmentID uint, studentID string, viewedAt time.Time) error {
+	db := r.getDB(tx)
+	view := &models.AssessmentView{
+		AssessmentID:  assessmentID,
+		StudentID:     studentID,
+		ViewCount:     1,
+		FirstViewedAt: viewedAt,
+		LastViewedAt:  viewedAt,
+	}
+	if err := db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "assessment_id"}, {Name: "student_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"view_count":     gorm.Expr("assessment_views.view_count + 1"),
+				"last_viewed_at": viewedAt,
+			}),
+		}).
+		Create(view).Error; err != nil {
+		return fmt.Errorf("failed to record assessment view: %w", err)
+	}
+	return nil
+}
+
+func (r *EnrollmentPostgreSQL) GetViews(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentView, error) {
+	db := r.getDB(tx)
+	var views []*models.AssessmentView
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ?", assessmentID).
+		Find(&views).Error; err != nil {
+		return nil, fmt.Errorf("failed to get assessment views: %w", err)
+	}
+	return views, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *EnrollmentPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
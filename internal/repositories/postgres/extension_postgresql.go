@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type ExtensionPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewExtensionPostgreSQL(db *gorm.DB) repositories.ExtensionRepository {
+	return &ExtensionPostgreSQL{db: db}
+}
+
+// ===== REQUESTS =====
+
+func (r *ExtensionPostgreSQL) Create(ctx context.Context, tx *gorm.DB, request *models.ExtensionRequest) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(request).Error; err != nil {
+		return fmt.Errorf("failed to create extension request: %w", err)
+	}
+	return nil
+}
+
+func (r *ExtensionPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.ExtensionRequest, error) {
+	db := r.getDB(tx)
+	var request models.ExtensionRequest
+	if err := db.WithContext(ctx).Preload("Assessment").First(&request, id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (r *ExtensionPostgreSQL) Update(ctx context.Context, tx *gorm.DB, request *models.ExtensionRequest) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Assessment").Save(request).Error; err != nil {
+		return fmt.Errorf("failed to update extension request: %w", err)
+	}
+	return nil
+}
+
+func (r *ExtensionPostgreSQL) List(ctx context.Context, tx *gorm.DB, filter repositories.ExtensionRequestFilter) ([]*models.ExtensionRequest, int64, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).Model(&models.ExtensionRequest{})
+
+	if filter.AssessmentID != nil {
+		query = query.Where("extension_requests.assessment_id = ?", *filter.AssessmentID)
+	}
+	if filter.StudentID != "" {
+		query = query.Where("extension_requests.student_id = ?", filter.StudentID)
+	}
+	if filter.CreatorID != "" {
+		query = query.Joins("JOIN assessments a ON a.id = extension_requests.assessment_id").
+			Where("a.created_by = ?", filter.CreatorID)
+	}
+	if filter.Status != "" {
+		query = query.Where("extension_requests.status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count extension requests: %w", err)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+
+	var requests []*models.ExtensionRequest
+	if err := query.Preload("Assessment").
+		Order("extension_requests.created_at ASC, extension_requests.id ASC").
+		Find(&requests).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list extension requests: %w", err)
+	}
+	return requests, total, nil
+}
+
+func (r *ExtensionPostgreSQL) HasPending(ctx context.Context, tx *gorm.DB, assessmentID uint, studentID string, kind models.ExtensionRequestKind) (bool, error) {
+	db := r.getDB(tx)
+	var count int64
+	if err := db.WithContext(ctx).
+		Model(&models.ExtensionRequest{}).
+		Where("assessment_id = ? AND student_id = ? AND kind = ? AND status = ?",
+			assessmentID, studentID, kind, models.ExtensionPending).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check pending extension requests: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ===== ACCOMMODATIONS =====
+
+func (r *ExtensionPostgreSQL) CreateAccommodation(ctx context.Context, tx *gorm.DB, accommodation *models.StudentAccommodation) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(accommodation).Error; err != nil {
+		return fmt.Errorf("failed to create accommodation: %w", err)
+	}
+	return nil
+}
+
+func (r *ExtensionPostgreSQL) GetAccommodations(ctx context.Context, tx *gorm.DB, assessmentID uint, studentID string) ([]*models.StudentAccommodation, error) {
+	db := r.getDB(tx)
+	var accommodations []*models.StudentAccommodation
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ? AND student_id = ?", assessmentID, studentID).
+		Order("id ASC").
+		Find(&accommodations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get accommodations: %w", err)
+	}
+	return accommodations, nil
+}
+
+func (r *ExtensionPostgreSQL) GetAccommodationsByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.StudentAccommodation, error) {
+	db := r.getDB(tx)
+	var accommodations []*models.StudentAccommodation
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ?", assessmentID).
+		Order("student_id ASC, id ASC").
+		Find(&accommodations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get accommodations: %w", err)
+	}
+	return accommodations, nil
+}
+
+// Helper methods
+
+func (r *ExtensionPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
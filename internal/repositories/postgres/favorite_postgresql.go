@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type FavoritePostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewFavoritePostgreSQL(db *gorm.DB) repositories.FavoriteRepository {
+	return &FavoritePostgreSQL{db: db}
+}
+
+// ===== BASIC OPERATIONS =====
+
+func (r *FavoritePostgreSQL) Create(ctx context.Context, tx *gorm.DB, favorite *models.Favorite) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(favorite).Error; err != nil {
+		return fmt.Errorf("failed to create favorite: %w", err)
+	}
+	return nil
+}
+
+func (r *FavoritePostgreSQL) Delete(ctx context.Context, tx *gorm.DB, userID string, itemType models.FavoriteItemType, itemID uint) error {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Where("user_id = ? AND item_type = ? AND item_id = ?", userID, itemType, itemID).
+		Delete(&models.Favorite{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete favorite: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *FavoritePostgreSQL) Exists(ctx context.Context, tx *gorm.DB, userID string, itemType models.FavoriteItemType, itemID uint) (bool, error) {
+	db := r.getDB(tx)
+	var count int64
+	if err := db.WithContext(ctx).
+		Model(&models.Favorite{}).
+		Where("user_id = ? AND item_type = ? AND item_id = ?", userID, itemType, itemID).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check favorite: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (r *FavoritePostgreSQL) GetEntries(ctx context.Context, tx *gorm.DB, userID string, itemType *models.FavoriteItemType) ([]repositories.FavoriteEntry, error) {
+	db := r.getDB(tx)
+	var entries []repositories.FavoriteEntry
+
+	query := db.WithContext(ctx).
+		Table("favorites f").
+		Select("f.id, f.item_type, f.item_id, COALESCE(q.text, a.title) as title, f.note, f.created_at").
+		Joins("LEFT JOIN questions q ON f.item_type = ? AND q.id = f.item_id AND q.deleted_at IS NULL", models.FavoriteQuestion).
+		Joins("LEFT JOIN assessments a ON f.item_type = ? AND a.id = f.item_id AND a.deleted_at IS NULL", models.FavoriteAssessment).
+		Where("f.user_id = ?", userID).
+		Where("q.id IS NOT NULL OR a.id IS NOT NULL")
+	if itemType != nil {
+		query = query.Where("f.item_type = ?", *itemType)
+	}
+
+	if err := query.Order("f.created_at DESC, f.id DESC").Scan(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to get favorites: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *FavoritePostgreSQL) GetFavoritedIDs(ctx context.Context, tx *gorm.DB, userID string, itemType models.FavoriteItemType, itemIDs []uint) (map[uint]bool, error) {
+	favorited := make(map[uint]bool)
+	if len(itemIDs) == 0 {
+		return favorited, nil
+	}
+
+	db := r.getDB(tx)
+	var ids []uint
+	if err := db.WithContext(ctx).
+		Model(&models.Favorite{}).
+		Where("user_id = ? AND item_type = ? AND item_id IN ?", userID, itemType, itemIDs).
+		Pluck("item_id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to get favorited items: %w", err)
+	}
+
+	for _, id := range ids {
+		favorited[id] = true
+	}
+	return favorited, nil
+}
+
+// ===== SHARING OPERATIONS =====
+
+func (r *FavoritePostgreSQL) Share(ctx context.Context, tx *gorm.DB, share *models.FavoriteListShare) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(share).Error; err != nil {
+		return fmt.Errorf("failed to share favorites: %w", err)
+	}
+	return nil
+}
+
+func (r *FavoritePostgreSQL) Unshare(ctx context.Context, tx *gorm.DB, ownerID, sharedWith string) error {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Where("owner_id = ? AND shared_with = ?", ownerID, sharedWith).
+		Delete(&models.FavoriteListShare{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to unshare favorites: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *FavoritePostgreSQL) IsShared(ctx context.Context, tx *gorm.DB, ownerID, sharedWith string) (bool, error) {
+	db := r.getDB(tx)
+	var count int64
+	if err := db.WithContext(ctx).
+		Model(&models.FavoriteListShare{}).
+		Where("owner_id = ? AND shared_with = ?", ownerID, sharedWith).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check favorites share: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *FavoritePostgreSQL) GetSharesByOwner(ctx context.Context, tx *gorm.DB, ownerID string) ([]*models.FavoriteListShare, error) {
+	db := r.getDB(tx)
+	var shares []*models.FavoriteListShare
+	if err := db.WithContext(ctx).
+		Where("owner_id = ?", ownerID).
+		Order("created_at ASC").
+		Find(&shares).Error; err != nil {
+		return nil, fmt.Errorf("failed to get favorites shares: %w", err)
+	}
+	return shares, nil
+}
+
+func (r *FavoritePostgreSQL) GetSharedWith(ctx context.Context, tx *gorm.DB, userID string) ([]*models.FavoriteListShare, error) {
+	db := r.getDB(tx)
+	var shares []*models.FavoriteListShare
+	if err := db.WithContext(ctx).
+		Preload("Owner").
+		Where("shared_with = ?", userID).
+		Order("created_at ASC").
+		Find(&shares).Error; err != nil {
+		return nil, fmt.Errorf("failed to get favorites shared with user: %w", err)
+	}
+	return shares, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *FavoritePostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type FeedbackCommentPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewFeedbackCommentPostgreSQL(db *gorm.DB) repositories.FeedbackCommentRepository {
+	return &FeedbackCommentPostgreSQL{db: db}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (r *FeedbackCommentPostgreSQL) Create(ctx context.Context, tx *gorm.DB, comment *models.FeedbackComment) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(comment).Error; err != nil {
+		return fmt.Errorf("failed to create feedback comment: %w", err)
+	}
+	return nil
+}
+
+func (r *FeedbackCommentPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.FeedbackComment, error) {
+	db := r.getDB(tx)
+	var comment models.FeedbackComment
+	if err := db.WithContext(ctx).First(&comment, id).Error; err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *FeedbackCommentPostgreSQL) Update(ctx context.Context, tx *gorm.DB, comment *models.FeedbackComment) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(comment).Error; err != nil {
+		return fmt.Errorf("failed to update feedback comment: %w", err)
+	}
+	return nil
+}
+
+func (r *FeedbackCommentPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Delete(&models.FeedbackComment{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete feedback comment: %w", err)
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (r *FeedbackCommentPostgreSQL) GetByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) ([]*models.FeedbackComment, error) {
+	db := r.getDB(tx)
+	var comments []*models.FeedbackComment
+	if err := db.WithContext(ctx).
+		Where("question_id = ?", questionID).
+		Order("pinned DESC, created_at ASC").
+		Find(&comments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get feedback comments: %w", err)
+	}
+	return comments, nil
+}
+
+// ===== MINING =====
+
+func (r *FeedbackCommentPostgreSQL) GetFeedbackFrequencies(ctx context.Context, tx *gorm.DB, questionID uint, limit int) ([]repositories.FeedbackFrequency, error) {
+	db := r.getDB(tx)
+	var frequencies []repositories.FeedbackFrequency
+	if err := db.WithContext(ctx).
+		Table("student_answers").
+		Select("MIN(TRIM(feedback)) AS text, COUNT(*) AS count").
+		Where("question_id = ? AND is_graded = ?", questionID, true).
+		Where("feedback IS NOT NULL AND TRIM(feedback) <> ''").
+		Group("LOWER(TRIM(feedback))").
+		Order("count DESC, MAX(graded_at) DESC").
+		Limit(limit).
+		Scan(&frequencies).Error; err != nil {
+		return nil, fmt.Errorf("failed to get feedback frequencies: %w", err)
+	}
+	return frequencies, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *FeedbackCommentPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
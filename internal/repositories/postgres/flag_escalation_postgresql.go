@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type FlagEscalationPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewFlagEscalationPostgreSQL(db *gorm.DB) repositories.FlagEscalationRepository {
+	return &FlagEscalationPostgreSQL{db: db}
+}
+
+func (r *FlagEscalationPostgreSQL) Create(ctx context.Context, tx *gorm.DB, escalation *models.QuestionFlagEscalation) (bool, error) {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "assessment_id"}, {Name: "question_id"}},
+			DoNothing: true,
+		}).
+		Create(escalation)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to create flag escalation: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *FlagEscalationPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.QuestionFlagEscalation, error) {
+	db := r.getDB(tx)
+	var escalation models.QuestionFlagEscalation
+	if err := db.WithContext(ctx).First(&escalation, id).Error; err != nil {
+		return nil, err
+	}
+	return &escalation, nil
+}
+
+func (r *FlagEscalationPostgreSQL) GetByQuestion(ctx context.Context, tx *gorm.DB, assessmentID, questionID uint) (*models.QuestionFlagEscalation, error) {
+	db := r.getDB(tx)
+	var escalation models.QuestionFlagEscalation
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ? AND question_id = ?", assessmentID, questionID).
+		First(&escalation).Error; err != nil {
+		return nil, err
+	}
+	return &escalation, nil
+}
+
+func (r *FlagEscalationPostgreSQL) GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint, status *models.FlagEscalationStatus) ([]*models.QuestionFlagEscalation, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).Where("assessment_id = ?", assessmentID)
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	var escalations []*models.QuestionFlagEscalation
+	if err := query.Order("escalated_at DESC, id DESC").Find(&escalations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get flag escalations: %w", err)
+	}
+	return escalations, nil
+}
+
+func (r *FlagEscalationPostgreSQL) Update(ctx context.Context, tx *gorm.DB, escalation *models.QuestionFlagEscalation) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(escalation).Error; err != nil {
+		return fmt.Errorf("failed to update flag escalation: %w", err)
+	}
+	return nil
+}
+
+func (r *FlagEscalationPostgreSQL) CountRecentFlags(ctx context.Context, tx *gorm.DB, assessmentID, questionID uint, since time.Time) (int, error) {
+	db := r.getDB(tx)
+	var count int64
+	if err := db.WithContext(ctx).
+		Table("student_answers sa").
+		Joins("JOIN assessment_attempts aa ON aa.id = sa.attempt_id").
+		Where("aa.assessment_id = ? AND sa.question_id = ?", assessmentID, questionID).
+		Where("aa.status <> ?", models.AttemptVoided).
+		Where("sa.flagged AND sa.flagged_at >= ?", since).
+		Distinct("sa.attempt_id").
+		Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count recent flags: %w", err)
+	}
+	return int(count), nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *FlagEscalationPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
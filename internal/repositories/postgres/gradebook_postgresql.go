@@ -0,0 +1,234 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GradebookPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewGradebookPostgreSQL(db *gorm.DB) repositories.GradebookRepository {
+	return &GradebookPostgreSQL{db: db}
+}
+
+// ===== INTEGRATIONS =====
+
+func (r *GradebookPostgreSQL) CreateIntegration(ctx context.Context, tx *gorm.DB, integration *models.GradebookIntegration) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(integration).Error; err != nil {
+		return fmt.Errorf("failed to create gradebook integration: %w", err)
+	}
+	return nil
+}
+
+func (r *GradebookPostgreSQL) GetIntegrationByID(ctx context.Context, tx *gorm.DB, id uint) (*models.GradebookIntegration, error) {
+	db := r.getDB(tx)
+	var integration models.GradebookIntegration
+	if err := db.WithContext(ctx).First(&integration, id).Error; err != nil {
+		return nil, err
+	}
+	return &integration, nil
+}
+
+func (r *GradebookPostgreSQL) UpdateIntegration(ctx context.Context, tx *gorm.DB, integration *models.GradebookIntegration) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(integration).Error; err != nil {
+		return fmt.Errorf("failed to update gradebook integration: %w", err)
+	}
+	return nil
+}
+
+func (r *GradebookPostgreSQL) DeleteIntegration(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("integration_id = ?", id).Delete(&models.GradePassback{}).Error; err != nil {
+			return fmt.Errorf("failed to delete grade passbacks: %w", err)
+		}
+		if err := tx.Delete(&models.GradebookIntegration{}, id).Error; err != nil {
+			return fmt.Errorf("failed to delete gradebook integration: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *GradebookPostgreSQL) GetIntegrationsByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.GradebookIntegration, error) {
+	db := r.getDB(tx)
+	var integrations []*models.GradebookIntegration
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ?", assessmentID).
+		Order("created_at ASC").
+		Find(&integrations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get gradebook integrations: %w", err)
+	}
+	return integrations, nil
+}
+
+func (r *GradebookPostgreSQL) GetEnabledIntegrations(ctx context.Context, tx *gorm.DB) ([]*models.GradebookIntegration, error) {
+	db := r.getDB(tx)
+	var integrations []*models.GradebookIntegration
+	if err := db.WithContext(ctx).
+		Where("enabled = ?", true).
+		Find(&integrations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get enabled gradebook integrations: %w", err)
+	}
+	return integrations, nil
+}
+
+// ===== PASSBACK QUEUE =====
+
+func (r *GradebookPostgreSQL) GetAttemptsNeedingPassback(ctx context.Context, tx *gorm.DB, integration *models.GradebookIntegration, limit int) ([]*models.AssessmentAttempt, error) {
+	db := r.getDB(tx)
+	finished := []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}
+	var attempts []*models.AssessmentAttempt
+	if err := db.WithContext(ctx).
+		Table("assessment_attempts aa").
+		Select("DISTINCT ON (aa.student_id) aa.*").
+		Joins("LEFT JOIN grade_passbacks gp ON gp.student_id = aa.student_id AND gp.integration_id = ?", integration.ID).
+		Where("aa.assessment_id = ? AND aa.status IN ? AND aa.deleted_at IS NULL", integration.AssessmentID, finished).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM student_answers sa
+			JOIN assessment_attempts other ON other.id = sa.attempt_id
+			WHERE other.assessment_id = aa.assessment_id AND other.student_id = aa.student_id
+				AND other.status IN ? AND other.deleted_at IS NULL AND sa.is_graded = false
+		)`, finished).
+		Where("gp.id IS NULL OR (gp.status = ? AND aa.updated_at > gp.delivered_at)", models.PassbackDelivered).
+		Order("aa.student_id, aa.updated_at DESC").
+		Limit(limit).
+		Find(&attempts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attempts needing passback: %w", err)
+	}
+	return attempts, nil
+}
+
+func (r *GradebookPostgreSQL) QueuePassbacks(ctx context.Context, tx *gorm.DB, passbacks []*models.GradePassback) error {
+	if len(passbacks) == 0 {
+		return nil
+	}
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "integration_id"}, {Name: "student_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"attempt_id":      gorm.Expr("excluded.attempt_id"),
+				"status":          models.PassbackPending,
+				"attempts":        0,
+				"next_attempt_at": gorm.Expr("excluded.next_attempt_at"),
+				"last_error":      nil,
+				"updated_at":      time.Now(),
+			}),
+		}).
+		Create(&passbacks).Error; err != nil {
+		return fmt.Errorf("failed to queue grade passbacks: %w", err)
+	}
+	return nil
+}
+
+func (r *GradebookPostgreSQL) RequeueByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint, includeDelivered bool) (int, error) {
+	db := r.getDB(tx)
+	statuses := []models.PassbackStatus{models.PassbackPending, models.PassbackDeadLetter}
+	if includeDelivered {
+		statuses = append(statuses, models.PassbackDelivered)
+	}
+	now := time.Now()
+	result := db.WithContext(ctx).
+		Model(&models.GradePassback{}).
+		Where("assessment_id = ? AND status IN ?", assessmentID, statuses).
+		Updates(map[string]interface{}{
+			"status":          models.PassbackPending,
+			"attempts":        0,
+			"next_attempt_at": now,
+			"last_error":      nil,
+			"updated_at":      now,
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to requeue grade passbacks: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
+func (r *GradebookPostgreSQL) ClaimPassback(ctx context.Context, tx *gorm.DB, id uint, now, staleBefore time.Time) (bool, error) {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Model(&models.GradePassback{}).
+		Where("id = ?", id).
+		Where(r.claimableCondition(), models.PassbackPending, now, models.PassbackProcessing, staleBefore).
+		Updates(map[string]interface{}{
+			"status":     models.PassbackProcessing,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim grade passback: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *GradebookPostgreSQL) GetDuePassbacks(ctx context.Context, tx *gorm.DB, now, staleBefore time.Time, limit int) ([]uint, error) {
+	db := r.getDB(tx)
+	var ids []uint
+	if err := db.WithContext(ctx).
+		Model(&models.GradePassback{}).
+		Where(r.claimableCondition(), models.PassbackPending, now, models.PassbackProcessing, staleBefore).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to get due grade passbacks: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *GradebookPostgreSQL) GetPassbackByID(ctx context.Context, tx *gorm.DB, id uint) (*models.GradePassback, error) {
+	db := r.getDB(tx)
+	var passback models.GradePassback
+	if err := db.WithContext(ctx).
+		Preload("Integration").
+		First(&passback, id).Error; err != nil {
+		return nil, err
+	}
+	return &passback, nil
+}
+
+func (r *GradebookPostgreSQL) UpdatePassback(ctx context.Context, tx *gorm.DB, passback *models.GradePassback) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Integration").Save(passback).Error; err != nil {
+		return fmt.Errorf("failed to update grade passback: %w", err)
+	}
+	return nil
+}
+
+func (r *GradebookPostgreSQL) ListPassbacks(ctx context.Context, tx *gorm.DB, assessmentID uint, status *models.PassbackStatus, limit int) ([]*models.GradePassback, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).Where("assessment_id = ?", assessmentID)
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	var passbacks []*models.GradePassback
+	if err := query.
+		Order("updated_at DESC").
+		Limit(limit).
+		Find(&passbacks).Error; err != nil {
+		return nil, fmt.Errorf("failed to list grade passbacks: %w", err)
+	}
+	return passbacks, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *GradebookPostgreSQL) claimableCondition() string {
+	return "(status = ? AND (next_attempt_at IS NULL OR next_attempt_at <= ?)) OR (status = ? AND updated_at < ?)"
+}
+
+func (r *GradebookPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type ImpersonationPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewImpersonationPostgreSQL(db *gorm.DB) repositories.ImpersonationRepository {
+	return &ImpersonationPostgreSQL{db: db}
+}
+
+// ===== BASIC OPERATIONS =====
+
+func (r *ImpersonationPostgreSQL) Create(ctx context.Context, tx *gorm.DB, session *models.ImpersonationSession) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(session).Error; err != nil {
+		return fmt.Errorf("failed to create impersonation session: %w", err)
+	}
+	return nil
+}
+
+func (r *ImpersonationPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.ImpersonationSession, error) {
+	db := r.getDB(tx)
+	var session models.ImpersonationSession
+	if err := db.WithContext(ctx).First(&session, id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *ImpersonationPostgreSQL) GetByTokenHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*models.ImpersonationSession, error) {
+	db := r.getDB(tx)
+	var session models.ImpersonationSession
+	if err := db.WithContext(ctx).
+		Where("token_hash = ?", tokenHash).
+		First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *ImpersonationPostgreSQL) Update(ctx context.Context, tx *gorm.DB, session *models.ImpersonationSession) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(session).Error; err != nil {
+		return fmt.Errorf("failed to update impersonation session: %w", err)
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (r *ImpersonationPostgreSQL) ListActiveByAdmin(ctx context.Context, tx *gorm.DB, adminID string, now time.Time) ([]*models.ImpersonationSession, error) {
+	db := r.getDB(tx)
+	var sessions []*models.ImpersonationSession
+	if err := db.WithContext(ctx).
+		Where("admin_id = ? AND ended_at IS NULL AND expires_at > ?", adminID, now).
+		Order("created_at DESC").
+		Find(&sessions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list impersonation sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *ImpersonationPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type ImportJobPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewImportJobPostgreSQL(db *gorm.DB) repositories.ImportJobRepository {
+	return &ImportJobPostgreSQL{db: db}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (r *ImportJobPostgreSQL) Create(ctx context.Context, tx *gorm.DB, job *models.ImportJob) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Assessment", "Bank", "User").Create(job).Error; err != nil {
+		return fmt.Errorf("failed to create import job: %w", err)
+	}
+	return nil
+}
+
+func (r *ImportJobPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id string) (*models.ImportJob, error) {
+	db := r.getDB(tx)
+	var job models.ImportJob
+	if err := db.WithContext(ctx).Where("id = ?", id).First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *ImportJobPostgreSQL) Update(ctx context.Context, tx *gorm.DB, job *models.ImportJob) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Assessment", "Bank", "User").Save(job).Error; err != nil {
+		return fmt.Errorf("failed to update import job: %w", err)
+	}
+	return nil
+}
+
+// ===== PROCESSING =====
+
+func (r *ImportJobPostgreSQL) Claim(ctx context.Context, tx *gorm.DB, id string, staleBefore time.Time) (bool, error) {
+	db := r.getDB(tx)
+	now := time.Now()
+	result := db.WithContext(ctx).
+		Model(&models.ImportJob{}).
+		Where("id = ?", id).
+		Where("status = ? OR (status = ? AND updated_at < ?)", models.ImportPending, models.ImportProcessing, staleBefore).
+		Updates(map[string]interface{}{
+			"status":     models.ImportProcessing,
+			"started_at": gorm.Expr("COALESCE(started_at, ?)", now),
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim import job: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ImportJobPostgreSQL) SaveProgress(ctx context.Context, tx *gorm.DB, job *models.ImportJob) (bool, error) {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Model(&models.ImportJob{}).
+		Where("id = ? AND status = ?", job.ID, models.ImportProcessing).
+		Updates(map[string]interface{}{
+			"status":         job.Status,
+			"total_rows":     job.TotalRows,
+			"processed_rows": job.ProcessedRows,
+			"success_count":  job.SuccessCount,
+			"updated_count":  job.UpdatedCount,
+			"error_count":    job.ErrorCount,
+			"progress":       job.Progress,
+			"errors":         job.Errors,
+			"warnings":       job.Warnings,
+			"summary":        job.Summary,
+			"completed_at":   job.CompletedAt,
+			"updated_at":     time.Now(),
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to save import job progress: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ImportJobPostgreSQL) Cancel(ctx context.Context, tx *gorm.DB, id string, cancelledAt time.Time) (bool, error) {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Model(&models.ImportJob{}).
+		Where("id = ? AND status IN ?", id, []models.ImportJobStatus{models.ImportPending, models.ImportProcessing}).
+		Updates(map[string]interface{}{
+			"status":       models.ImportCancelled,
+			"cancelled_at": cancelledAt,
+			"updated_at":   cancelledAt,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to cancel import job: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ImportJobPostgreSQL) GetResumable(ctx context.Context, tx *gorm.DB, staleBefore time.Time, limit int) ([]*models.ImportJob, error) {
+	db := r.getDB(tx)
+	var jobs []*models.ImportJob
+	if err := db.WithContext(ctx).
+		Where("status = ? OR (status = ? AND updated_at < ?)", models.ImportPending, models.ImportProcessing, staleBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get resumable import jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// ===== FILES =====
+
+func (r *ImportJobPostgreSQL) SaveFile(ctx context.Context, tx *gorm.DB, jobID string, data []byte) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(&models.ImportJobFile{JobID: jobID, Data: data}).Error; err != nil {
+		return fmt.Errorf("failed to store import file: %w", err)
+	}
+	return nil
+}
+
+func (r *ImportJobPostgreSQL) GetFile(ctx context.Context, tx *gorm.DB, jobID string) ([]byte, error) {
+	db := r.getDB(tx)
+	var file models.ImportJobFile
+	if err := db.WithContext(ctx).Where("job_id = ?", jobID).First(&file).Error; err != nil {
+		return nil, err
+	}
+	return file.Data, nil
+}
+
+func (r *ImportJobPostgreSQL) DeleteFile(ctx context.Context, tx *gorm.DB, jobID string) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Where("job_id = ?", jobID).Delete(&models.ImportJobFile{}).Error; err != nil {
+		return fmt.Errorf("failed to delete import file: %w", err)
+	}
+	return nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *ImportJobPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type JobPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewJobPostgreSQL(db *gorm.DB) repositories.JobRepository {
+	return &JobPostgreSQL{db: db}
+}
+
+func (r *JobPostgreSQL) Create(ctx context.Context, tx *gorm.DB, job *models.Job) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(job).Error; err != nil {
+		return fmt.Errorf("failed to create job: %w", err)
+	}
+	return nil
+}
+
+func (r *JobPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.Job, error) {
+	db := r.getDB(tx)
+	var job models.Job
+	if err := db.WithContext(ctx).First(&job, id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *JobPostgreSQL) Update(ctx context.Context, tx *gorm.DB, job *models.Job) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(job).Error; err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+	return nil
+}
+
+func (r *JobPostgreSQL) List(ctx context.Context, tx *gorm.DB, filters repositories.JobFilters) ([]*models.Job, int64, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).Model(&models.Job{})
+	if filters.Type != "" {
+		query = query.Where("type = ?", filters.Type)
+	}
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.CreatedBy != nil {
+		query = query.Where("created_by = ?", *filters.CreatedBy)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count jobs: %w", err)
+	}
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+
+	var jobs []*models.Job
+	if err := query.Order("created_at DESC, id DESC").Find(&jobs).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	return jobs, total, nil
+}
+
+func (r *JobPostgreSQL) GetActive(ctx context.Context, tx *gorm.DB, jobType, ref string) (*models.Job, error) {
+	db := r.getDB(tx)
+	var job models.Job
+	if err := db.WithContext(ctx).
+		Where("type = ? AND ref = ? AND status IN ?", jobType, ref, []models.JobStatus{models.JobQueued, models.JobRunning}).
+		Order("id DESC").
+		First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *JobPostgreSQL) GetRunnable(ctx context.Context, tx *gorm.DB, types []string, now, staleBefore time.Time, limit int) ([]*models.Job, error) {
+	db := r.getDB(tx)
+	var jobs []*models.Job
+	if err := db.WithContext(ctx).
+		Where("type IN ?", types).
+		Where("(status = ? AND run_at <= ?) OR (status = ? AND updated_at < ?)", models.JobQueued, now, models.JobRunning, staleBefore).
+		Order("run_at ASC, id ASC").
+		Limit(limit).
+		Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get runnable jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+func (r *JobPostgreSQL) Claim(ctx context.Context, tx *gorm.DB, id uint, now, staleBefore time.Time) (bool, error) {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Model(&models.Job{}).
+		Where("id = ?", id).
+		Where("(status = ? AND run_at <= ?) OR (status = ? AND updated_at < ?)", models.JobQueued, now, models.JobRunning, staleBefore).
+		Updates(map[string]interface{}{
+			"status":     models.JobRunning,
+			"attempts":   gorm.Expr("attempts + 1"),
+			"started_at": now,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim job: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *JobPostgreSQL) Heartbeat(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).
+		Model(&models.Job{}).
+		Where("id = ? AND status = ?", id, models.JobRunning).
+		Update("updated_at", time.Now()).Error; err != nil {
+		return fmt.Errorf("failed to record job heartbeat: %w", err)
+	}
+	return nil
+}
+
+func (r *JobPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
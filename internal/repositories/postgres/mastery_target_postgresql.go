@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type MasteryTargetPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewMasteryTargetPostgreSQL(db *gorm.DB) repositories.MasteryTargetRepository {
+	return &MasteryTargetPostgreSQL{db: db}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (r *MasteryTargetPostgreSQL) Create(ctx context.Context, tx *gorm.DB, target *models.MasteryTarget) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(target).Error; err != nil {
+		return fmt.Errorf("failed to create mastery target: %w", err)
+	}
+	return nil
+}
+
+func (r *MasteryTargetPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.MasteryTarget, error) {
+	db := r.getDB(tx)
+	var target models.MasteryTarget
+	if err := db.WithContext(ctx).First(&target, id).Error; err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
+
+func (r *MasteryTargetPostgreSQL) Update(ctx context.Context, tx *gorm.DB, target *models.MasteryTarget) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(target).Error; err != nil {
+		return fmt.Errorf("failed to update mastery target: %w", err)
+	}
+	return nil
+}
+
+func (r *MasteryTargetPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Delete(&models.MasteryTarget{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete mastery target: %w", err)
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (r *MasteryTargetPostgreSQL) GetByCreator(ctx context.Context, tx *gorm.DB, creatorID string) ([]*models.MasteryTarget, error) {
+	return r.GetByCreators(ctx, tx, []string{creatorID})
+}
+
+func (r *MasteryTargetPostgreSQL) GetByCreators(ctx context.Context, tx *gorm.DB, creatorIDs []string) ([]*models.MasteryTarget, error) {
+	if len(creatorIDs) == 0 {
+		return []*models.MasteryTarget{}, nil
+	}
+
+	db := r.getDB(tx)
+	var targets []*models.MasteryTarget
+	if err := db.WithContext(ctx).
+		Where("created_by IN ?", creatorIDs).
+		Order("skill ASC, created_by ASC").
+		Find(&targets).Error; err != nil {
+		return nil, fmt.Errorf("failed to get mastery targets: %w", err)
+	}
+	return targets, nil
+}
+
+func (r *MasteryTargetPostgreSQL) ExistsBySkill(ctx context.Context, tx *gorm.DB, creatorID, skill string, excludeID *uint) (bool, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).
+		Model(&models.MasteryTarget{}).
+		Where("created_by = ? AND skill = ?", creatorID, skill)
+
+	if excludeID != nil {
+		query = query.Where("id != ?", *excludeID)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check mastery target existence: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ===== EVIDENCE =====
+
+func (r *MasteryTargetPostgreSQL) GetSkillEvidence(ctx context.Context, tx *gorm.DB, studentID string) ([]repositories.SkillEvidence, error) {
+	db := r.getDB(tx)
+	var evidence []repositories.SkillEvidence
+	if err := db.WithContext(ctx).
+		Table("student_answers").
+		Select("student_answers.question_id, q.tags, a.created_by AS assessment_creator, student_answers.score, student_answers.max_score, COALESCE(student_answers.graded_at, student_answers.updated_at) AS graded_at").
+		Joins("JOIN assessment_attempts aa ON aa.id = student_answers.attempt_id").
+		Joins("JOIN assessments a ON a.id = aa.assessment_id").
+		Joins("JOIN questions q ON q.id = student_answers.question_id").
+		Where("aa.student_id = ?", studentID).
+		Where("aa.status IN ?", []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Where("student_answers.is_graded = ? AND student_answers.max_score > 0", true).
+		Order("graded_at ASC").
+		Scan(&evidence).Error; err != nil {
+		return nil, fmt.Errorf("failed to get skill evidence: %w", err)
+	}
+	return evidence, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *MasteryTargetPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
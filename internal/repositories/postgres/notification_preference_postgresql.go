@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationPreferencePostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewNotificationPreferencePostgreSQL(db *gorm.DB) repositories.NotificationPreferenceRepository {
+	return &NotificationPreferencePostgreSQL{db: db}
+}
+
+func (r *NotificationPreferencePostgreSQL) GetByUser(ctx context.Context, tx *gorm.DB, userID string) (*models.NotificationPreference, error) {
+	db := r.getDB(tx)
+	var preference models.NotificationPreference
+	if err := db.WithContext(ctx).Where("user_id = ?", userID).First(&preference).Error; err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+func (r *NotificationPreferencePostgreSQL) GetByUsers(ctx context.Context, tx *gorm.DB, userIDs []string) (map[string]*models.NotificationPreference, error) {
+	preferences := make(map[string]*models.NotificationPreference, len(userIDs))
+	if len(userIDs) == 0 {
+		return preferences, nil
+	}
+
+	db := r.getDB(tx)
+	var rows []*models.NotificationPreference
+	if err := db.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	for _, row := range rows {
+		preferences[row.UserID] = row
+	}
+	return preferences, nil
+}
+
+// Upsert stores the user's preferences, replacing any set before
+func (r *NotificationPreferencePostgreSQL) Upsert(ctx context.Context, tx *gorm.DB, preference *models.NotificationPreference) error {
+	db := r.getDB(tx)
+	err := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"channels", "quiet_hours_start", "quiet_hours_end", "timezone", "updated_at"}),
+	}).Create(preference).Error
+	if err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	return nil
+}
+
+func (r *NotificationPreferencePostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
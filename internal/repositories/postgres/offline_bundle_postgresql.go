@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type OfflineBundlePostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewOfflineBundlePostgreSQL(db *gorm.DB) repositories.OfflineBundleRepository {
+	return &OfflineBundlePostgreSQL{db: db}
+}
+
+func (r *OfflineBundlePostgreSQL) Create(ctx context.Context, tx *gorm.DB, bundle *models.OfflineBundle) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(bundle).Error; err != nil {
+		return fmt.Errorf("failed to create offline bundle: %w", err)
+	}
+	return nil
+}
+
+func (r *OfflineBundlePostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.OfflineBundle, error) {
+	db := r.getDB(tx)
+	var bundle models.OfflineBundle
+	if err := db.WithContext(ctx).First(&bundle, id).Error; err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+func (r *OfflineBundlePostgreSQL) GetByAttempt(ctx context.Context, tx *gorm.DB, attemptID uint) ([]*models.OfflineBundle, error) {
+	db := r.getDB(tx)
+	var bundles []*models.OfflineBundle
+	if err := db.WithContext(ctx).
+		Where("attempt_id = ?", attemptID).
+		Order("exported_at DESC").
+		Find(&bundles).Error; err != nil {
+		return nil, fmt.Errorf("failed to get offline bundles: %w", err)
+	}
+	return bundles, nil
+}
+
+func (r *OfflineBundlePostgreSQL) Update(ctx context.Context, tx *gorm.DB, bundle *models.OfflineBundle) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(bundle).Error; err != nil {
+		return fmt.Errorf("failed to update offline bundle: %w", err)
+	}
+	return nil
+}
+
+func (r *OfflineBundlePostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
@@ -5,48 +5,88 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 
 	"github.com/SAP-F-2025/assessment-service/internal/cache"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories/casdoor"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories/redis"
 )
 
 // PostgreSQLRepository implements the main Repository interface
 type PostgreSQLRepository struct {
 	db           *gorm.DB
-	redisClient  *redis.Client
+	redisClient  *cache.Client
 	cacheManager *cache.CacheManager
+	compressor   *cache.Compressor
 
 	// Repository instances
-	assessment         repositories.AssessmentRepository
-	assessmentSettings repositories.AssessmentSettingsRepository
-	question           repositories.QuestionRepository
-	questionCategory   repositories.QuestionCategoryRepository
-	questionAttachment repositories.QuestionAttachmentRepository
-	questionBank       repositories.QuestionBankRepository
-	assessmentQuestion repositories.AssessmentQuestionRepository
-	attempt            repositories.AttemptRepository
-	answer             repositories.AnswerRepository
-	user               repositories.UserRepository
+	assessment          repositories.AssessmentRepository
+	assessmentSettings  repositories.AssessmentSettingsRepository
+	question            repositories.QuestionRepository
+	questionCategory    repositories.QuestionCategoryRepository
+	questionAttachment  repositories.QuestionAttachmentRepository
+	questionBank        repositories.QuestionBankRepository
+	assessmentQuestion  repositories.AssessmentQuestionRepository
+	attempt             repositories.AttemptRepository
+	answer              repositories.AnswerRepository
+	answerBuffer        repositories.AnswerBufferRepository
+	answerReview        repositories.AnswerReviewRepository
+	reportSubscription  repositories.ReportSubscriptionRepository
+	auditLog            repositories.AuditLogRepository
+	feedbackComment     repositories.FeedbackCommentRepository
+	importJob           repositories.ImportJobRepository
+	enrollment          repositories.EnrollmentRepository
+	extension           repositories.ExtensionRepository
+	shareLink           repositories.ShareLinkRepository
+	questionAnalytics   repositories.QuestionAnalyticsRepository
+	masteryTarget       repositories.MasteryTargetRepository
+	favorite            repositories.FavoriteRepository
+	answerAttachment    repositories.AnswerAttachmentRepository
+	gradebook           repositories.GradebookRepository
+	assessmentAnalytics repositories.AssessmentAnalyticsRepository
+	answerAnnotation    repositories.AnswerAnnotationRepository
+	questionTranslation repositories.QuestionTranslationRepository
+	questionExternalRef repositories.QuestionExternalRefRepository
+	attemptQueue        repositories.AttemptQueueRepository
+	attemptQuota        repositories.AttemptQuotaRepository
+	job                 repositories.JobRepository
+	practice            repositories.PracticeRepository
+	offlineBundle       repositories.OfflineBundleRepository
+	attemptNavigation   repositories.AttemptNavigationRepository
+	impersonation       repositories.ImpersonationRepository
+	answerKeyChange     repositories.AnswerKeyChangeRepository
+	flagEscalation      repositories.FlagEscalationRepository
+	gradingDelegation   repositories.GradingDelegationRepository
+	questionTrial       repositories.QuestionTrialRepository
+	usage               repositories.UsageRepository
+	residency           repositories.ResidencyRepository
+	customField         repositories.CustomFieldRepository
+	notificationPref    repositories.NotificationPreferenceRepository
+	warehouse           repositories.WarehouseRepository
+	researchPolicy      repositories.ResearchPolicyRepository
+	webhook             repositories.WebhookRepository
+	user                repositories.UserRepository
 }
 
 // RepositoryConfig holds configuration for repository initialization
 type RepositoryConfig struct {
 	DB            *gorm.DB
-	RedisClient   *redis.Client
+	RedisClient   *cache.Client
 	CasdoorConfig casdoor.CasdoorConfig
+	// Compresses large answers in the database and large cached values; nil stores them as they are
+	Compressor *cache.Compressor
 }
 
 // NewPostgreSQLRepository creates a new repository manager with all sub-repositories
 func NewPostgreSQLRepository(config RepositoryConfig) repositories.Repository {
-	cacheManager := cache.NewCacheManager(config.RedisClient)
+	cacheManager := cache.NewCacheManager(config.RedisClient, "")
 
 	repo := &PostgreSQLRepository{
 		db:           config.DB,
 		redisClient:  config.RedisClient,
 		cacheManager: cacheManager,
+		compressor:   config.Compressor,
 	}
 
 	// Initialize sub-repositories with caching
@@ -59,11 +99,49 @@ func NewPostgreSQLRepository(config RepositoryConfig) repositories.Repository {
 	// User repository uses Casdoor
 	repo.user = casdoor.NewUserCasdoor(config.CasdoorConfig, config.RedisClient)
 
+	// Autosave buffer lives in Redis only
+	repo.answerBuffer = redis.NewAnswerBufferRedis(config.RedisClient)
+
 	// TODO: Initialize other repositories
 	repo.assessmentSettings = NewAssessmentSettingsPostgreSQL(config.DB, cacheManager)
 	// repo.questionCategory = NewQuestionCategoryPostgreSQL(config.DB, config.RedisClient)
-	// repo.questionAttachment = NewQuestionAttachmentPostgreSQL(config.DB, config.RedisClient)
+	repo.questionAttachment = NewQuestionAttachmentPostgreSQL(config.DB)
 	repo.answer = NewAnswerPostgreSQL(config.DB, config.RedisClient)
+	repo.answerReview = NewAnswerReviewPostgreSQL(config.DB)
+	repo.reportSubscription = NewReportSubscriptionPostgreSQL(config.DB)
+	repo.auditLog = NewAuditLogPostgreSQL(config.DB)
+	repo.feedbackComment = NewFeedbackCommentPostgreSQL(config.DB)
+	repo.importJob = NewImportJobPostgreSQL(config.DB)
+	repo.enrollment = NewEnrollmentPostgreSQL(config.DB)
+	repo.extension = NewExtensionPostgreSQL(config.DB)
+	repo.shareLink = NewShareLinkPostgreSQL(config.DB)
+	repo.questionAnalytics = NewQuestionAnalyticsPostgreSQL(config.DB, config.Compressor)
+	repo.masteryTarget = NewMasteryTargetPostgreSQL(config.DB)
+	repo.favorite = NewFavoritePostgreSQL(config.DB)
+	repo.answerAttachment = NewAnswerAttachmentPostgreSQL(config.DB)
+	repo.gradebook = NewGradebookPostgreSQL(config.DB)
+	repo.assessmentAnalytics = NewAssessmentAnalyticsPostgreSQL(config.DB)
+	repo.answerAnnotation = NewAnswerAnnotationPostgreSQL(config.DB)
+	repo.questionTranslation = NewQuestionTranslationPostgreSQL(config.DB)
+	repo.questionExternalRef = NewQuestionExternalRefPostgreSQL(config.DB)
+	repo.attemptQueue = NewAttemptQueuePostgreSQL(config.DB)
+	repo.attemptQuota = NewAttemptQuotaPostgreSQL(config.DB)
+	repo.job = NewJobPostgreSQL(config.DB)
+	repo.practice = NewPracticePostgreSQL(config.DB)
+	repo.offlineBundle = NewOfflineBundlePostgreSQL(config.DB)
+	repo.attemptNavigation = NewAttemptNavigationPostgreSQL(config.DB)
+	repo.impersonation = NewImpersonationPostgreSQL(config.DB)
+	repo.answerKeyChange = NewAnswerKeyChangePostgreSQL(config.DB)
+	repo.flagEscalation = NewFlagEscalationPostgreSQL(config.DB)
+	repo.gradingDelegation = NewGradingDelegationPostgreSQL(config.DB)
+	repo.questionTrial = NewQuestionTrialPostgreSQL(config.DB)
+	repo.usage = NewUsagePostgreSQL(config.DB)
+	repo.residency = NewResidencyPostgreSQL(config.DB)
+	repo.customField = NewCustomFieldPostgreSQL(config.DB)
+	repo.notificationPref = NewNotificationPreferencePostgreSQL(config.DB)
+	repo.warehouse = NewWarehousePostgreSQL(config.DB)
+	repo.researchPolicy = NewResearchPolicyPostgreSQL(config.DB)
+	repo.webhook = NewWebhookPostgreSQL(config.DB)
 
 	return repo
 }
@@ -113,6 +191,186 @@ func (r *PostgreSQLRepository) Answer() repositories.AnswerRepository {
 	return r.answer
 }
 
+// AnswerBuffer returns the autosave buffer repository
+func (r *PostgreSQLRepository) AnswerBuffer() repositories.AnswerBufferRepository {
+	return r.answerBuffer
+}
+
+// AnswerReview returns the answer review repository
+func (r *PostgreSQLRepository) AnswerReview() repositories.AnswerReviewRepository {
+	return r.answerReview
+}
+
+// ReportSubscription returns the report subscription repository
+func (r *PostgreSQLRepository) ReportSubscription() repositories.ReportSubscriptionRepository {
+	return r.reportSubscription
+}
+
+// AuditLog returns the audit log repository
+func (r *PostgreSQLRepository) AuditLog() repositories.AuditLogRepository {
+	return r.auditLog
+}
+
+// FeedbackComment returns the feedback comment repository
+func (r *PostgreSQLRepository) FeedbackComment() repositories.FeedbackCommentRepository {
+	return r.feedbackComment
+}
+
+// ImportJob returns the import job repository
+func (r *PostgreSQLRepository) ImportJob() repositories.ImportJobRepository {
+	return r.importJob
+}
+
+// Enrollment returns the enrollment repository
+func (r *PostgreSQLRepository) Enrollment() repositories.EnrollmentRepository {
+	return r.enrollment
+}
+
+// Extension returns the extension request and accommodation repository
+func (r *PostgreSQLRepository) Extension() repositories.ExtensionRepository {
+	return r.extension
+}
+
+// ShareLink returns the assessment share link repository
+func (r *PostgreSQLRepository) ShareLink() repositories.ShareLinkRepository {
+	return r.shareLink
+}
+
+// QuestionAnalytics returns the question analytics repository
+func (r *PostgreSQLRepository) QuestionAnalytics() repositories.QuestionAnalyticsRepository {
+	return r.questionAnalytics
+}
+
+// MasteryTarget returns the mastery target repository
+func (r *PostgreSQLRepository) MasteryTarget() repositories.MasteryTargetRepository {
+	return r.masteryTarget
+}
+
+// Favorite returns the favorite repository
+func (r *PostgreSQLRepository) Favorite() repositories.FavoriteRepository {
+	return r.favorite
+}
+
+// AnswerAttachment returns the answer attachment repository
+func (r *PostgreSQLRepository) AnswerAttachment() repositories.AnswerAttachmentRepository {
+	return r.answerAttachment
+}
+
+// Gradebook returns the gradebook repository
+func (r *PostgreSQLRepository) Gradebook() repositories.GradebookRepository {
+	return r.gradebook
+}
+
+// AssessmentAnalytics returns the assessment analytics repository
+func (r *PostgreSQLRepository) AssessmentAnalytics() repositories.AssessmentAnalyticsRepository {
+	return r.assessmentAnalytics
+}
+
+// AnswerAnnotation returns the answer annotation repository
+func (r *PostgreSQLRepository) AnswerAnnotation() repositories.AnswerAnnotationRepository {
+	return r.answerAnnotation
+}
+
+// QuestionTranslation returns the question translation repository
+func (r *PostgreSQLRepository) QuestionTranslation() repositories.QuestionTranslationRepository {
+	return r.questionTranslation
+}
+
+// QuestionExternalRef returns the question external ref repository
+func (r *PostgreSQLRepository) QuestionExternalRef() repositories.QuestionExternalRefRepository {
+	return r.questionExternalRef
+}
+
+// AttemptQueue returns the attempt queue repository
+func (r *PostgreSQLRepository) AttemptQueue() repositories.AttemptQueueRepository {
+	return r.attemptQueue
+}
+
+// AttemptQuota returns the attempt quota repository
+func (r *PostgreSQLRepository) AttemptQuota() repositories.AttemptQuotaRepository {
+	return r.attemptQuota
+}
+
+// Job returns the background job repository
+func (r *PostgreSQLRepository) Job() repositories.JobRepository {
+	return r.job
+}
+
+// Practice returns the practice set and session repository
+func (r *PostgreSQLRepository) Practice() repositories.PracticeRepository {
+	return r.practice
+}
+
+// AttemptNavigation returns the attempt navigation log repository
+func (r *PostgreSQLRepository) AttemptNavigation() repositories.AttemptNavigationRepository {
+	return r.attemptNavigation
+}
+
+// OfflineBundle returns the offline attempt bundle repository
+func (r *PostgreSQLRepository) OfflineBundle() repositories.OfflineBundleRepository {
+	return r.offlineBundle
+}
+
+// Impersonation returns the impersonation session repository
+func (r *PostgreSQLRepository) Impersonation() repositories.ImpersonationRepository {
+	return r.impersonation
+}
+
+// AnswerKeyChange returns the answer key change and regrade job repository
+func (r *PostgreSQLRepository) AnswerKeyChange() repositories.AnswerKeyChangeRepository {
+	return r.answerKeyChange
+}
+
+// FlagEscalation returns the repository of questions escalated after many student flags
+func (r *PostgreSQLRepository) FlagEscalation() repositories.FlagEscalationRepository {
+	return r.flagEscalation
+}
+
+// GradingDelegation returns the grading delegation repository
+func (r *PostgreSQLRepository) GradingDelegation() repositories.GradingDelegationRepository {
+	return r.gradingDelegation
+}
+
+// QuestionTrial returns the question trial repository
+func (r *PostgreSQLRepository) QuestionTrial() repositories.QuestionTrialRepository {
+	return r.questionTrial
+}
+
+// Usage returns the billing usage repository
+func (r *PostgreSQLRepository) Usage() repositories.UsageRepository {
+	return r.usage
+}
+
+// Residency returns the organization data residency repository
+func (r *PostgreSQLRepository) Residency() repositories.ResidencyRepository {
+	return r.residency
+}
+
+// CustomField returns the organization custom field definition repository
+func (r *PostgreSQLRepository) CustomField() repositories.CustomFieldRepository {
+	return r.customField
+}
+
+// NotificationPreference returns the notification preference repository
+func (r *PostgreSQLRepository) NotificationPreference() repositories.NotificationPreferenceRepository {
+	return r.notificationPref
+}
+
+// Warehouse returns the warehouse export repository
+func (r *PostgreSQLRepository) Warehouse() repositories.WarehouseRepository {
+	return r.warehouse
+}
+
+// ResearchPolicy returns the organization research sampling policy repository
+func (r *PostgreSQLRepository) ResearchPolicy() repositories.ResearchPolicyRepository {
+	return r.researchPolicy
+}
+
+// Webhook returns the webhook subscription repository
+func (r *PostgreSQLRepository) Webhook() repositories.WebhookRepository {
+	return r.webhook
+}
+
 // User returns the user repository
 func (r *PostgreSQLRepository) User() repositories.UserRepository {
 	return r.user
@@ -126,6 +384,7 @@ func (r *PostgreSQLRepository) WithTransaction(ctx context.Context, fn func(repo
 			db:           tx,
 			redisClient:  r.redisClient,
 			cacheManager: r.cacheManager,
+			compressor:   r.compressor,
 		}
 
 		// Initialize sub-repositories with transaction
@@ -134,9 +393,17 @@ func (r *PostgreSQLRepository) WithTransaction(ctx context.Context, fn func(repo
 		txRepo.questionBank = NewQuestionBankRepository(tx)
 		txRepo.assessmentQuestion = NewAssessmentQuestionPostgreSQL(tx, r.redisClient)
 		txRepo.attempt = NewAttemptPostgreSQL(tx, r.redisClient)
-
-		// User repository doesn't need transaction (it's external)
+		txRepo.answerReview = NewAnswerReviewPostgreSQL(tx)
+		txRepo.reportSubscription = NewReportSubscriptionPostgreSQL(tx)
+		txRepo.auditLog = NewAuditLogPostgreSQL(tx)
+		txRepo.feedbackComment = NewFeedbackCommentPostgreSQL(tx)
+		txRepo.importJob = NewImportJobPostgreSQL(tx)
+		txRepo.enrollment = NewEnrollmentPostgreSQL(tx)
+		txRepo.questionAnalytics = NewQuestionAnalyticsPostgreSQL(tx, r.compressor)
+
+		// User repository and autosave buffer don't need transaction (they're external)
 		txRepo.user = r.user
+		txRepo.answerBuffer = r.answerBuffer
 
 		return fn(txRepo)
 	})
@@ -154,11 +421,10 @@ func (r *PostgreSQLRepository) Ping(ctx context.Context) error {
 		return fmt.Errorf("database ping failed: %w", err)
 	}
 
-	// Check cache connection
+	// An unreachable cache only degrades performance, so it refreshes the
+	// cache availability without failing the health check
 	if r.redisClient != nil {
-		if err := r.cacheManager.HealthCheck(ctx); err != nil {
-			return fmt.Errorf("cache ping failed: %w", err)
-		}
+		_ = r.cacheManager.HealthCheck(ctx)
 	}
 
 	return nil
@@ -219,11 +485,14 @@ func (rm *RepositoryManager) Initialize() error {
 		return fmt.Errorf("database connection failed: %w", err)
 	}
 
-	// Test Redis connection if provided
+	// Redis is optional: repositories skip the cache while it is unreachable
 	if rm.config.RedisClient != nil {
-		if _, err := rm.config.RedisClient.Ping(ctx).Result(); err != nil {
-			return fmt.Errorf("Redis connection failed: %w", err)
-		}
+		_ = rm.config.RedisClient.CheckHealth(ctx)
+		rm.config.RedisClient.SetCompressor(rm.config.Compressor)
+	}
+
+	if err := registerAnswerCompression(rm.config.DB, rm.config.Compressor); err != nil {
+		return fmt.Errorf("failed to register answer compression: %w", err)
 	}
 
 	// Initialize repository
@@ -265,6 +534,7 @@ func (r *PostgreSQLRepository) CacheStats(ctx context.Context) (map[string]inter
 
 	stats := make(map[string]interface{})
 	stats["cache_enabled"] = true
+	stats["cache_available"] = r.redisClient.Available()
 
 	// Get Redis info
 	info, err := r.redisClient.Info(ctx, "memory", "stats").Result()
@@ -0,0 +1,186 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type PracticePostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewPracticePostgreSQL(db *gorm.DB) repositories.PracticeRepository {
+	return &PracticePostgreSQL{db: db}
+}
+
+// ===== PRACTICE SETS =====
+
+func (r *PracticePostgreSQL) CreateSet(ctx context.Context, tx *gorm.DB, set *models.PracticeSet) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(set).Error; err != nil {
+		return fmt.Errorf("failed to create practice set: %w", err)
+	}
+	return nil
+}
+
+func (r *PracticePostgreSQL) GetSetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.PracticeSet, error) {
+	db := r.getDB(tx)
+	var set models.PracticeSet
+	if err := db.WithContext(ctx).First(&set, id).Error; err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
+
+func (r *PracticePostgreSQL) UpdateSet(ctx context.Context, tx *gorm.DB, set *models.PracticeSet) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(set).Error; err != nil {
+		return fmt.Errorf("failed to update practice set: %w", err)
+	}
+	return nil
+}
+
+func (r *PracticePostgreSQL) ListSets(ctx context.Context, tx *gorm.DB, bankID *uint, activeOnly bool) ([]*models.PracticeSet, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).Model(&models.PracticeSet{})
+	if bankID != nil {
+		query = query.Where("bank_id = ?", *bankID)
+	}
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	var sets []*models.PracticeSet
+	if err := query.Order("created_at DESC, id DESC").Find(&sets).Error; err != nil {
+		return nil, fmt.Errorf("failed to list practice sets: %w", err)
+	}
+	return sets, nil
+}
+
+// ===== SESSIONS =====
+
+func (r *PracticePostgreSQL) CreateSession(ctx context.Context, tx *gorm.DB, session *models.PracticeSession) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(session).Error; err != nil {
+		return fmt.Errorf("failed to create practice session: %w", err)
+	}
+	return nil
+}
+
+func (r *PracticePostgreSQL) GetSessionByID(ctx context.Context, tx *gorm.DB, id uint) (*models.PracticeSession, error) {
+	db := r.getDB(tx)
+	var session models.PracticeSession
+	if err := db.WithContext(ctx).First(&session, id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *PracticePostgreSQL) UpdateSession(ctx context.Context, tx *gorm.DB, session *models.PracticeSession) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(session).Error; err != nil {
+		return fmt.Errorf("failed to update practice session: %w", err)
+	}
+	return nil
+}
+
+func (r *PracticePostgreSQL) ListSessions(ctx context.Context, tx *gorm.DB, filters repositories.PracticeSessionFilters) ([]*models.PracticeSession, int64, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).Model(&models.PracticeSession{})
+	if filters.PracticeSetID != nil {
+		query = query.Where("practice_set_id = ?", *filters.PracticeSetID)
+	}
+	if filters.StudentID != nil {
+		query = query.Where("student_id = ?", *filters.StudentID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count practice sessions: %w", err)
+	}
+
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+	if filters.Offset > 0 {
+		query = query.Offset(filters.Offset)
+	}
+	var sessions []*models.PracticeSession
+	if err := query.Order("created_at DESC, id DESC").Find(&sessions).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list practice sessions: %w", err)
+	}
+	return sessions, total, nil
+}
+
+// ===== ANSWERS =====
+
+func (r *PracticePostgreSQL) CreateAnswer(ctx context.Context, tx *gorm.DB, answer *models.PracticeAnswer) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(answer).Error; err != nil {
+		return fmt.Errorf("failed to create practice answer: %w", err)
+	}
+	return nil
+}
+
+func (r *PracticePostgreSQL) GetAnswers(ctx context.Context, tx *gorm.DB, sessionID uint) ([]*models.PracticeAnswer, error) {
+	db := r.getDB(tx)
+	var answers []*models.PracticeAnswer
+	if err := db.WithContext(ctx).
+		Where("session_id = ?", sessionID).
+		Order("created_at ASC, id ASC").
+		Find(&answers).Error; err != nil {
+		return nil, fmt.Errorf("failed to get practice answers: %w", err)
+	}
+	return answers, nil
+}
+
+// ===== STATISTICS =====
+
+func (r *PracticePostgreSQL) GetSetStats(ctx context.Context, tx *gorm.DB, setID uint) (*repositories.PracticeSetStats, error) {
+	db := r.getDB(tx)
+	var stats repositories.PracticeSetStats
+	if err := db.WithContext(ctx).
+		Model(&models.PracticeSession{}).
+		Select(`COUNT(*) AS sessions,
+			COUNT(completed_at) AS completed_sessions,
+			COUNT(DISTINCT student_id) AS students,
+			COALESCE(SUM(score), 0) AS score,
+			COALESCE(SUM(max_score), 0) AS max_score`).
+		Where("practice_set_id = ?", setID).
+		Scan(&stats).Error; err != nil {
+		return nil, fmt.Errorf("failed to get practice set stats: %w", err)
+	}
+	return &stats, nil
+}
+
+func (r *PracticePostgreSQL) GetQuestionStats(ctx context.Context, tx *gorm.DB, setID uint) ([]repositories.PracticeQuestionStats, error) {
+	db := r.getDB(tx)
+	var stats []repositories.PracticeQuestionStats
+	if err := db.WithContext(ctx).
+		Table("practice_answers pa").
+		Select(`pa.question_id,
+			COUNT(*) AS answers,
+			COUNT(*) FILTER (WHERE pa.is_correct) AS correct,
+			SUM(pa.score) AS score,
+			SUM(pa.max_score) AS max_score`).
+		Joins("INNER JOIN practice_sessions ps ON ps.id = pa.session_id").
+		Where("ps.practice_set_id = ?", setID).
+		Group("pa.question_id").
+		Order("pa.question_id ASC").
+		Scan(&stats).Error; err != nil {
+		return nil, fmt.Errorf("failed to get practice question stats: %w", err)
+	}
+	return stats, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *PracticePostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
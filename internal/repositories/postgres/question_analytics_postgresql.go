@@ -0,0 +1,279 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const scoredResponseColumns = "student_answers.attempt_id, student_answers.answer, student_answers.answer_compressed, student_answers.is_correct, student_answers.score, student_answers.time_spent, aa.percentage AS attempt_percentage"
+
+type QuestionAnalyticsPostgreSQL struct {
+	db         *gorm.DB
+	compressor *cache.Compressor
+}
+
+func NewQuestionAnalyticsPostgreSQL(db *gorm.DB, compressor *cache.Compressor) repositories.QuestionAnalyticsRepository {
+	return &QuestionAnalyticsPostgreSQL{db: db, compressor: compressor}
+}
+
+// ===== BASIC OPERATIONS =====
+
+func (r *QuestionAnalyticsPostgreSQL) Upsert(ctx context.Context, tx *gorm.DB, analytics *models.QuestionAnalytics) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).
+		Omit("Question").
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "question_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"total_responses", "correct_responses", "difficulty_index", "discrimination_index",
+				"average_score", "average_time_spent", "option_stats",
+				"top_quartile_correct", "bottom_quartile_correct", "last_calculated_at", "updated_at",
+			}),
+		}).
+		Create(analytics).Error; err != nil {
+		return fmt.Errorf("failed to save question analytics: %w", err)
+	}
+	return nil
+}
+
+func (r *QuestionAnalyticsPostgreSQL) GetByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) (*models.QuestionAnalytics, error) {
+	db := r.getDB(tx)
+	var analytics models.QuestionAnalytics
+	if err := db.WithContext(ctx).
+		Where("question_id = ?", questionID).
+		First(&analytics).Error; err != nil {
+		return nil, err
+	}
+	return &analytics, nil
+}
+
+// ===== ANALYSIS INPUTS =====
+
+func (r *QuestionAnalyticsPostgreSQL) GetScoredResponses(ctx context.Context, tx *gorm.DB, questionID uint) ([]repositories.ScoredResponse, error) {
+	db := r.getDB(tx)
+	var responses []repositories.ScoredResponse
+	if err := db.WithContext(ctx).
+		Table("student_answers").
+		Select(scoredResponseColumns).
+		Joins("JOIN assessment_attempts aa ON aa.id = student_answers.attempt_id").
+		Where("COALESCE(student_answers.variant_question_id, student_answers.question_id) = ?", questionID).
+		Where("aa.status IN ?", []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Scan(&responses).Error; err != nil {
+		return nil, fmt.Errorf("failed to get scored responses: %w", err)
+	}
+	return r.decompressResponses(responses)
+}
+
+func (r *QuestionAnalyticsPostgreSQL) GetTrialResponses(ctx context.Context, tx *gorm.DB, trial *models.QuestionTrial, variant bool) ([]repositories.ScoredResponse, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).
+		Table("student_answers").
+		Select(scoredResponseColumns).
+		Joins("JOIN assessment_attempts aa ON aa.id = student_answers.attempt_id").
+		Where("student_answers.question_id = ?", trial.QuestionID).
+		Where("aa.assessment_id = ? AND aa.started_at >= ?", trial.AssessmentID, trial.CreatedAt).
+		Where("aa.status IN ?", []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut})
+	if variant {
+		query = query.Where("student_answers.variant_question_id = ?", trial.VariantQuestionID)
+	} else {
+		query = query.Where("student_answers.variant_question_id IS NULL")
+	}
+
+	var responses []repositories.ScoredResponse
+	if err := query.Scan(&responses).Error; err != nil {
+		return nil, fmt.Errorf("failed to get trial responses: %w", err)
+	}
+	return r.decompressResponses(responses)
+}
+
+func (r *QuestionAnalyticsPostgreSQL) GetStaleQuestionIDs(ctx context.Context, tx *gorm.DB, questionType models.QuestionType, limit int) ([]uint, error) {
+	db := r.getDB(tx)
+	var questionIDs []uint
+	if err := db.WithContext(ctx).
+		Table("student_answers").
+		Select("q.id").
+		Joins("JOIN questions q ON q.id = COALESCE(student_answers.variant_question_id, student_answers.question_id)").
+		Joins("JOIN assessment_attempts aa ON aa.id = student_answers.attempt_id").
+		Joins("LEFT JOIN question_analytics qa ON qa.question_id = q.id").
+		Where("q.type = ?", questionType).
+		Where("aa.status IN ?", []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Group("q.id").
+		Having("MAX(qa.last_calculated_at) IS NULL OR MAX(student_answers.updated_at) > MAX(qa.last_calculated_at)").
+		Order("MAX(student_answers.updated_at) ASC").
+		Limit(limit).
+		Pluck("q.id", &questionIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get stale questions: %w", err)
+	}
+	return questionIDs, nil
+}
+
+func (r *QuestionAnalyticsPostgreSQL) GetHistoricalStats(ctx context.Context, tx *gorm.DB, questionIDs []uint) ([]repositories.QuestionHistoricalStats, error) {
+	if len(questionIDs) == 0 {
+		return []repositories.QuestionHistoricalStats{}, nil
+	}
+
+	db := r.getDB(tx)
+	var stats []repositories.QuestionHistoricalStats
+	if err := db.WithContext(ctx).
+		Table("student_answers").
+		Select(`student_answers.question_id,
+			COUNT(*) AS responses,
+			COUNT(CASE WHEN student_answers.max_score > 0 THEN 1 END) AS scored_responses,
+			COALESCE(AVG(CASE WHEN student_answers.is_correct THEN 1.0 WHEN NOT student_answers.is_correct THEN 0.0 END), 0) AS correct_rate,
+			COALESCE(AVG(CASE WHEN student_answers.max_score > 0 THEN student_answers.score / student_answers.max_score END), 0) AS score_rate,
+			COALESCE(AVG(NULLIF(student_answers.time_spent, 0)), 0) AS average_time_spent`).
+		Joins("JOIN assessment_attempts aa ON aa.id = student_answers.attempt_id").
+		Where("student_answers.question_id IN ?", questionIDs).
+		Where("aa.status IN ?", []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Group("student_answers.question_id").
+		Scan(&stats).Error; err != nil {
+		return nil, fmt.Errorf("failed to get question historical stats: %w", err)
+	}
+	return stats, nil
+}
+
+func (r *QuestionAnalyticsPostgreSQL) GetAssessmentQuestionStats(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]repositories.QuestionHistoricalStats, error) {
+	db := r.getDB(tx)
+	var stats []repositories.QuestionHistoricalStats
+	if err := db.WithContext(ctx).
+		Table("student_answers").
+		Select(`student_answers.question_id,
+			COUNT(*) AS responses,
+			COUNT(CASE WHEN student_answers.max_score > 0 THEN 1 END) AS scored_responses,
+			COALESCE(AVG(CASE WHEN student_answers.is_correct THEN 1.0 WHEN NOT student_answers.is_correct THEN 0.0 END), 0) AS correct_rate,
+			COALESCE(AVG(CASE WHEN student_answers.max_score > 0 THEN student_answers.score / student_answers.max_score END), 0) AS score_rate,
+			COALESCE(AVG(NULLIF(student_answers.time_spent, 0)), 0) AS average_time_spent`).
+		Joins("JOIN assessment_attempts aa ON aa.id = student_answers.attempt_id").
+		Where("aa.assessment_id = ?", assessmentID).
+		Where("aa.status IN ?", []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Group("student_answers.question_id").
+		Scan(&stats).Error; err != nil {
+		return nil, fmt.Errorf("failed to get assessment question stats: %w", err)
+	}
+	return stats, nil
+}
+
+// ===== RECOMPUTE JOBS =====
+
+func (r *QuestionAnalyticsPostgreSQL) GetBankQuestionIDs(ctx context.Context, tx *gorm.DB, bankID uint) ([]uint, error) {
+	db := r.getDB(tx)
+	var questionIDs []uint
+	if err := db.WithContext(ctx).
+		Table("question_bank_questions").
+		Where("question_bank_id = ?", bankID).
+		Order("question_id ASC").
+		Pluck("question_id", &questionIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get bank questions: %w", err)
+	}
+	return questionIDs, nil
+}
+
+func (r *QuestionAnalyticsPostgreSQL) CreateStatsJob(ctx context.Context, tx *gorm.DB, job *models.QuestionStatsJob) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(job).Error; err != nil {
+		return fmt.Errorf("failed to create question stats job: %w", err)
+	}
+	return nil
+}
+
+func (r *QuestionAnalyticsPostgreSQL) GetStatsJobByID(ctx context.Context, tx *gorm.DB, id uint) (*models.QuestionStatsJob, error) {
+	db := r.getDB(tx)
+	var job models.QuestionStatsJob
+	if err := db.WithContext(ctx).Where("id = ?", id).First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *QuestionAnalyticsPostgreSQL) UpdateStatsJob(ctx context.Context, tx *gorm.DB, job *models.QuestionStatsJob) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(job).Error; err != nil {
+		return fmt.Errorf("failed to update question stats job: %w", err)
+	}
+	return nil
+}
+
+func (r *QuestionAnalyticsPostgreSQL) GetActiveStatsJob(ctx context.Context, tx *gorm.DB, questionID, bankID *uint) (*models.QuestionStatsJob, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).
+		Where("status IN ?", []models.QuestionStatsJobStatus{models.QuestionStatsJobPending, models.QuestionStatsJobRunning})
+	if questionID != nil {
+		query = query.Where("question_id = ?", *questionID)
+	} else {
+		query = query.Where("question_id IS NULL")
+	}
+	if bankID != nil {
+		query = query.Where("bank_id = ?", *bankID)
+	} else {
+		query = query.Where("bank_id IS NULL")
+	}
+
+	var job models.QuestionStatsJob
+	if err := query.Order("created_at DESC").First(&job).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *QuestionAnalyticsPostgreSQL) ClaimStatsJob(ctx context.Context, tx *gorm.DB, id uint, staleBefore time.Time) (bool, error) {
+	db := r.getDB(tx)
+	now := time.Now()
+	result := db.WithContext(ctx).
+		Model(&models.QuestionStatsJob{}).
+		Where("id = ?", id).
+		Where("status = ? OR (status = ? AND updated_at < ?)", models.QuestionStatsJobPending, models.QuestionStatsJobRunning, staleBefore).
+		Updates(map[string]interface{}{
+			"status":     models.QuestionStatsJobRunning,
+			"started_at": gorm.Expr("COALESCE(started_at, ?)", now),
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim question stats job: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *QuestionAnalyticsPostgreSQL) GetRunnableStatsJobs(ctx context.Context, tx *gorm.DB, staleBefore time.Time, limit int) ([]*models.QuestionStatsJob, error) {
+	db := r.getDB(tx)
+	var jobs []*models.QuestionStatsJob
+	if err := db.WithContext(ctx).
+		Where("status = ? OR (status = ? AND updated_at < ?)", models.QuestionStatsJobPending, models.QuestionStatsJobRunning, staleBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get runnable question stats jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// ===== HELPER METHODS =====
+
+// decompressResponses unpacks compressed answers, since scans skip the answer compression callbacks
+func (r *QuestionAnalyticsPostgreSQL) decompressResponses(responses []repositories.ScoredResponse) ([]repositories.ScoredResponse, error) {
+	for i := range responses {
+		if len(responses[i].AnswerCompressed) == 0 {
+			continue
+		}
+		answer, err := r.compressor.Decompress(cache.CompressionStoreAnswers, responses[i].AnswerCompressed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress answer of attempt %d: %w", responses[i].AttemptID, err)
+		}
+		responses[i].Answer = answer
+		responses[i].AnswerCompressed = nil
+	}
+	return responses, nil
+}
+
+func (r *QuestionAnalyticsPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
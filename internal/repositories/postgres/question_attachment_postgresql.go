@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type QuestionAttachmentPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewQuestionAttachmentPostgreSQL(db *gorm.DB) repositories.QuestionAttachmentRepository {
+	return &QuestionAttachmentPostgreSQL{db: db}
+}
+
+// ===== BASIC OPERATIONS =====
+
+func (r *QuestionAttachmentPostgreSQL) Create(ctx context.Context, tx *gorm.DB, attachment *models.QuestionAttachment) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Question").Create(attachment).Error; err != nil {
+		return fmt.Errorf("failed to create question attachment: %w", err)
+	}
+	return nil
+}
+
+func (r *QuestionAttachmentPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.QuestionAttachment, error) {
+	db := r.getDB(tx)
+	var attachment models.QuestionAttachment
+	if err := db.WithContext(ctx).First(&attachment, id).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (r *QuestionAttachmentPostgreSQL) Update(ctx context.Context, tx *gorm.DB, attachment *models.QuestionAttachment) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Question").Save(attachment).Error; err != nil {
+		return fmt.Errorf("failed to update question attachment: %w", err)
+	}
+	return nil
+}
+
+func (r *QuestionAttachmentPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).Delete(&models.QuestionAttachment{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete question attachment: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (r *QuestionAttachmentPostgreSQL) GetByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) ([]*models.QuestionAttachment, error) {
+	db := r.getDB(tx)
+	var attachments []*models.QuestionAttachment
+	if err := db.WithContext(ctx).
+		Where("question_id = ?", questionID).
+		Order(`"order" ASC, id ASC`).
+		Find(&attachments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get question attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+func (r *QuestionAttachmentPostgreSQL) GetByQuestions(ctx context.Context, tx *gorm.DB, questionIDs []uint) (map[uint][]*models.QuestionAttachment, error) {
+	result := make(map[uint][]*models.QuestionAttachment, len(questionIDs))
+	if len(questionIDs) == 0 {
+		return result, nil
+	}
+
+	db := r.getDB(tx)
+	var attachments []*models.QuestionAttachment
+	if err := db.WithContext(ctx).
+		Where("question_id IN ?", questionIDs).
+		Order(`question_id ASC, "order" ASC, id ASC`).
+		Find(&attachments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get question attachments: %w", err)
+	}
+	for _, attachment := range attachments {
+		result[attachment.QuestionID] = append(result[attachment.QuestionID], attachment)
+	}
+	return result, nil
+}
+
+// ===== BULK OPERATIONS =====
+
+func (r *QuestionAttachmentPostgreSQL) CreateBatch(ctx context.Context, tx *gorm.DB, attachments []*models.QuestionAttachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Question").Create(&attachments).Error; err != nil {
+		return fmt.Errorf("failed to create question attachments: %w", err)
+	}
+	return nil
+}
+
+func (r *QuestionAttachmentPostgreSQL) DeleteByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).
+		Where("question_id = ?", questionID).
+		Delete(&models.QuestionAttachment{}).Error; err != nil {
+		return fmt.Errorf("failed to delete question attachments: %w", err)
+	}
+	return nil
+}
+
+// ===== FILE MANAGEMENT =====
+
+// GetOrphanedAttachments returns attachments whose question was deleted
+func (r *QuestionAttachmentPostgreSQL) GetOrphanedAttachments(ctx context.Context, tx *gorm.DB) ([]*models.QuestionAttachment, error) {
+	db := r.getDB(tx)
+	var attachments []*models.QuestionAttachment
+	if err := db.WithContext(ctx).
+		Where("NOT EXISTS (SELECT 1 FROM questions WHERE questions.id = question_attachments.question_id AND questions.deleted_at IS NULL)").
+		Find(&attachments).Error; err != nil {
+		return nil, fmt.Errorf("failed to get orphaned question attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+func (r *QuestionAttachmentPostgreSQL) UpdateOrder(ctx context.Context, tx *gorm.DB, questionID uint, attachmentOrders []repositories.AttachmentOrder) error {
+	db := r.getDB(tx)
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, order := range attachmentOrders {
+			if err := tx.Model(&models.QuestionAttachment{}).
+				Where("id = ? AND question_id = ?", order.AttachmentID, questionID).
+				Update("order", order.Order).Error; err != nil {
+				return fmt.Errorf("failed to update question attachment order: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ===== HELPER METHODS =====
+
+func (r *QuestionAttachmentPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
@@ -331,6 +332,7 @@ func (r *questionBankRepository) GetBankQuestions(ctx context.Context, tx *gorm.
 	if filters.CategoryID != nil {
 		query = query.Where("q.category_id = ?", *filters.CategoryID)
 	}
+	query = applyTranslationFilters(query, "q.id", filters)
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -361,6 +363,67 @@ func (r *questionBankRepository) IsQuestionInBank(ctx context.Context, tx *gorm.
 	return count > 0, nil
 }
 
+func (r *questionBankRepository) GetQuestionBankIDs(ctx context.Context, tx *gorm.DB, questionIDs []uint) (map[uint][]uint, error) {
+	bankIDs := make(map[uint][]uint)
+	if len(questionIDs) == 0 {
+		return bankIDs, nil
+	}
+
+	db := r.getDB(tx)
+	var rows []struct {
+		QuestionID     uint
+		QuestionBankID uint
+	}
+	if err := db.WithContext(ctx).
+		Table("question_bank_questions qbq").
+		Select("qbq.question_id, qbq.question_bank_id").
+		Joins("INNER JOIN question_banks qb ON qb.id = qbq.question_bank_id AND qb.deleted_at IS NULL").
+		Where("qbq.question_id IN ?", questionIDs).
+		Order("qbq.question_id, qbq.question_bank_id").
+		Scan(&rows).Error; err != nil {
+		return nil, r.handleDBError(err, "get question bank ids")
+	}
+
+	for _, row := range rows {
+		bankIDs[row.QuestionID] = append(bankIDs[row.QuestionID], row.QuestionBankID)
+	}
+	return bankIDs, nil
+}
+
+// ===== CHANGE FEED =====
+
+func (r *questionBankRepository) RecordChanges(ctx context.Context, tx *gorm.DB, changes []*models.QuestionBankChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).CreateInBatches(changes, 100).Error; err != nil {
+		return r.handleDBError(err, "record question bank changes")
+	}
+	return nil
+}
+
+func (r *questionBankRepository) GetChanges(ctx context.Context, tx *gorm.DB, bankID uint, after *repositories.PageCursor, limit int) ([]*models.QuestionBankChange, error) {
+	db := r.getDB(tx)
+	var changes []*models.QuestionBankChange
+
+	query := db.WithContext(ctx).
+		Where("bank_id = ?", bankID).
+		Order("created_at ASC, id ASC")
+	if after != nil {
+		query = query.Where("(created_at, id) > (?, ?)", after.CreatedAt, after.ID)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&changes).Error; err != nil {
+		return nil, r.handleDBError(err, "get question bank changes")
+	}
+	return changes, nil
+}
+
 // ===== PERMISSION CHECKS =====
 
 func (r *questionBankRepository) CanAccess(ctx context.Context, tx *gorm.DB, bankID uint, userID string) (bool, error) {
@@ -582,6 +645,180 @@ func (r *questionBankRepository) UpdateUsage(ctx context.Context, tx *gorm.DB, b
 	return nil
 }
 
+// ===== LICENSING =====
+
+func (r *questionBankRepository) GetLicensedBanks(ctx context.Context, tx *gorm.DB, creatorID string) ([]*models.QuestionBank, error) {
+	db := r.getDB(tx)
+	var banks []*models.QuestionBank
+
+	if err := db.WithContext(ctx).
+		Where("is_licensed = ? AND created_by = ?", true, creatorID).
+		Order("name ASC").
+		Find(&banks).Error; err != nil {
+		return nil, r.handleDBError(err, "get licensed banks")
+	}
+
+	return banks, nil
+}
+
+// GetLicensedBanksForAssessment returns the licensed banks holding any of the assessment's questions
+func (r *questionBankRepository) GetLicensedBanksForAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.QuestionBank, error) {
+	db := r.getDB(tx)
+	var banks []*models.QuestionBank
+
+	if err := db.WithContext(ctx).
+		Where("is_licensed = ?", true).
+		Where("id IN (?)", db.Table("question_bank_questions qbq").
+			Select("qbq.question_bank_id").
+			Joins("INNER JOIN assessment_questions aq ON aq.question_id = qbq.question_id").
+			Where("aq.assessment_id = ?", assessmentID)).
+		Find(&banks).Error; err != nil {
+		return nil, r.handleDBError(err, "get licensed banks for assessment")
+	}
+
+	return banks, nil
+}
+
+// GetLicenseExposure counts the students, questions and assessments that have put the
+// bank's questions in front of a student through an attempt
+func (r *questionBankRepository) GetLicenseExposure(ctx context.Context, tx *gorm.DB, bankID uint) (*repositories.BankLicenseExposure, error) {
+	db := r.getDB(tx)
+
+	type exposureRow struct {
+		StudentsExposed  int
+		QuestionsExposed int
+		AssessmentsUsing int
+		LastExposedAt    *time.Time
+	}
+	var row exposureRow
+
+	if err := db.WithContext(ctx).
+		Table("question_bank_questions qbq").
+		Select(`COUNT(DISTINCT att.student_id) as students_exposed,
+			COUNT(DISTINCT qbq.question_id) as questions_exposed,
+			COUNT(DISTINCT att.assessment_id) as assessments_using,
+			MAX(att.started_at) as last_exposed_at`).
+		Joins("INNER JOIN assessment_questions aq ON aq.question_id = qbq.question_id").
+		Joins("INNER JOIN assessment_attempts att ON att.assessment_id = aq.assessment_id").
+		Where("qbq.question_bank_id = ? AND att.started_at IS NOT NULL", bankID).
+		Scan(&row).Error; err != nil {
+		return nil, r.handleDBError(err, "get license exposure")
+	}
+
+	return &repositories.BankLicenseExposure{
+		StudentsExposed:  row.StudentsExposed,
+		QuestionsExposed: row.QuestionsExposed,
+		AssessmentsUsing: row.AssessmentsUsing,
+		LastExposedAt:    row.LastExposedAt,
+	}, nil
+}
+
+func (r *questionBankRepository) IsStudentExposed(ctx context.Context, tx *gorm.DB, bankID uint, studentID string) (bool, error) {
+	db := r.getDB(tx)
+	var count int64
+
+	if err := db.WithContext(ctx).
+		Table("question_bank_questions qbq").
+		Joins("INNER JOIN assessment_questions aq ON aq.question_id = qbq.question_id").
+		Joins("INNER JOIN assessment_attempts att ON att.assessment_id = aq.assessment_id").
+		Where("qbq.question_bank_id = ? AND att.student_id = ? AND att.started_at IS NOT NULL", bankID, studentID).
+		Count(&count).Error; err != nil {
+		return false, r.handleDBError(err, "check student license exposure")
+	}
+
+	return count > 0, nil
+}
+
+// ===== CONTENT REVIEW =====
+
+func (r *questionBankRepository) GetQuestionReviewStates(ctx context.Context, tx *gorm.DB, bankID uint) ([]repositories.QuestionReviewState, error) {
+	db := r.getDB(tx)
+	var states []repositories.QuestionReviewState
+
+	query := db.WithContext(ctx).
+		Table("question_bank_questions qbq").
+		Select(`qb.id as bank_id, qb.name as bank_name, qb.created_by as owner_id,
+			qb.review_reminder_sent_at as reminder_sent_at,
+			q.id as question_id, q.text as question_text, q.created_at,
+			q.last_reviewed_at, q.review_interval_months`).
+		Joins("INNER JOIN question_banks qb ON qb.id = qbq.question_bank_id AND qb.deleted_at IS NULL").
+		Joins("INNER JOIN questions q ON q.id = qbq.question_id AND q.deleted_at IS NULL")
+	if bankID != 0 {
+		query = query.Where("qbq.question_bank_id = ?", bankID)
+	}
+
+	if err := query.Order("qb.id, q.id").Scan(&states).Error; err != nil {
+		return nil, r.handleDBError(err, "get question review states")
+	}
+
+	return states, nil
+}
+
+// GetCorrectRateWindows counts graded answers from finished attempts completed in the window
+// before split and in the window before that. Answers served as a trial variant are left out.
+func (r *questionBankRepository) GetCorrectRateWindows(ctx context.Context, tx *gorm.DB, questionIDs []uint, split time.Time, window time.Duration) ([]repositories.CorrectRateWindows, error) {
+	if len(questionIDs) == 0 {
+		return nil, nil
+	}
+
+	db := r.getDB(tx)
+	recentFrom := split.Add(-window)
+	priorFrom := recentFrom.Add(-window)
+	var windows []repositories.CorrectRateWindows
+
+	if err := db.WithContext(ctx).
+		Table("student_answers sa").
+		Select(`sa.question_id,
+			COUNT(*) FILTER (WHERE aa.completed_at >= ?) as recent_responses,
+			COUNT(*) FILTER (WHERE aa.completed_at >= ? AND sa.is_correct) as recent_correct,
+			COUNT(*) FILTER (WHERE aa.completed_at < ?) as prior_responses,
+			COUNT(*) FILTER (WHERE aa.completed_at < ? AND sa.is_correct) as prior_correct`,
+			recentFrom, recentFrom, recentFrom, recentFrom).
+		Joins("INNER JOIN assessment_attempts aa ON aa.id = sa.attempt_id").
+		Where("sa.question_id IN ? AND sa.variant_question_id IS NULL AND sa.is_correct IS NOT NULL", questionIDs).
+		Where("aa.status IN ?", []models.AttemptStatus{models.AttemptCompleted, models.AttemptTimeOut}).
+		Where("aa.completed_at >= ? AND aa.completed_at < ?", priorFrom, split).
+		Group("sa.question_id").
+		Scan(&windows).Error; err != nil {
+		return nil, r.handleDBError(err, "get correct rate windows")
+	}
+
+	return windows, nil
+}
+
+// MarkQuestionsReviewed stamps the bank's questions as reviewed; no question IDs marks all of them
+func (r *questionBankRepository) MarkQuestionsReviewed(ctx context.Context, tx *gorm.DB, bankID uint, questionIDs []uint, reviewedAt time.Time) (int64, error) {
+	db := r.getDB(tx)
+
+	query := db.WithContext(ctx).
+		Model(&models.Question{}).
+		Where("id IN (?)", db.Table("question_bank_questions").Select("question_id").Where("question_bank_id = ?", bankID))
+	if len(questionIDs) > 0 {
+		query = query.Where("id IN ?", questionIDs)
+	}
+
+	result := query.UpdateColumn("last_reviewed_at", reviewedAt)
+	if result.Error != nil {
+		return 0, r.handleDBError(result.Error, "mark questions reviewed")
+	}
+
+	return result.RowsAffected, nil
+}
+
+// SetReviewReminderSent claims the bank's review reminder; it reports false when the owner was
+// already reminded after dueBefore, e.g. by another replica
+func (r *questionBankRepository) SetReviewReminderSent(ctx context.Context, tx *gorm.DB, bankID uint, sentAt, dueBefore time.Time) (bool, error) {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Model(&models.QuestionBank{}).
+		Where("id = ? AND (review_reminder_sent_at IS NULL OR review_reminder_sent_at <= ?)", bankID, dueBefore).
+		UpdateColumn("review_reminder_sent_at", sentAt)
+	if result.Error != nil {
+		return false, r.handleDBError(result.Error, "set review reminder sent")
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // ===== HELPER METHODS =====
 
 func (r *questionBankRepository) getDB(tx *gorm.DB) *gorm.DB {
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type QuestionExternalRefPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewQuestionExternalRefPostgreSQL(db *gorm.DB) repositories.QuestionExternalRefRepository {
+	return &QuestionExternalRefPostgreSQL{db: db}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (r *QuestionExternalRefPostgreSQL) Create(ctx context.Context, tx *gorm.DB, ref *models.QuestionExternalRef) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(ref).Error; err != nil {
+		return fmt.Errorf("failed to create question external ref: %w", err)
+	}
+	return nil
+}
+
+func (r *QuestionExternalRefPostgreSQL) Update(ctx context.Context, tx *gorm.DB, ref *models.QuestionExternalRef) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(ref).Error; err != nil {
+		return fmt.Errorf("failed to update question external ref: %w", err)
+	}
+	return nil
+}
+
+func (r *QuestionExternalRefPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Delete(&models.QuestionExternalRef{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete question external ref: %w", err)
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (r *QuestionExternalRefPostgreSQL) GetByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) ([]*models.QuestionExternalRef, error) {
+	db := r.getDB(tx)
+	var refs []*models.QuestionExternalRef
+	if err := db.WithContext(ctx).
+		Where("question_id = ?", questionID).
+		Order("provider ASC").
+		Find(&refs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get question external refs: %w", err)
+	}
+	return refs, nil
+}
+
+func (r *QuestionExternalRefPostgreSQL) GetByQuestions(ctx context.Context, tx *gorm.DB, questionIDs []uint) (map[uint][]*models.QuestionExternalRef, error) {
+	result := make(map[uint][]*models.QuestionExternalRef)
+	if len(questionIDs) == 0 {
+		return result, nil
+	}
+
+	db := r.getDB(tx)
+	var refs []*models.QuestionExternalRef
+	if err := db.WithContext(ctx).
+		Where("question_id IN ?", questionIDs).
+		Order("question_id ASC, provider ASC").
+		Find(&refs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get question external refs: %w", err)
+	}
+	for _, ref := range refs {
+		result[ref.QuestionID] = append(result[ref.QuestionID], ref)
+	}
+	return result, nil
+}
+
+func (r *QuestionExternalRefPostgreSQL) GetByQuestionAndProvider(ctx context.Context, tx *gorm.DB, questionID uint, provider string) (*models.QuestionExternalRef, error) {
+	db := r.getDB(tx)
+	var ref models.QuestionExternalRef
+	if err := db.WithContext(ctx).
+		Where("question_id = ? AND provider = ?", questionID, provider).
+		First(&ref).Error; err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
+
+func (r *QuestionExternalRefPostgreSQL) GetByExternalID(ctx context.Context, tx *gorm.DB, ownerID, provider, externalID string) (*models.QuestionExternalRef, error) {
+	db := r.getDB(tx)
+	var ref models.QuestionExternalRef
+	if err := db.WithContext(ctx).
+		Where("owner_id = ? AND provider = ? AND external_id = ?", ownerID, provider, externalID).
+		First(&ref).Error; err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
+
+func (r *QuestionExternalRefPostgreSQL) GetByExternalIDs(ctx context.Context, tx *gorm.DB, ownerID, provider string, externalIDs []string) ([]*models.QuestionExternalRef, error) {
+	if len(externalIDs) == 0 {
+		return nil, nil
+	}
+
+	db := r.getDB(tx)
+	var refs []*models.QuestionExternalRef
+	if err := db.WithContext(ctx).
+		Where("owner_id = ? AND provider = ? AND external_id IN ?", ownerID, provider, externalIDs).
+		Order("external_id ASC").
+		Find(&refs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get question external refs: %w", err)
+	}
+	return refs, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *QuestionExternalRefPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/SAP-F-2025/assessment-service/internal/cache"
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
-	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
@@ -20,11 +19,11 @@ type QuestionPostgreSQL struct {
 	cacheManager *cache.CacheManager
 }
 
-func NewQuestionPostgreSQL(db *gorm.DB, redisClient *redis.Client) repositories.QuestionRepository {
+func NewQuestionPostgreSQL(db *gorm.DB, redisClient *cache.Client) repositories.QuestionRepository {
 	return &QuestionPostgreSQL{
 		db:           db,
 		helpers:      NewSharedHelpers(db),
-		cacheManager: cache.NewCacheManager(redisClient),
+		cacheManager: cache.NewCacheManager(redisClient, cache.RepositoryQuestion),
 	}
 }
 
@@ -51,7 +50,7 @@ func (q *QuestionPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint)
 	cacheKey := fmt.Sprintf("id:%d", id)
 	var question models.Question
 
-	err := q.cacheManager.Question.CacheOrExecute(ctx, cacheKey, &question, cache.QuestionCacheConfig.TTL, func() (interface{}, error) {
+	err := q.cacheManager.Question.CacheOrExecute(ctx, cacheKey, &question, q.cacheManager.Question.TTL(), func() (interface{}, error) {
 		var dbQuestion models.Question
 		if err := db.WithContext(ctx).First(&dbQuestion, id).Error; err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -145,6 +144,7 @@ func (q *QuestionPostgreSQL) GetByIDs(ctx context.Context, tx *gorm.DB, ids []ui
 	db := q.getDB(tx)
 	var questions []*models.Question
 	if err := db.WithContext(ctx).
+		Preload("Category").
 		Where("id IN ?", ids).
 		Find(&questions).Error; err != nil {
 		return nil, fmt.Errorf("failed to get questions by IDs: %w", err)
@@ -176,6 +176,7 @@ func (q *QuestionPostgreSQL) List(ctx context.Context, tx *gorm.DB, filters repo
 
 	// Apply filters
 	query = q.applyQuestionFilters(query, filters)
+	query = applyTranslationFilters(query, "questions.id", filters)
 
 	// Count total records
 	var total int64
@@ -247,6 +248,7 @@ func (q *QuestionPostgreSQL) Search(ctx context.Context, tx *gorm.DB, query stri
 
 	// Apply additional filters
 	dbQuery = q.applyQuestionFilters(dbQuery, filters)
+	dbQuery = applyTranslationFilters(dbQuery, "questions.id", filters)
 
 	// Count total records
 	var total int64
@@ -274,7 +276,7 @@ func (q *QuestionPostgreSQL) GetByAssessment(ctx context.Context, tx *gorm.DB, a
 	cacheKey := fmt.Sprintf("assessment:%d", assessmentID)
 	var questions []*models.Question
 
-	err := q.cacheManager.Question.CacheOrExecute(ctx, cacheKey, &questions, cache.QuestionCacheConfig.TTL, func() (interface{}, error) {
+	err := q.cacheManager.Question.CacheOrExecute(ctx, cacheKey, &questions, q.cacheManager.Question.TTL(), func() (interface{}, error) {
 		var dbQuestions []*models.Question
 		if err := db.WithContext(ctx).
 			Joins("JOIN assessment_questions aq ON aq.question_id = questions.id").
@@ -292,7 +294,7 @@ func (q *QuestionPostgreSQL) GetByAssessment(ctx context.Context, tx *gorm.DB, a
 // GetRandomQuestions retrieves random questions based on filters
 func (q *QuestionPostgreSQL) GetRandomQuestions(ctx context.Context, tx *gorm.DB, filters repositories.RandomQuestionFilters) ([]*models.Question, error) {
 	db := q.getDB(tx)
-	query := db.WithContext(ctx).Model(&models.Question{})
+	query := db.WithContext(ctx).Model(&models.Question{}).Where("is_draft = ?", false)
 
 	// Apply filters
 	if filters.CategoryID != nil {
@@ -371,6 +373,7 @@ func (q *QuestionPostgreSQL) GetByTags(ctx context.Context, tx *gorm.DB, tags []
 
 	// Apply additional filters
 	query = q.applyQuestionFilters(query, filters)
+	query = applyTranslationFilters(query, "questions.id", filters)
 
 	// Apply pagination and sorting
 	query = q.helpers.ApplyPaginationAndSort(query, filters.SortBy, filters.SortOrder, filters.Limit, filters.Offset)
@@ -615,6 +618,7 @@ func (q *QuestionPostgreSQL) GetByBank(ctx context.Context, bankID uint, filters
 
 	// Apply question filters
 	query = q.applyQuestionFilters(query, filters)
+	query = applyTranslationFilters(query, "q.id", filters)
 
 	// Count total
 	if err := query.Count(&total).Error; err != nil {
@@ -707,6 +711,9 @@ func (q *QuestionPostgreSQL) applyQuestionFilters(query *gorm.DB, filters reposi
 	if filters.CreatedBy != nil {
 		query = query.Where("created_by = ?", *filters.CreatedBy)
 	}
+	if filters.IsDraft != nil {
+		query = query.Where("is_draft = ?", *filters.IsDraft)
+	}
 	if len(filters.Tags) > 0 {
 		for _, tag := range filters.Tags {
 			query = query.Where("tags::text LIKE ?", "%\""+tag+"\"%")
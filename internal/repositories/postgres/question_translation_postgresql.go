@@ -0,0 +1,199 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// reviewableTranslationStatuses are the statuses the review queue shows by default
+var reviewableTranslationStatuses = []models.TranslationStatus{
+	models.TranslationPendingReview,
+	models.TranslationStale,
+}
+
+type QuestionTranslationPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewQuestionTranslationPostgreSQL(db *gorm.DB) repositories.QuestionTranslationRepository {
+	return &QuestionTranslationPostgreSQL{db: db}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (r *QuestionTranslationPostgreSQL) Create(ctx context.Context, tx *gorm.DB, translation *models.QuestionTranslation) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(translation).Error; err != nil {
+		return fmt.Errorf("failed to create question translation: %w", err)
+	}
+	return nil
+}
+
+func (r *QuestionTranslationPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.QuestionTranslation, error) {
+	db := r.getDB(tx)
+	var translation models.QuestionTranslation
+	if err := db.WithContext(ctx).First(&translation, id).Error; err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+func (r *QuestionTranslationPostgreSQL) GetByQuestionAndLocale(ctx context.Context, tx *gorm.DB, questionID uint, locale string) (*models.QuestionTranslation, error) {
+	db := r.getDB(tx)
+	var translation models.QuestionTranslation
+	if err := db.WithContext(ctx).
+		Where("question_id = ? AND locale = ?", questionID, locale).
+		First(&translation).Error; err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+func (r *QuestionTranslationPostgreSQL) Update(ctx context.Context, tx *gorm.DB, translation *models.QuestionTranslation) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Omit("Question").Save(translation).Error; err != nil {
+		return fmt.Errorf("failed to update question translation: %w", err)
+	}
+	return nil
+}
+
+func (r *QuestionTranslationPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Delete(&models.QuestionTranslation{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete question translation: %w", err)
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (r *QuestionTranslationPostgreSQL) GetByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) ([]*models.QuestionTranslation, error) {
+	db := r.getDB(tx)
+	var translations []*models.QuestionTranslation
+	if err := db.WithContext(ctx).
+		Where("question_id = ?", questionID).
+		Order("locale ASC").
+		Find(&translations).Error; err != nil {
+		return nil, fmt.Errorf("failed to get question translations: %w", err)
+	}
+	return translations, nil
+}
+
+func (r *QuestionTranslationPostgreSQL) GetLocaleStatuses(ctx context.Context, tx *gorm.DB, questionIDs []uint) (map[uint][]models.LocaleStatus, error) {
+	statuses := make(map[uint][]models.LocaleStatus)
+	if len(questionIDs) == 0 {
+		return statuses, nil
+	}
+
+	db := r.getDB(tx)
+	var rows []models.LocaleStatus
+	if err := db.WithContext(ctx).
+		Model(&models.QuestionTranslation{}).
+		Select("question_id, locale, status").
+		Where("question_id IN ?", questionIDs).
+		Order("question_id ASC, locale ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get translation statuses: %w", err)
+	}
+
+	for _, row := range rows {
+		statuses[row.QuestionID] = append(statuses[row.QuestionID], row)
+	}
+	return statuses, nil
+}
+
+// ===== REVIEW WORKFLOW =====
+
+func (r *QuestionTranslationPostgreSQL) MarkStale(ctx context.Context, tx *gorm.DB, questionID uint, exceptLocale string, cause string, at time.Time) (int64, error) {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Model(&models.QuestionTranslation{}).
+		Where("question_id = ? AND locale <> ? AND status <> ?", questionID, exceptLocale, models.TranslationStale).
+		Updates(map[string]interface{}{
+			"status":        models.TranslationStale,
+			"stale_since":   at,
+			"stale_because": cause,
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to mark translations stale: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *QuestionTranslationPostgreSQL) GetReviewQueue(ctx context.Context, tx *gorm.DB, filters repositories.TranslationReviewFilters) ([]*models.QuestionTranslation, int64, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).
+		Model(&models.QuestionTranslation{}).
+		Joins("JOIN questions ON questions.id = question_translations.question_id AND questions.deleted_at IS NULL")
+
+	if filters.Status != nil {
+		query = query.Where("question_translations.status = ?", *filters.Status)
+	} else {
+		query = query.Where("question_translations.status IN ?", reviewableTranslationStatuses)
+	}
+	if filters.Locale != nil {
+		query = query.Where("question_translations.locale = ?", *filters.Locale)
+	}
+	if filters.CreatedBy != nil {
+		query = query.Where("questions.created_by = ?", *filters.CreatedBy)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count translation review queue: %w", err)
+	}
+
+	var translations []*models.QuestionTranslation
+	if err := query.
+		Preload("Question").
+		Order("COALESCE(question_translations.stale_since, question_translations.updated_at) ASC, question_translations.id ASC").
+		Limit(filters.Limit).
+		Offset(filters.Offset).
+		Find(&translations).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to get translation review queue: %w", err)
+	}
+
+	return translations, total, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *QuestionTranslationPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
+
+// applyTranslationFilters narrows a question query by translation locale and status;
+// questionIDColumn is the question ID column of the query
+func applyTranslationFilters(query *gorm.DB, questionIDColumn string, filters repositories.QuestionFilters) *gorm.DB {
+	if filters.Locale == nil && filters.TranslationStatus == nil {
+		return query
+	}
+
+	if filters.TranslationStatus != nil && *filters.TranslationStatus == models.TranslationMissing {
+		subquery := "SELECT 1 FROM question_translations qt WHERE qt.question_id = " + questionIDColumn
+		if filters.Locale != nil {
+			return query.Where("NOT EXISTS ("+subquery+" AND qt.locale = ?)", *filters.Locale)
+		}
+		return query.Where("NOT EXISTS (" + subquery + ")")
+	}
+
+	conditions := "qt.question_id = " + questionIDColumn
+	var args []interface{}
+	if filters.Locale != nil {
+		conditions += " AND qt.locale = ?"
+		args = append(args, *filters.Locale)
+	}
+	if filters.TranslationStatus != nil {
+		conditions += " AND qt.status = ?"
+		args = append(args, *filters.TranslationStatus)
+	}
+	return query.Where("EXISTS (SELECT 1 FROM question_translations qt WHERE "+conditions+")", args...)
+}
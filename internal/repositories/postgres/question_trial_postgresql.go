@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type QuestionTrialPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewQuestionTrialPostgreSQL(db *gorm.DB) repositories.QuestionTrialRepository {
+	return &QuestionTrialPostgreSQL{db: db}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (r *QuestionTrialPostgreSQL) Create(ctx context.Context, tx *gorm.DB, trial *models.QuestionTrial) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(trial).Error; err != nil {
+		return fmt.Errorf("failed to create question trial: %w", err)
+	}
+	return nil
+}
+
+func (r *QuestionTrialPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.QuestionTrial, error) {
+	db := r.getDB(tx)
+	var trial models.QuestionTrial
+	if err := db.WithContext(ctx).First(&trial, id).Error; err != nil {
+		return nil, err
+	}
+	return &trial, nil
+}
+
+func (r *QuestionTrialPostgreSQL) Update(ctx context.Context, tx *gorm.DB, trial *models.QuestionTrial) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(trial).Error; err != nil {
+		return fmt.Errorf("failed to update question trial: %w", err)
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (r *QuestionTrialPostgreSQL) GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.QuestionTrial, error) {
+	db := r.getDB(tx)
+	var trials []*models.QuestionTrial
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ?", assessmentID).
+		Order("created_at DESC, id DESC").
+		Find(&trials).Error; err != nil {
+		return nil, fmt.Errorf("failed to get question trials: %w", err)
+	}
+	return trials, nil
+}
+
+func (r *QuestionTrialPostgreSQL) GetRunningByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.QuestionTrial, error) {
+	db := r.getDB(tx)
+	var trials []*models.QuestionTrial
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ? AND status = ?", assessmentID, models.QuestionTrialRunning).
+		Order("id ASC").
+		Find(&trials).Error; err != nil {
+		return nil, fmt.Errorf("failed to get running question trials: %w", err)
+	}
+	return trials, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *QuestionTrialPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
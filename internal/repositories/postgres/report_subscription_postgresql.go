@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type ReportSubscriptionPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewReportSubscriptionPostgreSQL(db *gorm.DB) repositories.ReportSubscriptionRepository {
+	return &ReportSubscriptionPostgreSQL{db: db}
+}
+
+// ===== BASIC CRUD OPERATIONS =====
+
+func (r *ReportSubscriptionPostgreSQL) Create(ctx context.Context, tx *gorm.DB, subscription *models.ReportSubscription) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(subscription).Error; err != nil {
+		return fmt.Errorf("failed to create report subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *ReportSubscriptionPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.ReportSubscription, error) {
+	db := r.getDB(tx)
+	var subscription models.ReportSubscription
+	if err := db.WithContext(ctx).First(&subscription, id).Error; err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *ReportSubscriptionPostgreSQL) Update(ctx context.Context, tx *gorm.DB, subscription *models.ReportSubscription) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(subscription).Error; err != nil {
+		return fmt.Errorf("failed to update report subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *ReportSubscriptionPostgreSQL) Delete(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Delete(&models.ReportSubscription{}, id).Error; err != nil {
+		return fmt.Errorf("failed to delete report subscription: %w", err)
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (r *ReportSubscriptionPostgreSQL) GetByUser(ctx context.Context, tx *gorm.DB, userID string) ([]*models.ReportSubscription, error) {
+	db := r.getDB(tx)
+	var subscriptions []*models.ReportSubscription
+	if err := db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&subscriptions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get report subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (r *ReportSubscriptionPostgreSQL) ExistsByUserAndType(ctx context.Context, tx *gorm.DB, userID string, reportType models.ReportType) (bool, error) {
+	db := r.getDB(tx)
+	var count int64
+	if err := db.WithContext(ctx).
+		Model(&models.ReportSubscription{}).
+		Where("user_id = ? AND report_type = ?", userID, reportType).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check report subscription: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ===== SCHEDULING =====
+
+func (r *ReportSubscriptionPostgreSQL) GetDue(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]*models.ReportSubscription, error) {
+	db := r.getDB(tx)
+	var subscriptions []*models.ReportSubscription
+	query := db.WithContext(ctx).
+		Where("is_active = ? AND next_run_at <= ?", true, now).
+		Order("next_run_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&subscriptions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get due report subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (r *ReportSubscriptionPostgreSQL) ClaimRun(ctx context.Context, tx *gorm.DB, id uint, dueAt, nextRunAt time.Time) (bool, error) {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Model(&models.ReportSubscription{}).
+		Where("id = ? AND next_run_at = ?", id, dueAt).
+		Update("next_run_at", nextRunAt)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim report subscription: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *ReportSubscriptionPostgreSQL) SetLastSent(ctx context.Context, tx *gorm.DB, id uint, sentAt time.Time) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).
+		Model(&models.ReportSubscription{}).
+		Where("id = ?", id).
+		UpdateColumn("last_sent_at", sentAt).Error; err != nil {
+		return fmt.Errorf("failed to record report delivery: %w", err)
+	}
+	return nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *ReportSubscriptionPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
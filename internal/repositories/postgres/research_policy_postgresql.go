@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type ResearchPolicyPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewResearchPolicyPostgreSQL(db *gorm.DB) repositories.ResearchPolicyRepository {
+	return &ResearchPolicyPostgreSQL{db: db}
+}
+
+func (r *ResearchPolicyPostgreSQL) Create(ctx context.Context, tx *gorm.DB, policy *models.ResearchPolicy) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(policy).Error; err != nil {
+		return fmt.Errorf("failed to create research policy: %w", err)
+	}
+	return nil
+}
+
+func (r *ResearchPolicyPostgreSQL) Update(ctx context.Context, tx *gorm.DB, policy *models.ResearchPolicy) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(policy).Error; err != nil {
+		return fmt.Errorf("failed to update research policy: %w", err)
+	}
+	return nil
+}
+
+func (r *ResearchPolicyPostgreSQL) GetByOrganization(ctx context.Context, tx *gorm.DB, organization string) (*models.ResearchPolicy, error) {
+	db := r.getDB(tx)
+	var policy models.ResearchPolicy
+	if err := db.WithContext(ctx).Where("organization = ?", organization).First(&policy).Error; err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *ResearchPolicyPostgreSQL) List(ctx context.Context, tx *gorm.DB) ([]*models.ResearchPolicy, error) {
+	db := r.getDB(tx)
+	var policies []*models.ResearchPolicy
+	if err := db.WithContext(ctx).Order("organization ASC").Find(&policies).Error; err != nil {
+		return nil, fmt.Errorf("failed to list research policies: %w", err)
+	}
+	return policies, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *ResearchPolicyPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
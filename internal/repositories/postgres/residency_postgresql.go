@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/residency"
+	"gorm.io/gorm"
+)
+
+type ResidencyPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewResidencyPostgreSQL(db *gorm.DB) repositories.ResidencyRepository {
+	return &ResidencyPostgreSQL{db: db}
+}
+
+func (r *ResidencyPostgreSQL) Create(ctx context.Context, tx *gorm.DB, assignment *models.OrganizationResidency) error {
+	db := r.getDB(ctx, tx)
+	if err := db.Create(assignment).Error; err != nil {
+		return fmt.Errorf("failed to create organization residency: %w", err)
+	}
+	return nil
+}
+
+func (r *ResidencyPostgreSQL) GetByOrganization(ctx context.Context, tx *gorm.DB, organization string) (*models.OrganizationResidency, error) {
+	db := r.getDB(ctx, tx)
+	var assignment models.OrganizationResidency
+	if err := db.Where("organization = ?", organization).First(&assignment).Error; err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+func (r *ResidencyPostgreSQL) List(ctx context.Context, tx *gorm.DB) ([]*models.OrganizationResidency, error) {
+	db := r.getDB(ctx, tx)
+	var assignments []*models.OrganizationResidency
+	if err := db.Order("organization ASC").Find(&assignments).Error; err != nil {
+		return nil, fmt.Errorf("failed to list organization residencies: %w", err)
+	}
+	return assignments, nil
+}
+
+// ===== HELPER METHODS =====
+
+// getDB routes to the primary database, where residency assignments are kept. A transaction
+// must have been started on the primary database too.
+func (r *ResidencyPostgreSQL) getDB(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(residency.WithRegion(ctx, ""))
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/SAP-F-2025/assessment-service/internal/residency"
+)
+
+// EnableResidencyRouting makes db run each statement on the database of the data residency
+// region in the statement's context, falling back to db's own database without one. Every
+// repository and transaction built on db is routed, so the right connection is resolved per
+// request without repositories knowing about regions.
+func EnableResidencyRouting(db *gorm.DB, regions map[string]*gorm.DB) error {
+	if len(regions) == 0 {
+		return nil
+	}
+
+	primary, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get primary database: %w", err)
+	}
+	pool := &regionalConnPool{primary: primary, regions: make(map[string]*sql.DB, len(regions))}
+	for name, regionDB := range regions {
+		sqlDB, err := regionDB.DB()
+		if err != nil {
+			return fmt.Errorf("failed to get database of region %s: %w", name, err)
+		}
+		pool.regions[name] = sqlDB
+	}
+
+	db.ConnPool = pool
+	db.Statement.ConnPool = pool
+	return nil
+}
+
+// regionalConnPool picks the connection pool of the context's region for each statement
+type regionalConnPool struct {
+	primary *sql.DB
+	regions map[string]*sql.DB
+}
+
+// pool fails for a region without a database rather than using the primary one, which would
+// store the data outside its region
+func (p *regionalConnPool) pool(ctx context.Context) (*sql.DB, error) {
+	region := residency.Region(ctx)
+	if region == "" {
+		return p.primary, nil
+	}
+	pool, ok := p.regions[region]
+	if !ok {
+		return nil, fmt.Errorf("no database configured for residency region %q", region)
+	}
+	return pool, nil
+}
+
+func (p *regionalConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	pool, err := p.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pool.PrepareContext(ctx, query)
+}
+
+func (p *regionalConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	pool, err := p.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pool.ExecContext(ctx, query, args...)
+}
+
+func (p *regionalConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	pool, err := p.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pool.QueryContext(ctx, query, args...)
+}
+
+func (p *regionalConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	pool, err := p.pool(ctx)
+	if err != nil {
+		// A row cannot carry our error; a cancelled context makes the read fail instead
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		return p.primary.QueryRowContext(cancelled, query, args...)
+	}
+	return pool.QueryRowContext(ctx, query, args...)
+}
+
+func (p *regionalConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	pool, err := p.pool(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pool.BeginTx(ctx, opts)
+}
+
+// GetDBConn returns the primary database, for health checks and shutdown
+func (p *regionalConnPool) GetDBConn() (*sql.DB, error) {
+	return p.primary, nil
+}
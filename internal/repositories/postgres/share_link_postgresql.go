@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type ShareLinkPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewShareLinkPostgreSQL(db *gorm.DB) repositories.ShareLinkRepository {
+	return &ShareLinkPostgreSQL{db: db}
+}
+
+// ===== BASIC OPERATIONS =====
+
+func (r *ShareLinkPostgreSQL) Create(ctx context.Context, tx *gorm.DB, link *models.AssessmentShareLink) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(link).Error; err != nil {
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+	return nil
+}
+
+func (r *ShareLinkPostgreSQL) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AssessmentShareLink, error) {
+	db := r.getDB(tx)
+	var link models.AssessmentShareLink
+	if err := db.WithContext(ctx).First(&link, id).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *ShareLinkPostgreSQL) GetByTokenHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*models.AssessmentShareLink, error) {
+	db := r.getDB(tx)
+	var link models.AssessmentShareLink
+	if err := db.WithContext(ctx).
+		Where("token_hash = ?", tokenHash).
+		First(&link).Error; err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *ShareLinkPostgreSQL) Update(ctx context.Context, tx *gorm.DB, link *models.AssessmentShareLink) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(link).Error; err != nil {
+		return fmt.Errorf("failed to update share link: %w", err)
+	}
+	return nil
+}
+
+// ===== QUERY OPERATIONS =====
+
+func (r *ShareLinkPostgreSQL) ListByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentShareLink, error) {
+	db := r.getDB(tx)
+	var links []*models.AssessmentShareLink
+	if err := db.WithContext(ctx).
+		Where("assessment_id = ?", assessmentID).
+		Order("created_at DESC, id DESC").
+		Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to list share links: %w", err)
+	}
+	return links, nil
+}
+
+// ===== VIEW LOG =====
+
+func (r *ShareLinkPostgreSQL) RecordView(ctx context.Context, tx *gorm.DB, view *models.AssessmentShareView) error {
+	db := r.getDB(tx)
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(view).Error; err != nil {
+			return fmt.Errorf("failed to record share link view: %w", err)
+		}
+		if err := tx.Model(&models.AssessmentShareLink{}).
+			Where("id = ?", view.ShareLinkID).
+			Updates(map[string]interface{}{
+				"view_count":     gorm.Expr("view_count + 1"),
+				"last_viewed_at": view.ViewedAt,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to update share link views: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *ShareLinkPostgreSQL) GetViews(ctx context.Context, tx *gorm.DB, linkID uint) ([]*models.AssessmentShareView, error) {
+	db := r.getDB(tx)
+	var views []*models.AssessmentShareView
+	if err := db.WithContext(ctx).
+		Where("share_link_id = ?", linkID).
+		Order("viewed_at DESC, id DESC").
+		Find(&views).Error; err != nil {
+		return nil, fmt.Errorf("failed to get share link views: %w", err)
+	}
+	return views, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *ShareLinkPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
@@ -39,6 +40,17 @@ func (h *SharedHelpers) CountAttemptsByStudent(ctx context.Context, assessmentID
 	return count, err
 }
 
+// CountUsedAttemptsByStudent counts the attempts that use up the student's allowance; voided
+// attempts do not
+func (h *SharedHelpers) CountUsedAttemptsByStudent(ctx context.Context, assessmentID uint, studentID string) (int64, error) {
+	var count int64
+	err := h.db.WithContext(ctx).
+		Model(&models.AssessmentAttempt{}).
+		Where("assessment_id = ? AND student_id = ? AND status <> ?", assessmentID, studentID, models.AttemptVoided).
+		Count(&count).Error
+	return count, err
+}
+
 // CountAttemptsByStatus counts attempts by status
 func (h *SharedHelpers) CountAttemptsByStatus(ctx context.Context, assessmentID uint, status models.AttemptStatus) (int64, error) {
 	var count int64
@@ -72,7 +84,7 @@ func (h *SharedHelpers) ApplyAssessmentFilters(query *gorm.DB, filters repositor
 	if filters.DateTo != nil {
 		query = query.Where("created_at <= ?", *filters.DateTo)
 	}
-	return query
+	return applyCustomFieldFilters(query, filters.CustomFields)
 }
 
 // ApplyAttemptFilters applies common filters to attempt queries
@@ -89,6 +101,89 @@ func (h *SharedHelpers) ApplyAttemptFilters(query *gorm.DB, filters repositories
 	if filters.DateTo != nil {
 		query = query.Where("created_at <= ?", *filters.DateTo)
 	}
+	if filters.AssessmentID != nil {
+		query = query.Where("assessment_attempts.assessment_id = ?", *filters.AssessmentID)
+	}
+
+	if filters.MinScore != nil {
+		query = query.Where("assessment_attempts.score >= ?", *filters.MinScore)
+	}
+	if filters.MaxScore != nil {
+		query = query.Where("assessment_attempts.score <= ?", *filters.MaxScore)
+	}
+	if filters.MinPercentage != nil {
+		query = query.Where("assessment_attempts.percentage >= ?", *filters.MinPercentage)
+	}
+	if filters.MaxPercentage != nil {
+		query = query.Where("assessment_attempts.percentage <= ?", *filters.MaxPercentage)
+	}
+	if filters.Passed != nil {
+		query = query.Where("assessment_attempts.passed = ?", *filters.Passed)
+	}
+	if filters.CompletedFrom != nil {
+		query = query.Where("assessment_attempts.completed_at >= ?", *filters.CompletedFrom)
+	}
+	if filters.CompletedTo != nil {
+		query = query.Where("assessment_attempts.completed_at <= ?", *filters.CompletedTo)
+	}
+
+	if filters.HasProctoringFlags != nil {
+		flagged := "EXISTS (SELECT 1 FROM proctoring_events pe WHERE pe.attempt_id = assessment_attempts.id AND pe.review_status <> 'dismissed')"
+		if *filters.HasProctoringFlags {
+			query = query.Where(flagged)
+		} else {
+			query = query.Where("NOT " + flagged)
+		}
+	}
+	if len(filters.GradingStatuses) > 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM student_answers sa WHERE sa.attempt_id = assessment_attempts.id AND sa.grading_status IN ?)", filters.GradingStatuses)
+	}
+	if filters.Search != "" {
+		pattern := "%" + filters.Search + "%"
+		query = query.Where("EXISTS (SELECT 1 FROM users u WHERE u.id = assessment_attempts.student_id AND (u.full_name ILIKE ? OR u.email ILIKE ?))", pattern, pattern)
+	}
+	return applyCustomFieldFilters(query, filters.CustomFields)
+}
+
+// attemptSortColumns maps AttemptSortFields to the expressions they order by
+var attemptSortColumns = map[string]string{
+	"created_at":   "assessment_attempts.created_at",
+	"started_at":   "assessment_attempts.started_at",
+	"completed_at": "assessment_attempts.completed_at",
+	"score":        "assessment_attempts.score",
+	"percentage":   "assessment_attempts.percentage",
+	"time_spent":   "assessment_attempts.time_spent",
+	"student_name": "(SELECT u.full_name FROM users u WHERE u.id = assessment_attempts.student_id)",
+}
+
+// ApplyAttemptSort orders by each sort key in turn, with unset values last. Unknown keys
+// are ignored; the service rejects them before the query is built.
+func (h *SharedHelpers) ApplyAttemptSort(query *gorm.DB, sorts []repositories.AttemptSort) *gorm.DB {
+	for _, sort := range sorts {
+		column, ok := attemptSortColumns[sort.Field]
+		if !ok {
+			continue
+		}
+		direction := "ASC"
+		if sort.Desc {
+			direction = "DESC"
+		}
+		query = query.Order(fmt.Sprintf("%s %s NULLS LAST", column, direction))
+	}
+	return query.Order("assessment_attempts.created_at DESC, assessment_attempts.id DESC")
+}
+
+// applyCustomFieldFilters matches custom field values as text, so numbers, booleans and dates
+// are compared in their JSON form, e.g. "42" or "true"
+func applyCustomFieldFilters(query *gorm.DB, customFields map[string]string) *gorm.DB {
+	keys := make([]string, 0, len(customFields))
+	for key := range customFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		query = query.Where("custom_fields ->> ? = ?", key, customFields[key])
+	}
 	return query
 }
 
@@ -161,7 +256,7 @@ func (h *SharedHelpers) ValidateAttemptEligibility(ctx context.Context, assessme
 
 	// Check max attempts
 	if assessment.MaxAttempts > 0 {
-		attemptCount, err := h.CountAttemptsByStudent(ctx, assessmentID, studentID)
+		attemptCount, err := h.CountUsedAttemptsByStudent(ctx, assessmentID, studentID)
 		if err != nil {
 			return nil, err
 		}
@@ -198,7 +293,7 @@ func (h *SharedHelpers) GetRemainingAttempts(ctx context.Context, assessmentID u
 		return 0, err
 	}
 
-	attemptCount, err := h.CountAttemptsByStudent(ctx, assessmentID, studentID)
+	attemptCount, err := h.CountUsedAttemptsByStudent(ctx, assessmentID, studentID)
 	if err != nil {
 		return 0, err
 	}
@@ -209,3 +304,33 @@ func (h *SharedHelpers) GetRemainingAttempts(ctx context.Context, assessmentID u
 	}
 	return remaining, nil
 }
+
+// applyGraderStatsScope limits a query to the filter's assessment and owner; the
+// assessment column is qualified by the caller, e.g. "aa.assessment_id"
+func applyGraderStatsScope(query *gorm.DB, filter repositories.GraderStatsFilter, assessmentColumn string) *gorm.DB {
+	if filter.AssessmentID != nil {
+		query = query.Where(assessmentColumn+" = ?", *filter.AssessmentID)
+	}
+	if filter.OwnerID != nil {
+		query = query.Where(assessmentColumn+" IN (SELECT id FROM assessments WHERE created_by = ?)", *filter.OwnerID)
+	}
+	return query
+}
+
+// applyFeedbackSearchFilters applies the assessment, question type and date filters of a
+// feedback search; questions are joined as q
+func applyFeedbackSearchFilters(query *gorm.DB, filters repositories.FeedbackSearchFilters, writtenAtColumn string) *gorm.DB {
+	if filters.AssessmentID != nil {
+		query = query.Where("aa.assessment_id = ?", *filters.AssessmentID)
+	}
+	if filters.QuestionType != nil {
+		query = query.Where("q.type = ?", *filters.QuestionType)
+	}
+	if filters.From != nil {
+		query = query.Where(writtenAtColumn+" >= ?", *filters.From)
+	}
+	if filters.To != nil {
+		query = query.Where(writtenAtColumn+" < ?", *filters.To)
+	}
+	return query
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UsagePostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewUsagePostgreSQL(db *gorm.DB) repositories.UsageRepository {
+	return &UsagePostgreSQL{db: db}
+}
+
+// ===== EVENTS =====
+
+func (r *UsagePostgreSQL) CreateEvent(ctx context.Context, tx *gorm.DB, event *models.UsageEvent) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(event).Error; err != nil {
+		return fmt.Errorf("failed to create usage event: %w", err)
+	}
+	return nil
+}
+
+func (r *UsagePostgreSQL) SumEvents(ctx context.Context, tx *gorm.DB, metrics []models.UsageMetric, from, to time.Time) ([]repositories.UsageTotal, error) {
+	db := r.getDB(tx)
+	var totals []repositories.UsageTotal
+	if err := db.WithContext(ctx).
+		Model(&models.UsageEvent{}).
+		Select("organization, metric, COALESCE(SUM(quantity), 0) AS quantity, COUNT(*) AS events").
+		Where("metric IN ? AND occurred_at >= ? AND occurred_at < ?", metrics, from, to).
+		Group("organization, metric").
+		Scan(&totals).Error; err != nil {
+		return nil, fmt.Errorf("failed to sum usage events: %w", err)
+	}
+	return totals, nil
+}
+
+// ===== ROLLUPS =====
+
+func (r *UsagePostgreSQL) UpsertRollups(ctx context.Context, tx *gorm.DB, rollups []*models.UsageRollup) error {
+	if len(rollups) == 0 {
+		return nil
+	}
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "organization"}, {Name: "period"}, {Name: "metric"}},
+			DoUpdates: clause.AssignmentColumns([]string{"quantity", "events", "calculated_at", "updated_at"}),
+		}).
+		Create(&rollups).Error; err != nil {
+		return fmt.Errorf("failed to save usage rollups: %w", err)
+	}
+	return nil
+}
+
+func (r *UsagePostgreSQL) GetRollups(ctx context.Context, tx *gorm.DB, fromPeriod, toPeriod string, organization string) ([]*models.UsageRollup, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).
+		Where("period >= ? AND period <= ?", fromPeriod, toPeriod)
+	if organization != "" {
+		query = query.Where("organization = ?", organization)
+	}
+
+	var rollups []*models.UsageRollup
+	if err := query.
+		Order("organization ASC, period ASC, metric ASC").
+		Find(&rollups).Error; err != nil {
+		return nil, fmt.Errorf("failed to get usage rollups: %w", err)
+	}
+	return rollups, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *UsagePostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
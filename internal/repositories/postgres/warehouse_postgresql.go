@@ -0,0 +1,270 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WarehousePostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewWarehousePostgreSQL(db *gorm.DB) repositories.WarehouseRepository {
+	return &WarehousePostgreSQL{db: db}
+}
+
+// ===== CONNECTORS =====
+
+func (r *WarehousePostgreSQL) CreateConnector(ctx context.Context, tx *gorm.DB, connector *models.WarehouseConnector) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(connector).Error; err != nil {
+		return fmt.Errorf("failed to create warehouse connector: %w", err)
+	}
+	return nil
+}
+
+func (r *WarehousePostgreSQL) GetConnectorByID(ctx context.Context, tx *gorm.DB, id uint) (*models.WarehouseConnector, error) {
+	db := r.getDB(tx)
+	var connector models.WarehouseConnector
+	if err := db.WithContext(ctx).First(&connector, id).Error; err != nil {
+		return nil, err
+	}
+	return &connector, nil
+}
+
+func (r *WarehousePostgreSQL) UpdateConnector(ctx context.Context, tx *gorm.DB, connector *models.WarehouseConnector) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(connector).Error; err != nil {
+		return fmt.Errorf("failed to update warehouse connector: %w", err)
+	}
+	return nil
+}
+
+func (r *WarehousePostgreSQL) DeleteConnector(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("connector_id = ?", id).Delete(&models.WarehouseExportRun{}).Error; err != nil {
+			return fmt.Errorf("failed to delete warehouse export runs: %w", err)
+		}
+		if err := tx.Delete(&models.WarehouseConnector{}, id).Error; err != nil {
+			return fmt.Errorf("failed to delete warehouse connector: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *WarehousePostgreSQL) ListConnectors(ctx context.Context, tx *gorm.DB) ([]*models.WarehouseConnector, error) {
+	db := r.getDB(tx)
+	var connectors []*models.WarehouseConnector
+	if err := db.WithContext(ctx).
+		Order("organization ASC").
+		Find(&connectors).Error; err != nil {
+		return nil, fmt.Errorf("failed to list warehouse connectors: %w", err)
+	}
+	return connectors, nil
+}
+
+func (r *WarehousePostgreSQL) GetEnabledConnectors(ctx context.Context, tx *gorm.DB) ([]*models.WarehouseConnector, error) {
+	db := r.getDB(tx)
+	var connectors []*models.WarehouseConnector
+	if err := db.WithContext(ctx).
+		Where("enabled = ?", true).
+		Find(&connectors).Error; err != nil {
+		return nil, fmt.Errorf("failed to get enabled warehouse connectors: %w", err)
+	}
+	return connectors, nil
+}
+
+// ===== RUNS =====
+
+func (r *WarehousePostgreSQL) CreateRun(ctx context.Context, tx *gorm.DB, run *models.WarehouseExportRun) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(run).Error; err != nil {
+		return fmt.Errorf("failed to create warehouse export run: %w", err)
+	}
+	return nil
+}
+
+func (r *WarehousePostgreSQL) GetRunByID(ctx context.Context, tx *gorm.DB, id uint) (*models.WarehouseExportRun, error) {
+	db := r.getDB(tx)
+	var run models.WarehouseExportRun
+	if err := db.WithContext(ctx).First(&run, id).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *WarehousePostgreSQL) UpdateRun(ctx context.Context, tx *gorm.DB, run *models.WarehouseExportRun) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(run).Error; err != nil {
+		return fmt.Errorf("failed to update warehouse export run: %w", err)
+	}
+	return nil
+}
+
+func (r *WarehousePostgreSQL) ListRuns(ctx context.Context, tx *gorm.DB, connectorID uint, limit int) ([]*models.WarehouseExportRun, error) {
+	db := r.getDB(tx)
+	var runs []*models.WarehouseExportRun
+	if err := db.WithContext(ctx).
+		Where("connector_id = ?", connectorID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list warehouse export runs: %w", err)
+	}
+	return runs, nil
+}
+
+// CreateIncrementalRun relies on the partial unique index on open incremental runs, so
+// concurrent inserts for a connector leave one run
+func (r *WarehousePostgreSQL) CreateIncrementalRun(ctx context.Context, tx *gorm.DB, run *models.WarehouseExportRun) (bool, error) {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(run)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to create warehouse export run: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *WarehousePostgreSQL) GetClaimableRuns(ctx context.Context, tx *gorm.DB, staleBefore time.Time, limit int) ([]uint, error) {
+	db := r.getDB(tx)
+	var ids []uint
+	if err := db.WithContext(ctx).
+		Model(&models.WarehouseExportRun{}).
+		Where(r.claimableCondition(), models.WarehouseRunQueued, models.WarehouseRunRunning, staleBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to get claimable warehouse export runs: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *WarehousePostgreSQL) ClaimRun(ctx context.Context, tx *gorm.DB, id uint, now, staleBefore time.Time) (bool, error) {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Model(&models.WarehouseExportRun{}).
+		Where("id = ?", id).
+		Where(r.claimableCondition(), models.WarehouseRunQueued, models.WarehouseRunRunning, staleBefore).
+		Updates(map[string]interface{}{
+			"status":     models.WarehouseRunRunning,
+			"started_at": now,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim warehouse export run: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ===== FACTS =====
+
+func (r *WarehousePostgreSQL) GetAssessmentCreatorIDs(ctx context.Context, tx *gorm.DB) ([]string, error) {
+	db := r.getDB(tx)
+	var ids []string
+	if err := db.WithContext(ctx).
+		Unscoped().
+		Model(&models.Assessment{}).
+		Distinct("created_by").
+		Pluck("created_by", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to get assessment creators: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *WarehousePostgreSQL) GetAssessmentFacts(ctx context.Context, tx *gorm.DB, creatorIDs []string, window repositories.FactWindow, afterID uint, limit int) ([]repositories.AssessmentFact, error) {
+	if len(creatorIDs) == 0 {
+		return nil, nil
+	}
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).
+		Table("assessments a").
+		Select(`a.id AS assessment_id, a.title, a.status, a.created_by, a.term, a.duration,
+			a.passing_score, a.max_attempts, a.due_date, a.created_at, a.updated_at, a.deleted_at,
+			(SELECT COUNT(*) FROM assessment_questions aq WHERE aq.assessment_id = a.id) AS question_count`).
+		Where("a.created_by IN ? AND a.id > ?", creatorIDs, afterID)
+
+	var facts []repositories.AssessmentFact
+	if err := r.applyWindow(query, "a", window).
+		Order("a.id ASC").
+		Limit(limit).
+		Scan(&facts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get assessment facts: %w", err)
+	}
+	return facts, nil
+}
+
+func (r *WarehousePostgreSQL) GetAttemptFacts(ctx context.Context, tx *gorm.DB, creatorIDs []string, window repositories.FactWindow, afterID uint, limit int) ([]repositories.AttemptFact, error) {
+	if len(creatorIDs) == 0 {
+		return nil, nil
+	}
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).
+		Table("assessment_attempts aa").
+		Select(`aa.id AS attempt_id, aa.assessment_id, aa.student_id, aa.attempt_number, aa.status,
+			aa.started_at, aa.completed_at, aa.time_spent, aa.score, aa.max_score, aa.percentage,
+			aa.passed, aa.score_overridden, aa.updated_at`).
+		Joins("JOIN assessments a ON a.id = aa.assessment_id").
+		Where("a.created_by IN ? AND aa.id > ? AND aa.deleted_at IS NULL", creatorIDs, afterID)
+
+	var facts []repositories.AttemptFact
+	if err := r.applyWindow(query, "aa", window).
+		Order("aa.id ASC").
+		Limit(limit).
+		Scan(&facts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attempt facts: %w", err)
+	}
+	return facts, nil
+}
+
+func (r *WarehousePostgreSQL) GetAnswerFacts(ctx context.Context, tx *gorm.DB, creatorIDs []string, window repositories.FactWindow, afterID uint, limit int) ([]repositories.AnswerFact, error) {
+	if len(creatorIDs) == 0 {
+		return nil, nil
+	}
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).
+		Table("student_answers sa").
+		Select(`sa.id AS answer_id, sa.attempt_id, aa.assessment_id, aa.student_id, sa.question_id,
+			sa.score, sa.max_score, sa.is_correct, sa.grading_status, sa.graded_by, sa.graded_at,
+			sa.time_spent, sa.change_count, sa.spell_check_shown, sa.updated_at`).
+		Joins("JOIN assessment_attempts aa ON aa.id = sa.attempt_id").
+		Joins("JOIN assessments a ON a.id = aa.assessment_id").
+		Where("a.created_by IN ? AND sa.id > ? AND aa.deleted_at IS NULL", creatorIDs, afterID)
+
+	var facts []repositories.AnswerFact
+	if err := r.applyWindow(query, "sa", window).
+		Order("sa.id ASC").
+		Limit(limit).
+		Scan(&facts).Error; err != nil {
+		return nil, fmt.Errorf("failed to get answer facts: %w", err)
+	}
+	return facts, nil
+}
+
+// ===== HELPERS =====
+
+// claimableCondition matches queued runs and runs whose worker stopped before finishing
+func (r *WarehousePostgreSQL) claimableCondition() string {
+	return "status = ? OR (status = ? AND started_at < ?)"
+}
+
+func (r *WarehousePostgreSQL) applyWindow(query *gorm.DB, alias string, window repositories.FactWindow) *gorm.DB {
+	query = query.Where(alias+".updated_at <= ?", window.Through)
+	if window.After != nil {
+		query = query.Where(alias+".updated_at > ?", *window.After)
+	}
+	return query
+}
+
+func (r *WarehousePostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
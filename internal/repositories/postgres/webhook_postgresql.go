@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+type WebhookPostgreSQL struct {
+	db *gorm.DB
+}
+
+func NewWebhookPostgreSQL(db *gorm.DB) repositories.WebhookRepository {
+	return &WebhookPostgreSQL{db: db}
+}
+
+// ===== SUBSCRIPTIONS =====
+
+func (r *WebhookPostgreSQL) CreateSubscription(ctx context.Context, tx *gorm.DB, subscription *models.WebhookSubscription) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(subscription).Error; err != nil {
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookPostgreSQL) GetSubscriptionByID(ctx context.Context, tx *gorm.DB, id uint) (*models.WebhookSubscription, error) {
+	db := r.getDB(tx)
+	var subscription models.WebhookSubscription
+	if err := db.WithContext(ctx).First(&subscription, id).Error; err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *WebhookPostgreSQL) UpdateSubscription(ctx context.Context, tx *gorm.DB, subscription *models.WebhookSubscription) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(subscription).Error; err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookPostgreSQL) DeleteSubscription(ctx context.Context, tx *gorm.DB, id uint) error {
+	db := r.getDB(tx)
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&models.WebhookDelivery{}).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook deliveries: %w", err)
+		}
+		if err := tx.Delete(&models.WebhookSubscription{}, id).Error; err != nil {
+			return fmt.Errorf("failed to delete webhook subscription: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *WebhookPostgreSQL) ListSubscriptions(ctx context.Context, tx *gorm.DB) ([]*models.WebhookSubscription, error) {
+	db := r.getDB(tx)
+	var subscriptions []*models.WebhookSubscription
+	if err := db.WithContext(ctx).
+		Order("name ASC").
+		Find(&subscriptions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (r *WebhookPostgreSQL) GetEnabledSubscriptions(ctx context.Context, tx *gorm.DB) ([]*models.WebhookSubscription, error) {
+	db := r.getDB(tx)
+	var subscriptions []*models.WebhookSubscription
+	if err := db.WithContext(ctx).
+		Where("enabled = ?", true).
+		Find(&subscriptions).Error; err != nil {
+		return nil, fmt.Errorf("failed to get enabled webhook subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// ===== DELIVERIES =====
+
+func (r *WebhookPostgreSQL) CreateDeliveries(ctx context.Context, tx *gorm.DB, deliveries []*models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Create(deliveries).Error; err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookPostgreSQL) GetDeliveryByID(ctx context.Context, tx *gorm.DB, id uint) (*models.WebhookDelivery, error) {
+	db := r.getDB(tx)
+	var delivery models.WebhookDelivery
+	if err := db.WithContext(ctx).First(&delivery, id).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookPostgreSQL) UpdateDelivery(ctx context.Context, tx *gorm.DB, delivery *models.WebhookDelivery) error {
+	db := r.getDB(tx)
+	if err := db.WithContext(ctx).Save(delivery).Error; err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *WebhookPostgreSQL) ListDeliveries(ctx context.Context, tx *gorm.DB, subscriptionID uint, status *models.WebhookDeliveryStatus, limit int) ([]*models.WebhookDelivery, error) {
+	db := r.getDB(tx)
+	query := db.WithContext(ctx).Where("subscription_id = ?", subscriptionID)
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	var deliveries []*models.WebhookDelivery
+	if err := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookPostgreSQL) GetClaimableDeliveries(ctx context.Context, tx *gorm.DB, staleBefore time.Time, limit int) ([]uint, error) {
+	db := r.getDB(tx)
+	var ids []uint
+	if err := db.WithContext(ctx).
+		Model(&models.WebhookDelivery{}).
+		Where(r.claimableCondition(), models.WebhookDeliveryPending, models.WebhookDeliveryProcessing, staleBefore).
+		Order("created_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to get claimable webhook deliveries: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *WebhookPostgreSQL) ClaimDelivery(ctx context.Context, tx *gorm.DB, id uint, now, staleBefore time.Time) (bool, error) {
+	db := r.getDB(tx)
+	result := db.WithContext(ctx).
+		Model(&models.WebhookDelivery{}).
+		Where("id = ?", id).
+		Where(r.claimableCondition(), models.WebhookDeliveryPending, models.WebhookDeliveryProcessing, staleBefore).
+		Updates(map[string]interface{}{
+			"status":     models.WebhookDeliveryProcessing,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to claim webhook delivery: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (r *WebhookPostgreSQL) claimableCondition() string {
+	return "status = ? OR (status = ? AND updated_at < ?)"
+}
+
+func (r *WebhookPostgreSQL) getDB(tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx
+	}
+	return r.db
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// PracticeRepository interface for practice sets and the sessions students take from them
+type PracticeRepository interface {
+	// Practice sets
+	CreateSet(ctx context.Context, tx *gorm.DB, set *models.PracticeSet) error
+	GetSetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.PracticeSet, error)
+	UpdateSet(ctx context.Context, tx *gorm.DB, set *models.PracticeSet) error
+	// ListSets returns the practice sets, newest first, of one bank when bankID is set and
+	// only the active ones when activeOnly is set
+	ListSets(ctx context.Context, tx *gorm.DB, bankID *uint, activeOnly bool) ([]*models.PracticeSet, error)
+
+	// Sessions
+	CreateSession(ctx context.Context, tx *gorm.DB, session *models.PracticeSession) error
+	GetSessionByID(ctx context.Context, tx *gorm.DB, id uint) (*models.PracticeSession, error)
+	UpdateSession(ctx context.Context, tx *gorm.DB, session *models.PracticeSession) error
+	ListSessions(ctx context.Context, tx *gorm.DB, filters PracticeSessionFilters) ([]*models.PracticeSession, int64, error)
+
+	// Answers
+	CreateAnswer(ctx context.Context, tx *gorm.DB, answer *models.PracticeAnswer) error
+	GetAnswers(ctx context.Context, tx *gorm.DB, sessionID uint) ([]*models.PracticeAnswer, error)
+
+	// Statistics
+	GetSetStats(ctx context.Context, tx *gorm.DB, setID uint) (*PracticeSetStats, error)
+	GetQuestionStats(ctx context.Context, tx *gorm.DB, setID uint) ([]PracticeQuestionStats, error)
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// QuestionAnalyticsRepository interface for stored per-question item analysis
+type QuestionAnalyticsRepository interface {
+	// Upsert replaces the stored analysis for the question
+	Upsert(ctx context.Context, tx *gorm.DB, analytics *models.QuestionAnalytics) error
+	GetByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) (*models.QuestionAnalytics, error)
+
+	// Analysis inputs. Answers a question trial served a revision for count toward the revision.
+	GetScoredResponses(ctx context.Context, tx *gorm.DB, questionID uint) ([]ScoredResponse, error)
+	// GetTrialResponses returns one arm of a question trial: finished answers on the trial's
+	// assessment from attempts started since the trial began, to the revision or the original
+	GetTrialResponses(ctx context.Context, tx *gorm.DB, trial *models.QuestionTrial, variant bool) ([]ScoredResponse, error)
+	// GetStaleQuestionIDs returns questions of the given type answered since their last analysis
+	GetStaleQuestionIDs(ctx context.Context, tx *gorm.DB, questionType models.QuestionType, limit int) ([]uint, error)
+	// GetHistoricalStats aggregates finished responses per question; questions never answered are omitted
+	GetHistoricalStats(ctx context.Context, tx *gorm.DB, questionIDs []uint) ([]QuestionHistoricalStats, error)
+	// GetAssessmentQuestionStats is GetHistoricalStats limited to attempts on one assessment
+	GetAssessmentQuestionStats(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]QuestionHistoricalStats, error)
+
+	// Recompute jobs
+	GetBankQuestionIDs(ctx context.Context, tx *gorm.DB, bankID uint) ([]uint, error)
+	CreateStatsJob(ctx context.Context, tx *gorm.DB, job *models.QuestionStatsJob) error
+	GetStatsJobByID(ctx context.Context, tx *gorm.DB, id uint) (*models.QuestionStatsJob, error)
+	UpdateStatsJob(ctx context.Context, tx *gorm.DB, job *models.QuestionStatsJob) error
+	// GetActiveStatsJob returns a pending or running job for the question or bank
+	GetActiveStatsJob(ctx context.Context, tx *gorm.DB, questionID, bankID *uint) (*models.QuestionStatsJob, error)
+	// ClaimStatsJob marks a pending job, or one left running since before staleBefore, as running
+	ClaimStatsJob(ctx context.Context, tx *gorm.DB, id uint, staleBefore time.Time) (bool, error)
+	GetRunnableStatsJobs(ctx context.Context, tx *gorm.DB, staleBefore time.Time, limit int) ([]*models.QuestionStatsJob, error)
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// QuestionExternalRefRepository interface for the IDs content providers give questions
+type QuestionExternalRefRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, tx *gorm.DB, ref *models.QuestionExternalRef) error
+	Update(ctx context.Context, tx *gorm.DB, ref *models.QuestionExternalRef) error
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+
+	// Query operations
+	GetByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) ([]*models.QuestionExternalRef, error)
+	GetByQuestions(ctx context.Context, tx *gorm.DB, questionIDs []uint) (map[uint][]*models.QuestionExternalRef, error)
+	GetByQuestionAndProvider(ctx context.Context, tx *gorm.DB, questionID uint, provider string) (*models.QuestionExternalRef, error)
+	GetByExternalID(ctx context.Context, tx *gorm.DB, ownerID, provider, externalID string) (*models.QuestionExternalRef, error)
+	GetByExternalIDs(ctx context.Context, tx *gorm.DB, ownerID, provider string, externalIDs []string) ([]*models.QuestionExternalRef, error)
+}
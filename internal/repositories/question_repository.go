@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"gorm.io/gorm"
@@ -139,6 +140,13 @@ type QuestionBankRepository interface {
 	RemoveQuestions(ctx context.Context, tx *gorm.DB, bankID uint, questionIDs []uint) error
 	GetBankQuestions(ctx context.Context, tx *gorm.DB, bankID uint, filters QuestionFilters) ([]*models.Question, int64, error)
 	IsQuestionInBank(ctx context.Context, tx *gorm.DB, questionID, bankID uint) (bool, error)
+	// GetQuestionBankIDs returns the banks each of the questions belongs to
+	GetQuestionBankIDs(ctx context.Context, tx *gorm.DB, questionIDs []uint) (map[uint][]uint, error)
+
+	// Change feed
+	RecordChanges(ctx context.Context, tx *gorm.DB, changes []*models.QuestionBankChange) error
+	// GetChanges returns the bank's changes oldest first, continuing after the cursor when set
+	GetChanges(ctx context.Context, tx *gorm.DB, bankID uint, after *PageCursor, limit int) ([]*models.QuestionBankChange, error)
 
 	// Permission checks
 	CanAccess(ctx context.Context, tx *gorm.DB, bankID uint, userID string) (bool, error)
@@ -154,6 +162,18 @@ type QuestionBankRepository interface {
 	GetBankStats(ctx context.Context, tx *gorm.DB, bankID uint) (*QuestionBankStats, error)
 	GetUsageCount(ctx context.Context, tx *gorm.DB, bankID uint) (int, error)
 	UpdateUsage(ctx context.Context, tx *gorm.DB, bankID uint) error
+
+	// Licensing
+	GetLicensedBanks(ctx context.Context, tx *gorm.DB, creatorID string) ([]*models.QuestionBank, error)
+	GetLicensedBanksForAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.QuestionBank, error)
+	GetLicenseExposure(ctx context.Context, tx *gorm.DB, bankID uint) (*BankLicenseExposure, error)
+	IsStudentExposed(ctx context.Context, tx *gorm.DB, bankID uint, studentID string) (bool, error)
+
+	// Content review (bankID 0 = every bank)
+	GetQuestionReviewStates(ctx context.Context, tx *gorm.DB, bankID uint) ([]QuestionReviewState, error)
+	GetCorrectRateWindows(ctx context.Context, tx *gorm.DB, questionIDs []uint, split time.Time, window time.Duration) ([]CorrectRateWindows, error)
+	MarkQuestionsReviewed(ctx context.Context, tx *gorm.DB, bankID uint, questionIDs []uint, reviewedAt time.Time) (int64, error)
+	SetReviewReminderSent(ctx context.Context, tx *gorm.DB, bankID uint, sentAt, dueBefore time.Time) (bool, error)
 }
 
 // ===== ADDITIONAL FILTER STRUCTS =====
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// TranslationReviewFilters narrows the translation review queue
+type TranslationReviewFilters struct {
+	Locale    *string                   `json:"locale"`
+	Status    *models.TranslationStatus `json:"status"`     // Defaults to every status needing review
+	CreatedBy *string                   `json:"created_by"` // Owner of the question
+	Limit     int                       `json:"limit"`
+	Offset    int                       `json:"offset"`
+}
+
+// QuestionTranslationRepository interface for localized question variants
+type QuestionTranslationRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, tx *gorm.DB, translation *models.QuestionTranslation) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.QuestionTranslation, error)
+	GetByQuestionAndLocale(ctx context.Context, tx *gorm.DB, questionID uint, locale string) (*models.QuestionTranslation, error)
+	Update(ctx context.Context, tx *gorm.DB, translation *models.QuestionTranslation) error
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+
+	// Query operations
+	GetByQuestion(ctx context.Context, tx *gorm.DB, questionID uint) ([]*models.QuestionTranslation, error)
+	GetLocaleStatuses(ctx context.Context, tx *gorm.DB, questionIDs []uint) (map[uint][]models.LocaleStatus, error)
+
+	// Review workflow
+	// MarkStale flags every translation of the question except the given locale as stale,
+	// keeping the first cause and time of translations already stale
+	MarkStale(ctx context.Context, tx *gorm.DB, questionID uint, exceptLocale string, cause string, at time.Time) (int64, error)
+	// GetReviewQueue lists translations waiting for review, longest waiting first, with their question
+	GetReviewQueue(ctx context.Context, tx *gorm.DB, filters TranslationReviewFilters) ([]*models.QuestionTranslation, int64, error)
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// QuestionTrialRepository interface for A/B trials of revised questions
+type QuestionTrialRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, tx *gorm.DB, trial *models.QuestionTrial) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.QuestionTrial, error)
+	Update(ctx context.Context, tx *gorm.DB, trial *models.QuestionTrial) error
+
+	// Query operations
+	GetByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.QuestionTrial, error) // Newest first
+	GetRunningByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.QuestionTrial, error)
+}
@@ -0,0 +1,188 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/residency"
+)
+
+const (
+	answerBufferKeyPrefix = "answer_buffer:attempt:"
+	answerBufferPending   = "answer_buffer:pending"
+
+	// Safety net so abandoned buffers do not live forever; flushes happen within seconds
+	answerBufferTTL = 24 * time.Hour
+)
+
+// clearBufferedScript deletes hash fields whose value still matches the flushed one and
+// returns how many fields remain
+var clearBufferedScript = goredis.NewScript(`
+for i = 1, #ARGV, 2 do
+	if redis.call("HGET", KEYS[1], ARGV[i]) == ARGV[i + 1] then
+		redis.call("HDEL", KEYS[1], ARGV[i])
+	end
+end
+return redis.call("HLEN", KEYS[1])
+`)
+
+type AnswerBufferRedis struct {
+	client *cache.Client
+}
+
+func NewAnswerBufferRedis(client *cache.Client) repositories.AnswerBufferRepository {
+	return &AnswerBufferRedis{client: client}
+}
+
+func (r *AnswerBufferRedis) Available() bool {
+	return r.client.Available()
+}
+
+// ===== BUFFERING =====
+
+func (r *AnswerBufferRedis) Buffer(ctx context.Context, answer *repositories.BufferedAnswer) error {
+	if !r.client.Available() {
+		return cache.ErrCacheNotAvailable
+	}
+
+	raw, err := json.Marshal(answer)
+	if err != nil {
+		return fmt.Errorf("failed to marshal buffered answer: %w", err)
+	}
+	raw = r.client.Compressor().Compress(cache.CompressionStoreAutosave, raw)
+
+	key := answerBufferKey(ctx, answer.AttemptID)
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, bufferedAnswerField(answer), raw)
+	pipe.Expire(ctx, key, answerBufferTTL)
+	// NX keeps the oldest pending write as the score, so flush deadlines are not pushed back
+	pipe.ZAddNX(ctx, answerBufferPendingKey(ctx), goredis.Z{
+		Score:  float64(answer.BufferedAt.Unix()),
+		Member: answer.AttemptID,
+	})
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.client.ReportError(err)
+		return fmt.Errorf("failed to buffer answer: %w", err)
+	}
+	return nil
+}
+
+func (r *AnswerBufferRedis) GetByAttempt(ctx context.Context, attemptID uint) ([]*repositories.BufferedAnswer, error) {
+	if !r.client.Available() {
+		return nil, cache.ErrCacheNotAvailable
+	}
+
+	values, err := r.client.HGetAll(ctx, answerBufferKey(ctx, attemptID)).Result()
+	if err != nil {
+		r.client.ReportError(err)
+		return nil, fmt.Errorf("failed to get buffered answers: %w", err)
+	}
+
+	answers := make([]*repositories.BufferedAnswer, 0, len(values))
+	for _, raw := range values {
+		data, err := r.client.Compressor().Decompress(cache.CompressionStoreAutosave, []byte(raw))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress buffered answer: %w", err)
+		}
+		var answer repositories.BufferedAnswer
+		if err := json.Unmarshal(data, &answer); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal buffered answer: %w", err)
+		}
+		answer.Raw = raw
+		answers = append(answers, &answer)
+	}
+	return answers, nil
+}
+
+func (r *AnswerBufferRedis) Clear(ctx context.Context, attemptID uint, answers []*repositories.BufferedAnswer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+	if !r.client.Available() {
+		return cache.ErrCacheNotAvailable
+	}
+
+	args := make([]interface{}, 0, len(answers)*2)
+	for _, answer := range answers {
+		args = append(args, bufferedAnswerField(answer), answer.Raw)
+	}
+
+	key := answerBufferKey(ctx, attemptID)
+	remaining, err := clearBufferedScript.Run(ctx, r.client, []string{key}, args...).Int()
+	if err != nil {
+		r.client.ReportError(err)
+		return fmt.Errorf("failed to clear buffered answers: %w", err)
+	}
+	if remaining > 0 {
+		return nil
+	}
+
+	if err := r.client.ZRem(ctx, answerBufferPendingKey(ctx), attemptID).Err(); err != nil {
+		r.client.ReportError(err)
+		return fmt.Errorf("failed to clear pending attempt: %w", err)
+	}
+
+	// An autosave may have landed between the script and ZREM; keep it scheduled
+	if count, err := r.client.HLen(ctx, key).Result(); err == nil && count > 0 {
+		r.client.ZAddNX(ctx, answerBufferPendingKey(ctx), goredis.Z{
+			Score:  float64(time.Now().Unix()),
+			Member: attemptID,
+		})
+	}
+	return nil
+}
+
+// ===== SCHEDULING =====
+
+func (r *AnswerBufferRedis) GetDueAttempts(ctx context.Context, bufferedBefore time.Time, limit int) ([]uint, error) {
+	if !r.client.Available() {
+		return nil, cache.ErrCacheNotAvailable
+	}
+
+	members, err := r.client.ZRangeByScore(ctx, answerBufferPendingKey(ctx), &goredis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(bufferedBefore.Unix(), 10),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		r.client.ReportError(err)
+		return nil, fmt.Errorf("failed to get due attempts: %w", err)
+	}
+
+	attemptIDs := make([]uint, 0, len(members))
+	for _, member := range members {
+		id, err := strconv.ParseUint(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		attemptIDs = append(attemptIDs, uint(id))
+	}
+	return attemptIDs, nil
+}
+
+// ===== HELPER METHODS =====
+
+// Part answers get a field of their own so answers to sibling parts do not replace each other
+func bufferedAnswerField(answer *repositories.BufferedAnswer) string {
+	field := strconv.FormatUint(uint64(answer.QuestionID), 10)
+	if answer.PartID != "" {
+		field += ":" + answer.PartID
+	}
+	return field
+}
+
+// Buffers are kept apart per data residency region, whose databases can share attempt IDs
+func answerBufferKey(ctx context.Context, attemptID uint) string {
+	return residency.Key(ctx, answerBufferKeyPrefix+strconv.FormatUint(uint64(attemptID), 10))
+}
+
+func answerBufferPendingKey(ctx context.Context) string {
+	return residency.Key(ctx, answerBufferPending)
+}
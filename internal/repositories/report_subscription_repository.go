@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// ReportSubscriptionRepository interface for scheduled report subscription operations
+type ReportSubscriptionRepository interface {
+	// Basic CRUD operations
+	Create(ctx context.Context, tx *gorm.DB, subscription *models.ReportSubscription) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.ReportSubscription, error)
+	Update(ctx context.Context, tx *gorm.DB, subscription *models.ReportSubscription) error
+	Delete(ctx context.Context, tx *gorm.DB, id uint) error
+
+	// Query operations
+	GetByUser(ctx context.Context, tx *gorm.DB, userID string) ([]*models.ReportSubscription, error)
+	ExistsByUserAndType(ctx context.Context, tx *gorm.DB, userID string, reportType models.ReportType) (bool, error)
+
+	// Scheduling
+	GetDue(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]*models.ReportSubscription, error)
+	// ClaimRun moves a subscription's next run from dueAt to nextRunAt; it reports false
+	// when another instance already moved it
+	ClaimRun(ctx context.Context, tx *gorm.DB, id uint, dueAt, nextRunAt time.Time) (bool, error)
+	// SetLastSent records a delivery without touching the subscription's other fields
+	SetLastSent(ctx context.Context, tx *gorm.DB, id uint, sentAt time.Time) error
+}
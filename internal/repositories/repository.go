@@ -7,12 +7,19 @@ type Repository interface {
 	// Assessment domain
 	Assessment() AssessmentRepository
 	AssessmentSettings() AssessmentSettingsRepository
+	Enrollment() EnrollmentRepository
+	Extension() ExtensionRepository
+	ShareLink() ShareLinkRepository
 
 	// Question domain
 	Question() QuestionRepository
 	QuestionCategory() QuestionCategoryRepository
 	QuestionAttachment() QuestionAttachmentRepository
 	QuestionBank() QuestionBankRepository
+	ImportJob() ImportJobRepository
+	QuestionTranslation() QuestionTranslationRepository
+	QuestionExternalRef() QuestionExternalRefRepository
+	QuestionTrial() QuestionTrialRepository
 
 	// Assessment-Question relationship
 	AssessmentQuestion() AssessmentQuestionRepository
@@ -20,6 +27,57 @@ type Repository interface {
 	// Attempt domain
 	Attempt() AttemptRepository
 	Answer() AnswerRepository
+	AnswerBuffer() AnswerBufferRepository
+	AnswerAttachment() AnswerAttachmentRepository
+	AttemptQueue() AttemptQueueRepository
+	AttemptQuota() AttemptQuotaRepository
+	OfflineBundle() OfflineBundleRepository
+	AttemptNavigation() AttemptNavigationRepository
+
+	// Grading domain
+	AnswerReview() AnswerReviewRepository
+	FeedbackComment() FeedbackCommentRepository
+	Gradebook() GradebookRepository
+	AnswerAnnotation() AnswerAnnotationRepository
+	AnswerKeyChange() AnswerKeyChangeRepository
+	FlagEscalation() FlagEscalationRepository
+	GradingDelegation() GradingDelegationRepository
+
+	// Reporting domain
+	ReportSubscription() ReportSubscriptionRepository
+	QuestionAnalytics() QuestionAnalyticsRepository
+	MasteryTarget() MasteryTargetRepository
+	AssessmentAnalytics() AssessmentAnalyticsRepository
+
+	// Audit domain
+	AuditLog() AuditLogRepository
+	Impersonation() ImpersonationRepository
+
+	// Billing domain
+	Usage() UsageRepository
+
+	// Tenancy domain
+	Residency() ResidencyRepository
+	CustomField() CustomFieldRepository
+
+	// Data export domain
+	Warehouse() WarehouseRepository
+	ResearchPolicy() ResearchPolicyRepository
+
+	// Integrations domain
+	Webhook() WebhookRepository
+
+	// Background jobs domain
+	Job() JobRepository
+
+	// Practice domain
+	Practice() PracticeRepository
+
+	// Favorites domain
+	Favorite() FavoriteRepository
+
+	// Notification domain
+	NotificationPreference() NotificationPreferenceRepository
 
 	// User domain (read-only for assessment service)
 	User() UserRepository
@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// ResearchPolicyRepository interface for organizations' research sampling policies
+type ResearchPolicyRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, policy *models.ResearchPolicy) error
+	Update(ctx context.Context, tx *gorm.DB, policy *models.ResearchPolicy) error
+	GetByOrganization(ctx context.Context, tx *gorm.DB, organization string) (*models.ResearchPolicy, error)
+	List(ctx context.Context, tx *gorm.DB) ([]*models.ResearchPolicy, error)
+}
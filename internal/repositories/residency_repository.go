@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// ResidencyRepository interface for organizations' data residency regions. Assignments always
+// live in the primary database, whatever region the context is routed to.
+type ResidencyRepository interface {
+	Create(ctx context.Context, tx *gorm.DB, assignment *models.OrganizationResidency) error
+	GetByOrganization(ctx context.Context, tx *gorm.DB, organization string) (*models.OrganizationResidency, error)
+	List(ctx context.Context, tx *gorm.DB) ([]*models.OrganizationResidency, error)
+}
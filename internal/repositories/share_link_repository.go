@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// ShareLinkRepository interface for assessment share links and their view log
+type ShareLinkRepository interface {
+	// Basic operations
+	Create(ctx context.Context, tx *gorm.DB, link *models.AssessmentShareLink) error
+	GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AssessmentShareLink, error)
+	GetByTokenHash(ctx context.Context, tx *gorm.DB, tokenHash string) (*models.AssessmentShareLink, error)
+	Update(ctx context.Context, tx *gorm.DB, link *models.AssessmentShareLink) error
+
+	// Query operations
+	ListByAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentShareLink, error)
+
+	// View log
+	// RecordView logs a view and bumps the link's view count and last view time
+	RecordView(ctx context.Context, tx *gorm.DB, view *models.AssessmentShareView) error
+	GetViews(ctx context.Context, tx *gorm.DB, linkID uint) ([]*models.AssessmentShareView, error)
+}
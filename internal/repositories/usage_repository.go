@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// UsageTotal sums the events of one metric of one organization
+type UsageTotal struct {
+	Organization string             `json:"organization"`
+	Metric       models.UsageMetric `json:"metric"`
+	Quantity     int64              `json:"quantity"`
+	Events       int                `json:"events"`
+}
+
+// UsageRepository interface for billing usage events and their monthly rollups
+type UsageRepository interface {
+	// Events
+	CreateEvent(ctx context.Context, tx *gorm.DB, event *models.UsageEvent) error
+	SumEvents(ctx context.Context, tx *gorm.DB, metrics []models.UsageMetric, from, to time.Time) ([]UsageTotal, error) // from inclusive, to exclusive
+
+	// Rollups
+	UpsertRollups(ctx context.Context, tx *gorm.DB, rollups []*models.UsageRollup) error
+	GetRollups(ctx context.Context, tx *gorm.DB, fromPeriod, toPeriod string, organization string) ([]*models.UsageRollup, error) // Empty organization for all
+}
@@ -12,6 +12,8 @@ type UserRepository interface {
 	GetByID(ctx context.Context, id string) (*models.User, error)
 	GetByEmail(ctx context.Context, email string) (*models.User, error)
 	GetByIDs(ctx context.Context, ids []string) ([]*models.User, error)
+	// GetIDsByGroup returns the active users in a group, such as a class
+	GetIDsByGroup(ctx context.Context, group string) ([]string, error)
 
 	// Validation and checks
 	ExistsByID(ctx context.Context, id string) (bool, error)
@@ -1,7 +1,16 @@
 package repositories
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -9,3 +18,59 @@ import (
 func IsNotFoundError(err error) bool {
 	return errors.Is(err, gorm.ErrRecordNotFound)
 }
+
+// IsConnectionError reports whether err means the database could not be reached, as opposed
+// to the database refusing the statement
+func IsConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	// Postgres connection exceptions and server shutdowns
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		code := pgErr.SQLState()
+		return strings.HasPrefix(code, "08") || code == "57P01" || code == "57P02" || code == "57P03"
+	}
+	return false
+}
+
+// PageCursor marks the last row of a keyset-paginated page. Listings ordered by
+// (created_at, id) continue after it without scanning skipped rows like OFFSET does.
+type PageCursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uint      `json:"id"`
+}
+
+// Encode renders the cursor as an opaque token for clients
+func (c PageCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePageCursor parses a token produced by PageCursor.Encode
+func DecodePageCursor(token string) (*PageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+
+	var cursor PageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("invalid cursor payload: %w", err)
+	}
+	if cursor.ID == 0 || cursor.CreatedAt.IsZero() {
+		return nil, errors.New("invalid cursor position")
+	}
+
+	return &cursor, nil
+}
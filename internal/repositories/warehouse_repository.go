@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// FactWindow selects facts changed after After, from the beginning when nil, up to Through
+type FactWindow struct {
+	After   *time.Time
+	Through time.Time
+}
+
+// AssessmentFact is an assessment as exported to a warehouse; deleted assessments are included
+type AssessmentFact struct {
+	AssessmentID  uint       `json:"assessment_id"`
+	Title         string     `json:"title"`
+	Status        string     `json:"status"`
+	CreatedBy     string     `json:"created_by"`
+	Term          *string    `json:"term"`
+	Duration      int        `json:"duration"`
+	PassingScore  int        `json:"passing_score"`
+	MaxAttempts   int        `json:"max_attempts"`
+	DueDate       *time.Time `json:"due_date"`
+	QuestionCount int        `json:"question_count"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at"`
+}
+
+// AttemptFact is an attempt as exported to a warehouse
+type AttemptFact struct {
+	AttemptID       uint       `json:"attempt_id"`
+	AssessmentID    uint       `json:"assessment_id"`
+	StudentID       string     `json:"student_id"`
+	AttemptNumber   int        `json:"attempt_number"`
+	Status          string     `json:"status"`
+	StartedAt       *time.Time `json:"started_at"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	TimeSpent       int        `json:"time_spent"`
+	Score           float64    `json:"score"`
+	MaxScore        int        `json:"max_score"`
+	Percentage      float64    `json:"percentage"`
+	Passed          bool       `json:"passed"`
+	ScoreOverridden bool       `json:"score_overridden"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// AnswerFact is an answer's grade as exported to a warehouse; the answer content is not exported
+type AnswerFact struct {
+	AnswerID        uint       `json:"answer_id"`
+	AttemptID       uint       `json:"attempt_id"`
+	AssessmentID    uint       `json:"assessment_id"`
+	StudentID       string     `json:"student_id"`
+	QuestionID      uint       `json:"question_id"`
+	Score           float64    `json:"score"`
+	MaxScore        int        `json:"max_score"`
+	IsCorrect       *bool      `json:"is_correct"`
+	GradingStatus   string     `json:"grading_status"`
+	GradedBy        *string    `json:"graded_by"`
+	GradedAt        *time.Time `json:"graded_at"`
+	TimeSpent       int        `json:"time_spent"`
+	ChangeCount     int        `json:"change_count"`
+	SpellCheckShown bool       `json:"spell_check_shown"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// WarehouseRepository interface for warehouse connectors, their export runs and the facts they export
+type WarehouseRepository interface {
+	// Connectors
+	CreateConnector(ctx context.Context, tx *gorm.DB, connector *models.WarehouseConnector) error
+	GetConnectorByID(ctx context.Context, tx *gorm.DB, id uint) (*models.WarehouseConnector, error)
+	UpdateConnector(ctx context.Context, tx *gorm.DB, connector *models.WarehouseConnector) error
+	DeleteConnector(ctx context.Context, tx *gorm.DB, id uint) error // Removes its runs too
+	ListConnectors(ctx context.Context, tx *gorm.DB) ([]*models.WarehouseConnector, error)
+	GetEnabledConnectors(ctx context.Context, tx *gorm.DB) ([]*models.WarehouseConnector, error)
+
+	// Runs
+	CreateRun(ctx context.Context, tx *gorm.DB, run *models.WarehouseExportRun) error
+	GetRunByID(ctx context.Context, tx *gorm.DB, id uint) (*models.WarehouseExportRun, error)
+	UpdateRun(ctx context.Context, tx *gorm.DB, run *models.WarehouseExportRun) error
+	ListRuns(ctx context.Context, tx *gorm.DB, connectorID uint, limit int) ([]*models.WarehouseExportRun, error) // Newest first
+	// CreateIncrementalRun queues an incremental run; it reports false when the connector
+	// already has one queued or running, e.g. queued by another replica
+	CreateIncrementalRun(ctx context.Context, tx *gorm.DB, run *models.WarehouseExportRun) (bool, error)
+	// GetClaimableRuns returns queued runs and runs left running since before staleBefore, oldest first
+	GetClaimableRuns(ctx context.Context, tx *gorm.DB, staleBefore time.Time, limit int) ([]uint, error)
+	// ClaimRun marks a claimable run as running; it reports false when another worker holds it
+	ClaimRun(ctx context.Context, tx *gorm.DB, id uint, now, staleBefore time.Time) (bool, error)
+
+	// Facts, in ID order after afterID, of assessments created by the given users
+	GetAssessmentCreatorIDs(ctx context.Context, tx *gorm.DB) ([]string, error) // Deleted assessments included
+	GetAssessmentFacts(ctx context.Context, tx *gorm.DB, creatorIDs []string, window FactWindow, afterID uint, limit int) ([]AssessmentFact, error)
+	GetAttemptFacts(ctx context.Context, tx *gorm.DB, creatorIDs []string, window FactWindow, afterID uint, limit int) ([]AttemptFact, error)
+	GetAnswerFacts(ctx context.Context, tx *gorm.DB, creatorIDs []string, window FactWindow, afterID uint, limit int) ([]AnswerFact, error)
+}
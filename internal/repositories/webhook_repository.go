@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// WebhookRepository interface for webhook subscriptions and their deliveries
+type WebhookRepository interface {
+	// Subscriptions
+	CreateSubscription(ctx context.Context, tx *gorm.DB, subscription *models.WebhookSubscription) error
+	GetSubscriptionByID(ctx context.Context, tx *gorm.DB, id uint) (*models.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, tx *gorm.DB, subscription *models.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, tx *gorm.DB, id uint) error // Removes its deliveries too
+	ListSubscriptions(ctx context.Context, tx *gorm.DB) ([]*models.WebhookSubscription, error)
+	GetEnabledSubscriptions(ctx context.Context, tx *gorm.DB) ([]*models.WebhookSubscription, error)
+
+	// Deliveries
+	CreateDeliveries(ctx context.Context, tx *gorm.DB, deliveries []*models.WebhookDelivery) error
+	GetDeliveryByID(ctx context.Context, tx *gorm.DB, id uint) (*models.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, tx *gorm.DB, delivery *models.WebhookDelivery) error
+	// ListDeliveries returns a subscription's deliveries, optionally filtered by status, newest first
+	ListDeliveries(ctx context.Context, tx *gorm.DB, subscriptionID uint, status *models.WebhookDeliveryStatus, limit int) ([]*models.WebhookDelivery, error)
+	// GetClaimableDeliveries returns pending deliveries and ones left processing since before staleBefore, oldest first
+	GetClaimableDeliveries(ctx context.Context, tx *gorm.DB, staleBefore time.Time, limit int) ([]uint, error)
+	// ClaimDelivery marks a claimable delivery as processing; it reports false when another worker holds it
+	ClaimDelivery(ctx context.Context, tx *gorm.DB, id uint, now, staleBefore time.Time) (bool, error)
+}
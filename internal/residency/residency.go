@@ -0,0 +1,30 @@
+// Package residency carries the data residency region of a request's organization, so its
+// data is stored in that region's database, cache namespace and upload directory.
+package residency
+
+import (
+	"context"
+)
+
+type contextKey struct{}
+
+// WithRegion routes storage used with the returned context to the region. An empty region is
+// the primary database.
+func WithRegion(ctx context.Context, region string) context.Context {
+	return context.WithValue(ctx, contextKey{}, region)
+}
+
+// Region returns the region storage is routed to, empty for the primary database
+func Region(ctx context.Context) string {
+	region, _ := ctx.Value(contextKey{}).(string)
+	return region
+}
+
+// Key namespaces a cache key by region, since records in different regional databases can
+// share an ID
+func Key(ctx context.Context, key string) string {
+	if region := Region(ctx); region != "" {
+		return "region:" + region + ":" + key
+	}
+	return key
+}
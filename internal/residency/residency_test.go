@@ -0,0 +1,20 @@
+package residency
+
+import (
+	"context"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	ctx := context.Background()
+	if got := Key(ctx, "assessment:1"); got != "assessment:1" {
+		t.Errorf("expected primary keys unchanged, got %q", got)
+	}
+	if got := Key(WithRegion(ctx, "eu"), "assessment:1"); got != "region:eu:assessment:1" {
+		t.Errorf("expected a region prefix, got %q", got)
+	}
+	// Routing back to the primary database drops the prefix
+	if got := Key(WithRegion(WithRegion(ctx, "eu"), ""), "assessment:1"); got != "assessment:1" {
+		t.Errorf("expected the primary key, got %q", got)
+	}
+}
@@ -0,0 +1,94 @@
+package seed
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Config sizes a synthetic data set. Every organization gets its own teachers and students;
+// teachers build banks and assessments, and the organization's students take them.
+type Config struct {
+	Prefix string // Prefix of every seeded user ID and title, so seeded data is easy to find
+	Term   string // Academic term set on seeded assessments
+
+	Orgs                   int
+	TeachersPerOrg         int
+	StudentsPerOrg         int
+	BanksPerTeacher        int
+	QuestionsPerBank       int
+	AssessmentsPerTeacher  int
+	QuestionsPerAssessment int
+	AttemptRate            float64 // Share of an organization's students who take each assessment
+
+	// OwnerID replaces the first teacher with an existing account, so the seeded content
+	// can be browsed after logging in as that user
+	OwnerID string
+
+	Seed      int64 // Seed for every random choice; 0 picks one from the clock
+	BatchSize int   // Rows per insert statement
+	Now       time.Time
+}
+
+const (
+	defaultOrgs                   = 3
+	defaultTeachersPerOrg         = 4
+	defaultStudentsPerOrg         = 200
+	defaultBanksPerTeacher        = 2
+	defaultQuestionsPerBank       = 25
+	defaultAssessmentsPerTeacher  = 3
+	defaultQuestionsPerAssessment = 15
+	defaultAttemptRate            = 0.8
+	defaultBatchSize              = 500
+)
+
+func (c *Config) applyDefaults() {
+	c.Prefix = strings.TrimSpace(c.Prefix)
+	if c.Prefix == "" {
+		c.Prefix = "seed"
+	}
+	if c.Term == "" {
+		c.Term = "Seed Term"
+	}
+	setDefault(&c.Orgs, defaultOrgs)
+	setDefault(&c.TeachersPerOrg, defaultTeachersPerOrg)
+	setDefault(&c.StudentsPerOrg, defaultStudentsPerOrg)
+	setDefault(&c.BanksPerTeacher, defaultBanksPerTeacher)
+	setDefault(&c.QuestionsPerBank, defaultQuestionsPerBank)
+	setDefault(&c.AssessmentsPerTeacher, defaultAssessmentsPerTeacher)
+	setDefault(&c.QuestionsPerAssessment, defaultQuestionsPerAssessment)
+	setDefault(&c.BatchSize, defaultBatchSize)
+	if c.AttemptRate == 0 {
+		c.AttemptRate = defaultAttemptRate
+	}
+	if c.Seed == 0 {
+		c.Seed = time.Now().UnixNano()
+	}
+	if c.Now.IsZero() {
+		c.Now = time.Now()
+	}
+}
+
+func (c *Config) validate() error {
+	switch {
+	case c.Orgs < 0 || c.TeachersPerOrg < 0 || c.StudentsPerOrg < 0:
+		return errors.New("organization, teacher and student counts cannot be negative")
+	case c.BanksPerTeacher < 0 || c.QuestionsPerBank < 0:
+		return errors.New("bank and question counts cannot be negative")
+	case c.AssessmentsPerTeacher < 0 || c.QuestionsPerAssessment < 0:
+		return errors.New("assessment counts cannot be negative")
+	case c.AssessmentsPerTeacher > 0 && c.QuestionsPerAssessment > c.BanksPerTeacher*c.QuestionsPerBank:
+		return errors.New("assessments need more questions than each teacher's banks hold")
+	case c.AttemptRate < 0 || c.AttemptRate > 1:
+		return errors.New("attempt rate must be between 0 and 1")
+	case c.BatchSize < 0:
+		return errors.New("batch size cannot be negative")
+	}
+	return nil
+}
+
+func setDefault(value *int, fallback int) {
+	if *value == 0 {
+		*value = fallback
+	}
+}
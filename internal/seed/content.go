@@ -0,0 +1,154 @@
+package seed
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+var (
+	orgNames = []string{"Northbridge", "Riverside", "Lakeview", "Hillcrest", "Westfield", "Oakridge", "Brookside", "Fairview"}
+	topics   = []string{"Algebra", "Biology", "Chemistry", "World History", "Geography", "Physics", "Literature", "Statistics"}
+)
+
+// questionMix is how often each question type is generated, in percent
+var questionMix = []struct {
+	questionType models.QuestionType
+	weight       int
+	points       int
+}{
+	{models.MultipleChoice, 35, 2},
+	{models.TrueFalse, 15, 1},
+	{models.FillInBlank, 10, 2},
+	{models.ShortAnswer, 10, 2},
+	{models.Matching, 10, 4},
+	{models.Ordering, 10, 3},
+	{models.Essay, 10, 10},
+}
+
+// Matching and ordering questions use this many items
+const itemsPerQuestion = 4
+
+func orgName(index int) string {
+	if index < len(orgNames) {
+		return orgNames[index]
+	}
+	return fmt.Sprintf("%s %d", orgNames[index%len(orgNames)], index/len(orgNames)+1)
+}
+
+func pickQuestionType(rng *rand.Rand) (models.QuestionType, int) {
+	roll := rng.Intn(100)
+	for _, entry := range questionMix {
+		if roll < entry.weight {
+			return entry.questionType, entry.points
+		}
+		roll -= entry.weight
+	}
+	last := questionMix[len(questionMix)-1]
+	return last.questionType, last.points
+}
+
+func pickDifficulty(rng *rand.Rand) models.DifficultyLevel {
+	switch roll := rng.Intn(10); {
+	case roll < 3:
+		return models.DifficultyEasy
+	case roll < 8:
+		return models.DifficultyMedium
+	default:
+		return models.DifficultyHard
+	}
+}
+
+// buildQuestion generates a question of a random type about the topic; number keeps the
+// wording of questions in the same bank apart
+func buildQuestion(rng *rand.Rand, topic string, number int, createdBy string, createdAt time.Time) (models.Question, error) {
+	questionType, points := pickQuestionType(rng)
+	concept := fmt.Sprintf("%s concept %d", topic, number)
+
+	var text string
+	var content interface{}
+	switch questionType {
+	case models.MultipleChoice:
+		text = fmt.Sprintf("Which statement about %s is correct?", concept)
+		options := make([]models.MCOption, 4)
+		for i := range options {
+			id := string(rune('a' + i))
+			options[i] = models.MCOption{ID: id, Text: fmt.Sprintf("Statement %s about %s", id, concept), Order: i}
+		}
+		content = models.MultipleChoiceContent{
+			Options:          options,
+			CorrectAnswers:   []string{options[rng.Intn(len(options))].ID},
+			RandomizeOptions: true,
+		}
+	case models.TrueFalse:
+		text = fmt.Sprintf("True or false: %s applies to every case covered in class.", concept)
+		content = models.TrueFalseContent{CorrectAnswer: rng.Intn(2) == 0}
+	case models.FillInBlank:
+		text = fmt.Sprintf("Complete the definition of %s.", concept)
+		content = models.FillBlankContent{
+			Template:   fmt.Sprintf("In %s, concept %d is known as {blank1}.", topic, number),
+			Blanks:     map[string]models.BlankDef{"blank1": {AcceptedAnswers: []string{termFor(number)}, Points: points}},
+			TrimSpaces: true,
+		}
+	case models.ShortAnswer:
+		text = fmt.Sprintf("Name the term used for %s.", concept)
+		content = models.ShortAnswerContent{AcceptedAnswers: []string{termFor(number)}, MaxLength: 100}
+	case models.Matching:
+		text = fmt.Sprintf("Match each part of %s with its description.", concept)
+		matching := models.MatchingContent{RandomizeRight: true, PartialCredit: true}
+		for i := 1; i <= itemsPerQuestion; i++ {
+			left, right := fmt.Sprintf("l%d", i), fmt.Sprintf("r%d", i)
+			matching.LeftItems = append(matching.LeftItems, models.MatchItem{ID: left, Text: fmt.Sprintf("Part %d", i)})
+			matching.RightItems = append(matching.RightItems, models.MatchItem{ID: right, Text: fmt.Sprintf("Description of part %d", i)})
+			matching.CorrectPairs = append(matching.CorrectPairs, models.MatchPair{LeftID: left, RightID: right})
+		}
+		content = matching
+	case models.Ordering:
+		text = fmt.Sprintf("Put the steps of %s in order.", concept)
+		ordering := models.OrderingContent{RandomizeInit: true, PartialCredit: true}
+		for i := 1; i <= itemsPerQuestion; i++ {
+			id := fmt.Sprintf("s%d", i)
+			ordering.Items = append(ordering.Items, models.OrderItem{ID: id, Text: fmt.Sprintf("Step %d", i)})
+			ordering.CorrectOrder = append(ordering.CorrectOrder, id)
+		}
+		content = ordering
+	default:
+		text = fmt.Sprintf("Explain %s and give an example.", concept)
+		minWords, maxWords := 50, 400
+		content = models.EssayContent{
+			MinWords:        &minWords,
+			MaxWords:        &maxWords,
+			SuggestedLength: "2-3 paragraphs",
+			RubricCriteria:  []string{"Accuracy", "Use of examples", "Clarity"},
+		}
+	}
+
+	encoded, err := json.Marshal(content)
+	if err != nil {
+		return models.Question{}, fmt.Errorf("failed to encode %s content: %w", questionType, err)
+	}
+	tags, err := json.Marshal([]string{"seed", topic})
+	if err != nil {
+		return models.Question{}, err
+	}
+
+	return models.Question{
+		Type:       questionType,
+		Text:       text,
+		Points:     points,
+		Content:    datatypes.JSON(encoded),
+		Difficulty: pickDifficulty(rng),
+		Tags:       datatypes.JSON(tags),
+		CreatedBy:  createdBy,
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+	}, nil
+}
+
+func termFor(number int) string {
+	return fmt.Sprintf("term %d", number)
+}
@@ -0,0 +1,192 @@
+package seed
+
+import (
+	"encoding/json"
+	"math"
+	"math/rand"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// Item difficulty on the same scale as student ability, which is drawn from N(0, 1)
+var difficultyOffsets = map[models.DifficultyLevel]float64{
+	models.DifficultyEasy:   -1,
+	models.DifficultyMedium: 0,
+	models.DifficultyHard:   1,
+}
+
+// Expected seconds spent on each question type
+var typeSeconds = map[models.QuestionType]float64{
+	models.MultipleChoice: 45,
+	models.TrueFalse:      20,
+	models.FillInBlank:    40,
+	models.ShortAnswer:    50,
+	models.Matching:       90,
+	models.Ordering:       75,
+	models.Essay:          480,
+}
+
+var essayWords = strings.Fields("the answer depends on the definition because each step keeps the " +
+	"main idea intact and so the example shows how the concept applies in practice")
+
+// response is a generated answer with the grade automatic or manual grading would give it
+type response struct {
+	payload   interface{}
+	score     float64
+	isCorrect *bool // nil for manually graded questions
+	manual    bool
+	timeSpent int
+}
+
+// correctProbability is a two-parameter logistic model: stronger students and easier
+// questions give a higher chance of answering correctly
+func correctProbability(ability float64, difficulty models.DifficultyLevel) float64 {
+	return 1 / (1 + math.Exp(-1.7*(ability-difficultyOffsets[difficulty])))
+}
+
+// answerQuestion picks a plausible answer for a student of the given ability
+func answerQuestion(rng *rand.Rand, question *models.Question, points int, ability float64) response {
+	p := correctProbability(ability, question.Difficulty)
+	r := response{timeSpent: questionTime(rng, question.Type)}
+
+	switch question.Type {
+	case models.MultipleChoice:
+		var content models.MultipleChoiceContent
+		_ = json.Unmarshal(question.Content, &content)
+		selected := content.CorrectAnswers
+		correct := rng.Float64() < p
+		if !correct {
+			selected = []string{wrongOption(rng, content)}
+		}
+		r.payload = models.MultipleChoiceAnswer{SelectedOptions: selected, TimeSpent: r.timeSpent}
+		r.grade(correct, points)
+	case models.TrueFalse:
+		var content models.TrueFalseContent
+		_ = json.Unmarshal(question.Content, &content)
+		correct := rng.Float64() < p
+		r.payload = models.TrueFalseAnswer{Answer: content.CorrectAnswer == correct, TimeSpent: r.timeSpent}
+		r.grade(correct, points)
+	case models.FillInBlank:
+		var content models.FillBlankContent
+		_ = json.Unmarshal(question.Content, &content)
+		correct := rng.Float64() < p
+		answers := make(map[string]string, len(content.Blanks))
+		for id, blank := range content.Blanks {
+			answers[id] = "unsure"
+			if correct && len(blank.AcceptedAnswers) > 0 {
+				answers[id] = blank.AcceptedAnswers[0]
+			}
+		}
+		r.payload = models.FillBlankAnswer{Answers: answers, TimeSpent: r.timeSpent}
+		r.grade(correct, points)
+	case models.ShortAnswer:
+		var content models.ShortAnswerContent
+		_ = json.Unmarshal(question.Content, &content)
+		correct := rng.Float64() < p && len(content.AcceptedAnswers) > 0
+		text := "not sure"
+		if correct {
+			text = content.AcceptedAnswers[0]
+		}
+		r.payload = models.ShortAnswers{Text: text, TimeSpent: r.timeSpent}
+		r.grade(correct, points)
+	case models.Matching:
+		var content models.MatchingContent
+		_ = json.Unmarshal(question.Content, &content)
+		rights := make([]string, len(content.CorrectPairs))
+		for i, pair := range content.CorrectPairs {
+			rights[i] = pair.RightID
+		}
+		rights, right := partiallyCorrect(rng, rights, p)
+		pairs := make([]models.MatchPair, len(rights))
+		for i, pair := range content.CorrectPairs {
+			pairs[i] = models.MatchPair{LeftID: pair.LeftID, RightID: rights[i]}
+		}
+		r.payload = models.MatchingAnswer{Pairs: pairs, TimeSpent: r.timeSpent}
+		r.gradePartial(right, len(rights), points)
+	case models.Ordering:
+		var content models.OrderingContent
+		_ = json.Unmarshal(question.Content, &content)
+		order, right := partiallyCorrect(rng, content.CorrectOrder, p)
+		r.payload = models.OrderingAnswer{Order: order, TimeSpent: r.timeSpent}
+		r.gradePartial(right, len(order), points)
+	default:
+		// Essays are marked by hand; the mark tracks ability with some grader noise
+		words := 60 + rng.Intn(240)
+		quality := math.Max(0, math.Min(1, p+rng.NormFloat64()*0.1))
+		r.payload = models.EssayAnswer{Text: essayText(rng, words), WordCount: words, TimeSpent: r.timeSpent}
+		r.score = math.Round(quality * float64(points))
+		r.manual = true
+	}
+	return r
+}
+
+func (r *response) grade(correct bool, points int) {
+	r.isCorrect = &correct
+	if correct {
+		r.score = float64(points)
+	}
+}
+
+func (r *response) gradePartial(right, total, points int) {
+	correct := total > 0 && right == total
+	r.isCorrect = &correct
+	if total > 0 {
+		r.score = math.Round(float64(points)*float64(right)/float64(total)*100) / 100
+	}
+}
+
+// partiallyCorrect keeps each position with probability p and shuffles the rest so none of
+// them lands in its own place. It returns the answer and how many positions are right.
+func partiallyCorrect(rng *rand.Rand, correct []string, p float64) ([]string, int) {
+	answer := append([]string(nil), correct...)
+	var wrong []int
+	for i := range answer {
+		if rng.Float64() >= p {
+			wrong = append(wrong, i)
+		}
+	}
+	// A single misplaced item cannot be out of place on its own
+	if len(wrong) == 1 {
+		wrong = nil
+	}
+	// Rotating the misplaced items by one moves every one of them
+	for i, position := range wrong {
+		answer[position] = correct[wrong[(i+1)%len(wrong)]]
+	}
+	return answer, len(answer) - len(wrong)
+}
+
+func wrongOption(rng *rand.Rand, content models.MultipleChoiceContent) string {
+	correct := make(map[string]bool, len(content.CorrectAnswers))
+	for _, id := range content.CorrectAnswers {
+		correct[id] = true
+	}
+	var wrong []string
+	for _, option := range content.Options {
+		if !correct[option.ID] {
+			wrong = append(wrong, option.ID)
+		}
+	}
+	if len(wrong) == 0 {
+		return ""
+	}
+	return wrong[rng.Intn(len(wrong))]
+}
+
+// questionTime varies around the type's typical time with a long right tail
+func questionTime(rng *rand.Rand, questionType models.QuestionType) int {
+	mean, ok := typeSeconds[questionType]
+	if !ok {
+		mean = 60
+	}
+	return int(math.Max(5, math.Round(mean*math.Exp(rng.NormFloat64()*0.4))))
+}
+
+func essayText(rng *rand.Rand, words int) string {
+	parts := make([]string, words)
+	for i := range parts {
+		parts[i] = essayWords[rng.Intn(len(essayWords))]
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,65 @@
+package seed
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestPartiallyCorrectCountsRightPositions(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	correct := []string{"s1", "s2", "s3", "s4", "s5"}
+
+	for i := 0; i < 200; i++ {
+		answer, right := partiallyCorrect(rng, correct, 0.5)
+		matches := 0
+		for j := range answer {
+			if answer[j] == correct[j] {
+				matches++
+			}
+		}
+		if matches != right {
+			t.Fatalf("reported %d right positions but %d match in %v", right, matches, answer)
+		}
+		if right == len(correct)-1 {
+			t.Fatalf("exactly one misplaced item is impossible, got %v", answer)
+		}
+	}
+}
+
+func TestCorrectProbabilityFollowsAbilityAndDifficulty(t *testing.T) {
+	if correctProbability(1, models.DifficultyMedium) <= correctProbability(-1, models.DifficultyMedium) {
+		t.Error("stronger students should be more likely to answer correctly")
+	}
+	if correctProbability(0, models.DifficultyEasy) <= correctProbability(0, models.DifficultyHard) {
+		t.Error("easy questions should be answered correctly more often than hard ones")
+	}
+	if p := correctProbability(0, models.DifficultyMedium); p != 0.5 {
+		t.Errorf("an average student should have even odds on a medium question, got %v", p)
+	}
+}
+
+func TestAnswerQuestionGradesWithinPoints(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	now := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	for n := 1; n <= 300; n++ {
+		question, err := buildQuestion(rng, "Biology", n, "teacher-1", now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := answerQuestion(rng, &question, question.Points, rng.NormFloat64())
+
+		if r.score < 0 || r.score > float64(question.Points) {
+			t.Fatalf("%s question scored %v out of %d", question.Type, r.score, question.Points)
+		}
+		if r.manual != (question.Type == models.Essay) {
+			t.Fatalf("%s question: manual grading should only apply to essays", question.Type)
+		}
+		if !r.manual && (r.isCorrect == nil || *r.isCorrect != (r.score == float64(question.Points))) {
+			t.Fatalf("%s question: correctness does not match score %v/%d", question.Type, r.score, question.Points)
+		}
+	}
+}
@@ -0,0 +1,436 @@
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// Assessments open within this window before Now and stay open for attemptWindow
+	assessmentAge = 60 * 24 * time.Hour
+	attemptWindow = 21 * 24 * time.Hour
+
+	timeoutRate      = 0.05 // Attempts that ran out of time instead of being submitted
+	essayBacklogRate = 0.15 // Essays still waiting for a grade
+)
+
+// Seeder writes a synthetic data set straight to the database
+type Seeder struct {
+	cfg    Config
+	db     *gorm.DB
+	logger *slog.Logger
+	rng    *rand.Rand
+}
+
+// Summary counts what a run created
+type Summary struct {
+	Seed           int64   `json:"seed"`
+	Orgs           int     `json:"orgs"`
+	Teachers       int     `json:"teachers"`
+	Students       int     `json:"students"`
+	Banks          int     `json:"banks"`
+	Questions      int     `json:"questions"`
+	Assessments    int     `json:"assessments"`
+	Attempts       int     `json:"attempts"`
+	Answers        int     `json:"answers"`
+	PendingGrading int     `json:"pending_grading"` // Essays left ungraded
+	AverageScore   float64 `json:"average_score"`   // Mean attempt percentage
+	PassRate       float64 `json:"pass_rate"`       // Percentage of attempts that passed
+}
+
+type student struct {
+	id      string
+	ability float64
+}
+
+type org struct {
+	name     string
+	teachers []string
+	students []student
+}
+
+func NewSeeder(cfg Config, db *gorm.DB, logger *slog.Logger) (*Seeder, error) {
+	cfg.applyDefaults()
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &Seeder{
+		cfg:    cfg,
+		db:     db,
+		logger: logger,
+		rng:    rand.New(rand.NewSource(cfg.Seed)),
+	}, nil
+}
+
+// Run creates the whole data set in one transaction, so a failed run leaves nothing behind
+func (s *Seeder) Run(ctx context.Context) (*Summary, error) {
+	summary := &Summary{Seed: s.cfg.Seed}
+	s.logger.Info("Seeding data", "seed", s.cfg.Seed, "prefix", s.cfg.Prefix, "orgs", s.cfg.Orgs)
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		orgs, err := s.seedUsers(tx, summary)
+		if err != nil {
+			return err
+		}
+
+		percentSum, passed := 0.0, 0
+		for _, o := range orgs {
+			for _, teacherID := range o.teachers {
+				questions, err := s.seedBanks(tx, teacherID, summary)
+				if err != nil {
+					return err
+				}
+				for i := 0; i < s.cfg.AssessmentsPerTeacher; i++ {
+					attempts, err := s.seedAssessment(tx, o, teacherID, questions, i+1, summary)
+					if err != nil {
+						return err
+					}
+					for _, attempt := range attempts {
+						percentSum += attempt.Percentage
+						if attempt.Passed {
+							passed++
+						}
+					}
+				}
+			}
+			s.logger.Info("Seeded organization", "org", o.name, "attempts", summary.Attempts)
+		}
+
+		if summary.Attempts > 0 {
+			summary.AverageScore = math.Round(percentSum/float64(summary.Attempts)*10) / 10
+			summary.PassRate = math.Round(float64(passed)/float64(summary.Attempts)*1000) / 10
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// ===== USERS =====
+
+func (s *Seeder) seedUsers(tx *gorm.DB, summary *Summary) ([]org, error) {
+	orgs := make([]org, s.cfg.Orgs)
+	var users []models.User
+
+	for i := range orgs {
+		o := &orgs[i]
+		o.name = orgName(i)
+		slug := strings.ReplaceAll(strings.ToLower(o.name), " ", "-")
+
+		for j := 1; j <= s.cfg.TeachersPerOrg; j++ {
+			id := fmt.Sprintf("%s-%s-teacher-%d", s.cfg.Prefix, slug, j)
+			if i == 0 && j == 1 && s.cfg.OwnerID != "" {
+				o.teachers = append(o.teachers, s.cfg.OwnerID) // Existing account, not seeded
+				continue
+			}
+			o.teachers = append(o.teachers, id)
+			users = append(users, s.user(id, fmt.Sprintf("%s Teacher %d", o.name, j), slug))
+		}
+		for j := 1; j <= s.cfg.StudentsPerOrg; j++ {
+			id := fmt.Sprintf("%s-%s-student-%d", s.cfg.Prefix, slug, j)
+			o.students = append(o.students, student{id: id, ability: s.rng.NormFloat64()})
+			users = append(users, s.user(id, fmt.Sprintf("%s Student %d", o.name, j), slug))
+		}
+
+		summary.Teachers += len(o.teachers)
+		summary.Students += len(o.students)
+	}
+	summary.Orgs = len(orgs)
+
+	if len(users) == 0 {
+		return orgs, nil
+	}
+	// Users from an earlier run with the same prefix are reused
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(users, s.cfg.BatchSize).Error; err != nil {
+		return nil, fmt.Errorf("failed to seed users: %w", err)
+	}
+	return orgs, nil
+}
+
+func (s *Seeder) user(id, name, orgSlug string) models.User {
+	return models.User{
+		ID:            id,
+		FullName:      name,
+		Email:         fmt.Sprintf("%s@%s.example.edu", id, orgSlug),
+		Language:      "en",
+		IsActive:      true,
+		EmailVerified: true,
+		CreatedAt:     s.cfg.Now,
+		UpdatedAt:     s.cfg.Now,
+	}
+}
+
+// ===== QUESTION BANKS =====
+
+// seedBanks creates the teacher's banks and returns every question in them
+func (s *Seeder) seedBanks(tx *gorm.DB, teacherID string, summary *Summary) ([]models.Question, error) {
+	createdAt := s.cfg.Now.Add(-assessmentAge - attemptWindow)
+	var questions []models.Question
+
+	for b := 0; b < s.cfg.BanksPerTeacher; b++ {
+		topic := topics[s.rng.Intn(len(topics))]
+		description := fmt.Sprintf("Synthetic %s questions", strings.ToLower(topic))
+		bank := models.QuestionBank{
+			Name:        fmt.Sprintf("[%s] %s bank %d", s.cfg.Prefix, topic, b+1),
+			Description: &description,
+			CreatedBy:   teacherID,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+		}
+		if err := tx.Create(&bank).Error; err != nil {
+			return nil, fmt.Errorf("failed to seed question bank: %w", err)
+		}
+
+		bankQuestions := make([]models.Question, 0, s.cfg.QuestionsPerBank)
+		for n := 1; n <= s.cfg.QuestionsPerBank; n++ {
+			question, err := buildQuestion(s.rng, topic, n, teacherID, createdAt)
+			if err != nil {
+				return nil, err
+			}
+			bankQuestions = append(bankQuestions, question)
+		}
+		if len(bankQuestions) == 0 {
+			summary.Banks++
+			continue
+		}
+		if err := tx.CreateInBatches(bankQuestions, s.cfg.BatchSize).Error; err != nil {
+			return nil, fmt.Errorf("failed to seed questions: %w", err)
+		}
+
+		links := make([]map[string]interface{}, len(bankQuestions))
+		for i, question := range bankQuestions {
+			links[i] = map[string]interface{}{"question_bank_id": bank.ID, "question_id": question.ID}
+		}
+		if err := tx.Table("question_bank_questions").CreateInBatches(links, s.cfg.BatchSize).Error; err != nil {
+			return nil, fmt.Errorf("failed to link questions to bank: %w", err)
+		}
+
+		questions = append(questions, bankQuestions...)
+		summary.Banks++
+		summary.Questions += len(bankQuestions)
+	}
+	return questions, nil
+}
+
+// ===== ASSESSMENTS AND ATTEMPTS =====
+
+func (s *Seeder) seedAssessment(tx *gorm.DB, o org, teacherID string, pool []models.Question, number int, summary *Summary) ([]models.AssessmentAttempt, error) {
+	// Opened at least a day ago so every attempt has finished by Now
+	openedAt := s.cfg.Now.Add(-24*time.Hour - time.Duration(s.rng.Int63n(int64(assessmentAge))))
+	dueDate := openedAt.Add(attemptWindow)
+	term := s.cfg.Term
+	assessment := models.Assessment{
+		Title:        fmt.Sprintf("[%s] %s quiz %d (%s)", s.cfg.Prefix, o.name, number, teacherID),
+		Duration:     30 + 15*s.rng.Intn(5),
+		Status:       models.StatusActive,
+		PassingScore: []int{50, 60, 70}[s.rng.Intn(3)],
+		MaxAttempts:  1,
+		DueDate:      &dueDate,
+		DueTimezone:  "UTC",
+		Term:         &term,
+		CreatedBy:    teacherID,
+		CreatedAt:    openedAt,
+		UpdatedAt:    openedAt,
+	}
+	if err := tx.Create(&assessment).Error; err != nil {
+		return nil, fmt.Errorf("failed to seed assessment: %w", err)
+	}
+
+	settings := models.AssessmentSettings{
+		AssessmentID:       assessment.ID,
+		ShowProgressBar:    true,
+		ShowResults:        true,
+		ResultsReleaseMode: models.ResultsReleaseImmediate,
+		GradePolicy:        models.GradePolicyHighest,
+		TimingMode:         models.TimingModeTotal,
+		CalculatorType:     models.CalculatorNone,
+		TimeLimitEnforced:  true,
+		QuestionsPerPage:   1,
+		CreatedAt:          openedAt,
+		UpdatedAt:          openedAt,
+	}
+	if err := tx.Create(&settings).Error; err != nil {
+		return nil, fmt.Errorf("failed to seed assessment settings: %w", err)
+	}
+
+	picked := s.rng.Perm(len(pool))[:s.cfg.QuestionsPerAssessment]
+	questions := make([]*models.Question, len(picked))
+	links := make([]models.AssessmentQuestion, len(picked))
+	for i, index := range picked {
+		questions[i] = &pool[index]
+		links[i] = models.AssessmentQuestion{
+			AssessmentID: assessment.ID,
+			QuestionID:   pool[index].ID,
+			Order:        i + 1,
+			Required:     true,
+			CreatedAt:    openedAt,
+		}
+	}
+	if len(links) > 0 {
+		if err := tx.CreateInBatches(links, s.cfg.BatchSize).Error; err != nil {
+			return nil, fmt.Errorf("failed to add questions to assessment: %w", err)
+		}
+	}
+
+	var attempts []models.AssessmentAttempt
+	var answers [][]models.StudentAnswer
+	for _, st := range o.students {
+		if s.rng.Float64() >= s.cfg.AttemptRate {
+			continue
+		}
+		attempt, attemptAnswers, err := s.buildAttempt(&assessment, questions, st)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+		answers = append(answers, attemptAnswers)
+	}
+	if len(attempts) == 0 {
+		summary.Assessments++
+		return nil, nil
+	}
+	if err := tx.CreateInBatches(attempts, s.cfg.BatchSize).Error; err != nil {
+		return nil, fmt.Errorf("failed to seed attempts: %w", err)
+	}
+
+	var allAnswers []models.StudentAnswer
+	for i := range attempts {
+		for j := range answers[i] {
+			answers[i][j].AttemptID = attempts[i].ID
+			if !answers[i][j].IsGraded {
+				summary.PendingGrading++
+			}
+		}
+		allAnswers = append(allAnswers, answers[i]...)
+	}
+	if len(allAnswers) > 0 {
+		if err := tx.CreateInBatches(allAnswers, s.cfg.BatchSize).Error; err != nil {
+			return nil, fmt.Errorf("failed to seed answers: %w", err)
+		}
+	}
+
+	summary.Assessments++
+	summary.Attempts += len(attempts)
+	summary.Answers += len(allAnswers)
+	return attempts, nil
+}
+
+// buildAttempt answers every question and grades the attempt as the service would, leaving
+// some essays in the grading backlog
+func (s *Seeder) buildAttempt(assessment *models.Assessment, questions []*models.Question, st student) (models.AssessmentAttempt, []models.StudentAnswer, error) {
+	limit := assessment.Duration * 60
+	window := attemptWindow
+	if remaining := s.cfg.Now.Sub(assessment.CreatedAt) - time.Duration(limit)*time.Second; remaining < window {
+		window = remaining
+	}
+	if window < 0 {
+		window = 0
+	}
+	startedAt := assessment.CreatedAt.Add(time.Duration(s.rng.Int63n(int64(window) + 1)))
+
+	attempt := models.AssessmentAttempt{
+		AssessmentID:   assessment.ID,
+		StudentID:      st.id,
+		AttemptNumber:  1,
+		Status:         models.AttemptCompleted,
+		StartedAt:      &startedAt,
+		TotalQuestions: len(questions),
+		CreatedAt:      startedAt,
+	}
+
+	answers := make([]models.StudentAnswer, 0, len(questions))
+	elapsed := 0
+	for _, question := range questions {
+		r := answerQuestion(s.rng, question, question.Points, st.ability)
+		if elapsed+r.timeSpent > limit {
+			break // Out of time before reaching this question
+		}
+		elapsed += r.timeSpent
+
+		payload, err := json.Marshal(r.payload)
+		if err != nil {
+			return attempt, nil, fmt.Errorf("failed to encode answer: %w", err)
+		}
+		answeredAt := startedAt.Add(time.Duration(elapsed) * time.Second)
+		answer := models.StudentAnswer{
+			QuestionID:      question.ID,
+			Answer:          datatypes.JSON(payload),
+			MaxScore:        question.Points,
+			IsCorrect:       r.isCorrect,
+			TimeSpent:       r.timeSpent,
+			FirstAnsweredAt: &answeredAt,
+			LastModifiedAt:  &answeredAt,
+			CreatedAt:       answeredAt,
+			UpdatedAt:       answeredAt,
+		}
+		attempt.MaxScore += question.Points
+
+		gradedAt := answeredAt
+		if r.manual {
+			gradedAt = answeredAt.Add(time.Duration(1+s.rng.Intn(96)) * time.Hour)
+			if gradedAt.After(s.cfg.Now) || s.rng.Float64() < essayBacklogRate {
+				answers = append(answers, answer)
+				continue
+			}
+			grader := assessment.CreatedBy
+			answer.GradedBy = &grader
+			answer.GradingStatus = models.GradingStatusManuallyGraded
+		} else {
+			answer.GradingStatus = models.GradingStatusAutoGraded
+		}
+		answer.Score = r.score
+		answer.IsGraded = true
+		answer.GradedAt = &gradedAt
+		attempt.Score += r.score
+		answers = append(answers, answer)
+	}
+
+	// Unanswered questions still count towards the maximum score
+	for _, question := range questions[len(answers):] {
+		attempt.MaxScore += question.Points
+	}
+
+	timedOut := len(answers) < len(questions) || s.rng.Float64() < timeoutRate
+	if timedOut {
+		elapsed = limit
+		attempt.Status = models.AttemptTimeOut
+		reason := models.AttemptEndReasonTimeout
+		attempt.EndReason = &reason
+	}
+	endedAt := startedAt.Add(time.Duration(elapsed) * time.Second)
+	attempt.EndedAt = &endedAt
+	attempt.CompletedAt = &endedAt
+	attempt.TimeSpent = elapsed
+	attempt.QuestionsAnswered = len(answers)
+	attempt.CurrentQuestionIndex = len(answers)
+	attempt.UpdatedAt = endedAt
+	if attempt.MaxScore > 0 {
+		attempt.Percentage = math.Round(attempt.Score/float64(attempt.MaxScore)*1000) / 10
+	}
+	attempt.Passed = attempt.Percentage >= float64(assessment.PassingScore)
+
+	return attempt, answers, nil
+}
+
+// WriteText prints the summary for a terminal
+func (s *Summary) WriteText(w io.Writer) {
+	fmt.Fprintf(w, "Seed %d\n", s.Seed)
+	fmt.Fprintf(w, "%d orgs, %d teachers, %d students\n", s.Orgs, s.Teachers, s.Students)
+	fmt.Fprintf(w, "%d banks with %d questions, %d assessments\n", s.Banks, s.Questions, s.Assessments)
+	fmt.Fprintf(w, "%d attempts with %d answers, %d essays waiting for a grade\n", s.Attempts, s.Answers, s.PendingGrading)
+	fmt.Fprintf(w, "Average score %.1f%%, pass rate %.1f%%\n", s.AverageScore, s.PassRate)
+}
@@ -0,0 +1,111 @@
+package seed
+
+import (
+	"io"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func newTestSeeder(t *testing.T) *Seeder {
+	t.Helper()
+	seeder, err := NewSeeder(Config{Seed: 11, Now: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)}, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return seeder
+}
+
+func testAssessment(t *testing.T, s *Seeder) (*models.Assessment, []*models.Question) {
+	t.Helper()
+	assessment := &models.Assessment{
+		ID:           1,
+		Duration:     60,
+		PassingScore: 60,
+		CreatedBy:    "teacher-1",
+		CreatedAt:    s.cfg.Now.Add(-30 * 24 * time.Hour),
+	}
+	questions := make([]*models.Question, 15)
+	for i := range questions {
+		question, err := buildQuestion(s.rng, "Physics", i+1, "teacher-1", assessment.CreatedAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		question.ID = uint(i + 1)
+		questions[i] = &question
+	}
+	return assessment, questions
+}
+
+func TestBuildAttemptTotalsMatchAnswers(t *testing.T) {
+	s := newTestSeeder(t)
+	assessment, questions := testAssessment(t, s)
+
+	for i := 0; i < 50; i++ {
+		attempt, answers, err := s.buildAttempt(assessment, questions, student{id: "student-1", ability: s.rng.NormFloat64()})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		score, maxScore := 0.0, 0
+		for _, answer := range answers {
+			if answer.IsGraded {
+				score += answer.Score
+			}
+			if answer.GradedAt != nil && answer.GradedAt.After(s.cfg.Now) {
+				t.Fatalf("answer graded in the future: %v", answer.GradedAt)
+			}
+		}
+		for _, question := range questions {
+			maxScore += question.Points
+		}
+
+		if math.Abs(attempt.Score-score) > 1e-9 || attempt.MaxScore != maxScore {
+			t.Fatalf("attempt scored %v/%d, answers add up to %v/%d", attempt.Score, attempt.MaxScore, score, maxScore)
+		}
+		if attempt.TimeSpent > assessment.Duration*60 {
+			t.Fatalf("attempt took %ds, over the %d minute limit", attempt.TimeSpent, assessment.Duration)
+		}
+		if attempt.Passed != (attempt.Percentage >= float64(assessment.PassingScore)) {
+			t.Fatalf("passed does not match %v%%", attempt.Percentage)
+		}
+	}
+}
+
+func TestBuildAttemptScoresTrackAbility(t *testing.T) {
+	s := newTestSeeder(t)
+	assessment, questions := testAssessment(t, s)
+
+	average := func(ability float64) float64 {
+		total := 0.0
+		for i := 0; i < 100; i++ {
+			attempt, _, err := s.buildAttempt(assessment, questions, student{id: "student-1", ability: ability})
+			if err != nil {
+				t.Fatal(err)
+			}
+			total += attempt.Percentage
+		}
+		return total / 100
+	}
+
+	if strong, weak := average(1.5), average(-1.5); strong <= weak+20 {
+		t.Errorf("expected strong students to score well above weak ones, got %.1f%% vs %.1f%%", strong, weak)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := Config{BanksPerTeacher: 1, QuestionsPerBank: 5, QuestionsPerAssessment: 10}
+	cfg.applyDefaults()
+	if err := cfg.validate(); err == nil {
+		t.Error("expected an error when assessments need more questions than the banks hold")
+	}
+
+	cfg = Config{AttemptRate: 1.5}
+	cfg.applyDefaults()
+	if err := cfg.validate(); err == nil {
+		t.Error("expected an error for an attempt rate above 1")
+	}
+}
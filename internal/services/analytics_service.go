@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+// Thresholds used when raising alerts in teacher summaries
+const (
+	alertDueSoonWindow         = 48 * time.Hour
+	alertLowCompletionRate     = 0.5
+	alertLowPassRate           = 0.5
+	alertMinAttemptsForRates   = 5
+	alertPendingGradingBacklog = 20
+)
+
+type analyticsService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewAnalyticsService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) AnalyticsService {
+	return &analyticsService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// ===== TEACHER SUMMARIES =====
+
+func (s *analyticsService) GetTeacherSummary(ctx context.Context, teacherID string, since time.Time) (*TeacherAnalyticsSummary, error) {
+	s.logger.Info("Building teacher analytics summary", "teacher_id", teacherID, "since", since)
+
+	now := time.Now()
+	status := models.StatusActive
+	assessments, _, err := s.repo.Assessment().GetByCreator(ctx, nil, teacherID, repositories.AssessmentFilters{
+		Status: &status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active assessments: %w", err)
+	}
+
+	summary := &TeacherAnalyticsSummary{
+		TeacherID:         teacherID,
+		PeriodStart:       since,
+		PeriodEnd:         now,
+		ActiveAssessments: len(assessments),
+		Assessments:       []AssessmentActivitySummary{},
+		Alerts:            []string{},
+		GeneratedAt:       now,
+	}
+
+	for _, assessment := range assessments {
+		item := AssessmentActivitySummary{
+			AssessmentID: assessment.ID,
+			Title:        assessment.Title,
+			DueDate:      assessment.DueDate,
+		}
+
+		attemptStats, err := s.repo.Attempt().GetAssessmentAttemptStats(ctx, nil, assessment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get attempt stats for assessment %d: %w", assessment.ID, err)
+		}
+		item.TotalAttempts = attemptStats.TotalAttempts
+		item.CompletionRate = attemptStats.CompletionRate
+		item.AverageScore = attemptStats.AverageScore
+		item.PassRate = attemptStats.PassRate
+
+		gradingStats, err := s.repo.Answer().GetGradingStats(ctx, nil, assessment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get grading stats for assessment %d: %w", assessment.ID, err)
+		}
+		item.PendingGrading = gradingStats.PendingAnswers
+
+		item.Alerts = buildAssessmentAlerts(&item, now)
+		for _, alert := range item.Alerts {
+			summary.Alerts = append(summary.Alerts, fmt.Sprintf("%s: %s", item.Title, alert))
+		}
+
+		summary.TotalPendingGrading += item.PendingGrading
+		summary.Assessments = append(summary.Assessments, item)
+	}
+
+	licensedBanks, err := s.repo.QuestionBank().GetLicensedBanks(ctx, nil, teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get licensed banks: %w", err)
+	}
+	for _, bank := range licensedBanks {
+		exposure, err := s.repo.QuestionBank().GetLicenseExposure(ctx, nil, bank.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get license exposure for bank %d: %w", bank.ID, err)
+		}
+		for _, alert := range buildLicenseUsage(bank, exposure, now).Alerts {
+			summary.Alerts = append(summary.Alerts, fmt.Sprintf("%s: %s", bank.Name, alert))
+		}
+	}
+
+	return summary, nil
+}
+
+// buildAssessmentAlerts flags assessments that need the teacher's attention
+func buildAssessmentAlerts(item *AssessmentActivitySummary, now time.Time) []string {
+	alerts := []string{}
+
+	if item.DueDate != nil && item.DueDate.After(now) && item.DueDate.Sub(now) <= alertDueSoonWindow &&
+		item.CompletionRate < alertLowCompletionRate {
+		alerts = append(alerts, fmt.Sprintf("due %s with %.0f%% completion",
+			item.DueDate.Format("Jan 2 15:04 MST"), item.CompletionRate*100))
+	}
+
+	if item.TotalAttempts >= alertMinAttemptsForRates && item.PassRate < alertLowPassRate {
+		alerts = append(alerts, fmt.Sprintf("low pass rate (%.0f%%)", item.PassRate*100))
+	}
+
+	if item.PendingGrading >= alertPendingGradingBacklog {
+		alerts = append(alerts, fmt.Sprintf("%d answers waiting for grading", item.PendingGrading))
+	}
+
+	return alerts
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+const (
+	minConfidence = 1
+	maxConfidence = 5
+)
+
+// ===== CONFIDENCE CALIBRATION =====
+
+func (s *analyticsService) GetConfidenceCalibration(ctx context.Context, assessmentID uint, userID string) (*ConfidenceCalibrationReport, error) {
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	canAccess, err := NewAssessmentService(s.repo, s.db, s.logger, s.validator).CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "view_calibration", "not owner or insufficient permissions")
+	}
+
+	responses, err := s.repo.AssessmentAnalytics().GetConfidenceResponses(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.repo.AssessmentQuestion().GetQuestionsForAssessment(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+
+	report := buildCalibrationReport(responses)
+	report.AssessmentID = assessmentID
+	report.Title = assessment.Title
+
+	// Questions follow the assessment's order
+	order := make(map[uint]int, len(questions))
+	for i, question := range questions {
+		order[question.ID] = i
+	}
+	for i := range report.Questions {
+		if index, ok := order[report.Questions[i].QuestionID]; ok {
+			report.Questions[i].QuestionText = questions[index].Text
+		}
+	}
+	sort.SliceStable(report.Questions, func(i, j int) bool {
+		return order[report.Questions[i].QuestionID] < order[report.Questions[j].QuestionID]
+	})
+
+	return report, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// buildCalibrationReport groups rated answers overall, by student and by question
+func buildCalibrationReport(responses []repositories.ConfidenceResponse) *ConfidenceCalibrationReport {
+	report := &ConfidenceCalibrationReport{
+		Overall:   calibrationStats(responses),
+		Levels:    calibrationLevels(responses),
+		Students:  make([]StudentCalibration, 0),
+		Questions: make([]QuestionCalibration, 0),
+	}
+
+	byStudent := make(map[string][]repositories.ConfidenceResponse)
+	byQuestion := make(map[uint][]repositories.ConfidenceResponse)
+	for _, response := range responses {
+		byStudent[response.StudentID] = append(byStudent[response.StudentID], response)
+		byQuestion[response.QuestionID] = append(byQuestion[response.QuestionID], response)
+	}
+
+	for studentID, group := range byStudent {
+		report.Students = append(report.Students, StudentCalibration{StudentID: studentID, CalibrationStats: calibrationStats(group)})
+	}
+	sort.Slice(report.Students, func(i, j int) bool {
+		return report.Students[i].StudentID < report.Students[j].StudentID
+	})
+
+	for questionID, group := range byQuestion {
+		report.Questions = append(report.Questions, QuestionCalibration{QuestionID: questionID, CalibrationStats: calibrationStats(group)})
+	}
+	sort.Slice(report.Questions, func(i, j int) bool {
+		return report.Questions[i].QuestionID < report.Questions[j].QuestionID
+	})
+
+	return report
+}
+
+// calibrationStats compares the expected score of each rating with the share of the
+// maximum score the answer earned, so partial credit counts proportionally
+func calibrationStats(responses []repositories.ConfidenceResponse) CalibrationStats {
+	stats := CalibrationStats{}
+	var confidence, expected, accuracy, brier float64
+	for _, response := range responses {
+		if response.Confidence < minConfidence || response.Confidence > maxConfidence {
+			continue
+		}
+		p := confidenceExpectation(response.Confidence)
+		actual := answerScoreShare(response.Score, response.MaxScore)
+		stats.Responses++
+		confidence += float64(response.Confidence)
+		expected += p
+		accuracy += actual
+		brier += (p - actual) * (p - actual)
+	}
+	if stats.Responses == 0 {
+		return stats
+	}
+
+	n := float64(stats.Responses)
+	stats.AverageConfidence = roundTo(confidence/n, 2)
+	stats.Accuracy = roundTo(accuracy/n, 3)
+	stats.Bias = roundTo((expected-accuracy)/n, 3)
+	stats.BrierScore = roundTo(brier/n, 3)
+	return stats
+}
+
+// calibrationLevels is the accuracy at each rating, one entry per rating even when unused
+func calibrationLevels(responses []repositories.ConfidenceResponse) []CalibrationLevel {
+	levels := make([]CalibrationLevel, 0, maxConfidence)
+	totals := make([]float64, maxConfidence+1)
+	for confidence := minConfidence; confidence <= maxConfidence; confidence++ {
+		levels = append(levels, CalibrationLevel{Confidence: confidence})
+	}
+	for _, response := range responses {
+		if response.Confidence < minConfidence || response.Confidence > maxConfidence {
+			continue
+		}
+		levels[response.Confidence-minConfidence].Responses++
+		totals[response.Confidence] += answerScoreShare(response.Score, response.MaxScore)
+	}
+	for i := range levels {
+		if levels[i].Responses > 0 {
+			levels[i].Accuracy = roundTo(totals[levels[i].Confidence]/float64(levels[i].Responses), 3)
+		}
+	}
+	return levels
+}
+
+// confidenceExpectation reads a rating from 1 to 5 as an expected score from 0 to 1
+func confidenceExpectation(confidence int) float64 {
+	return float64(confidence-minConfidence) / float64(maxConfidence-minConfidence)
+}
+
+func answerScoreShare(score float64, maxScore int) float64 {
+	if maxScore <= 0 {
+		return 0
+	}
+	return math.Min(1, math.Max(0, score/float64(maxScore)))
+}
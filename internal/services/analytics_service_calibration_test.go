@@ -0,0 +1,88 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestCalibrationStats(t *testing.T) {
+	stats := calibrationStats([]repositories.ConfidenceResponse{
+		{Confidence: 5, Score: 2, MaxScore: 2}, // certain and right
+		{Confidence: 5, Score: 0, MaxScore: 2}, // certain and wrong
+		{Confidence: 1, Score: 0, MaxScore: 2}, // guessing and wrong
+		{Confidence: 3, Score: 1, MaxScore: 2}, // unsure, half credit
+	})
+
+	if stats.Responses != 4 {
+		t.Fatalf("expected 4 responses, got %d", stats.Responses)
+	}
+	if stats.AverageConfidence != 3.5 {
+		t.Errorf("expected average confidence 3.5, got %v", stats.AverageConfidence)
+	}
+	if stats.Accuracy != 0.375 {
+		t.Errorf("expected accuracy 0.375, got %v", stats.Accuracy)
+	}
+	// Expected 0.625 against an actual 0.375
+	if stats.Bias != 0.25 {
+		t.Errorf("expected an overconfidence bias of 0.25, got %v", stats.Bias)
+	}
+	if stats.BrierScore != 0.25 {
+		t.Errorf("expected a Brier score of 0.25, got %v", stats.BrierScore)
+	}
+
+	if empty := calibrationStats(nil); empty.Responses != 0 || empty.Accuracy != 0 {
+		t.Errorf("expected empty stats, got %+v", empty)
+	}
+}
+
+func TestCalibrationLevels(t *testing.T) {
+	levels := calibrationLevels([]repositories.ConfidenceResponse{
+		{Confidence: 4, Score: 1, MaxScore: 1},
+		{Confidence: 4, Score: 0, MaxScore: 1},
+		{Confidence: 9, Score: 1, MaxScore: 1},
+	})
+
+	if len(levels) != 5 {
+		t.Fatalf("expected one level per rating, got %d", len(levels))
+	}
+	if levels[3].Confidence != 4 || levels[3].Responses != 2 || levels[3].Accuracy != 0.5 {
+		t.Errorf("unexpected level %+v", levels[3])
+	}
+	for _, level := range levels {
+		if level.Confidence != 4 && level.Responses != 0 {
+			t.Errorf("expected no other responses, got %+v", level)
+		}
+	}
+}
+
+func TestBuildCalibrationReport(t *testing.T) {
+	report := buildCalibrationReport([]repositories.ConfidenceResponse{
+		{StudentID: "s2", QuestionID: 7, Confidence: 5, Score: 1, MaxScore: 1},
+		{StudentID: "s1", QuestionID: 7, Confidence: 1, Score: 1, MaxScore: 1},
+		{StudentID: "s1", QuestionID: 3, Confidence: 5, Score: 0, MaxScore: 1},
+	})
+
+	if report.Overall.Responses != 3 {
+		t.Errorf("expected 3 responses overall, got %d", report.Overall.Responses)
+	}
+	if len(report.Students) != 2 || report.Students[0].StudentID != "s1" || report.Students[0].Responses != 2 {
+		t.Fatalf("unexpected students %+v", report.Students)
+	}
+	if report.Students[1].Bias != 0 {
+		t.Errorf("expected s2 to be perfectly calibrated, got bias %v", report.Students[1].Bias)
+	}
+	if len(report.Questions) != 2 || report.Questions[0].QuestionID != 3 || report.Questions[0].Bias != 1 {
+		t.Errorf("unexpected questions %+v", report.Questions)
+	}
+}
+
+func TestOfflineConfidence(t *testing.T) {
+	rating, tooHigh := 4, 6
+	if got := offlineConfidence(&rating); got == nil || *got != 4 {
+		t.Errorf("expected the rating to be kept, got %v", got)
+	}
+	if got := offlineConfidence(&tooHigh); got != nil {
+		t.Errorf("expected an out of range rating to be dropped, got %v", *got)
+	}
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+const (
+	ChartTypeBar    = "bar"
+	ChartTypeFunnel = "funnel"
+
+	// Score histogram buckets are 10 percentage points wide
+	dashboardHistogramBuckets = 10
+)
+
+// ===== ASSESSMENT DASHBOARD =====
+
+func (s *analyticsService) GetAssessmentDashboard(ctx context.Context, assessmentID uint, userID string) (*AssessmentDashboard, error) {
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	canAccess, err := NewAssessmentService(s.repo, s.db, s.logger, s.validator).CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "view_dashboard", "not owner or insufficient permissions")
+	}
+
+	percentages, err := s.repo.Attempt().GetFinishedPercentages(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	funnel, err := s.repo.Attempt().GetAttemptFunnel(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := s.repo.Attempt().GetFinishedByAssessment(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := getGradePolicy(ctx, s.repo, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.repo.AssessmentQuestion().GetQuestionsForAssessment(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.repo.QuestionAnalytics().GetAssessmentQuestionStats(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := buildDashboardSummary(assessment, funnel, percentages)
+	summarizeFinalGrades(&summary, policy, computeFinalGrades(attempts, policy, assessment.PassingScore))
+
+	return &AssessmentDashboard{
+		AssessmentID: assessmentID,
+		Title:        assessment.Title,
+		Summary:      summary,
+		Charts: []DashboardChart{
+			buildScoreHistogram(percentages, assessment.PassingScore),
+			buildCompletionFunnel(funnel),
+			buildQuestionCorrectness(links, questions, stats),
+		},
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
+// ===== HELPER METHODS =====
+
+func buildDashboardSummary(assessment *models.Assessment, funnel *repositories.AttemptFunnel, percentages []float64) DashboardSummary {
+	summary := DashboardSummary{
+		TotalAttempts:     funnel.Started,
+		SubmittedAttempts: funnel.Submitted,
+		PassingScore:      assessment.PassingScore,
+	}
+	if funnel.Submitted > 0 {
+		summary.PassRate = float64(funnel.Passed) / float64(funnel.Submitted) * 100
+	}
+	if len(percentages) > 0 {
+		total := 0.0
+		for _, percentage := range percentages {
+			total += percentage
+		}
+		summary.AveragePercentage = total / float64(len(percentages))
+	}
+	return summary
+}
+
+// summarizeFinalGrades adds per-student results under the grade policy, so retakes
+// don't weigh in the way they do in the per-attempt figures
+func summarizeFinalGrades(summary *DashboardSummary, policy models.GradePolicy, grades []FinalGrade) {
+	summary.GradePolicy = policy
+	summary.GradedStudents = len(grades)
+	if len(grades) == 0 {
+		return
+	}
+
+	total, passed := 0.0, 0
+	for _, grade := range grades {
+		total += grade.Percentage
+		if grade.Passed {
+			passed++
+		}
+	}
+	summary.FinalAveragePercentage = total / float64(len(grades))
+	summary.FinalPassRate = float64(passed) / float64(len(grades)) * 100
+}
+
+// buildScoreHistogram counts finished attempts per 10-point score band; a perfect score
+// falls in the top band. A second series marks the bands at or above the passing score.
+func buildScoreHistogram(percentages []float64, passingScore int) DashboardChart {
+	counts := make([]int, dashboardHistogramBuckets)
+	width := 100 / dashboardHistogramBuckets
+	for _, percentage := range percentages {
+		bucket := int(percentage) / width
+		if bucket < 0 {
+			bucket = 0
+		}
+		if bucket >= dashboardHistogramBuckets {
+			bucket = dashboardHistogramBuckets - 1
+		}
+		counts[bucket]++
+	}
+
+	attempts := ChartSeries{Name: "Attempts", Points: make([]ChartPoint, 0, dashboardHistogramBuckets)}
+	passing := ChartSeries{Name: "Passing", Points: make([]ChartPoint, 0, dashboardHistogramBuckets)}
+	for i, count := range counts {
+		label := fmt.Sprintf("%d-%d", i*width, (i+1)*width)
+		attempts.Points = append(attempts.Points, ChartPoint{Label: label, Value: float64(count)})
+
+		passed := 0.0
+		if i*width >= passingScore {
+			passed = float64(count)
+		}
+		passing.Points = append(passing.Points, ChartPoint{Label: label, Value: passed})
+	}
+
+	return DashboardChart{
+		ID:     "score_histogram",
+		Type:   ChartTypeBar,
+		Title:  "Score distribution",
+		XLabel: "Score (%)",
+		YLabel: "Attempts",
+		Series: []ChartSeries{attempts, passing},
+	}
+}
+
+func buildCompletionFunnel(funnel *repositories.AttemptFunnel) DashboardChart {
+	stages := []struct {
+		label string
+		count int
+	}{
+		{"Started", funnel.Started},
+		{"Answered", funnel.Answered},
+		{"Submitted", funnel.Submitted},
+		{"Graded", funnel.Graded},
+		{"Passed", funnel.Passed},
+	}
+
+	series := ChartSeries{Name: "Attempts", Points: make([]ChartPoint, 0, len(stages))}
+	for _, stage := range stages {
+		series.Points = append(series.Points, ChartPoint{Label: stage.label, Value: float64(stage.count)})
+	}
+
+	return DashboardChart{
+		ID:     "completion_funnel",
+		Type:   ChartTypeFunnel,
+		Title:  "Completion funnel",
+		Series: []ChartSeries{series},
+	}
+}
+
+// buildQuestionCorrectness lists every question in assessment order with the share of
+// finished responses graded correct and the share of points earned. Questions nobody
+// answered yet show as zero.
+func buildQuestionCorrectness(links []*models.AssessmentQuestion, questions []*models.Question, stats []repositories.QuestionHistoricalStats) DashboardChart {
+	texts := make(map[uint]string, len(questions))
+	for _, question := range questions {
+		texts[question.ID] = question.Text
+	}
+	byQuestion := make(map[uint]repositories.QuestionHistoricalStats, len(stats))
+	for _, stat := range stats {
+		byQuestion[stat.QuestionID] = stat
+	}
+
+	correct := ChartSeries{Name: "Correct (%)", Points: make([]ChartPoint, 0, len(links))}
+	score := ChartSeries{Name: "Points earned (%)", Points: make([]ChartPoint, 0, len(links))}
+	for i, link := range links {
+		label := fmt.Sprintf("Q%d", i+1)
+		stat := byQuestion[link.QuestionID]
+		correct.Points = append(correct.Points, ChartPoint{Label: label, Value: stat.CorrectRate * 100, Detail: texts[link.QuestionID]})
+		score.Points = append(score.Points, ChartPoint{Label: label, Value: stat.ScoreRate * 100, Detail: texts[link.QuestionID]})
+	}
+
+	return DashboardChart{
+		ID:     "question_correctness",
+		Type:   ChartTypeBar,
+		Title:  "Correctness by question",
+		XLabel: "Question",
+		YLabel: "%",
+		Series: []ChartSeries{correct, score},
+	}
+}
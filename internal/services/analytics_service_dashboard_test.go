@@ -0,0 +1,80 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestBuildScoreHistogram(t *testing.T) {
+	chart := buildScoreHistogram([]float64{0, 9.9, 10, 55, 100, 95}, 60)
+
+	attempts := chart.Series[0].Points
+	if len(attempts) != 10 {
+		t.Fatalf("expected 10 buckets, got %d", len(attempts))
+	}
+	want := []float64{2, 1, 0, 0, 0, 1, 0, 0, 0, 2}
+	for i, value := range want {
+		if attempts[i].Value != value {
+			t.Errorf("bucket %s: expected %v, got %v", attempts[i].Label, value, attempts[i].Value)
+		}
+	}
+	if attempts[9].Label != "90-100" {
+		t.Errorf("expected top bucket label 90-100, got %s", attempts[9].Label)
+	}
+
+	passing := chart.Series[1].Points
+	if passing[5].Value != 0 || passing[9].Value != 2 {
+		t.Errorf("only bands at or above the passing score should count as passing, got %+v", passing)
+	}
+}
+
+func TestBuildCompletionFunnel(t *testing.T) {
+	chart := buildCompletionFunnel(&repositories.AttemptFunnel{Started: 10, Answered: 8, Submitted: 6, Graded: 5, Passed: 3})
+
+	points := chart.Series[0].Points
+	if chart.Type != ChartTypeFunnel || len(points) != 5 {
+		t.Fatalf("expected a five stage funnel, got %+v", chart)
+	}
+	if points[0].Label != "Started" || points[0].Value != 10 || points[4].Label != "Passed" || points[4].Value != 3 {
+		t.Errorf("unexpected funnel stages: %+v", points)
+	}
+}
+
+func TestBuildQuestionCorrectness(t *testing.T) {
+	links := []*models.AssessmentQuestion{{QuestionID: 7}, {QuestionID: 3}}
+	questions := []*models.Question{{ID: 3, Text: "Second"}, {ID: 7, Text: "First"}}
+	stats := []repositories.QuestionHistoricalStats{{QuestionID: 7, CorrectRate: 0.75, ScoreRate: 0.8}}
+
+	chart := buildQuestionCorrectness(links, questions, stats)
+
+	correct := chart.Series[0].Points
+	if len(correct) != 2 {
+		t.Fatalf("expected a point per question, got %d", len(correct))
+	}
+	if correct[0].Label != "Q1" || correct[0].Value != 75 || correct[0].Detail != "First" {
+		t.Errorf("first question should follow assessment order, got %+v", correct[0])
+	}
+	if correct[1].Value != 0 || correct[1].Detail != "Second" {
+		t.Errorf("unanswered question should show as zero, got %+v", correct[1])
+	}
+}
+
+func TestBuildDashboardSummary(t *testing.T) {
+	summary := buildDashboardSummary(&models.Assessment{PassingScore: 60}, &repositories.AttemptFunnel{Started: 5, Submitted: 4, Passed: 1}, []float64{40, 80})
+	if summary.PassRate != 25 || summary.AveragePercentage != 60 || summary.TotalAttempts != 5 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+}
+
+func TestSummarizeFinalGrades(t *testing.T) {
+	summary := DashboardSummary{}
+	summarizeFinalGrades(&summary, models.GradePolicyLatest, []FinalGrade{
+		{StudentID: "a", Percentage: 80, Passed: true},
+		{StudentID: "b", Percentage: 40},
+	})
+	if summary.GradePolicy != models.GradePolicyLatest || summary.GradedStudents != 2 || summary.FinalAveragePercentage != 60 || summary.FinalPassRate != 50 {
+		t.Errorf("unexpected final grade summary: %+v", summary)
+	}
+}
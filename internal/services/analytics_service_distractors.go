@@ -0,0 +1,314 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/datatypes"
+)
+
+const (
+	// Distractors chosen by fewer than 5% of students are not plausible enough to test anything
+	distractorNonFunctioningRate = 0.05
+	distractorAnalysisBatchSize  = 100
+)
+
+// itemAnalysis holds the statistics computed for one multiple choice question
+type itemAnalysis struct {
+	TotalResponses        int
+	CorrectResponses      int
+	DifficultyIndex       float64
+	DiscriminationIndex   float64
+	TopQuartileCorrect    float64
+	BottomQuartileCorrect float64
+	AverageScore          float64
+	AverageTimeSpent      int
+	Options               []models.OptionStat
+}
+
+// ===== DISTRACTOR ANALYSIS =====
+
+func (s *analyticsService) GetDistractorAnalysis(ctx context.Context, questionID uint, userID string) (*DistractorAnalysis, error) {
+	question, err := s.repo.Question().GetByID(ctx, nil, questionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	if question.Type != models.MultipleChoice {
+		return nil, NewValidationError("question_id", "distractor analysis is only available for multiple choice questions", questionID)
+	}
+
+	questionService := NewQuestionService(s.repo, s.db, s.logger, s.validator)
+	canAccess, err := questionService.CanAccess(ctx, questionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, questionID, "question", "view_item_analysis", "not owner or insufficient permissions")
+	}
+
+	analytics, err := s.repo.QuestionAnalytics().GetByQuestion(ctx, nil, questionID)
+	if err != nil {
+		if !repositories.IsNotFoundError(err) {
+			return nil, fmt.Errorf("failed to get question analytics: %w", err)
+		}
+		// Not analysed by the scheduler yet
+		if analytics, err = s.analyzeQuestion(ctx, question); err != nil {
+			return nil, err
+		}
+	}
+
+	var options []models.OptionStat
+	if len(analytics.OptionStats) > 0 {
+		if err := json.Unmarshal(analytics.OptionStats, &options); err != nil {
+			return nil, fmt.Errorf("failed to decode option stats: %w", err)
+		}
+	}
+
+	result := &DistractorAnalysis{
+		QuestionID:          questionID,
+		QuestionText:        question.Text,
+		TotalResponses:      analytics.TotalResponses,
+		DifficultyIndex:     analytics.DifficultyIndex,
+		DiscriminationIndex: analytics.DiscriminationIndex,
+		Options:             options,
+		NonFunctioning:      make([]string, 0),
+		LastCalculatedAt:    analytics.LastCalculatedAt,
+	}
+	for _, option := range options {
+		if option.NonFunctioning {
+			result.NonFunctioning = append(result.NonFunctioning, option.OptionID)
+		}
+	}
+
+	return result, nil
+}
+
+// RunDistractorAnalysis re-analyses multiple choice questions answered since their last analysis
+func (s *analyticsService) RunDistractorAnalysis(ctx context.Context, limit int) (int, error) {
+	questionIDs, err := s.repo.QuestionAnalytics().GetStaleQuestionIDs(ctx, nil, models.MultipleChoice, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	analysed := 0
+	for _, questionID := range questionIDs {
+		question, err := s.repo.Question().GetByID(ctx, nil, questionID)
+		if err != nil {
+			s.logger.Error("Failed to load question for distractor analysis", "question_id", questionID, "error", err)
+			continue
+		}
+		if _, err := s.analyzeQuestion(ctx, question); err != nil {
+			s.logger.Error("Failed to analyse distractors", "question_id", questionID, "error", err)
+			continue
+		}
+		analysed++
+	}
+
+	if analysed > 0 {
+		s.logger.Info("Distractor analysis completed", "questions", analysed)
+	}
+	return analysed, nil
+}
+
+// RunScheduler refreshes distractor and item analysis, and runs queued question statistics
+// recomputes, every interval until the context is cancelled
+func (s *analyticsService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.Info("Item analysis scheduler started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Item analysis scheduler stopped")
+			return
+		case <-ticker.C:
+			if _, err := s.RunDistractorAnalysis(ctx, distractorAnalysisBatchSize); err != nil {
+				s.logger.Error("Failed to run distractor analysis", "error", err)
+			}
+			if _, err := s.RunItemAnalysis(ctx, itemAnalysisBatchSize); err != nil {
+				s.logger.Error("Failed to run item analysis", "error", err)
+			}
+			if _, err := s.QueueQuestionStatsJobs(ctx, questionStatsJobBatch); err != nil {
+				s.logger.Error("Failed to queue question stats jobs", "error", err)
+			}
+		}
+	}
+}
+
+// ===== HELPER METHODS =====
+
+func (s *analyticsService) analyzeQuestion(ctx context.Context, question *models.Question) (*models.QuestionAnalytics, error) {
+	var content models.MultipleChoiceContent
+	if err := json.Unmarshal(question.Content, &content); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal question content: %w", err)
+	}
+
+	responses, err := s.repo.QuestionAnalytics().GetScoredResponses(ctx, nil, question.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := analyzeItem(&content, responses)
+	optionStats, err := json.Marshal(analysis.Options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode option stats: %w", err)
+	}
+
+	analytics := &models.QuestionAnalytics{
+		QuestionID:            question.ID,
+		TotalResponses:        analysis.TotalResponses,
+		CorrectResponses:      analysis.CorrectResponses,
+		DifficultyIndex:       analysis.DifficultyIndex,
+		DiscriminationIndex:   analysis.DiscriminationIndex,
+		AverageScore:          analysis.AverageScore,
+		AverageTimeSpent:      analysis.AverageTimeSpent,
+		OptionStats:           datatypes.JSON(optionStats),
+		TopQuartileCorrect:    analysis.TopQuartileCorrect,
+		BottomQuartileCorrect: analysis.BottomQuartileCorrect,
+		LastCalculatedAt:      time.Now(),
+	}
+	if err := s.repo.QuestionAnalytics().Upsert(ctx, nil, analytics); err != nil {
+		return nil, err
+	}
+
+	return analytics, nil
+}
+
+// analyzeItem computes option selection rates overall and within the lowest and highest
+// scoring quartiles of attempts. A working distractor attracts weaker students more than
+// stronger ones; one almost nobody picks is flagged as non-functioning.
+func analyzeItem(content *models.MultipleChoiceContent, responses []repositories.ScoredResponse) itemAnalysis {
+	correctOptions := make(map[string]bool, len(content.CorrectAnswers))
+	for _, optionID := range content.CorrectAnswers {
+		correctOptions[optionID] = true
+	}
+
+	type parsedResponse struct {
+		selected   map[string]bool
+		correct    bool
+		percentage float64
+	}
+	parsed := make([]parsedResponse, 0, len(responses))
+	analysis := itemAnalysis{}
+	totalScore, totalTime := 0.0, 0
+	for _, response := range responses {
+		selected, ok := parseSelectedOptions(response.Answer)
+		if !ok {
+			continue
+		}
+		p := parsedResponse{
+			selected:   make(map[string]bool, len(selected)),
+			correct:    response.IsCorrect != nil && *response.IsCorrect,
+			percentage: response.AttemptPercentage,
+		}
+		for _, optionID := range selected {
+			p.selected[optionID] = true
+		}
+		parsed = append(parsed, p)
+		totalScore += response.Score
+		totalTime += response.TimeSpent
+		if p.correct {
+			analysis.CorrectResponses++
+		}
+	}
+
+	analysis.TotalResponses = len(parsed)
+	analysis.Options = make([]models.OptionStat, 0, len(content.Options))
+	if analysis.TotalResponses == 0 {
+		for _, option := range content.Options {
+			analysis.Options = append(analysis.Options, models.OptionStat{
+				OptionID:   option.ID,
+				OptionText: option.Text,
+				IsCorrect:  correctOptions[option.ID],
+			})
+		}
+		return analysis
+	}
+
+	analysis.DifficultyIndex = float64(analysis.CorrectResponses) / float64(analysis.TotalResponses)
+	analysis.AverageScore = totalScore / float64(analysis.TotalResponses)
+	analysis.AverageTimeSpent = totalTime / analysis.TotalResponses
+
+	sort.SliceStable(parsed, func(i, j int) bool {
+		return parsed[i].percentage < parsed[j].percentage
+	})
+	groupSize := len(parsed) / 4
+	low, high := parsed[:groupSize], parsed[len(parsed)-groupSize:]
+
+	rate := func(group []parsedResponse, match func(parsedResponse) bool) float64 {
+		if len(group) == 0 {
+			return 0
+		}
+		count := 0
+		for _, p := range group {
+			if match(p) {
+				count++
+			}
+		}
+		return float64(count) / float64(len(group))
+	}
+
+	isCorrect := func(p parsedResponse) bool { return p.correct }
+	analysis.BottomQuartileCorrect = rate(low, isCorrect)
+	analysis.TopQuartileCorrect = rate(high, isCorrect)
+	analysis.DiscriminationIndex = analysis.TopQuartileCorrect - analysis.BottomQuartileCorrect
+
+	for _, option := range content.Options {
+		optionID := option.ID
+		selects := func(p parsedResponse) bool { return p.selected[optionID] }
+
+		stat := models.OptionStat{
+			OptionID:      optionID,
+			OptionText:    option.Text,
+			IsCorrect:     correctOptions[optionID],
+			SelectionRate: rate(parsed, selects),
+			LowGroupRate:  rate(low, selects),
+			HighGroupRate: rate(high, selects),
+		}
+		for _, p := range parsed {
+			if p.selected[optionID] {
+				stat.SelectionCount++
+			}
+		}
+		stat.Discrimination = stat.HighGroupRate - stat.LowGroupRate
+		stat.NonFunctioning = !stat.IsCorrect && stat.SelectionRate < distractorNonFunctioningRate
+		analysis.Options = append(analysis.Options, stat)
+	}
+
+	return analysis
+}
+
+// parseSelectedOptions reads a stored multiple choice answer, which may be a list of
+// option IDs, a single option ID or a full MultipleChoiceAnswer
+func parseSelectedOptions(raw datatypes.JSON) ([]string, bool) {
+	if len(raw) == 0 {
+		return nil, false
+	}
+
+	var selected []string
+	if err := json.Unmarshal(raw, &selected); err == nil {
+		return selected, true
+	}
+
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return []string{single}, true
+	}
+
+	var answer models.MultipleChoiceAnswer
+	if err := json.Unmarshal(raw, &answer); err == nil {
+		return answer.SelectedOptions, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/datatypes"
+)
+
+func TestAnalyzeItem(t *testing.T) {
+	content := &models.MultipleChoiceContent{
+		Options: []models.MCOption{
+			{ID: "a", Text: "Correct"},
+			{ID: "b", Text: "Common misconception"},
+			{ID: "c", Text: "Implausible"},
+		},
+		CorrectAnswers: []string{"a"},
+	}
+
+	correct, wrong := true, false
+	responses := make([]repositories.ScoredResponse, 0, 21)
+	// Weakest students pick the misconception, the strongest the correct answer
+	for i := 0; i < 20; i++ {
+		response := repositories.ScoredResponse{
+			AttemptID:         uint(i + 1),
+			AttemptPercentage: float64(i * 5),
+			TimeSpent:         30,
+		}
+		if i < 8 {
+			response.Answer = datatypes.JSON(`["b"]`)
+			response.IsCorrect = &wrong
+		} else {
+			response.Answer = datatypes.JSON(fmt.Sprintf(`{"selected_options":["a"],"time_spent":%d}`, i))
+			response.IsCorrect = &correct
+			response.Score = 1
+		}
+		responses = append(responses, response)
+	}
+	responses = append(responses, repositories.ScoredResponse{Answer: datatypes.JSON(`{not json`)})
+
+	analysis := analyzeItem(content, responses)
+
+	if analysis.TotalResponses != 20 || analysis.CorrectResponses != 12 {
+		t.Fatalf("unexpected totals: %+v", analysis)
+	}
+	if analysis.DifficultyIndex != 0.6 {
+		t.Errorf("expected difficulty 0.6, got %v", analysis.DifficultyIndex)
+	}
+	if analysis.TopQuartileCorrect != 1 || analysis.BottomQuartileCorrect != 0 || analysis.DiscriminationIndex != 1 {
+		t.Errorf("unexpected quartile results: %+v", analysis)
+	}
+	if analysis.AverageTimeSpent != 30 {
+		t.Errorf("expected average time 30, got %d", analysis.AverageTimeSpent)
+	}
+
+	if len(analysis.Options) != 3 {
+		t.Fatalf("expected 3 options, got %+v", analysis.Options)
+	}
+	if b := analysis.Options[1]; b.SelectionCount != 8 || b.LowGroupRate != 1 || b.HighGroupRate != 0 || b.Discrimination != -1 || b.NonFunctioning {
+		t.Errorf("misconception should be a working distractor, got %+v", b)
+	}
+	if c := analysis.Options[2]; c.SelectionCount != 0 || !c.NonFunctioning {
+		t.Errorf("unused distractor should be flagged, got %+v", c)
+	}
+	if a := analysis.Options[0]; !a.IsCorrect || a.NonFunctioning {
+		t.Errorf("correct option must not be flagged, got %+v", a)
+	}
+}
@@ -0,0 +1,408 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/datatypes"
+)
+
+const (
+	// Upper and lower groups for the discrimination index are the top and bottom 27% of attempts
+	itemAnalysisGroupShare = 0.27
+	// Items discriminating below 0.2 separate strong and weak students poorly
+	itemReviewDiscrimination = 0.2
+	itemAnalysisBatchSize    = 50
+)
+
+// itemScoreMatrix holds each scored attempt's share of the maximum score per question;
+// a question the attempt left unanswered counts as zero, as it does in the attempt's score
+type itemScoreMatrix struct {
+	QuestionIDs []uint
+	MaxScores   []float64
+	Responses   []int
+	Rows        [][]float64
+}
+
+// ===== ASSESSMENT ANALYTICS =====
+
+func (s *analyticsService) GetAssessmentAnalytics(ctx context.Context, assessmentID uint, userID string) (*AssessmentAnalyticsReport, error) {
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	canAccess, err := NewAssessmentService(s.repo, s.db, s.logger, s.validator).CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "view_item_analysis", "not owner or insufficient permissions")
+	}
+
+	analytics, err := s.repo.AssessmentAnalytics().GetByAssessment(ctx, nil, assessmentID)
+	if err != nil {
+		if !repositories.IsNotFoundError(err) {
+			return nil, fmt.Errorf("failed to get assessment analytics: %w", err)
+		}
+		// Not analysed by the scheduler yet
+		if analytics, err = s.analyzeAssessment(ctx, assessment); err != nil {
+			return nil, err
+		}
+	}
+
+	items := make([]models.ItemStat, 0)
+	if len(analytics.ItemStats) > 0 {
+		if err := json.Unmarshal(analytics.ItemStats, &items); err != nil {
+			return nil, fmt.Errorf("failed to decode item stats: %w", err)
+		}
+	}
+
+	report := &AssessmentAnalyticsReport{
+		AssessmentID:      assessmentID,
+		Title:             assessment.Title,
+		TotalAttempts:     analytics.TotalAttempts,
+		CompletedAttempts: analytics.CompletedAttempts,
+		AverageScore:      analytics.AverageScore,
+		MedianScore:       analytics.MedianScore,
+		HighestScore:      analytics.HighestScore,
+		LowestScore:       analytics.LowestScore,
+		StandardDeviation: analytics.StandardDeviation,
+		AverageTimeSpent:  analytics.AverageTimeSpent,
+		MedianTimeSpent:   analytics.MedianTimeSpent,
+		PassRate:          analytics.PassRate,
+		PassedCount:       analytics.PassedCount,
+		FailedCount:       analytics.FailedCount,
+		ScoredAttempts:    analytics.ScoredAttempts,
+		Reliability:       analytics.Reliability,
+		Items:             items,
+		ReviewItems:       make([]uint, 0),
+		LastCalculatedAt:  analytics.LastCalculatedAt,
+	}
+	for _, item := range items {
+		if item.DiscriminationIndex < itemReviewDiscrimination {
+			report.ReviewItems = append(report.ReviewItems, item.QuestionID)
+		}
+	}
+
+	return report, nil
+}
+
+// RunItemAnalysis re-analyses assessments with attempts finished or regraded since their last analysis
+func (s *analyticsService) RunItemAnalysis(ctx context.Context, limit int) (int, error) {
+	assessmentIDs, err := s.repo.AssessmentAnalytics().GetStaleAssessmentIDs(ctx, nil, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	analysed := 0
+	for _, assessmentID := range assessmentIDs {
+		assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+		if err != nil {
+			s.logger.Error("Failed to load assessment for item analysis", "assessment_id", assessmentID, "error", err)
+			continue
+		}
+		if _, err := s.analyzeAssessment(ctx, assessment); err != nil {
+			s.logger.Error("Failed to analyse assessment items", "assessment_id", assessmentID, "error", err)
+			continue
+		}
+		analysed++
+	}
+
+	if analysed > 0 {
+		s.logger.Info("Item analysis completed", "assessments", analysed)
+	}
+	return analysed, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (s *analyticsService) analyzeAssessment(ctx context.Context, assessment *models.Assessment) (*models.AssessmentAnalytics, error) {
+	funnel, err := s.repo.Attempt().GetAttemptFunnel(ctx, nil, assessment.ID)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := s.repo.Attempt().GetFinishedByAssessment(ctx, nil, assessment.ID)
+	if err != nil {
+		return nil, err
+	}
+	scores, err := s.repo.AssessmentAnalytics().GetItemScores(ctx, nil, assessment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := &models.AssessmentAnalytics{
+		AssessmentID:      assessment.ID,
+		TotalAttempts:     funnel.Started,
+		CompletedAttempts: funnel.Submitted,
+		LastCalculatedAt:  time.Now(),
+	}
+	summarizeAttemptScores(analytics, attempts)
+	rankedScores, err := json.Marshal(rankAttemptScores(attempts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ranked scores: %w", err)
+	}
+	analytics.RankedScores = datatypes.JSON(rankedScores)
+
+	matrix := buildItemScoreMatrix(scores)
+	items := analyzeItems(matrix)
+	itemStats, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode item stats: %w", err)
+	}
+	analytics.ScoredAttempts = len(matrix.Rows)
+	analytics.ItemCount = len(items)
+	analytics.Reliability = kr20(matrix)
+	analytics.ItemStats = datatypes.JSON(itemStats)
+
+	if err := s.repo.AssessmentAnalytics().Upsert(ctx, nil, analytics); err != nil {
+		return nil, err
+	}
+	return analytics, nil
+}
+
+// summarizeAttemptScores fills the score, time and pass statistics of finished attempts;
+// scores are percentages so assessments of different lengths compare
+func summarizeAttemptScores(analytics *models.AssessmentAnalytics, attempts []*models.AssessmentAttempt) {
+	if len(attempts) == 0 {
+		return
+	}
+
+	percentages := make([]float64, 0, len(attempts))
+	times := make([]float64, 0, len(attempts))
+	for _, attempt := range attempts {
+		percentages = append(percentages, attempt.Percentage)
+		times = append(times, float64(attempt.TimeSpent))
+		if attempt.Passed {
+			analytics.PassedCount++
+		} else {
+			analytics.FailedCount++
+		}
+	}
+	sort.Float64s(percentages)
+	sort.Float64s(times)
+
+	analytics.AverageScore = mean(percentages)
+	analytics.MedianScore = median(percentages)
+	analytics.LowestScore = percentages[0]
+	analytics.HighestScore = percentages[len(percentages)-1]
+	analytics.StandardDeviation = math.Sqrt(variance(percentages))
+	analytics.AverageTimeSpent = int(mean(times))
+	analytics.MedianTimeSpent = int(median(times))
+	analytics.PassRate = float64(analytics.PassedCount) / float64(len(attempts))
+}
+
+// rankAttemptScores lists finished attempts' percentages, highest first
+func rankAttemptScores(attempts []*models.AssessmentAttempt) []models.RankedScore {
+	ranked := make([]models.RankedScore, 0, len(attempts))
+	for _, attempt := range attempts {
+		ranked = append(ranked, models.RankedScore{AttemptID: attempt.ID, Percentage: attempt.Percentage})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Percentage > ranked[j].Percentage
+	})
+	return ranked
+}
+
+// buildItemScoreMatrix arranges graded answers by attempt and question, both in ID order.
+// Answers without a maximum score carry no information and are skipped.
+func buildItemScoreMatrix(scores []repositories.ItemScore) itemScoreMatrix {
+	questionMax := make(map[uint]float64)
+	attemptIndex := make(map[uint]int)
+	var attemptIDs []uint
+	for _, score := range scores {
+		if score.MaxScore <= 0 {
+			continue
+		}
+		if float64(score.MaxScore) > questionMax[score.QuestionID] {
+			questionMax[score.QuestionID] = float64(score.MaxScore)
+		}
+		if _, ok := attemptIndex[score.AttemptID]; !ok {
+			attemptIndex[score.AttemptID] = 0
+			attemptIDs = append(attemptIDs, score.AttemptID)
+		}
+	}
+
+	matrix := itemScoreMatrix{}
+	for questionID := range questionMax {
+		matrix.QuestionIDs = append(matrix.QuestionIDs, questionID)
+	}
+	sort.Slice(matrix.QuestionIDs, func(i, j int) bool { return matrix.QuestionIDs[i] < matrix.QuestionIDs[j] })
+	sort.Slice(attemptIDs, func(i, j int) bool { return attemptIDs[i] < attemptIDs[j] })
+
+	questionIndex := make(map[uint]int, len(matrix.QuestionIDs))
+	matrix.MaxScores = make([]float64, len(matrix.QuestionIDs))
+	matrix.Responses = make([]int, len(matrix.QuestionIDs))
+	for i, questionID := range matrix.QuestionIDs {
+		questionIndex[questionID] = i
+		matrix.MaxScores[i] = questionMax[questionID]
+	}
+	matrix.Rows = make([][]float64, len(attemptIDs))
+	for i, attemptID := range attemptIDs {
+		attemptIndex[attemptID] = i
+		matrix.Rows[i] = make([]float64, len(matrix.QuestionIDs))
+	}
+
+	for _, score := range scores {
+		if score.MaxScore <= 0 {
+			continue
+		}
+		item := questionIndex[score.QuestionID]
+		share := math.Max(0, math.Min(1, score.Score/matrix.MaxScores[item]))
+		matrix.Rows[attemptIndex[score.AttemptID]][item] = share
+		matrix.Responses[item]++
+	}
+	return matrix
+}
+
+// analyzeItems computes each question's difficulty (average share of the maximum score),
+// its discrimination (upper minus lower 27% of attempts by total score) and its point-biserial
+// correlation with the rest score, which leaves the item out so it doesn't correlate with itself
+func analyzeItems(matrix itemScoreMatrix) []models.ItemStat {
+	items := make([]models.ItemStat, 0, len(matrix.QuestionIDs))
+	if len(matrix.Rows) == 0 {
+		for i, questionID := range matrix.QuestionIDs {
+			items = append(items, models.ItemStat{QuestionID: questionID, Responses: matrix.Responses[i]})
+		}
+		return items
+	}
+
+	totals := make([]float64, len(matrix.Rows))
+	for a, row := range matrix.Rows {
+		for i, share := range row {
+			totals[a] += share * matrix.MaxScores[i]
+		}
+	}
+
+	order := make([]int, len(matrix.Rows))
+	for a := range order {
+		order[a] = a
+	}
+	sort.SliceStable(order, func(i, j int) bool { return totals[order[i]] < totals[order[j]] })
+	groupSize := int(math.Round(float64(len(order)) * itemAnalysisGroupShare))
+	lower, upper := order[:groupSize], order[len(order)-groupSize:]
+
+	groupMean := func(group []int, item int) float64 {
+		if len(group) == 0 {
+			return 0
+		}
+		sum := 0.0
+		for _, a := range group {
+			sum += matrix.Rows[a][item]
+		}
+		return sum / float64(len(group))
+	}
+
+	for i, questionID := range matrix.QuestionIDs {
+		itemScores := make([]float64, len(matrix.Rows))
+		restScores := make([]float64, len(matrix.Rows))
+		for a, row := range matrix.Rows {
+			itemScores[a] = row[i]
+			restScores[a] = totals[a] - row[i]*matrix.MaxScores[i]
+		}
+
+		stat := models.ItemStat{
+			QuestionID:          questionID,
+			Responses:           matrix.Responses[i],
+			DifficultyIndex:     mean(itemScores),
+			DiscriminationIndex: groupMean(upper, i) - groupMean(lower, i),
+		}
+		if r, ok := correlation(itemScores, restScores); ok {
+			stat.PointBiserial = &r
+		}
+		items = append(items, stat)
+	}
+	return items
+}
+
+// kr20 estimates internal consistency reliability. With partial credit items it is the
+// equivalent coefficient alpha, which reduces to KR-20 when every item is right or wrong.
+func kr20(matrix itemScoreMatrix) *float64 {
+	k := len(matrix.QuestionIDs)
+	if k < 2 || len(matrix.Rows) < 2 {
+		return nil
+	}
+
+	totals := make([]float64, len(matrix.Rows))
+	itemVariance := 0.0
+	for i := range matrix.QuestionIDs {
+		points := make([]float64, len(matrix.Rows))
+		for a, row := range matrix.Rows {
+			points[a] = row[i] * matrix.MaxScores[i]
+			totals[a] += points[a]
+		}
+		itemVariance += variance(points)
+	}
+
+	totalVariance := variance(totals)
+	if totalVariance == 0 {
+		return nil
+	}
+	reliability := float64(k) / float64(k-1) * (1 - itemVariance/totalVariance)
+	return &reliability
+}
+
+// correlation returns the Pearson correlation of two equally long samples; it is undefined
+// when either sample has no variance
+func correlation(x, y []float64) (float64, bool) {
+	if len(x) < 2 || len(x) != len(y) {
+		return 0, false
+	}
+	meanX, meanY := mean(x), mean(y)
+	covariance, varianceX, varianceY := 0.0, 0.0, 0.0
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+	if varianceX == 0 || varianceY == 0 {
+		return 0, false
+	}
+	return covariance / math.Sqrt(varianceX*varianceY), true
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	return sum / float64(len(values))
+}
+
+// median expects sorted values
+func median(values []float64) float64 {
+	n := len(values)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
+
+// variance is the population variance, as used by KR-20
+func variance(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	m := mean(values)
+	sum := 0.0
+	for _, value := range values {
+		sum += (value - m) * (value - m)
+	}
+	return sum / float64(len(values))
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// guttmanScores is a perfect scale: each stronger attempt also answers every easier item
+func guttmanScores() []repositories.ItemScore {
+	right := map[uint][]uint{
+		4: {1, 2, 3},
+		3: {1, 2},
+		2: {1},
+		1: {},
+	}
+	var scores []repositories.ItemScore
+	for attemptID, correct := range right {
+		for questionID := uint(1); questionID <= 3; questionID++ {
+			score := repositories.ItemScore{AttemptID: attemptID, QuestionID: questionID, MaxScore: 1}
+			for _, id := range correct {
+				if id == questionID {
+					score.Score = 1
+				}
+			}
+			scores = append(scores, score)
+		}
+	}
+	return scores
+}
+
+func TestBuildItemScoreMatrix(t *testing.T) {
+	matrix := buildItemScoreMatrix([]repositories.ItemScore{
+		{AttemptID: 9, QuestionID: 5, Score: 2, MaxScore: 4},
+		{AttemptID: 3, QuestionID: 5, Score: 6, MaxScore: 4},
+		{AttemptID: 3, QuestionID: 2, Score: 1, MaxScore: 1},
+		{AttemptID: 3, QuestionID: 7, Score: 0, MaxScore: 0},
+	})
+
+	if len(matrix.QuestionIDs) != 2 || matrix.QuestionIDs[0] != 2 || matrix.QuestionIDs[1] != 5 {
+		t.Fatalf("expected questions [2 5] without the unscored one, got %v", matrix.QuestionIDs)
+	}
+	if len(matrix.Rows) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(matrix.Rows))
+	}
+	// Attempt 3 first; its overscored answer is capped at full marks
+	if matrix.Rows[0][0] != 1 || matrix.Rows[0][1] != 1 {
+		t.Errorf("unexpected row for attempt 3: %v", matrix.Rows[0])
+	}
+	// Attempt 9 left question 2 unanswered
+	if matrix.Rows[1][0] != 0 || matrix.Rows[1][1] != 0.5 {
+		t.Errorf("unexpected row for attempt 9: %v", matrix.Rows[1])
+	}
+	if matrix.Responses[0] != 1 || matrix.Responses[1] != 2 {
+		t.Errorf("unexpected response counts: %v", matrix.Responses)
+	}
+}
+
+func TestAnalyzeItems(t *testing.T) {
+	items := analyzeItems(buildItemScoreMatrix(guttmanScores()))
+	if len(items) != 3 {
+		t.Fatalf("expected 3 items, got %d", len(items))
+	}
+
+	expectedDifficulty := []float64{0.75, 0.5, 0.25}
+	for i, item := range items {
+		if item.DifficultyIndex != expectedDifficulty[i] {
+			t.Errorf("item %d: expected difficulty %v, got %v", item.QuestionID, expectedDifficulty[i], item.DifficultyIndex)
+		}
+		// The single top attempt answers everything, the single bottom one nothing
+		if item.DiscriminationIndex != 1 {
+			t.Errorf("item %d: expected discrimination 1, got %v", item.QuestionID, item.DiscriminationIndex)
+		}
+		if item.PointBiserial == nil || *item.PointBiserial <= 0 {
+			t.Errorf("item %d: expected a positive point-biserial, got %v", item.QuestionID, item.PointBiserial)
+		}
+	}
+	if math.Abs(*items[0].PointBiserial-0.75/math.Sqrt(0.75*2.75)) > 1e-9 {
+		t.Errorf("unexpected point-biserial for item 1: %v", *items[0].PointBiserial)
+	}
+}
+
+func TestAnalyzeItems_NoVariance(t *testing.T) {
+	items := analyzeItems(buildItemScoreMatrix([]repositories.ItemScore{
+		{AttemptID: 1, QuestionID: 1, Score: 1, MaxScore: 1},
+		{AttemptID: 2, QuestionID: 1, Score: 1, MaxScore: 1},
+	}))
+
+	if len(items) != 1 || items[0].DifficultyIndex != 1 || items[0].DiscriminationIndex != 0 {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+	if items[0].PointBiserial != nil {
+		t.Errorf("expected no point-biserial without variance, got %v", *items[0].PointBiserial)
+	}
+
+	if items := analyzeItems(buildItemScoreMatrix(nil)); len(items) != 0 {
+		t.Errorf("expected no items without scores, got %+v", items)
+	}
+}
+
+func TestKR20(t *testing.T) {
+	reliability := kr20(buildItemScoreMatrix(guttmanScores()))
+	if reliability == nil || math.Abs(*reliability-0.75) > 1e-9 {
+		t.Fatalf("expected reliability 0.75, got %v", reliability)
+	}
+
+	single := buildItemScoreMatrix([]repositories.ItemScore{
+		{AttemptID: 1, QuestionID: 1, Score: 1, MaxScore: 1},
+		{AttemptID: 2, QuestionID: 1, Score: 0, MaxScore: 1},
+	})
+	if kr20(single) != nil {
+		t.Error("expected no reliability for a single item")
+	}
+
+	flat := buildItemScoreMatrix([]repositories.ItemScore{
+		{AttemptID: 1, QuestionID: 1, Score: 1, MaxScore: 1},
+		{AttemptID: 1, QuestionID: 2, Score: 1, MaxScore: 1},
+		{AttemptID: 2, QuestionID: 1, Score: 1, MaxScore: 1},
+		{AttemptID: 2, QuestionID: 2, Score: 1, MaxScore: 1},
+	})
+	if kr20(flat) != nil {
+		t.Error("expected no reliability without score variance")
+	}
+}
+
+func TestSummarizeAttemptScores(t *testing.T) {
+	analytics := &models.AssessmentAnalytics{}
+	summarizeAttemptScores(analytics, []*models.AssessmentAttempt{
+		{Percentage: 90, TimeSpent: 600, Passed: true},
+		{Percentage: 40, TimeSpent: 300},
+		{Percentage: 70, TimeSpent: 1200, Passed: true},
+		{Percentage: 60, TimeSpent: 900, Passed: true},
+	})
+
+	if analytics.AverageScore != 65 || analytics.MedianScore != 65 {
+		t.Errorf("unexpected average/median: %v/%v", analytics.AverageScore, analytics.MedianScore)
+	}
+	if analytics.HighestScore != 90 || analytics.LowestScore != 40 {
+		t.Errorf("unexpected range: %v-%v", analytics.LowestScore, analytics.HighestScore)
+	}
+	if math.Abs(analytics.StandardDeviation-math.Sqrt(325)) > 1e-9 {
+		t.Errorf("unexpected standard deviation: %v", analytics.StandardDeviation)
+	}
+	if analytics.AverageTimeSpent != 750 || analytics.MedianTimeSpent != 750 {
+		t.Errorf("unexpected times: %v/%v", analytics.AverageTimeSpent, analytics.MedianTimeSpent)
+	}
+	if analytics.PassedCount != 3 || analytics.FailedCount != 1 || analytics.PassRate != 0.75 {
+		t.Errorf("unexpected pass figures: %+v", analytics)
+	}
+}
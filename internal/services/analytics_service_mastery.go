@@ -0,0 +1,285 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+const defaultMasteryMinAnswers = 3
+
+// masteryMilestones are fractions of a skill's target percentage, in the order they are reached
+var masteryMilestones = []struct {
+	name     string
+	fraction float64
+}{
+	{"quarter_way", 0.25},
+	{"halfway", 0.5},
+	{"three_quarters", 0.75},
+	{"mastered", 1},
+}
+
+// ===== MASTERY TARGETS =====
+
+func (s *analyticsService) CreateMasteryTarget(ctx context.Context, req *CreateMasteryTargetRequest, userID string) (*models.MasteryTarget, error) {
+	s.logger.Info("Creating mastery target", "skill", req.Skill, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if err := s.requireTeacher(ctx, userID, 0, "create_mastery_target"); err != nil {
+		return nil, err
+	}
+
+	label := strings.TrimSpace(req.Skill)
+	skill := strings.ToLower(label)
+	if skill == "" {
+		return nil, NewValidationError("skill", "skill must not be blank", req.Skill)
+	}
+
+	exists, err := s.repo.MasteryTarget().ExistsBySkill(ctx, nil, userID, skill, nil)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, NewBusinessRuleError("duplicate_mastery_target", "a mastery target for this skill already exists", map[string]interface{}{
+			"skill": skill,
+		})
+	}
+
+	target := &models.MasteryTarget{
+		Skill:            skill,
+		Label:            label,
+		TargetPercentage: req.TargetPercentage,
+		MinAnswers:       defaultMasteryMinAnswers,
+		CreatedBy:        userID,
+	}
+	if req.MinAnswers != nil {
+		target.MinAnswers = *req.MinAnswers
+	}
+
+	if err := s.repo.MasteryTarget().Create(ctx, nil, target); err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+func (s *analyticsService) ListMasteryTargets(ctx context.Context, userID string) ([]*models.MasteryTarget, error) {
+	return s.repo.MasteryTarget().GetByCreator(ctx, nil, userID)
+}
+
+func (s *analyticsService) UpdateMasteryTarget(ctx context.Context, id uint, req *UpdateMasteryTargetRequest, userID string) (*models.MasteryTarget, error) {
+	s.logger.Info("Updating mastery target", "target_id", id, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	target, err := s.getOwnedMasteryTarget(ctx, id, userID, "update_mastery_target")
+	if err != nil {
+		return nil, err
+	}
+
+	if req.TargetPercentage != nil {
+		target.TargetPercentage = *req.TargetPercentage
+	}
+	if req.MinAnswers != nil {
+		target.MinAnswers = *req.MinAnswers
+	}
+
+	if err := s.repo.MasteryTarget().Update(ctx, nil, target); err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+func (s *analyticsService) DeleteMasteryTarget(ctx context.Context, id uint, userID string) error {
+	s.logger.Info("Deleting mastery target", "target_id", id, "user_id", userID)
+
+	if _, err := s.getOwnedMasteryTarget(ctx, id, userID, "delete_mastery_target"); err != nil {
+		return err
+	}
+
+	return s.repo.MasteryTarget().Delete(ctx, nil, id)
+}
+
+// ===== PROGRESS REPORTS =====
+
+// GetStudentProgress measures a student against mastery targets. Students see every target
+// set by the teachers whose assessments they took; teachers see only their own targets.
+func (s *analyticsService) GetStudentProgress(ctx context.Context, studentID string, userID string) (*ProgressReport, error) {
+	evidence, err := s.repo.MasteryTarget().GetSkillEvidence(ctx, nil, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var creatorIDs []string
+	switch {
+	case userID == studentID:
+		creatorIDs = evidenceCreators(evidence)
+	default:
+		role, err := s.getUserRole(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		switch role {
+		case models.RoleAdmin:
+			creatorIDs = evidenceCreators(evidence)
+		case models.RoleTeacher:
+			creatorIDs = []string{userID}
+		default:
+			return nil, NewPermissionError(userID, 0, "student_progress", "view", "students can only view their own progress")
+		}
+	}
+
+	targets, err := s.repo.MasteryTarget().GetByCreators(ctx, nil, creatorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	report := buildProgressReport(targets, evidence)
+	report.StudentID = studentID
+	report.GeneratedAt = time.Now()
+
+	return report, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (s *analyticsService) getUserRole(ctx context.Context, userID string) (models.UserRole, error) {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	return user.Role, nil
+}
+
+func (s *analyticsService) requireTeacher(ctx context.Context, userID string, resourceID uint, action string) error {
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if role != models.RoleTeacher && role != models.RoleAdmin {
+		return NewPermissionError(userID, resourceID, "mastery_target", action, "insufficient role permissions")
+	}
+	return nil
+}
+
+func (s *analyticsService) getOwnedMasteryTarget(ctx context.Context, id uint, userID, action string) (*models.MasteryTarget, error) {
+	target, err := s.repo.MasteryTarget().GetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get mastery target: %w", err)
+	}
+
+	if target.CreatedBy != userID {
+		role, err := s.getUserRole(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if role != models.RoleAdmin {
+			return nil, NewPermissionError(userID, id, "mastery_target", action, "not owner or insufficient permissions")
+		}
+	}
+
+	return target, nil
+}
+
+func evidenceCreators(evidence []repositories.SkillEvidence) []string {
+	seen := make(map[string]bool)
+	creators := make([]string, 0)
+	for _, item := range evidence {
+		if !seen[item.AssessmentCreator] {
+			seen[item.AssessmentCreator] = true
+			creators = append(creators, item.AssessmentCreator)
+		}
+	}
+	return creators
+}
+
+// buildProgressReport replays the student's graded answers in order for each target.
+// Milestones and mastery only count once the target's minimum number of answers is
+// graded, so a single lucky answer does not mark a skill as mastered.
+func buildProgressReport(targets []*models.MasteryTarget, evidence []repositories.SkillEvidence) *ProgressReport {
+	evidenceSkills := make([]map[string]bool, len(evidence))
+	for i, item := range evidence {
+		evidenceSkills[i] = make(map[string]bool)
+		for _, skill := range questionSkills(&models.Question{Tags: item.Tags}) {
+			evidenceSkills[i][strings.ToLower(skill)] = true
+		}
+	}
+
+	report := &ProgressReport{
+		TotalSkills: len(targets),
+		Skills:      make([]SkillProgress, 0, len(targets)),
+	}
+	for _, target := range targets {
+		progress := SkillProgress{
+			TargetID:         target.ID,
+			Skill:            target.Skill,
+			Label:            target.Label,
+			SetBy:            target.CreatedBy,
+			TargetPercentage: target.TargetPercentage,
+			MinAnswers:       target.MinAnswers,
+			Milestones:       make([]MasteryMilestone, len(masteryMilestones)),
+		}
+		for i, milestone := range masteryMilestones {
+			progress.Milestones[i] = MasteryMilestone{
+				Name:       milestone.name,
+				Percentage: math.Round(target.TargetPercentage*milestone.fraction*10) / 10,
+			}
+		}
+
+		percentage := 0.0
+		for i, item := range evidence {
+			if item.AssessmentCreator != target.CreatedBy || !evidenceSkills[i][target.Skill] {
+				continue
+			}
+			progress.AnsweredCount++
+			progress.EarnedPoints += item.Score
+			progress.PossiblePoints += item.MaxScore
+			percentage = scorePercentage(progress.EarnedPoints, progress.PossiblePoints)
+
+			if progress.AnsweredCount < target.MinAnswers {
+				continue
+			}
+			for m := range progress.Milestones {
+				milestone := &progress.Milestones[m]
+				if !milestone.Reached && percentage >= target.TargetPercentage*masteryMilestones[m].fraction {
+					reachedAt := item.GradedAt
+					milestone.Reached = true
+					milestone.ReachedAt = &reachedAt
+				}
+			}
+		}
+
+		progress.Percentage = math.Round(percentage*10) / 10
+		if target.TargetPercentage > 0 {
+			progress.Attainment = math.Min(1, math.Round(percentage/target.TargetPercentage*1000)/1000)
+		}
+		progress.Mastered = progress.AnsweredCount >= target.MinAnswers && percentage >= target.TargetPercentage
+		if progress.Mastered {
+			report.MasteredCount++
+		}
+		report.Skills = append(report.Skills, progress)
+	}
+
+	sort.SliceStable(report.Skills, func(i, j int) bool {
+		if report.Skills[i].Attainment != report.Skills[j].Attainment {
+			return report.Skills[i].Attainment < report.Skills[j].Attainment
+		}
+		return report.Skills[i].Skill < report.Skills[j].Skill
+	})
+
+	return report
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/datatypes"
+)
+
+func TestBuildProgressReport(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	answer := func(hour int, tags, creator string, score float64) repositories.SkillEvidence {
+		return repositories.SkillEvidence{
+			Tags:              datatypes.JSON(tags),
+			AssessmentCreator: creator,
+			Score:             score,
+			MaxScore:          10,
+			GradedAt:          start.Add(time.Duration(hour) * time.Hour),
+		}
+	}
+	evidence := []repositories.SkillEvidence{
+		answer(0, `["Algebra"]`, "teacher-1", 10),
+		answer(1, `["algebra", "Fractions"]`, "teacher-1", 4),
+		answer(2, `["Algebra"]`, "teacher-1", 10),
+		// Another teacher's assessment does not count towards teacher-1's target
+		answer(3, `["Algebra"]`, "teacher-2", 0),
+		answer(4, `["Geometry"]`, "teacher-1", 2),
+	}
+	targets := []*models.MasteryTarget{
+		{ID: 1, Skill: "algebra", Label: "Algebra", TargetPercentage: 80, MinAnswers: 3, CreatedBy: "teacher-1"},
+		{ID: 2, Skill: "fractions", Label: "Fractions", TargetPercentage: 80, MinAnswers: 3, CreatedBy: "teacher-1"},
+		{ID: 3, Skill: "geometry", Label: "Geometry", TargetPercentage: 80, MinAnswers: 1, CreatedBy: "teacher-1"},
+	}
+
+	report := buildProgressReport(targets, evidence)
+
+	if report.TotalSkills != 3 || report.MasteredCount != 1 {
+		t.Fatalf("unexpected totals: %+v", report)
+	}
+	bySkill := make(map[string]SkillProgress)
+	for _, skill := range report.Skills {
+		bySkill[skill.Skill] = skill
+	}
+
+	algebra := bySkill["algebra"]
+	if algebra.AnsweredCount != 3 || algebra.Percentage != 80 || !algebra.Mastered || algebra.Attainment != 1 {
+		t.Errorf("unexpected algebra progress: %+v", algebra)
+	}
+	// Milestones wait for the minimum number of answers, then all are reached at once
+	for _, milestone := range algebra.Milestones {
+		if !milestone.Reached || !milestone.ReachedAt.Equal(start.Add(2*time.Hour)) {
+			t.Errorf("milestone %s should be reached with the third answer, got %+v", milestone.Name, milestone)
+		}
+	}
+
+	fractions := bySkill["fractions"]
+	if fractions.AnsweredCount != 1 || fractions.Mastered || fractions.Milestones[0].Reached {
+		t.Errorf("too few answers should not reach milestones: %+v", fractions)
+	}
+
+	geometry := bySkill["geometry"]
+	if geometry.Attainment != 0.25 || !geometry.Milestones[0].Reached || geometry.Milestones[1].Reached {
+		t.Errorf("unexpected geometry progress: %+v", geometry)
+	}
+	if report.Skills[0].Skill != "geometry" {
+		t.Errorf("skills furthest from mastery should come first, got %s", report.Skills[0].Skill)
+	}
+}
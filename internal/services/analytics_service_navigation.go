@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+const (
+	maxBacktrackPaths  = 10
+	maxMostLeftForLast = 5
+)
+
+// ===== NAVIGATION ANALYTICS =====
+
+func (s *analyticsService) GetNavigationAnalytics(ctx context.Context, assessmentID uint, userID string) (*NavigationAnalytics, error) {
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	canAccess, err := NewAssessmentService(s.repo, s.db, s.logger, s.validator).CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "view_navigation", "not owner or insufficient permissions")
+	}
+
+	assessmentQuestions, err := s.repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+	order := make([]uint, 0, len(assessmentQuestions))
+	for _, aq := range assessmentQuestions {
+		order = append(order, aq.QuestionID)
+	}
+
+	events, err := s.repo.AttemptNavigation().ListSubmittedByAssessment(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := buildNavigationAnalytics(events, order)
+	analytics.AssessmentID = assessmentID
+	analytics.Title = assessment.Title
+	analytics.GeneratedAt = time.Now()
+	return analytics, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// navigationPaths splits the log, ordered by attempt and time, into each attempt's path of
+// questions. Questions no longer in the assessment are dropped, and repeated visits to the
+// same question in a row count once.
+func navigationPaths(events []*models.AttemptNavigationEvent, positions map[uint]int) [][]uint {
+	var paths [][]uint
+	var attemptID uint
+	for _, event := range events {
+		if _, ok := positions[event.QuestionID]; !ok {
+			continue
+		}
+		if len(paths) == 0 || event.AttemptID != attemptID {
+			attemptID = event.AttemptID
+			paths = append(paths, []uint{event.QuestionID})
+			continue
+		}
+		path := paths[len(paths)-1]
+		if path[len(path)-1] != event.QuestionID {
+			paths[len(paths)-1] = append(path, event.QuestionID)
+		}
+	}
+	return paths
+}
+
+// buildNavigationAnalytics counts visits, jumps back to earlier questions, and returns at the
+// end of an attempt. An attempt ending on the assessment's last question is not counted as
+// leaving it for last, since that is where working in order ends too.
+func buildNavigationAnalytics(events []*models.AttemptNavigationEvent, order []uint) *NavigationAnalytics {
+	positions := make(map[uint]int, len(order))
+	for i, questionID := range order {
+		positions[questionID] = i + 1
+	}
+	paths := navigationPaths(events, positions)
+
+	visits := make(map[uint]int, len(order))
+	revisits := make(map[uint]int, len(order))
+	leftForLast := make(map[uint]int, len(order))
+	backtracks := make(map[[2]uint]int)
+	for _, path := range paths {
+		seen := make(map[uint]bool, len(path))
+		for i, questionID := range path {
+			visits[questionID]++
+			if seen[questionID] {
+				revisits[questionID]++
+			}
+			seen[questionID] = true
+			if i > 0 && positions[questionID] < positions[path[i-1]] {
+				backtracks[[2]uint{path[i-1], questionID}]++
+			}
+		}
+		last := path[len(path)-1]
+		if slices.Contains(path[:len(path)-1], last) && positions[last] != len(order) {
+			leftForLast[last]++
+		}
+	}
+
+	analytics := &NavigationAnalytics{
+		AttemptsAnalyzed: len(paths),
+		Questions:        make([]QuestionNavigation, 0, len(order)),
+		BacktrackPaths:   make([]BacktrackPath, 0, len(backtracks)),
+		MostLeftForLast:  make([]uint, 0),
+	}
+	for i, questionID := range order {
+		question := QuestionNavigation{QuestionID: questionID, Position: i + 1, LeftForLast: leftForLast[questionID]}
+		if len(paths) > 0 {
+			attempts := float64(len(paths))
+			question.AverageVisits = roundTo(float64(visits[questionID])/attempts, 2)
+			question.AverageRevisits = roundTo(float64(revisits[questionID])/attempts, 2)
+			question.LeftForLastRate = roundTo(float64(leftForLast[questionID])/attempts*100, 2)
+		}
+		analytics.Questions = append(analytics.Questions, question)
+	}
+
+	for pair, count := range backtracks {
+		analytics.BacktrackPaths = append(analytics.BacktrackPaths, BacktrackPath{
+			FromQuestionID: pair[0],
+			FromPosition:   positions[pair[0]],
+			ToQuestionID:   pair[1],
+			ToPosition:     positions[pair[1]],
+			Count:          count,
+		})
+	}
+	sort.Slice(analytics.BacktrackPaths, func(i, j int) bool {
+		a, b := analytics.BacktrackPaths[i], analytics.BacktrackPaths[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.FromPosition != b.FromPosition {
+			return a.FromPosition < b.FromPosition
+		}
+		return a.ToPosition < b.ToPosition
+	})
+	if len(analytics.BacktrackPaths) > maxBacktrackPaths {
+		analytics.BacktrackPaths = analytics.BacktrackPaths[:maxBacktrackPaths]
+	}
+
+	for _, questionID := range order {
+		if leftForLast[questionID] > 0 {
+			analytics.MostLeftForLast = append(analytics.MostLeftForLast, questionID)
+		}
+	}
+	sort.SliceStable(analytics.MostLeftForLast, func(i, j int) bool {
+		return leftForLast[analytics.MostLeftForLast[i]] > leftForLast[analytics.MostLeftForLast[j]]
+	})
+	if len(analytics.MostLeftForLast) > maxMostLeftForLast {
+		analytics.MostLeftForLast = analytics.MostLeftForLast[:maxMostLeftForLast]
+	}
+
+	return analytics
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func navigationLog(attemptID uint, questionIDs ...uint) []*models.AttemptNavigationEvent {
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	events := make([]*models.AttemptNavigationEvent, 0, len(questionIDs))
+	for i, questionID := range questionIDs {
+		events = append(events, &models.AttemptNavigationEvent{
+			AttemptID:  attemptID,
+			QuestionID: questionID,
+			VisitedAt:  start.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	return events
+}
+
+func TestBuildNavigationAnalytics(t *testing.T) {
+	order := []uint{10, 20, 30, 40}
+	var events []*models.AttemptNavigationEvent
+	events = append(events, navigationLog(1, 10, 20, 30, 40, 20)...)     // skips back to 20 at the end
+	events = append(events, navigationLog(2, 10, 10, 30, 40, 20, 99)...) // repeat and removed question ignored
+	events = append(events, navigationLog(3, 10, 20, 30, 40)...)         // in order
+
+	analytics := buildNavigationAnalytics(events, order)
+
+	if analytics.AttemptsAnalyzed != 3 {
+		t.Fatalf("expected 3 attempts, got %d", analytics.AttemptsAnalyzed)
+	}
+	q10, q20 := analytics.Questions[0], analytics.Questions[1]
+	if q10.AverageVisits != 1 || q10.AverageRevisits != 0 {
+		t.Errorf("expected a repeated visit in a row to count once, got %+v", q10)
+	}
+	if q20.Position != 2 || q20.AverageVisits != 1.33 || q20.AverageRevisits != 0.33 {
+		t.Errorf("unexpected visits for question 20: %+v", q20)
+	}
+	if q20.LeftForLast != 1 || q20.LeftForLastRate != 33.33 {
+		t.Errorf("expected question 20 left for last once, got %+v", q20)
+	}
+	if len(analytics.MostLeftForLast) != 1 || analytics.MostLeftForLast[0] != 20 {
+		t.Errorf("unexpected most left for last %v", analytics.MostLeftForLast)
+	}
+
+	if len(analytics.BacktrackPaths) != 1 {
+		t.Fatalf("expected one backtrack path, got %+v", analytics.BacktrackPaths)
+	}
+	path := analytics.BacktrackPaths[0]
+	if path.FromQuestionID != 40 || path.ToQuestionID != 20 || path.FromPosition != 4 || path.ToPosition != 2 || path.Count != 2 {
+		t.Errorf("unexpected backtrack path %+v", path)
+	}
+}
+
+func TestBuildNavigationAnalyticsEndingOnLastQuestion(t *testing.T) {
+	// Reviewing from the start and finishing on the last question is not leaving it for last
+	analytics := buildNavigationAnalytics(navigationLog(1, 10, 20, 30, 10, 20, 30), []uint{10, 20, 30})
+	for _, question := range analytics.Questions {
+		if question.LeftForLast != 0 || question.AverageRevisits != 1 {
+			t.Errorf("unexpected navigation for question %d: %+v", question.QuestionID, question)
+		}
+	}
+	if len(analytics.MostLeftForLast) != 0 {
+		t.Errorf("expected nothing left for last, got %v", analytics.MostLeftForLast)
+	}
+}
+
+func TestBuildNavigationAnalyticsEmpty(t *testing.T) {
+	analytics := buildNavigationAnalytics(nil, []uint{10, 20})
+	if analytics.AttemptsAnalyzed != 0 || len(analytics.Questions) != 2 || analytics.Questions[0].AverageVisits != 0 {
+		t.Errorf("unexpected analytics without a log %+v", analytics)
+	}
+	if analytics.BacktrackPaths == nil || analytics.MostLeftForLast == nil {
+		t.Error("expected empty lists rather than null")
+	}
+}
@@ -0,0 +1,304 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+const (
+	questionStatsJobBatch      = 5
+	questionStatsJobStaleAfter = 10 * time.Minute
+	// Progress is saved, and the job's heartbeat refreshed, every this many questions
+	questionStatsProgressEvery = 20
+)
+
+// RecomputeQuestionStatsRequest selects the questions whose statistics are rebuilt: one
+// question or every question in a bank
+type RecomputeQuestionStatsRequest struct {
+	QuestionID *uint `json:"question_id"`
+	BankID     *uint `json:"bank_id"`
+}
+
+// ===== RECOMPUTE QUESTION STATISTICS =====
+
+// ScheduleQuestionStatsRecompute queues a job that throws away a question's stored statistics,
+// or those of a whole bank, and rebuilds them from the raw answers. A job already queued or
+// running for the same question or bank is returned instead of starting another.
+func (s *analyticsService) ScheduleQuestionStatsRecompute(ctx context.Context, req *RecomputeQuestionStatsRequest, userID string) (*models.QuestionStatsJob, error) {
+	if err := validateRecomputeQuestionStats(req); err != nil {
+		return nil, err
+	}
+
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role != models.RoleAdmin {
+		var resourceID uint
+		if req.QuestionID != nil {
+			resourceID = *req.QuestionID
+		} else {
+			resourceID = *req.BankID
+		}
+		return nil, NewPermissionError(userID, resourceID, "question_stats", "recompute", "only admins can recompute question statistics")
+	}
+
+	if req.QuestionID != nil {
+		if _, err := s.repo.Question().GetByID(ctx, nil, *req.QuestionID); err != nil {
+			if repositories.IsNotFoundError(err) {
+				return nil, ErrQuestionNotFound
+			}
+			return nil, fmt.Errorf("failed to get question: %w", err)
+		}
+	} else {
+		if _, err := s.repo.QuestionBank().GetByID(ctx, nil, *req.BankID); err != nil {
+			if repositories.IsNotFoundError(err) {
+				return nil, ErrQuestionBankNotFound
+			}
+			return nil, fmt.Errorf("failed to get question bank: %w", err)
+		}
+	}
+
+	active, err := s.repo.QuestionAnalytics().GetActiveStatsJob(ctx, nil, req.QuestionID, req.BankID)
+	if err == nil {
+		return active, nil
+	}
+	if !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get question stats job: %w", err)
+	}
+
+	job := &models.QuestionStatsJob{
+		QuestionID:  req.QuestionID,
+		BankID:      req.BankID,
+		Status:      models.QuestionStatsJobPending,
+		RequestedBy: userID,
+	}
+	if err := s.repo.QuestionAnalytics().CreateStatsJob(ctx, nil, job); err != nil {
+		return nil, err
+	}
+
+	if _, err := enqueueJob(ctx, s.repo, JobTypeQuestionStats, strconv.FormatUint(uint64(job.ID), 10), userID); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Question stats recompute scheduled", "job_id", job.ID, "question_id", req.QuestionID, "bank_id", req.BankID, "requested_by", userID)
+	return job, nil
+}
+
+func (s *analyticsService) GetQuestionStatsJob(ctx context.Context, jobID uint, userID string) (*models.QuestionStatsJob, error) {
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role != models.RoleAdmin {
+		return nil, NewPermissionError(userID, jobID, "question_stats_job", "view", "only admins can view question statistics jobs")
+	}
+
+	job, err := s.repo.QuestionAnalytics().GetStatsJobByID(ctx, nil, jobID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get question stats job: %w", err)
+	}
+	return job, nil
+}
+
+// QueueQuestionStatsJobs hands pending recomputes, and recomputes orphaned mid-way by a
+// crash, to the job workers. Recomputes that already have a queued or running job are left alone.
+func (s *analyticsService) QueueQuestionStatsJobs(ctx context.Context, limit int) (int, error) {
+	jobs, err := s.repo.QuestionAnalytics().GetRunnableStatsJobs(ctx, nil, time.Now().Add(-questionStatsJobStaleAfter), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for _, job := range jobs {
+		if _, err := enqueueJob(ctx, s.repo, JobTypeQuestionStats, strconv.FormatUint(uint64(job.ID), 10), job.RequestedBy); err != nil {
+			s.logger.Error("Failed to queue question stats job", "job_id", job.ID, "error", err)
+			continue
+		}
+		queued++
+	}
+	return queued, nil
+}
+
+// RunQuestionStatsJob runs a scheduled recompute for the job workers. A recompute that
+// failed before is run again, so a retried job redoes the work.
+func (s *analyticsService) RunQuestionStatsJob(ctx context.Context, jobID uint) error {
+	job, err := s.repo.QuestionAnalytics().GetStatsJobByID(ctx, nil, jobID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return NewPermanentJobError(ErrNotFound)
+		}
+		return fmt.Errorf("failed to get question stats job: %w", err)
+	}
+	if job.Status == models.QuestionStatsJobFailed {
+		job.Status = models.QuestionStatsJobPending
+		job.Error = nil
+		job.CompletedAt = nil
+		if err := s.repo.QuestionAnalytics().UpdateStatsJob(ctx, nil, job); err != nil {
+			return err
+		}
+	}
+
+	claimed, err := s.repo.QuestionAnalytics().ClaimStatsJob(ctx, nil, job.ID, time.Now().Add(-questionStatsJobStaleAfter))
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		// Completed, or still running elsewhere
+		return nil
+	}
+
+	job.Status = models.QuestionStatsJobRunning
+	runErr := s.runQuestionStatsJob(ctx, job)
+
+	now := time.Now()
+	job.CompletedAt = &now
+	job.Status = models.QuestionStatsJobCompleted
+	if runErr != nil {
+		s.logger.Error("Question stats job failed", "job_id", job.ID, "error", runErr)
+		job.Status = models.QuestionStatsJobFailed
+		job.Error = stringPtr(runErr.Error())
+	}
+	if err := s.repo.QuestionAnalytics().UpdateStatsJob(ctx, nil, job); err != nil {
+		return fmt.Errorf("failed to save question stats job: %w", err)
+	}
+	return runErr
+}
+
+// runQuestionStatsJob rebuilds the statistics of every question in the job's scope. A question
+// that fails is counted and skipped so one bad row cannot hold up the rest of a bank.
+func (s *analyticsService) runQuestionStatsJob(ctx context.Context, job *models.QuestionStatsJob) error {
+	var questionIDs []uint
+	if job.QuestionID != nil {
+		questionIDs = []uint{*job.QuestionID}
+	} else {
+		ids, err := s.repo.QuestionAnalytics().GetBankQuestionIDs(ctx, nil, *job.BankID)
+		if err != nil {
+			return err
+		}
+		questionIDs = ids
+	}
+
+	job.TotalQuestions = len(questionIDs)
+	job.ProcessedQuestions, job.FailedQuestions = 0, 0
+	for i, questionID := range questionIDs {
+		if err := s.recomputeQuestionStats(ctx, questionID); err != nil {
+			s.logger.Error("Failed to recompute question stats", "job_id", job.ID, "question_id", questionID, "error", err)
+			job.FailedQuestions++
+		} else {
+			job.ProcessedQuestions++
+		}
+
+		if (i+1)%questionStatsProgressEvery == 0 {
+			if err := s.repo.QuestionAnalytics().UpdateStatsJob(ctx, nil, job); err != nil {
+				return err
+			}
+		}
+	}
+
+	s.logger.Info("Question stats job completed",
+		"job_id", job.ID,
+		"processed_questions", job.ProcessedQuestions,
+		"failed_questions", job.FailedQuestions)
+	return nil
+}
+
+// recomputeQuestionStats replaces a question's stored statistics with ones computed from its
+// finished answers; a question nobody has answered ends up with zeroed statistics
+func (s *analyticsService) recomputeQuestionStats(ctx context.Context, questionID uint) error {
+	question, err := s.repo.Question().GetByID(ctx, nil, questionID)
+	if err != nil {
+		return fmt.Errorf("failed to get question: %w", err)
+	}
+	if question.Type == models.MultipleChoice {
+		_, err := s.analyzeQuestion(ctx, question)
+		return err
+	}
+
+	responses, err := s.repo.QuestionAnalytics().GetScoredResponses(ctx, nil, questionID)
+	if err != nil {
+		return err
+	}
+
+	summary := summarizeResponses(responses)
+	return s.repo.QuestionAnalytics().Upsert(ctx, nil, &models.QuestionAnalytics{
+		QuestionID:            questionID,
+		TotalResponses:        summary.TotalResponses,
+		CorrectResponses:      summary.CorrectResponses,
+		DifficultyIndex:       summary.DifficultyIndex,
+		DiscriminationIndex:   summary.DiscriminationIndex,
+		AverageScore:          summary.AverageScore,
+		AverageTimeSpent:      summary.AverageTimeSpent,
+		TopQuartileCorrect:    summary.TopQuartileCorrect,
+		BottomQuartileCorrect: summary.BottomQuartileCorrect,
+		LastCalculatedAt:      time.Now(),
+	})
+}
+
+// ===== HELPER METHODS =====
+
+func validateRecomputeQuestionStats(req *RecomputeQuestionStatsRequest) error {
+	if (req.QuestionID == nil) == (req.BankID == nil) {
+		return ValidationErrors{*NewValidationError("question_id", "exactly one of question_id and bank_id is required", req.QuestionID)}
+	}
+	return nil
+}
+
+// summarizeResponses computes the statistics of a question of any type from its scored answers.
+// Unlike analyzeItem it has no per-option breakdown.
+func summarizeResponses(responses []repositories.ScoredResponse) itemAnalysis {
+	analysis := itemAnalysis{TotalResponses: len(responses)}
+	if analysis.TotalResponses == 0 {
+		return analysis
+	}
+
+	totalScore, totalTime := 0.0, 0
+	sorted := make([]repositories.ScoredResponse, len(responses))
+	copy(sorted, responses)
+	for _, response := range sorted {
+		totalScore += response.Score
+		totalTime += response.TimeSpent
+		if isCorrectResponse(response) {
+			analysis.CorrectResponses++
+		}
+	}
+	analysis.DifficultyIndex = float64(analysis.CorrectResponses) / float64(analysis.TotalResponses)
+	analysis.AverageScore = totalScore / float64(analysis.TotalResponses)
+	analysis.AverageTimeSpent = totalTime / analysis.TotalResponses
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].AttemptPercentage < sorted[j].AttemptPercentage
+	})
+	groupSize := len(sorted) / 4
+	if groupSize == 0 {
+		return analysis
+	}
+
+	correctRate := func(group []repositories.ScoredResponse) float64 {
+		count := 0
+		for _, response := range group {
+			if isCorrectResponse(response) {
+				count++
+			}
+		}
+		return float64(count) / float64(len(group))
+	}
+	analysis.BottomQuartileCorrect = correctRate(sorted[:groupSize])
+	analysis.TopQuartileCorrect = correctRate(sorted[len(sorted)-groupSize:])
+	analysis.DiscriminationIndex = analysis.TopQuartileCorrect - analysis.BottomQuartileCorrect
+
+	return analysis
+}
+
+func isCorrectResponse(response repositories.ScoredResponse) bool {
+	return response.IsCorrect != nil && *response.IsCorrect
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestSummarizeResponses(t *testing.T) {
+	correct, wrong := true, false
+	responses := make([]repositories.ScoredResponse, 0, 8)
+	// The four weakest attempts miss the question, the four strongest get it right
+	for i := 0; i < 8; i++ {
+		response := repositories.ScoredResponse{AttemptPercentage: float64(i * 10), TimeSpent: 40, IsCorrect: &wrong}
+		if i >= 4 {
+			response.IsCorrect = &correct
+			response.Score = 2
+		}
+		responses = append(responses, response)
+	}
+
+	summary := summarizeResponses(responses)
+	if summary.TotalResponses != 8 || summary.CorrectResponses != 4 {
+		t.Fatalf("expected 4 of 8 correct, got %d of %d", summary.CorrectResponses, summary.TotalResponses)
+	}
+	if summary.DifficultyIndex != 0.5 || summary.AverageScore != 1 || summary.AverageTimeSpent != 40 {
+		t.Fatalf("unexpected averages: %+v", summary)
+	}
+	if summary.TopQuartileCorrect != 1 || summary.BottomQuartileCorrect != 0 || summary.DiscriminationIndex != 1 {
+		t.Fatalf("expected full discrimination, got %+v", summary)
+	}
+	if len(summary.Options) != 0 {
+		t.Fatalf("expected no option stats, got %d", len(summary.Options))
+	}
+}
+
+func TestSummarizeResponsesEmpty(t *testing.T) {
+	summary := summarizeResponses(nil)
+	if summary.TotalResponses != 0 || summary.DifficultyIndex != 0 || summary.DiscriminationIndex != 0 {
+		t.Fatalf("expected zeroed statistics, got %+v", summary)
+	}
+}
+
+func TestValidateRecomputeQuestionStats(t *testing.T) {
+	id := uint(3)
+	if err := validateRecomputeQuestionStats(&RecomputeQuestionStatsRequest{QuestionID: &id}); err != nil {
+		t.Fatalf("expected a single question to be valid: %v", err)
+	}
+	if err := validateRecomputeQuestionStats(&RecomputeQuestionStatsRequest{}); err == nil {
+		t.Fatal("expected an empty request to be rejected")
+	}
+	if err := validateRecomputeQuestionStats(&RecomputeQuestionStatsRequest{QuestionID: &id, BankID: &id}); err == nil {
+		t.Fatal("expected a request naming both a question and a bank to be rejected")
+	}
+}
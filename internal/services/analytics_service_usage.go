@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// ===== PEAK USAGE TIMES =====
+
+func (s *analyticsService) GetPeakUsageTimes(ctx context.Context, assessmentID uint, timezone string, userID string) (*PeakUsageTimes, error) {
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	canAccess, err := NewAssessmentService(s.repo, s.db, s.logger, s.validator).CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "view_usage", "not owner or insufficient permissions")
+	}
+
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return nil, NewValidationError("timezone", "unknown timezone", timezone)
+		}
+	} else {
+		timezone = assessment.DueTimezone
+	}
+	location, timezone := loadTimezone(timezone)
+
+	timings, err := s.repo.Attempt().GetAttemptTimings(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := buildPeakUsageTimes(timings, location, time.Now())
+	usage.AssessmentID = assessmentID
+	usage.Title = assessment.Title
+	usage.Timezone = timezone
+	return usage, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// buildPeakUsageTimes buckets attempts by the local hour and weekday they started in and
+// finds the most attempts running at once. Attempts still in progress run until now.
+func buildPeakUsageTimes(timings []repositories.AttemptTiming, location *time.Location, now time.Time) *PeakUsageTimes {
+	usage := &PeakUsageTimes{
+		TotalAttempts: len(timings),
+		ByHour:        make([]UsageBucket, 24),
+		ByWeekday:     make([]UsageBucket, 7),
+		GeneratedAt:   now,
+	}
+	for hour := range usage.ByHour {
+		usage.ByHour[hour].Label = fmt.Sprintf("%02d:00", hour)
+	}
+	for day := range usage.ByWeekday {
+		usage.ByWeekday[day].Label = time.Weekday(day).String()
+	}
+
+	hourTotals := make([]float64, 24)
+	weekdayTotals := make([]float64, 7)
+	for _, timing := range timings {
+		started := timing.StartedAt.In(location)
+		hour, day := started.Hour(), int(started.Weekday())
+		usage.Heatmap[day][hour]++
+		usage.ByHour[hour].Attempts++
+		usage.ByWeekday[day].Attempts++
+		if timing.Finished {
+			usage.ByHour[hour].FinishedAttempts++
+			usage.ByWeekday[day].FinishedAttempts++
+			hourTotals[hour] += timing.Percentage
+			weekdayTotals[day] += timing.Percentage
+		}
+	}
+	averageUsageBuckets(usage.ByHour, hourTotals)
+	averageUsageBuckets(usage.ByWeekday, weekdayTotals)
+
+	if len(timings) > 0 {
+		peakHour, peakDay := 0, 0
+		for hour, bucket := range usage.ByHour {
+			if bucket.Attempts > usage.ByHour[peakHour].Attempts {
+				peakHour = hour
+			}
+		}
+		for day, bucket := range usage.ByWeekday {
+			if bucket.Attempts > usage.ByWeekday[peakDay].Attempts {
+				peakDay = day
+			}
+		}
+		peakWeekday := time.Weekday(peakDay).String()
+		usage.PeakHour = &peakHour
+		usage.PeakWeekday = &peakWeekday
+	}
+
+	usage.PeakConcurrency, usage.PeakConcurrencyAt = peakConcurrency(timings, now)
+	return usage
+}
+
+func averageUsageBuckets(buckets []UsageBucket, totals []float64) {
+	for i := range buckets {
+		if buckets[i].FinishedAttempts > 0 {
+			average := roundTo(totals[i]/float64(buckets[i].FinishedAttempts), 2)
+			buckets[i].AveragePercentage = &average
+		}
+	}
+}
+
+// peakConcurrency sweeps attempt starts and ends in time order. An attempt ending at the
+// instant another starts does not overlap it.
+func peakConcurrency(timings []repositories.AttemptTiming, now time.Time) (int, *time.Time) {
+	type event struct {
+		at    time.Time
+		delta int
+	}
+	events := make([]event, 0, len(timings)*2)
+	for _, timing := range timings {
+		ended := now
+		if timing.EndedAt != nil {
+			ended = *timing.EndedAt
+		}
+		if ended.Before(timing.StartedAt) {
+			ended = timing.StartedAt
+		}
+		events = append(events, event{at: timing.StartedAt, delta: 1}, event{at: ended, delta: -1})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].at.Equal(events[j].at) {
+			return events[i].at.Before(events[j].at)
+		}
+		return events[i].delta < events[j].delta
+	})
+
+	running, peak := 0, 0
+	var peakAt *time.Time
+	for _, e := range events {
+		running += e.delta
+		if running > peak {
+			peak = running
+			at := e.at
+			peakAt = &at
+		}
+	}
+	return peak, peakAt
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestBuildPeakUsageTimes(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	// Monday 2025-03-03, 07:30 UTC is 08:30 in Berlin
+	monday := time.Date(2025, 3, 3, 7, 30, 0, 0, time.UTC)
+	ended := func(start time.Time, minutes int) *time.Time {
+		end := start.Add(time.Duration(minutes) * time.Minute)
+		return &end
+	}
+	timings := []repositories.AttemptTiming{
+		{StartedAt: monday, EndedAt: ended(monday, 60), Finished: true, Percentage: 80},
+		{StartedAt: monday.Add(10 * time.Minute), EndedAt: ended(monday.Add(10*time.Minute), 30), Finished: true, Percentage: 60},
+		{StartedAt: monday.Add(20 * time.Minute), EndedAt: ended(monday.Add(20*time.Minute), 5)}, // abandoned
+		{StartedAt: monday.Add(26 * time.Hour), EndedAt: ended(monday.Add(26*time.Hour), 45), Finished: true, Percentage: 90},
+	}
+
+	usage := buildPeakUsageTimes(timings, berlin, monday.Add(48*time.Hour))
+
+	if usage.TotalAttempts != 4 || usage.Heatmap[1][8] != 3 || usage.Heatmap[2][10] != 1 {
+		t.Fatalf("unexpected heatmap %v", usage.Heatmap[1:3])
+	}
+	morning := usage.ByHour[8]
+	if morning.Label != "08:00" || morning.Attempts != 3 || morning.FinishedAttempts != 2 || *morning.AveragePercentage != 70 {
+		t.Errorf("unexpected 08:00 bucket %+v", morning)
+	}
+	if usage.ByHour[0].AveragePercentage != nil {
+		t.Errorf("expected no average for an empty bucket")
+	}
+	if *usage.PeakHour != 8 || *usage.PeakWeekday != "Monday" {
+		t.Errorf("expected Monday 08:00 as the peak, got %v %v", *usage.PeakHour, *usage.PeakWeekday)
+	}
+	if usage.PeakConcurrency != 3 || !usage.PeakConcurrencyAt.Equal(monday.Add(20*time.Minute)) {
+		t.Errorf("expected 3 attempts at once from 07:50 UTC, got %d at %v", usage.PeakConcurrency, usage.PeakConcurrencyAt)
+	}
+}
+
+func TestPeakConcurrency(t *testing.T) {
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	now := start.Add(3 * time.Hour)
+
+	// Back to back attempts do not overlap
+	if peak, _ := peakConcurrency([]repositories.AttemptTiming{
+		{StartedAt: start, EndedAt: &end},
+		{StartedAt: end, EndedAt: &now},
+	}, now); peak != 1 {
+		t.Errorf("expected back to back attempts not to overlap, got %d", peak)
+	}
+
+	// An attempt in progress runs until now
+	if peak, _ := peakConcurrency([]repositories.AttemptTiming{
+		{StartedAt: start},
+		{StartedAt: start.Add(2 * time.Hour), EndedAt: &now},
+	}, now); peak != 2 {
+		t.Errorf("expected the running attempt to overlap, got %d", peak)
+	}
+
+	if peak, at := peakConcurrency(nil, now); peak != 0 || at != nil {
+		t.Errorf("expected no peak without attempts, got %d at %v", peak, at)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
 	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -18,6 +19,13 @@ type assessmentService struct {
 	db              *gorm.DB
 	logger          *slog.Logger
 	validator       *validator.Validator
+
+	// Organization policy: a creator may reuse a title in another term, with a warning
+	allowDuplicateTitlesAcrossTerms bool
+	// Organization policy on the question mix, checked when questions are added
+	examPolicy ExamPolicy
+	// Proctoring providers, checked against the proctoring settings at publish time
+	proctoringProviders ProctoringProviders
 }
 
 func NewAssessmentService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) AssessmentService {
@@ -27,9 +35,38 @@ func NewAssessmentService(repo repositories.Repository, db *gorm.DB, logger *slo
 		logger:          logger,
 		validator:       validator,
 		questionService: NewQuestionService(repo, db, logger, validator),
+		examPolicy:      DefaultExamPolicy(),
+
+		proctoringProviders: DefaultProctoringProviders(),
 	}
 }
 
+// NewAssessmentServiceWithTitlePolicy creates an assessment service that applies the
+// organization's policy on duplicate titles across terms
+func NewAssessmentServiceWithTitlePolicy(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, allowDuplicateTitlesAcrossTerms bool) AssessmentService {
+	service := NewAssessmentService(repo, db, logger, validator).(*assessmentService)
+	service.allowDuplicateTitlesAcrossTerms = allowDuplicateTitlesAcrossTerms
+	return service
+}
+
+// NewAssessmentServiceWithPolicies creates an assessment service that applies the
+// organization's title and exam policies
+func NewAssessmentServiceWithPolicies(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, allowDuplicateTitlesAcrossTerms bool, examPolicy ExamPolicy) AssessmentService {
+	service := NewAssessmentServiceWithTitlePolicy(repo, db, logger, validator, allowDuplicateTitlesAcrossTerms).(*assessmentService)
+	service.examPolicy = examPolicy
+	return service
+}
+
+// NewAssessmentServiceWithProctoring creates an assessment service that applies the
+// organization's policies and checks proctoring settings against its proctoring providers
+func NewAssessmentServiceWithProctoring(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, allowDuplicateTitlesAcrossTerms bool, examPolicy ExamPolicy, proctoringProviders ProctoringProviders) AssessmentService {
+	service := NewAssessmentServiceWithPolicies(repo, db, logger, validator, allowDuplicateTitlesAcrossTerms, examPolicy).(*assessmentService)
+	if proctoringProviders != nil {
+		service.proctoringProviders = proctoringProviders
+	}
+	return service
+}
+
 // ===== CORE CRUD OPERATIONS =====
 
 func (s *assessmentService) Create(ctx context.Context, req *CreateAssessmentRequest, creatorID string) (*AssessmentResponse, error) {
@@ -50,49 +87,21 @@ func (s *assessmentService) Create(ctx context.Context, req *CreateAssessmentReq
 	}
 
 	// Validate business rules
-	if err := s.validateCreateRequest(ctx, req, creatorID); err != nil {
+	title, err := s.validateCreateRequest(ctx, req, creatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	customFields, err := resolveCustomFields(ctx, s.repo, creatorID, models.CustomFieldEntityAssessment, nil, req.CustomFields)
+	if err != nil {
 		return nil, err
 	}
 
 	// Use transaction for complex operation
-	var assessment *models.Assessment
+	assessment := newAssessmentModel(req, title.title, creatorID)
+	assessment.CustomFields = customFields
 	err = s.withTx(ctx, func(tx *gorm.DB) error {
-		// Create assessment
-		assessment = &models.Assessment{
-			Title:        req.Title,
-			Description:  req.Description,
-			Duration:     req.Duration,
-			Status:       models.StatusDraft,
-			PassingScore: req.PassingScore,
-			MaxAttempts:  req.MaxAttempts,
-			TimeWarning:  300, // Default 5 minutes
-			DueDate:      req.DueDate,
-			CreatedBy:    creatorID,
-			Version:      1,
-		}
-
-		if req.TimeWarning != nil {
-			assessment.TimeWarning = *req.TimeWarning
-		}
-
-		if err := s.repo.Assessment().Create(ctx, tx, assessment); err != nil {
-			return fmt.Errorf("failed to create assessment: %w", err)
-		}
-
-		// Create settings
-		settings := s.buildAssessmentSettings(assessment.ID, req.Settings)
-		if err := s.repo.AssessmentSettings().Create(ctx, tx, settings); err != nil {
-			return fmt.Errorf("failed to create assessment settings: %w", err)
-		}
-
-		// Add questions if provided
-		if len(req.Questions) > 0 {
-			if err := s.addQuestionsToAssessment(ctx, tx, assessment.ID, req.Questions, creatorID); err != nil {
-				return fmt.Errorf("failed to add questions: %w", err)
-			}
-		}
-
-		return nil
+		return s.createAssessmentTx(ctx, tx, assessment, req, creatorID)
 	})
 
 	if err != nil {
@@ -102,7 +111,61 @@ func (s *assessmentService) Create(ctx context.Context, req *CreateAssessmentReq
 	s.logger.Info("Assessment created successfully", "assessment_id", assessment.ID)
 
 	// Return response
-	return s.GetByIDWithDetails(ctx, assessment.ID, creatorID)
+	response, err := s.GetByIDWithDetails(ctx, assessment.ID, creatorID)
+	if err != nil {
+		return nil, err
+	}
+	response.Warnings = title.warnings
+	return response, nil
+}
+
+// newAssessmentModel builds an unsaved draft assessment from a create request
+func newAssessmentModel(req *CreateAssessmentRequest, title string, creatorID string) *models.Assessment {
+	assessment := &models.Assessment{
+		Title:        title,
+		Description:  req.Description,
+		Duration:     req.Duration,
+		Status:       models.StatusDraft,
+		PassingScore: req.PassingScore,
+		MaxAttempts:  req.MaxAttempts,
+		TimeWarning:  300, // Default 5 minutes
+		DueDate:      req.DueDate,
+		DueTimezone:  "UTC",
+		Term:         req.Term,
+		CreatedBy:    creatorID,
+		Version:      1,
+	}
+
+	if req.TimeWarning != nil {
+		assessment.TimeWarning = *req.TimeWarning
+	}
+	if req.DueTimezone != nil {
+		assessment.DueTimezone = *req.DueTimezone
+	}
+	return assessment
+}
+
+// createAssessmentTx saves an assessment with its settings and questions inside a transaction
+func (s *assessmentService) createAssessmentTx(ctx context.Context, tx *gorm.DB, assessment *models.Assessment, req *CreateAssessmentRequest, creatorID string) error {
+	if err := s.repo.Assessment().Create(ctx, tx, assessment); err != nil {
+		return fmt.Errorf("failed to create assessment: %w", err)
+	}
+
+	// Create settings
+	settings := s.buildAssessmentSettings(assessment.ID, req.Settings)
+	if err := s.repo.AssessmentSettings().Create(ctx, tx, settings); err != nil {
+		return fmt.Errorf("failed to create assessment settings: %w", err)
+	}
+
+	// Add questions if provided
+	if len(req.Questions) > 0 {
+		if err := s.addQuestionsToAssessment(ctx, tx, assessment.ID, req.Questions, creatorID); err != nil {
+			return fmt.Errorf("failed to add questions: %w", err)
+		}
+	}
+
+	return s.recordAssessmentAudit(ctx, tx, models.AuditAssessmentCreated, assessment.ID, creatorID,
+		fmt.Sprintf("Assessment %q created", assessment.Title), nil)
 }
 
 func (s *assessmentService) GetByID(ctx context.Context, id uint, userID string) (*AssessmentResponse, error) {
@@ -124,6 +187,8 @@ func (s *assessmentService) GetByID(ctx context.Context, id uint, userID string)
 		return nil, fmt.Errorf("failed to get assessment: %w", err)
 	}
 
+	s.recordStudentView(ctx, id, userID)
+
 	return s.buildAssessmentResponse(ctx, assessment, userID), nil
 }
 
@@ -146,6 +211,8 @@ func (s *assessmentService) GetByIDWithDetails(ctx context.Context, id uint, use
 		return nil, fmt.Errorf("failed to get assessment with details: %w", err)
 	}
 
+	s.recordStudentView(ctx, id, userID)
+
 	return s.buildAssessmentResponse(ctx, assessment, userID), nil
 }
 
@@ -176,14 +243,40 @@ func (s *assessmentService) Update(ctx context.Context, id uint, req *UpdateAsse
 	}
 
 	// Validate business rules for update
-	if err := s.validateUpdateRequest(ctx, req, assessment, userID); err != nil {
+	title, err := s.validateUpdateRequest(ctx, req, assessment, userID)
+	if err != nil {
 		return nil, err
 	}
 
+	// Values are checked against the owner's organization, whoever edits the assessment
+	var customFields datatypes.JSON
+	if req.CustomFields != nil {
+		customFields, err = resolveCustomFields(ctx, s.repo, assessment.CreatedBy, models.CustomFieldEntityAssessment, assessment.CustomFields, req.CustomFields)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Duration, passing score and settings cannot change under students taking the assessment
+	var lock *contentLock
+	if assessmentUpdateLocked(req) {
+		lock, err = acquireContentLock(ctx, s.repo, userID, "update_assessment", id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Begin transaction at service layer
+	before := *assessment
 	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Apply updates
 		s.applyAssessmentUpdates(assessment, req)
+		if title != nil {
+			assessment.Title = title.title
+		}
+		if req.CustomFields != nil {
+			assessment.CustomFields = customFields
+		}
 
 		// Update assessment
 		if err := s.repo.Assessment().Update(ctx, tx, assessment); err != nil {
@@ -204,7 +297,13 @@ func (s *assessmentService) Update(ctx context.Context, id uint, req *UpdateAsse
 			}
 		}
 
-		return nil
+		changes := assessmentChanges(&before, assessment)
+		if err := s.recordAssessmentAudit(ctx, tx, models.AuditAssessmentUpdated, id, userID,
+			assessmentEditDescription(changes, req), changes); err != nil {
+			return err
+		}
+
+		return lock.release(ctx, tx)
 	})
 
 	if err != nil {
@@ -214,7 +313,14 @@ func (s *assessmentService) Update(ctx context.Context, id uint, req *UpdateAsse
 	s.logger.Info("Assessment updated successfully", "assessment_id", id)
 
 	// Return updated assessment
-	return s.GetByIDWithDetails(ctx, id, userID)
+	response, err := s.GetByIDWithDetails(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if title != nil {
+		response.Warnings = title.warnings
+	}
+	return response, nil
 }
 
 func (s *assessmentService) Delete(ctx context.Context, id uint, userID string) error {
@@ -267,6 +373,7 @@ func (s *assessmentService) List(ctx context.Context, filters repositories.Asses
 	for i, assessment := range assessments {
 		response.Assessments[i] = s.buildAssessmentResponse(ctx, assessment, userID)
 	}
+	s.markFavorites(ctx, response.Assessments, userID)
 
 	return response, nil
 }
@@ -291,6 +398,7 @@ func (s *assessmentService) GetByCreator(ctx context.Context, creatorID string,
 	for i, assessment := range assessments {
 		response.Assessments[i] = s.buildAssessmentResponse(ctx, assessment, creatorID)
 	}
+	s.markFavorites(ctx, response.Assessments, creatorID)
 
 	return response, nil
 }
@@ -322,6 +430,7 @@ func (s *assessmentService) Search(ctx context.Context, query string, filters re
 	for i, assessment := range assessments {
 		response.Assessments[i] = s.buildAssessmentResponse(ctx, assessment, userID)
 	}
+	s.markFavorites(ctx, response.Assessments, userID)
 
 	return response, nil
 }
@@ -360,11 +469,20 @@ func (s *assessmentService) UpdateStatus(ctx context.Context, id uint, req *Upda
 	}
 
 	// Update status
+	previous := assessment.Status
 	assessment.Status = req.Status
 	assessment.UpdatedAt = time.Now()
 
-	if err := s.repo.Assessment().Update(ctx, s.db, assessment); err != nil {
-		return fmt.Errorf("failed to update assessment status: %w", err)
+	err = s.withTx(ctx, func(tx *gorm.DB) error {
+		if err := s.repo.Assessment().Update(ctx, tx, assessment); err != nil {
+			return fmt.Errorf("failed to update assessment status: %w", err)
+		}
+		return s.recordAssessmentAudit(ctx, tx, statusAuditEvent(req.Status), id, userID,
+			statusChangeDescription(previous, req.Status, req.Reason),
+			map[string]interface{}{"status": map[string]interface{}{"before": previous, "after": req.Status}})
+	})
+	if err != nil {
+		return err
 	}
 
 	s.logger.Info("Assessment status updated successfully",
@@ -395,7 +513,7 @@ func (s *assessmentService) Archive(ctx context.Context, id uint, userID string)
 
 // ===== QUESTION MANAGEMENT =====
 
-func (s *assessmentService) AddQuestion(ctx context.Context, assessmentID, questionID uint, order int, points *int, userID string) error {
+func (s *assessmentService) AddQuestion(ctx context.Context, assessmentID, questionID uint, order int, points *int, userID string) (*AssemblyCheck, error) {
 	s.logger.Info("Adding question to assessment",
 		"assessment_id", assessmentID,
 		"question_id", questionID,
@@ -405,34 +523,45 @@ func (s *assessmentService) AddQuestion(ctx context.Context, assessmentID, quest
 	// Check edit permission
 	canEdit, err := s.CanEdit(ctx, assessmentID, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !canEdit {
-		return NewPermissionError(userID, assessmentID, "assessment", "add_question", "not owner or assessment not editable")
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "add_question", "not owner or assessment not editable")
 	}
 
 	// Verify question exists and user has access
 	canAccessQuestion, err := s.questionService.CanAccess(ctx, questionID, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !canAccessQuestion {
-		return NewPermissionError(userID, questionID, "question", "access", "question not found or access denied")
+		return nil, NewPermissionError(userID, questionID, "question", "access", "question not found or access denied")
+	}
+	if err := rejectDraftQuestions(ctx, s.repo, questionID); err != nil {
+		return nil, err
+	}
+
+	lock, err := acquireContentLock(ctx, s.repo, userID, "add_question", assessmentID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Add question to assessment
 	if err := s.repo.AssessmentQuestion().AddQuestion(ctx, s.db, assessmentID, questionID, order, points); err != nil {
-		return fmt.Errorf("failed to add question to assessment: %w", err)
+		return nil, fmt.Errorf("failed to add question to assessment: %w", err)
+	}
+	if err := lock.release(ctx, s.db); err != nil {
+		return nil, err
 	}
 
 	s.logger.Info("Question added to assessment successfully",
 		"assessment_id", assessmentID,
 		"question_id", questionID)
 
-	return nil
+	return s.checkAssembly(ctx, assessmentID)
 }
 
-func (s *assessmentService) AddQuestions(ctx context.Context, assessmentID uint, questionsId []uint, userID string) error {
+func (s *assessmentService) AddQuestions(ctx context.Context, assessmentID uint, questionsId []uint, userID string) (*AssemblyCheck, error) {
 	s.logger.Info("Adding multiple questions to assessment",
 		"assessment_id", assessmentID,
 		"question_count", len(questionsId),
@@ -441,23 +570,34 @@ func (s *assessmentService) AddQuestions(ctx context.Context, assessmentID uint,
 	// Check edit permission
 	canEdit, err := s.CanEdit(ctx, assessmentID, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !canEdit {
-		return NewPermissionError(userID, assessmentID, "assessment", "add_questions", "not owner or assessment not editable")
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "add_questions", "not owner or assessment not editable")
+	}
+	if err := rejectDraftQuestions(ctx, s.repo, questionsId...); err != nil {
+		return nil, err
+	}
+
+	lock, err := acquireContentLock(ctx, s.repo, userID, "add_questions", assessmentID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Add questions to assessment
 	if err := s.repo.AssessmentQuestion().AddQuestions(ctx, s.db, assessmentID, questionsId); err != nil {
-		return fmt.Errorf("failed to add questions to assessment: %w", err)
+		return nil, fmt.Errorf("failed to add questions to assessment: %w", err)
+	}
+	if err := lock.release(ctx, s.db); err != nil {
+		return nil, err
 	}
 
 	s.logger.Info("Questions added to assessment successfully",
 		"assessment_id", assessmentID,
 		"question_count", len(questionsId))
 
-	return nil
+	return s.checkAssembly(ctx, assessmentID)
 }
 
 func (s *assessmentService) UpdateAssessmentQuestion(ctx context.Context, assessmentID, questionID uint, req *UpdateAssessmentQuestionRequest, userID string) error {
@@ -488,10 +628,24 @@ func (s *assessmentService) UpdateAssessmentQuestion(ctx context.Context, assess
 	if req.TimeLimit != nil {
 		assessmentQuestion.TimeLimit = req.TimeLimit
 	}
+	if req.MaxAnswerChanges != nil {
+		assessmentQuestion.MaxAnswerChanges = answerChangeLimit(*req.MaxAnswerChanges)
+	}
+	if req.Section != nil {
+		assessmentQuestion.Section = questionSection(*req.Section)
+	}
+
+	lock, err := acquireContentLock(ctx, s.repo, userID, "update_assessment_question", assessmentID)
+	if err != nil {
+		return err
+	}
 
 	if err := s.repo.AssessmentQuestion().Update(ctx, s.db, assessmentQuestion); err != nil {
 		return fmt.Errorf("failed to update assessment question: %w", err)
 	}
+	if err := lock.release(ctx, s.db); err != nil {
+		return err
+	}
 
 	s.logger.Info("Assessment question updated successfully",
 		"assessment_id", assessmentID,
@@ -514,10 +668,18 @@ func (s *assessmentService) RemoveQuestions(ctx context.Context, assessmentID ui
 		return NewPermissionError(userID, assessmentID, "assessment", "remove_questions", "not owner or assessment not editable")
 	}
 
+	lock, err := acquireContentLock(ctx, s.repo, userID, "remove_questions", assessmentID)
+	if err != nil {
+		return err
+	}
+
 	// Remove questions from assessment
 	if err := s.repo.AssessmentQuestion().RemoveQuestions(ctx, s.db, assessmentID, questionsId); err != nil {
 		return fmt.Errorf("failed to remove questions from assessment: %w", err)
 	}
+	if err := lock.release(ctx, s.db); err != nil {
+		return err
+	}
 
 	s.logger.Info("Questions removed from assessment successfully",
 		"assessment_id", assessmentID,
@@ -540,6 +702,11 @@ func (s *assessmentService) UpdateAssessmentQuestionBatch(ctx context.Context, a
 		return NewPermissionError(userID, assessmentID, "assessment", "update_assessment_questions", "not owner or assessment not editable")
 	}
 
+	lock, err := acquireContentLock(ctx, s.repo, userID, "update_assessment_questions", assessmentID)
+	if err != nil {
+		return err
+	}
+
 	// Update assessment questions in batch
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		for _, req := range reqs {
@@ -556,12 +723,18 @@ func (s *assessmentService) UpdateAssessmentQuestionBatch(ctx context.Context, a
 			if req.TimeLimit != nil {
 				assessmentQuestion.TimeLimit = req.TimeLimit
 			}
+			if req.MaxAnswerChanges != nil {
+				assessmentQuestion.MaxAnswerChanges = answerChangeLimit(*req.MaxAnswerChanges)
+			}
+			if req.Section != nil {
+				assessmentQuestion.Section = questionSection(*req.Section)
+			}
 			// Save
 			if err := s.repo.AssessmentQuestion().Update(ctx, tx, assessmentQuestion); err != nil {
 				return fmt.Errorf("failed to update assessment question (question_id: %d): %w", req.QuestionId, err)
 			}
 		}
-		return nil
+		return lock.release(ctx, tx)
 	})
 	if err != nil {
 		return err
@@ -587,10 +760,18 @@ func (s *assessmentService) RemoveQuestion(ctx context.Context, assessmentID, qu
 		return NewPermissionError(userID, assessmentID, "assessment", "remove_question", "not owner or assessment not editable")
 	}
 
+	lock, err := acquireContentLock(ctx, s.repo, userID, "remove_question", assessmentID)
+	if err != nil {
+		return err
+	}
+
 	// Remove question from assessment
 	if err := s.repo.AssessmentQuestion().RemoveQuestion(ctx, s.db, assessmentID, questionID); err != nil {
 		return fmt.Errorf("failed to remove question from assessment: %w", err)
 	}
+	if err := lock.release(ctx, s.db); err != nil {
+		return err
+	}
 
 	s.logger.Info("Question removed from assessment successfully",
 		"assessment_id", assessmentID,
@@ -614,10 +795,18 @@ func (s *assessmentService) ReorderQuestions(ctx context.Context, assessmentID u
 		return NewPermissionError(userID, assessmentID, "assessment", "reorder_questions", "not owner or assessment not editable")
 	}
 
+	lock, err := acquireContentLock(ctx, s.repo, userID, "reorder_questions", assessmentID)
+	if err != nil {
+		return err
+	}
+
 	// Reorder questions
 	if err := s.repo.AssessmentQuestion().ReorderQuestions(ctx, s.db, assessmentID, orders); err != nil {
 		return fmt.Errorf("failed to reorder questions: %w", err)
 	}
+	if err := lock.release(ctx, s.db); err != nil {
+		return err
+	}
 
 	s.logger.Info("Assessment questions reordered successfully", "assessment_id", assessmentID)
 
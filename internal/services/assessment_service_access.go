@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// accessStatusOrder lists least engaged students first so teachers can chase them up
+var accessStatusOrder = map[StudentAccessStatus]int{
+	AccessNotAccessed: 0,
+	AccessViewed:      1,
+	AccessStarted:     2,
+	AccessSubmitted:   3,
+}
+
+// ===== ENROLLMENT =====
+
+func (s *assessmentService) EnrollStudents(ctx context.Context, assessmentID uint, req *EnrollStudentsRequest, userID string) (*EnrollStudentsResult, error) {
+	s.logger.Info("Enrolling students", "assessment_id", assessmentID, "count", len(req.StudentIDs), "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getOwnedAssessment(ctx, assessmentID, userID, "enroll_students"); err != nil {
+		return nil, err
+	}
+
+	studentIDs := make([]string, 0, len(req.StudentIDs))
+	seen := make(map[string]bool, len(req.StudentIDs))
+	for _, studentID := range req.StudentIDs {
+		if !seen[studentID] {
+			seen[studentID] = true
+			studentIDs = append(studentIDs, studentID)
+		}
+	}
+
+	users, err := s.repo.User().GetByIDs(ctx, studentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	students := make(map[string]bool, len(users))
+	for _, user := range users {
+		if user.Role == models.RoleStudent {
+			students[user.ID] = true
+		}
+	}
+
+	result := &EnrollStudentsResult{
+		AssessmentID: assessmentID,
+		Enrolled:     make([]string, 0, len(studentIDs)),
+		Invalid:      make([]string, 0),
+	}
+	enrollments := make([]*models.AssessmentEnrollment, 0, len(studentIDs))
+	for _, studentID := range studentIDs {
+		if !students[studentID] {
+			result.Invalid = append(result.Invalid, studentID)
+			continue
+		}
+		result.Enrolled = append(result.Enrolled, studentID)
+		enrollments = append(enrollments, &models.AssessmentEnrollment{
+			AssessmentID: assessmentID,
+			StudentID:    studentID,
+			ClassID:      req.ClassID,
+			EnrolledBy:   userID,
+		})
+	}
+
+	if err := s.repo.Enrollment().Enroll(ctx, s.db, enrollments); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *assessmentService) UnenrollStudent(ctx context.Context, assessmentID uint, studentID string, userID string) error {
+	s.logger.Info("Unenrolling student", "assessment_id", assessmentID, "student_id", studentID, "user_id", userID)
+
+	if _, err := s.getOwnedAssessment(ctx, assessmentID, userID, "enroll_students"); err != nil {
+		return err
+	}
+
+	if err := s.repo.Enrollment().Unenroll(ctx, s.db, assessmentID, studentID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ===== ACCESS AUDIT =====
+
+func (s *assessmentService) GetAccessAudit(ctx context.Context, assessmentID uint, userID string) (*AssessmentAccessAudit, error) {
+	assessment, err := s.getOwnedAssessment(ctx, assessmentID, userID, "view_access_audit")
+	if err != nil {
+		return nil, err
+	}
+
+	enrolledIDs, err := s.repo.Enrollment().GetStudentIDs(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	views, err := s.repo.Enrollment().GetViews(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	activity, err := s.repo.Attempt().GetStudentActivityByAssessment(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	audit := buildAccessAudit(enrolledIDs, views, activity)
+	audit.AssessmentID = assessmentID
+	audit.DueDate = assessment.DueDate
+	audit.GeneratedAt = time.Now()
+
+	// Names are a convenience; the audit is still useful with IDs only
+	studentIDs := make([]string, 0, len(audit.Students))
+	for _, record := range audit.Students {
+		studentIDs = append(studentIDs, record.StudentID)
+	}
+	if users, err := s.repo.User().GetByIDs(ctx, studentIDs); err != nil {
+		s.logger.Warn("Failed to load student names for access audit", "assessment_id", assessmentID, "error", err)
+	} else {
+		byID := make(map[string]*models.User, len(users))
+		for _, user := range users {
+			byID[user.ID] = user
+		}
+		for i := range audit.Students {
+			if user, ok := byID[audit.Students[i].StudentID]; ok {
+				audit.Students[i].StudentName = user.FullName
+				audit.Students[i].Email = user.Email
+			}
+		}
+	}
+
+	return audit, nil
+}
+
+// recordStudentView tracks that a student opened the assessment; failures only affect the audit
+func (s *assessmentService) recordStudentView(ctx context.Context, assessmentID uint, userID string) {
+	userRole, err := s.getUserRole(ctx, userID)
+	if err != nil || userRole != models.RoleStudent {
+		return
+	}
+
+	if err := s.repo.Enrollment().RecordView(ctx, s.db, assessmentID, userID, time.Now()); err != nil {
+		s.logger.Warn("Failed to record assessment view", "assessment_id", assessmentID, "student_id", userID, "error", err)
+	}
+}
+
+// getOwnedAssessment loads an assessment its owning teacher or an admin manages
+func (s *assessmentService) getOwnedAssessment(ctx context.Context, assessmentID uint, userID, action string) (*models.Assessment, error) {
+	assessment, err := s.repo.Assessment().GetByID(ctx, s.db, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	userRole, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if userRole != models.RoleAdmin && (userRole != models.RoleTeacher || assessment.CreatedBy != userID) {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", action, "not owner or insufficient permissions")
+	}
+
+	return assessment, nil
+}
+
+// buildAccessAudit combines enrollments, detail views and attempts into one record per student.
+// Students who viewed or attempted without an enrollment are listed too, marked as not enrolled.
+func buildAccessAudit(enrolledIDs []string, views []*models.AssessmentView, activity []repositories.StudentAttemptActivity) *AssessmentAccessAudit {
+	records := make(map[string]*StudentAccessRecord)
+	record := func(studentID string) *StudentAccessRecord {
+		r, ok := records[studentID]
+		if !ok {
+			r = &StudentAccessRecord{StudentID: studentID, Status: AccessNotAccessed}
+			records[studentID] = r
+		}
+		return r
+	}
+
+	for _, studentID := range enrolledIDs {
+		record(studentID).Enrolled = true
+	}
+
+	for _, view := range views {
+		r := record(view.StudentID)
+		r.ViewCount = view.ViewCount
+		r.FirstViewedAt = timePtr(view.FirstViewedAt)
+		r.LastViewedAt = timePtr(view.LastViewedAt)
+		r.Status = AccessViewed
+	}
+
+	for _, a := range activity {
+		r := record(a.StudentID)
+		r.AttemptCount = a.AttemptCount
+		r.FirstStartedAt = a.FirstStartedAt
+		r.LastActivityAt = a.LastActivityAt
+		r.Status = AccessStarted
+		if a.Submitted {
+			r.Status = AccessSubmitted
+		}
+	}
+
+	audit := &AssessmentAccessAudit{
+		Students: make([]StudentAccessRecord, 0, len(records)),
+	}
+	for _, r := range records {
+		if r.Enrolled {
+			audit.EnrolledCount++
+		}
+		switch r.Status {
+		case AccessNotAccessed:
+			audit.NotAccessedCount++
+		case AccessViewed:
+			audit.ViewedCount++
+		case AccessStarted:
+			audit.StartedCount++
+		case AccessSubmitted:
+			audit.SubmittedCount++
+		}
+		audit.Students = append(audit.Students, *r)
+	}
+
+	sort.Slice(audit.Students, func(i, j int) bool {
+		a, b := audit.Students[i], audit.Students[j]
+		if accessStatusOrder[a.Status] != accessStatusOrder[b.Status] {
+			return accessStatusOrder[a.Status] < accessStatusOrder[b.Status]
+		}
+		return a.StudentID < b.StudentID
+	})
+
+	return audit
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestBuildAccessAudit(t *testing.T) {
+	now := time.Now()
+	enrolled := []string{"s-absent", "s-viewer", "s-started", "s-done"}
+	views := []*models.AssessmentView{
+		{StudentID: "s-viewer", ViewCount: 2, FirstViewedAt: now.Add(-time.Hour), LastViewedAt: now},
+		{StudentID: "s-started", ViewCount: 1, FirstViewedAt: now, LastViewedAt: now},
+	}
+	activity := []repositories.StudentAttemptActivity{
+		{StudentID: "s-started", AttemptCount: 1, FirstStartedAt: &now, LastActivityAt: &now},
+		{StudentID: "s-done", AttemptCount: 2, FirstStartedAt: &now, LastActivityAt: &now, Submitted: true},
+		{StudentID: "s-walkin", AttemptCount: 1, FirstStartedAt: &now, LastActivityAt: &now},
+	}
+
+	audit := buildAccessAudit(enrolled, views, activity)
+
+	if audit.EnrolledCount != 4 {
+		t.Errorf("expected 4 enrolled, got %d", audit.EnrolledCount)
+	}
+	if audit.NotAccessedCount != 1 || audit.ViewedCount != 1 || audit.StartedCount != 2 || audit.SubmittedCount != 1 {
+		t.Errorf("unexpected counts: %+v", audit)
+	}
+
+	want := []struct {
+		id     string
+		status StudentAccessStatus
+	}{
+		{"s-absent", AccessNotAccessed},
+		{"s-viewer", AccessViewed},
+		{"s-started", AccessStarted},
+		{"s-walkin", AccessStarted},
+		{"s-done", AccessSubmitted},
+	}
+	if len(audit.Students) != len(want) {
+		t.Fatalf("expected %d students, got %+v", len(want), audit.Students)
+	}
+	for i, w := range want {
+		if got := audit.Students[i]; got.StudentID != w.id || got.Status != w.status {
+			t.Errorf("position %d: expected %s/%s, got %s/%s", i, w.id, w.status, got.StudentID, got.Status)
+		}
+	}
+
+	if walkin := audit.Students[3]; walkin.Enrolled {
+		t.Errorf("student without enrollment should be flagged, got %+v", walkin)
+	}
+	if viewer := audit.Students[1]; viewer.ViewCount != 2 || viewer.FirstViewedAt == nil {
+		t.Errorf("view details should be carried over, got %+v", viewer)
+	}
+}
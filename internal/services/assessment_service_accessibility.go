@@ -0,0 +1,300 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// Tables beyond this size are hard to follow with a screen reader or on a narrow screen
+const (
+	accessibleTableMaxRows    = 10
+	accessibleTableMaxColumns = 5
+)
+
+const (
+	wcagNonTextContent       = "WCAG 1.1.1 Non-text Content"
+	wcagInfoRelationships    = "WCAG 1.3.1 Info and Relationships"
+	wcagUseOfColor           = "WCAG 1.4.1 Use of Color"
+	wcagTimingAdjustable     = "WCAG 2.2.1 Timing Adjustable"
+	accessibilityColorPhrase = `red|green|blue|yellow|orange|purple|pink`
+)
+
+var (
+	htmlImagePattern     = regexp.MustCompile(`(?i)<img\b[^>]*>`)
+	htmlAltPattern       = regexp.MustCompile(`(?i)\balt\s*=`)
+	markdownNoAltPattern = regexp.MustCompile(`!\[\s*\]\(`)
+	htmlTagPattern       = regexp.MustCompile(`<[^>]*>`)
+	htmlTablePattern     = regexp.MustCompile(`(?is)<table\b.*?</table>`)
+	htmlRowPattern       = regexp.MustCompile(`(?i)<tr\b`)
+	htmlCellPattern      = regexp.MustCompile(`(?i)<t[dh]\b`)
+	markdownRowPattern   = regexp.MustCompile(`^\s*\|.*\|\s*$`)
+	markdownRulePattern  = regexp.MustCompile(`^\s*\|?[\s:|-]*-{3,}[\s:|-]*$`)
+
+	// Instructions such as "choose the red option" or "the word shown in green"
+	colorCuePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\b(?:` + accessibilityColorPhrase + `)\s+(?:option|answer|choice|item|box|word|text|one)s?\b`),
+		regexp.MustCompile(`(?i)\b(?:shown|marked|highlighted|printed|written|colou?red)\s+in\s+(?:` + accessibilityColorPhrase + `)\b`),
+	}
+)
+
+// ===== ACCESSIBILITY CHECKS =====
+
+// accessibilityWarnings flags content students using assistive technology or needing more
+// time may struggle with. Questions are checked in assessment order.
+func accessibilityWarnings(assessment *models.Assessment, settings *models.AssessmentSettings, questions []*models.Question) []ReadinessIssue {
+	warnings := make([]ReadinessIssue, 0)
+
+	if issue := timeAccommodationWarning(assessment, settings, questions); issue != nil {
+		warnings = append(warnings, *issue)
+	}
+
+	for i, question := range questions {
+		number := i + 1
+		texts := questionTexts(question)
+		options := questionOptionGroups(question.Type, question.Content)
+
+		if missing := countImagesMissingAlt(texts, options, question.Attachments); missing > 0 {
+			warnings = append(warnings, questionIssue(question, "A11Y-IMAGE-ALT-MISSING", wcagNonTextContent,
+				fmt.Sprintf("Question %d has %d image(s) without alternative text; describe each image so screen reader users get the same information", number, missing)))
+		}
+
+		if hasColorOnlyDistinction(texts, options) {
+			warnings = append(warnings, questionIssue(question, "A11Y-COLOR-ONLY", wcagUseOfColor,
+				fmt.Sprintf("Question %d tells options apart by colour alone; add text, labels or symbols that do not rely on colour", number)))
+		}
+
+		if rows, columns := largestTable(texts); rows > accessibleTableMaxRows || columns > accessibleTableMaxColumns {
+			warnings = append(warnings, questionIssue(question, "A11Y-LARGE-TABLE", wcagInfoRelationships,
+				fmt.Sprintf("Question %d contains a table of %d rows by %d columns; split it or summarise it, and mark up header cells so it can be navigated with a screen reader", number, rows, columns)))
+		}
+	}
+
+	return warnings
+}
+
+// timeAccommodationWarning flags enforced time limits when no extra time is set up for
+// students with a timing accommodation
+func timeAccommodationWarning(assessment *models.Assessment, settings *models.AssessmentSettings, questions []*models.Question) *ReadinessIssue {
+	if settings == nil || !settings.TimeLimitEnforced || settings.AccommodationExtraTime > 0 {
+		return nil
+	}
+
+	timed := assessment.Duration > 0
+	for _, question := range questions {
+		if question.TimeLimit != nil && *question.TimeLimit > 0 {
+			timed = true
+		}
+	}
+	if !timed {
+		return nil
+	}
+
+	return &ReadinessIssue{
+		Code:      "A11Y-TIME-NO-ACCOMMODATION",
+		Message:   "Time limits are enforced but no accommodation extra time is set; set accommodation_extra_time so students who need more time can be granted it in one step",
+		Guideline: wcagTimingAdjustable,
+	}
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func questionIssue(question *models.Question, code, guideline, message string) ReadinessIssue {
+	questionID := question.ID
+	return ReadinessIssue{
+		Code:       code,
+		Message:    message,
+		QuestionID: &questionID,
+		Guideline:  guideline,
+	}
+}
+
+// questionTexts returns the question text, explanation and every string in its content
+func questionTexts(question *models.Question) []string {
+	texts := []string{question.Text}
+	if question.Explanation != nil {
+		texts = append(texts, *question.Explanation)
+	}
+
+	var content interface{}
+	if len(question.Content) > 0 && json.Unmarshal(question.Content, &content) == nil {
+		texts = collectStrings(content, texts)
+	}
+	return texts
+}
+
+func collectStrings(value interface{}, into []string) []string {
+	switch v := value.(type) {
+	case string:
+		into = append(into, v)
+	case []interface{}:
+		for _, item := range v {
+			into = collectStrings(item, into)
+		}
+	case map[string]interface{}:
+		for _, item := range v {
+			into = collectStrings(item, into)
+		}
+	}
+	return into
+}
+
+// optionGroup is a set of choices shown side by side, such as the options of a
+// multiple choice question or one column of a matching question
+type optionGroup struct {
+	texts     []string
+	imageOnly int // Options shown as an image with no text
+}
+
+func questionOptionGroups(questionType models.QuestionType, content []byte) []optionGroup {
+	switch questionType {
+	case models.MultipleChoice:
+		var c models.MultipleChoiceContent
+		if json.Unmarshal(content, &c) != nil {
+			return nil
+		}
+		group := optionGroup{}
+		for _, option := range c.Options {
+			group.add(option.Text, option.ImageURL)
+		}
+		return []optionGroup{group}
+	case models.Matching:
+		var c models.MatchingContent
+		if json.Unmarshal(content, &c) != nil {
+			return nil
+		}
+		left, right := optionGroup{}, optionGroup{}
+		for _, item := range c.LeftItems {
+			left.add(item.Text, item.ImageURL)
+		}
+		for _, item := range c.RightItems {
+			right.add(item.Text, item.ImageURL)
+		}
+		return []optionGroup{left, right}
+	case models.Ordering:
+		var c models.OrderingContent
+		if json.Unmarshal(content, &c) != nil {
+			return nil
+		}
+		group := optionGroup{}
+		for _, item := range c.Items {
+			group.add(item.Text, item.ImageURL)
+		}
+		return []optionGroup{group}
+	case models.MultiPart:
+		var c models.MultiPartContent
+		if json.Unmarshal(content, &c) != nil {
+			return nil
+		}
+		var groups []optionGroup
+		for _, part := range c.Parts {
+			groups = append(groups, questionOptionGroups(part.Type, part.Content)...)
+		}
+		return groups
+	default:
+		return nil
+	}
+}
+
+func (g *optionGroup) add(text string, imageURL *string) {
+	if imageURL != nil && *imageURL != "" && visibleText(text) == "" {
+		g.imageOnly++
+		return
+	}
+	g.texts = append(g.texts, text)
+}
+
+// countImagesMissingAlt counts image attachments without alt text, inline images without an
+// alt attribute or with an empty Markdown description, and options shown only as an image
+func countImagesMissingAlt(texts []string, options []optionGroup, attachments []models.QuestionAttachment) int {
+	missing := 0
+	for _, attachment := range attachments {
+		if strings.HasPrefix(attachment.MimeType, "image/") && (attachment.Alt == nil || strings.TrimSpace(*attachment.Alt) == "") {
+			missing++
+		}
+	}
+	for _, text := range texts {
+		for _, tag := range htmlImagePattern.FindAllString(text, -1) {
+			if !htmlAltPattern.MatchString(tag) {
+				missing++
+			}
+		}
+		missing += len(markdownNoAltPattern.FindAllStringIndex(text, -1))
+	}
+	for _, group := range options {
+		missing += group.imageOnly
+	}
+	return missing
+}
+
+// hasColorOnlyDistinction reports colour-based instructions, and options that only differ
+// in their styling once markup is removed
+func hasColorOnlyDistinction(texts []string, options []optionGroup) bool {
+	for _, text := range texts {
+		for _, pattern := range colorCuePatterns {
+			if pattern.MatchString(visibleText(text)) {
+				return true
+			}
+		}
+	}
+
+	for _, group := range options {
+		seen := make(map[string]string, len(group.texts))
+		for _, text := range group.texts {
+			visible := strings.ToLower(visibleText(text))
+			if previous, ok := seen[visible]; ok && previous != text {
+				return true
+			}
+			seen[visible] = text
+		}
+	}
+	return false
+}
+
+// largestTable returns the size of the biggest HTML or Markdown table, by rows and then columns
+func largestTable(texts []string) (rows, columns int) {
+	consider := func(r, c int) {
+		if r > rows || (r == rows && c > columns) {
+			rows, columns = r, c
+		}
+	}
+
+	for _, text := range texts {
+		for _, table := range htmlTablePattern.FindAllString(text, -1) {
+			tableRows := htmlRowPattern.Split(table, -1)[1:]
+			widest := 0
+			for _, row := range tableRows {
+				if cells := len(htmlCellPattern.FindAllStringIndex(row, -1)); cells > widest {
+					widest = cells
+				}
+			}
+			consider(len(tableRows), widest)
+		}
+
+		tableRows, widest := 0, 0
+		for _, line := range strings.Split(text, "\n") {
+			if !markdownRowPattern.MatchString(line) {
+				consider(tableRows, widest)
+				tableRows, widest = 0, 0
+				continue
+			}
+			if markdownRulePattern.MatchString(line) {
+				continue
+			}
+			tableRows++
+			if cells := strings.Count(strings.TrimSpace(line), "|") - 1; cells > widest {
+				widest = cells
+			}
+		}
+		consider(tableRows, widest)
+	}
+	return rows, columns
+}
+
+// visibleText strips markup, leaving the text a reader sees
+func visibleText(text string) string {
+	return strings.TrimSpace(htmlTagPattern.ReplaceAllString(text, ""))
+}
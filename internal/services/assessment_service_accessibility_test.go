@@ -0,0 +1,112 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func accessibilityCodes(issues []ReadinessIssue) map[string]int {
+	codes := make(map[string]int)
+	for _, issue := range issues {
+		codes[issue.Code]++
+	}
+	return codes
+}
+
+func TestAccessibilityWarningsImagesAndColor(t *testing.T) {
+	alt := "Bar chart of rainfall"
+	questions := []*models.Question{
+		{
+			ID:      1,
+			Type:    models.MultipleChoice,
+			Text:    `Which month is wettest? <img src="chart.png"> ![](map.png)`,
+			Content: []byte(`{"options":[{"id":"a","text":"","image_url":"a.png"},{"id":"b","text":"June"}]}`),
+			Attachments: []models.QuestionAttachment{
+				{MimeType: "image/png"},
+				{MimeType: "image/png", Alt: &alt},
+				{MimeType: "application/pdf"},
+			},
+		},
+		{
+			ID:      2,
+			Type:    models.MultipleChoice,
+			Text:    "Pick the correct word",
+			Content: []byte(`{"options":[{"id":"a","text":"<span style=\"color:red\">affect</span>"},{"id":"b","text":"<span style=\"color:green\">affect</span>"}]}`),
+		},
+		{
+			ID:   3,
+			Type: models.TrueFalse,
+			Text: `The word shown in green is a verb. <img src="x.png" alt="">`,
+		},
+	}
+
+	warnings := accessibilityWarnings(&models.Assessment{}, nil, questions)
+	if len(warnings) != 3 {
+		t.Fatalf("expected 3 warnings, got %+v", warnings)
+	}
+	if w := warnings[0]; w.Code != "A11Y-IMAGE-ALT-MISSING" || *w.QuestionID != 1 || w.Guideline != wcagNonTextContent {
+		t.Errorf("unexpected image warning: %+v", w)
+	}
+	if countImagesMissingAlt(questionTexts(questions[0]), questionOptionGroups(questions[0].Type, questions[0].Content), questions[0].Attachments) != 4 {
+		t.Error("expected the attachment, inline image, Markdown image and image-only option to be counted")
+	}
+	if w := warnings[1]; w.Code != "A11Y-COLOR-ONLY" || *w.QuestionID != 2 {
+		t.Errorf("options differing only in colour should be flagged: %+v", w)
+	}
+	if w := warnings[2]; w.Code != "A11Y-COLOR-ONLY" || *w.QuestionID != 3 {
+		t.Errorf("colour instruction should be flagged: %+v", w)
+	}
+}
+
+func TestAccessibilityWarningsLargeTables(t *testing.T) {
+	row := "<tr><td>1</td><td>2</td></tr>"
+	html := "<table>"
+	for i := 0; i < accessibleTableMaxRows+1; i++ {
+		html += row
+	}
+	html += "</table>"
+
+	markdown := "| a | b | c | d | e | f |\n|---|---|---|---|---|---|\n| 1 | 2 | 3 | 4 | 5 | 6 |"
+	small := "| a | b |\n|---|---|\n| 1 | 2 |"
+
+	if rows, columns := largestTable([]string{html}); rows != 11 || columns != 2 {
+		t.Errorf("html table measured as %dx%d", rows, columns)
+	}
+	if rows, columns := largestTable([]string{markdown}); rows != 2 || columns != 6 {
+		t.Errorf("markdown table measured as %dx%d", rows, columns)
+	}
+
+	questions := []*models.Question{
+		{ID: 1, Type: models.Essay, Text: html},
+		{ID: 2, Type: models.Essay, Text: markdown},
+		{ID: 3, Type: models.Essay, Text: small},
+	}
+	codes := accessibilityCodes(accessibilityWarnings(&models.Assessment{}, nil, questions))
+	if codes["A11Y-LARGE-TABLE"] != 2 {
+		t.Errorf("expected two large tables flagged, got %v", codes)
+	}
+}
+
+func TestAccessibilityWarningsTimeAccommodation(t *testing.T) {
+	limit := 60
+	cases := []struct {
+		name       string
+		assessment *models.Assessment
+		settings   *models.AssessmentSettings
+		questions  []*models.Question
+		want       bool
+	}{
+		{"timed without default", &models.Assessment{Duration: 30}, &models.AssessmentSettings{TimeLimitEnforced: true}, nil, true},
+		{"timed with default", &models.Assessment{Duration: 30}, &models.AssessmentSettings{TimeLimitEnforced: true, AccommodationExtraTime: 50}, nil, false},
+		{"limit not enforced", &models.Assessment{Duration: 30}, &models.AssessmentSettings{}, nil, false},
+		{"question time limit", &models.Assessment{}, &models.AssessmentSettings{TimeLimitEnforced: true}, []*models.Question{{TimeLimit: &limit}}, true},
+		{"untimed", &models.Assessment{}, &models.AssessmentSettings{TimeLimitEnforced: true}, nil, false},
+	}
+	for _, c := range cases {
+		got := timeAccommodationWarning(c.assessment, c.settings, c.questions) != nil
+		if got != c.want {
+			t.Errorf("%s: warning = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
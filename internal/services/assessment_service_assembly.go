@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// ExamPolicy is the organization's guidance on how assessments are assembled. Going
+// against it produces warnings when questions are added, never errors.
+type ExamPolicy struct {
+	// Percentage of questions one skill tag may cover; zero disables the check
+	MaxSkillTagPercent float64
+	// Question texts at least this similar (0-1) are near-duplicates; zero disables the check
+	NearDuplicateSimilarity float64
+	// Target percentage of questions per difficulty; empty disables the check
+	DifficultyMix map[models.DifficultyLevel]float64
+	// Percentage points a difficulty may stray from its target
+	DifficultyTolerance float64
+	// Skill tag and difficulty shares are only checked from this many questions on
+	MinQuestions int
+	// Share (0-1) of an assessment's questions another assessment must also contain to be
+	// flagged as a possible duplicate before publishing; zero disables the check
+	DuplicateAssessmentOverlap float64
+}
+
+// DefaultExamPolicy returns the policy used when the organization has not set its own
+func DefaultExamPolicy() ExamPolicy {
+	return ExamPolicy{
+		MaxSkillTagPercent:      50,
+		NearDuplicateSimilarity: 0.8,
+		DifficultyMix:           defaultDifficultyDistribution,
+		DifficultyTolerance:     20,
+		MinQuestions:            5,
+
+		DuplicateAssessmentOverlap: 0.6,
+	}
+}
+
+// ===== ASSEMBLY CHECKS =====
+
+// checkAssembly compares the assessment's questions with the exam policy
+func (s *assessmentService) checkAssembly(ctx context.Context, assessmentID uint) (*AssemblyCheck, error) {
+	questions, err := s.repo.AssessmentQuestion().GetQuestionsForAssessment(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+
+	check := &AssemblyCheck{
+		AssessmentID:  assessmentID,
+		QuestionCount: len(questions),
+		Warnings:      assemblyWarnings(questions, s.examPolicy),
+	}
+	if len(check.Warnings) > 0 {
+		s.logger.Info("Assessment assembly goes against exam policy",
+			"assessment_id", assessmentID,
+			"warnings", len(check.Warnings))
+	}
+	return check, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func assemblyWarnings(questions []*models.Question, policy ExamPolicy) []AssemblyWarning {
+	warnings := make([]AssemblyWarning, 0)
+	warnings = append(warnings, skillConcentrationWarnings(questions, policy)...)
+	warnings = append(warnings, nearDuplicateWarnings(questions, policy)...)
+	warnings = append(warnings, difficultyMixWarnings(questions, policy)...)
+	return warnings
+}
+
+// skillConcentrationWarnings flags skill tags shared by more than the allowed share of questions
+func skillConcentrationWarnings(questions []*models.Question, policy ExamPolicy) []AssemblyWarning {
+	if policy.MaxSkillTagPercent <= 0 || len(questions) == 0 || len(questions) < policy.MinQuestions {
+		return nil
+	}
+
+	type skillGroup struct {
+		tag         string
+		questionIDs []uint
+	}
+	groups := make(map[string]*skillGroup)
+	var order []string
+	for _, question := range questions {
+		for _, skill := range questionSkills(question) {
+			key := strings.ToLower(skill)
+			if groups[key] == nil {
+				groups[key] = &skillGroup{tag: skill}
+				order = append(order, key)
+			}
+			groups[key].questionIDs = append(groups[key].questionIDs, question.ID)
+		}
+	}
+
+	var warnings []AssemblyWarning
+	for _, key := range order {
+		group := groups[key]
+		percent := roundPercent(float64(len(group.questionIDs)) / float64(len(questions)) * 100)
+		if percent <= policy.MaxSkillTagPercent {
+			continue
+		}
+		limit := policy.MaxSkillTagPercent
+		warnings = append(warnings, AssemblyWarning{
+			Code: "QT-ASSEMBLY-SKILL-CONCENTRATION",
+			Message: fmt.Sprintf("%d of %d questions (%.0f%%) are tagged %q; the exam policy allows at most %.0f%%",
+				len(group.questionIDs), len(questions), percent, group.tag, limit),
+			QuestionIDs: group.questionIDs,
+			SkillTag:    group.tag,
+			Percent:     &percent,
+			Expected:    &limit,
+		})
+	}
+	return warnings
+}
+
+// nearDuplicateWarnings flags pairs of questions whose texts are nearly the same
+func nearDuplicateWarnings(questions []*models.Question, policy ExamPolicy) []AssemblyWarning {
+	if policy.NearDuplicateSimilarity <= 0 {
+		return nil
+	}
+
+	shingles := make([]map[string]bool, len(questions))
+	for i, question := range questions {
+		shingles[i] = textShingles(question.Text)
+	}
+
+	var warnings []AssemblyWarning
+	for i := range questions {
+		for j := i + 1; j < len(questions); j++ {
+			similarity := shingleSimilarity(shingles[i], shingles[j])
+			if similarity < policy.NearDuplicateSimilarity {
+				continue
+			}
+			similarity = math.Round(similarity*100) / 100
+			warnings = append(warnings, AssemblyWarning{
+				Code: "QT-ASSEMBLY-NEAR-DUPLICATE",
+				Message: fmt.Sprintf("questions %d and %d are near-duplicates (%.0f%% similar)",
+					questions[i].ID, questions[j].ID, similarity*100),
+				QuestionIDs: []uint{questions[i].ID, questions[j].ID},
+				Similarity:  &similarity,
+			})
+		}
+	}
+	return warnings
+}
+
+// difficultyMixWarnings flags difficulties whose share of questions strays from the policy mix
+func difficultyMixWarnings(questions []*models.Question, policy ExamPolicy) []AssemblyWarning {
+	if len(policy.DifficultyMix) == 0 || len(questions) == 0 || len(questions) < policy.MinQuestions {
+		return nil
+	}
+
+	target := normalizeDifficultyDistribution(policy.DifficultyMix)
+	byDifficulty := make(map[models.DifficultyLevel][]uint)
+	for _, question := range questions {
+		difficulty := question.Difficulty
+		if difficulty == "" {
+			difficulty = models.DifficultyMedium
+		}
+		byDifficulty[difficulty] = append(byDifficulty[difficulty], question.ID)
+	}
+
+	var warnings []AssemblyWarning
+	for _, level := range []models.DifficultyLevel{models.DifficultyEasy, models.DifficultyMedium, models.DifficultyHard} {
+		percent := roundPercent(float64(len(byDifficulty[level])) / float64(len(questions)) * 100)
+		expected := roundPercent(target[level])
+		if math.Abs(percent-expected) <= policy.DifficultyTolerance {
+			continue
+		}
+		warnings = append(warnings, AssemblyWarning{
+			Code: "QT-ASSEMBLY-DIFFICULTY-MIX",
+			Message: fmt.Sprintf("%.0f%% of questions are %s; the exam policy targets %.0f%%",
+				percent, level, expected),
+			QuestionIDs: byDifficulty[level],
+			Difficulty:  level,
+			Percent:     &percent,
+			Expected:    &expected,
+		})
+	}
+	return warnings
+}
+
+func roundPercent(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func assemblyQuestion(id uint, text string, difficulty models.DifficultyLevel, tags ...string) *models.Question {
+	raw, _ := json.Marshal(tags)
+	return &models.Question{ID: id, Text: text, Difficulty: difficulty, Tags: raw}
+}
+
+func warningCodes(warnings []AssemblyWarning) []string {
+	codes := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		codes = append(codes, warning.Code)
+	}
+	return codes
+}
+
+func TestAssemblyWarningsBalancedAssessment(t *testing.T) {
+	questions := []*models.Question{
+		assemblyQuestion(1, "What is the derivative of x squared?", models.DifficultyEasy, "calculus"),
+		assemblyQuestion(2, "Name the capital of France.", models.DifficultyMedium, "geography"),
+		assemblyQuestion(3, "Explain the causes of the French Revolution.", models.DifficultyMedium, "history"),
+		assemblyQuestion(4, "Balance this chemical equation for combustion.", models.DifficultyHard, "chemistry"),
+		assemblyQuestion(5, "Which planet is closest to the sun?", models.DifficultyEasy, "astronomy"),
+		assemblyQuestion(6, "Summarize the plot of Hamlet in three sentences.", models.DifficultyMedium, "literature"),
+	}
+	if warnings := assemblyWarnings(questions, DefaultExamPolicy()); len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warningCodes(warnings))
+	}
+}
+
+func TestSkillConcentrationWarnings(t *testing.T) {
+	questions := []*models.Question{
+		assemblyQuestion(1, "a", models.DifficultyEasy, "Algebra"),
+		assemblyQuestion(2, "b", models.DifficultyEasy, "algebra", "graphs"),
+		assemblyQuestion(3, "c", models.DifficultyEasy, "ALGEBRA"),
+		assemblyQuestion(4, "d", models.DifficultyEasy, "geometry"),
+		assemblyQuestion(5, "e", models.DifficultyEasy),
+	}
+
+	warnings := skillConcentrationWarnings(questions, DefaultExamPolicy())
+	if len(warnings) != 1 || warnings[0].SkillTag != "Algebra" || *warnings[0].Percent != 60 {
+		t.Fatalf("unexpected warnings %+v", warnings)
+	}
+	if !reflect.DeepEqual(warnings[0].QuestionIDs, []uint{1, 2, 3}) {
+		t.Errorf("unexpected question ids %v", warnings[0].QuestionIDs)
+	}
+
+	if warnings := skillConcentrationWarnings(questions[:3], DefaultExamPolicy()); len(warnings) != 0 {
+		t.Errorf("shares of small assessments should not be checked, got %+v", warnings)
+	}
+}
+
+func TestNearDuplicateWarnings(t *testing.T) {
+	questions := []*models.Question{
+		assemblyQuestion(1, "What is the boiling point of water at sea level in degrees Celsius?", models.DifficultyEasy),
+		assemblyQuestion(2, "What is the boiling point of water at sea level, in degrees Celsius", models.DifficultyEasy),
+		assemblyQuestion(3, "What is the freezing point of ethanol?", models.DifficultyEasy),
+	}
+
+	warnings := nearDuplicateWarnings(questions, DefaultExamPolicy())
+	if len(warnings) != 1 || !reflect.DeepEqual(warnings[0].QuestionIDs, []uint{1, 2}) {
+		t.Fatalf("unexpected warnings %+v", warnings)
+	}
+
+	policy := DefaultExamPolicy()
+	policy.NearDuplicateSimilarity = 0
+	if warnings := nearDuplicateWarnings(questions, policy); len(warnings) != 0 {
+		t.Errorf("disabled check should not warn, got %+v", warnings)
+	}
+}
+
+func TestDifficultyMixWarnings(t *testing.T) {
+	questions := []*models.Question{
+		assemblyQuestion(1, "a", models.DifficultyHard),
+		assemblyQuestion(2, "b", models.DifficultyHard),
+		assemblyQuestion(3, "c", models.DifficultyHard),
+		assemblyQuestion(4, "d", models.DifficultyHard),
+		assemblyQuestion(5, "e", ""),
+	}
+
+	warnings := difficultyMixWarnings(questions, DefaultExamPolicy())
+	if got := warningCodes(warnings); len(got) != 3 {
+		t.Fatalf("expected every difficulty to stray from the mix, got %+v", warnings)
+	}
+	if warnings[0].Difficulty != models.DifficultyEasy || *warnings[0].Percent != 0 || *warnings[0].Expected != 30 {
+		t.Errorf("unexpected easy warning %+v", warnings[0])
+	}
+	if warnings[1].Difficulty != models.DifficultyMedium || !reflect.DeepEqual(warnings[1].QuestionIDs, []uint{5}) {
+		t.Errorf("questions without a difficulty should count as medium, got %+v", warnings[1])
+	}
+	if warnings[2].Difficulty != models.DifficultyHard || *warnings[2].Percent != 80 || len(warnings[2].QuestionIDs) != 4 {
+		t.Errorf("unexpected hard warning %+v", warnings[2])
+	}
+}
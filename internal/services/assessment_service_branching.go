@@ -0,0 +1,403 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// branchNode is one question of an assessment with its branching setup, in question order
+type branchNode struct {
+	QuestionID  uint
+	Type        models.QuestionType
+	Options     map[string]bool // Multiple choice option IDs
+	Rules       []models.BranchRule
+	Conditional bool
+}
+
+// ===== BRANCHING RULES =====
+
+// SetQuestionBranching replaces a question's branching rules. The whole assessment is
+// checked so the change cannot leave a rule pointing nowhere or a path that loops.
+func (s *assessmentService) SetQuestionBranching(ctx context.Context, assessmentID, questionID uint, req *SetQuestionBranchingRequest, userID string) (*models.AssessmentQuestion, error) {
+	s.logger.Info("Setting question branching",
+		"assessment_id", assessmentID,
+		"question_id", questionID,
+		"rules", len(req.Rules),
+		"user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	canEdit, err := s.CanEdit(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEdit {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "set_question_branching", "not owner or assessment not editable")
+	}
+
+	link, err := s.repo.AssessmentQuestion().GetQuestionAssessmentByAssessmentIdAndQuestionId(ctx, s.db, assessmentID, questionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment question: %w", err)
+	}
+
+	nodes, err := loadBranchNodes(ctx, s.repo, s.db, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range nodes {
+		if nodes[i].QuestionID == questionID {
+			nodes[i].Rules = req.Rules
+			nodes[i].Conditional = req.Conditional
+		}
+	}
+	if err := validateBranching(nodes); err != nil {
+		return nil, err
+	}
+
+	lock, err := acquireContentLock(ctx, s.repo, userID, "set_question_branching", assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	link.BranchRules = nil
+	if len(req.Rules) > 0 {
+		encoded, err := json.Marshal(req.Rules)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode branching rules: %w", err)
+		}
+		link.BranchRules = datatypes.JSON(encoded)
+	}
+	link.Conditional = req.Conditional
+
+	if err := s.repo.AssessmentQuestion().Update(ctx, s.db, link); err != nil {
+		return nil, fmt.Errorf("failed to update assessment question: %w", err)
+	}
+	if err := lock.release(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Question branching updated",
+		"assessment_id", assessmentID,
+		"question_id", questionID)
+
+	return link, nil
+}
+
+// validateAssessmentBranching re-checks the rules on publish, since removing or reordering
+// questions can break rules that were valid when saved
+func (s *assessmentService) validateAssessmentBranching(ctx context.Context, assessmentID uint) error {
+	nodes, err := loadBranchNodes(ctx, s.repo, s.db, assessmentID)
+	if err != nil {
+		return err
+	}
+	return validateBranching(nodes)
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func loadBranchNodes(ctx context.Context, repo repositories.Repository, db *gorm.DB, assessmentID uint) ([]branchNode, error) {
+	links, err := repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, db, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+	questions, err := repo.AssessmentQuestion().GetQuestionsForAssessment(ctx, db, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+	return buildBranchNodes(links, questions)
+}
+
+func buildBranchNodes(links []*models.AssessmentQuestion, questions []*models.Question) ([]branchNode, error) {
+	byID := make(map[uint]*models.Question, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+
+	nodes := make([]branchNode, 0, len(links))
+	for _, link := range links {
+		node := branchNode{QuestionID: link.QuestionID, Conditional: link.Conditional}
+		if len(link.BranchRules) > 0 {
+			if err := json.Unmarshal(link.BranchRules, &node.Rules); err != nil {
+				return nil, fmt.Errorf("invalid branching rules on question %d: %w", link.QuestionID, err)
+			}
+		}
+		if question, ok := byID[link.QuestionID]; ok {
+			node.Type = question.Type
+			if question.Type == models.MultipleChoice {
+				var content models.MultipleChoiceContent
+				if json.Unmarshal(question.Content, &content) == nil {
+					node.Options = make(map[string]bool, len(content.Options))
+					for _, option := range content.Options {
+						node.Options[option.ID] = true
+					}
+				}
+			}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+func hasBranching(nodes []branchNode) bool {
+	for _, node := range nodes {
+		if len(node.Rules) > 0 || node.Conditional {
+			return true
+		}
+	}
+	return false
+}
+
+// validateBranching checks that every rule can match, points at a question of the
+// assessment, and that no answer can send a student round in a loop
+func validateBranching(nodes []branchNode) error {
+	index := make(map[uint]int, len(nodes))
+	for i, node := range nodes {
+		index[node.QuestionID] = i
+	}
+
+	unlockable := make(map[uint]bool)
+	for _, node := range nodes {
+		for _, rule := range node.Rules {
+			if err := validateBranchRule(node, rule, index, nodes); err != nil {
+				return err
+			}
+			for _, id := range rule.Unlock {
+				unlockable[id] = true
+			}
+			if rule.GoTo != nil {
+				unlockable[*rule.GoTo] = true
+			}
+		}
+	}
+
+	for _, node := range nodes {
+		if node.Conditional && !unlockable[node.QuestionID] {
+			return branchingError(node.QuestionID, "conditional question is never unlocked by a rule, so no student would see it")
+		}
+	}
+
+	if cycle := findBranchCycle(nodes, index); cycle != nil {
+		return NewBusinessRuleError("QT-BRANCHING-CYCLE", "Branching rules create a loop between questions", map[string]interface{}{
+			"question_ids": cycle,
+		})
+	}
+	return nil
+}
+
+func validateBranchRule(node branchNode, rule models.BranchRule, index map[uint]int, nodes []branchNode) error {
+	switch node.Type {
+	case models.MultipleChoice:
+		if rule.OptionID == "" || rule.Value != nil {
+			return branchingError(node.QuestionID, "rules on multiple choice questions must match an option_id")
+		}
+		if !node.Options[rule.OptionID] {
+			return branchingError(node.QuestionID, fmt.Sprintf("option %q does not exist", rule.OptionID))
+		}
+	case models.TrueFalse:
+		if rule.Value == nil || rule.OptionID != "" {
+			return branchingError(node.QuestionID, "rules on true/false questions must match a value")
+		}
+	default:
+		return branchingError(node.QuestionID, "only multiple choice and true/false questions can branch")
+	}
+
+	if rule.GoTo == nil && len(rule.Unlock) == 0 {
+		return branchingError(node.QuestionID, "a rule must set go_to or unlock")
+	}
+	if rule.GoTo != nil {
+		if _, ok := index[*rule.GoTo]; !ok {
+			return branchingError(node.QuestionID, fmt.Sprintf("go_to question %d is not part of the assessment", *rule.GoTo))
+		}
+		if *rule.GoTo == node.QuestionID {
+			return branchingError(node.QuestionID, "a question cannot branch to itself")
+		}
+	}
+	for _, id := range rule.Unlock {
+		target, ok := index[id]
+		if !ok {
+			return branchingError(node.QuestionID, fmt.Sprintf("unlocked question %d is not part of the assessment", id))
+		}
+		if !nodes[target].Conditional {
+			return branchingError(node.QuestionID, fmt.Sprintf("unlocked question %d is not conditional", id))
+		}
+	}
+	return nil
+}
+
+// findBranchCycle walks every possible path: each question leads to the next one in order
+// and to its rules' go_to targets. It returns the questions of a loop, if there is one.
+func findBranchCycle(nodes []branchNode, index map[uint]int) []uint {
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make([]int, len(nodes))
+	path := make([]int, 0, len(nodes))
+
+	var visit func(i int) []uint
+	visit = func(i int) []uint {
+		state[i] = onPath
+		path = append(path, i)
+
+		next := make([]int, 0, len(nodes[i].Rules)+1)
+		if i+1 < len(nodes) {
+			next = append(next, i+1)
+		}
+		for _, rule := range nodes[i].Rules {
+			if rule.GoTo != nil {
+				next = append(next, index[*rule.GoTo])
+			}
+		}
+
+		for _, j := range next {
+			switch state[j] {
+			case onPath:
+				cycle := make([]uint, 0)
+				for k := len(path) - 1; k >= 0; k-- {
+					cycle = append([]uint{nodes[path[k]].QuestionID}, cycle...)
+					if path[k] == j {
+						break
+					}
+				}
+				return cycle
+			case unvisited:
+				if cycle := visit(j); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[i] = done
+		return nil
+	}
+
+	for i := range nodes {
+		if state[i] == unvisited {
+			if cycle := visit(i); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// resolveRoute lists the questions a student sees, in order, given their answers so far.
+// Questions after an unanswered one follow the default order.
+func resolveRoute(nodes []branchNode, answers map[uint][]byte) []uint {
+	index := make(map[uint]int, len(nodes))
+	for i, node := range nodes {
+		index[node.QuestionID] = i
+	}
+
+	route := make([]uint, 0, len(nodes))
+	unlocked := make(map[uint]bool)
+	visited := make(map[uint]bool)
+	for i := 0; i < len(nodes); {
+		node := nodes[i]
+		if visited[node.QuestionID] {
+			break // Rules saved before validation could loop
+		}
+		if node.Conditional && !unlocked[node.QuestionID] {
+			i++
+			continue
+		}
+		visited[node.QuestionID] = true
+		route = append(route, node.QuestionID)
+
+		next := i + 1
+		if rule := matchBranchRule(node, answers[node.QuestionID]); rule != nil {
+			for _, id := range rule.Unlock {
+				unlocked[id] = true
+			}
+			if rule.GoTo != nil {
+				if j, ok := index[*rule.GoTo]; ok {
+					unlocked[*rule.GoTo] = true
+					next = j
+				}
+			}
+		}
+		i = next
+	}
+	return route
+}
+
+// matchBranchRule returns the first rule the answer satisfies
+func matchBranchRule(node branchNode, answer []byte) *models.BranchRule {
+	if len(node.Rules) == 0 || !isAnswerGiven(answer) {
+		return nil
+	}
+
+	switch node.Type {
+	case models.MultipleChoice:
+		selected := make(map[string]bool)
+		for _, id := range selectedOptions(answer) {
+			selected[id] = true
+		}
+		for i := range node.Rules {
+			if selected[node.Rules[i].OptionID] {
+				return &node.Rules[i]
+			}
+		}
+	case models.TrueFalse:
+		value, ok := trueFalseValue(answer)
+		if !ok {
+			return nil
+		}
+		for i := range node.Rules {
+			if node.Rules[i].Value != nil && *node.Rules[i].Value == value {
+				return &node.Rules[i]
+			}
+		}
+	}
+	return nil
+}
+
+// selectedOptions reads a multiple choice answer given as a list of option IDs, a single
+// option ID or an object with selected_options
+func selectedOptions(answer []byte) []string {
+	var list []string
+	if json.Unmarshal(answer, &list) == nil {
+		return list
+	}
+	var single string
+	if json.Unmarshal(answer, &single) == nil {
+		return []string{single}
+	}
+	var typed models.MultipleChoiceAnswer
+	if json.Unmarshal(answer, &typed) == nil {
+		return typed.SelectedOptions
+	}
+	return nil
+}
+
+// trueFalseValue reads a true/false answer given as a bare value or an object with answer
+func trueFalseValue(answer []byte) (bool, bool) {
+	var value bool
+	if json.Unmarshal(answer, &value) == nil {
+		return value, true
+	}
+	var typed models.TrueFalseAnswer
+	if json.Unmarshal(answer, &typed) == nil {
+		return typed.Answer, true
+	}
+	return false, false
+}
+
+func branchingError(questionID uint, message string) error {
+	return NewBusinessRuleError("QT-BRANCHING-INVALID", message, map[string]interface{}{
+		"question_id": questionID,
+	})
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func uintPtr(v uint) *uint { return &v }
+
+func boolPtr(v bool) *bool { return &v }
+
+// branchingFixture: Q1 (MC) option b jumps to Q4, Q2 (TF) false unlocks Q5
+func branchingFixture() []branchNode {
+	return []branchNode{
+		{QuestionID: 1, Type: models.MultipleChoice, Options: map[string]bool{"a": true, "b": true},
+			Rules: []models.BranchRule{{OptionID: "b", GoTo: uintPtr(4)}}},
+		{QuestionID: 2, Type: models.TrueFalse,
+			Rules: []models.BranchRule{{Value: boolPtr(false), Unlock: []uint{5}}}},
+		{QuestionID: 3, Type: models.Essay},
+		{QuestionID: 4, Type: models.ShortAnswer},
+		{QuestionID: 5, Type: models.Essay, Conditional: true},
+	}
+}
+
+func TestValidateBranching(t *testing.T) {
+	if err := validateBranching(branchingFixture()); err != nil {
+		t.Fatalf("valid rules rejected: %v", err)
+	}
+
+	cases := map[string]func(nodes []branchNode){
+		"unknown option":          func(n []branchNode) { n[0].Rules[0].OptionID = "z" },
+		"missing target":          func(n []branchNode) { n[0].Rules[0].GoTo = uintPtr(99) },
+		"self target":             func(n []branchNode) { n[0].Rules[0].GoTo = uintPtr(1) },
+		"no action":               func(n []branchNode) { n[0].Rules[0].GoTo = nil },
+		"value on multiple":       func(n []branchNode) { n[0].Rules[0].Value = boolPtr(true) },
+		"true/false without":      func(n []branchNode) { n[1].Rules[0].Value = nil },
+		"essay rule":              func(n []branchNode) { n[2].Rules = []models.BranchRule{{GoTo: uintPtr(4)}} },
+		"unlock not conditional":  func(n []branchNode) { n[1].Rules[0].Unlock = []uint{3} },
+		"conditional unreachable": func(n []branchNode) { n[3].Conditional = true; n[0].Rules[0].GoTo = uintPtr(3) },
+	}
+	for name, mutate := range cases {
+		nodes := branchingFixture()
+		mutate(nodes)
+		var ruleErr *BusinessRuleError
+		if err := validateBranching(nodes); !errors.As(err, &ruleErr) || ruleErr.Rule != "QT-BRANCHING-INVALID" {
+			t.Errorf("%s: expected invalid branching error, got %v", name, err)
+		}
+	}
+}
+
+func TestValidateBranchingRejectsCycle(t *testing.T) {
+	nodes := branchingFixture()
+	nodes[3].Type = models.TrueFalse
+	nodes[3].Rules = []models.BranchRule{{Value: boolPtr(true), GoTo: uintPtr(2)}}
+
+	var ruleErr *BusinessRuleError
+	if err := validateBranching(nodes); !errors.As(err, &ruleErr) || ruleErr.Rule != "QT-BRANCHING-CYCLE" {
+		t.Fatalf("expected cycle error, got %v", err)
+	}
+	if cycle := ruleErr.Context["question_ids"]; !reflect.DeepEqual(cycle, []uint{2, 3, 4}) {
+		t.Errorf("unexpected cycle %v", cycle)
+	}
+}
+
+func TestResolveRoute(t *testing.T) {
+	nodes := branchingFixture()
+
+	cases := []struct {
+		name    string
+		answers map[uint][]byte
+		want    []uint
+	}{
+		{"no answers", map[uint][]byte{}, []uint{1, 2, 3, 4}},
+		{"option a", map[uint][]byte{1: []byte(`["a"]`)}, []uint{1, 2, 3, 4}},
+		{"option b skips ahead", map[uint][]byte{1: []byte(`["b"]`)}, []uint{1, 4}},
+		{"false unlocks follow-up", map[uint][]byte{2: []byte(`false`)}, []uint{1, 2, 3, 4, 5}},
+		{"typed answers", map[uint][]byte{1: []byte(`{"selected_options":["a"]}`), 2: []byte(`{"answer":false}`)}, []uint{1, 2, 3, 4, 5}},
+		{"empty answer", map[uint][]byte{1: []byte(`[]`)}, []uint{1, 2, 3, 4}},
+	}
+	for _, tc := range cases {
+		if got := resolveRoute(nodes, tc.answers); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got route %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestRouteBudgetsAndQuestions(t *testing.T) {
+	budgets := []questionBudget{{QuestionID: 1, Seconds: 30}, {QuestionID: 2, Seconds: 60}, {QuestionID: 3, Seconds: 90}}
+	if got := routeBudgets(budgets, []uint{1, 3}); !reflect.DeepEqual(got, []questionBudget{{1, 30}, {3, 90}}) {
+		t.Errorf("unexpected budgets %v", got)
+	}
+
+	questions := []QuestionForAttempt{
+		{Question: &models.Question{ID: 1}}, {Question: &models.Question{ID: 2}}, {Question: &models.Question{ID: 3}},
+	}
+	if got := orderByRoute(questions, nil); len(got) != 3 {
+		t.Errorf("a nil route should keep every question, got %d", len(got))
+	}
+	got := orderByRoute(questions, []uint{1, 3})
+	if len(got) != 2 || got[1].ID != 3 || !got[0].IsFirst || !got[1].IsLast {
+		t.Errorf("unexpected routed questions %+v", got)
+	}
+}
@@ -0,0 +1,332 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// defaultQuestionSeconds is the time budget assumed for questions without a time limit
+const defaultQuestionSeconds = 90
+
+// defaultDifficultyDistribution is used when the request does not specify one
+var defaultDifficultyDistribution = map[models.DifficultyLevel]float64{
+	models.DifficultyEasy:   30,
+	models.DifficultyMedium: 50,
+	models.DifficultyHard:   20,
+}
+
+type builderCandidate struct {
+	question    *models.Question
+	bankID      uint
+	matchedTags []string
+}
+
+// ===== SMART ASSESSMENT BUILDER =====
+
+func (s *assessmentService) BuildProposal(ctx context.Context, req *BuildAssessmentRequest, userID string) (*AssessmentBuildProposal, error) {
+	s.logger.Info("Building assessment proposal",
+		"total_points", req.TotalPoints,
+		"duration", req.Duration,
+		"tags", req.Tags,
+		"user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	canCreate, err := s.canCreateAssessment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canCreate {
+		return nil, NewPermissionError(userID, 0, "assessment", "build", "insufficient role permissions")
+	}
+
+	bankIDs, err := s.getBuilderBankIDs(ctx, req.BankIDs, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal := &AssessmentBuildProposal{
+		Questions:           []ProposedQuestion{},
+		DifficultyBreakdown: make(map[models.DifficultyLevel]int),
+		Explanation:         []string{},
+		Warnings:            []string{},
+	}
+
+	if len(bankIDs) == 0 {
+		proposal.Warnings = append(proposal.Warnings, "no accessible question banks found")
+		return proposal, nil
+	}
+
+	candidates, err := s.collectBuilderCandidates(ctx, bankIDs, req)
+	if err != nil {
+		return nil, err
+	}
+
+	proposal.Explanation = append(proposal.Explanation,
+		fmt.Sprintf("Considered %d candidate questions from %d accessible question banks", len(candidates), len(bankIDs)))
+
+	selectBuilderQuestions(req, candidates, proposal)
+
+	s.logger.Info("Assessment proposal built",
+		"questions", len(proposal.Questions),
+		"total_points", proposal.TotalPoints,
+		"estimated_duration", proposal.EstimatedDuration,
+		"user_id", userID)
+
+	return proposal, nil
+}
+
+// getBuilderBankIDs resolves the banks the builder may draw from: the requested ones
+// when given (each must be accessible), otherwise every owned, shared and public bank
+func (s *assessmentService) getBuilderBankIDs(ctx context.Context, requested []uint, userID string) ([]uint, error) {
+	if len(requested) > 0 {
+		for _, bankID := range requested {
+			canAccess, err := s.repo.QuestionBank().CanAccess(ctx, nil, bankID, userID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check question bank access: %w", err)
+			}
+			if !canAccess {
+				return nil, NewPermissionError(userID, bankID, "question_bank", "build_from", "not owner, not public, or not shared")
+			}
+		}
+		return requested, nil
+	}
+
+	filters := repositories.QuestionBankFilters{}
+	seen := make(map[uint]bool)
+	var bankIDs []uint
+	addBanks := func(banks []*models.QuestionBank) {
+		for _, bank := range banks {
+			if !seen[bank.ID] {
+				seen[bank.ID] = true
+				bankIDs = append(bankIDs, bank.ID)
+			}
+		}
+	}
+
+	owned, _, err := s.repo.QuestionBank().GetByCreator(ctx, nil, userID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owned question banks: %w", err)
+	}
+	addBanks(owned)
+
+	shared, _, err := s.repo.QuestionBank().GetSharedWithUser(ctx, nil, userID, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared question banks: %w", err)
+	}
+	addBanks(shared)
+
+	public, _, err := s.repo.QuestionBank().GetPublicBanks(ctx, nil, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public question banks: %w", err)
+	}
+	addBanks(public)
+
+	return bankIDs, nil
+}
+
+func (s *assessmentService) collectBuilderCandidates(ctx context.Context, bankIDs []uint, req *BuildAssessmentRequest) ([]*builderCandidate, error) {
+	allowedTypes := make(map[models.QuestionType]bool)
+	for _, questionType := range req.QuestionTypes {
+		allowedTypes[questionType] = true
+	}
+
+	seen := make(map[uint]bool)
+	var candidates []*builderCandidate
+
+	for _, bankID := range bankIDs {
+		questions, _, err := s.repo.QuestionBank().GetBankQuestions(ctx, nil, bankID, repositories.QuestionFilters{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bank questions: %w", err)
+		}
+
+		for _, question := range questions {
+			if seen[question.ID] {
+				continue
+			}
+			if len(allowedTypes) > 0 && !allowedTypes[question.Type] {
+				continue
+			}
+			seen[question.ID] = true
+
+			candidates = append(candidates, &builderCandidate{
+				question:    question,
+				bankID:      bankID,
+				matchedTags: matchQuestionTags(question, req.Tags),
+			})
+		}
+	}
+
+	return candidates, nil
+}
+
+// selectBuilderQuestions greedily fills each difficulty's share of the point target,
+// preferring questions that cover more of the requested tags, within the time budget
+func selectBuilderQuestions(req *BuildAssessmentRequest, candidates []*builderCandidate, proposal *AssessmentBuildProposal) {
+	distribution := normalizeDifficultyDistribution(req.DifficultyDistribution)
+	timeBudget := req.Duration * 60
+	usedSeconds := 0
+	selected := make(map[uint]bool)
+
+	// When tags are requested, only tagged matches are eligible unless nothing matches
+	pool := candidates
+	if len(req.Tags) > 0 {
+		var tagged []*builderCandidate
+		for _, candidate := range candidates {
+			if len(candidate.matchedTags) > 0 {
+				tagged = append(tagged, candidate)
+			}
+		}
+		if len(tagged) == 0 {
+			proposal.Warnings = append(proposal.Warnings, "no questions match the requested tags; selecting from all candidates")
+		} else {
+			pool = tagged
+			proposal.Explanation = append(proposal.Explanation,
+				fmt.Sprintf("%d questions match at least one of the requested tags: %s", len(tagged), strings.Join(req.Tags, ", ")))
+		}
+	}
+
+	// Shuffle first so equally ranked questions vary between proposals
+	rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	sort.SliceStable(pool, func(i, j int) bool {
+		return len(pool[i].matchedTags) > len(pool[j].matchedTags)
+	})
+
+	addCandidate := func(candidate *builderCandidate, reason string) {
+		selected[candidate.question.ID] = true
+		usedSeconds += questionSeconds(candidate.question)
+		proposal.TotalPoints += candidate.question.Points
+		proposal.DifficultyBreakdown[candidate.question.Difficulty] += candidate.question.Points
+		proposal.Questions = append(proposal.Questions, ProposedQuestion{
+			Question:    candidate.question,
+			BankID:      candidate.bankID,
+			MatchedTags: candidate.matchedTags,
+			Reason:      reason,
+		})
+	}
+
+	fits := func(candidate *builderCandidate, pointsLeft int) bool {
+		return !selected[candidate.question.ID] &&
+			candidate.question.Points <= pointsLeft &&
+			usedSeconds+questionSeconds(candidate.question) <= timeBudget
+	}
+
+	levels := []models.DifficultyLevel{models.DifficultyEasy, models.DifficultyMedium, models.DifficultyHard}
+	for _, level := range levels {
+		target := int(float64(req.TotalPoints) * distribution[level] / 100)
+		if target == 0 {
+			continue
+		}
+
+		filled := 0
+		for _, candidate := range pool {
+			if candidate.question.Difficulty != level || !fits(candidate, target-filled) {
+				continue
+			}
+			addCandidate(candidate, builderReason(candidate, fmt.Sprintf("fills %s difficulty target", level)))
+			filled += candidate.question.Points
+		}
+
+		proposal.Explanation = append(proposal.Explanation,
+			fmt.Sprintf("%s: selected %d of %d target points (%.0f%%)", level, filled, target, distribution[level]))
+		if filled < target {
+			proposal.Warnings = append(proposal.Warnings,
+				fmt.Sprintf("not enough %s questions to reach %d points", level, target))
+		}
+	}
+
+	// Top up with any difficulty if rounding or shortages left points unfilled
+	if proposal.TotalPoints < req.TotalPoints {
+		for _, candidate := range pool {
+			if !fits(candidate, req.TotalPoints-proposal.TotalPoints) {
+				continue
+			}
+			addCandidate(candidate, builderReason(candidate, "tops up remaining points"))
+		}
+	}
+
+	proposal.EstimatedDuration = (usedSeconds + 59) / 60
+	for i, proposed := range proposal.Questions {
+		proposal.Assessment.Questions = append(proposal.Assessment.Questions, AssessmentQuestionRequest{
+			QuestionID: proposed.Question.ID,
+			Order:      i + 1,
+		})
+	}
+	proposal.Assessment.Duration = req.Duration
+
+	if proposal.TotalPoints < req.TotalPoints {
+		proposal.Warnings = append(proposal.Warnings,
+			fmt.Sprintf("proposal reaches %d of %d requested points", proposal.TotalPoints, req.TotalPoints))
+	}
+	proposal.Explanation = append(proposal.Explanation,
+		fmt.Sprintf("Estimated %d of %d available minutes", proposal.EstimatedDuration, req.Duration))
+}
+
+// normalizeDifficultyDistribution scales the distribution to percentages summing to 100
+func normalizeDifficultyDistribution(distribution map[models.DifficultyLevel]float64) map[models.DifficultyLevel]float64 {
+	total := 0.0
+	for _, share := range distribution {
+		if share > 0 {
+			total += share
+		}
+	}
+	if total == 0 {
+		distribution = defaultDifficultyDistribution
+		total = 100
+	}
+
+	normalized := make(map[models.DifficultyLevel]float64)
+	for level, share := range distribution {
+		if share > 0 {
+			normalized[level] = share / total * 100
+		}
+	}
+	return normalized
+}
+
+func matchQuestionTags(question *models.Question, tags []string) []string {
+	if len(tags) == 0 || len(question.Tags) == 0 {
+		return nil
+	}
+
+	var questionTags []string
+	if err := json.Unmarshal(question.Tags, &questionTags); err != nil {
+		return nil
+	}
+
+	tagSet := make(map[string]bool)
+	for _, tag := range questionTags {
+		tagSet[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+
+	var matched []string
+	for _, tag := range tags {
+		if tagSet[strings.ToLower(strings.TrimSpace(tag))] {
+			matched = append(matched, tag)
+		}
+	}
+	return matched
+}
+
+func questionSeconds(question *models.Question) int {
+	if question.TimeLimit != nil && *question.TimeLimit > 0 {
+		return *question.TimeLimit
+	}
+	return defaultQuestionSeconds
+}
+
+func builderReason(candidate *builderCandidate, base string) string {
+	if len(candidate.matchedTags) == 0 {
+		return base
+	}
+	return fmt.Sprintf("%s; covers tags: %s", base, strings.Join(candidate.matchedTags, ", "))
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func builderTestCandidate(id uint, difficulty models.DifficultyLevel, points int, matchedTags ...string) *builderCandidate {
+	return &builderCandidate{
+		question:    &models.Question{ID: id, Difficulty: difficulty, Points: points},
+		bankID:      1,
+		matchedTags: matchedTags,
+	}
+}
+
+func TestNormalizeDifficultyDistribution(t *testing.T) {
+	tests := []struct {
+		name         string
+		distribution map[models.DifficultyLevel]float64
+		want         map[models.DifficultyLevel]float64
+	}{
+		{"missing uses the default", nil, defaultDifficultyDistribution},
+		{"relative shares become percentages",
+			map[models.DifficultyLevel]float64{models.DifficultyEasy: 1, models.DifficultyMedium: 1, models.DifficultyHard: 2},
+			map[models.DifficultyLevel]float64{models.DifficultyEasy: 25, models.DifficultyMedium: 25, models.DifficultyHard: 50}},
+		{"zero shares are left out",
+			map[models.DifficultyLevel]float64{models.DifficultyEasy: 0, models.DifficultyMedium: 3},
+			map[models.DifficultyLevel]float64{models.DifficultyMedium: 100}},
+		{"negative shares are left out",
+			map[models.DifficultyLevel]float64{models.DifficultyEasy: -50, models.DifficultyMedium: 50, models.DifficultyHard: 50},
+			map[models.DifficultyLevel]float64{models.DifficultyMedium: 50, models.DifficultyHard: 50}},
+		{"no positive share uses the default",
+			map[models.DifficultyLevel]float64{models.DifficultyEasy: 0, models.DifficultyHard: -1},
+			defaultDifficultyDistribution},
+	}
+	for _, tt := range tests {
+		got := normalizeDifficultyDistribution(tt.distribution)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for level, share := range tt.want {
+			if got[level] != share {
+				t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestSelectBuilderQuestions(t *testing.T) {
+	halfEasyHalfHard := map[models.DifficultyLevel]float64{models.DifficultyEasy: 50, models.DifficultyHard: 50}
+	onlyEasy := map[models.DifficultyLevel]float64{models.DifficultyEasy: 100}
+
+	tests := []struct {
+		name       string
+		req        BuildAssessmentRequest
+		candidates []*builderCandidate
+		wantIDs    []uint
+		wantPoints int
+		wantWarn   []string
+	}{
+		{
+			name: "fills each difficulty share",
+			req:  BuildAssessmentRequest{TotalPoints: 10, Duration: 60, DifficultyDistribution: halfEasyHalfHard},
+			candidates: []*builderCandidate{
+				builderTestCandidate(1, models.DifficultyEasy, 5),
+				builderTestCandidate(2, models.DifficultyHard, 5),
+				builderTestCandidate(3, models.DifficultyMedium, 5),
+			},
+			wantIDs:    []uint{1, 2},
+			wantPoints: 10,
+		},
+		{
+			name: "only tagged questions when some match",
+			req:  BuildAssessmentRequest{TotalPoints: 10, Duration: 60, Tags: []string{"algebra"}, DifficultyDistribution: onlyEasy},
+			candidates: []*builderCandidate{
+				builderTestCandidate(1, models.DifficultyEasy, 5, "algebra"),
+				builderTestCandidate(2, models.DifficultyEasy, 5),
+			},
+			wantIDs:    []uint{1},
+			wantPoints: 5,
+			wantWarn:   []string{"not enough easy questions", "proposal reaches 5 of 10 requested points"},
+		},
+		{
+			name: "falls back to all candidates when no tag matches",
+			req:  BuildAssessmentRequest{TotalPoints: 5, Duration: 60, Tags: []string{"geometry"}, DifficultyDistribution: onlyEasy},
+			candidates: []*builderCandidate{
+				builderTestCandidate(1, models.DifficultyEasy, 5),
+			},
+			wantIDs:    []uint{1},
+			wantPoints: 5,
+			wantWarn:   []string{"no questions match the requested tags"},
+		},
+		{
+			name: "tops up a missing difficulty with another",
+			req:  BuildAssessmentRequest{TotalPoints: 10, Duration: 60, DifficultyDistribution: halfEasyHalfHard},
+			candidates: []*builderCandidate{
+				builderTestCandidate(1, models.DifficultyEasy, 5),
+				builderTestCandidate(2, models.DifficultyMedium, 5),
+			},
+			wantIDs:    []uint{1, 2},
+			wantPoints: 10,
+			wantWarn:   []string{"not enough hard questions to reach 5 points"},
+		},
+		{
+			name: "tops up points lost to rounding",
+			req:  BuildAssessmentRequest{TotalPoints: 5, Duration: 60, DifficultyDistribution: halfEasyHalfHard},
+			candidates: []*builderCandidate{
+				builderTestCandidate(1, models.DifficultyEasy, 2),
+				builderTestCandidate(2, models.DifficultyHard, 2),
+				builderTestCandidate(3, models.DifficultyMedium, 1),
+			},
+			wantIDs:    []uint{1, 2, 3},
+			wantPoints: 5,
+		},
+		{
+			name: "stays within the time budget",
+			req:  BuildAssessmentRequest{TotalPoints: 10, Duration: 2, DifficultyDistribution: onlyEasy},
+			candidates: []*builderCandidate{
+				builderTestCandidate(1, models.DifficultyEasy, 5),
+				builderTestCandidate(2, models.DifficultyEasy, 5),
+			},
+			wantPoints: 5,
+			wantWarn:   []string{"proposal reaches 5 of 10 requested points"},
+		},
+	}
+	for _, tt := range tests {
+		proposal := &AssessmentBuildProposal{DifficultyBreakdown: make(map[models.DifficultyLevel]int)}
+		selectBuilderQuestions(&tt.req, tt.candidates, proposal)
+
+		var ids []uint
+		for _, proposed := range proposal.Questions {
+			ids = append(ids, proposed.Question.ID)
+		}
+		slices.Sort(ids)
+		if tt.wantIDs != nil && !slices.Equal(ids, tt.wantIDs) {
+			t.Errorf("%s: selected %v, want %v", tt.name, ids, tt.wantIDs)
+		}
+		if proposal.TotalPoints != tt.wantPoints {
+			t.Errorf("%s: total points %d, want %d", tt.name, proposal.TotalPoints, tt.wantPoints)
+		}
+		if len(proposal.Assessment.Questions) != len(proposal.Questions) || proposal.Assessment.Duration != tt.req.Duration {
+			t.Errorf("%s: assessment draft does not match the proposal: %+v", tt.name, proposal.Assessment)
+		}
+		for _, want := range tt.wantWarn {
+			if !slices.ContainsFunc(proposal.Warnings, func(w string) bool { return strings.Contains(w, want) }) {
+				t.Errorf("%s: missing warning %q in %v", tt.name, want, proposal.Warnings)
+			}
+		}
+		if tt.wantWarn == nil && len(proposal.Warnings) > 0 {
+			t.Errorf("%s: unexpected warnings %v", tt.name, proposal.Warnings)
+		}
+	}
+}
+
+func TestMatchQuestionTags(t *testing.T) {
+	tags, _ := json.Marshal([]string{"Algebra", " fractions "})
+	question := &models.Question{Tags: tags}
+
+	got := matchQuestionTags(question, []string{"algebra", "Fractions", "geometry"})
+	if !slices.Equal(got, []string{"algebra", "Fractions"}) {
+		t.Errorf("expected case and space insensitive matches, got %v", got)
+	}
+	if got := matchQuestionTags(&models.Question{}, []string{"algebra"}); got != nil {
+		t.Errorf("untagged question should match nothing, got %v", got)
+	}
+}
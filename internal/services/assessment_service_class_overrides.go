@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// ===== CLASS OVERRIDES =====
+
+func (s *assessmentService) ListClassOverrides(ctx context.Context, assessmentID uint, userID string) ([]*models.AssessmentClassOverride, error) {
+	if _, err := s.getOwnedAssessment(ctx, assessmentID, userID, "view_class_overrides"); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Enrollment().GetClassOverrides(ctx, s.db, assessmentID)
+}
+
+func (s *assessmentService) SetClassOverride(ctx context.Context, assessmentID uint, classID string, req *SetClassOverrideRequest, userID string) (*models.AssessmentClassOverride, error) {
+	s.logger.Info("Setting class override", "assessment_id", assessmentID, "class_id", classID, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if req.AvailableFrom != nil && req.AvailableUntil != nil && !req.AvailableFrom.Before(*req.AvailableUntil) {
+		return nil, ValidationErrors{*NewValidationError("available_until", "must be after available_from", req.AvailableUntil)}
+	}
+
+	if _, err := s.getOwnedAssessment(ctx, assessmentID, userID, "set_class_override"); err != nil {
+		return nil, err
+	}
+
+	override := &models.AssessmentClassOverride{
+		AssessmentID:   assessmentID,
+		ClassID:        classID,
+		DueDate:        req.DueDate,
+		AvailableFrom:  req.AvailableFrom,
+		AvailableUntil: req.AvailableUntil,
+		MaxAttempts:    req.MaxAttempts,
+		UpdatedBy:      userID,
+	}
+	if err := s.repo.Enrollment().SaveClassOverride(ctx, s.db, override); err != nil {
+		return nil, err
+	}
+
+	return override, nil
+}
+
+func (s *assessmentService) DeleteClassOverride(ctx context.Context, assessmentID uint, classID string, userID string) error {
+	s.logger.Info("Deleting class override", "assessment_id", assessmentID, "class_id", classID, "user_id", userID)
+
+	if _, err := s.getOwnedAssessment(ctx, assessmentID, userID, "delete_class_override"); err != nil {
+		return err
+	}
+
+	if err := s.repo.Enrollment().DeleteClassOverride(ctx, s.db, assessmentID, classID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// resolveAttemptTerms applies the override of the class the student is enrolled with, then the
+// student's own accommodations, to the assessment's due date, availability and attempt limit
+func resolveAttemptTerms(ctx context.Context, repo repositories.Repository, db *gorm.DB, assessment *models.Assessment, studentID string) (*StudentAttemptTerms, error) {
+	enrollment, err := repo.Enrollment().GetEnrollment(ctx, db, assessment.ID, studentID)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get enrollment: %w", err)
+	}
+
+	var override *models.AssessmentClassOverride
+	if enrollment != nil && enrollment.ClassID != nil {
+		override, err = repo.Enrollment().GetClassOverride(ctx, db, assessment.ID, *enrollment.ClassID)
+		if err != nil && !repositories.IsNotFoundError(err) {
+			return nil, fmt.Errorf("failed to get class override: %w", err)
+		}
+	}
+
+	accommodations, err := repo.Extension().GetAccommodations(ctx, db, assessment.ID, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := applyClassOverride(assessment, enrollment, override)
+	applyAccommodations(terms, accommodations)
+	return terms, nil
+}
+
+// applyClassOverride returns the assessment's terms with the override's fields replacing the
+// ones it sets
+func applyClassOverride(assessment *models.Assessment, enrollment *models.AssessmentEnrollment, override *models.AssessmentClassOverride) *StudentAttemptTerms {
+	terms := &StudentAttemptTerms{
+		DueDate:     assessment.DueDate,
+		MaxAttempts: assessment.MaxAttempts,
+	}
+	if enrollment != nil {
+		terms.ClassID = enrollment.ClassID
+	}
+	if override == nil {
+		return terms
+	}
+
+	if override.DueDate != nil {
+		terms.DueDate = override.DueDate
+	}
+	if override.AvailableFrom != nil {
+		terms.AvailableFrom = override.AvailableFrom
+	}
+	if override.AvailableUntil != nil {
+		terms.AvailableUntil = override.AvailableUntil
+	}
+	if override.MaxAttempts != nil {
+		terms.MaxAttempts = *override.MaxAttempts
+	}
+	return terms
+}
+
+// applyAccommodations moves the due date to the latest one granted and adds the extra attempts.
+// An availability window closing before the new due date is kept open until then. Granted
+// due dates earlier than the current one, or for an assessment without one, change nothing.
+func applyAccommodations(terms *StudentAttemptTerms, accommodations []*models.StudentAccommodation) {
+	for _, accommodation := range accommodations {
+		terms.MaxAttempts += accommodation.ExtraAttempts
+
+		if accommodation.DueDate == nil || terms.DueDate == nil || !accommodation.DueDate.After(*terms.DueDate) {
+			continue
+		}
+		terms.DueDate = accommodation.DueDate
+		if terms.AvailableUntil != nil && terms.AvailableUntil.Before(*terms.DueDate) {
+			terms.AvailableUntil = terms.DueDate
+		}
+	}
+}
+
+// openAt reports whether an attempt may start at the given time
+func (t *StudentAttemptTerms) openAt(now time.Time) bool {
+	if t.AvailableFrom != nil && now.Before(*t.AvailableFrom) {
+		return false
+	}
+	if t.AvailableUntil != nil && now.After(*t.AvailableUntil) {
+		return false
+	}
+	return t.DueDate == nil || !now.After(*t.DueDate)
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestApplyClassOverride(t *testing.T) {
+	due := time.Date(2025, 6, 1, 23, 59, 0, 0, time.UTC)
+	later := due.AddDate(0, 0, 7)
+	classID := "period-3"
+	three := 3
+	assessment := &models.Assessment{DueDate: &due, MaxAttempts: 1}
+	enrollment := &models.AssessmentEnrollment{ClassID: &classID}
+
+	terms := applyClassOverride(assessment, nil, nil)
+	if terms.DueDate != &due || terms.MaxAttempts != 1 || terms.ClassID != nil {
+		t.Errorf("without an enrollment the assessment's terms apply, got %+v", terms)
+	}
+
+	terms = applyClassOverride(assessment, enrollment, &models.AssessmentClassOverride{MaxAttempts: &three})
+	if !terms.DueDate.Equal(due) || terms.MaxAttempts != 3 || *terms.ClassID != classID {
+		t.Errorf("only the attempt limit should be overridden, got %+v", terms)
+	}
+
+	terms = applyClassOverride(assessment, enrollment, &models.AssessmentClassOverride{DueDate: &later})
+	if !terms.DueDate.Equal(later) || terms.MaxAttempts != 1 {
+		t.Errorf("only the due date should be overridden, got %+v", terms)
+	}
+}
+
+func TestStudentAttemptTermsOpenAt(t *testing.T) {
+	from := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	until := from.Add(2 * time.Hour)
+	due := from.Add(time.Hour)
+	terms := &StudentAttemptTerms{AvailableFrom: &from, AvailableUntil: &until}
+
+	if terms.openAt(from.Add(-time.Minute)) {
+		t.Error("should be closed before the window opens")
+	}
+	if !terms.openAt(from.Add(90 * time.Minute)) {
+		t.Error("should be open inside the window")
+	}
+	if terms.openAt(until.Add(time.Minute)) {
+		t.Error("should be closed after the window")
+	}
+
+	terms.DueDate = &due
+	if terms.openAt(from.Add(90 * time.Minute)) {
+		t.Error("should be closed after the due date")
+	}
+}
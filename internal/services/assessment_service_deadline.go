@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// ===== DEADLINES =====
+
+// GetDeadline states the assessment's due date in UTC, in the timezone it was set in and in
+// the viewer's timezone. The viewer's timezone is the one requested, else the one in their
+// profile, else the assessment's own.
+func (s *assessmentService) GetDeadline(ctx context.Context, assessmentID uint, userID string, viewerTimezone string) (*AssessmentDeadline, error) {
+	canAccess, err := s.CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "read", "not owner or insufficient permissions")
+	}
+
+	assessment, err := s.getAssessmentByID(ctx, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	// Students see the due date of their class
+	terms, err := resolveAttemptTerms(ctx, s.repo, s.db, assessment, userID)
+	if err != nil {
+		return nil, err
+	}
+	assessment.DueDate = terms.DueDate
+
+	if viewerTimezone != "" {
+		if _, err := time.LoadLocation(viewerTimezone); err != nil {
+			return nil, NewValidationError("timezone", "unknown timezone", viewerTimezone)
+		}
+	} else if user, err := s.repo.User().GetByID(ctx, userID); err == nil {
+		viewerTimezone = user.Timezone
+	}
+
+	attemptLength, err := s.attemptLength(ctx, assessment)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildAssessmentDeadline(assessment, viewerTimezone, attemptLength, time.Now()), nil
+}
+
+// ===== HELPER METHODS =====
+
+// attemptLength is how long an attempt started now would run: the sum of the question
+// budgets in per-question timing mode, the assessment's duration otherwise
+func (s *assessmentService) attemptLength(ctx context.Context, assessment *models.Assessment) (time.Duration, error) {
+	length := time.Duration(assessment.Duration) * time.Minute
+
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, s.db, assessment.ID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return length, nil
+		}
+		return 0, fmt.Errorf("failed to get assessment settings: %w", err)
+	}
+	if settings.TimingMode != models.TimingModePerQuestion {
+		return length, nil
+	}
+
+	links, err := s.repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, s.db, assessment.ID)
+	if err != nil {
+		return 0, err
+	}
+	questions, err := s.repo.AssessmentQuestion().GetQuestionsForAssessment(ctx, s.db, assessment.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	length = 0
+	for _, budget := range buildQuestionBudgets(links, questions) {
+		length += time.Duration(budget.Seconds) * time.Second
+	}
+	return length, nil
+}
+
+// buildAssessmentDeadline computes the cut-offs the server enforces: attempts can start until
+// the due date and then run their full length, so submissions are accepted until the due
+// date plus one attempt's length. Time extensions granted to single attempts come on top.
+func buildAssessmentDeadline(assessment *models.Assessment, viewerTimezone string, attemptLength time.Duration, now time.Time) *AssessmentDeadline {
+	zone, zoneName := loadTimezone(assessment.DueTimezone)
+	viewerZone, viewerZoneName := zone, zoneName
+	if viewerTimezone != "" {
+		viewerZone, viewerZoneName = loadTimezone(viewerTimezone)
+	}
+
+	deadline := &AssessmentDeadline{
+		AssessmentID:         assessment.ID,
+		HasDueDate:           assessment.DueDate != nil,
+		Timezone:             zoneName,
+		ViewerTimezone:       viewerZoneName,
+		AcceptingNewAttempts: true,
+		AcceptingSubmissions: true,
+		ServerTime:           now.UTC(),
+	}
+	if assessment.DueDate == nil {
+		return deadline
+	}
+
+	due := assessment.DueDate.UTC()
+	until := due.Add(attemptLength)
+	deadline.DueAt = &due
+	deadline.DueAtLocal = due.In(zone).Format(time.RFC3339)
+	deadline.DueAtViewer = due.In(viewerZone).Format(time.RFC3339)
+	deadline.StartBy = &due
+	deadline.AcceptingSubmissionsUntil = &until
+	deadline.AcceptingSubmissionsUntilViewer = until.In(viewerZone).Format(time.RFC3339)
+	deadline.AcceptingNewAttempts = !now.After(due)
+	deadline.AcceptingSubmissions = !now.After(until)
+	return deadline
+}
+
+// loadTimezone resolves an IANA zone name, falling back to UTC for empty or unknown names
+func loadTimezone(name string) (*time.Location, string) {
+	if name == "" {
+		return time.UTC, "UTC"
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC, "UTC"
+	}
+	return location, name
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestBuildAssessmentDeadline(t *testing.T) {
+	due := time.Date(2025, 3, 10, 22, 59, 0, 0, time.UTC)
+	assessment := &models.Assessment{DueDate: &due, DueTimezone: "Europe/Berlin"}
+
+	deadline := buildAssessmentDeadline(assessment, "Asia/Ho_Chi_Minh", 90*time.Minute, due.Add(-time.Hour))
+
+	if deadline.DueAt == nil || !deadline.DueAt.Equal(due) {
+		t.Fatalf("expected due at %v, got %v", due, deadline.DueAt)
+	}
+	if deadline.DueAtLocal != "2025-03-10T23:59:00+01:00" {
+		t.Errorf("unexpected local due date: %s", deadline.DueAtLocal)
+	}
+	if deadline.ViewerTimezone != "Asia/Ho_Chi_Minh" || deadline.DueAtViewer != "2025-03-11T05:59:00+07:00" {
+		t.Errorf("unexpected viewer due date: %s %s", deadline.ViewerTimezone, deadline.DueAtViewer)
+	}
+	if deadline.AcceptingSubmissionsUntil == nil || !deadline.AcceptingSubmissionsUntil.Equal(due.Add(90*time.Minute)) {
+		t.Errorf("unexpected submission cut-off: %v", deadline.AcceptingSubmissionsUntil)
+	}
+	if !deadline.AcceptingNewAttempts || !deadline.AcceptingSubmissions {
+		t.Errorf("expected attempts and submissions to be accepted before the due date: %+v", deadline)
+	}
+
+	late := buildAssessmentDeadline(assessment, "", 90*time.Minute, due.Add(time.Hour))
+	if late.AcceptingNewAttempts || !late.AcceptingSubmissions {
+		t.Errorf("expected only submissions within the cut-off after the due date: %+v", late)
+	}
+	if late.ViewerTimezone != "Europe/Berlin" {
+		t.Errorf("expected the assessment's timezone without a viewer timezone, got %s", late.ViewerTimezone)
+	}
+
+	closed := buildAssessmentDeadline(assessment, "", 90*time.Minute, due.Add(2*time.Hour))
+	if closed.AcceptingNewAttempts || closed.AcceptingSubmissions {
+		t.Errorf("expected nothing accepted after the cut-off: %+v", closed)
+	}
+}
+
+func TestBuildAssessmentDeadline_NoDueDate(t *testing.T) {
+	deadline := buildAssessmentDeadline(&models.Assessment{DueTimezone: "Not/AZone"}, "", time.Hour, time.Now())
+
+	if deadline.HasDueDate || deadline.DueAt != nil || deadline.AcceptingSubmissionsUntil != nil {
+		t.Errorf("expected no deadline: %+v", deadline)
+	}
+	if deadline.Timezone != "UTC" {
+		t.Errorf("expected unknown timezone to fall back to UTC, got %s", deadline.Timezone)
+	}
+	if !deadline.AcceptingNewAttempts || !deadline.AcceptingSubmissions {
+		t.Errorf("expected an assessment without due date to stay open: %+v", deadline)
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// findDuplicateAssessments looks across the organization for assessments that may be
+// parallel versions of this one
+func (s *assessmentService) findDuplicateAssessments(ctx context.Context, assessment *models.Assessment, questionCount int) ([]SimilarAssessment, error) {
+	if s.examPolicy.DuplicateAssessmentOverlap <= 0 {
+		return make([]SimilarAssessment, 0), nil
+	}
+
+	key := titleKey(assessment.Title)
+	candidates, err := s.repo.Assessment().GetOverlapping(ctx, s.db, assessment.ID, key)
+	if err != nil {
+		return nil, err
+	}
+	return matchDuplicateAssessments(key, questionCount, candidates, s.examPolicy.DuplicateAssessmentOverlap), nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// matchDuplicateAssessments keeps the candidates with the same title pattern or holding at
+// least minOverlap of the assessment's questions, largest overlap first
+func matchDuplicateAssessments(key string, questionCount int, candidates []repositories.AssessmentOverlap, minOverlap float64) []SimilarAssessment {
+	duplicates := make([]SimilarAssessment, 0)
+	for _, candidate := range candidates {
+		var overlap float64
+		if questionCount > 0 {
+			overlap = math.Round(float64(candidate.SharedQuestions)/float64(questionCount)*100) / 100
+		}
+		sameTitle := key != "" && titleKey(candidate.Title) == key
+		if !sameTitle && (questionCount == 0 || overlap < minOverlap) {
+			continue
+		}
+		duplicates = append(duplicates, SimilarAssessment{
+			AssessmentID:    candidate.AssessmentID,
+			Title:           candidate.Title,
+			Status:          candidate.Status,
+			CreatedBy:       candidate.CreatedBy,
+			CreatorName:     candidate.CreatorName,
+			SameTitle:       sameTitle,
+			SharedQuestions: candidate.SharedQuestions,
+			QuestionOverlap: overlap,
+			Link:            fmt.Sprintf("/api/v1/assessments/%d", candidate.AssessmentID),
+		})
+	}
+
+	slices.SortStableFunc(duplicates, func(a, b SimilarAssessment) int {
+		return cmp.Compare(b.QuestionOverlap, a.QuestionOverlap)
+	})
+	return duplicates
+}
+
+// duplicateWarnings turns possible duplicates into readiness warnings
+func duplicateWarnings(duplicates []SimilarAssessment) []ReadinessIssue {
+	warnings := make([]ReadinessIssue, 0, len(duplicates))
+	for _, duplicate := range duplicates {
+		var reasons []string
+		if duplicate.SameTitle {
+			reasons = append(reasons, "has a similar title")
+		}
+		if duplicate.SharedQuestions > 0 {
+			reasons = append(reasons, fmt.Sprintf("shares %.0f%% of the questions", duplicate.QuestionOverlap*100))
+		}
+		warnings = append(warnings, ReadinessIssue{
+			Code: "QT-POSSIBLE-DUPLICATE",
+			Message: fmt.Sprintf("Assessment %d %q by %s %s; check it is not a parallel version of this exam (%s)",
+				duplicate.AssessmentID, duplicate.Title, creatorLabel(duplicate), strings.Join(reasons, " and "), duplicate.Link),
+		})
+	}
+	return warnings
+}
+
+func creatorLabel(duplicate SimilarAssessment) string {
+	if duplicate.CreatorName != "" {
+		return duplicate.CreatorName
+	}
+	return duplicate.CreatedBy
+}
+
+// titleKey reduces a title to its lowercase letters, so numbering, years, " (n)" suffixes
+// and punctuation are ignored; it matches the repository's comparison
+func titleKey(title string) string {
+	var key strings.Builder
+	for _, r := range strings.ToLower(title) {
+		if unicode.IsLetter(r) {
+			key.WriteRune(r)
+		}
+	}
+	return key.String()
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestTitleKey(t *testing.T) {
+	if got := titleKey("Biology Midterm 2025 (2)"); got != "biologymidterm" {
+		t.Errorf("expected numbers and punctuation to be ignored, got %q", got)
+	}
+	if titleKey("biology – MIDTERM") != titleKey("Biology Midterm #2") {
+		t.Error("expected titles differing in case, dashes and numbering to match")
+	}
+	if got := titleKey("2025-06"); got != "" {
+		t.Errorf("expected a title without letters to have no key, got %q", got)
+	}
+}
+
+func TestMatchDuplicateAssessments(t *testing.T) {
+	candidates := []repositories.AssessmentOverlap{
+		{AssessmentID: 2, Title: "Unrelated quiz", SharedQuestions: 1, QuestionCount: 10},
+		{AssessmentID: 3, Title: "Chemistry final", SharedQuestions: 6, QuestionCount: 8, CreatedBy: "teacher-2"},
+		{AssessmentID: 4, Title: "Biology Midterm (2)", CreatedBy: "teacher-3", CreatorName: "Ada Lovelace"},
+		{AssessmentID: 5, Title: "Biology recap", SharedQuestions: 10, QuestionCount: 10},
+	}
+
+	duplicates := matchDuplicateAssessments(titleKey("Biology Midterm"), 10, candidates, 0.6)
+	if len(duplicates) != 3 {
+		t.Fatalf("expected three possible duplicates, got %+v", duplicates)
+	}
+	if duplicates[0].AssessmentID != 5 || duplicates[0].QuestionOverlap != 1 {
+		t.Errorf("expected the full overlap first, got %+v", duplicates[0])
+	}
+	if duplicates[1].AssessmentID != 3 || duplicates[1].QuestionOverlap != 0.6 || duplicates[1].SameTitle {
+		t.Errorf("expected a 60%% overlap second, got %+v", duplicates[1])
+	}
+	if duplicates[2].AssessmentID != 4 || !duplicates[2].SameTitle || duplicates[2].Link != "/api/v1/assessments/4" {
+		t.Errorf("expected the same-titled assessment with a link, got %+v", duplicates[2])
+	}
+
+	if got := matchDuplicateAssessments("", 0, candidates, 0.6); len(got) != 0 {
+		t.Errorf("expected nothing to match an empty assessment without a title key, got %+v", got)
+	}
+
+	warnings := duplicateWarnings(duplicates)
+	if len(warnings) != 3 || warnings[0].Code != "QT-POSSIBLE-DUPLICATE" {
+		t.Fatalf("expected a warning per duplicate, got %+v", warnings)
+	}
+	if !strings.Contains(warnings[2].Message, "Ada Lovelace") || !strings.Contains(warnings[2].Message, "/api/v1/assessments/4") {
+		t.Errorf("expected the warning to name the creator and link the assessment, got %q", warnings[2].Message)
+	}
+}
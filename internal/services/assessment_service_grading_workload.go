@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+const (
+	// Grades older than this no longer reflect how fast the teacher grades
+	gradingWorkloadLookback = 180 * 24 * time.Hour
+
+	// Caps the grades timed for one estimate
+	gradingWorkloadMaxRecords = 50000
+
+	// Minutes per answer when the teacher has no timed grades yet
+	defaultGradingMinutes = 5.0
+)
+
+// ===== GRADING WORKLOAD =====
+
+// gradingWorkload estimates the hand grading the assessment will need, timed from the
+// grades the owner's assessments received recently
+func (s *assessmentService) gradingWorkload(ctx context.Context, assessment *models.Assessment, questions []*models.Question) (*GradingWorkloadEstimate, error) {
+	studentIDs, err := s.repo.Enrollment().GetStudentIDs(ctx, s.db, assessment.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	grades, err := s.repo.Answer().GetManualGradeTimes(ctx, s.db, assessment.CreatedBy,
+		time.Now().Add(-gradingWorkloadLookback), gradingWorkloadMaxRecords)
+	if err != nil {
+		return nil, err
+	}
+
+	return estimateGradingWorkload(questions, len(studentIDs), grades), nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// estimateGradingWorkload multiplies the expected hand-graded answers by the average
+// grading time. The time between two grades a grader gave within one session counts
+// towards the later answer's question.
+func estimateGradingWorkload(questions []*models.Question, students int, grades []repositories.ManualGradeRecord) *GradingWorkloadEstimate {
+	perQuestion := make(map[uint][]float64)
+	overall := make([]float64, 0, len(grades))
+	for i := 1; i < len(grades); i++ {
+		previous, current := grades[i-1], grades[i]
+		if previous.GraderID != current.GraderID {
+			continue
+		}
+		gap := current.GradedAt.Sub(previous.GradedAt)
+		if gap < 0 || gap > gradingSessionGap {
+			continue
+		}
+		perQuestion[current.QuestionID] = append(perQuestion[current.QuestionID], gap.Minutes())
+		overall = append(overall, gap.Minutes())
+	}
+
+	fallback := QuestionGradingEstimate{MinutesPerAnswer: defaultGradingMinutes, Source: GradingTimeDefault}
+	if len(overall) >= estimateMinResponses {
+		fallback = QuestionGradingEstimate{MinutesPerAnswer: average(overall), Samples: len(overall), Source: GradingTimeFromTeacher}
+	}
+
+	estimate := &GradingWorkloadEstimate{
+		ExpectedStudents: students,
+		Questions:        make([]QuestionGradingEstimate, 0),
+	}
+	for _, question := range questions {
+		if !gradedByHand(question) {
+			continue
+		}
+
+		item := fallback
+		item.QuestionID, item.Type = question.ID, question.Type
+		if samples := perQuestion[question.ID]; len(samples) >= estimateMinResponses {
+			item.MinutesPerAnswer = average(samples)
+			item.Samples = len(samples)
+			item.Source = GradingTimeFromQuestion
+		}
+		item.MinutesPerAnswer = roundTo(item.MinutesPerAnswer, 1)
+
+		estimate.MinutesPerStudent += item.MinutesPerAnswer
+		estimate.Questions = append(estimate.Questions, item)
+	}
+
+	estimate.ManualQuestions = len(estimate.Questions)
+	estimate.ExpectedAnswers = estimate.ManualQuestions * students
+	estimate.MinutesPerStudent = roundTo(estimate.MinutesPerStudent, 1)
+	estimate.ExpectedMinutes = roundTo(estimate.MinutesPerStudent*float64(students), 1)
+	return estimate
+}
+
+// gradedByHand tells whether every answer to the question waits for a teacher
+func gradedByHand(question *models.Question) bool {
+	return question.Type == models.Essay || (question.RequireManualReview && question.Type != models.MultiPart)
+}
+
+func average(values []float64) float64 {
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	return total / float64(len(values))
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestEstimateGradingWorkload(t *testing.T) {
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	grades := []repositories.ManualGradeRecord{{GraderID: "g1", QuestionID: 1, GradedAt: start}}
+	// Five answers to question 1 graded 4 minutes apart
+	for i := 1; i <= 5; i++ {
+		grades = append(grades, repositories.ManualGradeRecord{GraderID: "g1", QuestionID: 1, GradedAt: start.Add(time.Duration(4*i) * time.Minute)})
+	}
+	// After a lunch break, one answer to question 2 two minutes after the last one
+	grades = append(grades,
+		repositories.ManualGradeRecord{GraderID: "g1", QuestionID: 2, GradedAt: start.Add(3 * time.Hour)},
+		repositories.ManualGradeRecord{GraderID: "g1", QuestionID: 2, GradedAt: start.Add(3*time.Hour + 2*time.Minute)},
+		// Another grader's first grade does not follow g1's
+		repositories.ManualGradeRecord{GraderID: "g2", QuestionID: 2, GradedAt: start.Add(3*time.Hour + 3*time.Minute)},
+	)
+
+	questions := []*models.Question{
+		{ID: 1, Type: models.Essay},
+		{ID: 2, Type: models.Essay},
+		{ID: 3, Type: models.ShortAnswer, RequireManualReview: true},
+		{ID: 4, Type: models.MultipleChoice},
+	}
+
+	estimate := estimateGradingWorkload(questions, 30, grades)
+
+	if estimate.ManualQuestions != 3 || estimate.ExpectedAnswers != 90 {
+		t.Fatalf("expected 3 hand-graded questions and 90 answers, got %+v", estimate)
+	}
+	if first := estimate.Questions[0]; first.Source != GradingTimeFromQuestion || first.MinutesPerAnswer != 4 || first.Samples != 5 {
+		t.Errorf("question with enough timed grades should use them, got %+v", first)
+	}
+	// (5*4 + 2) / 6
+	if second := estimate.Questions[1]; second.Source != GradingTimeFromTeacher || second.MinutesPerAnswer != 3.7 || second.Samples != 6 {
+		t.Errorf("question with few timed grades should use the teacher's average, got %+v", second)
+	}
+	if estimate.MinutesPerStudent != 11.4 || estimate.ExpectedMinutes != 342 {
+		t.Errorf("unexpected workload: %v per student, %v total", estimate.MinutesPerStudent, estimate.ExpectedMinutes)
+	}
+}
+
+func TestEstimateGradingWorkloadWithoutHistory(t *testing.T) {
+	estimate := estimateGradingWorkload([]*models.Question{{ID: 1, Type: models.Essay}}, 10, nil)
+
+	if item := estimate.Questions[0]; item.Source != GradingTimeDefault || item.MinutesPerAnswer != defaultGradingMinutes {
+		t.Errorf("expected the default grading time, got %+v", item)
+	}
+	if estimate.ExpectedMinutes != 50 {
+		t.Errorf("expected 50 minutes, got %v", estimate.ExpectedMinutes)
+	}
+
+	none := estimateGradingWorkload([]*models.Question{{ID: 2, Type: models.TrueFalse}}, 10, nil)
+	if none.ManualQuestions != 0 || none.ExpectedMinutes != 0 {
+		t.Errorf("auto-graded assessments need no hand grading, got %+v", none)
+	}
+}
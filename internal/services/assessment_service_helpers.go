@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
@@ -139,8 +140,14 @@ func (s *assessmentService) CanTake(ctx context.Context, assessmentID uint, user
 		return false, nil
 	}
 
-	// Check if not expired
-	if assessment.DueDate != nil && time.Now().After(*assessment.DueDate) {
+	// The student's class may have its own due date, availability window and attempt limit
+	terms, err := resolveAttemptTerms(ctx, s.repo, s.db, assessment, userID)
+	if err != nil {
+		return false, err
+	}
+
+	// Check if open and not expired
+	if !terms.openAt(time.Now()) {
 		return false, nil
 	}
 
@@ -150,7 +157,7 @@ func (s *assessmentService) CanTake(ctx context.Context, assessmentID uint, user
 		return false, err
 	}
 
-	if attemptCount >= assessment.MaxAttempts {
+	if attemptCount >= terms.MaxAttempts {
 		return false, nil
 	}
 
@@ -196,6 +203,24 @@ func (s *assessmentService) buildAssessmentResponse(ctx context.Context, assessm
 	return response
 }
 
+// markFavorites flags the assessments the user starred with a single lookup for the whole page
+func (s *assessmentService) markFavorites(ctx context.Context, responses []*AssessmentResponse, userID string) {
+	ids := make([]uint, len(responses))
+	for i, response := range responses {
+		ids[i] = response.ID
+	}
+
+	favorited, err := s.repo.Favorite().GetFavoritedIDs(ctx, nil, userID, models.FavoriteAssessment, ids)
+	if err != nil {
+		s.logger.Warn("Failed to load favorite assessments", "user_id", userID, "error", err)
+		return
+	}
+
+	for _, response := range responses {
+		response.IsFavorite = favorited[response.ID]
+	}
+}
+
 func (s *assessmentService) buildAssessmentSettings(assessmentID uint, req *AssessmentSettingsRequest) *models.AssessmentSettings {
 	settings := &models.AssessmentSettings{
 		AssessmentID: assessmentID,
@@ -207,11 +232,28 @@ func (s *assessmentService) buildAssessmentSettings(assessmentID uint, req *Asse
 		ShowResults:                 true,
 		ShowCorrectAnswers:          true,
 		ShowScoreBreakdown:          true,
+		ShowRank:                    false,
+		AnonymousGrading:            false,
+		ResultsReleaseMode:          models.ResultsReleaseImmediate,
 		AllowRetake:                 false,
 		RetakeDelay:                 0,
+		GradePolicy:                 models.GradePolicyHighest,
+		AllowSaveAndExit:            false,
+		LockCompletedSections:       false,
+		AskConfidence:               false,
+		MaxConcurrentAttempts:       0,
+		RequireAllAnswered:          false,
+		RequireFlaggedResolved:      false,
+		RequireSubmitConfirmation:   false,
 		TimeLimitEnforced:           true,
 		AutoSubmitOnTimeout:         true,
+		TimingMode:                  models.TimingModeTotal,
+		CalculatorType:              models.CalculatorNone,
+		AllowDictionary:             false,
+		AllowSpellCheck:             false,
+		ProctoringProvider:          BrowserProctoringProvider,
 		RequireWebcam:               false,
+		RequireScreenRecording:      false,
 		PreventTabSwitching:         false,
 		PreventRightClick:           false,
 		PreventCopyPaste:            false,
@@ -220,6 +262,7 @@ func (s *assessmentService) buildAssessmentSettings(assessmentID uint, req *Asse
 		AllowScreenReader:           false,
 		FontSizeAdjustment:          0,
 		HighContrastMode:            false,
+		AccommodationExtraTime:      0,
 	}
 
 	// Apply provided settings
@@ -252,6 +295,12 @@ func (s *assessmentService) applyAssessmentUpdates(assessment *models.Assessment
 	if req.DueDate != nil {
 		assessment.DueDate = req.DueDate
 	}
+	if req.DueTimezone != nil {
+		assessment.DueTimezone = *req.DueTimezone
+	}
+	if req.Term != nil {
+		assessment.Term = req.Term
+	}
 
 	assessment.Version += 1
 	assessment.UpdatedAt = time.Now()
@@ -279,6 +328,15 @@ func (s *assessmentService) applySettingsUpdates(settings *models.AssessmentSett
 	if req.ShowScoreBreakdown != nil {
 		settings.ShowScoreBreakdown = *req.ShowScoreBreakdown
 	}
+	if req.ShowRank != nil {
+		settings.ShowRank = *req.ShowRank
+	}
+	if req.AnonymousGrading != nil {
+		settings.AnonymousGrading = *req.AnonymousGrading
+		if settings.AnonymousGrading && settings.AnonymousGradingSalt == "" {
+			settings.AnonymousGradingSalt = newAnonymousGradingSalt()
+		}
+	}
 	if req.AllowRetake != nil {
 		settings.AllowRetake = *req.AllowRetake
 	}
@@ -294,6 +352,12 @@ func (s *assessmentService) applySettingsUpdates(settings *models.AssessmentSett
 	if req.RequireWebcam != nil {
 		settings.RequireWebcam = *req.RequireWebcam
 	}
+	if req.ProctoringProvider != nil {
+		settings.ProctoringProvider = *req.ProctoringProvider
+	}
+	if req.RequireScreenRecording != nil {
+		settings.RequireScreenRecording = *req.RequireScreenRecording
+	}
 	if req.PreventTabSwitching != nil {
 		settings.PreventTabSwitching = *req.PreventTabSwitching
 	}
@@ -318,9 +382,76 @@ func (s *assessmentService) applySettingsUpdates(settings *models.AssessmentSett
 	if req.HighContrastMode != nil {
 		settings.HighContrastMode = *req.HighContrastMode
 	}
+	if req.AccommodationExtraTime != nil {
+		settings.AccommodationExtraTime = *req.AccommodationExtraTime
+	}
+	if req.ResultsReleaseMode != nil {
+		settings.ResultsReleaseMode = *req.ResultsReleaseMode
+	}
+	if req.RequireAllAnswered != nil {
+		settings.RequireAllAnswered = *req.RequireAllAnswered
+	}
+	if req.RequireFlaggedResolved != nil {
+		settings.RequireFlaggedResolved = *req.RequireFlaggedResolved
+	}
+	if req.RequireSubmitConfirmation != nil {
+		settings.RequireSubmitConfirmation = *req.RequireSubmitConfirmation
+	}
+	if req.ResultsReleaseAt != nil {
+		settings.ResultsReleaseAt = req.ResultsReleaseAt
+	}
+	if req.TimingMode != nil {
+		settings.TimingMode = *req.TimingMode
+	}
+	if req.GradePolicy != nil {
+		settings.GradePolicy = *req.GradePolicy
+	}
+	if req.AllowSaveAndExit != nil {
+		settings.AllowSaveAndExit = *req.AllowSaveAndExit
+	}
+	if req.LockCompletedSections != nil {
+		settings.LockCompletedSections = *req.LockCompletedSections
+	}
+	if req.AskConfidence != nil {
+		settings.AskConfidence = *req.AskConfidence
+	}
+	if req.ExpectedLanguage != nil {
+		if language := *req.ExpectedLanguage; language != "" {
+			settings.ExpectedLanguage = &language
+		} else {
+			settings.ExpectedLanguage = nil
+		}
+	}
+	if req.MaxConcurrentAttempts != nil {
+		settings.MaxConcurrentAttempts = *req.MaxConcurrentAttempts
+	}
+	if req.CalculatorType != nil {
+		settings.CalculatorType = *req.CalculatorType
+	}
+	if req.FormulaSheetURL != nil {
+		if url := strings.TrimSpace(*req.FormulaSheetURL); url != "" {
+			settings.FormulaSheetURL = &url
+		} else {
+			settings.FormulaSheetURL = nil
+		}
+	}
+	if req.AllowDictionary != nil {
+		settings.AllowDictionary = *req.AllowDictionary
+	}
+	if req.AllowSpellCheck != nil {
+		settings.AllowSpellCheck = *req.AllowSpellCheck
+	}
 }
 
 func (s *assessmentService) addQuestionsToAssessment(ctx context.Context, tx *gorm.DB, assessmentID uint, questions []AssessmentQuestionRequest, userID string) error {
+	questionIDs := make([]uint, len(questions))
+	for i, qReq := range questions {
+		questionIDs[i] = qReq.QuestionID
+	}
+	if err := rejectDraftQuestions(ctx, s.repo, questionIDs...); err != nil {
+		return err
+	}
+
 	for _, qReq := range questions {
 		// Add question to assessment
 		if err := s.repo.AssessmentQuestion().AddQuestion(ctx, tx, assessmentID, qReq.QuestionID, qReq.Order, qReq.Points); err != nil {
@@ -333,16 +464,16 @@ func (s *assessmentService) addQuestionsToAssessment(ctx context.Context, tx *go
 
 // ===== VALIDATION FUNCTIONS =====
 
-func (s *assessmentService) validateCreateRequest(ctx context.Context, req *CreateAssessmentRequest, creatorID string) error {
+func (s *assessmentService) validateCreateRequest(ctx context.Context, req *CreateAssessmentRequest, creatorID string) (*titleResolution, error) {
 	var errors ValidationErrors
 
 	// Check title uniqueness
-	existing, err := s.repo.Assessment().ExistsByTitle(ctx, s.db, req.Title, creatorID, nil)
-	if err != nil && !repositories.IsNotFoundError(err) {
-		return fmt.Errorf("failed to check title uniqueness: %w", err)
+	title, err := s.resolveTitle(ctx, req.Title, req.Term, req.AutoSuffixTitle, creatorID, nil)
+	if err != nil {
+		return nil, err
 	}
-	if existing {
-		errors = append(errors, *NewValidationError("title", "already exists", req.Title))
+	if title.conflict != nil {
+		errors = append(errors, *title.conflict)
 	}
 
 	// Validate due date
@@ -366,30 +497,42 @@ func (s *assessmentService) validateCreateRequest(ctx context.Context, req *Crea
 				if repositories.IsNotFoundError(err) {
 					errors = append(errors, *NewValidationError(fmt.Sprintf("questions[%d].question_id", i), "question not found", q.QuestionID))
 				} else {
-					return fmt.Errorf("failed to validate question %d: %w", q.QuestionID, err)
+					return nil, fmt.Errorf("failed to validate question %d: %w", q.QuestionID, err)
 				}
 			}
 		}
 	}
 
 	if len(errors) > 0 {
-		return errors
+		return nil, errors
 	}
 
-	return nil
+	return title, nil
 }
 
-func (s *assessmentService) validateUpdateRequest(ctx context.Context, req *UpdateAssessmentRequest, assessment *models.Assessment, userID string) error {
+func (s *assessmentService) validateUpdateRequest(ctx context.Context, req *UpdateAssessmentRequest, assessment *models.Assessment, userID string) (*titleResolution, error) {
 	var errors ValidationErrors
 
-	// Check title uniqueness if title is being updated
-	if req.Title != nil && *req.Title != assessment.Title {
-		existing, err := s.repo.Assessment().ExistsByTitle(ctx, s.db, *req.Title, assessment.CreatedBy, &assessment.ID)
-		if err != nil && !repositories.IsNotFoundError(err) {
-			return fmt.Errorf("failed to check title uniqueness: %w", err)
+	// Check title uniqueness if the title or term is being changed
+	var title *titleResolution
+	titleChanged := req.Title != nil && *req.Title != assessment.Title
+	termChanged := req.Term != nil && (assessment.Term == nil || *req.Term != *assessment.Term)
+	if titleChanged || termChanged {
+		newTitle, newTerm := assessment.Title, assessment.Term
+		if req.Title != nil {
+			newTitle = *req.Title
+		}
+		if req.Term != nil {
+			newTerm = req.Term
+		}
+
+		var err error
+		title, err = s.resolveTitle(ctx, newTitle, newTerm, req.AutoSuffixTitle, assessment.CreatedBy, &assessment.ID)
+		if err != nil {
+			return nil, err
 		}
-		if existing {
-			errors = append(errors, *NewValidationError("title", "already exists", *req.Title))
+		if title.conflict != nil {
+			errors = append(errors, *title.conflict)
 		}
 	}
 
@@ -402,7 +545,7 @@ func (s *assessmentService) validateUpdateRequest(ctx context.Context, req *Upda
 	if assessment.Status != models.StatusDraft {
 		hasAttempts, err := s.repo.Assessment().HasAttempts(ctx, s.db, assessment.ID)
 		if err != nil {
-			return fmt.Errorf("failed to check attempts: %w", err)
+			return nil, fmt.Errorf("failed to check attempts: %w", err)
 		}
 
 		if hasAttempts {
@@ -416,10 +559,10 @@ func (s *assessmentService) validateUpdateRequest(ctx context.Context, req *Upda
 	}
 
 	if len(errors) > 0 {
-		return errors
+		return nil, errors
 	}
 
-	return nil
+	return title, nil
 }
 
 func (s *assessmentService) validateStatusTransition(ctx context.Context, assessment *models.Assessment, newStatus models.AssessmentStatus) error {
@@ -493,7 +636,11 @@ func (s *assessmentService) validateAssessmentReadyForPublish(ctx context.Contex
 		)
 	}
 
-	return nil
+	if err := s.validateProctoringCapabilities(ctx, assessment.ID); err != nil {
+		return err
+	}
+
+	return s.validateAssessmentBranching(ctx, assessment.ID)
 }
 
 func max(a, b int) int {
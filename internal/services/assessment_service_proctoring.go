@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// ProctoringCapability is a proctoring feature an assessment can ask for
+type ProctoringCapability string
+
+const (
+	CapabilityWebcam               ProctoringCapability = "webcam"
+	CapabilityScreenRecording      ProctoringCapability = "screen_recording"
+	CapabilityIdentityVerification ProctoringCapability = "identity_verification"
+	CapabilityLockdown             ProctoringCapability = "lockdown"
+)
+
+// BrowserProctoringProvider is the provider built into the exam client
+const BrowserProctoringProvider = "browser"
+
+// ProctoringProvider enforces proctoring settings during attempts
+type ProctoringProvider interface {
+	Name() string
+	Capabilities() []ProctoringCapability
+}
+
+// StaticProctoringProvider is a provider with a fixed set of capabilities, for providers
+// configured by the organization
+type StaticProctoringProvider struct {
+	ProviderName string
+	Supported    []ProctoringCapability
+}
+
+func (p StaticProctoringProvider) Name() string {
+	return p.ProviderName
+}
+
+func (p StaticProctoringProvider) Capabilities() []ProctoringCapability {
+	return p.Supported
+}
+
+// ProctoringProviders holds the proctoring providers assessments may use, by name
+type ProctoringProviders map[string]ProctoringProvider
+
+// NewProctoringProviders registers the given providers under their names
+func NewProctoringProviders(providers ...ProctoringProvider) ProctoringProviders {
+	registry := make(ProctoringProviders, len(providers))
+	for _, provider := range providers {
+		registry[provider.Name()] = provider
+	}
+	return registry
+}
+
+// DefaultProctoringProviders returns the built-in browser provider, which can watch the
+// webcam and lock the browser down but cannot record the screen or verify identities
+func DefaultProctoringProviders() ProctoringProviders {
+	return NewProctoringProviders(StaticProctoringProvider{
+		ProviderName: BrowserProctoringProvider,
+		Supported:    []ProctoringCapability{CapabilityWebcam, CapabilityLockdown},
+	})
+}
+
+// ===== PUBLISH CHECKS =====
+
+// validateProctoringCapabilities checks that the assessment's proctoring provider supports
+// every proctoring feature its settings ask for
+func (s *assessmentService) validateProctoringCapabilities(ctx context.Context, assessmentID uint) error {
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, s.db, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get assessment settings: %w", err)
+	}
+
+	required := requiredProctoringCapabilities(settings)
+	if len(required) == 0 {
+		return nil
+	}
+
+	name := settings.ProctoringProvider
+	if name == "" {
+		name = BrowserProctoringProvider
+	}
+	provider, ok := s.proctoringProviders[name]
+	if !ok {
+		return NewBusinessRuleError(
+			"QT-PROCTORING-PROVIDER-UNKNOWN",
+			fmt.Sprintf("Proctoring provider %q is not configured", name),
+			map[string]interface{}{
+				"assessment_id": assessmentID,
+				"provider":      name,
+			},
+		)
+	}
+
+	if missing := missingProctoringCapabilities(required, provider.Capabilities()); len(missing) > 0 {
+		return NewBusinessRuleError(
+			"QT-PROCTORING-UNSUPPORTED",
+			fmt.Sprintf("Proctoring provider %q does not support %v", name, missing),
+			map[string]interface{}{
+				"assessment_id": assessmentID,
+				"provider":      name,
+				"unsupported":   missing,
+			},
+		)
+	}
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// requiredProctoringCapabilities lists the capabilities the settings need from the provider
+func requiredProctoringCapabilities(settings *models.AssessmentSettings) []ProctoringCapability {
+	var required []ProctoringCapability
+	if settings.RequireWebcam {
+		required = append(required, CapabilityWebcam)
+	}
+	if settings.RequireScreenRecording {
+		required = append(required, CapabilityScreenRecording)
+	}
+	if settings.RequireIdentityVerification {
+		required = append(required, CapabilityIdentityVerification)
+	}
+	if settings.PreventTabSwitching || settings.PreventRightClick || settings.PreventCopyPaste || settings.RequireFullScreen {
+		required = append(required, CapabilityLockdown)
+	}
+	return required
+}
+
+// missingProctoringCapabilities returns the required capabilities the provider lacks, sorted
+func missingProctoringCapabilities(required, supported []ProctoringCapability) []ProctoringCapability {
+	has := make(map[ProctoringCapability]bool, len(supported))
+	for _, capability := range supported {
+		has[capability] = true
+	}
+
+	var missing []ProctoringCapability
+	for _, capability := range required {
+		if !has[capability] {
+			missing = append(missing, capability)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+	return missing
+}
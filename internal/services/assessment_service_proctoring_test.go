@@ -0,0 +1,39 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestRequiredProctoringCapabilities(t *testing.T) {
+	if got := requiredProctoringCapabilities(&models.AssessmentSettings{}); len(got) != 0 {
+		t.Errorf("unproctored settings should need nothing, got %v", got)
+	}
+
+	settings := &models.AssessmentSettings{
+		RequireWebcam:          true,
+		RequireScreenRecording: true,
+		PreventCopyPaste:       true,
+		RequireFullScreen:      true,
+	}
+	want := []ProctoringCapability{CapabilityWebcam, CapabilityScreenRecording, CapabilityLockdown}
+	if got := requiredProctoringCapabilities(settings); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestMissingProctoringCapabilities(t *testing.T) {
+	browser := DefaultProctoringProviders()[BrowserProctoringProvider]
+	required := []ProctoringCapability{CapabilityWebcam, CapabilityScreenRecording, CapabilityLockdown, CapabilityIdentityVerification}
+
+	want := []ProctoringCapability{CapabilityIdentityVerification, CapabilityScreenRecording}
+	if got := missingProctoringCapabilities(required, browser.Capabilities()); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got := missingProctoringCapabilities([]ProctoringCapability{CapabilityWebcam, CapabilityLockdown}, browser.Capabilities()); len(got) != 0 {
+		t.Errorf("the browser provider should cover webcam and lockdown, got %v", got)
+	}
+}
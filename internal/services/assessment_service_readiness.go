@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+const (
+	// Questions answered fewer times than this are estimated from their difficulty level
+	estimateMinResponses = 5
+
+	// Below this share of questions with history the estimate is flagged as rough
+	estimateMinCoverage = 0.5
+)
+
+// difficultyScorePriors is the expected share of points earned on questions without history
+var difficultyScorePriors = map[models.DifficultyLevel]float64{
+	models.DifficultyEasy:   0.8,
+	models.DifficultyMedium: 0.65,
+	models.DifficultyHard:   0.45,
+}
+
+// ===== READINESS REPORT =====
+
+func (s *assessmentService) GetReadinessReport(ctx context.Context, assessmentID uint, userID string) (*AssessmentReadinessReport, error) {
+	assessment, err := s.getOwnedAssessment(ctx, assessmentID, userID, "view_readiness")
+	if err != nil {
+		return nil, err
+	}
+
+	report := &AssessmentReadinessReport{
+		AssessmentID: assessmentID,
+		Blockers:     make([]ReadinessIssue, 0),
+		Warnings:     make([]ReadinessIssue, 0),
+		GeneratedAt:  time.Now(),
+	}
+
+	// Same checks Publish runs
+	if err := s.validateStatusTransition(ctx, assessment, models.StatusActive); err != nil {
+		var ruleErr *BusinessRuleError
+		if !errors.As(err, &ruleErr) {
+			return nil, err
+		}
+		report.Blockers = append(report.Blockers, ReadinessIssue{Code: ruleErr.Rule, Message: ruleErr.Message})
+	}
+	report.Ready = len(report.Blockers) == 0
+
+	assessmentQuestions, err := s.repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	questionList, err := s.repo.AssessmentQuestion().GetQuestionsForAssessment(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	questions := make(map[uint]*models.Question, len(questionList))
+	questionIDs := make([]uint, 0, len(questionList))
+	for _, question := range questionList {
+		questions[question.ID] = question
+		questionIDs = append(questionIDs, question.ID)
+	}
+
+	stats, err := s.repo.QuestionAnalytics().GetHistoricalStats(ctx, s.db, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	history := make(map[uint]repositories.QuestionHistoricalStats, len(stats))
+	for _, stat := range stats {
+		history[stat.QuestionID] = stat
+	}
+
+	report.Estimate = estimateAssessmentDifficulty(assessmentQuestions, questions, history)
+	report.Warnings = append(report.Warnings, estimateWarnings(assessment, report.Estimate)...)
+
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, s.db, assessmentID)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return nil, err
+	}
+	report.Warnings = append(report.Warnings, accessibilityWarnings(assessment, settings, questionList)...)
+
+	report.Grading, err = s.gradingWorkload(ctx, assessment, questionList)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Duplicates, err = s.findDuplicateAssessments(ctx, assessment, len(questionList))
+	if err != nil {
+		return nil, err
+	}
+	report.Warnings = append(report.Warnings, duplicateWarnings(report.Duplicates)...)
+
+	return report, nil
+}
+
+// ===== HELPER METHODS =====
+
+// estimateAssessmentDifficulty predicts the average score and completion time from each
+// question's past responses, falling back to its difficulty level when history is thin
+func estimateAssessmentDifficulty(assessmentQuestions []*models.AssessmentQuestion, questions map[uint]*models.Question, history map[uint]repositories.QuestionHistoricalStats) *AssessmentDifficultyEstimate {
+	estimate := &AssessmentDifficultyEstimate{
+		Questions: make([]QuestionDifficultyEstimate, 0, len(assessmentQuestions)),
+	}
+
+	historical := 0
+	for _, aq := range assessmentQuestions {
+		question, ok := questions[aq.QuestionID]
+		if !ok {
+			continue
+		}
+
+		item := QuestionDifficultyEstimate{
+			QuestionID: question.ID,
+			Points:     question.Points,
+			Difficulty: question.Difficulty,
+			Source:     EstimateFromDifficulty,
+		}
+		if aq.Points != nil {
+			item.Points = *aq.Points
+		}
+
+		prior, ok := difficultyScorePriors[question.Difficulty]
+		if !ok {
+			prior = difficultyScorePriors[models.DifficultyMedium]
+		}
+		item.ExpectedScoreRate = prior
+		item.ExpectedSeconds = questionSeconds(question)
+		if aq.TimeLimit != nil && *aq.TimeLimit > 0 {
+			item.ExpectedSeconds = *aq.TimeLimit
+		}
+
+		if stat, ok := history[question.ID]; ok {
+			item.Responses = stat.Responses
+			if stat.Responses > 0 {
+				correctRate := stat.CorrectRate
+				item.CorrectRate = &correctRate
+			}
+			if stat.ScoredResponses >= estimateMinResponses {
+				item.ExpectedScoreRate = stat.ScoreRate
+				item.Source = EstimateFromHistory
+				historical++
+			}
+			if stat.Responses >= estimateMinResponses && stat.AverageTimeSpent > 0 {
+				item.ExpectedSeconds = int(math.Round(stat.AverageTimeSpent))
+			}
+		}
+
+		estimate.TotalPoints += item.Points
+		estimate.ExpectedScore += item.ExpectedScoreRate * float64(item.Points)
+		estimate.ExpectedCompletionSeconds += item.ExpectedSeconds
+		estimate.Questions = append(estimate.Questions, item)
+	}
+
+	estimate.ExpectedScore = math.Round(estimate.ExpectedScore*10) / 10
+	if estimate.TotalPoints > 0 {
+		estimate.ExpectedPercentage = math.Round(estimate.ExpectedScore/float64(estimate.TotalPoints)*1000) / 10
+	}
+	if len(estimate.Questions) > 0 {
+		estimate.HistoricalCoverage = float64(historical) / float64(len(estimate.Questions))
+	}
+
+	return estimate
+}
+
+// estimateWarnings flags estimates the teacher may want to rebalance before going live
+func estimateWarnings(assessment *models.Assessment, estimate *AssessmentDifficultyEstimate) []ReadinessIssue {
+	warnings := make([]ReadinessIssue, 0)
+	if len(estimate.Questions) == 0 {
+		return warnings
+	}
+
+	expectedMinutes := int(math.Ceil(float64(estimate.ExpectedCompletionSeconds) / 60))
+	if assessment.Duration > 0 && expectedMinutes > assessment.Duration {
+		warnings = append(warnings, ReadinessIssue{
+			Code:    "QT-ESTIMATE-EXCEEDS-DURATION",
+			Message: fmt.Sprintf("Expected completion time of %d minutes exceeds the %d minute time limit", expectedMinutes, assessment.Duration),
+		})
+	}
+
+	if estimate.ExpectedPercentage < float64(assessment.PassingScore) {
+		warnings = append(warnings, ReadinessIssue{
+			Code:    "QT-ESTIMATE-BELOW-PASSING",
+			Message: fmt.Sprintf("Expected average score of %.1f%% is below the passing score of %d%%", estimate.ExpectedPercentage, assessment.PassingScore),
+		})
+	}
+
+	if estimate.HistoricalCoverage < estimateMinCoverage {
+		warnings = append(warnings, ReadinessIssue{
+			Code:    "QT-ESTIMATE-LIMITED-HISTORY",
+			Message: fmt.Sprintf("Only %.0f%% of questions have enough past responses; the estimate relies on difficulty levels", estimate.HistoricalCoverage*100),
+		})
+	}
+
+	return warnings
+}
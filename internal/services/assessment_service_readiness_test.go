@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestEstimateAssessmentDifficulty(t *testing.T) {
+	overridePoints, overrideTime := 20, 120
+	assessmentQuestions := []*models.AssessmentQuestion{
+		{QuestionID: 1},
+		{QuestionID: 2, Points: &overridePoints, TimeLimit: &overrideTime},
+		{QuestionID: 3},
+	}
+	questions := map[uint]*models.Question{
+		1: {ID: 1, Points: 10, Difficulty: models.DifficultyEasy},
+		2: {ID: 2, Points: 10, Difficulty: models.DifficultyHard},
+		3: {ID: 3, Points: 10, Difficulty: models.DifficultyMedium},
+	}
+	history := map[uint]repositories.QuestionHistoricalStats{
+		1: {QuestionID: 1, Responses: 40, ScoredResponses: 40, CorrectRate: 0.5, ScoreRate: 0.5, AverageTimeSpent: 45.4},
+		// Too few responses to trust
+		3: {QuestionID: 3, Responses: 2, ScoredResponses: 2, CorrectRate: 1, ScoreRate: 1, AverageTimeSpent: 10},
+	}
+
+	estimate := estimateAssessmentDifficulty(assessmentQuestions, questions, history)
+
+	if estimate.TotalPoints != 40 {
+		t.Fatalf("expected 40 total points, got %d", estimate.TotalPoints)
+	}
+	// 0.5*10 + 0.45*20 + 0.65*10
+	if estimate.ExpectedScore != 20.5 || estimate.ExpectedPercentage != 51.3 {
+		t.Errorf("unexpected score estimate: %v points, %v%%", estimate.ExpectedScore, estimate.ExpectedPercentage)
+	}
+	// 45 historical + 120 override + 90 default
+	if estimate.ExpectedCompletionSeconds != 255 {
+		t.Errorf("expected 255 seconds, got %d", estimate.ExpectedCompletionSeconds)
+	}
+
+	if first := estimate.Questions[0]; first.Source != EstimateFromHistory || first.CorrectRate == nil || *first.CorrectRate != 0.5 {
+		t.Errorf("question with history should use it, got %+v", first)
+	}
+	if third := estimate.Questions[2]; third.Source != EstimateFromDifficulty || third.Responses != 2 {
+		t.Errorf("question with thin history should use its difficulty, got %+v", third)
+	}
+	if coverage := estimate.HistoricalCoverage; coverage < 0.33 || coverage > 0.34 {
+		t.Errorf("expected a third of questions from history, got %v", coverage)
+	}
+}
+
+func TestEstimateWarnings(t *testing.T) {
+	assessment := &models.Assessment{Duration: 5, PassingScore: 60}
+	estimate := &AssessmentDifficultyEstimate{
+		ExpectedPercentage:        51.3,
+		ExpectedCompletionSeconds: 301,
+		HistoricalCoverage:        1,
+		Questions:                 []QuestionDifficultyEstimate{{QuestionID: 1}},
+	}
+
+	codes := make(map[string]bool)
+	for _, warning := range estimateWarnings(assessment, estimate) {
+		codes[warning.Code] = true
+	}
+	if !codes["QT-ESTIMATE-EXCEEDS-DURATION"] || !codes["QT-ESTIMATE-BELOW-PASSING"] || codes["QT-ESTIMATE-LIMITED-HISTORY"] {
+		t.Errorf("unexpected warnings: %v", codes)
+	}
+
+	if warnings := estimateWarnings(assessment, &AssessmentDifficultyEstimate{}); len(warnings) != 0 {
+		t.Errorf("empty assessments should not be warned about, got %+v", warnings)
+	}
+}
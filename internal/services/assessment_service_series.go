@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultSeriesCadenceDays = 7
+	scheduledPublishBatch    = 50
+)
+
+// seriesDifficultyOrder sorts questions before they are dealt out, so every quiz gets a similar mix
+var seriesDifficultyOrder = map[models.DifficultyLevel]int{
+	models.DifficultyEasy:   0,
+	models.DifficultyMedium: 1,
+	models.DifficultyHard:   2,
+}
+
+// ===== ASSESSMENT SERIES =====
+
+// GenerateSeries creates a run of draft quizzes from one bank, each scheduled to publish one
+// cadence after the previous and due when the next one opens. No question appears in more
+// than one quiz, and every quiz shares the template's settings. All quizzes are created in a
+// single transaction, so a failure leaves nothing behind.
+func (s *assessmentService) GenerateSeries(ctx context.Context, req *GenerateSeriesRequest, userID string) (*AssessmentSeriesResponse, error) {
+	s.logger.Info("Generating assessment series",
+		"bank_id", req.BankID,
+		"tags", req.Tags,
+		"quiz_count", req.QuizCount,
+		"user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if errs := validateSeriesRequest(req, time.Now()); len(errs) > 0 {
+		return nil, errs
+	}
+
+	canCreate, err := s.canCreateAssessment(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("permission check failed: %w", err)
+	}
+	if !canCreate {
+		return nil, NewPermissionError(userID, 0, "assessment", "create", "insufficient role permissions")
+	}
+
+	canAccess, err := s.repo.QuestionBank().CanAccess(ctx, nil, req.BankID, userID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionBankNotFound
+		}
+		return nil, fmt.Errorf("failed to check question bank access: %w", err)
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, req.BankID, "question_bank", "build_from", "not owner, not public, or not shared")
+	}
+
+	questions, _, err := s.repo.QuestionBank().GetBankQuestions(ctx, nil, req.BankID, repositories.QuestionFilters{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank questions: %w", err)
+	}
+	pool := seriesCandidates(questions, req.Tags)
+
+	needed := req.QuizCount * req.QuestionsPerQuiz
+	if len(pool) < needed {
+		return nil, ValidationErrors{*NewValidationError("questions_per_quiz",
+			fmt.Sprintf("the bank has %d matching questions, %d are needed", len(pool), needed), req.QuestionsPerQuiz)}
+	}
+
+	rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	selections := partitionSeriesQuestions(pool, req.QuizCount, req.QuestionsPerQuiz)
+
+	cadence := time.Duration(seriesCadenceDays(req)) * 24 * time.Hour
+	quizzes := make([]*CreateAssessmentRequest, req.QuizCount)
+	titles := make([]*titleResolution, req.QuizCount)
+	for i := range quizzes {
+		quizzes[i] = seriesQuizRequest(req, i, selections[i], cadence)
+		if errs := s.validator.GetBusinessValidator().ValidateAssessmentCreate(quizzes[i]); len(errs) > 0 {
+			return nil, errs
+		}
+		if titles[i], err = s.validateCreateRequest(ctx, quizzes[i], userID); err != nil {
+			return nil, err
+		}
+	}
+
+	tags, err := json.Marshal(req.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tags: %w", err)
+	}
+	series := &models.AssessmentSeries{
+		Title:            req.Assessment.Title,
+		BankID:           req.BankID,
+		Tags:             tags,
+		QuizCount:        req.QuizCount,
+		QuestionsPerQuiz: req.QuestionsPerQuiz,
+		CadenceDays:      seriesCadenceDays(req),
+		FirstPublishAt:   req.FirstPublishAt,
+		CreatedBy:        userID,
+	}
+
+	assessmentIDs := make([]uint, req.QuizCount)
+	err = s.withTx(ctx, func(tx *gorm.DB) error {
+		if err := s.repo.Assessment().CreateSeries(ctx, tx, series); err != nil {
+			return fmt.Errorf("failed to create assessment series: %w", err)
+		}
+
+		for i, quiz := range quizzes {
+			publishAt := req.FirstPublishAt.Add(time.Duration(i) * cadence)
+			assessment := newAssessmentModel(quiz, titles[i].title, userID)
+			assessment.SeriesID = &series.ID
+			assessment.PublishAt = &publishAt
+			if err := s.createAssessmentTx(ctx, tx, assessment, quiz, userID); err != nil {
+				return err
+			}
+			assessmentIDs[i] = assessment.ID
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Assessment series generated", "series_id", series.ID, "assessments", len(assessmentIDs))
+
+	response := &AssessmentSeriesResponse{Series: series}
+	for _, id := range assessmentIDs {
+		assessment, err := s.GetByIDWithDetails(ctx, id, userID)
+		if err != nil {
+			return nil, err
+		}
+		response.Assessments = append(response.Assessments, assessment)
+	}
+	return response, nil
+}
+
+// PublishScheduled publishes draft assessments whose publish time has passed, as their creator.
+// An assessment that fails the publish checks keeps its draft status and loses its schedule, so
+// it is not retried every run; the creator can fix it and publish by hand.
+func (s *assessmentService) PublishScheduled(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.repo.Assessment().GetDuePublishes(ctx, nil, now, scheduledPublishBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get scheduled publishes: %w", err)
+	}
+
+	published := 0
+	for _, assessment := range due {
+		if err := s.UpdateStatus(ctx, assessment.ID, &UpdateStatusRequest{
+			Status: models.StatusActive,
+			Reason: stringPtr("Published on schedule"),
+		}, assessment.CreatedBy); err != nil {
+			s.logger.Error("Scheduled publish failed", "assessment_id", assessment.ID, "error", err)
+		} else {
+			published++
+		}
+
+		if err := s.repo.Assessment().ClearPublishAt(ctx, nil, assessment.ID); err != nil {
+			s.logger.Error("Failed to clear publish time", "assessment_id", assessment.ID, "error", err)
+		}
+	}
+
+	if published > 0 {
+		s.logger.Info("Scheduled assessments published", "count", published)
+	}
+	return published, nil
+}
+
+// RunScheduler publishes scheduled assessments every interval until the context is cancelled
+func (s *assessmentService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.Info("Assessment publish scheduler started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Assessment publish scheduler stopped")
+			return
+		case now := <-ticker.C:
+			if _, err := s.PublishScheduled(ctx, now); err != nil {
+				s.logger.Error("Failed to run scheduled publishes", "error", err)
+			}
+		}
+	}
+}
+
+// ===== HELPER METHODS =====
+
+func validateSeriesRequest(req *GenerateSeriesRequest, now time.Time) ValidationErrors {
+	var errs ValidationErrors
+	if !req.FirstPublishAt.After(now) {
+		errs = append(errs, *NewValidationError("first_publish_at", "must be in the future", req.FirstPublishAt))
+	}
+	if req.Assessment.DueDate != nil {
+		errs = append(errs, *NewValidationError("assessment.due_date", "is set from the cadence for each quiz", req.Assessment.DueDate))
+	}
+	if len(req.Assessment.Questions) > 0 {
+		errs = append(errs, *NewValidationError("assessment.questions", "are selected from the bank for each quiz", len(req.Assessment.Questions)))
+	}
+	return errs
+}
+
+func seriesCadenceDays(req *GenerateSeriesRequest) int {
+	if req.CadenceDays > 0 {
+		return req.CadenceDays
+	}
+	return defaultSeriesCadenceDays
+}
+
+// seriesCandidates keeps the finalized questions carrying at least one of the tags, or every
+// finalized question when no tags are given
+func seriesCandidates(questions []*models.Question, tags []string) []*models.Question {
+	var pool []*models.Question
+	for _, question := range questions {
+		if question.IsDraft {
+			continue
+		}
+		if len(tags) > 0 && len(matchQuestionTags(question, tags)) == 0 {
+			continue
+		}
+		pool = append(pool, question)
+	}
+	return pool
+}
+
+// partitionSeriesQuestions takes count*perQuiz questions from the pool, orders them by
+// difficulty and deals them out in turn, so the quizzes never share a question and each
+// gets a similar spread of easy and hard questions
+func partitionSeriesQuestions(pool []*models.Question, count, perQuiz int) [][]*models.Question {
+	chosen := make([]*models.Question, count*perQuiz)
+	copy(chosen, pool)
+	sort.SliceStable(chosen, func(i, j int) bool {
+		return seriesDifficultyOrder[chosen[i].Difficulty] < seriesDifficultyOrder[chosen[j].Difficulty]
+	})
+
+	selections := make([][]*models.Question, count)
+	for i, question := range chosen {
+		selections[i%count] = append(selections[i%count], question)
+	}
+	return selections
+}
+
+// seriesQuizRequest copies the template for the i-th quiz, numbering its title and setting its
+// questions and its due date one cadence after it opens
+func seriesQuizRequest(req *GenerateSeriesRequest, i int, questions []*models.Question, cadence time.Duration) *CreateAssessmentRequest {
+	quiz := req.Assessment
+	quiz.Title = fmt.Sprintf("%s %d", req.Assessment.Title, i+1)
+
+	dueDate := req.FirstPublishAt.Add(time.Duration(i+1) * cadence)
+	quiz.DueDate = &dueDate
+
+	quiz.Questions = make([]AssessmentQuestionRequest, len(questions))
+	for j, question := range questions {
+		quiz.Questions[j] = AssessmentQuestionRequest{QuestionID: question.ID, Order: j + 1}
+	}
+	return &quiz
+}
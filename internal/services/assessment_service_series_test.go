@@ -0,0 +1,120 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+func TestSeriesCandidates(t *testing.T) {
+	questions := []*models.Question{
+		{ID: 1, Tags: datatypes.JSON(`["Algebra"]`)},
+		{ID: 2, Tags: datatypes.JSON(`["geometry"]`)},
+		{ID: 3, Tags: datatypes.JSON(`["algebra"]`), IsDraft: true},
+		{ID: 4, Tags: datatypes.JSON(`["algebra","geometry"]`)},
+	}
+
+	pool := seriesCandidates(questions, []string{"algebra"})
+	if len(pool) != 2 || pool[0].ID != 1 || pool[1].ID != 4 {
+		t.Errorf("expected finalized algebra questions 1 and 4, got %v", questionIDsOf(pool))
+	}
+
+	if all := seriesCandidates(questions, nil); len(all) != 3 {
+		t.Errorf("expected every finalized question without tags, got %v", questionIDsOf(all))
+	}
+}
+
+func TestPartitionSeriesQuestions(t *testing.T) {
+	pool := []*models.Question{
+		{ID: 1, Difficulty: models.DifficultyHard},
+		{ID: 2, Difficulty: models.DifficultyEasy},
+		{ID: 3, Difficulty: models.DifficultyMedium},
+		{ID: 4, Difficulty: models.DifficultyEasy},
+		{ID: 5, Difficulty: models.DifficultyHard},
+		{ID: 6, Difficulty: models.DifficultyMedium},
+		{ID: 7, Difficulty: models.DifficultyEasy},
+	}
+
+	selections := partitionSeriesQuestions(pool, 2, 3)
+	if len(selections) != 2 {
+		t.Fatalf("expected 2 quizzes, got %d", len(selections))
+	}
+
+	seen := make(map[uint]bool)
+	for i, quiz := range selections {
+		if len(quiz) != 3 {
+			t.Fatalf("quiz %d: expected 3 questions, got %d", i, len(quiz))
+		}
+		for _, question := range quiz {
+			if seen[question.ID] {
+				t.Errorf("question %d appears in more than one quiz", question.ID)
+			}
+			seen[question.ID] = true
+		}
+	}
+	if seen[7] {
+		t.Error("only the first count*perQuiz questions of the pool should be used")
+	}
+
+	// Sorted easy, easy, medium, medium, hard, hard and dealt in turn
+	for i, quiz := range selections {
+		if quiz[0].Difficulty != models.DifficultyEasy || quiz[1].Difficulty != models.DifficultyMedium || quiz[2].Difficulty != models.DifficultyHard {
+			t.Errorf("quiz %d: expected one question per difficulty, got %v", i, questionIDsOf(quiz))
+		}
+	}
+}
+
+func TestSeriesQuizRequest(t *testing.T) {
+	first := time.Date(2026, 9, 7, 8, 0, 0, 0, time.UTC)
+	req := &GenerateSeriesRequest{
+		FirstPublishAt: first,
+		Assessment:     CreateAssessmentRequest{Title: "Weekly Quiz", Duration: 20},
+	}
+	questions := []*models.Question{{ID: 10}, {ID: 11}}
+
+	quiz := seriesQuizRequest(req, 2, questions, 7*24*time.Hour)
+
+	if quiz.Title != "Weekly Quiz 3" || quiz.Duration != 20 {
+		t.Errorf("unexpected quiz template copy: %q, %d minutes", quiz.Title, quiz.Duration)
+	}
+	if want := first.AddDate(0, 0, 21); quiz.DueDate == nil || !quiz.DueDate.Equal(want) {
+		t.Errorf("expected due date %v, got %v", want, quiz.DueDate)
+	}
+	if len(quiz.Questions) != 2 || quiz.Questions[1].QuestionID != 11 || quiz.Questions[1].Order != 2 {
+		t.Errorf("unexpected questions: %+v", quiz.Questions)
+	}
+	if req.Assessment.Title != "Weekly Quiz" || req.Assessment.DueDate != nil || req.Assessment.Questions != nil {
+		t.Error("the template must not be modified")
+	}
+}
+
+func TestValidateSeriesRequest(t *testing.T) {
+	now := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	due := now.AddDate(0, 1, 0)
+
+	valid := &GenerateSeriesRequest{FirstPublishAt: now.Add(time.Hour)}
+	if errs := validateSeriesRequest(valid, now); len(errs) != 0 {
+		t.Errorf("expected no errors, got %v", errs)
+	}
+
+	invalid := &GenerateSeriesRequest{
+		FirstPublishAt: now,
+		Assessment: CreateAssessmentRequest{
+			DueDate:   &due,
+			Questions: []AssessmentQuestionRequest{{QuestionID: 1, Order: 1}},
+		},
+	}
+	if errs := validateSeriesRequest(invalid, now); len(errs) != 3 {
+		t.Errorf("expected 3 errors, got %v", errs)
+	}
+}
+
+func questionIDsOf(questions []*models.Question) []uint {
+	ids := make([]uint, len(questions))
+	for i, question := range questions {
+		ids[i] = question.ID
+	}
+	return ids
+}
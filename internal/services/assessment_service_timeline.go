@@ -0,0 +1,300 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+const assessmentAuditTarget = "assessment"
+
+// timelineFacts are the lifecycle moments not recorded as audit entries
+type timelineFacts struct {
+	FirstAttemptAt     *time.Time
+	FirstAttemptBy     string
+	GradingCompletedAt *time.Time
+	ResultsReleasedAt  *time.Time
+	ResultsReleasedBy  *string
+}
+
+// ===== TIMELINE =====
+
+// GetTimeline returns the assessment's lifecycle events, oldest first, with who caused each one
+func (s *assessmentService) GetTimeline(ctx context.Context, assessmentID uint, userID string) (*AssessmentTimeline, error) {
+	assessment, err := s.getOwnedAssessment(ctx, assessmentID, userID, "view_timeline")
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := s.repo.AuditLog().GetByTarget(ctx, s.db, assessmentAuditTarget, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	facts, err := s.timelineFacts(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := &AssessmentTimeline{
+		AssessmentID: assessment.ID,
+		Title:        assessment.Title,
+		Status:       assessment.Status,
+		Events:       buildAssessmentTimeline(assessment, logs, facts),
+		GeneratedAt:  time.Now(),
+	}
+
+	// Names are a convenience; the timeline is still useful with IDs only
+	actorIDs := make([]string, 0, len(timeline.Events))
+	for _, event := range timeline.Events {
+		if event.ActorID != "" {
+			actorIDs = append(actorIDs, event.ActorID)
+		}
+	}
+	if users, err := s.repo.User().GetByIDs(ctx, actorIDs); err != nil {
+		s.logger.Warn("Failed to load actor names for timeline", "assessment_id", assessmentID, "error", err)
+	} else {
+		byID := make(map[string]*models.User, len(users))
+		for _, user := range users {
+			byID[user.ID] = user
+		}
+		for i := range timeline.Events {
+			if user, ok := byID[timeline.Events[i].ActorID]; ok {
+				timeline.Events[i].ActorName = user.FullName
+				if timeline.Events[i].ActorRole == "" {
+					timeline.Events[i].ActorRole = user.Role
+				}
+			}
+		}
+	}
+
+	return timeline, nil
+}
+
+// timelineFacts collects the first attempt, grading completion and results release of an assessment
+func (s *assessmentService) timelineFacts(ctx context.Context, assessmentID uint) (timelineFacts, error) {
+	var facts timelineFacts
+
+	activity, err := s.repo.Attempt().GetStudentActivityByAssessment(ctx, s.db, assessmentID)
+	if err != nil {
+		return facts, err
+	}
+	for _, student := range activity {
+		if student.FirstStartedAt != nil && (facts.FirstAttemptAt == nil || student.FirstStartedAt.Before(*facts.FirstAttemptAt)) {
+			facts.FirstAttemptAt = student.FirstStartedAt
+			facts.FirstAttemptBy = student.StudentID
+		}
+	}
+
+	stats, err := s.repo.Answer().GetGradingStats(ctx, s.db, assessmentID)
+	if err != nil {
+		return facts, err
+	}
+	// Grading is complete once every answer given so far has a grade
+	if stats.TotalAnswers > 0 && stats.PendingAnswers == 0 {
+		facts.GradingCompletedAt = stats.LastGradedAt
+	}
+
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, s.db, assessmentID)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return facts, fmt.Errorf("failed to get assessment settings: %w", err)
+	}
+	if settings != nil {
+		facts.ResultsReleasedAt = settings.ResultsReleasedAt
+		facts.ResultsReleasedBy = settings.ResultsReleasedBy
+	}
+
+	return facts, nil
+}
+
+// recordAssessmentAudit appends an audit entry for a change a user made to an assessment.
+// The entry keeps the user ID alone when the user cannot be loaded.
+func (s *assessmentService) recordAssessmentAudit(ctx context.Context, tx *gorm.DB, event models.AuditEventType, assessmentID uint, userID, description string, changes map[string]interface{}) error {
+	entry := &models.AuditLog{
+		EventType:       event,
+		UserID:          userID,
+		TargetType:      assessmentAuditTarget,
+		TargetID:        &assessmentID,
+		Description:     description,
+		ComplianceLevel: "medium",
+	}
+	if user, err := s.repo.User().GetByID(ctx, userID); err == nil {
+		entry.UserEmail = user.Email
+		entry.UserRole = user.Role
+	}
+	if len(changes) > 0 {
+		encoded, err := json.Marshal(changes)
+		if err != nil {
+			return fmt.Errorf("failed to encode audit changes: %w", err)
+		}
+		entry.Changes = encoded
+	}
+	return s.repo.AuditLog().Create(ctx, tx, entry)
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// statusAuditEvent is the audit event recorded when an assessment moves to a status
+func statusAuditEvent(status models.AssessmentStatus) models.AuditEventType {
+	switch status {
+	case models.StatusActive:
+		return models.AuditAssessmentPublished
+	case models.StatusArchived:
+		return models.AuditAssessmentArchived
+	default:
+		return models.AuditAssessmentUpdated
+	}
+}
+
+// assessmentChanges lists the before and after values of the assessment fields an edit changed
+func assessmentChanges(before, after *models.Assessment) map[string]interface{} {
+	changes := make(map[string]interface{})
+	change := func(field string, old, new interface{}) {
+		changes[field] = map[string]interface{}{"before": old, "after": new}
+	}
+
+	if before.Title != after.Title {
+		change("title", before.Title, after.Title)
+	}
+	if !stringPtrEqual(before.Description, after.Description) {
+		change("description", before.Description, after.Description)
+	}
+	if before.Duration != after.Duration {
+		change("duration", before.Duration, after.Duration)
+	}
+	if before.PassingScore != after.PassingScore {
+		change("passing_score", before.PassingScore, after.PassingScore)
+	}
+	if before.MaxAttempts != after.MaxAttempts {
+		change("max_attempts", before.MaxAttempts, after.MaxAttempts)
+	}
+	if before.TimeWarning != after.TimeWarning {
+		change("time_warning", before.TimeWarning, after.TimeWarning)
+	}
+	if !timePtrEqual(before.DueDate, after.DueDate) {
+		change("due_date", before.DueDate, after.DueDate)
+	}
+	if before.DueTimezone != after.DueTimezone {
+		change("due_timezone", before.DueTimezone, after.DueTimezone)
+	}
+	if !stringPtrEqual(before.Term, after.Term) {
+		change("term", before.Term, after.Term)
+	}
+	return changes
+}
+
+// assessmentEditDescription names the parts of the assessment an edit changed
+func assessmentEditDescription(changes map[string]interface{}, req *UpdateAssessmentRequest) string {
+	fields := make([]string, 0, len(changes)+2)
+	for field := range changes {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	if req.Settings != nil {
+		fields = append(fields, "settings")
+	}
+	if req.CustomFields != nil {
+		fields = append(fields, "custom_fields")
+	}
+	if len(fields) == 0 {
+		return "Assessment edited without changes"
+	}
+	return "Assessment edited: " + strings.Join(fields, ", ")
+}
+
+// statusChangeDescription describes a status change with the reason given for it
+func statusChangeDescription(from, to models.AssessmentStatus, reason *string) string {
+	description := fmt.Sprintf("Status changed from %s to %s", from, to)
+	if reason != nil && *reason != "" {
+		description += ": " + *reason
+	}
+	return description
+}
+
+func timePtrEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// timelineEventTypes maps the audit events shown on the timeline to their timeline type
+var timelineEventTypes = map[models.AuditEventType]TimelineEventType{
+	models.AuditAssessmentCreated:   TimelineCreated,
+	models.AuditAssessmentUpdated:   TimelineEdited,
+	models.AuditAssessmentPublished: TimelinePublished,
+	models.AuditAssessmentArchived:  TimelineArchived,
+	models.AuditResultsReleased:     TimelineResultsReleased,
+}
+
+// buildAssessmentTimeline merges the assessment's audit entries with the facts kept elsewhere
+// into one chronological list. Creation and results release fall back to the assessment's own
+// records when they predate auditing.
+func buildAssessmentTimeline(assessment *models.Assessment, logs []*models.AuditLog, facts timelineFacts) []AssessmentTimelineEvent {
+	events := make([]AssessmentTimelineEvent, 0, len(logs)+4)
+	seen := make(map[TimelineEventType]bool)
+
+	for _, log := range logs {
+		eventType, ok := timelineEventTypes[log.EventType]
+		if !ok {
+			continue
+		}
+		seen[eventType] = true
+		events = append(events, AssessmentTimelineEvent{
+			Type:        eventType,
+			At:          log.CreatedAt,
+			ActorID:     log.UserID,
+			ActorRole:   log.UserRole,
+			Description: log.Description,
+			Changes:     log.Changes,
+		})
+	}
+
+	if !seen[TimelineCreated] {
+		events = append(events, AssessmentTimelineEvent{
+			Type:        TimelineCreated,
+			At:          assessment.CreatedAt,
+			ActorID:     assessment.CreatedBy,
+			Description: fmt.Sprintf("Assessment %q created", assessment.Title),
+		})
+	}
+	if facts.FirstAttemptAt != nil {
+		events = append(events, AssessmentTimelineEvent{
+			Type:        TimelineFirstAttempt,
+			At:          *facts.FirstAttemptAt,
+			ActorID:     facts.FirstAttemptBy,
+			ActorRole:   models.RoleStudent,
+			Description: "First attempt started",
+		})
+	}
+	if facts.GradingCompletedAt != nil {
+		events = append(events, AssessmentTimelineEvent{
+			Type:        TimelineGradingCompleted,
+			At:          *facts.GradingCompletedAt,
+			Description: "All submitted answers graded",
+		})
+	}
+	if !seen[TimelineResultsReleased] && facts.ResultsReleasedAt != nil {
+		event := AssessmentTimelineEvent{
+			Type:        TimelineResultsReleased,
+			At:          *facts.ResultsReleasedAt,
+			Description: "Results released",
+		}
+		if facts.ResultsReleasedBy != nil {
+			event.ActorID = *facts.ResultsReleasedBy
+		}
+		events = append(events, event)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].At.Before(events[j].At)
+	})
+	return events
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestBuildAssessmentTimeline(t *testing.T) {
+	created := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+	assessment := &models.Assessment{ID: 7, Title: "Midterm", CreatedBy: "t-1", CreatedAt: created}
+	id := assessment.ID
+	logs := []*models.AuditLog{
+		// Newest first, as the repository returns them
+		{EventType: models.AuditAssessmentArchived, UserID: "t-1", TargetID: &id, CreatedAt: created.Add(96 * time.Hour)},
+		{EventType: models.AuditResultsReleased, UserID: "system", TargetID: &id, CreatedAt: created.Add(72 * time.Hour)},
+		{EventType: models.AuditDataExported, UserID: "t-1", TargetID: &id, CreatedAt: created.Add(50 * time.Hour)},
+		{EventType: models.AuditAssessmentPublished, UserID: "t-1", TargetID: &id, CreatedAt: created.Add(2 * time.Hour)},
+		{EventType: models.AuditAssessmentUpdated, UserID: "admin-1", UserRole: models.RoleAdmin, TargetID: &id, CreatedAt: created.Add(time.Hour)},
+	}
+	firstAttempt := created.Add(24 * time.Hour)
+	graded := created.Add(48 * time.Hour)
+	released := created.Add(72 * time.Hour)
+	facts := timelineFacts{
+		FirstAttemptAt:     &firstAttempt,
+		FirstAttemptBy:     "s-1",
+		GradingCompletedAt: &graded,
+		ResultsReleasedAt:  &released,
+		ResultsReleasedBy:  stringPtr("system"),
+	}
+
+	events := buildAssessmentTimeline(assessment, logs, facts)
+
+	want := []TimelineEventType{
+		TimelineCreated, TimelineEdited, TimelinePublished, TimelineFirstAttempt,
+		TimelineGradingCompleted, TimelineResultsReleased, TimelineArchived,
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), events)
+	}
+	for i, w := range want {
+		if events[i].Type != w {
+			t.Errorf("position %d: expected %s, got %s", i, w, events[i].Type)
+		}
+		if i > 0 && events[i].At.Before(events[i-1].At) {
+			t.Errorf("position %d is out of order", i)
+		}
+	}
+	if events[0].ActorID != "t-1" || !events[0].At.Equal(created) {
+		t.Errorf("expected creation to fall back to the assessment, got %+v", events[0])
+	}
+	if events[1].ActorRole != models.RoleAdmin {
+		t.Errorf("expected the edit to keep the audited role, got %+v", events[1])
+	}
+	if events[3].ActorID != "s-1" || events[3].ActorRole != models.RoleStudent {
+		t.Errorf("expected the first attempt to name its student, got %+v", events[3])
+	}
+	if events[4].ActorID != "" {
+		t.Errorf("expected grading completion without an actor, got %+v", events[4])
+	}
+}
+
+func TestBuildAssessmentTimelineWithoutActivity(t *testing.T) {
+	created := time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC)
+	assessment := &models.Assessment{ID: 3, Title: "Quiz", CreatedBy: "t-1", CreatedAt: created}
+	logs := []*models.AuditLog{
+		{EventType: models.AuditAssessmentCreated, UserID: "t-1", Description: "audited", CreatedAt: created},
+	}
+	released := created.Add(time.Hour)
+
+	events := buildAssessmentTimeline(assessment, logs, timelineFacts{ResultsReleasedAt: &released, ResultsReleasedBy: stringPtr("t-1")})
+
+	if len(events) != 2 {
+		t.Fatalf("expected creation and results release, got %+v", events)
+	}
+	if events[0].Description != "audited" {
+		t.Errorf("expected the audited creation to replace the fallback, got %+v", events[0])
+	}
+	if events[1].Type != TimelineResultsReleased || events[1].ActorID != "t-1" {
+		t.Errorf("expected results release from the settings, got %+v", events[1])
+	}
+}
+
+func TestAssessmentChanges(t *testing.T) {
+	due := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	before := &models.Assessment{Title: "Quiz", Duration: 30, PassingScore: 60, Description: stringPtr("a"), DueDate: &due}
+	after := *before
+	after.Title = "Quiz 1"
+	after.Description = stringPtr("a")
+	sameDue := due.In(time.FixedZone("X", 3600))
+	after.DueDate = &sameDue
+	after.PassingScore = 70
+
+	changes := assessmentChanges(before, &after)
+
+	if len(changes) != 2 || changes["title"] == nil || changes["passing_score"] == nil {
+		t.Errorf("expected title and passing score changes, got %v", changes)
+	}
+	description := assessmentEditDescription(changes, &UpdateAssessmentRequest{Settings: &AssessmentSettingsRequest{}})
+	if description != "Assessment edited: passing_score, title, settings" {
+		t.Errorf("unexpected description %q", description)
+	}
+}
+
+func TestStatusAuditEvent(t *testing.T) {
+	cases := map[models.AssessmentStatus]models.AuditEventType{
+		models.StatusActive:   models.AuditAssessmentPublished,
+		models.StatusArchived: models.AuditAssessmentArchived,
+		models.StatusDraft:    models.AuditAssessmentUpdated,
+	}
+	for status, want := range cases {
+		if got := statusAuditEvent(status); got != want {
+			t.Errorf("%s: expected %s, got %s", status, want, got)
+		}
+	}
+}
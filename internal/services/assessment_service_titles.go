@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+const (
+	// How many free titles are offered when a title is taken
+	titleSuggestionCount = 3
+	// Longest title the validator accepts; suffixed suggestions must fit in it
+	maxAssessmentTitleLength = 200
+)
+
+// Matches a " (n)" suffix added by an earlier rename, so "Quiz (2)" suggests "Quiz (3)"
+var titleSuffixPattern = regexp.MustCompile(`^(.*\S) \((\d+)\)$`)
+
+// titleResolution is the outcome of checking a title against the creator's other assessments
+type titleResolution struct {
+	title    string           // Title to save, suffixed when auto-suffixing resolved a conflict
+	conflict *ValidationError // Set when the title is taken and was not auto-suffixed
+	warnings []string         // Same-titled assessments in other terms, allowed by organization policy
+}
+
+// resolveTitle checks a title for duplicates among the creator's assessments. Taken titles
+// are suffixed when autoSuffix is set and otherwise rejected with free titles suggested.
+func (s *assessmentService) resolveTitle(ctx context.Context, title string, term *string, autoSuffix bool, creatorID string, excludeID *uint) (*titleResolution, error) {
+	resolution := &titleResolution{title: title}
+
+	base := titleBase(title)
+	existing, err := s.repo.Assessment().GetByTitlePrefix(ctx, s.db, base, creatorID, excludeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check title uniqueness: %w", err)
+	}
+
+	conflict, otherTerms := classifyTitleConflict(existing, title, term, s.allowDuplicateTitlesAcrossTerms)
+	if !conflict {
+		if len(otherTerms) > 0 {
+			resolution.warnings = append(resolution.warnings,
+				fmt.Sprintf("you already have an assessment titled %q in %s", title, strings.Join(otherTerms, ", ")))
+		}
+		return resolution, nil
+	}
+
+	taken := make(map[string]bool, len(existing))
+	for _, assessment := range existing {
+		taken[assessment.Title] = true
+	}
+	suggestions := suggestTitles(base, taken, titleSuggestionCount)
+
+	if autoSuffix && len(suggestions) > 0 {
+		s.logger.Info("Renamed duplicate assessment title", "title", title, "renamed_to", suggestions[0], "creator_id", creatorID)
+		resolution.title = suggestions[0]
+		return resolution, nil
+	}
+
+	resolution.conflict = NewValidationError("title", "already exists", title)
+	resolution.conflict.Suggestions = suggestions
+	return resolution, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// classifyTitleConflict reports whether an existing assessment blocks the title. When the
+// organization allows duplicates across terms, only an assessment in the same term blocks
+// it; the terms of the others are returned so the creator can be warned.
+func classifyTitleConflict(existing []*models.Assessment, title string, term *string, allowAcrossTerms bool) (bool, []string) {
+	var otherTerms []string
+	for _, assessment := range existing {
+		if assessment.Title != title {
+			continue
+		}
+		if !allowAcrossTerms || !differentTerms(assessment.Term, term) {
+			return true, nil
+		}
+		otherTerms = append(otherTerms, strings.TrimSpace(*assessment.Term))
+	}
+	return false, otherTerms
+}
+
+// differentTerms is true only when both assessments name a term and the terms differ;
+// assessments without a term never count as being in another term
+func differentTerms(a, b *string) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	return !strings.EqualFold(strings.TrimSpace(*a), strings.TrimSpace(*b))
+}
+
+// titleBase strips a " (n)" suffix from a title
+func titleBase(title string) string {
+	if match := titleSuffixPattern.FindStringSubmatch(title); match != nil {
+		return match[1]
+	}
+	return title
+}
+
+// suggestTitles returns up to count free "base (n)" titles, starting at n = 2. The base is
+// shortened when needed so suggestions stay within the title length limit.
+func suggestTitles(base string, taken map[string]bool, count int) []string {
+	suggestions := make([]string, 0, count)
+	for n := 2; len(suggestions) < count && n < count+len(taken)+2; n++ {
+		suffix := " (" + strconv.Itoa(n) + ")"
+		runes := []rune(base)
+		if limit := maxAssessmentTitleLength - len(suffix); len(runes) > limit {
+			runes = runes[:limit]
+		}
+		candidate := strings.TrimRight(string(runes), " ") + suffix
+		if !taken[candidate] {
+			suggestions = append(suggestions, candidate)
+		}
+	}
+	return suggestions
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func titledAssessment(title string, term *string) *models.Assessment {
+	return &models.Assessment{Title: title, Term: term}
+}
+
+func TestClassifyTitleConflict(t *testing.T) {
+	fall, spring := stringPtr("2025 Fall"), stringPtr("2026 Spring")
+	existing := []*models.Assessment{
+		titledAssessment("Midterm", fall),
+		titledAssessment("Midterm (2)", spring),
+	}
+
+	if conflict, _ := classifyTitleConflict(existing, "Midterm", spring, false); !conflict {
+		t.Error("duplicates should be rejected without the organization policy")
+	}
+	if conflict, _ := classifyTitleConflict(existing, "Midterm", stringPtr(" 2025 fall "), true); !conflict {
+		t.Error("duplicates in the same term should be rejected")
+	}
+	if conflict, _ := classifyTitleConflict(existing, "Midterm", nil, true); !conflict {
+		t.Error("an assessment without a term should not count as another term")
+	}
+
+	conflict, otherTerms := classifyTitleConflict(existing, "Midterm", spring, true)
+	if conflict || !reflect.DeepEqual(otherTerms, []string{"2025 Fall"}) {
+		t.Errorf("expected a warning for 2025 Fall, got conflict %v, terms %v", conflict, otherTerms)
+	}
+
+	if conflict, otherTerms := classifyTitleConflict(existing, "Final", fall, false); conflict || otherTerms != nil {
+		t.Errorf("unused title: conflict %v, terms %v", conflict, otherTerms)
+	}
+}
+
+func TestSuggestTitles(t *testing.T) {
+	taken := map[string]bool{"Quiz": true, "Quiz (2)": true, "Quiz (4)": true}
+	got := suggestTitles(titleBase("Quiz (2)"), taken, 3)
+	want := []string{"Quiz (3)", "Quiz (5)", "Quiz (6)"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("suggestTitles = %v, want %v", got, want)
+	}
+
+	long := strings.Repeat("a", maxAssessmentTitleLength)
+	for _, suggestion := range suggestTitles(long, map[string]bool{long: true}, 3) {
+		if len([]rune(suggestion)) > maxAssessmentTitleLength {
+			t.Errorf("suggestion %q is over the title limit", suggestion)
+		}
+	}
+}
+
+func TestTitleBase(t *testing.T) {
+	cases := map[string]string{
+		"Quiz (2)":        "Quiz",
+		"Quiz":            "Quiz",
+		"Chapter (intro)": "Chapter (intro)",
+		"(3)":             "(3)",
+	}
+	for title, want := range cases {
+		if got := titleBase(title); got != want {
+			t.Errorf("titleBase(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// Responses each side of a trial needs before the comparison recommends anything
+const trialMinResponses = 30
+
+// ===== QUESTION TRIALS =====
+
+// StartQuestionTrial pilots a revision of one of the assessment's questions: from now on the
+// given fraction of new attempts is served the revision in the original's place
+func (s *assessmentService) StartQuestionTrial(ctx context.Context, assessmentID uint, req *StartQuestionTrialRequest, userID string) (*models.QuestionTrial, error) {
+	s.logger.Info("Starting question trial",
+		"assessment_id", assessmentID,
+		"question_id", req.QuestionID,
+		"variant_question_id", req.VariantQuestionID,
+		"fraction", req.Fraction,
+		"user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if req.VariantQuestionID == req.QuestionID {
+		return nil, NewValidationError("variant_question_id", "the revision must be a different question", req.VariantQuestionID)
+	}
+
+	canEdit, err := s.CanEdit(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEdit {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "start_question_trial", "not owner or assessment not editable")
+	}
+
+	link, err := s.repo.AssessmentQuestion().GetQuestionAssessmentByAssessmentIdAndQuestionId(ctx, s.db, assessmentID, req.QuestionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, NewValidationError("question_id", "question is not part of the assessment", req.QuestionID)
+		}
+		return nil, fmt.Errorf("failed to get assessment question: %w", err)
+	}
+	// Branching rules match the original's options, which a revision may not have
+	if len(link.BranchRules) > 0 {
+		return nil, NewBusinessRuleError("QT-TRIAL-BRANCHING", "Questions with branching rules cannot be trialled", map[string]interface{}{
+			"question_id": req.QuestionID,
+		})
+	}
+
+	original, err := s.repo.Question().GetByID(ctx, s.db, req.QuestionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	revision, err := s.repo.Question().GetByID(ctx, s.db, req.VariantQuestionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, NewValidationError("variant_question_id", "question not found", req.VariantQuestionID)
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	canAccess, err := NewQuestionService(s.repo, s.db, s.logger, s.validator).CanAccess(ctx, revision.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, revision.ID, "question", "start_question_trial", "not owner or insufficient permissions")
+	}
+	if err := checkTrialRevision(original, revision); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.AssessmentQuestion().GetQuestionAssessmentByAssessmentIdAndQuestionId(ctx, s.db, assessmentID, revision.ID); err == nil {
+		return nil, NewBusinessRuleError("QT-TRIAL-IN-ASSESSMENT", "The revision is already part of the assessment", map[string]interface{}{
+			"variant_question_id": revision.ID,
+		})
+	} else if !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get assessment question: %w", err)
+	}
+
+	running, err := s.repo.QuestionTrial().GetRunningByAssessment(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, trial := range running {
+		if trial.QuestionID == req.QuestionID {
+			return nil, NewBusinessRuleError("QT-TRIAL-RUNNING", "A trial is already running for this question", map[string]interface{}{
+				"trial_id": trial.ID,
+			})
+		}
+	}
+
+	trial := &models.QuestionTrial{
+		AssessmentID:      assessmentID,
+		QuestionID:        req.QuestionID,
+		VariantQuestionID: req.VariantQuestionID,
+		Fraction:          req.Fraction,
+		Status:            models.QuestionTrialRunning,
+		CreatedBy:         userID,
+	}
+	if err := s.repo.QuestionTrial().Create(ctx, s.db, trial); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Question trial started", "trial_id", trial.ID, "assessment_id", assessmentID)
+	return trial, nil
+}
+
+// ListQuestionTrials returns the assessment's trials, newest first
+func (s *assessmentService) ListQuestionTrials(ctx context.Context, assessmentID uint, userID string) ([]*models.QuestionTrial, error) {
+	canAccess, err := s.CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "view_question_trials", "not owner or insufficient permissions")
+	}
+
+	return s.repo.QuestionTrial().GetByAssessment(ctx, s.db, assessmentID)
+}
+
+// CompareQuestionTrial sets the revision's item statistics against the original's, both
+// taken from attempts started while the trial ran
+func (s *assessmentService) CompareQuestionTrial(ctx context.Context, assessmentID, trialID uint, userID string) (*QuestionTrialComparison, error) {
+	canAccess, err := s.CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "view_question_trials", "not owner or insufficient permissions")
+	}
+
+	trial, err := s.getQuestionTrial(ctx, assessmentID, trialID)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := s.repo.QuestionAnalytics().GetTrialResponses(ctx, s.db, trial, false)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.repo.QuestionAnalytics().GetTrialResponses(ctx, s.db, trial, true)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &QuestionTrialComparison{
+		Trial:    trial,
+		Original: trialArmStats(trial.QuestionID, original),
+		Variant:  trialArmStats(trial.VariantQuestionID, revision),
+	}
+	comparison.Recommendation = recommendTrial(comparison.Original, comparison.Variant)
+	return comparison, nil
+}
+
+// PromoteQuestionTrial ends a trial by making the revision the question: its content replaces
+// the original's, so every assessment using the question serves it from now on. Answers
+// already given keep the grades they got.
+func (s *assessmentService) PromoteQuestionTrial(ctx context.Context, assessmentID, trialID uint, userID string) (*models.QuestionTrial, error) {
+	trial, err := s.endQuestionTrial(ctx, assessmentID, trialID, userID, models.QuestionTrialPromoted, func(tx *gorm.DB, trial *models.QuestionTrial) error {
+		original, err := s.repo.Question().GetByID(ctx, tx, trial.QuestionID)
+		if err != nil {
+			return fmt.Errorf("failed to get question: %w", err)
+		}
+		revision, err := s.repo.Question().GetByID(ctx, tx, trial.VariantQuestionID)
+		if err != nil {
+			return fmt.Errorf("failed to get trial question: %w", err)
+		}
+		if err := checkTrialRevision(original, revision); err != nil {
+			return err
+		}
+		lock, err := acquireQuestionContentLock(ctx, s.repo, userID, "promote_question_trial", trial.QuestionID)
+		if err != nil {
+			return err
+		}
+
+		promoteRevision(original, revision)
+		if err := s.repo.Question().Update(ctx, tx, original); err != nil {
+			return fmt.Errorf("failed to update question: %w", err)
+		}
+		return lock.release(ctx, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Question trial promoted", "trial_id", trial.ID, "question_id", trial.QuestionID)
+	return trial, nil
+}
+
+// DiscardQuestionTrial ends a trial and keeps the original. Attempts already served the
+// revision keep it until they finish.
+func (s *assessmentService) DiscardQuestionTrial(ctx context.Context, assessmentID, trialID uint, userID string) (*models.QuestionTrial, error) {
+	trial, err := s.endQuestionTrial(ctx, assessmentID, trialID, userID, models.QuestionTrialDiscarded, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Question trial discarded", "trial_id", trial.ID, "question_id", trial.QuestionID)
+	return trial, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (s *assessmentService) getQuestionTrial(ctx context.Context, assessmentID, trialID uint) (*models.QuestionTrial, error) {
+	trial, err := s.repo.QuestionTrial().GetByID(ctx, s.db, trialID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get question trial: %w", err)
+	}
+	if trial.AssessmentID != assessmentID {
+		return nil, ErrNotFound
+	}
+	return trial, nil
+}
+
+// endQuestionTrial closes a running trial with the given status, running apply in the same
+// transaction
+func (s *assessmentService) endQuestionTrial(ctx context.Context, assessmentID, trialID uint, userID string, status models.QuestionTrialStatus, apply func(tx *gorm.DB, trial *models.QuestionTrial) error) (*models.QuestionTrial, error) {
+	canEdit, err := s.CanEdit(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEdit {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "end_question_trial", "not owner or assessment not editable")
+	}
+
+	trial, err := s.getQuestionTrial(ctx, assessmentID, trialID)
+	if err != nil {
+		return nil, err
+	}
+	if trial.Status != models.QuestionTrialRunning {
+		return nil, NewBusinessRuleError("QT-TRIAL-ENDED", "The trial has already ended", map[string]interface{}{
+			"trial_id": trial.ID,
+			"status":   trial.Status,
+		})
+	}
+
+	now := time.Now()
+	trial.Status = status
+	trial.EndedBy = &userID
+	trial.EndedAt = &now
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if apply != nil {
+			if err := apply(tx, trial); err != nil {
+				return err
+			}
+		}
+		return s.repo.QuestionTrial().Update(ctx, tx, trial)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return trial, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// checkTrialRevision makes sure a revision can stand in for the original
+func checkTrialRevision(original, revision *models.Question) error {
+	if revision.Type != original.Type {
+		return NewBusinessRuleError("QT-TRIAL-TYPE", "The revision must have the same question type as the original", map[string]interface{}{
+			"question_type": original.Type,
+			"variant_type":  revision.Type,
+		})
+	}
+	return nil
+}
+
+// promoteRevision copies what a student sees and is graded on from the revision to the original
+func promoteRevision(original, revision *models.Question) {
+	original.Text = revision.Text
+	original.Content = revision.Content
+	original.Answer = revision.Answer
+	original.Points = revision.Points
+	original.TimeLimit = revision.TimeLimit
+	original.Difficulty = revision.Difficulty
+	original.Explanation = revision.Explanation
+}
+
+// trialArmStats summarises one side of a trial. Discrimination compares the correct rate of
+// the highest and lowest scoring quarter of attempts, as in distractor analysis.
+func trialArmStats(questionID uint, responses []repositories.ScoredResponse) TrialArmStats {
+	stats := TrialArmStats{QuestionID: questionID, Responses: len(responses)}
+	if len(responses) == 0 {
+		return stats
+	}
+
+	sorted := make([]repositories.ScoredResponse, len(responses))
+	copy(sorted, responses)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].AttemptPercentage < sorted[j].AttemptPercentage
+	})
+
+	correctRate := func(group []repositories.ScoredResponse) float64 {
+		if len(group) == 0 {
+			return 0
+		}
+		correct := 0
+		for _, response := range group {
+			if response.IsCorrect != nil && *response.IsCorrect {
+				correct++
+			}
+		}
+		return float64(correct) / float64(len(group))
+	}
+
+	totalScore, totalTime := 0.0, 0
+	for _, response := range sorted {
+		totalScore += response.Score
+		totalTime += response.TimeSpent
+	}
+	stats.CorrectRate = correctRate(sorted)
+	stats.AverageScore = totalScore / float64(len(sorted))
+	stats.AverageTimeSpent = totalTime / len(sorted)
+
+	groupSize := len(sorted) / 4
+	stats.DiscriminationIndex = correctRate(sorted[len(sorted)-groupSize:]) - correctRate(sorted[:groupSize])
+	return stats
+}
+
+// recommendTrial favours the revision when it separates strong and weak students at least as
+// well as the original and well enough not to need a review itself
+func recommendTrial(original, variant TrialArmStats) TrialRecommendation {
+	if original.Responses < trialMinResponses || variant.Responses < trialMinResponses {
+		return TrialCollectMore
+	}
+	if variant.DiscriminationIndex >= original.DiscriminationIndex && variant.DiscriminationIndex >= itemReviewDiscrimination {
+		return TrialPromote
+	}
+	return TrialKeepOriginal
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func trialResponses(correct []bool) []repositories.ScoredResponse {
+	responses := make([]repositories.ScoredResponse, len(correct))
+	for i := range correct {
+		isCorrect := correct[i]
+		responses[i] = repositories.ScoredResponse{
+			AttemptID:         uint(i + 1),
+			IsCorrect:         &isCorrect,
+			AttemptPercentage: float64(i * 10),
+			TimeSpent:         60,
+		}
+		if isCorrect {
+			responses[i].Score = 10
+		}
+	}
+	return responses
+}
+
+func TestTrialArmStats(t *testing.T) {
+	// Ordered from the weakest attempt to the strongest
+	stats := trialArmStats(48, trialResponses([]bool{false, false, true, false, true, true, true, true}))
+
+	if stats.QuestionID != 48 || stats.Responses != 8 {
+		t.Fatalf("expected question 48 with 8 responses, got %d with %d", stats.QuestionID, stats.Responses)
+	}
+	if stats.CorrectRate != 0.625 {
+		t.Errorf("expected a correct rate of 0.625, got %v", stats.CorrectRate)
+	}
+	if stats.DiscriminationIndex != 1 {
+		t.Errorf("expected the top quarter all correct and the bottom all wrong, got %v", stats.DiscriminationIndex)
+	}
+	if stats.AverageScore != 6.25 || stats.AverageTimeSpent != 60 {
+		t.Errorf("expected 6.25 points in 60 seconds on average, got %v in %d", stats.AverageScore, stats.AverageTimeSpent)
+	}
+}
+
+func TestTrialArmStatsNoResponses(t *testing.T) {
+	stats := trialArmStats(12, nil)
+	if stats.Responses != 0 || stats.CorrectRate != 0 || stats.DiscriminationIndex != 0 {
+		t.Errorf("expected empty statistics, got %+v", stats)
+	}
+}
+
+func TestRecommendTrial(t *testing.T) {
+	arm := func(responses int, discrimination float64) TrialArmStats {
+		return TrialArmStats{Responses: responses, DiscriminationIndex: discrimination}
+	}
+
+	cases := []struct {
+		name     string
+		original TrialArmStats
+		variant  TrialArmStats
+		want     TrialRecommendation
+	}{
+		{"too few revision responses", arm(200, 0.1), arm(10, 0.6), TrialCollectMore},
+		{"revision discriminates better", arm(200, 0.25), arm(40, 0.4), TrialPromote},
+		{"revision discriminates worse", arm(200, 0.4), arm(40, 0.3), TrialKeepOriginal},
+		{"both discriminate poorly", arm(200, 0.05), arm(40, 0.1), TrialKeepOriginal},
+	}
+	for _, tc := range cases {
+		if got := recommendTrial(tc.original, tc.variant); got != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestCheckTrialRevision(t *testing.T) {
+	original := &models.Question{ID: 12, Type: models.MultipleChoice}
+
+	if err := checkTrialRevision(original, &models.Question{ID: 48, Type: models.MultipleChoice}); err != nil {
+		t.Errorf("expected a revision of the same type to be accepted, got %v", err)
+	}
+	if err := checkTrialRevision(original, &models.Question{ID: 49, Type: models.TrueFalse}); !IsBusinessRule(err) {
+		t.Errorf("expected a business rule error for a different type, got %v", err)
+	}
+}
+
+func TestPromoteRevisionKeepsIdentity(t *testing.T) {
+	explanation := "Paris has been the capital since 987"
+	original := &models.Question{ID: 12, Type: models.MultipleChoice, Text: "Capital of France?", Points: 5, CreatedBy: "teacher-1"}
+	revision := &models.Question{ID: 48, Type: models.MultipleChoice, Text: "Which city is the capital of France?", Points: 10, Explanation: &explanation, CreatedBy: "teacher-2"}
+
+	promoteRevision(original, revision)
+
+	if original.ID != 12 || original.CreatedBy != "teacher-1" {
+		t.Errorf("expected the original to keep its ID and owner, got %d and %s", original.ID, original.CreatedBy)
+	}
+	if original.Text != revision.Text || original.Points != 10 || original.Explanation != &explanation {
+		t.Errorf("expected the revision's content on the original, got %+v", original)
+	}
+}
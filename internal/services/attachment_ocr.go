@@ -0,0 +1,48 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// OCREngine extracts text from an image of handwritten or printed work
+type OCREngine interface {
+	ExtractText(ctx context.Context, path string) (string, error)
+}
+
+type tesseractOCREngine struct {
+	binary   string
+	language string
+}
+
+// NewTesseractOCREngine runs the tesseract command line tool; language is a tesseract
+// language code such as "eng", empty for the tool's default
+func NewTesseractOCREngine(binary, language string) OCREngine {
+	return &tesseractOCREngine{
+		binary:   binary,
+		language: language,
+	}
+}
+
+func (e *tesseractOCREngine) ExtractText(ctx context.Context, path string) (string, error) {
+	args := []string{path, "stdout"}
+	if e.language != "" {
+		args = append(args, "-l", e.language)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.binary, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("failed to recognize text: %w: %s", err, message)
+		}
+		return "", fmt.Errorf("failed to recognize text: %w", err)
+	}
+
+	return stdout.String(), nil
+}
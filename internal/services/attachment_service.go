@@ -0,0 +1,602 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxAttachmentsPerAnswer = 5
+
+	// Text recognition is retried this many times before an attachment stays failed
+	ocrMaxAttempts = 3
+	// An attachment processing for this long is treated as orphaned by a crash
+	ocrStaleAfter = 5 * time.Minute
+	ocrBatchSize  = 20
+	ocrTimeout    = 2 * time.Minute
+
+	// Uploads sharing at least this share of word sequences are reported as similar
+	attachmentSimilarityThreshold = 0.3
+	attachmentSearchLimit         = 50
+	attachmentSnippetRadius       = 60
+)
+
+// attachmentContentTypes maps accepted upload extensions to their content type
+var attachmentContentTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".tif":  "image/tiff",
+	".tiff": "image/tiff",
+	".webp": "image/webp",
+}
+
+// attachmentQuestionTypes are the question types students may upload written work for
+var attachmentQuestionTypes = map[models.QuestionType]bool{
+	models.Essay:       true,
+	models.ShortAnswer: true,
+}
+
+type attachmentService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+	storage   StorageDirs
+	mediaURL  string    // Base URL question images are served from
+	ocr       OCREngine // nil disables text recognition
+}
+
+func NewAttachmentService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, storage StorageDirs, mediaCDNBaseURL string, ocr OCREngine) AttachmentService {
+	mediaURL := mediaCDNBaseURL
+	if mediaURL == "" {
+		mediaURL = questionMediaPath
+	}
+	return &attachmentService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+		storage:   storage,
+		mediaURL:  mediaURL,
+		ocr:       ocr,
+	}
+}
+
+// ===== STUDENT UPLOADS =====
+
+func (s *attachmentService) UploadAttachment(ctx context.Context, attemptID, questionID uint, file io.Reader, filename string, studentID string) (*models.AnswerAttachment, error) {
+	s.logger.Info("Uploading answer attachment",
+		"attempt_id", attemptID,
+		"question_id", questionID,
+		"filename", filename,
+		"student_id", studentID)
+
+	ext, contentType, err := attachmentContentType(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt, err := s.getAttempt(ctx, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	if attempt.StudentID != studentID {
+		return nil, NewPermissionError(studentID, attemptID, "attempt", "upload_attachment", "not owned by student")
+	}
+	if attempt.Status != models.AttemptInProgress {
+		return nil, ErrAttemptNotActive
+	}
+
+	if err := s.checkAttachmentQuestion(ctx, attempt.AssessmentID, questionID); err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.AnswerAttachment().CountByAttemptAndQuestion(ctx, nil, attemptID, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxAttachmentsPerAnswer {
+		return nil, NewBusinessRuleError("attachment_limit", "too many files uploaded for this question", map[string]interface{}{
+			"question_id": questionID,
+			"max_files":   maxAttachmentsPerAnswer,
+		})
+	}
+
+	storageDir, err := s.storage.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(storageDir, fmt.Sprintf("attempt-%d", attemptID))
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create attachment storage: %w", err)
+	}
+	path := filepath.Join(dir, uuid.NewString()+ext)
+	size, err := storeAttachmentFile(path, file)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &models.AnswerAttachment{
+		AttemptID:   attemptID,
+		QuestionID:  questionID,
+		FileName:    filepath.Base(filename),
+		ContentType: contentType,
+		FileSize:    size,
+		FilePath:    path,
+		OCRStatus:   models.OCRPending,
+	}
+	if err := s.repo.AnswerAttachment().Create(ctx, nil, attachment); err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	recordUsage(ctx, s.repo, s.logger, &models.UsageEvent{
+		Metric:       models.UsageAttachmentStorage,
+		Quantity:     size,
+		UserID:       studentID,
+		AssessmentID: &attempt.AssessmentID,
+		AttemptID:    &attempt.ID,
+	})
+
+	s.processOCRAsync(ctx, attachment.ID)
+
+	return attachment, nil
+}
+
+func (s *attachmentService) ListAttachments(ctx context.Context, attemptID, questionID uint, userID string) ([]*models.AnswerAttachment, error) {
+	attempt, err := s.getAttempt(ctx, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAttachmentAccess(ctx, attempt, userID, "view_attachments"); err != nil {
+		return nil, err
+	}
+
+	return s.repo.AnswerAttachment().GetByAttemptAndQuestion(ctx, nil, attemptID, questionID)
+}
+
+func (s *attachmentService) GetAttachmentFile(ctx context.Context, id uint, userID string) (*models.AnswerAttachment, error) {
+	attachment, err := s.getAttachment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAttachmentAccess(ctx, &attachment.Attempt, userID, "view_attachment"); err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// ===== GRADING =====
+
+// GetAttachmentsForGrading returns a question's uploads in an attempt, each with its
+// recognised text and uploads from other attempts whose text overlaps with it
+func (s *attachmentService) GetAttachmentsForGrading(ctx context.Context, attemptID, questionID uint, userID string) (*AnswerAttachmentsForGrading, error) {
+	attempt, err := s.getAttempt(ctx, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkGradingAccess(ctx, attempt.AssessmentID, userID, "grade_attachments"); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.repo.AnswerAttachment().GetByAttemptAndQuestion(ctx, nil, attemptID, questionID)
+	if err != nil {
+		return nil, err
+	}
+	recognized, err := s.repo.AnswerAttachment().GetRecognizedByQuestion(ctx, nil, attempt.AssessmentID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AnswerAttachmentsForGrading{
+		AttemptID:   attemptID,
+		QuestionID:  questionID,
+		Attachments: make([]AttachmentForGrading, 0, len(attachments)),
+	}
+
+	answer, err := s.repo.Answer().GetByAttemptAndQuestion(ctx, nil, attemptID, questionID)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get answer: %w", err)
+	}
+	if answer != nil {
+		result.Answer = json.RawMessage(answer.Answer)
+	}
+
+	for _, attachment := range attachments {
+		result.Attachments = append(result.Attachments, AttachmentForGrading{
+			Attachment: attachment,
+			FileURL:    attachmentFileURL(attachment.ID),
+			Similar:    findSimilarAttachments(attachment, recognized, attachmentSimilarityThreshold),
+		})
+	}
+
+	return result, nil
+}
+
+func (s *attachmentService) SearchAttachments(ctx context.Context, assessmentID uint, query string, userID string) ([]AttachmentSearchResult, error) {
+	query = strings.TrimSpace(query)
+	if len([]rune(query)) < 2 {
+		return nil, NewValidationError("q", "search text must be at least 2 characters", query)
+	}
+
+	if err := s.checkGradingAccess(ctx, assessmentID, userID, "search_attachments"); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.repo.AnswerAttachment().SearchText(ctx, nil, assessmentID, query, attachmentSearchLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]AttachmentSearchResult, 0, len(attachments))
+	for _, attachment := range attachments {
+		if attachment.OCRText == nil {
+			continue
+		}
+		results = append(results, AttachmentSearchResult{
+			AttachmentID: attachment.ID,
+			AttemptID:    attachment.AttemptID,
+			QuestionID:   attachment.QuestionID,
+			Snippet:      searchSnippet(*attachment.OCRText, query, attachmentSnippetRadius),
+			FileURL:      attachmentFileURL(attachment.ID),
+		})
+	}
+
+	return results, nil
+}
+
+// RerunOCR queues an attachment for text recognition again, e.g. after a failure
+func (s *attachmentService) RerunOCR(ctx context.Context, id uint, userID string) (*models.AnswerAttachment, error) {
+	s.logger.Info("Re-running text recognition", "attachment_id", id, "user_id", userID)
+
+	attachment, err := s.getAttachment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkGradingAccess(ctx, attachment.Attempt.AssessmentID, userID, "rerun_ocr"); err != nil {
+		return nil, err
+	}
+	if attachment.OCRStatus == models.OCRProcessing {
+		return nil, NewBusinessRuleError("ocr_in_progress", "text recognition is already running for this file", map[string]interface{}{
+			"attachment_id": id,
+		})
+	}
+
+	attachment.OCRStatus = models.OCRPending
+	attachment.OCRError = nil
+	attachment.OCRAttempts = 0
+	if err := s.repo.AnswerAttachment().Update(ctx, nil, attachment); err != nil {
+		return nil, err
+	}
+
+	s.processOCRAsync(ctx, id)
+
+	return attachment, nil
+}
+
+// ===== TEXT RECOGNITION =====
+
+// ProcessPendingOCR recognises text on new uploads, retries failed ones and picks up ones
+// orphaned mid-way by a crash
+func (s *attachmentService) ProcessPendingOCR(ctx context.Context, limit int) (int, error) {
+	if s.ocr == nil {
+		return 0, nil
+	}
+
+	ids, err := s.repo.AnswerAttachment().GetPendingOCR(ctx, nil, time.Now().Add(-ocrStaleAfter), ocrMaxAttempts, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, id := range ids {
+		if err := s.processOCR(ctx, id); err != nil {
+			s.logger.Error("Failed to recognize attachment text", "attachment_id", id, "error", err)
+			continue
+		}
+		processed++
+	}
+
+	if processed > 0 {
+		s.logger.Info("Text recognition completed", "attachments", processed)
+	}
+	return processed, nil
+}
+
+// RunScheduler processes queued text recognition every interval until the context is cancelled
+func (s *attachmentService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.Info("Text recognition scheduler started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Text recognition scheduler stopped")
+			return
+		case <-ticker.C:
+			if _, err := s.ProcessPendingOCR(ctx, ocrBatchSize); err != nil {
+				s.logger.Error("Failed to process text recognition", "error", err)
+			}
+		}
+	}
+}
+
+// ===== HELPER METHODS =====
+
+func (s *attachmentService) processOCRAsync(ctx context.Context, id uint) {
+	if s.ocr == nil {
+		return
+	}
+	go func() {
+		if err := s.processOCR(context.WithoutCancel(ctx), id); err != nil {
+			s.logger.Error("Failed to recognize attachment text", "attachment_id", id, "error", err)
+		}
+	}()
+}
+
+// processOCR recognises an attachment's text. A failed recognition is stored on the
+// attachment and retried by the scheduler until it runs out of tries.
+func (s *attachmentService) processOCR(ctx context.Context, id uint) error {
+	claimed, err := s.repo.AnswerAttachment().ClaimForOCR(ctx, nil, id, time.Now().Add(-ocrStaleAfter), ocrMaxAttempts)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return nil
+	}
+
+	attachment, err := s.repo.AnswerAttachment().GetByID(ctx, nil, id)
+	if err != nil {
+		return fmt.Errorf("failed to get answer attachment: %w", err)
+	}
+
+	ocrCtx, cancel := context.WithTimeout(ctx, ocrTimeout)
+	defer cancel()
+	text, ocrErr := s.ocr.ExtractText(ocrCtx, attachment.FilePath)
+
+	now := time.Now()
+	attachment.OCRProcessedAt = &now
+	if ocrErr != nil {
+		attachment.OCRStatus = models.OCRFailed
+		attachment.OCRError = stringPtr(ocrErr.Error())
+	} else {
+		attachment.OCRStatus = models.OCRCompleted
+		attachment.OCRText = stringPtr(normalizeOCRText(text))
+		attachment.OCRError = nil
+	}
+
+	if err := s.repo.AnswerAttachment().Update(ctx, nil, attachment); err != nil {
+		return err
+	}
+	return ocrErr
+}
+
+func (s *attachmentService) getAttempt(ctx context.Context, attemptID uint) (*models.AssessmentAttempt, error) {
+	attempt, err := s.repo.Attempt().GetByID(ctx, nil, attemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+	return attempt, nil
+}
+
+func (s *attachmentService) getAttachment(ctx context.Context, id uint) (*models.AnswerAttachment, error) {
+	attachment, err := s.repo.AnswerAttachment().GetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get answer attachment: %w", err)
+	}
+	return attachment, nil
+}
+
+// checkAttachmentQuestion makes sure the question belongs to the assessment and takes written work
+func (s *attachmentService) checkAttachmentQuestion(ctx context.Context, assessmentID, questionID uint) error {
+	inAssessment, err := s.repo.AssessmentQuestion().Exists(ctx, nil, assessmentID, questionID)
+	if err != nil {
+		return err
+	}
+	if !inAssessment {
+		return ErrQuestionNotFound
+	}
+
+	question, err := s.repo.Question().GetByID(ctx, nil, questionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrQuestionNotFound
+		}
+		return fmt.Errorf("failed to get question: %w", err)
+	}
+	if !attachmentQuestionTypes[question.Type] {
+		return NewBusinessRuleError("attachment_question_type", "files can only be uploaded for essay and short answer questions", map[string]interface{}{
+			"question_id":   questionID,
+			"question_type": question.Type,
+		})
+	}
+	return nil
+}
+
+// checkAttachmentAccess lets the student who took the attempt and its graders see uploads
+func (s *attachmentService) checkAttachmentAccess(ctx context.Context, attempt *models.AssessmentAttempt, userID, action string) error {
+	if attempt.StudentID == userID {
+		return nil
+	}
+	return s.checkGradingAccess(ctx, attempt.AssessmentID, userID, action)
+}
+
+func (s *attachmentService) checkGradingAccess(ctx context.Context, assessmentID uint, userID, action string) error {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleTeacher && user.Role != models.RoleAdmin {
+		return NewPermissionError(userID, assessmentID, "assessment", action, "insufficient role permissions")
+	}
+
+	canAccess, err := NewAssessmentService(s.repo, s.db, s.logger, s.validator).CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return err
+	}
+	if !canAccess {
+		return NewPermissionError(userID, assessmentID, "assessment", action, "not owner or insufficient permissions")
+	}
+	return nil
+}
+
+func attachmentContentType(filename string) (string, string, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	contentType, ok := attachmentContentTypes[ext]
+	if !ok {
+		return "", "", NewValidationError("file", "unsupported file format; upload a JPEG, PNG, TIFF or WebP image", ext)
+	}
+	return ext, contentType, nil
+}
+
+func attachmentFileURL(id uint) string {
+	return fmt.Sprintf("/api/v1/attachments/%d/file", id)
+}
+
+func storeAttachmentFile(path string, file io.Reader) (int64, error) {
+	out, err := os.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	defer out.Close()
+
+	size, err := io.Copy(out, file)
+	if err != nil {
+		os.Remove(path)
+		return 0, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	return size, nil
+}
+
+// normalizeOCRText trims each recognised line and drops empty ones
+func normalizeOCRText(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
+// textShingles returns the set of three-word sequences in a text, or its words when it is
+// shorter than that. Case and punctuation are ignored so OCR noise matters less.
+func textShingles(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	size := 3
+	if len(words) < size {
+		size = len(words)
+	}
+	shingles := make(map[string]bool)
+	for i := 0; size > 0 && i+size <= len(words); i++ {
+		shingles[strings.Join(words[i:i+size], " ")] = true
+	}
+	return shingles
+}
+
+// textSimilarity is the Jaccard index of two texts' word sequences
+func textSimilarity(a, b string) float64 {
+	return shingleSimilarity(textShingles(a), textShingles(b))
+}
+
+// shingleSimilarity is the Jaccard index of two shingle sets
+func shingleSimilarity(shinglesA, shinglesB map[string]bool) float64 {
+	if len(shinglesA) == 0 || len(shinglesB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for shingle := range shinglesA {
+		if shinglesB[shingle] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(shinglesA)+len(shinglesB)-shared)
+}
+
+// findSimilarAttachments compares an upload with other attempts' uploads, most similar first
+func findSimilarAttachments(target *models.AnswerAttachment, others []*models.AnswerAttachment, threshold float64) []SimilarAttachment {
+	similar := make([]SimilarAttachment, 0)
+	if target.OCRText == nil {
+		return similar
+	}
+
+	for _, other := range others {
+		if other.AttemptID == target.AttemptID || other.OCRText == nil {
+			continue
+		}
+		if similarity := textSimilarity(*target.OCRText, *other.OCRText); similarity >= threshold {
+			similar = append(similar, SimilarAttachment{
+				AttachmentID: other.ID,
+				AttemptID:    other.AttemptID,
+				Similarity:   similarity,
+			})
+		}
+	}
+
+	sort.SliceStable(similar, func(i, j int) bool {
+		return similar[i].Similarity > similar[j].Similarity
+	})
+	return similar
+}
+
+// searchSnippet cuts the text around the first case-insensitive match of query
+func searchSnippet(text, query string, radius int) string {
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+	needle := []rune(strings.ToLower(query))
+	if len(lower) != len(runes) {
+		// Lower-casing changed the length, so indexes would not line up
+		lower, needle = runes, []rune(query)
+	}
+
+	index := -1
+	for i := 0; i+len(needle) <= len(lower); i++ {
+		if string(lower[i:i+len(needle)]) == string(needle) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		index = 0
+	}
+
+	start, end := index-radius, index+len(needle)+radius
+	prefix, suffix := "…", "…"
+	if start <= 0 {
+		start, prefix = 0, ""
+	}
+	if end >= len(runes) {
+		end, suffix = len(runes), ""
+	}
+	return prefix + strings.ReplaceAll(string(runes[start:end]), "\n", " ") + suffix
+}
@@ -0,0 +1,375 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif" // Decodes GIF question images
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/google/uuid"
+)
+
+const (
+	// Route question media is served from when no CDN is configured
+	questionMediaPath = "/media"
+	// Directory, under the default storage directory, question media is stored in
+	questionMediaDir = "question-media"
+
+	maxImagesPerQuestion = 10
+	// Larger images are refused before decoding, so a small file cannot expand to gigabytes
+	maxQuestionImagePixels   = 40_000_000
+	questionImageJPEGQuality = 82
+
+	imageVariantRetina    = "retina"
+	imageVariantStandard  = "standard"
+	imageVariantThumbnail = "thumbnail"
+)
+
+// questionImageVariants are the widths images are resized to, largest first
+var questionImageVariants = []struct {
+	name  string
+	width int
+}{
+	{imageVariantRetina, 1600},
+	{imageVariantStandard, 800},
+	{imageVariantThumbnail, 160},
+}
+
+// questionImageContentTypes maps accepted question image extensions to their content type
+var questionImageContentTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+}
+
+// ===== QUESTION IMAGES =====
+
+// UploadQuestionImage attaches an image to a question and stores web-optimized copies of
+// it next to the original. The attachment's URL is the standard copy and its thumbnail URL
+// the thumbnail; every copy is listed in its variants.
+func (s *attachmentService) UploadQuestionImage(ctx context.Context, questionID uint, file io.Reader, filename string, req *UploadQuestionImageRequest, userID string) (*models.QuestionAttachment, error) {
+	s.logger.Info("Uploading question image",
+		"question_id", questionID,
+		"filename", filename,
+		"user_id", userID)
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	contentType, ok := questionImageContentTypes[ext]
+	if !ok {
+		return nil, NewValidationError("file", "unsupported file format; upload a JPEG, PNG or GIF image", ext)
+	}
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.checkQuestionEditable(ctx, questionID, userID, "upload_image"); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.QuestionAttachment().GetByQuestion(ctx, nil, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxImagesPerQuestion {
+		return nil, NewBusinessRuleError("question_image_limit", "too many images attached to this question", map[string]interface{}{
+			"question_id": questionID,
+			"max_images":  maxImagesPerQuestion,
+		})
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, NewValidationError("file", "is not a readable image", filepath.Base(filename))
+	}
+	if config.Width*config.Height > maxQuestionImagePixels {
+		return nil, NewValidationError("file", fmt.Sprintf("image is too large; at most %d pixels", maxQuestionImagePixels), fmt.Sprintf("%dx%d", config.Width, config.Height))
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, NewValidationError("file", "is not a readable image", filepath.Base(filename))
+	}
+
+	// Question content is published through the CDN, so it is kept out of the residency directories
+	keyDir := path.Join(questionMediaDir, fmt.Sprintf("question-%d", questionID))
+	if err := os.MkdirAll(s.mediaFilePath(keyDir), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create question media storage: %w", err)
+	}
+	name := uuid.NewString()
+
+	originalKey := path.Join(keyDir, name+ext)
+	if err := os.WriteFile(s.mediaFilePath(originalKey), data, 0o640); err != nil {
+		return nil, fmt.Errorf("failed to store image: %w", err)
+	}
+	keys := []string{originalKey}
+
+	variants, err := s.storeImageVariants(img, contentType, path.Join(keyDir, name))
+	for _, variant := range variants {
+		keys = append(keys, variant.Key)
+	}
+	if err != nil {
+		s.removeMediaFiles(keys)
+		return nil, err
+	}
+	encoded, err := json.Marshal(variants)
+	if err != nil {
+		s.removeMediaFiles(keys)
+		return nil, fmt.Errorf("failed to encode image variants: %w", err)
+	}
+
+	attachment := &models.QuestionAttachment{
+		QuestionID:  questionID,
+		FileName:    filepath.Base(filename),
+		FileType:    strings.TrimPrefix(ext, "."),
+		FileSize:    int64(len(data)),
+		MimeType:    contentType,
+		StoragePath: originalKey,
+		URL:         mediaURL(s.mediaURL, originalKey),
+		Width:       config.Width,
+		Height:      config.Height,
+		Variants:    encoded,
+		Alt:         req.Alt,
+		Caption:     req.Caption,
+		Order:       len(existing),
+	}
+	for _, variant := range variants {
+		switch variant.Name {
+		case imageVariantStandard:
+			attachment.URL = variant.URL
+		case imageVariantThumbnail:
+			attachment.ThumbnailURL = stringPtr(variant.URL)
+		}
+	}
+
+	if err := s.repo.QuestionAttachment().Create(ctx, nil, attachment); err != nil {
+		s.removeMediaFiles(keys)
+		return nil, err
+	}
+
+	s.logger.Info("Question image uploaded",
+		"question_id", questionID,
+		"attachment_id", attachment.ID,
+		"variants", len(variants))
+
+	return attachment, nil
+}
+
+// DeleteQuestionImage removes an image from a question along with its stored copies
+func (s *attachmentService) DeleteQuestionImage(ctx context.Context, questionID, attachmentID uint, userID string) error {
+	s.logger.Info("Deleting question image",
+		"question_id", questionID,
+		"attachment_id", attachmentID,
+		"user_id", userID)
+
+	if err := s.checkQuestionEditable(ctx, questionID, userID, "delete_image"); err != nil {
+		return err
+	}
+
+	attachment, err := s.repo.QuestionAttachment().GetByID(ctx, nil, attachmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to get question attachment: %w", err)
+	}
+	if attachment.QuestionID != questionID {
+		return ErrNotFound
+	}
+
+	if err := s.repo.QuestionAttachment().Delete(ctx, nil, attachmentID); err != nil {
+		return err
+	}
+
+	keys := []string{attachment.StoragePath}
+	var variants []models.ImageVariant
+	if len(attachment.Variants) > 0 && json.Unmarshal(attachment.Variants, &variants) == nil {
+		for _, variant := range variants {
+			keys = append(keys, variant.Key)
+		}
+	}
+	s.removeMediaFiles(keys)
+	return nil
+}
+
+// QuestionMediaFile returns the stored file of a question media key, as requested by the CDN
+func (s *attachmentService) QuestionMediaFile(key string) (string, error) {
+	cleaned, ok := cleanMediaKey(key)
+	if !ok {
+		return "", ErrNotFound
+	}
+	filePath := s.mediaFilePath(cleaned)
+	info, err := os.Stat(filePath)
+	if err != nil || info.IsDir() {
+		return "", ErrNotFound
+	}
+	return filePath, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (s *attachmentService) checkQuestionEditable(ctx context.Context, questionID uint, userID, action string) error {
+	if _, err := s.repo.Question().GetByID(ctx, nil, questionID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrQuestionNotFound
+		}
+		return fmt.Errorf("failed to get question: %w", err)
+	}
+
+	canEdit, err := NewQuestionService(s.repo, s.db, s.logger, s.validator).CanEdit(ctx, questionID, userID)
+	if err != nil {
+		return err
+	}
+	if !canEdit {
+		return NewPermissionError(userID, questionID, "question", action, "not owner or insufficient permissions")
+	}
+	return nil
+}
+
+// storeImageVariants resizes the image to each planned variant, each from the previous,
+// larger one, and stores them under keyPrefix. It returns the variants stored so far
+// along with an error.
+func (s *attachmentService) storeImageVariants(img image.Image, contentType, keyPrefix string) ([]models.ImageVariant, error) {
+	bounds := img.Bounds()
+	ext, encode := variantEncoder(contentType)
+
+	planned := planImageVariants(bounds.Dx(), bounds.Dy())
+	stored := make([]models.ImageVariant, 0, len(planned))
+	source := img
+	for _, variant := range planned {
+		resized := resizeImage(source, variant.Width, variant.Height)
+		source = resized
+
+		var buf bytes.Buffer
+		if err := encode(&buf, resized); err != nil {
+			return stored, fmt.Errorf("failed to encode %s image: %w", variant.Name, err)
+		}
+		variant.Key = keyPrefix + "-" + variant.Name + ext
+		if err := os.WriteFile(s.mediaFilePath(variant.Key), buf.Bytes(), 0o640); err != nil {
+			return stored, fmt.Errorf("failed to store %s image: %w", variant.Name, err)
+		}
+		variant.Size = int64(buf.Len())
+		variant.URL = mediaURL(s.mediaURL, variant.Key)
+		stored = append(stored, variant)
+	}
+	return stored, nil
+}
+
+func (s *attachmentService) mediaFilePath(key string) string {
+	return filepath.Join(s.storage.Default, filepath.FromSlash(key))
+}
+
+func (s *attachmentService) removeMediaFiles(keys []string) {
+	for _, key := range keys {
+		if err := os.Remove(s.mediaFilePath(key)); err != nil && !os.IsNotExist(err) {
+			s.logger.Warn("Failed to remove question media", "key", key, "error", err)
+		}
+	}
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// planImageVariants sizes the variants of a width x height image. Images are never
+// upscaled, and the retina variant is skipped when it would be no larger than standard.
+func planImageVariants(width, height int) []models.ImageVariant {
+	var variants []models.ImageVariant
+	for _, spec := range questionImageVariants {
+		if spec.name == imageVariantRetina && width <= questionImageVariants[1].width {
+			continue
+		}
+		w, h := variantSize(width, height, spec.width)
+		variants = append(variants, models.ImageVariant{Name: spec.name, Width: w, Height: h})
+	}
+	return variants
+}
+
+// variantSize scales width x height down to maxWidth, keeping the aspect ratio
+func variantSize(width, height, maxWidth int) (int, int) {
+	if width <= maxWidth {
+		return width, height
+	}
+	h := (height*maxWidth + width/2) / width
+	if h < 1 {
+		h = 1
+	}
+	return maxWidth, h
+}
+
+// variantEncoder returns the extension and encoder of resized copies: photos stay JPEG,
+// while PNG and GIF images, which may be transparent, become PNG
+func variantEncoder(contentType string) (string, func(io.Writer, image.Image) error) {
+	if contentType == "image/jpeg" {
+		return ".jpg", func(w io.Writer, img image.Image) error {
+			return jpeg.Encode(w, img, &jpeg.Options{Quality: questionImageJPEGQuality})
+		}
+	}
+	return ".png", png.Encode
+}
+
+// resizeImage scales src to width x height, averaging the source pixels each target pixel covers
+func resizeImage(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	rgba, ok := src.(*image.RGBA)
+	if !ok || bounds.Min != (image.Point{}) {
+		rgba = image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+	}
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := y * srcH / height
+		y1 := max((y+1)*srcH/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := x * srcW / width
+			x1 := max((x+1)*srcW/width, x0+1)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := rgba.Pix[sy*rgba.Stride+x0*4 : sy*rgba.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+			count := (x1 - x0) * (y1 - y0)
+			offset := y*dst.Stride + x*4
+			for c := 0; c < 4; c++ {
+				dst.Pix[offset+c] = uint8((sum[c] + count/2) / count)
+			}
+		}
+	}
+	return dst
+}
+
+// mediaURL is where a media key is served from under base, a CDN or the service itself
+func mediaURL(base, key string) string {
+	return strings.TrimRight(base, "/") + "/" + key
+}
+
+// cleanMediaKey normalizes a requested media key, refusing anything outside the
+// question media directory
+func cleanMediaKey(key string) (string, bool) {
+	cleaned := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if !strings.HasPrefix(cleaned, questionMediaDir+"/") {
+		return "", false
+	}
+	return cleaned, true
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestPlanImageVariants(t *testing.T) {
+	variants := planImageVariants(4000, 3000)
+	if len(variants) != 3 {
+		t.Fatalf("expected retina, standard and thumbnail variants, got %+v", variants)
+	}
+	want := []struct {
+		name          string
+		width, height int
+	}{
+		{imageVariantRetina, 1600, 1200},
+		{imageVariantStandard, 800, 600},
+		{imageVariantThumbnail, 160, 120},
+	}
+	for i, w := range want {
+		if variants[i].Name != w.name || variants[i].Width != w.width || variants[i].Height != w.height {
+			t.Errorf("expected %s at %dx%d, got %+v", w.name, w.width, w.height, variants[i])
+		}
+	}
+
+	small := planImageVariants(600, 400)
+	if len(small) != 2 || small[0].Name != imageVariantStandard || small[1].Name != imageVariantThumbnail {
+		t.Fatalf("expected no retina variant for an image narrower than standard, got %+v", small)
+	}
+	if small[0].Width != 600 || small[0].Height != 400 {
+		t.Errorf("expected the standard variant not to be upscaled, got %dx%d", small[0].Width, small[0].Height)
+	}
+}
+
+func TestVariantSize(t *testing.T) {
+	if w, h := variantSize(1000, 333, 160); w != 160 || h != 53 {
+		t.Errorf("expected 160x53, got %dx%d", w, h)
+	}
+	if w, h := variantSize(5000, 2, 160); w != 160 || h != 1 {
+		t.Errorf("expected the height to stay at least 1, got %dx%d", w, h)
+	}
+	if w, h := variantSize(100, 50, 160); w != 100 || h != 50 {
+		t.Errorf("expected a smaller image to keep its size, got %dx%d", w, h)
+	}
+}
+
+func TestResizeImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	for y := 0; y < 2; y++ {
+		src.Set(0, y, color.RGBA{R: 255, A: 255})
+		src.Set(1, y, color.RGBA{R: 255, A: 255})
+		src.Set(2, y, color.RGBA{B: 255, A: 255})
+		src.Set(3, y, color.RGBA{B: 255, A: 255})
+	}
+
+	dst := resizeImage(src, 2, 1)
+	if dst.Bounds().Dx() != 2 || dst.Bounds().Dy() != 1 {
+		t.Fatalf("expected a 2x1 image, got %v", dst.Bounds())
+	}
+	if got := dst.RGBAAt(0, 0); got != (color.RGBA{R: 255, A: 255}) {
+		t.Errorf("expected the left half to stay red, got %v", got)
+	}
+
+	mixed := resizeImage(src, 1, 1).RGBAAt(0, 0)
+	if mixed.R != 128 || mixed.B != 128 || mixed.A != 255 {
+		t.Errorf("expected red and blue averaged, got %v", mixed)
+	}
+
+	offset := src.SubImage(image.Rect(2, 0, 4, 2))
+	if got := resizeImage(offset, 1, 1).RGBAAt(0, 0); got != (color.RGBA{B: 255, A: 255}) {
+		t.Errorf("expected a sub-image to be read from its own bounds, got %v", got)
+	}
+}
+
+func TestMediaURL(t *testing.T) {
+	key := "question-media/question-7/abc-standard.jpg"
+	if got := mediaURL("https://cdn.example.com/", key); got != "https://cdn.example.com/"+key {
+		t.Errorf("unexpected CDN URL %s", got)
+	}
+	if got := mediaURL(questionMediaPath, key); got != "/media/"+key {
+		t.Errorf("unexpected local URL %s", got)
+	}
+}
+
+func TestCleanMediaKey(t *testing.T) {
+	if key, ok := cleanMediaKey("/question-media/question-7/a.png"); !ok || key != "question-media/question-7/a.png" {
+		t.Errorf("expected a media key to be accepted, got %q", key)
+	}
+	for _, key := range []string{"/question-media/../attempt-1/a.png", "/../../etc/passwd", "/question-media", "/"} {
+		if _, ok := cleanMediaKey(key); ok {
+			t.Errorf("expected %q to be refused", key)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestAttachmentContentType(t *testing.T) {
+	ext, contentType, err := attachmentContentType("Page 1.JPG")
+	if err != nil || ext != ".jpg" || contentType != "image/jpeg" {
+		t.Errorf("expected .jpg image/jpeg, got %q %q %v", ext, contentType, err)
+	}
+	if _, _, err := attachmentContentType("essay.pdf"); err == nil {
+		t.Error("non-image uploads should be rejected")
+	}
+}
+
+func TestNormalizeOCRText(t *testing.T) {
+	got := normalizeOCRText("  The  cell\t membrane \n\n\n controls   transport \f\n")
+	want := "The cell membrane\ncontrols transport"
+	if got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestTextSimilarity(t *testing.T) {
+	if got := textSimilarity("Photosynthesis turns light into energy.", "photosynthesis TURNS light into energy"); got != 1 {
+		t.Errorf("case and punctuation should be ignored, got %v", got)
+	}
+	if got := textSimilarity("the mitochondria is the powerhouse", "rivers flow into the sea"); got != 0 {
+		t.Errorf("unrelated texts should not be similar, got %v", got)
+	}
+	if got := textSimilarity("", "anything"); got != 0 {
+		t.Errorf("empty text should not be similar, got %v", got)
+	}
+	if got := textSimilarity("one two", "one two"); got != 1 {
+		t.Errorf("short texts should be compared word by word, got %v", got)
+	}
+}
+
+func TestFindSimilarAttachments(t *testing.T) {
+	text := func(s string) *string { return &s }
+	target := &models.AnswerAttachment{ID: 1, AttemptID: 10, OCRText: text("the water cycle starts with evaporation from the sea")}
+	others := []*models.AnswerAttachment{
+		target,
+		{ID: 2, AttemptID: 10, OCRText: text("the water cycle starts with evaporation from the sea")},
+		{ID: 3, AttemptID: 11, OCRText: text("the water cycle starts with evaporation from lakes")},
+		{ID: 4, AttemptID: 12, OCRText: text("the water cycle starts with evaporation from the sea")},
+		{ID: 5, AttemptID: 13, OCRText: text("plants need sunlight")},
+		{ID: 6, AttemptID: 14},
+	}
+
+	similar := findSimilarAttachments(target, others, 0.3)
+	if len(similar) != 2 {
+		t.Fatalf("expected 2 similar uploads from other attempts, got %+v", similar)
+	}
+	if similar[0].AttachmentID != 4 || similar[0].Similarity != 1 {
+		t.Errorf("identical text should rank first, got %+v", similar[0])
+	}
+	if similar[1].AttachmentID != 3 {
+		t.Errorf("expected partially overlapping upload second, got %+v", similar[1])
+	}
+
+	if got := findSimilarAttachments(&models.AnswerAttachment{ID: 7, AttemptID: 15}, others, 0.3); len(got) != 0 {
+		t.Errorf("upload without recognised text should have no matches, got %+v", got)
+	}
+}
+
+func TestSearchSnippet(t *testing.T) {
+	text := "Introduction\nThe French Revolution began in 1789 and changed Europe"
+	if got := searchSnippet(text, "revolution", 8); got != "… French Revolution began i…" {
+		t.Errorf("unexpected snippet %q", got)
+	}
+	if got := searchSnippet("short text", "text", 50); got != "short text" {
+		t.Errorf("short text should be returned whole, got %q", got)
+	}
+}
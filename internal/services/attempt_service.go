@@ -18,14 +18,26 @@ type attemptService struct {
 	db        *gorm.DB
 	logger    *slog.Logger
 	validator *validator.Validator
+
+	// Autosaves are coalesced in Redis and flushed this often; zero writes every autosave through
+	autosaveFlushInterval time.Duration
+
+	// Per-assessment submissions and answer saves; nil records nothing
+	metrics *LiveMetrics
+
+	// Tells queued students a slot opened; nil skips notifications
+	notifier NotificationEventService
 }
 
-func NewAttemptService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) AttemptService {
+func NewAttemptService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, autosaveFlushInterval time.Duration, metrics *LiveMetrics, notifier NotificationEventService) AttemptService {
 	return &attemptService{
-		repo:      repo,
-		db:        db,
-		logger:    logger,
-		validator: validator,
+		repo:                  repo,
+		db:                    db,
+		logger:                logger,
+		validator:             validator,
+		autosaveFlushInterval: autosaveFlushInterval,
+		metrics:               metrics,
+		notifier:              notifier,
 	}
 }
 
@@ -67,26 +79,70 @@ func (s *attemptService) Start(ctx context.Context, req *StartAttemptRequest, st
 
 	if currentAttempt != nil && currentAttempt.Status == models.AttemptInProgress {
 		s.logger.Info("Resuming existing attempt", "attempt_id", currentAttempt.ID)
+		if currentAttempt.PausedAt != nil {
+			return s.Resume(ctx, currentAttempt.ID, studentID)
+		}
 		return currentAttempt, nil
 	}
 
+	// New attempts count against the student's attempt quotas, checked again under lock when
+	// the attempt is created, and the licenses of any licensed banks in use
+	quotas, err := s.studentAttemptQuotas(ctx, &req.AssessmentID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkBankLicenses(ctx, req.AssessmentID, studentID); err != nil {
+		return nil, err
+	}
+
+	budgets, perQuestion, err := s.loadQuestionBudgets(ctx, req.AssessmentID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	allowedResources, err := snapshotAllowedResources(&assessment.Settings)
+	if err != nil {
+		return nil, err
+	}
+
 	// Begin transaction
 	var attempt *models.AssessmentAttempt
+	var admitted []*models.AttemptQueueEntry
 	err = s.db.Transaction(func(tx *gorm.DB) error {
-		// Create new attempt
 		currentTime := time.Now()
+
+		if err := s.enforceAttemptQuotas(ctx, tx, quotas, studentID, currentTime); err != nil {
+			return err
+		}
+
+		// Capped assessments only start when a slot is free or held for the student
+		admitted, err = s.claimAttemptSlot(ctx, tx, req.AssessmentID, assessment.Settings.MaxConcurrentAttempts, studentID, currentTime)
+		if err != nil {
+			return err
+		}
+
+		// Create new attempt
 		attempt = &models.AssessmentAttempt{
 			AssessmentID:  req.AssessmentID,
 			StudentID:     studentID,
 			Status:        models.AttemptInProgress,
 			StartedAt:     &currentTime,
 			TimeRemaining: assessment.Duration * 60, // Convert minutes to seconds
+			Sessions:      1,
+
+			AllowedResources: allowedResources,
 		}
 
-		// Calculate end time
-		endTime := attempt.StartedAt.Add(time.Duration(assessment.Duration) * time.Second)
+		// Calculate end time; the duration is in minutes
+		endTime := attempt.StartedAt.Add(time.Duration(assessment.Duration) * time.Minute)
 		attempt.EndedAt = &endTime
 
+		// Per-question timing replaces the overall duration with the sum of question budgets
+		if perQuestion {
+			startQuestionClock(attempt, budgets, currentTime)
+			attempt.TimeRemaining = int(attempt.EndedAt.Sub(currentTime).Seconds())
+		}
+
 		if err = s.repo.Attempt().Create(ctx, tx, attempt); err != nil {
 			return fmt.Errorf("failed to create attempt: %w", err)
 		}
@@ -100,8 +156,19 @@ func (s *attemptService) Start(ctx context.Context, req *StartAttemptRequest, st
 	})
 
 	if err != nil {
+		if IsBusinessRule(err) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to start attempt transaction: %w", err)
 	}
+	s.notifyAdmitted(ctx, admitted)
+	recordUsage(ctx, s.repo, s.logger, &models.UsageEvent{
+		Metric:       models.UsageAttemptsStarted,
+		Quantity:     1,
+		UserID:       studentID,
+		AssessmentID: &attempt.AssessmentID,
+		AttemptID:    &attempt.ID,
+	})
 
 	s.logger.Info("Assessment attempt started successfully",
 		"attempt_id", attempt.ID,
@@ -145,6 +212,19 @@ func (s *attemptService) Resume(ctx context.Context, attemptID uint, studentID s
 		return nil, ErrAttemptTimeExpired
 	}
 
+	// A saved attempt starts a new session with the time it had left
+	if attempt.PausedAt != nil {
+		resumePausedAttempt(attempt, time.Now())
+		if err := s.repo.Attempt().Update(ctx, s.db, attempt); err != nil {
+			return nil, fmt.Errorf("failed to resume attempt: %w", err)
+		}
+	}
+
+	// Show the latest autosaved answers
+	if _, err := s.FlushBufferedAnswers(ctx, attemptID); err != nil {
+		s.logger.Error("Failed to flush autosaved answers", "attempt_id", attemptID, "error", err)
+	}
+
 	s.logger.Info("Assessment attempt resumed successfully", "attempt_id", attemptID)
 
 	// Return attempt with questions
@@ -181,19 +261,64 @@ func (s *attemptService) Submit(ctx context.Context, req *SubmitAttemptRequest,
 		return nil, ErrAttemptAlreadySubmitted
 	}
 
+	// Locked questions cannot be changed on the way out
+	budgets, perQuestion, err := s.syncQuestionClock(ctx, attempt)
+	if err != nil {
+		return nil, err
+	}
+	if perQuestion {
+		req.Answers = openAnswers(attempt, budgets, req.Answers)
+	}
+	lockCompleted, err := s.sectionLocksEnabled(ctx, attempt.AssessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if lockCompleted {
+		plan, err := s.loadSectionPlan(ctx, attempt.AssessmentID)
+		if err != nil {
+			return nil, err
+		}
+		req.Answers = sectionOpenAnswers(plan, decodeLockedSections(attempt.LockedSections), req.Answers)
+	}
+
+	// Autosaved answers must be durable before the attempt is closed
+	if _, err := s.FlushBufferedAnswers(ctx, req.AttemptID); err != nil {
+		return nil, fmt.Errorf("failed to flush autosaved answers: %w", err)
+	}
+
+	// Submission gates do not hold back an attempt whose time ran out
+	if req.EndReason != models.AttemptEndReasonTimeout {
+		summary, err := s.loadSubmissionSummary(ctx, attempt, req.Answers)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkSubmissionGates(summary, req.Confirmed); err != nil {
+			return nil, err
+		}
+	}
+
+	submittedAt := time.Now()
+
 	// Begin transaction
 	err = s.db.Transaction(func(tx *gorm.DB) error {
 		// Update all answers
 		for _, answerReq := range req.Answers {
-			if err := s.updateAttemptAnswer(ctx, tx, req.AttemptID, answerReq, studentID); err != nil {
+			if err := s.updateAttemptAnswer(ctx, tx, req.AttemptID, answerReq, submittedAt); err != nil {
 				return fmt.Errorf("failed to update answer for question %d: %w", answerReq.QuestionID, err)
 			}
 		}
 
+		if err := s.dropSkippedAnswers(ctx, tx, attempt); err != nil {
+			return err
+		}
+
 		// Update attempt status
 		attempt.Status = models.AttemptCompleted
-		attempt.CompletedAt = timePtr(time.Now())
-		if req.TimeSpent != nil {
+		attempt.CompletedAt = timePtr(submittedAt)
+		// Attempts taken over several sessions are timed by the server
+		if attempt.SessionStartedAt != nil {
+			closeSession(attempt, submittedAt)
+		} else if req.TimeSpent != nil {
 			attempt.TimeSpent = *req.TimeSpent
 		}
 		if req.EndReason != "" {
@@ -210,6 +335,9 @@ func (s *attemptService) Submit(ctx context.Context, req *SubmitAttemptRequest,
 	if err != nil {
 		return nil, fmt.Errorf("failed to submit attempt transaction: %w", err)
 	}
+	s.metrics.SubmissionRecorded(attempt.AssessmentID)
+	s.releaseAttemptSlot(ctx, attempt.AssessmentID)
+	s.meterProctoring(ctx, attempt)
 
 	s.logger.Info("Assessment attempt submitted successfully",
 		"attempt_id", req.AttemptID,
@@ -232,6 +360,7 @@ func timePtr(now time.Time) *time.Time {
 }
 
 func (s *attemptService) SubmitAnswer(ctx context.Context, attemptID uint, req *SubmitAnswerRequest, studentID string) error {
+	started := time.Now()
 	s.logger.Info("Submitting answer",
 		"attempt_id", attemptID,
 		"question_id", req.QuestionID,
@@ -265,12 +394,66 @@ func (s *attemptService) SubmitAnswer(ctx context.Context, attemptID uint, req *
 	if attempt.EndedAt != nil && time.Now().After(*attempt.EndedAt) {
 		return ErrAttemptTimeExpired
 	}
+	if err := checkNotPaused(attempt); err != nil {
+		return err
+	}
+
+	// Per-question timing only accepts answers to the question on the clock
+	budgets, perQuestion, err := s.syncQuestionClock(ctx, attempt)
+	if err != nil {
+		return err
+	}
+	if perQuestion {
+		if err := checkQuestionOpen(attempt, budgets, req.QuestionID); err != nil {
+			return err
+		}
+	}
+
+	if req.PartID != "" {
+		if err := s.checkQuestionPart(ctx, req.QuestionID, req.PartID); err != nil {
+			return err
+		}
+	}
+
+	// Completed sections are read-only; answering a later section completes those before it
+	if err := s.enforceSectionLocks(ctx, attempt, req.QuestionID); err != nil {
+		return err
+	}
+
+	// Answers with a change limit are written through so a change over it is refused now
+	changeLimit, err := s.questionChangeLimit(ctx, s.db, attempt.AssessmentID, req.QuestionID)
+	if err != nil {
+		return err
+	}
 
-	// Update answer
-	if err := s.updateAttemptAnswer(ctx, s.db, attemptID, *req, studentID); err != nil {
-		return fmt.Errorf("failed to update answer: %w", err)
+	// Coalesce rapid autosaves unless the client is navigating away
+	if !req.Flush && changeLimit == nil && s.bufferAnswer(ctx, attemptID, req) {
+		s.metrics.AnswerSaved(attempt.AssessmentID, time.Since(started))
+		return nil
 	}
 
+	// Earlier buffered and queued writes go first so they cannot overwrite this one. While Redis
+	// is down they are flushed later and lose to this newer write.
+	_, err = s.FlushBufferedAnswers(ctx, attemptID)
+	if errors.Is(err, ErrAutosavesUnavailable) {
+		err = nil
+	}
+	if err == nil {
+		err = s.updateAttemptAnswer(ctx, s.db, attemptID, *req, time.Now())
+	}
+	if err != nil {
+		if isAnswerChangeLimit(err) {
+			return err
+		}
+		// Keep the answer through a database outage; the scheduler replays it. Anything the
+		// database refused is reported rather than queued.
+		if !repositories.IsConnectionError(err) || !s.queueAnswerWrite(ctx, attemptID, req, err) {
+			s.metrics.AutosaveFailed(attempt.AssessmentID)
+			return fmt.Errorf("failed to update answer: %w", err)
+		}
+	}
+	s.metrics.AnswerSaved(attempt.AssessmentID, time.Since(started))
+
 	s.logger.Info("Answer submitted successfully",
 		"attempt_id", attemptID,
 		"question_id", req.QuestionID)
@@ -320,6 +503,7 @@ func (s *attemptService) GetByIDWithDetails(ctx context.Context, id uint, userID
 	if !canAccess {
 		return nil, NewPermissionError(userID, id, "attempt", "read", "not owner or insufficient permissions")
 	}
+	s.overlayBufferedAnswers(ctx, attempt)
 
 	return s.buildAttemptResponse(ctx, attempt, userID, true), nil
 }
@@ -340,6 +524,10 @@ func (s *attemptService) GetCurrentAttempt(ctx context.Context, assessmentID uin
 // ===== LIST OPERATIONS =====
 
 func (s *attemptService) List(ctx context.Context, filters repositories.AttemptFilters, userID string) ([]*AttemptResponse, int64, error) {
+	if err := validateAttemptFilters(filters); err != nil {
+		return nil, 0, err
+	}
+
 	// Get user role for permission filtering
 	userRole, err := s.getUserRole(ctx, userID)
 	if err != nil {
@@ -366,6 +554,10 @@ func (s *attemptService) List(ctx context.Context, filters repositories.AttemptF
 }
 
 func (s *attemptService) GetByStudent(ctx context.Context, studentID string, filters repositories.AttemptFilters) ([]*AttemptResponse, int64, error) {
+	if err := validateAttemptFilters(filters); err != nil {
+		return nil, 0, err
+	}
+
 	// Set student filter
 	filters.StudentID = &studentID
 
@@ -384,6 +576,10 @@ func (s *attemptService) GetByStudent(ctx context.Context, studentID string, fil
 }
 
 func (s *attemptService) GetByAssessment(ctx context.Context, assessmentID uint, filters repositories.AttemptFilters, userID string) ([]*AttemptResponse, int64, error) {
+	if err := validateAttemptFilters(filters); err != nil {
+		return nil, 0, err
+	}
+
 	// Check if user can access assessment attempts
 	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
 	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, userID)
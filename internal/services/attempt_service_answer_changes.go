@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+const ruleAnswerChangeLimit = "answer_change_limit"
+
+// ===== ANSWER CHANGE LIMITS =====
+
+// checkAnswerChangeLimit refuses to replace an answer once the question's change limit is used up
+func (s *attemptService) checkAnswerChangeLimit(ctx context.Context, tx *gorm.DB, attemptID uint, answer *models.StudentAnswer) error {
+	attempt, err := s.repo.Attempt().GetByID(ctx, tx, attemptID)
+	if err != nil {
+		return fmt.Errorf("failed to get attempt: %w", err)
+	}
+	limit, err := s.questionChangeLimit(ctx, tx, attempt.AssessmentID, answer.QuestionID)
+	if err != nil {
+		return err
+	}
+	return checkAnswerChange(answer.QuestionID, answer.ChangeCount, limit)
+}
+
+// questionChangeLimit returns the question's change limit, or nil when answers can be
+// changed freely. It reads the cached ordered links so answers are checked during a
+// database outage.
+func (s *attemptService) questionChangeLimit(ctx context.Context, tx *gorm.DB, assessmentID, questionID uint) (*int, error) {
+	links, err := s.repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, tx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+	for _, link := range links {
+		if link.QuestionID == questionID {
+			return link.MaxAnswerChanges, nil
+		}
+	}
+	return nil, nil
+}
+
+// applyAnswerChangeLimits shows each question's change limit and what is left of it
+func (s *attemptService) applyAnswerChangeLimits(ctx context.Context, attempt *models.AssessmentAttempt, questions []QuestionForAttempt) error {
+	links, err := s.repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, nil, attempt.AssessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+	limits := make(map[uint]int)
+	for _, link := range links {
+		if link.MaxAnswerChanges != nil {
+			limits[link.QuestionID] = *link.MaxAnswerChanges
+		}
+	}
+	if len(limits) == 0 {
+		return nil
+	}
+
+	answers, err := s.repo.Answer().GetByAttempt(ctx, nil, attempt.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get answers: %w", err)
+	}
+	markAnswerChangeLimits(questions, limits, answers)
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// answerChangeLimit converts a requested limit to the stored one; a negative limit lifts it
+func answerChangeLimit(requested int) *int {
+	if requested < 0 {
+		return nil
+	}
+	return &requested
+}
+
+// checkAnswerChange allows a change while fewer than limit changes were made. Giving the
+// first answer or re-sending the stored one is not a change.
+func checkAnswerChange(questionID uint, changes int, limit *int) error {
+	if limit == nil || changes < *limit {
+		return nil
+	}
+	return NewBusinessRuleError(ruleAnswerChangeLimit, "the answer to this question can no longer be changed", map[string]interface{}{
+		"question_id":        questionID,
+		"max_answer_changes": *limit,
+	})
+}
+
+func isAnswerChangeLimit(err error) bool {
+	var ruleErr *BusinessRuleError
+	return errors.As(err, &ruleErr) && ruleErr.Rule == ruleAnswerChangeLimit
+}
+
+func markAnswerChangeLimits(questions []QuestionForAttempt, limits map[uint]int, answers []*models.StudentAnswer) {
+	changes := make(map[uint]int, len(answers))
+	for _, answer := range answers {
+		changes[answer.QuestionID] = answer.ChangeCount
+	}
+
+	for i := range questions {
+		limit, ok := limits[questions[i].ID]
+		if !ok {
+			continue
+		}
+		remaining := limit - changes[questions[i].ID]
+		if remaining < 0 {
+			remaining = 0
+		}
+		questions[i].MaxAnswerChanges = &limit
+		questions[i].ChangesRemaining = &remaining
+	}
+}
+
+// sameAnswer compares answers by value, since stored JSON may be formatted differently
+func sameAnswer(a, b []byte) bool {
+	var left, right interface{}
+	if json.Unmarshal(a, &left) != nil || json.Unmarshal(b, &right) != nil {
+		return string(a) == string(b)
+	}
+	return reflect.DeepEqual(left, right)
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestCheckAnswerChange(t *testing.T) {
+	if err := checkAnswerChange(1, 5, nil); err != nil {
+		t.Errorf("unlimited question should allow changes, got %v", err)
+	}
+
+	locked := 0
+	if err := checkAnswerChange(1, 0, &locked); !isAnswerChangeLimit(err) {
+		t.Errorf("a question locked after the first answer should refuse changes, got %v", err)
+	}
+
+	two := 2
+	if err := checkAnswerChange(1, 1, &two); err != nil {
+		t.Errorf("second change should be allowed, got %v", err)
+	}
+	if err := checkAnswerChange(1, 2, &two); !isAnswerChangeLimit(err) {
+		t.Errorf("third change should be refused, got %v", err)
+	}
+}
+
+func TestAnswerChangeLimit(t *testing.T) {
+	if answerChangeLimit(-1) != nil {
+		t.Error("a negative limit should lift the limit")
+	}
+	if limit := answerChangeLimit(0); limit == nil || *limit != 0 {
+		t.Errorf("expected a limit of 0, got %v", limit)
+	}
+}
+
+func TestSameAnswer(t *testing.T) {
+	if !sameAnswer([]byte(`{"answer": true}`), []byte(`{"answer":true}`)) {
+		t.Error("formatting differences should not count as a change")
+	}
+	if sameAnswer([]byte(`["a"]`), []byte(`["b"]`)) {
+		t.Error("different options should count as a change")
+	}
+}
+
+func TestMarkAnswerChangeLimits(t *testing.T) {
+	questions := []QuestionForAttempt{{Question: &models.Question{ID: 1}}, {Question: &models.Question{ID: 2}}, {Question: &models.Question{ID: 3}}}
+	answers := []*models.StudentAnswer{{QuestionID: 1, ChangeCount: 1}, {QuestionID: 2, ChangeCount: 3}}
+
+	markAnswerChangeLimits(questions, map[uint]int{1: 2, 2: 1}, answers)
+
+	if questions[0].ChangesRemaining == nil || *questions[0].ChangesRemaining != 1 {
+		t.Errorf("expected 1 change left, got %v", questions[0].ChangesRemaining)
+	}
+	if questions[1].ChangesRemaining == nil || *questions[1].ChangesRemaining != 0 {
+		t.Errorf("remaining changes should not go below 0, got %v", questions[1].ChangesRemaining)
+	}
+	if questions[2].MaxAnswerChanges != nil || questions[2].ChangesRemaining != nil {
+		t.Error("questions without a limit should carry no limit metadata")
+	}
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/datatypes"
+)
+
+// Attempts flushed per scheduler tick
+const autosaveFlushBatchSize = 200
+
+// ===== AUTOSAVE COALESCING =====
+
+// FlushBufferedAnswers writes the attempt's buffered autosaves and queued writes to the database,
+// oldest first, and returns how many were applied. Answers saved directly after being buffered
+// are kept. While Redis is unavailable it returns ErrAutosavesUnavailable, since answers
+// acknowledged before the outage may still be held there.
+func (s *attemptService) FlushBufferedAnswers(ctx context.Context, attemptID uint) (int, error) {
+	buffer := s.repo.AnswerBuffer()
+	if !buffer.Available() {
+		return 0, ErrAutosavesUnavailable
+	}
+
+	buffered, err := buffer.GetByAttempt(ctx, attemptID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get buffered answers: %w", err)
+	}
+	if len(buffered) == 0 {
+		return 0, nil
+	}
+	sortBufferedAnswers(buffered)
+
+	applied := 0
+	for _, entry := range buffered {
+		existing, err := s.repo.Answer().GetByAttemptAndQuestion(ctx, nil, attemptID, entry.QuestionID)
+		if err != nil && !repositories.IsNotFoundError(err) {
+			return applied, fmt.Errorf("failed to get existing answer: %w", err)
+		}
+		if !shouldApplyBufferedAnswer(existing, entry) {
+			continue
+		}
+
+		req := SubmitAnswerRequest{
+			QuestionID: entry.QuestionID,
+			AnswerData: entry.Answer,
+			PartID:     entry.PartID,
+			TimeSpent:  entry.TimeSpent,
+			Confidence: entry.Confidence,
+		}
+		if err := s.updateAttemptAnswer(ctx, s.db, attemptID, req, entry.BufferedAt); err != nil {
+			if isAnswerChangeLimit(err) {
+				// Buffered before the limit applied; the stored answer stands
+				s.logger.Warn("Dropped autosave over the answer change limit", "attempt_id", attemptID, "question_id", entry.QuestionID)
+				continue
+			}
+			return applied, err
+		}
+		applied++
+	}
+
+	if err := buffer.Clear(ctx, attemptID, buffered); err != nil {
+		// Entries stay buffered and are re-applied harmlessly on the next flush
+		s.logger.Warn("Failed to clear flushed answers", "attempt_id", attemptID, "error", err)
+	}
+
+	s.logger.Debug("Buffered answers flushed", "attempt_id", attemptID, "applied", applied)
+	return applied, nil
+}
+
+// RunScheduler flushes autosaves buffered longer than the flush interval, replays answer writes
+// queued while the database was unavailable, and submits overdue attempts until the context
+// is cancelled
+func (s *attemptService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.Info("Attempt scheduler started", "interval", interval, "flush_interval", s.autosaveFlushInterval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastSweep time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Attempt scheduler stopped")
+			return
+		case now := <-ticker.C:
+			s.flushDueAttempts(ctx)
+			if now.Sub(lastSweep) >= overdueSweepInterval {
+				lastSweep = now
+				s.submitOverdueAttempts(ctx, now)
+			}
+		}
+	}
+}
+
+// ===== HELPER METHODS =====
+
+// bufferAnswer stores an autosave in Redis for a later flush. It reports false when the
+// answer must be written through instead.
+func (s *attemptService) bufferAnswer(ctx context.Context, attemptID uint, req *SubmitAnswerRequest) bool {
+	if s.autosaveFlushInterval <= 0 || !s.repo.AnswerBuffer().Available() {
+		return false
+	}
+
+	if err := s.storeBufferedAnswer(ctx, attemptID, req, time.Now()); err != nil {
+		s.logger.Warn("Failed to buffer answer, writing through", "attempt_id", attemptID, "error", err)
+		return false
+	}
+	return true
+}
+
+// queueAnswerWrite keeps an answer the database refused in Redis until the scheduler replays
+// it. It reports false when the answer could not be queued either.
+func (s *attemptService) queueAnswerWrite(ctx context.Context, attemptID uint, req *SubmitAnswerRequest, writeErr error) bool {
+	if !s.repo.AnswerBuffer().Available() {
+		return false
+	}
+
+	if err := s.storeBufferedAnswer(ctx, attemptID, req, time.Now()); err != nil {
+		s.logger.Error("Failed to queue answer write", "attempt_id", attemptID, "question_id", req.QuestionID, "error", err)
+		return false
+	}
+	s.logger.Warn("Answer write failed, queued for replay",
+		"attempt_id", attemptID,
+		"question_id", req.QuestionID,
+		"error", writeErr)
+	return true
+}
+
+func (s *attemptService) storeBufferedAnswer(ctx context.Context, attemptID uint, req *SubmitAnswerRequest, bufferedAt time.Time) error {
+	answer, err := json.Marshal(req.AnswerData)
+	if err != nil {
+		return fmt.Errorf("failed to marshal answer data: %w", err)
+	}
+
+	return s.repo.AnswerBuffer().Buffer(ctx, &repositories.BufferedAnswer{
+		AttemptID:  attemptID,
+		QuestionID: req.QuestionID,
+		PartID:     req.PartID,
+		Answer:     answer,
+		TimeSpent:  req.TimeSpent,
+		Confidence: req.Confidence,
+		BufferedAt: bufferedAt,
+	})
+}
+
+// overlayBufferedAnswers shows the student the answers still waiting in Redis, so a read
+// during a database outage or before a flush does not roll back their work
+func (s *attemptService) overlayBufferedAnswers(ctx context.Context, attempt *models.AssessmentAttempt) {
+	if attempt.Status != models.AttemptInProgress || !s.repo.AnswerBuffer().Available() {
+		return
+	}
+
+	buffered, err := s.repo.AnswerBuffer().GetByAttempt(ctx, attempt.ID)
+	if err != nil {
+		s.logger.Warn("Failed to read buffered answers", "attempt_id", attempt.ID, "error", err)
+		return
+	}
+	if len(buffered) == 0 {
+		return
+	}
+
+	merged, err := mergeBufferedAnswers(attempt.ID, attempt.Answers, buffered)
+	if err != nil {
+		s.logger.Warn("Failed to merge buffered answers", "attempt_id", attempt.ID, "error", err)
+		return
+	}
+	attempt.Answers = merged
+}
+
+func (s *attemptService) flushDueAttempts(ctx context.Context) {
+	buffer := s.repo.AnswerBuffer()
+	if !buffer.Available() {
+		return
+	}
+
+	attemptIDs, err := buffer.GetDueAttempts(ctx, time.Now().Add(-s.autosaveFlushInterval), autosaveFlushBatchSize)
+	if err != nil {
+		s.logger.Error("Failed to get attempts with buffered answers", "error", err)
+		return
+	}
+
+	for _, attemptID := range attemptIDs {
+		if _, err := s.FlushBufferedAnswers(ctx, attemptID); err != nil {
+			s.logger.Error("Failed to flush buffered answers", "attempt_id", attemptID, "error", err)
+			s.recordFlushFailure(ctx, attemptID)
+		}
+	}
+}
+
+// recordFlushFailure counts a failed background flush against the attempt's assessment
+func (s *attemptService) recordFlushFailure(ctx context.Context, attemptID uint) {
+	if s.metrics == nil {
+		return
+	}
+	attempt, err := s.repo.Attempt().GetByID(ctx, nil, attemptID)
+	if err != nil {
+		return
+	}
+	s.metrics.AutosaveFailed(attempt.AssessmentID)
+}
+
+// sortBufferedAnswers orders buffered writes oldest first, so a whole answer written after
+// one of its parts replaces it rather than the other way round
+func sortBufferedAnswers(buffered []*repositories.BufferedAnswer) {
+	sort.SliceStable(buffered, func(i, j int) bool {
+		return buffered[i].BufferedAt.Before(buffered[j].BufferedAt)
+	})
+}
+
+// mergeBufferedAnswers applies buffered writes to stored answers the way a flush would
+func mergeBufferedAnswers(attemptID uint, answers []models.StudentAnswer, buffered []*repositories.BufferedAnswer) ([]models.StudentAnswer, error) {
+	merged := append([]models.StudentAnswer(nil), answers...)
+	index := make(map[uint]int, len(merged))
+	for i := range merged {
+		index[merged[i].QuestionID] = i
+	}
+
+	sorted := append([]*repositories.BufferedAnswer(nil), buffered...)
+	sortBufferedAnswers(sorted)
+	for _, entry := range sorted {
+		i, ok := index[entry.QuestionID]
+		if !ok {
+			merged = append(merged, models.StudentAnswer{AttemptID: attemptID, QuestionID: entry.QuestionID})
+			i = len(merged) - 1
+			index[entry.QuestionID] = i
+		}
+		answer := &merged[i]
+		if !shouldApplyBufferedAnswer(answer, entry) {
+			continue
+		}
+
+		value := datatypes.JSON(entry.Answer)
+		if entry.PartID != "" {
+			var err error
+			if value, err = mergePartAnswer(answer.Answer, entry.PartID, entry.Answer); err != nil {
+				return nil, err
+			}
+		}
+		answer.Answer = value
+		answer.LastModifiedAt = timePtr(entry.BufferedAt)
+		if entry.TimeSpent != nil {
+			answer.TimeSpent = *entry.TimeSpent
+		}
+		if entry.Confidence != nil {
+			answer.Confidence = entry.Confidence
+		}
+	}
+	return merged, nil
+}
+
+// shouldApplyBufferedAnswer keeps last-write-wins: a buffered answer is dropped when the
+// stored one was modified after it, e.g. by a direct save on navigation
+func shouldApplyBufferedAnswer(existing *models.StudentAnswer, buffered *repositories.BufferedAnswer) bool {
+	if existing == nil || existing.LastModifiedAt == nil {
+		return true
+	}
+	return !existing.LastModifiedAt.After(buffered.BufferedAt)
+}
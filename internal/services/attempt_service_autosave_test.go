@@ -0,0 +1,252 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories/postgres"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	pgdriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestShouldApplyBufferedAnswer(t *testing.T) {
+	bufferedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	buffered := &repositories.BufferedAnswer{AttemptID: 1, QuestionID: 2, BufferedAt: bufferedAt}
+
+	if !shouldApplyBufferedAnswer(nil, buffered) {
+		t.Error("buffered answer should create a missing answer")
+	}
+	if !shouldApplyBufferedAnswer(&models.StudentAnswer{}, buffered) {
+		t.Error("buffered answer should apply over a never-modified answer")
+	}
+
+	older := bufferedAt.Add(-time.Second)
+	if !shouldApplyBufferedAnswer(&models.StudentAnswer{LastModifiedAt: &older}, buffered) {
+		t.Error("buffered answer should replace an older saved answer")
+	}
+
+	newer := bufferedAt.Add(time.Second)
+	if shouldApplyBufferedAnswer(&models.StudentAnswer{LastModifiedAt: &newer}, buffered) {
+		t.Error("buffered answer must not overwrite a newer saved answer")
+	}
+}
+
+func TestMergeBufferedAnswers(t *testing.T) {
+	saved := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	answers := []models.StudentAnswer{
+		{AttemptID: 1, QuestionID: 2, Answer: []byte(`"stored"`), LastModifiedAt: &saved},
+		{AttemptID: 1, QuestionID: 3, Answer: []byte(`"newer"`), LastModifiedAt: timePtr(saved.Add(time.Hour))},
+	}
+	timeSpent := 40
+	buffered := []*repositories.BufferedAnswer{
+		{QuestionID: 2, Answer: []byte(`"queued"`), TimeSpent: &timeSpent, BufferedAt: saved.Add(time.Minute)},
+		{QuestionID: 3, Answer: []byte(`"stale"`), BufferedAt: saved.Add(time.Minute)},
+		{QuestionID: 4, Answer: []byte(`"new"`), BufferedAt: saved.Add(2 * time.Minute)},
+	}
+
+	merged, err := mergeBufferedAnswers(1, answers, buffered)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(merged) != 3 {
+		t.Fatalf("expected 3 answers, got %d", len(merged))
+	}
+	if string(merged[0].Answer) != `"queued"` || merged[0].TimeSpent != 40 {
+		t.Errorf("queued write should show over the stored answer, got %+v", merged[0])
+	}
+	if string(merged[1].Answer) != `"newer"` {
+		t.Errorf("queued write must not hide a newer stored answer, got %s", merged[1].Answer)
+	}
+	if merged[2].QuestionID != 4 || merged[2].AttemptID != 1 || string(merged[2].Answer) != `"new"` {
+		t.Errorf("queued write for an unsaved question should be added, got %+v", merged[2])
+	}
+	if string(answers[0].Answer) != `"stored"` {
+		t.Error("stored answers must not be modified")
+	}
+}
+
+func TestMergeBufferedAnswersOrdersParts(t *testing.T) {
+	at := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	buffered := []*repositories.BufferedAnswer{
+		{QuestionID: 5, PartID: "b", Answer: []byte(`"part b"`), BufferedAt: at.Add(2 * time.Second)},
+		{QuestionID: 5, Answer: []byte(`{"a":"whole a"}`), BufferedAt: at},
+	}
+
+	merged, err := mergeBufferedAnswers(1, nil, buffered)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var parts map[string]string
+	if err := json.Unmarshal(merged[0].Answer, &parts); err != nil {
+		t.Fatalf("unexpected answer %s: %v", merged[0].Answer, err)
+	}
+	if parts["a"] != "whole a" || parts["b"] != "part b" {
+		t.Errorf("the later part write should merge into the earlier whole answer, got %v", parts)
+	}
+}
+
+// autosaveRepository serves an in-progress attempt whose settings and question links come
+// from the cache, with answers written to the given store
+type autosaveRepository struct {
+	MockNotificationRepository
+	answers repositories.AnswerRepository
+	buffer  *answerQueue
+}
+
+func (r *autosaveRepository) Attempt() repositories.AttemptRepository { return autosaveAttempts{} }
+func (r *autosaveRepository) AssessmentSettings() repositories.AssessmentSettingsRepository {
+	return autosaveSettings{}
+}
+func (r *autosaveRepository) AssessmentQuestion() repositories.AssessmentQuestionRepository {
+	return autosaveLinks{}
+}
+func (r *autosaveRepository) Answer() repositories.AnswerRepository             { return r.answers }
+func (r *autosaveRepository) AnswerBuffer() repositories.AnswerBufferRepository { return r.buffer }
+
+type autosaveAttempts struct {
+	repositories.AttemptRepository
+}
+
+func (autosaveAttempts) GetByID(ctx context.Context, tx *gorm.DB, id uint) (*models.AssessmentAttempt, error) {
+	return &models.AssessmentAttempt{ID: id, AssessmentID: 3, StudentID: "student-1", Status: models.AttemptInProgress}, nil
+}
+
+type autosaveSettings struct {
+	repositories.AssessmentSettingsRepository
+}
+
+func (autosaveSettings) GetByAssessmentID(ctx context.Context, tx *gorm.DB, assessmentID uint) (*models.AssessmentSettings, error) {
+	return &models.AssessmentSettings{AssessmentID: assessmentID}, nil
+}
+
+type autosaveLinks struct {
+	repositories.AssessmentQuestionRepository
+}
+
+func (autosaveLinks) GetByAssessmentOrdered(ctx context.Context, tx *gorm.DB, assessmentID uint) ([]*models.AssessmentQuestion, error) {
+	return []*models.AssessmentQuestion{{AssessmentID: assessmentID, QuestionID: 5, Order: 1}}, nil
+}
+
+// savedAnswers records the answers written to the database
+type savedAnswers struct {
+	repositories.AnswerRepository
+	created []*models.StudentAnswer
+}
+
+func (a *savedAnswers) GetByAttemptAndQuestion(ctx context.Context, tx *gorm.DB, attemptID, questionID uint) (*models.StudentAnswer, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (a *savedAnswers) Create(ctx context.Context, tx *gorm.DB, answer *models.StudentAnswer) error {
+	a.created = append(a.created, answer)
+	return nil
+}
+
+// refusingAnswers rejects every write the way a constraint violation would
+type refusingAnswers struct {
+	repositories.AnswerRepository
+}
+
+func (refusingAnswers) GetByAttemptAndQuestion(ctx context.Context, tx *gorm.DB, attemptID, questionID uint) (*models.StudentAnswer, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (refusingAnswers) Create(ctx context.Context, tx *gorm.DB, answer *models.StudentAnswer) error {
+	return errors.New("new row violates check constraint")
+}
+
+type answerQueue struct {
+	repositories.AnswerBufferRepository
+	down   bool
+	queued []*repositories.BufferedAnswer
+}
+
+func (q *answerQueue) Available() bool { return !q.down }
+
+func (q *answerQueue) Buffer(ctx context.Context, answer *repositories.BufferedAnswer) error {
+	q.queued = append(q.queued, answer)
+	return nil
+}
+
+func (q *answerQueue) GetByAttempt(ctx context.Context, attemptID uint) ([]*repositories.BufferedAnswer, error) {
+	return nil, nil
+}
+
+// unreachableDB points at a port nothing listens on, so every statement fails to connect
+func unreachableDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(pgdriver.Open("host=127.0.0.1 port=1 user=test dbname=test sslmode=disable connect_timeout=2"),
+		&gorm.Config{DisableAutomaticPing: true, Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	return db
+}
+
+func submitAutosave(repo *autosaveRepository, db *gorm.DB) error {
+	service := NewAttemptService(repo, db, slog.New(slog.DiscardHandler), validator.New(), 0, nil, nil)
+	return service.SubmitAnswer(context.Background(), 7, &SubmitAnswerRequest{QuestionID: 5, AnswerData: "B", Flush: true}, "student-1")
+}
+
+func TestSubmitAnswerQueuesWhileDatabaseIsDown(t *testing.T) {
+	db := unreachableDB(t)
+	repo := &autosaveRepository{answers: postgres.NewAnswerPostgreSQL(db, nil), buffer: &answerQueue{}}
+
+	if err := submitAutosave(repo, db); err != nil {
+		t.Fatalf("answer should be kept through the outage, got %v", err)
+	}
+	if len(repo.buffer.queued) != 1 || repo.buffer.queued[0].QuestionID != 5 || string(repo.buffer.queued[0].Answer) != `"B"` {
+		t.Errorf("answer should be queued for replay, got %+v", repo.buffer.queued)
+	}
+}
+
+func TestSubmitAnswerReportsRefusedWrites(t *testing.T) {
+	repo := &autosaveRepository{answers: refusingAnswers{}, buffer: &answerQueue{}}
+
+	if err := submitAutosave(repo, nil); err == nil {
+		t.Fatal("a write the database refused must be reported, not saved")
+	}
+	if len(repo.buffer.queued) != 0 {
+		t.Errorf("a refused write must not be queued, got %+v", repo.buffer.queued)
+	}
+}
+
+func TestIsConnectionError(t *testing.T) {
+	db := unreachableDB(t)
+	err := db.Exec("SELECT 1").Error
+	if !repositories.IsConnectionError(err) {
+		t.Errorf("failing to connect should count as a connection error: %v", err)
+	}
+	for _, err := range []error{nil, gorm.ErrRecordNotFound, errors.New("new row violates check constraint"), context.Canceled} {
+		if repositories.IsConnectionError(err) {
+			t.Errorf("%v should not count as a connection error", err)
+		}
+	}
+}
+
+func TestFlushWhileRedisIsDown(t *testing.T) {
+	answers := &savedAnswers{}
+	repo := &autosaveRepository{answers: answers, buffer: &answerQueue{down: true}}
+	service := NewAttemptService(repo, nil, slog.New(slog.DiscardHandler), validator.New(), 0, nil, nil)
+
+	// Closing the attempt now would leave out autosaves still held in Redis
+	if _, err := service.FlushBufferedAnswers(context.Background(), 7); !errors.Is(err, ErrAutosavesUnavailable) {
+		t.Errorf("flush without Redis should be retried later, got %v", err)
+	}
+
+	// A new answer is still written through, and wins over anything older held in Redis
+	if err := submitAutosave(repo, nil); err != nil {
+		t.Fatalf("answer should be written through while Redis is down, got %v", err)
+	}
+	if len(answers.created) != 1 || string(answers.created[0].Answer) != `"B"` {
+		t.Errorf("answer should be saved directly, got %+v", answers.created)
+	}
+}
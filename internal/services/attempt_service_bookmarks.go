@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/datatypes"
+)
+
+// Session data key holding the student's bookmarked question IDs
+const sessionBookmarksKey = "bookmarks"
+
+// ===== BOOKMARKS =====
+
+// BookmarkQuestion adds or removes a personal bookmark. Unlike flags, bookmarks are only
+// shown to the student and do not affect submission.
+func (s *attemptService) BookmarkQuestion(ctx context.Context, attemptID, questionID uint, req *BookmarkQuestionRequest, studentID string) (*AttemptBookmarks, error) {
+	attempt, err := s.getOwnedAttempt(ctx, attemptID, studentID, "bookmark_question")
+	if err != nil {
+		return nil, err
+	}
+	if attempt.Status != models.AttemptInProgress {
+		return nil, ErrAttemptNotActive
+	}
+
+	if _, err := s.repo.Answer().GetByAttemptAndQuestion(ctx, s.db, attemptID, questionID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get answer: %w", err)
+	}
+
+	bookmarks := setBookmark(sessionBookmarks(attempt.SessionData), questionID, req.Bookmarked)
+	sessionData, err := withSessionBookmarks(attempt.SessionData, bookmarks)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Attempt().UpdateSessionData(ctx, s.db, attemptID, sessionData); err != nil {
+		return nil, fmt.Errorf("failed to save bookmarks: %w", err)
+	}
+
+	s.logger.Info("Question bookmark updated",
+		"attempt_id", attemptID,
+		"question_id", questionID,
+		"bookmarked", req.Bookmarked)
+
+	return &AttemptBookmarks{AttemptID: attemptID, QuestionIDs: bookmarks}, nil
+}
+
+func (s *attemptService) GetBookmarks(ctx context.Context, attemptID uint, studentID string) (*AttemptBookmarks, error) {
+	attempt, err := s.getOwnedAttempt(ctx, attemptID, studentID, "view_bookmarks")
+	if err != nil {
+		return nil, err
+	}
+	return &AttemptBookmarks{AttemptID: attemptID, QuestionIDs: sessionBookmarks(attempt.SessionData)}, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// markBookmarkedQuestions sets the bookmark state on each question of the navigation map
+func markBookmarkedQuestions(questions []QuestionForAttempt, bookmarks []uint) {
+	bookmarked := make(map[uint]bool, len(bookmarks))
+	for _, id := range bookmarks {
+		bookmarked[id] = true
+	}
+	for i := range questions {
+		questions[i].Bookmarked = bookmarked[questions[i].ID]
+	}
+}
+
+// sessionBookmarks reads the bookmarked question IDs; unreadable session data has none
+func sessionBookmarks(sessionData []byte) []uint {
+	bookmarks := make([]uint, 0)
+	var data map[string]json.RawMessage
+	if len(sessionData) == 0 || json.Unmarshal(sessionData, &data) != nil {
+		return bookmarks
+	}
+	if raw, ok := data[sessionBookmarksKey]; ok {
+		_ = json.Unmarshal(raw, &bookmarks)
+	}
+	if bookmarks == nil {
+		bookmarks = make([]uint, 0)
+	}
+	return bookmarks
+}
+
+// withSessionBookmarks stores the bookmarks, keeping the rest of the session data
+func withSessionBookmarks(sessionData []byte, bookmarks []uint) (datatypes.JSON, error) {
+	data := make(map[string]json.RawMessage)
+	if len(sessionData) > 0 {
+		if err := json.Unmarshal(sessionData, &data); err != nil || data == nil {
+			data = make(map[string]json.RawMessage)
+		}
+	}
+
+	if len(bookmarks) == 0 {
+		delete(data, sessionBookmarksKey)
+	} else {
+		raw, err := json.Marshal(bookmarks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal bookmarks: %w", err)
+		}
+		data[sessionBookmarksKey] = raw
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal session data: %w", err)
+	}
+	return datatypes.JSON(encoded), nil
+}
+
+// setBookmark adds or removes a question, keeping the order bookmarks were made in
+func setBookmark(bookmarks []uint, questionID uint, bookmarked bool) []uint {
+	result := make([]uint, 0, len(bookmarks)+1)
+	found := false
+	for _, id := range bookmarks {
+		if id == questionID {
+			found = true
+			if !bookmarked {
+				continue
+			}
+		}
+		result = append(result, id)
+	}
+	if bookmarked && !found {
+		result = append(result, questionID)
+	}
+	return result
+}
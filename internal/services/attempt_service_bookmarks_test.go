@@ -0,0 +1,76 @@
+package services
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestSetBookmark(t *testing.T) {
+	bookmarks := setBookmark(nil, 5, true)
+	bookmarks = setBookmark(bookmarks, 2, true)
+	bookmarks = setBookmark(bookmarks, 5, true) // Already bookmarked, keeps its place
+	if !reflect.DeepEqual(bookmarks, []uint{5, 2}) {
+		t.Fatalf("expected [5 2], got %v", bookmarks)
+	}
+
+	bookmarks = setBookmark(bookmarks, 5, false)
+	if !reflect.DeepEqual(bookmarks, []uint{2}) {
+		t.Errorf("expected [2], got %v", bookmarks)
+	}
+}
+
+func TestSessionBookmarksKeepsOtherSessionData(t *testing.T) {
+	sessionData := []byte(`{"browser":"firefox","screen":"1920x1080"}`)
+
+	updated, err := withSessionBookmarks(sessionData, []uint{3, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sessionBookmarks(updated); !reflect.DeepEqual(got, []uint{3, 1}) {
+		t.Errorf("expected [3 1], got %v", got)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(updated, &data); err != nil {
+		t.Fatal(err)
+	}
+	if data["browser"] != "firefox" || data["screen"] != "1920x1080" {
+		t.Errorf("expected other session data to be kept, got %v", data)
+	}
+
+	cleared, err := withSessionBookmarks(updated, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sessionBookmarks(cleared); len(got) != 0 {
+		t.Errorf("expected no bookmarks, got %v", got)
+	}
+}
+
+func TestSessionBookmarksWithoutSessionData(t *testing.T) {
+	for _, data := range [][]byte{nil, []byte(`null`), []byte(`not json`)} {
+		if got := sessionBookmarks(data); got == nil || len(got) != 0 {
+			t.Errorf("expected an empty list for %q, got %v", data, got)
+		}
+	}
+}
+
+func TestMarkBookmarkedQuestions(t *testing.T) {
+	questions := []QuestionForAttempt{
+		{Question: &models.Question{ID: 1}},
+		{Question: &models.Question{ID: 2}},
+		{Question: &models.Question{ID: 3}},
+	}
+
+	markBookmarkedQuestions(questions, []uint{3, 1})
+
+	for _, q := range questions {
+		want := q.ID != 2
+		if q.Bookmarked != want {
+			t.Errorf("question %d: expected bookmarked %v", q.ID, want)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// ===== BRANCHING =====
+
+// GetNextQuestion returns the question after afterQuestionID on the student's route, or the
+// first one when afterQuestionID is zero. Rules are evaluated against the stored answers.
+func (s *attemptService) GetNextQuestion(ctx context.Context, attemptID, afterQuestionID uint, studentID string) (*NextQuestion, error) {
+	attempt, err := s.getOwnedAttempt(ctx, attemptID, studentID, "get_next_question")
+	if err != nil {
+		return nil, err
+	}
+	if attempt.Status != models.AttemptInProgress {
+		return nil, ErrAttemptNotActive
+	}
+	if attempt.EndedAt != nil && time.Now().After(*attempt.EndedAt) {
+		if err := s.HandleTimeout(ctx, attemptID); err != nil {
+			s.logger.Error("Failed to handle timeout", "attempt_id", attemptID, "error", err)
+		}
+		return nil, ErrAttemptTimeExpired
+	}
+
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, s.db, attempt.AssessmentID)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get assessment settings: %w", err)
+	}
+	if settings != nil && settings.TimingMode == models.TimingModePerQuestion {
+		return nil, NewBusinessRuleError("per_question_timing", "questions are delivered one at a time; advance to the next question instead", map[string]interface{}{
+			"assessment_id": attempt.AssessmentID,
+		})
+	}
+
+	// The answer to the question being left decides where the student goes
+	if _, err := s.FlushBufferedAnswers(ctx, attemptID); err != nil {
+		return nil, fmt.Errorf("failed to flush autosaved answers: %w", err)
+	}
+
+	route, err := s.attemptRoute(ctx, s.db, attempt, nil)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.getAttemptQuestions(ctx, attempt)
+	if err != nil {
+		return nil, err
+	}
+	questions = orderByRoute(questions, route)
+	markBookmarkedQuestions(questions, sessionBookmarks(attempt.SessionData))
+	if err := s.applyAnswerChangeLimits(ctx, attempt, questions); err != nil {
+		return nil, err
+	}
+
+	next := &NextQuestion{
+		AttemptID:       attemptID,
+		AfterQuestionID: afterQuestionID,
+		Route:           make([]uint, 0, len(questions)),
+	}
+	position := -1
+	for i := range questions {
+		next.Route = append(next.Route, questions[i].ID)
+		if questions[i].ID == afterQuestionID {
+			position = i
+		}
+	}
+	if afterQuestionID != 0 && position < 0 {
+		return nil, NewBusinessRuleError("question_not_on_route", "the question is not on this attempt's route", map[string]interface{}{
+			"question_id": afterQuestionID,
+		})
+	}
+	if position+1 < len(questions) {
+		next.Question = &questions[position+1]
+	}
+
+	return next, nil
+}
+
+// ===== HELPER METHODS =====
+
+// attemptRoute resolves the questions an attempt goes through from its stored answers and
+// any answers about to be saved. It returns nil when the assessment has no branching rules.
+func (s *attemptService) attemptRoute(ctx context.Context, tx *gorm.DB, attempt *models.AssessmentAttempt, pending []SubmitAnswerRequest) ([]uint, error) {
+	nodes, err := loadBranchNodes(ctx, s.repo, tx, attempt.AssessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if !hasBranching(nodes) {
+		return nil, nil
+	}
+
+	answers, err := s.loadRouteAnswers(ctx, tx, attempt.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range pending {
+		if req.PartID != "" {
+			continue // Multi-part answers never branch
+		}
+		if raw, err := json.Marshal(req.AnswerData); err == nil {
+			answers[req.QuestionID] = raw
+		}
+	}
+	return resolveRoute(nodes, answers), nil
+}
+
+func (s *attemptService) loadRouteAnswers(ctx context.Context, tx *gorm.DB, attemptID uint) (map[uint][]byte, error) {
+	stored, err := s.repo.Answer().GetByAttempt(ctx, tx, attemptID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answers: %w", err)
+	}
+	answers := make(map[uint][]byte, len(stored))
+	for _, answer := range stored {
+		answers[answer.QuestionID] = answer.Answer
+	}
+	return answers, nil
+}
+
+// dropSkippedAnswers removes the answers to questions branching rules took the student
+// past, so they count neither towards the score nor the maximum
+func (s *attemptService) dropSkippedAnswers(ctx context.Context, tx *gorm.DB, attempt *models.AssessmentAttempt) error {
+	route, err := s.attemptRoute(ctx, tx, attempt, nil)
+	if err != nil || route == nil {
+		return err
+	}
+	onRoute := make(map[uint]bool, len(route))
+	for _, id := range route {
+		onRoute[id] = true
+	}
+
+	answers, err := s.repo.Answer().GetByAttempt(ctx, tx, attempt.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get answers: %w", err)
+	}
+	for _, answer := range answers {
+		if onRoute[answer.QuestionID] {
+			continue
+		}
+		if err := s.repo.Answer().Delete(ctx, tx, answer.ID); err != nil {
+			return fmt.Errorf("failed to drop skipped answer for question %d: %w", answer.QuestionID, err)
+		}
+	}
+	return nil
+}
+
+// routeBudgets keeps the budgets of the questions on the route, in route order
+func routeBudgets(budgets []questionBudget, route []uint) []questionBudget {
+	byID := make(map[uint]questionBudget, len(budgets))
+	for _, budget := range budgets {
+		byID[budget.QuestionID] = budget
+	}
+
+	routed := make([]questionBudget, 0, len(route))
+	for _, id := range route {
+		if budget, ok := byID[id]; ok {
+			routed = append(routed, budget)
+		}
+	}
+	return routed
+}
+
+// orderByRoute keeps the questions on the route, in route order. A nil route keeps them all.
+func orderByRoute(questions []QuestionForAttempt, route []uint) []QuestionForAttempt {
+	if route == nil {
+		return questions
+	}
+
+	byID := make(map[uint]QuestionForAttempt, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+
+	routed := make([]QuestionForAttempt, 0, len(route))
+	for _, id := range route {
+		if question, ok := byID[id]; ok {
+			routed = append(routed, question)
+		}
+	}
+	for i := range routed {
+		routed[i].IsFirst = i == 0
+		routed[i].IsLast = i == len(routed)-1
+	}
+	return routed
+}
+
+// routeLinks keeps the assessment questions on the route. A nil route keeps them all.
+func routeLinks(links []*models.AssessmentQuestion, route []uint) []*models.AssessmentQuestion {
+	if route == nil {
+		return links
+	}
+
+	onRoute := make(map[uint]bool, len(route))
+	for _, id := range route {
+		onRoute[id] = true
+	}
+
+	routed := make([]*models.AssessmentQuestion, 0, len(route))
+	for _, link := range links {
+		if onRoute[link.QuestionID] {
+			routed = append(routed, link)
+		}
+	}
+	return routed
+}
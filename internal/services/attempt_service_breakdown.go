@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+const (
+	breakdownUncategorized = "uncategorized"
+	breakdownUntagged      = "untagged"
+)
+
+// scoredQuestion is one assessment question together with the student's result on it
+type scoredQuestion struct {
+	question *models.Question
+	points   int
+	answer   *models.StudentAnswer // nil when the question was not answered
+}
+
+// ===== SCORE BREAKDOWN =====
+
+func (s *attemptService) GetScoreBreakdown(ctx context.Context, attemptID uint, userID string) (*AttemptScoreBreakdown, error) {
+	s.logger.Info("Building attempt score breakdown", "attempt_id", attemptID, "user_id", userID)
+
+	attempt, err := s.repo.Attempt().GetByID(ctx, nil, attemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	canAccess, err := s.canAccessAttempt(ctx, attempt, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, attemptID, "attempt", "read", "not owner or insufficient permissions")
+	}
+
+	if attempt.Status == models.AttemptInProgress {
+		return nil, NewBusinessRuleError("attempt_in_progress", "score breakdown is available once the attempt is finished", map[string]interface{}{
+			"attempt_id": attemptID,
+		})
+	}
+
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, nil, attempt.AssessmentID)
+	if err != nil {
+		if !repositories.IsNotFoundError(err) {
+			return nil, fmt.Errorf("failed to get assessment settings: %w", err)
+		}
+		settings = nil
+	}
+	isStudent := attempt.StudentID == userID
+	released := resultsReleased(settings, time.Now())
+	if isStudent && !released {
+		return nil, NewBusinessRuleError("results_not_released", "results of this assessment have not been released yet", map[string]interface{}{
+			"assessment_id": attempt.AssessmentID,
+		})
+	}
+
+	assessmentQuestions, err := s.repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, nil, attempt.AssessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+
+	questionIDs := make([]uint, 0, len(assessmentQuestions))
+	for _, aq := range assessmentQuestions {
+		questionIDs = append(questionIDs, aq.QuestionID)
+	}
+	questions, err := s.repo.Question().GetByIDs(ctx, nil, questionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	questionsByID := make(map[uint]*models.Question, len(questions))
+	for _, question := range questions {
+		questionsByID[question.ID] = question
+	}
+
+	answers, err := s.repo.Answer().GetByAttempt(ctx, nil, attemptID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attempt answers: %w", err)
+	}
+	answersByQuestion := make(map[uint]*models.StudentAnswer, len(answers))
+	for _, answer := range answers {
+		answersByQuestion[answer.QuestionID] = answer
+	}
+
+	items := make([]scoredQuestion, 0, len(assessmentQuestions))
+	for _, aq := range assessmentQuestions {
+		question, ok := questionsByID[aq.QuestionID]
+		if !ok {
+			continue
+		}
+		points := question.Points
+		if aq.Points != nil {
+			points = *aq.Points
+		}
+		items = append(items, scoredQuestion{
+			question: question,
+			points:   points,
+			answer:   answersByQuestion[aq.QuestionID],
+		})
+	}
+
+	breakdown := buildScoreBreakdown(items)
+	breakdown.AttemptID = attempt.ID
+	breakdown.AssessmentID = attempt.AssessmentID
+	if rankVisible(settings, isStudent, released && (settings == nil || settings.ShowResults)) {
+		if breakdown.Standing, err = s.attemptStanding(ctx, attempt); err != nil {
+			return nil, err
+		}
+	}
+
+	return breakdown, nil
+}
+
+// buildScoreBreakdown groups earned and possible points by question type, difficulty,
+// category and tag. A question with several tags counts towards each of its skills.
+func buildScoreBreakdown(items []scoredQuestion) *AttemptScoreBreakdown {
+	byType := make(map[string]*ScoreBreakdownGroup)
+	byDifficulty := make(map[string]*ScoreBreakdownGroup)
+	bySection := make(map[string]*ScoreBreakdownGroup)
+	bySkill := make(map[string]*ScoreBreakdownGroup)
+
+	breakdown := &AttemptScoreBreakdown{}
+	for _, item := range items {
+		earned, pending := 0.0, 0
+		if item.answer != nil {
+			if item.answer.IsGraded {
+				earned = item.answer.Score
+			} else {
+				pending = item.points
+			}
+		}
+
+		breakdown.EarnedPoints += earned
+		breakdown.PossiblePoints += item.points
+		breakdown.PendingPoints += pending
+
+		addToScoreGroup(byType, string(item.question.Type), string(item.question.Type), item.points, earned, pending)
+		addToScoreGroup(byDifficulty, string(item.question.Difficulty), string(item.question.Difficulty), item.points, earned, pending)
+
+		sectionKey, sectionLabel := breakdownUncategorized, "Uncategorized"
+		if item.question.CategoryID != nil {
+			sectionKey = fmt.Sprintf("%d", *item.question.CategoryID)
+			sectionLabel = sectionKey
+			if item.question.Category != nil {
+				sectionLabel = item.question.Category.Name
+			}
+		}
+		addToScoreGroup(bySection, sectionKey, sectionLabel, item.points, earned, pending)
+
+		skills := questionSkills(item.question)
+		if len(skills) == 0 {
+			addToScoreGroup(bySkill, breakdownUntagged, "Untagged", item.points, earned, pending)
+		}
+		for _, skill := range skills {
+			addToScoreGroup(bySkill, strings.ToLower(skill), skill, item.points, earned, pending)
+		}
+	}
+
+	breakdown.Percentage = scorePercentage(breakdown.EarnedPoints, breakdown.PossiblePoints)
+	breakdown.ByType = sortedScoreGroups(byType)
+	breakdown.ByDifficulty = sortedScoreGroups(byDifficulty)
+	breakdown.BySection = sortedScoreGroups(bySection)
+	breakdown.BySkill = sortedScoreGroups(bySkill)
+
+	return breakdown
+}
+
+func addToScoreGroup(groups map[string]*ScoreBreakdownGroup, key, label string, possible int, earned float64, pending int) {
+	group, ok := groups[key]
+	if !ok {
+		group = &ScoreBreakdownGroup{Key: key, Label: label}
+		groups[key] = group
+	}
+	group.QuestionCount++
+	group.EarnedPoints += earned
+	group.PossiblePoints += possible
+	group.PendingPoints += pending
+}
+
+func sortedScoreGroups(groups map[string]*ScoreBreakdownGroup) []ScoreBreakdownGroup {
+	result := make([]ScoreBreakdownGroup, 0, len(groups))
+	for _, group := range groups {
+		group.Percentage = scorePercentage(group.EarnedPoints, group.PossiblePoints)
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+func scorePercentage(earned float64, possible int) float64 {
+	if possible == 0 {
+		return 0
+	}
+	return earned / float64(possible) * 100
+}
+
+// questionSkills returns the distinct tags of a question, keeping the first spelling seen
+func questionSkills(question *models.Question) []string {
+	if len(question.Tags) == 0 {
+		return nil
+	}
+
+	var tags []string
+	if err := json.Unmarshal(question.Tags, &tags); err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	skills := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		key := strings.ToLower(tag)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		skills = append(skills, tag)
+	}
+	return skills
+}
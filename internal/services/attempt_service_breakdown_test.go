@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+func TestBuildScoreBreakdown(t *testing.T) {
+	categoryID := uint(7)
+	items := []scoredQuestion{
+		{
+			question: &models.Question{
+				Type:       models.MultipleChoice,
+				Difficulty: models.DifficultyEasy,
+				CategoryID: &categoryID,
+				Category:   &models.QuestionCategory{Name: "Algebra"},
+				Tags:       datatypes.JSON(`["Fractions","equations"]`),
+			},
+			points: 10,
+			answer: &models.StudentAnswer{Score: 8, IsGraded: true},
+		},
+		{
+			question: &models.Question{
+				Type:       models.Essay,
+				Difficulty: models.DifficultyHard,
+				Tags:       datatypes.JSON(`["fractions"]`),
+			},
+			points: 20,
+			answer: &models.StudentAnswer{},
+		},
+		{
+			question: &models.Question{
+				Type:       models.MultipleChoice,
+				Difficulty: models.DifficultyEasy,
+			},
+			points: 10,
+		},
+	}
+
+	breakdown := buildScoreBreakdown(items)
+
+	if breakdown.EarnedPoints != 8 || breakdown.PossiblePoints != 40 || breakdown.PendingPoints != 20 {
+		t.Fatalf("totals = %v/%d pending %d", breakdown.EarnedPoints, breakdown.PossiblePoints, breakdown.PendingPoints)
+	}
+	if breakdown.Percentage != 20 {
+		t.Errorf("Percentage = %v, want 20", breakdown.Percentage)
+	}
+
+	if len(breakdown.ByType) != 2 || breakdown.ByType[1].Key != string(models.MultipleChoice) ||
+		breakdown.ByType[1].PossiblePoints != 20 || breakdown.ByType[1].QuestionCount != 2 {
+		t.Errorf("ByType = %+v", breakdown.ByType)
+	}
+
+	if len(breakdown.BySection) != 2 || breakdown.BySection[0].Label != "Algebra" ||
+		breakdown.BySection[1].Key != breakdownUncategorized {
+		t.Errorf("BySection = %+v", breakdown.BySection)
+	}
+
+	// equations, fractions (merged case-insensitively) and untagged
+	if len(breakdown.BySkill) != 3 || breakdown.BySkill[1].Key != "fractions" ||
+		breakdown.BySkill[1].PossiblePoints != 30 || breakdown.BySkill[1].Label != "Fractions" {
+		t.Errorf("BySkill = %+v", breakdown.BySkill)
+	}
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// Score differences below this are treated as rounding noise
+const comparisonTolerance = 1e-6
+
+// ===== ATTEMPT COMPARISON =====
+
+// CompareAttempts compares a student's finished attempts at an assessment question by
+// question. Students can compare their own attempts once results are released.
+func (s *attemptService) CompareAttempts(ctx context.Context, studentID string, assessmentID uint, userID string) (*AttemptComparison, error) {
+	s.logger.Info("Comparing attempts",
+		"student_id", studentID,
+		"assessment_id", assessmentID,
+		"user_id", userID)
+
+	if studentID == userID {
+		released, err := areResultsReleased(ctx, s.repo, assessmentID)
+		if err != nil {
+			return nil, err
+		}
+		if !released {
+			return nil, NewBusinessRuleError("results_not_released", "results of this assessment have not been released yet", map[string]interface{}{
+				"assessment_id": assessmentID,
+			})
+		}
+	} else {
+		userRole, err := s.getUserRole(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		canAccess := false
+		if userRole == models.RoleTeacher || userRole == models.RoleAdmin {
+			assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+			if canAccess, err = assessmentService.CanAccess(ctx, assessmentID, userID); err != nil {
+				return nil, err
+			}
+		}
+		if !canAccess {
+			return nil, NewPermissionError(userID, assessmentID, "assessment", "compare_attempts", "not the student or assessment owner")
+		}
+	}
+
+	attempts, err := s.repo.Attempt().GetByStudentAndAssessment(ctx, nil, studentID, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attempts: %w", err)
+	}
+	finished := make([]*models.AssessmentAttempt, 0, len(attempts))
+	for _, attempt := range attempts {
+		if attempt.Status == models.AttemptCompleted || attempt.Status == models.AttemptTimeOut {
+			finished = append(finished, attempt)
+		}
+	}
+
+	questions, err := s.repo.AssessmentQuestion().GetQuestionsForAssessment(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+
+	answers := make(map[uint][]*models.StudentAnswer, len(finished))
+	for _, attempt := range finished {
+		if answers[attempt.ID], err = s.repo.Answer().GetByAttempt(ctx, nil, attempt.ID); err != nil {
+			return nil, fmt.Errorf("failed to get attempt answers: %w", err)
+		}
+	}
+
+	comparison := compareAttempts(finished, questions, answers)
+	comparison.StudentID = studentID
+	comparison.AssessmentID = assessmentID
+
+	return comparison, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// compareAttempts lines up each question's result across attempts, oldest attempt first.
+// Questions no longer in the assessment are kept after the current ones.
+func compareAttempts(attempts []*models.AssessmentAttempt, questions []*models.Question, answers map[uint][]*models.StudentAnswer) *AttemptComparison {
+	ordered := make([]*models.AssessmentAttempt, len(attempts))
+	copy(ordered, attempts)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].AttemptNumber < ordered[j].AttemptNumber
+	})
+
+	comparison := &AttemptComparison{
+		Attempts:  make([]ComparedAttempt, 0, len(ordered)),
+		Questions: make([]QuestionComparison, 0, len(questions)),
+	}
+	for _, attempt := range ordered {
+		comparison.Attempts = append(comparison.Attempts, ComparedAttempt{
+			AttemptID:     attempt.ID,
+			AttemptNumber: attempt.AttemptNumber,
+			Status:        attempt.Status,
+			Score:         attempt.Score,
+			MaxScore:      attempt.MaxScore,
+			Percentage:    attempt.Percentage,
+			Passed:        attempt.Passed,
+			CompletedAt:   attempt.CompletedAt,
+		})
+	}
+	if n := len(ordered); n >= 2 {
+		comparison.ScoreChange = math.Round((ordered[n-1].Percentage-ordered[n-2].Percentage)*10) / 10
+	}
+
+	byAttempt := make(map[uint]map[uint]*models.StudentAnswer, len(answers))
+	var retired []uint
+	known := make(map[uint]bool, len(questions))
+	for _, question := range questions {
+		known[question.ID] = true
+	}
+	for _, attempt := range ordered {
+		byQuestion := make(map[uint]*models.StudentAnswer, len(answers[attempt.ID]))
+		for _, answer := range answers[attempt.ID] {
+			byQuestion[answer.QuestionID] = answer
+			if !known[answer.QuestionID] {
+				known[answer.QuestionID] = true
+				retired = append(retired, answer.QuestionID)
+			}
+		}
+		byAttempt[attempt.ID] = byQuestion
+	}
+	sort.Slice(retired, func(i, j int) bool { return retired[i] < retired[j] })
+
+	rows := make([]QuestionComparison, 0, len(questions)+len(retired))
+	for _, question := range questions {
+		rows = append(rows, QuestionComparison{QuestionID: question.ID, Type: question.Type, Text: question.Text})
+	}
+	for _, id := range retired {
+		rows = append(rows, QuestionComparison{QuestionID: id})
+	}
+
+	for _, row := range rows {
+		row.Results = make([]QuestionAttemptResult, 0, len(ordered))
+		for i, attempt := range ordered {
+			result := questionAttemptResult(attempt.ID, byAttempt[attempt.ID][row.QuestionID])
+			if i > 0 {
+				result.Change = compareResults(row.Results[i-1], result)
+			}
+			row.Results = append(row.Results, result)
+		}
+
+		if len(row.Results) >= 2 {
+			row.Change = row.Results[len(row.Results)-1].Change
+			switch row.Change {
+			case AttemptChangeImproved:
+				comparison.Improved++
+			case AttemptChangeRegressed:
+				comparison.Regressed++
+			case AttemptChangeUnchanged:
+				comparison.Unchanged++
+			default:
+				comparison.NotComparable++
+			}
+		}
+		comparison.Questions = append(comparison.Questions, row)
+	}
+
+	return comparison
+}
+
+func questionAttemptResult(attemptID uint, answer *models.StudentAnswer) QuestionAttemptResult {
+	result := QuestionAttemptResult{AttemptID: attemptID}
+	if answer == nil {
+		return result
+	}
+	result.Presented = true
+	result.Answered = isAnswerGiven(answer.Answer)
+	result.Graded = answer.IsGraded
+	result.Score = answer.Score
+	result.MaxScore = answer.MaxScore
+	result.IsCorrect = answer.IsCorrect
+	return result
+}
+
+// compareResults compares the share of points earned, so a question whose points changed
+// between attempts is still compared fairly
+func compareResults(previous, current QuestionAttemptResult) AttemptChange {
+	if !previous.Presented || !current.Presented || !previous.Graded || !current.Graded {
+		return AttemptChangeNotComparable
+	}
+
+	diff := scoreShare(current) - scoreShare(previous)
+	switch {
+	case diff > comparisonTolerance:
+		return AttemptChangeImproved
+	case diff < -comparisonTolerance:
+		return AttemptChangeRegressed
+	default:
+		return AttemptChangeUnchanged
+	}
+}
+
+func scoreShare(result QuestionAttemptResult) float64 {
+	if result.MaxScore <= 0 {
+		return result.Score
+	}
+	return result.Score / float64(result.MaxScore)
+}
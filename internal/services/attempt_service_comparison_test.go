@@ -0,0 +1,68 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestCompareAttempts(t *testing.T) {
+	attempts := []*models.AssessmentAttempt{
+		{ID: 20, AttemptNumber: 2, Status: models.AttemptCompleted, Percentage: 75},
+		{ID: 10, AttemptNumber: 1, Status: models.AttemptCompleted, Percentage: 50},
+	}
+	questions := []*models.Question{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+	given := []byte(`["a"]`)
+	answers := map[uint][]*models.StudentAnswer{
+		10: {
+			{QuestionID: 1, Answer: given, Score: 0, MaxScore: 2, IsGraded: true},
+			{QuestionID: 2, Answer: given, Score: 2, MaxScore: 2, IsGraded: true},
+			{QuestionID: 3, Answer: given, Score: 1, MaxScore: 2, IsGraded: true},
+			{QuestionID: 4, Answer: given, Score: 1, MaxScore: 2, IsGraded: true},
+			{QuestionID: 9, Answer: given, Score: 1, MaxScore: 1, IsGraded: true},
+		},
+		20: {
+			{QuestionID: 1, Answer: given, Score: 2, MaxScore: 2, IsGraded: true},
+			{QuestionID: 2, Answer: given, Score: 1, MaxScore: 2, IsGraded: true},
+			{QuestionID: 3, Answer: given, Score: 2, MaxScore: 4, IsGraded: true}, // Points doubled, same share
+			{QuestionID: 4, Answer: []byte(`"essay"`), MaxScore: 2},
+		},
+	}
+
+	comparison := compareAttempts(attempts, questions, answers)
+
+	if comparison.Attempts[0].AttemptID != 10 || comparison.ScoreChange != 25 {
+		t.Fatalf("attempts should be oldest first with a score change of 25, got %+v", comparison.Attempts)
+	}
+	want := []AttemptChange{
+		AttemptChangeImproved, AttemptChangeRegressed, AttemptChangeUnchanged,
+		AttemptChangeNotComparable, AttemptChangeNotComparable,
+	}
+	if len(comparison.Questions) != len(want) {
+		t.Fatalf("expected %d questions including the retired one, got %d", len(want), len(comparison.Questions))
+	}
+	for i, change := range want {
+		if got := comparison.Questions[i].Change; got != change {
+			t.Errorf("question %d: got %s, want %s", comparison.Questions[i].QuestionID, got, change)
+		}
+	}
+	if comparison.Questions[4].Results[1].Presented {
+		t.Error("a retired question should not count as presented in the later attempt")
+	}
+	if comparison.Improved != 1 || comparison.Regressed != 1 || comparison.Unchanged != 1 || comparison.NotComparable != 2 {
+		t.Errorf("unexpected summary %+v", comparison)
+	}
+}
+
+func TestCompareSingleAttempt(t *testing.T) {
+	attempts := []*models.AssessmentAttempt{{ID: 10, AttemptNumber: 1}}
+	answers := map[uint][]*models.StudentAnswer{10: {{QuestionID: 1, Score: 1, MaxScore: 1, IsGraded: true}}}
+
+	comparison := compareAttempts(attempts, []*models.Question{{ID: 1}}, answers)
+	if comparison.Questions[0].Change != "" || comparison.Questions[0].Results[0].Change != "" {
+		t.Error("a single attempt has nothing to compare against")
+	}
+	if comparison.Unchanged+comparison.NotComparable != 0 {
+		t.Errorf("unexpected summary %+v", comparison)
+	}
+}
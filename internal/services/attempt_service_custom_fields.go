@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// UpdateCustomFields records values of the organization's attempt fields, such as the proctor
+// or the room. The fields are those of the assessment owner's organization.
+func (s *attemptService) UpdateCustomFields(ctx context.Context, attemptID uint, req *UpdateAttemptCustomFieldsRequest, userID string) (*AttemptResponse, error) {
+	s.logger.Info("Updating attempt custom fields", "attempt_id", attemptID, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	userRole, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if userRole != models.RoleTeacher && userRole != models.RoleAdmin {
+		return nil, NewPermissionError(userID, attemptID, "attempt", "update_custom_fields", "insufficient permissions")
+	}
+
+	attempt, err := s.repo.Attempt().GetByID(ctx, nil, attemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	canAccess, err := assessmentService.CanAccess(ctx, attempt.AssessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, attempt.AssessmentID, "assessment", "update_attempt_custom_fields", "not owner or insufficient permissions")
+	}
+
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, attempt.AssessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	customFields, err := resolveCustomFields(ctx, s.repo, assessment.CreatedBy, models.CustomFieldEntityAttempt, attempt.CustomFields, req.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Attempt().UpdateCustomFields(ctx, nil, attemptID, customFields); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(ctx, attemptID, userID)
+}
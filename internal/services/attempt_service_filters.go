@@ -0,0 +1,61 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// filterableGradingStatuses are the answer grading states attempts can be filtered by
+var filterableGradingStatuses = []models.AnswerGradingStatus{
+	models.GradingStatusUngraded,
+	models.GradingStatusAutoGraded,
+	models.GradingStatusProvisional,
+	models.GradingStatusManuallyGraded,
+	models.GradingStatusPendingRegrade,
+	models.GradingStatusRegraded,
+	models.GradingStatusOverridden,
+}
+
+// validateAttemptFilters rejects empty ranges, unknown sort keys and grading states, and a
+// cursor combined with a custom sort, which keyset pagination cannot follow
+func validateAttemptFilters(filters repositories.AttemptFilters) error {
+	var errs ValidationErrors
+
+	if filters.MinScore != nil && filters.MaxScore != nil && *filters.MinScore > *filters.MaxScore {
+		errs = append(errs, *NewValidationError("min_score", "must not exceed max_score", *filters.MinScore))
+	}
+	if filters.MinPercentage != nil && filters.MaxPercentage != nil && *filters.MinPercentage > *filters.MaxPercentage {
+		errs = append(errs, *NewValidationError("min_percentage", "must not exceed max_percentage", *filters.MinPercentage))
+	}
+	if filters.CompletedFrom != nil && filters.CompletedTo != nil && filters.CompletedFrom.After(*filters.CompletedTo) {
+		errs = append(errs, *NewValidationError("completed_from", "must not be after completed_to", *filters.CompletedFrom))
+	}
+
+	for _, status := range filters.GradingStatuses {
+		if !slices.Contains(filterableGradingStatuses, status) {
+			errs = append(errs, *NewValidationError("grading_status", "unknown grading status", status))
+		}
+	}
+
+	seen := make(map[string]bool, len(filters.Sort))
+	for _, sort := range filters.Sort {
+		switch {
+		case !slices.Contains(repositories.AttemptSortFields, sort.Field):
+			errs = append(errs, *NewValidationError("sort", fmt.Sprintf("must be one of %v", repositories.AttemptSortFields), sort.Field))
+		case seen[sort.Field]:
+			errs = append(errs, *NewValidationError("sort", "sort key repeated", sort.Field))
+		}
+		seen[sort.Field] = true
+	}
+	if len(filters.Sort) > 0 && filters.After != nil {
+		errs = append(errs, *NewValidationError("cursor", "cannot be combined with sort; use page instead", nil))
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
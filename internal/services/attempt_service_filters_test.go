@@ -0,0 +1,51 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func float64Ptr(f float64) *float64 { return &f }
+
+func TestValidateAttemptFilters(t *testing.T) {
+	from := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	valid := repositories.AttemptFilters{
+		MinScore:        float64Ptr(5),
+		MaxScore:        float64Ptr(5),
+		MinPercentage:   float64Ptr(50),
+		MaxPercentage:   float64Ptr(100),
+		CompletedFrom:   &from,
+		CompletedTo:     &to,
+		GradingStatuses: []models.AnswerGradingStatus{models.GradingStatusProvisional},
+		Sort:            []repositories.AttemptSort{{Field: "percentage", Desc: true}, {Field: "student_name"}},
+	}
+	if err := validateAttemptFilters(valid); err != nil {
+		t.Fatalf("expected filters to be accepted, got %v", err)
+	}
+
+	cases := map[string]repositories.AttemptFilters{
+		"min_score":      {MinScore: float64Ptr(8), MaxScore: float64Ptr(5)},
+		"min_percentage": {MinPercentage: float64Ptr(90), MaxPercentage: float64Ptr(10)},
+		"completed_from": {CompletedFrom: &to, CompletedTo: &from},
+		"grading_status": {GradingStatuses: []models.AnswerGradingStatus{"graded"}},
+		"sort":           {Sort: []repositories.AttemptSort{{Field: "title"}}},
+		"cursor":         {Sort: []repositories.AttemptSort{{Field: "score"}}, After: &repositories.PageCursor{ID: 1}},
+	}
+	for field, filters := range cases {
+		var errs ValidationErrors
+		if err := validateAttemptFilters(filters); !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != field {
+			t.Errorf("expected one %s error, got %v", field, err)
+		}
+	}
+
+	repeated := repositories.AttemptFilters{Sort: []repositories.AttemptSort{{Field: "score"}, {Field: "score", Desc: true}}}
+	if err := validateAttemptFilters(repeated); err == nil {
+		t.Error("expected a repeated sort key to be refused")
+	}
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+const (
+	// Flags raised on one question within this window are counted together
+	flagEscalationWindow = 10 * time.Minute
+	// A question is escalated once this many students flagged it within the window...
+	flagEscalationMinFlags = 3
+	// ...and they make up this share of the attempts in progress
+	flagEscalationMinShare = 0.2
+)
+
+// ===== FLAG ESCALATION =====
+
+// checkFlagEscalation escalates the question to the assessment owner when many students
+// taking the assessment flagged it within the last few minutes. Failures are only logged;
+// they never stop a student from flagging.
+func (s *attemptService) checkFlagEscalation(ctx context.Context, attempt *models.AssessmentAttempt, questionID uint) {
+	now := time.Now()
+	flags, err := s.repo.FlagEscalation().CountRecentFlags(ctx, s.db, attempt.AssessmentID, questionID, now.Add(-flagEscalationWindow))
+	if err != nil {
+		s.logger.Warn("Failed to count question flags", "assessment_id", attempt.AssessmentID, "question_id", questionID, "error", err)
+		return
+	}
+	if flags < flagEscalationMinFlags {
+		return
+	}
+	active, err := s.repo.Attempt().CountActive(ctx, s.db, attempt.AssessmentID, now)
+	if err != nil {
+		s.logger.Warn("Failed to count active attempts", "assessment_id", attempt.AssessmentID, "error", err)
+		return
+	}
+	if !flagsWarrantEscalation(flags, active) {
+		return
+	}
+
+	escalation := &models.QuestionFlagEscalation{
+		AssessmentID:   attempt.AssessmentID,
+		QuestionID:     questionID,
+		Status:         models.FlagEscalationOpen,
+		FlagCount:      flags,
+		ActiveAttempts: active,
+		EscalatedAt:    now,
+	}
+	created, err := s.repo.FlagEscalation().Create(ctx, s.db, escalation)
+	if err != nil {
+		s.logger.Warn("Failed to escalate flagged question", "assessment_id", attempt.AssessmentID, "question_id", questionID, "error", err)
+		return
+	}
+	if !created {
+		return // Already escalated
+	}
+
+	s.logger.Info("Flagged question escalated",
+		"escalation_id", escalation.ID,
+		"assessment_id", attempt.AssessmentID,
+		"question_id", questionID,
+		"flags", flags,
+		"active_attempts", active)
+
+	if s.notifier != nil {
+		if err := s.notifier.NotifyQuestionFlagsEscalated(ctx, escalation); err != nil {
+			s.logger.Warn("Failed to notify flag escalation", "escalation_id", escalation.ID, "error", err)
+		}
+	}
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// flagsWarrantEscalation reports whether enough of the students taking the assessment
+// flagged a question. Students who flagged and already submitted still count, so the
+// flags may outnumber the attempts in progress.
+func flagsWarrantEscalation(flags, activeAttempts int) bool {
+	if flags < flagEscalationMinFlags {
+		return false
+	}
+	return float64(flags) >= flagEscalationMinShare*float64(activeAttempts)
+}
@@ -0,0 +1,22 @@
+package services
+
+import "testing"
+
+func TestFlagsWarrantEscalation(t *testing.T) {
+	cases := []struct {
+		flags, active int
+		want          bool
+	}{
+		{2, 2, false},   // Too few flags, however small the exam
+		{3, 10, true},   // 30% of the room
+		{3, 40, false},  // Under 20% of the room
+		{8, 40, true},   // Exactly 20%
+		{5, 0, true},    // Everyone who flagged has already submitted
+		{0, 100, false}, // No flags at all
+	}
+	for _, c := range cases {
+		if got := flagsWarrantEscalation(c.flags, c.active); got != c.want {
+			t.Errorf("flagsWarrantEscalation(%d, %d) = %v, want %v", c.flags, c.active, got, c.want)
+		}
+	}
+}
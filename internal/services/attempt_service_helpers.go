@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -33,6 +34,11 @@ func (s *attemptService) GetTimeRemaining(ctx context.Context, attemptID uint, s
 		return 0, ErrAttemptNotActive
 	}
 
+	// A saved attempt's clock is stopped
+	if attempt.PausedAt != nil {
+		return attempt.TimeRemaining, nil
+	}
+
 	// Calculate time remaining
 	if attempt.EndedAt == nil {
 		return 0, nil // No time limit
@@ -87,11 +93,26 @@ func (s *attemptService) ExtendTime(ctx context.Context, attemptID uint, minutes
 		return ErrAttemptNotActive
 	}
 
-	// Extend time
-	if attempt.EndedAt != nil {
+	// Without a number of minutes the assessment's accommodation default applies
+	if minutes == 0 {
+		minutes, err = s.accommodationMinutes(ctx, attempt.AssessmentID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Extend time; a saved attempt gets it when the student returns
+	if attempt.PausedAt != nil {
+		attempt.TimeRemaining += minutes * 60
+	} else if attempt.EndedAt != nil {
 		newEndTime := attempt.EndedAt.Add(time.Duration(minutes) * time.Minute)
 		attempt.EndedAt = &newEndTime
 	}
+	// In per-question timing the extra time goes to the question on the clock
+	if attempt.QuestionDeadline != nil {
+		newDeadline := attempt.QuestionDeadline.Add(time.Duration(minutes) * time.Minute)
+		attempt.QuestionDeadline = &newDeadline
+	}
 
 	if err := s.repo.Attempt().Update(ctx, nil, attempt); err != nil {
 		return fmt.Errorf("failed to extend attempt time: %w", err)
@@ -118,7 +139,23 @@ func (s *attemptService) HandleTimeout(ctx context.Context, attemptID uint) erro
 		return nil // Already handled
 	}
 
+	// Keep autosaved answers given before time ran out; the overdue sweep retries once
+	// Redis is back
+	if _, err := s.FlushBufferedAnswers(ctx, attemptID); err != nil {
+		if errors.Is(err, ErrAutosavesUnavailable) {
+			return err
+		}
+		s.logger.Error("Failed to flush autosaved answers", "attempt_id", attemptID, "error", err)
+	}
+
+	if err := s.dropSkippedAnswers(ctx, nil, attempt); err != nil {
+		s.logger.Error("Failed to drop skipped answers", "attempt_id", attemptID, "error", err)
+	}
+
 	// Update attempt status to timeout
+	if attempt.SessionStartedAt != nil {
+		closeSession(attempt, time.Now())
+	}
 	attempt.Status = models.AttemptTimeOut
 	timeoutReason := models.AttemptEndReasonTimeout
 	attempt.EndReason = &timeoutReason
@@ -127,6 +164,9 @@ func (s *attemptService) HandleTimeout(ctx context.Context, attemptID uint) erro
 	if err := s.repo.Attempt().Update(ctx, nil, attempt); err != nil {
 		return fmt.Errorf("failed to update attempt status: %w", err)
 	}
+	s.metrics.SubmissionRecorded(attempt.AssessmentID)
+	s.releaseAttemptSlot(ctx, attempt.AssessmentID)
+	s.meterProctoring(ctx, attempt)
 
 	s.logger.Info("Attempt timeout handled successfully", "attempt_id", attemptID)
 
@@ -159,6 +199,10 @@ func (s *attemptService) CanStart(ctx context.Context, assessmentID uint, studen
 	if err != nil {
 		return false, err
 	}
+	terms, err := resolveAttemptTerms(ctx, s.repo, s.db, assessment, studentID)
+	if err != nil {
+		return false, err
+	}
 
 	// Check attempt count
 	attemptCount, err := s.GetAttemptCount(ctx, assessmentID, studentID)
@@ -166,7 +210,7 @@ func (s *attemptService) CanStart(ctx context.Context, assessmentID uint, studen
 		return false, err
 	}
 
-	if attemptCount >= assessment.MaxAttempts {
+	if attemptCount >= terms.MaxAttempts {
 		return false, nil
 	}
 
@@ -241,6 +285,54 @@ func (s *attemptService) GetStats(ctx context.Context, assessmentID uint, userID
 
 // ===== HELPER FUNCTIONS =====
 
+// checkBankLicenses enforces the licenses of banks supplying the assessment's questions
+func (s *attemptService) checkBankLicenses(ctx context.Context, assessmentID uint, studentID string) error {
+	banks, err := s.repo.QuestionBank().GetLicensedBanksForAssessment(ctx, s.db, assessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get licensed banks: %w", err)
+	}
+
+	now := time.Now()
+	for _, bank := range banks {
+		exposure, err := s.repo.QuestionBank().GetLicenseExposure(ctx, s.db, bank.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get license exposure: %w", err)
+		}
+		exposed, err := s.repo.QuestionBank().IsStudentExposed(ctx, s.db, bank.ID, studentID)
+		if err != nil {
+			return fmt.Errorf("failed to check license exposure: %w", err)
+		}
+		if err := checkLicenseAccess(bank, exposure.StudentsExposed, exposed, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// accommodationMinutes is the extra time the assessment grants students with a timing accommodation
+func (s *attemptService) accommodationMinutes(ctx context.Context, assessmentID uint) (int, error) {
+	assessment, err := s.repo.Assessment().GetByID(ctx, s.db, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return 0, ErrAssessmentNotFound
+		}
+		return 0, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	settings, err := s.getCapacitySettings(ctx, assessmentID)
+	if err != nil {
+		return 0, err
+	}
+
+	minutes := accommodationExtraMinutes(assessment.Duration, settings.AccommodationExtraTime)
+	if minutes == 0 {
+		return 0, NewBusinessRuleError("no_accommodation_default", "the assessment has no accommodation extra time; give the number of minutes", map[string]interface{}{
+			"assessment_id": assessmentID,
+		})
+	}
+	return minutes, nil
+}
+
 func (s *attemptService) getUserRole(ctx context.Context, userID string) (models.UserRole, error) {
 	user, err := s.repo.User().GetByID(ctx, userID)
 	if err != nil {
@@ -282,22 +374,160 @@ func (s *attemptService) buildAttemptResponse(ctx context.Context, attempt *mode
 
 	response.CanResume = response.CanSubmit
 
+	// Students only see scores and feedback once results are released
+	response.ResultsReleased = true
+	if attempt.StudentID == userID {
+		released, err := areResultsReleased(ctx, s.repo, attempt.AssessmentID)
+		if err != nil {
+			s.logger.Error("Failed to check results release", "attempt_id", attempt.ID, "error", err)
+		}
+		response.ResultsReleased = released
+		if !released {
+			withholdResults(attempt)
+		}
+	}
+
+	// Bookmarks are the student's own navigation aid
+	if attempt.StudentID != userID && len(sessionBookmarks(attempt.SessionData)) > 0 {
+		if sessionData, err := withSessionBookmarks(attempt.SessionData, nil); err == nil {
+			attempt.SessionData = sessionData
+		}
+	}
+
+	// Grader annotations are feedback and follow the same release
+	if response.ResultsReleased && attempt.Status != models.AttemptInProgress {
+		annotations, err := s.repo.AnswerAnnotation().GetByAttempt(ctx, nil, attempt.ID)
+		if err != nil {
+			s.logger.Error("Failed to get answer annotations", "attempt_id", attempt.ID, "error", err)
+		} else {
+			response.Annotations = annotations
+		}
+	}
+
 	// Include questions if requested and user is the student
 	if includeQuestions && attempt.StudentID == userID {
-		questions, err := s.getAttemptQuestions(ctx, attempt.AssessmentID)
+		questions, err := s.getAttemptQuestions(ctx, attempt)
 		if err != nil {
 			s.logger.Error("Failed to get attempt questions", "attempt_id", attempt.ID, "error", err)
 		} else {
+			// Branching rules decide which questions the student goes through
+			route, err := s.attemptRoute(ctx, nil, attempt, nil)
+			if err != nil {
+				s.logger.Error("Failed to resolve attempt route", "attempt_id", attempt.ID, "error", err)
+			}
+			questions = orderByRoute(questions, route)
+			response.Bookmarks = sessionBookmarks(attempt.SessionData)
+			markBookmarkedQuestions(questions, response.Bookmarks)
+			if err := s.applyAnswerChangeLimits(ctx, attempt, questions); err != nil {
+				s.logger.Error("Failed to get answer change limits", "attempt_id", attempt.ID, "error", err)
+			}
+			if attempt.Status == models.AttemptInProgress {
+				if err := deliverOrderingQuestions(attempt.ID, questions); err != nil {
+					s.logger.Error("Failed to prepare ordering questions", "attempt_id", attempt.ID, "error", err)
+				}
+			}
 			response.Questions = questions
 		}
+
+		// Per-question timing shows only the question on the clock while the attempt runs
+		if attempt.Status == models.AttemptInProgress {
+			s.applyQuestionTiming(ctx, response)
+		}
+	}
+
+	// Blind marking hides who took the attempt from staff until results are released
+	if attempt.StudentID != userID {
+		s.hideIdentityWhileAnonymous(ctx, attempt)
 	}
 
 	return response
 }
 
-func (s *attemptService) getAttemptQuestions(ctx context.Context, assessmentId uint) ([]QuestionForAttempt, error) {
+// hideIdentityWhileAnonymous redacts the student while the assessment is graded anonymously.
+// It fails closed: when the settings cannot be read the student is hidden as well.
+func (s *attemptService) hideIdentityWhileAnonymous(ctx context.Context, attempt *models.AssessmentAttempt) {
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, nil, attempt.AssessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return
+		}
+		s.logger.Error("Failed to get assessment settings", "attempt_id", attempt.ID, "error", err)
+		redactAttemptIdentity(attempt, "")
+		return
+	}
+	if identitiesHidden(settings, time.Now()) {
+		redactAttemptIdentity(attempt, anonymousStudentLabel(settings.AnonymousGradingSalt, attempt.StudentID))
+	}
+}
+
+// applyQuestionTiming narrows an in-progress attempt to its current question
+func (s *attemptService) applyQuestionTiming(ctx context.Context, response *AttemptResponse) {
+	budgets, perQuestion, err := s.loadQuestionBudgets(ctx, response.AssessmentID, response.ID)
+	if err != nil {
+		s.logger.Error("Failed to get question budgets", "attempt_id", response.ID, "error", err)
+		return
+	}
+	if !perQuestion {
+		return
+	}
+
+	now := time.Now()
+	advanceExpiredQuestions(response.AssessmentAttempt, budgets, now)
+	response.QuestionTiming = buildQuestionTiming(response.AssessmentAttempt, budgets, now)
+
+	question := currentQuestionOf(response.AssessmentAttempt, budgets, response.Questions)
+	response.Questions = []QuestionForAttempt{}
+	if question != nil {
+		response.Questions = []QuestionForAttempt{*question}
+	}
+}
+
+// meterProctoring bills the time of a finished attempt when its assessment is proctored
+func (s *attemptService) meterProctoring(ctx context.Context, attempt *models.AssessmentAttempt) {
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, nil, attempt.AssessmentID)
+	if err != nil {
+		if !repositories.IsNotFoundError(err) {
+			s.logger.Error("Failed to get assessment settings for usage metering", "attempt_id", attempt.ID, "error", err)
+		}
+		return
+	}
+	if !isProctored(settings) {
+		return
+	}
+
+	minutes := proctoredMinutes(attempt)
+	if minutes == 0 {
+		return
+	}
+	recordUsage(ctx, s.repo, s.logger, &models.UsageEvent{
+		Metric:       models.UsageProctoringMinutes,
+		Quantity:     minutes,
+		UserID:       attempt.StudentID,
+		AssessmentID: &attempt.AssessmentID,
+		AttemptID:    &attempt.ID,
+	})
+}
+
+// withholdResults clears scores and feedback from an attempt before it is shown to the student
+func withholdResults(attempt *models.AssessmentAttempt) {
+	attempt.Score = 0
+	attempt.Percentage = 0
+	attempt.Passed = false
+	attempt.ComputedScore = nil
+	attempt.ComputedPercentage = nil
+	attempt.ComputedPassed = nil
+	attempt.OverrideReason = nil
+	for i := range attempt.Answers {
+		attempt.Answers[i].Score = 0
+		attempt.Answers[i].IsCorrect = nil
+		attempt.Answers[i].Feedback = nil
+		attempt.Answers[i].PartScores = nil
+	}
+}
+
+func (s *attemptService) getAttemptQuestions(ctx context.Context, attempt *models.AssessmentAttempt) ([]QuestionForAttempt, error) {
 	// Get assessment questions with answers
-	assessmentQuestions, err := s.repo.AssessmentQuestion().GetQuestionsForAssessment(ctx, nil, assessmentId)
+	assessmentQuestions, err := s.repo.AssessmentQuestion().GetQuestionsForAssessment(ctx, nil, attempt.AssessmentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
 	}
@@ -312,6 +542,10 @@ func (s *attemptService) getAttemptQuestions(ctx context.Context, assessmentId u
 		}
 	}
 
+	if err := s.applyQuestionTrials(ctx, attempt.ID, questions); err != nil {
+		return nil, fmt.Errorf("failed to apply question trials: %w", err)
+	}
+
 	return questions, nil
 }
 
@@ -335,6 +569,11 @@ func (s *attemptService) initializeAttemptAnswers(ctx context.Context, tx *gorm.
 		}
 	}
 
+	// Running question trials may serve this attempt revised questions
+	if err := s.assignQuestionTrials(ctx, tx, attempt, answers); err != nil {
+		return fmt.Errorf("failed to assign question trials: %w", err)
+	}
+
 	// Batch create answers
 	if err := s.repo.Answer().CreateBatch(ctx, tx, answers); err != nil {
 		return fmt.Errorf("failed to create initial answers: %w", err)
@@ -343,7 +582,9 @@ func (s *attemptService) initializeAttemptAnswers(ctx context.Context, tx *gorm.
 	return nil
 }
 
-func (s *attemptService) updateAttemptAnswer(ctx context.Context, tx *gorm.DB, attemptID uint, req SubmitAnswerRequest, studentID string) error {
+// updateAttemptAnswer upserts an answer; modifiedAt is when the student gave it, which may
+// precede the write when the answer was buffered
+func (s *attemptService) updateAttemptAnswer(ctx context.Context, tx *gorm.DB, attemptID uint, req SubmitAnswerRequest, modifiedAt time.Time) error {
 	// Get existing answer
 	answer, err := s.repo.Answer().GetByAttemptAndQuestion(ctx, tx, attemptID, req.QuestionID)
 	if err != nil {
@@ -364,14 +605,32 @@ func (s *attemptService) updateAttemptAnswer(ctx context.Context, tx *gorm.DB, a
 		if err != nil {
 			return fmt.Errorf("failed to marshal answer data: %w", err)
 		}
+		if req.PartID != "" {
+			if answerBytes, err = mergePartAnswer(answer.Answer, req.PartID, answerBytes); err != nil {
+				return err
+			}
+		}
+		if isAnswerGiven(answer.Answer) && !sameAnswer(answer.Answer, answerBytes) {
+			if err := s.checkAnswerChangeLimit(ctx, tx, attemptID, answer); err != nil {
+				return err
+			}
+			answer.ChangeCount++
+		}
 		answer.Answer = answerBytes
 	}
 
 	answer.UpdatedAt = time.Now()
+	answer.LastModifiedAt = timePtr(modifiedAt)
+	if answer.FirstAnsweredAt == nil {
+		answer.FirstAnsweredAt = timePtr(modifiedAt)
+	}
 
 	if req.TimeSpent != nil {
 		answer.TimeSpent = *req.TimeSpent
 	}
+	if req.Confidence != nil {
+		answer.Confidence = req.Confidence
+	}
 
 	// Upsert answer
 	if answer.ID == 0 {
@@ -386,3 +645,12 @@ func (s *attemptService) updateAttemptAnswer(ctx context.Context, tx *gorm.DB, a
 
 	return nil
 }
+
+// accommodationExtraMinutes converts the accommodation percentage of a time limit to
+// whole minutes, rounding up
+func accommodationExtraMinutes(durationMinutes, percent int) int {
+	if durationMinutes <= 0 || percent <= 0 {
+		return 0
+	}
+	return (durationMinutes*percent + 99) / 100
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/datatypes"
+)
+
+// ===== MULTI-PART ANSWERS =====
+
+// checkQuestionPart verifies that a part answer targets an existing part of a multi-part question
+func (s *attemptService) checkQuestionPart(ctx context.Context, questionID uint, partID string) error {
+	question, err := s.repo.Question().GetByID(ctx, nil, questionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrQuestionNotFound
+		}
+		return fmt.Errorf("failed to get question: %w", err)
+	}
+	if question.Type != models.MultiPart {
+		return NewValidationError("part_id", "question has no parts", partID)
+	}
+
+	var content models.MultiPartContent
+	if err := json.Unmarshal(question.Content, &content); err != nil {
+		return fmt.Errorf("failed to unmarshal question content: %w", err)
+	}
+	for _, part := range content.Parts {
+		if part.ID == partID {
+			return nil
+		}
+	}
+	return NewValidationError("part_id", "question has no such part", partID)
+}
+
+// mergePartAnswer stores one part's answer in a multi-part answer, keeping the answers
+// already given to the other parts
+func mergePartAnswer(existing datatypes.JSON, partID string, partAnswer []byte) (datatypes.JSON, error) {
+	answers := models.MultiPartAnswer{}
+	if len(existing) > 0 && string(existing) != "null" {
+		if err := json.Unmarshal(existing, &answers); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal multi-part answer: %w", err)
+		}
+	}
+	answers[partID] = datatypes.JSON(partAnswer)
+
+	merged, err := json.Marshal(answers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal multi-part answer: %w", err)
+	}
+	return merged, nil
+}
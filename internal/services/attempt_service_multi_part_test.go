@@ -0,0 +1,36 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+func TestMergePartAnswer(t *testing.T) {
+	merged, err := mergePartAnswer(nil, "a", []byte(`true`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	merged, err = mergePartAnswer(merged, "b", []byte(`["x","y"]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	merged, err = mergePartAnswer(merged, "a", []byte(`false`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var answers models.MultiPartAnswer
+	if err := json.Unmarshal(merged, &answers); err != nil {
+		t.Fatalf("merged answer is not a part map: %v", err)
+	}
+	if len(answers) != 2 || string(answers["a"]) != "false" || string(answers["b"]) != `["x","y"]` {
+		t.Errorf("unexpected merged answer: %s", merged)
+	}
+
+	if _, err := mergePartAnswer(datatypes.JSON(`"not a map"`), "a", []byte(`true`)); err == nil {
+		t.Error("expected an error merging into a non multi-part answer")
+	}
+}
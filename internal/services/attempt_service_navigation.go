@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// ===== NAVIGATION LOG =====
+
+// RecordQuestionVisit logs that the student opened a question. The log feeds the
+// assessment's navigation analytics and is not shown to the student.
+func (s *attemptService) RecordQuestionVisit(ctx context.Context, attemptID, questionID uint, studentID string) error {
+	attempt, err := s.getOwnedAttempt(ctx, attemptID, studentID, "record_navigation")
+	if err != nil {
+		return err
+	}
+	if attempt.Status != models.AttemptInProgress {
+		return ErrAttemptNotActive
+	}
+
+	if _, err := s.repo.Answer().GetByAttemptAndQuestion(ctx, s.db, attemptID, questionID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to get answer: %w", err)
+	}
+
+	return s.repo.AttemptNavigation().Create(ctx, nil, &models.AttemptNavigationEvent{
+		AttemptID:  attemptID,
+		QuestionID: questionID,
+		VisitedAt:  time.Now(),
+	})
+}
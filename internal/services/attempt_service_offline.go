@@ -0,0 +1,459 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	offlineSignatureAlgorithm = "HMAC-SHA256"
+	// Tolerated drift between the proctor app's clock and ours
+	offlineClockSkew = 2 * time.Minute
+)
+
+// ===== OFFLINE BUNDLES =====
+
+// ExportOfflineBundle packages an in-progress attempt for a proctor app. Exporting again
+// supersedes bundles of the attempt that have not come back yet.
+func (s *attemptService) ExportOfflineBundle(ctx context.Context, attemptID uint, userID string) (*OfflineBundleExport, error) {
+	s.logger.Info("Exporting offline bundle", "attempt_id", attemptID, "user_id", userID)
+
+	attempt, err := s.getProctoredAttempt(ctx, attemptID, userID, "export_offline_bundle")
+	if err != nil {
+		return nil, err
+	}
+	if attempt.Status != models.AttemptInProgress {
+		return nil, ErrAttemptNotActive
+	}
+	if attempt.PausedAt != nil {
+		return nil, NewBusinessRuleError("attempt_paused", "a saved attempt must be resumed before it can be taken offline", map[string]interface{}{
+			"attempt_id": attemptID,
+		})
+	}
+
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, attempt.AssessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	// Autosaved answers belong in the bundle
+	if _, err := s.FlushBufferedAnswers(ctx, attemptID); err != nil {
+		return nil, fmt.Errorf("failed to flush autosaved answers: %w", err)
+	}
+
+	questions, err := s.getAttemptQuestions(ctx, attempt)
+	if err != nil {
+		return nil, err
+	}
+	route, err := s.attemptRoute(ctx, nil, attempt, nil)
+	if err != nil {
+		return nil, err
+	}
+	questions = orderByRoute(questions, route)
+	if err := s.applyAnswerChangeLimits(ctx, attempt, questions); err != nil {
+		return nil, err
+	}
+	if err := deliverOrderingQuestions(attemptID, questions); err != nil {
+		return nil, err
+	}
+
+	answers, err := s.repo.Answer().GetByAttempt(ctx, nil, attemptID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answers: %w", err)
+	}
+
+	now := time.Now()
+	bundle := &models.OfflineBundle{
+		AttemptID:  attemptID,
+		Status:     models.OfflineBundleExported,
+		SigningKey: newOfflineSigningKey(),
+		ExportedBy: userID,
+		ExportedAt: now,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		pending, err := s.repo.OfflineBundle().GetByAttempt(ctx, tx, attemptID)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.OfflineBundle().Create(ctx, tx, bundle); err != nil {
+			return err
+		}
+		reason := fmt.Sprintf("superseded by bundle %d", bundle.ID)
+		for _, previous := range pending {
+			if previous.Status != models.OfflineBundleExported {
+				continue
+			}
+			previous.Status = models.OfflineBundleRejected
+			previous.RejectionReason = &reason
+			if err := s.repo.OfflineBundle().Update(ctx, tx, previous); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export offline bundle: %w", err)
+	}
+
+	return &OfflineBundleExport{
+		BundleID:           bundle.ID,
+		AttemptID:          attempt.ID,
+		AssessmentID:       assessment.ID,
+		AssessmentTitle:    assessment.Title,
+		StudentID:          attempt.StudentID,
+		StartedAt:          attempt.StartedAt,
+		Deadline:           attempt.EndedAt,
+		ExportedAt:         now,
+		Questions:          questions,
+		Answers:            onlineAnswers(answers),
+		SigningKey:         bundle.SigningKey,
+		SignatureAlgorithm: offlineSignatureAlgorithm,
+	}, nil
+}
+
+// ImportOfflineBundle applies the answers of a signed bundle to its attempt. Answers given
+// outside the attempt window or to questions changed online since the export are not
+// applied and come back as conflicts. A bundle with a submission time closes the attempt.
+func (s *attemptService) ImportOfflineBundle(ctx context.Context, attemptID, bundleID uint, req *ImportOfflineBundleRequest, userID string) (*OfflineBundleImportResult, error) {
+	s.logger.Info("Importing offline bundle", "attempt_id", attemptID, "bundle_id", bundleID, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	attempt, err := s.getProctoredAttempt(ctx, attemptID, userID, "import_offline_bundle")
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := s.repo.OfflineBundle().GetByID(ctx, nil, bundleID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, NewBusinessRuleError("offline_bundle_not_found", "offline bundle not found", map[string]interface{}{
+				"bundle_id": bundleID,
+			})
+		}
+		return nil, fmt.Errorf("failed to get offline bundle: %w", err)
+	}
+	if bundle.AttemptID != attemptID {
+		return nil, NewBusinessRuleError("offline_bundle_not_found", "offline bundle not found", map[string]interface{}{
+			"bundle_id": bundleID,
+		})
+	}
+	if bundle.Status != models.OfflineBundleExported {
+		return nil, NewBusinessRuleError("offline_bundle_closed", "offline bundle was already imported or superseded", map[string]interface{}{
+			"bundle_id": bundleID,
+			"status":    bundle.Status,
+		})
+	}
+
+	// Nothing in the payload is trusted before the signature checks out
+	if !verifyOfflineSignature(bundle.SigningKey, req.Payload, req.Signature) {
+		s.logger.Warn("Offline bundle signature mismatch", "attempt_id", attemptID, "bundle_id", bundleID, "user_id", userID)
+		return nil, NewBusinessRuleError("invalid_bundle_signature", "offline bundle signature does not match", map[string]interface{}{
+			"bundle_id": bundleID,
+		})
+	}
+
+	var submission OfflineBundleSubmission
+	if err := json.Unmarshal([]byte(req.Payload), &submission); err != nil {
+		return nil, ValidationErrors{*NewValidationError("payload", "must be a JSON offline bundle submission", nil)}
+	}
+	if submission.BundleID != bundleID || submission.AttemptID != attemptID {
+		return nil, ValidationErrors{*NewValidationError("payload", "belongs to another bundle or attempt", nil)}
+	}
+
+	now := time.Now()
+	bundle.ImportedBy = &userID
+	bundle.ImportedAt = &now
+	if submission.Device != nil {
+		if deviceInfo, err := json.Marshal(submission.Device); err == nil {
+			bundle.DeviceInfo = deviceInfo
+		}
+	}
+
+	// An attempt the student finished online keeps its online answers
+	if attempt.Status == models.AttemptCompleted || attempt.Status == models.AttemptAbandoned {
+		reason := fmt.Sprintf("attempt is %s", attempt.Status)
+		bundle.Status = models.OfflineBundleRejected
+		bundle.RejectionReason = &reason
+		if err := s.repo.OfflineBundle().Update(ctx, nil, bundle); err != nil {
+			return nil, fmt.Errorf("failed to update offline bundle: %w", err)
+		}
+		return &OfflineBundleImportResult{
+			BundleID:        bundle.ID,
+			AttemptID:       attemptID,
+			Status:          bundle.Status,
+			Conflicts:       []OfflineAnswerConflict{},
+			RejectionReason: &reason,
+		}, nil
+	}
+
+	if _, err := s.FlushBufferedAnswers(ctx, attemptID); err != nil {
+		return nil, fmt.Errorf("failed to flush autosaved answers: %w", err)
+	}
+	answers, err := s.repo.Answer().GetByAttempt(ctx, nil, attemptID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answers: %w", err)
+	}
+
+	window := offlineAttemptWindow(attempt, bundle, now)
+	accepted, conflicts := checkOfflineAnswers(submission.Answers, answers, bundle.ExportedAt, window)
+
+	closeAttempt := attempt.Status == models.AttemptInProgress && submission.SubmittedAt != nil
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		for _, answer := range accepted {
+			answerReq := SubmitAnswerRequest{
+				QuestionID: answer.QuestionID,
+				AnswerData: answer.AnswerData,
+				PartID:     answer.PartID,
+				TimeSpent:  answer.TimeSpent,
+				Confidence: offlineConfidence(answer.Confidence),
+			}
+			if err := s.updateAttemptAnswer(ctx, tx, attemptID, answerReq, answer.AnsweredAt); err != nil {
+				return fmt.Errorf("failed to update answer for question %d: %w", answer.QuestionID, err)
+			}
+		}
+
+		if closeAttempt {
+			if err := s.dropSkippedAnswers(ctx, tx, attempt); err != nil {
+				return err
+			}
+			completedAt := window.clamp(*submission.SubmittedAt)
+			attempt.Status = models.AttemptCompleted
+			attempt.CompletedAt = &completedAt
+			closeSession(attempt, completedAt)
+			if err := s.repo.Attempt().Update(ctx, tx, attempt); err != nil {
+				return fmt.Errorf("failed to update attempt: %w", err)
+			}
+		}
+
+		conflictsJSON, err := json.Marshal(conflicts)
+		if err != nil {
+			return err
+		}
+		bundle.Status = models.OfflineBundleImported
+		bundle.AnswersApplied = len(accepted)
+		bundle.Conflicts = conflictsJSON
+		return s.repo.OfflineBundle().Update(ctx, tx, bundle)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to import offline bundle: %w", err)
+	}
+
+	if closeAttempt {
+		s.metrics.SubmissionRecorded(attempt.AssessmentID)
+		s.releaseAttemptSlot(ctx, attempt.AssessmentID)
+		s.meterProctoring(ctx, attempt)
+	}
+
+	// Finished attempts are graded again with the offline answers in
+	if attempt.Status != models.AttemptInProgress && len(accepted) > 0 {
+		go func() {
+			gradingService := NewGradingService(s.db, s.repo, s.logger, s.validator)
+			if _, err := gradingService.AutoGradeAttempt(context.Background(), attemptID); err != nil {
+				s.logger.Error("Failed to auto-grade attempt", "attempt_id", attemptID, "error", err)
+			}
+		}()
+	}
+
+	return &OfflineBundleImportResult{
+		BundleID:       bundle.ID,
+		AttemptID:      attemptID,
+		Status:         bundle.Status,
+		AnswersApplied: len(accepted),
+		Conflicts:      conflicts,
+		Submitted:      closeAttempt,
+	}, nil
+}
+
+// ===== HELPER METHODS =====
+
+// getProctoredAttempt loads an attempt for a teacher or admin who may access its assessment
+func (s *attemptService) getProctoredAttempt(ctx context.Context, attemptID uint, userID, action string) (*models.AssessmentAttempt, error) {
+	attempt, err := s.repo.Attempt().GetByID(ctx, nil, attemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	userRole, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if userRole != models.RoleTeacher && userRole != models.RoleAdmin {
+		return nil, NewPermissionError(userID, attemptID, "attempt", action, "insufficient permissions")
+	}
+
+	canAccess, err := s.canAccessAttempt(ctx, attempt, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, attemptID, "attempt", action, "not owner or insufficient permissions")
+	}
+
+	return attempt, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// offlineWindow is when offline answers are accepted; a zero end means no time limit
+type offlineWindow struct {
+	start time.Time
+	end   time.Time
+}
+
+func (w offlineWindow) contains(t time.Time) bool {
+	return !t.Before(w.start) && (w.end.IsZero() || !t.After(w.end))
+}
+
+// clamp moves a time into the window, e.g. a submission recorded after the deadline
+func (w offlineWindow) clamp(t time.Time) time.Time {
+	if t.Before(w.start) {
+		return w.start
+	}
+	if !w.end.IsZero() && t.After(w.end) {
+		return w.end
+	}
+	return t
+}
+
+// offlineAttemptWindow runs from the export to the attempt's deadline, or to now without a
+// time limit, widened by the tolerated clock drift
+func offlineAttemptWindow(attempt *models.AssessmentAttempt, bundle *models.OfflineBundle, now time.Time) offlineWindow {
+	start := bundle.ExportedAt
+	if attempt.StartedAt != nil && attempt.StartedAt.After(start) {
+		start = *attempt.StartedAt
+	}
+	end := now
+	if attempt.EndedAt != nil && attempt.EndedAt.Before(end) {
+		end = *attempt.EndedAt
+	}
+	return offlineWindow{start: start.Add(-offlineClockSkew), end: end.Add(offlineClockSkew)}
+}
+
+// checkOfflineAnswers keeps the last answer per question part from the bundle and splits
+// them into those to apply and conflicts. Answers to questions of the attempt that were
+// changed online after the export are conflicts; the online answer stays.
+func checkOfflineAnswers(offline []OfflineAnswer, online []*models.StudentAnswer, exportedAt time.Time, window offlineWindow) ([]OfflineAnswer, []OfflineAnswerConflict) {
+	byQuestion := make(map[uint]*models.StudentAnswer, len(online))
+	for _, answer := range online {
+		byQuestion[answer.QuestionID] = answer
+	}
+
+	type answerKey struct {
+		questionID uint
+		partID     string
+	}
+	latest := make(map[answerKey]OfflineAnswer)
+	var order []answerKey
+	for _, answer := range offline {
+		key := answerKey{answer.QuestionID, answer.PartID}
+		previous, seen := latest[key]
+		if !seen {
+			order = append(order, key)
+		}
+		if !seen || !answer.AnsweredAt.Before(previous.AnsweredAt) {
+			latest[key] = answer
+		}
+	}
+
+	accepted := []OfflineAnswer{}
+	conflicts := []OfflineAnswerConflict{}
+	for _, key := range order {
+		answer := latest[key]
+		reason := ""
+		current, ok := byQuestion[answer.QuestionID]
+		switch {
+		case !ok:
+			reason = OfflineConflictNotInAttempt
+		case !window.contains(answer.AnsweredAt):
+			reason = OfflineConflictOutsideWindow
+		case current.LastModifiedAt != nil && current.LastModifiedAt.After(exportedAt):
+			reason = OfflineConflictChangedOnline
+		}
+		if reason != "" {
+			conflicts = append(conflicts, OfflineAnswerConflict{
+				QuestionID: answer.QuestionID,
+				PartID:     answer.PartID,
+				AnsweredAt: answer.AnsweredAt,
+				Reason:     reason,
+			})
+			continue
+		}
+		accepted = append(accepted, answer)
+	}
+
+	// Applied in the order they were given, so change counts follow the student
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].AnsweredAt.Before(accepted[j].AnsweredAt)
+	})
+	return accepted, conflicts
+}
+
+// onlineAnswers lists the answers already given, for the proctor app to start from
+func onlineAnswers(answers []*models.StudentAnswer) []OfflineAnswer {
+	result := []OfflineAnswer{}
+	for _, answer := range answers {
+		if !isAnswerGiven(answer.Answer) {
+			continue
+		}
+		timeSpent := answer.TimeSpent
+		given := OfflineAnswer{
+			QuestionID: answer.QuestionID,
+			AnswerData: json.RawMessage(answer.Answer),
+			TimeSpent:  &timeSpent,
+			Confidence: answer.Confidence,
+		}
+		if answer.LastModifiedAt != nil {
+			given.AnsweredAt = *answer.LastModifiedAt
+		}
+		result = append(result, given)
+	}
+	return result
+}
+
+// offlineConfidence drops a confidence rating outside 1-5; bundles are not validated
+// like online answers
+func offlineConfidence(confidence *int) *int {
+	if confidence == nil || *confidence < minConfidence || *confidence > maxConfidence {
+		return nil
+	}
+	return confidence
+}
+
+// verifyOfflineSignature checks a hex HMAC-SHA256 of the payload, keyed with the bundle's
+// signing key string as exported
+func verifyOfflineSignature(key, payload, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(payload))
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+func newOfflineSigningKey() string {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand only fails when the OS entropy source is unavailable
+		panic(fmt.Sprintf("failed to generate offline signing key: %v", err))
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestVerifyOfflineSignature(t *testing.T) {
+	key := newOfflineSigningKey()
+	payload := `{"bundle_id":1,"attempt_id":2,"answers":[]}`
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(payload))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	if !verifyOfflineSignature(key, payload, signature) {
+		t.Fatal("expected the signature to verify")
+	}
+	if verifyOfflineSignature(key, payload+" ", signature) {
+		t.Error("a changed payload should not verify")
+	}
+	if verifyOfflineSignature(newOfflineSigningKey(), payload, signature) {
+		t.Error("another bundle's key should not verify")
+	}
+	if verifyOfflineSignature(key, payload, "not hex") {
+		t.Error("a malformed signature should not verify")
+	}
+}
+
+func TestOfflineAttemptWindow(t *testing.T) {
+	started := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	exported := started.Add(5 * time.Minute)
+	deadline := started.Add(time.Hour)
+	now := started.Add(3 * time.Hour)
+	attempt := &models.AssessmentAttempt{StartedAt: &started, EndedAt: &deadline}
+	bundle := &models.OfflineBundle{ExportedAt: exported}
+
+	window := offlineAttemptWindow(attempt, bundle, now)
+	if !window.contains(exported.Add(-time.Minute)) || window.contains(exported.Add(-3*time.Minute)) {
+		t.Errorf("window should start at the export less the clock skew: %+v", window)
+	}
+	if !window.contains(deadline.Add(time.Minute)) || window.contains(deadline.Add(3*time.Minute)) {
+		t.Errorf("window should end at the deadline plus the clock skew: %+v", window)
+	}
+	if got := window.clamp(now); !got.Equal(deadline.Add(offlineClockSkew)) {
+		t.Errorf("late submission should be clamped to the window end, got %v", got)
+	}
+
+	// Without a time limit answers cannot come from the future
+	attempt.EndedAt = nil
+	window = offlineAttemptWindow(attempt, bundle, now)
+	if window.contains(now.Add(time.Hour)) {
+		t.Error("answers after the import should be outside the window")
+	}
+}
+
+func TestCheckOfflineAnswers(t *testing.T) {
+	exported := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	window := offlineWindow{start: exported, end: exported.Add(time.Hour)}
+	changedOnline := exported.Add(10 * time.Minute)
+	online := []*models.StudentAnswer{
+		{QuestionID: 1},
+		{QuestionID: 2, LastModifiedAt: &changedOnline},
+		{QuestionID: 3},
+	}
+	at := func(minutes int) time.Time { return exported.Add(time.Duration(minutes) * time.Minute) }
+	offline := []OfflineAnswer{
+		{QuestionID: 1, AnswerData: json.RawMessage(`"b"`), AnsweredAt: at(20)},
+		{QuestionID: 1, AnswerData: json.RawMessage(`"a"`), AnsweredAt: at(5)},
+		{QuestionID: 2, AnswerData: json.RawMessage(`true`), AnsweredAt: at(15)},
+		{QuestionID: 3, AnswerData: json.RawMessage(`"late"`), AnsweredAt: at(90)},
+		{QuestionID: 9, AnswerData: json.RawMessage(`"x"`), AnsweredAt: at(1)},
+	}
+
+	accepted, conflicts := checkOfflineAnswers(offline, online, exported, window)
+	if len(accepted) != 1 || accepted[0].QuestionID != 1 || string(accepted[0].AnswerData) != `"b"` {
+		t.Fatalf("expected only the last answer to question 1, got %+v", accepted)
+	}
+
+	want := map[uint]string{
+		2: OfflineConflictChangedOnline,
+		3: OfflineConflictOutsideWindow,
+		9: OfflineConflictNotInAttempt,
+	}
+	if len(conflicts) != len(want) {
+		t.Fatalf("expected %d conflicts, got %+v", len(want), conflicts)
+	}
+	for _, conflict := range conflicts {
+		if want[conflict.QuestionID] != conflict.Reason {
+			t.Errorf("question %d: got %s, want %s", conflict.QuestionID, conflict.Reason, want[conflict.QuestionID])
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"slices"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+// orderingDelivery is the content of an ordering question as a student answering it sees it
+type orderingDelivery struct {
+	Items         []models.OrderItem `json:"items"` // In the order to start from
+	RandomizeInit bool               `json:"randomize_initial"`
+}
+
+// ===== ORDERING DELIVERY =====
+
+// deliverOrderingQuestions replaces the content of the ordering questions of an attempt in
+// progress by what the student may see: the items without the correct order or scoring
+func deliverOrderingQuestions(attemptID uint, questions []QuestionForAttempt) error {
+	for i := range questions {
+		question := questions[i].Question
+		if question == nil || question.Type != models.Ordering {
+			continue
+		}
+		content, err := orderingDeliveryContent(question.Content, attemptID, question.ID)
+		if err != nil {
+			return err
+		}
+		served := *question
+		served.Content = content
+		questions[i].Question = &served
+	}
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// orderingDeliveryContent strips the answer key from ordering content. Items the question
+// randomizes are shuffled per attempt, the same way on every request, and never start in the
+// correct order.
+func orderingDeliveryContent(content []byte, attemptID, questionID uint) (datatypes.JSON, error) {
+	var c models.OrderingContent
+	if err := json.Unmarshal(content, &c); err != nil {
+		return nil, fmt.Errorf("invalid ordering content: %w", err)
+	}
+
+	items := append([]models.OrderItem(nil), c.Items...)
+	if c.RandomizeInit && len(items) > 1 {
+		h := fnv.New64a()
+		_, _ = fmt.Fprintf(h, "%d:%d", attemptID, questionID)
+		rng := rand.New(rand.NewSource(int64(h.Sum64())))
+		rng.Shuffle(len(items), func(i, j int) { items[i], items[j] = items[j], items[i] })
+
+		ids := make([]string, len(items))
+		for i, item := range items {
+			ids[i] = item.ID
+		}
+		if slices.Equal(ids, c.CorrectOrder) {
+			items = append(items[1:], items[0])
+		}
+	}
+
+	data, err := json.Marshal(orderingDelivery{Items: items, RandomizeInit: c.RandomizeInit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ordering content: %w", err)
+	}
+	return data, nil
+}
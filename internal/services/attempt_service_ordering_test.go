@@ -0,0 +1,64 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestOrderingDeliveryContent(t *testing.T) {
+	content, _ := json.Marshal(models.OrderingContent{
+		Items:         []models.OrderItem{{ID: "a", Text: "One"}, {ID: "b", Text: "Two"}},
+		CorrectOrder:  []string{"a", "b"},
+		RandomizeInit: true,
+		PartialCredit: true,
+		Scoring:       models.OrderingScoringKendallTau,
+	})
+
+	for attemptID := uint(1); attemptID <= 20; attemptID++ {
+		delivered, err := orderingDeliveryContent(content, attemptID, 9)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Contains(string(delivered), "correct_order") || strings.Contains(string(delivered), "kendall_tau") {
+			t.Fatalf("expected the answer key to be stripped, got %s", delivered)
+		}
+		var served orderingDelivery
+		if err := json.Unmarshal(delivered, &served); err != nil || len(served.Items) != 2 {
+			t.Fatalf("unexpected content %s", delivered)
+		}
+		if served.Items[0].ID != "b" {
+			t.Errorf("attempt %d: expected the items never to start in the correct order", attemptID)
+		}
+		again, _ := orderingDeliveryContent(content, attemptID, 9)
+		if string(again) != string(delivered) {
+			t.Errorf("attempt %d: expected the same order on every request", attemptID)
+		}
+	}
+}
+
+func TestDeliverOrderingQuestions(t *testing.T) {
+	ordering, _ := json.Marshal(models.OrderingContent{
+		Items:        []models.OrderItem{{ID: "a", Text: "One"}, {ID: "b", Text: "Two"}},
+		CorrectOrder: []string{"a", "b"},
+	})
+	original := &models.Question{ID: 1, Type: models.Ordering, Content: ordering}
+	essay := &models.Question{ID: 2, Type: models.Essay, Content: []byte(`{}`)}
+	questions := []QuestionForAttempt{{Question: original}, {Question: essay}}
+
+	if err := deliverOrderingQuestions(5, questions); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(string(questions[0].Content), "correct_order") {
+		t.Errorf("expected the ordering answer key to be stripped, got %s", questions[0].Content)
+	}
+	if !strings.Contains(string(original.Content), "correct_order") {
+		t.Error("expected the original question to be left alone")
+	}
+	if questions[1].Question != essay {
+		t.Error("expected other question types to be left alone")
+	}
+}
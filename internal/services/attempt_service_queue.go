@@ -0,0 +1,315 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// How long a freed slot is held for the student at the head of the queue
+const attemptQueueHold = 5 * time.Minute
+
+// ===== ATTEMPT QUEUE =====
+
+// JoinAttemptQueue puts the student in line for a capped assessment. Students already
+// queued keep their place; a free slot is held for them straight away.
+func (s *attemptService) JoinAttemptQueue(ctx context.Context, assessmentID uint, studentID string) (*AttemptQueueStatus, error) {
+	s.logger.Info("Joining attempt queue", "assessment_id", assessmentID, "student_id", studentID)
+
+	settings, err := s.getCapacitySettings(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.MaxConcurrentAttempts == 0 {
+		return nil, NewBusinessRuleError("attempt_queue_not_needed", "this assessment has no cap on concurrent attempts", map[string]interface{}{
+			"assessment_id": assessmentID,
+		})
+	}
+
+	hasActive, err := s.repo.Attempt().HasActiveAttempt(ctx, s.db, studentID, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check active attempt: %w", err)
+	}
+	if !hasActive {
+		canStart, err := s.CanStart(ctx, assessmentID, studentID)
+		if err != nil {
+			return nil, err
+		}
+		if !canStart {
+			return nil, ErrAttemptCannotStart
+		}
+	}
+
+	var admitted []*models.AttemptQueueEntry
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.AttemptQueue().LockAssessment(ctx, tx, assessmentID); err != nil {
+			return err
+		}
+
+		// Students resuming an attempt already have a slot
+		if !hasActive {
+			_, err := s.repo.AttemptQueue().GetByStudent(ctx, tx, assessmentID, studentID)
+			if repositories.IsNotFoundError(err) {
+				err = s.repo.AttemptQueue().Create(ctx, tx, &models.AttemptQueueEntry{
+					AssessmentID: assessmentID,
+					StudentID:    studentID,
+					Status:       models.AttemptQueueWaiting,
+				})
+			}
+			if err != nil {
+				return fmt.Errorf("failed to join attempt queue: %w", err)
+			}
+		}
+
+		admitted, err = s.admitQueuedStudents(ctx, tx, assessmentID, settings.MaxConcurrentAttempts, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notifyAdmitted(ctx, admitted)
+
+	return s.buildAttemptQueueStatus(ctx, assessmentID, studentID, settings.MaxConcurrentAttempts)
+}
+
+// GetAttemptQueueStatus reports the student's place in line. Slots freed by lapsed
+// admissions are handed on first, so polling students see an up-to-date position.
+func (s *attemptService) GetAttemptQueueStatus(ctx context.Context, assessmentID uint, studentID string) (*AttemptQueueStatus, error) {
+	settings, err := s.getCapacitySettings(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.MaxConcurrentAttempts > 0 {
+		s.releaseAttemptSlot(ctx, assessmentID)
+	}
+
+	return s.buildAttemptQueueStatus(ctx, assessmentID, studentID, settings.MaxConcurrentAttempts)
+}
+
+// LeaveAttemptQueue gives up the student's place, or the slot held for them
+func (s *attemptService) LeaveAttemptQueue(ctx context.Context, assessmentID uint, studentID string) error {
+	s.logger.Info("Leaving attempt queue", "assessment_id", assessmentID, "student_id", studentID)
+
+	entry, err := s.repo.AttemptQueue().GetByStudent(ctx, s.db, assessmentID, studentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to get attempt queue entry: %w", err)
+	}
+	if err := s.repo.AttemptQueue().Delete(ctx, s.db, entry.ID); err != nil {
+		return err
+	}
+
+	if entry.Status == models.AttemptQueueAdmitted {
+		s.releaseAttemptSlot(ctx, assessmentID)
+	}
+	return nil
+}
+
+// ===== HELPER METHODS =====
+
+// claimAttemptSlot takes a slot for a new attempt on a capped assessment, using the slot
+// held for the student if they were admitted from the queue. Newly admitted queue entries
+// are returned for notification once the transaction commits.
+func (s *attemptService) claimAttemptSlot(ctx context.Context, tx *gorm.DB, assessmentID uint, capacity int, studentID string, now time.Time) ([]*models.AttemptQueueEntry, error) {
+	if capacity == 0 {
+		return nil, nil
+	}
+
+	queue := s.repo.AttemptQueue()
+	if err := queue.LockAssessment(ctx, tx, assessmentID); err != nil {
+		return nil, err
+	}
+
+	admitted, err := s.admitQueuedStudents(ctx, tx, assessmentID, capacity, now)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := queue.GetByStudent(ctx, tx, assessmentID, studentID)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get attempt queue entry: %w", err)
+	}
+	if entry != nil && entry.Status == models.AttemptQueueAdmitted {
+		if err := queue.Delete(ctx, tx, entry.ID); err != nil {
+			return nil, err
+		}
+		return admitted, nil
+	}
+
+	active, err := s.repo.Attempt().CountActive(ctx, tx, assessmentID, now)
+	if err != nil {
+		return nil, err
+	}
+	held, err := queue.CountHeld(ctx, tx, assessmentID, now)
+	if err != nil {
+		return nil, err
+	}
+	waiting, err := queue.CountWaiting(ctx, tx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAttemptCapacity(capacity, active, held, waiting); err != nil {
+		return nil, err
+	}
+
+	return admitted, nil
+}
+
+// releaseAttemptSlot hands slots freed by finished attempts or lapsed admissions to the
+// students next in line. Failures are logged; the next queue check retries.
+func (s *attemptService) releaseAttemptSlot(ctx context.Context, assessmentID uint) {
+	settings, err := s.getCapacitySettings(ctx, assessmentID)
+	if err != nil || settings.MaxConcurrentAttempts == 0 {
+		return
+	}
+
+	var admitted []*models.AttemptQueueEntry
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.AttemptQueue().LockAssessment(ctx, tx, assessmentID); err != nil {
+			return err
+		}
+		admitted, err = s.admitQueuedStudents(ctx, tx, assessmentID, settings.MaxConcurrentAttempts, time.Now())
+		return err
+	})
+	if err != nil {
+		s.logger.Error("Failed to admit queued students", "assessment_id", assessmentID, "error", err)
+		return
+	}
+	s.notifyAdmitted(ctx, admitted)
+}
+
+// admitQueuedStudents drops lapsed admissions and holds free slots for the students who
+// have waited longest. Callers hold the assessment lock.
+func (s *attemptService) admitQueuedStudents(ctx context.Context, tx *gorm.DB, assessmentID uint, capacity int, now time.Time) ([]*models.AttemptQueueEntry, error) {
+	queue := s.repo.AttemptQueue()
+	if err := queue.DeleteExpiredHolds(ctx, tx, assessmentID, now); err != nil {
+		return nil, err
+	}
+
+	active, err := s.repo.Attempt().CountActive(ctx, tx, assessmentID, now)
+	if err != nil {
+		return nil, err
+	}
+	held, err := queue.CountHeld(ctx, tx, assessmentID, now)
+	if err != nil {
+		return nil, err
+	}
+	free := attemptSlotsFree(capacity, active, held)
+	if free == 0 {
+		return nil, nil
+	}
+
+	next, err := queue.GetWaiting(ctx, tx, assessmentID, free)
+	if err != nil {
+		return nil, err
+	}
+
+	heldUntil := now.Add(attemptQueueHold)
+	for _, entry := range next {
+		entry.Status = models.AttemptQueueAdmitted
+		entry.AdmittedAt = timePtr(now)
+		entry.AdmittedUntil = timePtr(heldUntil)
+		if err := queue.Update(ctx, tx, entry); err != nil {
+			return nil, err
+		}
+	}
+	return next, nil
+}
+
+func (s *attemptService) notifyAdmitted(ctx context.Context, admitted []*models.AttemptQueueEntry) {
+	if s.notifier == nil {
+		return
+	}
+	for _, entry := range admitted {
+		if err := s.notifier.NotifyAttemptSlotOpened(ctx, entry.AssessmentID, entry.StudentID, *entry.AdmittedUntil); err != nil {
+			s.logger.Error("Failed to notify admitted student",
+				"assessment_id", entry.AssessmentID,
+				"student_id", entry.StudentID,
+				"error", err)
+		}
+	}
+}
+
+func (s *attemptService) buildAttemptQueueStatus(ctx context.Context, assessmentID uint, studentID string, capacity int) (*AttemptQueueStatus, error) {
+	status := &AttemptQueueStatus{
+		AssessmentID: assessmentID,
+		Capacity:     capacity,
+	}
+	if capacity == 0 {
+		return status, nil
+	}
+
+	now := time.Now()
+	queue := s.repo.AttemptQueue()
+	var err error
+	if status.ActiveAttempts, err = s.repo.Attempt().CountActive(ctx, s.db, assessmentID, now); err != nil {
+		return nil, err
+	}
+	if status.QueueLength, err = queue.CountWaiting(ctx, s.db, assessmentID); err != nil {
+		return nil, err
+	}
+
+	entry, err := queue.GetByStudent(ctx, s.db, assessmentID, studentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return status, nil
+		}
+		return nil, fmt.Errorf("failed to get attempt queue entry: %w", err)
+	}
+
+	status.Queued = true
+	status.JoinedAt = &entry.CreatedAt
+	if entry.Status == models.AttemptQueueAdmitted {
+		status.Admitted = true
+		status.AdmittedUntil = entry.AdmittedUntil
+		return status, nil
+	}
+
+	ahead, err := queue.CountWaitingAhead(ctx, s.db, assessmentID, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+	status.Position = ahead + 1
+	return status, nil
+}
+
+func (s *attemptService) getCapacitySettings(ctx context.Context, assessmentID uint) (*models.AssessmentSettings, error) {
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, s.db, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment settings: %w", err)
+	}
+	return settings, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// attemptSlotsFree is how many more attempts may start or be held for queued students
+func attemptSlotsFree(capacity, active, held int) int {
+	free := capacity - active - held
+	if free < 0 {
+		return 0
+	}
+	return free
+}
+
+// checkAttemptCapacity lets a student without a held slot start only when a slot is free
+// and nobody is waiting for it
+func checkAttemptCapacity(capacity, active, held, waiting int) error {
+	if attemptSlotsFree(capacity, active, held) > 0 && waiting == 0 {
+		return nil
+	}
+	return NewBusinessRuleError("attempt_capacity_reached", "all attempt slots are taken; join the queue to be notified when one frees up", map[string]interface{}{
+		"capacity":        capacity,
+		"active_attempts": active,
+		"queue_length":    waiting,
+	})
+}
@@ -0,0 +1,34 @@
+package services
+
+import "testing"
+
+func TestAttemptSlotsFree(t *testing.T) {
+	cases := []struct {
+		capacity, active, held, want int
+	}{
+		{5, 2, 1, 2},
+		{5, 5, 0, 0},
+		{5, 4, 1, 0},
+		// Lowering the cap below running attempts must not go negative
+		{3, 5, 1, 0},
+	}
+	for _, c := range cases {
+		if got := attemptSlotsFree(c.capacity, c.active, c.held); got != c.want {
+			t.Errorf("attemptSlotsFree(%d, %d, %d) = %d, want %d", c.capacity, c.active, c.held, got, c.want)
+		}
+	}
+}
+
+func TestCheckAttemptCapacity(t *testing.T) {
+	if err := checkAttemptCapacity(5, 3, 1, 0); err != nil {
+		t.Errorf("expected a free slot, got %v", err)
+	}
+
+	// A free slot is kept for the queue rather than taken by a newcomer
+	if err := checkAttemptCapacity(5, 3, 0, 1); !IsBusinessRule(err) {
+		t.Errorf("expected waiting students to go first, got %v", err)
+	}
+	if err := checkAttemptCapacity(5, 4, 1, 0); !IsBusinessRule(err) {
+		t.Errorf("expected capacity to be reached, got %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+const ruleAttemptQuotaExceeded = "attempt_quota_exceeded"
+
+// ===== QUOTA MANAGEMENT =====
+
+func (s *attemptService) CreateAttemptQuota(ctx context.Context, req *CreateAttemptQuotaRequest, adminID string) (*models.AttemptQuota, error) {
+	s.logger.Info("Creating attempt quota", "bank_id", req.BankID, "admin_id", adminID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := s.requireQuotaAdmin(ctx, adminID, "create"); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.QuestionBank().GetByID(ctx, nil, req.BankID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ValidationErrors{*NewValidationError("bank_id", "question bank not found", req.BankID)}
+		}
+		return nil, fmt.Errorf("failed to get question bank: %w", err)
+	}
+
+	quota := &models.AttemptQuota{
+		Name:        strings.TrimSpace(req.Name),
+		BankID:      req.BankID,
+		MaxAttempts: req.MaxAttempts,
+		WindowHours: req.WindowHours,
+		IsActive:    true,
+		CreatedBy:   adminID,
+	}
+	if req.ClassID != nil {
+		quota.ClassID = quotaClassID(*req.ClassID)
+	}
+	if err := s.repo.AttemptQuota().Create(ctx, nil, quota); err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+func (s *attemptService) ListAttemptQuotas(ctx context.Context, adminID string) ([]*models.AttemptQuota, error) {
+	if err := s.requireQuotaAdmin(ctx, adminID, "list"); err != nil {
+		return nil, err
+	}
+	return s.repo.AttemptQuota().List(ctx, nil)
+}
+
+// UpdateAttemptQuota changes a quota's limits or group. Its bank cannot change; create
+// another quota instead.
+func (s *attemptService) UpdateAttemptQuota(ctx context.Context, id uint, req *UpdateAttemptQuotaRequest, adminID string) (*models.AttemptQuota, error) {
+	s.logger.Info("Updating attempt quota", "quota_id", id, "admin_id", adminID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	if err := s.requireQuotaAdmin(ctx, adminID, "update"); err != nil {
+		return nil, err
+	}
+
+	quota, err := s.getAttemptQuota(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		quota.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.ClassID != nil {
+		quota.ClassID = quotaClassID(*req.ClassID)
+	}
+	if req.MaxAttempts != nil {
+		quota.MaxAttempts = *req.MaxAttempts
+	}
+	if req.WindowHours != nil {
+		quota.WindowHours = *req.WindowHours
+	}
+	if req.IsActive != nil {
+		quota.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.AttemptQuota().Update(ctx, nil, quota); err != nil {
+		return nil, err
+	}
+	return quota, nil
+}
+
+func (s *attemptService) DeleteAttemptQuota(ctx context.Context, id uint, adminID string) error {
+	s.logger.Info("Deleting attempt quota", "quota_id", id, "admin_id", adminID)
+
+	if err := s.requireQuotaAdmin(ctx, adminID, "delete"); err != nil {
+		return err
+	}
+	if _, err := s.getAttemptQuota(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.AttemptQuota().Delete(ctx, nil, id)
+}
+
+// ===== STUDENT STATUS =====
+
+// GetAttemptQuotaStatus reports the student's use of each active quota that applies to them,
+// or only of those covering the assessment when assessmentID is set
+func (s *attemptService) GetAttemptQuotaStatus(ctx context.Context, assessmentID *uint, studentID string) ([]AttemptQuotaStatus, error) {
+	quotas, err := s.studentAttemptQuotas(ctx, assessmentID, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	statuses := make([]AttemptQuotaStatus, 0, len(quotas))
+	for _, quota := range quotas {
+		status, err := s.evaluateQuota(ctx, s.db, quota, studentID, now)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// ===== HELPER METHODS =====
+
+// enforceAttemptQuotas refuses a new attempt once the student used up one of the quotas
+// within its window. The quotas stay locked for the student until tx ends, so concurrent
+// starts on any replica are counted one after another.
+func (s *attemptService) enforceAttemptQuotas(ctx context.Context, tx *gorm.DB, quotas []*models.AttemptQuota, studentID string, now time.Time) error {
+	// Lock in a fixed order so starts covered by several quotas cannot deadlock
+	locked := slices.Clone(quotas)
+	slices.SortFunc(locked, func(a, b *models.AttemptQuota) int { return cmp.Compare(a.ID, b.ID) })
+	for _, quota := range locked {
+		if err := s.repo.AttemptQuota().LockStudent(ctx, tx, quota.ID, studentID); err != nil {
+			return err
+		}
+	}
+
+	for _, quota := range quotas {
+		status, err := s.evaluateQuota(ctx, tx, quota, studentID, now)
+		if err != nil {
+			return err
+		}
+		if status.Remaining > 0 {
+			continue
+		}
+		return NewBusinessRuleError(ruleAttemptQuotaExceeded, fmt.Sprintf("attempt quota %q is used up; at most %d attempts are allowed every %d hours", quota.Name, quota.MaxAttempts, quota.WindowHours), map[string]interface{}{
+			"quota_id":          quota.ID,
+			"max_attempts":      quota.MaxAttempts,
+			"window_hours":      quota.WindowHours,
+			"used":              status.Used,
+			"next_available_at": status.NextAvailableAt,
+		})
+	}
+	return nil
+}
+
+// studentAttemptQuotas returns the active quotas applying to the student's classes
+func (s *attemptService) studentAttemptQuotas(ctx context.Context, assessmentID *uint, studentID string) ([]*models.AttemptQuota, error) {
+	quotas, err := s.repo.AttemptQuota().GetActive(ctx, s.db, assessmentID)
+	if err != nil || len(quotas) == 0 {
+		return nil, err
+	}
+
+	enrollments, err := s.repo.Enrollment().GetByStudent(ctx, s.db, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrollments: %w", err)
+	}
+	var classes []string
+	for _, enrollment := range enrollments {
+		if enrollment.ClassID != nil && !slices.Contains(classes, *enrollment.ClassID) {
+			classes = append(classes, *enrollment.ClassID)
+		}
+	}
+
+	applying := make([]*models.AttemptQuota, 0, len(quotas))
+	for _, quota := range quotas {
+		if quotaAppliesTo(quota, classes) {
+			applying = append(applying, quota)
+		}
+	}
+	return applying, nil
+}
+
+func (s *attemptService) evaluateQuota(ctx context.Context, tx *gorm.DB, quota *models.AttemptQuota, studentID string, now time.Time) (AttemptQuotaStatus, error) {
+	starts, err := s.repo.AttemptQuota().GetAttemptStarts(ctx, tx, studentID, quota.BankID, now.Add(-quota.Window()))
+	if err != nil {
+		return AttemptQuotaStatus{}, err
+	}
+	return evaluateAttemptQuota(quota, starts, now), nil
+}
+
+func (s *attemptService) getAttemptQuota(ctx context.Context, id uint) (*models.AttemptQuota, error) {
+	quota, err := s.repo.AttemptQuota().GetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt quota: %w", err)
+	}
+	return quota, nil
+}
+
+func (s *attemptService) requireQuotaAdmin(ctx context.Context, adminID, action string) error {
+	role, err := s.getUserRole(ctx, adminID)
+	if err != nil {
+		return err
+	}
+	if role != models.RoleAdmin {
+		return NewPermissionError(adminID, 0, "attempt_quota", action, "only admins may manage attempt quotas")
+	}
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// quotaAppliesTo reports whether a quota covers a student enrolled with the given classes
+func quotaAppliesTo(quota *models.AttemptQuota, classes []string) bool {
+	return quota.ClassID == nil || slices.Contains(classes, *quota.ClassID)
+}
+
+// evaluateAttemptQuota counts the attempts started within the quota's window. Once it is
+// used up, the next attempt frees up when enough of the oldest starts leave the window.
+func evaluateAttemptQuota(quota *models.AttemptQuota, starts []time.Time, now time.Time) AttemptQuotaStatus {
+	windowStart := now.Add(-quota.Window())
+	var counted []time.Time
+	for _, start := range starts {
+		if !start.Before(windowStart) && !start.After(now) {
+			counted = append(counted, start)
+		}
+	}
+	slices.SortFunc(counted, func(a, b time.Time) int { return a.Compare(b) })
+
+	status := AttemptQuotaStatus{
+		QuotaID:     quota.ID,
+		Name:        quota.Name,
+		BankID:      quota.BankID,
+		ClassID:     quota.ClassID,
+		MaxAttempts: quota.MaxAttempts,
+		WindowHours: quota.WindowHours,
+		WindowStart: windowStart,
+		Used:        len(counted),
+		Remaining:   max(quota.MaxAttempts-len(counted), 0),
+	}
+	if status.Remaining == 0 && len(counted) > 0 {
+		status.NextAvailableAt = timePtr(counted[len(counted)-quota.MaxAttempts].Add(quota.Window()))
+	}
+	return status
+}
+
+// quotaClassID normalizes a requested class; empty applies the quota to every student
+func quotaClassID(classID string) *string {
+	classID = strings.TrimSpace(classID)
+	if classID == "" {
+		return nil
+	}
+	return &classID
+}
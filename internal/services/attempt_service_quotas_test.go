@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+func TestEvaluateAttemptQuota(t *testing.T) {
+	now := time.Date(2025, 5, 10, 12, 0, 0, 0, time.UTC)
+	quota := &models.AttemptQuota{ID: 1, Name: "Practice", BankID: 4, MaxAttempts: 2, WindowHours: 168}
+
+	status := evaluateAttemptQuota(quota, []time.Time{now.Add(-200 * time.Hour), now.Add(-time.Hour)}, now)
+	if status.Used != 1 || status.Remaining != 1 || status.NextAvailableAt != nil {
+		t.Errorf("expected an attempt outside the window to be ignored, got %+v", status)
+	}
+	if !status.WindowStart.Equal(now.Add(-168 * time.Hour)) {
+		t.Errorf("expected the window to start a week ago, got %v", status.WindowStart)
+	}
+
+	oldest := now.Add(-100 * time.Hour)
+	status = evaluateAttemptQuota(quota, []time.Time{now.Add(-2 * time.Hour), oldest, now.Add(-time.Hour)}, now)
+	if status.Used != 3 || status.Remaining != 0 {
+		t.Fatalf("expected the quota to be used up, got %+v", status)
+	}
+	// Two starts must leave the window before a third fits; the second oldest leaves last
+	if want := now.Add(-2 * time.Hour).Add(168 * time.Hour); status.NextAvailableAt == nil || !status.NextAvailableAt.Equal(want) {
+		t.Errorf("expected the next attempt at %v, got %v", want, status.NextAvailableAt)
+	}
+
+	status = evaluateAttemptQuota(quota, []time.Time{oldest, now.Add(-time.Hour)}, now)
+	if want := oldest.Add(168 * time.Hour); status.NextAvailableAt == nil || !status.NextAvailableAt.Equal(want) {
+		t.Errorf("expected the next attempt once the oldest start leaves the window, got %v", status.NextAvailableAt)
+	}
+}
+
+func TestQuotaAppliesTo(t *testing.T) {
+	everyone := &models.AttemptQuota{}
+	if !quotaAppliesTo(everyone, nil) {
+		t.Error("expected a quota without a class to apply to every student")
+	}
+
+	classQuota := &models.AttemptQuota{ClassID: stringPtr("bio-2")}
+	if !quotaAppliesTo(classQuota, []string{"chem-1", "bio-2"}) {
+		t.Error("expected the quota to apply to students enrolled with its class")
+	}
+	if quotaAppliesTo(classQuota, []string{"chem-1"}) || quotaAppliesTo(classQuota, nil) {
+		t.Error("expected the quota not to apply to other students")
+	}
+}
+
+func TestQuotaClassID(t *testing.T) {
+	if got := quotaClassID("  bio-2 "); got == nil || *got != "bio-2" {
+		t.Errorf("expected a trimmed class, got %v", got)
+	}
+	if got := quotaClassID("  "); got != nil {
+		t.Errorf("expected an empty class to apply the quota to everyone, got %q", *got)
+	}
+}
+
+// quotaLockStore records the quota locks taken and the transaction starts are counted in
+type quotaLockStore struct {
+	repositories.AttemptQuotaRepository
+	starts    map[uint][]time.Time
+	locked    []uint
+	countedIn []*gorm.DB
+}
+
+func (q *quotaLockStore) LockStudent(ctx context.Context, tx *gorm.DB, quotaID uint, studentID string) error {
+	q.locked = append(q.locked, quotaID)
+	return nil
+}
+
+func (q *quotaLockStore) GetAttemptStarts(ctx context.Context, tx *gorm.DB, studentID string, bankID uint, since time.Time) ([]time.Time, error) {
+	q.countedIn = append(q.countedIn, tx)
+	return q.starts[bankID], nil
+}
+
+type quotaRepository struct {
+	MockNotificationRepository
+	quotas *quotaLockStore
+}
+
+func (r *quotaRepository) AttemptQuota() repositories.AttemptQuotaRepository { return r.quotas }
+
+func TestEnforceAttemptQuotasUnderLock(t *testing.T) {
+	now := time.Now()
+	store := &quotaLockStore{starts: map[uint][]time.Time{4: {now.Add(-time.Hour)}}}
+	service := &attemptService{repo: &quotaRepository{quotas: store}}
+	tx := &gorm.DB{}
+	// Listed by name, as GetActive returns them
+	quotas := []*models.AttemptQuota{
+		{ID: 7, Name: "Algebra", BankID: 3, MaxAttempts: 2, WindowHours: 24},
+		{ID: 2, Name: "Practice", BankID: 4, MaxAttempts: 2, WindowHours: 24},
+	}
+
+	if err := service.enforceAttemptQuotas(context.Background(), tx, quotas, "student-1", now); err != nil {
+		t.Fatalf("expected a start within both quotas, got %v", err)
+	}
+	if !slices.Equal(store.locked, []uint{2, 7}) {
+		t.Errorf("expected the quotas locked by id, got %v", store.locked)
+	}
+	for _, counted := range store.countedIn {
+		if counted != tx {
+			t.Error("expected the starts counted inside the locking transaction")
+		}
+	}
+
+	store.starts[4] = append(store.starts[4], now.Add(-2*time.Hour))
+	if err := service.enforceAttemptQuotas(context.Background(), tx, quotas, "student-1", now); !IsBusinessRule(err) {
+		t.Errorf("expected the used up quota to refuse the start, got %v", err)
+	}
+}
@@ -0,0 +1,375 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// ===== DUPLICATE ATTEMPT REPAIR =====
+
+// FindDuplicateAttempts lists students with more than one attempt in progress on an
+// assessment, with the merge MergeDuplicateAttempts would make. Answers still buffered
+// from autosave are not included until the merge flushes them.
+func (s *attemptService) FindDuplicateAttempts(ctx context.Context, assessmentID *uint, adminID string) ([]DuplicateAttemptGroup, error) {
+	if _, err := s.requireRepairAdmin(ctx, adminID, "find_duplicates"); err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.repo.Attempt().GetDuplicateActiveAttempts(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]DuplicateAttemptGroup, 0)
+	for _, duplicates := range groupDuplicateAttempts(attempts) {
+		answers, err := s.getAttemptAnswers(ctx, duplicates)
+		if err != nil {
+			return nil, err
+		}
+		survivor, err := chooseSurvivingAttempt(duplicates, nil)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, DuplicateAttemptGroup{
+			StudentID:         survivor.StudentID,
+			AssessmentID:      survivor.AssessmentID,
+			Attempts:          duplicates,
+			SurvivorAttemptID: survivor.ID,
+			Merges:            planAttemptMerge(survivor.ID, answers),
+		})
+	}
+	return groups, nil
+}
+
+// MergeDuplicateAttempts keeps one of a student's attempts in progress on an assessment and
+// voids the others. For every question the most recently modified answer across the
+// attempts ends up in the survivor; the voided attempts keep their answers for the record.
+func (s *attemptService) MergeDuplicateAttempts(ctx context.Context, req *MergeDuplicateAttemptsRequest, adminID string) (*AttemptMergeResult, error) {
+	s.logger.Info("Merging duplicate attempts",
+		"student_id", req.StudentID,
+		"assessment_id", req.AssessmentID,
+		"admin_id", adminID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	admin, err := s.requireRepairAdmin(ctx, adminID, "merge_duplicates")
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := s.repo.Attempt().GetByStudentAndAssessment(ctx, nil, req.StudentID, req.AssessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attempts: %w", err)
+	}
+	var attempts []*models.AssessmentAttempt
+	for _, attempt := range all {
+		if attempt.Status == models.AttemptInProgress {
+			attempts = append(attempts, attempt)
+		}
+	}
+	if len(attempts) < 2 {
+		return nil, NewBusinessRuleError("no_duplicate_attempts", "student does not have several attempts in progress on this assessment", map[string]interface{}{
+			"student_id":    req.StudentID,
+			"assessment_id": req.AssessmentID,
+		})
+	}
+
+	survivor, err := chooseSurvivingAttempt(attempts, req.SurvivorAttemptID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Autosaves not yet written would otherwise be left out of the merge
+	for _, attempt := range attempts {
+		if _, err := s.FlushBufferedAnswers(ctx, attempt.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	answers, err := s.getAttemptAnswers(ctx, attempts)
+	if err != nil {
+		return nil, err
+	}
+	merges := planAttemptMerge(survivor.ID, answers)
+
+	now := time.Now()
+	result := &AttemptMergeResult{
+		StudentID:         req.StudentID,
+		AssessmentID:      req.AssessmentID,
+		SurvivorAttemptID: survivor.ID,
+		VoidedAttemptIDs:  []uint{},
+		Merges:            merges,
+		Reason:            req.Reason,
+		MergedBy:          admin.ID,
+		MergedAt:          now,
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		kept := answersByQuestion(answers[survivor.ID])
+		sources := make(map[uint]*models.StudentAnswer)
+		for _, attemptAnswers := range answers {
+			for _, answer := range attemptAnswers {
+				sources[answer.ID] = answer
+			}
+		}
+
+		for _, merge := range merges {
+			target := kept[merge.QuestionID]
+			if target == nil {
+				target = &models.StudentAnswer{AttemptID: survivor.ID, QuestionID: merge.QuestionID}
+			}
+			mergeAnswerInto(target, sources[merge.SourceAnswerID])
+			if target.ID == 0 {
+				if err := s.repo.Answer().Create(ctx, tx, target); err != nil {
+					return fmt.Errorf("failed to copy answer: %w", err)
+				}
+			} else if err := s.repo.Answer().Update(ctx, tx, target); err != nil {
+				return fmt.Errorf("failed to replace answer: %w", err)
+			}
+			kept[merge.QuestionID] = target
+		}
+
+		endReason := models.AttemptEndReasonMergedDuplicate
+		for _, attempt := range attempts {
+			if attempt.ID == survivor.ID {
+				continue
+			}
+			attempt.Status = models.AttemptVoided
+			attempt.EndedAt = timePtr(now)
+			attempt.EndReason = &endReason
+			if err := s.repo.Attempt().Update(ctx, tx, attempt); err != nil {
+				return fmt.Errorf("failed to void attempt: %w", err)
+			}
+			result.VoidedAttemptIDs = append(result.VoidedAttemptIDs, attempt.ID)
+		}
+
+		result.QuestionsAnswered = countAnsweredQuestions(kept)
+		survivor.QuestionsAnswered = result.QuestionsAnswered
+		if err := s.repo.Attempt().Update(ctx, tx, survivor); err != nil {
+			return fmt.Errorf("failed to update surviving attempt: %w", err)
+		}
+
+		entry, err := buildAttemptMergeAudit(result, admin)
+		if err != nil {
+			return err
+		}
+		return s.repo.AuditLog().Create(ctx, tx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Duplicate attempts merged",
+		"survivor_attempt_id", survivor.ID,
+		"voided_attempt_ids", result.VoidedAttemptIDs,
+		"answers_merged", len(merges))
+
+	return result, nil
+}
+
+func (s *attemptService) GetAttemptRepairLog(ctx context.Context, attemptID uint, adminID string) ([]*models.AuditLog, error) {
+	if _, err := s.requireRepairAdmin(ctx, adminID, "view_repairs"); err != nil {
+		return nil, err
+	}
+
+	logs, err := s.repo.AuditLog().GetByTarget(ctx, nil, "attempt", attemptID)
+	if err != nil {
+		return nil, err
+	}
+	repairs := make([]*models.AuditLog, 0)
+	for _, log := range logs {
+		if log.EventType == models.AuditAttemptsMerged {
+			repairs = append(repairs, log)
+		}
+	}
+	return repairs, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (s *attemptService) requireRepairAdmin(ctx context.Context, adminID, action string) (*models.User, error) {
+	admin, err := s.repo.User().GetByID(ctx, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if admin.Role != models.RoleAdmin {
+		return nil, NewPermissionError(adminID, 0, "attempt", action, "only admins may repair attempts")
+	}
+	return admin, nil
+}
+
+func (s *attemptService) getAttemptAnswers(ctx context.Context, attempts []*models.AssessmentAttempt) (map[uint][]*models.StudentAnswer, error) {
+	answers := make(map[uint][]*models.StudentAnswer, len(attempts))
+	for _, attempt := range attempts {
+		attemptAnswers, err := s.repo.Answer().GetByAttempt(ctx, nil, attempt.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get attempt answers: %w", err)
+		}
+		answers[attempt.ID] = attemptAnswers
+	}
+	return answers, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// groupDuplicateAttempts splits attempts ordered by student and assessment into one group per pair
+func groupDuplicateAttempts(attempts []*models.AssessmentAttempt) [][]*models.AssessmentAttempt {
+	var groups [][]*models.AssessmentAttempt
+	for i, attempt := range attempts {
+		if i == 0 || attempt.StudentID != attempts[i-1].StudentID || attempt.AssessmentID != attempts[i-1].AssessmentID {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], attempt)
+	}
+	return groups
+}
+
+// chooseSurvivingAttempt returns the requested attempt, or else the one started first
+func chooseSurvivingAttempt(attempts []*models.AssessmentAttempt, requestedID *uint) (*models.AssessmentAttempt, error) {
+	if requestedID != nil {
+		for _, attempt := range attempts {
+			if attempt.ID == *requestedID {
+				return attempt, nil
+			}
+		}
+		return nil, NewValidationError("survivor_attempt_id", "must be one of the student's attempts in progress", *requestedID)
+	}
+
+	var survivor *models.AssessmentAttempt
+	for _, attempt := range attempts {
+		if survivor == nil || startedEarlier(attempt, survivor) {
+			survivor = attempt
+		}
+	}
+	if survivor == nil {
+		return nil, NewValidationError("attempts", "no attempts to choose from", nil)
+	}
+	return survivor, nil
+}
+
+func startedEarlier(a, b *models.AssessmentAttempt) bool {
+	switch {
+	case a.StartedAt == nil && b.StartedAt == nil:
+		return a.ID < b.ID
+	case a.StartedAt == nil || b.StartedAt == nil:
+		return a.StartedAt != nil
+	case !a.StartedAt.Equal(*b.StartedAt):
+		return a.StartedAt.Before(*b.StartedAt)
+	default:
+		return a.ID < b.ID
+	}
+}
+
+// planAttemptMerge picks, for every question, the most recently modified answer across the
+// attempts. The survivor keeps its answer on a tie; empty answers never win.
+func planAttemptMerge(survivorID uint, answers map[uint][]*models.StudentAnswer) []AnswerMerge {
+	kept := answersByQuestion(answers[survivorID])
+
+	attemptIDs := make([]uint, 0, len(answers))
+	for attemptID := range answers {
+		if attemptID != survivorID {
+			attemptIDs = append(attemptIDs, attemptID)
+		}
+	}
+	sort.Slice(attemptIDs, func(i, j int) bool { return attemptIDs[i] < attemptIDs[j] })
+
+	latest := make(map[uint]*models.StudentAnswer)
+	for _, attemptID := range attemptIDs {
+		for _, answer := range answers[attemptID] {
+			if !isAnswerGiven(answer.Answer) {
+				continue
+			}
+			if best := latest[answer.QuestionID]; best == nil || answerModifiedAt(answer).After(answerModifiedAt(best)) {
+				latest[answer.QuestionID] = answer
+			}
+		}
+	}
+
+	merges := make([]AnswerMerge, 0, len(latest))
+	for questionID, answer := range latest {
+		action := AnswerMergeCopied
+		if existing := kept[questionID]; existing != nil && isAnswerGiven(existing.Answer) {
+			if !answerModifiedAt(answer).After(answerModifiedAt(existing)) {
+				continue
+			}
+			action = AnswerMergeReplaced
+		}
+		merges = append(merges, AnswerMerge{
+			QuestionID:       questionID,
+			FromAttemptID:    answer.AttemptID,
+			SourceAnswerID:   answer.ID,
+			Action:           action,
+			AnswerModifiedAt: answerModifiedAt(answer),
+		})
+	}
+	sort.Slice(merges, func(i, j int) bool { return merges[i].QuestionID < merges[j].QuestionID })
+	return merges
+}
+
+// mergeAnswerInto gives the target the source's answer, keeping the target's identity and
+// the earliest time the question was first answered
+func mergeAnswerInto(target, source *models.StudentAnswer) {
+	target.Answer = source.Answer
+	target.VariantQuestionID = source.VariantQuestionID
+	target.TimeSpent = source.TimeSpent
+	target.Confidence = source.Confidence
+	target.Flagged = source.Flagged
+	target.LastModifiedAt = timePtr(answerModifiedAt(source))
+	if target.FirstAnsweredAt == nil || (source.FirstAnsweredAt != nil && source.FirstAnsweredAt.Before(*target.FirstAnsweredAt)) {
+		target.FirstAnsweredAt = source.FirstAnsweredAt
+	}
+	target.ChangeCount = max(target.ChangeCount, source.ChangeCount)
+}
+
+func answerModifiedAt(answer *models.StudentAnswer) time.Time {
+	if answer.LastModifiedAt != nil {
+		return *answer.LastModifiedAt
+	}
+	return answer.UpdatedAt
+}
+
+func answersByQuestion(answers []*models.StudentAnswer) map[uint]*models.StudentAnswer {
+	byQuestion := make(map[uint]*models.StudentAnswer, len(answers))
+	for _, answer := range answers {
+		byQuestion[answer.QuestionID] = answer
+	}
+	return byQuestion
+}
+
+func countAnsweredQuestions(answers map[uint]*models.StudentAnswer) int {
+	count := 0
+	for _, answer := range answers {
+		if isAnswerGiven(answer.Answer) {
+			count++
+		}
+	}
+	return count
+}
+
+func buildAttemptMergeAudit(result *AttemptMergeResult, admin *models.User) (*models.AuditLog, error) {
+	metadata, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit metadata: %w", err)
+	}
+
+	return &models.AuditLog{
+		EventType:  models.AuditAttemptsMerged,
+		UserID:     admin.ID,
+		UserEmail:  admin.Email,
+		UserRole:   admin.Role,
+		TargetType: "attempt",
+		TargetID:   &result.SurvivorAttemptID,
+		Description: fmt.Sprintf("Merged duplicate attempts %v of student %s into attempt %d (%d answers taken over)",
+			result.VoidedAttemptIDs, result.StudentID, result.SurvivorAttemptID, len(result.Merges)),
+		Metadata:        metadata,
+		ComplianceLevel: "high",
+	}, nil
+}
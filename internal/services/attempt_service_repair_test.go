@@ -0,0 +1,112 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+func repairAnswer(id, attemptID, questionID uint, answer string, modifiedAt time.Time) *models.StudentAnswer {
+	return &models.StudentAnswer{
+		ID:             id,
+		AttemptID:      attemptID,
+		QuestionID:     questionID,
+		Answer:         datatypes.JSON(answer),
+		LastModifiedAt: &modifiedAt,
+	}
+}
+
+func TestPlanAttemptMerge(t *testing.T) {
+	at := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	answers := map[uint][]*models.StudentAnswer{
+		10: {
+			repairAnswer(1, 10, 1, `{"selected":"a"}`, at.Add(5*time.Minute)),
+			repairAnswer(2, 10, 2, `{"selected":"b"}`, at.Add(time.Minute)),
+			repairAnswer(3, 10, 4, `{"selected":"c"}`, at.Add(time.Minute)),
+		},
+		11: {
+			repairAnswer(4, 11, 1, `{"selected":"x"}`, at.Add(2*time.Minute)), // older, survivor keeps its own
+			repairAnswer(5, 11, 2, `{"selected":"y"}`, at.Add(3*time.Minute)), // newer, replaces
+			repairAnswer(6, 11, 3, `{"selected":"z"}`, at.Add(time.Minute)),   // missing in survivor
+			repairAnswer(7, 11, 4, `{"selected":"c"}`, at.Add(time.Minute)),   // tie, survivor wins
+		},
+		12: {
+			repairAnswer(8, 12, 3, `{"selected":"w"}`, at.Add(4*time.Minute)), // latest of the duplicates
+			repairAnswer(9, 12, 2, `null`, at.Add(time.Hour)),                 // empty answers never win
+		},
+	}
+
+	merges := planAttemptMerge(10, answers)
+
+	want := []AnswerMerge{
+		{QuestionID: 2, FromAttemptID: 11, SourceAnswerID: 5, Action: AnswerMergeReplaced, AnswerModifiedAt: at.Add(3 * time.Minute)},
+		{QuestionID: 3, FromAttemptID: 12, SourceAnswerID: 8, Action: AnswerMergeCopied, AnswerModifiedAt: at.Add(4 * time.Minute)},
+	}
+	if !reflect.DeepEqual(merges, want) {
+		t.Errorf("unexpected merges\n got %+v\nwant %+v", merges, want)
+	}
+}
+
+func TestChooseSurvivingAttempt(t *testing.T) {
+	at := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	later := at.Add(time.Minute)
+	attempts := []*models.AssessmentAttempt{
+		{ID: 7, StartedAt: &later},
+		{ID: 9, StartedAt: &at},
+		{ID: 5},
+	}
+
+	if survivor, err := chooseSurvivingAttempt(attempts, nil); err != nil || survivor.ID != 9 {
+		t.Errorf("expected the attempt started first to survive, got %v (%v)", survivor, err)
+	}
+	requested := uint(7)
+	if survivor, err := chooseSurvivingAttempt(attempts, &requested); err != nil || survivor.ID != 7 {
+		t.Errorf("expected the requested attempt to survive, got %v (%v)", survivor, err)
+	}
+	other := uint(8)
+	if _, err := chooseSurvivingAttempt(attempts, &other); err == nil {
+		t.Error("expected an attempt outside the group to be rejected")
+	}
+}
+
+func TestGroupDuplicateAttempts(t *testing.T) {
+	attempts := []*models.AssessmentAttempt{
+		{ID: 1, StudentID: "s1", AssessmentID: 1},
+		{ID: 2, StudentID: "s1", AssessmentID: 1},
+		{ID: 3, StudentID: "s1", AssessmentID: 2},
+		{ID: 4, StudentID: "s1", AssessmentID: 2},
+		{ID: 5, StudentID: "s2", AssessmentID: 2},
+		{ID: 6, StudentID: "s2", AssessmentID: 2},
+	}
+
+	groups := groupDuplicateAttempts(attempts)
+	if len(groups) != 3 || groups[1][0].ID != 3 || len(groups[2]) != 2 {
+		t.Errorf("expected one group per student and assessment, got %d groups", len(groups))
+	}
+}
+
+func TestMergeAnswerInto(t *testing.T) {
+	first := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	confidence := 4
+	target := repairAnswer(1, 10, 2, `{"selected":"b"}`, first.Add(time.Minute))
+	target.FirstAnsweredAt = timePtr(first)
+	target.ChangeCount = 2
+	source := repairAnswer(5, 11, 2, `{"selected":"y"}`, first.Add(3*time.Minute))
+	source.FirstAnsweredAt = timePtr(first.Add(2 * time.Minute))
+	source.Confidence = &confidence
+
+	mergeAnswerInto(target, source)
+
+	if target.ID != 1 || target.AttemptID != 10 || string(target.Answer) != `{"selected":"y"}` {
+		t.Errorf("expected the survivor's answer to take the duplicate's content, got %+v", target)
+	}
+	if !target.FirstAnsweredAt.Equal(first) || !target.LastModifiedAt.Equal(first.Add(3*time.Minute)) {
+		t.Errorf("unexpected timestamps %v %v", target.FirstAnsweredAt, target.LastModifiedAt)
+	}
+	if target.ChangeCount != 2 || *target.Confidence != 4 {
+		t.Errorf("unexpected change count %d or confidence %v", target.ChangeCount, target.Confidence)
+	}
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+// snapshotAllowedResources records the tools allowed when an attempt starts, so
+// later changes to the assessment settings don't rewrite what the student was given
+func snapshotAllowedResources(settings *models.AssessmentSettings) (datatypes.JSON, error) {
+	data, err := json.Marshal(settings.AllowedResources())
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode allowed resources: %w", err)
+	}
+	return datatypes.JSON(data), nil
+}
+
+// attemptAllowedResources reads the tools snapshotted on an attempt; an unreadable
+// snapshot allows nothing
+func attemptAllowedResources(snapshot datatypes.JSON) models.AllowedResources {
+	resources := models.AllowedResources{Calculator: models.CalculatorNone}
+	if len(snapshot) > 0 {
+		_ = json.Unmarshal(snapshot, &resources)
+	}
+	return resources
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestSnapshotAllowedResources(t *testing.T) {
+	sheet := "https://example.com/formulas.pdf"
+	snapshot, err := snapshotAllowedResources(&models.AssessmentSettings{
+		CalculatorType:  models.CalculatorScientific,
+		FormulaSheetURL: &sheet,
+		AllowDictionary: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resources models.AllowedResources
+	if err := json.Unmarshal(snapshot, &resources); err != nil {
+		t.Fatal(err)
+	}
+	if resources.Calculator != models.CalculatorScientific || resources.FormulaSheetURL == nil || *resources.FormulaSheetURL != sheet || !resources.Dictionary {
+		t.Errorf("unexpected snapshot %s", snapshot)
+	}
+}
+
+func TestSnapshotAllowedResourcesDefaults(t *testing.T) {
+	snapshot, err := snapshotAllowedResources(&models.AssessmentSettings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(snapshot) != `{"calculator":"none","dictionary":false,"spell_check":false}` {
+		t.Errorf("settings without resources should allow nothing, got %s", snapshot)
+	}
+}
+
+func TestAttemptAllowedResources(t *testing.T) {
+	resources := attemptAllowedResources([]byte(`{"calculator":"basic","dictionary":false,"spell_check":true}`))
+	if resources.Calculator != models.CalculatorBasic || !resources.SpellCheck {
+		t.Errorf("unexpected resources %+v", resources)
+	}
+
+	// Attempts started before spell check existed have no spell_check key
+	older := attemptAllowedResources([]byte(`{"calculator":"none","dictionary":true}`))
+	if older.SpellCheck || !older.Dictionary {
+		t.Errorf("unexpected resources %+v", older)
+	}
+
+	if none := attemptAllowedResources(nil); none.SpellCheck || none.Calculator != models.CalculatorNone {
+		t.Errorf("an attempt without a snapshot should allow nothing, got %+v", none)
+	}
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+const (
+	// Overdue attempts submitted per sweep
+	overdueSweepBatchSize = 100
+	// How often the scheduler looks for attempts whose time or window ran out
+	overdueSweepInterval = time.Minute
+	// Attempts are left this long past their end so a submission in flight can land first
+	overdueSubmitGrace = time.Minute
+)
+
+// ===== SAVE AND EXIT =====
+
+// SaveAndExit parks an attempt so the student can come back later. Its clock stops until
+// they return; while away the attempt is submitted automatically once the due date passes.
+func (s *attemptService) SaveAndExit(ctx context.Context, attemptID uint, studentID string) (*AttemptResponse, error) {
+	s.logger.Info("Saving and exiting attempt",
+		"attempt_id", attemptID,
+		"student_id", studentID)
+
+	attempt, err := s.getOwnedAttempt(ctx, attemptID, studentID, "save_and_exit")
+	if err != nil {
+		return nil, err
+	}
+	if attempt.Status != models.AttemptInProgress {
+		return nil, ErrAttemptNotActive
+	}
+	if attempt.PausedAt != nil {
+		return s.GetByID(ctx, attemptID, studentID)
+	}
+
+	now := time.Now()
+	if attempt.EndedAt != nil && now.After(*attempt.EndedAt) {
+		if err := s.HandleTimeout(ctx, attemptID); err != nil {
+			s.logger.Error("Failed to handle timeout", "attempt_id", attemptID, "error", err)
+		}
+		return nil, ErrAttemptTimeExpired
+	}
+
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, s.db, attempt.AssessmentID)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get assessment settings: %w", err)
+	}
+	if settings == nil || !settings.AllowSaveAndExit {
+		return nil, NewBusinessRuleError("save_and_exit_not_allowed", "this assessment must be finished in one sitting", map[string]interface{}{
+			"assessment_id": attempt.AssessmentID,
+		})
+	}
+	if settings.TimingMode == models.TimingModePerQuestion {
+		return nil, NewBusinessRuleError("per_question_timing", "attempts timed per question must be finished in one sitting", map[string]interface{}{
+			"assessment_id": attempt.AssessmentID,
+		})
+	}
+
+	assessment, err := s.repo.Assessment().GetByID(ctx, s.db, attempt.AssessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	if assessment.DueDate != nil && !now.Before(*assessment.DueDate) {
+		return nil, NewBusinessRuleError("due_date_passed", "the assessment is past its due date; finish the attempt now", map[string]interface{}{
+			"due_date": *assessment.DueDate,
+		})
+	}
+
+	// Everything typed so far must be stored before the student leaves
+	if _, err := s.FlushBufferedAnswers(ctx, attemptID); err != nil {
+		return nil, fmt.Errorf("failed to flush autosaved answers: %w", err)
+	}
+
+	pauseAttempt(attempt, assessment.DueDate, now)
+	if err := s.repo.Attempt().Update(ctx, s.db, attempt); err != nil {
+		return nil, fmt.Errorf("failed to save attempt: %w", err)
+	}
+
+	s.logger.Info("Attempt saved for later",
+		"attempt_id", attemptID,
+		"time_spent", attempt.TimeSpent,
+		"time_remaining", attempt.TimeRemaining)
+
+	return s.GetByID(ctx, attemptID, studentID)
+}
+
+// ===== HELPER METHODS =====
+
+// submitOverdueAttempts times out attempts whose time or availability window ran out,
+// including saved attempts whose student never came back
+func (s *attemptService) submitOverdueAttempts(ctx context.Context, now time.Time) {
+	attempts, err := s.repo.Attempt().GetOverdue(ctx, nil, now.Add(-overdueSubmitGrace), overdueSweepBatchSize)
+	if err != nil {
+		s.logger.Error("Failed to get overdue attempts", "error", err)
+		return
+	}
+
+	for _, attempt := range attempts {
+		if err := s.HandleTimeout(ctx, attempt.ID); err != nil {
+			s.logger.Error("Failed to submit overdue attempt", "attempt_id", attempt.ID, "error", err)
+		}
+	}
+}
+
+// checkNotPaused refuses changes to a saved attempt until the student resumes it, so the
+// time spent is always counted
+func checkNotPaused(attempt *models.AssessmentAttempt) error {
+	if attempt.PausedAt == nil {
+		return nil
+	}
+	return NewBusinessRuleError("attempt_saved", "resume the attempt before continuing", map[string]interface{}{
+		"attempt_id": attempt.ID,
+		"paused_at":  *attempt.PausedAt,
+	})
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// pauseAttempt banks the session's time and stops the clock. While paused the attempt
+// ends when the availability window closes, or never when there is no due date.
+func pauseAttempt(attempt *models.AssessmentAttempt, dueDate *time.Time, now time.Time) {
+	closeSession(attempt, now)
+	if attempt.EndedAt != nil {
+		attempt.TimeRemaining = secondsUntil(*attempt.EndedAt, now)
+	}
+
+	attempt.PausedAt = &now
+	attempt.EndedAt = nil
+	if dueDate != nil {
+		windowEnd := *dueDate
+		attempt.EndedAt = &windowEnd
+	}
+}
+
+// resumePausedAttempt starts a new session with the time left when the student paused
+func resumePausedAttempt(attempt *models.AssessmentAttempt, now time.Time) {
+	endTime := now.Add(time.Duration(attempt.TimeRemaining) * time.Second)
+	attempt.EndedAt = &endTime
+	attempt.PausedAt = nil
+	attempt.SessionStartedAt = &now
+	attempt.Sessions++
+}
+
+// closeSession adds the current session, up to the attempt's end, to the time spent. A
+// paused attempt has no session running.
+func closeSession(attempt *models.AssessmentAttempt, now time.Time) {
+	if attempt.PausedAt != nil {
+		return
+	}
+	start := attempt.SessionStartedAt
+	if start == nil {
+		start = attempt.StartedAt
+	}
+	if start == nil {
+		return
+	}
+
+	end := now
+	if attempt.EndedAt != nil && attempt.EndedAt.Before(end) {
+		end = *attempt.EndedAt
+	}
+	if end.After(*start) {
+		attempt.TimeSpent += int(end.Sub(*start).Seconds())
+	}
+}
+
+func secondsUntil(end, now time.Time) int {
+	if !end.After(now) {
+		return 0
+	}
+	return int(end.Sub(now).Seconds())
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestPauseAndResumeAttempt(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(60 * time.Minute)
+	due := start.Add(7 * 24 * time.Hour)
+	attempt := &models.AssessmentAttempt{StartedAt: &start, EndedAt: &end, Sessions: 1}
+
+	pauseAttempt(attempt, &due, start.Add(20*time.Minute))
+	if attempt.TimeSpent != 20*60 || attempt.TimeRemaining != 40*60 {
+		t.Fatalf("expected 20 minutes spent and 40 left, got %d and %d", attempt.TimeSpent, attempt.TimeRemaining)
+	}
+	if attempt.PausedAt == nil || attempt.EndedAt == nil || !attempt.EndedAt.Equal(due) {
+		t.Fatalf("a saved attempt should end at the due date, got %v", attempt.EndedAt)
+	}
+
+	back := start.Add(48 * time.Hour)
+	resumePausedAttempt(attempt, back)
+	if attempt.PausedAt != nil || attempt.Sessions != 2 || !attempt.EndedAt.Equal(back.Add(40*time.Minute)) {
+		t.Fatalf("unexpected resumed attempt %+v", attempt)
+	}
+
+	pauseAttempt(attempt, &due, back.Add(15*time.Minute))
+	if attempt.TimeSpent != 35*60 || attempt.TimeRemaining != 25*60 {
+		t.Errorf("expected time to add up across sessions, got %d spent and %d left", attempt.TimeSpent, attempt.TimeRemaining)
+	}
+}
+
+func TestPauseAttemptWithoutDueDate(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(30 * time.Minute)
+	attempt := &models.AssessmentAttempt{StartedAt: &start, EndedAt: &end}
+
+	pauseAttempt(attempt, nil, start.Add(10*time.Minute))
+	if attempt.EndedAt != nil {
+		t.Errorf("without a due date a saved attempt should wait indefinitely, got %v", attempt.EndedAt)
+	}
+	if err := checkNotPaused(attempt); err == nil {
+		t.Error("a saved attempt should refuse answers until resumed")
+	}
+}
+
+func TestCloseSessionStopsAtEnd(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(10 * time.Minute)
+	attempt := &models.AssessmentAttempt{SessionStartedAt: &start, EndedAt: &end, TimeSpent: 300}
+
+	closeSession(attempt, start.Add(time.Hour))
+	if attempt.TimeSpent != 300+10*60 {
+		t.Errorf("time after the end should not count, got %d", attempt.TimeSpent)
+	}
+
+	paused := start
+	attempt.PausedAt = &paused
+	closeSession(attempt, start.Add(2*time.Hour))
+	if attempt.TimeSpent != 300+10*60 {
+		t.Errorf("a paused attempt has no running session, got %d", attempt.TimeSpent)
+	}
+}
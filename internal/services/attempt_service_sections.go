@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/datatypes"
+)
+
+const ruleSectionLocked = "section_locked"
+
+// ===== SECTIONS =====
+
+// GetSections lists the attempt's sections in delivery order and which of them are locked
+func (s *attemptService) GetSections(ctx context.Context, attemptID uint, studentID string) (*AttemptSections, error) {
+	attempt, err := s.getOwnedAttempt(ctx, attemptID, studentID, "get_sections")
+	if err != nil {
+		return nil, err
+	}
+
+	lockCompleted, err := s.sectionLocksEnabled(ctx, attempt.AssessmentID)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := s.loadSectionPlan(ctx, attempt.AssessmentID)
+	if err != nil {
+		return nil, err
+	}
+	return buildAttemptSections(attempt, plan, lockCompleted), nil
+}
+
+// CompleteSection finishes a section before the student moves on. With anti-backtracking
+// the section and every section before it become read-only for the rest of the attempt.
+func (s *attemptService) CompleteSection(ctx context.Context, attemptID uint, req *CompleteSectionRequest, studentID string) (*AttemptSections, error) {
+	s.logger.Info("Completing section",
+		"attempt_id", attemptID,
+		"section", req.Section,
+		"student_id", studentID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	attempt, err := s.getOwnedAttempt(ctx, attemptID, studentID, "complete_section")
+	if err != nil {
+		return nil, err
+	}
+	if attempt.Status != models.AttemptInProgress {
+		return nil, ErrAttemptNotActive
+	}
+	if attempt.EndedAt != nil && time.Now().After(*attempt.EndedAt) {
+		return nil, ErrAttemptTimeExpired
+	}
+	if err := checkNotPaused(attempt); err != nil {
+		return nil, err
+	}
+
+	lockCompleted, err := s.sectionLocksEnabled(ctx, attempt.AssessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if !lockCompleted {
+		return nil, NewBusinessRuleError("section_locks_disabled", "sections of this assessment stay open until the attempt is submitted", map[string]interface{}{
+			"assessment_id": attempt.AssessmentID,
+		})
+	}
+
+	plan, err := s.loadSectionPlan(ctx, attempt.AssessmentID)
+	if err != nil {
+		return nil, err
+	}
+	index := plan.index(strings.TrimSpace(req.Section))
+	if index < 0 {
+		return nil, ValidationErrors{*NewValidationError("section", "is not a section of this assessment", req.Section)}
+	}
+
+	locked := decodeLockedSections(attempt.LockedSections)
+	updated := lockSectionsThrough(plan, locked, index)
+	if len(updated) > len(locked) {
+		// The section's autosaved answers must be stored before it closes
+		if _, err := s.FlushBufferedAnswers(ctx, attemptID); err != nil {
+			return nil, fmt.Errorf("failed to flush autosaved answers: %w", err)
+		}
+		if err := s.saveLockedSections(ctx, attempt, updated); err != nil {
+			return nil, err
+		}
+	}
+
+	return buildAttemptSections(attempt, plan, true), nil
+}
+
+// ===== HELPER METHODS =====
+
+// enforceSectionLocks refuses an answer to a locked section. Answering a later section
+// completes every section before it, so the student cannot go back to them.
+func (s *attemptService) enforceSectionLocks(ctx context.Context, attempt *models.AssessmentAttempt, questionID uint) error {
+	lockCompleted, err := s.sectionLocksEnabled(ctx, attempt.AssessmentID)
+	if err != nil || !lockCompleted {
+		return err
+	}
+	plan, err := s.loadSectionPlan(ctx, attempt.AssessmentID)
+	if err != nil {
+		return err
+	}
+
+	locked := decodeLockedSections(attempt.LockedSections)
+	updated, err := checkSectionOpen(plan, locked, questionID)
+	if err != nil {
+		return err
+	}
+	if len(updated) > len(locked) {
+		return s.saveLockedSections(ctx, attempt, updated)
+	}
+	return nil
+}
+
+// sectionLocksEnabled reports whether the assessment makes completed sections read-only
+func (s *attemptService) sectionLocksEnabled(ctx context.Context, assessmentID uint) (bool, error) {
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, s.db, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get assessment settings: %w", err)
+	}
+	return settings.LockCompletedSections, nil
+}
+
+func (s *attemptService) loadSectionPlan(ctx context.Context, assessmentID uint) (*sectionPlan, error) {
+	links, err := s.repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+	return buildSectionPlan(links), nil
+}
+
+func (s *attemptService) saveLockedSections(ctx context.Context, attempt *models.AssessmentAttempt, sections []string) error {
+	data, err := json.Marshal(sections)
+	if err != nil {
+		return fmt.Errorf("failed to encode locked sections: %w", err)
+	}
+	if err := s.repo.Attempt().UpdateLockedSections(ctx, s.db, attempt.ID, data); err != nil {
+		return err
+	}
+	attempt.LockedSections = data
+
+	s.logger.Info("Sections locked", "attempt_id", attempt.ID, "sections", sections)
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// sectionPlan is an assessment's sections, ordered by their first question
+type sectionPlan struct {
+	names      []string
+	questions  map[string][]uint
+	byQuestion map[uint]string
+}
+
+func buildSectionPlan(links []*models.AssessmentQuestion) *sectionPlan {
+	plan := &sectionPlan{
+		questions:  make(map[string][]uint),
+		byQuestion: make(map[uint]string),
+	}
+	for _, link := range links {
+		if link.Section == nil || *link.Section == "" {
+			continue
+		}
+		name := *link.Section
+		if _, ok := plan.questions[name]; !ok {
+			plan.names = append(plan.names, name)
+		}
+		plan.questions[name] = append(plan.questions[name], link.QuestionID)
+		plan.byQuestion[link.QuestionID] = name
+	}
+	return plan
+}
+
+func (p *sectionPlan) index(name string) int {
+	for i, section := range p.names {
+		if section == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkSectionOpen refuses a question of a locked section and returns the sections locked
+// once the question is answered. Questions outside any section are always open.
+func checkSectionOpen(plan *sectionPlan, locked []string, questionID uint) ([]string, error) {
+	section, ok := plan.byQuestion[questionID]
+	if !ok {
+		return locked, nil
+	}
+	if slices.Contains(locked, section) {
+		return nil, NewBusinessRuleError(ruleSectionLocked, "this section was completed and its answers can no longer be changed", map[string]interface{}{
+			"question_id": questionID,
+			"section":     section,
+		})
+	}
+	if index := plan.index(section); index > 0 {
+		return lockSectionsThrough(plan, locked, index-1), nil
+	}
+	return locked, nil
+}
+
+// lockSectionsThrough adds the section at index, and every section before it, to locked
+func lockSectionsThrough(plan *sectionPlan, locked []string, index int) []string {
+	updated := append([]string{}, locked...)
+	for _, name := range plan.names[:index+1] {
+		if !slices.Contains(updated, name) {
+			updated = append(updated, name)
+		}
+	}
+	return updated
+}
+
+// sectionOpenAnswers drops submitted answers to questions of locked sections
+func sectionOpenAnswers(plan *sectionPlan, locked []string, answers []SubmitAnswerRequest) []SubmitAnswerRequest {
+	open := make([]SubmitAnswerRequest, 0, len(answers))
+	for _, answer := range answers {
+		if section, ok := plan.byQuestion[answer.QuestionID]; ok && slices.Contains(locked, section) {
+			continue
+		}
+		open = append(open, answer)
+	}
+	return open
+}
+
+func buildAttemptSections(attempt *models.AssessmentAttempt, plan *sectionPlan, lockCompleted bool) *AttemptSections {
+	locked := decodeLockedSections(attempt.LockedSections)
+	sections := &AttemptSections{
+		AttemptID:     attempt.ID,
+		LockCompleted: lockCompleted,
+		Sections:      make([]AttemptSection, 0, len(plan.names)),
+	}
+	for _, name := range plan.names {
+		sections.Sections = append(sections.Sections, AttemptSection{
+			Name:        name,
+			QuestionIDs: plan.questions[name],
+			Locked:      lockCompleted && slices.Contains(locked, name),
+		})
+	}
+	return sections
+}
+
+func decodeLockedSections(data datatypes.JSON) []string {
+	var sections []string
+	if len(data) == 0 || json.Unmarshal(data, &sections) != nil {
+		return nil
+	}
+	return sections
+}
+
+// questionSection normalizes a requested section; empty removes the question from its section
+func questionSection(requested string) *string {
+	requested = strings.TrimSpace(requested)
+	if requested == "" {
+		return nil
+	}
+	return &requested
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func sectionLinks() []*models.AssessmentQuestion {
+	return []*models.AssessmentQuestion{
+		{QuestionID: 1, Order: 1, Section: stringPtr("Listening A")},
+		{QuestionID: 2, Order: 2, Section: stringPtr("Listening A")},
+		{QuestionID: 3, Order: 3},
+		{QuestionID: 4, Order: 4, Section: stringPtr("Listening B")},
+		{QuestionID: 5, Order: 5, Section: stringPtr("Writing")},
+	}
+}
+
+func TestBuildSectionPlan(t *testing.T) {
+	plan := buildSectionPlan(sectionLinks())
+
+	if len(plan.names) != 3 || plan.names[0] != "Listening A" || plan.names[2] != "Writing" {
+		t.Errorf("expected sections in the order of their first question, got %v", plan.names)
+	}
+	if len(plan.questions["Listening A"]) != 2 {
+		t.Errorf("expected two questions in the first section, got %v", plan.questions["Listening A"])
+	}
+	if _, ok := plan.byQuestion[3]; ok {
+		t.Error("expected a question without a section to be left out")
+	}
+}
+
+func TestCheckSectionOpen(t *testing.T) {
+	plan := buildSectionPlan(sectionLinks())
+
+	locked, err := checkSectionOpen(plan, nil, 2)
+	if err != nil || len(locked) != 0 {
+		t.Errorf("expected the first section to stay open, got %v, %v", locked, err)
+	}
+
+	locked, err = checkSectionOpen(plan, nil, 5)
+	if err != nil || len(locked) != 2 || locked[0] != "Listening A" || locked[1] != "Listening B" {
+		t.Errorf("expected answering the last section to lock the ones before it, got %v, %v", locked, err)
+	}
+
+	_, err = checkSectionOpen(plan, locked, 1)
+	var ruleErr *BusinessRuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != ruleSectionLocked {
+		t.Errorf("expected a locked section to refuse answers, got %v", err)
+	}
+
+	if _, err := checkSectionOpen(plan, locked, 3); err != nil {
+		t.Errorf("expected a question without a section to stay open, got %v", err)
+	}
+}
+
+func TestLockSectionsThrough(t *testing.T) {
+	plan := buildSectionPlan(sectionLinks())
+
+	locked := lockSectionsThrough(plan, []string{"Listening A"}, 1)
+	if len(locked) != 2 || locked[1] != "Listening B" {
+		t.Errorf("expected the section and those before it to be locked once, got %v", locked)
+	}
+}
+
+func TestSectionOpenAnswers(t *testing.T) {
+	plan := buildSectionPlan(sectionLinks())
+	answers := []SubmitAnswerRequest{{QuestionID: 1}, {QuestionID: 3}, {QuestionID: 4}}
+
+	open := sectionOpenAnswers(plan, []string{"Listening A"}, answers)
+	if len(open) != 2 || open[0].QuestionID != 3 || open[1].QuestionID != 4 {
+		t.Errorf("expected the answer to the locked section to be dropped, got %+v", open)
+	}
+}
+
+func TestBuildAttemptSections(t *testing.T) {
+	plan := buildSectionPlan(sectionLinks())
+	attempt := &models.AssessmentAttempt{ID: 9, LockedSections: []byte(`["Listening A"]`)}
+
+	sections := buildAttemptSections(attempt, plan, true)
+	if len(sections.Sections) != 3 || !sections.Sections[0].Locked || sections.Sections[1].Locked {
+		t.Errorf("expected only the first section locked, got %+v", sections.Sections)
+	}
+
+	sections = buildAttemptSections(attempt, plan, false)
+	if sections.Sections[0].Locked {
+		t.Error("expected no section locked while anti-backtracking is off")
+	}
+}
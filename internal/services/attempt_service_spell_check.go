@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+)
+
+// ===== SPELL CHECK =====
+
+// SpellCheckAnswer suggests corrections for an essay answer's draft without changing it.
+// When any suggestion is shown the answer is marked, so spelling can be left out of
+// research on it; the grade is still given on the text as submitted.
+func (s *attemptService) SpellCheckAnswer(ctx context.Context, attemptID, questionID uint, req *SpellCheckRequest, studentID string) (*SpellCheckResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	attempt, err := s.getOwnedAttempt(ctx, attemptID, studentID, "spell_check")
+	if err != nil {
+		return nil, err
+	}
+	if attempt.Status != models.AttemptInProgress {
+		return nil, ErrAttemptNotActive
+	}
+	if !attemptAllowedResources(attempt.AllowedResources).SpellCheck {
+		return nil, NewBusinessRuleError("spell_check_not_allowed",
+			"spell check is not offered in this assessment",
+			map[string]interface{}{"attempt_id": attemptID})
+	}
+
+	answer, err := s.repo.Answer().GetByAttemptAndQuestion(ctx, s.db, attemptID, questionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get answer: %w", err)
+	}
+
+	servedID := questionID
+	if answer.VariantQuestionID != nil {
+		servedID = *answer.VariantQuestionID
+	}
+	question, err := s.repo.Question().GetByID(ctx, nil, servedID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	if question.Type != models.Essay {
+		return nil, NewBusinessRuleError("spell_check_essay_only",
+			"spell check is only offered on essay answers",
+			map[string]interface{}{"question_id": questionID, "question_type": question.Type})
+	}
+
+	suggestions := spellingSuggestions(s.validator.ContentLinter().CheckSpelling("text", req.Text))
+	if len(suggestions) > 0 && !answer.SpellCheckShown {
+		if err := s.repo.Answer().MarkSpellCheckShown(ctx, s.db, answer); err != nil {
+			return nil, fmt.Errorf("failed to record spell check: %w", err)
+		}
+		s.logger.Info("Spelling suggestions shown",
+			"attempt_id", attemptID,
+			"question_id", questionID,
+			"suggestions", len(suggestions))
+	}
+
+	return &SpellCheckResponse{
+		AttemptID:   attemptID,
+		QuestionID:  questionID,
+		Suggestions: suggestions,
+	}, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// spellingSuggestions turns the linter's spelling warnings into suggestions for the student
+func spellingSuggestions(warnings []validator.ContentWarning) []SpellingSuggestion {
+	suggestions := make([]SpellingSuggestion, 0, len(warnings))
+	for _, warning := range warnings {
+		if warning.Code != validator.WarningSpelling {
+			continue
+		}
+		suggestions = append(suggestions, SpellingSuggestion{
+			Word:       warning.Value,
+			Suggestion: warning.Suggestion,
+		})
+	}
+	return suggestions
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+)
+
+func TestSpellingSuggestions(t *testing.T) {
+	linter := validator.NewContentLinter()
+	suggestions := spellingSuggestions(linter.CheckSpelling("text", "I recieve the result. We recieve it; the enviroment changed."))
+	if len(suggestions) != 2 {
+		t.Fatalf("expected one suggestion per distinct word, got %+v", suggestions)
+	}
+	if suggestions[0].Word != "recieve" || suggestions[0].Suggestion != "receive" {
+		t.Errorf("unexpected suggestion %+v", suggestions[0])
+	}
+
+	if clean := spellingSuggestions(linter.CheckSpelling("text", "The environment changed.")); len(clean) != 0 {
+		t.Errorf("expected no suggestions, got %+v", clean)
+	}
+
+	readability := []validator.ContentWarning{{Field: "text", Code: validator.WarningReadability, Value: "14.2"}}
+	if got := spellingSuggestions(readability); len(got) != 0 {
+		t.Errorf("only spelling warnings should become suggestions, got %+v", got)
+	}
+}
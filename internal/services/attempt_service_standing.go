@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// ===== PERCENTILE AND RANK =====
+
+// attemptStanding ranks a finished attempt against the assessment's stored analytics, which the
+// item analysis scheduler refreshes whenever attempts finish or are regraded. The attempt's
+// current percentage is used even when the stored list is older, so a regrade shows at once.
+// Nil when the attempt is not finished or the assessment has not been analysed yet.
+func (s *attemptService) attemptStanding(ctx context.Context, attempt *models.AssessmentAttempt) (*AttemptStanding, error) {
+	if attempt.Status != models.AttemptCompleted && attempt.Status != models.AttemptTimeOut {
+		return nil, nil
+	}
+
+	analytics, err := s.repo.AssessmentAnalytics().GetByAssessment(ctx, nil, attempt.AssessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get assessment analytics: %w", err)
+	}
+
+	var ranked []models.RankedScore
+	if len(analytics.RankedScores) > 0 {
+		if err := json.Unmarshal(analytics.RankedScores, &ranked); err != nil {
+			return nil, fmt.Errorf("failed to decode ranked scores: %w", err)
+		}
+	}
+
+	standing := computeStanding(ranked, attempt.ID, attempt.Percentage)
+	standing.CalculatedAt = analytics.LastCalculatedAt
+	return standing, nil
+}
+
+// computeStanding ranks a percentage among the other ranked attempts. The attempt's own stored
+// entry is replaced by the given percentage, and the attempt is counted even when it finished
+// after the list was built.
+func computeStanding(ranked []models.RankedScore, attemptID uint, percentage float64) *AttemptStanding {
+	above, equal, below := 0, 0, 0
+	for _, entry := range ranked {
+		if entry.AttemptID == attemptID {
+			continue
+		}
+		switch {
+		case entry.Percentage > percentage:
+			above++
+		case entry.Percentage == percentage:
+			equal++
+		default:
+			below++
+		}
+	}
+
+	outOf := above + equal + below + 1
+	return &AttemptStanding{
+		Rank:       above + 1,
+		OutOf:      outOf,
+		Percentile: (float64(below) + float64(equal+1)/2) / float64(outOf) * 100,
+	}
+}
+
+// rankVisible reports whether the viewer may see an attempt's standing. Students see it once
+// results are released and shown, and only when the assessment enables it.
+func rankVisible(settings *models.AssessmentSettings, isStudent bool, results bool) bool {
+	if !isStudent {
+		return true
+	}
+	return results && settings != nil && settings.ShowRank
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestComputeStanding(t *testing.T) {
+	ranked := []models.RankedScore{
+		{AttemptID: 1, Percentage: 95},
+		{AttemptID: 2, Percentage: 80},
+		{AttemptID: 3, Percentage: 80},
+		{AttemptID: 4, Percentage: 60},
+	}
+
+	standing := computeStanding(ranked, 2, 80)
+	if standing.Rank != 2 || standing.OutOf != 4 {
+		t.Fatalf("expected rank 2 of 4, got %d of %d", standing.Rank, standing.OutOf)
+	}
+	// One attempt below and the tie with attempt 3 counted half, out of four
+	if standing.Percentile != 50 {
+		t.Fatalf("expected the 50th percentile, got %v", standing.Percentile)
+	}
+
+	// Regraded since the list was built: the current percentage replaces the stored one
+	if standing := computeStanding(ranked, 4, 99); standing.Rank != 1 || standing.OutOf != 4 {
+		t.Fatalf("expected the regraded attempt to rank first of 4, got %d of %d", standing.Rank, standing.OutOf)
+	}
+
+	// Finished after the list was built: the attempt is still counted
+	if standing := computeStanding(ranked, 5, 50); standing.Rank != 5 || standing.OutOf != 5 || standing.Percentile != 10 {
+		t.Fatalf("expected the new attempt last of 5 at the 10th percentile, got %+v", standing)
+	}
+}
+
+func TestRankAttemptScores(t *testing.T) {
+	attempts := []*models.AssessmentAttempt{
+		{ID: 1, Percentage: 70},
+		{ID: 2, Percentage: 90},
+		{ID: 3, Percentage: 40},
+	}
+	ranked := rankAttemptScores(attempts)
+	if len(ranked) != 3 || ranked[0].AttemptID != 2 || ranked[2].AttemptID != 3 {
+		t.Fatalf("expected attempts ordered by percentage, highest first, got %+v", ranked)
+	}
+}
+
+func TestRankVisible(t *testing.T) {
+	if !rankVisible(nil, false, false) {
+		t.Fatal("expected staff to always see the standing")
+	}
+	if rankVisible(&models.AssessmentSettings{ShowRank: true}, true, false) {
+		t.Fatal("expected the standing to stay hidden before results are shown")
+	}
+	if rankVisible(nil, true, true) || rankVisible(&models.AssessmentSettings{}, true, true) {
+		t.Fatal("expected the standing to be hidden from students unless enabled")
+	}
+	if !rankVisible(&models.AssessmentSettings{ShowRank: true}, true, true) {
+		t.Fatal("expected students to see the standing when enabled")
+	}
+}
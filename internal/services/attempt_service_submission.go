@@ -0,0 +1,188 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// ===== SUBMISSION GATES =====
+
+func (s *attemptService) GetSubmissionSummary(ctx context.Context, attemptID uint, studentID string) (*SubmissionSummary, error) {
+	attempt, err := s.getOwnedAttempt(ctx, attemptID, studentID, "view_submission_summary")
+	if err != nil {
+		return nil, err
+	}
+
+	// Summarize the latest autosaved answers
+	if _, err := s.FlushBufferedAnswers(ctx, attemptID); err != nil {
+		return nil, fmt.Errorf("failed to flush autosaved answers: %w", err)
+	}
+
+	return s.loadSubmissionSummary(ctx, attempt, nil)
+}
+
+func (s *attemptService) FlagQuestion(ctx context.Context, attemptID, questionID uint, req *FlagQuestionRequest, studentID string) error {
+	attempt, err := s.getOwnedAttempt(ctx, attemptID, studentID, "flag_question")
+	if err != nil {
+		return err
+	}
+	if attempt.Status != models.AttemptInProgress {
+		return ErrAttemptNotActive
+	}
+
+	answer, err := s.repo.Answer().GetByAttemptAndQuestion(ctx, s.db, attemptID, questionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to get answer: %w", err)
+	}
+
+	if err := s.repo.Answer().FlagAnswer(ctx, s.db, answer.ID, req.Flagged); err != nil {
+		return fmt.Errorf("failed to flag question: %w", err)
+	}
+
+	s.logger.Info("Question flag updated",
+		"attempt_id", attemptID,
+		"question_id", questionID,
+		"flagged", req.Flagged)
+
+	if req.Flagged {
+		s.checkFlagEscalation(ctx, attempt, questionID)
+	}
+
+	return nil
+}
+
+// ===== HELPER METHODS =====
+
+func (s *attemptService) getOwnedAttempt(ctx context.Context, attemptID uint, studentID, action string) (*models.AssessmentAttempt, error) {
+	attempt, err := s.repo.Attempt().GetByID(ctx, s.db, attemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	if attempt.StudentID != studentID {
+		return nil, NewPermissionError(studentID, attemptID, "attempt", action, "not owned by student")
+	}
+
+	return attempt, nil
+}
+
+// loadSubmissionSummary summarizes stored answers together with answers about to be
+// submitted in the same request
+func (s *attemptService) loadSubmissionSummary(ctx context.Context, attempt *models.AssessmentAttempt, pending []SubmitAnswerRequest) (*SubmissionSummary, error) {
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, s.db, attempt.AssessmentID)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get assessment settings: %w", err)
+	}
+
+	assessmentQuestions, err := s.repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, s.db, attempt.AssessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	answers, err := s.repo.Answer().GetByAttempt(ctx, s.db, attempt.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answers: %w", err)
+	}
+
+	// Questions branching rules skip are not expected to be answered
+	route, err := s.attemptRoute(ctx, s.db, attempt, pending)
+	if err != nil {
+		return nil, err
+	}
+	assessmentQuestions = routeLinks(assessmentQuestions, route)
+
+	summary := buildSubmissionSummary(settings, assessmentQuestions, answers, pending)
+	summary.AttemptID = attempt.ID
+
+	return summary, nil
+}
+
+// buildSubmissionSummary works out which questions are still unanswered or flagged and
+// whether the assessment's submission gates let the attempt be submitted
+func buildSubmissionSummary(settings *models.AssessmentSettings, assessmentQuestions []*models.AssessmentQuestion, answers []*models.StudentAnswer, pending []SubmitAnswerRequest) *SubmissionSummary {
+	answered := make(map[uint]bool, len(answers))
+	flagged := make(map[uint]bool)
+	for _, answer := range answers {
+		if isAnswerGiven(answer.Answer) {
+			answered[answer.QuestionID] = true
+		}
+		if answer.Flagged {
+			flagged[answer.QuestionID] = true
+		}
+	}
+	for _, req := range pending {
+		raw, err := json.Marshal(req.AnswerData)
+		answered[req.QuestionID] = err == nil && isAnswerGiven(raw)
+	}
+
+	summary := &SubmissionSummary{
+		TotalQuestions: len(assessmentQuestions),
+		Unanswered:     make([]uint, 0),
+		Flagged:        make([]uint, 0),
+	}
+	if settings != nil {
+		summary.RequireAllAnswered = settings.RequireAllAnswered
+		summary.RequireFlaggedResolved = settings.RequireFlaggedResolved
+		summary.RequireConfirmation = settings.RequireSubmitConfirmation
+	}
+
+	for _, aq := range assessmentQuestions {
+		if answered[aq.QuestionID] {
+			summary.AnsweredCount++
+		} else {
+			summary.Unanswered = append(summary.Unanswered, aq.QuestionID)
+		}
+		if flagged[aq.QuestionID] {
+			summary.Flagged = append(summary.Flagged, aq.QuestionID)
+		}
+	}
+
+	summary.CanSubmit = !(summary.RequireAllAnswered && len(summary.Unanswered) > 0) &&
+		!(summary.RequireFlaggedResolved && len(summary.Flagged) > 0)
+	summary.NeedsConfirmation = summary.RequireConfirmation &&
+		(len(summary.Unanswered) > 0 || len(summary.Flagged) > 0)
+
+	return summary
+}
+
+// checkSubmissionGates enforces the summary's gates on final submission
+func checkSubmissionGates(summary *SubmissionSummary, confirmed bool) error {
+	if summary.RequireAllAnswered && len(summary.Unanswered) > 0 {
+		return NewBusinessRuleError("submission_unanswered_questions", "all questions must be answered before submitting", map[string]interface{}{
+			"question_ids": summary.Unanswered,
+		})
+	}
+	if summary.RequireFlaggedResolved && len(summary.Flagged) > 0 {
+		return NewBusinessRuleError("submission_flagged_questions", "flagged questions must be resolved before submitting", map[string]interface{}{
+			"question_ids": summary.Flagged,
+		})
+	}
+	if summary.NeedsConfirmation && !confirmed {
+		return NewBusinessRuleError("submission_confirmation_required", "confirm the unanswered and flagged questions before submitting", map[string]interface{}{
+			"unanswered": summary.Unanswered,
+			"flagged":    summary.Flagged,
+		})
+	}
+	return nil
+}
+
+// isAnswerGiven treats missing, null and empty JSON values as unanswered
+func isAnswerGiven(raw []byte) bool {
+	trimmed := bytes.TrimSpace(raw)
+	switch string(trimmed) {
+	case "", "null", `""`, "[]", "{}":
+		return false
+	}
+	return true
+}
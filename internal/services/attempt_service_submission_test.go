@@ -0,0 +1,60 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+func TestBuildSubmissionSummary(t *testing.T) {
+	assessmentQuestions := []*models.AssessmentQuestion{
+		{QuestionID: 3}, {QuestionID: 1}, {QuestionID: 2}, {QuestionID: 4},
+	}
+	answers := []*models.StudentAnswer{
+		{QuestionID: 1, Answer: datatypes.JSON(`["a"]`), Flagged: true},
+		{QuestionID: 2, Answer: datatypes.JSON(`null`)},
+		{QuestionID: 3, Answer: datatypes.JSON(`[]`)},
+		{QuestionID: 4},
+	}
+	// Answered in the submit request itself
+	pending := []SubmitAnswerRequest{{QuestionID: 4, AnswerData: "Paris"}}
+
+	summary := buildSubmissionSummary(nil, assessmentQuestions, answers, pending)
+	if summary.TotalQuestions != 4 || summary.AnsweredCount != 2 {
+		t.Fatalf("unexpected counts: %+v", summary)
+	}
+	if len(summary.Unanswered) != 2 || summary.Unanswered[0] != 3 || summary.Unanswered[1] != 2 {
+		t.Errorf("unanswered questions should follow assessment order, got %v", summary.Unanswered)
+	}
+	if len(summary.Flagged) != 1 || summary.Flagged[0] != 1 {
+		t.Errorf("expected question 1 flagged, got %v", summary.Flagged)
+	}
+	if !summary.CanSubmit || summary.NeedsConfirmation {
+		t.Errorf("no gates should apply without settings, got %+v", summary)
+	}
+	if err := checkSubmissionGates(summary, false); err != nil {
+		t.Errorf("expected submission to pass, got %v", err)
+	}
+
+	settings := &models.AssessmentSettings{RequireSubmitConfirmation: true}
+	confirm := buildSubmissionSummary(settings, assessmentQuestions, answers, pending)
+	if !confirm.CanSubmit || !confirm.NeedsConfirmation {
+		t.Errorf("expected confirmation to be needed, got %+v", confirm)
+	}
+	if err := checkSubmissionGates(confirm, false); !IsBusinessRule(err) {
+		t.Errorf("unconfirmed submission should be rejected, got %v", err)
+	}
+	if err := checkSubmissionGates(confirm, true); err != nil {
+		t.Errorf("confirmed submission should pass, got %v", err)
+	}
+
+	settings = &models.AssessmentSettings{RequireAllAnswered: true, RequireFlaggedResolved: true}
+	blocked := buildSubmissionSummary(settings, assessmentQuestions, answers, pending)
+	if blocked.CanSubmit {
+		t.Errorf("unanswered questions should block submission, got %+v", blocked)
+	}
+	if err := checkSubmissionGates(blocked, true); !IsBusinessRule(err) {
+		t.Errorf("confirmation must not bypass a blocking gate, got %v", err)
+	}
+}
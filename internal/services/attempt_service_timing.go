@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// questionBudget is the time a student gets for one question in per-question timing mode
+type questionBudget struct {
+	QuestionID uint
+	Seconds    int
+}
+
+// ===== PER-QUESTION TIMING =====
+
+func (s *attemptService) GetCurrentQuestion(ctx context.Context, attemptID uint, studentID string) (*CurrentQuestion, error) {
+	attempt, budgets, err := s.getTimedAttempt(ctx, attemptID, studentID, "get_current_question")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildCurrentQuestion(ctx, attempt, budgets)
+}
+
+func (s *attemptService) AdvanceQuestion(ctx context.Context, attemptID uint, studentID string) (*CurrentQuestion, error) {
+	s.logger.Info("Advancing to next question",
+		"attempt_id", attemptID,
+		"student_id", studentID)
+
+	attempt, budgets, err := s.getTimedAttempt(ctx, attemptID, studentID, "advance_question")
+	if err != nil {
+		return nil, err
+	}
+
+	// The question is locked from here on, so its autosaved answer must be stored first
+	if _, err := s.FlushBufferedAnswers(ctx, attemptID); err != nil {
+		return nil, fmt.Errorf("failed to flush autosaved answers: %w", err)
+	}
+
+	// The answer just stored can change where branching rules send the student
+	budgets, _, err = s.loadQuestionBudgets(ctx, attempt.AssessmentID, attempt.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := moveToNextQuestion(attempt, budgets, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Attempt().Update(ctx, s.db, attempt); err != nil {
+		return nil, fmt.Errorf("failed to advance question: %w", err)
+	}
+
+	s.logger.Info("Advanced to next question",
+		"attempt_id", attemptID,
+		"question_index", attempt.CurrentQuestionIndex)
+
+	return s.buildCurrentQuestion(ctx, attempt, budgets)
+}
+
+// ===== HELPER METHODS =====
+
+// getTimedAttempt loads an active attempt taken in per-question timing mode with its clock
+// brought up to date. An attempt whose last question ran out is timed out.
+func (s *attemptService) getTimedAttempt(ctx context.Context, attemptID uint, studentID, action string) (*models.AssessmentAttempt, []questionBudget, error) {
+	attempt, err := s.getOwnedAttempt(ctx, attemptID, studentID, action)
+	if err != nil {
+		return nil, nil, err
+	}
+	if attempt.Status != models.AttemptInProgress {
+		return nil, nil, ErrAttemptNotActive
+	}
+
+	budgets, perQuestion, err := s.syncQuestionClock(ctx, attempt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !perQuestion {
+		return nil, nil, NewBusinessRuleError("not_per_question_timing", "assessment does not use per-question timing", map[string]interface{}{
+			"assessment_id": attempt.AssessmentID,
+		})
+	}
+
+	if attempt.CurrentQuestionIndex >= len(budgets) {
+		if err := s.HandleTimeout(ctx, attemptID); err != nil {
+			s.logger.Error("Failed to handle timeout", "attempt_id", attemptID, "error", err)
+		}
+		return nil, nil, ErrAttemptTimeExpired
+	}
+
+	return attempt, budgets, nil
+}
+
+// syncQuestionClock auto-advances an in-progress attempt past questions whose time ran out
+// and stores the move. It reports false when the assessment uses a single overall duration.
+func (s *attemptService) syncQuestionClock(ctx context.Context, attempt *models.AssessmentAttempt) ([]questionBudget, bool, error) {
+	budgets, perQuestion, err := s.loadQuestionBudgets(ctx, attempt.AssessmentID, attempt.ID)
+	if err != nil || !perQuestion {
+		return nil, false, err
+	}
+
+	if attempt.Status == models.AttemptInProgress && advanceExpiredQuestions(attempt, budgets, time.Now()) {
+		if err := s.repo.Attempt().Update(ctx, s.db, attempt); err != nil {
+			if !repositories.IsConnectionError(err) {
+				return nil, false, fmt.Errorf("failed to advance expired questions: %w", err)
+			}
+			// The move follows from the clock, so the next request makes it again
+			s.logger.Warn("Failed to store expired questions, database unavailable", "attempt_id", attempt.ID, "error", err)
+		}
+	}
+
+	return budgets, true, nil
+}
+
+// loadQuestionBudgets returns the question budgets in delivery order when the assessment
+// uses per-question timing. With branching rules only the attempt's route is timed; pass
+// a zero attemptID for an attempt with no answers yet.
+func (s *attemptService) loadQuestionBudgets(ctx context.Context, assessmentID, attemptID uint) ([]questionBudget, bool, error) {
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, s.db, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to get assessment settings: %w", err)
+	}
+	if settings.TimingMode != models.TimingModePerQuestion {
+		return nil, false, nil
+	}
+
+	links, err := s.repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, false, err
+	}
+	questions, err := s.repo.AssessmentQuestion().GetQuestionsForAssessment(ctx, s.db, assessmentID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	budgets := buildQuestionBudgets(links, questions)
+
+	nodes, err := buildBranchNodes(links, questions)
+	if err != nil {
+		return nil, false, err
+	}
+	if !hasBranching(nodes) {
+		return budgets, true, nil
+	}
+
+	answers := map[uint][]byte{}
+	if attemptID != 0 {
+		if answers, err = s.loadRouteAnswers(ctx, s.db, attemptID); err != nil {
+			return nil, false, err
+		}
+	}
+	return routeBudgets(budgets, resolveRoute(nodes, answers)), true, nil
+}
+
+func (s *attemptService) buildCurrentQuestion(ctx context.Context, attempt *models.AssessmentAttempt, budgets []questionBudget) (*CurrentQuestion, error) {
+	questions, err := s.getAttemptQuestions(ctx, attempt)
+	if err != nil {
+		return nil, err
+	}
+	markBookmarkedQuestions(questions, sessionBookmarks(attempt.SessionData))
+	if err := s.applyAnswerChangeLimits(ctx, attempt, questions); err != nil {
+		return nil, err
+	}
+	if err := deliverOrderingQuestions(attempt.ID, questions); err != nil {
+		return nil, err
+	}
+
+	return &CurrentQuestion{
+		AttemptID: attempt.ID,
+		Question:  currentQuestionOf(attempt, budgets, questions),
+		Timing:    buildQuestionTiming(attempt, budgets, time.Now()),
+	}, nil
+}
+
+// buildQuestionBudgets resolves each question's budget: the assessment's override, then the
+// question's own time limit, then the default
+func buildQuestionBudgets(links []*models.AssessmentQuestion, questions []*models.Question) []questionBudget {
+	byID := make(map[uint]*models.Question, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+
+	budgets := make([]questionBudget, 0, len(links))
+	for _, link := range links {
+		seconds := defaultQuestionSeconds
+		if question, ok := byID[link.QuestionID]; ok {
+			seconds = questionSeconds(question)
+		}
+		if link.TimeLimit != nil && *link.TimeLimit > 0 {
+			seconds = *link.TimeLimit
+		}
+		budgets = append(budgets, questionBudget{QuestionID: link.QuestionID, Seconds: seconds})
+	}
+	return budgets
+}
+
+// startQuestionClock opens the first question of a new attempt
+func startQuestionClock(attempt *models.AssessmentAttempt, budgets []questionBudget, now time.Time) {
+	attempt.CurrentQuestionIndex = 0
+	attempt.TotalQuestions = len(budgets)
+	openQuestion(attempt, budgets, now)
+}
+
+// openQuestion starts the clock of the question at CurrentQuestionIndex. The attempt ends
+// once the remaining budgets are spent, so it moves with each early advance.
+func openQuestion(attempt *models.AssessmentAttempt, budgets []questionBudget, start time.Time) {
+	index := attempt.CurrentQuestionIndex
+	if index >= len(budgets) {
+		return
+	}
+
+	deadline := start.Add(time.Duration(budgets[index].Seconds) * time.Second)
+	end := deadline
+	for _, budget := range budgets[index+1:] {
+		end = end.Add(time.Duration(budget.Seconds) * time.Second)
+	}
+
+	attempt.QuestionStartedAt = &start
+	attempt.QuestionDeadline = &deadline
+	attempt.EndedAt = &end
+}
+
+// advanceExpiredQuestions moves past every question whose time ran out by now, each next
+// question starting when the previous one expired. It reports whether the attempt moved.
+func advanceExpiredQuestions(attempt *models.AssessmentAttempt, budgets []questionBudget, now time.Time) bool {
+	moved := false
+	for attempt.CurrentQuestionIndex < len(budgets) && attempt.QuestionDeadline != nil && !now.Before(*attempt.QuestionDeadline) {
+		attempt.CurrentQuestionIndex++
+		openQuestion(attempt, budgets, *attempt.QuestionDeadline)
+		moved = true
+	}
+	return moved
+}
+
+// moveToNextQuestion lets a student leave the current question before its time runs out
+func moveToNextQuestion(attempt *models.AssessmentAttempt, budgets []questionBudget, now time.Time) error {
+	if attempt.CurrentQuestionIndex >= len(budgets) {
+		return ErrAttemptTimeExpired
+	}
+	if attempt.CurrentQuestionIndex == len(budgets)-1 {
+		return NewBusinessRuleError("last_question", "this is the last question; submit the attempt instead", map[string]interface{}{
+			"attempt_id": attempt.ID,
+		})
+	}
+
+	attempt.CurrentQuestionIndex++
+	openQuestion(attempt, budgets, now)
+	return nil
+}
+
+// checkQuestionOpen rejects answers to any question but the one on the clock
+func checkQuestionOpen(attempt *models.AssessmentAttempt, budgets []questionBudget, questionID uint) error {
+	if attempt.CurrentQuestionIndex >= len(budgets) {
+		return ErrAttemptTimeExpired
+	}
+
+	current := budgets[attempt.CurrentQuestionIndex].QuestionID
+	if questionID != current {
+		return NewBusinessRuleError("question_locked", "only the current question can be answered", map[string]interface{}{
+			"question_id":         questionID,
+			"current_question_id": current,
+		})
+	}
+	return nil
+}
+
+// openAnswers drops submitted answers to questions that are already locked or not yet shown
+func openAnswers(attempt *models.AssessmentAttempt, budgets []questionBudget, answers []SubmitAnswerRequest) []SubmitAnswerRequest {
+	open := make([]SubmitAnswerRequest, 0, 1)
+	for _, answer := range answers {
+		if checkQuestionOpen(attempt, budgets, answer.QuestionID) == nil {
+			open = append(open, answer)
+		}
+	}
+	return open
+}
+
+func buildQuestionTiming(attempt *models.AssessmentAttempt, budgets []questionBudget, now time.Time) *QuestionTiming {
+	timing := &QuestionTiming{
+		Mode:           models.TimingModePerQuestion,
+		QuestionIndex:  attempt.CurrentQuestionIndex,
+		TotalQuestions: len(budgets),
+	}
+
+	if attempt.CurrentQuestionIndex >= len(budgets) {
+		timing.Expired = true
+		return timing
+	}
+
+	budget := budgets[attempt.CurrentQuestionIndex]
+	timing.QuestionID = budget.QuestionID
+	timing.BudgetSeconds = budget.Seconds
+	timing.StartedAt = attempt.QuestionStartedAt
+	timing.Deadline = attempt.QuestionDeadline
+	timing.IsLast = attempt.CurrentQuestionIndex == len(budgets)-1
+	if attempt.QuestionDeadline != nil {
+		if remaining := int(attempt.QuestionDeadline.Sub(now).Seconds()); remaining > 0 {
+			timing.SecondsRemaining = remaining
+		}
+	}
+	return timing
+}
+
+// currentQuestionOf picks the question on the clock out of the assessment's questions
+func currentQuestionOf(attempt *models.AssessmentAttempt, budgets []questionBudget, questions []QuestionForAttempt) *QuestionForAttempt {
+	if attempt.CurrentQuestionIndex >= len(budgets) {
+		return nil
+	}
+
+	current := budgets[attempt.CurrentQuestionIndex].QuestionID
+	for i := range questions {
+		if questions[i].ID == current {
+			question := questions[i]
+			question.IsFirst = attempt.CurrentQuestionIndex == 0
+			question.IsLast = attempt.CurrentQuestionIndex == len(budgets)-1
+			return &question
+		}
+	}
+	return nil
+}
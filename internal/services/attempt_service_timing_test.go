@@ -0,0 +1,98 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestBuildQuestionBudgets(t *testing.T) {
+	override, own := 45, 120
+	links := []*models.AssessmentQuestion{
+		{QuestionID: 1, TimeLimit: &override},
+		{QuestionID: 2},
+		{QuestionID: 3},
+	}
+	questions := []*models.Question{{ID: 1, TimeLimit: &own}, {ID: 2, TimeLimit: &own}, {ID: 3}}
+
+	budgets := buildQuestionBudgets(links, questions)
+	if budgets[0].Seconds != 45 || budgets[1].Seconds != 120 || budgets[2].Seconds != defaultQuestionSeconds {
+		t.Errorf("unexpected budgets: %+v", budgets)
+	}
+}
+
+func TestQuestionClock(t *testing.T) {
+	start := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	budgets := []questionBudget{{QuestionID: 10, Seconds: 60}, {QuestionID: 20, Seconds: 30}, {QuestionID: 30, Seconds: 90}}
+	attempt := &models.AssessmentAttempt{}
+
+	startQuestionClock(attempt, budgets, start)
+	if !attempt.QuestionDeadline.Equal(start.Add(time.Minute)) || !attempt.EndedAt.Equal(start.Add(3*time.Minute)) {
+		t.Fatalf("unexpected clock: deadline %v, end %v", attempt.QuestionDeadline, attempt.EndedAt)
+	}
+	if err := checkQuestionOpen(attempt, budgets, 20); !IsBusinessRule(err) {
+		t.Errorf("answering ahead should be rejected, got %v", err)
+	}
+
+	// The first question expires and the second starts at its deadline
+	if !advanceExpiredQuestions(attempt, budgets, start.Add(70*time.Second)) {
+		t.Fatal("expired question should auto-advance")
+	}
+	if attempt.CurrentQuestionIndex != 1 || !attempt.QuestionDeadline.Equal(start.Add(90*time.Second)) {
+		t.Errorf("unexpected clock after auto-advance: index %d, deadline %v", attempt.CurrentQuestionIndex, attempt.QuestionDeadline)
+	}
+	if err := checkQuestionOpen(attempt, budgets, 10); !IsBusinessRule(err) {
+		t.Errorf("going back should be rejected, got %v", err)
+	}
+
+	// Leaving early moves the attempt end forward
+	if err := moveToNextQuestion(attempt, budgets, start.Add(75*time.Second)); err != nil {
+		t.Fatalf("unexpected advance error: %v", err)
+	}
+	if !attempt.EndedAt.Equal(start.Add(165 * time.Second)) {
+		t.Errorf("attempt should end when the last budget is spent, got %v", attempt.EndedAt)
+	}
+	if err := moveToNextQuestion(attempt, budgets, start.Add(80*time.Second)); !IsBusinessRule(err) {
+		t.Errorf("advancing past the last question should be rejected, got %v", err)
+	}
+
+	timing := buildQuestionTiming(attempt, budgets, start.Add(105*time.Second))
+	if timing.QuestionID != 30 || !timing.IsLast || timing.SecondsRemaining != 60 {
+		t.Errorf("unexpected timing: %+v", timing)
+	}
+
+	advanceExpiredQuestions(attempt, budgets, start.Add(10*time.Minute))
+	if timing := buildQuestionTiming(attempt, budgets, start.Add(10*time.Minute)); !timing.Expired {
+		t.Errorf("every question ran out, got %+v", timing)
+	}
+	if err := checkQuestionOpen(attempt, budgets, 30); err != ErrAttemptTimeExpired {
+		t.Errorf("expected time expired, got %v", err)
+	}
+}
+
+func TestOpenAnswers(t *testing.T) {
+	budgets := []questionBudget{{QuestionID: 10, Seconds: 60}, {QuestionID: 20, Seconds: 60}}
+	attempt := &models.AssessmentAttempt{CurrentQuestionIndex: 1}
+
+	open := openAnswers(attempt, budgets, []SubmitAnswerRequest{{QuestionID: 10}, {QuestionID: 20}})
+	if len(open) != 1 || open[0].QuestionID != 20 {
+		t.Errorf("only the current question's answer should be kept, got %+v", open)
+	}
+}
+
+func TestAccommodationExtraMinutes(t *testing.T) {
+	cases := []struct {
+		duration, percent, want int
+	}{
+		{60, 50, 30},
+		{45, 25, 12}, // 11.25 rounds up
+		{0, 50, 0},
+		{60, 0, 0},
+	}
+	for _, c := range cases {
+		if got := accommodationExtraMinutes(c.duration, c.percent); got != c.want {
+			t.Errorf("accommodationExtraMinutes(%d, %d) = %d, want %d", c.duration, c.percent, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,657 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/datatypes"
+)
+
+const (
+	transcriptFormatPDF  = "pdf"
+	transcriptFormatJSON = "json"
+	transcriptFormatCSV  = "csv"
+)
+
+// transcriptSections decides which parts of a transcript the viewer may see
+type transcriptSections struct {
+	results        bool
+	correctAnswers bool
+	breakdown      bool
+	rank           bool
+	integrity      bool
+}
+
+// ===== TRANSCRIPT =====
+
+func (s *attemptService) GetTranscript(ctx context.Context, attemptID uint, userID string) (*AttemptTranscript, error) {
+	s.logger.Info("Building attempt transcript", "attempt_id", attemptID, "user_id", userID)
+
+	attempt, err := s.repo.Attempt().GetByIDWithDetails(ctx, nil, attemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	canAccess, err := s.canAccessAttempt(ctx, attempt, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, attemptID, "attempt", "read", "not owner or insufficient permissions")
+	}
+
+	if attempt.Status == models.AttemptInProgress {
+		return nil, NewBusinessRuleError("attempt_in_progress", "transcript is available once the attempt is finished", map[string]interface{}{
+			"attempt_id": attemptID,
+		})
+	}
+
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, nil, attempt.AssessmentID)
+	if err != nil {
+		if !repositories.IsNotFoundError(err) {
+			return nil, fmt.Errorf("failed to get assessment settings: %w", err)
+		}
+		settings = nil
+	}
+
+	now := time.Now()
+	isStudent := attempt.StudentID == userID
+	sections := transcriptVisibility(settings, isStudent, now)
+
+	questions, err := s.getAttemptQuestions(ctx, attempt)
+	if err != nil {
+		return nil, err
+	}
+	route, err := s.attemptRoute(ctx, nil, attempt, nil)
+	if err != nil {
+		return nil, err
+	}
+	questions = orderByRoute(questions, route)
+
+	links, err := s.repo.AssessmentQuestion().GetByAssessmentOrdered(ctx, nil, attempt.AssessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment questions: %w", err)
+	}
+	pointOverrides := make(map[uint]int, len(links))
+	for _, link := range links {
+		if link.Points != nil {
+			pointOverrides[link.QuestionID] = *link.Points
+		}
+	}
+
+	answers, err := s.repo.Answer().GetByAttempt(ctx, nil, attemptID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attempt answers: %w", err)
+	}
+	answersByQuestion := make(map[uint]*models.StudentAnswer, len(answers))
+	for _, answer := range answers {
+		answersByQuestion[answer.QuestionID] = answer
+	}
+
+	// Grader annotations are feedback and follow the same release
+	annotationsByAnswer := make(map[uint][]*models.AnswerAnnotation)
+	if sections.results {
+		annotations, err := s.repo.AnswerAnnotation().GetByAttempt(ctx, nil, attemptID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get answer annotations: %w", err)
+		}
+		for _, annotation := range annotations {
+			annotationsByAnswer[annotation.AnswerID] = append(annotationsByAnswer[annotation.AnswerID], annotation)
+		}
+	}
+
+	transcript := &AttemptTranscript{
+		AttemptID:              attempt.ID,
+		AssessmentID:           attempt.AssessmentID,
+		AssessmentTitle:        attempt.Assessment.Title,
+		StudentID:              attempt.StudentID,
+		StudentName:            attempt.Student.FullName,
+		AttemptNumber:          attempt.AttemptNumber,
+		Status:                 attempt.Status,
+		StartedAt:              attempt.StartedAt,
+		CompletedAt:            attempt.CompletedAt,
+		TimeSpent:              attempt.TimeSpent,
+		ResultsIncluded:        sections.results,
+		CorrectAnswersIncluded: sections.correctAnswers,
+		MaxScore:               attempt.MaxScore,
+		GeneratedAt:            now,
+	}
+	if !isStudent && identitiesHidden(settings, now) {
+		transcript.StudentID = ""
+		transcript.StudentName = anonymousStudentLabel(settings.AnonymousGradingSalt, attempt.StudentID)
+	}
+	if sections.results {
+		score, percentage, passed := attempt.Score, attempt.Percentage, attempt.Passed
+		transcript.Score = &score
+		transcript.Percentage = &percentage
+		transcript.Passed = &passed
+	}
+
+	items := make([]scoredQuestion, 0, len(questions))
+	for i, delivered := range questions {
+		question := delivered.Question
+		points := question.Points
+		if override, ok := pointOverrides[question.ID]; ok {
+			points = override
+		}
+		answer := answersByQuestion[question.ID]
+		items = append(items, scoredQuestion{question: question, points: points, answer: answer})
+
+		entry := TranscriptQuestion{
+			Number:     i + 1,
+			QuestionID: question.ID,
+			Type:       question.Type,
+			Text:       question.Text,
+			Points:     points,
+		}
+		if answer != nil {
+			entry.Answer = transcriptAnswerText(question.Type, question.Content, answer.Answer)
+		}
+		if sections.correctAnswers {
+			entry.CorrectAnswer = transcriptCorrectAnswer(question.Type, question.Content)
+		}
+		if sections.results && answer != nil {
+			if answer.IsGraded {
+				score := answer.Score
+				entry.Score = &score
+			}
+			entry.Feedback = answer.Feedback
+			if len(answer.PartScores) > 0 {
+				var partScores []models.PartScore
+				if err := json.Unmarshal(answer.PartScores, &partScores); err == nil {
+					entry.PartScores = partScores
+				}
+			}
+			entry.Rubric, entry.Comments = transcriptRubric(question, annotationsByAnswer[answer.ID])
+		}
+		transcript.Questions = append(transcript.Questions, entry)
+	}
+
+	if sections.breakdown {
+		breakdown := buildScoreBreakdown(items)
+		breakdown.AttemptID = attempt.ID
+		breakdown.AssessmentID = attempt.AssessmentID
+		transcript.Breakdown = breakdown
+	}
+	if sections.rank {
+		if transcript.Standing, err = s.attemptStanding(ctx, attempt); err != nil {
+			return nil, err
+		}
+	}
+	if sections.integrity {
+		transcript.Integrity = summarizeIntegrity(attempt.ProctoringEvents, attempt.Sessions)
+		for _, answer := range answers {
+			if answer.LanguageMismatch {
+				transcript.Integrity.LanguageMismatches++
+			}
+		}
+	}
+
+	return transcript, nil
+}
+
+func (s *attemptService) ExportTranscript(ctx context.Context, attemptID uint, format string, userID string) (*ExportedDocument, error) {
+	if format == "" {
+		format = transcriptFormatPDF
+	}
+	if format != transcriptFormatPDF && format != transcriptFormatJSON {
+		return nil, ValidationErrors{*NewValidationError("format", "must be pdf or json", format)}
+	}
+
+	transcript, err := s.GetTranscript(ctx, attemptID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == transcriptFormatJSON {
+		content, err := json.MarshalIndent(transcript, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal transcript: %w", err)
+		}
+		return &ExportedDocument{
+			FileName:    fmt.Sprintf("attempt-%d-transcript.json", attemptID),
+			ContentType: "application/json",
+			Content:     content,
+		}, nil
+	}
+
+	return &ExportedDocument{
+		FileName:    fmt.Sprintf("attempt-%d-transcript.pdf", attemptID),
+		ContentType: "application/pdf",
+		Content:     renderTranscriptPDF(transcript),
+	}, nil
+}
+
+// transcriptVisibility applies the assessment's result settings to student downloads. Staff
+// see the whole transcript; students see scores, feedback and the integrity summary only
+// once results are released and shown, and correct answers and the breakdown only when
+// those are enabled too.
+func transcriptVisibility(settings *models.AssessmentSettings, isStudent bool, now time.Time) transcriptSections {
+	if !isStudent {
+		return transcriptSections{results: true, correctAnswers: true, breakdown: true, rank: true, integrity: true}
+	}
+
+	results := resultsReleased(settings, now) && (settings == nil || settings.ShowResults)
+	return transcriptSections{
+		results:        results,
+		correctAnswers: results && (settings == nil || settings.ShowCorrectAnswers),
+		breakdown:      results && (settings == nil || settings.ShowScoreBreakdown),
+		rank:           rankVisible(settings, isStudent, results),
+		integrity:      results,
+	}
+}
+
+// transcriptRubric files a question's annotations under its rubric criteria. Annotations
+// without a criterion, or naming one the question does not have, are returned as comments.
+func transcriptRubric(question *models.Question, annotations []*models.AnswerAnnotation) ([]TranscriptRubricCriterion, []string) {
+	var rubric []TranscriptRubricCriterion
+	index := make(map[string]int)
+	if question.Type == models.Essay {
+		var content models.EssayContent
+		if err := json.Unmarshal(question.Content, &content); err == nil {
+			for _, criterion := range content.Rubric {
+				index[criterion.Name] = len(rubric)
+				rubric = append(rubric, TranscriptRubricCriterion{Name: criterion.Name, Levels: criterion.Levels})
+			}
+		}
+	}
+
+	var comments []string
+	for _, annotation := range annotations {
+		comment := annotation.Comment
+		if annotation.Quote != "" {
+			comment = fmt.Sprintf("%q: %s", annotation.Quote, annotation.Comment)
+		}
+		if annotation.RubricCriterion != nil {
+			if i, ok := index[*annotation.RubricCriterion]; ok {
+				rubric[i].Comments = append(rubric[i].Comments, comment)
+				continue
+			}
+		}
+		comments = append(comments, comment)
+	}
+	return rubric, comments
+}
+
+// summarizeIntegrity counts proctoring events by type, most frequent first
+func summarizeIntegrity(events []models.ProctoringEvent, sessions int) *IntegritySummary {
+	summary := &IntegritySummary{TotalEvents: len(events), Sessions: sessions, Events: []IntegrityEventCount{}}
+	counts := make(map[models.ProctoringEventType]int)
+	for _, event := range events {
+		counts[event.Type]++
+		if event.Severity > summary.HighestSeverity {
+			summary.HighestSeverity = event.Severity
+		}
+	}
+	for eventType, count := range counts {
+		summary.Events = append(summary.Events, IntegrityEventCount{Type: eventType, Count: count})
+	}
+	sort.Slice(summary.Events, func(i, j int) bool {
+		if summary.Events[i].Count != summary.Events[j].Count {
+			return summary.Events[i].Count > summary.Events[j].Count
+		}
+		return summary.Events[i].Type < summary.Events[j].Type
+	})
+	return summary
+}
+
+// ===== ANSWER TEXT =====
+
+// transcriptAnswerText renders a stored answer for reading, naming options and items by
+// their text. Answers it cannot interpret are shown as compact JSON.
+func transcriptAnswerText(questionType models.QuestionType, content datatypes.JSON, raw datatypes.JSON) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+
+	switch questionType {
+	case models.MultipleChoice:
+		var mc models.MultipleChoiceContent
+		if selected, ok := parseSelectedOptions(raw); ok && json.Unmarshal(content, &mc) == nil {
+			texts := make(map[string]string, len(mc.Options))
+			for _, option := range mc.Options {
+				texts[option.ID] = option.Text
+			}
+			return strings.Join(lookupTexts(selected, texts), "; ")
+		}
+	case models.TrueFalse:
+		var tf models.TrueFalseContent
+		_ = json.Unmarshal(content, &tf)
+		var value bool
+		if json.Unmarshal(raw, &value) == nil {
+			return trueFalseLabel(tf, value)
+		}
+	case models.Essay, models.ShortAnswer:
+		var text string
+		if json.Unmarshal(raw, &text) == nil {
+			return text
+		}
+		var answer models.EssayAnswer
+		if json.Unmarshal(raw, &answer) == nil && answer.Text != "" {
+			return answer.Text
+		}
+	case models.FillInBlank:
+		answers := make(map[string]string)
+		if json.Unmarshal(raw, &answers) != nil {
+			var answer models.FillBlankAnswer
+			if json.Unmarshal(raw, &answer) != nil || answer.Answers == nil {
+				break
+			}
+			answers = answer.Answers
+		}
+		blankIDs := make([]string, 0, len(answers))
+		for blankID := range answers {
+			blankIDs = append(blankIDs, blankID)
+		}
+		sort.Strings(blankIDs)
+		parts := make([]string, len(blankIDs))
+		for i, blankID := range blankIDs {
+			parts[i] = blankID + ": " + answers[blankID]
+		}
+		return strings.Join(parts, "; ")
+	case models.Matching:
+		var mc models.MatchingContent
+		_ = json.Unmarshal(content, &mc)
+		pairs := make(map[string]string)
+		if json.Unmarshal(raw, &pairs) != nil {
+			var answer models.MatchingAnswer
+			if json.Unmarshal(raw, &answer) != nil || len(answer.Pairs) == 0 {
+				break
+			}
+			for _, pair := range answer.Pairs {
+				pairs[pair.LeftID] = pair.RightID
+			}
+		}
+		return matchingText(mc, pairs)
+	case models.Ordering:
+		var oc models.OrderingContent
+		_ = json.Unmarshal(content, &oc)
+		order, err := parseOrderingAnswer(raw)
+		if err != nil || len(order) == 0 {
+			break
+		}
+		return orderingText(oc, order)
+	case models.MultiPart:
+		var mp models.MultiPartContent
+		var answer models.MultiPartAnswer
+		if json.Unmarshal(content, &mp) == nil && json.Unmarshal(raw, &answer) == nil {
+			parts := make([]string, 0, len(mp.Parts))
+			for _, part := range mp.Parts {
+				if text := transcriptAnswerText(part.Type, part.Content, answer[part.ID]); text != "" {
+					parts = append(parts, part.Label+") "+text)
+				}
+			}
+			return strings.Join(parts, "\n")
+		}
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err != nil {
+		return string(raw)
+	}
+	return compact.String()
+}
+
+// transcriptCorrectAnswer renders a question's answer key for reading; essays show their
+// sample answer, if any
+func transcriptCorrectAnswer(questionType models.QuestionType, content datatypes.JSON) string {
+	switch questionType {
+	case models.MultipleChoice:
+		var mc models.MultipleChoiceContent
+		if json.Unmarshal(content, &mc) == nil {
+			texts := make(map[string]string, len(mc.Options))
+			for _, option := range mc.Options {
+				texts[option.ID] = option.Text
+			}
+			return strings.Join(lookupTexts(mc.CorrectAnswers, texts), "; ")
+		}
+	case models.TrueFalse:
+		var tf models.TrueFalseContent
+		if json.Unmarshal(content, &tf) == nil {
+			return trueFalseLabel(tf, tf.CorrectAnswer)
+		}
+	case models.ShortAnswer:
+		var sa models.ShortAnswerContent
+		if json.Unmarshal(content, &sa) == nil {
+			return strings.Join(sa.AcceptedAnswers, " / ")
+		}
+	case models.Essay:
+		var ec models.EssayContent
+		if json.Unmarshal(content, &ec) == nil && ec.SampleAnswer != nil {
+			return *ec.SampleAnswer
+		}
+	case models.FillInBlank:
+		var fb models.FillBlankContent
+		if json.Unmarshal(content, &fb) == nil {
+			blankIDs := make([]string, 0, len(fb.Blanks))
+			for blankID := range fb.Blanks {
+				blankIDs = append(blankIDs, blankID)
+			}
+			sort.Strings(blankIDs)
+			parts := make([]string, len(blankIDs))
+			for i, blankID := range blankIDs {
+				parts[i] = blankID + ": " + strings.Join(fb.Blanks[blankID].AcceptedAnswers, " / ")
+			}
+			return strings.Join(parts, "; ")
+		}
+	case models.Matching:
+		var mc models.MatchingContent
+		if json.Unmarshal(content, &mc) == nil {
+			pairs := make(map[string]string, len(mc.CorrectPairs))
+			for _, pair := range mc.CorrectPairs {
+				pairs[pair.LeftID] = pair.RightID
+			}
+			return matchingText(mc, pairs)
+		}
+	case models.Ordering:
+		var oc models.OrderingContent
+		if json.Unmarshal(content, &oc) == nil {
+			return orderingText(oc, oc.CorrectOrder)
+		}
+	case models.MultiPart:
+		var mp models.MultiPartContent
+		if json.Unmarshal(content, &mp) == nil {
+			parts := make([]string, 0, len(mp.Parts))
+			for _, part := range mp.Parts {
+				if text := transcriptCorrectAnswer(part.Type, part.Content); text != "" {
+					parts = append(parts, part.Label+") "+text)
+				}
+			}
+			return strings.Join(parts, "\n")
+		}
+	}
+	return ""
+}
+
+// lookupTexts maps IDs to their texts, keeping IDs that have none
+func lookupTexts(ids []string, texts map[string]string) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = id
+		if text, ok := texts[id]; ok {
+			result[i] = text
+		}
+	}
+	return result
+}
+
+func trueFalseLabel(content models.TrueFalseContent, value bool) string {
+	if value {
+		if content.TrueLabel != nil {
+			return *content.TrueLabel
+		}
+		return "True"
+	}
+	if content.FalseLabel != nil {
+		return *content.FalseLabel
+	}
+	return "False"
+}
+
+// matchingText lists pairs in the order of the left items
+func matchingText(content models.MatchingContent, pairs map[string]string) string {
+	rightTexts := make(map[string]string, len(content.RightItems))
+	for _, item := range content.RightItems {
+		rightTexts[item.ID] = item.Text
+	}
+
+	lines := make([]string, 0, len(pairs))
+	seen := make(map[string]bool, len(pairs))
+	for _, left := range content.LeftItems {
+		if right, ok := pairs[left.ID]; ok {
+			lines = append(lines, left.Text+" -> "+lookupTexts([]string{right}, rightTexts)[0])
+			seen[left.ID] = true
+		}
+	}
+	var unknown []string
+	for leftID := range pairs {
+		if !seen[leftID] {
+			unknown = append(unknown, leftID)
+		}
+	}
+	sort.Strings(unknown)
+	for _, leftID := range unknown {
+		lines = append(lines, leftID+" -> "+lookupTexts([]string{pairs[leftID]}, rightTexts)[0])
+	}
+	return strings.Join(lines, "; ")
+}
+
+func orderingText(content models.OrderingContent, order []string) string {
+	texts := make(map[string]string, len(content.Items))
+	for _, item := range content.Items {
+		texts[item.ID] = item.Text
+	}
+	items := lookupTexts(order, texts)
+	for i := range items {
+		items[i] = fmt.Sprintf("%d. %s", i+1, items[i])
+	}
+	return strings.Join(items, "; ")
+}
+
+// ===== PDF =====
+
+func renderTranscriptPDF(t *AttemptTranscript) []byte {
+	doc := newPDFDocument()
+
+	doc.Heading(t.AssessmentTitle)
+	doc.Field("Student", t.StudentName)
+	doc.Field("Attempt", fmt.Sprintf("#%d (%s)", t.AttemptNumber, t.Status))
+	if t.StartedAt != nil {
+		doc.Field("Started", formatTranscriptTime(*t.StartedAt))
+	}
+	if t.CompletedAt != nil {
+		doc.Field("Completed", formatTranscriptTime(*t.CompletedAt))
+	}
+	doc.Field("Time spent", formatTranscriptDuration(t.TimeSpent))
+	if t.ResultsIncluded && t.Score != nil {
+		doc.Field("Score", fmt.Sprintf("%s / %d (%.1f%%)", formatPoints(*t.Score), t.MaxScore, *t.Percentage))
+		if *t.Passed {
+			doc.Field("Result", "Passed")
+		} else {
+			doc.Field("Result", "Not passed")
+		}
+		if t.Standing != nil {
+			doc.Field("Rank", fmt.Sprintf("%d of %d, percentile %.0f", t.Standing.Rank, t.Standing.OutOf, t.Standing.Percentile))
+		}
+	} else {
+		doc.Field("Score", "Not released")
+	}
+
+	for _, question := range t.Questions {
+		doc.Subheading(fmt.Sprintf("Question %d (%d points)", question.Number, question.Points))
+		doc.Paragraph(question.Text)
+		doc.Space(2)
+
+		answer := question.Answer
+		if answer == "" {
+			answer = "Not answered"
+		}
+		doc.Paragraph("Answer:")
+		doc.Indented(answer)
+		if question.CorrectAnswer != "" {
+			doc.Paragraph("Correct answer:")
+			doc.Indented(question.CorrectAnswer)
+		}
+		if t.ResultsIncluded {
+			if question.Score != nil {
+				doc.Field("Score", fmt.Sprintf("%s / %d", formatPoints(*question.Score), question.Points))
+			} else {
+				doc.Field("Score", "Not graded yet")
+			}
+		}
+		for _, part := range question.PartScores {
+			doc.Indented(fmt.Sprintf("Part %s: %s / %d", part.PartID, formatPoints(part.Score), part.MaxScore))
+		}
+		if question.Feedback != nil && *question.Feedback != "" {
+			doc.Paragraph("Feedback:")
+			doc.Indented(*question.Feedback)
+		}
+		for _, criterion := range question.Rubric {
+			doc.Paragraph("Rubric - " + criterion.Name)
+			for _, comment := range criterion.Comments {
+				doc.Indented(comment)
+			}
+		}
+		if len(question.Comments) > 0 {
+			doc.Paragraph("Comments:")
+			for _, comment := range question.Comments {
+				doc.Indented(comment)
+			}
+		}
+	}
+
+	if t.Breakdown != nil {
+		doc.Subheading("Score breakdown")
+		for _, group := range t.Breakdown.ByType {
+			doc.Field(group.Label, fmt.Sprintf("%s / %d", formatPoints(group.EarnedPoints), group.PossiblePoints))
+		}
+		for _, group := range t.Breakdown.BySkill {
+			doc.Field("Skill "+group.Label, fmt.Sprintf("%s / %d", formatPoints(group.EarnedPoints), group.PossiblePoints))
+		}
+	}
+
+	if t.Integrity != nil {
+		doc.Subheading("Integrity summary")
+		doc.Field("Sessions", fmt.Sprintf("%d", t.Integrity.Sessions))
+		doc.Field("Proctoring events", fmt.Sprintf("%d", t.Integrity.TotalEvents))
+		if t.Integrity.TotalEvents > 0 {
+			doc.Field("Highest severity", fmt.Sprintf("%d of 5", t.Integrity.HighestSeverity))
+			for _, event := range t.Integrity.Events {
+				doc.Indented(fmt.Sprintf("%s: %d", strings.ReplaceAll(string(event.Type), "_", " "), event.Count))
+			}
+		}
+		if t.Integrity.LanguageMismatches > 0 {
+			doc.Field("Answers in an unexpected language", fmt.Sprintf("%d", t.Integrity.LanguageMismatches))
+		}
+	}
+
+	doc.Space(10)
+	doc.Paragraph("Generated " + formatTranscriptTime(t.GeneratedAt))
+	return doc.Bytes()
+}
+
+func formatTranscriptTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04 UTC")
+}
+
+func formatTranscriptDuration(seconds int) string {
+	return fmt.Sprintf("%dm %02ds", seconds/60, seconds%60)
+}
+
+// formatPoints drops the decimals of whole scores
+func formatPoints(points float64) string {
+	if points == float64(int64(points)) {
+		return fmt.Sprintf("%d", int64(points))
+	}
+	return fmt.Sprintf("%.2f", points)
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+func TestTranscriptVisibility(t *testing.T) {
+	now := time.Now()
+	released := &models.AssessmentSettings{ShowResults: true, ShowCorrectAnswers: true, ShowScoreBreakdown: true}
+	hidden := &models.AssessmentSettings{ShowResults: false, ShowCorrectAnswers: true, ShowScoreBreakdown: true}
+	noKey := &models.AssessmentSettings{ShowResults: true, ShowCorrectAnswers: false, ShowScoreBreakdown: true}
+	manual := &models.AssessmentSettings{ShowResults: true, ShowCorrectAnswers: true, ShowScoreBreakdown: true, ResultsReleaseMode: models.ResultsReleaseManual}
+	ranked := &models.AssessmentSettings{ShowResults: true, ShowCorrectAnswers: true, ShowScoreBreakdown: true, ShowRank: true}
+
+	tests := []struct {
+		name      string
+		settings  *models.AssessmentSettings
+		isStudent bool
+		want      transcriptSections
+	}{
+		{"staff see everything", manual, false, transcriptSections{true, true, true, true, true}},
+		{"student without settings", nil, true, transcriptSections{true, true, true, false, true}},
+		{"student after release", released, true, transcriptSections{true, true, true, false, true}},
+		{"student with rank shown", ranked, true, transcriptSections{true, true, true, true, true}},
+		{"student with results hidden", hidden, true, transcriptSections{}},
+		{"student without correct answers", noKey, true, transcriptSections{true, false, true, false, true}},
+		{"student before manual release", manual, true, transcriptSections{}},
+	}
+
+	for _, tt := range tests {
+		if got := transcriptVisibility(tt.settings, tt.isStudent, now); got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTranscriptAnswerText(t *testing.T) {
+	mc := datatypes.JSON(`{"options":[{"id":"a","text":"Paris"},{"id":"b","text":"Rome"}],"correct_answers":["a"]}`)
+	tf := datatypes.JSON(`{"correct_answer":true,"true_label":"Yes"}`)
+	matching := datatypes.JSON(`{"left_items":[{"id":"l1","text":"France"},{"id":"l2","text":"Italy"}],"right_items":[{"id":"r1","text":"Paris"},{"id":"r2","text":"Rome"}],"correct_pairs":[{"left_id":"l1","right_id":"r1"},{"left_id":"l2","right_id":"r2"}]}`)
+	ordering := datatypes.JSON(`{"items":[{"id":"x","text":"First"},{"id":"y","text":"Second"}],"correct_order":["x","y"]}`)
+	multiPart := datatypes.JSON(`{"parts":[{"id":"p1","label":"a","type":"short_answer","content":{"accepted_answers":["4"]}},{"id":"p2","label":"b","type":"true_false","content":{"correct_answer":false}}]}`)
+
+	tests := []struct {
+		name    string
+		qType   models.QuestionType
+		content datatypes.JSON
+		raw     string
+		want    string
+	}{
+		{"multiple choice ids", models.MultipleChoice, mc, `["b","a"]`, "Rome; Paris"},
+		{"multiple choice unknown option", models.MultipleChoice, mc, `"z"`, "z"},
+		{"true false label", models.TrueFalse, tf, `true`, "Yes"},
+		{"true false default label", models.TrueFalse, tf, `false`, "False"},
+		{"essay object", models.Essay, nil, `{"text":"An essay"}`, "An essay"},
+		{"short answer", models.ShortAnswer, nil, `"42"`, "42"},
+		{"fill blank", models.FillInBlank, nil, `{"b2":"y","b1":"x"}`, "b1: x; b2: y"},
+		{"matching", models.Matching, matching, `{"l2":"r2","l1":"r1"}`, "France -> Paris; Italy -> Rome"},
+		{"ordering", models.Ordering, ordering, `["y","x"]`, "1. Second; 2. First"},
+		{"multi part", models.MultiPart, multiPart, `{"p1":"4","p2":true}`, "a) 4\nb) True"},
+		{"unanswered", models.ShortAnswer, nil, `null`, ""},
+		{"unreadable", models.TrueFalse, tf, `{ "odd": 1 }`, `{"odd":1}`},
+	}
+
+	for _, tt := range tests {
+		if got := transcriptAnswerText(tt.qType, tt.content, datatypes.JSON(tt.raw)); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTranscriptCorrectAnswer(t *testing.T) {
+	tests := []struct {
+		qType   models.QuestionType
+		content string
+		want    string
+	}{
+		{models.MultipleChoice, `{"options":[{"id":"a","text":"Paris"},{"id":"b","text":"Rome"}],"correct_answers":["a"]}`, "Paris"},
+		{models.TrueFalse, `{"correct_answer":false,"false_label":"No"}`, "No"},
+		{models.ShortAnswer, `{"accepted_answers":["4","four"]}`, "4 / four"},
+		{models.FillInBlank, `{"blanks":{"b1":{"accepted_answers":["Paris"]}}}`, "b1: Paris"},
+		{models.Essay, `{"rubric_criteria":[]}`, ""},
+		{models.Essay, `{"sample_answer":"A model answer"}`, "A model answer"},
+	}
+
+	for _, tt := range tests {
+		if got := transcriptCorrectAnswer(tt.qType, datatypes.JSON(tt.content)); got != tt.want {
+			t.Errorf("%s %s: got %q, want %q", tt.qType, tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestTranscriptRubric(t *testing.T) {
+	question := &models.Question{
+		Type:    models.Essay,
+		Content: datatypes.JSON(`{"rubric":[{"name":"Clarity","levels":[{"name":"Good","points":2}]},{"name":"Evidence","levels":[]}]}`),
+	}
+	clarity, unknown := "Clarity", "Style"
+	annotations := []*models.AnswerAnnotation{
+		{Comment: "Well put", Quote: "In short", RubricCriterion: &clarity},
+		{Comment: "Cite a source"},
+		{Comment: "Wordy", RubricCriterion: &unknown},
+	}
+
+	rubric, comments := transcriptRubric(question, annotations)
+	if len(rubric) != 2 || rubric[0].Name != "Clarity" || rubric[1].Name != "Evidence" {
+		t.Fatalf("unexpected rubric %+v", rubric)
+	}
+	if len(rubric[0].Comments) != 1 || rubric[0].Comments[0] != `"In short": Well put` {
+		t.Errorf("unexpected clarity comments %q", rubric[0].Comments)
+	}
+	if len(rubric[1].Comments) != 0 {
+		t.Errorf("evidence should have no comments, got %q", rubric[1].Comments)
+	}
+	if len(comments) != 2 || comments[0] != "Cite a source" || comments[1] != "Wordy" {
+		t.Errorf("unexpected general comments %q", comments)
+	}
+}
+
+func TestSummarizeIntegrity(t *testing.T) {
+	events := []models.ProctoringEvent{
+		{Type: models.EventTabSwitch, Severity: 2},
+		{Type: models.EventCopyPaste, Severity: 4},
+		{Type: models.EventTabSwitch, Severity: 1},
+	}
+
+	summary := summarizeIntegrity(events, 2)
+	if summary.TotalEvents != 3 || summary.HighestSeverity != 4 || summary.Sessions != 2 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+	if len(summary.Events) != 2 || summary.Events[0].Type != models.EventTabSwitch || summary.Events[0].Count != 2 {
+		t.Errorf("unexpected event counts %+v", summary.Events)
+	}
+}
+
+func TestRenderTranscriptPDFWithheldResults(t *testing.T) {
+	transcript := &AttemptTranscript{
+		AssessmentTitle: "Geography",
+		StudentName:     "Ada",
+		AttemptNumber:   1,
+		Status:          models.AttemptCompleted,
+		Questions: []TranscriptQuestion{
+			{Number: 1, Text: "Capital of France?", Points: 5, Answer: "Paris"},
+		},
+		GeneratedAt: time.Now(),
+	}
+
+	out := renderTranscriptPDF(transcript)
+	if !bytes.Contains(out, []byte("(Score: Not released) Tj")) {
+		t.Errorf("withheld score should be marked as not released")
+	}
+	if bytes.Contains(out, []byte("Integrity summary")) {
+		t.Errorf("integrity summary should be left out")
+	}
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// ===== QUESTION TRIALS =====
+
+// assignQuestionTrials decides, per running trial on the assessment, whether the attempt is
+// served the revision. The choice is recorded on the answer, so it holds for the rest of the
+// attempt even if the trial ends meanwhile.
+func (s *attemptService) assignQuestionTrials(ctx context.Context, tx *gorm.DB, attempt *models.AssessmentAttempt, answers []*models.StudentAnswer) error {
+	trials, err := s.repo.QuestionTrial().GetRunningByAssessment(ctx, tx, attempt.AssessmentID)
+	if err != nil {
+		return err
+	}
+
+	for _, trial := range trials {
+		if !inTrialVariant(attempt.ID, trial.ID, trial.Fraction) {
+			continue
+		}
+		for _, answer := range answers {
+			if answer.QuestionID == trial.QuestionID {
+				variantID := trial.VariantQuestionID
+				answer.VariantQuestionID = &variantID
+			}
+		}
+	}
+	return nil
+}
+
+// applyQuestionTrials swaps in the revisions the attempt was assigned. A revision keeps the
+// original's ID, so answers, branching and timing work unchanged and the student cannot tell.
+func (s *attemptService) applyQuestionTrials(ctx context.Context, attemptID uint, questions []QuestionForAttempt) error {
+	variants, err := s.repo.Answer().GetTrialVariants(ctx, nil, attemptID)
+	if err != nil {
+		return err
+	}
+	if len(variants) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(variants))
+	for _, variantID := range variants {
+		ids = append(ids, variantID)
+	}
+	revisions, err := s.repo.Question().GetByIDs(ctx, nil, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get trial questions: %w", err)
+	}
+	byID := make(map[uint]*models.Question, len(revisions))
+	for _, revision := range revisions {
+		byID[revision.ID] = revision
+	}
+
+	for i := range questions {
+		revision, ok := byID[variants[questions[i].ID]]
+		if !ok {
+			continue
+		}
+		served := *revision
+		served.ID = questions[i].ID
+		questions[i].Question = &served
+	}
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// inTrialVariant assigns an attempt to a trial's revision with probability fraction. It is
+// deterministic, so an attempt always lands on the same side of a trial.
+func inTrialVariant(attemptID, trialID uint, fraction float64) bool {
+	h := fnv.New32a()
+	_, _ = fmt.Fprintf(h, "%d:%d", trialID, attemptID)
+	return float64(h.Sum32()%10000)/10000 < fraction
+}
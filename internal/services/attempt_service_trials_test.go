@@ -0,0 +1,40 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInTrialVariantIsStable(t *testing.T) {
+	for attemptID := uint(1); attemptID <= 100; attemptID++ {
+		if inTrialVariant(attemptID, 7, 0.3) != inTrialVariant(attemptID, 7, 0.3) {
+			t.Fatalf("expected attempt %d to land on the same side every time", attemptID)
+		}
+	}
+}
+
+func TestInTrialVariantFollowsFraction(t *testing.T) {
+	const attempts = 20000
+	for _, fraction := range []float64{0.1, 0.5, 0.9} {
+		served := 0
+		for attemptID := uint(1); attemptID <= attempts; attemptID++ {
+			if inTrialVariant(attemptID, 3, fraction) {
+				served++
+			}
+		}
+		if share := float64(served) / attempts; math.Abs(share-fraction) > 0.02 {
+			t.Errorf("expected about %.0f%% of attempts to get the revision, got %.1f%%", fraction*100, share*100)
+		}
+	}
+}
+
+func TestInTrialVariantEdges(t *testing.T) {
+	for attemptID := uint(1); attemptID <= 100; attemptID++ {
+		if inTrialVariant(attemptID, 1, 0) {
+			t.Fatalf("expected no attempt to get the revision at fraction 0")
+		}
+		if !inTrialVariant(attemptID, 1, 1) {
+			t.Fatalf("expected every attempt to get the revision at fraction 1")
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// While an attempt is in progress on an assessment, paused ones included, its questions,
+// points and settings must not change under the student. Admins can override the lock; the
+// attempts in progress are then voided, without using up one of the students' attempts.
+
+type contentLockOverrideKey struct{}
+
+// WithContentLockOverride asks for an admin override of the content lock
+func WithContentLockOverride(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contentLockOverrideKey{}, true)
+}
+
+func contentLockOverridden(ctx context.Context) bool {
+	overridden, _ := ctx.Value(contentLockOverrideKey{}).(bool)
+	return overridden
+}
+
+// contentLock holds the attempts an overridden change invalidates; nil when there are none
+type contentLock struct {
+	repo     repositories.Repository
+	admin    *models.User
+	change   string
+	attempts []*models.AssessmentAttempt
+}
+
+// acquireContentLock refuses a change to the assessments while attempts are in progress on
+// them, unless an admin overrides the lock
+func acquireContentLock(ctx context.Context, repo repositories.Repository, userID, change string, assessmentIDs ...uint) (*contentLock, error) {
+	attempts, err := repo.Attempt().GetInProgressByAssessments(ctx, nil, assessmentIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(attempts) == 0 {
+		return nil, nil
+	}
+	if !contentLockOverridden(ctx) {
+		return nil, newContentLockedError(attempts)
+	}
+
+	admin, err := repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if admin.Role != models.RoleAdmin {
+		return nil, NewPermissionError(userID, attempts[0].AssessmentID, "assessment", "override_content_lock", "only admins may change content while attempts are in progress")
+	}
+	return &contentLock{repo: repo, admin: admin, change: change, attempts: attempts}, nil
+}
+
+// acquireQuestionContentLock locks every assessment the question is used in
+func acquireQuestionContentLock(ctx context.Context, repo repositories.Repository, userID, change string, questionID uint) (*contentLock, error) {
+	links, err := repo.AssessmentQuestion().GetByQuestion(ctx, nil, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessments using question: %w", err)
+	}
+	assessmentIDs := make([]uint, 0, len(links))
+	for _, link := range links {
+		assessmentIDs = append(assessmentIDs, link.AssessmentID)
+	}
+	return acquireContentLock(ctx, repo, userID, change, assessmentIDs...)
+}
+
+// release voids the attempts the change invalidated and records the override. Call it with
+// the change's transaction, if any, once the change is applied.
+func (l *contentLock) release(ctx context.Context, tx *gorm.DB) error {
+	if l == nil {
+		return nil
+	}
+
+	now := time.Now()
+	endReason := models.AttemptEndReasonContentChanged
+	voided := make([]uint, 0, len(l.attempts))
+	for _, attempt := range l.attempts {
+		attempt.Status = models.AttemptVoided
+		attempt.EndedAt = timePtr(now)
+		attempt.EndReason = &endReason
+		if err := l.repo.Attempt().Update(ctx, tx, attempt); err != nil {
+			return fmt.Errorf("failed to void attempt: %w", err)
+		}
+		voided = append(voided, attempt.ID)
+	}
+
+	lockedErr := newContentLockedError(l.attempts)
+	metadata, err := json.Marshal(map[string]interface{}{
+		"change":             l.change,
+		"assessment_ids":     lockedErr.AssessmentIDs,
+		"voided_attempt_ids": voided,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode audit metadata: %w", err)
+	}
+	return l.repo.AuditLog().Create(ctx, tx, &models.AuditLog{
+		EventType:       models.AuditContentLockOverride,
+		UserID:          l.admin.ID,
+		UserEmail:       l.admin.Email,
+		UserRole:        l.admin.Role,
+		TargetType:      "assessment",
+		TargetID:        &lockedErr.AssessmentIDs[0],
+		Description:     fmt.Sprintf("Overrode the content lock for %s, voiding attempts %v", l.change, voided),
+		Metadata:        metadata,
+		ComplianceLevel: "high",
+	})
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func newContentLockedError(attempts []*models.AssessmentAttempt) *ContentLockedError {
+	assessmentIDs := make([]uint, 0)
+	for _, attempt := range attempts {
+		if !slices.Contains(assessmentIDs, attempt.AssessmentID) {
+			assessmentIDs = append(assessmentIDs, attempt.AssessmentID)
+		}
+	}
+	slices.Sort(assessmentIDs)
+	return &ContentLockedError{
+		AssessmentIDs:      assessmentIDs,
+		AttemptsInProgress: len(attempts),
+	}
+}
+
+// assessmentUpdateLocked reports whether an update changes what students taking the
+// assessment work against; titles, descriptions and due dates can change at any time
+func assessmentUpdateLocked(req *UpdateAssessmentRequest) bool {
+	return req.Duration != nil || req.PassingScore != nil || req.TimeWarning != nil || req.Settings != nil
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+)
+
+func TestNewContentLockedError(t *testing.T) {
+	err := newContentLockedError([]*models.AssessmentAttempt{
+		{ID: 1, AssessmentID: 7},
+		{ID: 2, AssessmentID: 3},
+		{ID: 3, AssessmentID: 7},
+	})
+	if !slices.Equal(err.AssessmentIDs, []uint{3, 7}) {
+		t.Errorf("expected distinct sorted assessments, got %v", err.AssessmentIDs)
+	}
+	if err.AttemptsInProgress != 3 {
+		t.Errorf("expected 3 attempts in progress, got %d", err.AttemptsInProgress)
+	}
+}
+
+func TestAssessmentUpdateLocked(t *testing.T) {
+	title := "Renamed"
+	duration := 45
+	if assessmentUpdateLocked(&UpdateAssessmentRequest{Title: &title}) {
+		t.Error("expected a title change to be allowed during attempts")
+	}
+	if !assessmentUpdateLocked(&UpdateAssessmentRequest{Duration: &duration}) {
+		t.Error("expected a duration change to be locked")
+	}
+	if !assessmentUpdateLocked(&UpdateAssessmentRequest{Settings: &validator.AssessmentSettingsRequest{}}) {
+		t.Error("expected a settings change to be locked")
+	}
+}
+
+func TestContentLockOverride(t *testing.T) {
+	if contentLockOverridden(context.Background()) {
+		t.Error("expected no override by default")
+	}
+	if !contentLockOverridden(WithContentLockOverride(context.Background())) {
+		t.Error("expected the override to be carried by the context")
+	}
+}
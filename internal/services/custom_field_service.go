@@ -0,0 +1,410 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	customFieldDateLayout     = "2006-01-02"
+	customFieldTextMaxLength  = 2000 // For text fields without their own limit
+	customFieldExportPrefix   = "cf_"
+	maxCustomFieldsPerRequest = 50
+)
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+type customFieldService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewCustomFieldService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) CustomFieldService {
+	return &customFieldService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// ===== DEFINITIONS =====
+
+// CreateDefinition adds a custom field to an organization's assessments or attempts. Values
+// are stored in the records' custom_fields column, so no migration is needed.
+func (s *customFieldService) CreateDefinition(ctx context.Context, req *CreateCustomFieldRequest, userID string) (*models.CustomFieldDefinition, error) {
+	s.logger.Info("Creating custom field", "organization", req.Organization, "entity", req.Entity, "key", req.Key, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(ctx, userID, "manage_custom_fields"); err != nil {
+		return nil, err
+	}
+
+	definition := &models.CustomFieldDefinition{
+		Organization: strings.TrimSpace(req.Organization),
+		Entity:       req.Entity,
+		Key:          strings.TrimSpace(req.Key),
+		Label:        strings.TrimSpace(req.Label),
+		Type:         req.Type,
+		Required:     req.Required,
+		MaxLength:    req.MaxLength,
+		CreatedBy:    userID,
+	}
+	if err := setCustomFieldOptions(definition, req.Options); err != nil {
+		return nil, err
+	}
+	if err := checkCustomFieldDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.CustomField().List(ctx, nil, definition.Organization, &definition.Entity)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range existing {
+		if other.Key == definition.Key {
+			return nil, NewBusinessRuleError("custom_field_exists", "the organization already has a field with this key", map[string]interface{}{
+				"organization": definition.Organization,
+				"entity":       definition.Entity,
+				"key":          definition.Key,
+			})
+		}
+	}
+
+	if err := s.repo.CustomField().Create(ctx, nil, definition); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+func (s *customFieldService) ListDefinitions(ctx context.Context, organization string, entity *models.CustomFieldEntity, userID string) ([]*models.CustomFieldDefinition, error) {
+	if err := s.requireAdmin(ctx, userID, "view_custom_fields"); err != nil {
+		return nil, err
+	}
+	organization = strings.TrimSpace(organization)
+	if organization == "" {
+		return nil, ValidationErrors{*NewValidationError("organization", "is required", organization)}
+	}
+	return s.repo.CustomField().List(ctx, nil, organization, entity)
+}
+
+// UpdateDefinition changes a field's label, options, limit or whether it is required. Values
+// already recorded are not checked again; they are when the record's custom fields next change.
+func (s *customFieldService) UpdateDefinition(ctx context.Context, id uint, req *UpdateCustomFieldRequest, userID string) (*models.CustomFieldDefinition, error) {
+	s.logger.Info("Updating custom field", "custom_field_id", id, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(ctx, userID, "manage_custom_fields"); err != nil {
+		return nil, err
+	}
+
+	definition, err := s.getDefinition(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Label != nil {
+		definition.Label = strings.TrimSpace(*req.Label)
+	}
+	if req.Options != nil {
+		if err := setCustomFieldOptions(definition, *req.Options); err != nil {
+			return nil, err
+		}
+	}
+	if req.Required != nil {
+		definition.Required = *req.Required
+	}
+	if req.MaxLength != nil {
+		definition.MaxLength = req.MaxLength
+	}
+	if err := checkCustomFieldDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CustomField().Update(ctx, nil, definition); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+// DeleteDefinition removes a field. Recorded values stay on the records but are no longer
+// validated, exported or accepted in new changes.
+func (s *customFieldService) DeleteDefinition(ctx context.Context, id uint, userID string) error {
+	s.logger.Info("Deleting custom field", "custom_field_id", id, "user_id", userID)
+
+	if err := s.requireAdmin(ctx, userID, "manage_custom_fields"); err != nil {
+		return err
+	}
+	if _, err := s.getDefinition(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.CustomField().Delete(ctx, nil, id)
+}
+
+// ===== HELPER METHODS =====
+
+func (s *customFieldService) getDefinition(ctx context.Context, id uint) (*models.CustomFieldDefinition, error) {
+	definition, err := s.repo.CustomField().GetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get custom field definition: %w", err)
+	}
+	return definition, nil
+}
+
+func (s *customFieldService) requireAdmin(ctx context.Context, userID, action string) error {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleAdmin {
+		return NewPermissionError(userID, 0, "custom_field", action, "only admins may manage custom fields")
+	}
+	return nil
+}
+
+// ===== SHARED HELPERS =====
+
+// resolveCustomFields applies changes to a record's custom field values and validates the
+// result against the definitions of the owner's organization. A null value removes a field.
+func resolveCustomFields(ctx context.Context, repo repositories.Repository, ownerID string, entity models.CustomFieldEntity, current datatypes.JSON, changes map[string]interface{}) (datatypes.JSON, error) {
+	definitions, err := ownerCustomFields(ctx, repo, ownerID, entity)
+	if err != nil {
+		return nil, err
+	}
+	return mergeCustomFieldValues(definitions, current, changes)
+}
+
+// ownerCustomFields returns the definitions of the user's organization; users outside an
+// organization have none
+func ownerCustomFields(ctx context.Context, repo repositories.Repository, ownerID string, entity models.CustomFieldEntity) ([]*models.CustomFieldDefinition, error) {
+	owner, err := repo.User().GetByID(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if owner.Organization == nil || strings.TrimSpace(*owner.Organization) == "" {
+		return nil, nil
+	}
+	return repo.CustomField().List(ctx, nil, strings.TrimSpace(*owner.Organization), &entity)
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func setCustomFieldOptions(definition *models.CustomFieldDefinition, options []string) error {
+	if len(options) == 0 {
+		definition.Options = nil
+		return nil
+	}
+	trimmed := make([]string, 0, len(options))
+	for _, option := range options {
+		trimmed = append(trimmed, strings.TrimSpace(option))
+	}
+	data, err := json.Marshal(trimmed)
+	if err != nil {
+		return fmt.Errorf("failed to encode custom field options: %w", err)
+	}
+	definition.Options = data
+	return nil
+}
+
+func checkCustomFieldDefinition(definition *models.CustomFieldDefinition) error {
+	var errs ValidationErrors
+	if !customFieldKeyPattern.MatchString(definition.Key) {
+		errs = append(errs, *NewValidationError("key", "must start with a lower case letter and contain only lower case letters, digits and underscores", definition.Key))
+	}
+	options := customFieldOptions(definition)
+	if definition.Type == models.CustomFieldSelect && len(options) == 0 {
+		errs = append(errs, *NewValidationError("options", "are required for select fields", nil))
+	}
+	if definition.Type != models.CustomFieldSelect && len(options) > 0 {
+		errs = append(errs, *NewValidationError("options", "are only allowed on select fields", options))
+	}
+	if definition.MaxLength != nil && definition.Type != models.CustomFieldText {
+		errs = append(errs, *NewValidationError("max_length", "is only allowed on text fields", *definition.MaxLength))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	// Attempts are started by students, who cannot fill in the organization's fields
+	if definition.Required && definition.Entity == models.CustomFieldEntityAttempt {
+		return NewBusinessRuleError("custom_field_not_requirable", "attempt fields are filled in after the attempt starts and cannot be required", map[string]interface{}{
+			"key": definition.Key,
+		})
+	}
+	return nil
+}
+
+func customFieldOptions(definition *models.CustomFieldDefinition) []string {
+	var options []string
+	if len(definition.Options) > 0 {
+		_ = json.Unmarshal(definition.Options, &options)
+	}
+	return options
+}
+
+// mergeCustomFieldValues applies changes to the current values, checking each changed value
+// against its definition and that every required field ends up set
+func mergeCustomFieldValues(definitions []*models.CustomFieldDefinition, current datatypes.JSON, changes map[string]interface{}) (datatypes.JSON, error) {
+	values := map[string]interface{}{}
+	if len(current) > 0 {
+		if err := json.Unmarshal(current, &values); err != nil {
+			return nil, fmt.Errorf("failed to decode custom fields: %w", err)
+		}
+		if values == nil {
+			values = map[string]interface{}{}
+		}
+	}
+
+	byKey := make(map[string]*models.CustomFieldDefinition, len(definitions))
+	for _, definition := range definitions {
+		byKey[definition.Key] = definition
+	}
+
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs ValidationErrors
+	rejected := make(map[string]bool)
+	if len(changes) > maxCustomFieldsPerRequest {
+		errs = append(errs, *NewValidationError("custom_fields", fmt.Sprintf("cannot change more than %d fields at once", maxCustomFieldsPerRequest), len(changes)))
+	}
+	for _, key := range keys {
+		field := "custom_fields." + key
+		definition := byKey[key]
+		if definition == nil {
+			errs = append(errs, *NewValidationError(field, "is not a custom field of the organization", changes[key]))
+			continue
+		}
+		if changes[key] == nil {
+			delete(values, key)
+			continue
+		}
+		value, message := checkCustomFieldValue(definition, changes[key])
+		if message != "" {
+			errs = append(errs, *NewValidationError(field, message, changes[key]))
+			rejected[key] = true
+			continue
+		}
+		values[key] = value
+	}
+	for _, definition := range definitions {
+		if _, ok := values[definition.Key]; definition.Required && !ok && !rejected[definition.Key] {
+			errs = append(errs, *NewValidationError("custom_fields."+definition.Key, "is required", nil))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	if len(values) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode custom fields: %w", err)
+	}
+	return data, nil
+}
+
+// checkCustomFieldValue returns the value to store, or why it does not fit the definition
+func checkCustomFieldValue(definition *models.CustomFieldDefinition, value interface{}) (interface{}, string) {
+	switch definition.Type {
+	case models.CustomFieldText:
+		text, ok := value.(string)
+		if !ok {
+			return nil, "must be text"
+		}
+		text = strings.TrimSpace(text)
+		limit := customFieldTextMaxLength
+		if definition.MaxLength != nil {
+			limit = *definition.MaxLength
+		}
+		if len([]rune(text)) > limit {
+			return nil, fmt.Sprintf("must be at most %d characters", limit)
+		}
+		return text, ""
+	case models.CustomFieldNumber:
+		number, ok := value.(float64)
+		if !ok {
+			return nil, "must be a number"
+		}
+		return number, ""
+	case models.CustomFieldBoolean:
+		flag, ok := value.(bool)
+		if !ok {
+			return nil, "must be true or false"
+		}
+		return flag, ""
+	case models.CustomFieldDate:
+		text, ok := value.(string)
+		if !ok {
+			return nil, "must be a date in YYYY-MM-DD form"
+		}
+		if _, err := time.Parse(customFieldDateLayout, strings.TrimSpace(text)); err != nil {
+			return nil, "must be a date in YYYY-MM-DD form"
+		}
+		return strings.TrimSpace(text), ""
+	case models.CustomFieldSelect:
+		text, ok := value.(string)
+		if !ok {
+			return nil, "must be one of the field's options"
+		}
+		for _, option := range customFieldOptions(definition) {
+			if option == text {
+				return text, ""
+			}
+		}
+		return nil, "must be one of the field's options"
+	}
+	return nil, "has an unknown field type"
+}
+
+// customFieldExportValue formats a stored value for a CSV cell
+func customFieldExportValue(values map[string]interface{}, key string) string {
+	switch value := values[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		data, _ := json.Marshal(value)
+		return string(data)
+	}
+}
+
+func decodeCustomFields(data datatypes.JSON) map[string]interface{} {
+	values := map[string]interface{}{}
+	if len(data) > 0 {
+		_ = json.Unmarshal(data, &values)
+	}
+	return values
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func customFieldDefinitions() []*models.CustomFieldDefinition {
+	return []*models.CustomFieldDefinition{
+		{Key: "cost_center", Type: models.CustomFieldText, Required: true, MaxLength: intPtr(8)},
+		{Key: "credits", Type: models.CustomFieldNumber},
+		{Key: "external", Type: models.CustomFieldBoolean},
+		{Key: "exam_date", Type: models.CustomFieldDate},
+		{Key: "campus", Type: models.CustomFieldSelect, Options: []byte(`["North","South"]`)},
+	}
+}
+
+func TestMergeCustomFieldValues(t *testing.T) {
+	data, err := mergeCustomFieldValues(customFieldDefinitions(), nil, map[string]interface{}{
+		"cost_center": "  4100 ",
+		"credits":     float64(5),
+		"external":    true,
+		"exam_date":   "2025-06-30",
+		"campus":      "North",
+	})
+	if err != nil {
+		t.Fatalf("expected valid values to be accepted, got %v", err)
+	}
+	values := decodeCustomFields(data)
+	if values["cost_center"] != "4100" || values["credits"] != float64(5) || values["campus"] != "North" {
+		t.Errorf("expected trimmed values to be stored, got %v", values)
+	}
+
+	// Changes replace the given keys, null removes one and the rest are kept
+	data, err = mergeCustomFieldValues(customFieldDefinitions(), data, map[string]interface{}{
+		"credits": float64(7.5),
+		"campus":  nil,
+	})
+	if err != nil {
+		t.Fatalf("expected the change to be accepted, got %v", err)
+	}
+	values = decodeCustomFields(data)
+	if values["credits"] != 7.5 || values["cost_center"] != "4100" {
+		t.Errorf("expected credits changed and cost center kept, got %v", values)
+	}
+	if _, ok := values["campus"]; ok {
+		t.Errorf("expected null to remove the field, got %v", values)
+	}
+}
+
+func TestMergeCustomFieldValuesRejectsInvalidValues(t *testing.T) {
+	_, err := mergeCustomFieldValues(customFieldDefinitions(), nil, map[string]interface{}{
+		"cost_center": "longer than eight",
+		"credits":     "five",
+		"external":    "yes",
+		"exam_date":   "30/06/2025",
+		"campus":      "East",
+		"unknown":     "x",
+	})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected validation errors, got %v", err)
+	}
+	if len(errs) != 6 {
+		t.Errorf("expected one error per field, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestMergeCustomFieldValuesRequiresRequiredFields(t *testing.T) {
+	_, err := mergeCustomFieldValues(customFieldDefinitions(), nil, nil)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "custom_fields.cost_center" {
+		t.Fatalf("expected the required field to be reported, got %v", err)
+	}
+
+	_, err = mergeCustomFieldValues(customFieldDefinitions(), []byte(`{"cost_center":"4100"}`), map[string]interface{}{"cost_center": nil})
+	if !errors.As(err, &errs) {
+		t.Errorf("expected removing a required field to fail, got %v", err)
+	}
+
+	data, err := mergeCustomFieldValues(nil, nil, nil)
+	if err != nil || data != nil {
+		t.Errorf("expected no values without definitions, got %s, %v", data, err)
+	}
+}
+
+func TestCheckCustomFieldDefinition(t *testing.T) {
+	valid := &models.CustomFieldDefinition{Key: "campus", Entity: models.CustomFieldEntityAssessment, Type: models.CustomFieldSelect, Options: []byte(`["North"]`), Required: true}
+	if err := checkCustomFieldDefinition(valid); err != nil {
+		t.Errorf("expected a valid definition, got %v", err)
+	}
+
+	badKey := &models.CustomFieldDefinition{Key: "Cost Center", Entity: models.CustomFieldEntityAssessment, Type: models.CustomFieldText}
+	var errs ValidationErrors
+	if err := checkCustomFieldDefinition(badKey); !errors.As(err, &errs) {
+		t.Errorf("expected a key with spaces to be rejected, got %v", err)
+	}
+
+	noOptions := &models.CustomFieldDefinition{Key: "campus", Entity: models.CustomFieldEntityAssessment, Type: models.CustomFieldSelect}
+	if err := checkCustomFieldDefinition(noOptions); !errors.As(err, &errs) {
+		t.Errorf("expected a select field without options to be rejected, got %v", err)
+	}
+
+	requiredAttempt := &models.CustomFieldDefinition{Key: "room", Entity: models.CustomFieldEntityAttempt, Type: models.CustomFieldText, Required: true}
+	var ruleErr *BusinessRuleError
+	if err := checkCustomFieldDefinition(requiredAttempt); !errors.As(err, &ruleErr) {
+		t.Errorf("expected a required attempt field to be rejected, got %v", err)
+	}
+}
+
+func TestCustomFieldExportRecord(t *testing.T) {
+	definitions := customFieldDefinitions()
+	header := customFieldExportHeader(definitions)
+	if strings.Join(header, ",") != "cf_cost_center,cf_credits,cf_external,cf_exam_date,cf_campus" {
+		t.Errorf("unexpected header %v", header)
+	}
+
+	record := customFieldExportRecord(definitions, []byte(`{"cost_center":"4100","credits":1000000,"external":false}`))
+	if strings.Join(record, ",") != "4100,1000000,false,," {
+		t.Errorf("unexpected record %v", record)
+	}
+}
@@ -54,6 +54,8 @@ var (
 	ErrAttemptTimeExpired      = errors.New("attempt time has expired")
 	ErrAttemptNotStarted       = errors.New("attempt not started")
 	ErrAttemptCannotStart      = errors.New("cannot start new attempt")
+	// Autosaves held in Redis cannot be read, so the attempt must not be closed yet
+	ErrAutosavesUnavailable = errors.New("autosaved answers are temporarily unavailable")
 
 	// Grading specific errors
 	ErrGradingNotAllowed       = errors.New("grading not allowed for this question type")
@@ -65,6 +67,16 @@ var (
 	ErrUserNotFound            = errors.New("user not found")
 	ErrInvalidRole             = errors.New("invalid user role")
 	ErrInsufficientPermissions = errors.New("insufficient permissions")
+
+	// Share link errors
+	ErrShareLinkInvalid = errors.New("share link is invalid, expired or revoked")
+
+	// Impersonation errors
+	ErrImpersonationInvalid  = errors.New("impersonation session is invalid or has ended")
+	ErrImpersonationReadOnly = errors.New("impersonation session is read-only")
+
+	// Data residency errors
+	ErrResidencyUnavailable = errors.New("data residency region is unavailable")
 )
 
 // ===== CUSTOM ERROR TYPES =====
@@ -96,6 +108,16 @@ func (pe *PermissionError) Error() string {
 		pe.UserID, pe.Action, pe.Resource, pe.ResourceID, pe.Reason)
 }
 
+// ContentLockedError refuses a content change while attempts are in progress on the assessments
+type ContentLockedError struct {
+	AssessmentIDs      []uint `json:"assessment_ids"`
+	AttemptsInProgress int    `json:"attempts_in_progress"`
+}
+
+func (cle *ContentLockedError) Error() string {
+	return fmt.Sprintf("content locked: %d attempts in progress on assessments %v", cle.AttemptsInProgress, cle.AssessmentIDs)
+}
+
 // ===== ERROR HELPERS =====
 
 // NewValidationError creates a new validation error using the shared type
@@ -0,0 +1,490 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultExtensionQueueSize = 20
+	maxExtensionQueueSize     = 100
+)
+
+// extensionAttachmentContentTypes maps accepted supporting document extensions to their content type
+var extensionAttachmentContentTypes = map[string]string{
+	".pdf":  "application/pdf",
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+}
+
+type extensionService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+	storage   StorageDirs
+	notifier  NotificationEventService // nil disables notifications
+}
+
+func NewExtensionService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, storage StorageDirs, notifier NotificationEventService) ExtensionService {
+	return &extensionService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+		storage:   storage,
+		notifier:  notifier,
+	}
+}
+
+// ===== STUDENT REQUESTS =====
+
+func (s *extensionService) RequestExtension(ctx context.Context, assessmentID uint, req *CreateExtensionRequest, attachment *ExtensionAttachment, studentID string) (*models.ExtensionRequest, error) {
+	s.logger.Info("Requesting extension",
+		"assessment_id", assessmentID,
+		"kind", req.Kind,
+		"student_id", studentID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.User().GetByID(ctx, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleStudent {
+		return nil, NewPermissionError(studentID, assessmentID, "assessment", "request_extension", "only students can request extensions")
+	}
+
+	assessment, err := s.repo.Assessment().GetByID(ctx, s.db, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	if assessment.Status != models.StatusActive {
+		return nil, NewPermissionError(studentID, assessmentID, "assessment", "request_extension", "assessment is not active")
+	}
+
+	terms, err := resolveAttemptTerms(ctx, s.repo, s.db, assessment, studentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkExtensionRequest(req, terms); err != nil {
+		return nil, err
+	}
+
+	pending, err := s.repo.Extension().HasPending(ctx, nil, assessmentID, studentID, req.Kind)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, NewBusinessRuleError("extension_pending", "an extension request of this kind is already waiting for a decision", map[string]interface{}{
+			"assessment_id": assessmentID,
+			"kind":          req.Kind,
+		})
+	}
+
+	request := &models.ExtensionRequest{
+		AssessmentID: assessmentID,
+		StudentID:    studentID,
+		Kind:         req.Kind,
+		Status:       models.ExtensionPending,
+		Reason:       strings.TrimSpace(req.Reason),
+	}
+	if req.Kind == models.ExtensionDeadline {
+		request.RequestedDueDate = req.RequestedDueDate
+	}
+
+	if attachment != nil {
+		if err := s.storeAttachment(ctx, request, attachment); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Extension().Create(ctx, nil, request); err != nil {
+		if request.AttachmentPath != nil {
+			os.Remove(*request.AttachmentPath)
+		}
+		return nil, err
+	}
+
+	if s.notifier != nil {
+		if err := s.notifier.NotifyExtensionRequested(ctx, request); err != nil {
+			s.logger.Error("Failed to notify extension request", "request_id", request.ID, "error", err)
+		}
+	}
+
+	return request, nil
+}
+
+func (s *extensionService) ListMyRequests(ctx context.Context, studentID string) ([]*models.ExtensionRequest, error) {
+	requests, _, err := s.repo.Extension().List(ctx, nil, repositories.ExtensionRequestFilter{StudentID: studentID})
+	if err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+// Withdraw cancels a request that has not been decided yet
+func (s *extensionService) Withdraw(ctx context.Context, id uint, studentID string) (*models.ExtensionRequest, error) {
+	s.logger.Info("Withdrawing extension request", "request_id", id, "student_id", studentID)
+
+	request, err := s.getRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if request.StudentID != studentID {
+		return nil, NewPermissionError(studentID, id, "extension_request", "withdraw", "not the student's request")
+	}
+	if err := checkExtensionPending(request); err != nil {
+		return nil, err
+	}
+
+	request.Status = models.ExtensionWithdrawn
+	if err := s.repo.Extension().Update(ctx, nil, request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// ===== TEACHER QUEUE =====
+
+// GetQueue lists requests for the teacher's own assessments, or for all assessments to admins,
+// oldest first so they are decided in the order they came in
+func (s *extensionService) GetQueue(ctx context.Context, filter *ExtensionQueueFilter, userID string) (*ExtensionRequestListResponse, error) {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleTeacher && user.Role != models.RoleAdmin {
+		return nil, NewPermissionError(userID, 0, "extension_request", "list", "insufficient role permissions")
+	}
+
+	page, size := filter.Page, filter.Size
+	if page < 1 {
+		page = 1
+	}
+	if size < 1 {
+		size = defaultExtensionQueueSize
+	}
+	if size > maxExtensionQueueSize {
+		size = maxExtensionQueueSize
+	}
+
+	repoFilter := repositories.ExtensionRequestFilter{
+		AssessmentID: filter.AssessmentID,
+		Status:       filter.Status,
+		Limit:        size,
+		Offset:       (page - 1) * size,
+	}
+	if repoFilter.Status == "" {
+		repoFilter.Status = models.ExtensionPending
+	}
+	if user.Role != models.RoleAdmin {
+		repoFilter.CreatorID = userID
+	}
+
+	requests, total, err := s.repo.Extension().List(ctx, nil, repoFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExtensionRequestListResponse{
+		Requests: requests,
+		Total:    total,
+		Page:     page,
+		Size:     size,
+	}, nil
+}
+
+// Approve grants the request as an accommodation for the student. A deadline request is granted
+// the requested due date unless the teacher gives another.
+func (s *extensionService) Approve(ctx context.Context, id uint, req *ApproveExtensionRequest, userID string) (*models.ExtensionRequest, error) {
+	s.logger.Info("Approving extension request", "request_id", id, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	request, assessment, err := s.getDecidableRequest(ctx, id, userID, "approve")
+	if err != nil {
+		return nil, err
+	}
+
+	terms, err := resolveAttemptTerms(ctx, s.repo, s.db, assessment, request.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	accommodation, err := buildAccommodation(request, req, terms, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.Extension().CreateAccommodation(ctx, tx, accommodation); err != nil {
+			return err
+		}
+
+		request.Status = models.ExtensionApproved
+		request.DecidedBy = &userID
+		request.DecidedAt = &now
+		request.DecisionNote = req.Note
+		request.AccommodationID = &accommodation.ID
+		return s.repo.Extension().Update(ctx, tx, request)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyDecided(ctx, request, accommodation)
+
+	return request, nil
+}
+
+func (s *extensionService) Deny(ctx context.Context, id uint, req *DenyExtensionRequest, userID string) (*models.ExtensionRequest, error) {
+	s.logger.Info("Denying extension request", "request_id", id, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	request, _, err := s.getDecidableRequest(ctx, id, userID, "deny")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	note := strings.TrimSpace(req.Note)
+	request.Status = models.ExtensionDenied
+	request.DecidedBy = &userID
+	request.DecidedAt = &now
+	request.DecisionNote = &note
+	if err := s.repo.Extension().Update(ctx, nil, request); err != nil {
+		return nil, err
+	}
+
+	s.notifyDecided(ctx, request, nil)
+
+	return request, nil
+}
+
+func (s *extensionService) ListAccommodations(ctx context.Context, assessmentID uint, userID string) ([]*models.StudentAccommodation, error) {
+	if _, err := s.getManagedAssessment(ctx, assessmentID, userID, "view_accommodations"); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Extension().GetAccommodationsByAssessment(ctx, nil, assessmentID)
+}
+
+// ===== SHARED =====
+
+func (s *extensionService) GetRequest(ctx context.Context, id uint, userID string) (*models.ExtensionRequest, error) {
+	request, err := s.getRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRequestAccess(ctx, request, userID, "view"); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *extensionService) GetAttachment(ctx context.Context, id uint, userID string) (*models.ExtensionRequest, error) {
+	request, err := s.GetRequest(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if request.AttachmentPath == nil {
+		return nil, ErrNotFound
+	}
+	return request, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (s *extensionService) getRequest(ctx context.Context, id uint) (*models.ExtensionRequest, error) {
+	request, err := s.repo.Extension().GetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get extension request: %w", err)
+	}
+	return request, nil
+}
+
+// getDecidableRequest loads a pending request the user may decide on, with its assessment
+func (s *extensionService) getDecidableRequest(ctx context.Context, id uint, userID, action string) (*models.ExtensionRequest, *models.Assessment, error) {
+	request, err := s.getRequest(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	assessment, err := s.getManagedAssessment(ctx, request.AssessmentID, userID, action+"_extension")
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := checkExtensionPending(request); err != nil {
+		return nil, nil, err
+	}
+	return request, assessment, nil
+}
+
+// checkRequestAccess lets the student who asked, the assessment's teacher and admins see a request
+func (s *extensionService) checkRequestAccess(ctx context.Context, request *models.ExtensionRequest, userID, action string) error {
+	if request.StudentID == userID {
+		return nil
+	}
+	_, err := s.getManagedAssessment(ctx, request.AssessmentID, userID, action+"_extension")
+	return err
+}
+
+// getManagedAssessment returns the assessment if the user created it or is an admin
+func (s *extensionService) getManagedAssessment(ctx context.Context, assessmentID uint, userID, action string) (*models.Assessment, error) {
+	assessment, err := s.repo.Assessment().GetByID(ctx, s.db, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleAdmin && (user.Role != models.RoleTeacher || assessment.CreatedBy != userID) {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", action, "not owner or insufficient permissions")
+	}
+
+	return assessment, nil
+}
+
+func (s *extensionService) storeAttachment(ctx context.Context, request *models.ExtensionRequest, attachment *ExtensionAttachment) error {
+	ext, contentType, err := extensionAttachmentContentType(attachment.FileName)
+	if err != nil {
+		return err
+	}
+
+	storageDir, err := s.storage.For(ctx)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(storageDir, "extension-requests")
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create attachment storage: %w", err)
+	}
+	path := filepath.Join(dir, uuid.NewString()+ext)
+	size, err := storeAttachmentFile(path, attachment.File)
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Base(attachment.FileName)
+	request.AttachmentName = &name
+	request.AttachmentType = &contentType
+	request.AttachmentSize = size
+	request.AttachmentPath = &path
+	return nil
+}
+
+func (s *extensionService) notifyDecided(ctx context.Context, request *models.ExtensionRequest, accommodation *models.StudentAccommodation) {
+	if s.notifier == nil {
+		return
+	}
+	if err := s.notifier.NotifyExtensionDecided(ctx, request, accommodation); err != nil {
+		s.logger.Error("Failed to notify extension decision", "request_id", request.ID, "error", err)
+	}
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// checkExtensionRequest checks a request against the student's current terms: a deadline
+// request needs a due date to move and asks for a later one
+func checkExtensionRequest(req *CreateExtensionRequest, terms *StudentAttemptTerms) error {
+	switch req.Kind {
+	case models.ExtensionDeadline:
+		if terms.DueDate == nil {
+			return NewBusinessRuleError("no_due_date", "the assessment has no due date to extend", nil)
+		}
+		if req.RequestedDueDate == nil {
+			return ValidationErrors{*NewValidationError("requested_due_date", "is required for a deadline extension", nil)}
+		}
+		if !req.RequestedDueDate.After(*terms.DueDate) {
+			return ValidationErrors{*NewValidationError("requested_due_date", "must be after the current due date", req.RequestedDueDate)}
+		}
+	case models.ExtensionExtraAttempt:
+		if req.RequestedDueDate != nil {
+			return ValidationErrors{*NewValidationError("requested_due_date", "only applies to deadline extensions", req.RequestedDueDate)}
+		}
+	}
+	return nil
+}
+
+func checkExtensionPending(request *models.ExtensionRequest) error {
+	if request.Status != models.ExtensionPending {
+		return NewBusinessRuleError("extension_not_pending", "the extension request has already been "+string(request.Status), map[string]interface{}{
+			"request_id": request.ID,
+			"status":     request.Status,
+		})
+	}
+	return nil
+}
+
+// buildAccommodation is what approving a request grants: the due date the teacher chose or the
+// one requested, which must still be later than the student's current one, or one extra attempt
+func buildAccommodation(request *models.ExtensionRequest, req *ApproveExtensionRequest, terms *StudentAttemptTerms, grantedBy string) (*models.StudentAccommodation, error) {
+	accommodation := &models.StudentAccommodation{
+		AssessmentID:       request.AssessmentID,
+		StudentID:          request.StudentID,
+		ExtensionRequestID: &request.ID,
+		GrantedBy:          grantedBy,
+	}
+
+	switch request.Kind {
+	case models.ExtensionDeadline:
+		dueDate := request.RequestedDueDate
+		if req.DueDate != nil {
+			dueDate = req.DueDate
+		}
+		if dueDate == nil {
+			return nil, ValidationErrors{*NewValidationError("due_date", "is required", nil)}
+		}
+		if terms.DueDate != nil && !dueDate.After(*terms.DueDate) {
+			return nil, ValidationErrors{*NewValidationError("due_date", "must be after the student's current due date", dueDate)}
+		}
+		accommodation.DueDate = dueDate
+	case models.ExtensionExtraAttempt:
+		if req.DueDate != nil {
+			return nil, ValidationErrors{*NewValidationError("due_date", "only applies to deadline extensions", req.DueDate)}
+		}
+		accommodation.ExtraAttempts = 1
+	}
+
+	return accommodation, nil
+}
+
+func extensionAttachmentContentType(filename string) (string, string, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	contentType, ok := extensionAttachmentContentTypes[ext]
+	if !ok {
+		return "", "", NewValidationError("file", "unsupported file format; upload a PDF, JPEG or PNG file", ext)
+	}
+	return ext, contentType, nil
+}
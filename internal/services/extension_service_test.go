@@ -0,0 +1,111 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestApplyAccommodations(t *testing.T) {
+	due := time.Date(2025, 6, 1, 23, 59, 0, 0, time.UTC)
+	until := due.Add(time.Hour)
+	later := due.AddDate(0, 0, 3)
+	latest := due.AddDate(0, 0, 7)
+
+	terms := &StudentAttemptTerms{DueDate: &due, AvailableUntil: &until, MaxAttempts: 1}
+	applyAccommodations(terms, []*models.StudentAccommodation{
+		{DueDate: &latest},
+		{ExtraAttempts: 1},
+		{DueDate: &later},
+	})
+	if !terms.DueDate.Equal(latest) {
+		t.Errorf("expected the latest granted due date, got %v", terms.DueDate)
+	}
+	if !terms.AvailableUntil.Equal(latest) {
+		t.Errorf("expected the window to stay open until the new due date, got %v", terms.AvailableUntil)
+	}
+	if terms.MaxAttempts != 2 {
+		t.Errorf("expected one extra attempt, got %d attempts", terms.MaxAttempts)
+	}
+
+	open := &StudentAttemptTerms{MaxAttempts: 1}
+	applyAccommodations(open, []*models.StudentAccommodation{{DueDate: &later}})
+	if open.DueDate != nil {
+		t.Errorf("an assessment without a due date should not get one, got %v", open.DueDate)
+	}
+}
+
+func TestCheckExtensionRequest(t *testing.T) {
+	due := time.Date(2025, 6, 1, 23, 59, 0, 0, time.UTC)
+	later := due.AddDate(0, 0, 2)
+	earlier := due.AddDate(0, 0, -1)
+	terms := &StudentAttemptTerms{DueDate: &due, MaxAttempts: 1}
+
+	if err := checkExtensionRequest(&CreateExtensionRequest{Kind: models.ExtensionDeadline, RequestedDueDate: &later}, terms); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := checkExtensionRequest(&CreateExtensionRequest{Kind: models.ExtensionExtraAttempt}, terms); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := map[string]*CreateExtensionRequest{
+		"missing date":          {Kind: models.ExtensionDeadline},
+		"earlier date":          {Kind: models.ExtensionDeadline, RequestedDueDate: &earlier},
+		"same date":             {Kind: models.ExtensionDeadline, RequestedDueDate: &due},
+		"date on extra attempt": {Kind: models.ExtensionExtraAttempt, RequestedDueDate: &later},
+	}
+	for name, req := range invalid {
+		var validationErrors ValidationErrors
+		if err := checkExtensionRequest(req, terms); !errors.As(err, &validationErrors) {
+			t.Errorf("%s: expected a validation error, got %v", name, err)
+		}
+	}
+
+	var businessRuleError *BusinessRuleError
+	err := checkExtensionRequest(&CreateExtensionRequest{Kind: models.ExtensionDeadline, RequestedDueDate: &later}, &StudentAttemptTerms{MaxAttempts: 1})
+	if !errors.As(err, &businessRuleError) || businessRuleError.Rule != "no_due_date" {
+		t.Errorf("expected a deadline request without a due date to be refused, got %v", err)
+	}
+}
+
+func TestBuildAccommodation(t *testing.T) {
+	due := time.Date(2025, 6, 1, 23, 59, 0, 0, time.UTC)
+	requested := due.AddDate(0, 0, 7)
+	granted := due.AddDate(0, 0, 3)
+	terms := &StudentAttemptTerms{DueDate: &due, MaxAttempts: 1}
+	deadline := &models.ExtensionRequest{ID: 4, AssessmentID: 1, StudentID: "s1", Kind: models.ExtensionDeadline, RequestedDueDate: &requested}
+
+	accommodation, err := buildAccommodation(deadline, &ApproveExtensionRequest{}, terms, "t1")
+	if err != nil || !accommodation.DueDate.Equal(requested) || *accommodation.ExtensionRequestID != 4 || accommodation.GrantedBy != "t1" {
+		t.Errorf("expected the requested due date to be granted, got %+v (%v)", accommodation, err)
+	}
+
+	accommodation, err = buildAccommodation(deadline, &ApproveExtensionRequest{DueDate: &granted}, terms, "t1")
+	if err != nil || !accommodation.DueDate.Equal(granted) {
+		t.Errorf("expected the teacher's due date to be granted, got %+v (%v)", accommodation, err)
+	}
+
+	if _, err := buildAccommodation(deadline, &ApproveExtensionRequest{}, &StudentAttemptTerms{DueDate: &requested}, "t1"); err == nil {
+		t.Error("expected a due date no later than the student's current one to be rejected")
+	}
+
+	extra := &models.ExtensionRequest{ID: 5, Kind: models.ExtensionExtraAttempt}
+	accommodation, err = buildAccommodation(extra, &ApproveExtensionRequest{}, terms, "t1")
+	if err != nil || accommodation.ExtraAttempts != 1 || accommodation.DueDate != nil {
+		t.Errorf("expected one extra attempt, got %+v (%v)", accommodation, err)
+	}
+	if _, err := buildAccommodation(extra, &ApproveExtensionRequest{DueDate: &granted}, terms, "t1"); err == nil {
+		t.Error("expected a due date on an extra attempt to be rejected")
+	}
+}
+
+func TestExtensionAttachmentContentType(t *testing.T) {
+	if ext, contentType, err := extensionAttachmentContentType("Doctor's Note.PDF"); err != nil || ext != ".pdf" || contentType != "application/pdf" {
+		t.Errorf("unexpected result %s %s %v", ext, contentType, err)
+	}
+	if _, _, err := extensionAttachmentContentType("note.exe"); err == nil {
+		t.Error("expected an executable to be rejected")
+	}
+}
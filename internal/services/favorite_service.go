@@ -0,0 +1,384 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+type favoriteService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewFavoriteService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) FavoriteService {
+	return &favoriteService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// ===== STARRED ITEMS =====
+
+func (s *favoriteService) AddFavorite(ctx context.Context, itemType models.FavoriteItemType, itemID uint, req *AddFavoriteRequest, userID string) (*models.Favorite, error) {
+	s.logger.Info("Adding favorite", "item_type", itemType, "item_id", itemID, "user_id", userID)
+
+	if err := validateFavoriteItemType(itemType); err != nil {
+		return nil, err
+	}
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if err := s.checkItemAccess(ctx, itemType, itemID, userID); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.repo.Favorite().Exists(ctx, nil, userID, itemType, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, NewBusinessRuleError("favorite_exists", "item is already in favorites", map[string]interface{}{
+			"item_type": itemType,
+			"item_id":   itemID,
+		})
+	}
+
+	favorite := &models.Favorite{
+		UserID:   userID,
+		ItemType: itemType,
+		ItemID:   itemID,
+		Note:     req.Note,
+	}
+	if err := s.repo.Favorite().Create(ctx, nil, favorite); err != nil {
+		return nil, err
+	}
+
+	return favorite, nil
+}
+
+func (s *favoriteService) RemoveFavorite(ctx context.Context, itemType models.FavoriteItemType, itemID uint, userID string) error {
+	s.logger.Info("Removing favorite", "item_type", itemType, "item_id", itemID, "user_id", userID)
+
+	if err := validateFavoriteItemType(itemType); err != nil {
+		return err
+	}
+
+	if err := s.repo.Favorite().Delete(ctx, nil, userID, itemType, itemID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *favoriteService) ListFavorites(ctx context.Context, itemType *models.FavoriteItemType, userID string) (*FavoriteList, error) {
+	if itemType != nil {
+		if err := validateFavoriteItemType(*itemType); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := s.repo.Favorite().GetEntries(ctx, nil, userID, itemType)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildFavoriteList(userID, entries), nil
+}
+
+// ===== SHARING =====
+
+func (s *favoriteService) ShareFavorites(ctx context.Context, req *ShareFavoritesRequest, userID string) (*models.FavoriteListShare, error) {
+	s.logger.Info("Sharing favorites", "shared_with", req.UserID, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	colleague, err := s.repo.User().GetByID(ctx, req.UserID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if err := checkFavoriteShare(userID, colleague); err != nil {
+		return nil, err
+	}
+
+	shared, err := s.repo.Favorite().IsShared(ctx, nil, userID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if shared {
+		return nil, NewBusinessRuleError("favorites_already_shared", "favorites are already shared with this user", map[string]interface{}{
+			"user_id": req.UserID,
+		})
+	}
+
+	share := &models.FavoriteListShare{
+		OwnerID:    userID,
+		SharedWith: req.UserID,
+	}
+	if err := s.repo.Favorite().Share(ctx, nil, share); err != nil {
+		return nil, err
+	}
+
+	return share, nil
+}
+
+func (s *favoriteService) UnshareFavorites(ctx context.Context, sharedWith string, userID string) error {
+	s.logger.Info("Unsharing favorites", "shared_with", sharedWith, "user_id", userID)
+
+	if err := s.repo.Favorite().Unshare(ctx, nil, userID, sharedWith); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *favoriteService) ListShares(ctx context.Context, userID string) ([]*models.FavoriteListShare, error) {
+	return s.repo.Favorite().GetSharesByOwner(ctx, nil, userID)
+}
+
+func (s *favoriteService) ListSharedWithMe(ctx context.Context, userID string) ([]*models.FavoriteListShare, error) {
+	return s.repo.Favorite().GetSharedWith(ctx, nil, userID)
+}
+
+// GetSharedFavorites lists a colleague's favorites, leaving out the items the viewer may not see
+func (s *favoriteService) GetSharedFavorites(ctx context.Context, ownerID string, itemType *models.FavoriteItemType, userID string) (*FavoriteList, error) {
+	if ownerID == userID {
+		return s.ListFavorites(ctx, itemType, ownerID)
+	}
+
+	shared, err := s.repo.Favorite().IsShared(ctx, nil, ownerID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !shared {
+		return nil, NewPermissionError(userID, 0, "favorite_list", "view", "favorites not shared with user")
+	}
+
+	list, err := s.ListFavorites(ctx, itemType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	visible, err := s.visibleSharedEntries(ctx, list.Items, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildFavoriteList(ownerID, visible), nil
+}
+
+// ===== HELPER METHODS =====
+
+// checkItemAccess makes sure the starred item exists and the user may see it
+func (s *favoriteService) checkItemAccess(ctx context.Context, itemType models.FavoriteItemType, itemID uint, userID string) error {
+	var canAccess bool
+
+	switch itemType {
+	case models.FavoriteQuestion:
+		if _, err := s.repo.Question().GetByID(ctx, nil, itemID); err != nil {
+			if repositories.IsNotFoundError(err) {
+				return ErrQuestionNotFound
+			}
+			return fmt.Errorf("failed to get question: %w", err)
+		}
+		allowed, err := NewQuestionService(s.repo, s.db, s.logger, s.validator).CanAccess(ctx, itemID, userID)
+		if err != nil {
+			return err
+		}
+		canAccess = allowed
+	case models.FavoriteAssessment:
+		if _, err := s.repo.Assessment().GetByID(ctx, nil, itemID); err != nil {
+			if repositories.IsNotFoundError(err) {
+				return ErrAssessmentNotFound
+			}
+			return fmt.Errorf("failed to get assessment: %w", err)
+		}
+		allowed, err := NewAssessmentService(s.repo, s.db, s.logger, s.validator).CanAccess(ctx, itemID, userID)
+		if err != nil {
+			return err
+		}
+		canAccess = allowed
+	}
+
+	if !canAccess {
+		return NewPermissionError(userID, itemID, string(itemType), "favorite", "not owner or insufficient permissions")
+	}
+	return nil
+}
+
+// visibleSharedEntries keeps the entries of a shared list the viewer may see. Questions are
+// visible to their creator, or through a bank the viewer can open; see bankRevealsQuestion.
+func (s *favoriteService) visibleSharedEntries(ctx context.Context, entries []repositories.FavoriteEntry, viewerID string) ([]repositories.FavoriteEntry, error) {
+	viewer, err := s.repo.User().GetByID(ctx, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if viewer.Role == models.RoleAdmin {
+		return entries, nil
+	}
+
+	var questionIDs []uint
+	for _, entry := range entries {
+		if entry.ItemType == models.FavoriteQuestion {
+			questionIDs = append(questionIDs, entry.ItemID)
+		}
+	}
+	questionBanks, err := s.repo.QuestionBank().GetQuestionBankIDs(ctx, nil, questionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question banks: %w", err)
+	}
+
+	assessments := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	revealing := make(map[uint]bool)
+	visible := make([]repositories.FavoriteEntry, 0, len(entries))
+	for _, entry := range entries {
+		var allowed bool
+		switch entry.ItemType {
+		case models.FavoriteQuestion:
+			allowed, err = s.canViewSharedQuestion(ctx, entry.ItemID, questionBanks[entry.ItemID], viewerID, revealing)
+		case models.FavoriteAssessment:
+			allowed, err = assessments.CanAccess(ctx, entry.ItemID, viewerID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			visible = append(visible, entry)
+		}
+	}
+
+	return visible, nil
+}
+
+// canViewSharedQuestion reports whether the viewer may see a question from someone else's
+// list; revealing caches the verdict per bank across the list
+func (s *favoriteService) canViewSharedQuestion(ctx context.Context, questionID uint, bankIDs []uint, viewerID string, revealing map[uint]bool) (bool, error) {
+	question, err := s.repo.Question().GetByID(ctx, nil, questionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get question: %w", err)
+	}
+	if question.CreatedBy == viewerID {
+		return true, nil
+	}
+	if question.IsDraft {
+		return false, nil
+	}
+
+	for _, bankID := range bankIDs {
+		reveals, known := revealing[bankID]
+		if !known {
+			if reveals, err = s.bankRevealsTo(ctx, bankID, viewerID); err != nil {
+				return false, err
+			}
+			revealing[bankID] = reveals
+		}
+		if reveals {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// bankRevealsTo looks up what bankRevealsQuestion needs to know about the bank and viewer
+func (s *favoriteService) bankRevealsTo(ctx context.Context, bankID uint, viewerID string) (bool, error) {
+	bank, err := s.repo.QuestionBank().GetByID(ctx, nil, bankID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get question bank: %w", err)
+	}
+	canAccess, err := s.repo.QuestionBank().CanAccess(ctx, nil, bankID, viewerID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check question bank access: %w", err)
+	}
+
+	sharedWithViewer := false
+	if canAccess && bank.CreatedBy != viewerID && bankIsLicensed(bank) {
+		shares, err := s.repo.QuestionBank().GetBankShares(ctx, nil, bankID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get question bank shares: %w", err)
+		}
+		for _, share := range shares {
+			if share.UserID == viewerID {
+				sharedWithViewer = true
+				break
+			}
+		}
+	}
+
+	return bankRevealsQuestion(bank, viewerID, canAccess, sharedWithViewer), nil
+}
+
+// bankRevealsQuestion decides whether a bank lets the viewer see its questions on a shared
+// list. Licensed banks only count for their owner and the colleagues they are shared with,
+// since being public does not give anyone a seat.
+func bankRevealsQuestion(bank *models.QuestionBank, viewerID string, canAccess, sharedWithViewer bool) bool {
+	if !canAccess {
+		return false
+	}
+	if bank.CreatedBy == viewerID || sharedWithViewer {
+		return true
+	}
+	return !bankIsLicensed(bank)
+}
+
+// bankIsLicensed reports whether the bank carries third-party license limits
+func bankIsLicensed(bank *models.QuestionBank) bool {
+	return bank.LicenseSeats != nil || bank.LicenseExpiresAt != nil
+}
+
+func validateFavoriteItemType(itemType models.FavoriteItemType) error {
+	switch itemType {
+	case models.FavoriteQuestion, models.FavoriteAssessment:
+		return nil
+	}
+	return NewValidationError("item_type", "item type must be question or assessment", itemType)
+}
+
+// checkFavoriteShare allows sharing favorites only with other teachers and admins
+func checkFavoriteShare(ownerID string, colleague *models.User) error {
+	if colleague.ID == ownerID {
+		return NewBusinessRuleError("favorites_share_self", "favorites cannot be shared with yourself", nil)
+	}
+	if colleague.Role != models.RoleTeacher && colleague.Role != models.RoleAdmin {
+		return NewBusinessRuleError("favorites_share_role", "favorites can only be shared with teachers", map[string]interface{}{
+			"user_id": colleague.ID,
+			"role":    colleague.Role,
+		})
+	}
+	return nil
+}
+
+func buildFavoriteList(ownerID string, entries []repositories.FavoriteEntry) *FavoriteList {
+	if entries == nil {
+		entries = []repositories.FavoriteEntry{}
+	}
+	return &FavoriteList{
+		OwnerID: ownerID,
+		Items:   entries,
+		Total:   len(entries),
+	}
+}
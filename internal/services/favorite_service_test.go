@@ -0,0 +1,67 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestValidateFavoriteItemType(t *testing.T) {
+	for _, itemType := range []models.FavoriteItemType{models.FavoriteQuestion, models.FavoriteAssessment} {
+		if err := validateFavoriteItemType(itemType); err != nil {
+			t.Errorf("%s should be accepted, got %v", itemType, err)
+		}
+	}
+	if err := validateFavoriteItemType("question_bank"); err == nil {
+		t.Error("unknown item type should be rejected")
+	}
+}
+
+func TestCheckFavoriteShare(t *testing.T) {
+	if err := checkFavoriteShare("teacher-1", &models.User{ID: "teacher-2", Role: models.RoleTeacher}); err != nil {
+		t.Errorf("sharing with a teacher should be allowed, got %v", err)
+	}
+	if err := checkFavoriteShare("teacher-1", &models.User{ID: "teacher-1", Role: models.RoleTeacher}); !IsBusinessRule(err) {
+		t.Errorf("sharing with yourself should be rejected, got %v", err)
+	}
+	if err := checkFavoriteShare("teacher-1", &models.User{ID: "student-1", Role: models.RoleStudent}); !IsBusinessRule(err) {
+		t.Errorf("sharing with a student should be rejected, got %v", err)
+	}
+}
+
+func TestBuildFavoriteList(t *testing.T) {
+	list := buildFavoriteList("teacher-1", nil)
+	if list.Items == nil || list.Total != 0 || list.OwnerID != "teacher-1" {
+		t.Errorf("empty list should serialize as an empty array, got %+v", list)
+	}
+}
+
+func TestBankRevealsQuestion(t *testing.T) {
+	seats := 30
+	expires := time.Now().AddDate(1, 0, 0)
+	public := &models.QuestionBank{CreatedBy: "teacher-1", IsPublic: true}
+	licensed := &models.QuestionBank{CreatedBy: "teacher-1", IsPublic: true, LicenseSeats: &seats}
+	expiring := &models.QuestionBank{CreatedBy: "teacher-1", IsPublic: true, LicenseExpiresAt: &expires}
+
+	tests := []struct {
+		name      string
+		bank      *models.QuestionBank
+		viewer    string
+		canAccess bool
+		shared    bool
+		want      bool
+	}{
+		{"inaccessible bank", &models.QuestionBank{CreatedBy: "teacher-1"}, "teacher-2", false, false, false},
+		{"public bank", public, "teacher-2", true, false, true},
+		{"public licensed bank", licensed, "teacher-2", true, false, false},
+		{"public bank with an expiring license", expiring, "teacher-2", true, false, false},
+		{"licensed bank shared with the viewer", licensed, "teacher-2", true, true, true},
+		{"licensed bank owned by the viewer", licensed, "teacher-1", true, false, true},
+	}
+	for _, tt := range tests {
+		if got := bankRevealsQuestion(tt.bank, tt.viewer, tt.canAccess, tt.shared); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+const (
+	googleClassroomAPIBase  = "https://classroom.googleapis.com/v1"
+	gradebookErrorBodyLimit = 500
+)
+
+// GradebookClient writes a grade to an external gradebook
+type GradebookClient interface {
+	PushGrade(ctx context.Context, integration *models.GradebookIntegration, grade *GradebookGrade) error
+}
+
+// permanentGradebookError is a rejection that retrying will not fix, such as an unknown
+// student or a revoked token
+type permanentGradebookError struct {
+	err error
+}
+
+func (e *permanentGradebookError) Error() string { return e.err.Error() }
+func (e *permanentGradebookError) Unwrap() error { return e.err }
+
+func isPermanentGradebookError(err error) bool {
+	var permanent *permanentGradebookError
+	return errors.As(err, &permanent)
+}
+
+// NewGradebookClients returns a client per supported provider
+func NewGradebookClients(httpClient *http.Client) map[models.GradebookProvider]GradebookClient {
+	return map[models.GradebookProvider]GradebookClient{
+		models.GradebookProviderREST:            &restGradebookClient{http: httpClient},
+		models.GradebookProviderGoogleClassroom: &googleClassroomClient{http: httpClient},
+	}
+}
+
+// ===== GENERIC REST =====
+
+// restGradebookClient posts the grade as JSON to the integration's endpoint
+type restGradebookClient struct {
+	http *http.Client
+}
+
+func (c *restGradebookClient) PushGrade(ctx context.Context, integration *models.GradebookIntegration, grade *GradebookGrade) error {
+	return doGradebookRequest(ctx, c.http, http.MethodPost, integration.Endpoint, integration.AuthToken, grade, nil)
+}
+
+// ===== GOOGLE CLASSROOM =====
+
+// googleClassroomClient sets the assigned grade on the student's submission for the
+// course work mapped as the integration's column
+type googleClassroomClient struct {
+	http *http.Client
+}
+
+type classroomSubmissions struct {
+	StudentSubmissions []struct {
+		ID string `json:"id"`
+	} `json:"studentSubmissions"`
+}
+
+func (c *googleClassroomClient) PushGrade(ctx context.Context, integration *models.GradebookIntegration, grade *GradebookGrade) error {
+	base := googleClassroomAPIBase
+	if integration.Endpoint != "" {
+		base = strings.TrimRight(integration.Endpoint, "/")
+	}
+	submissionsURL := fmt.Sprintf("%s/courses/%s/courseWork/%s/studentSubmissions",
+		base, url.PathEscape(integration.CourseID), url.PathEscape(integration.ColumnID))
+
+	var submissions classroomSubmissions
+	query := submissionsURL + "?userId=" + url.QueryEscape(grade.StudentKey)
+	if err := doGradebookRequest(ctx, c.http, http.MethodGet, query, integration.AuthToken, nil, &submissions); err != nil {
+		return err
+	}
+	if len(submissions.StudentSubmissions) == 0 {
+		return &permanentGradebookError{err: fmt.Errorf("no classroom submission for student %s", grade.StudentKey)}
+	}
+
+	update := map[string]float64{
+		"assignedGrade": grade.Score,
+		"draftGrade":    grade.Score,
+	}
+	patchURL := fmt.Sprintf("%s/%s?updateMask=assignedGrade,draftGrade",
+		submissionsURL, url.PathEscape(submissions.StudentSubmissions[0].ID))
+	return doGradebookRequest(ctx, c.http, http.MethodPatch, patchURL, integration.AuthToken, update, nil)
+}
+
+// ===== HELPER METHODS =====
+
+// doGradebookRequest sends body as JSON and decodes the response into out when given.
+// Client errors other than rate limiting are reported as permanent.
+func doGradebookRequest(ctx context.Context, client *http.Client, method, target, token string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode gradebook request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return &permanentGradebookError{err: fmt.Errorf("invalid gradebook request: %w", err)}
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach gradebook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, gradebookErrorBodyLimit))
+		err := fmt.Errorf("gradebook returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			return &permanentGradebookError{err: err}
+		}
+		return err
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode gradebook response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,541 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+const (
+	// A grade is pushed this many times before its passback moves to the dead letter queue
+	passbackMaxAttempts = 6
+	passbackRetryBase   = time.Minute
+	passbackRetryMax    = time.Hour
+	// A passback processing for this long is treated as orphaned by a crash
+	passbackStaleAfter = 5 * time.Minute
+	passbackBatchSize  = 50
+	passbackTimeout    = 30 * time.Second
+	passbackListLimit  = 200
+)
+
+type gradebookService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+	clients   map[models.GradebookProvider]GradebookClient
+}
+
+func NewGradebookService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, clients map[models.GradebookProvider]GradebookClient) GradebookService {
+	if clients == nil {
+		clients = NewGradebookClients(&http.Client{Timeout: passbackTimeout})
+	}
+	return &gradebookService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+		clients:   clients,
+	}
+}
+
+// ===== INTEGRATIONS =====
+
+func (s *gradebookService) CreateIntegration(ctx context.Context, assessmentID uint, req *CreateGradebookIntegrationRequest, userID string) (*models.GradebookIntegration, error) {
+	s.logger.Info("Creating gradebook integration",
+		"assessment_id", assessmentID,
+		"provider", req.Provider,
+		"user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccess(ctx, assessmentID, userID, "manage_gradebook"); err != nil {
+		return nil, err
+	}
+
+	integration := &models.GradebookIntegration{
+		AssessmentID: assessmentID,
+		Provider:     req.Provider,
+		Name:         strings.TrimSpace(req.Name),
+		Enabled:      true,
+		Endpoint:     strings.TrimSpace(req.Endpoint),
+		AuthToken:    req.AuthToken,
+		CourseID:     strings.TrimSpace(req.CourseID),
+		ColumnID:     strings.TrimSpace(req.ColumnID),
+		StudentKey:   req.StudentKey,
+		ScoreField:   req.ScoreField,
+		MaxPoints:    req.MaxPoints,
+		CreatedBy:    userID,
+	}
+	if integration.StudentKey == "" {
+		integration.StudentKey = models.GradebookStudentKeyID
+	}
+	if integration.ScoreField == "" {
+		integration.ScoreField = models.GradebookScoreFieldPercentage
+	}
+	if err := validateGradebookIntegration(integration); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Gradebook().CreateIntegration(ctx, nil, integration); err != nil {
+		return nil, err
+	}
+
+	return integration, nil
+}
+
+func (s *gradebookService) ListIntegrations(ctx context.Context, assessmentID uint, userID string) ([]*models.GradebookIntegration, error) {
+	if err := s.checkAccess(ctx, assessmentID, userID, "view_gradebook"); err != nil {
+		return nil, err
+	}
+	return s.repo.Gradebook().GetIntegrationsByAssessment(ctx, nil, assessmentID)
+}
+
+func (s *gradebookService) UpdateIntegration(ctx context.Context, id uint, req *UpdateGradebookIntegrationRequest, userID string) (*models.GradebookIntegration, error) {
+	s.logger.Info("Updating gradebook integration", "integration_id", id, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	integration, err := s.getIntegration(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccess(ctx, integration.AssessmentID, userID, "manage_gradebook"); err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		integration.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Enabled != nil {
+		integration.Enabled = *req.Enabled
+	}
+	if req.Endpoint != nil {
+		integration.Endpoint = strings.TrimSpace(*req.Endpoint)
+	}
+	if req.AuthToken != nil {
+		integration.AuthToken = *req.AuthToken
+	}
+	if req.CourseID != nil {
+		integration.CourseID = strings.TrimSpace(*req.CourseID)
+	}
+	if req.ColumnID != nil {
+		integration.ColumnID = strings.TrimSpace(*req.ColumnID)
+	}
+	if req.StudentKey != nil {
+		integration.StudentKey = *req.StudentKey
+	}
+	if req.ScoreField != nil {
+		integration.ScoreField = *req.ScoreField
+	}
+	if req.MaxPoints != nil {
+		integration.MaxPoints = req.MaxPoints
+		if *req.MaxPoints == 0 {
+			integration.MaxPoints = nil
+		}
+	}
+	if err := validateGradebookIntegration(integration); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Gradebook().UpdateIntegration(ctx, nil, integration); err != nil {
+		return nil, err
+	}
+
+	return integration, nil
+}
+
+func (s *gradebookService) DeleteIntegration(ctx context.Context, id uint, userID string) error {
+	s.logger.Info("Deleting gradebook integration", "integration_id", id, "user_id", userID)
+
+	integration, err := s.getIntegration(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkAccess(ctx, integration.AssessmentID, userID, "manage_gradebook"); err != nil {
+		return err
+	}
+
+	return s.repo.Gradebook().DeleteIntegration(ctx, nil, id)
+}
+
+// ===== DELIVERIES =====
+
+func (s *gradebookService) ListPassbacks(ctx context.Context, assessmentID uint, status *models.PassbackStatus, userID string) ([]*models.GradePassback, error) {
+	if err := s.checkAccess(ctx, assessmentID, userID, "view_gradebook"); err != nil {
+		return nil, err
+	}
+	return s.repo.Gradebook().ListPassbacks(ctx, nil, assessmentID, status, passbackListLimit)
+}
+
+// Resync retries dead-lettered and pending grades right away and queues released grades
+// not yet pushed; with IncludeDelivered every delivered grade is pushed again
+func (s *gradebookService) Resync(ctx context.Context, assessmentID uint, req *ResyncGradesRequest, userID string) (*ResyncGradesResult, error) {
+	s.logger.Info("Resyncing gradebook grades",
+		"assessment_id", assessmentID,
+		"include_delivered", req.IncludeDelivered,
+		"user_id", userID)
+
+	if err := s.checkAccess(ctx, assessmentID, userID, "manage_gradebook"); err != nil {
+		return nil, err
+	}
+
+	released, err := areResultsReleased(ctx, s.repo, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if !released {
+		return nil, NewBusinessRuleError("results_not_released", "grades are pushed to gradebooks once results are released", map[string]interface{}{
+			"assessment_id": assessmentID,
+		})
+	}
+
+	requeued, err := s.repo.Gradebook().RequeueByAssessment(ctx, nil, assessmentID, req.IncludeDelivered)
+	if err != nil {
+		return nil, err
+	}
+
+	integrations, err := s.repo.Gradebook().GetIntegrationsByAssessment(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	queued := 0
+	for _, integration := range integrations {
+		if !integration.Enabled {
+			continue
+		}
+		count, err := s.queueIntegration(ctx, integration, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		queued += count
+	}
+
+	return &ResyncGradesResult{
+		AssessmentID: assessmentID,
+		Requeued:     requeued,
+		Queued:       queued,
+	}, nil
+}
+
+// ===== BACKGROUND DELIVERY =====
+
+// QueueReleasedGrades queues a passback for every student with released results whose final
+// grade was not delivered yet or may have changed since, e.g. by a regrade, override or late attempt
+func (s *gradebookService) QueueReleasedGrades(ctx context.Context) (int, error) {
+	integrations, err := s.repo.Gradebook().GetEnabledIntegrations(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	released := make(map[uint]bool)
+	queued := 0
+	for _, integration := range integrations {
+		isReleased, checked := released[integration.AssessmentID]
+		if !checked {
+			isReleased, err = areResultsReleased(ctx, s.repo, integration.AssessmentID)
+			if err != nil {
+				s.logger.Error("Failed to check results release", "assessment_id", integration.AssessmentID, "error", err)
+				continue
+			}
+			released[integration.AssessmentID] = isReleased
+		}
+		if !isReleased {
+			continue
+		}
+
+		count, err := s.queueIntegration(ctx, integration, now)
+		if err != nil {
+			s.logger.Error("Failed to queue grade passbacks", "integration_id", integration.ID, "error", err)
+			continue
+		}
+		queued += count
+	}
+
+	if queued > 0 {
+		s.logger.Info("Grade passbacks queued", "passbacks", queued)
+	}
+	return queued, nil
+}
+
+// DeliverPendingGrades pushes due passbacks, including retries and ones orphaned by a crash
+func (s *gradebookService) DeliverPendingGrades(ctx context.Context, limit int) (int, error) {
+	now := time.Now()
+	ids, err := s.repo.Gradebook().GetDuePassbacks(ctx, nil, now, now.Add(-passbackStaleAfter), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, id := range ids {
+		ok, err := s.deliver(ctx, id)
+		if err != nil {
+			s.logger.Error("Failed to process grade passback", "passback_id", id, "error", err)
+			continue
+		}
+		if ok {
+			delivered++
+		}
+	}
+
+	if delivered > 0 {
+		s.logger.Info("Grade passbacks delivered", "passbacks", delivered)
+	}
+	return delivered, nil
+}
+
+// RunScheduler queues and delivers grade passbacks every interval until the context is cancelled
+func (s *gradebookService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.Info("Gradebook passback scheduler started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Gradebook passback scheduler stopped")
+			return
+		case <-ticker.C:
+			if _, err := s.QueueReleasedGrades(ctx); err != nil {
+				s.logger.Error("Failed to queue grade passbacks", "error", err)
+			}
+			if _, err := s.DeliverPendingGrades(ctx, passbackBatchSize); err != nil {
+				s.logger.Error("Failed to deliver grade passbacks", "error", err)
+			}
+		}
+	}
+}
+
+// ===== HELPER METHODS =====
+
+func (s *gradebookService) queueIntegration(ctx context.Context, integration *models.GradebookIntegration, now time.Time) (int, error) {
+	attempts, err := s.repo.Gradebook().GetAttemptsNeedingPassback(ctx, nil, integration, passbackBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	passbacks := make([]*models.GradePassback, 0, len(attempts))
+	for _, attempt := range attempts {
+		passbacks = append(passbacks, &models.GradePassback{
+			IntegrationID: integration.ID,
+			AttemptID:     attempt.ID,
+			AssessmentID:  attempt.AssessmentID,
+			StudentID:     attempt.StudentID,
+			Status:        models.PassbackPending,
+			NextAttemptAt: timePtr(now),
+		})
+	}
+	if err := s.repo.Gradebook().QueuePassbacks(ctx, nil, passbacks); err != nil {
+		return 0, err
+	}
+	return len(passbacks), nil
+}
+
+// deliver pushes one passback's grade. A failed push is stored on the passback and
+// retried with backoff until it runs out of tries and moves to the dead letter queue.
+func (s *gradebookService) deliver(ctx context.Context, id uint) (bool, error) {
+	now := time.Now()
+	claimed, err := s.repo.Gradebook().ClaimPassback(ctx, nil, id, now, now.Add(-passbackStaleAfter))
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	passback, err := s.repo.Gradebook().GetPassbackByID(ctx, nil, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to get grade passback: %w", err)
+	}
+
+	grade, pushErr := s.push(ctx, passback)
+	if pushErr == nil {
+		passback.Status = models.PassbackDelivered
+		passback.DeliveredScore = &grade.Score
+		passback.DeliveredAt = timePtr(time.Now())
+		passback.NextAttemptAt = nil
+		passback.LastError = nil
+	} else {
+		recordPassbackFailure(passback, pushErr, time.Now())
+		if passback.Status == models.PassbackDeadLetter {
+			s.logger.Error("Grade passback moved to dead letter queue",
+				"passback_id", passback.ID,
+				"integration_id", passback.IntegrationID,
+				"attempt_id", passback.AttemptID,
+				"student_id", passback.StudentID,
+				"attempts", passback.Attempts,
+				"error", pushErr)
+		} else {
+			s.logger.Warn("Grade passback failed, will retry",
+				"passback_id", passback.ID,
+				"attempts", passback.Attempts,
+				"next_attempt_at", passback.NextAttemptAt,
+				"error", pushErr)
+		}
+	}
+
+	if err := s.repo.Gradebook().UpdatePassback(ctx, nil, passback); err != nil {
+		return false, err
+	}
+	return pushErr == nil, nil
+}
+
+func (s *gradebookService) push(ctx context.Context, passback *models.GradePassback) (*GradebookGrade, error) {
+	integration := &passback.Integration
+	client, ok := s.clients[integration.Provider]
+	if !ok {
+		return nil, &permanentGradebookError{err: fmt.Errorf("unsupported gradebook provider %q", integration.Provider)}
+	}
+
+	// The final grade is worked out when pushed, so it reflects every attempt graded so far
+	finalGrade, err := s.getFinalGrade(ctx, passback.AssessmentID, passback.StudentID)
+	if err != nil {
+		return nil, err
+	}
+	if finalGrade == nil {
+		return nil, &permanentGradebookError{err: fmt.Errorf("student %s has no finished attempts", passback.StudentID)}
+	}
+
+	studentKey := passback.StudentID
+	if integration.StudentKey == models.GradebookStudentKeyEmail {
+		student, err := s.repo.User().GetByID(ctx, passback.StudentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get student: %w", err)
+		}
+		studentKey = student.Email
+	}
+
+	grade := buildGradebookGrade(integration, passback.AssessmentID, finalGrade, studentKey)
+
+	pushCtx, cancel := context.WithTimeout(ctx, passbackTimeout)
+	defer cancel()
+	return grade, client.PushGrade(pushCtx, integration, grade)
+}
+
+func (s *gradebookService) getFinalGrade(ctx context.Context, assessmentID uint, studentID string) (*FinalGrade, error) {
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	policy, err := getGradePolicy(ctx, s.repo, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	attempts, err := s.repo.Attempt().GetByStudentAndAssessment(ctx, nil, studentID, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	return computeFinalGrade(attempts, policy, assessment.PassingScore), nil
+}
+
+func (s *gradebookService) getIntegration(ctx context.Context, id uint) (*models.GradebookIntegration, error) {
+	integration, err := s.repo.Gradebook().GetIntegrationByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get gradebook integration: %w", err)
+	}
+	return integration, nil
+}
+
+func (s *gradebookService) checkAccess(ctx context.Context, assessmentID uint, userID, action string) error {
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return err
+	}
+	if !canAccess {
+		return NewPermissionError(userID, assessmentID, "assessment", action, "not owner or insufficient permissions")
+	}
+	return nil
+}
+
+// validateGradebookIntegration checks the connection fields each provider needs
+func validateGradebookIntegration(integration *models.GradebookIntegration) error {
+	switch integration.Provider {
+	case models.GradebookProviderREST:
+		if integration.Endpoint == "" {
+			return NewValidationError("endpoint", "endpoint is required for REST gradebooks", integration.Endpoint)
+		}
+	case models.GradebookProviderGoogleClassroom:
+		if integration.CourseID == "" {
+			return NewValidationError("course_id", "course_id is required for Google Classroom", integration.CourseID)
+		}
+	default:
+		return NewValidationError("provider", "unsupported gradebook provider", integration.Provider)
+	}
+	if integration.Endpoint != "" && !strings.HasPrefix(integration.Endpoint, "https://") && !strings.HasPrefix(integration.Endpoint, "http://") {
+		return NewValidationError("endpoint", "endpoint must be an http or https URL", integration.Endpoint)
+	}
+	if integration.ColumnID == "" {
+		return NewValidationError("column_id", "column_id is required", integration.ColumnID)
+	}
+	return nil
+}
+
+// buildGradebookGrade maps a student's final grade onto the integration's column
+func buildGradebookGrade(integration *models.GradebookIntegration, assessmentID uint, finalGrade *FinalGrade, studentKey string) *GradebookGrade {
+	score, maxScore := finalGrade.Percentage, 100.0
+	if integration.ScoreField == models.GradebookScoreFieldScore {
+		score, maxScore = finalGrade.Score, float64(finalGrade.MaxScore)
+	}
+	if integration.MaxPoints != nil && maxScore > 0 {
+		score = score / maxScore * *integration.MaxPoints
+		maxScore = *integration.MaxPoints
+	}
+
+	return &GradebookGrade{
+		StudentKey:   studentKey,
+		Column:       integration.ColumnID,
+		Score:        math.Round(score*100) / 100,
+		MaxScore:     maxScore,
+		Passed:       finalGrade.Passed,
+		AssessmentID: assessmentID,
+		Policy:       finalGrade.Policy,
+		AttemptID:    finalGrade.AttemptID,
+	}
+}
+
+// recordPassbackFailure schedules the next try with exponential backoff, or moves the
+// passback to the dead letter queue once retrying cannot help or tries run out
+func recordPassbackFailure(passback *models.GradePassback, err error, now time.Time) {
+	passback.LastError = stringPtr(err.Error())
+	if isPermanentGradebookError(err) || passback.Attempts >= passbackMaxAttempts {
+		passback.Status = models.PassbackDeadLetter
+		passback.NextAttemptAt = nil
+		return
+	}
+
+	passback.Status = models.PassbackPending
+	passback.NextAttemptAt = timePtr(now.Add(passbackRetryDelay(passback.Attempts)))
+}
+
+// passbackRetryDelay doubles the wait after each failed try, up to passbackRetryMax
+func passbackRetryDelay(attempts int) time.Duration {
+	delay := passbackRetryBase
+	for i := 1; i < attempts && delay < passbackRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > passbackRetryMax {
+		delay = passbackRetryMax
+	}
+	return delay
+}
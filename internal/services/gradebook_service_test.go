@@ -0,0 +1,97 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestBuildGradebookGrade(t *testing.T) {
+	attemptID := uint(9)
+	finalGrade := &FinalGrade{StudentID: "s1", Policy: models.GradePolicyHighest, Score: 18, MaxScore: 24, Percentage: 75, Passed: true, AttemptID: &attemptID}
+
+	grade := buildGradebookGrade(&models.GradebookIntegration{ColumnID: "quiz-1", ScoreField: models.GradebookScoreFieldPercentage}, 4, finalGrade, "s1")
+	if grade.Score != 75 || grade.MaxScore != 100 || grade.Column != "quiz-1" || grade.StudentKey != "s1" || !grade.Passed {
+		t.Errorf("unexpected percentage grade %+v", grade)
+	}
+	if grade.AssessmentID != 4 || grade.Policy != models.GradePolicyHighest || grade.AttemptID == nil || *grade.AttemptID != 9 {
+		t.Errorf("expected the counted attempt and policy to be reported, got %+v", grade)
+	}
+
+	grade = buildGradebookGrade(&models.GradebookIntegration{ScoreField: models.GradebookScoreFieldScore}, 4, finalGrade, "s1")
+	if grade.Score != 18 || grade.MaxScore != 24 {
+		t.Errorf("expected raw score 18/24, got %v/%v", grade.Score, grade.MaxScore)
+	}
+
+	maxPoints := 10.0
+	grade = buildGradebookGrade(&models.GradebookIntegration{ScoreField: models.GradebookScoreFieldScore, MaxPoints: &maxPoints}, 4, &FinalGrade{Score: 2, MaxScore: 3}, "s1")
+	if grade.Score != 6.67 || grade.MaxScore != 10 {
+		t.Errorf("expected score rescaled to 6.67/10, got %v/%v", grade.Score, grade.MaxScore)
+	}
+}
+
+func TestPassbackRetryDelay(t *testing.T) {
+	cases := map[int]time.Duration{
+		1:  time.Minute,
+		2:  2 * time.Minute,
+		4:  8 * time.Minute,
+		20: time.Hour,
+	}
+	for attempts, want := range cases {
+		if got := passbackRetryDelay(attempts); got != want {
+			t.Errorf("after %d tries expected %v, got %v", attempts, want, got)
+		}
+	}
+}
+
+func TestRecordPassbackFailure(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	passback := &models.GradePassback{Status: models.PassbackProcessing, Attempts: 2}
+	recordPassbackFailure(passback, errors.New("gradebook returned 503"), now)
+	if passback.Status != models.PassbackPending || passback.NextAttemptAt == nil || !passback.NextAttemptAt.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("transient failure should be retried with backoff, got %+v", passback)
+	}
+	if passback.LastError == nil || *passback.LastError != "gradebook returned 503" {
+		t.Errorf("expected last error to be kept, got %v", passback.LastError)
+	}
+
+	passback = &models.GradePassback{Status: models.PassbackProcessing, Attempts: passbackMaxAttempts}
+	recordPassbackFailure(passback, errors.New("timeout"), now)
+	if passback.Status != models.PassbackDeadLetter || passback.NextAttemptAt != nil {
+		t.Errorf("passback out of tries should be dead-lettered, got %+v", passback)
+	}
+
+	passback = &models.GradePassback{Status: models.PassbackProcessing, Attempts: 1}
+	recordPassbackFailure(passback, &permanentGradebookError{err: errors.New("gradebook returned 404")}, now)
+	if passback.Status != models.PassbackDeadLetter {
+		t.Errorf("permanent rejection should be dead-lettered right away, got %+v", passback)
+	}
+}
+
+func TestValidateGradebookIntegration(t *testing.T) {
+	valid := []*models.GradebookIntegration{
+		{Provider: models.GradebookProviderREST, Endpoint: "https://grades.example.com/api", ColumnID: "c1"},
+		{Provider: models.GradebookProviderGoogleClassroom, CourseID: "123", ColumnID: "456"},
+	}
+	for _, integration := range valid {
+		if err := validateGradebookIntegration(integration); err != nil {
+			t.Errorf("expected %+v to be valid, got %v", integration, err)
+		}
+	}
+
+	invalid := []*models.GradebookIntegration{
+		{Provider: models.GradebookProviderREST, ColumnID: "c1"},
+		{Provider: models.GradebookProviderREST, Endpoint: "ftp://grades.example.com", ColumnID: "c1"},
+		{Provider: models.GradebookProviderGoogleClassroom, ColumnID: "456"},
+		{Provider: "canvas", Endpoint: "https://grades.example.com", ColumnID: "c1"},
+		{Provider: models.GradebookProviderREST, Endpoint: "https://grades.example.com"},
+	}
+	for _, integration := range invalid {
+		if err := validateGradebookIntegration(integration); err == nil {
+			t.Errorf("expected %+v to be rejected", integration)
+		}
+	}
+}
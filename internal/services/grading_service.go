@@ -47,7 +47,8 @@ func (s *gradingService) GradeAnswer(ctx context.Context, answerID uint, score f
 	}
 
 	// Check grading permissions
-	if err := s.checkGradingPermission(ctx, answer, graderID); err != nil {
+	delegation, err := s.checkGradingPermission(ctx, answer, graderID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -58,15 +59,18 @@ func (s *gradingService) GradeAnswer(ctx context.Context, answerID uint, score f
 	}
 
 	// Update answer with grade
+	before := gradeSnapshot(answer)
 	answer.Score = score
 	answer.Feedback = feedback
 	answer.GradedBy = &graderID
 	answer.GradedAt = timePtr(time.Now())
 	answer.IsGraded = true
+	answer.GradingStatus = nextGradingStatus(answer.GradingStatus, gradingEventManuallyGraded)
 
 	if err := s.repo.Answer().Update(ctx, nil, answer); err != nil {
 		return nil, fmt.Errorf("failed to update answer grade: %w", err)
 	}
+	s.auditGradeChange(ctx, answer, before, graderID)
 
 	result := &GradingResult{
 		AnswerID:      answerID,
@@ -84,6 +88,10 @@ func (s *gradingService) GradeAnswer(ctx context.Context, answerID uint, score f
 		"answer_id", answerID,
 		"score", score,
 		"max_score", maxScore)
+	s.recordDelegatedGrading(ctx, delegation, graderID, fmt.Sprintf("graded answer %d", answerID), map[string]interface{}{
+		"answer_id": answerID,
+		"score":     score,
+	})
 
 	// Update attempt grade if all questions are graded
 	go s.updateAttemptGradeIfComplete(answer.AttemptID)
@@ -106,14 +114,10 @@ func (s *gradingService) GradeAttempt(ctx context.Context, attemptID uint, grade
 	}
 
 	// Check grading permissions
-	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
-	canAccess, err := assessmentService.CanAccess(ctx, attempt.AssessmentID, graderID)
+	delegation, err := s.checkAssessmentGrading(ctx, attempt.AssessmentID, graderID)
 	if err != nil {
 		return nil, err
 	}
-	if !canAccess {
-		return nil, NewPermissionError(graderID, attempt.AssessmentID, "assessment", "grade", "not owner or insufficient permissions")
-	}
 
 	// Get all answers for attempt
 	answers, err := s.repo.Answer().GetByAttempt(ctx, nil, attemptID)
@@ -178,12 +182,10 @@ func (s *gradingService) GradeAttempt(ctx context.Context, attemptID uint, grade
 	}
 
 	isPassing := percentage >= float64(assessment.PassingScore)
-	grade := s.calculateLetterGrade(percentage)
+	grade := letterGrade(percentage)
 
 	// Update attempt with final grade
-	attempt.Score = totalScore
-	attempt.Percentage = percentage
-	attempt.Passed = isPassing
+	recordComputedScore(attempt, totalScore, percentage, isPassing)
 
 	if err := s.repo.Attempt().Update(ctx, nil, attempt); err != nil {
 		return nil, fmt.Errorf("failed to update attempt grade: %w", err)
@@ -206,6 +208,10 @@ func (s *gradingService) GradeAttempt(ctx context.Context, attemptID uint, grade
 		"total_score", totalScore,
 		"percentage", percentage,
 		"is_passing", isPassing)
+	s.recordDelegatedGrading(ctx, delegation, graderID, fmt.Sprintf("graded attempt %d", attemptID), map[string]interface{}{
+		"attempt_id":  attemptID,
+		"total_score": totalScore,
+	})
 
 	return result, nil
 }
@@ -252,6 +258,9 @@ func (s *gradingService) AutoGradeAnswer(ctx context.Context, answerID uint) (*G
 		}
 		return nil, fmt.Errorf("failed to get answer: %w", err)
 	}
+	if err := s.applyTrialVariant(ctx, answer); err != nil {
+		return nil, err
+	}
 
 	// Skip if already graded
 	if answer.IsGraded {
@@ -268,12 +277,25 @@ func (s *gradingService) AutoGradeAnswer(ctx context.Context, answerID uint) (*G
 		}, nil
 	}
 
+	// Multi-part answers are graded part by part
+	if answer.Question.Type == models.MultiPart {
+		result, _, err := s.autoGradeMultiPartAnswer(ctx, answer)
+		return result, err
+	}
+
 	// Calculate score based on question type
 	score, isCorrect, err := s.CalculateScore(ctx, answer.Question.Type, json.RawMessage(answer.Question.Content), json.RawMessage(answer.Answer))
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate score: %w", err)
 	}
 
+	// A question students flagged may have been voided or had answers accepted in this assessment
+	remediation, err := s.getFlagRemediation(ctx, answer.Attempt.AssessmentID, answer.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	score, isCorrect = remediatedScore(remediation, json.RawMessage(answer.Answer), score, isCorrect)
+
 	// Generate feedback
 	feedback, err := s.GenerateFeedback(ctx, answer.Question.Type, json.RawMessage(answer.Question.Content), json.RawMessage(answer.Answer), isCorrect)
 	if err != nil {
@@ -281,16 +303,27 @@ func (s *gradingService) AutoGradeAnswer(ctx context.Context, answerID uint) (*G
 	}
 
 	// Update answer with auto-grade
+	before := gradeSnapshot(answer)
 	finalScore := score * float64(answer.Question.Points)
 	answer.Score = finalScore
 	answer.Feedback = feedback
 	answer.GradedAt = timePtr(time.Now())
 	answer.IsGraded = true
+	answer.GradingStatus = nextGradingStatus(answer.GradingStatus, gradingEventAutoGraded)
 	// Note: GradedBy is nil for auto-graded answers
 
+	// Questions requiring manual review keep the score as provisional; the answer stays
+	// ungraded, and so in the grading queue, until a teacher grades it
+	if answer.Question.RequireManualReview {
+		answer.GradedAt = nil
+		answer.IsGraded = false
+		answer.GradingStatus = nextGradingStatus(answer.GradingStatus, gradingEventProvisional)
+	}
+
 	if err := s.repo.Answer().Update(ctx, nil, answer); err != nil {
 		return nil, fmt.Errorf("failed to update answer with auto-grade: %w", err)
 	}
+	s.auditGradeChange(ctx, answer, before, "")
 
 	result := &GradingResult{
 		AnswerID:      answerID,
@@ -302,12 +335,14 @@ func (s *gradingService) AutoGradeAnswer(ctx context.Context, answerID uint) (*G
 		Feedback:      feedback,
 		GradedAt:      time.Now(),
 		GradedBy:      nil, // Auto-graded
+		Provisional:   !answer.IsGraded,
 	}
 
 	s.logger.Debug("Answer auto-graded successfully",
 		"answer_id", answerID,
 		"score", finalScore,
-		"is_correct", isCorrect)
+		"is_correct", isCorrect,
+		"provisional", result.Provisional)
 
 	return result, nil
 }
@@ -330,6 +365,9 @@ func (s *gradingService) AutoGradeAttempt(ctx context.Context, attemptID uint) (
 		return nil, fmt.Errorf("failed to get attempt answers: %w", err)
 	}
 
+	// Flag essays written in another language than expected before they reach graders
+	s.checkAnswerLanguages(ctx, attempt.AssessmentID, answers)
+
 	// Auto-grade all gradeable answers
 	var questionResults []GradingResult
 	totalScore := 0.0
@@ -347,6 +385,23 @@ func (s *gradingService) AutoGradeAttempt(ctx context.Context, attemptID uint) (
 					s.logger.Warn("Failed to auto-grade answer", "answer_id", answer.ID, "error", err)
 					continue // Skip ungradeable answers
 				}
+				if result.Provisional {
+					// Waits for a teacher to confirm the score
+					hasManualGrading = true
+					continue
+				}
+			} else if answer.Question.Type == models.MultiPart {
+				// Grade the automatic parts; manual parts wait for a teacher
+				var graded bool
+				result, graded, err = s.autoGradeMultiPartAnswer(ctx, answer)
+				if err != nil {
+					s.logger.Warn("Failed to auto-grade answer", "answer_id", answer.ID, "error", err)
+					continue
+				}
+				if !graded {
+					hasManualGrading = true
+					continue
+				}
 			} else {
 				// Requires manual grading
 				hasManualGrading = true
@@ -385,13 +440,11 @@ func (s *gradingService) AutoGradeAttempt(ctx context.Context, attemptID uint) (
 	}
 
 	isPassing := percentage >= float64(assessment.PassingScore)
-	grade := s.calculateLetterGrade(percentage)
+	grade := letterGrade(percentage)
 
 	// Update attempt only if fully graded
 	if !hasManualGrading {
-		attempt.Score = totalScore
-		attempt.Percentage = percentage
-		attempt.Passed = isPassing
+		recordComputedScore(attempt, totalScore, percentage, isPassing)
 		// GradedBy is nil for auto-graded attempts
 
 		if err := s.repo.Attempt().Update(ctx, nil, attempt); err != nil {
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// ===== ANSWER ANNOTATIONS =====
+
+func (s *gradingService) GetAnswerAnnotations(ctx context.Context, answerID uint, userID string) ([]*models.AnswerAnnotation, error) {
+	answer, err := s.getAnnotatedAnswer(ctx, answerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.checkGradingPermission(ctx, answer, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.AnswerAnnotation().GetByAnswer(ctx, nil, answerID)
+}
+
+func (s *gradingService) AddAnswerAnnotation(ctx context.Context, answerID uint, req *CreateAnswerAnnotationRequest, graderID string) (*models.AnswerAnnotation, error) {
+	s.logger.Info("Annotating answer", "answer_id", answerID, "grader_id", graderID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	answer, err := s.getAnnotatedAnswer(ctx, answerID)
+	if err != nil {
+		return nil, err
+	}
+
+	delegation, err := s.checkGradingPermission(ctx, answer, graderID)
+	if err != nil {
+		return nil, err
+	}
+
+	annotation := &models.AnswerAnnotation{
+		AnswerID:        answer.ID,
+		AttemptID:       answer.AttemptID,
+		PartID:          req.PartID,
+		StartOffset:     req.StartOffset,
+		EndOffset:       req.EndOffset,
+		Comment:         strings.TrimSpace(req.Comment),
+		RubricCriterion: req.RubricCriterion,
+		CreatedBy:       graderID,
+	}
+	if err := applyAnnotationTarget(answer, annotation); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AnswerAnnotation().Create(ctx, nil, annotation); err != nil {
+		return nil, err
+	}
+	s.recordDelegatedGrading(ctx, delegation, graderID, fmt.Sprintf("annotated answer %d", answer.ID), map[string]interface{}{
+		"answer_id":     answer.ID,
+		"annotation_id": annotation.ID,
+	})
+
+	return annotation, nil
+}
+
+func (s *gradingService) UpdateAnswerAnnotation(ctx context.Context, annotationID uint, req *UpdateAnswerAnnotationRequest, graderID string) (*models.AnswerAnnotation, error) {
+	s.logger.Info("Updating answer annotation", "annotation_id", annotationID, "grader_id", graderID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	annotation, err := s.getAnswerAnnotation(ctx, annotationID)
+	if err != nil {
+		return nil, err
+	}
+
+	answer, err := s.getAnnotatedAnswer(ctx, annotation.AnswerID)
+	if err != nil {
+		return nil, err
+	}
+
+	delegation, err := s.checkGradingPermission(ctx, answer, graderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.StartOffset != nil {
+		annotation.StartOffset = *req.StartOffset
+	}
+	if req.EndOffset != nil {
+		annotation.EndOffset = *req.EndOffset
+	}
+	if req.Comment != nil {
+		annotation.Comment = strings.TrimSpace(*req.Comment)
+	}
+	if req.RubricCriterion != nil {
+		annotation.RubricCriterion = req.RubricCriterion
+		if *req.RubricCriterion == "" {
+			annotation.RubricCriterion = nil
+		}
+	}
+	if err := applyAnnotationTarget(answer, annotation); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AnswerAnnotation().Update(ctx, nil, annotation); err != nil {
+		return nil, err
+	}
+	s.recordDelegatedGrading(ctx, delegation, graderID, fmt.Sprintf("updated annotation %d on answer %d", annotation.ID, answer.ID), map[string]interface{}{
+		"answer_id":     answer.ID,
+		"annotation_id": annotation.ID,
+	})
+
+	return annotation, nil
+}
+
+func (s *gradingService) DeleteAnswerAnnotation(ctx context.Context, annotationID uint, graderID string) error {
+	s.logger.Info("Deleting answer annotation", "annotation_id", annotationID, "grader_id", graderID)
+
+	annotation, err := s.getAnswerAnnotation(ctx, annotationID)
+	if err != nil {
+		return err
+	}
+
+	answer, err := s.getAnnotatedAnswer(ctx, annotation.AnswerID)
+	if err != nil {
+		return err
+	}
+
+	delegation, err := s.checkGradingPermission(ctx, answer, graderID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.AnswerAnnotation().Delete(ctx, nil, annotationID); err != nil {
+		return err
+	}
+	s.recordDelegatedGrading(ctx, delegation, graderID, fmt.Sprintf("deleted annotation %d on answer %d", annotationID, answer.ID), map[string]interface{}{
+		"answer_id":     answer.ID,
+		"annotation_id": annotationID,
+	})
+	return nil
+}
+
+// ===== HELPER METHODS =====
+
+func (s *gradingService) getAnnotatedAnswer(ctx context.Context, answerID uint) (*models.StudentAnswer, error) {
+	answer, err := s.repo.Answer().GetByIDWithDetails(ctx, nil, answerID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get answer: %w", err)
+	}
+	return answer, nil
+}
+
+func (s *gradingService) getAnswerAnnotation(ctx context.Context, annotationID uint) (*models.AnswerAnnotation, error) {
+	annotation, err := s.repo.AnswerAnnotation().GetByID(ctx, nil, annotationID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get answer annotation: %w", err)
+	}
+	return annotation, nil
+}
+
+// applyAnnotationTarget checks the annotation against the essay text it highlights and
+// stores the highlighted quote, so the span still reads right if offsets are later disputed
+func applyAnnotationTarget(answer *models.StudentAnswer, annotation *models.AnswerAnnotation) error {
+	text, criteria, err := essayAnnotationTarget(answer, annotation.PartID)
+	if err != nil {
+		return err
+	}
+
+	quote, err := annotationQuote(text, annotation.StartOffset, annotation.EndOffset)
+	if err != nil {
+		return err
+	}
+
+	if annotation.RubricCriterion != nil && !hasRubricCriterion(criteria, *annotation.RubricCriterion) {
+		return NewValidationError("rubric_criterion", "question has no such rubric criterion", *annotation.RubricCriterion)
+	}
+
+	annotation.Quote = quote
+	return nil
+}
+
+// essayAnnotationTarget returns the essay text an annotation refers to and the rubric
+// criteria it may link: the answer itself on essay questions, an essay part on multi-part ones
+func essayAnnotationTarget(answer *models.StudentAnswer, partID *string) (string, []string, error) {
+	rawContent, rawAnswer := json.RawMessage(answer.Question.Content), json.RawMessage(answer.Answer)
+
+	switch answer.Question.Type {
+	case models.Essay:
+		if partID != nil {
+			return "", nil, NewValidationError("part_id", "essay questions have no parts", *partID)
+		}
+	case models.MultiPart:
+		if partID == nil {
+			return "", nil, NewValidationError("part_id", "part_id is required for multi-part questions", nil)
+		}
+		var content models.MultiPartContent
+		if err := json.Unmarshal(rawContent, &content); err != nil {
+			return "", nil, fmt.Errorf("failed to unmarshal question content: %w", err)
+		}
+		part := findQuestionPart(&content, *partID)
+		if part == nil {
+			return "", nil, NewValidationError("part_id", "question has no such part", *partID)
+		}
+		if part.Type != models.Essay {
+			return "", nil, NewValidationError("part_id", "only essay parts can be annotated", *partID)
+		}
+		partAnswers := models.MultiPartAnswer{}
+		if len(rawAnswer) > 0 {
+			if err := json.Unmarshal(rawAnswer, &partAnswers); err != nil {
+				return "", nil, fmt.Errorf("failed to unmarshal student answer: %w", err)
+			}
+		}
+		rawContent, rawAnswer = json.RawMessage(part.Content), json.RawMessage(partAnswers[*partID])
+	default:
+		return "", nil, NewValidationError("answer_id", "only essay answers can be annotated", answer.ID)
+	}
+
+	var content models.EssayContent
+	if len(rawContent) > 0 {
+		if err := json.Unmarshal(rawContent, &content); err != nil {
+			return "", nil, fmt.Errorf("failed to unmarshal essay content: %w", err)
+		}
+	}
+	var essay models.EssayAnswer
+	if len(rawAnswer) > 0 {
+		if err := json.Unmarshal(rawAnswer, &essay); err != nil {
+			return "", nil, fmt.Errorf("failed to unmarshal essay answer: %w", err)
+		}
+	}
+
+	return essay.Text, content.RubricCriteria, nil
+}
+
+// annotationQuote returns characters [start, end) of the text
+func annotationQuote(text string, start, end int) (string, error) {
+	runes := []rune(text)
+	if start < 0 || end <= start {
+		return "", NewValidationError("end_offset", "end_offset must be after start_offset", end)
+	}
+	if end > len(runes) {
+		return "", NewValidationError("end_offset", "span runs past the end of the answer", end)
+	}
+	return string(runes[start:end]), nil
+}
+
+func hasRubricCriterion(criteria []string, criterion string) bool {
+	for _, c := range criteria {
+		if strings.EqualFold(strings.TrimSpace(c), strings.TrimSpace(criterion)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+func essayStudentAnswer(text string) *models.StudentAnswer {
+	return &models.StudentAnswer{
+		ID:     1,
+		Answer: datatypes.JSON(`{"text": "` + text + `"}`),
+		Question: models.Question{
+			Type:    models.Essay,
+			Content: datatypes.JSON(`{"rubric_criteria": ["Argument", "Evidence"]}`),
+		},
+	}
+}
+
+func TestApplyAnnotationTarget(t *testing.T) {
+	criterion := "evidence"
+	annotation := &models.AnswerAnnotation{StartOffset: 6, EndOffset: 11, RubricCriterion: &criterion}
+
+	if err := applyAnnotationTarget(essayStudentAnswer("Über größte Zahl"), annotation); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Offsets count characters, not bytes
+	if annotation.Quote != "rößte" {
+		t.Errorf("expected quote %q, got %q", "rößte", annotation.Quote)
+	}
+
+	tooLong := &models.AnswerAnnotation{StartOffset: 0, EndOffset: 40}
+	if err := applyAnnotationTarget(essayStudentAnswer("short"), tooLong); err == nil {
+		t.Error("expected an error for a span past the end of the answer")
+	}
+
+	unknown := "Style"
+	if err := applyAnnotationTarget(essayStudentAnswer("Some essay"), &models.AnswerAnnotation{EndOffset: 4, RubricCriterion: &unknown}); err == nil {
+		t.Error("expected an error for an unknown rubric criterion")
+	}
+}
+
+func TestEssayAnnotationTarget_MultiPart(t *testing.T) {
+	answer := &models.StudentAnswer{
+		Answer: datatypes.JSON(`{"a": true, "b": {"text": "Because it is"}}`),
+		Question: models.Question{
+			Type: models.MultiPart,
+			Content: datatypes.JSON(`{"parts": [
+				{"id": "a", "type": "true_false", "points": 1, "content": {"correct_answer": true}},
+				{"id": "b", "type": "essay", "points": 4, "content": {"rubric_criteria": ["Reasoning"]}}
+			]}`),
+		},
+	}
+
+	partB := "b"
+	text, criteria, err := essayAnnotationTarget(answer, &partB)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text != "Because it is" || len(criteria) != 1 || criteria[0] != "Reasoning" {
+		t.Errorf("unexpected target: %q %v", text, criteria)
+	}
+
+	partA := "a"
+	if _, _, err := essayAnnotationTarget(answer, &partA); err == nil {
+		t.Error("expected an error for a non-essay part")
+	}
+	if _, _, err := essayAnnotationTarget(answer, nil); err == nil {
+		t.Error("expected an error without a part")
+	}
+
+	answer.Question.Type = models.MultipleChoice
+	if _, _, err := essayAnnotationTarget(answer, nil); err == nil {
+		t.Error("expected an error for a non-essay question")
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// ===== GRADING QUEUE AND ANONYMOUS GRADING =====
+
+func (s *gradingService) GetPendingGrading(ctx context.Context, graderID string) ([]PendingGradingItem, error) {
+	answers, err := s.repo.Answer().GetPendingGrading(ctx, nil, graderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending grading: %w", err)
+	}
+
+	assessmentIDs := make([]uint, 0)
+	seen := make(map[uint]bool)
+	for _, answer := range answers {
+		if !seen[answer.Attempt.AssessmentID] {
+			seen[answer.Attempt.AssessmentID] = true
+			assessmentIDs = append(assessmentIDs, answer.Attempt.AssessmentID)
+		}
+	}
+
+	settings, err := s.repo.AssessmentSettings().GetMultiple(ctx, nil, assessmentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment settings: %w", err)
+	}
+
+	now := time.Now()
+	items := make([]PendingGradingItem, 0, len(answers))
+	for _, answer := range answers {
+		item := PendingGradingItem{
+			AnswerID:     answer.ID,
+			AttemptID:    answer.AttemptID,
+			AssessmentID: answer.Attempt.AssessmentID,
+			QuestionID:   answer.QuestionID,
+			QuestionType: answer.Question.Type,
+			QuestionText: answer.Question.Text,
+			MaxScore:     answer.MaxScore,
+			Answer:       []byte(answer.Answer),
+			SubmittedAt:  answer.Attempt.CompletedAt,
+
+			GradingStatus:    effectiveGradingStatus(answer.GradingStatus),
+			DetectedLanguage: answer.DetectedLanguage,
+			LanguageMismatch: answer.LanguageMismatch,
+		}
+		if item.GradingStatus == models.GradingStatusProvisional {
+			score := answer.Score
+			item.ProvisionalScore = &score
+		}
+
+		assessmentSettings := settings[item.AssessmentID]
+		if identitiesHidden(assessmentSettings, now) {
+			item.Anonymous = true
+			item.StudentLabel = anonymousStudentLabel(assessmentSettings.AnonymousGradingSalt, answer.Attempt.StudentID)
+		} else {
+			item.StudentID = answer.Attempt.StudentID
+			item.StudentLabel = answer.Attempt.StudentID
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// identitiesHidden reports whether graders must see pseudonyms instead of student identities
+func identitiesHidden(settings *models.AssessmentSettings, now time.Time) bool {
+	return settings != nil && settings.AnonymousGrading && !resultsReleased(settings, now)
+}
+
+// redactAttemptIdentity swaps the student of an attempt for a label, their grading pseudonym
+// when known, and drops the connection details that could point back to them
+func redactAttemptIdentity(attempt *models.AssessmentAttempt, label string) {
+	attempt.StudentID = ""
+	attempt.Student = models.User{FullName: label}
+	attempt.IPAddress = nil
+	attempt.UserAgent = nil
+}
+
+// anonymousStudentLabel derives a pseudonym that is stable within one assessment
+// but cannot be linked back to the student without the assessment's salt
+func anonymousStudentLabel(salt, studentID string) string {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(studentID))
+	return "Candidate " + strings.ToUpper(hex.EncodeToString(mac.Sum(nil))[:8])
+}
+
+func newAnonymousGradingSalt() string {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		// crypto/rand only fails when the OS entropy source is unavailable
+		panic(fmt.Sprintf("failed to generate anonymous grading salt: %v", err))
+	}
+	return hex.EncodeToString(buf)
+}
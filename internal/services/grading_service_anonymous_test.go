@@ -0,0 +1,43 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestAnonymousStudentLabel(t *testing.T) {
+	first := anonymousStudentLabel("salt-a", "student-1")
+	if first != anonymousStudentLabel("salt-a", "student-1") {
+		t.Error("expected labels to be stable for the same salt and student")
+	}
+	if first == anonymousStudentLabel("salt-a", "student-2") {
+		t.Error("expected different students to get different labels")
+	}
+	if first == anonymousStudentLabel("salt-b", "student-1") {
+		t.Error("expected labels to differ between assessments")
+	}
+}
+
+func TestIdentitiesHidden(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		settings *models.AssessmentSettings
+		want     bool
+	}{
+		{"no settings", nil, false},
+		{"anonymous grading off", &models.AssessmentSettings{}, false},
+		{"anonymous before release", &models.AssessmentSettings{AnonymousGrading: true}, true},
+		{"anonymous after release", &models.AssessmentSettings{AnonymousGrading: true, ResultsReleasedAt: &now}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := identitiesHidden(tt.settings, now); got != tt.want {
+				t.Errorf("identitiesHidden() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
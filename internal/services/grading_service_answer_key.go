@@ -0,0 +1,392 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+const (
+	regradeJobBatch      = 10
+	regradeJobStaleAfter = 10 * time.Minute
+)
+
+// ===== ANSWER KEY CHANGES =====
+
+func (s *gradingService) GetAnswerKeyChanges(ctx context.Context, questionID uint, userID string) ([]*models.AnswerKeyChange, error) {
+	if err := s.checkQuestionRegradeAccess(ctx, questionID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.AnswerKeyChange().GetChangesByQuestion(ctx, nil, questionID)
+}
+
+// GetAnswerKeyChangeReport lists every assessment with finished attempts graded before the
+// change, together with the regrade job last scheduled for it
+func (s *gradingService) GetAnswerKeyChangeReport(ctx context.Context, changeID uint, userID string) (*AnswerKeyChangeReport, error) {
+	change, err := s.getAnswerKeyChange(ctx, changeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkQuestionRegradeAccess(ctx, change.QuestionID, userID); err != nil {
+		return nil, err
+	}
+
+	answers, err := s.repo.AnswerKeyChange().GetAffectedAnswers(ctx, nil, change.QuestionID, change.CreatedAt, change.AssessmentID)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := s.repo.AnswerKeyChange().GetJobsByChange(ctx, nil, changeID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &AnswerKeyChangeReport{
+		Change:      change,
+		Assessments: groupAffectedAnswers(answers, latestRegradeJobs(jobs)),
+		GeneratedAt: time.Now(),
+	}
+
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	for i := range report.Assessments {
+		affected := &report.Assessments[i]
+		report.TotalAttempts += affected.AttemptCount
+
+		if assessment, err := s.repo.Assessment().GetByID(ctx, nil, affected.AssessmentID); err == nil {
+			affected.Title = assessment.Title
+		}
+		canAccess, err := assessmentService.CanAccess(ctx, affected.AssessmentID, userID)
+		if err != nil {
+			return nil, err
+		}
+		affected.CanRegrade = canAccess
+		if !canAccess {
+			// Other teachers' students stay private; only the counts are shown
+			affected.Attempts = nil
+			affected.LatestJob = nil
+			continue
+		}
+		if affected.NeedsRegradeSchedule {
+			report.UnscheduledAssessments++
+		}
+	}
+
+	return report, nil
+}
+
+// ScheduleRegrade queues a regrade of one affected assessment. A job already waiting or
+// running for it is returned instead of queueing another.
+func (s *gradingService) ScheduleRegrade(ctx context.Context, changeID, assessmentID uint, userID string) (*models.RegradeJob, error) {
+	s.logger.Info("Scheduling regrade", "change_id", changeID, "assessment_id", assessmentID, "user_id", userID)
+
+	change, err := s.getAnswerKeyChange(ctx, changeID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAssessmentRegradeAccess(ctx, assessmentID, userID); err != nil {
+		return nil, err
+	}
+	if change.AssessmentID != nil && *change.AssessmentID != assessmentID {
+		return nil, NewBusinessRuleError("regrade_not_needed", "the change only applies to another assessment", map[string]interface{}{
+			"change_id":     changeID,
+			"assessment_id": assessmentID,
+		})
+	}
+
+	answers, err := s.repo.AnswerKeyChange().GetAffectedAnswers(ctx, nil, change.QuestionID, change.CreatedAt, &assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(answers) == 0 {
+		return nil, NewBusinessRuleError("regrade_not_needed", "no finished attempts of this assessment were graded with the old answer key", map[string]interface{}{
+			"change_id":     changeID,
+			"assessment_id": assessmentID,
+		})
+	}
+
+	jobs, err := s.repo.AnswerKeyChange().GetJobsByChange(ctx, nil, changeID)
+	if err != nil {
+		return nil, err
+	}
+	if latest := latestRegradeJobs(jobs)[assessmentID]; latest != nil &&
+		(latest.Status == models.RegradePending || latest.Status == models.RegradeRunning) {
+		return latest, nil
+	}
+
+	job := &models.RegradeJob{
+		ChangeID:     changeID,
+		AssessmentID: assessmentID,
+		Status:       models.RegradePending,
+		RequestedBy:  userID,
+	}
+	if err := s.repo.AnswerKeyChange().CreateJob(ctx, nil, job); err != nil {
+		return nil, err
+	}
+	if err := s.markPendingRegrade(ctx, answers); err != nil {
+		return nil, err
+	}
+	if _, err := enqueueJob(ctx, s.repo, JobTypeRegrade, strconv.FormatUint(uint64(job.ID), 10), userID); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (s *gradingService) GetRegradeJob(ctx context.Context, jobID uint, userID string) (*models.RegradeJob, error) {
+	job, err := s.repo.AnswerKeyChange().GetJobByID(ctx, nil, jobID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get regrade job: %w", err)
+	}
+	if err := s.checkAssessmentRegradeAccess(ctx, job.AssessmentID, userID); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ===== REGRADE JOBS =====
+
+// QueueRegradeJobs hands pending regrades, and regrades orphaned mid-way by a crash, to the
+// job workers. Regrades that already have a queued or running job are left alone.
+func (s *gradingService) QueueRegradeJobs(ctx context.Context, limit int) (int, error) {
+	jobs, err := s.repo.AnswerKeyChange().GetRunnableJobs(ctx, nil, time.Now().Add(-regradeJobStaleAfter), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for _, job := range jobs {
+		if _, err := enqueueJob(ctx, s.repo, JobTypeRegrade, strconv.FormatUint(uint64(job.ID), 10), job.RequestedBy); err != nil {
+			s.logger.Error("Failed to queue regrade job", "job_id", job.ID, "error", err)
+			continue
+		}
+		queued++
+	}
+	return queued, nil
+}
+
+// RunRegradeJob runs a scheduled regrade for the job workers. A regrade that failed before
+// is run again, so a retried job redoes the work; the failure is returned to be retried.
+func (s *gradingService) RunRegradeJob(ctx context.Context, jobID uint) error {
+	job, err := s.repo.AnswerKeyChange().GetJobByID(ctx, nil, jobID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return NewPermanentJobError(ErrNotFound)
+		}
+		return fmt.Errorf("failed to get regrade job: %w", err)
+	}
+	if job.Status == models.RegradeFailed {
+		job.Status = models.RegradePending
+		job.Error = nil
+		job.CompletedAt = nil
+		if err := s.repo.AnswerKeyChange().UpdateJob(ctx, nil, job); err != nil {
+			return err
+		}
+	}
+
+	claimed, err := s.repo.AnswerKeyChange().ClaimJob(ctx, nil, job.ID, time.Now().Add(-regradeJobStaleAfter))
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		// Completed, or still running elsewhere
+		return nil
+	}
+
+	job.Status = models.RegradeRunning
+	runErr := s.runRegradeJob(ctx, job)
+
+	now := time.Now()
+	job.CompletedAt = &now
+	job.Status = models.RegradeCompleted
+	if runErr != nil {
+		s.logger.Error("Regrade job failed", "job_id", job.ID, "error", runErr)
+		job.Status = models.RegradeFailed
+		job.Error = stringPtr(runErr.Error())
+	}
+	if err := s.repo.AnswerKeyChange().UpdateJob(ctx, nil, job); err != nil {
+		return fmt.Errorf("failed to save regrade job: %w", err)
+	}
+	return runErr
+}
+
+// RunScheduler queues scheduled regrades every interval until the context is cancelled
+func (s *gradingService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.Info("Regrade job scheduler started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Regrade job scheduler stopped")
+			return
+		case <-ticker.C:
+			if _, err := s.QueueRegradeJobs(ctx, regradeJobBatch); err != nil {
+				s.logger.Error("Failed to queue regrade jobs", "error", err)
+			}
+		}
+	}
+}
+
+// runRegradeJob scores the affected answers with the current key and recomputes their
+// attempts. Answers a teacher graded by hand keep their grade and are only counted.
+func (s *gradingService) runRegradeJob(ctx context.Context, job *models.RegradeJob) error {
+	change, err := s.getAnswerKeyChange(ctx, job.ChangeID)
+	if err != nil {
+		return err
+	}
+	answers, err := s.repo.AnswerKeyChange().GetAffectedAnswers(ctx, nil, change.QuestionID, change.CreatedAt, &job.AssessmentID)
+	if err != nil {
+		return err
+	}
+
+	job.AnswersRegraded, job.AttemptsRegraded, job.ManualAnswersSkipped = 0, 0, 0
+	attemptIDs := make([]uint, 0, len(answers))
+	seen := make(map[uint]bool, len(answers))
+	for _, affected := range answers {
+		answer, err := s.repo.Answer().GetByIDWithDetails(ctx, nil, affected.AnswerID)
+		if err != nil {
+			return fmt.Errorf("failed to get answer %d: %w", affected.AnswerID, err)
+		}
+		if err := s.applyTrialVariant(ctx, answer); err != nil {
+			return err
+		}
+		if keepsManualGrade(answer) {
+			job.ManualAnswersSkipped++
+			continue
+		}
+		if err := s.regradeAnswer(ctx, answer); err != nil {
+			return fmt.Errorf("failed to regrade answer %d: %w", answer.ID, err)
+		}
+		job.AnswersRegraded++
+
+		if !seen[answer.AttemptID] {
+			seen[answer.AttemptID] = true
+			attemptIDs = append(attemptIDs, answer.AttemptID)
+		}
+	}
+
+	for _, attemptID := range attemptIDs {
+		if _, err := s.AutoGradeAttempt(ctx, attemptID); err != nil {
+			return fmt.Errorf("failed to recompute attempt %d: %w", attemptID, err)
+		}
+		job.AttemptsRegraded++
+	}
+
+	s.logger.Info("Regrade job completed",
+		"job_id", job.ID,
+		"answers_regraded", job.AnswersRegraded,
+		"attempts_regraded", job.AttemptsRegraded,
+		"manual_answers_skipped", job.ManualAnswersSkipped)
+	return nil
+}
+
+// regradeAnswer scores an automatically graded answer again with the question's current key
+func (s *gradingService) regradeAnswer(ctx context.Context, answer *models.StudentAnswer) error {
+	answer.GradingStatus = nextGradingStatus(answer.GradingStatus, gradingEventRegradeRequested)
+	if answer.Question.Type == models.MultiPart {
+		// Automatic parts are rescored; parts graded by a teacher are kept
+		_, _, err := s.autoGradeMultiPartAnswer(ctx, answer)
+		return err
+	}
+
+	// AutoGradeAnswer leaves graded answers alone
+	answer.IsGraded = false
+	if err := s.repo.Answer().Update(ctx, nil, answer); err != nil {
+		return err
+	}
+	_, err := s.AutoGradeAnswer(ctx, answer.ID)
+	return err
+}
+
+// ===== HELPER METHODS =====
+
+func (s *gradingService) getAnswerKeyChange(ctx context.Context, changeID uint) (*models.AnswerKeyChange, error) {
+	change, err := s.repo.AnswerKeyChange().GetChangeByID(ctx, nil, changeID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get answer key change: %w", err)
+	}
+	return change, nil
+}
+
+func (s *gradingService) checkQuestionRegradeAccess(ctx context.Context, questionID uint, userID string) error {
+	questionService := NewQuestionService(s.repo, s.db, s.logger, s.validator)
+	canAccess, err := questionService.CanAccess(ctx, questionID, userID)
+	if err != nil {
+		return err
+	}
+	if !canAccess {
+		return NewPermissionError(userID, questionID, "question", "view_answer_key_changes", "not owner or insufficient permissions")
+	}
+	return nil
+}
+
+func (s *gradingService) checkAssessmentRegradeAccess(ctx context.Context, assessmentID uint, userID string) error {
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return err
+	}
+	if !canAccess {
+		return NewPermissionError(userID, assessmentID, "assessment", "regrade", "not owner or insufficient permissions")
+	}
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// groupAffectedAnswers collects affected answers per assessment, in the order the
+// assessments first appear
+func groupAffectedAnswers(answers []repositories.AffectedAnswer, latestJobs map[uint]*models.RegradeJob) []AffectedAssessment {
+	index := make(map[uint]int)
+	var groups []AffectedAssessment
+	for _, answer := range answers {
+		i, ok := index[answer.AssessmentID]
+		if !ok {
+			i = len(groups)
+			index[answer.AssessmentID] = i
+			latest := latestJobs[answer.AssessmentID]
+			groups = append(groups, AffectedAssessment{
+				AssessmentID:         answer.AssessmentID,
+				LatestJob:            latest,
+				NeedsRegradeSchedule: latest == nil || latest.Status == models.RegradeFailed,
+			})
+		}
+		group := &groups[i]
+		group.AttemptCount++
+		if answer.ManuallyGraded {
+			group.ManuallyGradedCount++
+		}
+		group.Attempts = append(group.Attempts, answer)
+	}
+	return groups
+}
+
+// latestRegradeJobs picks each assessment's most recent job from jobs listed newest first
+func latestRegradeJobs(jobs []*models.RegradeJob) map[uint]*models.RegradeJob {
+	latest := make(map[uint]*models.RegradeJob)
+	for _, job := range jobs {
+		if _, ok := latest[job.AssessmentID]; !ok {
+			latest[job.AssessmentID] = job
+		}
+	}
+	return latest
+}
+
+// keepsManualGrade reports whether a regrade must leave the answer's grade alone. Multi-part
+// answers keep teacher-graded parts themselves, so they are always regraded.
+func keepsManualGrade(answer *models.StudentAnswer) bool {
+	if answer.Question.Type == models.MultiPart {
+		return false
+	}
+	return answer.GradedBy != nil
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestGroupAffectedAnswers(t *testing.T) {
+	answers := []repositories.AffectedAnswer{
+		{AnswerID: 1, AttemptID: 10, AssessmentID: 7},
+		{AnswerID: 2, AttemptID: 11, AssessmentID: 7, ManuallyGraded: true},
+		{AnswerID: 3, AttemptID: 12, AssessmentID: 9},
+	}
+	failed := &models.RegradeJob{ID: 1, AssessmentID: 9, Status: models.RegradeFailed}
+
+	groups := groupAffectedAnswers(answers, map[uint]*models.RegradeJob{9: failed})
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 assessments, got %d", len(groups))
+	}
+	if g := groups[0]; g.AssessmentID != 7 || g.AttemptCount != 2 || g.ManuallyGradedCount != 1 || !g.NeedsRegradeSchedule {
+		t.Errorf("unexpected first group: %+v", g)
+	}
+	if g := groups[1]; g.AssessmentID != 9 || g.LatestJob != failed || !g.NeedsRegradeSchedule {
+		t.Errorf("assessment whose regrade failed should need scheduling again: %+v", g)
+	}
+
+	done := &models.RegradeJob{ID: 2, AssessmentID: 7, Status: models.RegradeCompleted}
+	groups = groupAffectedAnswers(answers, map[uint]*models.RegradeJob{7: done})
+	if groups[0].NeedsRegradeSchedule {
+		t.Error("regraded assessment should not need scheduling")
+	}
+}
+
+func TestLatestRegradeJobs(t *testing.T) {
+	newest := &models.RegradeJob{ID: 3, AssessmentID: 7}
+	latest := latestRegradeJobs([]*models.RegradeJob{
+		newest,
+		{ID: 2, AssessmentID: 9},
+		{ID: 1, AssessmentID: 7},
+	})
+	if latest[7] != newest || latest[9].ID != 2 {
+		t.Errorf("unexpected latest jobs: %+v", latest)
+	}
+}
+
+func TestKeepsManualGrade(t *testing.T) {
+	teacher := "teacher-1"
+	cases := []struct {
+		name   string
+		answer *models.StudentAnswer
+		want   bool
+	}{
+		{"auto graded", &models.StudentAnswer{Question: models.Question{Type: models.MultipleChoice}}, false},
+		{"teacher graded", &models.StudentAnswer{GradedBy: &teacher, Question: models.Question{Type: models.MultipleChoice}}, true},
+		{"multi-part", &models.StudentAnswer{GradedBy: &teacher, Question: models.Question{Type: models.MultiPart}}, false},
+	}
+	for _, c := range cases {
+		if got := keepsManualGrade(c.answer); got != c.want {
+			t.Errorf("%s: keepsManualGrade = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+const (
+	commentSourceCurated = "curated"
+	commentSourceHistory = "history"
+
+	// Mined feedback must recur before it is suggested; one-off comments are usually student specific
+	commentBankMinUsage   = 2
+	commentBankMinedLimit = 50
+)
+
+// ===== COMMENT BANK =====
+
+func (s *gradingService) GetCommentBank(ctx context.Context, questionID uint, userID string) (*QuestionCommentBank, error) {
+	if err := s.checkCommentBankAccess(ctx, questionID, userID, "view_comment_bank"); err != nil {
+		return nil, err
+	}
+
+	curated, err := s.repo.FeedbackComment().GetByQuestion(ctx, nil, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	mined, err := s.repo.FeedbackComment().GetFeedbackFrequencies(ctx, nil, questionID, commentBankMinedLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	bank := buildCommentBank(curated, mined)
+	bank.QuestionID = questionID
+
+	return bank, nil
+}
+
+func (s *gradingService) AddFeedbackComment(ctx context.Context, questionID uint, req *CreateFeedbackCommentRequest, userID string) (*models.FeedbackComment, error) {
+	s.logger.Info("Adding comment bank entry", "question_id", questionID, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if req.Pinned && req.Hidden {
+		return nil, NewValidationError("hidden", "a comment cannot be pinned and hidden at the same time", req.Hidden)
+	}
+
+	if err := s.checkCommentBankAccess(ctx, questionID, userID, "curate_comment_bank"); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FeedbackComment().GetByQuestion(ctx, nil, questionID)
+	if err != nil {
+		return nil, err
+	}
+	text := strings.TrimSpace(req.Text)
+	for _, comment := range existing {
+		if normalizeFeedback(comment.Text) == normalizeFeedback(text) {
+			return nil, NewBusinessRuleError("duplicate_comment", "the comment bank already has this comment", map[string]interface{}{
+				"comment_id": comment.ID,
+			})
+		}
+	}
+
+	comment := &models.FeedbackComment{
+		QuestionID: questionID,
+		Text:       text,
+		Pinned:     req.Pinned,
+		Hidden:     req.Hidden,
+		CreatedBy:  userID,
+	}
+	if err := s.repo.FeedbackComment().Create(ctx, nil, comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (s *gradingService) UpdateFeedbackComment(ctx context.Context, commentID uint, req *UpdateFeedbackCommentRequest, userID string) (*models.FeedbackComment, error) {
+	s.logger.Info("Updating comment bank entry", "comment_id", commentID, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.getFeedbackComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCommentBankAccess(ctx, comment.QuestionID, userID, "curate_comment_bank"); err != nil {
+		return nil, err
+	}
+
+	if req.Text != nil {
+		comment.Text = strings.TrimSpace(*req.Text)
+	}
+	if req.Pinned != nil {
+		comment.Pinned = *req.Pinned
+	}
+	if req.Hidden != nil {
+		comment.Hidden = *req.Hidden
+	}
+	if comment.Pinned && comment.Hidden {
+		return nil, NewValidationError("hidden", "a comment cannot be pinned and hidden at the same time", comment.Hidden)
+	}
+
+	if err := s.repo.FeedbackComment().Update(ctx, nil, comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (s *gradingService) DeleteFeedbackComment(ctx context.Context, commentID uint, userID string) error {
+	s.logger.Info("Deleting comment bank entry", "comment_id", commentID, "user_id", userID)
+
+	comment, err := s.getFeedbackComment(ctx, commentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.checkCommentBankAccess(ctx, comment.QuestionID, userID, "curate_comment_bank"); err != nil {
+		return err
+	}
+
+	return s.repo.FeedbackComment().Delete(ctx, nil, commentID)
+}
+
+// ===== HELPER METHODS =====
+
+func (s *gradingService) checkCommentBankAccess(ctx context.Context, questionID uint, userID, action string) error {
+	if _, err := s.repo.Question().GetByID(ctx, nil, questionID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrQuestionNotFound
+		}
+		return fmt.Errorf("failed to get question: %w", err)
+	}
+
+	questionService := NewQuestionService(s.repo, s.db, s.logger, s.validator)
+	canAccess, err := questionService.CanAccess(ctx, questionID, userID)
+	if err != nil {
+		return err
+	}
+	if !canAccess {
+		return NewPermissionError(userID, questionID, "question", action, "not owner or insufficient permissions")
+	}
+	return nil
+}
+
+func (s *gradingService) getFeedbackComment(ctx context.Context, commentID uint) (*models.FeedbackComment, error) {
+	comment, err := s.repo.FeedbackComment().GetByID(ctx, nil, commentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get feedback comment: %w", err)
+	}
+	return comment, nil
+}
+
+// buildCommentBank merges curated entries with feedback mined from earlier grading.
+// Pinned entries come first, then everything else by how often graders used it;
+// hidden entries suppress matching mined feedback.
+func buildCommentBank(curated []*models.FeedbackComment, mined []repositories.FeedbackFrequency) *QuestionCommentBank {
+	usage := make(map[string]int, len(mined))
+	for _, frequency := range mined {
+		usage[normalizeFeedback(frequency.Text)] += frequency.Count
+	}
+
+	bank := &QuestionCommentBank{
+		Entries: make([]CommentBankEntry, 0, len(curated)+len(mined)),
+		Hidden:  make([]*models.FeedbackComment, 0),
+	}
+
+	seen := make(map[string]bool, len(curated))
+	for _, comment := range curated {
+		key := normalizeFeedback(comment.Text)
+		seen[key] = true
+		if comment.Hidden {
+			bank.Hidden = append(bank.Hidden, comment)
+			continue
+		}
+
+		commentID := comment.ID
+		bank.Entries = append(bank.Entries, CommentBankEntry{
+			CommentID:  &commentID,
+			Text:       comment.Text,
+			UsageCount: usage[key],
+			Pinned:     comment.Pinned,
+			Source:     commentSourceCurated,
+		})
+	}
+
+	for _, frequency := range mined {
+		key := normalizeFeedback(frequency.Text)
+		if seen[key] || frequency.Count < commentBankMinUsage {
+			continue
+		}
+		seen[key] = true
+		bank.Entries = append(bank.Entries, CommentBankEntry{
+			Text:       frequency.Text,
+			UsageCount: usage[key],
+			Source:     commentSourceHistory,
+		})
+	}
+
+	sort.SliceStable(bank.Entries, func(i, j int) bool {
+		if bank.Entries[i].Pinned != bank.Entries[j].Pinned {
+			return bank.Entries[i].Pinned
+		}
+		return bank.Entries[i].UsageCount > bank.Entries[j].UsageCount
+	})
+
+	return bank
+}
+
+// normalizeFeedback makes feedback comparable regardless of case and whitespace
+func normalizeFeedback(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
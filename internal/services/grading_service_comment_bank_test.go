@@ -0,0 +1,41 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestBuildCommentBank(t *testing.T) {
+	curated := []*models.FeedbackComment{
+		{ID: 1, Text: "Show your working", Pinned: true},
+		{ID: 2, Text: "Good use of examples"},
+		{ID: 3, Text: "Wrong unit", Hidden: true},
+	}
+	mined := []repositories.FeedbackFrequency{
+		{Text: "Forgot to carry the one", Count: 9},
+		{Text: "good use of  examples", Count: 4},
+		{Text: "wrong unit", Count: 3},
+		{Text: "Nice handwriting, Alex", Count: 1},
+	}
+
+	bank := buildCommentBank(curated, mined)
+
+	if len(bank.Hidden) != 1 || bank.Hidden[0].ID != 3 {
+		t.Fatalf("unexpected hidden entries: %+v", bank.Hidden)
+	}
+	if len(bank.Entries) != 3 {
+		t.Fatalf("expected 3 entries, got %+v", bank.Entries)
+	}
+
+	if e := bank.Entries[0]; !e.Pinned || e.CommentID == nil || *e.CommentID != 1 {
+		t.Errorf("pinned entry should come first, got %+v", e)
+	}
+	if e := bank.Entries[1]; e.Source != commentSourceHistory || e.UsageCount != 9 {
+		t.Errorf("most used mined feedback should follow pinned entries, got %+v", e)
+	}
+	if e := bank.Entries[2]; e.Source != commentSourceCurated || e.UsageCount != 4 {
+		t.Errorf("curated entry should carry usage of matching feedback, got %+v", e)
+	}
+}
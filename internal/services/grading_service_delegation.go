@@ -0,0 +1,282 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	maxGradingDelegationDuration = 90 * 24 * time.Hour
+	gradingDelegationAuditTarget = "grading_delegation"
+)
+
+// ===== GRADING DELEGATION =====
+
+// CreateGradingDelegation lets another teacher grade the assessment for a bounded window.
+// The delegate gains grading rights only; everything else stays with the owner.
+func (s *gradingService) CreateGradingDelegation(ctx context.Context, assessmentID uint, req *CreateGradingDelegationRequest, userID string) (*models.GradingDelegation, error) {
+	s.logger.Info("Delegating grading", "assessment_id", assessmentID, "delegate_id", req.DelegateID, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	owner, assessment, err := s.requireDelegationOwner(ctx, assessmentID, userID, "delegate_grading")
+	if err != nil {
+		return nil, err
+	}
+
+	delegate, err := s.repo.User().GetByID(ctx, req.DelegateID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if err := checkGradingDelegate(assessment, delegate); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	startsAt, expiresAt, err := gradingDelegationWindow(req.StartsAt, req.ExpiresAt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GradingDelegation().ListByAssessment(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if overlapping := overlappingDelegation(existing, delegate.ID, startsAt, expiresAt); overlapping != nil {
+		return nil, NewBusinessRuleError("delegation_overlaps", "teacher already has a grading delegation for this period", map[string]interface{}{
+			"delegation_id": overlapping.ID,
+		})
+	}
+
+	delegation := &models.GradingDelegation{
+		AssessmentID: assessmentID,
+		OwnerID:      owner.ID,
+		DelegateID:   delegate.ID,
+		Note:         req.Note,
+		StartsAt:     startsAt,
+		ExpiresAt:    expiresAt,
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.GradingDelegation().Create(ctx, tx, delegation); err != nil {
+			return err
+		}
+		entry, err := buildGradingDelegationAudit(models.AuditGradingDelegated, delegation, owner,
+			fmt.Sprintf("%s delegated grading of assessment %d to %s", owner.ID, assessmentID, delegate.ID),
+			map[string]interface{}{
+				"note": delegation.Note,
+			})
+		if err != nil {
+			return err
+		}
+		return s.repo.AuditLog().Create(ctx, tx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return delegation, nil
+}
+
+func (s *gradingService) ListGradingDelegations(ctx context.Context, assessmentID uint, userID string) ([]*models.GradingDelegation, error) {
+	if _, _, err := s.requireDelegationOwner(ctx, assessmentID, userID, "view_delegations"); err != nil {
+		return nil, err
+	}
+	return s.repo.GradingDelegation().ListByAssessment(ctx, nil, assessmentID)
+}
+
+func (s *gradingService) ListMyGradingDelegations(ctx context.Context, userID string) ([]*models.GradingDelegation, error) {
+	return s.repo.GradingDelegation().ListByDelegate(ctx, nil, userID)
+}
+
+// RevokeGradingDelegation ends a delegation before it expires; the delegate loses access at once
+func (s *gradingService) RevokeGradingDelegation(ctx context.Context, delegationID uint, userID string) (*models.GradingDelegation, error) {
+	s.logger.Info("Revoking grading delegation", "delegation_id", delegationID, "user_id", userID)
+
+	delegation, err := s.getGradingDelegation(ctx, delegationID)
+	if err != nil {
+		return nil, err
+	}
+	owner, _, err := s.requireDelegationOwner(ctx, delegation.AssessmentID, userID, "revoke_delegation")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if delegation.RevokedAt != nil || !now.Before(delegation.ExpiresAt) {
+		return nil, NewBusinessRuleError("delegation_ended", "grading delegation has already ended", map[string]interface{}{
+			"delegation_id": delegationID,
+		})
+	}
+	delegation.RevokedAt = timePtr(now)
+	delegation.RevokedBy = &owner.ID
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.GradingDelegation().Update(ctx, tx, delegation); err != nil {
+			return err
+		}
+		entry, err := buildGradingDelegationAudit(models.AuditDelegationRevoked, delegation, owner,
+			fmt.Sprintf("%s revoked grading delegation of assessment %d to %s", owner.ID, delegation.AssessmentID, delegation.DelegateID), nil)
+		if err != nil {
+			return err
+		}
+		return s.repo.AuditLog().Create(ctx, tx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return delegation, nil
+}
+
+func (s *gradingService) GetGradingDelegationAudit(ctx context.Context, delegationID uint, userID string) ([]*models.AuditLog, error) {
+	delegation, err := s.getGradingDelegation(ctx, delegationID)
+	if err != nil {
+		return nil, err
+	}
+	// Delegates may review their own actions
+	if delegation.DelegateID != userID {
+		if _, _, err := s.requireDelegationOwner(ctx, delegation.AssessmentID, userID, "view_delegation_audit"); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.AuditLog().GetByTarget(ctx, nil, gradingDelegationAuditTarget, delegationID)
+}
+
+// ===== HELPER METHODS =====
+
+// recordDelegatedGrading audit-logs a grading action taken under a delegation, attributed to
+// the delegate. Grading done with the grader's own access is not logged here. Failures are
+// logged only; the grade has already been saved.
+func (s *gradingService) recordDelegatedGrading(ctx context.Context, delegation *models.GradingDelegation, graderID, action string, details map[string]interface{}) {
+	if delegation == nil {
+		return
+	}
+	grader, err := s.repo.User().GetByID(ctx, graderID)
+	if err != nil {
+		s.logger.Error("Failed to get delegate for grading audit", "delegation_id", delegation.ID, "error", err)
+		return
+	}
+	entry, err := buildGradingDelegationAudit(models.AuditDelegatedGrading, delegation, grader,
+		fmt.Sprintf("%s %s on behalf of %s", grader.ID, action, delegation.OwnerID), details)
+	if err == nil {
+		err = s.repo.AuditLog().Create(ctx, nil, entry)
+	}
+	if err != nil {
+		s.logger.Error("Failed to audit delegated grading", "delegation_id", delegation.ID, "grader_id", graderID, "error", err)
+	}
+}
+
+// requireDelegationOwner lets only the assessment's owner, or an admin, manage its delegations.
+// Delegates cannot pass their access on.
+func (s *gradingService) requireDelegationOwner(ctx context.Context, assessmentID uint, userID, action string) (*models.User, *models.Assessment, error) {
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, nil, ErrAssessmentNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if assessment.CreatedBy != userID && user.Role != models.RoleAdmin {
+		return nil, nil, NewPermissionError(userID, assessmentID, "assessment", action, "not assessment owner")
+	}
+	return user, assessment, nil
+}
+
+func (s *gradingService) getGradingDelegation(ctx context.Context, id uint) (*models.GradingDelegation, error) {
+	delegation, err := s.repo.GradingDelegation().GetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get grading delegation: %w", err)
+	}
+	return delegation, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func checkGradingDelegate(assessment *models.Assessment, delegate *models.User) error {
+	if delegate.ID == assessment.CreatedBy {
+		return NewBusinessRuleError("delegate_to_owner", "the assessment owner can already grade it", nil)
+	}
+	if delegate.Role != models.RoleTeacher {
+		return NewBusinessRuleError("delegate_not_teacher", "grading can only be delegated to teachers", map[string]interface{}{
+			"delegate_id": delegate.ID,
+		})
+	}
+	if !delegate.IsActive {
+		return NewBusinessRuleError("delegate_inactive", "grading cannot be delegated to inactive users", map[string]interface{}{
+			"delegate_id": delegate.ID,
+		})
+	}
+	return nil
+}
+
+// gradingDelegationWindow resolves the requested access window. A window starting in the
+// past starts now, and no window may outlast maxGradingDelegationDuration.
+func gradingDelegationWindow(startsAt *time.Time, expiresAt, now time.Time) (time.Time, time.Time, error) {
+	start := now
+	if startsAt != nil && startsAt.After(now) {
+		start = *startsAt
+	}
+	if !expiresAt.After(start) {
+		return time.Time{}, time.Time{}, NewValidationError("expires_at", "must be after the delegation starts", expiresAt)
+	}
+	if expiresAt.Sub(start) > maxGradingDelegationDuration {
+		return time.Time{}, time.Time{}, NewValidationError("expires_at", "delegation cannot last longer than 90 days", expiresAt)
+	}
+	return start, expiresAt, nil
+}
+
+// overlappingDelegation returns a delegation to the same teacher that is not revoked and
+// shares part of the window, if any
+func overlappingDelegation(delegations []*models.GradingDelegation, delegateID string, startsAt, expiresAt time.Time) *models.GradingDelegation {
+	for _, d := range delegations {
+		if d.DelegateID == delegateID && d.RevokedAt == nil && d.StartsAt.Before(expiresAt) && startsAt.Before(d.ExpiresAt) {
+			return d
+		}
+	}
+	return nil
+}
+
+func buildGradingDelegationAudit(eventType models.AuditEventType, delegation *models.GradingDelegation, actor *models.User, description string, details map[string]interface{}) (*models.AuditLog, error) {
+	metadata := map[string]interface{}{
+		"assessment_id": delegation.AssessmentID,
+		"owner_id":      delegation.OwnerID,
+		"delegate_id":   delegation.DelegateID,
+		"starts_at":     delegation.StartsAt,
+		"expires_at":    delegation.ExpiresAt,
+	}
+	for key, value := range details {
+		metadata[key] = value
+	}
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit metadata: %w", err)
+	}
+
+	return &models.AuditLog{
+		EventType:       eventType,
+		UserID:          actor.ID,
+		UserEmail:       actor.Email,
+		UserRole:        actor.Role,
+		TargetType:      gradingDelegationAuditTarget,
+		TargetID:        &delegation.ID,
+		Description:     description,
+		Metadata:        encoded,
+		ComplianceLevel: "high",
+	}, nil
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestGradingDelegationIsActive(t *testing.T) {
+	start := time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)
+	delegation := &models.GradingDelegation{StartsAt: start, ExpiresAt: start.Add(48 * time.Hour)}
+
+	if delegation.IsActive(start.Add(-time.Minute)) {
+		t.Error("expected the delegation to be inactive before it starts")
+	}
+	if !delegation.IsActive(start) || !delegation.IsActive(start.Add(47*time.Hour)) {
+		t.Error("expected the delegation to be active within its window")
+	}
+	if delegation.IsActive(start.Add(48 * time.Hour)) {
+		t.Error("expected the delegation to expire")
+	}
+
+	delegation.RevokedAt = timePtr(start.Add(time.Hour))
+	if delegation.IsActive(start.Add(2 * time.Hour)) {
+		t.Error("expected a revoked delegation to be inactive")
+	}
+}
+
+func TestGradingDelegationWindow(t *testing.T) {
+	now := time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)
+
+	// A start in the past is moved to now
+	past := now.Add(-time.Hour)
+	start, end, err := gradingDelegationWindow(&past, now.Add(24*time.Hour), now)
+	if err != nil || !start.Equal(now) || !end.Equal(now.Add(24*time.Hour)) {
+		t.Errorf("unexpected window %v - %v (%v)", start, end, err)
+	}
+
+	later := now.Add(72 * time.Hour)
+	if start, _, err := gradingDelegationWindow(&later, later.Add(time.Hour), now); err != nil || !start.Equal(later) {
+		t.Errorf("expected the window to start later, got %v (%v)", start, err)
+	}
+
+	if _, _, err := gradingDelegationWindow(&later, later, now); err == nil {
+		t.Error("expected an empty window to be rejected")
+	}
+	if _, _, err := gradingDelegationWindow(nil, now.Add(-time.Minute), now); err == nil {
+		t.Error("expected a window in the past to be rejected")
+	}
+	if _, _, err := gradingDelegationWindow(nil, now.Add(maxGradingDelegationDuration+time.Hour), now); err == nil {
+		t.Error("expected a window over 90 days to be rejected")
+	}
+}
+
+func TestOverlappingDelegation(t *testing.T) {
+	start := time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)
+	existing := []*models.GradingDelegation{
+		{ID: 1, DelegateID: "teacher-b", StartsAt: start, ExpiresAt: start.Add(24 * time.Hour)},
+		{ID: 2, DelegateID: "teacher-c", StartsAt: start, ExpiresAt: start.Add(24 * time.Hour), RevokedAt: timePtr(start)},
+	}
+
+	if d := overlappingDelegation(existing, "teacher-b", start.Add(12*time.Hour), start.Add(36*time.Hour)); d == nil || d.ID != 1 {
+		t.Errorf("expected delegation 1 to overlap, got %v", d)
+	}
+	if d := overlappingDelegation(existing, "teacher-b", start.Add(24*time.Hour), start.Add(36*time.Hour)); d != nil {
+		t.Errorf("expected back to back windows not to overlap, got %d", d.ID)
+	}
+	if d := overlappingDelegation(existing, "teacher-c", start, start.Add(time.Hour)); d != nil {
+		t.Errorf("expected a revoked delegation not to count, got %d", d.ID)
+	}
+}
+
+func TestBuildGradingDelegationAudit(t *testing.T) {
+	delegation := &models.GradingDelegation{ID: 7, AssessmentID: 3, OwnerID: "teacher-a", DelegateID: "teacher-b"}
+	delegate := &models.User{ID: "teacher-b", Email: "b@example.com", Role: models.RoleTeacher}
+
+	entry, err := buildGradingDelegationAudit(models.AuditDelegatedGrading, delegation, delegate, "graded answer 42",
+		map[string]interface{}{"answer_id": 42})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry.UserID != "teacher-b" || entry.TargetType != gradingDelegationAuditTarget || *entry.TargetID != 7 {
+		t.Errorf("expected the entry to be attributed to the delegate under the delegation, got %+v", entry)
+	}
+
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(entry.Metadata, &metadata); err != nil {
+		t.Fatalf("invalid metadata: %v", err)
+	}
+	if metadata["owner_id"] != "teacher-a" || metadata["answer_id"] != float64(42) {
+		t.Errorf("unexpected metadata %v", metadata)
+	}
+}
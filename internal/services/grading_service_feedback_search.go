@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+const (
+	minFeedbackQueryLength    = 2
+	defaultFeedbackSearchSize = 20
+	maxFeedbackSearchSize     = 100
+)
+
+// ===== FEEDBACK SEARCH =====
+
+// SearchFeedback full-text searches the answer feedback and annotation comments the user
+// wrote as a grader. Graders only ever search their own history.
+func (s *gradingService) SearchFeedback(ctx context.Context, filters repositories.FeedbackSearchFilters, userID string) ([]repositories.FeedbackSearchHit, int64, error) {
+	filters, err := normalizeFeedbackSearch(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s.logger.Info("Searching grading feedback",
+		"grader_id", userID,
+		"assessment_id", filters.AssessmentID,
+		"question_type", filters.QuestionType)
+
+	return s.repo.Answer().SearchFeedback(ctx, nil, userID, filters)
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// normalizeFeedbackSearch trims the query and clamps the page size
+func normalizeFeedbackSearch(filters repositories.FeedbackSearchFilters) (repositories.FeedbackSearchFilters, error) {
+	filters.Query = strings.TrimSpace(filters.Query)
+	if utf8.RuneCountInString(filters.Query) < minFeedbackQueryLength {
+		return filters, NewValidationError("q", "search query must be at least 2 characters", filters.Query)
+	}
+	if filters.From != nil && filters.To != nil && !filters.From.Before(*filters.To) {
+		return filters, NewValidationError("to", "to must be after from", *filters.To)
+	}
+
+	if filters.Limit <= 0 {
+		filters.Limit = defaultFeedbackSearchSize
+	}
+	if filters.Limit > maxFeedbackSearchSize {
+		filters.Limit = maxFeedbackSearchSize
+	}
+	if filters.Offset < 0 {
+		filters.Offset = 0
+	}
+	return filters, nil
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestNormalizeFeedbackSearch(t *testing.T) {
+	filters, err := normalizeFeedbackSearch(repositories.FeedbackSearchFilters{Query: "  thesis statement ", Limit: 500, Offset: -5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filters.Query != "thesis statement" || filters.Limit != maxFeedbackSearchSize || filters.Offset != 0 {
+		t.Errorf("unexpected filters %+v", filters)
+	}
+
+	if filters, _ = normalizeFeedbackSearch(repositories.FeedbackSearchFilters{Query: "ok"}); filters.Limit != defaultFeedbackSearchSize {
+		t.Errorf("expected default page size, got %d", filters.Limit)
+	}
+}
+
+func TestNormalizeFeedbackSearchRejectsBadInput(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	cases := map[string]repositories.FeedbackSearchFilters{
+		"empty query":    {Query: "   "},
+		"short query":    {Query: " a "},
+		"inverted range": {Query: "citation", From: &now, To: &earlier},
+	}
+	for name, filters := range cases {
+		var validationErr *ValidationError
+		if _, err := normalizeFeedbackSearch(filters); !errors.As(err, &validationErr) {
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// flagEscalationDismiss closes an escalation without changing how the question is graded
+const flagEscalationDismiss = "dismiss"
+
+// ===== FLAG ESCALATIONS =====
+
+func (s *gradingService) GetFlagEscalations(ctx context.Context, assessmentID uint, status *models.FlagEscalationStatus, userID string) ([]*models.QuestionFlagEscalation, error) {
+	if err := s.checkAssessmentRegradeAccess(ctx, assessmentID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.FlagEscalation().GetByAssessment(ctx, nil, assessmentID, status)
+}
+
+// ResolveFlagEscalation applies the owner's remediation to an escalated question. Accepting
+// more answers or voiding the question only changes grading in the escalation's assessment:
+// it is recorded as an answer key change scoped to that assessment, attempts still in
+// progress are graded with it on submission, and finished attempts are queued for a regrade.
+func (s *gradingService) ResolveFlagEscalation(ctx context.Context, escalationID uint, req *ResolveFlagEscalationRequest, userID string) (*models.QuestionFlagEscalation, error) {
+	s.logger.Info("Resolving flag escalation", "escalation_id", escalationID, "action", req.Action, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	escalation, err := s.repo.FlagEscalation().GetByID(ctx, nil, escalationID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get flag escalation: %w", err)
+	}
+	if err := s.checkAssessmentRegradeAccess(ctx, escalation.AssessmentID, userID); err != nil {
+		return nil, err
+	}
+	if escalation.Status != models.FlagEscalationOpen {
+		return nil, NewBusinessRuleError("escalation_resolved", "the escalation was already resolved", map[string]interface{}{
+			"escalation_id": escalationID,
+			"status":        escalation.Status,
+		})
+	}
+
+	now := time.Now()
+	escalation.ResolvedBy = &userID
+	escalation.ResolvedAt = &now
+
+	if req.Action == flagEscalationDismiss {
+		escalation.Status = models.FlagEscalationDismissed
+		if err := s.repo.FlagEscalation().Update(ctx, nil, escalation); err != nil {
+			return nil, err
+		}
+		return escalation, nil
+	}
+
+	question, err := s.repo.Question().GetByID(ctx, nil, escalation.QuestionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	remediation := models.FlagRemediation(req.Action)
+	accepted, err := checkFlagRemediation(question, remediation, req.AcceptedAnswers)
+	if err != nil {
+		return nil, err
+	}
+
+	previousKey, err := questionAnswerKey(question.Type, question.Points, question.Content)
+	if err != nil {
+		return nil, err
+	}
+	newKey, err := remediatedAnswerKey(previousKey, remediation, accepted)
+	if err != nil {
+		return nil, err
+	}
+	change := &models.AnswerKeyChange{
+		QuestionID:   question.ID,
+		AssessmentID: &escalation.AssessmentID,
+		ChangedBy:    userID,
+		PreviousKey:  previousKey,
+		NewKey:       newKey,
+	}
+
+	escalation.Status = models.FlagEscalationRemediated
+	escalation.Remediation = &remediation
+	if accepted != nil {
+		if escalation.AcceptedAnswers, err = json.Marshal(accepted); err != nil {
+			return nil, fmt.Errorf("failed to encode accepted answers: %w", err)
+		}
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.AnswerKeyChange().CreateChange(ctx, tx, change); err != nil {
+			return err
+		}
+		escalation.AnswerKeyChangeID = &change.ID
+		return s.repo.FlagEscalation().Update(ctx, tx, escalation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Attempts finished so far were graded with the old key
+	job, err := s.ScheduleRegrade(ctx, change.ID, escalation.AssessmentID, userID)
+	var ruleErr *BusinessRuleError
+	switch {
+	case errors.As(err, &ruleErr) && ruleErr.Rule == "regrade_not_needed":
+	case err != nil:
+		// The remediation stands; the regrade can be scheduled again from the change report
+		s.logger.Error("Failed to schedule regrade for flag remediation", "escalation_id", escalationID, "change_id", change.ID, "error", err)
+	default:
+		escalation.RegradeJobID = &job.ID
+		if err := s.repo.FlagEscalation().Update(ctx, nil, escalation); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.Info("Flag escalation remediated",
+		"escalation_id", escalationID,
+		"remediation", remediation,
+		"change_id", change.ID,
+		"regrade_job_id", escalation.RegradeJobID)
+
+	return escalation, nil
+}
+
+// ===== HELPER METHODS =====
+
+// getFlagRemediation returns the remediation of the question in the assessment, or nil
+func (s *gradingService) getFlagRemediation(ctx context.Context, assessmentID, questionID uint) (*models.QuestionFlagEscalation, error) {
+	escalation, err := s.repo.FlagEscalation().GetByQuestion(ctx, nil, assessmentID, questionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get flag escalation: %w", err)
+	}
+	if escalation.Status != models.FlagEscalationRemediated {
+		return nil, nil
+	}
+	return escalation, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// checkFlagRemediation checks the remediation suits the question and returns the options to
+// accept. Only automatically graded questions can be remediated, and more answers can only
+// be accepted on single answer multiple choice questions; void the others instead.
+func checkFlagRemediation(question *models.Question, remediation models.FlagRemediation, acceptedAnswers []string) ([]string, error) {
+	switch question.Type {
+	case models.MultipleChoice, models.TrueFalse, models.FillInBlank, models.ShortAnswer, models.Matching, models.Ordering:
+	default:
+		return nil, NewBusinessRuleError("remediation_not_supported", "only automatically graded questions can be remediated; grade the answers by hand instead", map[string]interface{}{
+			"question_id":   question.ID,
+			"question_type": question.Type,
+		})
+	}
+
+	if remediation == models.FlagRemediationVoid {
+		if len(acceptedAnswers) > 0 {
+			return nil, ValidationErrors{*NewValidationError("accepted_answers", "only allowed when accepting answers", acceptedAnswers)}
+		}
+		return nil, nil
+	}
+
+	var content models.MultipleChoiceContent
+	if question.Type == models.MultipleChoice {
+		if err := json.Unmarshal(question.Content, &content); err != nil {
+			return nil, fmt.Errorf("invalid multiple choice content: %w", err)
+		}
+	}
+	if question.Type != models.MultipleChoice || content.MultipleCorrect {
+		return nil, NewBusinessRuleError("remediation_not_supported", "more answers can only be accepted on single answer multiple choice questions; void the question instead", map[string]interface{}{
+			"question_id":   question.ID,
+			"question_type": question.Type,
+		})
+	}
+	if len(acceptedAnswers) == 0 {
+		return nil, ValidationErrors{*NewValidationError("accepted_answers", "at least one option is required", acceptedAnswers)}
+	}
+
+	options := make(map[string]bool, len(content.Options))
+	for _, option := range content.Options {
+		options[option.ID] = true
+	}
+	correct := make(map[string]bool, len(content.CorrectAnswers))
+	for _, answer := range content.CorrectAnswers {
+		correct[answer] = true
+	}
+
+	var errs ValidationErrors
+	accepted := make([]string, 0, len(acceptedAnswers))
+	seen := make(map[string]bool, len(acceptedAnswers))
+	for _, id := range acceptedAnswers {
+		switch {
+		case !options[id]:
+			errs = append(errs, *NewValidationError("accepted_answers", "not an option of the question", id))
+		case correct[id]:
+			errs = append(errs, *NewValidationError("accepted_answers", "already a correct answer", id))
+		case !seen[id]:
+			seen[id] = true
+			accepted = append(accepted, id)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return accepted, nil
+}
+
+// remediatedAnswerKey records the remediation on top of the question's key
+func remediatedAnswerKey(previousKey []byte, remediation models.FlagRemediation, accepted []string) ([]byte, error) {
+	key := map[string]interface{}{}
+	if len(previousKey) > 0 {
+		if err := json.Unmarshal(previousKey, &key); err != nil {
+			return nil, fmt.Errorf("invalid answer key: %w", err)
+		}
+	}
+	key["remediation"] = remediation
+	if accepted != nil {
+		key["accepted_answers"] = accepted
+	}
+	return json.Marshal(key)
+}
+
+// remediatedScore applies a remediation to an automatically calculated score. A voided
+// question gives every answer full credit; an accepted option counts like the key.
+func remediatedScore(escalation *models.QuestionFlagEscalation, studentAnswer json.RawMessage, score float64, isCorrect bool) (float64, bool) {
+	if escalation == nil || escalation.Remediation == nil {
+		return score, isCorrect
+	}
+
+	switch *escalation.Remediation {
+	case models.FlagRemediationVoid:
+		return 1.0, true
+	case models.FlagRemediationAcceptAnswers:
+		var accepted []string
+		if err := json.Unmarshal(escalation.AcceptedAnswers, &accepted); err != nil {
+			return score, isCorrect
+		}
+		var selected []string
+		if err := json.Unmarshal(studentAnswer, &selected); err != nil {
+			var single string
+			if err := json.Unmarshal(studentAnswer, &single); err != nil {
+				return score, isCorrect
+			}
+			selected = []string{single}
+		}
+		if len(selected) != 1 {
+			return score, isCorrect
+		}
+		for _, option := range accepted {
+			if selected[0] == option {
+				return 1.0, true
+			}
+		}
+	}
+	return score, isCorrect
+}
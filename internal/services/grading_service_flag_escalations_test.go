@@ -0,0 +1,77 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func flaggedMultipleChoice(multipleCorrect bool) *models.Question {
+	content, _ := json.Marshal(models.MultipleChoiceContent{
+		Options:         []models.MCOption{{ID: "a", Text: "1"}, {ID: "b", Text: "2"}, {ID: "c", Text: "3"}},
+		CorrectAnswers:  []string{"a"},
+		MultipleCorrect: multipleCorrect,
+	})
+	return &models.Question{ID: 4, Type: models.MultipleChoice, Points: 2, Content: content}
+}
+
+func TestCheckFlagRemediation(t *testing.T) {
+	accepted, err := checkFlagRemediation(flaggedMultipleChoice(false), models.FlagRemediationAcceptAnswers, []string{"b", "b"})
+	if err != nil || len(accepted) != 1 || accepted[0] != "b" {
+		t.Fatalf("expected option b to be accepted once, got %v, %v", accepted, err)
+	}
+
+	var errs ValidationErrors
+	if _, err := checkFlagRemediation(flaggedMultipleChoice(false), models.FlagRemediationAcceptAnswers, []string{"a", "z"}); !errors.As(err, &errs) || len(errs) != 2 {
+		t.Errorf("expected the key and an unknown option to be rejected, got %v", err)
+	}
+	if _, err := checkFlagRemediation(flaggedMultipleChoice(false), models.FlagRemediationAcceptAnswers, nil); !errors.As(err, &errs) {
+		t.Errorf("expected an option to be required, got %v", err)
+	}
+
+	var ruleErr *BusinessRuleError
+	if _, err := checkFlagRemediation(flaggedMultipleChoice(true), models.FlagRemediationAcceptAnswers, []string{"b"}); !errors.As(err, &ruleErr) {
+		t.Errorf("expected accepting answers on a multiple answer question to be refused, got %v", err)
+	}
+	if _, err := checkFlagRemediation(&models.Question{Type: models.Essay}, models.FlagRemediationVoid, nil); !errors.As(err, &ruleErr) {
+		t.Errorf("expected an essay not to be remediated, got %v", err)
+	}
+
+	if accepted, err := checkFlagRemediation(&models.Question{Type: models.TrueFalse}, models.FlagRemediationVoid, nil); err != nil || accepted != nil {
+		t.Errorf("expected a true/false question to be voidable, got %v, %v", accepted, err)
+	}
+}
+
+func TestRemediatedAnswerKey(t *testing.T) {
+	key, err := remediatedAnswerKey([]byte(`{"correct_answers":["a"],"points":2}`), models.FlagRemediationAcceptAnswers, []string{"b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(key) != `{"accepted_answers":["b"],"correct_answers":["a"],"points":2,"remediation":"accept_answers"}` {
+		t.Errorf("unexpected key %s", key)
+	}
+}
+
+func TestRemediatedScore(t *testing.T) {
+	void := models.FlagRemediationVoid
+	if score, correct := remediatedScore(&models.QuestionFlagEscalation{Remediation: &void}, json.RawMessage(`["c"]`), 0, false); score != 1 || !correct {
+		t.Errorf("expected a voided question to give full credit, got %v, %v", score, correct)
+	}
+
+	accept := models.FlagRemediationAcceptAnswers
+	escalation := &models.QuestionFlagEscalation{Remediation: &accept, AcceptedAnswers: []byte(`["b"]`)}
+	if score, correct := remediatedScore(escalation, json.RawMessage(`"b"`), 0, false); score != 1 || !correct {
+		t.Errorf("expected an accepted option to count as correct, got %v, %v", score, correct)
+	}
+	if score, correct := remediatedScore(escalation, json.RawMessage(`["a"]`), 1, true); score != 1 || !correct {
+		t.Errorf("expected the key to stay correct, got %v, %v", score, correct)
+	}
+	if score, correct := remediatedScore(escalation, json.RawMessage(`["b","c"]`), 0, false); score != 0 || correct {
+		t.Errorf("expected selecting several options to stay wrong, got %v, %v", score, correct)
+	}
+	if score, correct := remediatedScore(nil, json.RawMessage(`["c"]`), 0, false); score != 0 || correct {
+		t.Errorf("expected no change without a remediation, got %v, %v", score, correct)
+	}
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	gradeChangeAuditTarget = "answer"
+	// Actor recorded for automatic grading and regrades
+	gradeChangeSystemActor = "system"
+
+	gradeChangeAuto     = "auto"
+	gradeChangeManual   = "manual"
+	gradeChangeRegrade  = "regrade"
+	gradeChangeOverride = "override"
+)
+
+// ===== GRADE HISTORY =====
+
+// GetAnswerGradeHistory returns every change of an answer's grade, oldest first, with the
+// fields each change touched
+func (s *gradingService) GetAnswerGradeHistory(ctx context.Context, answerID uint, userID string) (*AnswerGradeHistory, error) {
+	answer, err := s.repo.Answer().GetByIDWithDetails(ctx, nil, answerID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get answer: %w", err)
+	}
+	if _, err := s.checkGradingPermission(ctx, answer, userID); err != nil {
+		return nil, err
+	}
+
+	logs, err := s.repo.AuditLog().GetByTarget(ctx, nil, gradeChangeAuditTarget, answerID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := buildAnswerGradeHistory(answer, logs)
+	return &history, nil
+}
+
+// GetAttemptGradeHistory returns the grade history of each of the attempt's answers
+func (s *gradingService) GetAttemptGradeHistory(ctx context.Context, attemptID uint, userID string) ([]AnswerGradeHistory, error) {
+	attempt, err := s.repo.Attempt().GetByID(ctx, nil, attemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role != models.RoleTeacher && role != models.RoleAdmin {
+		return nil, NewPermissionError(userID, attemptID, "attempt", "view_grade_history", "insufficient role permissions")
+	}
+	if _, err := s.checkAssessmentGrading(ctx, attempt.AssessmentID, userID); err != nil {
+		return nil, err
+	}
+
+	answers, err := s.repo.Answer().GetByAttempt(ctx, nil, attemptID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answers: %w", err)
+	}
+	answerIDs := make([]uint, 0, len(answers))
+	for _, answer := range answers {
+		answerIDs = append(answerIDs, answer.ID)
+	}
+	logs, err := s.repo.AuditLog().GetByTargets(ctx, nil, gradeChangeAuditTarget, answerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byAnswer := make(map[uint][]*models.AuditLog)
+	for _, log := range logs {
+		if log.TargetID != nil {
+			byAnswer[*log.TargetID] = append(byAnswer[*log.TargetID], log)
+		}
+	}
+	histories := make([]AnswerGradeHistory, 0, len(answers))
+	for _, answer := range answers {
+		histories = append(histories, buildAnswerGradeHistory(answer, byAnswer[answer.ID]))
+	}
+	return histories, nil
+}
+
+// ===== HELPER METHODS =====
+
+// recordGradeChange audit-logs the change of an answer's grade since before. An empty
+// graderID marks automatic grading. Nothing is recorded when the grade did not change.
+func (s *gradingService) recordGradeChange(ctx context.Context, tx *gorm.DB, answer *models.StudentAnswer, before GradeSnapshot, graderID string) error {
+	change := buildGradeChange(before, gradeSnapshot(answer), graderID, time.Now())
+	if change == nil {
+		return nil
+	}
+
+	actor := &models.User{ID: gradeChangeSystemActor}
+	if graderID != "" {
+		grader, err := s.repo.User().GetByID(ctx, graderID)
+		if err != nil {
+			return fmt.Errorf("failed to get grader: %w", err)
+		}
+		actor = grader
+	}
+
+	entry, err := buildGradeChangeAudit(answer, change, actor)
+	if err != nil {
+		return err
+	}
+	return s.repo.AuditLog().Create(ctx, tx, entry)
+}
+
+// auditGradeChange records a grade change outside a transaction; the grade is already
+// stored, so a failure is only logged
+func (s *gradingService) auditGradeChange(ctx context.Context, answer *models.StudentAnswer, before GradeSnapshot, graderID string) {
+	if err := s.recordGradeChange(ctx, nil, answer, before, graderID); err != nil {
+		s.logger.Error("Failed to audit grade change", "answer_id", answer.ID, "error", err)
+	}
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func gradeSnapshot(answer *models.StudentAnswer) GradeSnapshot {
+	return GradeSnapshot{
+		Score:         answer.Score,
+		IsCorrect:     answer.IsCorrect,
+		Feedback:      answer.Feedback,
+		GradedBy:      answer.GradedBy,
+		GradedAt:      answer.GradedAt,
+		GradingStatus: answer.GradingStatus,
+	}
+}
+
+// buildGradeChange compares two grades, or returns nil when they are the same. The time
+// of grading alone is not a change.
+func buildGradeChange(before, after GradeSnapshot, graderID string, now time.Time) *GradeChange {
+	var fields []string
+	if before.Score != after.Score {
+		fields = append(fields, "score")
+	}
+	if !reflect.DeepEqual(before.IsCorrect, after.IsCorrect) {
+		fields = append(fields, "is_correct")
+	}
+	if !reflect.DeepEqual(before.Feedback, after.Feedback) {
+		fields = append(fields, "feedback")
+	}
+	if !reflect.DeepEqual(before.GradedBy, after.GradedBy) {
+		fields = append(fields, "graded_by")
+	}
+	if before.GradingStatus != after.GradingStatus {
+		fields = append(fields, "grading_status")
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	changedBy := graderID
+	if changedBy == "" {
+		changedBy = gradeChangeSystemActor
+	}
+	return &GradeChange{
+		Kind:      gradeChangeKind(after.GradingStatus, graderID),
+		Fields:    fields,
+		Before:    before,
+		After:     after,
+		ChangedBy: changedBy,
+		ChangedAt: now,
+	}
+}
+
+func gradeChangeKind(status models.AnswerGradingStatus, graderID string) string {
+	switch {
+	case status == models.GradingStatusRegraded:
+		return gradeChangeRegrade
+	case status == models.GradingStatusOverridden:
+		return gradeChangeOverride
+	case graderID != "":
+		return gradeChangeManual
+	default:
+		return gradeChangeAuto
+	}
+}
+
+func buildGradeChangeAudit(answer *models.StudentAnswer, change *GradeChange, actor *models.User) (*models.AuditLog, error) {
+	before, after := gradeFields(change.Before), gradeFields(change.After)
+	diff := make(map[string]interface{}, len(change.Fields))
+	for _, field := range change.Fields {
+		diff[field] = map[string]interface{}{"before": before[field], "after": after[field]}
+	}
+	changes, err := json.Marshal(diff)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit changes: %w", err)
+	}
+	metadata, err := json.Marshal(change)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit metadata: %w", err)
+	}
+
+	description := fmt.Sprintf("Grade of answer %d changed (%s) from %.2f to %.2f",
+		answer.ID, change.Kind, change.Before.Score, change.After.Score)
+
+	return &models.AuditLog{
+		EventType:       models.AuditGradeUpdated,
+		UserID:          actor.ID,
+		UserEmail:       actor.Email,
+		UserRole:        actor.Role,
+		TargetType:      gradeChangeAuditTarget,
+		TargetID:        &answer.ID,
+		Description:     description,
+		Changes:         changes,
+		Metadata:        metadata,
+		ComplianceLevel: "high",
+	}, nil
+}
+
+// gradeFields keys a grade's compared fields by the names used in GradeChange.Fields
+func gradeFields(grade GradeSnapshot) map[string]interface{} {
+	return map[string]interface{}{
+		"score":          grade.Score,
+		"is_correct":     grade.IsCorrect,
+		"feedback":       grade.Feedback,
+		"graded_by":      grade.GradedBy,
+		"grading_status": grade.GradingStatus,
+	}
+}
+
+// buildAnswerGradeHistory collects the answer's grade changes, oldest first, from its audit
+// log entries, newest first
+func buildAnswerGradeHistory(answer *models.StudentAnswer, logs []*models.AuditLog) AnswerGradeHistory {
+	history := AnswerGradeHistory{
+		AnswerID:   answer.ID,
+		AttemptID:  answer.AttemptID,
+		QuestionID: answer.QuestionID,
+		Current:    gradeSnapshot(answer),
+		Changes:    []GradeChange{},
+	}
+
+	for i := len(logs) - 1; i >= 0; i-- {
+		if logs[i].EventType != models.AuditGradeUpdated {
+			continue
+		}
+		var change GradeChange
+		if err := json.Unmarshal(logs[i].Metadata, &change); err != nil {
+			continue
+		}
+		history.Changes = append(history.Changes, change)
+	}
+
+	return history
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestBuildGradeChange(t *testing.T) {
+	now := time.Date(2025, 5, 2, 10, 0, 0, 0, time.UTC)
+	before := GradeSnapshot{Score: 2, IsCorrect: boolPtr(false), GradingStatus: models.GradingStatusAutoGraded}
+
+	if change := buildGradeChange(before, before, "", now); change != nil {
+		t.Errorf("expected no change for the same grade, got %+v", change)
+	}
+
+	graded := before
+	graded.GradedAt = &now
+	if change := buildGradeChange(before, graded, "", now); change != nil {
+		t.Errorf("expected the grading time alone not to be a change, got %+v", change)
+	}
+
+	after := GradeSnapshot{Score: 4, IsCorrect: boolPtr(false), Feedback: stringPtr("Partly right"), GradedBy: stringPtr("t1"), GradingStatus: models.GradingStatusOverridden}
+	change := buildGradeChange(before, after, "t1", now)
+	if change == nil {
+		t.Fatal("expected a change")
+	}
+	if change.Kind != gradeChangeOverride || change.ChangedBy != "t1" || !change.ChangedAt.Equal(now) {
+		t.Errorf("expected an override by t1, got %+v", change)
+	}
+	if len(change.Fields) != 4 || change.Fields[0] != "score" || change.Fields[3] != "grading_status" {
+		t.Errorf("expected score, feedback, grader and status to differ, got %v", change.Fields)
+	}
+}
+
+func TestGradeChangeKind(t *testing.T) {
+	cases := []struct {
+		status   models.AnswerGradingStatus
+		graderID string
+		want     string
+	}{
+		{models.GradingStatusAutoGraded, "", gradeChangeAuto},
+		{models.GradingStatusProvisional, "", gradeChangeAuto},
+		{models.GradingStatusManuallyGraded, "t1", gradeChangeManual},
+		{models.GradingStatusRegraded, "", gradeChangeRegrade},
+		{models.GradingStatusRegraded, "t1", gradeChangeRegrade},
+		{models.GradingStatusOverridden, "t1", gradeChangeOverride},
+	}
+	for _, tc := range cases {
+		if got := gradeChangeKind(tc.status, tc.graderID); got != tc.want {
+			t.Errorf("%s by %q: expected %s, got %s", tc.status, tc.graderID, tc.want, got)
+		}
+	}
+}
+
+func TestBuildGradeChangeAudit(t *testing.T) {
+	answer := &models.StudentAnswer{ID: 314}
+	change := buildGradeChange(GradeSnapshot{Score: 0}, GradeSnapshot{Score: 3, GradingStatus: models.GradingStatusAutoGraded}, "", time.Now())
+
+	entry, err := buildGradeChangeAudit(answer, change, &models.User{ID: gradeChangeSystemActor})
+	if err != nil {
+		t.Fatalf("expected an audit entry, got %v", err)
+	}
+	if entry.EventType != models.AuditGradeUpdated || entry.TargetType != gradeChangeAuditTarget || *entry.TargetID != 314 {
+		t.Errorf("expected a grade update of answer 314, got %+v", entry)
+	}
+
+	var changes map[string]map[string]interface{}
+	if err := json.Unmarshal(entry.Changes, &changes); err != nil {
+		t.Fatalf("expected decodable changes, got %v", err)
+	}
+	if len(changes) != 2 || changes["score"]["before"] != float64(0) || changes["score"]["after"] != float64(3) {
+		t.Errorf("expected only the changed fields with before and after, got %v", changes)
+	}
+}
+
+func TestBuildAnswerGradeHistory(t *testing.T) {
+	first := buildGradeChange(GradeSnapshot{}, GradeSnapshot{Score: 1, GradingStatus: models.GradingStatusAutoGraded}, "", time.Now())
+	second := buildGradeChange(first.After, GradeSnapshot{Score: 2, GradingStatus: models.GradingStatusOverridden}, "t1", time.Now())
+	answer := &models.StudentAnswer{ID: 5, AttemptID: 6, QuestionID: 7, Score: 2, GradingStatus: models.GradingStatusOverridden}
+
+	var logs []*models.AuditLog
+	for _, change := range []*GradeChange{second, first} { // Newest first, as stored
+		entry, err := buildGradeChangeAudit(answer, change, &models.User{ID: change.ChangedBy})
+		if err != nil {
+			t.Fatalf("expected an audit entry, got %v", err)
+		}
+		logs = append(logs, entry)
+	}
+	logs = append(logs, &models.AuditLog{EventType: models.AuditScoreOverridden})
+
+	history := buildAnswerGradeHistory(answer, logs)
+	if len(history.Changes) != 2 || history.Changes[0].Kind != gradeChangeAuto || history.Changes[1].Kind != gradeChangeOverride {
+		t.Errorf("expected the two grade changes oldest first, got %+v", history.Changes)
+	}
+	if history.Current.Score != 2 || history.AttemptID != 6 {
+		t.Errorf("expected the current grade of the answer, got %+v", history)
+	}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// Grades given further apart than this are treated as separate grading sessions, so the
+// gap is not counted as time spent on the answer
+const gradingSessionGap = 15 * time.Minute
+
+// ===== GRADER STATISTICS =====
+
+// GetGraderStats summarizes a grader's workload and consistency. Graders see their own
+// stats and admins see everyone's; teachers see a grader's work on their own assessments.
+func (s *gradingService) GetGraderStats(ctx context.Context, graderID string, assessmentID *uint, since *time.Time, userID string) (*GraderStats, error) {
+	filter := repositories.GraderStatsFilter{AssessmentID: assessmentID, Since: since}
+
+	if userID != graderID {
+		role, err := s.getUserRole(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		switch role {
+		case models.RoleAdmin:
+		case models.RoleTeacher:
+			filter.OwnerID = &userID
+		default:
+			return nil, NewPermissionError(userID, 0, "grader_stats", "view", "can only view own grading statistics")
+		}
+	}
+
+	activity, err := s.repo.Answer().GetGraderActivity(ctx, nil, graderID, filter)
+	if err != nil {
+		return nil, err
+	}
+	backlog, err := s.repo.Answer().CountGradingBacklog(ctx, nil, graderID, filter)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := s.repo.AnswerReview().GetByGrader(ctx, nil, graderID, filter)
+	if err != nil {
+		return nil, err
+	}
+	pendingReviews, err := s.repo.AnswerReview().CountPendingByReviewer(ctx, nil, graderID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := summarizeGraderActivity(graderID, activity)
+	stats.AssessmentID = assessmentID
+	stats.Since = since
+	stats.Backlog = backlog
+	stats.PendingReviews = pendingReviews
+
+	for _, reliability := range buildGraderReliabilityReport(0, reviews).Graders {
+		if reliability.GraderID != graderID || reliability.Reviewed == 0 {
+			continue
+		}
+		agreementRate := reliability.AgreementRate
+		meanDifference := reliability.MeanAbsoluteDifference
+		stats.Reviewed = reliability.Reviewed
+		stats.AgreementRate = &agreementRate
+		stats.MeanAbsoluteDifference = &meanDifference
+	}
+
+	return stats, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// summarizeGraderActivity computes volume, scoring and speed figures from grades ordered
+// oldest first
+func summarizeGraderActivity(graderID string, activity []repositories.GradedAnswerRecord) *GraderStats {
+	stats := &GraderStats{
+		GraderID:    graderID,
+		GeneratedAt: time.Now(),
+	}
+	if len(activity) == 0 {
+		return stats
+	}
+
+	assessments := make(map[uint]bool)
+	scoreSum, percentSum, turnaroundSum := 0.0, 0.0, 0.0
+	scored, turnarounds := 0, 0
+	gradedAt := make([]time.Time, 0, len(activity))
+
+	for _, record := range activity {
+		assessments[record.AssessmentID] = true
+		gradedAt = append(gradedAt, record.GradedAt)
+		scoreSum += record.Score
+		if record.MaxScore > 0 {
+			percentSum += record.Score / float64(record.MaxScore) * 100
+			scored++
+		}
+		if record.SubmittedAt != nil && record.GradedAt.After(*record.SubmittedAt) {
+			turnaroundSum += record.GradedAt.Sub(*record.SubmittedAt).Hours()
+			turnarounds++
+		}
+	}
+
+	stats.AnswersGraded = len(activity)
+	stats.AssessmentsGraded = len(assessments)
+	stats.AverageScore = roundTo(scoreSum/float64(len(activity)), 2)
+	if scored > 0 {
+		stats.AverageScorePercent = roundTo(percentSum/float64(scored), 1)
+	}
+	if turnarounds > 0 {
+		stats.AverageTurnaroundHours = roundTo(turnaroundSum/float64(turnarounds), 1)
+	}
+	if seconds, ok := estimateGradingSeconds(gradedAt); ok {
+		stats.AverageSecondsPerAnswer = &seconds
+	}
+
+	return stats
+}
+
+// estimateGradingSeconds averages the gaps between consecutive grades within a session.
+// The first grade of each session has no measurable duration and is left out.
+func estimateGradingSeconds(gradedAt []time.Time) (float64, bool) {
+	total, counted := 0.0, 0
+	for i := 1; i < len(gradedAt); i++ {
+		gap := gradedAt[i].Sub(gradedAt[i-1])
+		if gap < 0 || gap > gradingSessionGap {
+			continue
+		}
+		total += gap.Seconds()
+		counted++
+	}
+	if counted == 0 {
+		return 0, false
+	}
+	return roundTo(total/float64(counted), 1), true
+}
+
+func roundTo(value float64, places int) float64 {
+	factor := math.Pow(10, float64(places))
+	return math.Round(value*factor) / factor
+}
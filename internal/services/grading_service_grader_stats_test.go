@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestEstimateGradingSecondsSkipsSessionBreaks(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	gradedAt := []time.Time{
+		start,
+		start.Add(60 * time.Second),
+		start.Add(180 * time.Second),
+		start.Add(3 * time.Hour), // New session
+		start.Add(3*time.Hour + 30*time.Second),
+	}
+
+	seconds, ok := estimateGradingSeconds(gradedAt)
+	if !ok {
+		t.Fatal("expected an estimate")
+	}
+	// Gaps of 60, 120 and 30 seconds
+	if seconds != 70 {
+		t.Errorf("expected 70 seconds per answer, got %v", seconds)
+	}
+}
+
+func TestEstimateGradingSecondsWithoutConsecutiveGrades(t *testing.T) {
+	start := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	if _, ok := estimateGradingSeconds([]time.Time{start}); ok {
+		t.Error("expected no estimate for a single grade")
+	}
+	if _, ok := estimateGradingSeconds([]time.Time{start, start.Add(time.Hour)}); ok {
+		t.Error("expected no estimate when grades are in separate sessions")
+	}
+}
+
+func TestSummarizeGraderActivity(t *testing.T) {
+	submitted := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	activity := []repositories.GradedAnswerRecord{
+		{AnswerID: 1, AssessmentID: 10, Score: 4, MaxScore: 5, GradedAt: submitted.Add(2 * time.Hour), SubmittedAt: &submitted},
+		{AnswerID: 2, AssessmentID: 10, Score: 2, MaxScore: 10, GradedAt: submitted.Add(2*time.Hour + time.Minute), SubmittedAt: &submitted},
+		{AnswerID: 3, AssessmentID: 11, Score: 3, MaxScore: 0, GradedAt: submitted.Add(6 * time.Hour)},
+	}
+
+	stats := summarizeGraderActivity("ta-1", activity)
+
+	if stats.AnswersGraded != 3 || stats.AssessmentsGraded != 2 {
+		t.Errorf("expected 3 answers over 2 assessments, got %d over %d", stats.AnswersGraded, stats.AssessmentsGraded)
+	}
+	if stats.AverageScore != 3 {
+		t.Errorf("expected average score 3, got %v", stats.AverageScore)
+	}
+	// 80% and 20%; the answer without a max score is left out
+	if stats.AverageScorePercent != 50 {
+		t.Errorf("expected average score percent 50, got %v", stats.AverageScorePercent)
+	}
+	if stats.AverageTurnaroundHours != 2 {
+		t.Errorf("expected 2 hour turnaround, got %v", stats.AverageTurnaroundHours)
+	}
+	if stats.AverageSecondsPerAnswer == nil || *stats.AverageSecondsPerAnswer != 60 {
+		t.Errorf("expected 60 seconds per answer, got %v", stats.AverageSecondsPerAnswer)
+	}
+}
+
+func TestSummarizeGraderActivityEmpty(t *testing.T) {
+	stats := summarizeGraderActivity("ta-1", nil)
+	if stats.AnswersGraded != 0 || stats.AverageSecondsPerAnswer != nil {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+}
@@ -35,6 +35,8 @@ func (s *gradingService) CalculateScore(ctx context.Context, questionType models
 		return s.gradeMatching(questionContent, studentAnswer)
 	case models.Ordering:
 		return s.gradeOrdering(questionContent, studentAnswer)
+	case models.MultiPart:
+		return s.gradeMultiPart(ctx, questionContent, studentAnswer)
 	case models.Essay:
 		// Essays require manual grading
 		return 0.0, false, ErrGradingNotAllowed
@@ -383,28 +385,13 @@ func (s *gradingService) gradeOrdering(questionContent json.RawMessage, studentA
 		return 0.0, false, fmt.Errorf("failed to unmarshal question content: %w", err)
 	}
 
-	var answer []string // ordered list of item IDs
-	if err := json.Unmarshal(studentAnswer, &answer); err != nil {
-		return 0.0, false, fmt.Errorf("failed to unmarshal student answer: %w", err)
-	}
-
-	expectedOrder := content.CorrectOrder
-
-	// Perfect match
-	if reflect.DeepEqual(answer, expectedOrder) {
-		return 1.0, true, nil
-	}
-
-	// Partial credit based on position accuracy
-	correct := 0
-	for i, itemID := range answer {
-		if i < len(expectedOrder) && itemID == expectedOrder[i] {
-			correct++
-		}
+	answer, err := parseOrderingAnswer(studentAnswer)
+	if err != nil {
+		return 0.0, false, err
 	}
 
-	score := float64(correct) / float64(len(content.Items))
-	return score, false, nil
+	score, isCorrect := orderingScore(content, answer)
+	return score, isCorrect, nil
 }
 
 // ===== FEEDBACK GENERATION =====
@@ -435,15 +422,25 @@ func (s *gradingService) generateMultipleChoiceFeedback(questionContent json.Raw
 }
 
 func (s *gradingService) generateTrueFalseFeedback(questionContent json.RawMessage, studentAnswer json.RawMessage, isCorrect bool) string {
-	if isCorrect {
-		return "Correct!"
-	}
-
 	var content models.TrueFalseContent
 	if err := json.Unmarshal(questionContent, &content); err != nil {
+		if isCorrect {
+			return "Correct!"
+		}
 		return "Incorrect answer."
 	}
 
+	// Feedback written by the question author wins over the generated text
+	if isCorrect {
+		if content.CorrectFeedback != nil {
+			return *content.CorrectFeedback
+		}
+		return "Correct!"
+	}
+	if content.IncorrectFeedback != nil {
+		return *content.IncorrectFeedback
+	}
+
 	correctText := "True"
 	if !content.CorrectAnswer {
 		correctText = "False"
@@ -481,28 +478,57 @@ func (s *gradingService) generateOrderingFeedback(questionContent json.RawMessag
 
 // ===== HELPER FUNCTIONS =====
 
-func (s *gradingService) checkGradingPermission(ctx context.Context, answer *models.StudentAnswer, graderID string) error {
+func (s *gradingService) checkGradingPermission(ctx context.Context, answer *models.StudentAnswer, graderID string) (*models.GradingDelegation, error) {
 	// Get user role
 	userRole, err := s.getUserRole(ctx, graderID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Only teachers and admins can grade
 	if userRole != models.RoleTeacher && userRole != models.RoleAdmin {
-		return NewPermissionError(graderID, answer.ID, "answer", "grade", "insufficient role permissions")
+		return nil, NewPermissionError(graderID, answer.ID, "answer", "grade", "insufficient role permissions")
 	}
 
-	// Check if grader has access to the assessment
+	return s.checkAssessmentGrading(ctx, answer.Attempt.AssessmentID, graderID)
+}
+
+// checkAssessmentGrading lets users with access to the assessment grade it, as well as
+// teachers it is currently delegated to. The delegation, if that is what grants access, is
+// returned so that the delegate's actions can be audited under it.
+func (s *gradingService) checkAssessmentGrading(ctx context.Context, assessmentID uint, graderID string) (*models.GradingDelegation, error) {
 	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
-	canAccess, err := assessmentService.CanAccess(ctx, answer.Attempt.AssessmentID, graderID)
+	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, graderID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if !canAccess {
-		return NewPermissionError(graderID, answer.Attempt.AssessmentID, "assessment", "grade", "not owner or insufficient permissions")
+	if canAccess {
+		return nil, nil
 	}
 
+	delegation, err := s.repo.GradingDelegation().GetActive(ctx, nil, assessmentID, graderID, time.Now())
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, NewPermissionError(graderID, assessmentID, "assessment", "grade", "not owner or insufficient permissions")
+		}
+		return nil, fmt.Errorf("failed to get grading delegation: %w", err)
+	}
+	return delegation, nil
+}
+
+// applyTrialVariant grades an answer against the revision a question trial served, if any.
+// As when it was served, the revision keeps the original's ID, so saving the answer cannot
+// move it to the revision.
+func (s *gradingService) applyTrialVariant(ctx context.Context, answer *models.StudentAnswer) error {
+	if answer.VariantQuestionID == nil {
+		return nil
+	}
+	revision, err := s.repo.Question().GetByID(ctx, nil, *answer.VariantQuestionID)
+	if err != nil {
+		return fmt.Errorf("failed to get trial question: %w", err)
+	}
+	answer.Question = *revision
+	answer.Question.ID = answer.QuestionID
 	return nil
 }
 
@@ -528,7 +554,8 @@ func (s *gradingService) isAutoGradeable(questionType models.QuestionType) bool
 	return autoGradeableTypes[questionType]
 }
 
-func (s *gradingService) calculateLetterGrade(percentage float64) string {
+// letterGrade maps a percentage to the institution's letter grade scale
+func letterGrade(percentage float64) string {
 	if percentage >= 97 {
 		return "A+"
 	} else if percentage >= 93 {
@@ -566,16 +593,21 @@ func (s *gradingService) gradeAnswerInTransaction(ctx context.Context, tx *gorm.
 	}
 
 	// Update with grade
+	before := gradeSnapshot(answer)
 	maxScore := float64(answer.Question.Points)
 	answer.Score = score
 	answer.Feedback = feedback
 	answer.GradedBy = &graderID
 	answer.GradedAt = timePtr(time.Now())
 	answer.IsGraded = true
+	answer.GradingStatus = nextGradingStatus(answer.GradingStatus, gradingEventManuallyGraded)
 
 	if err := s.repo.Answer().Update(ctx, tx, answer); err != nil {
 		return nil, fmt.Errorf("failed to update answer: %w", err)
 	}
+	if err := s.recordGradeChange(ctx, tx, answer, before, graderID); err != nil {
+		return nil, fmt.Errorf("failed to audit grade change: %w", err)
+	}
 
 	return &GradingResult{
 		AnswerID:      answerID,
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"unicode"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+// Answers with fewer letters than this are not classified; short answers, names and
+// quotes are too easily mistaken for another language
+const minLanguageDetectionLetters = 60
+
+// scriptLanguages names the language of text written mostly in a script used by one language
+var scriptLanguages = []struct {
+	script   *unicode.RangeTable
+	language string
+}{
+	{unicode.Hangul, "ko"},
+	{unicode.Greek, "el"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+}
+
+// latinStopwords are frequent function words telling apart languages written in Latin script
+var latinStopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "in", "that", "it", "was", "for", "with", "as", "are", "this", "be", "not", "have", "by", "which", "they"},
+	"es": {"el", "la", "de", "que", "y", "en", "los", "las", "del", "se", "por", "un", "una", "para", "con", "es", "lo", "como", "más", "pero"},
+	"fr": {"le", "la", "les", "de", "des", "et", "est", "un", "une", "du", "que", "qui", "dans", "pour", "pas", "au", "sur", "ce", "il", "sont"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ein", "eine", "zu", "den", "von", "mit", "sich", "auf", "für", "dem", "im", "auch", "es", "werden"},
+	"it": {"il", "di", "che", "e", "la", "per", "un", "una", "non", "sono", "del", "della", "le", "gli", "con", "è", "si", "nel", "anche", "come"},
+	"pt": {"o", "a", "os", "as", "de", "que", "e", "do", "da", "em", "um", "uma", "para", "não", "com", "dos", "das", "por", "é", "mais"},
+	"nl": {"de", "het", "een", "en", "van", "is", "dat", "niet", "op", "te", "zijn", "met", "voor", "die", "er", "ook", "als", "maar", "wordt", "bij"},
+	"vi": {"và", "của", "là", "không", "có", "những", "được", "trong", "các", "một", "người", "này", "cho", "với", "để", "đã", "khi", "như", "từ", "cũng"},
+}
+
+// ===== ANSWER LANGUAGE CHECK =====
+
+// checkAnswerLanguages detects the language of an attempt's essay answers and flags those
+// written in another language than the assessment expects. Failures are logged only; the
+// check must not hold up grading.
+func (s *gradingService) checkAnswerLanguages(ctx context.Context, assessmentID uint, answers []*models.StudentAnswer) {
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, nil, assessmentID)
+	if err != nil {
+		s.logger.Warn("Failed to get assessment settings for language check", "assessment_id", assessmentID, "error", err)
+		return
+	}
+
+	for _, answer := range answers {
+		if answer.Question.Type != models.Essay {
+			continue
+		}
+		language, mismatch := essayLanguageCheck(answer.Answer, settings.ExpectedLanguage)
+		if sameLanguage(answer.DetectedLanguage, language) && answer.LanguageMismatch == mismatch {
+			continue
+		}
+		if err := s.repo.Answer().SetLanguage(ctx, nil, answer.ID, language, mismatch); err != nil {
+			s.logger.Warn("Failed to record answer language", "answer_id", answer.ID, "error", err)
+			continue
+		}
+		answer.DetectedLanguage = language
+		answer.LanguageMismatch = mismatch
+		if mismatch {
+			s.logger.Info("Essay answer in unexpected language",
+				"answer_id", answer.ID,
+				"detected_language", *language,
+				"expected_language", *settings.ExpectedLanguage)
+		}
+	}
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// essayLanguageCheck returns the language detected in an essay answer, nil when unsure,
+// and whether it differs from the expected one
+func essayLanguageCheck(raw datatypes.JSON, expected *string) (*string, bool) {
+	var essay models.EssayAnswer
+	if len(raw) == 0 || json.Unmarshal(raw, &essay) != nil {
+		return nil, false
+	}
+	detected := detectLanguage(essay.Text)
+	if detected == "" {
+		return nil, false
+	}
+	mismatch := expected != nil && *expected != "" && baseLanguage(*expected) != detected
+	return &detected, mismatch
+}
+
+// detectLanguage guesses the ISO 639-1 code of a text, or returns "" when the text is too
+// short or the guess is not clear. Scripts used by a single language decide on their own;
+// Latin-script text is scored by its most frequent function words.
+func detectLanguage(text string) string {
+	letters, latin, kana, han, cyrillic := 0, 0, 0, 0, 0
+	scripts := make([]int, len(scriptLanguages))
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		default:
+			for i, sl := range scriptLanguages {
+				if unicode.Is(sl.script, r) {
+					scripts[i]++
+					break
+				}
+			}
+		}
+	}
+
+	// Chinese and Japanese carry more meaning per character
+	if kana+han > 0 && (kana+han)*3 >= minLanguageDetectionLetters && (kana+han)*2 > letters {
+		// Japanese mixes kanji with kana; Chinese has none
+		if kana*10 >= kana+han {
+			return "ja"
+		}
+		return "zh"
+	}
+	if letters < minLanguageDetectionLetters {
+		return ""
+	}
+	if cyrillic*2 > letters {
+		if strings.ContainsAny(strings.ToLower(text), "іїєґ") {
+			return "uk"
+		}
+		return "ru"
+	}
+	for i, sl := range scriptLanguages {
+		if scripts[i]*2 > letters {
+			return sl.language
+		}
+	}
+	if latin*2 > letters {
+		return detectLatinLanguage(text)
+	}
+	return ""
+}
+
+// detectLatinLanguage picks the language whose function words are most frequent. It needs
+// a few hits and a clear lead over the runner-up.
+func detectLatinLanguage(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+
+	scores := make(map[string]int, len(latinStopwords))
+	for language, stopwords := range latinStopwords {
+		set := make(map[string]bool, len(stopwords))
+		for _, word := range stopwords {
+			set[word] = true
+		}
+		for _, word := range words {
+			if set[word] {
+				scores[language]++
+			}
+		}
+	}
+
+	best, bestScore, runnerUp := "", 0, 0
+	for language, score := range scores {
+		switch {
+		case score > bestScore:
+			best, bestScore, runnerUp = language, score, bestScore
+		case score > runnerUp:
+			runnerUp = score
+		}
+	}
+	if bestScore < 3 || bestScore*10 < len(words) || bestScore*4 < runnerUp*5 {
+		return ""
+	}
+	return best
+}
+
+// baseLanguage reduces a language tag such as "en-GB" to its ISO 639-1 code
+func baseLanguage(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+func sameLanguage(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
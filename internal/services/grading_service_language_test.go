@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+
+	"gorm.io/datatypes"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	cases := map[string]string{
+		"en": "The French Revolution was a period of political and social change in France. It began in 1789 and ended with the rise of Napoleon, who took power in a coup.",
+		"es": "La Revolución francesa fue un periodo de cambios políticos y sociales en Francia. Comenzó en 1789 y terminó con el ascenso de Napoleón, que tomó el poder.",
+		"fr": "La Révolution française est une période de bouleversements politiques et sociaux en France. Elle commence en 1789 et se termine avec la prise du pouvoir par Napoléon.",
+		"de": "Die Französische Revolution war eine Zeit politischer und sozialer Umbrüche in Frankreich. Sie begann im Jahr 1789 und endete mit dem Aufstieg von Napoleon.",
+		"vi": "Cách mạng Pháp là một thời kỳ biến động chính trị và xã hội ở Pháp. Nó bắt đầu vào năm 1789 và kết thúc khi Napoleon lên nắm quyền, người đã có công lớn.",
+		"ru": "Французская революция была периодом политических и социальных перемен во Франции, который начался в 1789 году и закончился приходом Наполеона.",
+		"zh": "法国大革命是法国政治和社会剧烈变革的时期，始于一七八九年，以拿破仑夺取政权而告终。",
+		"ja": "フランス革命は、フランスにおける政治的および社会的な大変動の時代であり、一七八九年に始まりました。",
+	}
+	for want, text := range cases {
+		if got := detectLanguage(text); got != want {
+			t.Errorf("expected %s, got %q for %q", want, got, text)
+		}
+	}
+}
+
+func TestDetectLanguageUnsure(t *testing.T) {
+	for _, text := range []string{
+		"Napoleon Bonaparte",               // too short
+		"1789 1799 1804 1815",              // no letters
+		"E = mc^2, F = ma, p = mv, W = Fd", // formulas, no function words
+	} {
+		if got := detectLanguage(text); got != "" {
+			t.Errorf("expected no guess for %q, got %q", text, got)
+		}
+	}
+}
+
+func TestEssayLanguageCheck(t *testing.T) {
+	answer := datatypes.JSON(`{"text": "La Revolución francesa fue un periodo de cambios políticos y sociales en Francia. Comenzó en 1789 y terminó con el ascenso de Napoleón, que tomó el poder."}`)
+
+	english := "en-GB"
+	language, mismatch := essayLanguageCheck(answer, &english)
+	if language == nil || *language != "es" || !mismatch {
+		t.Errorf("expected a Spanish answer to be flagged, got %v %v", language, mismatch)
+	}
+
+	spanish := "es"
+	if _, mismatch := essayLanguageCheck(answer, &spanish); mismatch {
+		t.Error("expected an answer in the expected language not to be flagged")
+	}
+	if _, mismatch := essayLanguageCheck(answer, nil); mismatch {
+		t.Error("expected no flag without an expected language")
+	}
+	if language, mismatch := essayLanguageCheck(datatypes.JSON(`{"text": "Paris"}`), &english); language != nil || mismatch {
+		t.Errorf("expected a short answer to be left alone, got %v %v", language, mismatch)
+	}
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// ===== MULTI-PART GRADING =====
+
+// GradeAnswerPart manually grades one part of a multi-part answer; the answer counts as
+// graded once every part is
+func (s *gradingService) GradeAnswerPart(ctx context.Context, answerID uint, partID string, score float64, feedback *string, graderID string) (*GradingResult, error) {
+	s.logger.Info("Manually grading answer part",
+		"answer_id", answerID,
+		"part_id", partID,
+		"score", score,
+		"grader_id", graderID)
+
+	answer, err := s.repo.Answer().GetByIDWithDetails(ctx, nil, answerID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, fmt.Errorf("answer not found")
+		}
+		return nil, fmt.Errorf("failed to get answer: %w", err)
+	}
+
+	delegation, err := s.checkGradingPermission(ctx, answer, graderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if answer.Question.Type != models.MultiPart {
+		return nil, NewValidationError("answer_id", "answer is not to a multi-part question", answerID)
+	}
+	content, partAnswers, partScores, err := decodeMultiPartAnswer(answer)
+	if err != nil {
+		return nil, err
+	}
+
+	part := findQuestionPart(content, partID)
+	if part == nil {
+		return nil, NewValidationError("part_id", "question has no such part", partID)
+	}
+	if score < 0 || score > float64(part.Points) {
+		return nil, NewValidationError("score", "score must be between 0 and the part's points", score)
+	}
+
+	now := time.Now()
+	isCorrect := score == float64(part.Points)
+	partScores = setPartScore(partScores, models.PartScore{
+		PartID:    partID,
+		Score:     score,
+		MaxScore:  part.Points,
+		IsCorrect: &isCorrect,
+		IsGraded:  true,
+		GradedBy:  &graderID,
+		GradedAt:  &now,
+		Feedback:  feedback,
+	})
+
+	result, err := s.saveMultiPartGrade(ctx, answer, content, partAnswers, partScores, gradingEventManuallyGraded, graderID, now)
+	if err != nil {
+		return nil, err
+	}
+	result.GradedBy = &graderID
+	s.recordDelegatedGrading(ctx, delegation, graderID, fmt.Sprintf("graded part %s of answer %d", partID, answerID), map[string]interface{}{
+		"answer_id": answerID,
+		"part_id":   partID,
+		"score":     score,
+	})
+
+	if answer.IsGraded {
+		go s.updateAttemptGradeIfComplete(answer.AttemptID)
+	}
+
+	return result, nil
+}
+
+// ===== HELPER METHODS =====
+
+// gradeMultiPart scores a multi-part answer as a share of the question's points; like
+// essays, questions with manually graded parts cannot be scored automatically
+func (s *gradingService) gradeMultiPart(ctx context.Context, questionContent json.RawMessage, studentAnswer json.RawMessage) (float64, bool, error) {
+	var content models.MultiPartContent
+	if err := json.Unmarshal(questionContent, &content); err != nil {
+		return 0.0, false, fmt.Errorf("failed to unmarshal question content: %w", err)
+	}
+	for _, part := range content.Parts {
+		if part.EffectiveGradingMode() == models.PartGradingManual {
+			return 0.0, false, ErrGradingNotAllowed
+		}
+	}
+
+	var partAnswers models.MultiPartAnswer
+	if err := json.Unmarshal(studentAnswer, &partAnswers); err != nil {
+		return 0.0, false, fmt.Errorf("failed to unmarshal student answer: %w", err)
+	}
+
+	total, maxTotal, _, allCorrect := summarizePartScores(s.gradeParts(ctx, &content, partAnswers, nil, time.Now()))
+	if maxTotal == 0 {
+		return 0.0, false, nil
+	}
+	return total / maxTotal, allCorrect, nil
+}
+
+// autoGradeMultiPartAnswer grades the automatic parts of a multi-part answer and reports
+// whether parts are still waiting for a teacher
+func (s *gradingService) autoGradeMultiPartAnswer(ctx context.Context, answer *models.StudentAnswer) (*GradingResult, bool, error) {
+	content, partAnswers, partScores, err := decodeMultiPartAnswer(answer)
+	if err != nil {
+		return nil, false, err
+	}
+
+	result, err := s.saveMultiPartGrade(ctx, answer, content, partAnswers, partScores, gradingEventAutoGraded, "", time.Now())
+	if err != nil {
+		return nil, false, err
+	}
+	return result, answer.IsGraded, nil
+}
+
+// saveMultiPartGrade scores the automatic parts, adds up all parts and stores the answer.
+// The grading status only moves once every part is graded. An empty graderID marks
+// automatic grading.
+func (s *gradingService) saveMultiPartGrade(ctx context.Context, answer *models.StudentAnswer, content *models.MultiPartContent, partAnswers models.MultiPartAnswer, partScores []models.PartScore, event gradingEvent, graderID string, now time.Time) (*GradingResult, error) {
+	before := gradeSnapshot(answer)
+	partScores = s.gradeParts(ctx, content, partAnswers, partScores, now)
+	total, maxTotal, graded, allCorrect := summarizePartScores(partScores)
+
+	encoded, err := json.Marshal(partScores)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode part scores: %w", err)
+	}
+
+	answer.PartScores = encoded
+	answer.Score = total
+	answer.IsGraded = graded
+	answer.IsCorrect = nil
+	answer.GradedAt = nil
+	if graded {
+		answer.IsCorrect = &allCorrect
+		answer.GradedAt = &now
+		answer.GradingStatus = nextGradingStatus(answer.GradingStatus, event)
+	}
+
+	if err := s.repo.Answer().Update(ctx, nil, answer); err != nil {
+		return nil, fmt.Errorf("failed to update answer grade: %w", err)
+	}
+	s.auditGradeChange(ctx, answer, before, graderID)
+
+	return &GradingResult{
+		AnswerID:      answer.ID,
+		QuestionID:    answer.QuestionID,
+		Score:         total,
+		MaxScore:      maxTotal,
+		IsCorrect:     graded && allCorrect,
+		PartialCredit: total > 0 && total < maxTotal,
+		Feedback:      answer.Feedback,
+		GradedAt:      now,
+		PartScores:    partScores,
+	}, nil
+}
+
+// gradeParts returns a score for every part in question order. Automatic parts are scored
+// from the student's answer, with an unreadable answer earning nothing; manual parts keep
+// the grade a teacher gave and stay ungraded until then.
+func (s *gradingService) gradeParts(ctx context.Context, content *models.MultiPartContent, partAnswers models.MultiPartAnswer, existing []models.PartScore, now time.Time) []models.PartScore {
+	previous := make(map[string]models.PartScore, len(existing))
+	for _, partScore := range existing {
+		previous[partScore.PartID] = partScore
+	}
+
+	scores := make([]models.PartScore, 0, len(content.Parts))
+	for _, part := range content.Parts {
+		partScore := models.PartScore{PartID: part.ID, MaxScore: part.Points}
+
+		if part.EffectiveGradingMode() == models.PartGradingManual {
+			if prev, ok := previous[part.ID]; ok && prev.IsGraded {
+				partScore = prev
+				partScore.MaxScore = part.Points
+			}
+			scores = append(scores, partScore)
+			continue
+		}
+
+		var fraction float64
+		var isCorrect bool
+		if raw := partAnswers[part.ID]; len(raw) > 0 {
+			var err error
+			fraction, isCorrect, err = s.CalculateScore(ctx, part.Type, json.RawMessage(part.Content), json.RawMessage(raw))
+			if err != nil {
+				fraction, isCorrect = 0, false
+			}
+		}
+
+		partScore.Score = fraction * float64(part.Points)
+		partScore.IsCorrect = &isCorrect
+		partScore.IsGraded = true
+		partScore.GradedAt = &now
+		scores = append(scores, partScore)
+	}
+	return scores
+}
+
+// summarizePartScores adds up the parts of an answer; it is graded once every part is
+func summarizePartScores(scores []models.PartScore) (total, maxTotal float64, graded, allCorrect bool) {
+	graded, allCorrect = true, true
+	for _, partScore := range scores {
+		total += partScore.Score
+		maxTotal += float64(partScore.MaxScore)
+		if !partScore.IsGraded {
+			graded = false
+		}
+		if partScore.IsCorrect == nil || !*partScore.IsCorrect {
+			allCorrect = false
+		}
+	}
+	return total, maxTotal, graded, allCorrect
+}
+
+func decodeMultiPartAnswer(answer *models.StudentAnswer) (*models.MultiPartContent, models.MultiPartAnswer, []models.PartScore, error) {
+	var content models.MultiPartContent
+	if err := json.Unmarshal(answer.Question.Content, &content); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to unmarshal question content: %w", err)
+	}
+
+	partAnswers := models.MultiPartAnswer{}
+	if len(answer.Answer) > 0 {
+		if err := json.Unmarshal(answer.Answer, &partAnswers); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to unmarshal student answer: %w", err)
+		}
+	}
+
+	var partScores []models.PartScore
+	if len(answer.PartScores) > 0 {
+		if err := json.Unmarshal(answer.PartScores, &partScores); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to decode part scores: %w", err)
+		}
+	}
+
+	return &content, partAnswers, partScores, nil
+}
+
+func findQuestionPart(content *models.MultiPartContent, partID string) *models.QuestionPart {
+	for i := range content.Parts {
+		if content.Parts[i].ID == partID {
+			return &content.Parts[i]
+		}
+	}
+	return nil
+}
+
+// setPartScore replaces the part's score, or adds it when the part has none yet
+func setPartScore(scores []models.PartScore, partScore models.PartScore) []models.PartScore {
+	for i := range scores {
+		if scores[i].PartID == partScore.PartID {
+			scores[i] = partScore
+			return scores
+		}
+	}
+	return append(scores, partScore)
+}
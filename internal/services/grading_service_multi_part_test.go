@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+func multiPartFixture() *models.MultiPartContent {
+	return &models.MultiPartContent{Parts: []models.QuestionPart{
+		{ID: "a", Label: "a", Type: models.TrueFalse, Points: 2, Content: datatypes.JSON(`{"correct_answer":true}`)},
+		{ID: "b", Label: "b", Type: models.ShortAnswer, Points: 3, Content: datatypes.JSON(`{"accepted_answers":["paris"],"max_length":50}`)},
+		{ID: "c", Label: "c", Type: models.Essay, Points: 5, Content: datatypes.JSON(`{}`)},
+	}}
+}
+
+func TestGradeParts(t *testing.T) {
+	s := &gradingService{}
+	now := time.Now()
+	answers := models.MultiPartAnswer{
+		"a": datatypes.JSON(`true`),
+		"b": datatypes.JSON(`"Paris"`),
+		"c": datatypes.JSON(`"An essay"`),
+	}
+
+	scores := s.gradeParts(context.Background(), multiPartFixture(), answers, nil, now)
+	if len(scores) != 3 {
+		t.Fatalf("expected 3 part scores, got %d", len(scores))
+	}
+	if scores[0].Score != 2 || !scores[0].IsGraded || scores[1].Score != 3 || !scores[1].IsGraded {
+		t.Errorf("expected automatic parts at full marks, got %+v %+v", scores[0], scores[1])
+	}
+	if scores[2].IsGraded || scores[2].MaxScore != 5 {
+		t.Errorf("expected the essay part to wait for a teacher, got %+v", scores[2])
+	}
+
+	total, maxTotal, graded, _ := summarizePartScores(scores)
+	if total != 5 || maxTotal != 10 || graded {
+		t.Errorf("unexpected summary: %v/%v graded=%v", total, maxTotal, graded)
+	}
+
+	// A teacher's grade on the manual part survives regrading; a wrong answer earns nothing
+	correct := false
+	teacher := "teacher-1"
+	manual := models.PartScore{PartID: "c", Score: 4, MaxScore: 5, IsCorrect: &correct, IsGraded: true, GradedBy: &teacher}
+	answers["a"] = datatypes.JSON(`false`)
+	scores = s.gradeParts(context.Background(), multiPartFixture(), answers, []models.PartScore{manual}, now)
+
+	total, _, graded, allCorrect := summarizePartScores(scores)
+	if total != 7 || !graded || allCorrect {
+		t.Errorf("unexpected summary after manual grading: %v graded=%v correct=%v", total, graded, allCorrect)
+	}
+	if scores[2].GradedBy == nil || *scores[2].GradedBy != teacher {
+		t.Errorf("expected the manual grade to be kept, got %+v", scores[2])
+	}
+}
+
+func TestGradeParts_UnansweredAndUnreadable(t *testing.T) {
+	s := &gradingService{}
+	answers := models.MultiPartAnswer{"a": datatypes.JSON(`"not a bool"`)}
+
+	scores := s.gradeParts(context.Background(), multiPartFixture(), answers, nil, time.Now())
+	for _, partScore := range scores[:2] {
+		if partScore.Score != 0 || !partScore.IsGraded || partScore.IsCorrect == nil || *partScore.IsCorrect {
+			t.Errorf("expected part %s graded wrong, got %+v", partScore.PartID, partScore)
+		}
+	}
+}
+
+func TestGradeMultiPart(t *testing.T) {
+	s := &gradingService{}
+	content := multiPartFixture()
+	answer := json.RawMessage(`{"a":true,"b":"london"}`)
+
+	encoded, _ := json.Marshal(content)
+	if _, _, err := s.gradeMultiPart(context.Background(), encoded, answer); err != ErrGradingNotAllowed {
+		t.Errorf("expected manual parts to block automatic scoring, got %v", err)
+	}
+
+	content.Parts = content.Parts[:2]
+	encoded, _ = json.Marshal(content)
+	score, isCorrect, err := s.gradeMultiPart(context.Background(), encoded, answer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if score != 0.4 || isCorrect {
+		t.Errorf("expected 2 of 5 points, got %v correct=%v", score, isCorrect)
+	}
+}
+
+func TestSetPartScore(t *testing.T) {
+	scores := []models.PartScore{{PartID: "a", Score: 1}}
+	scores = setPartScore(scores, models.PartScore{PartID: "a", Score: 2})
+	scores = setPartScore(scores, models.PartScore{PartID: "b", Score: 3})
+
+	if len(scores) != 2 || scores[0].Score != 2 || scores[1].PartID != "b" {
+		t.Errorf("unexpected part scores: %+v", scores)
+	}
+}
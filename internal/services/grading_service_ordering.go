@@ -0,0 +1,148 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// ===== HELPER FUNCTIONS =====
+
+// parseOrderingAnswer reads an ordering answer, given either as the list of item IDs or as
+// an object with the list in "order"
+func parseOrderingAnswer(raw []byte) ([]string, error) {
+	var order []string
+	if err := json.Unmarshal(raw, &order); err == nil {
+		return order, nil
+	}
+	var answer models.OrderingAnswer
+	if err := json.Unmarshal(raw, &answer); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal student answer: %w", err)
+	}
+	return answer.Order, nil
+}
+
+// validOrderingScoring reports whether scoring names a known method; empty means position
+func validOrderingScoring(scoring models.OrderingScoring) bool {
+	switch scoring {
+	case "", models.OrderingScoringPosition, models.OrderingScoringKendallTau, models.OrderingScoringAdjacentPairs:
+		return true
+	}
+	return false
+}
+
+// orderingScore grades an order against the correct one. Questions that choose a scoring
+// method score only the exact order without partial credit; otherwise the method decides
+// the share earned. Questions written before scoring methods existed keep position credit.
+func orderingScore(content models.OrderingContent, answer []string) (float64, bool) {
+	expected := content.CorrectOrder
+	if len(expected) == 0 {
+		return 0.0, false
+	}
+	if slices.Equal(answer, expected) {
+		return 1.0, true
+	}
+	if content.Scoring == "" {
+		return legacyPositionScore(content, answer), false
+	}
+	if !content.PartialCredit {
+		return 0.0, false
+	}
+
+	switch content.Scoring {
+	case models.OrderingScoringKendallTau:
+		return kendallTauScore(expected, answer), false
+	case models.OrderingScoringAdjacentPairs:
+		return adjacentPairsScore(expected, answer), false
+	default:
+		return positionScore(expected, answer), false
+	}
+}
+
+// positionScore is the share of items placed at their correct position
+func positionScore(expected, answer []string) float64 {
+	correct := 0
+	for i, itemID := range answer {
+		if i < len(expected) && itemID == expected[i] {
+			correct++
+		}
+	}
+	return float64(correct) / float64(len(expected))
+}
+
+// legacyPositionScore is the position credit ordering questions without a scoring method
+// always earned, whatever partial_credit says, out of the number of items
+func legacyPositionScore(content models.OrderingContent, answer []string) float64 {
+	total := len(content.Items)
+	if total == 0 {
+		total = len(content.CorrectOrder)
+	}
+	correct := 0
+	for i, itemID := range answer {
+		if i < len(content.CorrectOrder) && itemID == content.CorrectOrder[i] {
+			correct++
+		}
+	}
+	return float64(correct) / float64(total)
+}
+
+// kendallTauScore is Kendall's tau between the answer and the correct order, floored at 0 so
+// an order no better than chance earns nothing. Pairs with an item missing from the answer
+// count as discordant.
+func kendallTauScore(expected, answer []string) float64 {
+	if len(expected) < 2 {
+		return 0.0
+	}
+	positions := orderingPositions(answer)
+
+	concordant, discordant := 0, 0
+	for i := 0; i < len(expected); i++ {
+		for j := i + 1; j < len(expected); j++ {
+			first, okFirst := positions[expected[i]]
+			second, okSecond := positions[expected[j]]
+			if okFirst && okSecond && first < second {
+				concordant++
+			} else {
+				discordant++
+			}
+		}
+	}
+
+	tau := float64(concordant-discordant) / float64(concordant+discordant)
+	if tau < 0 {
+		return 0.0
+	}
+	return tau
+}
+
+// adjacentPairsScore is the share of items directly followed by the same item as in the
+// correct order, so a correct run moved as a block keeps most of its credit
+func adjacentPairsScore(expected, answer []string) float64 {
+	if len(expected) < 2 {
+		return 0.0
+	}
+	positions := orderingPositions(answer)
+
+	kept := 0
+	for i := 0; i+1 < len(expected); i++ {
+		first, okFirst := positions[expected[i]]
+		second, okSecond := positions[expected[i+1]]
+		if okFirst && okSecond && second == first+1 {
+			kept++
+		}
+	}
+	return float64(kept) / float64(len(expected)-1)
+}
+
+// orderingPositions maps each item of an answer to its position, keeping the first of repeats
+func orderingPositions(answer []string) map[string]int {
+	positions := make(map[string]int, len(answer))
+	for i, itemID := range answer {
+		if _, seen := positions[itemID]; !seen {
+			positions[itemID] = i
+		}
+	}
+	return positions
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestParseOrderingAnswer(t *testing.T) {
+	for _, raw := range []string{`["b","a"]`, `{"order":["b","a"],"time_spent":12}`} {
+		order, err := parseOrderingAnswer([]byte(raw))
+		if err != nil || len(order) != 2 || order[0] != "b" {
+			t.Errorf("%s: unexpected order %v (%v)", raw, order, err)
+		}
+	}
+	if _, err := parseOrderingAnswer([]byte(`"b,a"`)); err == nil {
+		t.Error("expected an error for a string answer")
+	}
+}
+
+func TestOrderingScore(t *testing.T) {
+	content := models.OrderingContent{CorrectOrder: []string{"a", "b", "c", "d"}, PartialCredit: true}
+	cases := []struct {
+		name    string
+		scoring models.OrderingScoring
+		answer  []string
+		want    float64
+	}{
+		{"position default", "", []string{"a", "b", "d", "c"}, 0.5},
+		{"position shifted", models.OrderingScoringPosition, []string{"d", "a", "b", "c"}, 0},
+		{"kendall one swap", models.OrderingScoringKendallTau, []string{"a", "b", "d", "c"}, 4.0 / 6},
+		{"kendall shifted", models.OrderingScoringKendallTau, []string{"d", "a", "b", "c"}, 0},
+		{"kendall reversed", models.OrderingScoringKendallTau, []string{"d", "c", "b", "a"}, 0},
+		{"kendall missing item", models.OrderingScoringKendallTau, []string{"a", "b", "c"}, 0},
+		{"adjacent shifted", models.OrderingScoringAdjacentPairs, []string{"d", "a", "b", "c"}, 2.0 / 3},
+		{"adjacent repeated item", models.OrderingScoringAdjacentPairs, []string{"a", "a", "b", "c"}, 1.0 / 3},
+		{"adjacent reversed", models.OrderingScoringAdjacentPairs, []string{"d", "c", "b", "a"}, 0},
+	}
+	for _, c := range cases {
+		content.Scoring = c.scoring
+		score, isCorrect := orderingScore(content, c.answer)
+		if math.Abs(score-c.want) > 1e-9 || isCorrect {
+			t.Errorf("%s: expected %.3f, got %.3f (correct %v)", c.name, c.want, score, isCorrect)
+		}
+	}
+
+	content.Scoring = models.OrderingScoringKendallTau
+	if score, isCorrect := orderingScore(content, []string{"a", "b", "c", "d"}); score != 1 || !isCorrect {
+		t.Errorf("expected full credit for the correct order, got %v %v", score, isCorrect)
+	}
+	content.PartialCredit = false
+	if score, _ := orderingScore(content, []string{"a", "b", "d", "c"}); score != 0 {
+		t.Errorf("expected no credit without partial credit, got %v", score)
+	}
+}
+
+func TestGradeOrderingPreExistingQuestion(t *testing.T) {
+	// Stored before scoring methods existed: no scoring and no partial credit
+	content := []byte(`{"items":[{"id":"a","text":"A"},{"id":"b","text":"B"},{"id":"c","text":"C"},{"id":"d","text":"D"},{"id":"e","text":"E"}],"correct_order":["a","b","c","d","e"],"randomize_initial":false}`)
+
+	score, isCorrect, err := (&gradingService{}).gradeOrdering(content, []byte(`["a","b","c","e","d"]`))
+	if err != nil {
+		t.Fatalf("gradeOrdering: %v", err)
+	}
+	if math.Abs(score-0.6) > 1e-9 || isCorrect {
+		t.Errorf("expected the position credit it earned before, 0.6, got %.3f (correct %v)", score, isCorrect)
+	}
+}
+
+func TestValidOrderingScoring(t *testing.T) {
+	for _, scoring := range []models.OrderingScoring{"", models.OrderingScoringPosition, models.OrderingScoringKendallTau, models.OrderingScoringAdjacentPairs} {
+		if !validOrderingScoring(scoring) {
+			t.Errorf("expected %q to be valid", scoring)
+		}
+	}
+	if validOrderingScoring("spearman") {
+		t.Error("expected an unknown method to be rejected")
+	}
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// ===== FINAL SCORE OVERRIDES =====
+
+func (s *gradingService) OverrideAttemptScore(ctx context.Context, attemptID uint, req *OverrideAttemptScoreRequest, userID string) (*ScoreOverrideHistory, error) {
+	s.logger.Info("Overriding attempt score",
+		"attempt_id", attemptID,
+		"revert", req.Score == nil,
+		"user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	attempt, err := s.repo.Attempt().GetByID(ctx, nil, attemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+	if attempt.Status == models.AttemptInProgress {
+		return nil, NewBusinessRuleError("score_override_in_progress", "attempt must be finished before its score can be overridden", map[string]interface{}{
+			"attempt_id": attemptID,
+		})
+	}
+
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, attempt.AssessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	// Only the assessment owner decides final scores
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if assessment.CreatedBy != userID && user.Role != models.RoleAdmin {
+		return nil, NewPermissionError(userID, attemptID, "attempt", "override_score", "not assessment owner")
+	}
+
+	totalPoints, err := s.repo.AssessmentQuestion().GetTotalPoints(ctx, nil, assessment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment total points: %w", err)
+	}
+	if req.Score != nil && *req.Score > float64(totalPoints) {
+		return nil, NewValidationError("score", fmt.Sprintf("score cannot exceed the assessment's %d points", totalPoints), *req.Score)
+	}
+
+	now := time.Now()
+	entry, err := applyScoreOverride(attempt, req, totalPoints, assessment.PassingScore, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := buildScoreOverrideAudit(attempt, entry, user)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.Attempt().Update(ctx, tx, attempt); err != nil {
+			return fmt.Errorf("failed to update attempt score: %w", err)
+		}
+		if err := s.repo.AuditLog().Create(ctx, tx, audit); err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetScoreOverrideHistory(ctx, attemptID, userID)
+}
+
+func (s *gradingService) GetScoreOverrideHistory(ctx context.Context, attemptID uint, userID string) (*ScoreOverrideHistory, error) {
+	attempt, err := s.repo.Attempt().GetByID(ctx, nil, attemptID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAttemptNotFound
+		}
+		return nil, fmt.Errorf("failed to get attempt: %w", err)
+	}
+
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	canAccess, err := assessmentService.CanAccess(ctx, attempt.AssessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, attemptID, "attempt", "view_score_overrides", "not owner or insufficient permissions")
+	}
+
+	logs, err := s.repo.AuditLog().GetByTarget(ctx, nil, "attempt", attemptID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildScoreOverrideHistory(attempt, logs), nil
+}
+
+// ===== HELPER METHODS =====
+
+// recordComputedScore stores a freshly computed result. Overridden attempts keep their
+// final score and only the computed score shown alongside it changes.
+func recordComputedScore(attempt *models.AssessmentAttempt, score, percentage float64, passed bool) {
+	if attempt.ScoreOverridden {
+		attempt.ComputedScore = &score
+		attempt.ComputedPercentage = &percentage
+		attempt.ComputedPassed = &passed
+		return
+	}
+
+	attempt.Score = score
+	attempt.Percentage = percentage
+	attempt.Passed = passed
+}
+
+// applyScoreOverride sets or reverts the attempt's final score. The first override keeps
+// the computed result so that later overrides and reverts never lose it.
+func applyScoreOverride(attempt *models.AssessmentAttempt, req *OverrideAttemptScoreRequest, totalPoints, passingScore int, userID string, now time.Time) (*ScoreOverrideEntry, error) {
+	entry := &ScoreOverrideEntry{
+		PreviousScore: attempt.Score,
+		ComputedScore: attempt.Score,
+		Justification: req.Justification,
+		OverriddenBy:  userID,
+		OverriddenAt:  now,
+	}
+	if attempt.ScoreOverridden && attempt.ComputedScore != nil {
+		entry.ComputedScore = *attempt.ComputedScore
+	}
+
+	if req.Score == nil {
+		if !attempt.ScoreOverridden {
+			return nil, NewBusinessRuleError("score_not_overridden", "attempt score has not been overridden", map[string]interface{}{
+				"attempt_id": attempt.ID,
+			})
+		}
+
+		if attempt.ComputedScore != nil {
+			attempt.Score = *attempt.ComputedScore
+		}
+		if attempt.ComputedPercentage != nil {
+			attempt.Percentage = *attempt.ComputedPercentage
+		}
+		if attempt.ComputedPassed != nil {
+			attempt.Passed = *attempt.ComputedPassed
+		}
+		attempt.ScoreOverridden = false
+		attempt.ComputedScore = nil
+		attempt.ComputedPercentage = nil
+		attempt.ComputedPassed = nil
+		attempt.OverrideReason = nil
+		attempt.OverriddenBy = nil
+		attempt.OverriddenAt = nil
+
+		entry.NewScore = attempt.Score
+		entry.Reverted = true
+		return entry, nil
+	}
+
+	if !attempt.ScoreOverridden {
+		computedScore, computedPercentage, computedPassed := attempt.Score, attempt.Percentage, attempt.Passed
+		attempt.ComputedScore = &computedScore
+		attempt.ComputedPercentage = &computedPercentage
+		attempt.ComputedPassed = &computedPassed
+	}
+
+	attempt.Score = *req.Score
+	attempt.Percentage = scorePercentage(*req.Score, totalPoints)
+	attempt.Passed = attempt.Percentage >= float64(passingScore)
+	attempt.ScoreOverridden = true
+	attempt.OverrideReason = &req.Justification
+	attempt.OverriddenBy = &userID
+	attempt.OverriddenAt = &now
+
+	entry.NewScore = attempt.Score
+	return entry, nil
+}
+
+func buildScoreOverrideAudit(attempt *models.AssessmentAttempt, entry *ScoreOverrideEntry, actor *models.User) (*models.AuditLog, error) {
+	changes, err := json.Marshal(map[string]interface{}{
+		"score": map[string]interface{}{"before": entry.PreviousScore, "after": entry.NewScore},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit changes: %w", err)
+	}
+	metadata, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit metadata: %w", err)
+	}
+
+	description := fmt.Sprintf("Score of attempt %d overridden from %.2f to %.2f (computed %.2f)",
+		attempt.ID, entry.PreviousScore, entry.NewScore, entry.ComputedScore)
+	if entry.Reverted {
+		description = fmt.Sprintf("Score override of attempt %d reverted to computed score %.2f", attempt.ID, entry.NewScore)
+	}
+
+	return &models.AuditLog{
+		EventType:       models.AuditScoreOverridden,
+		UserID:          actor.ID,
+		UserEmail:       actor.Email,
+		UserRole:        actor.Role,
+		TargetType:      "attempt",
+		TargetID:        &attempt.ID,
+		Description:     description,
+		Changes:         changes,
+		Metadata:        metadata,
+		ComplianceLevel: "high",
+	}, nil
+}
+
+// buildScoreOverrideHistory collects the attempt's override entries, oldest first
+func buildScoreOverrideHistory(attempt *models.AssessmentAttempt, logs []*models.AuditLog) *ScoreOverrideHistory {
+	history := &ScoreOverrideHistory{
+		AttemptID:     attempt.ID,
+		Score:         attempt.Score,
+		ComputedScore: attempt.Score,
+		Overridden:    attempt.ScoreOverridden,
+		Entries:       []ScoreOverrideEntry{},
+	}
+	if attempt.ScoreOverridden && attempt.ComputedScore != nil {
+		history.ComputedScore = *attempt.ComputedScore
+	}
+
+	for i := len(logs) - 1; i >= 0; i-- {
+		if logs[i].EventType != models.AuditScoreOverridden {
+			continue
+		}
+		var entry ScoreOverrideEntry
+		if err := json.Unmarshal(logs[i].Metadata, &entry); err != nil {
+			continue
+		}
+		history.Entries = append(history.Entries, entry)
+	}
+
+	return history
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestApplyScoreOverride(t *testing.T) {
+	now := time.Date(2025, 5, 2, 10, 0, 0, 0, time.UTC)
+	attempt := &models.AssessmentAttempt{ID: 4, Score: 42, Percentage: 42, Passed: false}
+	score := 65.0
+
+	entry, err := applyScoreOverride(attempt, &OverrideAttemptScoreRequest{Score: &score, Justification: "Question 3 key was wrong"}, 100, 60, "teacher-1", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempt.Score != 65 || attempt.Percentage != 65 || !attempt.Passed || !attempt.ScoreOverridden {
+		t.Errorf("override not applied: %+v", attempt)
+	}
+	if *attempt.ComputedScore != 42 || *attempt.ComputedPassed {
+		t.Errorf("computed result should be kept, got %v / %v", *attempt.ComputedScore, *attempt.ComputedPassed)
+	}
+	if entry.PreviousScore != 42 || entry.NewScore != 65 || entry.ComputedScore != 42 {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+
+	// Regrading an overridden attempt only refreshes the computed result
+	recordComputedScore(attempt, 45, 45, false)
+	if attempt.Score != 65 || *attempt.ComputedScore != 45 {
+		t.Errorf("regrade should not replace the override, got %v / %v", attempt.Score, *attempt.ComputedScore)
+	}
+
+	// A second override still remembers the computed score
+	score = 70
+	entry, _ = applyScoreOverride(attempt, &OverrideAttemptScoreRequest{Score: &score, Justification: "Late rubric correction"}, 100, 60, "teacher-1", now)
+	if entry.PreviousScore != 65 || entry.ComputedScore != 45 {
+		t.Errorf("unexpected second entry: %+v", entry)
+	}
+
+	entry, err = applyScoreOverride(attempt, &OverrideAttemptScoreRequest{Justification: "Override made in error"}, 100, 60, "teacher-1", now)
+	if err != nil || !entry.Reverted {
+		t.Fatalf("expected a revert, got %+v, %v", entry, err)
+	}
+	if attempt.Score != 45 || attempt.Passed || attempt.ScoreOverridden || attempt.ComputedScore != nil {
+		t.Errorf("revert should restore the computed result, got %+v", attempt)
+	}
+
+	if _, err := applyScoreOverride(attempt, &OverrideAttemptScoreRequest{Justification: "Nothing to revert"}, 100, 60, "teacher-1", now); !IsBusinessRule(err) {
+		t.Errorf("reverting without an override should be rejected, got %v", err)
+	}
+}
+
+func TestBuildScoreOverrideHistory(t *testing.T) {
+	computed := 42.0
+	attempt := &models.AssessmentAttempt{ID: 4, Score: 70, ScoreOverridden: true, ComputedScore: &computed}
+	first, _ := json.Marshal(ScoreOverrideEntry{PreviousScore: 42, NewScore: 65})
+	second, _ := json.Marshal(ScoreOverrideEntry{PreviousScore: 65, NewScore: 70})
+
+	// Audit logs arrive newest first
+	logs := []*models.AuditLog{
+		{EventType: models.AuditScoreOverridden, Metadata: second},
+		{EventType: models.AuditGradeUpdated},
+		{EventType: models.AuditScoreOverridden, Metadata: first},
+	}
+
+	history := buildScoreOverrideHistory(attempt, logs)
+	if history.Score != 70 || history.ComputedScore != 42 || !history.Overridden {
+		t.Errorf("unexpected history: %+v", history)
+	}
+	if len(history.Entries) != 2 || history.Entries[0].NewScore != 65 || history.Entries[1].NewScore != 70 {
+		t.Errorf("expected overrides oldest first, got %+v", history.Entries)
+	}
+}
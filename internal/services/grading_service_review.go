@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// ===== MODERATION REVIEW SAMPLING =====
+
+func (s *gradingService) SampleAnswersForReview(ctx context.Context, assessmentID uint, req *CreateReviewSampleRequest, userID string) (*ReviewSampleResult, error) {
+	s.logger.Info("Sampling graded answers for review",
+		"assessment_id", assessmentID,
+		"reviewer_id", req.ReviewerID,
+		"samples_per_grader", req.SamplesPerGrader,
+		"user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	// Check permission
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "sample_reviews", "not owner or insufficient permissions")
+	}
+
+	// Reviewer must be allowed to grade
+	reviewerRole, err := s.getUserRole(ctx, req.ReviewerID)
+	if err != nil {
+		return nil, err
+	}
+	if reviewerRole != models.RoleTeacher && reviewerRole != models.RoleAdmin {
+		return nil, NewValidationError("reviewer_id", "reviewer must be a teacher or admin", req.ReviewerID)
+	}
+
+	candidates, err := s.repo.AnswerReview().GetSampleCandidates(ctx, nil, assessmentID, req.ReviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sample candidates: %w", err)
+	}
+
+	// Group candidates by original grader
+	byGrader := make(map[string][]*models.StudentAnswer)
+	for _, answer := range candidates {
+		if answer.GradedBy == nil {
+			continue
+		}
+		byGrader[*answer.GradedBy] = append(byGrader[*answer.GradedBy], answer)
+	}
+
+	result := &ReviewSampleResult{
+		AssessmentID:    assessmentID,
+		ReviewerID:      req.ReviewerID,
+		SampledByGrader: make(map[string]int),
+	}
+
+	var reviews []*models.AnswerReview
+	for graderID, answers := range byGrader {
+		rand.Shuffle(len(answers), func(i, j int) {
+			answers[i], answers[j] = answers[j], answers[i]
+		})
+
+		count := req.SamplesPerGrader
+		if count > len(answers) {
+			count = len(answers)
+		}
+
+		for _, answer := range answers[:count] {
+			reviews = append(reviews, &models.AnswerReview{
+				AssessmentID:  assessmentID,
+				AnswerID:      answer.ID,
+				GraderID:      graderID,
+				ReviewerID:    req.ReviewerID,
+				Status:        models.AnswerReviewPending,
+				OriginalScore: answer.Score,
+				MaxScore:      float64(answer.Question.Points),
+				Tolerance:     req.Tolerance,
+				CreatedBy:     userID,
+			})
+		}
+		result.SampledByGrader[graderID] = count
+	}
+
+	if err := s.repo.AnswerReview().CreateBatch(ctx, nil, reviews); err != nil {
+		return nil, fmt.Errorf("failed to save review samples: %w", err)
+	}
+	result.Reviews = reviews
+
+	s.logger.Info("Review samples created",
+		"assessment_id", assessmentID,
+		"graders", len(result.SampledByGrader),
+		"samples", len(reviews))
+
+	return result, nil
+}
+
+func (s *gradingService) GetPendingReviews(ctx context.Context, reviewerID string) ([]*models.AnswerReview, error) {
+	reviews, err := s.repo.AnswerReview().GetPendingByReviewer(ctx, nil, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+func (s *gradingService) SubmitAnswerReview(ctx context.Context, reviewID uint, req *SubmitAnswerReviewRequest, reviewerID string) (*models.AnswerReview, error) {
+	s.logger.Info("Submitting answer review",
+		"review_id", reviewID,
+		"score", req.Score,
+		"reviewer_id", reviewerID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	review, err := s.repo.AnswerReview().GetByID(ctx, nil, reviewID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get answer review: %w", err)
+	}
+
+	if review.ReviewerID != reviewerID {
+		return nil, NewPermissionError(reviewerID, reviewID, "answer_review", "submit", "not the assigned reviewer")
+	}
+	if review.Status == models.AnswerReviewCompleted {
+		return nil, NewBusinessRuleError("review_already_completed", "answer review has already been submitted", map[string]interface{}{
+			"review_id": reviewID,
+		})
+	}
+	if req.Score > review.MaxScore {
+		return nil, NewValidationError("score", "score must be between 0 and max points", req.Score)
+	}
+
+	agrees := math.Abs(review.OriginalScore-req.Score) <= review.Tolerance
+	review.ReviewScore = &req.Score
+	review.Agrees = &agrees
+	review.Comment = req.Comment
+	review.ReviewedAt = timePtr(time.Now())
+	review.Status = models.AnswerReviewCompleted
+
+	if err := s.repo.AnswerReview().Update(ctx, nil, review); err != nil {
+		return nil, fmt.Errorf("failed to update answer review: %w", err)
+	}
+
+	s.logger.Info("Answer review submitted",
+		"review_id", reviewID,
+		"original_score", review.OriginalScore,
+		"review_score", req.Score,
+		"agrees", agrees)
+
+	return review, nil
+}
+
+func (s *gradingService) GetGraderReliabilityReport(ctx context.Context, assessmentID uint, userID string) (*GraderReliabilityReport, error) {
+	// Check permission
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", "view_reliability_report", "not owner or insufficient permissions")
+	}
+
+	reviews, err := s.repo.AnswerReview().GetByAssessment(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answer reviews: %w", err)
+	}
+
+	return buildGraderReliabilityReport(assessmentID, reviews), nil
+}
+
+// buildGraderReliabilityReport aggregates review samples into per-grader agreement figures
+func buildGraderReliabilityReport(assessmentID uint, reviews []*models.AnswerReview) *GraderReliabilityReport {
+	report := &GraderReliabilityReport{
+		AssessmentID: assessmentID,
+		Graders:      []GraderReliability{},
+		GeneratedAt:  time.Now(),
+	}
+
+	type accumulator struct {
+		stats       GraderReliability
+		absDiffSum  float64
+		biasDiffSum float64
+	}
+
+	byGrader := make(map[string]*accumulator)
+	totalAgreed := 0
+
+	for _, review := range reviews {
+		acc, ok := byGrader[review.GraderID]
+		if !ok {
+			acc = &accumulator{stats: GraderReliability{GraderID: review.GraderID}}
+			byGrader[review.GraderID] = acc
+		}
+
+		acc.stats.Sampled++
+		report.TotalSampled++
+
+		if review.Status != models.AnswerReviewCompleted || review.ReviewScore == nil {
+			continue
+		}
+
+		acc.stats.Reviewed++
+		report.TotalReviewed++
+
+		diff := review.OriginalScore - *review.ReviewScore
+		acc.absDiffSum += math.Abs(diff)
+		acc.biasDiffSum += diff
+
+		if review.Agrees != nil && *review.Agrees {
+			acc.stats.Agreed++
+			totalAgreed++
+		}
+	}
+
+	for _, acc := range byGrader {
+		if acc.stats.Reviewed > 0 {
+			reviewed := float64(acc.stats.Reviewed)
+			acc.stats.AgreementRate = float64(acc.stats.Agreed) / reviewed * 100
+			acc.stats.MeanAbsoluteDifference = acc.absDiffSum / reviewed
+			acc.stats.MeanScoreBias = acc.biasDiffSum / reviewed
+		}
+		report.Graders = append(report.Graders, acc.stats)
+	}
+
+	sort.Slice(report.Graders, func(i, j int) bool {
+		return report.Graders[i].GraderID < report.Graders[j].GraderID
+	})
+
+	if report.TotalReviewed > 0 {
+		report.OverallAgreementRate = float64(totalAgreed) / float64(report.TotalReviewed) * 100
+	}
+
+	return report
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestBuildGraderReliabilityReport(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+	agree := func(v bool) *bool { return &v }
+
+	reviews := []*models.AnswerReview{
+		{GraderID: "grader-a", Status: models.AnswerReviewCompleted, OriginalScore: 8, ReviewScore: score(8), Agrees: agree(true)},
+		{GraderID: "grader-a", Status: models.AnswerReviewCompleted, OriginalScore: 9, ReviewScore: score(5), Agrees: agree(false)},
+		{GraderID: "grader-a", Status: models.AnswerReviewPending, OriginalScore: 7},
+		{GraderID: "grader-b", Status: models.AnswerReviewCompleted, OriginalScore: 4, ReviewScore: score(5), Agrees: agree(true)},
+	}
+
+	report := buildGraderReliabilityReport(1, reviews)
+
+	if report.TotalSampled != 4 || report.TotalReviewed != 3 {
+		t.Fatalf("unexpected totals: sampled=%d reviewed=%d", report.TotalSampled, report.TotalReviewed)
+	}
+	if len(report.Graders) != 2 || report.Graders[0].GraderID != "grader-a" {
+		t.Fatalf("unexpected graders: %+v", report.Graders)
+	}
+
+	a := report.Graders[0]
+	if a.Sampled != 3 || a.Reviewed != 2 || a.Agreed != 1 {
+		t.Errorf("grader-a counts = %+v", a)
+	}
+	if a.AgreementRate != 50 || a.MeanAbsoluteDifference != 2 || a.MeanScoreBias != 2 {
+		t.Errorf("grader-a rates = %+v", a)
+	}
+
+	b := report.Graders[1]
+	if b.MeanScoreBias != -1 || b.AgreementRate != 100 {
+		t.Errorf("grader-b rates = %+v", b)
+	}
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// ===== SAMPLE GRADING =====
+
+// GradeSampleAnswer runs the grading engine on a hypothetical answer. Essays, and the manually
+// graded parts of multi-part questions, score nothing and are reported as needing a teacher.
+func (s *gradingService) GradeSampleAnswer(ctx context.Context, question *models.Question, studentAnswer json.RawMessage) (*SampleGradingResult, error) {
+	result := &SampleGradingResult{
+		QuestionID:   question.ID,
+		QuestionType: question.Type,
+		MaxScore:     float64(question.Points),
+	}
+
+	if question.Type == models.MultiPart {
+		content, partAnswers, _, err := decodeMultiPartAnswer(&models.StudentAnswer{Question: *question, Answer: []byte(studentAnswer)})
+		if err != nil {
+			return nil, ValidationErrors{*NewValidationError("answer_data", err.Error(), nil)}
+		}
+		partScores := s.gradeParts(ctx, content, partAnswers, nil, time.Now())
+		total, maxTotal, graded, allCorrect := summarizePartScores(partScores)
+		result.Score = total
+		result.MaxScore = maxTotal
+		result.IsCorrect = graded && allCorrect
+		result.PartialCredit = total > 0 && total < maxTotal
+		result.NeedsManualGrading = !graded
+		result.PartScores = partScores
+		return result, nil
+	}
+
+	score, isCorrect, err := s.CalculateScore(ctx, question.Type, json.RawMessage(question.Content), studentAnswer)
+	if err != nil {
+		if errors.Is(err, ErrGradingNotAllowed) {
+			result.NeedsManualGrading = true
+			return result, nil
+		}
+		return nil, ValidationErrors{*NewValidationError("answer_data", err.Error(), nil)}
+	}
+
+	feedback, err := s.GenerateFeedback(ctx, question.Type, json.RawMessage(question.Content), studentAnswer, isCorrect)
+	if err != nil {
+		s.logger.Warn("Failed to generate feedback", "question_id", question.ID, "error", err)
+	}
+
+	result.Score = score * float64(question.Points)
+	result.IsCorrect = isCorrect
+	result.PartialCredit = score > 0 && score < 1.0
+	result.Feedback = feedback
+	return result, nil
+}
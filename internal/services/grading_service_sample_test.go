@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+func TestGradeSampleAnswer(t *testing.T) {
+	s := &gradingService{}
+	question := &models.Question{
+		Type:    models.ShortAnswer,
+		Points:  4,
+		Content: datatypes.JSON(`{"accepted_answers":["paris"],"max_length":50}`),
+	}
+
+	result, err := s.GradeSampleAnswer(context.Background(), question, json.RawMessage(`"Paris"`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Score != 4 || result.MaxScore != 4 || !result.IsCorrect || result.NeedsManualGrading {
+		t.Errorf("expected full marks, got %+v", result)
+	}
+
+	result, err = s.GradeSampleAnswer(context.Background(), question, json.RawMessage(`"London"`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Score != 0 || result.IsCorrect {
+		t.Errorf("expected no marks for a wrong answer, got %+v", result)
+	}
+
+	essay := &models.Question{Type: models.Essay, Points: 10, Content: datatypes.JSON(`{}`)}
+	result, err = s.GradeSampleAnswer(context.Background(), essay, json.RawMessage(`"An essay"`))
+	if err != nil || !result.NeedsManualGrading || result.Score != 0 {
+		t.Errorf("expected essays to need a teacher, got %+v %v", result, err)
+	}
+}
+
+func TestGradeSampleAnswerMultiPart(t *testing.T) {
+	s := &gradingService{}
+	encoded, _ := json.Marshal(multiPartFixture())
+	question := &models.Question{Type: models.MultiPart, Points: 10, Content: datatypes.JSON(encoded)}
+
+	result, err := s.GradeSampleAnswer(context.Background(), question, json.RawMessage(`{"a":true,"b":"london"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Score != 2 || result.MaxScore != 10 || !result.PartialCredit || !result.NeedsManualGrading || len(result.PartScores) != 3 {
+		t.Errorf("unexpected multi-part result %+v", result)
+	}
+
+	var validationErrors ValidationErrors
+	if _, err := s.GradeSampleAnswer(context.Background(), question, json.RawMessage(`["a"]`)); !errors.As(err, &validationErrors) {
+		t.Errorf("expected a validation error for a malformed answer, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// gradingEvent is something that happens to an answer's grade
+type gradingEvent string
+
+const (
+	gradingEventAutoGraded       gradingEvent = "auto_graded"
+	gradingEventProvisional      gradingEvent = "provisionally_graded" // Auto-graded, but the question requires manual review
+	gradingEventManuallyGraded   gradingEvent = "manually_graded"
+	gradingEventRegradeRequested gradingEvent = "regrade_requested"
+)
+
+// ===== GRADING STATUS =====
+
+func (s *gradingService) GetAnswersByGradingStatus(ctx context.Context, assessmentID uint, filters repositories.AnswerFilters, userID string) ([]AnswerGradingState, int64, error) {
+	for _, status := range filters.GradingStatuses {
+		if !validGradingStatus(status) {
+			return nil, 0, ValidationErrors{*NewValidationError("grading_status", "unknown grading status", status)}
+		}
+	}
+
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !canAccess {
+		return nil, 0, NewPermissionError(userID, assessmentID, "assessment", "view_grading_status", "not owner or insufficient permissions")
+	}
+
+	answers, total, err := s.repo.Answer().GetByAssessment(ctx, nil, assessmentID, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	states := make([]AnswerGradingState, 0, len(answers))
+	for _, answer := range answers {
+		states = append(states, AnswerGradingState{
+			AnswerID:      answer.ID,
+			AttemptID:     answer.AttemptID,
+			QuestionID:    answer.QuestionID,
+			GradingStatus: effectiveGradingStatus(answer.GradingStatus),
+			Score:         answer.Score,
+			MaxScore:      answer.MaxScore,
+			GradedBy:      answer.GradedBy,
+			GradedAt:      answer.GradedAt,
+			CreatedAt:     answer.CreatedAt,
+		})
+	}
+	return states, total, nil
+}
+
+// ===== HELPER METHODS =====
+
+// markPendingRegrade flags the graded answers among the affected ones as waiting for a regrade
+func (s *gradingService) markPendingRegrade(ctx context.Context, answers []repositories.AffectedAnswer) error {
+	ids := make([]uint, 0, len(answers))
+	for _, answer := range answers {
+		ids = append(ids, answer.AnswerID)
+	}
+	if _, err := s.repo.Answer().MarkPendingRegrade(ctx, nil, ids); err != nil {
+		return fmt.Errorf("failed to mark answers pending regrade: %w", err)
+	}
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// nextGradingStatus moves an answer through the grading workflow. Grading an answer waiting
+// for a regrade completes the regrade; a teacher grading an answer that already had a grade
+// overrides it, while confirming a provisional score is the answer's first manual grade.
+// Automatic grading never replaces a teacher's grade, and answers without a grade have
+// nothing to regrade.
+func nextGradingStatus(current models.AnswerGradingStatus, event gradingEvent) models.AnswerGradingStatus {
+	current = effectiveGradingStatus(current)
+
+	switch event {
+	case gradingEventRegradeRequested:
+		if current == models.GradingStatusUngraded {
+			return current
+		}
+		return models.GradingStatusPendingRegrade
+
+	case gradingEventAutoGraded:
+		switch current {
+		case models.GradingStatusPendingRegrade, models.GradingStatusRegraded:
+			return models.GradingStatusRegraded
+		case models.GradingStatusManuallyGraded, models.GradingStatusOverridden:
+			return current
+		}
+		return models.GradingStatusAutoGraded
+
+	case gradingEventProvisional:
+		switch current {
+		case models.GradingStatusManuallyGraded, models.GradingStatusOverridden:
+			return current
+		}
+		return models.GradingStatusProvisional
+
+	case gradingEventManuallyGraded:
+		switch current {
+		case models.GradingStatusPendingRegrade:
+			return models.GradingStatusRegraded
+		case models.GradingStatusUngraded, models.GradingStatusProvisional:
+			return models.GradingStatusManuallyGraded
+		}
+		return models.GradingStatusOverridden
+	}
+
+	return current
+}
+
+// effectiveGradingStatus treats answers saved before grading states existed as ungraded
+func effectiveGradingStatus(status models.AnswerGradingStatus) models.AnswerGradingStatus {
+	if status == "" {
+		return models.GradingStatusUngraded
+	}
+	return status
+}
+
+func validGradingStatus(status models.AnswerGradingStatus) bool {
+	switch status {
+	case models.GradingStatusUngraded, models.GradingStatusAutoGraded, models.GradingStatusProvisional, models.GradingStatusManuallyGraded,
+		models.GradingStatusPendingRegrade, models.GradingStatusRegraded, models.GradingStatusOverridden:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestNextGradingStatus(t *testing.T) {
+	tests := []struct {
+		current models.AnswerGradingStatus
+		event   gradingEvent
+		want    models.AnswerGradingStatus
+	}{
+		{"", gradingEventAutoGraded, models.GradingStatusAutoGraded},
+		{models.GradingStatusUngraded, gradingEventManuallyGraded, models.GradingStatusManuallyGraded},
+		{models.GradingStatusAutoGraded, gradingEventManuallyGraded, models.GradingStatusOverridden},
+		{models.GradingStatusManuallyGraded, gradingEventManuallyGraded, models.GradingStatusOverridden},
+		{models.GradingStatusManuallyGraded, gradingEventAutoGraded, models.GradingStatusManuallyGraded},
+		{models.GradingStatusAutoGraded, gradingEventRegradeRequested, models.GradingStatusPendingRegrade},
+		{models.GradingStatusUngraded, gradingEventRegradeRequested, models.GradingStatusUngraded},
+		{models.GradingStatusPendingRegrade, gradingEventAutoGraded, models.GradingStatusRegraded},
+		{models.GradingStatusPendingRegrade, gradingEventManuallyGraded, models.GradingStatusRegraded},
+		{models.GradingStatusRegraded, gradingEventManuallyGraded, models.GradingStatusOverridden},
+		{models.GradingStatusUngraded, gradingEventProvisional, models.GradingStatusProvisional},
+		{models.GradingStatusPendingRegrade, gradingEventProvisional, models.GradingStatusProvisional},
+		{models.GradingStatusOverridden, gradingEventProvisional, models.GradingStatusOverridden},
+		{models.GradingStatusProvisional, gradingEventManuallyGraded, models.GradingStatusManuallyGraded},
+	}
+	for _, tt := range tests {
+		if got := nextGradingStatus(tt.current, tt.event); got != tt.want {
+			t.Errorf("%q after %s: got %s, want %s", tt.current, tt.event, got, tt.want)
+		}
+	}
+}
+
+func TestValidGradingStatus(t *testing.T) {
+	if !validGradingStatus(models.GradingStatusPendingRegrade) {
+		t.Error("pending_regrade should be valid")
+	}
+	if validGradingStatus("graded") {
+		t.Error("graded is not a grading status")
+	}
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultImpersonationDuration = 30 * time.Minute
+	impersonationAuditTarget     = "impersonation_session"
+)
+
+type impersonationService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewImpersonationService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) ImpersonationService {
+	return &impersonationService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// ===== SESSIONS =====
+
+// Start opens a time-limited session in which the admin sees the service as the target
+// user. Sessions are read-only unless writes are granted explicitly.
+func (s *impersonationService) Start(ctx context.Context, req *StartImpersonationRequest, adminID string) (*ImpersonationGrant, error) {
+	s.logger.Info("Starting impersonation", "admin_id", adminID, "target_user_id", req.TargetUserID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	admin, err := s.requireAdmin(ctx, adminID, "start")
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.repo.User().GetByID(ctx, req.TargetUserID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	if err := checkImpersonationTarget(admin, target); err != nil {
+		return nil, err
+	}
+
+	token, err := newImpersonationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	duration := defaultImpersonationDuration
+	if req.DurationMinutes > 0 {
+		duration = time.Duration(req.DurationMinutes) * time.Minute
+	}
+
+	session := &models.ImpersonationSession{
+		TokenHash:    hashImpersonationToken(token),
+		AdminID:      admin.ID,
+		TargetUserID: target.ID,
+		Reason:       req.Reason,
+		AllowWrites:  req.AllowWrites,
+		ExpiresAt:    time.Now().Add(duration),
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.Impersonation().Create(ctx, tx, session); err != nil {
+			return err
+		}
+		entry, err := buildImpersonationAudit(models.AuditImpersonationStart, session, admin,
+			fmt.Sprintf("%s started impersonating %s", admin.ID, target.ID),
+			map[string]interface{}{
+				"reason":       session.Reason,
+				"allow_writes": session.AllowWrites,
+				"expires_at":   session.ExpiresAt,
+			})
+		if err != nil {
+			return err
+		}
+		return s.repo.AuditLog().Create(ctx, tx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImpersonationGrant{Session: session, Token: token}, nil
+}
+
+// End closes a session early; requests made with its token are refused afterwards
+func (s *impersonationService) End(ctx context.Context, id uint, adminID string) error {
+	s.logger.Info("Ending impersonation", "session_id", id, "admin_id", adminID)
+
+	admin, err := s.requireAdmin(ctx, adminID, "end")
+	if err != nil {
+		return err
+	}
+	session, err := s.getOwnSession(ctx, id, admin.ID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if !session.IsActive(now) {
+		return NewBusinessRuleError("impersonation_ended", "impersonation session has already ended", map[string]interface{}{
+			"session_id": id,
+		})
+	}
+	session.EndedAt = timePtr(now)
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.Impersonation().Update(ctx, tx, session); err != nil {
+			return err
+		}
+		entry, err := buildImpersonationAudit(models.AuditImpersonationEnd, session, admin,
+			fmt.Sprintf("%s stopped impersonating %s", admin.ID, session.TargetUserID), nil)
+		if err != nil {
+			return err
+		}
+		return s.repo.AuditLog().Create(ctx, tx, entry)
+	})
+}
+
+func (s *impersonationService) ListActive(ctx context.Context, adminID string) ([]*models.ImpersonationSession, error) {
+	if _, err := s.requireAdmin(ctx, adminID, "list"); err != nil {
+		return nil, err
+	}
+	return s.repo.Impersonation().ListActiveByAdmin(ctx, nil, adminID, time.Now())
+}
+
+// GetAuditTrail lists the start, end and every request of a session, newest first
+func (s *impersonationService) GetAuditTrail(ctx context.Context, id uint, adminID string) ([]*models.AuditLog, error) {
+	admin, err := s.requireAdmin(ctx, adminID, "audit")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.getOwnSession(ctx, id, admin.ID); err != nil {
+		return nil, err
+	}
+	return s.repo.AuditLog().GetByTarget(ctx, nil, impersonationAuditTarget, id)
+}
+
+// ===== REQUEST HANDLING =====
+
+// Authorize resolves the session behind a token presented by an authenticated admin and
+// returns the user to act as
+func (s *impersonationService) Authorize(ctx context.Context, token string, admin *models.User, method string) (*models.ImpersonationSession, *models.User, error) {
+	session, err := s.repo.Impersonation().GetByTokenHash(ctx, nil, hashImpersonationToken(token))
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, nil, ErrImpersonationInvalid
+		}
+		return nil, nil, fmt.Errorf("failed to get impersonation session: %w", err)
+	}
+
+	if err := checkImpersonationRequest(session, admin, method, time.Now()); err != nil {
+		if errors.Is(err, ErrImpersonationReadOnly) {
+			return session, nil, err
+		}
+		return nil, nil, err
+	}
+
+	target, err := s.repo.User().GetByID(ctx, session.TargetUserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get impersonated user: %w", err)
+	}
+	return session, target, nil
+}
+
+// RecordRequest audit-logs a request made, or refused, under an impersonation session
+func (s *impersonationService) RecordRequest(ctx context.Context, session *models.ImpersonationSession, admin *models.User, req *ImpersonatedRequest) error {
+	entry, err := buildImpersonationAudit(models.AuditImpersonatedRequest, session, admin,
+		fmt.Sprintf("%s %s as %s", req.Method, req.Path, session.TargetUserID),
+		map[string]interface{}{
+			"method":  req.Method,
+			"path":    req.Path,
+			"blocked": req.Blocked,
+		})
+	if err != nil {
+		return err
+	}
+	entry.IPAddress = req.IPAddress
+	entry.UserAgent = req.UserAgent
+	entry.RequestID = req.RequestID
+
+	return s.repo.AuditLog().Create(ctx, nil, entry)
+}
+
+// ===== HELPER METHODS =====
+
+func (s *impersonationService) requireAdmin(ctx context.Context, adminID, action string) (*models.User, error) {
+	admin, err := s.repo.User().GetByID(ctx, adminID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if admin.Role != models.RoleAdmin {
+		return nil, NewPermissionError(adminID, 0, "impersonation", action, "only admins may impersonate users")
+	}
+	return admin, nil
+}
+
+func (s *impersonationService) getOwnSession(ctx context.Context, id uint, adminID string) (*models.ImpersonationSession, error) {
+	session, err := s.repo.Impersonation().GetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get impersonation session: %w", err)
+	}
+	if session.AdminID != adminID {
+		return nil, NewPermissionError(adminID, id, "impersonation", "manage", "session belongs to another admin")
+	}
+	return session, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// checkImpersonationTarget keeps impersonation to ordinary accounts, so it cannot be used
+// to borrow another admin's access
+func checkImpersonationTarget(admin, target *models.User) error {
+	if target.ID == admin.ID {
+		return NewBusinessRuleError("impersonate_self", "you cannot impersonate yourself", nil)
+	}
+	if target.Role == models.RoleAdmin {
+		return NewBusinessRuleError("impersonate_admin", "admins cannot be impersonated", map[string]interface{}{
+			"target_user_id": target.ID,
+		})
+	}
+	if !target.IsActive {
+		return NewBusinessRuleError("impersonate_inactive", "inactive users cannot be impersonated", map[string]interface{}{
+			"target_user_id": target.ID,
+		})
+	}
+	return nil
+}
+
+// checkImpersonationRequest lets a request through only for the admin who opened the
+// session, while they are still an admin and the session is live. Read-only sessions
+// refuse every method that can change data.
+func checkImpersonationRequest(session *models.ImpersonationSession, admin *models.User, method string, now time.Time) error {
+	if session.AdminID != admin.ID || admin.Role != models.RoleAdmin || !session.IsActive(now) {
+		return ErrImpersonationInvalid
+	}
+	if !session.AllowWrites && !isReadOnlyMethod(method) {
+		return ErrImpersonationReadOnly
+	}
+	return nil
+}
+
+func isReadOnlyMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func buildImpersonationAudit(eventType models.AuditEventType, session *models.ImpersonationSession, admin *models.User, description string, details map[string]interface{}) (*models.AuditLog, error) {
+	metadata := map[string]interface{}{
+		"target_user_id": session.TargetUserID,
+	}
+	for key, value := range details {
+		metadata[key] = value
+	}
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit metadata: %w", err)
+	}
+
+	return &models.AuditLog{
+		EventType:       eventType,
+		UserID:          admin.ID,
+		UserEmail:       admin.Email,
+		UserRole:        admin.Role,
+		TargetType:      impersonationAuditTarget,
+		TargetID:        &session.ID,
+		Description:     description,
+		Metadata:        encoded,
+		ComplianceLevel: "high",
+	}, nil
+}
+
+func newImpersonationToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func hashImpersonationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
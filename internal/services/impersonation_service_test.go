@@ -0,0 +1,76 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestCheckImpersonationTarget(t *testing.T) {
+	admin := &models.User{ID: "admin-1", Role: models.RoleAdmin, IsActive: true}
+
+	if err := checkImpersonationTarget(admin, &models.User{ID: "teacher-1", Role: models.RoleTeacher, IsActive: true}); err != nil {
+		t.Errorf("expected teacher to be impersonable, got %v", err)
+	}
+	for name, target := range map[string]*models.User{
+		"self":     admin,
+		"admin":    {ID: "admin-2", Role: models.RoleAdmin, IsActive: true},
+		"inactive": {ID: "student-1", Role: models.RoleStudent},
+	} {
+		if err := checkImpersonationTarget(admin, target); !IsBusinessRule(err) {
+			t.Errorf("%s: expected a business rule error, got %v", name, err)
+		}
+	}
+}
+
+func TestCheckImpersonationRequest(t *testing.T) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	admin := &models.User{ID: "admin-1", Role: models.RoleAdmin}
+	session := &models.ImpersonationSession{AdminID: admin.ID, ExpiresAt: now.Add(time.Minute)}
+
+	if err := checkImpersonationRequest(session, admin, http.MethodGet, now); err != nil {
+		t.Errorf("expected reads to be allowed, got %v", err)
+	}
+	if err := checkImpersonationRequest(session, admin, http.MethodPost, now); !errors.Is(err, ErrImpersonationReadOnly) {
+		t.Errorf("expected writes to be refused by default, got %v", err)
+	}
+
+	writable := *session
+	writable.AllowWrites = true
+	if err := checkImpersonationRequest(&writable, admin, http.MethodDelete, now); err != nil {
+		t.Errorf("expected writes to be allowed when granted, got %v", err)
+	}
+
+	cases := map[string]struct {
+		session *models.ImpersonationSession
+		admin   *models.User
+		now     time.Time
+	}{
+		"expired":       {session, admin, now.Add(time.Minute)},
+		"ended":         {&models.ImpersonationSession{AdminID: admin.ID, ExpiresAt: now.Add(time.Minute), EndedAt: &now}, admin, now},
+		"other admin":   {session, &models.User{ID: "admin-2", Role: models.RoleAdmin}, now},
+		"admin demoted": {session, &models.User{ID: admin.ID, Role: models.RoleTeacher}, now},
+	}
+	for name, c := range cases {
+		if err := checkImpersonationRequest(c.session, c.admin, http.MethodGet, c.now); !errors.Is(err, ErrImpersonationInvalid) {
+			t.Errorf("%s: expected the session to be refused, got %v", name, err)
+		}
+	}
+}
+
+func TestImpersonationTokenHashing(t *testing.T) {
+	token, err := newImpersonationToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := newImpersonationToken()
+	if token == other {
+		t.Error("tokens should be random")
+	}
+	if hashImpersonationToken(token) != hashImpersonationToken(token) || hashImpersonationToken(token) == token {
+		t.Error("hash should be stable and differ from the token")
+	}
+}
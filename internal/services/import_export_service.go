@@ -11,11 +11,13 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
 	"github.com/SAP-F-2025/assessment-service/internal/validator"
 	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
 )
 
 // ImportExportService handles file import/export operations for questions and assessments
@@ -31,19 +33,25 @@ type ImportExportService interface {
 	ExportAssessmentResults(ctx context.Context, assessmentID uint, userID string) ([]byte, error)
 
 	// Job management
-	GetImportJob(ctx context.Context, jobID string) (*models.ImportJob, error)
-	ProcessImportJobAsync(ctx context.Context, jobID string) error
+	CreateImportJob(ctx context.Context, file io.Reader, filename string, creatorID string, opts ImportJobOptions) (*models.ImportJob, error)
+	GetImportJob(ctx context.Context, jobID string, userID string) (*models.ImportJob, error)
+	CancelImportJob(ctx context.Context, jobID string, userID string) (*models.ImportJob, error)
+	RunImportJob(ctx context.Context, jobID string) error
+	ResumeImportJobs(ctx context.Context) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
 }
 
 type importExportService struct {
 	repo      repositories.Repository
+	db        *gorm.DB
 	logger    *slog.Logger
 	validator *validator.Validator
 }
 
-func NewImportExportService(repo repositories.Repository, logger *slog.Logger, validator *validator.Validator) ImportExportService {
+func NewImportExportService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) ImportExportService {
 	return &importExportService{
 		repo:      repo,
+		db:        db,
 		logger:    logger,
 		validator: validator,
 	}
@@ -51,11 +59,18 @@ func NewImportExportService(repo repositories.Repository, logger *slog.Logger, v
 
 // ===== IMPORT OPERATIONS =====
 
+// ImportJobOptions are optional settings chosen when an import job is created
+type ImportJobOptions struct {
+	Lint             bool     // Report spelling and readability warnings for imported questions
+	TargetGradeLevel *float64 // Flesch-Kincaid grade the question text should not exceed
+}
+
 type ImportResult struct {
 	JobID         string                         `json:"job_id"`
 	TotalRows     int                            `json:"total_rows"`
 	ProcessedRows int                            `json:"processed_rows"`
 	SuccessCount  int                            `json:"success_count"`
+	UpdatedCount  int                            `json:"updated_count"` // Successful rows that updated a question imported before
 	ErrorCount    int                            `json:"error_count"`
 	Errors        []models.ImportValidationError `json:"errors"`
 	Questions     []*models.Question             `json:"questions,omitempty"`
@@ -92,15 +107,10 @@ func (s *importExportService) ImportQuestionsFromCSV(ctx context.Context, reader
 	}
 
 	// Parse header
-	headers := records[0]
-	headerMap := make(map[string]int)
-	for i, header := range headers {
-		headerMap[strings.ToLower(strings.TrimSpace(header))] = i
-	}
+	headerMap := importHeaderMap(records[0])
 
 	// Validate required columns
-	requiredColumns := []string{"question_type", "question_text", "correct_answer"}
-	for _, col := range requiredColumns {
+	for _, col := range importRequiredColumns {
 		if _, exists := headerMap[col]; !exists {
 			return nil, NewValidationError("headers", fmt.Sprintf("missing required column: %s", col), col)
 		}
@@ -111,27 +121,9 @@ func (s *importExportService) ImportQuestionsFromCSV(ctx context.Context, reader
 		Status:    models.ImportProcessing,
 	}
 
-	var questions []*models.Question
-	var errors []models.ImportValidationError
-
-	// Process each data row
-	for rowIndex, record := range records[1:] {
-		question, rowErrors := s.parseCSVRow(record, headerMap, rowIndex+2, creatorID)
-		if len(rowErrors) > 0 {
-			errors = append(errors, rowErrors...)
-			result.ErrorCount++
-		} else if question != nil {
-			questions = append(questions, question)
-			result.SuccessCount++
-		}
-		result.ProcessedRows++
-	}
-
-	// Save valid questions
-	if len(questions) > 0 {
-		if err := s.saveImportedQuestions(ctx, questions); err != nil {
-			return nil, fmt.Errorf("failed to save questions: %w", err)
-		}
+	questions, errors, err := s.importRows(ctx, records[1:], headerMap, creatorID, result)
+	if err != nil {
+		return nil, err
 	}
 
 	result.Questions = questions
@@ -177,38 +169,16 @@ func (s *importExportService) ImportQuestionsFromExcel(ctx context.Context, read
 	}
 
 	// Parse header
-	headers := rows[0]
-	headerMap := make(map[string]int)
-	for i, header := range headers {
-		headerMap[strings.ToLower(strings.TrimSpace(header))] = i
-	}
+	headerMap := importHeaderMap(rows[0])
 
 	result := &ImportResult{
 		TotalRows: len(rows) - 1,
 		Status:    models.ImportProcessing,
 	}
 
-	var questions []*models.Question
-	var errors []models.ImportValidationError
-
-	// Process each data row
-	for rowIndex, row := range rows[1:] {
-		question, rowErrors := s.parseExcelRow(row, headerMap, rowIndex+2, creatorID)
-		if len(rowErrors) > 0 {
-			errors = append(errors, rowErrors...)
-			result.ErrorCount++
-		} else if question != nil {
-			questions = append(questions, question)
-			result.SuccessCount++
-		}
-		result.ProcessedRows++
-	}
-
-	// Save valid questions
-	if len(questions) > 0 {
-		if err := s.saveImportedQuestions(ctx, questions); err != nil {
-			return nil, fmt.Errorf("failed to save questions: %w", err)
-		}
+	questions, errors, err := s.importRows(ctx, rows[1:], headerMap, creatorID, result)
+	if err != nil {
+		return nil, err
 	}
 
 	result.Questions = questions
@@ -225,27 +195,35 @@ func (s *importExportService) ImportQuestionsFromExcel(ctx context.Context, read
 
 // ===== EXPORT OPERATIONS =====
 
+// questionExportHeaders are the columns of a question export; import reads them back
+var questionExportHeaders = []string{
+	"Question Type", "Question Text", "Option A", "Option B", "Option C", "Option D",
+	"Correct Answer", "Points", "Category", "Difficulty", "Tags", "Explanation",
+	"Provider", "External ID",
+}
+
 func (s *importExportService) ExportQuestionsToCSV(ctx context.Context, questionIDs []uint, userID string) ([]byte, error) {
 	questions, err := s.getQuestionsForExport(ctx, questionIDs, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	refs, err := s.exportExternalRefs(ctx, questions)
+	if err != nil {
+		return nil, err
+	}
+
 	var buf strings.Builder
 	writer := csv.NewWriter(&buf)
 
 	// Write header
-	headers := []string{
-		"Question Type", "Question Text", "Option A", "Option B", "Option C", "Option D",
-		"Correct Answer", "Points", "Category", "Difficulty", "Tags", "Explanation",
-	}
-	if err := writer.Write(headers); err != nil {
+	if err := writer.Write(questionExportHeaders); err != nil {
 		return nil, fmt.Errorf("failed to write CSV header: %w", err)
 	}
 
 	// Write data rows
 	for _, question := range questions {
-		row := s.questionToCSVRow(question)
+		row := s.questionToCSVRow(question, refs[question.ID])
 		if err := writer.Write(row); err != nil {
 			return nil, fmt.Errorf("failed to write CSV row: %w", err)
 		}
@@ -265,6 +243,11 @@ func (s *importExportService) ExportQuestionsToExcel(ctx context.Context, questi
 		return nil, err
 	}
 
+	refs, err := s.exportExternalRefs(ctx, questions)
+	if err != nil {
+		return nil, err
+	}
+
 	f := excelize.NewFile()
 	sheetName := "Questions"
 
@@ -276,19 +259,14 @@ func (s *importExportService) ExportQuestionsToExcel(ctx context.Context, questi
 	f.SetActiveSheet(index)
 
 	// Write headers
-	headers := []string{
-		"Question Type", "Question Text", "Option A", "Option B", "Option C", "Option D",
-		"Correct Answer", "Points", "Category", "Difficulty", "Tags", "Explanation",
-	}
-
-	for i, header := range headers {
+	for i, header := range questionExportHeaders {
 		cell := fmt.Sprintf("%c1", 'A'+i)
 		f.SetCellValue(sheetName, cell, header)
 	}
 
 	// Write data
 	for rowIndex, question := range questions {
-		row := s.questionToCSVRow(question)
+		row := s.questionToCSVRow(question, refs[question.ID])
 		for colIndex, value := range row {
 			cell := fmt.Sprintf("%c%d", 'A'+colIndex, rowIndex+2)
 			f.SetCellValue(sheetName, cell, value)
@@ -333,7 +311,8 @@ func (s *importExportService) ExportAssessmentResults(ctx context.Context, asses
 	// Write headers
 	headers := []string{
 		"Student ID", "Student Name", "Attempt", "Status", "Started At", "Submitted At",
-		"Total Score", "Percentage", "Grade", "Is Passing", "Time Spent (minutes)",
+		"Total Score", "Percentage", "Is Passing", "Time Spent (minutes)",
+		"Computed Score", "Score Overridden", "Override Justification",
 	}
 
 	for i, header := range headers {
@@ -371,12 +350,27 @@ func (s *importExportService) ExportAssessmentResults(ctx context.Context, asses
 
 		row = append(row, attempt.TimeSpent/60) // Convert seconds to minutes
 
+		// Overridden scores are exported next to the score grading computed
+		if attempt.ScoreOverridden && attempt.ComputedScore != nil {
+			justification := ""
+			if attempt.OverrideReason != nil {
+				justification = *attempt.OverrideReason
+			}
+			row = append(row, *attempt.ComputedScore, "Yes", justification)
+		} else {
+			row = append(row, attempt.Score, "No", "")
+		}
+
 		for colIndex, value := range row {
 			cell := fmt.Sprintf("%c%d", 'A'+colIndex, rowIndex+2)
 			f.SetCellValue(sheetName, cell, value)
 		}
 	}
 
+	if err := s.writeFinalGradesSheet(ctx, f, assessmentID, attempts); err != nil {
+		return nil, err
+	}
+
 	buf, err := f.WriteToBuffer()
 	if err != nil {
 		return nil, fmt.Errorf("failed to write Excel file: %w", err)
@@ -385,29 +379,63 @@ func (s *importExportService) ExportAssessmentResults(ctx context.Context, asses
 	return buf.Bytes(), nil
 }
 
-// ===== JOB MANAGEMENT =====
+// ===== HELPER FUNCTIONS =====
+
+// writeFinalGradesSheet adds one row per student with the grade counted under the
+// assessment's grade policy
+func (s *importExportService) writeFinalGradesSheet(ctx context.Context, f *excelize.File, assessmentID uint, attempts []*models.AssessmentAttempt) error {
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get assessment: %w", err)
+	}
+	policy, err := getGradePolicy(ctx, s.repo, assessmentID)
+	if err != nil {
+		return err
+	}
 
-func (s *importExportService) GetImportJob(ctx context.Context, jobID string) (*models.ImportJob, error) {
-	// TODO: Implement job storage and retrieval
-	// For now, return a placeholder
-	return &models.ImportJob{
-		ID:     jobID,
-		Status: "completed",
-	}, nil
-}
+	sheetName := "Final Grades"
+	if _, err := f.NewSheet(sheetName); err != nil {
+		return fmt.Errorf("failed to create Excel sheet: %w", err)
+	}
+
+	headers := []string{
+		"Student ID", "Student Name", "Grade Policy", "Attempts Counted",
+		"Final Score", "Max Score", "Final Percentage", "Is Passing",
+	}
+	for i, header := range headers {
+		cell := fmt.Sprintf("%c1", 'A'+i)
+		f.SetCellValue(sheetName, cell, header)
+	}
+
+	names := make(map[string]string)
+	for _, attempt := range attempts {
+		names[attempt.StudentID] = attempt.Student.FullName
+	}
+
+	for rowIndex, grade := range computeFinalGrades(attempts, policy, assessment.PassingScore) {
+		result := "Fail"
+		if grade.Passed {
+			result = "Pass"
+		}
+		row := []interface{}{
+			grade.StudentID,
+			names[grade.StudentID],
+			string(grade.Policy),
+			grade.AttemptsCounted,
+			grade.Score,
+			grade.MaxScore,
+			grade.Percentage,
+			result,
+		}
+		for colIndex, value := range row {
+			cell := fmt.Sprintf("%c%d", 'A'+colIndex, rowIndex+2)
+			f.SetCellValue(sheetName, cell, value)
+		}
+	}
 
-func (s *importExportService) ProcessImportJobAsync(ctx context.Context, jobID string) error {
-	// TODO: Implement async job processing
-	// This would typically involve:
-	// 1. Get job from storage
-	// 2. Process file in background
-	// 3. Update job status and progress
-	// 4. Store results
 	return nil
 }
 
-// ===== HELPER FUNCTIONS =====
-
 func (s *importExportService) parseCSVRow(record []string, headerMap map[string]int, rowNum int, creatorID string) (*models.Question, []models.ImportValidationError) {
 	var errors []models.ImportValidationError
 
@@ -469,6 +497,16 @@ func (s *importExportService) parseCSVRow(record []string, headerMap map[string]
 		return nil, errors
 	}
 
+	// Graders can't award more through the rubric than the question is worth
+	if essay, ok := content.(models.EssayContent); ok {
+		if maxPoints := rubricMaxPoints(essay.Rubric); maxPoints > points {
+			errors = append(errors, models.ImportValidationError{
+				Row: rowNum, Column: "rubric_points", Message: fmt.Sprintf("rubric is worth %d points but the question only %d", maxPoints, points), Value: getColumn("rubric_points"),
+			})
+			return nil, errors
+		}
+	}
+
 	contentBytes, err := json.Marshal(content)
 	if err != nil {
 		errors = append(errors, models.ImportValidationError{
@@ -510,11 +548,6 @@ func (s *importExportService) parseCSVRow(record []string, headerMap map[string]
 	return question, errors
 }
 
-func (s *importExportService) parseExcelRow(record []string, headerMap map[string]int, rowNum int, creatorID string) (*models.Question, []models.ImportValidationError) {
-	// Excel parsing is similar to CSV, just different input format
-	return s.parseCSVRow(record, headerMap, rowNum, creatorID)
-}
-
 func (s *importExportService) parseQuestionContent(questionType models.QuestionType, record []string, headerMap map[string]int, rowNum int) (interface{}, []models.ImportValidationError) {
 	var errors []models.ImportValidationError
 
@@ -536,10 +569,15 @@ func (s *importExportService) parseQuestionContent(questionType models.QuestionT
 			})
 			return nil, errors
 		}
-		isTrue := correctAnswer == "true"
-		return models.TrueFalseContent{CorrectAnswer: isTrue}, nil
+		content := models.TrueFalseContent{CorrectAnswer: correctAnswer == "true"}
+		applyTrueFalseImportFeedback(&content, getColumn)
+		return content, nil
 	case models.Essay:
-		return models.EssayContent{}, nil
+		content, rubricErrors := parseEssayImportContent(getColumn, rowNum)
+		if len(rubricErrors) > 0 {
+			return nil, rubricErrors
+		}
+		return content, nil
 	default:
 		errors = append(errors, models.ImportValidationError{
 			Row: rowNum, Column: "question_type", Message: "unsupported question type", Value: string(questionType),
@@ -612,31 +650,46 @@ func (s *importExportService) parseMultipleChoiceContent(record []string, header
 	}, nil
 }
 
-func (s *importExportService) saveImportedQuestions(ctx context.Context, questions []*models.Question) error {
-	// Begin transaction
-	txRepo, err := s.repo.(repositories.TransactionRepository).Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer func() {
-		if err != nil {
-			txRepo.(repositories.TransactionRepository).Rollback(ctx)
-		}
-	}()
+// importRows applies the data rows of an import file. New questions are saved in one
+// transaction; rows for questions imported before from the same provider content update them.
+func (s *importExportService) importRows(ctx context.Context, data [][]string, headerMap map[string]int, creatorID string, result *ImportResult) ([]*models.Question, []models.ImportValidationError, error) {
+	duplicates := duplicateImportRefs(data, headerMap)
 
-	// Save questions
-	for _, question := range questions {
-		if err := txRepo.Question().Create(ctx, nil, question); err != nil {
-			return fmt.Errorf("failed to create question: %w", err)
+	var created []*importedRow
+	var questions []*models.Question
+	var errors []models.ImportValidationError
+	for rowIndex, record := range data {
+		row, rowErrors := s.importRow(ctx, record, headerMap, rowIndex+2, creatorID, duplicates[rowIndex])
+		if len(rowErrors) > 0 {
+			errors = append(errors, rowErrors...)
+			result.ErrorCount++
+		} else if row != nil {
+			questions = append(questions, row.question)
+			result.SuccessCount++
+			if row.updated {
+				result.UpdatedCount++
+			} else {
+				created = append(created, row)
+			}
 		}
+		result.ProcessedRows++
 	}
 
-	// Commit transaction
-	if err := txRepo.(repositories.TransactionRepository).Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	if len(created) > 0 {
+		err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for _, row := range created {
+				if err := s.createImportedQuestion(ctx, tx, row); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to save questions: %w", err)
+		}
 	}
 
-	return nil
+	return questions, errors, nil
 }
 
 func (s *importExportService) getQuestionsForExport(ctx context.Context, questionIDs []uint, userID string) ([]*models.Question, error) {
@@ -667,8 +720,8 @@ func (s *importExportService) getQuestionsForExport(ctx context.Context, questio
 	return questions, nil
 }
 
-func (s *importExportService) questionToCSVRow(question *models.Question) []string {
-	row := make([]string, 12) // 12 columns as defined in headers
+func (s *importExportService) questionToCSVRow(question *models.Question, refs []*models.QuestionExternalRef) []string {
+	row := make([]string, len(questionExportHeaders))
 
 	row[0] = string(question.Type)
 	row[1] = question.Text
@@ -725,5 +778,8 @@ func (s *importExportService) questionToCSVRow(question *models.Question) []stri
 		row[11] = *question.Explanation
 	}
 
+	// Provider references, so a re-import of the file updates these questions
+	row[12], row[13] = externalRefCells(refs)
+
 	return row
 }
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// importRefSeparator separates several provider references in the provider and external_id cells
+const importRefSeparator = ";"
+
+// importedRow is a parsed import row. Rows carrying provider references the owner imported
+// before update that question instead of creating one.
+type importedRow struct {
+	question *models.Question
+	refs     []*models.QuestionExternalRef
+	updated  bool
+}
+
+// importRow parses a row and applies it to a question imported before from the same provider
+// content, if there is one. duplicate marks a row whose references repeat an earlier row.
+func (s *importExportService) importRow(ctx context.Context, record []string, headerMap map[string]int, rowNum int, ownerID string, duplicate bool) (*importedRow, []models.ImportValidationError) {
+	question, rowErrors := s.parseCSVRow(record, headerMap, rowNum, ownerID)
+	if len(rowErrors) > 0 || question == nil {
+		return nil, rowErrors
+	}
+
+	refs, rowErrors := parseImportExternalRefs(record, headerMap, rowNum, ownerID)
+	if len(rowErrors) > 0 {
+		return nil, rowErrors
+	}
+	if duplicate {
+		return nil, []models.ImportValidationError{{
+			Row: rowNum, Column: "external_id", Message: "repeats the provider reference of an earlier row", Value: importColumn(record, headerMap, "external_id"),
+		}}
+	}
+
+	updated, rowErrors := s.reimportQuestion(ctx, question, refs, rowNum, ownerID)
+	if len(rowErrors) > 0 {
+		return nil, rowErrors
+	}
+	return &importedRow{question: question, refs: refs, updated: updated}, nil
+}
+
+// reimportQuestion updates the owner's question one of the references already maps to and
+// records the references it lacks. It reports false when no reference is known yet.
+func (s *importExportService) reimportQuestion(ctx context.Context, imported *models.Question, refs []*models.QuestionExternalRef, rowNum int, ownerID string) (bool, []models.ImportValidationError) {
+	rowError := func(message string) []models.ImportValidationError {
+		return []models.ImportValidationError{{Row: rowNum, Column: "external_id", Message: message, Value: formatExternalRefs(refs)}}
+	}
+
+	var existing []*models.QuestionExternalRef
+	for _, ref := range refs {
+		found, err := s.repo.QuestionExternalRef().GetByExternalID(ctx, nil, ownerID, ref.Provider, ref.ExternalID)
+		if err != nil {
+			if repositories.IsNotFoundError(err) {
+				continue
+			}
+			return false, rowError(fmt.Sprintf("failed to look up provider reference: %v", err))
+		}
+		existing = append(existing, found)
+	}
+	if len(existing) == 0 {
+		return false, nil
+	}
+	for _, ref := range existing[1:] {
+		if ref.QuestionID != existing[0].QuestionID {
+			return false, rowError(fmt.Sprintf("references map to different questions (%d and %d)", existing[0].QuestionID, ref.QuestionID))
+		}
+	}
+
+	questionID := existing[0].QuestionID
+	question, err := s.repo.Question().GetByID(ctx, nil, questionID)
+	if err != nil {
+		if !repositories.IsNotFoundError(err) {
+			return false, rowError(fmt.Sprintf("failed to get question %d: %v", questionID, err))
+		}
+		// The question was deleted; the row imports as a new question
+		for _, ref := range existing {
+			if err := s.repo.QuestionExternalRef().Delete(ctx, nil, ref.ID); err != nil {
+				return false, rowError(err.Error())
+			}
+		}
+		return false, nil
+	}
+	if question.Type != imported.Type {
+		return false, rowError(fmt.Sprintf("question %d is %s and cannot change to %s", questionID, question.Type, imported.Type))
+	}
+
+	var tags []string
+	if err := json.Unmarshal(imported.Tags, &tags); err != nil {
+		return false, rowError("failed to read tags")
+	}
+	req := &UpdateQuestionRequest{
+		Text:        &imported.Text,
+		Content:     json.RawMessage(imported.Content),
+		Points:      &imported.Points,
+		Difficulty:  &imported.Difficulty,
+		Tags:        tags,
+		Explanation: imported.Explanation,
+	}
+	questionService := NewQuestionService(s.repo, s.db, s.logger, s.validator)
+	updated, err := questionService.Update(ctx, questionID, req, ownerID)
+	if err != nil {
+		return false, rowError(fmt.Sprintf("failed to update question %d: %v", questionID, err))
+	}
+	for _, ref := range refs {
+		if _, err := questionService.SetExternalRef(ctx, questionID, ref.Provider, &SetQuestionExternalRefRequest{ExternalID: ref.ExternalID}, ownerID); err != nil {
+			return false, rowError(fmt.Sprintf("failed to record provider reference: %v", err))
+		}
+	}
+
+	*imported = *updated.Question
+	return true, nil
+}
+
+// createImportedQuestion saves a new question with its provider references
+func (s *importExportService) createImportedQuestion(ctx context.Context, tx *gorm.DB, row *importedRow) error {
+	if err := s.repo.Question().Create(ctx, tx, row.question); err != nil {
+		return fmt.Errorf("failed to create question: %w", err)
+	}
+	for _, ref := range row.refs {
+		ref.QuestionID = row.question.ID
+		ref.OwnerID = row.question.CreatedBy
+		if err := s.repo.QuestionExternalRef().Create(ctx, tx, ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportExternalRefs returns the provider references of the exported questions
+func (s *importExportService) exportExternalRefs(ctx context.Context, questions []*models.Question) (map[uint][]*models.QuestionExternalRef, error) {
+	questionIDs := make([]uint, len(questions))
+	for i, question := range questions {
+		questionIDs[i] = question.ID
+	}
+	return s.repo.QuestionExternalRef().GetByQuestions(ctx, nil, questionIDs)
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func importColumn(record []string, headerMap map[string]int, name string) string {
+	if index, exists := headerMap[name]; exists && index < len(record) {
+		return strings.TrimSpace(record[index])
+	}
+	return ""
+}
+
+// parseImportExternalRefs reads the provider and external_id cells of a row. Several
+// references are separated by semicolons, in the same order in both cells.
+func parseImportExternalRefs(record []string, headerMap map[string]int, rowNum int, ownerID string) ([]*models.QuestionExternalRef, []models.ImportValidationError) {
+	providerCell := importColumn(record, headerMap, "provider")
+	externalIDCell := importColumn(record, headerMap, "external_id")
+	if providerCell == "" && externalIDCell == "" {
+		return nil, nil
+	}
+
+	providers := strings.Split(providerCell, importRefSeparator)
+	externalIDs := strings.Split(externalIDCell, importRefSeparator)
+	if providerCell == "" || externalIDCell == "" || len(providers) != len(externalIDs) {
+		return nil, []models.ImportValidationError{{
+			Row: rowNum, Column: "external_id", Message: "every provider needs exactly one external ID", Value: externalIDCell,
+		}}
+	}
+
+	refs := make([]*models.QuestionExternalRef, 0, len(providers))
+	seen := make(map[string]bool, len(providers))
+	for i := range providers {
+		provider, externalID, err := normalizeExternalRef(providers[i], externalIDs[i])
+		if err != nil {
+			return nil, []models.ImportValidationError{{
+				Row: rowNum, Column: "external_id", Message: err.Error(), Value: providers[i] + ":" + externalIDs[i],
+			}}
+		}
+		if seen[provider] {
+			return nil, []models.ImportValidationError{{
+				Row: rowNum, Column: "provider", Message: "provider is listed more than once", Value: provider,
+			}}
+		}
+		seen[provider] = true
+		refs = append(refs, &models.QuestionExternalRef{
+			OwnerID:    ownerID,
+			Provider:   provider,
+			ExternalID: externalID,
+			CreatedBy:  ownerID,
+		})
+	}
+	return refs, nil
+}
+
+// duplicateImportRefs returns the indexes of data rows carrying a provider reference an
+// earlier row already carries. Rows with unreadable references are left to row parsing.
+func duplicateImportRefs(data [][]string, headerMap map[string]int) map[int]bool {
+	duplicates := make(map[int]bool)
+	seen := make(map[string]bool)
+	for i, record := range data {
+		refs, rowErrors := parseImportExternalRefs(record, headerMap, i+2, "")
+		if len(rowErrors) > 0 {
+			continue
+		}
+		for _, ref := range refs {
+			key := ref.Provider + importRefSeparator + ref.ExternalID
+			if seen[key] {
+				duplicates[i] = true
+			}
+			seen[key] = true
+		}
+	}
+	return duplicates
+}
+
+// formatExternalRefs writes references as the provider and external_id cells of an export
+func formatExternalRefs(refs []*models.QuestionExternalRef) string {
+	pairs := make([]string, len(refs))
+	for i, ref := range refs {
+		pairs[i] = ref.Provider + ":" + ref.ExternalID
+	}
+	return strings.Join(pairs, importRefSeparator)
+}
+
+// externalRefCells returns the provider and external_id cells of a question's references
+func externalRefCells(refs []*models.QuestionExternalRef) (string, string) {
+	providers := make([]string, len(refs))
+	externalIDs := make([]string, len(refs))
+	for i, ref := range refs {
+		providers[i] = ref.Provider
+		externalIDs[i] = ref.ExternalID
+	}
+	return strings.Join(providers, importRefSeparator), strings.Join(externalIDs, importRefSeparator)
+}
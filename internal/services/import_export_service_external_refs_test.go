@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestParseImportExternalRefs(t *testing.T) {
+	headerMap := importHeaderMap(questionExportHeaders)
+	record := make([]string, len(questionExportHeaders))
+	record[12] = "Pearson; oup"
+	record[13] = "p-1;o-9"
+
+	refs, rowErrors := parseImportExternalRefs(record, headerMap, 2, "t-1")
+	if len(rowErrors) > 0 {
+		t.Fatalf("unexpected errors %v", rowErrors)
+	}
+	if len(refs) != 2 || refs[0].Provider != "pearson" || refs[0].ExternalID != "p-1" || refs[1].Provider != "oup" || refs[1].ExternalID != "o-9" {
+		t.Errorf("unexpected refs %+v %+v", refs[0], refs[1])
+	}
+	if refs[0].OwnerID != "t-1" {
+		t.Errorf("expected the importer to own the refs, got %q", refs[0].OwnerID)
+	}
+
+	record[12], record[13] = "", ""
+	if refs, rowErrors := parseImportExternalRefs(record, headerMap, 2, "t-1"); refs != nil || rowErrors != nil {
+		t.Errorf("expected a row without refs to parse to nothing, got %v %v", refs, rowErrors)
+	}
+
+	invalid := map[string][2]string{
+		"missing external ID": {"pearson", ""},
+		"count mismatch":      {"pearson;oup", "p-1"},
+		"repeated provider":   {"pearson;Pearson", "p-1;p-2"},
+	}
+	for name, cells := range invalid {
+		record[12], record[13] = cells[0], cells[1]
+		if _, rowErrors := parseImportExternalRefs(record, headerMap, 2, "t-1"); len(rowErrors) != 1 {
+			t.Errorf("%s: expected one row error, got %v", name, rowErrors)
+		}
+	}
+}
+
+func TestDuplicateImportRefs(t *testing.T) {
+	headerMap := map[string]int{"provider": 0, "external_id": 1}
+	data := [][]string{
+		{"pearson", "p-1"},
+		{"", ""},
+		{"PEARSON", " p-1"},
+		{"pearson;oup", "p-2;o-1"},
+		{"oup", "o-1"},
+		{"pearson", ""},
+	}
+
+	duplicates := duplicateImportRefs(data, headerMap)
+
+	if len(duplicates) != 2 || !duplicates[2] || !duplicates[4] {
+		t.Errorf("expected rows 2 and 4 to repeat refs, got %v", duplicates)
+	}
+}
+
+func TestExternalRefCells(t *testing.T) {
+	refs := []*models.QuestionExternalRef{
+		{Provider: "oup", ExternalID: "o-1"},
+		{Provider: "pearson", ExternalID: "p-1"},
+	}
+
+	providers, externalIDs := externalRefCells(refs)
+
+	if providers != "oup;pearson" || externalIDs != "o-1;p-1" {
+		t.Errorf("unexpected cells %q %q", providers, externalIDs)
+	}
+	headerMap := importHeaderMap(questionExportHeaders)
+	if headerMap["provider"] != 12 || headerMap["external_id"] != 13 || headerMap["question_type"] != 0 {
+		t.Errorf("expected export headers to map back to import columns, got %v", headerMap)
+	}
+}
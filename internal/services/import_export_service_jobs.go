@@ -0,0 +1,509 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	// Rows saved per transaction; each chunk commits together with the job checkpoint
+	importChunkSize = 100
+
+	// A processing job without a checkpoint for this long is treated as orphaned by a crash
+	importJobStaleAfter = 2 * time.Minute
+	importResumeBatch   = 20
+)
+
+var errImportJobCancelled = errors.New("import job cancelled")
+
+// importFileTypes maps accepted upload extensions to the stored file type
+var importFileTypes = map[string]string{
+	".csv":  "csv",
+	".xlsx": "xlsx",
+	".xls":  "xlsx",
+}
+
+var importRequiredColumns = []string{"question_type", "question_text", "correct_answer"}
+
+// ===== JOB MANAGEMENT =====
+
+func (s *importExportService) CreateImportJob(ctx context.Context, file io.Reader, filename string, creatorID string, opts ImportJobOptions) (*models.ImportJob, error) {
+	s.logger.Info("Creating import job", "filename", filename, "creator_id", creatorID)
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	fileType, ok := importFileTypes[ext]
+	if !ok {
+		return nil, NewValidationError("file", "unsupported file format", ext)
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	// Reject malformed files up front instead of failing the job later
+	rows, err := readImportRows(data, fileType)
+	if err == nil {
+		err = validateImportHeader(rows)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	jobID := uuid.NewString()
+	job := &models.ImportJob{
+		ID:        jobID,
+		UserID:    creatorID,
+		FileName:  filepath.Base(filename),
+		FileType:  fileType,
+		FileSize:  int64(len(data)),
+		FilePath:  importFilePath(jobID),
+		Status:    models.ImportPending,
+		TotalRows: len(rows) - 1,
+
+		LintEnabled:      opts.Lint,
+		TargetGradeLevel: opts.TargetGradeLevel,
+	}
+	// The file is kept in the database rather than on this pod, so any replica can run the job
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.repo.ImportJob().Create(ctx, tx, job); err != nil {
+			return err
+		}
+		return s.repo.ImportJob().SaveFile(ctx, tx, jobID, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := enqueueJob(ctx, s.repo, JobTypeImport, job.ID, creatorID); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (s *importExportService) GetImportJob(ctx context.Context, jobID string, userID string) (*models.ImportJob, error) {
+	job, err := s.repo.ImportJob().GetByID(ctx, nil, jobID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	if err := s.checkImportJobAccess(ctx, job, userID, "read"); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (s *importExportService) CancelImportJob(ctx context.Context, jobID string, userID string) (*models.ImportJob, error) {
+	s.logger.Info("Cancelling import job", "job_id", jobID, "user_id", userID)
+
+	job, err := s.GetImportJob(ctx, jobID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled, err := s.repo.ImportJob().Cancel(ctx, nil, jobID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, NewBusinessRuleError("import_job_finished", "only pending or running imports can be cancelled", map[string]interface{}{
+			"job_id": jobID,
+			"status": job.Status,
+		})
+	}
+
+	// Rows committed before the cancellation are kept; the worker stops at its next checkpoint
+	s.removeImportFile(ctx, job)
+
+	return s.GetImportJob(ctx, jobID, userID)
+}
+
+// RunImportJob processes an import for the job workers. A failed import is not retried: its
+// file was checked when the import was created and is removed once the import fails.
+func (s *importExportService) RunImportJob(ctx context.Context, jobID string) error {
+	job, err := s.repo.ImportJob().GetByID(ctx, nil, jobID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return NewPermanentJobError(ErrNotFound)
+		}
+		return fmt.Errorf("failed to get import job: %w", err)
+	}
+	if importFailed(job) {
+		return NewPermanentJobError(fmt.Errorf("import already ended with status %s; upload the file again", job.Status))
+	}
+
+	if err := s.processImportJob(ctx, jobID); err != nil {
+		if job, getErr := s.repo.ImportJob().GetByID(ctx, nil, jobID); getErr == nil && importFailed(job) {
+			return NewPermanentJobError(err)
+		}
+		return err
+	}
+	return nil
+}
+
+// ===== RESUMPTION =====
+
+// ResumeImportJobs hands pending imports, and imports orphaned mid-way by a crash, to the job
+// workers. Imports that already have a queued or running job are left alone.
+func (s *importExportService) ResumeImportJobs(ctx context.Context) (int, error) {
+	jobs, err := s.repo.ImportJob().GetResumable(ctx, nil, time.Now().Add(-importJobStaleAfter), importResumeBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	resumed := 0
+	for _, job := range jobs {
+		s.logger.Info("Resuming import job", "job_id", job.ID, "processed_rows", job.ProcessedRows, "total_rows", job.TotalRows)
+		if _, err := enqueueJob(ctx, s.repo, JobTypeImport, job.ID, job.UserID); err != nil {
+			s.logger.Error("Failed to resume import job", "job_id", job.ID, "error", err)
+			continue
+		}
+		resumed++
+	}
+
+	return resumed, nil
+}
+
+// RunScheduler resumes interrupted imports on start and then every interval until the context is cancelled
+func (s *importExportService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.Info("Import job scheduler started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.ResumeImportJobs(ctx); err != nil {
+			s.logger.Error("Failed to resume import jobs", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Import job scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ===== PROCESSING =====
+
+// processImportJob imports the job's rows in chunks, starting after the last checkpoint.
+// Questions of a chunk are saved in the same transaction as the checkpoint, so a resumed
+// job never imports a row twice.
+func (s *importExportService) processImportJob(ctx context.Context, jobID string) error {
+	claimed, err := s.repo.ImportJob().Claim(ctx, nil, jobID, time.Now().Add(-importJobStaleAfter))
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		// Finished, cancelled or still owned by another worker
+		return nil
+	}
+
+	job, err := s.repo.ImportJob().GetByID(ctx, nil, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to get import job: %w", err)
+	}
+
+	file, err := s.repo.ImportJob().GetFile(ctx, nil, jobID)
+	if err != nil {
+		if !repositories.IsNotFoundError(err) {
+			return fmt.Errorf("failed to get import file: %w", err)
+		}
+		return s.failImportJob(ctx, job, NewValidationError("file", "import file is no longer available; upload it again", nil))
+	}
+
+	rows, err := readImportRows(file, job.FileType)
+	if err == nil {
+		err = validateImportHeader(rows)
+	}
+	if err != nil {
+		return s.failImportJob(ctx, job, err)
+	}
+
+	headerMap := importHeaderMap(rows[0])
+	data := rows[1:]
+	duplicates := duplicateImportRefs(data, headerMap)
+
+	var importErrors []models.ImportValidationError
+	if len(job.Errors) > 0 {
+		if err := json.Unmarshal(job.Errors, &importErrors); err != nil {
+			return s.failImportJob(ctx, job, fmt.Errorf("failed to decode import errors: %w", err))
+		}
+	}
+
+	var importWarnings []models.ImportValidationError
+	if len(job.Warnings) > 0 {
+		if err := json.Unmarshal(job.Warnings, &importWarnings); err != nil {
+			return s.failImportJob(ctx, job, fmt.Errorf("failed to decode import warnings: %w", err))
+		}
+	}
+
+	for start := job.ProcessedRows; start < len(data); start += importChunkSize {
+		end := start + importChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+
+		checkpoint := *job
+		checkpoint.TotalRows = len(data)
+		checkpoint.ProcessedRows = end
+		checkpoint.Progress = importProgress(end, len(data))
+
+		var created []*importedRow
+		chunkErrors := importErrors
+		chunkWarnings := importWarnings
+		for i := start; i < end; i++ {
+			row, rowErrors := s.importRow(ctx, data[i], headerMap, i+2, job.UserID, duplicates[i])
+			if len(rowErrors) > 0 {
+				chunkErrors = append(chunkErrors, rowErrors...)
+				checkpoint.ErrorCount++
+			} else if row != nil {
+				checkpoint.SuccessCount++
+				if row.updated {
+					checkpoint.UpdatedCount++
+				} else {
+					created = append(created, row)
+				}
+				if job.LintEnabled {
+					chunkWarnings = append(chunkWarnings, s.lintImportedQuestion(row.question, i+2, job.TargetGradeLevel)...)
+				}
+			}
+		}
+
+		errorsJSON, err := json.Marshal(chunkErrors)
+		if err != nil {
+			return s.failImportJob(ctx, job, fmt.Errorf("failed to encode import errors: %w", err))
+		}
+		checkpoint.Errors = errorsJSON
+
+		if job.LintEnabled {
+			warningsJSON, err := json.Marshal(chunkWarnings)
+			if err != nil {
+				return s.failImportJob(ctx, job, fmt.Errorf("failed to encode import warnings: %w", err))
+			}
+			checkpoint.Warnings = warningsJSON
+		}
+
+		err = s.db.Transaction(func(tx *gorm.DB) error {
+			for _, row := range created {
+				if err := s.createImportedQuestion(ctx, tx, row); err != nil {
+					return err
+				}
+			}
+			saved, err := s.repo.ImportJob().SaveProgress(ctx, tx, &checkpoint)
+			if err != nil {
+				return err
+			}
+			if !saved {
+				return errImportJobCancelled
+			}
+			return nil
+		})
+		if errors.Is(err, errImportJobCancelled) {
+			s.logger.Info("Import job cancelled", "job_id", job.ID, "processed_rows", job.ProcessedRows)
+			s.removeImportFile(ctx, job)
+			return nil
+		}
+		if err != nil {
+			return s.failImportJob(ctx, job, err)
+		}
+
+		*job = checkpoint
+		importErrors = chunkErrors
+		importWarnings = chunkWarnings
+	}
+
+	summary, err := json.Marshal(map[string]interface{}{
+		"created_questions": job.SuccessCount - job.UpdatedCount,
+		"updated_questions": job.UpdatedCount,
+		"failed_rows":       job.ErrorCount,
+		"content_warnings":  len(importWarnings),
+	})
+	if err != nil {
+		return s.failImportJob(ctx, job, fmt.Errorf("failed to encode import summary: %w", err))
+	}
+
+	job.Status = models.ImportCompleted
+	job.Progress = 100
+	job.Summary = summary
+	job.CompletedAt = timePtr(time.Now())
+	if _, err := s.repo.ImportJob().SaveProgress(ctx, nil, job); err != nil {
+		return err
+	}
+	s.removeImportFile(ctx, job)
+
+	s.logger.Info("Import job completed",
+		"job_id", job.ID,
+		"total_rows", job.TotalRows,
+		"success_count", job.SuccessCount,
+		"error_count", job.ErrorCount)
+
+	return nil
+}
+
+// lintImportedQuestion reports spelling and readability issues of an imported row as warnings
+func (s *importExportService) lintImportedQuestion(question *models.Question, rowNum int, targetGradeLevel *float64) []models.ImportValidationError {
+	targetGrade := 0.0
+	if targetGradeLevel != nil {
+		targetGrade = *targetGradeLevel
+	}
+
+	lint := s.validator.ContentLinter().LintQuestion(question.Text, question.Explanation, targetGrade)
+	warnings := make([]models.ImportValidationError, 0, len(lint.Warnings))
+	for _, warning := range lint.Warnings {
+		column := "question_text"
+		if warning.Field == "explanation" {
+			column = "explanation"
+		}
+		warnings = append(warnings, models.ImportValidationError{
+			Row:     rowNum,
+			Column:  column,
+			Message: warning.Message,
+			Value:   warning.Value,
+			Code:    warning.Code,
+		})
+	}
+	return warnings
+}
+
+// failImportJob records a processing failure; validation problems of the file itself are
+// reported as validation_failed so users know retrying will not help
+func (s *importExportService) failImportJob(ctx context.Context, job *models.ImportJob, cause error) error {
+	job.Status = models.ImportFailed
+	var validationErr *ValidationError
+	if errors.As(cause, &validationErr) {
+		job.Status = models.ImportValidationFailed
+	}
+
+	summary, err := json.Marshal(map[string]interface{}{
+		"error": cause.Error(),
+	})
+	if err == nil {
+		job.Summary = summary
+	}
+	job.CompletedAt = timePtr(time.Now())
+
+	if _, err := s.repo.ImportJob().SaveProgress(ctx, nil, job); err != nil {
+		s.logger.Error("Failed to record import job failure", "job_id", job.ID, "error", err)
+	}
+	s.removeImportFile(ctx, job)
+
+	return cause
+}
+
+// ===== HELPER METHODS =====
+
+func (s *importExportService) checkImportJobAccess(ctx context.Context, job *models.ImportJob, userID, action string) error {
+	if job.UserID == userID {
+		return nil
+	}
+
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleAdmin {
+		return NewPermissionError(userID, 0, "import_job", action, "not owner of the import job")
+	}
+	return nil
+}
+
+func importFailed(job *models.ImportJob) bool {
+	return job.Status == models.ImportFailed || job.Status == models.ImportValidationFailed
+}
+
+func (s *importExportService) removeImportFile(ctx context.Context, job *models.ImportJob) {
+	if err := s.repo.ImportJob().DeleteFile(ctx, nil, job.ID); err != nil {
+		s.logger.Warn("Failed to remove import file", "job_id", job.ID, "error", err)
+	}
+}
+
+// importFilePath records where a job's file is kept
+func importFilePath(jobID string) string {
+	return "import_job_files/" + jobID
+}
+
+// readImportRows returns all rows of an uploaded import file, header row first
+func readImportRows(data []byte, fileType string) ([][]string, error) {
+	switch fileType {
+	case "csv":
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.TrimLeadingSpace = true
+		reader.FieldsPerRecord = -1
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, NewValidationError("file", fmt.Sprintf("failed to read CSV: %v", err), nil)
+		}
+		return rows, nil
+
+	case "xlsx":
+		f, err := excelize.OpenReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, NewValidationError("file", fmt.Sprintf("failed to open Excel file: %v", err), nil)
+		}
+		defer f.Close()
+
+		sheets := f.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, NewValidationError("file", "Excel file has no sheets", nil)
+		}
+		rows, err := f.GetRows(sheets[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Excel rows: %w", err)
+		}
+		return rows, nil
+
+	default:
+		return nil, NewValidationError("file_type", "unsupported file type", fileType)
+	}
+}
+
+func validateImportHeader(rows [][]string) error {
+	if len(rows) < 2 {
+		return NewValidationError("file", "file must have header row and at least one data row", len(rows))
+	}
+
+	headerMap := importHeaderMap(rows[0])
+	for _, col := range importRequiredColumns {
+		if _, exists := headerMap[col]; !exists {
+			return NewValidationError("headers", fmt.Sprintf("missing required column: %s", col), col)
+		}
+	}
+	return nil
+}
+
+func importHeaderMap(headers []string) map[string]int {
+	headerMap := make(map[string]int, len(headers))
+	for i, header := range headers {
+		// Exported headers such as "Question Type" name the question_type column
+		headerMap[strings.ReplaceAll(strings.ToLower(strings.TrimSpace(header)), " ", "_")] = i
+	}
+	return headerMap
+}
+
+func importProgress(processed, total int) int {
+	if total == 0 {
+		return 100
+	}
+	return processed * 100 / total
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"testing"
+)
+
+func TestReadImportRowsAndValidateHeader(t *testing.T) {
+	content := "Question_Type, Question_Text, Correct_Answer\ntrue_false,The sky is blue,true\n"
+
+	rows, err := readImportRows([]byte(content), "csv")
+	if err != nil {
+		t.Fatalf("readImportRows: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if err := validateImportHeader(rows); err != nil {
+		t.Errorf("expected valid header, got %v", err)
+	}
+
+	if err := validateImportHeader([][]string{{"question_type", "question_text"}, {"essay", "Explain"}}); err == nil {
+		t.Error("expected missing correct_answer column to be rejected")
+	}
+	if err := validateImportHeader(rows[:1]); err == nil {
+		t.Error("expected file without data rows to be rejected")
+	}
+}
+
+func TestImportProgress(t *testing.T) {
+	cases := []struct{ processed, total, want int }{
+		{0, 250, 0},
+		{100, 250, 40},
+		{250, 250, 100},
+		{0, 0, 100},
+	}
+	for _, c := range cases {
+		if got := importProgress(c.processed, c.total); got != c.want {
+			t.Errorf("importProgress(%d, %d) = %d, want %d", c.processed, c.total, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// Rubric cells list one entry per criterion separated by ";", and within a criterion one
+// entry per level separated by "|", e.g. rubric_levels "Strong|Weak; Clear|Unclear" with
+// rubric_points "4|1; 2|0".
+const (
+	rubricCriterionSeparator = ";"
+	rubricLevelSeparator     = "|"
+)
+
+// ===== ESSAY RUBRICS =====
+
+// parseEssayImportContent builds essay content from the optional rubric_criteria,
+// rubric_levels and rubric_points columns. Criteria may be imported as names only.
+func parseEssayImportContent(getColumn func(string) string, rowNum int) (models.EssayContent, []models.ImportValidationError) {
+	var content models.EssayContent
+
+	criteriaStr := getColumn("rubric_criteria")
+	levelsStr := getColumn("rubric_levels")
+	pointsStr := getColumn("rubric_points")
+
+	if criteriaStr == "" {
+		if levelsStr != "" || pointsStr != "" {
+			return content, []models.ImportValidationError{{
+				Row: rowNum, Column: "rubric_criteria", Message: "required when rubric levels or points are given", Value: "",
+			}}
+		}
+		return content, nil
+	}
+
+	criteria := splitRubricCell(criteriaStr, rubricCriterionSeparator)
+	for _, name := range criteria {
+		if name == "" {
+			return content, []models.ImportValidationError{{
+				Row: rowNum, Column: "rubric_criteria", Message: "criterion names cannot be empty", Value: criteriaStr,
+			}}
+		}
+	}
+	content.RubricCriteria = criteria
+
+	if levelsStr == "" && pointsStr == "" {
+		return content, nil
+	}
+	if levelsStr == "" || pointsStr == "" {
+		column := "rubric_levels"
+		if pointsStr == "" {
+			column = "rubric_points"
+		}
+		return content, []models.ImportValidationError{{
+			Row: rowNum, Column: column, Message: "rubric levels and points must be given together", Value: "",
+		}}
+	}
+
+	levels := splitRubricCell(levelsStr, rubricCriterionSeparator)
+	points := splitRubricCell(pointsStr, rubricCriterionSeparator)
+	if len(levels) != len(criteria) {
+		return content, []models.ImportValidationError{{
+			Row: rowNum, Column: "rubric_levels", Message: fmt.Sprintf("expected levels for %d criteria, got %d", len(criteria), len(levels)), Value: levelsStr,
+		}}
+	}
+	if len(points) != len(criteria) {
+		return content, []models.ImportValidationError{{
+			Row: rowNum, Column: "rubric_points", Message: fmt.Sprintf("expected points for %d criteria, got %d", len(criteria), len(points)), Value: pointsStr,
+		}}
+	}
+
+	var errors []models.ImportValidationError
+	rubric := make([]models.RubricCriterion, 0, len(criteria))
+	for i, name := range criteria {
+		criterion, err := parseRubricCriterion(name, levels[i], points[i], rowNum)
+		if err != nil {
+			errors = append(errors, *err)
+			continue
+		}
+		rubric = append(rubric, criterion)
+	}
+	if len(errors) > 0 {
+		return content, errors
+	}
+
+	content.Rubric = rubric
+	return content, nil
+}
+
+func parseRubricCriterion(name, levelsStr, pointsStr string, rowNum int) (models.RubricCriterion, *models.ImportValidationError) {
+	criterion := models.RubricCriterion{Name: name}
+
+	levelNames := splitRubricCell(levelsStr, rubricLevelSeparator)
+	levelPoints := splitRubricCell(pointsStr, rubricLevelSeparator)
+	if len(levelNames) != len(levelPoints) {
+		return criterion, &models.ImportValidationError{
+			Row: rowNum, Column: "rubric_points", Message: fmt.Sprintf("criterion %q has %d levels but %d points", name, len(levelNames), len(levelPoints)), Value: pointsStr,
+		}
+	}
+
+	for i, levelName := range levelNames {
+		if levelName == "" {
+			return criterion, &models.ImportValidationError{
+				Row: rowNum, Column: "rubric_levels", Message: fmt.Sprintf("criterion %q has an empty level name", name), Value: levelsStr,
+			}
+		}
+		points, err := strconv.Atoi(levelPoints[i])
+		if err != nil || points < 0 {
+			return criterion, &models.ImportValidationError{
+				Row: rowNum, Column: "rubric_points", Message: fmt.Sprintf("criterion %q points must be non-negative whole numbers", name), Value: levelPoints[i],
+			}
+		}
+		criterion.Levels = append(criterion.Levels, models.RubricLevel{Name: levelName, Points: points})
+	}
+
+	return criterion, nil
+}
+
+// rubricMaxPoints is the score of the best level of every criterion
+func rubricMaxPoints(rubric []models.RubricCriterion) int {
+	total := 0
+	for _, criterion := range rubric {
+		best := 0
+		for _, level := range criterion.Levels {
+			if level.Points > best {
+				best = level.Points
+			}
+		}
+		total += best
+	}
+	return total
+}
+
+// ===== TRUE/FALSE FEEDBACK =====
+
+// applyTrueFalseImportFeedback copies the optional correct_feedback and incorrect_feedback columns
+func applyTrueFalseImportFeedback(content *models.TrueFalseContent, getColumn func(string) string) {
+	if feedback := getColumn("correct_feedback"); feedback != "" {
+		content.CorrectFeedback = &feedback
+	}
+	if feedback := getColumn("incorrect_feedback"); feedback != "" {
+		content.IncorrectFeedback = &feedback
+	}
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func splitRubricCell(cell, separator string) []string {
+	parts := strings.Split(cell, separator)
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func importColumns(values map[string]string) func(string) string {
+	return func(name string) string { return values[name] }
+}
+
+func TestParseEssayImportContent(t *testing.T) {
+	content, errs := parseEssayImportContent(importColumns(map[string]string{
+		"rubric_criteria": "Content; Grammar",
+		"rubric_levels":   "Strong|Weak; Clean | Sloppy",
+		"rubric_points":   "4|1; 2|0",
+	}), 2)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %+v", errs)
+	}
+	if len(content.RubricCriteria) != 2 || content.RubricCriteria[1] != "Grammar" {
+		t.Errorf("unexpected criteria: %v", content.RubricCriteria)
+	}
+	if len(content.Rubric) != 2 || len(content.Rubric[1].Levels) != 2 {
+		t.Fatalf("unexpected rubric: %+v", content.Rubric)
+	}
+	if level := content.Rubric[1].Levels[1]; level.Name != "Sloppy" || level.Points != 0 {
+		t.Errorf("unexpected level: %+v", level)
+	}
+	if got := rubricMaxPoints(content.Rubric); got != 6 {
+		t.Errorf("rubricMaxPoints = %d, want 6", got)
+	}
+
+	// Names alone are still accepted
+	content, errs = parseEssayImportContent(importColumns(map[string]string{"rubric_criteria": "Content"}), 2)
+	if len(errs) > 0 || len(content.RubricCriteria) != 1 || content.Rubric != nil {
+		t.Errorf("names-only rubric: content %+v, errors %+v", content, errs)
+	}
+}
+
+func TestParseEssayImportContentRejectsMismatches(t *testing.T) {
+	cases := []struct {
+		name   string
+		values map[string]string
+		column string
+	}{
+		{"levels without criteria", map[string]string{"rubric_levels": "Strong|Weak"}, "rubric_criteria"},
+		{"levels without points", map[string]string{"rubric_criteria": "Content", "rubric_levels": "Strong|Weak"}, "rubric_points"},
+		{"missing criterion levels", map[string]string{"rubric_criteria": "Content; Grammar", "rubric_levels": "Strong|Weak", "rubric_points": "4|1"}, "rubric_levels"},
+		{"points per level", map[string]string{"rubric_criteria": "Content", "rubric_levels": "Strong|Weak", "rubric_points": "4"}, "rubric_points"},
+		{"negative points", map[string]string{"rubric_criteria": "Content", "rubric_levels": "Strong|Weak", "rubric_points": "4|-1"}, "rubric_points"},
+		{"empty level", map[string]string{"rubric_criteria": "Content", "rubric_levels": "Strong|", "rubric_points": "4|1"}, "rubric_levels"},
+	}
+	for _, c := range cases {
+		_, errs := parseEssayImportContent(importColumns(c.values), 3)
+		if len(errs) == 0 {
+			t.Errorf("%s: expected an error", c.name)
+			continue
+		}
+		if errs[0].Column != c.column || errs[0].Row != 3 {
+			t.Errorf("%s: error on row %d column %q, want row 3 column %q", c.name, errs[0].Row, errs[0].Column, c.column)
+		}
+	}
+}
+
+func TestParseCSVRowImportsRubricsAndFeedback(t *testing.T) {
+	s := &importExportService{}
+	headerMap := importHeaderMap([]string{
+		"question_type", "question_text", "correct_answer", "points",
+		"rubric_criteria", "rubric_levels", "rubric_points", "correct_feedback", "incorrect_feedback",
+	})
+
+	essay, errs := s.parseCSVRow([]string{"essay", "Discuss", "", "5", "Content", "Strong|Weak", "4|1", "", ""}, headerMap, 2, "teacher")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected essay errors: %+v", errs)
+	}
+	var essayContent models.EssayContent
+	if err := json.Unmarshal(essay.Content, &essayContent); err != nil {
+		t.Fatal(err)
+	}
+	if len(essayContent.Rubric) != 1 || essayContent.Rubric[0].Levels[0].Points != 4 {
+		t.Errorf("rubric not persisted in content: %s", essay.Content)
+	}
+
+	_, errs = s.parseCSVRow([]string{"essay", "Discuss", "", "3", "Content", "Strong|Weak", "4|1", "", ""}, headerMap, 3, "teacher")
+	if len(errs) == 0 || errs[0].Column != "rubric_points" {
+		t.Errorf("expected rubric worth more than the question to be rejected, got %+v", errs)
+	}
+
+	tf, errs := s.parseCSVRow([]string{"true_false", "The sky is blue", "true", "", "", "", "", "Right, Rayleigh scattering", "Look up"}, headerMap, 4, "teacher")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected true/false errors: %+v", errs)
+	}
+	var tfContent models.TrueFalseContent
+	if err := json.Unmarshal(tf.Content, &tfContent); err != nil {
+		t.Fatal(err)
+	}
+	if tfContent.CorrectFeedback == nil || *tfContent.CorrectFeedback != "Right, Rayleigh scattering" || tfContent.IncorrectFeedback == nil {
+		t.Errorf("feedback not persisted in content: %s", tf.Content)
+	}
+}
@@ -3,11 +3,14 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"time"
 
+	"github.com/SAP-F-2025/assessment-service/internal/events"
 	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
 	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/datatypes"
 )
 
 // ===== REQUEST/RESPONSE DTOs =====
@@ -22,9 +25,13 @@ type AssessmentQuestionRequest = validator.AssessmentQuestionRequest
 
 type AssessmentResponse struct {
 	*models.Assessment
-	CanEdit   bool `json:"can_edit"`
-	CanDelete bool `json:"can_delete"`
-	CanTake   bool `json:"can_take"`
+	CanEdit    bool `json:"can_edit"`
+	CanDelete  bool `json:"can_delete"`
+	CanTake    bool `json:"can_take"`
+	IsFavorite bool `json:"is_favorite"`
+
+	// Set on create and update, e.g. when the title is reused from another term
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 type AssessmentListResponse struct {
@@ -40,101 +47,1593 @@ type UpdateStatusRequest struct {
 }
 
 type UpdateAssessmentQuestionRequest struct {
-	QuestionId uint `json:"question_id"`
-	Points     *int `json:"points" validate:"omitempty,min=1,max=100"`
-	TimeLimit  *int `json:"time_limit" validate:"omitempty,min=30,max=3600"`
+	QuestionId       uint    `json:"question_id"`
+	Points           *int    `json:"points" validate:"omitempty,min=1,max=100"`
+	TimeLimit        *int    `json:"time_limit" validate:"omitempty,min=30,max=3600"`
+	MaxAnswerChanges *int    `json:"max_answer_changes" validate:"omitempty,min=-1,max=100"` // -1 lifts the limit
+	Section          *string `json:"section" validate:"omitempty,max=100"`                   // Empty removes the question from its section
+}
+
+// SetQuestionBranchingRequest replaces a question's branching rules. A conditional question is
+// skipped unless a rule unlocks it or routes to it.
+type SetQuestionBranchingRequest struct {
+	Rules       []models.BranchRule `json:"rules" validate:"omitempty,max=20"`
+	Conditional bool                `json:"conditional"`
+}
+
+// StartQuestionTrialRequest pilots a revision of an assessment question on a share of new attempts
+type StartQuestionTrialRequest struct {
+	QuestionID        uint    `json:"question_id" validate:"required"`
+	VariantQuestionID uint    `json:"variant_question_id" validate:"required"`
+	Fraction          float64 `json:"fraction" validate:"required,gt=0,lt=1"` // Share of attempts served the revision
+}
+
+// TrialArmStats are the item statistics of one side of a question trial
+type TrialArmStats struct {
+	QuestionID          uint    `json:"question_id"`
+	Responses           int     `json:"responses"`
+	CorrectRate         float64 `json:"correct_rate"`         // Difficulty index
+	DiscriminationIndex float64 `json:"discrimination_index"` // Top minus bottom quarter correct rate
+	AverageScore        float64 `json:"average_score"`
+	AverageTimeSpent    int     `json:"average_time_spent"` // seconds
+}
+
+type TrialRecommendation string
+
+const (
+	TrialCollectMore  TrialRecommendation = "collect_more" // Too few responses on either side
+	TrialPromote      TrialRecommendation = "promote"
+	TrialKeepOriginal TrialRecommendation = "keep_original"
+)
+
+// QuestionTrialComparison sets a trial's revision against the original over the same attempts
+type QuestionTrialComparison struct {
+	Trial          *models.QuestionTrial `json:"trial"`
+	Original       TrialArmStats         `json:"original"`
+	Variant        TrialArmStats         `json:"variant"`
+	Recommendation TrialRecommendation   `json:"recommendation"`
 }
 
 type ReorderQuestionsRequest struct {
 	QuestionOrders []repositories.QuestionOrder `json:"question_orders"`
 }
 
+type BuildAssessmentRequest struct {
+	TotalPoints            int                                `json:"total_points" validate:"required,min=1,max=1000"`
+	Duration               int                                `json:"duration" validate:"required,min=1,max=480"` // minutes
+	Tags                   []string                           `json:"tags" validate:"omitempty,max=20,dive,max=50"`
+	DifficultyDistribution map[models.DifficultyLevel]float64 `json:"difficulty_distribution"` // relative share of points per difficulty
+	QuestionTypes          []models.QuestionType              `json:"question_types"`
+	BankIDs                []uint                             `json:"bank_ids"` // restrict to these banks, defaults to all accessible banks
+}
+
+type ProposedQuestion struct {
+	Question    *models.Question `json:"question"`
+	BankID      uint             `json:"bank_id"`
+	MatchedTags []string         `json:"matched_tags"`
+	Reason      string           `json:"reason"`
+}
+
+type AssessmentBuildProposal struct {
+	Questions           []ProposedQuestion             `json:"questions"`
+	TotalPoints         int                            `json:"total_points"`
+	EstimatedDuration   int                            `json:"estimated_duration"`   // minutes
+	DifficultyBreakdown map[models.DifficultyLevel]int `json:"difficulty_breakdown"` // points per difficulty
+	Explanation         []string                       `json:"explanation"`
+	Warnings            []string                       `json:"warnings"`
+	Assessment          CreateAssessmentRequest        `json:"assessment"` // Pre-filled create payload to tweak before creating
+}
+
+// GenerateSeriesRequest describes a run of quizzes drawn from one bank. Assessment is the
+// template every quiz is created from; its title is numbered and its due date and questions
+// are filled in per quiz.
+type GenerateSeriesRequest struct {
+	BankID           uint                    `json:"bank_id" validate:"required"`
+	Tags             []string                `json:"tags" validate:"omitempty,max=20,dive,max=50"`
+	QuizCount        int                     `json:"quiz_count" validate:"required,min=1,max=52"`
+	QuestionsPerQuiz int                     `json:"questions_per_quiz" validate:"required,min=1,max=100"`
+	CadenceDays      int                     `json:"cadence_days" validate:"omitempty,min=1,max=90"` // defaults to 7
+	FirstPublishAt   time.Time               `json:"first_publish_at" validate:"required"`
+	Assessment       CreateAssessmentRequest `json:"assessment"`
+}
+
+type AssessmentSeriesResponse struct {
+	Series      *models.AssessmentSeries `json:"series"`
+	Assessments []*AssessmentResponse    `json:"assessments"`
+}
+
+type EnrollStudentsRequest struct {
+	StudentIDs []string `json:"student_ids" validate:"required,min=1,max=500,dive,required"`
+	// Class the students take the assessment with; its override applies to them
+	ClassID *string `json:"class_id" validate:"omitempty,min=1,max=100"`
+}
+
+type EnrollStudentsResult struct {
+	AssessmentID uint     `json:"assessment_id"`
+	Enrolled     []string `json:"enrolled"`
+	Invalid      []string `json:"invalid"` // Unknown users or users who are not students
+}
+
+// SetClassOverrideRequest replaces a class's override; unset fields keep the assessment's values
+type SetClassOverrideRequest struct {
+	DueDate        *time.Time `json:"due_date"`
+	AvailableFrom  *time.Time `json:"available_from"`
+	AvailableUntil *time.Time `json:"available_until"`
+	MaxAttempts    *int       `json:"max_attempts" validate:"omitempty,min=1,max=10"`
+}
+
+// StudentAttemptTerms is when and how often a student may attempt an assessment, after their
+// class override and accommodations are applied
+type StudentAttemptTerms struct {
+	ClassID        *string    `json:"class_id"`
+	DueDate        *time.Time `json:"due_date"`
+	AvailableFrom  *time.Time `json:"available_from"`
+	AvailableUntil *time.Time `json:"available_until"`
+	MaxAttempts    int        `json:"max_attempts"`
+}
+
+type StudentAccessStatus string
+
+const (
+	AccessNotAccessed StudentAccessStatus = "not_accessed"
+	AccessViewed      StudentAccessStatus = "viewed"
+	AccessStarted     StudentAccessStatus = "started"
+	AccessSubmitted   StudentAccessStatus = "submitted"
+)
+
+type StudentAccessRecord struct {
+	StudentID      string              `json:"student_id"`
+	StudentName    string              `json:"student_name,omitempty"`
+	Email          string              `json:"email,omitempty"`
+	Enrolled       bool                `json:"enrolled"` // False for students who accessed the assessment without an enrollment
+	Status         StudentAccessStatus `json:"status"`
+	ViewCount      int                 `json:"view_count"`
+	FirstViewedAt  *time.Time          `json:"first_viewed_at"`
+	LastViewedAt   *time.Time          `json:"last_viewed_at"`
+	AttemptCount   int                 `json:"attempt_count"`
+	FirstStartedAt *time.Time          `json:"first_started_at"`
+	LastActivityAt *time.Time          `json:"last_activity_at"`
+}
+
+type AssessmentAccessAudit struct {
+	AssessmentID     uint                  `json:"assessment_id"`
+	DueDate          *time.Time            `json:"due_date"`
+	EnrolledCount    int                   `json:"enrolled_count"`
+	NotAccessedCount int                   `json:"not_accessed_count"`
+	ViewedCount      int                   `json:"viewed_count"` // Viewed without starting an attempt
+	StartedCount     int                   `json:"started_count"`
+	SubmittedCount   int                   `json:"submitted_count"`
+	Students         []StudentAccessRecord `json:"students"` // Least engaged students first
+	GeneratedAt      time.Time             `json:"generated_at"`
+}
+
+// TimelineEventType names a lifecycle event on an assessment's timeline
+type TimelineEventType string
+
+const (
+	TimelineCreated          TimelineEventType = "created"
+	TimelineEdited           TimelineEventType = "edited"
+	TimelinePublished        TimelineEventType = "published"
+	TimelineFirstAttempt     TimelineEventType = "first_attempt"
+	TimelineGradingCompleted TimelineEventType = "grading_completed"
+	TimelineResultsReleased  TimelineEventType = "results_released"
+	TimelineArchived         TimelineEventType = "archived"
+)
+
+type AssessmentTimelineEvent struct {
+	Type        TimelineEventType `json:"type"`
+	At          time.Time         `json:"at"`
+	ActorID     string            `json:"actor_id,omitempty"` // Empty for events without a single actor
+	ActorName   string            `json:"actor_name,omitempty"`
+	ActorRole   models.UserRole   `json:"actor_role,omitempty"`
+	Description string            `json:"description"`
+	Changes     datatypes.JSON    `json:"changes,omitempty"` // Before/after values of edits
+}
+
+type AssessmentTimeline struct {
+	AssessmentID uint                      `json:"assessment_id"`
+	Title        string                    `json:"title"`
+	Status       models.AssessmentStatus   `json:"status"`
+	Events       []AssessmentTimelineEvent `json:"events"` // Oldest first
+	GeneratedAt  time.Time                 `json:"generated_at"`
+}
+
+type ReadinessIssue struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	QuestionID *uint  `json:"question_id,omitempty"` // Set when one question needs fixing
+	Guideline  string `json:"guideline,omitempty"`   // WCAG success criterion, for accessibility issues
+}
+
+// EstimateSource tells whether a question estimate comes from past responses or its difficulty level
+type EstimateSource string
+
+const (
+	EstimateFromHistory    EstimateSource = "historical"
+	EstimateFromDifficulty EstimateSource = "difficulty_prior"
+)
+
+type QuestionDifficultyEstimate struct {
+	QuestionID        uint                   `json:"question_id"`
+	Points            int                    `json:"points"`
+	Difficulty        models.DifficultyLevel `json:"difficulty"`
+	Responses         int                    `json:"responses"`
+	CorrectRate       *float64               `json:"correct_rate"`        // nil without historical responses
+	ExpectedScoreRate float64                `json:"expected_score_rate"` // 0-1 share of points earned
+	ExpectedSeconds   int                    `json:"expected_seconds"`
+	Source            EstimateSource         `json:"source"`
+}
+
+type AssessmentDifficultyEstimate struct {
+	TotalPoints               int                          `json:"total_points"`
+	ExpectedScore             float64                      `json:"expected_score"`      // points
+	ExpectedPercentage        float64                      `json:"expected_percentage"` // 0-100
+	ExpectedCompletionSeconds int                          `json:"expected_completion_seconds"`
+	HistoricalCoverage        float64                      `json:"historical_coverage"` // Share of questions estimated from history
+	Questions                 []QuestionDifficultyEstimate `json:"questions"`
+}
+
+// GradingTimeSource tells where a question's grading time per answer comes from
+type GradingTimeSource string
+
+const (
+	GradingTimeFromQuestion GradingTimeSource = "question_history" // Past grades of this question
+	GradingTimeFromTeacher  GradingTimeSource = "teacher_history"  // Past grades on the teacher's assessments
+	GradingTimeDefault      GradingTimeSource = "default"
+)
+
+type QuestionGradingEstimate struct {
+	QuestionID       uint                `json:"question_id"`
+	Type             models.QuestionType `json:"type"`
+	MinutesPerAnswer float64             `json:"minutes_per_answer"`
+	Samples          int                 `json:"samples"` // Timed grades behind the estimate
+	Source           GradingTimeSource   `json:"source"`
+}
+
+// GradingWorkloadEstimate is the hand grading an assessment is expected to need once published
+type GradingWorkloadEstimate struct {
+	ExpectedStudents  int                       `json:"expected_students"` // Enrolled students
+	ManualQuestions   int                       `json:"manual_questions"`
+	ExpectedAnswers   int                       `json:"expected_answers"`
+	MinutesPerStudent float64                   `json:"minutes_per_student"`
+	ExpectedMinutes   float64                   `json:"expected_minutes"`
+	Questions         []QuestionGradingEstimate `json:"questions"`
+}
+
+// SimilarAssessment is another assessment in the organization that may duplicate this one
+type SimilarAssessment struct {
+	AssessmentID    uint                    `json:"assessment_id"`
+	Title           string                  `json:"title"`
+	Status          models.AssessmentStatus `json:"status"`
+	CreatedBy       string                  `json:"created_by"`
+	CreatorName     string                  `json:"creator_name"`
+	SameTitle       bool                    `json:"same_title"` // Titles equal ignoring case, numbers and punctuation
+	SharedQuestions int                     `json:"shared_questions"`
+	QuestionOverlap float64                 `json:"question_overlap"` // Share (0-1) of this assessment's questions it also contains
+	Link            string                  `json:"link"`
+}
+
+// AssessmentReadinessReport lists what blocks publishing and what the teacher may want to rebalance
+type AssessmentReadinessReport struct {
+	AssessmentID uint                          `json:"assessment_id"`
+	Ready        bool                          `json:"ready"` // No blockers
+	Blockers     []ReadinessIssue              `json:"blockers"`
+	Warnings     []ReadinessIssue              `json:"warnings"`
+	Estimate     *AssessmentDifficultyEstimate `json:"estimate"`
+	Grading      *GradingWorkloadEstimate      `json:"grading"`
+	Duplicates   []SimilarAssessment           `json:"duplicates"`
+	GeneratedAt  time.Time                     `json:"generated_at"`
+}
+
+// AssemblyWarning flags a part of an assessment's question mix that goes against the exam policy
+type AssemblyWarning struct {
+	Code        string                 `json:"code"`
+	Message     string                 `json:"message"`
+	QuestionIDs []uint                 `json:"question_ids"`
+	SkillTag    string                 `json:"skill_tag,omitempty"`
+	Difficulty  models.DifficultyLevel `json:"difficulty,omitempty"`
+	Percent     *float64               `json:"percent,omitempty"`    // Share of questions, 0-100
+	Expected    *float64               `json:"expected,omitempty"`   // Policy limit or target, 0-100
+	Similarity  *float64               `json:"similarity,omitempty"` // Near-duplicates only, 0-1
+}
+
+// AssemblyCheck is returned when questions are added to an assessment
+type AssemblyCheck struct {
+	AssessmentID  uint              `json:"assessment_id"`
+	QuestionCount int               `json:"question_count"`
+	Warnings      []AssemblyWarning `json:"warnings"`
+}
+
+// AssessmentDeadline states the due date unambiguously and the cut-offs the server enforces.
+// Local times are RFC 3339 strings carrying their offset.
+type AssessmentDeadline struct {
+	AssessmentID                    uint       `json:"assessment_id"`
+	HasDueDate                      bool       `json:"has_due_date"`
+	DueAt                           *time.Time `json:"due_at"`   // UTC
+	Timezone                        string     `json:"timezone"` // Zone the due date was set in
+	DueAtLocal                      string     `json:"due_at_local"`
+	ViewerTimezone                  string     `json:"viewer_timezone"`
+	DueAtViewer                     string     `json:"due_at_viewer"`
+	StartBy                         *time.Time `json:"start_by"`                    // Last moment to start an attempt
+	AcceptingSubmissionsUntil       *time.Time `json:"accepting_submissions_until"` // Attempts started by the due date end by then
+	AcceptingSubmissionsUntilViewer string     `json:"accepting_submissions_until_viewer"`
+	AcceptingNewAttempts            bool       `json:"accepting_new_attempts"`
+	AcceptingSubmissions            bool       `json:"accepting_submissions"`
+	ServerTime                      time.Time  `json:"server_time"`
+}
+
 // ===== ATTEMPT RELATED DTOs =====
 
-type StartAttemptRequest struct {
-	AssessmentID uint `json:"assessment_id" validate:"required"`
+type StartAttemptRequest struct {
+	AssessmentID uint `json:"assessment_id" validate:"required"`
+}
+
+type SubmitAnswerRequest struct {
+	QuestionID uint        `json:"question_id" validate:"required"`
+	AnswerData interface{} `json:"answer_data" validate:"required"`
+	PartID     string      `json:"part_id"` // Answers one part of a multi-part question, keeping the others
+	TimeSpent  *int        `json:"time_spent"`
+	Flush      bool        `json:"flush"` // Write immediately, e.g. when navigating away from the question
+	// Self-rated confidence from 1 to 5, when the assessment asks for it
+	Confidence *int `json:"confidence" validate:"omitempty,min=1,max=5"`
+}
+
+type SubmitAttemptRequest struct {
+	AttemptID uint                  `json:"attempt_id" validate:"required"`
+	Answers   []SubmitAnswerRequest `json:"answers" validate:"required,dive"`
+	TimeSpent *int                  `json:"time_spent"`
+	EndReason string                `json:"end_reason"`
+	Confirmed bool                  `json:"confirmed"` // Student acknowledged the submission summary
+}
+
+// UpdateAttemptCustomFieldsRequest changes the given custom fields of an attempt; null removes a field
+type UpdateAttemptCustomFieldsRequest struct {
+	CustomFields map[string]interface{} `json:"custom_fields" validate:"required"`
+}
+
+type FlagQuestionRequest struct {
+	Flagged bool `json:"flagged"`
+}
+
+// BookmarkQuestionRequest sets a personal bookmark; bookmarks are not shown to graders
+type BookmarkQuestionRequest struct {
+	Bookmarked bool `json:"bookmarked"`
+}
+
+type AttemptBookmarks struct {
+	AttemptID   uint   `json:"attempt_id"`
+	QuestionIDs []uint `json:"question_ids"` // In the order they were bookmarked
+}
+
+// SpellCheckRequest carries the essay draft to check; the saved answer is not changed
+type SpellCheckRequest struct {
+	Text string `json:"text" validate:"required,max=50000"`
+}
+
+type SpellingSuggestion struct {
+	Word       string `json:"word"`
+	Suggestion string `json:"suggestion"`
+}
+
+type SpellCheckResponse struct {
+	AttemptID   uint                 `json:"attempt_id"`
+	QuestionID  uint                 `json:"question_id"`
+	Suggestions []SpellingSuggestion `json:"suggestions"` // Once per distinct misspelled word
+}
+
+// NextQuestion is where branching rules send a student after a question. Question is nil
+// once the route is finished.
+type NextQuestion struct {
+	AttemptID       uint                `json:"attempt_id"`
+	AfterQuestionID uint                `json:"after_question_id"`
+	Question        *QuestionForAttempt `json:"question"`
+	Route           []uint              `json:"route"` // Questions the student goes through given the answers so far
+}
+
+// SubmissionSummary lists what is left open in an attempt and which submission gates apply
+type SubmissionSummary struct {
+	AttemptID              uint   `json:"attempt_id"`
+	TotalQuestions         int    `json:"total_questions"`
+	AnsweredCount          int    `json:"answered_count"`
+	Unanswered             []uint `json:"unanswered"` // Question IDs in assessment order
+	Flagged                []uint `json:"flagged"`
+	RequireAllAnswered     bool   `json:"require_all_answered"`
+	RequireFlaggedResolved bool   `json:"require_flagged_resolved"`
+	RequireConfirmation    bool   `json:"require_confirmation"`
+	CanSubmit              bool   `json:"can_submit"`         // No blocking gate is failing
+	NeedsConfirmation      bool   `json:"needs_confirmation"` // Submit must be sent with confirmed set
+}
+
+type AttemptResponse struct {
+	*models.AssessmentAttempt
+	CanSubmit       bool                       `json:"can_submit"`
+	CanResume       bool                       `json:"can_resume"`
+	ResultsReleased bool                       `json:"results_released"`
+	Questions       []QuestionForAttempt       `json:"questions,omitempty"`
+	Bookmarks       []uint                     `json:"bookmarks,omitempty"`       // Student's bookmarked question IDs
+	QuestionTiming  *QuestionTiming            `json:"question_timing,omitempty"` // Per-question timing mode only
+	Annotations     []*models.AnswerAnnotation `json:"annotations,omitempty"`     // Grader annotations, once results are released
+}
+
+type QuestionForAttempt struct {
+	*models.Question
+	IsLast           bool `json:"is_last"`
+	IsFirst          bool `json:"is_first"`
+	Bookmarked       bool `json:"bookmarked"`
+	MaxAnswerChanges *int `json:"max_answer_changes,omitempty"` // Unset when answers can be changed freely
+	ChangesRemaining *int `json:"changes_remaining,omitempty"`
+}
+
+// QuestionTiming is the clock of an attempt taken in per-question timing mode
+type QuestionTiming struct {
+	Mode             models.TimingMode `json:"mode"`
+	QuestionIndex    int               `json:"question_index"`
+	TotalQuestions   int               `json:"total_questions"`
+	QuestionID       uint              `json:"question_id"`
+	BudgetSeconds    int               `json:"budget_seconds"`
+	StartedAt        *time.Time        `json:"started_at"`
+	Deadline         *time.Time        `json:"deadline"`
+	SecondsRemaining int               `json:"seconds_remaining"`
+	IsLast           bool              `json:"is_last"`
+	Expired          bool              `json:"expired"` // Time ran out on every question
+}
+
+// CurrentQuestion is the only question a student can see and answer in per-question timing mode
+type CurrentQuestion struct {
+	AttemptID uint                `json:"attempt_id"`
+	Question  *QuestionForAttempt `json:"question"`
+	Timing    *QuestionTiming     `json:"timing"`
+}
+
+// AttemptSection is one section of an attempt. A locked section's questions are read-only.
+type AttemptSection struct {
+	Name        string `json:"name"`
+	QuestionIDs []uint `json:"question_ids"`
+	Locked      bool   `json:"locked"`
+}
+
+// AttemptSections lists an attempt's sections in delivery order
+type AttemptSections struct {
+	AttemptID     uint             `json:"attempt_id"`
+	LockCompleted bool             `json:"lock_completed"` // Whether finished sections become read-only
+	Sections      []AttemptSection `json:"sections"`
+}
+
+// CompleteSectionRequest finishes a section, and every section before it, ahead of moving on
+type CompleteSectionRequest struct {
+	Section string `json:"section" validate:"required,max=100"`
+}
+
+// AttemptQueueStatus is a student's place in line for an assessment that caps concurrent attempts
+type AttemptQueueStatus struct {
+	AssessmentID   uint       `json:"assessment_id"`
+	Capacity       int        `json:"capacity"` // 0 when attempts are not capped
+	ActiveAttempts int        `json:"active_attempts"`
+	QueueLength    int        `json:"queue_length"` // Students still waiting
+	Queued         bool       `json:"queued"`
+	JoinedAt       *time.Time `json:"joined_at,omitempty"`
+	Position       int        `json:"position,omitempty"` // 1 is next in line; 0 once admitted
+	Admitted       bool       `json:"admitted"`
+	AdmittedUntil  *time.Time `json:"admitted_until,omitempty"` // Start before this or the slot goes to the next student
+}
+
+type CreateAttemptQuotaRequest struct {
+	Name        string  `json:"name" validate:"required,min=1,max=100"`
+	BankID      uint    `json:"bank_id" validate:"required"`
+	ClassID     *string `json:"class_id" validate:"omitempty,min=1,max=100"` // Omit for every student
+	MaxAttempts int     `json:"max_attempts" validate:"required,min=1,max=1000"`
+	WindowHours int     `json:"window_hours" validate:"required,min=1,max=8760"`
+}
+
+type UpdateAttemptQuotaRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=100"`
+	ClassID     *string `json:"class_id" validate:"omitempty,max=100"` // Empty applies the quota to every student
+	MaxAttempts *int    `json:"max_attempts" validate:"omitempty,min=1,max=1000"`
+	WindowHours *int    `json:"window_hours" validate:"omitempty,min=1,max=8760"`
+	IsActive    *bool   `json:"is_active"`
+}
+
+// AttemptQuotaStatus is how much of a quota a student has used in its current window
+type AttemptQuotaStatus struct {
+	QuotaID         uint       `json:"quota_id"`
+	Name            string     `json:"name"`
+	BankID          uint       `json:"bank_id"`
+	ClassID         *string    `json:"class_id,omitempty"`
+	MaxAttempts     int        `json:"max_attempts"`
+	WindowHours     int        `json:"window_hours"`
+	WindowStart     time.Time  `json:"window_start"`
+	Used            int        `json:"used"`
+	Remaining       int        `json:"remaining"`
+	NextAvailableAt *time.Time `json:"next_available_at,omitempty"` // When an attempt frees up once the quota is used up
+}
+
+// ScoreBreakdownGroup aggregates earned and possible points for one group of questions
+type ScoreBreakdownGroup struct {
+	Key            string  `json:"key"`
+	Label          string  `json:"label"`
+	QuestionCount  int     `json:"question_count"`
+	EarnedPoints   float64 `json:"earned_points"`
+	PossiblePoints int     `json:"possible_points"`
+	PendingPoints  int     `json:"pending_points"` // Possible points of answers not graded yet
+	Percentage     float64 `json:"percentage"`
+}
+
+// AttemptChange is how a student's result on a question moved from one attempt to the next
+type AttemptChange string
+
+const (
+	AttemptChangeImproved      AttemptChange = "improved"
+	AttemptChangeRegressed     AttemptChange = "regressed"
+	AttemptChangeUnchanged     AttemptChange = "unchanged"
+	AttemptChangeNotComparable AttemptChange = "not_comparable" // Not graded yet, or not in both attempts
+)
+
+// AttemptComparison sets a student's finished attempts at an assessment side by side
+type AttemptComparison struct {
+	StudentID    string               `json:"student_id"`
+	AssessmentID uint                 `json:"assessment_id"`
+	Attempts     []ComparedAttempt    `json:"attempts"` // Oldest first
+	Questions    []QuestionComparison `json:"questions"`
+	// Latest attempt against the one before it
+	Improved      int     `json:"improved"`
+	Regressed     int     `json:"regressed"`
+	Unchanged     int     `json:"unchanged"`
+	NotComparable int     `json:"not_comparable"`
+	ScoreChange   float64 `json:"score_change"` // Percentage points
+}
+
+type ComparedAttempt struct {
+	AttemptID     uint                 `json:"attempt_id"`
+	AttemptNumber int                  `json:"attempt_number"`
+	Status        models.AttemptStatus `json:"status"`
+	Score         float64              `json:"score"`
+	MaxScore      int                  `json:"max_score"`
+	Percentage    float64              `json:"percentage"`
+	Passed        bool                 `json:"passed"`
+	CompletedAt   *time.Time           `json:"completed_at"`
+}
+
+type QuestionComparison struct {
+	QuestionID uint                    `json:"question_id"`
+	Type       models.QuestionType     `json:"type"`
+	Text       string                  `json:"text"`
+	Results    []QuestionAttemptResult `json:"results"` // One per attempt, in attempt order
+	Change     AttemptChange           `json:"change"`  // Latest attempt against the one before it
+}
+
+type QuestionAttemptResult struct {
+	AttemptID uint          `json:"attempt_id"`
+	Presented bool          `json:"presented"` // False when the attempt did not include the question
+	Answered  bool          `json:"answered"`
+	Graded    bool          `json:"graded"`
+	Score     float64       `json:"score"`
+	MaxScore  int           `json:"max_score"`
+	IsCorrect *bool         `json:"is_correct"`
+	Change    AttemptChange `json:"change,omitempty"` // Against the previous attempt; empty on the first
+}
+
+type AttemptScoreBreakdown struct {
+	AttemptID      uint                  `json:"attempt_id"`
+	AssessmentID   uint                  `json:"assessment_id"`
+	EarnedPoints   float64               `json:"earned_points"`
+	PossiblePoints int                   `json:"possible_points"`
+	PendingPoints  int                   `json:"pending_points"`
+	Percentage     float64               `json:"percentage"`
+	ByType         []ScoreBreakdownGroup `json:"by_type"`
+	ByDifficulty   []ScoreBreakdownGroup `json:"by_difficulty"`
+	BySection      []ScoreBreakdownGroup `json:"by_section"` // Sections follow question categories
+	BySkill        []ScoreBreakdownGroup `json:"by_skill"`   // Skills follow question tags
+	Standing       *AttemptStanding      `json:"standing,omitempty"`
+}
+
+// AttemptStanding places a finished attempt among all finished attempts on its assessment.
+// Attempts with the same percentage share a rank.
+type AttemptStanding struct {
+	Rank         int       `json:"rank"`       // 1 is the highest percentage
+	OutOf        int       `json:"out_of"`     // Finished attempts, this one included
+	Percentile   float64   `json:"percentile"` // Share of attempts scoring lower, ties counting half
+	CalculatedAt time.Time `json:"calculated_at"`
+}
+
+// AttemptTranscript is a finished attempt as it was delivered, with the results the viewer may
+// see. For students, results, correct answers and the breakdown follow the assessment's
+// result settings; withheld parts are left empty.
+type AttemptTranscript struct {
+	AttemptID       uint                 `json:"attempt_id"`
+	AssessmentID    uint                 `json:"assessment_id"`
+	AssessmentTitle string               `json:"assessment_title"`
+	StudentID       string               `json:"student_id,omitempty"` // Omitted while grading is anonymous
+	StudentName     string               `json:"student_name"`         // A pseudonym while grading is anonymous
+	AttemptNumber   int                  `json:"attempt_number"`
+	Status          models.AttemptStatus `json:"status"`
+	StartedAt       *time.Time           `json:"started_at"`
+	CompletedAt     *time.Time           `json:"completed_at"`
+	TimeSpent       int                  `json:"time_spent"` // seconds
+
+	ResultsIncluded        bool     `json:"results_included"`
+	CorrectAnswersIncluded bool     `json:"correct_answers_included"`
+	Score                  *float64 `json:"score,omitempty"`
+	MaxScore               int      `json:"max_score"`
+	Percentage             *float64 `json:"percentage,omitempty"`
+	Passed                 *bool    `json:"passed,omitempty"`
+
+	Questions   []TranscriptQuestion   `json:"questions"`
+	Breakdown   *AttemptScoreBreakdown `json:"breakdown,omitempty"`
+	Standing    *AttemptStanding       `json:"standing,omitempty"`
+	Integrity   *IntegritySummary      `json:"integrity,omitempty"`
+	GeneratedAt time.Time              `json:"generated_at"`
+}
+
+// TranscriptQuestion is one delivered question with the student's answer in readable form
+type TranscriptQuestion struct {
+	Number        int                         `json:"number"`
+	QuestionID    uint                        `json:"question_id"`
+	Type          models.QuestionType         `json:"type"`
+	Text          string                      `json:"text"`
+	Points        int                         `json:"points"`
+	Answer        string                      `json:"answer"` // Empty when not answered
+	CorrectAnswer string                      `json:"correct_answer,omitempty"`
+	Score         *float64                    `json:"score,omitempty"` // Nil while ungraded or withheld
+	Feedback      *string                     `json:"feedback,omitempty"`
+	PartScores    []models.PartScore          `json:"part_scores,omitempty"`
+	Rubric        []TranscriptRubricCriterion `json:"rubric,omitempty"`
+	Comments      []string                    `json:"comments,omitempty"` // Grader annotations outside the rubric
+}
+
+// TranscriptRubricCriterion is a rubric criterion with the grader's comments filed under it
+type TranscriptRubricCriterion struct {
+	Name     string               `json:"name"`
+	Levels   []models.RubricLevel `json:"levels,omitempty"`
+	Comments []string             `json:"comments,omitempty"`
+}
+
+// IntegritySummary counts the proctoring events recorded during an attempt
+type IntegritySummary struct {
+	TotalEvents     int                   `json:"total_events"`
+	HighestSeverity int                   `json:"highest_severity"` // 1-5, 0 without events
+	Events          []IntegrityEventCount `json:"events"`
+	Sessions        int                   `json:"sessions"`
+	// Essay answers written in another language than the assessment expects
+	LanguageMismatches int `json:"language_mismatches"`
+}
+
+type IntegrityEventCount struct {
+	Type  models.ProctoringEventType `json:"type"`
+	Count int                        `json:"count"`
+}
+
+// ExportedDocument is a generated file ready for download
+type ExportedDocument struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// OfflineBundleExport is an in-progress attempt packaged for a proctor app in an exam hall
+// without internet. The app signs the answers it sends back with signing_key.
+type OfflineBundleExport struct {
+	BundleID           uint                 `json:"bundle_id"`
+	AttemptID          uint                 `json:"attempt_id"`
+	AssessmentID       uint                 `json:"assessment_id"`
+	AssessmentTitle    string               `json:"assessment_title"`
+	StudentID          string               `json:"student_id"`
+	StartedAt          *time.Time           `json:"started_at"`
+	Deadline           *time.Time           `json:"deadline"` // Unset without a time limit
+	ExportedAt         time.Time            `json:"exported_at"`
+	Questions          []QuestionForAttempt `json:"questions"`
+	Answers            []OfflineAnswer      `json:"answers"` // Given online before the export
+	SigningKey         string               `json:"signing_key"`
+	SignatureAlgorithm string               `json:"signature_algorithm"`
+}
+
+// ImportOfflineBundleRequest carries the filled bundle exactly as the proctor app signed it
+type ImportOfflineBundleRequest struct {
+	Payload   string `json:"payload" validate:"required"`   // JSON of an OfflineBundleSubmission
+	Signature string `json:"signature" validate:"required"` // Hex HMAC-SHA256 of payload with the bundle's signing key
+}
+
+// OfflineBundleSubmission is what the proctor app collected while offline
+type OfflineBundleSubmission struct {
+	BundleID    uint                   `json:"bundle_id"`
+	AttemptID   uint                   `json:"attempt_id"`
+	Answers     []OfflineAnswer        `json:"answers"`
+	SubmittedAt *time.Time             `json:"submitted_at"` // Unset when the student did not finish
+	Device      map[string]interface{} `json:"device"`       // Device ID, app version and the like
+}
+
+type OfflineAnswer struct {
+	QuestionID uint            `json:"question_id"`
+	PartID     string          `json:"part_id,omitempty"`
+	AnswerData json.RawMessage `json:"answer_data"`
+	AnsweredAt time.Time       `json:"answered_at"`
+	TimeSpent  *int            `json:"time_spent,omitempty"`
+	Confidence *int            `json:"confidence,omitempty"`
+}
+
+// Reasons an offline answer is not applied
+const (
+	OfflineConflictNotInAttempt  = "not_in_attempt"
+	OfflineConflictOutsideWindow = "outside_attempt_window"
+	OfflineConflictChangedOnline = "changed_online"
+)
+
+type OfflineAnswerConflict struct {
+	QuestionID uint      `json:"question_id"`
+	PartID     string    `json:"part_id,omitempty"`
+	AnsweredAt time.Time `json:"answered_at"`
+	Reason     string    `json:"reason"`
+}
+
+type OfflineBundleImportResult struct {
+	BundleID        uint                       `json:"bundle_id"`
+	AttemptID       uint                       `json:"attempt_id"`
+	Status          models.OfflineBundleStatus `json:"status"`
+	AnswersApplied  int                        `json:"answers_applied"`
+	Conflicts       []OfflineAnswerConflict    `json:"conflicts"`
+	Submitted       bool                       `json:"submitted"` // The bundle closed the attempt
+	RejectionReason *string                    `json:"rejection_reason,omitempty"`
+}
+
+// Ways a duplicate's answer is merged into the surviving attempt
+const (
+	AnswerMergeCopied   = "copied"   // The survivor had no answer to the question
+	AnswerMergeReplaced = "replaced" // The duplicate's answer was more recent
+)
+
+// AnswerMerge is one answer taken over from a duplicate attempt
+type AnswerMerge struct {
+	QuestionID       uint      `json:"question_id"`
+	FromAttemptID    uint      `json:"from_attempt_id"`
+	SourceAnswerID   uint      `json:"source_answer_id"`
+	Action           string    `json:"action"`
+	AnswerModifiedAt time.Time `json:"answer_modified_at"`
+}
+
+// DuplicateAttemptGroup is a student with several attempts in progress on one assessment,
+// with the merge that would repair it
+type DuplicateAttemptGroup struct {
+	StudentID         string                      `json:"student_id"`
+	AssessmentID      uint                        `json:"assessment_id"`
+	Attempts          []*models.AssessmentAttempt `json:"attempts"`
+	SurvivorAttemptID uint                        `json:"survivor_attempt_id"` // The earliest started, unless chosen otherwise
+	Merges            []AnswerMerge               `json:"merges"`
+}
+
+type MergeDuplicateAttemptsRequest struct {
+	StudentID         string `json:"student_id" validate:"required,max=255"`
+	AssessmentID      uint   `json:"assessment_id" validate:"required"`
+	SurvivorAttemptID *uint  `json:"survivor_attempt_id"` // Defaults to the earliest started attempt
+	Reason            string `json:"reason" validate:"required,min=10,max=500"`
+}
+
+type AttemptMergeResult struct {
+	StudentID         string        `json:"student_id"`
+	AssessmentID      uint          `json:"assessment_id"`
+	SurvivorAttemptID uint          `json:"survivor_attempt_id"`
+	VoidedAttemptIDs  []uint        `json:"voided_attempt_ids"`
+	Merges            []AnswerMerge `json:"merges"`
+	QuestionsAnswered int           `json:"questions_answered"`
+	Reason            string        `json:"reason"`
+	MergedBy          string        `json:"merged_by"`
+	MergedAt          time.Time     `json:"merged_at"`
+}
+
+// ===== QUESTION RELATED DTOs =====
+
+// Use business validator types
+type CreateQuestionRequest = validator.QuestionCreateRequest
+
+type UpdateQuestionRequest struct {
+	Text        *string                 `json:"text" validate:"omitempty,max=2000"`
+	Content     interface{}             `json:"content"`
+	Points      *int                    `json:"points" validate:"omitempty,min=1,max=100"`
+	TimeLimit   *int                    `json:"time_limit" validate:"omitempty,min=30,max=3600"`
+	Difficulty  *models.DifficultyLevel `json:"difficulty"`
+	CategoryID  *uint                   `json:"category_id"`
+	Tags        []string                `json:"tags"`
+	Explanation *string                 `json:"explanation" validate:"omitempty,max=1000"`
+
+	ReviewIntervalMonths *int  `json:"review_interval_months" validate:"omitempty,min=1,max=60"`
+	RequireManualReview  *bool `json:"require_manual_review"`
+}
+
+type QuestionResponse struct {
+	*models.Question
+	CanEdit      bool                         `json:"can_edit"`
+	CanDelete    bool                         `json:"can_delete"`
+	UsageCount   int                          `json:"usage_count"`
+	IsFavorite   bool                         `json:"is_favorite"`
+	Lint         *validator.ContentLintResult `json:"lint,omitempty"` // Only when linting was requested
+	Translations []models.LocaleStatus        `json:"translations"`   // Review status per locale
+}
+
+// UpsertQuestionTranslationRequest replaces a question's translation in one locale; content
+// follows the question type's structure and defaults to the source content
+type UpsertQuestionTranslationRequest struct {
+	Text        string      `json:"text" validate:"required,max=2000"`
+	Content     interface{} `json:"content"`
+	Explanation *string     `json:"explanation" validate:"omitempty,max=1000"`
+}
+
+type ReviewQuestionTranslationRequest struct {
+	Approved bool    `json:"approved"`
+	Note     *string `json:"note" validate:"omitempty,max=2000"` // Required when requesting changes
+}
+
+type TranslationReviewQueue struct {
+	Translations []*models.QuestionTranslation `json:"translations"`
+	Total        int64                         `json:"total"`
+	Page         int                           `json:"page"`
+	Size         int                           `json:"size"`
+}
+
+// SetQuestionExternalRefRequest sets the ID a content provider gives a question
+type SetQuestionExternalRefRequest struct {
+	ExternalID string `json:"external_id" validate:"required,max=255"`
+}
+
+// ResolveExternalRefsRequest looks up many of a provider's IDs; the owner defaults to the caller
+type ResolveExternalRefsRequest struct {
+	Provider    string   `json:"provider" validate:"required,max=100"`
+	ExternalIDs []string `json:"external_ids" validate:"required,min=1,max=500"`
+	OwnerID     *string  `json:"owner_id"` // Admins only
+}
+
+type ExternalRefResolution struct {
+	Provider string          `json:"provider"`
+	Found    map[string]uint `json:"found"`   // External ID to question ID
+	Missing  []string        `json:"missing"` // External IDs without a question
+}
+
+// BulkReassignQuestionsRequest retags or recategorizes many questions at once. Questions
+// are selected by ID, by filter, or both; filters only match the caller's own questions.
+type BulkReassignQuestionsRequest struct {
+	QuestionIDs   []uint              `json:"question_ids" validate:"omitempty,max=5000"`
+	Filter        *BulkQuestionFilter `json:"filter"`
+	AddTags       []string            `json:"add_tags" validate:"omitempty,max=10,dive,min=1,max=50"`
+	RemoveTags    []string            `json:"remove_tags" validate:"omitempty,max=10,dive,min=1,max=50"`
+	CategoryID    *uint               `json:"category_id"`    // Moves the questions to this category
+	ClearCategory bool                `json:"clear_category"` // Takes the questions out of their category
+}
+
+// BulkQuestionFilter matches questions having all of Tags and every other field set
+type BulkQuestionFilter struct {
+	Tags       []string                `json:"tags" validate:"omitempty,max=10,dive,min=1,max=50"`
+	CategoryID *uint                   `json:"category_id"`
+	Type       *models.QuestionType    `json:"type"`
+	Difficulty *models.DifficultyLevel `json:"difficulty"`
+}
+
+// BulkReassignResult counts what a bulk reassignment changes, or would change in a preview
+type BulkReassignResult struct {
+	Matched       int            `json:"matched"`      // Questions selected
+	Affected      int            `json:"affected"`     // Questions actually changed
+	TagsAdded     map[string]int `json:"tags_added"`   // Questions gaining each tag
+	TagsRemoved   map[string]int `json:"tags_removed"` // Questions losing each tag
+	Recategorized int            `json:"recategorized"`
+	QuestionIDs   []uint         `json:"question_ids"` // Affected questions
+	Applied       bool           `json:"applied"`      // False for a preview
+}
+
+type QuestionListResponse struct {
+	Questions []*QuestionResponse `json:"questions"`
+	Total     int64               `json:"total"`
+	Page      int                 `json:"page"`
+	Size      int                 `json:"size"`
+}
+
+// ===== GRADING RELATED DTOs =====
+
+type GradingResult struct {
+	AnswerID      uint      `json:"answer_id"`
+	QuestionID    uint      `json:"question_id"`
+	Score         float64   `json:"score"`
+	MaxScore      float64   `json:"max_score"`
+	IsCorrect     bool      `json:"is_correct"`
+	PartialCredit bool      `json:"partial_credit"`
+	Feedback      *string   `json:"feedback"`
+	GradedAt      time.Time `json:"graded_at"`
+	GradedBy      *string   `json:"graded_by"`
+	// The score is provisional: the question requires manual review, so the answer stays in
+	// the grading queue until a teacher grades it
+	Provisional bool `json:"provisional,omitempty"`
+
+	PartScores []models.PartScore `json:"part_scores,omitempty"` // Multi-part questions only
+}
+
+// TestGradeQuestionRequest is a hypothetical student answer, in the format students submit it
+type TestGradeQuestionRequest struct {
+	AnswerData interface{} `json:"answer_data" validate:"required"`
+}
+
+// SampleGradingResult is what the grading engine would give an answer; nothing is stored
+type SampleGradingResult struct {
+	QuestionID         uint                `json:"question_id"`
+	QuestionType       models.QuestionType `json:"question_type"`
+	Score              float64             `json:"score"`
+	MaxScore           float64             `json:"max_score"`
+	IsCorrect          bool                `json:"is_correct"`
+	PartialCredit      bool                `json:"partial_credit"`
+	Feedback           *string             `json:"feedback"`
+	NeedsManualGrading bool                `json:"needs_manual_grading"`  // Essays, and manually graded parts
+	PartScores         []models.PartScore  `json:"part_scores,omitempty"` // Multi-part questions only
+}
+
+type AttemptGradingResult struct {
+	AttemptID  uint            `json:"attempt_id"`
+	TotalScore float64         `json:"total_score"`
+	MaxScore   float64         `json:"max_score"`
+	Percentage float64         `json:"percentage"`
+	IsPassing  bool            `json:"is_passing"`
+	Grade      *string         `json:"grade"`
+	Questions  []GradingResult `json:"questions"`
+	GradedAt   time.Time       `json:"graded_at"`
+	GradedBy   string          `json:"graded_by"`
+}
+
+// PendingGradingItem is an answer waiting for manual grading. Under anonymous grading
+// StudentID is withheld and StudentLabel carries a stable pseudonym instead.
+type PendingGradingItem struct {
+	AnswerID     uint                `json:"answer_id"`
+	AttemptID    uint                `json:"attempt_id"`
+	AssessmentID uint                `json:"assessment_id"`
+	QuestionID   uint                `json:"question_id"`
+	QuestionType models.QuestionType `json:"question_type"`
+	QuestionText string              `json:"question_text"`
+	MaxScore     int                 `json:"max_score"`
+	Answer       json.RawMessage     `json:"answer"`
+	StudentID    string              `json:"student_id,omitempty"`
+	StudentLabel string              `json:"student_label"`
+	Anonymous    bool                `json:"anonymous"`
+	SubmittedAt  *time.Time          `json:"submitted_at"`
+	// pending_regrade for graded answers waiting for a teacher to regrade them, provisional for
+	// auto-graded answers to questions requiring manual review
+	GradingStatus models.AnswerGradingStatus `json:"grading_status"`
+	// Score given by auto-grading, for the teacher to confirm or change
+	ProvisionalScore *float64 `json:"provisional_score,omitempty"`
+	// Language detected in an essay answer; language_mismatch when the assessment expects another
+	DetectedLanguage *string `json:"detected_language,omitempty"`
+	LanguageMismatch bool    `json:"language_mismatch"`
+}
+
+// AnswerGradingState is an answer's place in the grading workflow
+type AnswerGradingState struct {
+	AnswerID      uint                       `json:"answer_id"`
+	AttemptID     uint                       `json:"attempt_id"`
+	QuestionID    uint                       `json:"question_id"`
+	GradingStatus models.AnswerGradingStatus `json:"grading_status"`
+	Score         float64                    `json:"score"`
+	MaxScore      int                        `json:"max_score"`
+	GradedBy      *string                    `json:"graded_by"`
+	GradedAt      *time.Time                 `json:"graded_at"`
+	CreatedAt     time.Time                  `json:"created_at"`
+}
+
+// ResultsReleaseStatus describes whether students can see scores and reviews of an assessment
+type ResultsReleaseStatus struct {
+	AssessmentID     uint                      `json:"assessment_id"`
+	Mode             models.ResultsReleaseMode `json:"mode"`
+	ReleaseAt        *time.Time                `json:"release_at,omitempty"`
+	Released         bool                      `json:"released"`
+	ReleasedAt       *time.Time                `json:"released_at,omitempty"`
+	ReleasedBy       *string                   `json:"released_by,omitempty"`
+	AnonymousGrading bool                      `json:"anonymous_grading"`
+}
+
+// FinalGrade is a student's grade on an assessment under its grade policy
+type FinalGrade struct {
+	StudentID       string             `json:"student_id"`
+	Policy          models.GradePolicy `json:"policy"`
+	Score           float64            `json:"score"`
+	MaxScore        int                `json:"max_score"`
+	Percentage      float64            `json:"percentage"`
+	Passed          bool               `json:"passed"`
+	AttemptID       *uint              `json:"attempt_id,omitempty"` // Counted attempt; unset for averages
+	AttemptsCounted int                `json:"attempts_counted"`
+}
+
+type FinalGradesReport struct {
+	AssessmentID uint               `json:"assessment_id"`
+	Policy       models.GradePolicy `json:"policy"`
+	PassingScore int                `json:"passing_score"`
+	Grades       []FinalGrade       `json:"grades"`
+}
+
+// ResultsExportContent is what a results export lists
+type ResultsExportContent string
+
+const (
+	ResultsExportAttempts ResultsExportContent = "results" // One row per finished attempt
+	ResultsExportAnswers  ResultsExportContent = "answers" // One row per answer of a finished attempt
+)
+
+// ExportRecipient is who a results export is for. Exports for anyone outside the
+// institution must be redacted.
+type ExportRecipient string
+
+const (
+	ExportRecipientInternal         ExportRecipient = "internal"
+	ExportRecipientExternalExaminer ExportRecipient = "external_examiner"
+	ExportRecipientResearcher       ExportRecipient = "researcher"
+)
+
+// ResultsExportRequest selects a results export. Redacted exports replace student IDs
+// and names with aliases that are stable within the assessment.
+type ResultsExportRequest struct {
+	Content   ResultsExportContent `json:"content" validate:"omitempty,oneof=results answers"`
+	Recipient ExportRecipient      `json:"recipient" validate:"omitempty,oneof=internal external_examiner researcher"`
+	Redact    bool                 `json:"redact"`
+}
+
+// StudentTranscriptFilters narrows a student transcript to one term, one class, or both
+type StudentTranscriptFilters struct {
+	Term    *string `json:"term,omitempty"`
+	ClassID *string `json:"class_id,omitempty"`
+}
+
+// StudentTranscriptEntry is a student's final grade on one assessment
+type StudentTranscriptEntry struct {
+	AssessmentID    uint       `json:"assessment_id"`
+	AssessmentTitle string     `json:"assessment_title"`
+	Term            *string    `json:"term,omitempty"`
+	ClassID         *string    `json:"class_id,omitempty"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"` // Latest finished attempt
+	LetterGrade     string     `json:"letter_grade"`
+	FinalGrade
+}
+
+// StudentTranscript is a student's consolidated record of finalized results, for advising.
+// Assessments whose results are not released yet are left out.
+type StudentTranscript struct {
+	StudentID         string                   `json:"student_id"`
+	StudentName       string                   `json:"student_name"`
+	Filters           StudentTranscriptFilters `json:"filters"`
+	Entries           []StudentTranscriptEntry `json:"entries"` // Oldest first
+	AssessmentsCount  int                      `json:"assessments_count"`
+	PassedCount       int                      `json:"passed_count"`
+	AveragePercentage float64                  `json:"average_percentage"` // Unweighted over the entries
+	LetterGrade       string                   `json:"letter_grade"`       // Of the average
+	GeneratedAt       time.Time                `json:"generated_at"`
+}
+
+// SetResearchPolicyRequest sets whether an organization's assessment data may be sampled for
+// research. Enabling sampling needs consent, an ethics approval reference and an approval end.
+type SetResearchPolicyRequest struct {
+	Organization       string     `json:"organization" validate:"required,max=255"`
+	SamplingEnabled    bool       `json:"sampling_enabled"`
+	ConsentObtained    bool       `json:"consent_obtained"`
+	EthicsApproval     string     `json:"ethics_approval" validate:"max=255"`
+	ApprovedUntil      *time.Time `json:"approved_until"`
+	AllowAnswerText    bool       `json:"allow_answer_text"`
+	MinStudentsPerBand *int       `json:"min_students_per_band" validate:"omitempty,min=1,max=100"` // Defaults to 5
+}
+
+// ResearchSampleRequest selects a research sample of an assessment's students, drawn across
+// equal-width score bands in proportion to how many students each band holds
+type ResearchSampleRequest struct {
+	SampleSize     int    `json:"sample_size" validate:"required,min=1,max=1000"`
+	Bands          int    `json:"bands" validate:"omitempty,min=1,max=10"` // Defaults to 4
+	Seed           *int64 `json:"seed"`                                    // Repeats an earlier sample of unchanged data
+	IncludeAnswers bool   `json:"include_answers"`
+}
+
+// ResearchSampleBand is one score band of a research sample
+type ResearchSampleBand struct {
+	MinPercentage float64 `json:"min_percentage"`
+	MaxPercentage float64 `json:"max_percentage"`
+	Students      int     `json:"students"`   // Students with a finished attempt in the band
+	Sampled       int     `json:"sampled"`    // Of them, in the sample
+	Suppressed    bool    `json:"suppressed"` // Left out for holding too few students to stay anonymous
+}
+
+// ResearchSampleAnswer is one answer of a sampled attempt. Answer carries what the student
+// wrote, with their identity redacted, only where the organization allows it.
+type ResearchSampleAnswer struct {
+	QuestionID    uint                       `json:"question_id"`
+	Score         float64                    `json:"score"`
+	MaxScore      int                        `json:"max_score"`
+	IsCorrect     *bool                      `json:"is_correct,omitempty"`
+	GradingStatus models.AnswerGradingStatus `json:"grading_status"`
+	TimeSpent     int                        `json:"time_spent_seconds"`
+	Answer        string                     `json:"answer,omitempty"`
+}
+
+// ResearchParticipant is a sampled student's latest finished attempt under an alias that is
+// new in every sample
+type ResearchParticipant struct {
+	Participant string                 `json:"participant"`
+	Band        int                    `json:"band"` // Index into the sample's bands
+	Percentage  float64                `json:"percentage"`
+	Score       float64                `json:"score"`
+	MaxScore    int                    `json:"max_score"`
+	Passed      bool                   `json:"passed"`
+	TimeSpent   int                    `json:"time_spent_seconds"`
+	Answers     []ResearchSampleAnswer `json:"answers,omitempty"`
+}
+
+// ResearchSample is an anonymized, score-stratified sample of an assessment's students
+type ResearchSample struct {
+	AssessmentID   uint                  `json:"assessment_id"`
+	Organization   string                `json:"organization"`
+	EthicsApproval string                `json:"ethics_approval"`
+	Seed           int64                 `json:"seed"`
+	Bands          []ResearchSampleBand  `json:"bands"`
+	Participants   []ResearchParticipant `json:"participants"`
+	GeneratedAt    time.Time             `json:"generated_at"`
+}
+
+// ===== MODERATION RELATED DTOs =====
+
+type CreateReviewSampleRequest struct {
+	ReviewerID       string  `json:"reviewer_id" validate:"required"`
+	SamplesPerGrader int     `json:"samples_per_grader" validate:"required,min=1,max=100"`
+	Tolerance        float64 `json:"tolerance" validate:"min=0"` // Points difference still counted as agreement
+}
+
+type ReviewSampleResult struct {
+	AssessmentID    uint                   `json:"assessment_id"`
+	ReviewerID      string                 `json:"reviewer_id"`
+	SampledByGrader map[string]int         `json:"sampled_by_grader"`
+	Reviews         []*models.AnswerReview `json:"reviews"`
+}
+
+type SubmitAnswerReviewRequest struct {
+	Score   float64 `json:"score" validate:"min=0"`
+	Comment *string `json:"comment" validate:"omitempty,max=2000"`
+}
+
+type GraderReliability struct {
+	GraderID               string  `json:"grader_id"`
+	Sampled                int     `json:"sampled"`
+	Reviewed               int     `json:"reviewed"`
+	Agreed                 int     `json:"agreed"`
+	AgreementRate          float64 `json:"agreement_rate"`           // Percentage of reviewed samples within tolerance
+	MeanAbsoluteDifference float64 `json:"mean_absolute_difference"` // Average |original - review| in points
+	MeanScoreBias          float64 `json:"mean_score_bias"`          // Positive when the grader scores higher than the reviewer
+}
+
+type GraderReliabilityReport struct {
+	AssessmentID         uint                `json:"assessment_id"`
+	TotalSampled         int                 `json:"total_sampled"`
+	TotalReviewed        int                 `json:"total_reviewed"`
+	OverallAgreementRate float64             `json:"overall_agreement_rate"`
+	Graders              []GraderReliability `json:"graders"`
+	GeneratedAt          time.Time           `json:"generated_at"`
+}
+
+// GraderStats summarizes one grader's workload, speed and agreement with second markers
+type GraderStats struct {
+	GraderID                string     `json:"grader_id"`
+	AssessmentID            *uint      `json:"assessment_id,omitempty"`
+	Since                   *time.Time `json:"since,omitempty"`
+	AnswersGraded           int        `json:"answers_graded"`
+	AssessmentsGraded       int        `json:"assessments_graded"`
+	AverageScore            float64    `json:"average_score"`              // Points given per answer
+	AverageScorePercent     float64    `json:"average_score_percent"`      // Share of the answer's max score
+	AverageTurnaroundHours  float64    `json:"average_turnaround_hours"`   // Submission to grade
+	AverageSecondsPerAnswer *float64   `json:"average_seconds_per_answer"` // Estimated from grading sessions; null without consecutive grades
+	Backlog                 int        `json:"backlog"`                    // Ungraded answers in assessments the grader owns or grades
+	PendingReviews          int        `json:"pending_reviews"`            // Second marking assigned to the grader
+	Reviewed                int        `json:"reviewed"`                   // Grades checked by a second marker
+	AgreementRate           *float64   `json:"agreement_rate"`             // null until a second marker has reviewed a grade
+	MeanAbsoluteDifference  *float64   `json:"mean_absolute_difference"`
+	GeneratedAt             time.Time  `json:"generated_at"`
+}
+
+// ===== SCORE OVERRIDE DTOs =====
+
+// OverrideAttemptScoreRequest sets the final score of an attempt; a null score reverts
+// to the computed score
+type OverrideAttemptScoreRequest struct {
+	Score         *float64 `json:"score" validate:"omitempty,min=0"`
+	Justification string   `json:"justification" validate:"required,min=10,max=2000"`
+}
+
+// ScoreOverrideEntry is one override or revert, as recorded in the audit log
+type ScoreOverrideEntry struct {
+	PreviousScore float64   `json:"previous_score"`
+	NewScore      float64   `json:"new_score"`
+	ComputedScore float64   `json:"computed_score"`
+	Reverted      bool      `json:"reverted"`
+	Justification string    `json:"justification"`
+	OverriddenBy  string    `json:"overridden_by"`
+	OverriddenAt  time.Time `json:"overridden_at"`
+}
+
+type ScoreOverrideHistory struct {
+	AttemptID     uint                 `json:"attempt_id"`
+	Score         float64              `json:"score"`
+	ComputedScore float64              `json:"computed_score"`
+	Overridden    bool                 `json:"overridden"`
+	Entries       []ScoreOverrideEntry `json:"entries"` // Oldest first
+}
+
+// GradeSnapshot is an answer's grade at one point in time
+type GradeSnapshot struct {
+	Score         float64                    `json:"score"`
+	IsCorrect     *bool                      `json:"is_correct"`
+	Feedback      *string                    `json:"feedback"`
+	GradedBy      *string                    `json:"graded_by"`
+	GradedAt      *time.Time                 `json:"graded_at"`
+	GradingStatus models.AnswerGradingStatus `json:"grading_status"`
+}
+
+// GradeChange is one change of an answer's grade, as recorded in the audit log
+type GradeChange struct {
+	Kind      string        `json:"kind"`   // auto, manual, regrade or override
+	Fields    []string      `json:"fields"` // Fields that differ between before and after
+	Before    GradeSnapshot `json:"before"`
+	After     GradeSnapshot `json:"after"`
+	ChangedBy string        `json:"changed_by"` // "system" for automatic grading
+	ChangedAt time.Time     `json:"changed_at"`
+}
+
+// AnswerGradeHistory is every change of an answer's grade, for resolving grade disputes
+type AnswerGradeHistory struct {
+	AnswerID   uint          `json:"answer_id"`
+	AttemptID  uint          `json:"attempt_id"`
+	QuestionID uint          `json:"question_id"`
+	Current    GradeSnapshot `json:"current"`
+	Changes    []GradeChange `json:"changes"` // Oldest first
+}
+
+// ===== GRADING DELEGATION DTOs =====
+
+// CreateGradingDelegationRequest lets another teacher grade an assessment until ExpiresAt
+type CreateGradingDelegationRequest struct {
+	DelegateID string     `json:"delegate_id" validate:"required,max=255"`
+	StartsAt   *time.Time `json:"starts_at"` // Defaults to now
+	ExpiresAt  time.Time  `json:"expires_at" validate:"required"`
+	Note       string     `json:"note" validate:"max=500"`
+}
+
+// ===== ANSWER KEY CHANGE DTOs =====
+
+// AffectedAssessment groups the attempts of one assessment graded with an outdated answer key.
+// Attempts are only listed for assessments the viewer may regrade.
+type AffectedAssessment struct {
+	AssessmentID         uint                          `json:"assessment_id"`
+	Title                string                        `json:"title"`
+	AttemptCount         int                           `json:"attempt_count"`
+	ManuallyGradedCount  int                           `json:"manually_graded_count"` // Kept as graded by the teacher when regrading
+	CanRegrade           bool                          `json:"can_regrade"`
+	Attempts             []repositories.AffectedAnswer `json:"attempts,omitempty"`
+	LatestJob            *models.RegradeJob            `json:"latest_job,omitempty"`
+	NeedsRegradeSchedule bool                          `json:"needs_regrade_schedule"` // No job yet, or the last one failed
+}
+
+type AnswerKeyChangeReport struct {
+	Change                 *models.AnswerKeyChange `json:"change"`
+	Assessments            []AffectedAssessment    `json:"assessments"`
+	TotalAttempts          int                     `json:"total_attempts"`
+	UnscheduledAssessments int                     `json:"unscheduled_assessments"`
+	GeneratedAt            time.Time               `json:"generated_at"`
+}
+
+// ===== FLAG ESCALATION DTOs =====
+
+// ResolveFlagEscalationRequest answers an escalated question: accept more options as
+// correct, void the question, or dismiss the escalation and keep the key
+type ResolveFlagEscalationRequest struct {
+	Action          string   `json:"action" validate:"required,oneof=accept_answers void dismiss"`
+	AcceptedAnswers []string `json:"accepted_answers"` // Option IDs also counted as correct, accept_answers only
+}
+
+// ===== COMMENT BANK DTOs =====
+
+type CommentBankEntry struct {
+	CommentID  *uint  `json:"comment_id,omitempty"` // Set for teacher-curated entries
+	Text       string `json:"text"`
+	UsageCount int    `json:"usage_count"` // Times graders gave this feedback on the question
+	Pinned     bool   `json:"pinned"`
+	Source     string `json:"source"` // curated or history
+}
+
+type QuestionCommentBank struct {
+	QuestionID uint                      `json:"question_id"`
+	Entries    []CommentBankEntry        `json:"entries"`
+	Hidden     []*models.FeedbackComment `json:"hidden"` // Suppressed suggestions, kept so they can be restored
+}
+
+type CreateFeedbackCommentRequest struct {
+	Text   string `json:"text" validate:"required,max=2000"`
+	Pinned bool   `json:"pinned"`
+	Hidden bool   `json:"hidden"` // Suppress a suggestion mined from earlier feedback
+}
+
+type UpdateFeedbackCommentRequest struct {
+	Text   *string `json:"text" validate:"omitempty,min=1,max=2000"`
+	Pinned *bool   `json:"pinned"`
+	Hidden *bool   `json:"hidden"`
+}
+
+// CreateAnswerAnnotationRequest highlights characters [start_offset, end_offset) of an essay answer
+type CreateAnswerAnnotationRequest struct {
+	PartID          *string `json:"part_id" validate:"omitempty,max=50"` // Essay part of a multi-part question
+	StartOffset     int     `json:"start_offset" validate:"min=0"`
+	EndOffset       int     `json:"end_offset" validate:"gtfield=StartOffset"`
+	Comment         string  `json:"comment" validate:"required,max=2000"`
+	RubricCriterion *string `json:"rubric_criterion" validate:"omitempty,max=255"`
+}
+
+type UpdateAnswerAnnotationRequest struct {
+	StartOffset     *int    `json:"start_offset" validate:"omitempty,min=0"`
+	EndOffset       *int    `json:"end_offset" validate:"omitempty,min=1"`
+	Comment         *string `json:"comment" validate:"omitempty,min=1,max=2000"`
+	RubricCriterion *string `json:"rubric_criterion" validate:"omitempty,max=255"` // Empty string unlinks the criterion
+}
+
+// ===== ANALYTICS & REPORTING DTOs =====
+
+type AssessmentActivitySummary struct {
+	AssessmentID   uint       `json:"assessment_id"`
+	Title          string     `json:"title"`
+	DueDate        *time.Time `json:"due_date,omitempty"`
+	TotalAttempts  int        `json:"total_attempts"`
+	CompletionRate float64    `json:"completion_rate"`
+	AverageScore   float64    `json:"average_score"`
+	PassRate       float64    `json:"pass_rate"`
+	PendingGrading int        `json:"pending_grading"`
+	Alerts         []string   `json:"alerts"`
+}
+
+type TeacherAnalyticsSummary struct {
+	TeacherID           string                      `json:"teacher_id"`
+	PeriodStart         time.Time                   `json:"period_start"`
+	PeriodEnd           time.Time                   `json:"period_end"`
+	ActiveAssessments   int                         `json:"active_assessments"`
+	TotalPendingGrading int                         `json:"total_pending_grading"`
+	Assessments         []AssessmentActivitySummary `json:"assessments"`
+	Alerts              []string                    `json:"alerts"`
+	GeneratedAt         time.Time                   `json:"generated_at"`
+}
+
+// DistractorAnalysis reports how well each option of a multiple choice question works
+type DistractorAnalysis struct {
+	QuestionID          uint                `json:"question_id"`
+	QuestionText        string              `json:"question_text"`
+	TotalResponses      int                 `json:"total_responses"`
+	DifficultyIndex     float64             `json:"difficulty_index"`
+	DiscriminationIndex float64             `json:"discrimination_index"`
+	Options             []models.OptionStat `json:"options"`
+	NonFunctioning      []string            `json:"non_functioning"` // Option IDs of distractors to revise
+	LastCalculatedAt    time.Time           `json:"last_calculated_at"`
+}
+
+// AssessmentAnalyticsReport is an assessment's score statistics with classical test theory
+// item analysis, as last calculated after grading
+type AssessmentAnalyticsReport struct {
+	AssessmentID      uint    `json:"assessment_id"`
+	Title             string  `json:"title"`
+	TotalAttempts     int     `json:"total_attempts"`
+	CompletedAttempts int     `json:"completed_attempts"`
+	AverageScore      float64 `json:"average_score"` // Percentages over finished attempts
+	MedianScore       float64 `json:"median_score"`
+	HighestScore      float64 `json:"highest_score"`
+	LowestScore       float64 `json:"lowest_score"`
+	StandardDeviation float64 `json:"standard_deviation"`
+	AverageTimeSpent  int     `json:"average_time_spent"` // seconds
+	MedianTimeSpent   int     `json:"median_time_spent"`
+	PassRate          float64 `json:"pass_rate"` // 0.0 - 1.0
+	PassedCount       int     `json:"passed_count"`
+	FailedCount       int     `json:"failed_count"`
+
+	// Item analysis covers fully graded attempts only
+	ScoredAttempts   int               `json:"scored_attempts"`
+	Reliability      *float64          `json:"reliability"` // KR-20
+	Items            []models.ItemStat `json:"items"`
+	ReviewItems      []uint            `json:"review_items"` // Question IDs discriminating poorly
+	LastCalculatedAt time.Time         `json:"last_calculated_at"`
+}
+
+// ConfidenceCalibrationReport compares students' confidence ratings with how their answers
+// actually scored. A rating from 1 to 5 is read as an expected score of 0% to 100%.
+type ConfidenceCalibrationReport struct {
+	AssessmentID uint                  `json:"assessment_id"`
+	Title        string                `json:"title"`
+	Overall      CalibrationStats      `json:"overall"`
+	Levels       []CalibrationLevel    `json:"levels"` // Calibration curve, one point per rating
+	Students     []StudentCalibration  `json:"students"`
+	Questions    []QuestionCalibration `json:"questions"`
+}
+
+type CalibrationStats struct {
+	Responses         int     `json:"responses"`
+	AverageConfidence float64 `json:"average_confidence"` // 1.0 - 5.0
+	Accuracy          float64 `json:"accuracy"`           // Average share of the maximum score, 0.0 - 1.0
+	Bias              float64 `json:"bias"`               // Expected minus actual score; positive means overconfident
+	BrierScore        float64 `json:"brier_score"`        // Mean squared gap, 0 when perfectly calibrated
+}
+
+type CalibrationLevel struct {
+	Confidence int     `json:"confidence"`
+	Responses  int     `json:"responses"`
+	Accuracy   float64 `json:"accuracy"`
+}
+
+type StudentCalibration struct {
+	StudentID string `json:"student_id"`
+	CalibrationStats
+}
+
+type QuestionCalibration struct {
+	QuestionID   uint   `json:"question_id"`
+	QuestionText string `json:"question_text"`
+	CalibrationStats
+}
+
+// PeakUsageTimes shows when students take an assessment, with start times read in Timezone
+type PeakUsageTimes struct {
+	AssessmentID  uint   `json:"assessment_id"`
+	Title         string `json:"title"`
+	Timezone      string `json:"timezone"`
+	TotalAttempts int    `json:"total_attempts"`
+
+	// Heatmap counts attempts started by weekday, Sunday first, and hour of day
+	Heatmap   [7][24]int    `json:"heatmap"`
+	ByHour    []UsageBucket `json:"by_hour"`    // 24 buckets, midnight first
+	ByWeekday []UsageBucket `json:"by_weekday"` // 7 buckets, Sunday first
+	// Busiest start hour and weekday; nil without attempts
+	PeakHour    *int    `json:"peak_hour"`
+	PeakWeekday *string `json:"peak_weekday"`
+
+	// Most attempts observed running at once, and when that was first reached
+	PeakConcurrency   int        `json:"peak_concurrency"`
+	PeakConcurrencyAt *time.Time `json:"peak_concurrency_at"`
+
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// UsageBucket counts the attempts started in one hour or weekday and how they scored
+type UsageBucket struct {
+	Label             string   `json:"label"`
+	Attempts          int      `json:"attempts"`
+	FinishedAttempts  int      `json:"finished_attempts"`
+	AveragePercentage *float64 `json:"average_percentage"` // Over finished attempts; nil without any
+}
+
+// NavigationAnalytics shows how students move through an assessment, to help order its
+// questions and size its time limit
+type NavigationAnalytics struct {
+	AssessmentID     uint   `json:"assessment_id"`
+	Title            string `json:"title"`
+	AttemptsAnalyzed int    `json:"attempts_analyzed"` // Submitted attempts with a navigation log
+
+	Questions []QuestionNavigation `json:"questions"` // In assessment order
+	// Jumps back to an earlier question, most common first
+	BacktrackPaths []BacktrackPath `json:"backtrack_paths"`
+	// Questions students most often came back to at the end, most often first
+	MostLeftForLast []uint `json:"most_left_for_last"`
+
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+type QuestionNavigation struct {
+	QuestionID uint `json:"question_id"`
+	Position   int  `json:"position"` // 1-based
+	// Averaged over the attempts analyzed; a revisit is any visit after the first
+	AverageVisits   float64 `json:"average_visits"`
+	AverageRevisits float64 `json:"average_revisits"`
+	// Attempts whose last visit returned to this question, and their share of all attempts
+	LeftForLast     int     `json:"left_for_last"`
+	LeftForLastRate float64 `json:"left_for_last_rate"` // Percentage
+}
+
+type BacktrackPath struct {
+	FromQuestionID uint `json:"from_question_id"`
+	FromPosition   int  `json:"from_position"`
+	ToQuestionID   uint `json:"to_question_id"`
+	ToPosition     int  `json:"to_position"`
+	Count          int  `json:"count"`
+}
+
+// AssessmentDashboard bundles an assessment's analytics as charts ready to render, so
+// dashboards and embedded widgets need neither several calls nor client-side aggregation
+type AssessmentDashboard struct {
+	AssessmentID uint             `json:"assessment_id"`
+	Title        string           `json:"title"`
+	Summary      DashboardSummary `json:"summary"`
+	Charts       []DashboardChart `json:"charts"`
+	GeneratedAt  time.Time        `json:"generated_at"`
 }
 
-type SubmitAnswerRequest struct {
-	QuestionID uint        `json:"question_id" validate:"required"`
-	AnswerData interface{} `json:"answer_data" validate:"required"`
-	TimeSpent  *int        `json:"time_spent"`
+type DashboardSummary struct {
+	TotalAttempts     int     `json:"total_attempts"`
+	SubmittedAttempts int     `json:"submitted_attempts"`
+	AveragePercentage float64 `json:"average_percentage"`
+	PassRate          float64 `json:"pass_rate"` // Over submitted attempts
+	PassingScore      int     `json:"passing_score"`
+
+	// Per student under the assessment's grade policy
+	GradePolicy            models.GradePolicy `json:"grade_policy"`
+	GradedStudents         int                `json:"graded_students"`
+	FinalAveragePercentage float64            `json:"final_average_percentage"`
+	FinalPassRate          float64            `json:"final_pass_rate"`
 }
 
-type SubmitAttemptRequest struct {
-	AttemptID uint                  `json:"attempt_id" validate:"required"`
-	Answers   []SubmitAnswerRequest `json:"answers" validate:"required,dive"`
-	TimeSpent *int                  `json:"time_spent"`
-	EndReason string                `json:"end_reason"`
+// DashboardChart describes one chart; Type tells the renderer how to draw the series
+type DashboardChart struct {
+	ID     string        `json:"id"`
+	Type   string        `json:"type"` // "bar", "funnel"
+	Title  string        `json:"title"`
+	XLabel string        `json:"x_label,omitempty"`
+	YLabel string        `json:"y_label,omitempty"`
+	Series []ChartSeries `json:"series"`
 }
 
-type AttemptResponse struct {
-	*models.AssessmentAttempt
-	CanSubmit bool                 `json:"can_submit"`
-	CanResume bool                 `json:"can_resume"`
-	Questions []QuestionForAttempt `json:"questions,omitempty"`
+type ChartSeries struct {
+	Name   string       `json:"name"`
+	Points []ChartPoint `json:"points"`
 }
 
-type QuestionForAttempt struct {
-	*models.Question
-	IsLast  bool `json:"is_last"`
-	IsFirst bool `json:"is_first"`
+type ChartPoint struct {
+	Label  string  `json:"label"`
+	Value  float64 `json:"value"`
+	Detail string  `json:"detail,omitempty"` // Tooltip text, e.g. the question text
 }
 
-// ===== QUESTION RELATED DTOs =====
+type CreateMasteryTargetRequest struct {
+	Skill            string  `json:"skill" validate:"required,min=1,max=100"` // Question tag
+	TargetPercentage float64 `json:"target_percentage" validate:"required,gt=0,max=100"`
+	MinAnswers       *int    `json:"min_answers" validate:"omitempty,min=1,max=100"`
+}
 
-// Use business validator types
-type CreateQuestionRequest = validator.QuestionCreateRequest
+type UpdateMasteryTargetRequest struct {
+	TargetPercentage *float64 `json:"target_percentage" validate:"omitempty,gt=0,max=100"`
+	MinAnswers       *int     `json:"min_answers" validate:"omitempty,min=1,max=100"`
+}
 
-type UpdateQuestionRequest struct {
-	Text        *string                 `json:"text" validate:"omitempty,max=2000"`
-	Content     interface{}             `json:"content"`
-	Points      *int                    `json:"points" validate:"omitempty,min=1,max=100"`
-	TimeLimit   *int                    `json:"time_limit" validate:"omitempty,min=30,max=3600"`
-	Difficulty  *models.DifficultyLevel `json:"difficulty"`
-	CategoryID  *uint                   `json:"category_id"`
-	Tags        []string                `json:"tags"`
-	Explanation *string                 `json:"explanation" validate:"omitempty,max=1000"`
+// MasteryMilestone is a step towards a mastery target; ReachedAt is when the student's
+// running score first crossed it
+type MasteryMilestone struct {
+	Name       string     `json:"name"`
+	Percentage float64    `json:"percentage"` // Score needed, a fraction of the target percentage
+	Reached    bool       `json:"reached"`
+	ReachedAt  *time.Time `json:"reached_at"`
 }
 
-type QuestionResponse struct {
-	*models.Question
-	CanEdit    bool `json:"can_edit"`
-	CanDelete  bool `json:"can_delete"`
-	UsageCount int  `json:"usage_count"`
+type SkillProgress struct {
+	TargetID         uint               `json:"target_id"`
+	Skill            string             `json:"skill"`
+	Label            string             `json:"label"`
+	SetBy            string             `json:"set_by"`
+	TargetPercentage float64            `json:"target_percentage"`
+	MinAnswers       int                `json:"min_answers"`
+	AnsweredCount    int                `json:"answered_count"`
+	EarnedPoints     float64            `json:"earned_points"`
+	PossiblePoints   int                `json:"possible_points"`
+	Percentage       float64            `json:"percentage"`
+	Attainment       float64            `json:"attainment"` // 0-1 share of the target reached
+	Mastered         bool               `json:"mastered"`
+	Milestones       []MasteryMilestone `json:"milestones"`
 }
 
-type QuestionListResponse struct {
-	Questions []*QuestionResponse `json:"questions"`
-	Total     int64               `json:"total"`
-	Page      int                 `json:"page"`
-	Size      int                 `json:"size"`
+type ProgressReport struct {
+	StudentID     string          `json:"student_id"`
+	TotalSkills   int             `json:"total_skills"`
+	MasteredCount int             `json:"mastered_count"`
+	Skills        []SkillProgress `json:"skills"` // Furthest from mastery first
+	GeneratedAt   time.Time       `json:"generated_at"`
 }
 
-// ===== GRADING RELATED DTOs =====
+type RenderedReport struct {
+	Subject     string `json:"subject"`
+	Body        string `json:"body"`
+	ContentType string `json:"content_type"`
+}
 
-type GradingResult struct {
-	AnswerID      uint      `json:"answer_id"`
-	QuestionID    uint      `json:"question_id"`
-	Score         float64   `json:"score"`
-	MaxScore      float64   `json:"max_score"`
-	IsCorrect     bool      `json:"is_correct"`
-	PartialCredit bool      `json:"partial_credit"`
-	Feedback      *string   `json:"feedback"`
-	GradedAt      time.Time `json:"graded_at"`
-	GradedBy      *string   `json:"graded_by"`
+type CreateReportSubscriptionRequest struct {
+	ReportType models.ReportType `json:"report_type" validate:"required,oneof=teacher_weekly_summary"`
+	DayOfWeek  *int              `json:"day_of_week" validate:"omitempty,min=0,max=6"`
+	HourOfDay  *int              `json:"hour_of_day" validate:"omitempty,min=0,max=23"`
+	Email      string            `json:"email" validate:"omitempty,email"`
 }
 
-type AttemptGradingResult struct {
-	AttemptID  uint            `json:"attempt_id"`
-	TotalScore float64         `json:"total_score"`
-	MaxScore   float64         `json:"max_score"`
-	Percentage float64         `json:"percentage"`
-	IsPassing  bool            `json:"is_passing"`
-	Grade      *string         `json:"grade"`
-	Questions  []GradingResult `json:"questions"`
-	GradedAt   time.Time       `json:"graded_at"`
-	GradedBy   string          `json:"graded_by"`
+type UpdateReportSubscriptionRequest struct {
+	DayOfWeek *int    `json:"day_of_week" validate:"omitempty,min=0,max=6"`
+	HourOfDay *int    `json:"hour_of_day" validate:"omitempty,min=0,max=23"`
+	Email     *string `json:"email" validate:"omitempty,email"`
+	IsActive  *bool   `json:"is_active"`
 }
 
 // ===== QUESTION BANK RELATED DTOs =====
@@ -185,6 +1684,214 @@ type AddQuestionsTobankRequest struct {
 	QuestionIDs []uint `json:"question_ids" validate:"required,min=1"`
 }
 
+type MergeQuestionBanksRequest struct {
+	SourceBankID uint `json:"source_bank_id" validate:"required"`
+	DeleteSource bool `json:"delete_source"` // Delete the source bank once merged
+	DryRun       bool `json:"dry_run"`       // Build the report without changing anything
+}
+
+type MergedQuestionDuplicate struct {
+	DuplicateQuestionID uint `json:"duplicate_question_id"`
+	SurvivingQuestionID uint `json:"surviving_question_id"`
+	AssessmentsRemapped int  `json:"assessments_remapped"`
+	// Assessments left on the duplicate: not editable by the caller, or already attempted
+	SkippedAssessmentIDs []uint `json:"skipped_assessment_ids"`
+	TagsAdded            int    `json:"tags_added"`
+	CategoryAdopted      bool   `json:"category_adopted"`
+}
+
+type QuestionBankMergeReport struct {
+	TargetBankID        uint                      `json:"target_bank_id"`
+	SourceBankID        uint                      `json:"source_bank_id"`
+	SourceQuestionCount int                       `json:"source_question_count"`
+	MovedQuestionIDs    []uint                    `json:"moved_question_ids"`
+	Duplicates          []MergedQuestionDuplicate `json:"duplicates"`
+	AssessmentsRemapped int                       `json:"assessments_remapped"`
+	AssessmentsSkipped  int                       `json:"assessments_skipped"`
+	SourceDeleted       bool                      `json:"source_deleted"`
+	DryRun              bool                      `json:"dry_run"`
+	MergedAt            time.Time                 `json:"merged_at"`
+}
+
+type UpdateBankLicenseRequest struct {
+	IsLicensed bool       `json:"is_licensed"`
+	Provider   *string    `json:"provider" validate:"omitempty,max=200"`
+	Seats      *int       `json:"seats" validate:"omitempty,min=1"` // Omit for unlimited seats
+	ExpiresAt  *time.Time `json:"expires_at"`
+}
+
+type BankLicenseUsage struct {
+	BankID           uint       `json:"bank_id"`
+	BankName         string     `json:"bank_name"`
+	Provider         *string    `json:"provider"`
+	Seats            *int       `json:"seats"`
+	SeatsUsed        int        `json:"seats_used"` // Distinct students exposed to the bank's questions
+	SeatsRemaining   *int       `json:"seats_remaining"`
+	SeatUtilization  *float64   `json:"seat_utilization"` // Percentage of seats used
+	ExpiresAt        *time.Time `json:"expires_at"`
+	DaysUntilExpiry  *int       `json:"days_until_expiry"`
+	Expired          bool       `json:"expired"`
+	QuestionsExposed int        `json:"questions_exposed"`
+	AssessmentsUsing int        `json:"assessments_using"`
+	LastExposedAt    *time.Time `json:"last_exposed_at"`
+	Alerts           []string   `json:"alerts"`
+	GeneratedAt      time.Time  `json:"generated_at"`
+}
+
+// Reasons a question is due for review
+const (
+	ReviewReasonOverdue           = "review_overdue"
+	ReviewReasonDecliningAccuracy = "declining_correct_rate"
+)
+
+type StaleQuestion struct {
+	QuestionID        uint       `json:"question_id"`
+	Text              string     `json:"text"`
+	Reasons           []string   `json:"reasons"`
+	LastReviewedAt    *time.Time `json:"last_reviewed_at"`
+	ReviewDueAt       time.Time  `json:"review_due_at"`
+	RecentCorrectRate *float64   `json:"recent_correct_rate,omitempty"` // Percentage over the last 90 days
+	PriorCorrectRate  *float64   `json:"prior_correct_rate,omitempty"`  // Percentage over the 90 days before
+}
+
+type StaleQuestionReport struct {
+	BankID      uint            `json:"bank_id"`
+	BankName    string          `json:"bank_name"`
+	OwnerID     string          `json:"owner_id"`
+	Questions   []StaleQuestion `json:"questions"`
+	GeneratedAt time.Time       `json:"generated_at"`
+}
+
+// MarkQuestionsReviewedRequest stamps questions as reviewed; omit question_ids for the whole bank
+type MarkQuestionsReviewedRequest struct {
+	QuestionIDs []uint `json:"question_ids" validate:"omitempty,max=1000"`
+}
+
+type MarkQuestionsReviewedResponse struct {
+	Reviewed   int64     `json:"reviewed"`
+	ReviewedAt time.Time `json:"reviewed_at"`
+}
+
+type QuestionBankChangeEntry struct {
+	ID              uint                          `json:"id"`
+	QuestionID      uint                          `json:"question_id"`
+	Change          models.QuestionBankChangeType `json:"change"`
+	QuestionVersion int                           `json:"question_version"`
+	Link            string                        `json:"link"` // The question as it is now
+	ChangedBy       string                        `json:"changed_by"`
+	ChangedAt       time.Time                     `json:"changed_at"`
+}
+
+// QuestionBankChangeFeed is a page of a bank's change feed, oldest change first. NextCursor
+// continues after the last entry; on an empty page it is the cursor that was passed in, so
+// clients can keep polling with it.
+type QuestionBankChangeFeed struct {
+	BankID     uint                      `json:"bank_id"`
+	Entries    []QuestionBankChangeEntry `json:"entries"`
+	NextCursor *string                   `json:"next_cursor"`
+	HasMore    bool                      `json:"has_more"` // A full page; more changes may follow
+}
+
+// ===== FAVORITE DTOs =====
+
+type AddFavoriteRequest struct {
+	Note *string `json:"note" validate:"omitempty,max=500"`
+}
+
+type ShareFavoritesRequest struct {
+	UserID string `json:"user_id" validate:"required"`
+}
+
+type FavoriteList struct {
+	OwnerID string                       `json:"owner_id"`
+	Items   []repositories.FavoriteEntry `json:"items"`
+	Total   int                          `json:"total"`
+}
+
+// AttachmentForGrading shows an uploaded image next to the text recognised in it
+type AttachmentForGrading struct {
+	Attachment *models.AnswerAttachment `json:"attachment"`
+	FileURL    string                   `json:"file_url"`
+	Similar    []SimilarAttachment      `json:"similar"` // Other attempts' uploads with overlapping text
+}
+
+// SimilarAttachment is another attempt's upload for the same question whose recognised text
+// overlaps with the one being graded
+type SimilarAttachment struct {
+	AttachmentID uint    `json:"attachment_id"`
+	AttemptID    uint    `json:"attempt_id"`
+	Similarity   float64 `json:"similarity"` // 0-1
+}
+
+type AnswerAttachmentsForGrading struct {
+	AttemptID   uint                   `json:"attempt_id"`
+	QuestionID  uint                   `json:"question_id"`
+	Answer      json.RawMessage        `json:"answer"` // Typed answer, if any
+	Attachments []AttachmentForGrading `json:"attachments"`
+}
+
+type AttachmentSearchResult struct {
+	AttachmentID uint   `json:"attachment_id"`
+	AttemptID    uint   `json:"attempt_id"`
+	QuestionID   uint   `json:"question_id"`
+	Snippet      string `json:"snippet"`
+	FileURL      string `json:"file_url"`
+}
+
+// UploadQuestionImageRequest describes an image attached to a question
+type UploadQuestionImageRequest struct {
+	Alt     *string `json:"alt" validate:"omitempty,max=255"`
+	Caption *string `json:"caption" validate:"omitempty,max=2000"`
+}
+
+// ===== GRADEBOOK DTOs =====
+
+type CreateGradebookIntegrationRequest struct {
+	Provider   models.GradebookProvider   `json:"provider" validate:"required,oneof=rest google_classroom"`
+	Name       string                     `json:"name" validate:"required,min=1,max=100"`
+	Endpoint   string                     `json:"endpoint" validate:"omitempty,url,max=500"` // Required for REST
+	AuthToken  string                     `json:"auth_token" validate:"omitempty,max=4000"`
+	CourseID   string                     `json:"course_id" validate:"omitempty,max=255"` // Required for Google Classroom
+	ColumnID   string                     `json:"column_id" validate:"required,max=255"`
+	StudentKey models.GradebookStudentKey `json:"student_key" validate:"omitempty,oneof=id email"`
+	ScoreField models.GradebookScoreField `json:"score_field" validate:"omitempty,oneof=score percentage"`
+	MaxPoints  *float64                   `json:"max_points" validate:"omitempty,gt=0"`
+}
+
+type UpdateGradebookIntegrationRequest struct {
+	Name       *string                     `json:"name" validate:"omitempty,min=1,max=100"`
+	Enabled    *bool                       `json:"enabled"`
+	Endpoint   *string                     `json:"endpoint" validate:"omitempty,max=500"`
+	AuthToken  *string                     `json:"auth_token" validate:"omitempty,max=4000"`
+	CourseID   *string                     `json:"course_id" validate:"omitempty,max=255"`
+	ColumnID   *string                     `json:"column_id" validate:"omitempty,min=1,max=255"`
+	StudentKey *models.GradebookStudentKey `json:"student_key" validate:"omitempty,oneof=id email"`
+	ScoreField *models.GradebookScoreField `json:"score_field" validate:"omitempty,oneof=score percentage"`
+	MaxPoints  *float64                    `json:"max_points" validate:"omitempty,gte=0"` // Zero removes rescaling
+}
+
+type ResyncGradesRequest struct {
+	IncludeDelivered bool `json:"include_delivered"` // Also push grades that were already delivered
+}
+
+type ResyncGradesResult struct {
+	AssessmentID uint `json:"assessment_id"`
+	Requeued     int  `json:"requeued"`
+	Queued       int  `json:"queued"` // Graded attempts not yet queued
+}
+
+// GradebookGrade is the value written to an external gradebook column for one student
+type GradebookGrade struct {
+	StudentKey   string             `json:"student"`
+	Column       string             `json:"column"`
+	Score        float64            `json:"score"`
+	MaxScore     float64            `json:"max_score"`
+	Passed       bool               `json:"passed"`
+	AssessmentID uint               `json:"assessment_id"`
+	Policy       models.GradePolicy `json:"grade_policy"`
+	AttemptID    *uint              `json:"attempt_id,omitempty"` // Counted attempt; unset for averages
+}
+
 // ===== SERVICE INTERFACES =====
 
 type AssessmentService interface {
@@ -206,13 +1913,14 @@ type AssessmentService interface {
 	Archive(ctx context.Context, id uint, userID string) error
 
 	// Question management
-	AddQuestion(ctx context.Context, assessmentID, questionID uint, order int, points *int, userID string) error
-	AddQuestions(ctx context.Context, assessmentID uint, questionsId []uint, userID string) error
+	AddQuestion(ctx context.Context, assessmentID, questionID uint, order int, points *int, userID string) (*AssemblyCheck, error)
+	AddQuestions(ctx context.Context, assessmentID uint, questionsId []uint, userID string) (*AssemblyCheck, error)
 	RemoveQuestion(ctx context.Context, assessmentID, questionID uint, userID string) error
 	RemoveQuestions(ctx context.Context, assessmentID uint, questionsId []uint, userID string) error
 	ReorderQuestions(ctx context.Context, assessmentID uint, orders []repositories.QuestionOrder, userID string) error
 	UpdateAssessmentQuestionBatch(ctx context.Context, assessmentID uint, reqs []UpdateAssessmentQuestionRequest, userID string) error
 	UpdateAssessmentQuestion(ctx context.Context, assessmentID, questionID uint, req *UpdateAssessmentQuestionRequest, userID string) error
+	SetQuestionBranching(ctx context.Context, assessmentID, questionID uint, req *SetQuestionBranchingRequest, userID string) (*models.AssessmentQuestion, error)
 
 	// Statistics and analytics
 	GetStats(ctx context.Context, id uint, userID string) (*repositories.AssessmentStats, error)
@@ -223,6 +1931,40 @@ type AssessmentService interface {
 	CanEdit(ctx context.Context, assessmentID uint, userID string) (bool, error)
 	CanDelete(ctx context.Context, assessmentID uint, userID string) (bool, error)
 	CanTake(ctx context.Context, assessmentID uint, userID string) (bool, error)
+
+	// Smart builder
+	BuildProposal(ctx context.Context, req *BuildAssessmentRequest, userID string) (*AssessmentBuildProposal, error)
+
+	// Series of quizzes published on a schedule
+	GenerateSeries(ctx context.Context, req *GenerateSeriesRequest, userID string) (*AssessmentSeriesResponse, error)
+	PublishScheduled(ctx context.Context, now time.Time) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+
+	// Enrollment and access audit
+	EnrollStudents(ctx context.Context, assessmentID uint, req *EnrollStudentsRequest, userID string) (*EnrollStudentsResult, error)
+	UnenrollStudent(ctx context.Context, assessmentID uint, studentID string, userID string) error
+	GetAccessAudit(ctx context.Context, assessmentID uint, userID string) (*AssessmentAccessAudit, error)
+
+	// Lifecycle history
+	GetTimeline(ctx context.Context, assessmentID uint, userID string) (*AssessmentTimeline, error)
+
+	// Per-class overrides
+	ListClassOverrides(ctx context.Context, assessmentID uint, userID string) ([]*models.AssessmentClassOverride, error)
+	SetClassOverride(ctx context.Context, assessmentID uint, classID string, req *SetClassOverrideRequest, userID string) (*models.AssessmentClassOverride, error)
+	DeleteClassOverride(ctx context.Context, assessmentID uint, classID string, userID string) error
+
+	// Pre-publish checks
+	GetReadinessReport(ctx context.Context, assessmentID uint, userID string) (*AssessmentReadinessReport, error)
+
+	// Deadlines
+	GetDeadline(ctx context.Context, assessmentID uint, userID string, viewerTimezone string) (*AssessmentDeadline, error)
+
+	// Question trials
+	StartQuestionTrial(ctx context.Context, assessmentID uint, req *StartQuestionTrialRequest, userID string) (*models.QuestionTrial, error)
+	ListQuestionTrials(ctx context.Context, assessmentID uint, userID string) ([]*models.QuestionTrial, error)
+	CompareQuestionTrial(ctx context.Context, assessmentID, trialID uint, userID string) (*QuestionTrialComparison, error)
+	PromoteQuestionTrial(ctx context.Context, assessmentID, trialID uint, userID string) (*models.QuestionTrial, error)
+	DiscardQuestionTrial(ctx context.Context, assessmentID, trialID uint, userID string) (*models.QuestionTrial, error)
 }
 
 type QuestionService interface {
@@ -233,6 +1975,10 @@ type QuestionService interface {
 	Update(ctx context.Context, id uint, req *UpdateQuestionRequest, userID string) (*QuestionResponse, error)
 	Delete(ctx context.Context, id uint, userID string) error
 
+	// Drafts are saved without full validation and finalized later
+	CreateDraft(ctx context.Context, req *CreateQuestionRequest, creatorID string) (*QuestionResponse, error)
+	FinalizeDraft(ctx context.Context, id uint, userID string) (*QuestionResponse, error)
+
 	// List and search operations
 	List(ctx context.Context, filters repositories.QuestionFilters, userID string) (*QuestionListResponse, error)
 	GetByCreator(ctx context.Context, creatorID string, filters repositories.QuestionFilters) (*QuestionListResponse, error)
@@ -242,6 +1988,11 @@ type QuestionService interface {
 	// Bulk operations
 	CreateBatch(ctx context.Context, questions []*CreateQuestionRequest, creatorID string) ([]*QuestionResponse, []error)
 	UpdateBatch(ctx context.Context, updates map[uint]*UpdateQuestionRequest, userID string) (map[uint]*QuestionResponse, map[uint]error)
+	PreviewBulkReassign(ctx context.Context, req *BulkReassignQuestionsRequest, userID string) (*BulkReassignResult, error)
+	BulkReassign(ctx context.Context, req *BulkReassignQuestionsRequest, userID string) (*BulkReassignResult, error)
+
+	// Answer key checks
+	TestGrade(ctx context.Context, questionID uint, req *TestGradeQuestionRequest, userID string) (*SampleGradingResult, error)
 
 	// Question banking
 	GetByBank(ctx context.Context, bankID uint, filters repositories.QuestionFilters, userID string) (*QuestionListResponse, error)
@@ -256,6 +2007,20 @@ type QuestionService interface {
 	CanAccess(ctx context.Context, questionID uint, userID string) (bool, error)
 	CanEdit(ctx context.Context, questionID uint, userID string) (bool, error)
 	CanDelete(ctx context.Context, questionID uint, userID string) (bool, error)
+
+	// Translations
+	GetTranslations(ctx context.Context, questionID uint, userID string) ([]*models.QuestionTranslation, error)
+	UpsertTranslation(ctx context.Context, questionID uint, locale string, req *UpsertQuestionTranslationRequest, userID string) (*models.QuestionTranslation, error)
+	DeleteTranslation(ctx context.Context, questionID uint, locale string, userID string) error
+	ReviewTranslation(ctx context.Context, questionID uint, locale string, req *ReviewQuestionTranslationRequest, reviewerID string) (*models.QuestionTranslation, error)
+	GetTranslationReviewQueue(ctx context.Context, filters repositories.TranslationReviewFilters, userID string) (*TranslationReviewQueue, error)
+
+	// IDs content providers give questions
+	GetExternalRefs(ctx context.Context, questionID uint, userID string) ([]*models.QuestionExternalRef, error)
+	SetExternalRef(ctx context.Context, questionID uint, provider string, req *SetQuestionExternalRefRequest, userID string) (*models.QuestionExternalRef, error)
+	DeleteExternalRef(ctx context.Context, questionID uint, provider string, userID string) error
+	LookupExternalRef(ctx context.Context, provider, externalID string, ownerID *string, userID string) (*QuestionResponse, error)
+	ResolveExternalRefs(ctx context.Context, req *ResolveExternalRefsRequest, userID string) (*ExternalRefResolution, error)
 }
 
 type QuestionBankService interface {
@@ -285,6 +2050,22 @@ type QuestionBankService interface {
 	RemoveQuestions(ctx context.Context, bankID uint, questionIDs []uint, userID string) error
 	GetBankQuestions(ctx context.Context, bankID uint, filters repositories.QuestionFilters, userID string) (*QuestionListResponse, error)
 
+	// Merge and deduplication
+	Merge(ctx context.Context, targetBankID uint, req *MergeQuestionBanksRequest, userID string) (*QuestionBankMergeReport, error)
+
+	// Licensing
+	UpdateLicense(ctx context.Context, bankID uint, req *UpdateBankLicenseRequest, userID string) (*QuestionBankResponse, error)
+	GetLicenseUsage(ctx context.Context, bankID uint, userID string) (*BankLicenseUsage, error)
+
+	// Content review
+	GetStaleQuestions(ctx context.Context, bankID uint, userID string) (*StaleQuestionReport, error)
+	MarkQuestionsReviewed(ctx context.Context, bankID uint, req *MarkQuestionsReviewedRequest, userID string) (*MarkQuestionsReviewedResponse, error)
+	SendReviewReminders(ctx context.Context) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+
+	// Change feed
+	GetChangeFeed(ctx context.Context, bankID uint, after *repositories.PageCursor, limit int, userID string) (*QuestionBankChangeFeed, error)
+
 	// Statistics
 	GetStats(ctx context.Context, bankID uint, userID string) (*repositories.QuestionBankStats, error)
 
@@ -299,6 +2080,7 @@ type AttemptService interface {
 	// Core attempt operations
 	Start(ctx context.Context, req *StartAttemptRequest, studentID string) (*AttemptResponse, error)
 	Resume(ctx context.Context, attemptID uint, studentID string) (*AttemptResponse, error)
+	SaveAndExit(ctx context.Context, attemptID uint, studentID string) (*AttemptResponse, error)
 	Submit(ctx context.Context, req *SubmitAttemptRequest, studentID string) (*AttemptResponse, error)
 	SubmitAnswer(ctx context.Context, attemptID uint, req *SubmitAnswerRequest, studentID string) error
 
@@ -307,6 +2089,16 @@ type AttemptService interface {
 	GetByIDWithDetails(ctx context.Context, id uint, userID string) (*AttemptResponse, error)
 	GetCurrentAttempt(ctx context.Context, assessmentID uint, studentID string) (*AttemptResponse, error)
 
+	// Results
+	GetScoreBreakdown(ctx context.Context, attemptID uint, userID string) (*AttemptScoreBreakdown, error)
+	GetTranscript(ctx context.Context, attemptID uint, userID string) (*AttemptTranscript, error)
+	ExportTranscript(ctx context.Context, attemptID uint, format string, userID string) (*ExportedDocument, error)
+	CompareAttempts(ctx context.Context, studentID string, assessmentID uint, userID string) (*AttemptComparison, error)
+
+	// Offline exam halls
+	ExportOfflineBundle(ctx context.Context, attemptID uint, userID string) (*OfflineBundleExport, error)
+	ImportOfflineBundle(ctx context.Context, attemptID, bundleID uint, req *ImportOfflineBundleRequest, userID string) (*OfflineBundleImportResult, error)
+
 	// List operations
 	List(ctx context.Context, filters repositories.AttemptFilters, userID string) ([]*AttemptResponse, int64, error)
 	GetByStudent(ctx context.Context, studentID string, filters repositories.AttemptFilters) ([]*AttemptResponse, int64, error)
@@ -317,6 +2109,50 @@ type AttemptService interface {
 	ExtendTime(ctx context.Context, attemptID uint, minutes int, userID string) error
 	HandleTimeout(ctx context.Context, attemptID uint) error
 
+	// Per-question timing
+	GetCurrentQuestion(ctx context.Context, attemptID uint, studentID string) (*CurrentQuestion, error)
+	AdvanceQuestion(ctx context.Context, attemptID uint, studentID string) (*CurrentQuestion, error)
+
+	// Branching
+	GetNextQuestion(ctx context.Context, attemptID, afterQuestionID uint, studentID string) (*NextQuestion, error)
+
+	// Sections and anti-backtracking
+	GetSections(ctx context.Context, attemptID uint, studentID string) (*AttemptSections, error)
+	CompleteSection(ctx context.Context, attemptID uint, req *CompleteSectionRequest, studentID string) (*AttemptSections, error)
+
+	// Submission gates
+	GetSubmissionSummary(ctx context.Context, attemptID uint, studentID string) (*SubmissionSummary, error)
+	FlagQuestion(ctx context.Context, attemptID, questionID uint, req *FlagQuestionRequest, studentID string) error
+	BookmarkQuestion(ctx context.Context, attemptID, questionID uint, req *BookmarkQuestionRequest, studentID string) (*AttemptBookmarks, error)
+	GetBookmarks(ctx context.Context, attemptID uint, studentID string) (*AttemptBookmarks, error)
+	RecordQuestionVisit(ctx context.Context, attemptID, questionID uint, studentID string) error
+	SpellCheckAnswer(ctx context.Context, attemptID, questionID uint, req *SpellCheckRequest, studentID string) (*SpellCheckResponse, error)
+
+	// Organization custom fields, set by teachers and admins
+	UpdateCustomFields(ctx context.Context, attemptID uint, req *UpdateAttemptCustomFieldsRequest, userID string) (*AttemptResponse, error)
+
+	// Autosave coalescing and overdue submission
+	FlushBufferedAnswers(ctx context.Context, attemptID uint) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+
+	// Waiting queue for assessments that cap concurrent attempts
+	JoinAttemptQueue(ctx context.Context, assessmentID uint, studentID string) (*AttemptQueueStatus, error)
+	GetAttemptQueueStatus(ctx context.Context, assessmentID uint, studentID string) (*AttemptQueueStatus, error)
+	LeaveAttemptQueue(ctx context.Context, assessmentID uint, studentID string) error
+
+	// Attempt quotas across a bank's assessments; managed by admins
+	CreateAttemptQuota(ctx context.Context, req *CreateAttemptQuotaRequest, adminID string) (*models.AttemptQuota, error)
+	ListAttemptQuotas(ctx context.Context, adminID string) ([]*models.AttemptQuota, error)
+	UpdateAttemptQuota(ctx context.Context, id uint, req *UpdateAttemptQuotaRequest, adminID string) (*models.AttemptQuota, error)
+	DeleteAttemptQuota(ctx context.Context, id uint, adminID string) error
+	GetAttemptQuotaStatus(ctx context.Context, assessmentID *uint, studentID string) ([]AttemptQuotaStatus, error)
+
+	// Duplicate attempt repair, for admins
+	FindDuplicateAttempts(ctx context.Context, assessmentID *uint, adminID string) ([]DuplicateAttemptGroup, error)
+	MergeDuplicateAttempts(ctx context.Context, req *MergeDuplicateAttemptsRequest, adminID string) (*AttemptMergeResult, error)
+	// GetAttemptRepairLog lists the merges into an attempt, newest first
+	GetAttemptRepairLog(ctx context.Context, attemptID uint, adminID string) ([]*models.AuditLog, error)
+
 	// Validation
 	CanStart(ctx context.Context, assessmentID uint, studentID string) (bool, error)
 	GetAttemptCount(ctx context.Context, assessmentID uint, studentID string) (int, error)
@@ -331,6 +2167,7 @@ type GradingService interface {
 	GradeAnswer(ctx context.Context, answerID uint, score float64, feedback *string, graderID string) (*GradingResult, error)
 	GradeAttempt(ctx context.Context, attemptID uint, graderID string) (*AttemptGradingResult, error)
 	GradeMultipleAnswers(ctx context.Context, grades []repositories.AnswerGrade, graderID string) ([]GradingResult, error)
+	GradeAnswerPart(ctx context.Context, answerID uint, partID string, score float64, feedback *string, graderID string) (*GradingResult, error)
 
 	// Auto grading
 	AutoGradeAnswer(ctx context.Context, answerID uint) (*GradingResult, error)
@@ -340,6 +2177,8 @@ type GradingService interface {
 	// Grading utilities
 	CalculateScore(ctx context.Context, questionType models.QuestionType, questionContent json.RawMessage, studentAnswer json.RawMessage) (float64, bool, error)
 	GenerateFeedback(ctx context.Context, questionType models.QuestionType, questionContent json.RawMessage, studentAnswer json.RawMessage, isCorrect bool) (*string, error)
+	// GradeSampleAnswer grades a hypothetical answer to a question the way a student's would be, without storing anything
+	GradeSampleAnswer(ctx context.Context, question *models.Question, studentAnswer json.RawMessage) (*SampleGradingResult, error)
 
 	// Bulk operations
 	ReGradeQuestion(ctx context.Context, questionID uint, userID string) ([]GradingResult, error)
@@ -347,6 +2186,633 @@ type GradingService interface {
 
 	// Statistics
 	GetGradingOverview(ctx context.Context, assessmentID uint, userID string) (*repositories.GradingStats, error)
+	// GetAnswersByGradingStatus lists a page of an assessment's answers in the filters' grading states; no states lists all
+	GetAnswersByGradingStatus(ctx context.Context, assessmentID uint, filters repositories.AnswerFilters, userID string) ([]AnswerGradingState, int64, error)
+
+	// Grading queue and anonymous grading
+	GetPendingGrading(ctx context.Context, graderID string) ([]PendingGradingItem, error)
+
+	// Moderation review sampling
+	SampleAnswersForReview(ctx context.Context, assessmentID uint, req *CreateReviewSampleRequest, userID string) (*ReviewSampleResult, error)
+	GetPendingReviews(ctx context.Context, reviewerID string) ([]*models.AnswerReview, error)
+	SubmitAnswerReview(ctx context.Context, reviewID uint, req *SubmitAnswerReviewRequest, reviewerID string) (*models.AnswerReview, error)
+	GetGraderReliabilityReport(ctx context.Context, assessmentID uint, userID string) (*GraderReliabilityReport, error)
+	GetGraderStats(ctx context.Context, graderID string, assessmentID *uint, since *time.Time, userID string) (*GraderStats, error)
+
+	// Feedback search
+	SearchFeedback(ctx context.Context, filters repositories.FeedbackSearchFilters, userID string) ([]repositories.FeedbackSearchHit, int64, error)
+
+	// Comment bank
+	GetCommentBank(ctx context.Context, questionID uint, userID string) (*QuestionCommentBank, error)
+	AddFeedbackComment(ctx context.Context, questionID uint, req *CreateFeedbackCommentRequest, userID string) (*models.FeedbackComment, error)
+	UpdateFeedbackComment(ctx context.Context, commentID uint, req *UpdateFeedbackCommentRequest, userID string) (*models.FeedbackComment, error)
+	DeleteFeedbackComment(ctx context.Context, commentID uint, userID string) error
+
+	// Answer annotations
+	GetAnswerAnnotations(ctx context.Context, answerID uint, userID string) ([]*models.AnswerAnnotation, error)
+	AddAnswerAnnotation(ctx context.Context, answerID uint, req *CreateAnswerAnnotationRequest, graderID string) (*models.AnswerAnnotation, error)
+	UpdateAnswerAnnotation(ctx context.Context, annotationID uint, req *UpdateAnswerAnnotationRequest, graderID string) (*models.AnswerAnnotation, error)
+	DeleteAnswerAnnotation(ctx context.Context, annotationID uint, graderID string) error
+
+	// Final score overrides
+	OverrideAttemptScore(ctx context.Context, attemptID uint, req *OverrideAttemptScoreRequest, userID string) (*ScoreOverrideHistory, error)
+	GetScoreOverrideHistory(ctx context.Context, attemptID uint, userID string) (*ScoreOverrideHistory, error)
+
+	// Grade change audit
+	GetAnswerGradeHistory(ctx context.Context, answerID uint, userID string) (*AnswerGradeHistory, error)
+	GetAttemptGradeHistory(ctx context.Context, attemptID uint, userID string) ([]AnswerGradeHistory, error)
+
+	// Grading delegation
+	CreateGradingDelegation(ctx context.Context, assessmentID uint, req *CreateGradingDelegationRequest, userID string) (*models.GradingDelegation, error)
+	ListGradingDelegations(ctx context.Context, assessmentID uint, userID string) ([]*models.GradingDelegation, error)
+	// ListMyGradingDelegations lists the delegations granted to the user, including expired ones
+	ListMyGradingDelegations(ctx context.Context, userID string) ([]*models.GradingDelegation, error)
+	RevokeGradingDelegation(ctx context.Context, delegationID uint, userID string) (*models.GradingDelegation, error)
+	// GetGradingDelegationAudit lists the grant, revocation and every grading action taken under a delegation, newest first
+	GetGradingDelegationAudit(ctx context.Context, delegationID uint, userID string) ([]*models.AuditLog, error)
+
+	// Answer key changes
+	GetAnswerKeyChanges(ctx context.Context, questionID uint, userID string) ([]*models.AnswerKeyChange, error)
+	GetAnswerKeyChangeReport(ctx context.Context, changeID uint, userID string) (*AnswerKeyChangeReport, error)
+	ScheduleRegrade(ctx context.Context, changeID, assessmentID uint, userID string) (*models.RegradeJob, error)
+	GetRegradeJob(ctx context.Context, jobID uint, userID string) (*models.RegradeJob, error)
+	QueueRegradeJobs(ctx context.Context, limit int) (int, error)
+	RunRegradeJob(ctx context.Context, jobID uint) error
+
+	// Questions escalated after many students flagged them during the assessment
+	GetFlagEscalations(ctx context.Context, assessmentID uint, status *models.FlagEscalationStatus, userID string) ([]*models.QuestionFlagEscalation, error)
+	ResolveFlagEscalation(ctx context.Context, escalationID uint, req *ResolveFlagEscalationRequest, userID string) (*models.QuestionFlagEscalation, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+}
+
+type ResultsService interface {
+	// Release state
+	GetReleaseStatus(ctx context.Context, assessmentID uint, userID string) (*ResultsReleaseStatus, error)
+	ReleaseResults(ctx context.Context, assessmentID uint, userID string) (*ResultsReleaseStatus, error)
+
+	// Final grades under the assessment's grade policy
+	GetFinalGrades(ctx context.Context, assessmentID uint, userID string) (*FinalGradesReport, error)
+
+	// CSV export of results or answers, optionally redacted for external recipients
+	ExportResults(ctx context.Context, assessmentID uint, req *ResultsExportRequest, userID string) (*ExportedDocument, error)
+
+	// Consolidated transcript of a student's finalized results, as PDF or CSV for advising
+	GetStudentTranscript(ctx context.Context, studentID string, filters StudentTranscriptFilters, userID string) (*StudentTranscript, error)
+	ExportStudentTranscript(ctx context.Context, studentID string, filters StudentTranscriptFilters, format string, userID string) (*ExportedDocument, error)
+
+	// Anonymized research samples, under each organization's research policy
+	SetResearchPolicy(ctx context.Context, req *SetResearchPolicyRequest, userID string) (*models.ResearchPolicy, error)
+	ListResearchPolicies(ctx context.Context, userID string) ([]*models.ResearchPolicy, error)
+	SampleForResearch(ctx context.Context, assessmentID uint, req *ResearchSampleRequest, userID string) (*ResearchSample, error)
+
+	// Scheduled releases
+	ReleaseScheduledResults(ctx context.Context, now time.Time) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+}
+
+type AnalyticsService interface {
+	// Teacher summaries
+	GetTeacherSummary(ctx context.Context, teacherID string, since time.Time) (*TeacherAnalyticsSummary, error)
+
+	// Dashboards
+	GetAssessmentDashboard(ctx context.Context, assessmentID uint, userID string) (*AssessmentDashboard, error)
+
+	// Item analysis
+	GetDistractorAnalysis(ctx context.Context, questionID uint, userID string) (*DistractorAnalysis, error)
+	RunDistractorAnalysis(ctx context.Context, limit int) (int, error)
+	GetAssessmentAnalytics(ctx context.Context, assessmentID uint, userID string) (*AssessmentAnalyticsReport, error)
+	RunItemAnalysis(ctx context.Context, limit int) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+
+	// Question statistics recompute, admins only
+	ScheduleQuestionStatsRecompute(ctx context.Context, req *RecomputeQuestionStatsRequest, userID string) (*models.QuestionStatsJob, error)
+	GetQuestionStatsJob(ctx context.Context, jobID uint, userID string) (*models.QuestionStatsJob, error)
+	QueueQuestionStatsJobs(ctx context.Context, limit int) (int, error)
+	RunQuestionStatsJob(ctx context.Context, jobID uint) error
+
+	// Confidence calibration
+	GetConfidenceCalibration(ctx context.Context, assessmentID uint, userID string) (*ConfidenceCalibrationReport, error)
+
+	// Usage times; timezone defaults to the assessment's due date timezone
+	GetPeakUsageTimes(ctx context.Context, assessmentID uint, timezone string, userID string) (*PeakUsageTimes, error)
+
+	// Navigation patterns, from the question navigation log of submitted attempts
+	GetNavigationAnalytics(ctx context.Context, assessmentID uint, userID string) (*NavigationAnalytics, error)
+
+	// Skill mastery
+	CreateMasteryTarget(ctx context.Context, req *CreateMasteryTargetRequest, userID string) (*models.MasteryTarget, error)
+	ListMasteryTargets(ctx context.Context, userID string) ([]*models.MasteryTarget, error)
+	UpdateMasteryTarget(ctx context.Context, id uint, req *UpdateMasteryTargetRequest, userID string) (*models.MasteryTarget, error)
+	DeleteMasteryTarget(ctx context.Context, id uint, userID string) error
+	GetStudentProgress(ctx context.Context, studentID string, userID string) (*ProgressReport, error)
+}
+
+type ReportService interface {
+	// Subscription management
+	Subscribe(ctx context.Context, req *CreateReportSubscriptionRequest, userID string) (*models.ReportSubscription, error)
+	ListSubscriptions(ctx context.Context, userID string) ([]*models.ReportSubscription, error)
+	UpdateSubscription(ctx context.Context, id uint, req *UpdateReportSubscriptionRequest, userID string) (*models.ReportSubscription, error)
+	Unsubscribe(ctx context.Context, id uint, userID string) error
+
+	// Report generation
+	PreviewReport(ctx context.Context, id uint, userID string) (*RenderedReport, error)
+
+	// Scheduled delivery
+	DeliverDueReports(ctx context.Context, now time.Time) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+}
+
+type FavoriteService interface {
+	// Starred items
+	AddFavorite(ctx context.Context, itemType models.FavoriteItemType, itemID uint, req *AddFavoriteRequest, userID string) (*models.Favorite, error)
+	RemoveFavorite(ctx context.Context, itemType models.FavoriteItemType, itemID uint, userID string) error
+	ListFavorites(ctx context.Context, itemType *models.FavoriteItemType, userID string) (*FavoriteList, error)
+
+	// Sharing with colleagues
+	ShareFavorites(ctx context.Context, req *ShareFavoritesRequest, userID string) (*models.FavoriteListShare, error)
+	UnshareFavorites(ctx context.Context, sharedWith string, userID string) error
+	ListShares(ctx context.Context, userID string) ([]*models.FavoriteListShare, error)
+	ListSharedWithMe(ctx context.Context, userID string) ([]*models.FavoriteListShare, error)
+	GetSharedFavorites(ctx context.Context, ownerID string, itemType *models.FavoriteItemType, userID string) (*FavoriteList, error)
+}
+
+type AttachmentService interface {
+	// Student uploads
+	UploadAttachment(ctx context.Context, attemptID, questionID uint, file io.Reader, filename string, studentID string) (*models.AnswerAttachment, error)
+	ListAttachments(ctx context.Context, attemptID, questionID uint, userID string) ([]*models.AnswerAttachment, error)
+	GetAttachmentFile(ctx context.Context, id uint, userID string) (*models.AnswerAttachment, error)
+
+	// Grading
+	GetAttachmentsForGrading(ctx context.Context, attemptID, questionID uint, userID string) (*AnswerAttachmentsForGrading, error)
+	SearchAttachments(ctx context.Context, assessmentID uint, query string, userID string) ([]AttachmentSearchResult, error)
+	RerunOCR(ctx context.Context, id uint, userID string) (*models.AnswerAttachment, error)
+
+	// Question images
+	UploadQuestionImage(ctx context.Context, questionID uint, file io.Reader, filename string, req *UploadQuestionImageRequest, userID string) (*models.QuestionAttachment, error)
+	DeleteQuestionImage(ctx context.Context, questionID, attachmentID uint, userID string) error
+	QuestionMediaFile(key string) (string, error)
+
+	// Text recognition
+	ProcessPendingOCR(ctx context.Context, limit int) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+}
+
+type GradebookService interface {
+	// Integrations
+	CreateIntegration(ctx context.Context, assessmentID uint, req *CreateGradebookIntegrationRequest, userID string) (*models.GradebookIntegration, error)
+	ListIntegrations(ctx context.Context, assessmentID uint, userID string) ([]*models.GradebookIntegration, error)
+	UpdateIntegration(ctx context.Context, id uint, req *UpdateGradebookIntegrationRequest, userID string) (*models.GradebookIntegration, error)
+	DeleteIntegration(ctx context.Context, id uint, userID string) error
+
+	// Deliveries
+	ListPassbacks(ctx context.Context, assessmentID uint, status *models.PassbackStatus, userID string) ([]*models.GradePassback, error)
+	Resync(ctx context.Context, assessmentID uint, req *ResyncGradesRequest, userID string) (*ResyncGradesResult, error)
+
+	// Background delivery
+	QueueReleasedGrades(ctx context.Context) (int, error)
+	DeliverPendingGrades(ctx context.Context, limit int) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+}
+
+// ===== EXTENSION REQUESTS =====
+
+// CreateExtensionRequest asks for a later due date or one more attempt. It is sent as JSON,
+// or as a multipart form when a supporting document is attached.
+type CreateExtensionRequest struct {
+	Kind             models.ExtensionRequestKind `json:"kind" form:"kind" validate:"required,oneof=deadline extra_attempt"`
+	Reason           string                      `json:"reason" form:"reason" validate:"required,min=10,max=2000"`
+	RequestedDueDate *time.Time                  `json:"requested_due_date" form:"requested_due_date" time_format:"2006-01-02T15:04:05Z07:00"` // Required for deadline requests
+}
+
+// ExtensionAttachment is the supporting document uploaded with an extension request
+type ExtensionAttachment struct {
+	File     io.Reader
+	FileName string
+}
+
+type ApproveExtensionRequest struct {
+	DueDate *time.Time `json:"due_date"` // Grants another due date than the one requested
+	Note    *string    `json:"note" validate:"omitempty,max=2000"`
+}
+
+type DenyExtensionRequest struct {
+	Note string `json:"note" validate:"required,min=1,max=2000"` // Shown to the student
+}
+
+// ExtensionQueueFilter selects requests in a teacher's queue
+type ExtensionQueueFilter struct {
+	AssessmentID *uint
+	Status       models.ExtensionRequestStatus // Defaults to pending
+	Page         int
+	Size         int
+}
+
+type ExtensionRequestListResponse struct {
+	Requests []*models.ExtensionRequest `json:"requests"`
+	Total    int64                      `json:"total"`
+	Page     int                        `json:"page"`
+	Size     int                        `json:"size"`
+}
+
+type ExtensionService interface {
+	// Requests, for students
+	RequestExtension(ctx context.Context, assessmentID uint, req *CreateExtensionRequest, attachment *ExtensionAttachment, studentID string) (*models.ExtensionRequest, error)
+	ListMyRequests(ctx context.Context, studentID string) ([]*models.ExtensionRequest, error)
+	Withdraw(ctx context.Context, id uint, studentID string) (*models.ExtensionRequest, error)
+
+	// Queue, for the assessment's teacher and admins. Approving grants an accommodation.
+	GetQueue(ctx context.Context, filter *ExtensionQueueFilter, userID string) (*ExtensionRequestListResponse, error)
+	Approve(ctx context.Context, id uint, req *ApproveExtensionRequest, userID string) (*models.ExtensionRequest, error)
+	Deny(ctx context.Context, id uint, req *DenyExtensionRequest, userID string) (*models.ExtensionRequest, error)
+	ListAccommodations(ctx context.Context, assessmentID uint, userID string) ([]*models.StudentAccommodation, error)
+
+	// For the student who asked and the teacher deciding
+	GetRequest(ctx context.Context, id uint, userID string) (*models.ExtensionRequest, error)
+	GetAttachment(ctx context.Context, id uint, userID string) (*models.ExtensionRequest, error)
+}
+
+// ===== SHARE LINKS =====
+
+type CreateShareLinkRequest struct {
+	Label          string `json:"label" validate:"required,min=1,max=200"`
+	ExpiresInHours int    `json:"expires_in_hours" validate:"omitempty,min=1,max=720"` // Defaults to 72
+}
+
+// ShareLinkGrant is returned once when a link is created; the token cannot be recovered later
+type ShareLinkGrant struct {
+	Link  *models.AssessmentShareLink `json:"link"`
+	Token string                      `json:"token"` // Opens GET /api/v1/shared/assessments/{token}
+}
+
+// ShareViewer describes who opened a share link, for the view log
+type ShareViewer struct {
+	IPAddress string
+	UserAgent string
+}
+
+// SharedAssessment is what an external reviewer sees through a share link: the content and
+// settings of the assessment, without attempts, scores or usage statistics
+type SharedAssessment struct {
+	ID            uint                      `json:"id"`
+	Title         string                    `json:"title"`
+	Description   *string                   `json:"description"`
+	Duration      int                       `json:"duration"`
+	PassingScore  int                       `json:"passing_score"`
+	MaxAttempts   int                       `json:"max_attempts"`
+	DueDate       *time.Time                `json:"due_date"`
+	DueTimezone   string                    `json:"due_timezone"`
+	Term          *string                   `json:"term"`
+	Version       int                       `json:"version"`
+	TotalPoints   int                       `json:"total_points"`
+	Settings      models.AssessmentSettings `json:"settings"`
+	Questions     []SharedQuestion          `json:"questions"`
+	LinkExpiresAt time.Time                 `json:"link_expires_at"`
+}
+
+type SharedQuestion struct {
+	ID          uint                   `json:"id"`
+	Order       int                    `json:"order"`
+	Type        models.QuestionType    `json:"type"`
+	Text        string                 `json:"text"`
+	Points      int                    `json:"points"` // As weighted in this assessment
+	TimeLimit   *int                   `json:"time_limit"`
+	Required    bool                   `json:"required"`
+	Difficulty  models.DifficultyLevel `json:"difficulty"`
+	Content     json.RawMessage        `json:"content"`
+	Answer      json.RawMessage        `json:"answer"`
+	Explanation *string                `json:"explanation"`
+}
+
+type ShareLinkService interface {
+	// Link management, for the assessment's teacher and admins
+	CreateLink(ctx context.Context, assessmentID uint, req *CreateShareLinkRequest, userID string) (*ShareLinkGrant, error)
+	ListLinks(ctx context.Context, assessmentID uint, userID string) ([]*models.AssessmentShareLink, error)
+	RevokeLink(ctx context.Context, id uint, userID string) (*models.AssessmentShareLink, error)
+	GetViews(ctx context.Context, id uint, userID string) ([]*models.AssessmentShareView, error)
+
+	// Token access, for reviewers without an account
+	Authorize(ctx context.Context, token string) (*models.AssessmentShareLink, error)
+	GetSharedAssessment(ctx context.Context, link *models.AssessmentShareLink, viewer *ShareViewer) (*SharedAssessment, error)
+}
+
+// ===== IMPERSONATION =====
+
+type StartImpersonationRequest struct {
+	TargetUserID    string `json:"target_user_id" validate:"required,max=255"`
+	Reason          string `json:"reason" validate:"required,min=10,max=500"`
+	DurationMinutes int    `json:"duration_minutes" validate:"omitempty,min=1,max=120"` // Defaults to 30
+	AllowWrites     bool   `json:"allow_writes"`
+}
+
+// ImpersonationGrant is returned once when a session starts; the token cannot be recovered later
+type ImpersonationGrant struct {
+	Session *models.ImpersonationSession `json:"session"`
+	Token   string                       `json:"token"` // Sent as the X-Impersonation-Token header
+}
+
+// ImpersonatedRequest describes a request made under an impersonation session for the audit log
+type ImpersonatedRequest struct {
+	Method    string
+	Path      string
+	Blocked   bool // Refused because the session is read-only
+	IPAddress string
+	UserAgent string
+	RequestID *string
+}
+
+type ImpersonationService interface {
+	// Sessions, for admins
+	Start(ctx context.Context, req *StartImpersonationRequest, adminID string) (*ImpersonationGrant, error)
+	End(ctx context.Context, id uint, adminID string) error
+	ListActive(ctx context.Context, adminID string) ([]*models.ImpersonationSession, error)
+	GetAuditTrail(ctx context.Context, id uint, adminID string) ([]*models.AuditLog, error)
+
+	// Request handling, for the auth middleware. On ErrImpersonationReadOnly the session is
+	// still returned so the blocked request can be recorded.
+	Authorize(ctx context.Context, token string, admin *models.User, method string) (*models.ImpersonationSession, *models.User, error)
+	RecordRequest(ctx context.Context, session *models.ImpersonationSession, admin *models.User, req *ImpersonatedRequest) error
+}
+
+// ===== USAGE METERING =====
+
+// UsageReportFilter selects the months, in YYYY-MM form, and the organization of a usage report
+type UsageReportFilter struct {
+	From         string // Defaults to 11 months before To
+	To           string // Defaults to the current month
+	Organization string // Empty for all organizations
+}
+
+// UsageReportRow is one organization's billable usage in one month
+type UsageReportRow struct {
+	Organization           string `json:"organization"`
+	Period                 string `json:"period"` // YYYY-MM
+	AttemptsStarted        int64  `json:"attempts_started"`
+	AIGradingCalls         int64  `json:"ai_grading_calls"`
+	ProctoringMinutes      int64  `json:"proctoring_minutes"`
+	AttachmentStorageBytes int64  `json:"attachment_storage_bytes"` // Stored at the end of the month
+}
+
+type UsageReport struct {
+	From         string           `json:"from"`
+	To           string           `json:"to"`
+	Organization string           `json:"organization,omitempty"`
+	Rows         []UsageReportRow `json:"rows"`
+	GeneratedAt  time.Time        `json:"generated_at"`
+}
+
+type UsageService interface {
+	// Reporting, for admins
+	GetReport(ctx context.Context, filter *UsageReportFilter, userID string) (*UsageReport, error)
+	ExportReport(ctx context.Context, filter *UsageReportFilter, userID string) (*ExportedDocument, error) // CSV
+
+	// Monthly rollups
+	RollupMonth(ctx context.Context, month time.Time) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+}
+
+// ===== WAREHOUSE EXPORT =====
+
+type CreateWarehouseConnectorRequest struct {
+	Organization string                 `json:"organization" validate:"required,min=1,max=255"`
+	Target       models.WarehouseTarget `json:"target" validate:"required,oneof=bigquery snowflake s3_parquet"`
+	Endpoint     string                 `json:"endpoint" validate:"omitempty,url,max=500"` // Required for Snowflake
+	AuthToken    string                 `json:"auth_token" validate:"required,max=4000"`
+	AccessKeyID  string                 `json:"access_key_id" validate:"omitempty,max=255"` // Required for S3
+	Region       string                 `json:"region" validate:"omitempty,max=50"`         // Required for S3
+	Dataset      string                 `json:"dataset" validate:"required,max=255"`
+}
+
+type UpdateWarehouseConnectorRequest struct {
+	Enabled     *bool   `json:"enabled"`
+	Endpoint    *string `json:"endpoint" validate:"omitempty,max=500"`
+	AuthToken   *string `json:"auth_token" validate:"omitempty,max=4000"`
+	AccessKeyID *string `json:"access_key_id" validate:"omitempty,max=255"`
+	Region      *string `json:"region" validate:"omitempty,max=50"`
+	Dataset     *string `json:"dataset" validate:"omitempty,min=1,max=255"`
+}
+
+// WarehouseBackfillRequest selects the facts to export again by when they last changed
+type WarehouseBackfillRequest struct {
+	From *time.Time `json:"from"` // From the beginning when omitted
+	To   *time.Time `json:"to"`   // Defaults to what the nightly export has reached
+}
+
+// WarehouseColumn is a column of an exported fact table
+type WarehouseColumn struct {
+	Name     string              `json:"name"`
+	Type     WarehouseColumnType `json:"type"`
+	Nullable bool                `json:"nullable"`
+}
+
+// WarehouseTable is a fact table as it is created in the warehouse. Tables are versioned:
+// a schema change writes to new tables, so the old ones keep working until analysts move.
+type WarehouseTable struct {
+	Name    string            `json:"name"` // Versioned, e.g. attempt_facts_v2
+	Version int               `json:"version"`
+	Columns []WarehouseColumn `json:"columns"`
+}
+
+type WarehouseExportService interface {
+	// Connectors, for admins
+	CreateConnector(ctx context.Context, req *CreateWarehouseConnectorRequest, userID string) (*models.WarehouseConnector, error)
+	ListConnectors(ctx context.Context, userID string) ([]*models.WarehouseConnector, error)
+	UpdateConnector(ctx context.Context, id uint, req *UpdateWarehouseConnectorRequest, userID string) (*models.WarehouseConnector, error)
+	DeleteConnector(ctx context.Context, id uint, userID string) error
+	GetSchema() []WarehouseTable
+
+	// Runs
+	ListRuns(ctx context.Context, connectorID uint, userID string) ([]*models.WarehouseExportRun, error)
+	RequestBackfill(ctx context.Context, connectorID uint, req *WarehouseBackfillRequest, userID string) (*models.WarehouseExportRun, error) // Queued for the scheduler
+	// Backfill exports right away, for the warehouse-backfill command
+	Backfill(ctx context.Context, connectorID uint, req *WarehouseBackfillRequest, requestedBy string) (*models.WarehouseExportRun, error)
+
+	// Nightly export
+	QueueNightlyExports(ctx context.Context, now time.Time) (int, error)
+	ProcessQueuedRuns(ctx context.Context, limit int) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+}
+
+// ===== WEBHOOKS =====
+
+type CreateWebhookSubscriptionRequest struct {
+	Name       string   `json:"name" validate:"required,min=1,max=100"`
+	URL        string   `json:"url" validate:"required,url,max=500"`
+	EventTypes []string `json:"event_types" validate:"omitempty,max=50,dive,required,max=100"` // Every event type when empty
+}
+
+type UpdateWebhookSubscriptionRequest struct {
+	Name       *string   `json:"name" validate:"omitempty,min=1,max=100"`
+	URL        *string   `json:"url" validate:"omitempty,url,max=500"`
+	EventTypes *[]string `json:"event_types" validate:"omitempty,max=50,dive,required,max=100"`
+	Enabled    *bool     `json:"enabled"`
+}
+
+// WebhookSubscriptionGrant carries the signing secret, which is only returned when the
+// subscription is created
+type WebhookSubscriptionGrant struct {
+	Subscription *models.WebhookSubscription `json:"subscription"`
+	Secret       string                      `json:"secret"`
+}
+
+type WebhookService interface {
+	// Subscriptions, for admins
+	CreateSubscription(ctx context.Context, req *CreateWebhookSubscriptionRequest, userID string) (*WebhookSubscriptionGrant, error)
+	ListSubscriptions(ctx context.Context, userID string) ([]*models.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, id uint, req *UpdateWebhookSubscriptionRequest, userID string) (*models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uint, userID string) error
+
+	// Debugging console; test events and replays are sent right away
+	SendTestEvent(ctx context.Context, subscriptionID uint, userID string) (*models.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, subscriptionID uint, status *models.WebhookDeliveryStatus, userID string) ([]*models.WebhookDelivery, error)
+	ReplayDelivery(ctx context.Context, deliveryID uint, userID string) (*models.WebhookDelivery, error)
+
+	// Background delivery of published events
+	QueueEvent(ctx context.Context, event *events.NotificationEvent) (int, error)
+	DeliverPending(ctx context.Context, limit int) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+}
+
+// ===== DATA RESIDENCY =====
+
+type ProvisionResidencyRequest struct {
+	Organization string `json:"organization" validate:"required,min=1,max=255"`
+	Region       string `json:"region" validate:"required,max=50"`
+}
+
+// ResidencyOverview lists the configured regions and the organizations pinned to them.
+// Organizations not listed are stored in the primary database.
+type ResidencyOverview struct {
+	Regions       []string                        `json:"regions"`
+	Organizations []*models.OrganizationResidency `json:"organizations"`
+}
+
+type ResidencyService interface {
+	// Provisioning, for admins
+	ProvisionOrganization(ctx context.Context, req *ProvisionResidencyRequest, userID string) (*models.OrganizationResidency, error)
+	ListOrganizationResidency(ctx context.Context, userID string) (*ResidencyOverview, error)
+
+	// Request routing
+	ResolveRegion(ctx context.Context, user *models.User) (string, error)
+}
+
+// ===== CUSTOM FIELDS =====
+
+type CreateCustomFieldRequest struct {
+	Organization string                   `json:"organization" validate:"required,min=1,max=255"`
+	Entity       models.CustomFieldEntity `json:"entity" validate:"required,oneof=assessment attempt"`
+	Key          string                   `json:"key" validate:"required,max=50"` // Lower case letters, digits and underscores
+	Label        string                   `json:"label" validate:"required,min=1,max=100"`
+	Type         models.CustomFieldType   `json:"type" validate:"required,oneof=text number boolean date select"`
+	Options      []string                 `json:"options" validate:"omitempty,max=100,dive,required,max=100"` // Select fields only
+	Required     bool                     `json:"required"`                                                   // Assessment fields only
+	MaxLength    *int                     `json:"max_length" validate:"omitempty,min=1,max=2000"`             // Text fields only
+}
+
+// UpdateCustomFieldRequest changes a definition; its organization, entity, key and type are fixed
+// because recorded values and filters refer to them
+type UpdateCustomFieldRequest struct {
+	Label     *string   `json:"label" validate:"omitempty,min=1,max=100"`
+	Options   *[]string `json:"options" validate:"omitempty,max=100,dive,required,max=100"`
+	Required  *bool     `json:"required"`
+	MaxLength *int      `json:"max_length" validate:"omitempty,min=1,max=2000"`
+}
+
+type CustomFieldService interface {
+	// Definitions, for admins
+	CreateDefinition(ctx context.Context, req *CreateCustomFieldRequest, userID string) (*models.CustomFieldDefinition, error)
+	ListDefinitions(ctx context.Context, organization string, entity *models.CustomFieldEntity, userID string) ([]*models.CustomFieldDefinition, error)
+	UpdateDefinition(ctx context.Context, id uint, req *UpdateCustomFieldRequest, userID string) (*models.CustomFieldDefinition, error)
+	DeleteDefinition(ctx context.Context, id uint, userID string) error
+}
+
+// ===== BACKGROUND JOBS =====
+
+type JobService interface {
+	// Queue and workers
+	Register(jobType string, handler JobHandler)
+	Enqueue(ctx context.Context, jobType, ref, createdBy string) (*models.Job, error)
+	ProcessJobs(ctx context.Context, limit int) (int, error)
+	RunScheduler(ctx context.Context, interval time.Duration)
+
+	// Status; users see the jobs they started, admins every job
+	List(ctx context.Context, filters repositories.JobFilters, userID string) ([]*models.Job, int64, error)
+	Get(ctx context.Context, id uint, userID string) (*models.Job, error)
+	// Retry requeues a failed job, for admins
+	Retry(ctx context.Context, id uint, userID string) (*models.Job, error)
+}
+
+// ===== PRACTICE =====
+
+type CreatePracticeSetRequest struct {
+	Title            string  `json:"title" validate:"required,min=1,max=200"`
+	Description      *string `json:"description" validate:"omitempty,max=2000"`
+	QuestionIDs      []uint  `json:"question_ids" validate:"required,min=1,max=500"`
+	QuestionsPerQuiz int     `json:"questions_per_quiz" validate:"required,min=1,max=50"`
+	ClassID          *string `json:"class_id" validate:"omitempty,min=1,max=100"` // Omit for every student
+}
+
+type UpdatePracticeSetRequest struct {
+	Title            *string `json:"title" validate:"omitempty,min=1,max=200"`
+	Description      *string `json:"description" validate:"omitempty,max=2000"`
+	QuestionIDs      []uint  `json:"question_ids" validate:"omitempty,min=1,max=500"`
+	QuestionsPerQuiz *int    `json:"questions_per_quiz" validate:"omitempty,min=1,max=50"`
+	ClassID          *string `json:"class_id" validate:"omitempty,max=100"` // Empty opens the set to every student
+	IsActive         *bool   `json:"is_active"`
+}
+
+type PracticeAnswerRequest struct {
+	QuestionID uint        `json:"question_id" validate:"required"`
+	AnswerData interface{} `json:"answer_data" validate:"required"`
+}
+
+// PracticeSessionResponse is a practice quiz with its questions and the answers given so far.
+// Questions still to be answered come without their explanation.
+type PracticeSessionResponse struct {
+	*models.PracticeSession
+	Title     string                   `json:"title"`
+	Questions []*models.Question       `json:"questions"`
+	Answers   []*models.PracticeAnswer `json:"answers"`
+}
+
+// PracticeAnswerResult is the instant feedback on a practice answer
+type PracticeAnswerResult struct {
+	*SampleGradingResult
+	Explanation   *string                 `json:"explanation"`
+	CorrectAnswer datatypes.JSON          `json:"correct_answer"`
+	Session       *models.PracticeSession `json:"session"` // Running totals after the answer
+}
+
+// PracticeSetStats shows how students do on a practice set, apart from assessment analytics
+type PracticeSetStats struct {
+	PracticeSetID     uint                    `json:"practice_set_id"`
+	Title             string                  `json:"title"`
+	Sessions          int64                   `json:"sessions"`
+	CompletedSessions int64                   `json:"completed_sessions"`
+	Students          int64                   `json:"students"`
+	AverageScore      float64                 `json:"average_score"` // Percent of the points answered
+	Questions         []PracticeQuestionStats `json:"questions"`
+}
+
+type PracticeQuestionStats struct {
+	QuestionID   uint    `json:"question_id"`
+	Answers      int64   `json:"answers"`
+	CorrectRate  float64 `json:"correct_rate"`  // Percent
+	AverageScore float64 `json:"average_score"` // Percent of the question's points
+}
+
+type PracticeService interface {
+	// Practice sets; teachers curate them from banks they can edit
+	CreateSet(ctx context.Context, bankID uint, req *CreatePracticeSetRequest, userID string) (*models.PracticeSet, error)
+	UpdateSet(ctx context.Context, id uint, req *UpdatePracticeSetRequest, userID string) (*models.PracticeSet, error)
+	ListBankSets(ctx context.Context, bankID uint, userID string) ([]*models.PracticeSet, error)
+	GetSetStats(ctx context.Context, id uint, userID string) (*PracticeSetStats, error)
+
+	// Sessions; students take them as often as they like
+	ListAvailableSets(ctx context.Context, studentID string) ([]*models.PracticeSet, error)
+	StartSession(ctx context.Context, setID uint, studentID string) (*PracticeSessionResponse, error)
+	GetSession(ctx context.Context, id uint, studentID string) (*PracticeSessionResponse, error)
+	AnswerQuestion(ctx context.Context, sessionID uint, req *PracticeAnswerRequest, studentID string) (*PracticeAnswerResult, error)
+	ListSessions(ctx context.Context, setID *uint, studentID string, limit, offset int) ([]*models.PracticeSession, int64, error)
 }
 
 // ===== SERVICE MANAGER =====
@@ -358,11 +2824,30 @@ type ServiceManager interface {
 	QuestionBank() QuestionBankService
 	Attempt() AttemptService
 	Grading() GradingService
+	Results() ResultsService
 
 	// Additional service getters
 	ImportExport() ImportExportService
 	// Notification() NotificationService
-	// Analytics() AnalyticsService
+	Analytics() AnalyticsService
+	Report() ReportService
+	Favorite() FavoriteService
+	Attachment() AttachmentService
+	Gradebook() GradebookService
+	Extension() ExtensionService
+	ShareLink() ShareLinkService
+	Impersonation() ImpersonationService
+	NotificationEvents() NotificationEventService
+	Usage() UsageService
+	Warehouse() WarehouseExportService
+	Residency() ResidencyService
+	Webhook() WebhookService
+	CustomField() CustomFieldService
+	Jobs() JobService
+	Practice() PracticeService
+
+	// Per-assessment live metrics; nil when metrics are disabled
+	LiveMetrics() *LiveMetrics
 
 	// Health and lifecycle
 	Initialize(ctx context.Context) error
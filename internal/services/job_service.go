@@ -0,0 +1,358 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+// Job types with a registered handler
+const (
+	JobTypeImport        = "import"         // Ref is the import job ID
+	JobTypeRegrade       = "regrade"        // Ref is the regrade job ID
+	JobTypeQuestionStats = "question_stats" // Ref is the question stats job ID
+)
+
+const (
+	defaultJobMaxAttempts = 3
+	// A failed attempt is retried after the base delay, doubled for every further attempt
+	jobRetryBaseDelay = 30 * time.Second
+	jobRetryMaxDelay  = 30 * time.Minute
+	// A running job without a heartbeat for this long is treated as orphaned by a crash
+	jobStaleAfter     = 5 * time.Minute
+	jobHeartbeatEvery = time.Minute
+	jobBatch          = 20
+)
+
+// JobHandler does the work of one job. A returned error retries the job later, unless the
+// error is permanent or the job is out of attempts.
+type JobHandler func(ctx context.Context, job *models.Job) error
+
+// permanentJobError marks a failure that retrying cannot fix
+type permanentJobError struct {
+	err error
+}
+
+func (e *permanentJobError) Error() string { return e.err.Error() }
+func (e *permanentJobError) Unwrap() error { return e.err }
+
+// NewPermanentJobError wraps a handler error so the job fails without further attempts
+func NewPermanentJobError(err error) error {
+	return &permanentJobError{err: err}
+}
+
+type jobService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+
+	mu       sync.RWMutex
+	handlers map[string]JobHandler
+}
+
+func NewJobService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) JobService {
+	return &jobService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+		handlers:  make(map[string]JobHandler),
+	}
+}
+
+// Register makes the workers run jobs of the type with the handler
+func (s *jobService) Register(jobType string, handler JobHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[jobType] = handler
+}
+
+func (s *jobService) Enqueue(ctx context.Context, jobType, ref, createdBy string) (*models.Job, error) {
+	return enqueueJob(ctx, s.repo, jobType, ref, createdBy)
+}
+
+// ===== STATUS =====
+
+// List returns jobs newest first; admins see every job and other users the jobs they started
+func (s *jobService) List(ctx context.Context, filters repositories.JobFilters, userID string) ([]*models.Job, int64, error) {
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if role != models.RoleAdmin {
+		filters.CreatedBy = &userID
+	}
+	return s.repo.Job().List(ctx, nil, filters)
+}
+
+func (s *jobService) Get(ctx context.Context, id uint, userID string) (*models.Job, error) {
+	job, err := s.getJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.CreatedBy == userID {
+		return job, nil
+	}
+
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role != models.RoleAdmin {
+		return nil, NewPermissionError(userID, id, "job", "view", "not the user who started the job")
+	}
+	return job, nil
+}
+
+// Retry takes a failed job off the dead-letter queue and runs it again with fresh attempts
+func (s *jobService) Retry(ctx context.Context, id uint, userID string) (*models.Job, error) {
+	s.logger.Info("Retrying job", "job_id", id, "user_id", userID)
+
+	role, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role != models.RoleAdmin {
+		return nil, NewPermissionError(userID, id, "job", "retry", "only admins can retry jobs")
+	}
+
+	job, err := s.getJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status != models.JobFailed {
+		return nil, NewBusinessRuleError("job_not_failed", "only failed jobs can be retried", map[string]interface{}{
+			"job_id": id,
+			"status": job.Status,
+		})
+	}
+
+	job.Status = models.JobQueued
+	job.Attempts = 0
+	job.RunAt = time.Now()
+	job.FinishedAt = nil
+	if err := s.repo.Job().Update(ctx, nil, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ===== WORKERS =====
+
+// ProcessJobs runs due jobs of the registered types and jobs orphaned mid-way by a crash
+func (s *jobService) ProcessJobs(ctx context.Context, limit int) (int, error) {
+	types := s.registeredTypes()
+	if len(types) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	jobs, err := s.repo.Job().GetRunnable(ctx, nil, types, now, now.Add(-jobStaleAfter), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, job := range jobs {
+		now := time.Now()
+		claimed, err := s.repo.Job().Claim(ctx, nil, job.ID, now, now.Add(-jobStaleAfter))
+		if err != nil {
+			s.logger.Error("Failed to claim job", "job_id", job.ID, "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		job.Status = models.JobRunning
+		job.Attempts++
+		job.StartedAt = &now
+		if err := s.runJob(ctx, job); err != nil {
+			s.logger.Error("Failed to save job", "job_id", job.ID, "error", err)
+			continue
+		}
+		processed++
+	}
+
+	return processed, nil
+}
+
+// RunScheduler runs queued jobs every interval until the context is cancelled
+func (s *jobService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.Info("Job scheduler started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Job scheduler stopped")
+			return
+		case <-ticker.C:
+			if _, err := s.ProcessJobs(ctx, jobBatch); err != nil {
+				s.logger.Error("Failed to process jobs", "error", err)
+			}
+		}
+	}
+}
+
+// ===== HELPER METHODS =====
+
+// runJob runs a claimed job's handler, keeping its heartbeat fresh, and records the outcome
+func (s *jobService) runJob(ctx context.Context, job *models.Job) error {
+	var runErr error
+	if job.Attempts > job.MaxAttempts {
+		// Orphaned by a crash on its last attempt
+		runErr = NewPermanentJobError(errors.New("abandoned after its last attempt stopped responding"))
+	} else {
+		s.mu.RLock()
+		handler := s.handlers[job.Type]
+		s.mu.RUnlock()
+
+		stop := s.keepAlive(ctx, job.ID)
+		runErr = handler(ctx, job)
+		stop()
+	}
+
+	settleJob(job, runErr, time.Now())
+	switch job.Status {
+	case models.JobQueued:
+		s.logger.Warn("Job attempt failed; retrying", "job_id", job.ID, "type", job.Type, "attempt", job.Attempts, "run_at", job.RunAt, "error", runErr)
+	case models.JobFailed:
+		s.logger.Error("Job failed", "job_id", job.ID, "type", job.Type, "attempts", job.Attempts, "error", runErr)
+	}
+	return s.repo.Job().Update(ctx, nil, job)
+}
+
+// keepAlive refreshes a job's heartbeat until the returned function is called
+func (s *jobService) keepAlive(ctx context.Context, id uint) func() {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(jobHeartbeatEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := s.repo.Job().Heartbeat(ctx, nil, id); err != nil {
+					s.logger.Warn("Failed to record job heartbeat", "job_id", id, "error", err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+func (s *jobService) registeredTypes() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	types := make([]string, 0, len(s.handlers))
+	for jobType := range s.handlers {
+		types = append(types, jobType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func (s *jobService) getJob(ctx context.Context, id uint) (*models.Job, error) {
+	job, err := s.repo.Job().GetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+	return job, nil
+}
+
+func (s *jobService) getUserRole(ctx context.Context, userID string) (models.UserRole, error) {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	return user.Role, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// enqueueJob queues work on a record for the job workers. A job already queued or running
+// for the same record is returned instead of queueing another.
+func enqueueJob(ctx context.Context, repo repositories.Repository, jobType, ref, createdBy string) (*models.Job, error) {
+	active, err := repo.Job().GetActive(ctx, nil, jobType, ref)
+	if err == nil {
+		return active, nil
+	}
+	if !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get active job: %w", err)
+	}
+
+	job := &models.Job{
+		Type:        jobType,
+		Ref:         ref,
+		Status:      models.JobQueued,
+		MaxAttempts: defaultJobMaxAttempts,
+		RunAt:       time.Now(),
+		CreatedBy:   createdBy,
+	}
+	if err := repo.Job().Create(ctx, nil, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// settleJob records the outcome of an attempt: done, queued again after a backoff, or
+// failed for good once the error is permanent or the attempts are used up
+func settleJob(job *models.Job, runErr error, now time.Time) {
+	if runErr == nil {
+		job.Status = models.JobSucceeded
+		job.LastError = nil
+		job.FinishedAt = &now
+		return
+	}
+
+	job.LastError = stringPtr(runErr.Error())
+	var permanent *permanentJobError
+	if errors.As(runErr, &permanent) || job.Attempts >= job.MaxAttempts {
+		job.Status = models.JobFailed
+		job.FinishedAt = &now
+		return
+	}
+	job.Status = models.JobQueued
+	job.RunAt = now.Add(jobRetryDelay(job.Attempts))
+}
+
+// jobRetryDelay is the wait before the attempt after the given one
+func jobRetryDelay(attempt int) time.Duration {
+	delay := float64(jobRetryBaseDelay) * math.Pow(2, float64(max(attempt-1, 0)))
+	return time.Duration(math.Min(delay, float64(jobRetryMaxDelay)))
+}
+
+// jobRefID reads a job's ref as the numeric ID of its record
+func jobRefID(job *models.Job) (uint, error) {
+	id, err := strconv.ParseUint(job.Ref, 10, 32)
+	if err != nil {
+		return 0, NewPermanentJobError(fmt.Errorf("invalid %s job ref %q", job.Type, job.Ref))
+	}
+	return uint(id), nil
+}
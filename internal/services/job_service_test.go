@@ -0,0 +1,81 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestSettleJob(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	runFailed := errors.New("connection reset")
+
+	tests := []struct {
+		name         string
+		attempts     int
+		err          error
+		wantStatus   models.JobStatus
+		wantRunAt    time.Time
+		wantError    bool
+		wantFinished bool
+	}{
+		{"success", 1, nil, models.JobSucceeded, time.Time{}, false, true},
+		{"first failure retries", 1, runFailed, models.JobQueued, now.Add(30 * time.Second), true, false},
+		{"second failure backs off", 2, runFailed, models.JobQueued, now.Add(time.Minute), true, false},
+		{"last attempt dead-letters", 3, runFailed, models.JobFailed, time.Time{}, true, true},
+		{"permanent error dead-letters", 1, NewPermanentJobError(runFailed), models.JobFailed, time.Time{}, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &models.Job{Status: models.JobRunning, Attempts: tt.attempts, MaxAttempts: 3}
+			settleJob(job, tt.err, now)
+
+			if job.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", job.Status, tt.wantStatus)
+			}
+			if !tt.wantRunAt.IsZero() && !job.RunAt.Equal(tt.wantRunAt) {
+				t.Errorf("run at = %v, want %v", job.RunAt, tt.wantRunAt)
+			}
+			if (job.LastError != nil) != tt.wantError {
+				t.Errorf("last error = %v, want set %v", job.LastError, tt.wantError)
+			}
+			if (job.FinishedAt != nil) != tt.wantFinished {
+				t.Errorf("finished at = %v, want set %v", job.FinishedAt, tt.wantFinished)
+			}
+		})
+	}
+}
+
+func TestJobRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, 30 * time.Second},
+		{3, 2 * time.Minute},
+		{20, 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := jobRetryDelay(tt.attempt); got != tt.want {
+			t.Errorf("jobRetryDelay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestJobRefID(t *testing.T) {
+	id, err := jobRefID(&models.Job{Type: JobTypeRegrade, Ref: "42"})
+	if err != nil || id != 42 {
+		t.Fatalf("jobRefID = %d, %v, want 42", id, err)
+	}
+
+	_, err = jobRefID(&models.Job{Type: JobTypeRegrade, Ref: "abc"})
+	var permanent *permanentJobError
+	if !errors.As(err, &permanent) {
+		t.Errorf("bad ref error = %v, want a permanent error", err)
+	}
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultLiveMetricsMaxAssessments bounds the assessment_id label; further assessments
+	// are reported together as "other"
+	DefaultLiveMetricsMaxAssessments = 200
+
+	// Assessments without events or active attempts for this long give their series up
+	liveMetricsIdleTTL = 30 * time.Minute
+
+	liveMetricsWindowSeconds = 60
+	liveMetricsOtherLabel    = "other"
+)
+
+// ActiveAttemptCounter counts attempts in progress per assessment
+type ActiveAttemptCounter func(ctx context.Context, now time.Time) (map[uint]int, error)
+
+// LiveMetrics collects per-assessment gauges for watching exams as they run and writes them
+// in the Prometheus text format. Event metrics are counted by this instance; active
+// attempts are counted from the database on each scrape so every replica reports the
+// same value. A nil *LiveMetrics records nothing.
+type LiveMetrics struct {
+	mu             sync.Mutex
+	maxAssessments int
+	assessments    map[uint]*assessmentLiveMetrics
+	other          *assessmentLiveMetrics
+	activeAttempts ActiveAttemptCounter
+	now            func() time.Time
+}
+
+type assessmentLiveMetrics struct {
+	activeAttempts   int
+	submissionsTotal uint64
+	autosaveErrors   uint64
+	submissions      metricsWindow
+	answerLatency    metricsWindow // Seconds
+	lastSeen         time.Time
+}
+
+// metricsWindow sums observations over the last minute in one-second buckets
+type metricsWindow struct {
+	buckets [liveMetricsWindowSeconds]metricsBucket
+}
+
+type metricsBucket struct {
+	second int64
+	count  int
+	sum    float64
+}
+
+func NewLiveMetrics(maxAssessments int, activeAttempts ActiveAttemptCounter) *LiveMetrics {
+	if maxAssessments <= 0 {
+		maxAssessments = DefaultLiveMetricsMaxAssessments
+	}
+	return &LiveMetrics{
+		maxAssessments: maxAssessments,
+		assessments:    make(map[uint]*assessmentLiveMetrics),
+		other:          &assessmentLiveMetrics{},
+		activeAttempts: activeAttempts,
+		now:            time.Now,
+	}
+}
+
+// ===== RECORDING =====
+
+// SubmissionRecorded counts an attempt handed in, by the student or on timeout
+func (m *LiveMetrics) SubmissionRecorded(assessmentID uint) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	series := m.series(assessmentID, now)
+	series.submissionsTotal++
+	series.submissions.add(now, 1)
+}
+
+// AutosaveFailed counts an answer save that did not reach the database
+func (m *LiveMetrics) AutosaveFailed(assessmentID uint) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.series(assessmentID, m.now()).autosaveErrors++
+}
+
+// AnswerSaved records how long saving an answer took
+func (m *LiveMetrics) AnswerSaved(assessmentID uint, latency time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.series(assessmentID, now).answerLatency.add(now, latency.Seconds())
+}
+
+// ===== EXPOSITION =====
+
+// WritePrometheus writes the current metrics. When active attempts cannot be counted the
+// other metrics are still written and the error is returned.
+func (m *LiveMetrics) WritePrometheus(ctx context.Context, w io.Writer) error {
+	if m == nil {
+		return nil
+	}
+
+	var countErr error
+	var counts map[uint]int
+	if m.activeAttempts != nil {
+		counts, countErr = m.activeAttempts(ctx, m.now())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if countErr == nil {
+		m.setActiveAttempts(counts, now)
+	}
+
+	ids := make([]uint, 0, len(m.assessments))
+	for id := range m.assessments {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	type labeled struct {
+		label  string
+		series *assessmentLiveMetrics
+	}
+	all := make([]labeled, 0, len(ids)+1)
+	for _, id := range ids {
+		all = append(all, labeled{strconv.FormatUint(uint64(id), 10), m.assessments[id]})
+	}
+	if m.other.lastSeen != (time.Time{}) {
+		all = append(all, labeled{liveMetricsOtherLabel, m.other})
+	}
+
+	write := func(name, kind, help string, value func(*assessmentLiveMetrics) (float64, bool)) error {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind); err != nil {
+			return err
+		}
+		for _, entry := range all {
+			v, ok := value(entry.series)
+			if !ok {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "%s{assessment_id=%q} %s\n", name, entry.label, strconv.FormatFloat(v, 'g', -1, 64)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	metrics := []struct {
+		name, kind, help string
+		value            func(*assessmentLiveMetrics) (float64, bool)
+	}{
+		{"assessment_active_attempts", "gauge", "Attempts in progress.", func(s *assessmentLiveMetrics) (float64, bool) {
+			return float64(s.activeAttempts), countErr == nil
+		}},
+		{"assessment_submissions_total", "counter", "Attempts submitted or timed out.", func(s *assessmentLiveMetrics) (float64, bool) {
+			return float64(s.submissionsTotal), true
+		}},
+		{"assessment_submissions_per_minute", "gauge", "Attempts submitted or timed out in the last minute.", func(s *assessmentLiveMetrics) (float64, bool) {
+			count, _ := s.submissions.totals(now)
+			return float64(count), true
+		}},
+		{"assessment_autosave_errors_total", "counter", "Answer saves that failed.", func(s *assessmentLiveMetrics) (float64, bool) {
+			return float64(s.autosaveErrors), true
+		}},
+		{"assessment_answer_latency_seconds", "gauge", "Average time to save an answer over the last minute.", func(s *assessmentLiveMetrics) (float64, bool) {
+			count, sum := s.answerLatency.totals(now)
+			if count == 0 {
+				return 0, false
+			}
+			return sum / float64(count), true
+		}},
+	}
+	for _, metric := range metrics {
+		if err := write(metric.name, metric.kind, metric.help, metric.value); err != nil {
+			return err
+		}
+	}
+
+	if _, err := fmt.Fprintf(w, "# HELP assessment_metrics_tracked_assessments Assessments with their own series.\n# TYPE assessment_metrics_tracked_assessments gauge\nassessment_metrics_tracked_assessments %d\n", len(m.assessments)); err != nil {
+		return err
+	}
+
+	return countErr
+}
+
+// ===== HELPER METHODS =====
+
+// series returns the assessment's metrics, falling back to "other" once the label limit is
+// reached and no idle assessment can make room. Callers hold the lock.
+func (m *LiveMetrics) series(assessmentID uint, now time.Time) *assessmentLiveMetrics {
+	series, ok := m.assessments[assessmentID]
+	if !ok {
+		if len(m.assessments) >= m.maxAssessments {
+			m.evictIdle(now)
+		}
+		if len(m.assessments) >= m.maxAssessments {
+			m.other.lastSeen = now
+			return m.other
+		}
+		series = &assessmentLiveMetrics{}
+		m.assessments[assessmentID] = series
+	}
+	series.lastSeen = now
+	return series
+}
+
+func (m *LiveMetrics) evictIdle(now time.Time) {
+	for id, series := range m.assessments {
+		if series.activeAttempts == 0 && now.Sub(series.lastSeen) > liveMetricsIdleTTL {
+			delete(m.assessments, id)
+		}
+	}
+}
+
+// setActiveAttempts replaces the active attempt gauges with fresh counts. Callers hold the lock.
+func (m *LiveMetrics) setActiveAttempts(counts map[uint]int, now time.Time) {
+	for _, series := range m.assessments {
+		series.activeAttempts = 0
+	}
+	m.other.activeAttempts = 0
+
+	// Assessments in order, so which ones spill into "other" does not change between scrapes
+	ids := make([]uint, 0, len(counts))
+	for id, count := range counts {
+		if count > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		m.series(id, now).activeAttempts += counts[id]
+	}
+}
+
+func (w *metricsWindow) add(now time.Time, value float64) {
+	second := now.Unix()
+	bucket := &w.buckets[second%liveMetricsWindowSeconds]
+	if bucket.second != second {
+		*bucket = metricsBucket{second: second}
+	}
+	bucket.count++
+	bucket.sum += value
+}
+
+// totals sums the observations of the last minute
+func (w *metricsWindow) totals(now time.Time) (int, float64) {
+	cutoff := now.Unix() - liveMetricsWindowSeconds
+	count, sum := 0, 0.0
+	for _, bucket := range w.buckets {
+		if bucket.second > cutoff && bucket.count > 0 {
+			count += bucket.count
+			sum += bucket.sum
+		}
+	}
+	return count, sum
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLiveMetrics(maxAssessments int, counts map[uint]int, countErr error) (*LiveMetrics, *time.Time) {
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	m := NewLiveMetrics(maxAssessments, func(ctx context.Context, _ time.Time) (map[uint]int, error) {
+		return counts, countErr
+	})
+	m.now = func() time.Time { return now }
+	return m, &now
+}
+
+func scrape(t *testing.T, m *LiveMetrics) (string, error) {
+	t.Helper()
+	var out strings.Builder
+	err := m.WritePrometheus(context.Background(), &out)
+	return out.String(), err
+}
+
+func TestLiveMetricsRecordsPerAssessment(t *testing.T) {
+	m, now := newTestLiveMetrics(10, map[uint]int{7: 3}, nil)
+
+	m.SubmissionRecorded(7)
+	m.SubmissionRecorded(7)
+	m.AutosaveFailed(7)
+	m.AnswerSaved(7, 100*time.Millisecond)
+	m.AnswerSaved(7, 300*time.Millisecond)
+
+	out, err := scrape(t, m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`assessment_active_attempts{assessment_id="7"} 3`,
+		`assessment_submissions_total{assessment_id="7"} 2`,
+		`assessment_submissions_per_minute{assessment_id="7"} 2`,
+		`assessment_autosave_errors_total{assessment_id="7"} 1`,
+		`assessment_answer_latency_seconds{assessment_id="7"} 0.2`,
+		"# TYPE assessment_submissions_total counter",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+
+	// Rates and averages only cover the last minute; totals keep counting
+	*now = now.Add(2 * time.Minute)
+	out, _ = scrape(t, m)
+	if !strings.Contains(out, `assessment_submissions_per_minute{assessment_id="7"} 0`) {
+		t.Errorf("submissions per minute should drop to zero:\n%s", out)
+	}
+	if strings.Contains(out, `assessment_answer_latency_seconds{assessment_id="7"}`) {
+		t.Errorf("latency without recent saves should be omitted:\n%s", out)
+	}
+	if !strings.Contains(out, `assessment_submissions_total{assessment_id="7"} 2`) {
+		t.Errorf("submission total should be kept:\n%s", out)
+	}
+}
+
+func TestLiveMetricsBoundsCardinality(t *testing.T) {
+	m, now := newTestLiveMetrics(2, nil, nil)
+
+	m.SubmissionRecorded(1)
+	m.SubmissionRecorded(2)
+	m.SubmissionRecorded(3)
+	m.SubmissionRecorded(4)
+
+	out, _ := scrape(t, m)
+	if !strings.Contains(out, `assessment_submissions_total{assessment_id="other"} 2`) {
+		t.Errorf("assessments over the limit should be reported as other:\n%s", out)
+	}
+	if strings.Contains(out, `assessment_id="3"`) {
+		t.Errorf("assessment over the limit should not get its own series:\n%s", out)
+	}
+
+	// Idle assessments make room for new ones
+	*now = now.Add(liveMetricsIdleTTL + time.Minute)
+	m.SubmissionRecorded(5)
+	out, _ = scrape(t, m)
+	if !strings.Contains(out, `assessment_submissions_total{assessment_id="5"} 1`) {
+		t.Errorf("new assessment should replace idle ones:\n%s", out)
+	}
+	if !strings.Contains(out, "assessment_metrics_tracked_assessments 1") {
+		t.Errorf("idle assessments should be evicted:\n%s", out)
+	}
+}
+
+func TestLiveMetricsCountErrorKeepsEventMetrics(t *testing.T) {
+	countErr := errors.New("database down")
+	m, _ := newTestLiveMetrics(10, nil, countErr)
+	m.AutosaveFailed(9)
+
+	out, err := scrape(t, m)
+	if !errors.Is(err, countErr) {
+		t.Fatalf("expected count error, got %v", err)
+	}
+	if strings.Contains(out, `assessment_active_attempts{`) {
+		t.Errorf("active attempts should be omitted when they cannot be counted:\n%s", out)
+	}
+	if !strings.Contains(out, `assessment_autosave_errors_total{assessment_id="9"} 1`) {
+		t.Errorf("event metrics should still be written:\n%s", out)
+	}
+}
+
+func TestLiveMetricsNilRecordsNothing(t *testing.T) {
+	var m *LiveMetrics
+	m.SubmissionRecorded(1)
+	m.AutosaveFailed(1)
+	m.AnswerSaved(1, time.Second)
+	if err := m.WritePrometheus(context.Background(), &strings.Builder{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -19,19 +19,37 @@ type NotificationEventService interface {
 	NotifyAssessmentPublished(ctx context.Context, assessmentID uint) error
 	NotifyAssessmentExpiring(ctx context.Context, assessmentID uint, hoursRemaining int) error
 	NotifyAssessmentExpired(ctx context.Context, assessmentID uint) error
+	NotifyQuestionFlagsEscalated(ctx context.Context, escalation *models.QuestionFlagEscalation) error
 
 	// Attempt notifications
 	NotifyAttemptStarted(ctx context.Context, attemptID uint) error
 	NotifyAttemptSubmitted(ctx context.Context, attemptID uint) error
 	NotifyAttemptGraded(ctx context.Context, attemptID uint) error
 	NotifyAttemptTimeWarning(ctx context.Context, attemptID uint, minutesRemaining int) error
+	NotifyAttemptSlotOpened(ctx context.Context, assessmentID uint, studentID string, heldUntil time.Time) error
+
+	// Extension request notifications
+	NotifyExtensionRequested(ctx context.Context, request *models.ExtensionRequest) error
+	NotifyExtensionDecided(ctx context.Context, request *models.ExtensionRequest, accommodation *models.StudentAccommodation) error
 
 	// Grading notifications
 	NotifyGradingCompleted(ctx context.Context, assessmentID uint) error
 	NotifyManualGradingRequired(ctx context.Context, assessmentID uint, questionCount int) error
+	NotifyResultsReleased(ctx context.Context, assessmentID uint, releasedBy string) error
+
+	// Question bank notifications
+	NotifyQuestionsReviewDue(ctx context.Context, report *StaleQuestionReport) error
 
 	// System notifications
 	SendBulkNotification(ctx context.Context, userIDs []uint, notification *NotificationRequest) error
+
+	// Audience notifications, with recipients picked by filter
+	PreviewAudience(ctx context.Context, audiences []NotificationAudience, userID string) (*AudiencePreview, error)
+	SendAudienceNotification(ctx context.Context, req *AudienceNotificationRequest, userID string) (*AudiencePreview, error)
+
+	// Notification preferences of the signed-in user
+	GetNotificationPreferences(ctx context.Context, userID string) (*NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context, req *UpdateNotificationPreferencesRequest, userID string) (*NotificationPreferences, error)
 }
 
 type NotificationRequest struct {
@@ -88,7 +106,7 @@ func (s *notificationEventService) NotifyAssessmentPublished(ctx context.Context
 		assessment.CreatedBy,
 	)
 
-	return s.eventPublisher.PublishNotificationEvent(ctx, event)
+	return s.publish(ctx, event, studentIDs...)
 }
 
 func (s *notificationEventService) NotifyAssessmentExpiring(ctx context.Context, assessmentID uint, hoursRemaining int) error {
@@ -121,7 +139,7 @@ func (s *notificationEventService) NotifyAssessmentExpiring(ctx context.Context,
 		},
 	}
 
-	return s.eventPublisher.PublishNotificationEvent(ctx, event)
+	return s.publish(ctx, event, studentIDs...)
 }
 
 func (s *notificationEventService) NotifyAssessmentExpired(ctx context.Context, assessmentID uint) error {
@@ -152,7 +170,47 @@ func (s *notificationEventService) NotifyAssessmentExpired(ctx context.Context,
 		},
 	}
 
-	return s.eventPublisher.PublishNotificationEvent(ctx, event)
+	return s.publish(ctx, event, append(studentIDs, assessment.CreatedBy)...)
+}
+
+// NotifyQuestionFlagsEscalated alerts the assessment owner that many students flagged a question
+func (s *notificationEventService) NotifyQuestionFlagsEscalated(ctx context.Context, escalation *models.QuestionFlagEscalation) error {
+	s.logger.Info("Publishing question flags escalated event",
+		"escalation_id", escalation.ID,
+		"assessment_id", escalation.AssessmentID,
+		"question_id", escalation.QuestionID)
+
+	// Get assessment and question details
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, escalation.AssessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get assessment: %w", err)
+	}
+	question, err := s.repo.Question().GetByID(ctx, nil, escalation.QuestionID)
+	if err != nil {
+		return fmt.Errorf("failed to get question: %w", err)
+	}
+
+	// Create and publish event
+	event := &events.NotificationEvent{
+		ID:        events.GenerateEventID(),
+		Type:      events.EventQuestionFlagsEscalated,
+		Timestamp: time.Now(),
+		Source:    "assessment-service",
+		Version:   "1.0",
+		Data: events.QuestionFlagsEscalatedEvent{
+			EscalationID:    escalation.ID,
+			AssessmentID:    assessment.ID,
+			AssessmentTitle: assessment.Title,
+			QuestionID:      question.ID,
+			QuestionText:    question.Text,
+			OwnerID:         assessment.CreatedBy,
+			FlagCount:       escalation.FlagCount,
+			ActiveAttempts:  escalation.ActiveAttempts,
+			EscalatedAt:     escalation.EscalatedAt,
+		},
+	}
+
+	return s.publish(ctx, event, assessment.CreatedBy)
 }
 
 // ===== ATTEMPT NOTIFICATIONS =====
@@ -176,7 +234,7 @@ func (s *notificationEventService) NotifyAttemptStarted(ctx context.Context, att
 		&attempt.Assessment.Duration,
 	)
 
-	return s.eventPublisher.PublishNotificationEvent(ctx, event)
+	return s.publish(ctx, event, attempt.StudentID)
 }
 
 func (s *notificationEventService) NotifyAttemptSubmitted(ctx context.Context, attemptID uint) error {
@@ -207,7 +265,7 @@ func (s *notificationEventService) NotifyAttemptSubmitted(ctx context.Context, a
 		},
 	}
 
-	return s.eventPublisher.PublishNotificationEvent(ctx, event)
+	return s.publish(ctx, event, attempt.StudentID, attempt.Assessment.CreatedBy)
 }
 
 func (s *notificationEventService) NotifyAttemptGraded(ctx context.Context, attemptID uint) error {
@@ -240,7 +298,7 @@ func (s *notificationEventService) NotifyAttemptGraded(ctx context.Context, atte
 		},
 	}
 
-	return s.eventPublisher.PublishNotificationEvent(ctx, event)
+	return s.publish(ctx, event, attempt.StudentID)
 }
 
 func (s *notificationEventService) NotifyAttemptTimeWarning(ctx context.Context, attemptID uint, minutesRemaining int) error {
@@ -271,7 +329,117 @@ func (s *notificationEventService) NotifyAttemptTimeWarning(ctx context.Context,
 		},
 	}
 
-	return s.eventPublisher.PublishNotificationEvent(ctx, event)
+	return s.publish(ctx, event, attempt.StudentID)
+}
+
+func (s *notificationEventService) NotifyAttemptSlotOpened(ctx context.Context, assessmentID uint, studentID string, heldUntil time.Time) error {
+	s.logger.Info("Publishing attempt slot opened event",
+		"assessment_id", assessmentID,
+		"student_id", studentID)
+
+	// Get assessment details
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	// Create and publish event
+	event := &events.NotificationEvent{
+		ID:        events.GenerateEventID(),
+		Type:      events.EventAttemptSlotOpened,
+		Timestamp: time.Now(),
+		Source:    "assessment-service",
+		Version:   "1.0",
+		Data: events.AttemptSlotOpenedEvent{
+			AssessmentID:    assessmentID,
+			AssessmentTitle: assessment.Title,
+			StudentID:       studentID,
+			HeldUntil:       heldUntil,
+		},
+	}
+
+	return s.publish(ctx, event, studentID)
+}
+
+// ===== EXTENSION REQUEST NOTIFICATIONS =====
+
+func (s *notificationEventService) NotifyExtensionRequested(ctx context.Context, request *models.ExtensionRequest) error {
+	s.logger.Info("Publishing extension requested event",
+		"request_id", request.ID,
+		"assessment_id", request.AssessmentID,
+		"student_id", request.StudentID)
+
+	// Get assessment details
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, request.AssessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	// Create and publish event
+	event := &events.NotificationEvent{
+		ID:        events.GenerateEventID(),
+		Type:      events.EventExtensionRequested,
+		Timestamp: time.Now(),
+		Source:    "assessment-service",
+		Version:   "1.0",
+		Data: events.ExtensionRequestedEvent{
+			RequestID:        request.ID,
+			AssessmentID:     request.AssessmentID,
+			AssessmentTitle:  assessment.Title,
+			StudentID:        request.StudentID,
+			TeacherID:        assessment.CreatedBy,
+			Kind:             request.Kind,
+			Reason:           request.Reason,
+			RequestedDueDate: request.RequestedDueDate,
+			HasAttachment:    request.AttachmentPath != nil,
+		},
+	}
+
+	return s.publish(ctx, event, assessment.CreatedBy)
+}
+
+// NotifyExtensionDecided announces an approval, with the accommodation it granted, or a denial
+func (s *notificationEventService) NotifyExtensionDecided(ctx context.Context, request *models.ExtensionRequest, accommodation *models.StudentAccommodation) error {
+	s.logger.Info("Publishing extension decided event",
+		"request_id", request.ID,
+		"assessment_id", request.AssessmentID,
+		"status", request.Status)
+
+	// Get assessment details
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, request.AssessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	data := events.ExtensionDecidedEvent{
+		RequestID:       request.ID,
+		AssessmentID:    request.AssessmentID,
+		AssessmentTitle: assessment.Title,
+		StudentID:       request.StudentID,
+		TeacherID:       assessment.CreatedBy,
+		Kind:            request.Kind,
+		Status:          request.Status,
+		Note:            request.DecisionNote,
+	}
+	if request.DecidedBy != nil {
+		data.DecidedBy = *request.DecidedBy
+	}
+	if accommodation != nil {
+		data.DueDate = accommodation.DueDate
+		data.ExtraAttempts = accommodation.ExtraAttempts
+	}
+
+	// Create and publish event
+	event := &events.NotificationEvent{
+		ID:        events.GenerateEventID(),
+		Type:      events.EventExtensionDecided,
+		Timestamp: time.Now(),
+		Source:    "assessment-service",
+		Version:   "1.0",
+		Data:      data,
+	}
+
+	return s.publish(ctx, event, request.StudentID)
 }
 
 // ===== GRADING NOTIFICATIONS =====
@@ -306,7 +474,7 @@ func (s *notificationEventService) NotifyGradingCompleted(ctx context.Context, a
 		},
 	}
 
-	return s.eventPublisher.PublishNotificationEvent(ctx, event)
+	return s.publish(ctx, event, assessment.CreatedBy)
 }
 
 func (s *notificationEventService) NotifyManualGradingRequired(ctx context.Context, assessmentID uint, questionCount int) error {
@@ -344,7 +512,82 @@ func (s *notificationEventService) NotifyManualGradingRequired(ctx context.Conte
 		},
 	}
 
-	return s.eventPublisher.PublishNotificationEvent(ctx, event)
+	return s.publish(ctx, event, append(graderIDs, assessment.CreatedBy)...)
+}
+
+func (s *notificationEventService) NotifyResultsReleased(ctx context.Context, assessmentID uint, releasedBy string) error {
+	s.logger.Info("Publishing results released event",
+		"assessment_id", assessmentID,
+		"released_by", releasedBy)
+
+	// Get assessment details
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	// Everyone who attempted the assessment can now see their results
+	studentIDs, err := s.repo.Attempt().GetStudentIDsByAssessment(ctx, nil, assessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get students: %w", err)
+	}
+
+	// Create and publish event
+	event := &events.NotificationEvent{
+		ID:        events.GenerateEventID(),
+		Type:      events.EventResultsReleased,
+		Timestamp: time.Now(),
+		Source:    "assessment-service",
+		Version:   "1.0",
+		Data: events.ResultsReleasedEvent{
+			AssessmentID:    assessmentID,
+			AssessmentTitle: assessment.Title,
+			ReleasedAt:      time.Now(),
+			ReleasedBy:      releasedBy,
+			StudentIDs:      studentIDs,
+		},
+	}
+
+	return s.publish(ctx, event, studentIDs...)
+}
+
+// ===== QUESTION BANK NOTIFICATIONS =====
+
+func (s *notificationEventService) NotifyQuestionsReviewDue(ctx context.Context, report *StaleQuestionReport) error {
+	s.logger.Info("Publishing questions review due event",
+		"bank_id", report.BankID,
+		"owner_id", report.OwnerID,
+		"questions", len(report.Questions))
+
+	questions := make([]events.ReviewDueQuestion, 0, len(report.Questions))
+	for _, question := range report.Questions {
+		questions = append(questions, events.ReviewDueQuestion{
+			QuestionID:        question.QuestionID,
+			Text:              question.Text,
+			Reasons:           question.Reasons,
+			LastReviewedAt:    question.LastReviewedAt,
+			ReviewDueAt:       question.ReviewDueAt,
+			RecentCorrectRate: question.RecentCorrectRate,
+			PriorCorrectRate:  question.PriorCorrectRate,
+		})
+	}
+
+	// Create and publish event
+	event := &events.NotificationEvent{
+		ID:        events.GenerateEventID(),
+		Type:      events.EventQuestionsReviewDue,
+		Timestamp: time.Now(),
+		Source:    "assessment-service",
+		Version:   "1.0",
+		Data: events.QuestionsReviewDueEvent{
+			BankID:    report.BankID,
+			BankName:  report.BankName,
+			OwnerID:   report.OwnerID,
+			Questions: questions,
+		},
+	}
+
+	return s.publish(ctx, event, report.OwnerID)
 }
 
 // ===== SYSTEM NOTIFICATIONS =====
@@ -376,10 +619,13 @@ func (s *notificationEventService) SendBulkNotification(ctx context.Context, use
 // For now, they return placeholder data
 
 func (s *notificationEventService) getEnrolledStudentIDs(ctx context.Context, assessmentID uint) []string {
-	// TODO: Implement based on your enrollment/class management system
-	// This might involve querying a separate enrollment service or database table
 	s.logger.Debug("Getting enrolled student IDs", "assessment_id", assessmentID)
-	return []string{} // Placeholder
+	studentIDs, err := s.repo.Enrollment().GetStudentIDs(ctx, nil, assessmentID)
+	if err != nil {
+		s.logger.Error("Failed to get enrolled students", "assessment_id", assessmentID, "error", err)
+		return []string{}
+	}
+	return studentIDs
 }
 
 func (s *notificationEventService) getStudentsWithIncompleteAssessment(ctx context.Context, assessmentID uint) []string {
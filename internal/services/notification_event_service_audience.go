@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/events"
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+const (
+	// Recipient IDs shown in an audience preview
+	audiencePreviewSampleSize = 10
+	// Recipients per published bulk notification event
+	audienceNotificationBatchSize = 1000
+)
+
+// AudienceType selects how bulk notification recipients are found
+type AudienceType string
+
+const (
+	AudienceIncompleteAttempts AudienceType = "incomplete_attempts" // Students with an attempt in progress on an assessment
+	AudienceGradingBacklog     AudienceType = "grading_backlog"     // Graders with more than a number of answers waiting
+	AudienceGroup              AudienceType = "group"               // Members of a group, such as a class
+)
+
+// NotificationAudience is a filter resolved to recipients when the notification is sent
+type NotificationAudience struct {
+	Type         AudienceType `json:"type" validate:"required,oneof=incomplete_attempts grading_backlog group"`
+	AssessmentID uint         `json:"assessment_id,omitempty"` // incomplete_attempts
+	MinBacklog   int          `json:"min_backlog,omitempty"`   // grading_backlog: more than this many answers waiting
+	Group        string       `json:"group,omitempty"`         // group
+}
+
+type AudienceNotificationRequest struct {
+	Audiences    []NotificationAudience `json:"audiences" validate:"required,min=1,max=10,dive"`
+	Notification NotificationRequest    `json:"notification"`
+}
+
+// AudiencePreview shows who a notification reaches; recipients in several audiences count once
+type AudiencePreview struct {
+	Audiences          []AudienceCount `json:"audiences"`
+	RecipientCount     int             `json:"recipient_count"`
+	SampleRecipientIDs []string        `json:"sample_recipient_ids"`
+}
+
+type AudienceCount struct {
+	Audience       NotificationAudience `json:"audience"`
+	RecipientCount int                  `json:"recipient_count"`
+}
+
+// ===== AUDIENCE NOTIFICATIONS =====
+
+// PreviewAudience counts the recipients the audiences resolve to, without sending anything
+func (s *notificationEventService) PreviewAudience(ctx context.Context, audiences []NotificationAudience, userID string) (*AudiencePreview, error) {
+	preview, _, err := s.resolveAudiences(ctx, audiences, userID)
+	return preview, err
+}
+
+// SendAudienceNotification resolves the audiences server-side and notifies every recipient once
+func (s *notificationEventService) SendAudienceNotification(ctx context.Context, req *AudienceNotificationRequest, userID string) (*AudiencePreview, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Notification.Title) == "" {
+		return nil, NewValidationError("notification.title", "title is required", req.Notification.Title)
+	}
+	if strings.TrimSpace(req.Notification.Message) == "" {
+		return nil, NewValidationError("notification.message", "message is required", req.Notification.Message)
+	}
+
+	preview, recipients, err := s.resolveAudiences(ctx, req.Audiences, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Publishing audience notification events",
+		"sender_id", userID,
+		"audiences", len(req.Audiences),
+		"recipient_count", len(recipients),
+		"notification_type", req.Notification.Type)
+
+	notification := req.Notification
+	for _, batch := range chunkStrings(recipients, audienceNotificationBatchSize) {
+		// Recipients who turned off low-priority announcements are left out of the batch
+		deliveries := s.deliveries(ctx, events.EventBulkNotification, notification.Priority, batch)
+		if len(deliveries) == 0 {
+			continue
+		}
+		batch = make([]string, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			batch = append(batch, delivery.UserID)
+		}
+
+		event := &events.NotificationEvent{
+			ID:        events.GenerateEventID(),
+			Type:      events.EventBulkNotification,
+			Timestamp: time.Now(),
+			Source:    "assessment-service",
+			Version:   "1.0",
+			Data: events.BulkNotificationEvent{
+				RecipientUserIDs: batch,
+				Type:             notification.Type,
+				Title:            notification.Title,
+				Message:          notification.Message,
+				Priority:         notification.Priority,
+				ActionURL:        notification.ActionURL,
+				Metadata:         notification.Metadata,
+				ScheduledAt:      notification.ScheduledAt,
+				SenderID:         userID,
+			},
+			Deliveries: deliveries,
+		}
+		if err := s.eventPublisher.PublishNotificationEvent(ctx, event); err != nil {
+			return nil, fmt.Errorf("failed to publish audience notification: %w", err)
+		}
+	}
+
+	return preview, nil
+}
+
+// ===== HELPER METHODS =====
+
+// resolveAudiences returns the preview and the distinct recipients, sorted
+func (s *notificationEventService) resolveAudiences(ctx context.Context, audiences []NotificationAudience, userID string) (*AudiencePreview, []string, error) {
+	if len(audiences) == 0 {
+		return nil, nil, NewValidationError("audiences", "at least one audience is required", nil)
+	}
+
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	preview := &AudiencePreview{Audiences: make([]AudienceCount, 0, len(audiences))}
+	resolved := make([][]string, 0, len(audiences))
+	for _, audience := range audiences {
+		if err := s.checkAudienceAccess(ctx, audience, user); err != nil {
+			return nil, nil, err
+		}
+		ids, err := s.resolveAudience(ctx, audience)
+		if err != nil {
+			return nil, nil, err
+		}
+		resolved = append(resolved, ids)
+		preview.Audiences = append(preview.Audiences, AudienceCount{Audience: audience, RecipientCount: len(ids)})
+	}
+
+	recipients := mergeRecipients(resolved)
+	preview.RecipientCount = len(recipients)
+	preview.SampleRecipientIDs = recipients
+	if len(recipients) > audiencePreviewSampleSize {
+		preview.SampleRecipientIDs = recipients[:audiencePreviewSampleSize]
+	}
+	return preview, recipients, nil
+}
+
+func (s *notificationEventService) resolveAudience(ctx context.Context, audience NotificationAudience) ([]string, error) {
+	switch audience.Type {
+	case AudienceIncompleteAttempts:
+		if audience.AssessmentID == 0 {
+			return nil, NewValidationError("assessment_id", "assessment_id is required for this audience", audience.AssessmentID)
+		}
+		ids, err := s.repo.Attempt().GetStudentIDsWithIncompleteAttempts(ctx, nil, audience.AssessmentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve students with incomplete attempts: %w", err)
+		}
+		return ids, nil
+	case AudienceGradingBacklog:
+		if audience.MinBacklog < 0 {
+			return nil, NewValidationError("min_backlog", "min_backlog cannot be negative", audience.MinBacklog)
+		}
+		backlogs, err := s.repo.Answer().GetGraderBacklogs(ctx, nil, audience.MinBacklog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve graders with a backlog: %w", err)
+		}
+		ids := make([]string, 0, len(backlogs))
+		for graderID := range backlogs {
+			ids = append(ids, graderID)
+		}
+		return ids, nil
+	case AudienceGroup:
+		group := strings.TrimSpace(audience.Group)
+		if group == "" {
+			return nil, NewValidationError("group", "group is required for this audience", audience.Group)
+		}
+		ids, err := s.repo.User().GetIDsByGroup(ctx, group)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve group members: %w", err)
+		}
+		return ids, nil
+	default:
+		return nil, NewValidationError("type", "unknown audience type", audience.Type)
+	}
+}
+
+// checkAudienceAccess lets admins target anyone; teachers may only reach students taking
+// assessments they created
+func (s *notificationEventService) checkAudienceAccess(ctx context.Context, audience NotificationAudience, user *models.User) error {
+	if user.Role == models.RoleAdmin {
+		return nil
+	}
+	if user.Role == models.RoleTeacher && audience.Type == AudienceIncompleteAttempts && audience.AssessmentID != 0 {
+		assessment, err := s.repo.Assessment().GetByID(ctx, nil, audience.AssessmentID)
+		if err != nil {
+			return fmt.Errorf("failed to get assessment: %w", err)
+		}
+		if assessment.CreatedBy == user.ID {
+			return nil
+		}
+	}
+	return NewPermissionError(user.ID, audience.AssessmentID, "notification", "send_bulk", "audience not allowed for this user")
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// mergeRecipients returns the distinct recipients of all audiences, sorted
+func mergeRecipients(audiences [][]string) []string {
+	seen := make(map[string]bool)
+	recipients := make([]string, 0)
+	for _, ids := range audiences {
+		for _, id := range ids {
+			if id == "" || seen[id] {
+				continue
+			}
+			seen[id] = true
+			recipients = append(recipients, id)
+		}
+	}
+	sort.Strings(recipients)
+	return recipients
+}
+
+func chunkStrings(values []string, size int) [][]string {
+	var chunks [][]string
+	for start := 0; start < len(values); start += size {
+		end := start + size
+		if end > len(values) {
+			end = len(values)
+		}
+		chunks = append(chunks, values[start:end])
+	}
+	return chunks
+}
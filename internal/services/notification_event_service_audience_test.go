@@ -0,0 +1,40 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeRecipientsCountsEachRecipientOnce(t *testing.T) {
+	got := mergeRecipients([][]string{
+		{"student-2", "student-1", ""},
+		{"teacher-1", "student-1"},
+		nil,
+	})
+
+	want := []string{"student-1", "student-2", "teacher-1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestMergeRecipientsEmpty(t *testing.T) {
+	got := mergeRecipients(nil)
+	if got == nil || len(got) != 0 {
+		t.Errorf("expected an empty, non-nil list, got %#v", got)
+	}
+}
+
+func TestChunkStrings(t *testing.T) {
+	values := []string{"a", "b", "c", "d", "e"}
+
+	chunks := chunkStrings(values, 2)
+	want := [][]string{{"a", "b"}, {"c", "d"}, {"e"}}
+	if !reflect.DeepEqual(chunks, want) {
+		t.Errorf("expected %v, got %v", want, chunks)
+	}
+
+	if chunks := chunkStrings(nil, 2); len(chunks) != 0 {
+		t.Errorf("expected no chunks for no values, got %v", chunks)
+	}
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/events"
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// NotificationTypeInfo describes a notification type for the settings screen
+type NotificationTypeInfo struct {
+	Type            events.EventType            `json:"type"`
+	Priority        models.NotificationPriority `json:"priority"`
+	DefaultChannels []string                    `json:"default_channels"`
+	CanOptOut       bool                        `json:"can_opt_out"`
+	// Urgent notifications are delivered during quiet hours too
+	Urgent bool `json:"urgent"`
+}
+
+// NotificationPreferences are a user's effective preferences
+type NotificationPreferences struct {
+	Channels        map[events.EventType][]string `json:"channels"`
+	QuietHoursStart *string                       `json:"quiet_hours_start"`
+	QuietHoursEnd   *string                       `json:"quiet_hours_end"`
+	Timezone        string                        `json:"timezone"`
+	Types           []NotificationTypeInfo        `json:"types"`
+}
+
+// UpdateNotificationPreferencesRequest replaces the user's preferences. Types left out of
+// Channels go back to their default channels; an empty list opts out of a low-priority type.
+type UpdateNotificationPreferencesRequest struct {
+	Channels        map[events.EventType][]string `json:"channels"`
+	QuietHoursStart *string                       `json:"quiet_hours_start"`
+	QuietHoursEnd   *string                       `json:"quiet_hours_end"`
+	Timezone        string                        `json:"timezone"`
+}
+
+var notificationChannels = []string{models.NotificationChannelInApp, models.NotificationChannelPush, models.NotificationChannelEmail}
+
+// notificationTypes are the types users can set preferences for
+var notificationTypes = map[events.EventType]NotificationTypeInfo{
+	events.EventAssessmentPublished:    {Priority: models.PriorityNormal, DefaultChannels: []string{models.NotificationChannelInApp, models.NotificationChannelPush, models.NotificationChannelEmail}},
+	events.EventAssessmentExpiring:     {Priority: models.PriorityHigh, DefaultChannels: []string{models.NotificationChannelInApp, models.NotificationChannelPush, models.NotificationChannelEmail}},
+	events.EventAssessmentExpired:      {Priority: models.PriorityLow, DefaultChannels: []string{models.NotificationChannelInApp}},
+	events.EventQuestionFlagsEscalated: {Priority: models.PriorityCritical, DefaultChannels: []string{models.NotificationChannelInApp, models.NotificationChannelPush}},
+	events.EventAttemptStarted:         {Priority: models.PriorityLow, DefaultChannels: []string{models.NotificationChannelInApp}},
+	events.EventAttemptSubmitted:       {Priority: models.PriorityLow, DefaultChannels: []string{models.NotificationChannelInApp, models.NotificationChannelEmail}},
+	events.EventAttemptGraded:          {Priority: models.PriorityNormal, DefaultChannels: []string{models.NotificationChannelInApp, models.NotificationChannelPush, models.NotificationChannelEmail}},
+	events.EventAttemptTimeWarning:     {Priority: models.PriorityCritical, DefaultChannels: []string{models.NotificationChannelInApp, models.NotificationChannelPush}},
+	events.EventAttemptSlotOpened:      {Priority: models.PriorityHigh, DefaultChannels: []string{models.NotificationChannelInApp, models.NotificationChannelPush, models.NotificationChannelEmail}},
+	events.EventExtensionRequested:     {Priority: models.PriorityNormal, DefaultChannels: []string{models.NotificationChannelInApp, models.NotificationChannelEmail}},
+	events.EventExtensionDecided:       {Priority: models.PriorityNormal, DefaultChannels: []string{models.NotificationChannelInApp, models.NotificationChannelPush, models.NotificationChannelEmail}},
+	events.EventGradingCompleted:       {Priority: models.PriorityLow, DefaultChannels: []string{models.NotificationChannelInApp}},
+	events.EventManualGradingRequired:  {Priority: models.PriorityNormal, DefaultChannels: []string{models.NotificationChannelInApp, models.NotificationChannelEmail}},
+	events.EventResultsReleased:        {Priority: models.PriorityNormal, DefaultChannels: []string{models.NotificationChannelInApp, models.NotificationChannelPush, models.NotificationChannelEmail}},
+	events.EventQuestionsReviewDue:     {Priority: models.PriorityLow, DefaultChannels: []string{models.NotificationChannelInApp, models.NotificationChannelEmail}},
+	events.EventBulkNotification:       {Priority: models.PriorityNormal, DefaultChannels: []string{models.NotificationChannelInApp, models.NotificationChannelPush}},
+}
+
+// ===== PREFERENCES =====
+
+func (s *notificationEventService) GetNotificationPreferences(ctx context.Context, userID string) (*NotificationPreferences, error) {
+	preference, err := s.repo.NotificationPreference().GetByUser(ctx, nil, userID)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	return effectivePreferences(preference), nil
+}
+
+func (s *notificationEventService) UpdateNotificationPreferences(ctx context.Context, req *UpdateNotificationPreferencesRequest, userID string) (*NotificationPreferences, error) {
+	s.logger.Info("Updating notification preferences", "user_id", userID)
+
+	preference, err := buildNotificationPreference(req, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.NotificationPreference().Upsert(ctx, nil, preference); err != nil {
+		return nil, err
+	}
+	return effectivePreferences(preference), nil
+}
+
+// ===== DISPATCH =====
+
+// publish publishes the event with a delivery for each recipient that honours their
+// preferences. If every recipient opted out there is nothing to publish. Preferences that
+// cannot be loaded are logged and the defaults used, so notifications are never lost.
+func (s *notificationEventService) publish(ctx context.Context, event *events.NotificationEvent, recipientIDs ...string) error {
+	deliveries := s.deliveries(ctx, event.Type, notificationTypes[event.Type].Priority, recipientIDs)
+	if len(recipientIDs) > 0 && len(deliveries) == 0 {
+		s.logger.Info("All recipients opted out of notification", "type", event.Type, "event_id", event.ID)
+		return nil
+	}
+	event.Deliveries = deliveries
+	return s.eventPublisher.PublishNotificationEvent(ctx, event)
+}
+
+func (s *notificationEventService) deliveries(ctx context.Context, eventType events.EventType, priority models.NotificationPriority, recipientIDs []string) []events.NotificationDelivery {
+	recipientIDs = mergeRecipients([][]string{recipientIDs})
+	if len(recipientIDs) == 0 {
+		return nil
+	}
+
+	preferences, err := s.repo.NotificationPreference().GetByUsers(ctx, nil, recipientIDs)
+	if err != nil {
+		s.logger.Warn("Failed to load notification preferences, using defaults", "type", eventType, "error", err)
+		preferences = nil
+	}
+
+	now := time.Now()
+	deliveries := make([]events.NotificationDelivery, 0, len(recipientIDs))
+	for _, recipientID := range recipientIDs {
+		channels, deliverAfter := notificationDelivery(preferences[recipientID], eventType, priority, now)
+		if len(channels) == 0 {
+			continue
+		}
+		deliveries = append(deliveries, events.NotificationDelivery{
+			UserID:       recipientID,
+			Channels:     channels,
+			DeliverAfter: deliverAfter,
+		})
+	}
+	return deliveries
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// notificationDelivery returns the channels to notify a user on, none when they opted out,
+// and when to deliver if they are in their quiet hours. Only low-priority notifications can
+// be opted out of; urgent ones ignore quiet hours.
+func notificationDelivery(preference *models.NotificationPreference, eventType events.EventType, priority models.NotificationPriority, now time.Time) ([]string, *time.Time) {
+	channels := notificationTypes[eventType].DefaultChannels
+	if len(channels) == 0 {
+		channels = []string{models.NotificationChannelInApp}
+	}
+	if preference == nil {
+		return channels, nil
+	}
+
+	if chosen, ok := preferenceChannels(preference)[eventType]; ok && (len(chosen) > 0 || priority <= models.PriorityLow) {
+		channels = chosen
+	}
+	if len(channels) == 0 || priority >= models.PriorityCritical || preference.QuietHoursStart == nil || preference.QuietHoursEnd == nil {
+		return channels, nil
+	}
+
+	location, err := time.LoadLocation(preference.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	return channels, quietHoursEnd(now, *preference.QuietHoursStart, *preference.QuietHoursEnd, location)
+}
+
+// quietHoursEnd returns when the quiet hours now falls in end, nil outside them. The window
+// runs from start up to end in the location and may span midnight.
+func quietHoursEnd(now time.Time, start, end string, location *time.Location) *time.Time {
+	startMinute, okStart := parseClock(start)
+	endMinute, okEnd := parseClock(end)
+	if !okStart || !okEnd || startMinute == endMinute {
+		return nil
+	}
+
+	local := now.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	var inWindow bool
+	if startMinute < endMinute {
+		inWindow = minute >= startMinute && minute < endMinute
+	} else {
+		inWindow = minute >= startMinute || minute < endMinute
+	}
+	if !inWindow {
+		return nil
+	}
+
+	ends := time.Date(local.Year(), local.Month(), local.Day(), endMinute/60, endMinute%60, 0, 0, location)
+	if minute >= endMinute {
+		ends = ends.AddDate(0, 0, 1)
+	}
+	return &ends
+}
+
+// parseClock parses "HH:MM" into minutes past midnight
+func parseClock(value string) (int, bool) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil || len(value) != 5 {
+		return 0, false
+	}
+	return parsed.Hour()*60 + parsed.Minute(), true
+}
+
+func preferenceChannels(preference *models.NotificationPreference) map[events.EventType][]string {
+	channels := make(map[events.EventType][]string)
+	if preference == nil || len(preference.Channels) == 0 {
+		return channels
+	}
+	if err := json.Unmarshal(preference.Channels, &channels); err != nil {
+		return map[events.EventType][]string{}
+	}
+	return channels
+}
+
+func effectivePreferences(preference *models.NotificationPreference) *NotificationPreferences {
+	chosen := preferenceChannels(preference)
+	result := &NotificationPreferences{
+		Channels: make(map[events.EventType][]string, len(notificationTypes)),
+		Timezone: "UTC",
+		Types:    make([]NotificationTypeInfo, 0, len(notificationTypes)),
+	}
+	if preference != nil {
+		result.QuietHoursStart = preference.QuietHoursStart
+		result.QuietHoursEnd = preference.QuietHoursEnd
+		result.Timezone = preference.Timezone
+	}
+
+	for eventType, info := range notificationTypes {
+		channels, ok := chosen[eventType]
+		if !ok {
+			channels = info.DefaultChannels
+		}
+		result.Channels[eventType] = channels
+
+		info.Type = eventType
+		info.CanOptOut = info.Priority <= models.PriorityLow
+		info.Urgent = info.Priority >= models.PriorityCritical
+		result.Types = append(result.Types, info)
+	}
+	sort.Slice(result.Types, func(i, j int) bool {
+		return result.Types[i].Type < result.Types[j].Type
+	})
+	return result
+}
+
+// buildNotificationPreference validates the request and returns the preferences to store
+func buildNotificationPreference(req *UpdateNotificationPreferencesRequest, userID string) (*models.NotificationPreference, error) {
+	var errs ValidationErrors
+
+	channels := make(map[events.EventType][]string, len(req.Channels))
+	for eventType, chosen := range req.Channels {
+		info, ok := notificationTypes[eventType]
+		if !ok {
+			errs = append(errs, *NewValidationError("channels", "unknown notification type", eventType))
+			continue
+		}
+		if len(chosen) == 0 && info.Priority > models.PriorityLow {
+			errs = append(errs, *NewValidationError("channels", "only low-priority notifications can be turned off", eventType))
+			continue
+		}
+		deduped := make([]string, 0, len(chosen))
+		for _, channel := range chosen {
+			if !slices.Contains(notificationChannels, channel) {
+				errs = append(errs, *NewValidationError("channels", fmt.Sprintf("unknown channel, valid channels: %s", strings.Join(notificationChannels, ", ")), channel))
+				continue
+			}
+			if !slices.Contains(deduped, channel) {
+				deduped = append(deduped, channel)
+			}
+		}
+		channels[eventType] = deduped
+	}
+
+	if (req.QuietHoursStart == nil) != (req.QuietHoursEnd == nil) {
+		errs = append(errs, *NewValidationError("quiet_hours", "quiet hours need both a start and an end", nil))
+	}
+	if req.QuietHoursStart != nil && req.QuietHoursEnd != nil {
+		start, okStart := parseClock(*req.QuietHoursStart)
+		end, okEnd := parseClock(*req.QuietHoursEnd)
+		if !okStart {
+			errs = append(errs, *NewValidationError("quiet_hours_start", "must be HH:MM", *req.QuietHoursStart))
+		}
+		if !okEnd {
+			errs = append(errs, *NewValidationError("quiet_hours_end", "must be HH:MM", *req.QuietHoursEnd))
+		}
+		if okStart && okEnd && start == end {
+			errs = append(errs, *NewValidationError("quiet_hours_end", "quiet hours must not start and end at the same time", *req.QuietHoursEnd))
+		}
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		errs = append(errs, *NewValidationError("timezone", "unknown timezone", req.Timezone))
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	encoded, err := json.Marshal(channels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode channels: %w", err)
+	}
+	return &models.NotificationPreference{
+		UserID:          userID,
+		Channels:        encoded,
+		QuietHoursStart: req.QuietHoursStart,
+		QuietHoursEnd:   req.QuietHoursEnd,
+		Timezone:        timezone,
+	}, nil
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/events"
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestQuietHoursEnd(t *testing.T) {
+	day := func(hour, minute int) time.Time {
+		return time.Date(2025, 3, 10, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		now        time.Time
+		start, end string
+		want       *time.Time
+	}{
+		{"before daytime window", day(8, 59), "09:00", "17:00", nil},
+		{"inside daytime window", day(12, 0), "09:00", "17:00", timePtr(day(17, 0))},
+		{"end is exclusive", day(17, 0), "09:00", "17:00", nil},
+		{"overnight window before midnight", day(23, 30), "22:00", "07:00", timePtr(day(7, 0).AddDate(0, 0, 1))},
+		{"overnight window after midnight", day(6, 59), "22:00", "07:00", timePtr(day(7, 0))},
+		{"outside overnight window", day(12, 0), "22:00", "07:00", nil},
+		{"invalid clock", day(12, 0), "9:00", "17:00", nil},
+	}
+
+	for _, tt := range tests {
+		got := quietHoursEnd(tt.now, tt.start, tt.end, time.UTC)
+		if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestQuietHoursEndUsesTimezone(t *testing.T) {
+	location, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	if err != nil {
+		t.Skip("timezone data not available")
+	}
+	// 16:00 UTC is 23:00 in Ho Chi Minh City, inside 22:00-07:00 there
+	now := time.Date(2025, 3, 10, 16, 0, 0, 0, time.UTC)
+	got := quietHoursEnd(now, "22:00", "07:00", location)
+	want := time.Date(2025, 3, 11, 7, 0, 0, 0, location)
+	if got == nil || !got.Equal(want) {
+		t.Errorf("expected quiet hours to end at %v, got %v", want, got)
+	}
+}
+
+func TestNotificationDelivery(t *testing.T) {
+	now := time.Date(2025, 3, 10, 23, 0, 0, 0, time.UTC)
+	preference := &models.NotificationPreference{
+		Channels:        []byte(`{"attempt.started": [], "attempt.time_warning": [], "attempt.graded": ["email"]}`),
+		QuietHoursStart: stringPtr("22:00"),
+		QuietHoursEnd:   stringPtr("07:00"),
+		Timezone:        "UTC",
+	}
+
+	channels, after := notificationDelivery(nil, events.EventAttemptGraded, models.PriorityNormal, now)
+	if !slices.Equal(channels, notificationTypes[events.EventAttemptGraded].DefaultChannels) || after != nil {
+		t.Errorf("expected default channels without preferences, got %v %v", channels, after)
+	}
+
+	if channels, _ := notificationDelivery(preference, events.EventAttemptStarted, models.PriorityLow, now); len(channels) != 0 {
+		t.Errorf("expected a low-priority type to be opted out of, got %v", channels)
+	}
+
+	channels, after = notificationDelivery(preference, events.EventAttemptGraded, models.PriorityNormal, now)
+	if !slices.Equal(channels, []string{models.NotificationChannelEmail}) {
+		t.Errorf("expected the chosen channels, got %v", channels)
+	}
+	if after == nil || !after.Equal(time.Date(2025, 3, 11, 7, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected delivery to wait for quiet hours to end, got %v", after)
+	}
+
+	// Urgent notifications cannot be turned off and ignore quiet hours
+	channels, after = notificationDelivery(preference, events.EventAttemptTimeWarning, models.PriorityCritical, now)
+	if len(channels) == 0 || after != nil {
+		t.Errorf("expected an urgent notification to be delivered now, got %v %v", channels, after)
+	}
+}
+
+func TestBuildNotificationPreference(t *testing.T) {
+	preference, err := buildNotificationPreference(&UpdateNotificationPreferencesRequest{
+		Channels: map[events.EventType][]string{
+			events.EventGradingCompleted: {},
+			events.EventAttemptGraded:    {"push", "push", "email"},
+		},
+		QuietHoursStart: stringPtr("22:00"),
+		QuietHoursEnd:   stringPtr("07:00"),
+		Timezone:        "Europe/Berlin",
+	}, "student-1")
+	if err != nil {
+		t.Fatalf("expected valid preferences, got %v", err)
+	}
+	if got := preferenceChannels(preference)[events.EventAttemptGraded]; !slices.Equal(got, []string{"push", "email"}) {
+		t.Errorf("expected duplicate channels to be dropped, got %v", got)
+	}
+
+	invalid := []*UpdateNotificationPreferencesRequest{
+		{Channels: map[events.EventType][]string{events.EventAttemptGraded: {}}},
+		{Channels: map[events.EventType][]string{"attempt.unknown": {"push"}}},
+		{Channels: map[events.EventType][]string{events.EventAttemptGraded: {"sms"}}},
+		{QuietHoursStart: stringPtr("22:00")},
+		{QuietHoursStart: stringPtr("25:00"), QuietHoursEnd: stringPtr("07:00")},
+		{QuietHoursStart: stringPtr("07:00"), QuietHoursEnd: stringPtr("07:00")},
+		{Timezone: "Mars/Olympus"},
+	}
+	for i, req := range invalid {
+		var validationErrors ValidationErrors
+		if _, err := buildNotificationPreference(req, "student-1"); !errors.As(err, &validationErrors) {
+			t.Errorf("request %d: expected a validation error, got %v", i, err)
+		}
+	}
+}
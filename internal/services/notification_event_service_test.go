@@ -38,6 +38,114 @@ func (m *MockNotificationRepository) WithTransaction(ctx context.Context, fn fun
 }
 func (m *MockNotificationRepository) Ping(ctx context.Context) error { return nil }
 func (m *MockNotificationRepository) Close() error                   { return nil }
+func (m *MockNotificationRepository) AnswerReview() repositories.AnswerReviewRepository {
+	return nil
+}
+func (m *MockNotificationRepository) ReportSubscription() repositories.ReportSubscriptionRepository {
+	return nil
+}
+func (m *MockNotificationRepository) AuditLog() repositories.AuditLogRepository {
+	return nil
+}
+func (m *MockNotificationRepository) FeedbackComment() repositories.FeedbackCommentRepository {
+	return nil
+}
+func (m *MockNotificationRepository) ImportJob() repositories.ImportJobRepository {
+	return nil
+}
+func (m *MockNotificationRepository) Enrollment() repositories.EnrollmentRepository {
+	return nil
+}
+func (m *MockNotificationRepository) Extension() repositories.ExtensionRepository {
+	return nil
+}
+func (m *MockNotificationRepository) ShareLink() repositories.ShareLinkRepository {
+	return nil
+}
+func (m *MockNotificationRepository) QuestionAnalytics() repositories.QuestionAnalyticsRepository {
+	return nil
+}
+func (m *MockNotificationRepository) AnswerBuffer() repositories.AnswerBufferRepository {
+	return nil
+}
+func (m *MockNotificationRepository) MasteryTarget() repositories.MasteryTargetRepository {
+	return nil
+}
+func (m *MockNotificationRepository) Favorite() repositories.FavoriteRepository {
+	return nil
+}
+func (m *MockNotificationRepository) AnswerAttachment() repositories.AnswerAttachmentRepository {
+	return nil
+}
+func (m *MockNotificationRepository) Gradebook() repositories.GradebookRepository {
+	return nil
+}
+func (m *MockNotificationRepository) AssessmentAnalytics() repositories.AssessmentAnalyticsRepository {
+	return nil
+}
+func (m *MockNotificationRepository) AnswerAnnotation() repositories.AnswerAnnotationRepository {
+	return nil
+}
+func (m *MockNotificationRepository) QuestionTranslation() repositories.QuestionTranslationRepository {
+	return nil
+}
+func (m *MockNotificationRepository) QuestionExternalRef() repositories.QuestionExternalRefRepository {
+	return nil
+}
+func (m *MockNotificationRepository) AttemptQueue() repositories.AttemptQueueRepository {
+	return nil
+}
+func (m *MockNotificationRepository) AttemptQuota() repositories.AttemptQuotaRepository {
+	return nil
+}
+func (m *MockNotificationRepository) Job() repositories.JobRepository {
+	return nil
+}
+func (m *MockNotificationRepository) Practice() repositories.PracticeRepository {
+	return nil
+}
+func (m *MockNotificationRepository) AttemptNavigation() repositories.AttemptNavigationRepository {
+	return nil
+}
+func (m *MockNotificationRepository) OfflineBundle() repositories.OfflineBundleRepository {
+	return nil
+}
+func (m *MockNotificationRepository) Impersonation() repositories.ImpersonationRepository {
+	return nil
+}
+func (m *MockNotificationRepository) AnswerKeyChange() repositories.AnswerKeyChangeRepository {
+	return nil
+}
+func (m *MockNotificationRepository) FlagEscalation() repositories.FlagEscalationRepository {
+	return nil
+}
+func (m *MockNotificationRepository) GradingDelegation() repositories.GradingDelegationRepository {
+	return nil
+}
+func (m *MockNotificationRepository) QuestionTrial() repositories.QuestionTrialRepository {
+	return nil
+}
+func (m *MockNotificationRepository) Usage() repositories.UsageRepository {
+	return nil
+}
+func (m *MockNotificationRepository) Residency() repositories.ResidencyRepository {
+	return nil
+}
+func (m *MockNotificationRepository) CustomField() repositories.CustomFieldRepository {
+	return nil
+}
+func (m *MockNotificationRepository) Warehouse() repositories.WarehouseRepository {
+	return nil
+}
+func (m *MockNotificationRepository) ResearchPolicy() repositories.ResearchPolicyRepository {
+	return nil
+}
+func (m *MockNotificationRepository) Webhook() repositories.WebhookRepository {
+	return nil
+}
+func (m *MockNotificationRepository) NotificationPreference() repositories.NotificationPreferenceRepository {
+	return nil
+}
 
 func TestNotificationEventService_PublishEvents(t *testing.T) {
 	// Setup
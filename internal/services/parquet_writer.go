@@ -0,0 +1,342 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Parquet format constants used by the writer, from parquet.thrift
+const (
+	parquetMagic = "PAR1"
+
+	parquetBoolean   = 0
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetRequired = 0
+	parquetOptional = 1
+
+	parquetConvertedUTF8            = 0
+	parquetConvertedTimestampMillis = 9
+
+	parquetEncodingPlain = 0
+	parquetEncodingRLE   = 3
+	parquetUncompressed  = 0
+	parquetDataPage      = 0
+)
+
+// Thrift compact protocol type codes
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// parquetChunk locates one column's data page in the file
+type parquetChunk struct {
+	offset int64
+	size   int64
+}
+
+// writeParquet encodes rows as a Parquet file with a single row group holding one
+// uncompressed, PLAIN-encoded data page per column. Timestamps are stored as UTC
+// milliseconds. It covers the flat fact tables only, not nested or repeated columns.
+func writeParquet(table WarehouseTable, rows []WarehouseRow) ([]byte, error) {
+	var file bytes.Buffer
+	file.WriteString(parquetMagic)
+
+	chunks := make([]parquetChunk, len(table.Columns))
+	for i, column := range table.Columns {
+		page, err := encodeParquetPage(column, i, rows)
+		if err != nil {
+			return nil, err
+		}
+		header := parquetPageHeader(len(page), len(rows))
+
+		chunks[i] = parquetChunk{offset: int64(file.Len()), size: int64(len(header) + len(page))}
+		file.Write(header)
+		file.Write(page)
+	}
+
+	footer := parquetFooter(table, chunks, len(rows))
+	file.Write(footer)
+	file.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(footer))))
+	file.WriteString(parquetMagic)
+	return file.Bytes(), nil
+}
+
+// encodeParquetPage writes the definition levels of a nullable column followed by the
+// values that are not null
+func encodeParquetPage(column WarehouseColumn, index int, rows []WarehouseRow) ([]byte, error) {
+	var values []byte
+	var bits []bool
+	defined := make([]bool, 0, len(rows))
+
+	for _, row := range rows {
+		if index >= len(row) {
+			return nil, fmt.Errorf("row has no value for column %s", column.Name)
+		}
+		value := row[index]
+		if value == nil {
+			if !column.Nullable {
+				return nil, fmt.Errorf("column %s is not nullable", column.Name)
+			}
+			defined = append(defined, false)
+			continue
+		}
+		defined = append(defined, true)
+
+		ok := true
+		switch column.Type {
+		case WarehouseInt64:
+			var n int64
+			n, ok = value.(int64)
+			values = binary.LittleEndian.AppendUint64(values, uint64(n))
+		case WarehouseFloat64:
+			var f float64
+			f, ok = value.(float64)
+			values = binary.LittleEndian.AppendUint64(values, math.Float64bits(f))
+		case WarehouseString:
+			var s string
+			s, ok = value.(string)
+			values = binary.LittleEndian.AppendUint32(values, uint32(len(s)))
+			values = append(values, s...)
+		case WarehouseBool:
+			var b bool
+			b, ok = value.(bool)
+			bits = append(bits, b)
+		case WarehouseTimestamp:
+			var t time.Time
+			t, ok = value.(time.Time)
+			values = binary.LittleEndian.AppendUint64(values, uint64(t.UnixMilli()))
+		default:
+			return nil, fmt.Errorf("column %s has unsupported type %s", column.Name, column.Type)
+		}
+		if !ok {
+			return nil, fmt.Errorf("column %s expects %s, got %T", column.Name, column.Type, value)
+		}
+	}
+
+	// Booleans are bit-packed, least significant bit first
+	if column.Type == WarehouseBool {
+		values = make([]byte, (len(bits)+7)/8)
+		for i, b := range bits {
+			if b {
+				values[i/8] |= 1 << (i % 8)
+			}
+		}
+	}
+
+	var page bytes.Buffer
+	if column.Nullable {
+		levels := parquetDefinitionLevels(defined)
+		page.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(levels))))
+		page.Write(levels)
+	}
+	page.Write(values)
+	return page.Bytes(), nil
+}
+
+// parquetDefinitionLevels encodes 1-bit definition levels as runs of the RLE/bit-packing
+// hybrid encoding
+func parquetDefinitionLevels(defined []bool) []byte {
+	var out []byte
+	for start := 0; start < len(defined); {
+		end := start
+		for end < len(defined) && defined[end] == defined[start] {
+			end++
+		}
+		out = binary.AppendUvarint(out, uint64(end-start)<<1)
+		if defined[start] {
+			out = append(out, 1)
+		} else {
+			out = append(out, 0)
+		}
+		start = end
+	}
+	return out
+}
+
+func parquetPageHeader(size, rows int) []byte {
+	w := newThriftWriter()
+	w.i32(1, parquetDataPage)
+	w.i32(2, int32(size))
+	w.i32(3, int32(size))
+	w.structBegin(5)
+	w.i32(1, int32(rows))
+	w.i32(2, parquetEncodingPlain)
+	w.i32(3, parquetEncodingRLE)
+	w.i32(4, parquetEncodingRLE)
+	w.structEnd()
+	return w.finish()
+}
+
+func parquetFooter(table WarehouseTable, chunks []parquetChunk, rows int) []byte {
+	w := newThriftWriter()
+	w.i32(1, 1)
+
+	w.listBegin(2, thriftStruct, len(table.Columns)+1)
+	w.elementBegin()
+	w.binary(4, "schema")
+	w.i32(5, int32(len(table.Columns)))
+	w.structEnd()
+	for _, column := range table.Columns {
+		physical, converted := parquetColumnType(column.Type)
+		repetition := int32(parquetRequired)
+		if column.Nullable {
+			repetition = parquetOptional
+		}
+		w.elementBegin()
+		w.i32(1, physical)
+		w.i32(3, repetition)
+		w.binary(4, column.Name)
+		if converted >= 0 {
+			w.i32(6, converted)
+		}
+		w.structEnd()
+	}
+
+	w.i64(3, int64(rows))
+
+	var totalSize int64
+	for _, chunk := range chunks {
+		totalSize += chunk.size
+	}
+	w.listBegin(4, thriftStruct, 1)
+	w.elementBegin()
+	w.listBegin(1, thriftStruct, len(chunks))
+	for i, column := range table.Columns {
+		physical, _ := parquetColumnType(column.Type)
+		w.elementBegin()
+		w.i64(2, chunks[i].offset)
+		w.structBegin(3)
+		w.i32(1, physical)
+		w.listBegin(2, thriftI32, 2)
+		w.listI32(parquetEncodingPlain)
+		w.listI32(parquetEncodingRLE)
+		w.listBegin(3, thriftBinary, 1)
+		w.listBinary(column.Name)
+		w.i32(4, parquetUncompressed)
+		w.i64(5, int64(rows))
+		w.i64(6, chunks[i].size)
+		w.i64(7, chunks[i].size)
+		w.i64(9, chunks[i].offset)
+		w.structEnd()
+		w.structEnd()
+	}
+	w.i64(2, totalSize)
+	w.i64(3, int64(rows))
+	w.structEnd()
+
+	w.binary(6, "assessment-service")
+	return w.finish()
+}
+
+// parquetColumnType returns the physical and converted type of a column; -1 when it has no
+// converted type
+func parquetColumnType(columnType WarehouseColumnType) (int32, int32) {
+	switch columnType {
+	case WarehouseFloat64:
+		return parquetDouble, -1
+	case WarehouseString:
+		return parquetByteArray, parquetConvertedUTF8
+	case WarehouseBool:
+		return parquetBoolean, -1
+	case WarehouseTimestamp:
+		return parquetInt64, parquetConvertedTimestampMillis
+	}
+	return parquetInt64, -1
+}
+
+// ===== THRIFT COMPACT PROTOCOL =====
+
+// thriftWriter writes the Thrift compact protocol that Parquet metadata is stored in.
+// Fields must be written in increasing ID order within each struct.
+type thriftWriter struct {
+	buf       bytes.Buffer
+	lastField []int16 // Last field ID written, per open struct
+}
+
+func newThriftWriter() *thriftWriter {
+	return &thriftWriter{lastField: []int16{0}}
+}
+
+func (w *thriftWriter) fieldHeader(id int16, fieldType byte) {
+	last := &w.lastField[len(w.lastField)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		w.buf.WriteByte(byte(delta)<<4 | fieldType)
+	} else {
+		w.buf.WriteByte(fieldType)
+		w.varint(zigzag(int64(id)))
+	}
+	*last = id
+}
+
+func (w *thriftWriter) i32(id int16, value int32) {
+	w.fieldHeader(id, thriftI32)
+	w.varint(zigzag(int64(value)))
+}
+
+func (w *thriftWriter) i64(id int16, value int64) {
+	w.fieldHeader(id, thriftI64)
+	w.varint(zigzag(value))
+}
+
+func (w *thriftWriter) binary(id int16, value string) {
+	w.fieldHeader(id, thriftBinary)
+	w.listBinary(value)
+}
+
+func (w *thriftWriter) structBegin(id int16) {
+	w.fieldHeader(id, thriftStruct)
+	w.lastField = append(w.lastField, 0)
+}
+
+func (w *thriftWriter) structEnd() {
+	w.buf.WriteByte(0)
+	w.lastField = w.lastField[:len(w.lastField)-1]
+}
+
+func (w *thriftWriter) listBegin(id int16, elementType byte, size int) {
+	w.fieldHeader(id, thriftList)
+	if size < 15 {
+		w.buf.WriteByte(byte(size)<<4 | elementType)
+		return
+	}
+	w.buf.WriteByte(0xF0 | elementType)
+	w.varint(uint64(size))
+}
+
+// elementBegin starts a struct inside a list, which has no field header
+func (w *thriftWriter) elementBegin() {
+	w.lastField = append(w.lastField, 0)
+}
+
+func (w *thriftWriter) listI32(value int32) {
+	w.varint(zigzag(int64(value)))
+}
+
+func (w *thriftWriter) listBinary(value string) {
+	w.varint(uint64(len(value)))
+	w.buf.WriteString(value)
+}
+
+func (w *thriftWriter) varint(value uint64) {
+	w.buf.Write(binary.AppendUvarint(nil, value))
+}
+
+// finish closes the outermost struct
+func (w *thriftWriter) finish() []byte {
+	w.buf.WriteByte(0)
+	return w.buf.Bytes()
+}
+
+func zigzag(value int64) uint64 {
+	return uint64((value << 1) ^ (value >> 63))
+}
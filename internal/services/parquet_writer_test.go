@@ -0,0 +1,77 @@
+package services
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestWriteParquetLayout(t *testing.T) {
+	table := WarehouseTable{Name: "t_v1", Columns: []WarehouseColumn{
+		{Name: "id", Type: WarehouseInt64},
+		{Name: "name", Type: WarehouseString, Nullable: true},
+		{Name: "passed", Type: WarehouseBool},
+		{Name: "at", Type: WarehouseTimestamp},
+	}}
+	at := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	out, err := writeParquet(table, []WarehouseRow{
+		{int64(1), "alice", true, at},
+		{int64(2), nil, false, at},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(out, []byte("PAR1")) || !bytes.HasSuffix(out, []byte("PAR1")) {
+		t.Fatal("missing Parquet magic")
+	}
+
+	footerLength := int(binary.LittleEndian.Uint32(out[len(out)-8:]))
+	footer := out[len(out)-8-footerLength : len(out)-8]
+	for _, name := range []string{"schema", "id", "name", "passed", "at"} {
+		if !bytes.Contains(footer, []byte(name)) {
+			t.Errorf("footer does not describe column %s", name)
+		}
+	}
+}
+
+func TestWriteParquetRejectsBadValues(t *testing.T) {
+	table := WarehouseTable{Name: "t_v1", Columns: []WarehouseColumn{{Name: "id", Type: WarehouseInt64}}}
+
+	if _, err := writeParquet(table, []WarehouseRow{{nil}}); err == nil {
+		t.Error("expected a null in a required column to be rejected")
+	}
+	if _, err := writeParquet(table, []WarehouseRow{{"1"}}); err == nil {
+		t.Error("expected a string in an integer column to be rejected")
+	}
+}
+
+func TestParquetDefinitionLevels(t *testing.T) {
+	got := parquetDefinitionLevels([]bool{true, true, true, false, true})
+	want := []byte{3 << 1, 1, 1 << 1, 0, 1 << 1, 1}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestThriftWriter(t *testing.T) {
+	w := newThriftWriter()
+	w.i32(1, -1)
+	w.i64(3, 300)
+	w.structBegin(20)
+	w.binary(1, "a")
+	w.structEnd()
+
+	want := []byte{
+		0x15, 0x01, // field 1, i32, zigzag(-1)
+		0x26, 0xd8, 0x04, // field 3, i64, zigzag(300) as a varint
+		0x0c, 0x28, // field 20 is too far for a delta: type, then zigzag(20)
+		0x18, 0x01, 'a', // nested field 1, binary
+		0x00, // end of nested struct
+		0x00, // end of outer struct
+	}
+	if got := w.finish(); !bytes.Equal(got, want) {
+		t.Errorf("got % x, want % x", got, want)
+	}
+}
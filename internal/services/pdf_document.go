@@ -0,0 +1,179 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// A4 in points, with the margins used on every page
+const (
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+	pdfMargin     = 50.0
+	// Helvetica averages about half an em per character; wrapping on a slightly wider
+	// estimate keeps lines inside the margin
+	pdfCharWidth = 0.55
+)
+
+// pdfDocument lays out plain text on A4 pages using the standard Helvetica fonts, which
+// every PDF reader has, so nothing needs to be embedded. Text outside Windows-1252 is
+// replaced with "?".
+type pdfDocument struct {
+	pages [][]byte
+	page  bytes.Buffer
+	y     float64
+}
+
+func newPDFDocument() *pdfDocument {
+	return &pdfDocument{y: pdfPageHeight - pdfMargin}
+}
+
+// Heading writes a bold title line
+func (d *pdfDocument) Heading(text string) {
+	d.write(text, "F2", 15, 0)
+	d.Space(4)
+}
+
+// Subheading writes a bold section line
+func (d *pdfDocument) Subheading(text string) {
+	d.Space(6)
+	d.write(text, "F2", 11, 0)
+}
+
+// Paragraph writes wrapped text; line breaks in the text are kept
+func (d *pdfDocument) Paragraph(text string) {
+	d.write(text, "F1", 10, 0)
+}
+
+// Field writes a "label: value" line, wrapping the value under itself
+func (d *pdfDocument) Field(label, value string) {
+	d.write(label+": "+value, "F1", 10, 0)
+}
+
+// Indented writes wrapped text shifted right, for answers and feedback under a question
+func (d *pdfDocument) Indented(text string) {
+	d.write(text, "F1", 10, 16)
+}
+
+// Space leaves a vertical gap
+func (d *pdfDocument) Space(points float64) {
+	d.y -= points
+}
+
+// Bytes finishes the document
+func (d *pdfDocument) Bytes() []byte {
+	d.endPage()
+	if len(d.pages) == 0 {
+		d.pages = append(d.pages, nil)
+	}
+
+	var out bytes.Buffer
+	offsets := make([]int, 0, 4+2*len(d.pages))
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n")
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, content := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfPageWidth, pdfPageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+func (d *pdfDocument) write(text, font string, size, indent float64) {
+	lineHeight := size * 1.35
+	width := int((pdfPageWidth - 2*pdfMargin - indent) / (size * pdfCharWidth))
+	for _, line := range wrapText(text, width) {
+		if d.y-lineHeight < pdfMargin {
+			d.endPage()
+		}
+		d.y -= lineHeight
+		fmt.Fprintf(&d.page, "BT /%s %.0f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, pdfMargin+indent, d.y, pdfEscape(line))
+	}
+}
+
+func (d *pdfDocument) endPage() {
+	if d.page.Len() == 0 {
+		return
+	}
+	d.pages = append(d.pages, append([]byte(nil), d.page.Bytes()...))
+	d.page.Reset()
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// wrapText breaks text into lines of at most width characters at spaces, splitting words
+// longer than a line. Line breaks in the text are kept; an empty text is one empty line.
+func wrapText(text string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			for len([]rune(word)) > width {
+				if line != "" {
+					lines = append(lines, line)
+					line = ""
+				}
+				runes := []rune(word)
+				lines = append(lines, string(runes[:width]))
+				word = string(runes[width:])
+			}
+			switch {
+			case line == "":
+				line = word
+			case len([]rune(line))+1+len([]rune(word)) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// pdfEscape encodes text for a PDF string in WinAnsi encoding
+func pdfEscape(text string) string {
+	var out strings.Builder
+	for _, r := range text {
+		b, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok {
+			b = '?'
+		}
+		switch {
+		case b == '(' || b == ')' || b == '\\':
+			out.WriteByte('\\')
+			out.WriteByte(b)
+		case b < 0x20 || b > 0x7e:
+			fmt.Fprintf(&out, "\\%03o", b)
+		default:
+			out.WriteByte(b)
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPDFDocumentStructure(t *testing.T) {
+	doc := newPDFDocument()
+	doc.Heading("Midterm (Group A)")
+	doc.Paragraph("Hello")
+
+	out := doc.Bytes()
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) {
+		t.Fatalf("missing PDF header: %q", out[:16])
+	}
+	if !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Errorf("missing EOF marker")
+	}
+	if !bytes.Contains(out, []byte(`(Midterm \(Group A\)) Tj`)) {
+		t.Errorf("heading not written with escaped parentheses")
+	}
+	if !bytes.Contains(out, []byte("/Count 1")) {
+		t.Errorf("expected a single page")
+	}
+}
+
+func TestPDFDocumentBreaksPages(t *testing.T) {
+	doc := newPDFDocument()
+	for i := 0; i < 200; i++ {
+		doc.Paragraph("line")
+	}
+
+	if out := doc.Bytes(); !bytes.Contains(out, []byte("/Count 4")) {
+		t.Errorf("expected 200 lines to fill 4 pages")
+	}
+}
+
+func TestPDFDocumentEmpty(t *testing.T) {
+	if out := newPDFDocument().Bytes(); !bytes.Contains(out, []byte("/Count 1")) {
+		t.Errorf("an empty document should still have one page")
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"", 10, []string{""}},
+		{"short", 10, []string{"short"}},
+		{"the quick brown fox", 10, []string{"the quick", "brown fox"}},
+		{"abcdefghijkl", 5, []string{"abcde", "fghij", "kl"}},
+		{"one\ntwo", 10, []string{"one", "two"}},
+		{"a\n\nb", 10, []string{"a", "", "b"}},
+	}
+
+	for _, tt := range tests {
+		got := wrapText(tt.text, tt.width)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("wrapText(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestPDFEscape(t *testing.T) {
+	tests := map[string]string{
+		`a(b)c\d`: `a\(b\)c\\d`,
+		"café":    `caf\351`,
+		"€":       `\200`,
+		"日本":      "??",
+	}
+
+	for in, want := range tests {
+		if got := pdfEscape(in); got != want {
+			t.Errorf("pdfEscape(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
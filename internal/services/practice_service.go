@@ -0,0 +1,592 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	rulePracticeLicensedBank    = "practice_licensed_bank"
+	rulePracticeSetEmpty        = "practice_set_empty"
+	rulePracticeAlreadyAnswered = "practice_question_answered"
+)
+
+type practiceService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewPracticeService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) PracticeService {
+	return &practiceService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// ===== PRACTICE SETS =====
+
+func (s *practiceService) CreateSet(ctx context.Context, bankID uint, req *CreatePracticeSetRequest, userID string) (*models.PracticeSet, error) {
+	s.logger.Info("Creating practice set", "bank_id", bankID, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if err := s.requireBankEditor(ctx, bankID, userID, "create_practice_set"); err != nil {
+		return nil, err
+	}
+
+	questionIDs, err := s.checkPracticeQuestions(ctx, bankID, req.QuestionIDs, req.QuestionsPerQuiz)
+	if err != nil {
+		return nil, err
+	}
+
+	set := &models.PracticeSet{
+		BankID:           bankID,
+		Title:            strings.TrimSpace(req.Title),
+		Description:      req.Description,
+		QuestionIDs:      questionIDs,
+		QuestionsPerQuiz: req.QuestionsPerQuiz,
+		IsActive:         true,
+		CreatedBy:        userID,
+	}
+	if req.ClassID != nil {
+		set.ClassID = quotaClassID(*req.ClassID)
+	}
+	if err := s.repo.Practice().CreateSet(ctx, nil, set); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// UpdateSet changes a practice set. Sessions already started keep the questions they drew.
+func (s *practiceService) UpdateSet(ctx context.Context, id uint, req *UpdatePracticeSetRequest, userID string) (*models.PracticeSet, error) {
+	s.logger.Info("Updating practice set", "practice_set_id", id, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	set, err := s.getSet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireBankEditor(ctx, set.BankID, userID, "update_practice_set"); err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		set.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Description != nil {
+		set.Description = req.Description
+	}
+	if req.ClassID != nil {
+		set.ClassID = quotaClassID(*req.ClassID)
+	}
+	if req.IsActive != nil {
+		set.IsActive = *req.IsActive
+	}
+	if req.QuestionIDs != nil || req.QuestionsPerQuiz != nil {
+		questionIDs := req.QuestionIDs
+		if questionIDs == nil {
+			questionIDs = practiceQuestionIDs(set.QuestionIDs)
+		}
+		if req.QuestionsPerQuiz != nil {
+			set.QuestionsPerQuiz = *req.QuestionsPerQuiz
+		}
+		if set.QuestionIDs, err = s.checkPracticeQuestions(ctx, set.BankID, questionIDs, set.QuestionsPerQuiz); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.Practice().UpdateSet(ctx, nil, set); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+func (s *practiceService) ListBankSets(ctx context.Context, bankID uint, userID string) ([]*models.PracticeSet, error) {
+	if err := s.requireBankEditor(ctx, bankID, userID, "list_practice_sets"); err != nil {
+		return nil, err
+	}
+	return s.repo.Practice().ListSets(ctx, nil, &bankID, false)
+}
+
+// GetSetStats sums up the practice sessions taken from a set. Practice is kept out of the
+// assessment and question analytics, so this is the only place it shows up.
+func (s *practiceService) GetSetStats(ctx context.Context, id uint, userID string) (*PracticeSetStats, error) {
+	set, err := s.getSet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireBankEditor(ctx, set.BankID, userID, "view_practice_stats"); err != nil {
+		return nil, err
+	}
+
+	totals, err := s.repo.Practice().GetSetStats(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.repo.Practice().GetQuestionStats(ctx, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	return summarizePracticeStats(set, totals, questions), nil
+}
+
+// ===== SESSIONS =====
+
+// ListAvailableSets returns the active practice sets open to the student's classes
+func (s *practiceService) ListAvailableSets(ctx context.Context, studentID string) ([]*models.PracticeSet, error) {
+	sets, err := s.repo.Practice().ListSets(ctx, nil, nil, true)
+	if err != nil || len(sets) == 0 {
+		return nil, err
+	}
+	classes, err := s.studentClasses(ctx, studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	open := make([]*models.PracticeSet, 0, len(sets))
+	for _, set := range sets {
+		if practiceSetOpenTo(set, classes) {
+			open = append(open, set)
+		}
+	}
+	return open, nil
+}
+
+// StartSession draws a short quiz from the set's questions. Students may start as many
+// sessions as they like.
+func (s *practiceService) StartSession(ctx context.Context, setID uint, studentID string) (*PracticeSessionResponse, error) {
+	s.logger.Info("Starting practice session", "practice_set_id", setID, "student_id", studentID)
+
+	set, err := s.getSet(ctx, setID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireOpenSet(ctx, set, studentID); err != nil {
+		return nil, err
+	}
+
+	// Questions deleted or changed since the set was curated are skipped
+	questions, err := s.repo.Question().GetByIDs(ctx, nil, practiceQuestionIDs(set.QuestionIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	pool := make([]uint, 0, len(questions))
+	byID := make(map[uint]*models.Question, len(questions))
+	for _, question := range questions {
+		if practiceIneligibility(question) == "" {
+			pool = append(pool, question.ID)
+			byID[question.ID] = question
+		}
+	}
+	if len(pool) == 0 {
+		return nil, NewBusinessRuleError(rulePracticeSetEmpty, "practice set has no questions left to practice", map[string]interface{}{
+			"practice_set_id": set.ID,
+		})
+	}
+
+	rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	drawn := pool
+	if len(drawn) > set.QuestionsPerQuiz {
+		drawn = drawn[:set.QuestionsPerQuiz]
+	}
+
+	encoded, err := json.Marshal(drawn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode practice questions: %w", err)
+	}
+	session := &models.PracticeSession{
+		PracticeSetID: set.ID,
+		StudentID:     studentID,
+		QuestionIDs:   encoded,
+	}
+	if err := s.repo.Practice().CreateSession(ctx, nil, session); err != nil {
+		return nil, err
+	}
+
+	served := make([]*models.Question, 0, len(drawn))
+	for _, id := range drawn {
+		served = append(served, byID[id])
+	}
+	return buildPracticeSessionResponse(set, session, served, nil), nil
+}
+
+func (s *practiceService) GetSession(ctx context.Context, id uint, studentID string) (*PracticeSessionResponse, error) {
+	session, err := s.getOwnSession(ctx, id, studentID)
+	if err != nil {
+		return nil, err
+	}
+	set, err := s.getSet(ctx, session.PracticeSetID)
+	if err != nil {
+		return nil, err
+	}
+
+	questionIDs := practiceQuestionIDs(session.QuestionIDs)
+	questions, err := s.repo.Question().GetByIDs(ctx, nil, questionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	slices.SortFunc(questions, func(a, b *models.Question) int {
+		return slices.Index(questionIDs, a.ID) - slices.Index(questionIDs, b.ID)
+	})
+	answers, err := s.repo.Practice().GetAnswers(ctx, nil, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	return buildPracticeSessionResponse(set, session, questions, answers), nil
+}
+
+// AnswerQuestion grades an answer straight away and returns the feedback, the explanation and
+// the correct answer. Each question of a session is answered once.
+func (s *practiceService) AnswerQuestion(ctx context.Context, sessionID uint, req *PracticeAnswerRequest, studentID string) (*PracticeAnswerResult, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	session, err := s.getOwnSession(ctx, sessionID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	questionIDs := practiceQuestionIDs(session.QuestionIDs)
+	if !slices.Contains(questionIDs, req.QuestionID) {
+		return nil, ValidationErrors{*NewValidationError("question_id", "question is not part of this practice session", req.QuestionID)}
+	}
+
+	question, err := s.repo.Question().GetByID(ctx, nil, req.QuestionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	answerData, err := json.Marshal(req.AnswerData)
+	if err != nil {
+		return nil, ValidationErrors{*NewValidationError("answer_data", "answer cannot be encoded", nil)}
+	}
+	graded, err := NewGradingService(s.db, s.repo, s.logger, s.validator).GradeSampleAnswer(ctx, question, answerData)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		answers, err := s.repo.Practice().GetAnswers(ctx, tx, session.ID)
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(answers, func(a *models.PracticeAnswer) bool { return a.QuestionID == req.QuestionID }) {
+			return NewBusinessRuleError(rulePracticeAlreadyAnswered, "question was already answered in this practice session; start a new session to try again", map[string]interface{}{
+				"session_id":  session.ID,
+				"question_id": req.QuestionID,
+			})
+		}
+
+		answer := &models.PracticeAnswer{
+			SessionID:  session.ID,
+			QuestionID: req.QuestionID,
+			Answer:     answerData,
+			Score:      graded.Score,
+			MaxScore:   graded.MaxScore,
+			IsCorrect:  graded.IsCorrect,
+		}
+		if err := s.repo.Practice().CreateAnswer(ctx, tx, answer); err != nil {
+			return err
+		}
+		applyPracticeAnswer(session, answer, len(questionIDs), time.Now())
+		return s.repo.Practice().UpdateSession(ctx, tx, session)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PracticeAnswerResult{
+		SampleGradingResult: graded,
+		Explanation:         question.Explanation,
+		CorrectAnswer:       question.Answer,
+		Session:             session,
+	}, nil
+}
+
+// ListSessions returns the student's practice history, newest first
+func (s *practiceService) ListSessions(ctx context.Context, setID *uint, studentID string, limit, offset int) ([]*models.PracticeSession, int64, error) {
+	return s.repo.Practice().ListSessions(ctx, nil, repositories.PracticeSessionFilters{
+		PracticeSetID: setID,
+		StudentID:     &studentID,
+		Limit:         limit,
+		Offset:        offset,
+	})
+}
+
+// ===== HELPER METHODS =====
+
+// checkPracticeQuestions makes sure every question belongs to the bank and is graded
+// automatically, and returns them encoded for the set
+func (s *practiceService) checkPracticeQuestions(ctx context.Context, bankID uint, questionIDs []uint, perQuiz int) (datatypes.JSON, error) {
+	bank, err := s.repo.QuestionBank().GetByID(ctx, nil, bankID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question bank: %w", err)
+	}
+	if bank.IsLicensed {
+		return nil, NewBusinessRuleError(rulePracticeLicensedBank, "licensed banks cannot be used for practice, since practice does not count license seats", map[string]interface{}{
+			"bank_id": bankID,
+		})
+	}
+
+	questionIDs = slices.Clone(questionIDs)
+	slices.Sort(questionIDs)
+	questionIDs = slices.Compact(questionIDs)
+	if perQuiz > len(questionIDs) {
+		return nil, ValidationErrors{*NewValidationError("questions_per_quiz", fmt.Sprintf("cannot exceed the %d questions of the set", len(questionIDs)), perQuiz)}
+	}
+
+	questions, err := s.repo.Question().GetByIDs(ctx, nil, questionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	bankIDs, err := s.repo.QuestionBank().GetQuestionBankIDs(ctx, nil, questionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question banks: %w", err)
+	}
+
+	var errs ValidationErrors
+	found := make(map[uint]bool, len(questions))
+	for _, question := range questions {
+		found[question.ID] = true
+		if !slices.Contains(bankIDs[question.ID], bankID) {
+			errs = append(errs, *NewValidationError("question_ids", "question is not in this bank", question.ID))
+		} else if reason := practiceIneligibility(question); reason != "" {
+			errs = append(errs, *NewValidationError("question_ids", reason, question.ID))
+		}
+	}
+	for _, id := range questionIDs {
+		if !found[id] {
+			errs = append(errs, *NewValidationError("question_ids", "question not found", id))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	encoded, err := json.Marshal(questionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode practice questions: %w", err)
+	}
+	return encoded, nil
+}
+
+func (s *practiceService) requireBankEditor(ctx context.Context, bankID uint, userID, action string) error {
+	if _, err := s.repo.QuestionBank().GetByID(ctx, nil, bankID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to get question bank: %w", err)
+	}
+
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role == models.RoleAdmin {
+		return nil
+	}
+	canEdit, err := s.repo.QuestionBank().CanEdit(ctx, nil, bankID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check bank permissions: %w", err)
+	}
+	if !canEdit {
+		return NewPermissionError(userID, bankID, "question_bank", action, "not owner or insufficient permissions")
+	}
+	return nil
+}
+
+func (s *practiceService) requireOpenSet(ctx context.Context, set *models.PracticeSet, studentID string) error {
+	user, err := s.repo.User().GetByID(ctx, studentID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleStudent {
+		return NewPermissionError(studentID, set.ID, "practice_set", "practice", "only students can practice")
+	}
+	if !set.IsActive {
+		return NewPermissionError(studentID, set.ID, "practice_set", "practice", "practice set is closed")
+	}
+
+	classes, err := s.studentClasses(ctx, studentID)
+	if err != nil {
+		return err
+	}
+	if !practiceSetOpenTo(set, classes) {
+		return NewPermissionError(studentID, set.ID, "practice_set", "practice", "practice set is not open to your classes")
+	}
+	return nil
+}
+
+// studentClasses returns the classes the student is enrolled with on any assessment
+func (s *practiceService) studentClasses(ctx context.Context, studentID string) ([]string, error) {
+	enrollments, err := s.repo.Enrollment().GetByStudent(ctx, nil, studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrollments: %w", err)
+	}
+	var classes []string
+	for _, enrollment := range enrollments {
+		if enrollment.ClassID != nil && !slices.Contains(classes, *enrollment.ClassID) {
+			classes = append(classes, *enrollment.ClassID)
+		}
+	}
+	return classes, nil
+}
+
+func (s *practiceService) getSet(ctx context.Context, id uint) (*models.PracticeSet, error) {
+	set, err := s.repo.Practice().GetSetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get practice set: %w", err)
+	}
+	return set, nil
+}
+
+// getOwnSession returns the student's session; other students' sessions are not found
+func (s *practiceService) getOwnSession(ctx context.Context, id uint, studentID string) (*models.PracticeSession, error) {
+	session, err := s.repo.Practice().GetSessionByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get practice session: %w", err)
+	}
+	if session.StudentID != studentID {
+		return nil, ErrNotFound
+	}
+	return session, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// practiceIneligibility explains why a question cannot be practiced, or returns "" when it
+// can. Practice grades every answer on the spot, so nothing may wait for a teacher.
+func practiceIneligibility(question *models.Question) string {
+	switch {
+	case question.IsDraft:
+		return "draft questions cannot be practiced"
+	case question.Type == models.Essay:
+		return "essays are graded by hand and cannot be practiced"
+	case question.RequireManualReview:
+		return "questions that require manual review cannot be practiced"
+	case question.Type == models.MultiPart:
+		var content models.MultiPartContent
+		if err := json.Unmarshal(question.Content, &content); err != nil {
+			return "question content cannot be read"
+		}
+		for _, part := range content.Parts {
+			if part.EffectiveGradingMode() == models.PartGradingManual {
+				return "multi-part questions with manually graded parts cannot be practiced"
+			}
+		}
+	}
+	return ""
+}
+
+// practiceSetOpenTo reports whether a set is open to a student enrolled with the given classes
+func practiceSetOpenTo(set *models.PracticeSet, classes []string) bool {
+	return set.ClassID == nil || slices.Contains(classes, *set.ClassID)
+}
+
+func practiceQuestionIDs(encoded datatypes.JSON) []uint {
+	var ids []uint
+	if len(encoded) > 0 {
+		_ = json.Unmarshal(encoded, &ids)
+	}
+	return ids
+}
+
+// applyPracticeAnswer adds a graded answer to the session's totals and completes the session
+// once every question is answered
+func applyPracticeAnswer(session *models.PracticeSession, answer *models.PracticeAnswer, questionCount int, now time.Time) {
+	session.Answered++
+	if answer.IsCorrect {
+		session.Correct++
+	}
+	session.Score += answer.Score
+	session.MaxScore += answer.MaxScore
+	if session.Answered >= questionCount && session.CompletedAt == nil {
+		session.CompletedAt = &now
+	}
+}
+
+// buildPracticeSessionResponse leaves out the answer key and explanation of questions the
+// student has not answered yet
+func buildPracticeSessionResponse(set *models.PracticeSet, session *models.PracticeSession, questions []*models.Question, answers []*models.PracticeAnswer) *PracticeSessionResponse {
+	answered := make(map[uint]bool, len(answers))
+	for _, answer := range answers {
+		answered[answer.QuestionID] = true
+	}
+
+	served := make([]*models.Question, 0, len(questions))
+	for _, question := range questions {
+		if !answered[question.ID] {
+			hidden := *question
+			hidden.Answer = nil
+			hidden.Explanation = nil
+			question = &hidden
+		}
+		served = append(served, question)
+	}
+	if answers == nil {
+		answers = []*models.PracticeAnswer{}
+	}
+
+	return &PracticeSessionResponse{
+		PracticeSession: session,
+		Title:           set.Title,
+		Questions:       served,
+		Answers:         answers,
+	}
+}
+
+// summarizePracticeStats turns the summed scores into percentages
+func summarizePracticeStats(set *models.PracticeSet, totals *repositories.PracticeSetStats, questions []repositories.PracticeQuestionStats) *PracticeSetStats {
+	stats := &PracticeSetStats{
+		PracticeSetID:     set.ID,
+		Title:             set.Title,
+		Sessions:          totals.Sessions,
+		CompletedSessions: totals.CompletedSessions,
+		Students:          totals.Students,
+		AverageScore:      practicePercent(totals.Score, totals.MaxScore),
+		Questions:         make([]PracticeQuestionStats, 0, len(questions)),
+	}
+	for _, question := range questions {
+		stats.Questions = append(stats.Questions, PracticeQuestionStats{
+			QuestionID:   question.QuestionID,
+			Answers:      question.Answers,
+			CorrectRate:  practicePercent(float64(question.Correct), float64(question.Answers)),
+			AverageScore: practicePercent(question.Score, question.MaxScore),
+		})
+	}
+	return stats
+}
+
+func practicePercent(part, whole float64) float64 {
+	if whole <= 0 {
+		return 0
+	}
+	return math.Round(part/whole*10000) / 100
+}
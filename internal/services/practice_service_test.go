@@ -0,0 +1,114 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestPracticeIneligibility(t *testing.T) {
+	tests := []struct {
+		name     string
+		question models.Question
+		eligible bool
+	}{
+		{"multiple choice", models.Question{Type: models.MultipleChoice}, true},
+		{"draft", models.Question{Type: models.MultipleChoice, IsDraft: true}, false},
+		{"essay", models.Question{Type: models.Essay}, false},
+		{"manual review", models.Question{Type: models.ShortAnswer, RequireManualReview: true}, false},
+		{"auto multi-part", models.Question{Type: models.MultiPart, Content: []byte(`{"parts":[{"id":"a","type":"true_false"},{"id":"b","type":"short_answer"}]}`)}, true},
+		{"multi-part with essay part", models.Question{Type: models.MultiPart, Content: []byte(`{"parts":[{"id":"a","type":"true_false"},{"id":"b","type":"essay"}]}`)}, false},
+		{"multi-part with manual part", models.Question{Type: models.MultiPart, Content: []byte(`{"parts":[{"id":"a","type":"true_false"},{"id":"b","type":"short_answer","grading_mode":"manual"}]}`)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := practiceIneligibility(&tt.question)
+			if (reason == "") != tt.eligible {
+				t.Errorf("practiceIneligibility = %q, want eligible %v", reason, tt.eligible)
+			}
+		})
+	}
+}
+
+func TestPracticeSetOpenTo(t *testing.T) {
+	everyone := &models.PracticeSet{}
+	if !practiceSetOpenTo(everyone, nil) {
+		t.Error("set without a class should be open to every student")
+	}
+
+	classSet := &models.PracticeSet{ClassID: stringPtr("bio-1")}
+	if !practiceSetOpenTo(classSet, []string{"chem-2", "bio-1"}) {
+		t.Error("set should be open to students enrolled with its class")
+	}
+	if practiceSetOpenTo(classSet, []string{"chem-2"}) {
+		t.Error("set should be closed to students of other classes")
+	}
+}
+
+func TestApplyPracticeAnswer(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	session := &models.PracticeSession{}
+
+	applyPracticeAnswer(session, &models.PracticeAnswer{Score: 10, MaxScore: 10, IsCorrect: true}, 2, now)
+	if session.Answered != 1 || session.Correct != 1 || session.Score != 10 || session.MaxScore != 10 {
+		t.Fatalf("after first answer = %+v", session)
+	}
+	if session.CompletedAt != nil {
+		t.Fatal("session completed before every question was answered")
+	}
+
+	applyPracticeAnswer(session, &models.PracticeAnswer{Score: 2.5, MaxScore: 5}, 2, now)
+	if session.Answered != 2 || session.Correct != 1 || session.Score != 12.5 || session.MaxScore != 15 {
+		t.Fatalf("after second answer = %+v", session)
+	}
+	if session.CompletedAt == nil || !session.CompletedAt.Equal(now) {
+		t.Errorf("completed at = %v, want %v", session.CompletedAt, now)
+	}
+}
+
+func TestBuildPracticeSessionResponseHidesUnansweredKeys(t *testing.T) {
+	explanation := "Because."
+	answered := &models.Question{ID: 1, Answer: []byte(`"a"`), Explanation: &explanation}
+	pending := &models.Question{ID: 2, Answer: []byte(`"b"`), Explanation: &explanation}
+
+	response := buildPracticeSessionResponse(
+		&models.PracticeSet{Title: "Cells"},
+		&models.PracticeSession{},
+		[]*models.Question{answered, pending},
+		[]*models.PracticeAnswer{{QuestionID: 1}},
+	)
+
+	if response.Questions[0].Answer == nil || response.Questions[0].Explanation == nil {
+		t.Error("answered question should keep its answer and explanation")
+	}
+	if response.Questions[1].Answer != nil || response.Questions[1].Explanation != nil {
+		t.Error("unanswered question should not reveal its answer or explanation")
+	}
+	if pending.Answer == nil {
+		t.Error("stored question was modified")
+	}
+}
+
+func TestSummarizePracticeStats(t *testing.T) {
+	stats := summarizePracticeStats(
+		&models.PracticeSet{ID: 4, Title: "Cells"},
+		&repositories.PracticeSetStats{Sessions: 5, CompletedSessions: 3, Students: 2, Score: 30, MaxScore: 40},
+		[]repositories.PracticeQuestionStats{
+			{QuestionID: 7, Answers: 3, Correct: 2, Score: 20, MaxScore: 30},
+			{QuestionID: 8, Answers: 0},
+		},
+	)
+
+	if stats.AverageScore != 75 {
+		t.Errorf("average score = %v, want 75", stats.AverageScore)
+	}
+	if stats.Questions[0].CorrectRate != 66.67 || stats.Questions[0].AverageScore != 66.67 {
+		t.Errorf("question 7 = %+v, want 66.67%% correct and score", stats.Questions[0])
+	}
+	if stats.Questions[1].CorrectRate != 0 {
+		t.Errorf("unanswered question correct rate = %v, want 0", stats.Questions[1].CorrectRate)
+	}
+}
@@ -17,14 +17,16 @@ type questionBankService struct {
 	db        *gorm.DB
 	logger    *slog.Logger
 	validator *validator.Validator
+	notifier  NotificationEventService
 }
 
-func NewQuestionBankService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) QuestionBankService {
+func NewQuestionBankService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, notifier NotificationEventService) QuestionBankService {
 	return &questionBankService{
 		repo:      repo,
 		db:        db,
 		logger:    logger,
 		validator: validator,
+		notifier:  notifier,
 	}
 }
 
@@ -498,11 +500,24 @@ func (s *questionBankService) AddQuestions(ctx context.Context, bankID uint, req
 		}
 	}
 
+	if err := rejectDraftQuestions(ctx, s.repo, req.QuestionIDs...); err != nil {
+		return err
+	}
+
+	added, err := questionsByBankMembership(ctx, s.repo, bankID, req.QuestionIDs, false)
+	if err != nil {
+		return err
+	}
+
 	// Add questions to bank
 	if err := s.repo.QuestionBank().AddQuestions(ctx, nil, bankID, req.QuestionIDs); err != nil {
 		return fmt.Errorf("failed to add questions to bank: %w", err)
 	}
 
+	if err := recordBankChanges(ctx, s.repo, nil, bankID, models.QuestionBankChangeAdded, added, userID); err != nil {
+		s.logger.Error("Failed to record question bank changes", "bank_id", bankID, "error", err)
+	}
+
 	s.logger.Info("Questions added to bank successfully", "bank_id", bankID, "question_count", len(req.QuestionIDs))
 	return nil
 }
@@ -519,11 +534,20 @@ func (s *questionBankService) RemoveQuestions(ctx context.Context, bankID uint,
 		return NewPermissionError(userID, bankID, "question_bank", "remove_questions", "not owner or insufficient permissions")
 	}
 
+	removed, err := questionsByBankMembership(ctx, s.repo, bankID, questionIDs, true)
+	if err != nil {
+		return err
+	}
+
 	// Remove questions from bank
 	if err := s.repo.QuestionBank().RemoveQuestions(ctx, nil, bankID, questionIDs); err != nil {
 		return fmt.Errorf("failed to remove questions from bank: %w", err)
 	}
 
+	if err := recordBankChanges(ctx, s.repo, nil, bankID, models.QuestionBankChangeRetired, removed, userID); err != nil {
+		s.logger.Error("Failed to record question bank changes", "bank_id", bankID, "error", err)
+	}
+
 	s.logger.Info("Questions removed from bank successfully", "bank_id", bankID, "question_count", len(questionIDs))
 	return nil
 }
@@ -554,6 +578,7 @@ func (s *questionBankService) GetBankQuestions(ctx context.Context, bankID uint,
 	for i, question := range questions {
 		response.Questions[i] = s.buildQuestionResponse(ctx, question, userID)
 	}
+	attachLocaleStatuses(ctx, s.repo, s.logger, response.Questions)
 
 	return response, nil
 }
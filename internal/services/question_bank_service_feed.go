@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultChangeFeedPageSize = 100
+	maxChangeFeedPageSize     = 1000
+)
+
+// ===== CHANGE FEED =====
+
+// GetChangeFeed returns the bank's changes after the cursor, oldest first
+func (s *questionBankService) GetChangeFeed(ctx context.Context, bankID uint, after *repositories.PageCursor, limit int, userID string) (*QuestionBankChangeFeed, error) {
+	if _, err := s.repo.QuestionBank().GetByID(ctx, nil, bankID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionBankNotFound
+		}
+		return nil, fmt.Errorf("failed to get question bank: %w", err)
+	}
+
+	canAccess, err := s.CanAccess(ctx, bankID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, bankID, "question_bank", "view_changes", "not owner, not public, or not shared")
+	}
+
+	if limit <= 0 {
+		limit = defaultChangeFeedPageSize
+	}
+	if limit > maxChangeFeedPageSize {
+		limit = maxChangeFeedPageSize
+	}
+
+	changes, err := s.repo.QuestionBank().GetChanges(ctx, nil, bankID, after, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildChangeFeed(bankID, changes, after, limit), nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// questionsByBankMembership loads the questions that are, or are not, in the bank, so a
+// change is only recorded for questions it actually adds or removes
+func questionsByBankMembership(ctx context.Context, repo repositories.Repository, bankID uint, questionIDs []uint, member bool) ([]*models.Question, error) {
+	questions, err := repo.Question().GetByIDs(ctx, nil, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	bankIDs, err := repo.QuestionBank().GetQuestionBankIDs(ctx, nil, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	return questionsByMembership(questions, bankIDs, bankID, member), nil
+}
+
+// recordQuestionChanges adds a change of the questions to the feed of every bank holding them
+func recordQuestionChanges(ctx context.Context, repo repositories.Repository, tx *gorm.DB, change models.QuestionBankChangeType, questions []*models.Question, userID string) error {
+	if len(questions) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(questions))
+	for i, question := range questions {
+		ids[i] = question.ID
+	}
+	bankIDs, err := repo.QuestionBank().GetQuestionBankIDs(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+
+	var changes []*models.QuestionBankChange
+	for _, question := range questions {
+		for _, bankID := range bankIDs[question.ID] {
+			changes = append(changes, newBankChange(bankID, question, change, userID))
+		}
+	}
+	return repo.QuestionBank().RecordChanges(ctx, tx, changes)
+}
+
+// recordBankChanges adds a change of the questions to one bank's feed
+func recordBankChanges(ctx context.Context, repo repositories.Repository, tx *gorm.DB, bankID uint, change models.QuestionBankChangeType, questions []*models.Question, userID string) error {
+	changes := make([]*models.QuestionBankChange, len(questions))
+	for i, question := range questions {
+		changes[i] = newBankChange(bankID, question, change, userID)
+	}
+	return repo.QuestionBank().RecordChanges(ctx, tx, changes)
+}
+
+// questionsByMembership picks the questions that are, or are not, in the bank
+func questionsByMembership(questions []*models.Question, bankIDs map[uint][]uint, bankID uint, member bool) []*models.Question {
+	picked := make([]*models.Question, 0, len(questions))
+	for _, question := range questions {
+		if slices.Contains(bankIDs[question.ID], bankID) == member {
+			picked = append(picked, question)
+		}
+	}
+	return picked
+}
+
+func newBankChange(bankID uint, question *models.Question, change models.QuestionBankChangeType, userID string) *models.QuestionBankChange {
+	version := question.Version
+	if version < 1 {
+		version = 1
+	}
+	return &models.QuestionBankChange{
+		BankID:          bankID,
+		QuestionID:      question.ID,
+		Change:          change,
+		QuestionVersion: version,
+		ChangedBy:       userID,
+	}
+}
+
+func buildChangeFeed(bankID uint, changes []*models.QuestionBankChange, after *repositories.PageCursor, limit int) *QuestionBankChangeFeed {
+	feed := &QuestionBankChangeFeed{
+		BankID:  bankID,
+		Entries: make([]QuestionBankChangeEntry, len(changes)),
+		HasMore: len(changes) == limit,
+	}
+	for i, change := range changes {
+		feed.Entries[i] = QuestionBankChangeEntry{
+			ID:              change.ID,
+			QuestionID:      change.QuestionID,
+			Change:          change.Change,
+			QuestionVersion: change.QuestionVersion,
+			Link:            fmt.Sprintf("/api/v1/questions/%d", change.QuestionID),
+			ChangedBy:       change.ChangedBy,
+			ChangedAt:       change.CreatedAt,
+		}
+	}
+
+	if len(changes) > 0 {
+		last := changes[len(changes)-1]
+		next := repositories.PageCursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+		feed.NextCursor = &next
+	} else if after != nil {
+		next := after.Encode()
+		feed.NextCursor = &next
+	}
+	return feed
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestQuestionsByMembership(t *testing.T) {
+	questions := []*models.Question{{ID: 1}, {ID: 2}, {ID: 3}}
+	bankIDs := map[uint][]uint{1: {7}, 2: {4, 9}}
+
+	in := questionsByMembership(questions, bankIDs, 7, true)
+	if len(in) != 1 || in[0].ID != 1 {
+		t.Errorf("expected only question 1 in bank 7, got %v", in)
+	}
+	out := questionsByMembership(questions, bankIDs, 7, false)
+	if len(out) != 2 || out[0].ID != 2 || out[1].ID != 3 {
+		t.Errorf("expected questions 2 and 3 outside bank 7, got %v", out)
+	}
+}
+
+func TestNewBankChange(t *testing.T) {
+	change := newBankChange(7, &models.Question{ID: 3, Version: 4}, models.QuestionBankChangeUpdated, "teacher-1")
+	if change.BankID != 7 || change.QuestionID != 3 || change.QuestionVersion != 4 || change.ChangedBy != "teacher-1" {
+		t.Errorf("unexpected change %+v", change)
+	}
+
+	unversioned := newBankChange(7, &models.Question{ID: 3}, models.QuestionBankChangeAdded, "teacher-1")
+	if unversioned.QuestionVersion != 1 {
+		t.Errorf("questions start at version 1, got %d", unversioned.QuestionVersion)
+	}
+}
+
+func TestBuildChangeFeed(t *testing.T) {
+	at := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	changes := []*models.QuestionBankChange{
+		{ID: 10, BankID: 7, QuestionID: 3, Change: models.QuestionBankChangeAdded, QuestionVersion: 1, CreatedAt: at},
+		{ID: 11, BankID: 7, QuestionID: 3, Change: models.QuestionBankChangeRetired, QuestionVersion: 2, CreatedAt: at.Add(time.Minute)},
+	}
+
+	feed := buildChangeFeed(7, changes, nil, 2)
+	if len(feed.Entries) != 2 || !feed.HasMore {
+		t.Fatalf("expected a full page of two entries, got %+v", feed)
+	}
+	if entry := feed.Entries[1]; entry.Link != "/api/v1/questions/3" || entry.QuestionVersion != 2 || !entry.ChangedAt.Equal(at.Add(time.Minute)) {
+		t.Errorf("unexpected entry %+v", entry)
+	}
+	cursor, err := repositories.DecodePageCursor(*feed.NextCursor)
+	if err != nil || cursor.ID != 11 {
+		t.Errorf("expected the cursor to continue after the last entry, got %+v (%v)", cursor, err)
+	}
+
+	after := &repositories.PageCursor{CreatedAt: at, ID: 11}
+	empty := buildChangeFeed(7, nil, after, 100)
+	if len(empty.Entries) != 0 || empty.HasMore || empty.NextCursor == nil || *empty.NextCursor != after.Encode() {
+		t.Errorf("an empty page should hand back the cursor to poll with, got %+v", empty)
+	}
+
+	first := buildChangeFeed(7, nil, nil, 100)
+	if first.NextCursor != nil {
+		t.Errorf("an empty feed has no cursor yet, got %v", *first.NextCursor)
+	}
+}
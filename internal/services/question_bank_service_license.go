@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// Thresholds used when raising license alerts
+const (
+	licenseSeatAlertUtilization = 90.0
+	licenseExpiryAlertWindow    = 30 * 24 * time.Hour
+)
+
+// ===== LICENSING =====
+
+func (s *questionBankService) UpdateLicense(ctx context.Context, bankID uint, req *UpdateBankLicenseRequest, userID string) (*QuestionBankResponse, error) {
+	s.logger.Info("Updating question bank license",
+		"bank_id", bankID,
+		"is_licensed", req.IsLicensed,
+		"user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	bank, err := s.getLicenseManagedBank(ctx, bankID, userID, "update_license")
+	if err != nil {
+		return nil, err
+	}
+
+	bank.IsLicensed = req.IsLicensed
+	bank.LicenseProvider = req.Provider
+	bank.LicenseSeats = req.Seats
+	bank.LicenseExpiresAt = req.ExpiresAt
+	if !req.IsLicensed {
+		bank.LicenseProvider = nil
+		bank.LicenseSeats = nil
+		bank.LicenseExpiresAt = nil
+	}
+	bank.UpdatedAt = time.Now()
+
+	if err := s.repo.QuestionBank().Update(ctx, nil, bank); err != nil {
+		return nil, fmt.Errorf("failed to update question bank license: %w", err)
+	}
+
+	return s.buildQuestionBankResponse(ctx, bank, userID), nil
+}
+
+func (s *questionBankService) GetLicenseUsage(ctx context.Context, bankID uint, userID string) (*BankLicenseUsage, error) {
+	bank, err := s.getLicenseManagedBank(ctx, bankID, userID, "view_license_usage")
+	if err != nil {
+		return nil, err
+	}
+	if !bank.IsLicensed {
+		return nil, NewBusinessRuleError("bank_not_licensed", "question bank has no license to report on", map[string]interface{}{
+			"bank_id": bankID,
+		})
+	}
+
+	exposure, err := s.repo.QuestionBank().GetLicenseExposure(ctx, nil, bankID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get license exposure: %w", err)
+	}
+
+	return buildLicenseUsage(bank, exposure, time.Now()), nil
+}
+
+// ===== HELPER METHODS =====
+
+// getLicenseManagedBank loads a bank whose license the user may manage; licenses are
+// contractual, so only the owner or an admin may see or change them
+func (s *questionBankService) getLicenseManagedBank(ctx context.Context, bankID uint, userID, action string) (*models.QuestionBank, error) {
+	bank, err := s.repo.QuestionBank().GetByID(ctx, nil, bankID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionBankNotFound
+		}
+		return nil, fmt.Errorf("failed to get question bank: %w", err)
+	}
+
+	if bank.CreatedBy != userID {
+		role, err := s.getUserRole(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if role != models.RoleAdmin {
+			return nil, NewPermissionError(userID, bankID, "question_bank", action, "not owner or insufficient permissions")
+		}
+	}
+
+	return bank, nil
+}
+
+// buildLicenseUsage reports seat usage and expiry for a licensed bank
+func buildLicenseUsage(bank *models.QuestionBank, exposure *repositories.BankLicenseExposure, now time.Time) *BankLicenseUsage {
+	usage := &BankLicenseUsage{
+		BankID:           bank.ID,
+		BankName:         bank.Name,
+		Provider:         bank.LicenseProvider,
+		Seats:            bank.LicenseSeats,
+		SeatsUsed:        exposure.StudentsExposed,
+		ExpiresAt:        bank.LicenseExpiresAt,
+		QuestionsExposed: exposure.QuestionsExposed,
+		AssessmentsUsing: exposure.AssessmentsUsing,
+		LastExposedAt:    exposure.LastExposedAt,
+		GeneratedAt:      now,
+	}
+
+	if bank.LicenseSeats != nil {
+		remaining := *bank.LicenseSeats - exposure.StudentsExposed
+		if remaining < 0 {
+			remaining = 0
+		}
+		utilization := math.Round(float64(exposure.StudentsExposed)/float64(*bank.LicenseSeats)*1000) / 10
+		usage.SeatsRemaining = &remaining
+		usage.SeatUtilization = &utilization
+	}
+
+	if bank.LicenseExpiresAt != nil {
+		days := int(math.Ceil(bank.LicenseExpiresAt.Sub(now).Hours() / 24))
+		usage.DaysUntilExpiry = &days
+		usage.Expired = !now.Before(*bank.LicenseExpiresAt)
+	}
+
+	usage.Alerts = licenseAlerts(usage, now)
+	return usage
+}
+
+// licenseAlerts flags licenses that are expired, about to expire or running out of seats
+func licenseAlerts(usage *BankLicenseUsage, now time.Time) []string {
+	alerts := []string{}
+
+	if usage.ExpiresAt != nil {
+		if usage.Expired {
+			alerts = append(alerts, fmt.Sprintf("license expired %s", usage.ExpiresAt.Format("Jan 2 2006")))
+		} else if usage.ExpiresAt.Sub(now) <= licenseExpiryAlertWindow {
+			alerts = append(alerts, fmt.Sprintf("license expires %s", usage.ExpiresAt.Format("Jan 2 2006")))
+		}
+	}
+
+	if usage.SeatUtilization != nil {
+		if *usage.SeatsRemaining == 0 {
+			alerts = append(alerts, fmt.Sprintf("all %d license seats used", *usage.Seats))
+		} else if *usage.SeatUtilization >= licenseSeatAlertUtilization {
+			alerts = append(alerts, fmt.Sprintf("%.0f%% of license seats used (%d left)", *usage.SeatUtilization, *usage.SeatsRemaining))
+		}
+	}
+
+	return alerts
+}
+
+// checkLicenseAccess decides whether a student may be shown a licensed bank's questions.
+// Students already counted against the license keep access while it is valid.
+func checkLicenseAccess(bank *models.QuestionBank, seatsUsed int, alreadyExposed bool, now time.Time) error {
+	if bank.LicenseExpiresAt != nil && !now.Before(*bank.LicenseExpiresAt) {
+		return NewBusinessRuleError("license_expired", "the license for questions in this assessment has expired", map[string]interface{}{
+			"bank_id":    bank.ID,
+			"expired_at": bank.LicenseExpiresAt,
+		})
+	}
+
+	if bank.LicenseSeats != nil && !alreadyExposed && seatsUsed >= *bank.LicenseSeats {
+		return NewBusinessRuleError("license_seats_exhausted", "no license seats are left for questions in this assessment", map[string]interface{}{
+			"bank_id": bank.ID,
+			"seats":   *bank.LicenseSeats,
+		})
+	}
+
+	return nil
+}
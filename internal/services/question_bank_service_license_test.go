@@ -0,0 +1,56 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+func TestBuildLicenseUsage(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	seats := 20
+	expiresAt := now.Add(10 * 24 * time.Hour)
+	bank := &models.QuestionBank{ID: 7, Name: "Vendor Physics", IsLicensed: true, LicenseSeats: &seats, LicenseExpiresAt: &expiresAt}
+
+	usage := buildLicenseUsage(bank, &repositories.BankLicenseExposure{StudentsExposed: 18, QuestionsExposed: 4}, now)
+	if usage.SeatsUsed != 18 || *usage.SeatsRemaining != 2 || *usage.SeatUtilization != 90 {
+		t.Fatalf("unexpected seat usage: %+v", usage)
+	}
+	if usage.Expired || *usage.DaysUntilExpiry != 10 {
+		t.Errorf("unexpected expiry: %+v", usage)
+	}
+	if len(usage.Alerts) != 2 {
+		t.Errorf("expected expiry and seat alerts, got %v", usage.Alerts)
+	}
+
+	// Unlimited seats and no expiry never alert
+	unlimited := &models.QuestionBank{ID: 8, IsLicensed: true}
+	usage = buildLicenseUsage(unlimited, &repositories.BankLicenseExposure{StudentsExposed: 500}, now)
+	if usage.SeatsRemaining != nil || usage.SeatUtilization != nil || len(usage.Alerts) != 0 {
+		t.Errorf("unlimited license should not report seats or alerts, got %+v", usage)
+	}
+}
+
+func TestCheckLicenseAccess(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	seats := 2
+	bank := &models.QuestionBank{ID: 7, IsLicensed: true, LicenseSeats: &seats}
+
+	if err := checkLicenseAccess(bank, 1, false, now); err != nil {
+		t.Errorf("free seat should admit a new student, got %v", err)
+	}
+	if err := checkLicenseAccess(bank, 2, false, now); !IsBusinessRule(err) {
+		t.Errorf("full license should reject a new student, got %v", err)
+	}
+	if err := checkLicenseAccess(bank, 2, true, now); err != nil {
+		t.Errorf("students already counted should keep access, got %v", err)
+	}
+
+	expired := now.Add(-time.Hour)
+	bank.LicenseExpiresAt = &expired
+	if err := checkLicenseAccess(bank, 0, true, now); !IsBusinessRule(err) {
+		t.Errorf("expired license should reject everyone, got %v", err)
+	}
+}
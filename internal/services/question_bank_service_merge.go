@@ -0,0 +1,304 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// ===== MERGE AND DEDUPLICATION =====
+
+func (s *questionBankService) Merge(ctx context.Context, targetBankID uint, req *MergeQuestionBanksRequest, userID string) (*QuestionBankMergeReport, error) {
+	s.logger.Info("Merging question banks",
+		"target_bank_id", targetBankID,
+		"source_bank_id", req.SourceBankID,
+		"delete_source", req.DeleteSource,
+		"dry_run", req.DryRun,
+		"user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if req.SourceBankID == targetBankID {
+		return nil, NewValidationError("source_bank_id", "source and target banks must differ", req.SourceBankID)
+	}
+
+	// Check permissions on both banks
+	canEditTarget, err := s.CanEdit(ctx, targetBankID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEditTarget {
+		return nil, NewPermissionError(userID, targetBankID, "question_bank", "merge_into", "not owner or insufficient permissions")
+	}
+
+	canEditSource, err := s.CanEdit(ctx, req.SourceBankID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEditSource {
+		return nil, NewPermissionError(userID, req.SourceBankID, "question_bank", "merge_from", "not owner or insufficient permissions")
+	}
+
+	if req.DeleteSource {
+		canDeleteSource, err := s.CanDelete(ctx, req.SourceBankID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !canDeleteSource {
+			return nil, NewPermissionError(userID, req.SourceBankID, "question_bank", "delete", "not owner or insufficient permissions")
+		}
+	}
+
+	orderByID := repositories.QuestionFilters{SortBy: "q.id", SortOrder: "asc"}
+	targetQuestions, _, err := s.repo.QuestionBank().GetBankQuestions(ctx, nil, targetBankID, orderByID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target bank questions: %w", err)
+	}
+	sourceQuestions, _, err := s.repo.QuestionBank().GetBankQuestions(ctx, nil, req.SourceBankID, orderByID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source bank questions: %w", err)
+	}
+
+	report := &QuestionBankMergeReport{
+		TargetBankID:        targetBankID,
+		SourceBankID:        req.SourceBankID,
+		SourceQuestionCount: len(sourceQuestions),
+		MovedQuestionIDs:    []uint{},
+		Duplicates:          []MergedQuestionDuplicate{},
+		DryRun:              req.DryRun,
+		MergedAt:            time.Now(),
+	}
+
+	// Index the target bank; unique source questions join the index so that
+	// duplicates inside the source collapse onto their first occurrence
+	survivors := make(map[string]*models.Question)
+	for _, question := range targetQuestions {
+		fingerprint := questionFingerprint(question)
+		if _, exists := survivors[fingerprint]; !exists {
+			survivors[fingerprint] = question
+		}
+	}
+
+	type duplicatePair struct {
+		duplicate *models.Question
+		survivor  *models.Question
+	}
+	var duplicates []duplicatePair
+	var moved []*models.Question
+	for _, question := range sourceQuestions {
+		fingerprint := questionFingerprint(question)
+		if survivor, exists := survivors[fingerprint]; exists {
+			if survivor.ID != question.ID {
+				duplicates = append(duplicates, duplicatePair{duplicate: question, survivor: survivor})
+			}
+			continue
+		}
+		survivors[fingerprint] = question
+		report.MovedQuestionIDs = append(report.MovedQuestionIDs, question.ID)
+		moved = append(moved, question)
+	}
+
+	// Merge tags and categories onto survivors before anything is written
+	updatedSurvivors := make(map[uint]*models.Question)
+	remappable := make(map[uint][]uint)
+	for _, pair := range duplicates {
+		entry := MergedQuestionDuplicate{
+			DuplicateQuestionID:  pair.duplicate.ID,
+			SurvivingQuestionID:  pair.survivor.ID,
+			SkippedAssessmentIDs: []uint{},
+		}
+
+		remap, skipped, err := s.remappableAssessments(ctx, pair.duplicate.ID, userID)
+		if err != nil {
+			return nil, err
+		}
+		remappable[pair.duplicate.ID] = remap
+		entry.SkippedAssessmentIDs = append(entry.SkippedAssessmentIDs, skipped...)
+		report.AssessmentsSkipped += len(skipped)
+
+		merged, added := mergeQuestionTags(pair.survivor.Tags, pair.duplicate.Tags)
+		if added > 0 {
+			pair.survivor.Tags = merged
+			entry.TagsAdded = added
+			updatedSurvivors[pair.survivor.ID] = pair.survivor
+		}
+		if pair.survivor.CategoryID == nil && pair.duplicate.CategoryID != nil {
+			pair.survivor.CategoryID = pair.duplicate.CategoryID
+			entry.CategoryAdopted = true
+			updatedSurvivors[pair.survivor.ID] = pair.survivor
+		}
+
+		report.Duplicates = append(report.Duplicates, entry)
+	}
+
+	if req.DryRun {
+		return report, nil
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if len(report.MovedQuestionIDs) > 0 {
+			if err := s.repo.QuestionBank().AddQuestions(ctx, tx, targetBankID, report.MovedQuestionIDs); err != nil {
+				return fmt.Errorf("failed to move questions: %w", err)
+			}
+			if err := recordBankChanges(ctx, s.repo, tx, targetBankID, models.QuestionBankChangeAdded, moved, userID); err != nil {
+				return err
+			}
+		}
+
+		for i := range report.Duplicates {
+			entry := &report.Duplicates[i]
+			remapped, err := s.repo.AssessmentQuestion().ReplaceQuestion(ctx, tx, entry.DuplicateQuestionID, entry.SurvivingQuestionID, remappable[entry.DuplicateQuestionID])
+			if err != nil {
+				return err
+			}
+			entry.AssessmentsRemapped = remapped
+			report.AssessmentsRemapped += remapped
+		}
+
+		updated := make([]*models.Question, 0, len(updatedSurvivors))
+		for _, survivor := range updatedSurvivors {
+			survivor.Version++
+			if err := s.repo.Question().Update(ctx, tx, survivor); err != nil {
+				return fmt.Errorf("failed to update surviving question %d: %w", survivor.ID, err)
+			}
+			updated = append(updated, survivor)
+		}
+		if err := recordQuestionChanges(ctx, s.repo, tx, models.QuestionBankChangeUpdated, updated, userID); err != nil {
+			return err
+		}
+
+		if req.DeleteSource {
+			if err := s.repo.QuestionBank().Delete(ctx, tx, req.SourceBankID); err != nil {
+				return fmt.Errorf("failed to delete source bank: %w", err)
+			}
+			report.SourceDeleted = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Question banks merged",
+		"target_bank_id", targetBankID,
+		"source_bank_id", req.SourceBankID,
+		"moved", len(report.MovedQuestionIDs),
+		"duplicates", len(report.Duplicates),
+		"assessments_remapped", report.AssessmentsRemapped,
+		"assessments_skipped", report.AssessmentsSkipped)
+
+	return report, nil
+}
+
+// remappableAssessments splits the assessments using a duplicate question into those a merge
+// may point at the survivor and those it must leave alone. Only assessments the user can edit
+// and nobody has attempted are remapped, so existing answers keep the question they were given for.
+func (s *questionBankService) remappableAssessments(ctx context.Context, questionID uint, userID string) ([]uint, []uint, error) {
+	links, err := s.repo.AssessmentQuestion().GetByQuestion(ctx, nil, questionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get assessments using question %d: %w", questionID, err)
+	}
+
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	seen := make(map[uint]bool)
+	var remap, skipped []uint
+	for _, link := range links {
+		if seen[link.AssessmentID] {
+			continue
+		}
+		seen[link.AssessmentID] = true
+
+		canEdit, err := assessmentService.CanEdit(ctx, link.AssessmentID, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !canEdit {
+			skipped = append(skipped, link.AssessmentID)
+			continue
+		}
+
+		hasAttempts, err := s.repo.Assessment().HasAttempts(ctx, nil, link.AssessmentID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if hasAttempts {
+			skipped = append(skipped, link.AssessmentID)
+			continue
+		}
+		remap = append(remap, link.AssessmentID)
+	}
+
+	return remap, skipped, nil
+}
+
+// questionFingerprint identifies questions with the same type, wording and content,
+// ignoring case, whitespace and JSON key order
+func questionFingerprint(question *models.Question) string {
+	text := strings.Join(strings.Fields(strings.ToLower(question.Text)), " ")
+	return fmt.Sprintf("%s|%s|%s", question.Type, text, canonicalJSON(question.Content))
+}
+
+// canonicalJSON re-encodes JSON so that equivalent documents compare equal
+func canonicalJSON(data []byte) string {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return string(data)
+	}
+
+	// encoding/json sorts map keys when marshalling
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return string(data)
+	}
+	return string(normalized)
+}
+
+// mergeQuestionTags appends tags from extra that base does not already have (case-insensitive)
+func mergeQuestionTags(base, extra []byte) ([]byte, int) {
+	var baseTags, extraTags []string
+	if len(base) > 0 {
+		_ = json.Unmarshal(base, &baseTags)
+	}
+	if len(extra) > 0 {
+		_ = json.Unmarshal(extra, &extraTags)
+	}
+
+	seen := make(map[string]bool)
+	for _, tag := range baseTags {
+		seen[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+
+	added := 0
+	for _, tag := range extraTags {
+		key := strings.ToLower(strings.TrimSpace(tag))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		baseTags = append(baseTags, tag)
+		added++
+	}
+
+	if added == 0 {
+		return base, 0
+	}
+
+	merged, err := json.Marshal(baseTags)
+	if err != nil {
+		return base, 0
+	}
+	return merged, added
+}
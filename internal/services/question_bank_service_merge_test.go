@@ -0,0 +1,45 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+func TestQuestionFingerprint(t *testing.T) {
+	base := &models.Question{
+		Type:    models.MultipleChoice,
+		Text:    "What is  2 + 2?",
+		Content: datatypes.JSON(`{"options":["3","4"],"multiple":false}`),
+	}
+	reformatted := &models.Question{
+		Type:    models.MultipleChoice,
+		Text:    "what is 2 + 2? ",
+		Content: datatypes.JSON(`{"multiple": false, "options": ["3", "4"]}`),
+	}
+	otherType := &models.Question{
+		Type:    models.ShortAnswer,
+		Text:    base.Text,
+		Content: base.Content,
+	}
+
+	if questionFingerprint(base) != questionFingerprint(reformatted) {
+		t.Error("expected whitespace, case and key order to be ignored")
+	}
+	if questionFingerprint(base) == questionFingerprint(otherType) {
+		t.Error("expected different question types to differ")
+	}
+}
+
+func TestMergeQuestionTags(t *testing.T) {
+	merged, added := mergeQuestionTags([]byte(`["Algebra","fractions"]`), []byte(`["algebra","Geometry"]`))
+	if added != 1 || string(merged) != `["Algebra","fractions","Geometry"]` {
+		t.Errorf("mergeQuestionTags() = %s, %d", merged, added)
+	}
+
+	unchanged, added := mergeQuestionTags([]byte(`["a"]`), nil)
+	if added != 0 || string(unchanged) != `["a"]` {
+		t.Errorf("mergeQuestionTags() with no extra tags = %s, %d", unchanged, added)
+	}
+}
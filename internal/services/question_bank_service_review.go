@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+// Thresholds used when looking for questions due for review
+const (
+	defaultQuestionReviewIntervalMonths = 12
+	// Correct rates of the last window are compared with the window before it
+	correctRateTrendWindow = 90 * 24 * time.Hour
+	// Both windows need this many graded answers before a trend counts
+	correctRateTrendMinResponses = 10
+	// Drop in percentage points that marks a declining correct rate
+	correctRateDeclineThreshold = 15.0
+	// Owners are reminded about a bank at most this often
+	reviewReminderInterval = 7 * 24 * time.Hour
+)
+
+// ===== CONTENT REVIEW =====
+
+func (s *questionBankService) GetStaleQuestions(ctx context.Context, bankID uint, userID string) (*StaleQuestionReport, error) {
+	bank, err := s.repo.QuestionBank().GetByID(ctx, nil, bankID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionBankNotFound
+		}
+		return nil, fmt.Errorf("failed to get question bank: %w", err)
+	}
+
+	canEdit, err := s.CanEdit(ctx, bankID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEdit {
+		return nil, NewPermissionError(userID, bankID, "question_bank", "view_stale_questions", "not owner or insufficient permissions")
+	}
+
+	now := time.Now()
+	reports, err := s.findStaleQuestions(ctx, bankID, now)
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) > 0 {
+		return reports[0], nil
+	}
+
+	return &StaleQuestionReport{
+		BankID:      bank.ID,
+		BankName:    bank.Name,
+		OwnerID:     bank.CreatedBy,
+		Questions:   []StaleQuestion{},
+		GeneratedAt: now,
+	}, nil
+}
+
+func (s *questionBankService) MarkQuestionsReviewed(ctx context.Context, bankID uint, req *MarkQuestionsReviewedRequest, userID string) (*MarkQuestionsReviewedResponse, error) {
+	s.logger.Info("Marking questions reviewed",
+		"bank_id", bankID,
+		"question_count", len(req.QuestionIDs),
+		"user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.QuestionBank().GetByID(ctx, nil, bankID); err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionBankNotFound
+		}
+		return nil, fmt.Errorf("failed to get question bank: %w", err)
+	}
+
+	canEdit, err := s.CanEdit(ctx, bankID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEdit {
+		return nil, NewPermissionError(userID, bankID, "question_bank", "mark_reviewed", "not owner or insufficient permissions")
+	}
+
+	reviewedAt := time.Now()
+	reviewed, err := s.repo.QuestionBank().MarkQuestionsReviewed(ctx, nil, bankID, req.QuestionIDs, reviewedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark questions reviewed: %w", err)
+	}
+
+	return &MarkQuestionsReviewedResponse{
+		Reviewed:   reviewed,
+		ReviewedAt: reviewedAt,
+	}, nil
+}
+
+// SendReviewReminders notifies the owner of every bank with questions due for review,
+// skipping banks whose owner was reminded within the last week
+func (s *questionBankService) SendReviewReminders(ctx context.Context) (int, error) {
+	now := time.Now()
+	reports, err := s.findStaleQuestions(ctx, 0, now)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, report := range reports {
+		claimed, err := s.sendReviewReminder(ctx, report, now)
+		if err != nil {
+			s.logger.Error("Failed to send review reminder", "bank_id", report.BankID, "error", err)
+			continue
+		}
+		if claimed {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// sendReviewReminder claims the bank's reminder and notifies its owner in one transaction, so
+// only one replica reminds the owner and a failed notification releases the claim
+func (s *questionBankService) sendReviewReminder(ctx context.Context, report *StaleQuestionReport, now time.Time) (bool, error) {
+	claimed := false
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ok, err := s.repo.QuestionBank().SetReviewReminderSent(ctx, tx, report.BankID, now, now.Add(-reviewReminderInterval))
+		if err != nil {
+			return fmt.Errorf("failed to record review reminder: %w", err)
+		}
+		if !ok {
+			return nil
+		}
+		if err := s.notifier.NotifyQuestionsReviewDue(ctx, report); err != nil {
+			return err
+		}
+		claimed = true
+		return nil
+	})
+	return claimed, err
+}
+
+// RunScheduler sends review reminders every interval until the context is cancelled
+func (s *questionBankService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.Info("Question review reminder scheduler started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Question review reminder scheduler stopped")
+			return
+		case <-ticker.C:
+			sent, err := s.SendReviewReminders(ctx)
+			if err != nil {
+				s.logger.Error("Failed to send review reminders", "error", err)
+				continue
+			}
+			if sent > 0 {
+				s.logger.Info("Sent question review reminders", "banks", sent)
+			}
+		}
+	}
+}
+
+// ===== HELPER METHODS =====
+
+// findStaleQuestions reports the questions due for review per bank; bankID 0 checks every
+// bank whose owner is due a reminder
+func (s *questionBankService) findStaleQuestions(ctx context.Context, bankID uint, now time.Time) ([]*StaleQuestionReport, error) {
+	states, err := s.repo.QuestionBank().GetQuestionReviewStates(ctx, nil, bankID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question review states: %w", err)
+	}
+	if bankID == 0 {
+		states = filterReminderDue(states, now)
+	}
+
+	questionIDs := make([]uint, 0, len(states))
+	seen := make(map[uint]bool, len(states))
+	for _, state := range states {
+		if !seen[state.QuestionID] {
+			seen[state.QuestionID] = true
+			questionIDs = append(questionIDs, state.QuestionID)
+		}
+	}
+
+	windows, err := s.repo.QuestionBank().GetCorrectRateWindows(ctx, nil, questionIDs, now, correctRateTrendWindow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get correct rate trends: %w", err)
+	}
+
+	return buildStaleQuestionReports(states, windows, now), nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// filterReminderDue keeps the questions of banks not reminded within the reminder interval
+func filterReminderDue(states []repositories.QuestionReviewState, now time.Time) []repositories.QuestionReviewState {
+	due := make([]repositories.QuestionReviewState, 0, len(states))
+	for _, state := range states {
+		if state.ReminderSentAt == nil || now.Sub(*state.ReminderSentAt) >= reviewReminderInterval {
+			due = append(due, state)
+		}
+	}
+	return due
+}
+
+// questionReviewDueAt is when a question next needs a review, counted from its last review
+// or, if never reviewed, from its creation
+func questionReviewDueAt(state repositories.QuestionReviewState) time.Time {
+	months := defaultQuestionReviewIntervalMonths
+	if state.ReviewIntervalMonths != nil && *state.ReviewIntervalMonths > 0 {
+		months = *state.ReviewIntervalMonths
+	}
+	from := state.CreatedAt
+	if state.LastReviewedAt != nil {
+		from = *state.LastReviewedAt
+	}
+	return from.AddDate(0, months, 0)
+}
+
+// correctRateTrend returns the recent and prior correct rates as percentages, and whether
+// the rate has dropped by the decline threshold. Windows with too few answers have no rate.
+func correctRateTrend(windows repositories.CorrectRateWindows) (*float64, *float64, bool) {
+	if windows.RecentResponses < correctRateTrendMinResponses || windows.PriorResponses < correctRateTrendMinResponses {
+		return nil, nil, false
+	}
+	recent := math.Round(float64(windows.RecentCorrect)/float64(windows.RecentResponses)*1000) / 10
+	prior := math.Round(float64(windows.PriorCorrect)/float64(windows.PriorResponses)*1000) / 10
+	return &recent, &prior, prior-recent >= correctRateDeclineThreshold
+}
+
+// buildStaleQuestionReports groups the questions due for review by bank, in the order of the
+// states. A declining correct rate is not reported again once the question was reviewed
+// during the recent window.
+func buildStaleQuestionReports(states []repositories.QuestionReviewState, windows []repositories.CorrectRateWindows, now time.Time) []*StaleQuestionReport {
+	trends := make(map[uint]repositories.CorrectRateWindows, len(windows))
+	for _, w := range windows {
+		trends[w.QuestionID] = w
+	}
+
+	var reports []*StaleQuestionReport
+	byBank := make(map[uint]*StaleQuestionReport)
+	for _, state := range states {
+		dueAt := questionReviewDueAt(state)
+		var reasons []string
+		if !now.Before(dueAt) {
+			reasons = append(reasons, ReviewReasonOverdue)
+		}
+
+		recent, prior, declining := correctRateTrend(trends[state.QuestionID])
+		reviewedRecently := state.LastReviewedAt != nil && now.Sub(*state.LastReviewedAt) < correctRateTrendWindow
+		if declining && !reviewedRecently {
+			reasons = append(reasons, ReviewReasonDecliningAccuracy)
+		}
+
+		if len(reasons) == 0 {
+			continue
+		}
+
+		report, ok := byBank[state.BankID]
+		if !ok {
+			report = &StaleQuestionReport{
+				BankID:      state.BankID,
+				BankName:    state.BankName,
+				OwnerID:     state.OwnerID,
+				GeneratedAt: now,
+			}
+			byBank[state.BankID] = report
+			reports = append(reports, report)
+		}
+		report.Questions = append(report.Questions, StaleQuestion{
+			QuestionID:        state.QuestionID,
+			Text:              state.QuestionText,
+			Reasons:           reasons,
+			LastReviewedAt:    state.LastReviewedAt,
+			ReviewDueAt:       dueAt,
+			RecentCorrectRate: recent,
+			PriorCorrectRate:  prior,
+		})
+	}
+	return reports
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+func TestQuestionReviewDueAt(t *testing.T) {
+	created := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	reviewed := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	six := 6
+
+	tests := []struct {
+		name  string
+		state repositories.QuestionReviewState
+		want  time.Time
+	}{
+		{"never reviewed uses creation", repositories.QuestionReviewState{CreatedAt: created}, created.AddDate(1, 0, 0)},
+		{"last review restarts the interval", repositories.QuestionReviewState{CreatedAt: created, LastReviewedAt: &reviewed}, reviewed.AddDate(1, 0, 0)},
+		{"custom interval", repositories.QuestionReviewState{CreatedAt: created, LastReviewedAt: &reviewed, ReviewIntervalMonths: &six}, reviewed.AddDate(0, 6, 0)},
+	}
+	for _, tt := range tests {
+		if got := questionReviewDueAt(tt.state); !got.Equal(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCorrectRateTrend(t *testing.T) {
+	recent, prior, declining := correctRateTrend(repositories.CorrectRateWindows{RecentResponses: 20, RecentCorrect: 9, PriorResponses: 40, PriorCorrect: 30})
+	if !declining || *recent != 45 || *prior != 75 {
+		t.Errorf("expected a decline from 75 to 45, got %v %v %v", prior, recent, declining)
+	}
+
+	if _, _, declining := correctRateTrend(repositories.CorrectRateWindows{RecentResponses: 20, RecentCorrect: 14, PriorResponses: 20, PriorCorrect: 16}); declining {
+		t.Error("a 10 point drop should not count as declining")
+	}
+
+	if recent, _, declining := correctRateTrend(repositories.CorrectRateWindows{RecentResponses: 5, RecentCorrect: 0, PriorResponses: 40, PriorCorrect: 40}); declining || recent != nil {
+		t.Error("too few recent answers should not report a trend")
+	}
+}
+
+func TestBuildStaleQuestionReports(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	old := now.AddDate(-2, 0, 0)
+	fresh := now.AddDate(0, -1, 0)
+	states := []repositories.QuestionReviewState{
+		{BankID: 1, BankName: "Algebra", OwnerID: "t1", QuestionID: 10, CreatedAt: old},
+		{BankID: 1, BankName: "Algebra", OwnerID: "t1", QuestionID: 11, CreatedAt: fresh},
+		{BankID: 1, BankName: "Algebra", OwnerID: "t1", QuestionID: 12, CreatedAt: old, LastReviewedAt: &fresh},
+		{BankID: 2, BankName: "Geometry", OwnerID: "t2", QuestionID: 20, CreatedAt: fresh},
+	}
+	declining := func(id uint) repositories.CorrectRateWindows {
+		return repositories.CorrectRateWindows{QuestionID: id, RecentResponses: 20, RecentCorrect: 5, PriorResponses: 20, PriorCorrect: 18}
+	}
+	windows := []repositories.CorrectRateWindows{declining(10), declining(11), declining(12)}
+
+	reports := buildStaleQuestionReports(states, windows, now)
+	if len(reports) != 1 || reports[0].BankID != 1 || reports[0].OwnerID != "t1" {
+		t.Fatalf("expected one report for bank 1, got %+v", reports)
+	}
+
+	questions := reports[0].Questions
+	if len(questions) != 2 {
+		t.Fatalf("expected questions 10 and 11, got %+v", questions)
+	}
+	if questions[0].QuestionID != 10 || len(questions[0].Reasons) != 2 {
+		t.Errorf("question 10 should be overdue and declining, got %+v", questions[0])
+	}
+	if questions[1].QuestionID != 11 || len(questions[1].Reasons) != 1 || questions[1].Reasons[0] != ReviewReasonDecliningAccuracy {
+		t.Errorf("question 11 should only be declining, got %+v", questions[1])
+	}
+}
+
+func TestFilterReminderDue(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+	lastWeek := now.Add(-8 * 24 * time.Hour)
+	states := []repositories.QuestionReviewState{
+		{BankID: 1, QuestionID: 10},
+		{BankID: 2, QuestionID: 20, ReminderSentAt: &yesterday},
+		{BankID: 3, QuestionID: 30, ReminderSentAt: &lastWeek},
+	}
+
+	due := filterReminderDue(states, now)
+	if len(due) != 2 || due[0].BankID != 1 || due[1].BankID != 3 {
+		t.Errorf("expected banks 1 and 3, got %+v", due)
+	}
+}
+
+// reviewBankStore serves the same stale states to every replica, as when both read before
+// either claims, and claims reminders the way the conditional update does
+type reviewBankStore struct {
+	repositories.QuestionBankRepository
+	mu     sync.Mutex
+	states []repositories.QuestionReviewState
+	sentAt map[uint]time.Time
+}
+
+func (s *reviewBankStore) GetQuestionReviewStates(ctx context.Context, tx *gorm.DB, bankID uint) ([]repositories.QuestionReviewState, error) {
+	return s.states, nil
+}
+
+func (s *reviewBankStore) GetCorrectRateWindows(ctx context.Context, tx *gorm.DB, questionIDs []uint, split time.Time, window time.Duration) ([]repositories.CorrectRateWindows, error) {
+	return nil, nil
+}
+
+func (s *reviewBankStore) SetReviewReminderSent(ctx context.Context, tx *gorm.DB, bankID uint, sentAt, dueBefore time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if last, ok := s.sentAt[bankID]; ok && last.After(dueBefore) {
+		return false, nil
+	}
+	s.sentAt[bankID] = sentAt
+	return true, nil
+}
+
+type reviewRepository struct {
+	MockNotificationRepository
+	banks *reviewBankStore
+}
+
+func (r *reviewRepository) QuestionBank() repositories.QuestionBankRepository { return r.banks }
+
+type reviewNotifier struct {
+	NotificationEventService
+	mu       sync.Mutex
+	reminded map[uint]int
+}
+
+func (n *reviewNotifier) NotifyQuestionsReviewDue(ctx context.Context, report *StaleQuestionReport) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.reminded[report.BankID]++
+	return nil
+}
+
+func TestSendReviewRemindersOnceAcrossReplicas(t *testing.T) {
+	old := time.Now().AddDate(-2, 0, 0)
+	repo := &reviewRepository{banks: &reviewBankStore{
+		states: []repositories.QuestionReviewState{
+			{BankID: 1, OwnerID: "t1", QuestionID: 10, CreatedAt: old},
+			{BankID: 2, OwnerID: "t2", QuestionID: 20, CreatedAt: old},
+		},
+		sentAt: map[uint]time.Time{},
+	}}
+	notifier := &reviewNotifier{reminded: map[uint]int{}}
+	db := txOnlyDB(t)
+
+	total := 0
+	for replica := 0; replica < 2; replica++ {
+		service := NewQuestionBankService(repo, db, slog.New(slog.DiscardHandler), nil, notifier)
+		sent, err := service.SendReviewReminders(context.Background())
+		if err != nil {
+			t.Fatalf("replica %d: %v", replica, err)
+		}
+		total += sent
+	}
+
+	if total != 2 || notifier.reminded[1] != 1 || notifier.reminded[2] != 1 {
+		t.Errorf("expected one reminder per bank, got %d sent and %v", total, notifier.reminded)
+	}
+}
@@ -89,6 +89,12 @@ func (s *questionService) Create(ctx context.Context, req *CreateQuestionRequest
 		Tags:        datatypes.JSON(tagsBytes),
 		Explanation: req.Explanation,
 		CreatedBy:   creatorID,
+
+		ReviewIntervalMonths: req.ReviewIntervalMonths,
+		RequireManualReview:  req.RequireManualReview,
+	}
+	if err := applyMultiPartPoints(question); err != nil {
+		return nil, err
 	}
 
 	if err = s.repo.Question().Create(ctx, nil, question); err != nil {
@@ -97,8 +103,16 @@ func (s *questionService) Create(ctx context.Context, req *CreateQuestionRequest
 
 	s.logger.Info("Question created successfully", "question_id", question.ID)
 
-	// Return response
-	return s.buildQuestionResponse(ctx, question, creatorID), nil
+	response := s.buildQuestionResponse(ctx, question, creatorID)
+	if req.Lint {
+		targetGrade := 0.0
+		if req.TargetGradeLevel != nil {
+			targetGrade = *req.TargetGradeLevel
+		}
+		response.Lint = s.validator.ContentLinter().LintQuestion(req.Text, req.Explanation, targetGrade)
+	}
+
+	return response, nil
 }
 
 func (s *questionService) GetByID(ctx context.Context, id uint, userID string) (*QuestionResponse, error) {
@@ -120,7 +134,9 @@ func (s *questionService) GetByID(ctx context.Context, id uint, userID string) (
 		return nil, fmt.Errorf("failed to get question: %w", err)
 	}
 
-	return s.buildQuestionResponse(ctx, question, userID), nil
+	response := s.buildQuestionResponse(ctx, question, userID)
+	attachLocaleStatuses(ctx, s.repo, s.logger, []*QuestionResponse{response})
+	return response, nil
 }
 
 func (s *questionService) GetByIDWithDetails(ctx context.Context, id uint, userID string) (*QuestionResponse, error) {
@@ -142,7 +158,9 @@ func (s *questionService) GetByIDWithDetails(ctx context.Context, id uint, userI
 		return nil, fmt.Errorf("failed to get question with details: %w", err)
 	}
 
-	return s.buildQuestionResponse(ctx, question, userID), nil
+	response := s.buildQuestionResponse(ctx, question, userID)
+	attachLocaleStatuses(ctx, s.repo, s.logger, []*QuestionResponse{response})
+	return response, nil
 }
 
 func (s *questionService) Update(ctx context.Context, id uint, req *UpdateQuestionRequest, userID string) (*QuestionResponse, error) {
@@ -171,8 +189,8 @@ func (s *questionService) Update(ctx context.Context, id uint, req *UpdateQuesti
 		return nil, fmt.Errorf("failed to get question: %w", err)
 	}
 
-	// Validate content if being updated
-	if req.Content != nil {
+	// Validate content if being updated; drafts are only checked when finalized
+	if req.Content != nil && !question.IsDraft {
 		questionType := question.Type
 		if err := s.validateQuestionContent(questionType, req.Content); err != nil {
 			return nil, fmt.Errorf("content validation failed: %w", err)
@@ -187,19 +205,43 @@ func (s *questionService) Update(ctx context.Context, id uint, req *UpdateQuesti
 	}
 
 	// Apply updates
+	before := *question
 	if err := s.applyQuestionUpdates(question, req); err != nil {
 		return nil, err
 	}
+	if !question.IsDraft {
+		if err := applyMultiPartPoints(question); err != nil {
+			return nil, err
+		}
+	}
+
+	lock, err := acquireQuestionContentLock(ctx, s.repo, userID, "update_question", id)
+	if err != nil {
+		return nil, err
+	}
 
 	// Update question
+	question.Version++
 	if err = s.repo.Question().Update(ctx, nil, question); err != nil {
 		return nil, fmt.Errorf("failed to update question: %w", err)
 	}
+	if err := lock.release(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	if err := recordQuestionChanges(ctx, s.repo, nil, models.QuestionBankChangeUpdated, []*models.Question{question}, userID); err != nil {
+		s.logger.Error("Failed to record question bank change", "question_id", id, "error", err)
+	}
+
+	s.markSourceChange(ctx, &before, question)
+	s.recordAnswerKeyChange(ctx, &before, question, userID)
 
 	s.logger.Info("Question updated successfully", "question_id", id)
 
 	// Return updated question
-	return s.buildQuestionResponse(ctx, question, userID), nil
+	response := s.buildQuestionResponse(ctx, question, userID)
+	attachLocaleStatuses(ctx, s.repo, s.logger, []*QuestionResponse{response})
+	return response, nil
 }
 
 func (s *questionService) Delete(ctx context.Context, id uint, userID string) error {
@@ -214,11 +256,23 @@ func (s *questionService) Delete(ctx context.Context, id uint, userID string) er
 		return NewPermissionError(userID, id, "question", "delete", "not owner or question in use")
 	}
 
+	question, err := s.repo.Question().GetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrQuestionNotFound
+		}
+		return fmt.Errorf("failed to get question: %w", err)
+	}
+
 	// Soft delete
 	if err := s.repo.Question().Delete(ctx, nil, id); err != nil {
 		return fmt.Errorf("failed to delete question: %w", err)
 	}
 
+	if err := recordQuestionChanges(ctx, s.repo, nil, models.QuestionBankChangeRetired, []*models.Question{question}, userID); err != nil {
+		s.logger.Error("Failed to record question bank change", "question_id", id, "error", err)
+	}
+
 	s.logger.Info("Question deleted successfully", "question_id", id)
 	return nil
 }
@@ -252,6 +306,8 @@ func (s *questionService) List(ctx context.Context, filters repositories.Questio
 	for i, question := range questions {
 		response.Questions[i] = s.buildQuestionResponse(ctx, question, userID)
 	}
+	s.markFavorites(ctx, response.Questions, userID)
+	attachLocaleStatuses(ctx, s.repo, s.logger, response.Questions)
 
 	return response, nil
 }
@@ -276,6 +332,8 @@ func (s *questionService) GetByCreator(ctx context.Context, creatorID string, fi
 	for i, question := range questions {
 		response.Questions[i] = s.buildQuestionResponse(ctx, question, creatorID)
 	}
+	s.markFavorites(ctx, response.Questions, creatorID)
+	attachLocaleStatuses(ctx, s.repo, s.logger, response.Questions)
 
 	return response, nil
 }
@@ -307,6 +365,8 @@ func (s *questionService) Search(ctx context.Context, query string, filters repo
 	for i, question := range questions {
 		response.Questions[i] = s.buildQuestionResponse(ctx, question, userID)
 	}
+	s.markFavorites(ctx, response.Questions, userID)
+	attachLocaleStatuses(ctx, s.repo, s.logger, response.Questions)
 
 	return response, nil
 }
@@ -406,6 +466,8 @@ func (s *questionService) GetByBank(ctx context.Context, bankID uint, filters re
 	for i, question := range questions {
 		response.Questions[i] = s.buildQuestionResponse(ctx, question, userID)
 	}
+	s.markFavorites(ctx, response.Questions, userID)
+	attachLocaleStatuses(ctx, s.repo, s.logger, response.Questions)
 
 	return response, nil
 }
@@ -431,11 +493,24 @@ func (s *questionService) AddToBank(ctx context.Context, questionID, bankID uint
 		return NewPermissionError(userID, bankID, "question_bank", "edit", "bank not found or access denied")
 	}
 
+	if err := rejectDraftQuestions(ctx, s.repo, questionID); err != nil {
+		return err
+	}
+
+	added, err := questionsByBankMembership(ctx, s.repo, bankID, []uint{questionID}, false)
+	if err != nil {
+		return err
+	}
+
 	// Add question to bank
 	if err := s.repo.Question().AddToBank(ctx, questionID, bankID); err != nil {
 		return fmt.Errorf("failed to add question to bank: %w", err)
 	}
 
+	if err := recordBankChanges(ctx, s.repo, nil, bankID, models.QuestionBankChangeAdded, added, userID); err != nil {
+		s.logger.Error("Failed to record question bank change", "question_id", questionID, "bank_id", bankID, "error", err)
+	}
+
 	s.logger.Info("Question added to bank successfully", "question_id", questionID, "bank_id", bankID)
 	return nil
 }
@@ -452,11 +527,20 @@ func (s *questionService) RemoveFromBank(ctx context.Context, questionID, bankID
 		return NewPermissionError(userID, bankID, "question_bank", "edit", "not owner or insufficient permissions")
 	}
 
+	removed, err := questionsByBankMembership(ctx, s.repo, bankID, []uint{questionID}, true)
+	if err != nil {
+		return err
+	}
+
 	// Remove question from bank
 	if err := s.repo.Question().RemoveFromBank(ctx, questionID, bankID); err != nil {
 		return fmt.Errorf("failed to remove question from bank: %w", err)
 	}
 
+	if err := recordBankChanges(ctx, s.repo, nil, bankID, models.QuestionBankChangeRetired, removed, userID); err != nil {
+		s.logger.Error("Failed to record question bank change", "question_id", questionID, "bank_id", bankID, "error", err)
+	}
+
 	s.logger.Info("Question removed from bank successfully", "question_id", questionID, "bank_id", bankID)
 	return nil
 }
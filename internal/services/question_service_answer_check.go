@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// ===== ANSWER KEY CHECKS =====
+
+// TestGrade shows the score and feedback a hypothetical student answer would get, so answer
+// keys, tolerances and normalization can be checked before the question is used
+func (s *questionService) TestGrade(ctx context.Context, questionID uint, req *TestGradeQuestionRequest, userID string) (*SampleGradingResult, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	question, err := s.repo.Question().GetByID(ctx, nil, questionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+
+	canAccess, err := s.CanAccess(ctx, questionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, questionID, "question", "test_grade", "not owner or insufficient permissions")
+	}
+
+	answer, err := json.Marshal(req.AnswerData)
+	if err != nil {
+		return nil, ValidationErrors{*NewValidationError("answer_data", "answer cannot be encoded", nil)}
+	}
+
+	return NewGradingService(s.db, s.repo, s.logger, s.validator).GradeSampleAnswer(ctx, question, answer)
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// recordAnswerKeyChange logs an update that changed how the question is scored, so the
+// attempts graded with the old key show up in the answer key change report
+func (s *questionService) recordAnswerKeyChange(ctx context.Context, before, after *models.Question, userID string) {
+	previousKey, err := questionAnswerKey(before.Type, before.Points, before.Content)
+	if err != nil {
+		s.logger.Warn("Failed to read previous answer key", "question_id", after.ID, "error", err)
+		return
+	}
+	newKey, err := questionAnswerKey(after.Type, after.Points, after.Content)
+	if err != nil {
+		s.logger.Warn("Failed to read new answer key", "question_id", after.ID, "error", err)
+		return
+	}
+	if bytes.Equal(previousKey, newKey) {
+		return
+	}
+
+	change := &models.AnswerKeyChange{
+		QuestionID:  after.ID,
+		ChangedBy:   userID,
+		PreviousKey: previousKey,
+		NewKey:      newKey,
+	}
+	if err := s.repo.AnswerKeyChange().CreateChange(ctx, nil, change); err != nil {
+		s.logger.Error("Failed to record answer key change", "question_id", after.ID, "error", err)
+		return
+	}
+	s.logger.Info("Answer key changed", "question_id", after.ID, "change_id", change.ID)
+}
+
+// questionAnswerKey extracts what automatic grading depends on: the points and the
+// correct answers with their matching rules. Wording, options text and shuffling are left
+// out. Manually graded questions have no key.
+func questionAnswerKey(questionType models.QuestionType, points int, content []byte) ([]byte, error) {
+	key, err := answerKeyFields(questionType, content)
+	if err != nil || key == nil {
+		return nil, err
+	}
+	key["points"] = points
+	return json.Marshal(key) // Map keys are sorted, so equal keys encode identically
+}
+
+func answerKeyFields(questionType models.QuestionType, content []byte) (map[string]interface{}, error) {
+	switch questionType {
+	case models.MultipleChoice:
+		var c models.MultipleChoiceContent
+		if err := json.Unmarshal(content, &c); err != nil {
+			return nil, fmt.Errorf("invalid multiple choice content: %w", err)
+		}
+		return map[string]interface{}{
+			"correct_answers":  c.CorrectAnswers,
+			"multiple_correct": c.MultipleCorrect,
+			"partial_credit":   c.PartialCredit,
+		}, nil
+	case models.TrueFalse:
+		var c models.TrueFalseContent
+		if err := json.Unmarshal(content, &c); err != nil {
+			return nil, fmt.Errorf("invalid true/false content: %w", err)
+		}
+		return map[string]interface{}{"correct_answer": c.CorrectAnswer}, nil
+	case models.FillInBlank:
+		var c models.FillBlankContent
+		if err := json.Unmarshal(content, &c); err != nil {
+			return nil, fmt.Errorf("invalid fill in the blank content: %w", err)
+		}
+		blanks := make(map[string]interface{}, len(c.Blanks))
+		for id, blank := range c.Blanks {
+			blanks[id] = map[string]interface{}{
+				"accepted_answers": blank.AcceptedAnswers,
+				"points":           blank.Points,
+			}
+		}
+		return map[string]interface{}{
+			"blanks":         blanks,
+			"case_sensitive": c.CaseSensitive,
+			"trim_spaces":    c.TrimSpaces,
+		}, nil
+	case models.Matching:
+		var c models.MatchingContent
+		if err := json.Unmarshal(content, &c); err != nil {
+			return nil, fmt.Errorf("invalid matching content: %w", err)
+		}
+		return map[string]interface{}{
+			"correct_pairs":  c.CorrectPairs,
+			"partial_credit": c.PartialCredit,
+		}, nil
+	case models.Ordering:
+		var c models.OrderingContent
+		if err := json.Unmarshal(content, &c); err != nil {
+			return nil, fmt.Errorf("invalid ordering content: %w", err)
+		}
+		return map[string]interface{}{
+			"correct_order":  c.CorrectOrder,
+			"partial_credit": c.PartialCredit,
+			"scoring":        c.Scoring,
+		}, nil
+	case models.ShortAnswer:
+		var c models.ShortAnswerContent
+		if err := json.Unmarshal(content, &c); err != nil {
+			return nil, fmt.Errorf("invalid short answer content: %w", err)
+		}
+		return map[string]interface{}{
+			"accepted_answers": c.AcceptedAnswers,
+			"case_sensitive":   c.CaseSensitive,
+			"exact_match":      c.ExactMatch,
+			"fuzzy_matching":   c.FuzzyMatching,
+		}, nil
+	case models.MultiPart:
+		var c models.MultiPartContent
+		if err := json.Unmarshal(content, &c); err != nil {
+			return nil, fmt.Errorf("invalid multi-part content: %w", err)
+		}
+		parts := make([]map[string]interface{}, 0, len(c.Parts))
+		for _, part := range c.Parts {
+			entry := map[string]interface{}{
+				"id":           part.ID,
+				"points":       part.Points,
+				"grading_mode": part.EffectiveGradingMode(),
+			}
+			if part.EffectiveGradingMode() == models.PartGradingAuto {
+				key, err := answerKeyFields(part.Type, part.Content)
+				if err != nil {
+					return nil, fmt.Errorf("part %s: %w", part.ID, err)
+				}
+				entry["key"] = key
+			}
+			parts = append(parts, entry)
+		}
+		return map[string]interface{}{"parts": parts}, nil
+	default:
+		return nil, nil
+	}
+}
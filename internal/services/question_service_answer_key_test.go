@@ -0,0 +1,65 @@
+package services
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestQuestionAnswerKeyIgnoresWording(t *testing.T) {
+	before := []byte(`{"options":[{"id":"a","text":"Paris"},{"id":"b","text":"Rome"}],"correct_answers":["a"],"randomize_options":false}`)
+	reworded := []byte(`{"options":[{"id":"a","text":"Paris, France"},{"id":"b","text":"Rome"}],"correct_answers":["a"],"randomize_options":true}`)
+	fixed := []byte(`{"options":[{"id":"a","text":"Paris"},{"id":"b","text":"Rome"}],"correct_answers":["b"]}`)
+
+	key := func(content []byte, points int) []byte {
+		t.Helper()
+		k, err := questionAnswerKey(models.MultipleChoice, points, content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return k
+	}
+
+	if !bytes.Equal(key(before, 5), key(reworded, 5)) {
+		t.Error("rewording options should not change the answer key")
+	}
+	if bytes.Equal(key(before, 5), key(fixed, 5)) {
+		t.Error("a different correct answer should change the answer key")
+	}
+	if bytes.Equal(key(before, 5), key(before, 10)) {
+		t.Error("different points should change the answer key")
+	}
+}
+
+func TestQuestionAnswerKeyMultiPart(t *testing.T) {
+	content := func(correct string) []byte {
+		return []byte(`{"parts":[
+			{"id":"p1","type":"true_false","points":2,"content":{"correct_answer":` + correct + `}},
+			{"id":"p2","type":"essay","points":3,"content":{"min_words":50}}
+		]}`)
+	}
+
+	before, err := questionAnswerKey(models.MultiPart, 5, content("true"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	after, err := questionAnswerKey(models.MultiPart, 5, content("false"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bytes.Equal(before, after) {
+		t.Error("changing a part's correct answer should change the answer key")
+	}
+}
+
+func TestQuestionAnswerKeyEssayHasNone(t *testing.T) {
+	key, err := questionAnswerKey(models.Essay, 10, []byte(`{"min_words":100}`))
+	if err != nil || key != nil {
+		t.Errorf("essays have no answer key, got %s, %v", key, err)
+	}
+
+	if _, err := questionAnswerKey(models.TrueFalse, 1, []byte(`not json`)); err == nil {
+		t.Error("expected an error for unreadable content")
+	}
+}
@@ -0,0 +1,320 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	maxBulkReassignQuestions = 5000
+	maxQuestionTags          = 10
+)
+
+// ===== BULK TAG AND CATEGORY REASSIGNMENT =====
+
+// PreviewBulkReassign counts what BulkReassign would change without changing anything
+func (s *questionService) PreviewBulkReassign(ctx context.Context, req *BulkReassignQuestionsRequest, userID string) (*BulkReassignResult, error) {
+	return s.bulkReassign(ctx, req, userID, false)
+}
+
+// BulkReassign retags and recategorizes the selected questions in one transaction;
+// either every question is changed or none is
+func (s *questionService) BulkReassign(ctx context.Context, req *BulkReassignQuestionsRequest, userID string) (*BulkReassignResult, error) {
+	s.logger.Info("Bulk reassigning questions", "user_id", userID, "question_ids", len(req.QuestionIDs))
+	return s.bulkReassign(ctx, req, userID, true)
+}
+
+func (s *questionService) bulkReassign(ctx context.Context, req *BulkReassignQuestionsRequest, userID string, apply bool) (*BulkReassignResult, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if err := checkBulkReassignRequest(req); err != nil {
+		return nil, err
+	}
+
+	userRole, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if userRole != models.RoleTeacher && userRole != models.RoleAdmin {
+		return nil, NewPermissionError(userID, 0, "question", "bulk_reassign", "insufficient permissions")
+	}
+
+	if req.CategoryID != nil {
+		if err := s.validateCategoryAccess(ctx, *req.CategoryID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	var result *BulkReassignResult
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		questions, err := s.selectBulkQuestions(ctx, tx, req, userID, userRole)
+		if err != nil {
+			return err
+		}
+
+		changed, planned, err := planBulkReassign(questions, req)
+		if err != nil {
+			return err
+		}
+		result = planned
+		if !apply || len(changed) == 0 {
+			return nil
+		}
+
+		for _, question := range changed {
+			question.Version++
+		}
+		if err := s.repo.Question().UpdateBatch(ctx, tx, changed); err != nil {
+			return err
+		}
+		if err := recordQuestionChanges(ctx, s.repo, tx, models.QuestionBankChangeUpdated, changed, userID); err != nil {
+			return err
+		}
+		result.Applied = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if apply {
+		s.logger.Info("Questions bulk reassigned",
+			"user_id", userID,
+			"matched", result.Matched,
+			"affected", result.Affected)
+	}
+
+	return result, nil
+}
+
+// selectBulkQuestions loads the questions a request selects. Explicit IDs must all exist
+// and be editable by the caller; a filter alone only matches the caller's own questions.
+func (s *questionService) selectBulkQuestions(ctx context.Context, tx *gorm.DB, req *BulkReassignQuestionsRequest, userID string, userRole models.UserRole) ([]*models.Question, error) {
+	if len(req.QuestionIDs) == 0 {
+		filters := repositories.QuestionFilters{
+			Type:       req.Filter.Type,
+			Difficulty: req.Filter.Difficulty,
+			CategoryID: req.Filter.CategoryID,
+			CreatedBy:  &userID,
+			Tags:       req.Filter.Tags,
+			Limit:      maxBulkReassignQuestions,
+			SortBy:     "id",
+			SortOrder:  "ASC",
+		}
+		questions, total, err := s.repo.Question().List(ctx, tx, filters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list questions: %w", err)
+		}
+		if total > maxBulkReassignQuestions {
+			return nil, NewBusinessRuleError("bulk_reassign_too_large", "filter matches too many questions; narrow it down", map[string]interface{}{
+				"matched": total,
+				"max":     maxBulkReassignQuestions,
+			})
+		}
+		// The tag filter is a text match, so check tags exactly
+		return filterBulkQuestions(questions, req.Filter), nil
+	}
+
+	ids := slices.Clone(req.QuestionIDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	questions, err := s.repo.Question().GetByIDs(ctx, tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(questions) != len(ids) {
+		found := make(map[uint]bool, len(questions))
+		for _, question := range questions {
+			found[question.ID] = true
+		}
+		missing := make([]uint, 0)
+		for _, id := range ids {
+			if !found[id] {
+				missing = append(missing, id)
+			}
+		}
+		return nil, ValidationErrors{*NewValidationError("question_ids", "questions not found", missing)}
+	}
+
+	for _, question := range questions {
+		if userRole != models.RoleAdmin && question.CreatedBy != userID {
+			return nil, NewPermissionError(userID, question.ID, "question", "bulk_reassign", "not owner or insufficient permissions")
+		}
+	}
+
+	return filterBulkQuestions(questions, req.Filter), nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func checkBulkReassignRequest(req *BulkReassignQuestionsRequest) error {
+	var errs ValidationErrors
+	if len(req.QuestionIDs) == 0 && !req.Filter.isSet() {
+		errs = append(errs, *NewValidationError("question_ids", "select questions by ID or by filter", nil))
+	}
+	if len(req.AddTags) == 0 && len(req.RemoveTags) == 0 && req.CategoryID == nil && !req.ClearCategory {
+		errs = append(errs, *NewValidationError("add_tags", "nothing to change; add or remove tags or set a category", nil))
+	}
+	if req.CategoryID != nil && req.ClearCategory {
+		errs = append(errs, *NewValidationError("clear_category", "cannot be combined with category_id", req.CategoryID))
+	}
+	for _, tag := range req.AddTags {
+		if slices.Contains(req.RemoveTags, tag) {
+			errs = append(errs, *NewValidationError("remove_tags", "tag is also being added", tag))
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (f *BulkQuestionFilter) isSet() bool {
+	return f != nil && (len(f.Tags) > 0 || f.CategoryID != nil || f.Type != nil || f.Difficulty != nil)
+}
+
+func (f *BulkQuestionFilter) matches(question *models.Question) bool {
+	if !f.isSet() {
+		return true
+	}
+	if f.Type != nil && question.Type != *f.Type {
+		return false
+	}
+	if f.Difficulty != nil && question.Difficulty != *f.Difficulty {
+		return false
+	}
+	if f.CategoryID != nil && (question.CategoryID == nil || *question.CategoryID != *f.CategoryID) {
+		return false
+	}
+	tags := decodeQuestionTags(question.Tags)
+	for _, tag := range f.Tags {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func filterBulkQuestions(questions []*models.Question, filter *BulkQuestionFilter) []*models.Question {
+	matched := make([]*models.Question, 0, len(questions))
+	for _, question := range questions {
+		if filter.matches(question) {
+			matched = append(matched, question)
+		}
+	}
+	return matched
+}
+
+// planBulkReassign applies the request to the questions in memory and returns the ones
+// that changed. Any question left with more than the allowed tags fails the whole plan.
+func planBulkReassign(questions []*models.Question, req *BulkReassignQuestionsRequest) ([]*models.Question, *BulkReassignResult, error) {
+	result := &BulkReassignResult{
+		Matched:     len(questions),
+		TagsAdded:   make(map[string]int),
+		TagsRemoved: make(map[string]int),
+		QuestionIDs: make([]uint, 0),
+	}
+	changed := make([]*models.Question, 0)
+	overfull := make([]uint, 0)
+
+	for _, question := range questions {
+		tags := decodeQuestionTags(question.Tags)
+		newTags, added, removed := reassignTags(tags, req.AddTags, req.RemoveTags)
+		if len(added) > 0 && len(newTags) > maxQuestionTags {
+			overfull = append(overfull, question.ID)
+			continue
+		}
+
+		recategorize := false
+		if req.CategoryID != nil && (question.CategoryID == nil || *question.CategoryID != *req.CategoryID) {
+			recategorize = true
+		}
+		if req.ClearCategory && question.CategoryID != nil {
+			recategorize = true
+		}
+		if len(added) == 0 && len(removed) == 0 && !recategorize {
+			continue
+		}
+
+		for _, tag := range added {
+			result.TagsAdded[tag]++
+		}
+		for _, tag := range removed {
+			result.TagsRemoved[tag]++
+		}
+		if len(added) > 0 || len(removed) > 0 {
+			encoded, err := json.Marshal(newTags)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to encode tags: %w", err)
+			}
+			question.Tags = datatypes.JSON(encoded)
+		}
+		if recategorize {
+			result.Recategorized++
+			if req.ClearCategory {
+				question.CategoryID = nil
+			} else {
+				categoryID := *req.CategoryID
+				question.CategoryID = &categoryID
+			}
+		}
+		// A preloaded category would be saved back over the new category ID
+		question.Category = nil
+
+		changed = append(changed, question)
+		result.QuestionIDs = append(result.QuestionIDs, question.ID)
+	}
+
+	if len(overfull) > 0 {
+		return nil, nil, NewBusinessRuleError("too_many_tags", fmt.Sprintf("questions would have more than %d tags", maxQuestionTags), map[string]interface{}{
+			"question_ids": overfull,
+			"max_tags":     maxQuestionTags,
+		})
+	}
+
+	result.Affected = len(changed)
+	return changed, result, nil
+}
+
+// reassignTags removes and then adds tags, keeping the existing order and skipping
+// tags already present. It returns the new tags and what actually changed.
+func reassignTags(tags, add, remove []string) (newTags, added, removed []string) {
+	newTags = make([]string, 0, len(tags)+len(add))
+	for _, tag := range tags {
+		if slices.Contains(remove, tag) {
+			if !slices.Contains(removed, tag) {
+				removed = append(removed, tag)
+			}
+			continue
+		}
+		if !slices.Contains(newTags, tag) {
+			newTags = append(newTags, tag)
+		}
+	}
+	for _, tag := range add {
+		if !slices.Contains(newTags, tag) {
+			newTags = append(newTags, tag)
+			added = append(added, tag)
+		}
+	}
+	return newTags, added, removed
+}
+
+func decodeQuestionTags(raw datatypes.JSON) []string {
+	var tags []string
+	if len(raw) == 0 {
+		return tags
+	}
+	if err := json.Unmarshal(raw, &tags); err != nil {
+		return nil
+	}
+	return tags
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+func bulkQuestion(id uint, categoryID *uint, tags ...string) *models.Question {
+	raw, _ := json.Marshal(append([]string{}, tags...))
+	return &models.Question{ID: id, CategoryID: categoryID, Tags: datatypes.JSON(raw)}
+}
+
+func TestReassignTags(t *testing.T) {
+	tags, added, removed := reassignTags(
+		[]string{"algebra-old", "linear", "algebra-old"},
+		[]string{"linear", "2025-syllabus"},
+		[]string{"algebra-old"},
+	)
+
+	if !reflect.DeepEqual(tags, []string{"linear", "2025-syllabus"}) {
+		t.Errorf("unexpected tags %v", tags)
+	}
+	if !reflect.DeepEqual(added, []string{"2025-syllabus"}) {
+		t.Errorf("expected only the missing tag to be added, got %v", added)
+	}
+	if !reflect.DeepEqual(removed, []string{"algebra-old"}) {
+		t.Errorf("expected the removed tag once, got %v", removed)
+	}
+}
+
+func TestPlanBulkReassign(t *testing.T) {
+	algebra, algebraI := uint(3), uint(4)
+	questions := []*models.Question{
+		bulkQuestion(1, &algebra, "algebra-old"),
+		bulkQuestion(2, &algebraI, "2025-syllabus"), // already done
+		bulkQuestion(3, nil),
+	}
+
+	changed, result, err := planBulkReassign(questions, &BulkReassignQuestionsRequest{
+		AddTags:    []string{"2025-syllabus"},
+		CategoryID: &algebraI,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Matched != 3 || result.Affected != 2 || len(changed) != 2 {
+		t.Fatalf("expected 2 of 3 questions to change, got %+v", result)
+	}
+	if result.TagsAdded["2025-syllabus"] != 2 || result.Recategorized != 2 {
+		t.Errorf("unexpected counts %+v", result)
+	}
+	if !reflect.DeepEqual(result.QuestionIDs, []uint{1, 3}) {
+		t.Errorf("unexpected affected questions %v", result.QuestionIDs)
+	}
+	if *questions[0].CategoryID != algebraI || string(questions[0].Tags) != `["algebra-old","2025-syllabus"]` {
+		t.Errorf("question 1 not updated: category %d, tags %s", *questions[0].CategoryID, questions[0].Tags)
+	}
+}
+
+func TestPlanBulkReassignTooManyTags(t *testing.T) {
+	full := make([]string, maxQuestionTags)
+	for i := range full {
+		full[i] = fmt.Sprintf("tag-%d", i)
+	}
+
+	_, _, err := planBulkReassign([]*models.Question{bulkQuestion(9, nil, full...)}, &BulkReassignQuestionsRequest{
+		AddTags: []string{"one-more"},
+	})
+	var ruleErr *BusinessRuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "too_many_tags" {
+		t.Fatalf("expected a too_many_tags error, got %v", err)
+	}
+
+	// Removing a tag at the same time keeps the question within the limit
+	if _, _, err := planBulkReassign([]*models.Question{bulkQuestion(9, nil, full...)}, &BulkReassignQuestionsRequest{
+		AddTags:    []string{"one-more"},
+		RemoveTags: []string{"tag-0"},
+	}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckBulkReassignRequest(t *testing.T) {
+	categoryID := uint(1)
+	cases := map[string]*BulkReassignQuestionsRequest{
+		"no selection":   {AddTags: []string{"a"}},
+		"empty filter":   {Filter: &BulkQuestionFilter{}, AddTags: []string{"a"}},
+		"no change":      {QuestionIDs: []uint{1}},
+		"set and clear":  {QuestionIDs: []uint{1}, CategoryID: &categoryID, ClearCategory: true},
+		"add and remove": {QuestionIDs: []uint{1}, AddTags: []string{"a"}, RemoveTags: []string{"a"}},
+	}
+	for name, req := range cases {
+		if err := checkBulkReassignRequest(req); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+
+	if err := checkBulkReassignRequest(&BulkReassignQuestionsRequest{
+		Filter:     &BulkQuestionFilter{Tags: []string{"algebra-old"}},
+		CategoryID: &categoryID,
+	}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestBulkQuestionFilterMatchesTagsExactly(t *testing.T) {
+	filter := &BulkQuestionFilter{Tags: []string{"algebra"}}
+	if !filter.matches(bulkQuestion(1, nil, "algebra", "linear")) {
+		t.Error("expected a question with the tag to match")
+	}
+	if filter.matches(bulkQuestion(2, nil, "algebra-old")) {
+		t.Error("expected a similar tag not to match")
+	}
+}
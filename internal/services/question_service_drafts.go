@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/datatypes"
+)
+
+// ===== DRAFT QUESTIONS =====
+
+// CreateDraft saves a question that is still being written. Only the type is required, so the
+// content can be read later; everything else is checked when the draft is finalized.
+func (s *questionService) CreateDraft(ctx context.Context, req *CreateQuestionRequest, creatorID string) (*QuestionResponse, error) {
+	s.logger.Info("Creating draft question", "creator_id", creatorID, "type", req.Type)
+
+	if errs := validateQuestionDraft(req); len(errs) > 0 {
+		return nil, errs
+	}
+
+	canCreate, err := s.canCreateQuestion(ctx, creatorID)
+	if err != nil {
+		return nil, fmt.Errorf("permission check failed: %w", err)
+	}
+	if !canCreate {
+		return nil, NewPermissionError(creatorID, 0, "question", "create", "insufficient role permissions")
+	}
+
+	question, err := buildDraftQuestion(req, creatorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Question().Create(ctx, nil, question); err != nil {
+		return nil, fmt.Errorf("failed to create draft question: %w", err)
+	}
+
+	s.logger.Info("Draft question created", "question_id", question.ID)
+	return s.buildQuestionResponse(ctx, question, creatorID), nil
+}
+
+// FinalizeDraft runs the full create-time validation on a draft and, when it passes, makes the
+// question usable in banks and assessments. Every problem is reported at once.
+func (s *questionService) FinalizeDraft(ctx context.Context, id uint, userID string) (*QuestionResponse, error) {
+	s.logger.Info("Finalizing draft question", "question_id", id, "user_id", userID)
+
+	canEdit, err := s.CanEdit(ctx, id, userID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, err
+	}
+	if !canEdit {
+		return nil, NewPermissionError(userID, id, "question", "finalize", "not owner or question not editable")
+	}
+
+	question, err := s.repo.Question().GetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	if !question.IsDraft {
+		return nil, NewBusinessRuleError("question_not_draft", "question is not a draft", map[string]interface{}{
+			"question_id": id,
+		})
+	}
+
+	req, err := draftCreateRequest(question)
+	if err != nil {
+		return nil, err
+	}
+	if errs := s.validator.GetBusinessValidator().ValidateQuestionCreate(req); len(errs) > 0 {
+		return nil, errs
+	}
+	if err := s.validateQuestionContent(question.Type, req.Content); err != nil {
+		return nil, fmt.Errorf("content validation failed: %w", err)
+	}
+	if err := applyMultiPartPoints(question); err != nil {
+		return nil, err
+	}
+
+	question.IsDraft = false
+	if err := s.repo.Question().Update(ctx, nil, question); err != nil {
+		return nil, fmt.Errorf("failed to finalize question: %w", err)
+	}
+
+	s.logger.Info("Draft question finalized", "question_id", id)
+
+	response := s.buildQuestionResponse(ctx, question, userID)
+	attachLocaleStatuses(ctx, s.repo, s.logger, []*QuestionResponse{response})
+	return response, nil
+}
+
+// rejectDraftQuestions fails when any of the questions is still a draft
+func rejectDraftQuestions(ctx context.Context, repo repositories.Repository, questionIDs ...uint) error {
+	questions, err := repo.Question().GetByIDs(ctx, nil, questionIDs)
+	if err != nil {
+		return err
+	}
+	if drafts := draftQuestionIDs(questions); len(drafts) > 0 {
+		return NewBusinessRuleError("question_draft", "draft questions must be finalized before they can be used", map[string]interface{}{
+			"question_ids": drafts,
+		})
+	}
+	return nil
+}
+
+// ===== HELPER METHODS =====
+
+// validateQuestionDraft holds a draft to the minimum needed to store it
+func validateQuestionDraft(req *CreateQuestionRequest) ValidationErrors {
+	var errs ValidationErrors
+	switch req.Type {
+	case models.MultipleChoice, models.TrueFalse, models.Essay, models.FillInBlank,
+		models.Matching, models.Ordering, models.ShortAnswer, models.MultiPart:
+	default:
+		errs = append(errs, *NewValidationError("type", "unsupported question type", req.Type))
+	}
+	if len(req.Text) > 2000 {
+		errs = append(errs, *NewValidationError("text", "must be at most 2000 characters", len(req.Text)))
+	}
+	if len(req.Tags) > 10 {
+		errs = append(errs, *NewValidationError("tags", "cannot have more than 10 tags", len(req.Tags)))
+	}
+	return errs
+}
+
+func buildDraftQuestion(req *CreateQuestionRequest, creatorID string) (*models.Question, error) {
+	question := &models.Question{
+		Type:        req.Type,
+		Text:        req.Text,
+		Points:      req.Points,
+		TimeLimit:   req.TimeLimit,
+		Difficulty:  req.Difficulty,
+		CategoryID:  req.CategoryID,
+		Explanation: req.Explanation,
+		CreatedBy:   creatorID,
+		IsDraft:     true,
+
+		ReviewIntervalMonths: req.ReviewIntervalMonths,
+		RequireManualReview:  req.RequireManualReview,
+	}
+	if question.Difficulty == "" {
+		question.Difficulty = models.DifficultyMedium
+	}
+
+	if req.Content != nil {
+		contentBytes, err := json.Marshal(req.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal content: %w", err)
+		}
+		question.Content = contentBytes
+	}
+
+	tags := req.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	tagsBytes, err := json.Marshal(tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tags: %w", err)
+	}
+	question.Tags = datatypes.JSON(tagsBytes)
+
+	return question, nil
+}
+
+// draftCreateRequest turns a stored draft back into the request it would have been created
+// with, so it goes through the same validation as a new question
+func draftCreateRequest(question *models.Question) (*CreateQuestionRequest, error) {
+	req := &CreateQuestionRequest{
+		Type:                 question.Type,
+		Text:                 question.Text,
+		Points:               question.Points,
+		TimeLimit:            question.TimeLimit,
+		Difficulty:           question.Difficulty,
+		CategoryID:           question.CategoryID,
+		Explanation:          question.Explanation,
+		ReviewIntervalMonths: question.ReviewIntervalMonths,
+		RequireManualReview:  question.RequireManualReview,
+	}
+	if len(question.Content) > 0 {
+		if err := json.Unmarshal(question.Content, &req.Content); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal question content: %w", err)
+		}
+	}
+	if len(question.Tags) > 0 {
+		if err := json.Unmarshal(question.Tags, &req.Tags); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal question tags: %w", err)
+		}
+	}
+	return req, nil
+}
+
+func draftQuestionIDs(questions []*models.Question) []uint {
+	var drafts []uint
+	for _, question := range questions {
+		if question.IsDraft {
+			drafts = append(drafts, question.ID)
+		}
+	}
+	return drafts
+}
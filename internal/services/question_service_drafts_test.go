@@ -0,0 +1,52 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestValidateQuestionDraft(t *testing.T) {
+	// Incomplete content and missing points are fine for a draft
+	if errs := validateQuestionDraft(&CreateQuestionRequest{Type: models.Matching}); len(errs) > 0 {
+		t.Fatalf("expected an empty matching draft to be valid, got %v", errs)
+	}
+	if errs := validateQuestionDraft(&CreateQuestionRequest{Type: "diagram"}); len(errs) != 1 || errs[0].Field != "type" {
+		t.Fatalf("expected an unsupported type to be rejected, got %v", errs)
+	}
+}
+
+func TestDraftCreateRequestRoundTrip(t *testing.T) {
+	req := &CreateQuestionRequest{
+		Type:    models.MultipleChoice,
+		Text:    "Which planet is largest?",
+		Content: map[string]interface{}{"options": []interface{}{map[string]interface{}{"id": "a", "text": "Jupiter"}}},
+		Tags:    []string{"astronomy"},
+	}
+	question, err := buildDraftQuestion(req, "teacher-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !question.IsDraft || question.Difficulty != models.DifficultyMedium {
+		t.Fatalf("expected a medium difficulty draft, got draft=%v difficulty=%s", question.IsDraft, question.Difficulty)
+	}
+
+	restored, err := draftCreateRequest(question)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Text != req.Text || len(restored.Tags) != 1 || restored.Tags[0] != "astronomy" {
+		t.Fatalf("unexpected restored request: %+v", restored)
+	}
+	content, ok := restored.Content.(map[string]interface{})
+	if !ok || len(content["options"].([]interface{})) != 1 {
+		t.Fatalf("expected content to survive the round trip, got %#v", restored.Content)
+	}
+}
+
+func TestDraftQuestionIDs(t *testing.T) {
+	questions := []*models.Question{{ID: 1}, {ID: 2, IsDraft: true}, {ID: 3}}
+	if drafts := draftQuestionIDs(questions); len(drafts) != 1 || drafts[0] != 2 {
+		t.Fatalf("expected only question 2, got %v", drafts)
+	}
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// externalRefProviderPattern is the shape of a provider name once normalized
+var externalRefProviderPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,99}$`)
+
+const maxExternalIDLength = 255
+
+// ===== EXTERNAL REFERENCES =====
+
+func (s *questionService) GetExternalRefs(ctx context.Context, questionID uint, userID string) ([]*models.QuestionExternalRef, error) {
+	canAccess, err := s.CanAccess(ctx, questionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, questionID, "question", "read", "not owner or insufficient permissions")
+	}
+
+	return s.repo.QuestionExternalRef().GetByQuestion(ctx, nil, questionID)
+}
+
+// SetExternalRef records the ID a provider gives the question, replacing the question's
+// earlier ID from that provider
+func (s *questionService) SetExternalRef(ctx context.Context, questionID uint, provider string, req *SetQuestionExternalRefRequest, userID string) (*models.QuestionExternalRef, error) {
+	s.logger.Info("Setting question external ref", "question_id", questionID, "provider", provider, "user_id", userID)
+
+	provider, externalID, err := normalizeExternalRef(provider, req.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := s.getEditableQuestion(ctx, questionID, userID, "set_external_ref")
+	if err != nil {
+		return nil, err
+	}
+
+	taken, err := s.repo.QuestionExternalRef().GetByExternalID(ctx, nil, question.CreatedBy, provider, externalID)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get question external ref: %w", err)
+	}
+	if taken != nil {
+		if taken.QuestionID == questionID {
+			return taken, nil
+		}
+		return nil, NewBusinessRuleError("external_ref_taken",
+			fmt.Sprintf("%s ID %q already maps to question %d", provider, externalID, taken.QuestionID),
+			map[string]interface{}{"provider": provider, "external_id": externalID, "question_id": taken.QuestionID})
+	}
+
+	ref, err := s.repo.QuestionExternalRef().GetByQuestionAndProvider(ctx, nil, questionID, provider)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get question external ref: %w", err)
+	}
+	if ref != nil {
+		ref.ExternalID = externalID
+		if err := s.repo.QuestionExternalRef().Update(ctx, nil, ref); err != nil {
+			return nil, err
+		}
+		return ref, nil
+	}
+
+	ref = &models.QuestionExternalRef{
+		QuestionID: questionID,
+		OwnerID:    question.CreatedBy,
+		Provider:   provider,
+		ExternalID: externalID,
+		CreatedBy:  userID,
+	}
+	if err := s.repo.QuestionExternalRef().Create(ctx, nil, ref); err != nil {
+		return nil, err
+	}
+	return ref, nil
+}
+
+func (s *questionService) DeleteExternalRef(ctx context.Context, questionID uint, provider string, userID string) error {
+	s.logger.Info("Deleting question external ref", "question_id", questionID, "provider", provider, "user_id", userID)
+
+	if _, err := s.getEditableQuestion(ctx, questionID, userID, "set_external_ref"); err != nil {
+		return err
+	}
+
+	ref, err := s.repo.QuestionExternalRef().GetByQuestionAndProvider(ctx, nil, questionID, normalizeExternalRefProvider(provider))
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to get question external ref: %w", err)
+	}
+
+	return s.repo.QuestionExternalRef().Delete(ctx, nil, ref.ID)
+}
+
+// LookupExternalRef finds the question a provider's ID maps to among the owner's questions.
+// The owner defaults to the caller; only admins look up other owners' questions.
+func (s *questionService) LookupExternalRef(ctx context.Context, provider, externalID string, ownerID *string, userID string) (*QuestionResponse, error) {
+	provider, externalID, err := normalizeExternalRef(provider, externalID)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := s.externalRefOwner(ctx, ownerID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := s.repo.QuestionExternalRef().GetByExternalID(ctx, nil, owner, provider, externalID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to get question external ref: %w", err)
+	}
+
+	return s.GetByID(ctx, ref.QuestionID, userID)
+}
+
+// ResolveExternalRefs maps many of a provider's IDs to question IDs at once, listing the IDs
+// without a question separately
+func (s *questionService) ResolveExternalRefs(ctx context.Context, req *ResolveExternalRefsRequest, userID string) (*ExternalRefResolution, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	provider := normalizeExternalRefProvider(req.Provider)
+	externalIDs := make([]string, 0, len(req.ExternalIDs))
+	for _, externalID := range req.ExternalIDs {
+		_, externalID, err := normalizeExternalRef(provider, externalID)
+		if err != nil {
+			return nil, err
+		}
+		externalIDs = append(externalIDs, externalID)
+	}
+	owner, err := s.externalRefOwner(ctx, req.OwnerID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := s.repo.QuestionExternalRef().GetByExternalIDs(ctx, nil, owner, provider, externalIDs)
+	if err != nil {
+		return nil, err
+	}
+	return buildExternalRefResolution(provider, externalIDs, refs), nil
+}
+
+// externalRefOwner is whose questions an external ID is looked up in
+func (s *questionService) externalRefOwner(ctx context.Context, ownerID *string, userID string) (string, error) {
+	if ownerID == nil || *ownerID == "" || *ownerID == userID {
+		return userID, nil
+	}
+	userRole, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if userRole != models.RoleAdmin {
+		return "", NewPermissionError(userID, 0, "question", "lookup_external_ref", "only admins may look up other owners' questions")
+	}
+	return *ownerID, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func normalizeExternalRefProvider(provider string) string {
+	return strings.ToLower(strings.TrimSpace(provider))
+}
+
+// normalizeExternalRef lowercases the provider and trims both values, rejecting values that
+// cannot be stored
+func normalizeExternalRef(provider, externalID string) (string, string, error) {
+	provider = normalizeExternalRefProvider(provider)
+	externalID = strings.TrimSpace(externalID)
+
+	var errs ValidationErrors
+	if !externalRefProviderPattern.MatchString(provider) {
+		errs = append(errs, *NewValidationError("provider", "must be 1-100 letters, digits, dots, dashes or underscores", provider))
+	}
+	if externalID == "" || len(externalID) > maxExternalIDLength {
+		errs = append(errs, *NewValidationError("external_id", fmt.Sprintf("must be 1-%d characters", maxExternalIDLength), externalID))
+	}
+	if len(errs) > 0 {
+		return "", "", errs
+	}
+	return provider, externalID, nil
+}
+
+// buildExternalRefResolution lists the question each requested ID maps to, in request order
+func buildExternalRefResolution(provider string, externalIDs []string, refs []*models.QuestionExternalRef) *ExternalRefResolution {
+	byExternalID := make(map[string]uint, len(refs))
+	for _, ref := range refs {
+		byExternalID[ref.ExternalID] = ref.QuestionID
+	}
+
+	resolution := &ExternalRefResolution{
+		Provider: provider,
+		Found:    make(map[string]uint),
+		Missing:  make([]string, 0),
+	}
+	for _, externalID := range externalIDs {
+		if questionID, ok := byExternalID[externalID]; ok {
+			resolution.Found[externalID] = questionID
+		} else if !slices.Contains(resolution.Missing, externalID) {
+			resolution.Missing = append(resolution.Missing, externalID)
+		}
+	}
+	return resolution
+}
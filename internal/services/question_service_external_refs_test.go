@@ -0,0 +1,46 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestNormalizeExternalRef(t *testing.T) {
+	provider, externalID, err := normalizeExternalRef("  Pearson ", " ISBN-1:q7 ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider != "pearson" || externalID != "ISBN-1:q7" {
+		t.Errorf("expected a lowercased provider and trimmed ID, got %q %q", provider, externalID)
+	}
+
+	cases := map[string][2]string{
+		"empty provider":    {"", "q1"},
+		"provider spaces":   {"my provider", "q1"},
+		"empty external ID": {"pearson", "  "},
+		"long external ID":  {"pearson", strings.Repeat("x", maxExternalIDLength+1)},
+	}
+	for name, c := range cases {
+		if _, _, err := normalizeExternalRef(c[0], c[1]); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+}
+
+func TestBuildExternalRefResolution(t *testing.T) {
+	refs := []*models.QuestionExternalRef{
+		{QuestionID: 11, ExternalID: "a"},
+		{QuestionID: 12, ExternalID: "c"},
+	}
+
+	resolution := buildExternalRefResolution("pearson", []string{"a", "b", "c", "b"}, refs)
+
+	if len(resolution.Found) != 2 || resolution.Found["a"] != 11 || resolution.Found["c"] != 12 {
+		t.Errorf("unexpected found IDs %v", resolution.Found)
+	}
+	if len(resolution.Missing) != 1 || resolution.Missing[0] != "b" {
+		t.Errorf("expected b to be missing once, got %v", resolution.Missing)
+	}
+}
@@ -66,6 +66,11 @@ func (s *questionService) CanAccess(ctx context.Context, questionID uint, userID
 		return true, nil
 	}
 
+	// Drafts are private to their creator
+	if question.IsDraft {
+		return false, nil
+	}
+
 	// Teachers can access public questions or questions shared with them
 	if userRole == models.RoleTeacher {
 		// TODO: Check if question is public or shared
@@ -244,6 +249,24 @@ func (s *questionService) buildQuestionResponse(ctx context.Context, question *m
 	return response
 }
 
+// markFavorites flags the questions the user starred with a single lookup for the whole page
+func (s *questionService) markFavorites(ctx context.Context, responses []*QuestionResponse, userID string) {
+	ids := make([]uint, len(responses))
+	for i, response := range responses {
+		ids[i] = response.ID
+	}
+
+	favorited, err := s.repo.Favorite().GetFavoritedIDs(ctx, nil, userID, models.FavoriteQuestion, ids)
+	if err != nil {
+		s.logger.Warn("Failed to load favorite questions", "user_id", userID, "error", err)
+		return
+	}
+
+	for _, response := range responses {
+		response.IsFavorite = favorited[response.ID]
+	}
+}
+
 func (s *questionService) applyQuestionUpdates(question *models.Question, req *UpdateQuestionRequest) error {
 	if req.Text != nil {
 		question.Text = *req.Text
@@ -286,6 +309,14 @@ func (s *questionService) applyQuestionUpdates(question *models.Question, req *U
 		question.Explanation = req.Explanation
 	}
 
+	if req.ReviewIntervalMonths != nil {
+		question.ReviewIntervalMonths = req.ReviewIntervalMonths
+	}
+
+	if req.RequireManualReview != nil {
+		question.RequireManualReview = *req.RequireManualReview
+	}
+
 	return nil
 }
 
@@ -330,6 +361,8 @@ func (s *questionService) validateQuestionContent(questionType models.QuestionTy
 		return s.validateOrderingContent(content)
 	case models.ShortAnswer:
 		return s.validateShortAnswerContent(content)
+	case models.MultiPart:
+		return s.validateMultiPartContent(content)
 	default:
 		return NewValidationError("type", "unsupported question type", questionType)
 	}
@@ -534,6 +567,10 @@ func (s *questionService) validateOrderingContent(content interface{}) error {
 		errors = append(errors, *NewValidationError("content.correct_order", "correct order must match number of items", len(orderContent.CorrectOrder)))
 	}
 
+	if !validOrderingScoring(orderContent.Scoring) {
+		errors = append(errors, *NewValidationError("content.scoring", "must be position, kendall_tau or adjacent_pairs", orderContent.Scoring))
+	}
+
 	if len(errors) > 0 {
 		return errors
 	}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+const (
+	multiPartMinParts = 2
+	multiPartMaxParts = 10
+)
+
+// ===== MULTI-PART QUESTIONS =====
+
+func (s *questionService) validateMultiPartContent(content interface{}) error {
+	var mpContent models.MultiPartContent
+
+	if err := s.convertContent(content, &mpContent); err != nil {
+		return err
+	}
+
+	var errors ValidationErrors
+
+	if len(mpContent.Parts) < multiPartMinParts {
+		errors = append(errors, *NewValidationError("content.parts", "must have at least 2 parts", len(mpContent.Parts)))
+	}
+	if len(mpContent.Parts) > multiPartMaxParts {
+		errors = append(errors, *NewValidationError("content.parts", "cannot have more than 10 parts", len(mpContent.Parts)))
+	}
+
+	partIDs := make(map[string]bool)
+	totalPoints := 0
+	for i, part := range mpContent.Parts {
+		field := fmt.Sprintf("content.parts[%d]", i)
+
+		if part.ID == "" {
+			errors = append(errors, *NewValidationError(field+".id", "part ID cannot be empty", nil))
+		} else if partIDs[part.ID] {
+			errors = append(errors, *NewValidationError(field+".id", "duplicate part ID", part.ID))
+		}
+		partIDs[part.ID] = true
+
+		if part.Points < 1 {
+			errors = append(errors, *NewValidationError(field+".points", "part must be worth at least 1 point", part.Points))
+		}
+		totalPoints += part.Points
+
+		if part.GradingMode != "" && part.GradingMode != models.PartGradingAuto && part.GradingMode != models.PartGradingManual {
+			errors = append(errors, *NewValidationError(field+".grading_mode", "grading mode must be auto or manual", part.GradingMode))
+		}
+
+		if part.Type == models.MultiPart {
+			errors = append(errors, *NewValidationError(field+".type", "parts cannot be multi-part questions", part.Type))
+			continue
+		}
+		if err := s.validateQuestionContent(part.Type, part.Content); err != nil {
+			errors = append(errors, prefixValidationErrors(err, field)...)
+		}
+	}
+
+	if totalPoints > 100 {
+		errors = append(errors, *NewValidationError("content.parts", "parts cannot be worth more than 100 points in total", totalPoints))
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+
+	return nil
+}
+
+// applyMultiPartPoints makes a multi-part question worth the sum of its parts
+func applyMultiPartPoints(question *models.Question) error {
+	if question.Type != models.MultiPart {
+		return nil
+	}
+
+	var content models.MultiPartContent
+	if err := json.Unmarshal(question.Content, &content); err != nil {
+		return fmt.Errorf("failed to unmarshal multi-part content: %w", err)
+	}
+
+	question.Points = multiPartPoints(&content)
+	return nil
+}
+
+func multiPartPoints(content *models.MultiPartContent) int {
+	points := 0
+	for _, part := range content.Parts {
+		points += part.Points
+	}
+	return points
+}
+
+// prefixValidationErrors moves a part's content errors under the part's field path, so
+// "content.options" becomes "content.parts[1].content.options"
+func prefixValidationErrors(err error, prefix string) ValidationErrors {
+	switch e := err.(type) {
+	case ValidationErrors:
+		prefixed := make(ValidationErrors, len(e))
+		for i, ve := range e {
+			ve.Field = prefix + "." + ve.Field
+			prefixed[i] = ve
+		}
+		return prefixed
+	case *ValidationError:
+		ve := *e
+		ve.Field = prefix + "." + ve.Field
+		return ValidationErrors{ve}
+	default:
+		return ValidationErrors{*NewValidationError(prefix+".content", err.Error(), nil)}
+	}
+}
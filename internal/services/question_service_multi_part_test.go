@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+func TestValidateMultiPartContent(t *testing.T) {
+	s := &questionService{}
+
+	valid := models.MultiPartContent{Parts: []models.QuestionPart{
+		{ID: "a", Type: models.TrueFalse, Points: 2, Content: datatypes.JSON(`{"correct_answer":true}`)},
+		{ID: "b", Type: models.Essay, Points: 8, Content: datatypes.JSON(`{}`), GradingMode: models.PartGradingManual},
+	}}
+	if err := s.validateMultiPartContent(valid); err != nil {
+		t.Fatalf("expected valid content, got %v", err)
+	}
+
+	invalid := models.MultiPartContent{Parts: []models.QuestionPart{
+		{ID: "a", Type: models.ShortAnswer, Points: 2, Content: datatypes.JSON(`{"accepted_answers":[]}`)},
+		{ID: "a", Type: models.MultiPart, Points: 0},
+	}}
+	err := s.validateMultiPartContent(invalid)
+	errs, ok := err.(ValidationErrors)
+	if !ok {
+		t.Fatalf("expected validation errors, got %v", err)
+	}
+
+	fields := make(map[string]bool)
+	for _, e := range errs {
+		fields[e.Field] = true
+	}
+	for _, field := range []string{
+		"content.parts[0].content.accepted_answers",
+		"content.parts[1].id",
+		"content.parts[1].points",
+		"content.parts[1].type",
+	} {
+		if !fields[field] {
+			t.Errorf("expected an error on %s, got %v", field, errs)
+		}
+	}
+}
+
+func TestApplyMultiPartPoints(t *testing.T) {
+	question := &models.Question{
+		Type:    models.MultiPart,
+		Points:  1,
+		Content: datatypes.JSON(`{"parts":[{"id":"a","points":3},{"id":"b","points":4}]}`),
+	}
+	if err := applyMultiPartPoints(question); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if question.Points != 7 {
+		t.Errorf("expected 7 points, got %d", question.Points)
+	}
+
+	other := &models.Question{Type: models.Essay, Points: 5}
+	if err := applyMultiPartPoints(other); err != nil || other.Points != 5 {
+		t.Errorf("expected other question types untouched, got %d (%v)", other.Points, err)
+	}
+}
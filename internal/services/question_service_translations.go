@@ -0,0 +1,290 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"golang.org/x/text/language"
+	"gorm.io/gorm"
+)
+
+// ===== TRANSLATIONS =====
+
+func (s *questionService) GetTranslations(ctx context.Context, questionID uint, userID string) ([]*models.QuestionTranslation, error) {
+	canAccess, err := s.CanAccess(ctx, questionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canAccess {
+		return nil, NewPermissionError(userID, questionID, "question", "read", "not owner or insufficient permissions")
+	}
+
+	return s.repo.QuestionTranslation().GetByQuestion(ctx, nil, questionID)
+}
+
+// UpsertTranslation creates or replaces the question's translation in a locale. The edited
+// translation waits for review, and when its wording changed every other locale is marked
+// stale so it gets checked against the new meaning.
+func (s *questionService) UpsertTranslation(ctx context.Context, questionID uint, locale string, req *UpsertQuestionTranslationRequest, userID string) (*models.QuestionTranslation, error) {
+	s.logger.Info("Saving question translation", "question_id", questionID, "locale", locale, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	locale, err := normalizeLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	question, err := s.getEditableQuestion(ctx, questionID, userID, "translate")
+	if err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	if req.Content != nil {
+		if err := s.validateQuestionContent(question.Type, req.Content); err != nil {
+			return nil, fmt.Errorf("content validation failed: %w", err)
+		}
+		if content, err = json.Marshal(req.Content); err != nil {
+			return nil, fmt.Errorf("failed to marshal content: %w", err)
+		}
+	}
+
+	var translation *models.QuestionTranslation
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		existing, err := s.repo.QuestionTranslation().GetByQuestionAndLocale(ctx, tx, questionID, locale)
+		if err != nil && !repositories.IsNotFoundError(err) {
+			return fmt.Errorf("failed to get question translation: %w", err)
+		}
+
+		translation = existing
+		if translation == nil {
+			translation = &models.QuestionTranslation{QuestionID: questionID, Locale: locale}
+		}
+		changed := applyTranslationEdit(translation, strings.TrimSpace(req.Text), content, req.Explanation, userID)
+
+		if translation.ID == 0 {
+			if err := s.repo.QuestionTranslation().Create(ctx, tx, translation); err != nil {
+				return err
+			}
+		} else if err := s.repo.QuestionTranslation().Update(ctx, tx, translation); err != nil {
+			return err
+		}
+
+		if !changed {
+			return nil
+		}
+		_, err = s.repo.QuestionTranslation().MarkStale(ctx, tx, questionID, locale, locale, time.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return translation, nil
+}
+
+func (s *questionService) DeleteTranslation(ctx context.Context, questionID uint, locale string, userID string) error {
+	s.logger.Info("Deleting question translation", "question_id", questionID, "locale", locale, "user_id", userID)
+
+	if _, err := s.getEditableQuestion(ctx, questionID, userID, "translate"); err != nil {
+		return err
+	}
+
+	translation, err := s.getTranslation(ctx, questionID, locale)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.QuestionTranslation().Delete(ctx, nil, translation.ID)
+}
+
+// ReviewTranslation approves a translation or sends it back with a note
+func (s *questionService) ReviewTranslation(ctx context.Context, questionID uint, locale string, req *ReviewQuestionTranslationRequest, reviewerID string) (*models.QuestionTranslation, error) {
+	s.logger.Info("Reviewing question translation", "question_id", questionID, "locale", locale, "reviewer_id", reviewerID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if !req.Approved && (req.Note == nil || strings.TrimSpace(*req.Note) == "") {
+		return nil, NewValidationError("note", "a note is required when requesting changes", nil)
+	}
+
+	if _, err := s.getEditableQuestion(ctx, questionID, reviewerID, "review_translation"); err != nil {
+		return nil, err
+	}
+
+	translation, err := s.getTranslation(ctx, questionID, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	translation.Status = models.TranslationChangesRequested
+	if req.Approved {
+		translation.Status = models.TranslationApproved
+	}
+	translation.StaleSince = nil
+	translation.StaleBecause = nil
+	translation.ReviewedBy = &reviewerID
+	translation.ReviewedAt = &now
+	translation.ReviewNote = req.Note
+
+	if err := s.repo.QuestionTranslation().Update(ctx, nil, translation); err != nil {
+		return nil, err
+	}
+
+	return translation, nil
+}
+
+// GetTranslationReviewQueue lists translations waiting for review on the user's questions;
+// admins see every question's
+func (s *questionService) GetTranslationReviewQueue(ctx context.Context, filters repositories.TranslationReviewFilters, userID string) (*TranslationReviewQueue, error) {
+	userRole, err := s.getUserRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if userRole != models.RoleTeacher && userRole != models.RoleAdmin {
+		return nil, NewPermissionError(userID, 0, "question", "review_translation", "insufficient role permissions")
+	}
+	if userRole != models.RoleAdmin {
+		filters.CreatedBy = &userID
+	}
+
+	if filters.Locale != nil {
+		locale, err := normalizeLocale(*filters.Locale)
+		if err != nil {
+			return nil, err
+		}
+		filters.Locale = &locale
+	}
+
+	translations, total, err := s.repo.QuestionTranslation().GetReviewQueue(ctx, nil, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TranslationReviewQueue{
+		Translations: translations,
+		Total:        total,
+		Page:         filters.Offset/max(filters.Limit, 1) + 1,
+		Size:         filters.Limit,
+	}, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (s *questionService) getEditableQuestion(ctx context.Context, questionID uint, userID, action string) (*models.Question, error) {
+	question, err := s.repo.Question().GetByID(ctx, nil, questionID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrQuestionNotFound
+		}
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+
+	canEdit, err := s.CanEdit(ctx, questionID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canEdit {
+		return nil, NewPermissionError(userID, questionID, "question", action, "not owner or question not editable")
+	}
+
+	return question, nil
+}
+
+func (s *questionService) getTranslation(ctx context.Context, questionID uint, locale string) (*models.QuestionTranslation, error) {
+	locale, err := normalizeLocale(locale)
+	if err != nil {
+		return nil, err
+	}
+
+	translation, err := s.repo.QuestionTranslation().GetByQuestionAndLocale(ctx, nil, questionID, locale)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get question translation: %w", err)
+	}
+	return translation, nil
+}
+
+// markSourceChange flags every translation stale when an update changed the question's wording
+func (s *questionService) markSourceChange(ctx context.Context, before, after *models.Question) {
+	if before.Text == after.Text && bytes.Equal(before.Content, after.Content) && stringPtrEqual(before.Explanation, after.Explanation) {
+		return
+	}
+
+	marked, err := s.repo.QuestionTranslation().MarkStale(ctx, nil, after.ID, "", models.TranslationSourceLocale, time.Now())
+	if err != nil {
+		s.logger.Error("Failed to mark translations stale", "question_id", after.ID, "error", err)
+		return
+	}
+	if marked > 0 {
+		s.logger.Info("Marked translations stale", "question_id", after.ID, "count", marked)
+	}
+}
+
+// attachLocaleStatuses adds the translation statuses of a page of questions with one lookup
+func attachLocaleStatuses(ctx context.Context, repo repositories.Repository, logger *slog.Logger, responses []*QuestionResponse) {
+	ids := make([]uint, len(responses))
+	for i, response := range responses {
+		ids[i] = response.ID
+	}
+
+	statuses, err := repo.QuestionTranslation().GetLocaleStatuses(ctx, nil, ids)
+	if err != nil {
+		logger.Warn("Failed to load translation statuses", "error", err)
+		return
+	}
+
+	for _, response := range responses {
+		response.Translations = statuses[response.ID]
+	}
+}
+
+// applyTranslationEdit stores an edit and sends the translation back to review. It reports
+// whether the wording changed; saving the same wording keeps the review state.
+func applyTranslationEdit(translation *models.QuestionTranslation, text string, content []byte, explanation *string, editorID string) bool {
+	changed := translation.ID == 0 ||
+		translation.Text != text ||
+		!bytes.Equal(translation.Content, content) ||
+		!stringPtrEqual(translation.Explanation, explanation)
+
+	translation.Text = text
+	translation.Content = content
+	translation.Explanation = explanation
+	translation.UpdatedBy = editorID
+
+	if changed || translation.Status == models.TranslationStale {
+		translation.Status = models.TranslationPendingReview
+		translation.StaleSince = nil
+		translation.StaleBecause = nil
+	}
+	return changed
+}
+
+// normalizeLocale canonicalizes a BCP 47 tag so "en-us" and "en-US" name the same locale
+func normalizeLocale(locale string) (string, error) {
+	tag, err := language.Parse(strings.TrimSpace(locale))
+	if err != nil || strings.EqualFold(locale, models.TranslationSourceLocale) {
+		return "", NewValidationError("locale", "locale must be a BCP 47 language tag", locale)
+	}
+	return tag.String(), nil
+}
+
+func stringPtrEqual(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestApplyTranslationEdit(t *testing.T) {
+	explanation := "Weil"
+	created := &models.QuestionTranslation{}
+	if !applyTranslationEdit(created, "Warum?", nil, &explanation, "teacher-1") {
+		t.Error("expected a new translation to count as changed")
+	}
+	if created.Status != models.TranslationPendingReview || created.UpdatedBy != "teacher-1" {
+		t.Errorf("unexpected new translation: %+v", created)
+	}
+
+	reviewer := "teacher-2"
+	approved := &models.QuestionTranslation{ID: 1, Text: "Warum?", Explanation: &explanation, Status: models.TranslationApproved, ReviewedBy: &reviewer}
+	same := "Weil"
+	if applyTranslationEdit(approved, "Warum?", nil, &same, "teacher-1") {
+		t.Error("expected saving the same wording not to count as changed")
+	}
+	if approved.Status != models.TranslationApproved {
+		t.Errorf("expected an unchanged approval to stand, got %s", approved.Status)
+	}
+
+	if !applyTranslationEdit(approved, "Wieso?", nil, &same, "teacher-1") {
+		t.Error("expected new text to count as changed")
+	}
+	if approved.Status != models.TranslationPendingReview {
+		t.Errorf("expected an edited translation to wait for review, got %s", approved.Status)
+	}
+
+	cause := "vi"
+	stale := &models.QuestionTranslation{ID: 2, Text: "Warum?", Status: models.TranslationStale, StaleBecause: &cause}
+	if applyTranslationEdit(stale, "Warum?", nil, nil, "teacher-1") {
+		t.Error("expected the same wording not to count as changed")
+	}
+	// Re-saving a stale translation confirms it and sends it to review
+	if stale.Status != models.TranslationPendingReview || stale.StaleBecause != nil {
+		t.Errorf("expected a re-saved stale translation to wait for review: %+v", stale)
+	}
+}
+
+func TestNormalizeLocale(t *testing.T) {
+	cases := map[string]string{
+		"en-us": "en-US",
+		"vi":    "vi",
+		"pt-BR": "pt-BR",
+		" de ":  "de",
+	}
+	for input, expected := range cases {
+		locale, err := normalizeLocale(input)
+		if err != nil || locale != expected {
+			t.Errorf("normalizeLocale(%q) = %q, %v; expected %q", input, locale, err, expected)
+		}
+	}
+
+	for _, input := range []string{"", "not a locale", "source"} {
+		if _, err := normalizeLocale(input); err == nil {
+			t.Errorf("expected %q to be rejected", input)
+		}
+	}
+}
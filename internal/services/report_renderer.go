@@ -0,0 +1,65 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+)
+
+// ReportRenderer turns analytics summaries into deliverable documents
+type ReportRenderer interface {
+	RenderTeacherSummary(summary *TeacherAnalyticsSummary) (*RenderedReport, error)
+}
+
+type htmlReportRenderer struct {
+	teacherSummary *template.Template
+}
+
+func NewReportRenderer() ReportRenderer {
+	funcs := template.FuncMap{
+		"percent": func(rate float64) string { return fmt.Sprintf("%.0f%%", rate*100) },
+		"score":   func(score float64) string { return fmt.Sprintf("%.1f", score) },
+	}
+
+	return &htmlReportRenderer{
+		teacherSummary: template.Must(template.New("teacher_summary").Funcs(funcs).Parse(teacherSummaryTemplate)),
+	}
+}
+
+func (r *htmlReportRenderer) RenderTeacherSummary(summary *TeacherAnalyticsSummary) (*RenderedReport, error) {
+	var body bytes.Buffer
+	if err := r.teacherSummary.Execute(&body, summary); err != nil {
+		return nil, fmt.Errorf("failed to render teacher summary: %w", err)
+	}
+
+	return &RenderedReport{
+		Subject: fmt.Sprintf("Weekly assessment summary: %d active, %d answers to grade",
+			summary.ActiveAssessments, summary.TotalPendingGrading),
+		Body:        body.String(),
+		ContentType: "text/html",
+	}, nil
+}
+
+const teacherSummaryTemplate = `<html>
+<body>
+<h2>Weekly assessment summary</h2>
+<p>{{.PeriodStart.Format "Jan 2, 2006"}} &ndash; {{.PeriodEnd.Format "Jan 2, 2006"}}</p>
+<p>Active assessments: <strong>{{.ActiveAssessments}}</strong><br>
+Answers pending grading: <strong>{{.TotalPendingGrading}}</strong></p>
+{{if .Alerts}}
+<h3>Needs attention</h3>
+<ul>
+{{range .Alerts}}<li>{{.}}</li>
+{{end}}</ul>
+{{end}}
+{{if .Assessments}}
+<table border="1" cellpadding="4" cellspacing="0">
+<tr><th>Assessment</th><th>Attempts</th><th>Completion</th><th>Average score</th><th>Pass rate</th><th>Pending grading</th></tr>
+{{range .Assessments}}<tr><td>{{.Title}}</td><td>{{.TotalAttempts}}</td><td>{{percent .CompletionRate}}</td><td>{{score .AverageScore}}</td><td>{{percent .PassRate}}</td><td>{{.PendingGrading}}</td></tr>
+{{end}}</table>
+{{else}}
+<p>You have no active assessments.</p>
+{{end}}
+</body>
+</html>
+`
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/events"
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultReportDayOfWeek = int(time.Monday)
+	defaultReportHourOfDay = 8
+	reportDeliveryBatch    = 100
+)
+
+type reportService struct {
+	repo           repositories.Repository
+	db             *gorm.DB
+	logger         *slog.Logger
+	validator      *validator.Validator
+	analytics      AnalyticsService
+	renderer       ReportRenderer
+	eventPublisher events.EventPublisher
+}
+
+func NewReportService(
+	repo repositories.Repository,
+	db *gorm.DB,
+	logger *slog.Logger,
+	validator *validator.Validator,
+	analytics AnalyticsService,
+	renderer ReportRenderer,
+	eventPublisher events.EventPublisher,
+) ReportService {
+	return &reportService{
+		repo:           repo,
+		db:             db,
+		logger:         logger,
+		validator:      validator,
+		analytics:      analytics,
+		renderer:       renderer,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// ===== SUBSCRIPTION MANAGEMENT =====
+
+func (s *reportService) Subscribe(ctx context.Context, req *CreateReportSubscriptionRequest, userID string) (*models.ReportSubscription, error) {
+	s.logger.Info("Creating report subscription", "report_type", req.ReportType, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleTeacher && user.Role != models.RoleAdmin {
+		return nil, NewPermissionError(userID, 0, "report_subscription", "create", "only teachers can subscribe to reports")
+	}
+
+	exists, err := s.repo.ReportSubscription().ExistsByUserAndType(ctx, nil, userID, req.ReportType)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, NewBusinessRuleError("subscription_exists", "already subscribed to this report", map[string]interface{}{
+			"report_type": req.ReportType,
+		})
+	}
+
+	subscription := &models.ReportSubscription{
+		UserID:     userID,
+		ReportType: req.ReportType,
+		Frequency:  models.ReportFrequencyWeekly,
+		DayOfWeek:  defaultReportDayOfWeek,
+		HourOfDay:  defaultReportHourOfDay,
+		Email:      req.Email,
+		IsActive:   true,
+	}
+	if req.DayOfWeek != nil {
+		subscription.DayOfWeek = *req.DayOfWeek
+	}
+	if req.HourOfDay != nil {
+		subscription.HourOfDay = *req.HourOfDay
+	}
+	subscription.NextRunAt = nextReportRun(time.Now(), subscription.DayOfWeek, subscription.HourOfDay)
+
+	if err := s.repo.ReportSubscription().Create(ctx, nil, subscription); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Report subscription created",
+		"subscription_id", subscription.ID,
+		"next_run_at", subscription.NextRunAt,
+		"user_id", userID)
+
+	return subscription, nil
+}
+
+func (s *reportService) ListSubscriptions(ctx context.Context, userID string) ([]*models.ReportSubscription, error) {
+	return s.repo.ReportSubscription().GetByUser(ctx, nil, userID)
+}
+
+func (s *reportService) UpdateSubscription(ctx context.Context, id uint, req *UpdateReportSubscriptionRequest, userID string) (*models.ReportSubscription, error) {
+	s.logger.Info("Updating report subscription", "subscription_id", id, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.getOwnedSubscription(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DayOfWeek != nil {
+		subscription.DayOfWeek = *req.DayOfWeek
+	}
+	if req.HourOfDay != nil {
+		subscription.HourOfDay = *req.HourOfDay
+	}
+	if req.Email != nil {
+		subscription.Email = *req.Email
+	}
+	if req.IsActive != nil {
+		subscription.IsActive = *req.IsActive
+	}
+	subscription.NextRunAt = nextReportRun(time.Now(), subscription.DayOfWeek, subscription.HourOfDay)
+
+	if err := s.repo.ReportSubscription().Update(ctx, nil, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (s *reportService) Unsubscribe(ctx context.Context, id uint, userID string) error {
+	s.logger.Info("Deleting report subscription", "subscription_id", id, "user_id", userID)
+
+	if _, err := s.getOwnedSubscription(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return s.repo.ReportSubscription().Delete(ctx, nil, id)
+}
+
+// ===== REPORT GENERATION =====
+
+func (s *reportService) PreviewReport(ctx context.Context, id uint, userID string) (*RenderedReport, error) {
+	subscription, err := s.getOwnedSubscription(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.renderReport(ctx, subscription, reportPeriodStart(subscription, time.Now()))
+}
+
+func (s *reportService) renderReport(ctx context.Context, subscription *models.ReportSubscription, since time.Time) (*RenderedReport, error) {
+	switch subscription.ReportType {
+	case models.ReportTeacherWeeklySummary:
+		summary, err := s.analytics.GetTeacherSummary(ctx, subscription.UserID, since)
+		if err != nil {
+			return nil, err
+		}
+		return s.renderer.RenderTeacherSummary(summary)
+	default:
+		return nil, fmt.Errorf("unsupported report type: %s", subscription.ReportType)
+	}
+}
+
+// ===== SCHEDULED DELIVERY =====
+
+func (s *reportService) DeliverDueReports(ctx context.Context, now time.Time) (int, error) {
+	subscriptions, err := s.repo.ReportSubscription().GetDue(ctx, nil, now, reportDeliveryBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, subscription := range subscriptions {
+		sent, err := s.deliverReport(ctx, subscription, now)
+		if err != nil {
+			s.logger.Error("Failed to deliver report",
+				"subscription_id", subscription.ID,
+				"user_id", subscription.UserID,
+				"error", err)
+			continue
+		}
+		if sent {
+			delivered++
+		}
+	}
+
+	if len(subscriptions) > 0 {
+		s.logger.Info("Scheduled reports delivered", "due", len(subscriptions), "delivered", delivered)
+	}
+
+	return delivered, nil
+}
+
+// RunScheduler delivers due reports every interval until the context is cancelled
+func (s *reportService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.Info("Report scheduler started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Report scheduler stopped")
+			return
+		case now := <-ticker.C:
+			if _, err := s.DeliverDueReports(ctx, now); err != nil {
+				s.logger.Error("Failed to run scheduled reports", "error", err)
+			}
+		}
+	}
+}
+
+// deliverReport claims the subscription's run before publishing so that only one
+// replica sends it; it reports false when another replica got there first
+func (s *reportService) deliverReport(ctx context.Context, subscription *models.ReportSubscription, now time.Time) (bool, error) {
+	dueAt := subscription.NextRunAt
+	nextRunAt := nextReportRun(now, subscription.DayOfWeek, subscription.HourOfDay)
+	claimed, err := s.repo.ReportSubscription().ClaimRun(ctx, nil, subscription.ID, dueAt, nextRunAt)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	if err := s.sendReport(ctx, subscription, now); err != nil {
+		// Hand the run back so the next tick retries it
+		if _, releaseErr := s.repo.ReportSubscription().ClaimRun(ctx, nil, subscription.ID, nextRunAt, dueAt); releaseErr != nil {
+			s.logger.Error("Failed to release report subscription",
+				"subscription_id", subscription.ID,
+				"error", releaseErr)
+		}
+		return false, err
+	}
+
+	// Only the delivery is recorded, so edits made meanwhile survive and a deleted
+	// subscription stays deleted
+	subscription.LastSentAt = &now
+	subscription.NextRunAt = nextRunAt
+	return true, s.repo.ReportSubscription().SetLastSent(ctx, nil, subscription.ID, now)
+}
+
+func (s *reportService) sendReport(ctx context.Context, subscription *models.ReportSubscription, now time.Time) error {
+	since := reportPeriodStart(subscription, now)
+
+	report, err := s.renderReport(ctx, subscription, since)
+	if err != nil {
+		return err
+	}
+
+	email := subscription.Email
+	if email == "" {
+		user, err := s.repo.User().GetByID(ctx, subscription.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		email = user.Email
+	}
+
+	event := events.NewReportDeliveryEvent(events.ReportDeliveryEvent{
+		SubscriptionID: subscription.ID,
+		ReportType:     string(subscription.ReportType),
+		RecipientID:    subscription.UserID,
+		RecipientEmail: email,
+		Channel:        "email",
+		Subject:        report.Subject,
+		Body:           report.Body,
+		ContentType:    report.ContentType,
+		PeriodStart:    since,
+		PeriodEnd:      now,
+	})
+	return s.eventPublisher.PublishNotificationEvent(ctx, event)
+}
+
+// ===== HELPER METHODS =====
+
+func (s *reportService) getOwnedSubscription(ctx context.Context, id uint, userID string) (*models.ReportSubscription, error) {
+	subscription, err := s.repo.ReportSubscription().GetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get report subscription: %w", err)
+	}
+
+	if subscription.UserID != userID {
+		return nil, NewPermissionError(userID, id, "report_subscription", "manage", "not the subscriber")
+	}
+
+	return subscription, nil
+}
+
+// nextReportRun returns the first time strictly after now that falls on the given weekday and hour (UTC)
+func nextReportRun(now time.Time, dayOfWeek, hourOfDay int) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), hourOfDay, 0, 0, 0, time.UTC)
+	next = next.AddDate(0, 0, (dayOfWeek-int(now.Weekday())+7)%7)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 7)
+	}
+	return next
+}
+
+// reportPeriodStart covers the time since the previous delivery, or the last week for a first report
+func reportPeriodStart(subscription *models.ReportSubscription, now time.Time) time.Time {
+	if subscription.LastSentAt != nil {
+		return *subscription.LastSentAt
+	}
+	return now.AddDate(0, 0, -7)
+}
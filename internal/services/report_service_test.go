@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/events"
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+func TestNextReportRun(t *testing.T) {
+	// Wednesday 2025-01-15 10:30 UTC
+	now := time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		dayOfWeek int
+		hourOfDay int
+		want      time.Time
+	}{
+		{"later this week", int(time.Friday), 8, time.Date(2025, 1, 17, 8, 0, 0, 0, time.UTC)},
+		{"later today", int(time.Wednesday), 12, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)},
+		{"earlier today rolls to next week", int(time.Wednesday), 8, time.Date(2025, 1, 22, 8, 0, 0, 0, time.UTC)},
+		{"earlier this week", int(time.Monday), 8, time.Date(2025, 1, 20, 8, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextReportRun(now, tt.dayOfWeek, tt.hourOfDay); !got.Equal(tt.want) {
+				t.Errorf("nextReportRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// reportSubscriptionStore keeps subscriptions in memory with the same claim rule as the database
+type reportSubscriptionStore struct {
+	repositories.ReportSubscriptionRepository
+	mu            sync.Mutex
+	subscriptions map[uint]models.ReportSubscription
+}
+
+func (r *reportSubscriptionStore) ClaimRun(ctx context.Context, tx *gorm.DB, id uint, dueAt, nextRunAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	subscription, ok := r.subscriptions[id]
+	if !ok || !subscription.NextRunAt.Equal(dueAt) {
+		return false, nil
+	}
+	subscription.NextRunAt = nextRunAt
+	r.subscriptions[id] = subscription
+	return true, nil
+}
+
+func (r *reportSubscriptionStore) SetLastSent(ctx context.Context, tx *gorm.DB, id uint, sentAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if subscription, ok := r.subscriptions[id]; ok {
+		subscription.LastSentAt = &sentAt
+		r.subscriptions[id] = subscription
+	}
+	return nil
+}
+
+type reportRepository struct {
+	MockNotificationRepository
+	subscriptions *reportSubscriptionStore
+}
+
+func (r *reportRepository) ReportSubscription() repositories.ReportSubscriptionRepository {
+	return r.subscriptions
+}
+
+type teacherSummaryAnalytics struct {
+	AnalyticsService
+}
+
+func (teacherSummaryAnalytics) GetTeacherSummary(ctx context.Context, teacherID string, since time.Time) (*TeacherAnalyticsSummary, error) {
+	return &TeacherAnalyticsSummary{}, nil
+}
+
+type reportPublisher struct {
+	mu   sync.Mutex
+	sent int
+	err  error
+}
+
+func (p *reportPublisher) PublishNotificationEvent(ctx context.Context, event *events.NotificationEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	p.sent++
+	return nil
+}
+
+func (p *reportPublisher) Close() error { return nil }
+
+func newReportReplica(store *reportSubscriptionStore, publisher *reportPublisher) *reportService {
+	return NewReportService(&reportRepository{subscriptions: store}, nil, slog.New(slog.DiscardHandler), nil,
+		teacherSummaryAnalytics{}, NewReportRenderer(), publisher).(*reportService)
+}
+
+func TestDeliverReportOncePerRun(t *testing.T) {
+	now := time.Date(2025, 1, 20, 8, 0, 0, 0, time.UTC)
+	due := models.ReportSubscription{
+		ID:         1,
+		UserID:     "teacher-1",
+		ReportType: models.ReportTeacherWeeklySummary,
+		DayOfWeek:  int(time.Monday),
+		HourOfDay:  8,
+		Email:      "teacher@example.com",
+		IsActive:   true,
+		NextRunAt:  now,
+	}
+	store := &reportSubscriptionStore{subscriptions: map[uint]models.ReportSubscription{1: due}}
+	publisher := &reportPublisher{}
+
+	// Both replicas picked the subscription up as due before either delivered it
+	for _, replica := range []*reportService{newReportReplica(store, publisher), newReportReplica(store, publisher)} {
+		subscription := due
+		if _, err := replica.deliverReport(context.Background(), &subscription, now); err != nil {
+			t.Fatalf("deliverReport: %v", err)
+		}
+	}
+
+	if publisher.sent != 1 {
+		t.Errorf("report published %d times, want once", publisher.sent)
+	}
+	if next := store.subscriptions[1].NextRunAt; !next.Equal(now.AddDate(0, 0, 7)) {
+		t.Errorf("next run = %v, want a week later", next)
+	}
+}
+
+func TestDeliverReportReleasesFailedRun(t *testing.T) {
+	now := time.Date(2025, 1, 20, 8, 0, 0, 0, time.UTC)
+	due := models.ReportSubscription{
+		ID:         1,
+		UserID:     "teacher-1",
+		ReportType: models.ReportTeacherWeeklySummary,
+		DayOfWeek:  int(time.Monday),
+		HourOfDay:  8,
+		Email:      "teacher@example.com",
+		NextRunAt:  now,
+	}
+	store := &reportSubscriptionStore{subscriptions: map[uint]models.ReportSubscription{1: due}}
+	publisher := &reportPublisher{err: errors.New("broker unavailable")}
+
+	subscription := due
+	if _, err := newReportReplica(store, publisher).deliverReport(context.Background(), &subscription, now); err == nil {
+		t.Fatal("expected the publish error")
+	}
+	if next := store.subscriptions[1].NextRunAt; !next.Equal(now) {
+		t.Errorf("next run = %v, want the failed run handed back", next)
+	}
+}
+
+func TestDeliverReportKeepsConcurrentEdits(t *testing.T) {
+	now := time.Date(2025, 1, 20, 8, 0, 0, 0, time.UTC)
+	due := models.ReportSubscription{
+		ID:         1,
+		UserID:     "teacher-1",
+		ReportType: models.ReportTeacherWeeklySummary,
+		DayOfWeek:  int(time.Monday),
+		HourOfDay:  8,
+		Email:      "teacher@example.com",
+		IsActive:   true,
+		NextRunAt:  now,
+	}
+	store := &reportSubscriptionStore{subscriptions: map[uint]models.ReportSubscription{1: due}}
+	replica := newReportReplica(store, &reportPublisher{})
+
+	// The teacher changes the address while the report is being sent
+	subscription := due
+	edited := store.subscriptions[1]
+	edited.Email = "new@example.com"
+	store.subscriptions[1] = edited
+	if _, err := replica.deliverReport(context.Background(), &subscription, now); err != nil {
+		t.Fatalf("deliverReport: %v", err)
+	}
+	if got := store.subscriptions[1]; got.Email != "new@example.com" || got.LastSentAt == nil {
+		t.Errorf("expected the edit kept and the delivery recorded, got %+v", got)
+	}
+}
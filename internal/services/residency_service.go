@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/residency"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+// StorageDirs are the directories uploaded files are stored in, one per data residency region.
+// A region without a directory is an error rather than a fall back to the default directory.
+type StorageDirs struct {
+	Default string
+	Regions map[string]string
+}
+
+// For returns the directory of the region the context is routed to
+func (d StorageDirs) For(ctx context.Context) (string, error) {
+	region := residency.Region(ctx)
+	if region == "" {
+		return d.Default, nil
+	}
+	dir, ok := d.Regions[region]
+	if !ok || dir == "" {
+		return "", fmt.Errorf("%w: no storage directory for region %q", ErrResidencyUnavailable, region)
+	}
+	return dir, nil
+}
+
+type residencyService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+	regions   []string
+}
+
+func NewResidencyService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, regions []string) ResidencyService {
+	return &residencyService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+		regions:   regions,
+	}
+}
+
+// ===== PROVISIONING =====
+
+// ProvisionOrganization pins an organization to a region. Provisioning again with the same
+// region is a no-op; moving an organization to another region is refused, since its data
+// already lives in the first one.
+func (s *residencyService) ProvisionOrganization(ctx context.Context, req *ProvisionResidencyRequest, userID string) (*models.OrganizationResidency, error) {
+	s.logger.Info("Provisioning organization residency", "organization", req.Organization, "region", req.Region, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(ctx, userID, "provision_residency"); err != nil {
+		return nil, err
+	}
+
+	organization := strings.TrimSpace(req.Organization)
+	if err := checkResidencyRegion(req.Region, s.regions); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.Residency().GetByOrganization(ctx, nil, organization)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.Region != req.Region {
+			return nil, NewBusinessRuleError("residency_fixed", "organization is already pinned to another region", map[string]interface{}{
+				"organization": organization,
+				"region":       existing.Region,
+			})
+		}
+		return existing, nil
+	}
+
+	assignment := &models.OrganizationResidency{
+		Organization:  organization,
+		Region:        req.Region,
+		ProvisionedBy: userID,
+	}
+	if err := s.repo.Residency().Create(ctx, nil, assignment); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func (s *residencyService) ListOrganizationResidency(ctx context.Context, userID string) (*ResidencyOverview, error) {
+	if err := s.requireAdmin(ctx, userID, "view_residency"); err != nil {
+		return nil, err
+	}
+	assignments, err := s.repo.Residency().List(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ResidencyOverview{
+		Regions:       s.regions,
+		Organizations: assignments,
+	}, nil
+}
+
+// ===== ROUTING =====
+
+// ResolveRegion returns the region the user's data is stored in, empty for the primary
+// database. An organization pinned to a region this instance is not configured for is an
+// error; its data must not be written anywhere else.
+func (s *residencyService) ResolveRegion(ctx context.Context, user *models.User) (string, error) {
+	if user == nil || user.Organization == nil || strings.TrimSpace(*user.Organization) == "" {
+		return "", nil
+	}
+	assignment, err := s.repo.Residency().GetByOrganization(ctx, nil, strings.TrimSpace(*user.Organization))
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get organization residency: %w", err)
+	}
+	if !slices.Contains(s.regions, assignment.Region) {
+		return "", fmt.Errorf("%w: region %q is not configured", ErrResidencyUnavailable, assignment.Region)
+	}
+	return assignment.Region, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (s *residencyService) requireAdmin(ctx context.Context, userID, action string) error {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleAdmin {
+		return NewPermissionError(userID, 0, "residency", action, "only admins may manage data residency")
+	}
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func checkResidencyRegion(region string, regions []string) error {
+	if !slices.Contains(regions, region) {
+		return ValidationErrors{*NewValidationError("region", fmt.Sprintf("unknown region, configured regions: %s", strings.Join(regions, ", ")), region)}
+	}
+	return nil
+}
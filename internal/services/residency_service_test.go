@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/residency"
+)
+
+func TestStorageDirsFor(t *testing.T) {
+	dirs := StorageDirs{
+		Default: "/data/uploads",
+		Regions: map[string]string{"eu": "/data/eu/uploads"},
+	}
+
+	if dir, err := dirs.For(context.Background()); err != nil || dir != "/data/uploads" {
+		t.Errorf("expected the default directory without a region, got %q %v", dir, err)
+	}
+	if dir, err := dirs.For(residency.WithRegion(context.Background(), "eu")); err != nil || dir != "/data/eu/uploads" {
+		t.Errorf("expected the region's directory, got %q %v", dir, err)
+	}
+	// A region without a directory must not fall back to the default
+	if dir, err := dirs.For(residency.WithRegion(context.Background(), "apac")); !errors.Is(err, ErrResidencyUnavailable) || dir != "" {
+		t.Errorf("expected an unavailable region, got %q %v", dir, err)
+	}
+}
+
+func TestCheckResidencyRegion(t *testing.T) {
+	regions := []string{"apac", "eu"}
+	if err := checkResidencyRegion("eu", regions); err != nil {
+		t.Errorf("expected a configured region to pass, got %v", err)
+	}
+	var validationErrors ValidationErrors
+	if err := checkResidencyRegion("us", regions); !errors.As(err, &validationErrors) {
+		t.Errorf("expected a validation error for an unknown region, got %v", err)
+	}
+	if err := checkResidencyRegion("eu", nil); err == nil {
+		t.Error("expected no region to be accepted without residency configured")
+	}
+}
@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+const (
+	resultsReleaseBatch = 100
+
+	// Actor recorded for releases triggered by the scheduler
+	resultsReleaseSystemActor = "system"
+)
+
+type resultsService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+	notifier  NotificationEventService
+}
+
+func NewResultsService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, notifier NotificationEventService) ResultsService {
+	return &resultsService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+		notifier:  notifier,
+	}
+}
+
+// ===== RELEASE STATE =====
+
+func (s *resultsService) GetReleaseStatus(ctx context.Context, assessmentID uint, userID string) (*ResultsReleaseStatus, error) {
+	if err := s.checkAccess(ctx, assessmentID, userID, "view_results_release"); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSettings(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildResultsReleaseStatus(settings, time.Now()), nil
+}
+
+func (s *resultsService) ReleaseResults(ctx context.Context, assessmentID uint, userID string) (*ResultsReleaseStatus, error) {
+	s.logger.Info("Releasing assessment results", "assessment_id", assessmentID, "user_id", userID)
+
+	if err := s.checkAccess(ctx, assessmentID, userID, "release_results"); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSettings(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Releasing twice is a no-op so students are not notified again
+	if settings.ResultsReleasedAt != nil {
+		return buildResultsReleaseStatus(settings, time.Now()), nil
+	}
+
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	released, err := s.release(ctx, settings, user, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !released {
+		// Released concurrently; report the release that won
+		if settings, err = s.getSettings(ctx, assessmentID); err != nil {
+			return nil, err
+		}
+	}
+
+	return buildResultsReleaseStatus(settings, time.Now()), nil
+}
+
+// ===== SCHEDULED RELEASES =====
+
+func (s *resultsService) ReleaseScheduledResults(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.repo.AssessmentSettings().GetDueResultsReleases(ctx, nil, now, resultsReleaseBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, settings := range due {
+		ok, err := s.release(ctx, settings, nil, now)
+		if err != nil {
+			s.logger.Error("Failed to release scheduled results",
+				"assessment_id", settings.AssessmentID,
+				"error", err)
+			continue
+		}
+		if ok {
+			released++
+		}
+	}
+
+	if len(due) > 0 {
+		s.logger.Info("Scheduled results released", "due", len(due), "released", released)
+	}
+
+	return released, nil
+}
+
+// RunScheduler releases scheduled results every interval until the context is cancelled
+func (s *resultsService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.Info("Results release scheduler started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Results release scheduler stopped")
+			return
+		case now := <-ticker.C:
+			if _, err := s.ReleaseScheduledResults(ctx, now); err != nil {
+				s.logger.Error("Failed to run scheduled results releases", "error", err)
+			}
+		}
+	}
+}
+
+// ===== HELPER METHODS =====
+
+// release records the release and its audit entry, then notifies students. It reports false
+// when another replica or request released the results first, which leaves nothing to do.
+// A nil actor marks a release triggered by the scheduler.
+func (s *resultsService) release(ctx context.Context, settings *models.AssessmentSettings, actor *models.User, now time.Time) (bool, error) {
+	actorID := resultsReleaseSystemActor
+	entry := &models.AuditLog{
+		EventType:       models.AuditResultsReleased,
+		UserID:          actorID,
+		TargetType:      "assessment",
+		TargetID:        &settings.AssessmentID,
+		Description:     fmt.Sprintf("Results of assessment %d released", settings.AssessmentID),
+		ComplianceLevel: "medium",
+	}
+	if actor != nil {
+		actorID = actor.ID
+		entry.UserID = actor.ID
+		entry.UserEmail = actor.Email
+		entry.UserRole = actor.Role
+	}
+
+	changes, err := json.Marshal(map[string]interface{}{
+		"results_released_at": map[string]interface{}{"before": nil, "after": now},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to encode audit changes: %w", err)
+	}
+	metadata, err := json.Marshal(map[string]interface{}{
+		"mode":       settings.ResultsReleaseMode,
+		"release_at": settings.ResultsReleaseAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to encode audit metadata: %w", err)
+	}
+	entry.Changes = changes
+	entry.Metadata = metadata
+
+	released := false
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		if released, err = s.repo.AssessmentSettings().ReleaseResults(ctx, tx, settings.AssessmentID, now, actorID); err != nil || !released {
+			return err
+		}
+		return s.repo.AuditLog().Create(ctx, tx, entry)
+	})
+	if err != nil || !released {
+		return false, err
+	}
+	settings.ResultsReleasedAt = timePtr(now)
+	settings.ResultsReleasedBy = stringPtr(actorID)
+
+	// Notification failures must not undo the release
+	if err := s.notifier.NotifyResultsReleased(ctx, settings.AssessmentID, actorID); err != nil {
+		s.logger.Error("Failed to notify students of released results",
+			"assessment_id", settings.AssessmentID,
+			"error", err)
+	}
+
+	return true, nil
+}
+
+func (s *resultsService) checkAccess(ctx context.Context, assessmentID uint, userID, action string) error {
+	assessmentService := NewAssessmentService(s.repo, s.db, s.logger, s.validator)
+	canAccess, err := assessmentService.CanAccess(ctx, assessmentID, userID)
+	if err != nil {
+		return err
+	}
+	if !canAccess {
+		return NewPermissionError(userID, assessmentID, "assessment", action, "not owner or insufficient permissions")
+	}
+	return nil
+}
+
+func (s *resultsService) getSettings(ctx context.Context, assessmentID uint) (*models.AssessmentSettings, error) {
+	settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, nil, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment settings: %w", err)
+	}
+	return settings, nil
+}
+
+func buildResultsReleaseStatus(settings *models.AssessmentSettings, now time.Time) *ResultsReleaseStatus {
+	return &ResultsReleaseStatus{
+		AssessmentID:     settings.AssessmentID,
+		Mode:             settings.ResultsReleaseMode,
+		ReleaseAt:        settings.ResultsReleaseAt,
+		Released:         resultsReleased(settings, now),
+		ReleasedAt:       settings.ResultsReleasedAt,
+		ReleasedBy:       settings.ResultsReleasedBy,
+		AnonymousGrading: settings.AnonymousGrading,
+	}
+}
+
+// resultsReleased reports whether students may see scores and reviews.
+// Assessments without settings keep the historical behaviour of immediate results.
+func resultsReleased(settings *models.AssessmentSettings, now time.Time) bool {
+	if settings == nil || settings.ResultsReleasedAt != nil {
+		return true
+	}
+
+	switch settings.ResultsReleaseMode {
+	case models.ResultsReleaseManual:
+		return false
+	case models.ResultsReleaseScheduled:
+		return settings.ResultsReleaseAt != nil && !now.Before(*settings.ResultsReleaseAt)
+	default:
+		// Blind marking holds immediate results back until an explicit release
+		return !settings.AnonymousGrading
+	}
+}
+
+// areResultsReleased loads the assessment settings and applies resultsReleased.
+// It fails closed: on error results are treated as not released.
+func areResultsReleased(ctx context.Context, repo repositories.Repository, assessmentID uint) (bool, error) {
+	settings, err := repo.AssessmentSettings().GetByAssessmentID(ctx, nil, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return resultsReleased(nil, time.Now()), nil
+		}
+		return false, fmt.Errorf("failed to get assessment settings: %w", err)
+	}
+	return resultsReleased(settings, time.Now()), nil
+}
@@ -0,0 +1,370 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+// Identifiers shorter than this are not searched for in free text; they would match
+// inside ordinary words
+const minRedactedIdentifierLength = 3
+
+// ===== RESULTS EXPORT =====
+
+// ExportResults writes the finished attempts of an assessment, or their answers, as CSV.
+// Redacted exports are required for recipients outside the institution: student IDs and
+// names become per-assessment aliases, and the student's name and email are also replaced
+// in answer texts and feedback. Every export is audit logged.
+func (s *resultsService) ExportResults(ctx context.Context, assessmentID uint, req *ResultsExportRequest, userID string) (*ExportedDocument, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	content, recipient := req.Content, req.Recipient
+	if content == "" {
+		content = ResultsExportAttempts
+	}
+	if recipient == "" {
+		recipient = ExportRecipientInternal
+	}
+	if recipient != ExportRecipientInternal && !req.Redact {
+		return nil, NewBusinessRuleError("redaction_required",
+			"exports for external examiners and researchers must be redacted",
+			map[string]interface{}{"recipient": recipient})
+	}
+
+	if err := s.checkAccess(ctx, assessmentID, userID, "export_results"); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSettings(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Redact {
+		if settings.ExportAliasSalt, err = s.exportAliasSalt(ctx, settings); err != nil {
+			return nil, err
+		}
+	}
+
+	attempts, err := s.repo.Attempt().GetFinishedByAssessment(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	students, err := s.exportStudents(ctx, attempts)
+	if err != nil {
+		return nil, err
+	}
+
+	var pseudonymizer *exportPseudonymizer
+	if req.Redact {
+		pseudonymizer = &exportPseudonymizer{salt: settings.ExportAliasSalt, students: students}
+	}
+
+	// Attempt rows carry a cf_<key> column per custom attempt field of the owner's organization
+	var customFields []*models.CustomFieldDefinition
+	if content == ResultsExportAttempts {
+		if customFields, err = s.exportCustomFields(ctx, assessmentID); err != nil {
+			return nil, err
+		}
+	}
+
+	records := [][]string{append(resultsExportHeader(content, req.Redact), customFieldExportHeader(customFields)...)}
+	for _, attempt := range attempts {
+		identity := studentExportColumns(attempt.StudentID, students[attempt.StudentID], pseudonymizer)
+		if content == ResultsExportAttempts {
+			values := customFieldExportRecord(customFields, attempt.CustomFields)
+			if pseudonymizer != nil {
+				for i := range values {
+					values[i] = pseudonymizer.redact(values[i], attempt.StudentID)
+				}
+			}
+			records = append(records, append(attemptExportRecord(identity, attempt), values...))
+			continue
+		}
+
+		answers, err := s.repo.Answer().GetByAttempt(ctx, nil, attempt.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get answers: %w", err)
+		}
+		for _, answer := range answers {
+			records = append(records, answerExportRecord(identity, attempt, answer, pseudonymizer))
+		}
+	}
+
+	data, err := writeExportCSV(records)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.auditExport(ctx, assessmentID, userID, content, recipient, req.Redact, len(records)-1); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Results exported",
+		"assessment_id", assessmentID,
+		"user_id", userID,
+		"content", content,
+		"recipient", recipient,
+		"redacted", req.Redact,
+		"rows", len(records)-1)
+
+	suffix := ""
+	if req.Redact {
+		suffix = "-redacted"
+	}
+	return &ExportedDocument{
+		FileName:    fmt.Sprintf("assessment-%d-%s%s.csv", assessmentID, content, suffix),
+		ContentType: "text/csv",
+		Content:     data,
+	}, nil
+}
+
+// ===== HELPER METHODS =====
+
+func (s *resultsService) exportStudents(ctx context.Context, attempts []*models.AssessmentAttempt) (map[string]*models.User, error) {
+	ids := make([]string, 0, len(attempts))
+	seen := make(map[string]bool, len(attempts))
+	for _, attempt := range attempts {
+		if !seen[attempt.StudentID] {
+			seen[attempt.StudentID] = true
+			ids = append(ids, attempt.StudentID)
+		}
+	}
+
+	students := make(map[string]*models.User, len(ids))
+	if len(ids) == 0 {
+		return students, nil
+	}
+	users, err := s.repo.User().GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get students: %w", err)
+	}
+	for _, user := range users {
+		students[user.ID] = user
+	}
+	return students, nil
+}
+
+func (s *resultsService) exportCustomFields(ctx context.Context, assessmentID uint) ([]*models.CustomFieldDefinition, error) {
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	return ownerCustomFields(ctx, s.repo, assessment.CreatedBy, models.CustomFieldEntityAttempt)
+}
+
+func (s *resultsService) auditExport(ctx context.Context, assessmentID uint, userID string, content ResultsExportContent, recipient ExportRecipient, redacted bool, rows int) error {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	metadata, err := json.Marshal(map[string]interface{}{
+		"content":   content,
+		"recipient": recipient,
+		"redacted":  redacted,
+		"rows":      rows,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode audit metadata: %w", err)
+	}
+
+	complianceLevel := "high"
+	if redacted {
+		complianceLevel = "medium"
+	}
+	return s.repo.AuditLog().Create(ctx, nil, &models.AuditLog{
+		EventType:       models.AuditDataExported,
+		UserID:          user.ID,
+		UserEmail:       user.Email,
+		UserRole:        user.Role,
+		TargetType:      "assessment",
+		TargetID:        &assessmentID,
+		Description:     fmt.Sprintf("Exported %s of assessment %d for %s", content, assessmentID, recipient),
+		Metadata:        metadata,
+		ComplianceLevel: complianceLevel,
+	})
+}
+
+// exportAliasSalt returns the assessment's alias salt, storing a new one on its first
+// redacted export
+func (s *resultsService) exportAliasSalt(ctx context.Context, settings *models.AssessmentSettings) (string, error) {
+	if settings.ExportAliasSalt != "" {
+		return settings.ExportAliasSalt, nil
+	}
+
+	salt, err := newExportAliasSalt()
+	if err != nil {
+		return "", err
+	}
+	set, err := s.repo.AssessmentSettings().SetExportAliasSalt(ctx, nil, settings.AssessmentID, salt)
+	if err != nil {
+		return "", err
+	}
+	if set {
+		return salt, nil
+	}
+
+	// A concurrent export stored its salt first; aliases must come from that one
+	current, err := s.getSettings(ctx, settings.AssessmentID)
+	if err != nil {
+		return "", err
+	}
+	if current.ExportAliasSalt == "" {
+		return "", fmt.Errorf("export alias salt of assessment %d was not stored", settings.AssessmentID)
+	}
+	return current.ExportAliasSalt, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// exportPseudonymizer replaces student identities in an export with aliases that are
+// stable within one assessment but cannot be linked back without its salt
+type exportPseudonymizer struct {
+	salt     string
+	students map[string]*models.User
+}
+
+func (p *exportPseudonymizer) alias(studentID string) string {
+	mac := hmac.New(sha256.New, []byte(p.salt))
+	mac.Write([]byte(studentID))
+	return "Participant " + strings.ToUpper(hex.EncodeToString(mac.Sum(nil))[:10])
+}
+
+// redact replaces the student's ID, name and email wherever they appear in text
+func (p *exportPseudonymizer) redact(text, studentID string) string {
+	identifiers := []string{studentID}
+	if student := p.students[studentID]; student != nil {
+		identifiers = append(identifiers, student.Email, student.FullName)
+	}
+
+	alias := p.alias(studentID)
+	for _, identifier := range identifiers {
+		identifier = strings.TrimSpace(identifier)
+		if len(identifier) < minRedactedIdentifierLength {
+			continue
+		}
+		pattern := regexp.MustCompile(`(?i)` + regexp.QuoteMeta(identifier))
+		text = pattern.ReplaceAllLiteralString(text, alias)
+	}
+	return text
+}
+
+func resultsExportHeader(content ResultsExportContent, redacted bool) []string {
+	header := []string{"student_id", "student_name"}
+	if redacted {
+		header = []string{"participant"}
+	}
+	header = append(header, "attempt_number")
+	if content == ResultsExportAnswers {
+		return append(header, "question_id", "answer", "score", "max_score", "is_correct", "grading_status", "feedback")
+	}
+	return append(header, "status", "started_at", "completed_at", "time_spent_seconds", "score", "max_score", "percentage", "passed")
+}
+
+// studentExportColumns returns the columns identifying the student of a row
+func studentExportColumns(studentID string, student *models.User, pseudonymizer *exportPseudonymizer) []string {
+	if pseudonymizer != nil {
+		return []string{pseudonymizer.alias(studentID)}
+	}
+	name := ""
+	if student != nil {
+		name = student.FullName
+	}
+	return []string{studentID, name}
+}
+
+func attemptExportRecord(identity []string, attempt *models.AssessmentAttempt) []string {
+	return append(append([]string{}, identity...),
+		strconv.Itoa(attempt.AttemptNumber),
+		string(attempt.Status),
+		exportTime(attempt.StartedAt),
+		exportTime(attempt.CompletedAt),
+		strconv.Itoa(attempt.TimeSpent),
+		strconv.FormatFloat(attempt.Score, 'f', -1, 64),
+		strconv.Itoa(attempt.MaxScore),
+		strconv.FormatFloat(attempt.Percentage, 'f', 2, 64),
+		strconv.FormatBool(attempt.Passed),
+	)
+}
+
+func answerExportRecord(identity []string, attempt *models.AssessmentAttempt, answer *models.StudentAnswer, pseudonymizer *exportPseudonymizer) []string {
+	text := string(answer.Answer)
+	feedback := ""
+	if answer.Feedback != nil {
+		feedback = *answer.Feedback
+	}
+	if pseudonymizer != nil {
+		text = pseudonymizer.redact(text, attempt.StudentID)
+		feedback = pseudonymizer.redact(feedback, attempt.StudentID)
+	}
+
+	isCorrect := ""
+	if answer.IsCorrect != nil {
+		isCorrect = strconv.FormatBool(*answer.IsCorrect)
+	}
+	return append(append([]string{}, identity...),
+		strconv.Itoa(attempt.AttemptNumber),
+		strconv.FormatUint(uint64(answer.QuestionID), 10),
+		text,
+		strconv.FormatFloat(answer.Score, 'f', -1, 64),
+		strconv.Itoa(answer.MaxScore),
+		isCorrect,
+		string(answer.GradingStatus),
+		feedback,
+	)
+}
+
+func customFieldExportHeader(definitions []*models.CustomFieldDefinition) []string {
+	header := make([]string, 0, len(definitions))
+	for _, definition := range definitions {
+		header = append(header, customFieldExportPrefix+definition.Key)
+	}
+	return header
+}
+
+func customFieldExportRecord(definitions []*models.CustomFieldDefinition, data datatypes.JSON) []string {
+	values := decodeCustomFields(data)
+	record := make([]string, 0, len(definitions))
+	for _, definition := range definitions {
+		record = append(record, customFieldExportValue(values, definition.Key))
+	}
+	return record
+}
+
+func exportTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.UTC().Format(time.RFC3339)
+}
+
+func writeExportCSV(records [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(records); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func newExportAliasSalt() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate export alias salt: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
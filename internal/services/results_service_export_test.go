@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+func TestExportPseudonymizerAlias(t *testing.T) {
+	p := &exportPseudonymizer{salt: "salt-a"}
+	if p.alias("student-1") != p.alias("student-1") {
+		t.Error("expected the same alias for the same student")
+	}
+	if p.alias("student-1") == p.alias("student-2") {
+		t.Error("expected different students to get different aliases")
+	}
+	other := &exportPseudonymizer{salt: "salt-b"}
+	if p.alias("student-1") == other.alias("student-1") {
+		t.Error("expected aliases to differ between assessments")
+	}
+	if !strings.HasPrefix(p.alias("student-1"), "Participant ") || strings.Contains(p.alias("student-1"), "student-1") {
+		t.Errorf("unexpected alias %q", p.alias("student-1"))
+	}
+}
+
+func TestExportPseudonymizerRedact(t *testing.T) {
+	p := &exportPseudonymizer{
+		salt: "salt",
+		students: map[string]*models.User{
+			"s-123": {ID: "s-123", FullName: "Ada Lovelace", Email: "ada@example.com"},
+		},
+	}
+	alias := p.alias("s-123")
+
+	got := p.redact(`{"text":"I, ADA LOVELACE (ada@example.com, id s-123), argue that..."}`, "s-123")
+	if strings.Contains(strings.ToLower(got), "lovelace") || strings.Contains(got, "ada@example.com") || strings.Contains(got, "s-123") {
+		t.Errorf("expected identifiers to be replaced, got %s", got)
+	}
+	if strings.Count(got, alias) != 3 {
+		t.Errorf("expected each identifier replaced by the alias, got %s", got)
+	}
+
+	if text := p.redact("Well argued.", "unknown"); text != "Well argued." {
+		t.Errorf("expected text without identifiers to be kept, got %q", text)
+	}
+}
+
+func TestResultsExportRecords(t *testing.T) {
+	started := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	attempt := &models.AssessmentAttempt{
+		StudentID:     "s-123",
+		AttemptNumber: 2,
+		Status:        models.AttemptCompleted,
+		StartedAt:     &started,
+		Score:         7.5,
+		MaxScore:      10,
+		Percentage:    75,
+		Passed:        true,
+	}
+	student := &models.User{ID: "s-123", FullName: "Ada Lovelace", Email: "ada@example.com"}
+
+	header := resultsExportHeader(ResultsExportAttempts, false)
+	record := attemptExportRecord(studentExportColumns("s-123", student, nil), attempt)
+	if len(record) != len(header) || record[0] != "s-123" || record[1] != "Ada Lovelace" {
+		t.Errorf("unexpected record %v for header %v", record, header)
+	}
+
+	p := &exportPseudonymizer{salt: "salt", students: map[string]*models.User{"s-123": student}}
+	redactedHeader := resultsExportHeader(ResultsExportAnswers, true)
+	feedback := "Good work, Ada Lovelace."
+	answer := &models.StudentAnswer{QuestionID: 4, Answer: []byte(`{"text":"by Ada Lovelace"}`), Feedback: &feedback}
+	redacted := answerExportRecord(studentExportColumns("s-123", student, p), attempt, answer, p)
+	if len(redacted) != len(redactedHeader) || redactedHeader[0] != "participant" {
+		t.Fatalf("unexpected record %v for header %v", redacted, redactedHeader)
+	}
+	for _, value := range redacted {
+		if strings.Contains(value, "Lovelace") || strings.Contains(value, "s-123") {
+			t.Errorf("redacted record leaks identity: %v", redacted)
+		}
+	}
+}
+
+// aliasSaltStore stores the first salt set, as the conditional update does
+type aliasSaltStore struct {
+	repositories.AssessmentSettingsRepository
+	mu   sync.Mutex
+	salt string
+}
+
+func (a *aliasSaltStore) GetByAssessmentID(ctx context.Context, tx *gorm.DB, assessmentID uint) (*models.AssessmentSettings, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return &models.AssessmentSettings{AssessmentID: assessmentID, ExportAliasSalt: a.salt}, nil
+}
+
+func (a *aliasSaltStore) SetExportAliasSalt(ctx context.Context, tx *gorm.DB, assessmentID uint, salt string) (bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.salt != "" {
+		return false, nil
+	}
+	a.salt = salt
+	return true, nil
+}
+
+type aliasSaltRepository struct {
+	MockNotificationRepository
+	settings *aliasSaltStore
+}
+
+func (r *aliasSaltRepository) AssessmentSettings() repositories.AssessmentSettingsRepository {
+	return r.settings
+}
+
+func TestExportAliasSaltSharedByConcurrentExports(t *testing.T) {
+	store := &aliasSaltStore{}
+	service := NewResultsService(&aliasSaltRepository{settings: store}, nil, slog.New(slog.DiscardHandler), nil, nil).(*resultsService)
+
+	// Both exports read the settings before either stored a salt
+	var salts []string
+	for range 2 {
+		salt, err := service.exportAliasSalt(context.Background(), &models.AssessmentSettings{AssessmentID: 4})
+		if err != nil {
+			t.Fatalf("exportAliasSalt: %v", err)
+		}
+		salts = append(salts, salt)
+	}
+
+	if salts[0] == "" || salts[0] != salts[1] || salts[0] != store.salt {
+		t.Errorf("expected both exports to use the stored salt %q, got %v", store.salt, salts)
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// ===== FINAL GRADES =====
+
+func (s *resultsService) GetFinalGrades(ctx context.Context, assessmentID uint, userID string) (*FinalGradesReport, error) {
+	if err := s.checkAccess(ctx, assessmentID, userID, "view_final_grades"); err != nil {
+		return nil, err
+	}
+
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	policy, err := getGradePolicy(ctx, s.repo, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.repo.Attempt().GetFinishedByAssessment(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FinalGradesReport{
+		AssessmentID: assessmentID,
+		Policy:       policy,
+		PassingScore: assessment.PassingScore,
+		Grades:       computeFinalGrades(attempts, policy, assessment.PassingScore),
+	}, nil
+}
+
+// ===== HELPER METHODS =====
+
+// getGradePolicy returns the assessment's grade policy; assessments without settings
+// count the highest attempt
+func getGradePolicy(ctx context.Context, repo repositories.Repository, assessmentID uint) (models.GradePolicy, error) {
+	settings, err := repo.AssessmentSettings().GetByAssessmentID(ctx, nil, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return models.GradePolicyHighest, nil
+		}
+		return "", fmt.Errorf("failed to get assessment settings: %w", err)
+	}
+	if settings.GradePolicy == "" {
+		return models.GradePolicyHighest, nil
+	}
+	return settings.GradePolicy, nil
+}
+
+// computeFinalGrades applies the grade policy to each student's finished attempts,
+// ordered by student ID
+func computeFinalGrades(attempts []*models.AssessmentAttempt, policy models.GradePolicy, passingScore int) []FinalGrade {
+	byStudent := make(map[string][]*models.AssessmentAttempt)
+	for _, attempt := range attempts {
+		byStudent[attempt.StudentID] = append(byStudent[attempt.StudentID], attempt)
+	}
+
+	grades := make([]FinalGrade, 0, len(byStudent))
+	for _, studentAttempts := range byStudent {
+		if grade := computeFinalGrade(studentAttempts, policy, passingScore); grade != nil {
+			grades = append(grades, *grade)
+		}
+	}
+	sort.Slice(grades, func(i, j int) bool {
+		return grades[i].StudentID < grades[j].StudentID
+	})
+	return grades
+}
+
+// computeFinalGrade applies the grade policy to one student's attempts. Only completed and
+// timed out attempts count; it returns nil when the student has none. A late attempt is
+// picked up on the next call, so final grades never need to be stored.
+func computeFinalGrade(attempts []*models.AssessmentAttempt, policy models.GradePolicy, passingScore int) *FinalGrade {
+	var finished []*models.AssessmentAttempt
+	for _, attempt := range attempts {
+		if attempt.Status == models.AttemptCompleted || attempt.Status == models.AttemptTimeOut {
+			finished = append(finished, attempt)
+		}
+	}
+	if len(finished) == 0 {
+		return nil
+	}
+
+	// Oldest first, so the last attempt is the latest
+	sort.Slice(finished, func(i, j int) bool {
+		if finished[i].AttemptNumber != finished[j].AttemptNumber {
+			return finished[i].AttemptNumber < finished[j].AttemptNumber
+		}
+		return finished[i].ID < finished[j].ID
+	})
+	latest := finished[len(finished)-1]
+
+	grade := &FinalGrade{
+		StudentID:       latest.StudentID,
+		Policy:          policy,
+		AttemptsCounted: 1,
+	}
+
+	switch policy {
+	case models.GradePolicyAverage:
+		for _, attempt := range finished {
+			grade.Score += attempt.Score
+			grade.Percentage += attempt.Percentage
+		}
+		grade.Score /= float64(len(finished))
+		grade.Percentage /= float64(len(finished))
+		grade.MaxScore = latest.MaxScore
+		grade.Passed = grade.Percentage >= float64(passingScore)
+		grade.AttemptsCounted = len(finished)
+		return grade
+	case models.GradePolicyLatest:
+		setFinalGradeAttempt(grade, latest)
+	default:
+		// Ties go to the later attempt
+		best := finished[0]
+		for _, attempt := range finished[1:] {
+			if attempt.Percentage >= best.Percentage {
+				best = attempt
+			}
+		}
+		setFinalGradeAttempt(grade, best)
+	}
+	return grade
+}
+
+func setFinalGradeAttempt(grade *FinalGrade, attempt *models.AssessmentAttempt) {
+	attemptID := attempt.ID
+	grade.AttemptID = &attemptID
+	grade.Score = attempt.Score
+	grade.MaxScore = attempt.MaxScore
+	grade.Percentage = attempt.Percentage
+	grade.Passed = attempt.Passed
+}
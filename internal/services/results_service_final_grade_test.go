@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func finalGradeAttempts() []*models.AssessmentAttempt {
+	return []*models.AssessmentAttempt{
+		{ID: 1, StudentID: "s1", AttemptNumber: 1, Status: models.AttemptCompleted, Score: 8, MaxScore: 10, Percentage: 80, Passed: true},
+		{ID: 2, StudentID: "s1", AttemptNumber: 2, Status: models.AttemptTimeOut, Score: 4, MaxScore: 10, Percentage: 40},
+		{ID: 3, StudentID: "s1", AttemptNumber: 3, Status: models.AttemptInProgress, Score: 10, MaxScore: 10, Percentage: 100, Passed: true},
+	}
+}
+
+func TestComputeFinalGradeHighest(t *testing.T) {
+	grade := computeFinalGrade(finalGradeAttempts(), models.GradePolicyHighest, 60)
+	if grade == nil || grade.AttemptID == nil || *grade.AttemptID != 1 || grade.Percentage != 80 || !grade.Passed {
+		t.Errorf("expected best finished attempt to count, got %+v", grade)
+	}
+}
+
+func TestComputeFinalGradeLatest(t *testing.T) {
+	grade := computeFinalGrade(finalGradeAttempts(), models.GradePolicyLatest, 60)
+	if grade == nil || grade.AttemptID == nil || *grade.AttemptID != 2 || grade.Percentage != 40 || grade.Passed {
+		t.Errorf("expected latest finished attempt to count, got %+v", grade)
+	}
+}
+
+func TestComputeFinalGradeAverage(t *testing.T) {
+	grade := computeFinalGrade(finalGradeAttempts(), models.GradePolicyAverage, 60)
+	if grade == nil || grade.AttemptID != nil || grade.AttemptsCounted != 2 {
+		t.Fatalf("expected an average over both finished attempts, got %+v", grade)
+	}
+	if grade.Score != 6 || grade.Percentage != 60 || grade.MaxScore != 10 || !grade.Passed {
+		t.Errorf("unexpected average grade %+v", grade)
+	}
+}
+
+func TestComputeFinalGradeWithoutFinishedAttempts(t *testing.T) {
+	attempts := []*models.AssessmentAttempt{{ID: 1, StudentID: "s1", Status: models.AttemptInProgress}}
+	if grade := computeFinalGrade(attempts, models.GradePolicyHighest, 60); grade != nil {
+		t.Errorf("expected no final grade, got %+v", grade)
+	}
+}
+
+func TestComputeFinalGrades(t *testing.T) {
+	attempts := append(finalGradeAttempts(),
+		&models.AssessmentAttempt{ID: 4, StudentID: "a0", AttemptNumber: 1, Status: models.AttemptCompleted, Percentage: 55},
+		&models.AssessmentAttempt{ID: 5, StudentID: "z9", AttemptNumber: 1, Status: models.AttemptAbandoned},
+	)
+
+	grades := computeFinalGrades(attempts, models.GradePolicyHighest, 60)
+	if len(grades) != 2 || grades[0].StudentID != "a0" || grades[1].StudentID != "s1" {
+		t.Errorf("expected a grade per student with finished attempts ordered by student, got %+v", grades)
+	}
+}
@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+const (
+	defaultResearchBands              = 4
+	defaultResearchMinStudentsPerBand = 5
+)
+
+// ===== RESEARCH POLICIES =====
+
+// SetResearchPolicy creates or replaces an organization's research policy. Sampling can only
+// be enabled with the students' consent and an ethics approval that has not ended.
+func (s *resultsService) SetResearchPolicy(ctx context.Context, req *SetResearchPolicyRequest, userID string) (*models.ResearchPolicy, error) {
+	s.logger.Info("Setting research policy", "organization", req.Organization, "sampling_enabled", req.SamplingEnabled, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(ctx, userID, "manage_research_policy"); err != nil {
+		return nil, err
+	}
+
+	organization := strings.TrimSpace(req.Organization)
+	policy, err := s.repo.ResearchPolicy().GetByOrganization(ctx, nil, organization)
+	if err != nil && !repositories.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get research policy: %w", err)
+	}
+	exists := policy != nil
+	if !exists {
+		policy = &models.ResearchPolicy{
+			Organization:       organization,
+			MinStudentsPerBand: defaultResearchMinStudentsPerBand,
+		}
+	}
+
+	policy.SamplingEnabled = req.SamplingEnabled
+	policy.ConsentObtained = req.ConsentObtained
+	policy.EthicsApproval = strings.TrimSpace(req.EthicsApproval)
+	policy.ApprovedUntil = req.ApprovedUntil
+	policy.AllowAnswerText = req.AllowAnswerText
+	if req.MinStudentsPerBand != nil {
+		policy.MinStudentsPerBand = *req.MinStudentsPerBand
+	}
+	policy.UpdatedBy = userID
+
+	if policy.SamplingEnabled {
+		if refusal := researchSamplingRefusal(policy, time.Now()); refusal != "" {
+			return nil, ValidationErrors{*NewValidationError("sampling_enabled", refusal, true)}
+		}
+	}
+
+	if exists {
+		err = s.repo.ResearchPolicy().Update(ctx, nil, policy)
+	} else {
+		err = s.repo.ResearchPolicy().Create(ctx, nil, policy)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (s *resultsService) ListResearchPolicies(ctx context.Context, userID string) ([]*models.ResearchPolicy, error) {
+	if err := s.requireAdmin(ctx, userID, "view_research_policies"); err != nil {
+		return nil, err
+	}
+	return s.repo.ResearchPolicy().List(ctx, nil)
+}
+
+// ===== RESEARCH SAMPLES =====
+
+// SampleForResearch draws a random sample of the students who finished an assessment,
+// stratified by the score of their latest finished attempt. Students appear under aliases
+// that differ in every sample, so samples cannot be joined with each other or with exports.
+// Score bands holding fewer students than the organization's minimum are left out.
+func (s *resultsService) SampleForResearch(ctx context.Context, assessmentID uint, req *ResearchSampleRequest, userID string) (*ResearchSample, error) {
+	s.logger.Info("Sampling assessment for research", "assessment_id", assessmentID, "sample_size", req.SampleSize, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if err := s.checkAccess(ctx, assessmentID, userID, "sample_for_research"); err != nil {
+		return nil, err
+	}
+
+	policy, err := s.researchPolicy(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if refusal := researchSamplingRefusal(policy, time.Now()); refusal != "" {
+		return nil, NewBusinessRuleError("research_not_permitted", refusal,
+			map[string]interface{}{"organization": policy.Organization})
+	}
+
+	attempts, err := s.repo.Attempt().GetFinishedByAssessment(ctx, nil, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	bands := req.Bands
+	if bands == 0 {
+		bands = defaultResearchBands
+	}
+	seed := time.Now().UnixNano()
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+	sampleBands, sampled := drawResearchSample(latestFinishedAttempts(attempts), bands, policy.MinStudentsPerBand, req.SampleSize, rand.New(rand.NewSource(seed)))
+
+	// A fresh salt per sample keeps aliases from linking samples together
+	salt, err := newExportAliasSalt()
+	if err != nil {
+		return nil, err
+	}
+	students, err := s.exportStudents(ctx, sampled)
+	if err != nil {
+		return nil, err
+	}
+	pseudonymizer := &exportPseudonymizer{salt: salt, students: students}
+
+	sample := &ResearchSample{
+		AssessmentID:   assessmentID,
+		Organization:   policy.Organization,
+		EthicsApproval: policy.EthicsApproval,
+		Seed:           seed,
+		Bands:          sampleBands,
+		Participants:   make([]ResearchParticipant, 0, len(sampled)),
+		GeneratedAt:    time.Now(),
+	}
+	for _, attempt := range sampled {
+		participant := ResearchParticipant{
+			Participant: pseudonymizer.alias(attempt.StudentID),
+			Band:        researchScoreBand(attempt.Percentage, bands),
+			Percentage:  attempt.Percentage,
+			Score:       attempt.Score,
+			MaxScore:    attempt.MaxScore,
+			Passed:      attempt.Passed,
+			TimeSpent:   attempt.TimeSpent,
+		}
+		if req.IncludeAnswers {
+			answers, err := s.repo.Answer().GetByAttempt(ctx, nil, attempt.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get answers: %w", err)
+			}
+			participant.Answers = researchSampleAnswers(answers, attempt.StudentID, pseudonymizer, policy.AllowAnswerText)
+		}
+		sample.Participants = append(sample.Participants, participant)
+	}
+
+	if err := s.auditResearchSample(ctx, assessmentID, userID, policy, sample, req.IncludeAnswers); err != nil {
+		return nil, err
+	}
+	return sample, nil
+}
+
+// ===== HELPER METHODS =====
+
+// researchPolicy returns the research policy of the organization owning the assessment
+func (s *resultsService) researchPolicy(ctx context.Context, assessmentID uint) (*models.ResearchPolicy, error) {
+	assessment, err := s.repo.Assessment().GetByID(ctx, nil, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	owner, err := s.repo.User().GetByID(ctx, assessment.CreatedBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	noPolicy := NewBusinessRuleError("research_not_permitted",
+		"the assessment's organization has no research policy",
+		map[string]interface{}{"assessment_id": assessmentID})
+	if owner.Organization == nil || strings.TrimSpace(*owner.Organization) == "" {
+		return nil, noPolicy
+	}
+	policy, err := s.repo.ResearchPolicy().GetByOrganization(ctx, nil, strings.TrimSpace(*owner.Organization))
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, noPolicy
+		}
+		return nil, fmt.Errorf("failed to get research policy: %w", err)
+	}
+	return policy, nil
+}
+
+func (s *resultsService) requireAdmin(ctx context.Context, userID, action string) error {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleAdmin {
+		return NewPermissionError(userID, 0, "research_policy", action, "admins only")
+	}
+	return nil
+}
+
+func (s *resultsService) auditResearchSample(ctx context.Context, assessmentID uint, userID string, policy *models.ResearchPolicy, sample *ResearchSample, withAnswers bool) error {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	metadata, err := json.Marshal(map[string]interface{}{
+		"recipient":       ExportRecipientResearcher,
+		"ethics_approval": policy.EthicsApproval,
+		"seed":            sample.Seed,
+		"participants":    len(sample.Participants),
+		"with_answers":    withAnswers,
+		"answer_text":     withAnswers && policy.AllowAnswerText,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode audit metadata: %w", err)
+	}
+
+	return s.repo.AuditLog().Create(ctx, nil, &models.AuditLog{
+		EventType:       models.AuditDataExported,
+		UserID:          user.ID,
+		UserEmail:       user.Email,
+		UserRole:        user.Role,
+		TargetType:      "assessment",
+		TargetID:        &assessmentID,
+		Description:     fmt.Sprintf("Sampled %d participants of assessment %d for research under %s", len(sample.Participants), assessmentID, policy.EthicsApproval),
+		Metadata:        metadata,
+		ComplianceLevel: "medium",
+	})
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// researchSamplingRefusal explains why the policy does not allow sampling, or returns ""
+func researchSamplingRefusal(policy *models.ResearchPolicy, now time.Time) string {
+	switch {
+	case !policy.SamplingEnabled:
+		return "the organization has not enabled research sampling"
+	case !policy.ConsentObtained:
+		return "students of the organization have not consented to research use"
+	case policy.EthicsApproval == "":
+		return "research sampling needs an ethics approval reference"
+	case policy.ApprovedUntil == nil:
+		return "research sampling needs the date the ethics approval ends"
+	case !now.Before(*policy.ApprovedUntil):
+		return "the ethics approval has ended"
+	}
+	return ""
+}
+
+// latestFinishedAttempts keeps each student's finished attempt with the highest number,
+// ordered by student ID so a seed draws the same sample again
+func latestFinishedAttempts(attempts []*models.AssessmentAttempt) []*models.AssessmentAttempt {
+	latest := make(map[string]*models.AssessmentAttempt)
+	for _, attempt := range attempts {
+		if current, ok := latest[attempt.StudentID]; !ok || attempt.AttemptNumber > current.AttemptNumber {
+			latest[attempt.StudentID] = attempt
+		}
+	}
+	result := make([]*models.AssessmentAttempt, 0, len(latest))
+	for _, attempt := range latest {
+		result = append(result, attempt)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StudentID < result[j].StudentID
+	})
+	return result
+}
+
+// researchScoreBand is the index of the equal-width percentage band a score falls in
+func researchScoreBand(percentage float64, bands int) int {
+	band := int(math.Floor(percentage * float64(bands) / 100))
+	if band < 0 {
+		return 0
+	}
+	if band >= bands {
+		return bands - 1
+	}
+	return band
+}
+
+// drawResearchSample groups attempts into score bands, suppresses bands below the minimum
+// size and draws up to size attempts from the rest, in proportion to each band's size
+func drawResearchSample(attempts []*models.AssessmentAttempt, bands, minPerBand, size int, rng *rand.Rand) ([]ResearchSampleBand, []*models.AssessmentAttempt) {
+	grouped := make([][]*models.AssessmentAttempt, bands)
+	for _, attempt := range attempts {
+		band := researchScoreBand(attempt.Percentage, bands)
+		grouped[band] = append(grouped[band], attempt)
+	}
+
+	result := make([]ResearchSampleBand, bands)
+	eligible := make([]int, bands)
+	for i := range grouped {
+		result[i] = ResearchSampleBand{
+			MinPercentage: float64(i) * 100 / float64(bands),
+			MaxPercentage: float64(i+1) * 100 / float64(bands),
+			Students:      len(grouped[i]),
+			Suppressed:    len(grouped[i]) > 0 && len(grouped[i]) < minPerBand,
+		}
+		if !result[i].Suppressed {
+			eligible[i] = len(grouped[i])
+		}
+	}
+
+	var sampled []*models.AssessmentAttempt
+	for i, count := range allocateResearchSample(eligible, size) {
+		pool := grouped[i]
+		rng.Shuffle(len(pool), func(a, b int) { pool[a], pool[b] = pool[b], pool[a] })
+		sampled = append(sampled, pool[:count]...)
+		result[i].Sampled = count
+	}
+	return result, sampled
+}
+
+// allocateResearchSample splits a sample across bands in proportion to their sizes, giving
+// the seats left by rounding down to the bands with the largest remainders
+func allocateResearchSample(sizes []int, size int) []int {
+	allocation := make([]int, len(sizes))
+	total := 0
+	for _, n := range sizes {
+		total += n
+	}
+	if total <= size {
+		copy(allocation, sizes)
+		return allocation
+	}
+
+	remainders := make([]int, len(sizes))
+	fractions := make([]float64, len(sizes))
+	assigned := 0
+	for i, n := range sizes {
+		exact := float64(size) * float64(n) / float64(total)
+		allocation[i] = int(exact)
+		fractions[i] = exact - float64(allocation[i])
+		remainders[i] = i
+		assigned += allocation[i]
+	}
+	sort.SliceStable(remainders, func(a, b int) bool {
+		return fractions[remainders[a]] > fractions[remainders[b]]
+	})
+	for k := 0; assigned < size; k++ {
+		allocation[remainders[k]]++
+		assigned++
+	}
+	return allocation
+}
+
+// researchSampleAnswers lists a sampled attempt's answers, with the text only when allowed
+func researchSampleAnswers(answers []*models.StudentAnswer, studentID string, pseudonymizer *exportPseudonymizer, withText bool) []ResearchSampleAnswer {
+	result := make([]ResearchSampleAnswer, 0, len(answers))
+	for _, answer := range answers {
+		entry := ResearchSampleAnswer{
+			QuestionID:    answer.QuestionID,
+			Score:         answer.Score,
+			MaxScore:      answer.MaxScore,
+			IsCorrect:     answer.IsCorrect,
+			GradingStatus: answer.GradingStatus,
+			TimeSpent:     answer.TimeSpent,
+		}
+		if withText {
+			entry.Answer = pseudonymizer.redact(string(answer.Answer), studentID)
+		}
+		result = append(result, entry)
+	}
+	return result
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestResearchSamplingRefusal(t *testing.T) {
+	now := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	until := now.Add(24 * time.Hour)
+	allowed := models.ResearchPolicy{SamplingEnabled: true, ConsentObtained: true, EthicsApproval: "IRB-2025-114", ApprovedUntil: &until}
+	if refusal := researchSamplingRefusal(&allowed, now); refusal != "" {
+		t.Fatalf("expected sampling to be allowed, got %q", refusal)
+	}
+
+	disabled, noConsent, noApproval, expired := allowed, allowed, allowed, allowed
+	disabled.SamplingEnabled = false
+	noConsent.ConsentObtained = false
+	noApproval.EthicsApproval = ""
+	ended := now.Add(-time.Hour)
+	expired.ApprovedUntil = &ended
+	for name, policy := range map[string]models.ResearchPolicy{
+		"disabled": disabled, "without consent": noConsent, "without approval": noApproval, "expired": expired,
+	} {
+		if researchSamplingRefusal(&policy, now) == "" {
+			t.Errorf("%s: expected sampling to be refused", name)
+		}
+	}
+}
+
+func TestResearchScoreBand(t *testing.T) {
+	cases := map[float64]int{0: 0, 24.9: 0, 25: 1, 74.99: 2, 99: 3, 100: 3, -5: 0}
+	for percentage, want := range cases {
+		if got := researchScoreBand(percentage, 4); got != want {
+			t.Errorf("%v%%: expected band %d, got %d", percentage, want, got)
+		}
+	}
+}
+
+func TestAllocateResearchSample(t *testing.T) {
+	got := allocateResearchSample([]int{10, 0, 30, 20}, 10)
+	want := []int{2, 0, 5, 3}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+
+	all := allocateResearchSample([]int{3, 4}, 50)
+	if all[0] != 3 || all[1] != 4 {
+		t.Errorf("expected everyone when the sample exceeds the population, got %v", all)
+	}
+}
+
+func TestLatestFinishedAttempts(t *testing.T) {
+	attempts := []*models.AssessmentAttempt{
+		{ID: 1, StudentID: "s-2", AttemptNumber: 1},
+		{ID: 2, StudentID: "s-1", AttemptNumber: 1},
+		{ID: 3, StudentID: "s-2", AttemptNumber: 2},
+	}
+
+	latest := latestFinishedAttempts(attempts)
+
+	if len(latest) != 2 || latest[0].ID != 2 || latest[1].ID != 3 {
+		t.Errorf("expected attempts 2 and 3, got %+v", latest)
+	}
+}
+
+func TestDrawResearchSample(t *testing.T) {
+	var attempts []*models.AssessmentAttempt
+	add := func(count int, percentage float64) {
+		for i := 0; i < count; i++ {
+			attempts = append(attempts, &models.AssessmentAttempt{StudentID: fmt.Sprintf("s-%d", len(attempts)), Percentage: percentage})
+		}
+	}
+	add(2, 10)  // Too small to stay anonymous
+	add(10, 40) // Band 1
+	add(30, 90) // Band 3
+
+	draw := func(seed int64) ([]ResearchSampleBand, []*models.AssessmentAttempt) {
+		pool := append([]*models.AssessmentAttempt(nil), attempts...)
+		return drawResearchSample(pool, 4, 5, 8, rand.New(rand.NewSource(seed)))
+	}
+	bands, sampled := draw(7)
+
+	if !bands[0].Suppressed || bands[0].Sampled != 0 || bands[0].Students != 2 {
+		t.Errorf("expected the small band to be suppressed, got %+v", bands[0])
+	}
+	if bands[1].Sampled != 2 || bands[3].Sampled != 6 || bands[2].Suppressed {
+		t.Errorf("expected a 2/6 split, got %+v", bands)
+	}
+	if len(sampled) != 8 {
+		t.Fatalf("expected 8 sampled attempts, got %d", len(sampled))
+	}
+	for _, attempt := range sampled {
+		if attempt.Percentage == 10 {
+			t.Errorf("sampled %s from a suppressed band", attempt.StudentID)
+		}
+	}
+
+	_, again := draw(7)
+	for i := range sampled {
+		if sampled[i].StudentID != again[i].StudentID {
+			t.Fatalf("expected the same seed to draw the same sample")
+		}
+	}
+}
+
+func TestResearchSampleAnswers(t *testing.T) {
+	pseudonymizer := &exportPseudonymizer{salt: "salt", students: map[string]*models.User{"s-1": {FullName: "Ada Lovelace"}}}
+	answers := []*models.StudentAnswer{{QuestionID: 4, Answer: []byte(`"I am Ada Lovelace"`), Score: 2, MaxScore: 5}}
+
+	withoutText := researchSampleAnswers(answers, "s-1", pseudonymizer, false)
+	if withoutText[0].Answer != "" || withoutText[0].Score != 2 {
+		t.Errorf("expected scores without text, got %+v", withoutText[0])
+	}
+	withText := researchSampleAnswers(answers, "s-1", pseudonymizer, true)
+	if withText[0].Answer != `"I am `+pseudonymizer.alias("s-1")+`"` {
+		t.Errorf("expected the student's name redacted, got %q", withText[0].Answer)
+	}
+}
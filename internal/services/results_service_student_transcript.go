@@ -0,0 +1,303 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// ===== STUDENT TRANSCRIPT =====
+
+// GetStudentTranscript consolidates a student's final grades across assessments, applying
+// each assessment's grade policy. Only released results are included, so the transcript
+// never shows a grade that may still change. Students see their own transcript, admins
+// every student's, and teachers the entries of their own assessments.
+func (s *resultsService) GetStudentTranscript(ctx context.Context, studentID string, filters StudentTranscriptFilters, userID string) (*StudentTranscript, error) {
+	s.logger.Info("Building student transcript", "student_id", studentID, "user_id", userID)
+
+	ownerID, err := s.transcriptScope(ctx, studentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	student, err := s.repo.User().GetByID(ctx, studentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get student: %w", err)
+	}
+
+	attempts, err := s.repo.Attempt().GetFinishedByStudent(ctx, nil, studentID)
+	if err != nil {
+		return nil, err
+	}
+	enrollments, err := s.repo.Enrollment().GetByStudent(ctx, nil, studentID)
+	if err != nil {
+		return nil, err
+	}
+	classes := make(map[uint]*string, len(enrollments))
+	for _, enrollment := range enrollments {
+		classes[enrollment.AssessmentID] = enrollment.ClassID
+	}
+
+	now := time.Now()
+	var entries []StudentTranscriptEntry
+	for _, group := range groupAttemptsByAssessment(attempts) {
+		assessment := &group[0].Assessment
+		if ownerID != "" && assessment.CreatedBy != ownerID {
+			continue
+		}
+		if !transcriptIncludes(assessment, classes[assessment.ID], filters) {
+			continue
+		}
+
+		settings, err := s.repo.AssessmentSettings().GetByAssessmentID(ctx, nil, assessment.ID)
+		if err != nil {
+			if !repositories.IsNotFoundError(err) {
+				return nil, fmt.Errorf("failed to get assessment settings: %w", err)
+			}
+			settings = nil
+		}
+		if !resultsReleased(settings, now) {
+			continue
+		}
+
+		policy := models.GradePolicyHighest
+		if settings != nil && settings.GradePolicy != "" {
+			policy = settings.GradePolicy
+		}
+		if entry := studentTranscriptEntry(assessment, classes[assessment.ID], group, policy); entry != nil {
+			entries = append(entries, *entry)
+		}
+	}
+
+	transcript := buildStudentTranscript(entries)
+	transcript.StudentID = studentID
+	transcript.StudentName = student.FullName
+	transcript.Filters = filters
+	transcript.GeneratedAt = now
+	return transcript, nil
+}
+
+// ExportStudentTranscript renders the transcript as PDF for advising meetings, or as CSV
+func (s *resultsService) ExportStudentTranscript(ctx context.Context, studentID string, filters StudentTranscriptFilters, format string, userID string) (*ExportedDocument, error) {
+	if format == "" {
+		format = transcriptFormatPDF
+	}
+	if format != transcriptFormatPDF && format != transcriptFormatCSV {
+		return nil, ValidationErrors{*NewValidationError("format", "must be pdf or csv", format)}
+	}
+
+	transcript, err := s.GetStudentTranscript(ctx, studentID, filters, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == transcriptFormatCSV {
+		content, err := writeExportCSV(studentTranscriptRecords(transcript))
+		if err != nil {
+			return nil, err
+		}
+		return &ExportedDocument{
+			FileName:    fmt.Sprintf("student-%s-transcript.csv", studentID),
+			ContentType: "text/csv",
+			Content:     content,
+		}, nil
+	}
+
+	return &ExportedDocument{
+		FileName:    fmt.Sprintf("student-%s-transcript.pdf", studentID),
+		ContentType: "application/pdf",
+		Content:     renderStudentTranscriptPDF(transcript),
+	}, nil
+}
+
+// ===== HELPER METHODS =====
+
+// transcriptScope returns the creator whose assessments the viewer is limited to, or "" for all
+func (s *resultsService) transcriptScope(ctx context.Context, studentID, userID string) (string, error) {
+	if studentID == userID {
+		return "", nil
+	}
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+	switch user.Role {
+	case models.RoleAdmin:
+		return "", nil
+	case models.RoleTeacher:
+		return userID, nil
+	default:
+		return "", NewPermissionError(userID, 0, "student_transcript", "view", "students can only view their own transcript")
+	}
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// groupAttemptsByAssessment groups attempts by assessment, in the order each assessment was
+// first attempted
+func groupAttemptsByAssessment(attempts []*models.AssessmentAttempt) [][]*models.AssessmentAttempt {
+	index := make(map[uint]int)
+	var groups [][]*models.AssessmentAttempt
+	for _, attempt := range attempts {
+		i, ok := index[attempt.AssessmentID]
+		if !ok {
+			i = len(groups)
+			index[attempt.AssessmentID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], attempt)
+	}
+	return groups
+}
+
+// transcriptIncludes reports whether the assessment is in the requested term and the
+// student took it with the requested class
+func transcriptIncludes(assessment *models.Assessment, classID *string, filters StudentTranscriptFilters) bool {
+	if filters.Term != nil && (assessment.Term == nil || !strings.EqualFold(strings.TrimSpace(*assessment.Term), strings.TrimSpace(*filters.Term))) {
+		return false
+	}
+	if filters.ClassID != nil && (classID == nil || *classID != strings.TrimSpace(*filters.ClassID)) {
+		return false
+	}
+	return true
+}
+
+// studentTranscriptEntry applies the grade policy to the student's attempts on one assessment
+func studentTranscriptEntry(assessment *models.Assessment, classID *string, attempts []*models.AssessmentAttempt, policy models.GradePolicy) *StudentTranscriptEntry {
+	grade := computeFinalGrade(attempts, policy, assessment.PassingScore)
+	if grade == nil {
+		return nil
+	}
+
+	entry := &StudentTranscriptEntry{
+		AssessmentID:    assessment.ID,
+		AssessmentTitle: assessment.Title,
+		Term:            assessment.Term,
+		ClassID:         classID,
+		LetterGrade:     letterGrade(grade.Percentage),
+		FinalGrade:      *grade,
+	}
+	for _, attempt := range attempts {
+		if attempt.CompletedAt != nil && (entry.CompletedAt == nil || attempt.CompletedAt.After(*entry.CompletedAt)) {
+			entry.CompletedAt = attempt.CompletedAt
+		}
+	}
+	return entry
+}
+
+// buildStudentTranscript orders the entries oldest first and summarizes them
+func buildStudentTranscript(entries []StudentTranscriptEntry) *StudentTranscript {
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].CompletedAt, entries[j].CompletedAt
+		if a == nil || b == nil {
+			return a != nil && b == nil
+		}
+		return a.Before(*b)
+	})
+
+	transcript := &StudentTranscript{Entries: entries, AssessmentsCount: len(entries)}
+	if transcript.Entries == nil {
+		transcript.Entries = []StudentTranscriptEntry{}
+	}
+	if len(entries) == 0 {
+		return transcript
+	}
+
+	total := 0.0
+	for _, entry := range entries {
+		total += entry.Percentage
+		if entry.Passed {
+			transcript.PassedCount++
+		}
+	}
+	transcript.AveragePercentage = total / float64(len(entries))
+	transcript.LetterGrade = letterGrade(transcript.AveragePercentage)
+	return transcript
+}
+
+func studentTranscriptRecords(t *StudentTranscript) [][]string {
+	records := [][]string{{
+		"student_id", "student_name", "assessment_id", "assessment_title", "term", "class_id", "completed_at",
+		"grade_policy", "attempts_counted", "score", "max_score", "percentage", "letter_grade", "passed",
+	}}
+	for _, entry := range t.Entries {
+		records = append(records, []string{
+			t.StudentID,
+			t.StudentName,
+			strconv.FormatUint(uint64(entry.AssessmentID), 10),
+			entry.AssessmentTitle,
+			stringValue(entry.Term),
+			stringValue(entry.ClassID),
+			exportTime(entry.CompletedAt),
+			string(entry.Policy),
+			strconv.Itoa(entry.AttemptsCounted),
+			strconv.FormatFloat(entry.Score, 'f', -1, 64),
+			strconv.Itoa(entry.MaxScore),
+			strconv.FormatFloat(entry.Percentage, 'f', 2, 64),
+			entry.LetterGrade,
+			strconv.FormatBool(entry.Passed),
+		})
+	}
+	return records
+}
+
+func renderStudentTranscriptPDF(t *StudentTranscript) []byte {
+	doc := newPDFDocument()
+
+	doc.Heading("Transcript")
+	doc.Field("Student", t.StudentName)
+	doc.Field("Student ID", t.StudentID)
+	if t.Filters.Term != nil {
+		doc.Field("Term", *t.Filters.Term)
+	}
+	if t.Filters.ClassID != nil {
+		doc.Field("Class", *t.Filters.ClassID)
+	}
+	doc.Field("Generated", formatTranscriptTime(t.GeneratedAt))
+
+	for _, entry := range t.Entries {
+		doc.Subheading(entry.AssessmentTitle)
+		if entry.Term != nil {
+			doc.Field("Term", *entry.Term)
+		}
+		if entry.ClassID != nil {
+			doc.Field("Class", *entry.ClassID)
+		}
+		if entry.CompletedAt != nil {
+			doc.Field("Completed", formatTranscriptTime(*entry.CompletedAt))
+		}
+		doc.Field("Grade", fmt.Sprintf("%s / %d (%.1f%%, %s)", formatPoints(entry.Score), entry.MaxScore, entry.Percentage, entry.LetterGrade))
+		if entry.Passed {
+			doc.Field("Result", "Passed")
+		} else {
+			doc.Field("Result", "Not passed")
+		}
+		doc.Field("Grade policy", string(entry.Policy))
+	}
+
+	doc.Subheading("Summary")
+	if len(t.Entries) == 0 {
+		doc.Paragraph("No released results.")
+		return doc.Bytes()
+	}
+	doc.Field("Assessments", strconv.Itoa(t.AssessmentsCount))
+	doc.Field("Passed", strconv.Itoa(t.PassedCount))
+	doc.Field("Average", fmt.Sprintf("%.1f%% (%s)", t.AveragePercentage, t.LetterGrade))
+	return doc.Bytes()
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestTranscriptIncludes(t *testing.T) {
+	assessment := &models.Assessment{Term: stringPtr("2025-Spring")}
+	term := " 2025-spring "
+	class := "math-a"
+	other := "math-b"
+
+	if !transcriptIncludes(assessment, &class, StudentTranscriptFilters{Term: &term, ClassID: &class}) {
+		t.Error("expected the term to match case-insensitively and the class to match")
+	}
+	if transcriptIncludes(assessment, &other, StudentTranscriptFilters{ClassID: &class}) {
+		t.Error("expected another class to be excluded")
+	}
+	if transcriptIncludes(assessment, nil, StudentTranscriptFilters{ClassID: &class}) {
+		t.Error("expected an assessment taken without a class to be excluded by a class filter")
+	}
+	if transcriptIncludes(&models.Assessment{}, nil, StudentTranscriptFilters{Term: &term}) {
+		t.Error("expected an assessment without a term to be excluded by a term filter")
+	}
+	if !transcriptIncludes(&models.Assessment{}, nil, StudentTranscriptFilters{}) {
+		t.Error("expected everything without filters")
+	}
+}
+
+func TestStudentTranscriptEntry(t *testing.T) {
+	first := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	second := first.Add(48 * time.Hour)
+	assessment := &models.Assessment{ID: 7, Title: "Algebra", PassingScore: 60}
+	attempts := []*models.AssessmentAttempt{
+		{ID: 1, AttemptNumber: 1, StudentID: "s1", Status: models.AttemptCompleted, Score: 9, MaxScore: 10, Percentage: 90, Passed: true, CompletedAt: &first},
+		{ID: 2, AttemptNumber: 2, StudentID: "s1", Status: models.AttemptCompleted, Score: 5, MaxScore: 10, Percentage: 50, CompletedAt: &second},
+	}
+
+	entry := studentTranscriptEntry(assessment, nil, attempts, models.GradePolicyLatest)
+	if entry == nil {
+		t.Fatal("expected an entry")
+	}
+	if entry.Percentage != 50 || entry.Passed || entry.LetterGrade != "F" {
+		t.Errorf("expected the latest attempt to count, got %+v", entry)
+	}
+	if entry.CompletedAt == nil || !entry.CompletedAt.Equal(second) {
+		t.Errorf("expected the last completion time, got %v", entry.CompletedAt)
+	}
+
+	entry = studentTranscriptEntry(assessment, nil, attempts, models.GradePolicyHighest)
+	if entry.Percentage != 90 || !entry.Passed || entry.LetterGrade != "A-" {
+		t.Errorf("expected the highest attempt to count, got %+v", entry)
+	}
+
+	inProgress := []*models.AssessmentAttempt{{ID: 3, Status: models.AttemptInProgress}}
+	if entry := studentTranscriptEntry(assessment, nil, inProgress, models.GradePolicyHighest); entry != nil {
+		t.Errorf("expected no entry without finished attempts, got %+v", entry)
+	}
+}
+
+func TestBuildStudentTranscript(t *testing.T) {
+	early := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	late := early.AddDate(0, 2, 0)
+	transcript := buildStudentTranscript([]StudentTranscriptEntry{
+		{AssessmentID: 2, CompletedAt: &late, FinalGrade: FinalGrade{Percentage: 70, Passed: true}},
+		{AssessmentID: 3, FinalGrade: FinalGrade{Percentage: 40}},
+		{AssessmentID: 1, CompletedAt: &early, FinalGrade: FinalGrade{Percentage: 100, Passed: true}},
+	})
+
+	if transcript.Entries[0].AssessmentID != 1 || transcript.Entries[1].AssessmentID != 2 || transcript.Entries[2].AssessmentID != 3 {
+		t.Errorf("expected entries oldest first with undated ones last, got %+v", transcript.Entries)
+	}
+	if transcript.AssessmentsCount != 3 || transcript.PassedCount != 2 {
+		t.Errorf("expected 3 assessments and 2 passes, got %d and %d", transcript.AssessmentsCount, transcript.PassedCount)
+	}
+	if transcript.AveragePercentage != 70 || transcript.LetterGrade != "C-" {
+		t.Errorf("expected an unweighted average of 70 (C-), got %v (%s)", transcript.AveragePercentage, transcript.LetterGrade)
+	}
+
+	empty := buildStudentTranscript(nil)
+	if empty.Entries == nil || empty.LetterGrade != "" {
+		t.Errorf("expected an empty transcript without a letter grade, got %+v", empty)
+	}
+}
+
+func TestStudentTranscriptRecords(t *testing.T) {
+	completed := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	records := studentTranscriptRecords(&StudentTranscript{
+		StudentID:   "s1",
+		StudentName: "Ada",
+		Entries: []StudentTranscriptEntry{{
+			AssessmentID:    7,
+			AssessmentTitle: "Algebra",
+			Term:            stringPtr("2025-spring"),
+			CompletedAt:     &completed,
+			LetterGrade:     "B",
+			FinalGrade:      FinalGrade{Policy: models.GradePolicyHighest, Score: 8.5, MaxScore: 10, Percentage: 85, Passed: true, AttemptsCounted: 1},
+		}},
+	})
+
+	if len(records) != 2 {
+		t.Fatalf("expected a header and one row, got %d records", len(records))
+	}
+	if len(records[0]) != len(records[1]) {
+		t.Errorf("expected the row to match the header, got %d and %d columns", len(records[0]), len(records[1]))
+	}
+	if got := strings.Join(records[1], ","); got != "s1,Ada,7,Algebra,2025-spring,,"+exportTime(&completed)+",highest,1,8.5,10,85.00,B,true" {
+		t.Errorf("unexpected row %s", got)
+	}
+}
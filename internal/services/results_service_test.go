@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	pgdriver "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestResultsReleased(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		settings *models.AssessmentSettings
+		want     bool
+	}{
+		{"no settings", nil, true},
+		{"immediate", &models.AssessmentSettings{ResultsReleaseMode: models.ResultsReleaseImmediate}, true},
+		{"immediate with anonymous grading", &models.AssessmentSettings{ResultsReleaseMode: models.ResultsReleaseImmediate, AnonymousGrading: true}, false},
+		{"manual before release", &models.AssessmentSettings{ResultsReleaseMode: models.ResultsReleaseManual}, false},
+		{"manual after release", &models.AssessmentSettings{ResultsReleaseMode: models.ResultsReleaseManual, ResultsReleasedAt: &past}, true},
+		{"scheduled in future", &models.AssessmentSettings{ResultsReleaseMode: models.ResultsReleaseScheduled, ResultsReleaseAt: &future}, false},
+		{"scheduled in past", &models.AssessmentSettings{ResultsReleaseMode: models.ResultsReleaseScheduled, ResultsReleaseAt: &past}, true},
+		{"scheduled without time", &models.AssessmentSettings{ResultsReleaseMode: models.ResultsReleaseScheduled}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resultsReleased(tt.settings, now); got != tt.want {
+				t.Errorf("resultsReleased() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// txOnlyDriver opens connections that begin and commit transactions but run no statements,
+// for services whose repositories are faked but which still wrap them in s.db.Transaction
+type txOnlyDriver struct{}
+
+func (txOnlyDriver) Open(string) (driver.Conn, error) { return txOnlyConn{}, nil }
+
+type txOnlyConn struct{}
+
+func (txOnlyConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("statements are not supported")
+}
+func (txOnlyConn) Close() error              { return nil }
+func (txOnlyConn) Begin() (driver.Tx, error) { return txOnlyConn{}, nil }
+func (txOnlyConn) Commit() error             { return nil }
+func (txOnlyConn) Rollback() error           { return nil }
+
+var registerTxOnly sync.Once
+
+func txOnlyDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	registerTxOnly.Do(func() { sql.Register("tx-only", txOnlyDriver{}) })
+	conn, err := sql.Open("tx-only", "")
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	db, err := gorm.Open(pgdriver.New(pgdriver.Config{Conn: conn}), &gorm.Config{DisableAutomaticPing: true, Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	return db
+}
+
+// releaseSettingsStore hands every reader the same unreleased row, like two replicas reading it
+// before either releases
+type releaseSettingsStore struct {
+	repositories.AssessmentSettingsRepository
+	mu       sync.Mutex
+	released map[uint]string
+}
+
+func (s *releaseSettingsStore) GetDueResultsReleases(ctx context.Context, tx *gorm.DB, now time.Time, limit int) ([]*models.AssessmentSettings, error) {
+	return []*models.AssessmentSettings{{AssessmentID: 4, ResultsReleaseMode: models.ResultsReleaseScheduled}}, nil
+}
+
+func (s *releaseSettingsStore) ReleaseResults(ctx context.Context, tx *gorm.DB, assessmentID uint, releasedAt time.Time, releasedBy string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.released[assessmentID]; ok {
+		return false, nil
+	}
+	s.released[assessmentID] = releasedBy
+	return true, nil
+}
+
+type releaseAuditLog struct {
+	repositories.AuditLogRepository
+	mu      sync.Mutex
+	entries int
+}
+
+func (a *releaseAuditLog) Create(ctx context.Context, tx *gorm.DB, entry *models.AuditLog) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.entries++
+	return nil
+}
+
+type releaseRepository struct {
+	MockNotificationRepository
+	settings *releaseSettingsStore
+	audit    *releaseAuditLog
+}
+
+func (r *releaseRepository) AssessmentSettings() repositories.AssessmentSettingsRepository {
+	return r.settings
+}
+func (r *releaseRepository) AuditLog() repositories.AuditLogRepository { return r.audit }
+
+type releaseNotifier struct {
+	NotificationEventService
+	mu       sync.Mutex
+	notified int
+}
+
+func (n *releaseNotifier) NotifyResultsReleased(ctx context.Context, assessmentID uint, releasedBy string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.notified++
+	return nil
+}
+
+func TestReleaseScheduledResultsOnceAcrossReplicas(t *testing.T) {
+	repo := &releaseRepository{
+		settings: &releaseSettingsStore{released: map[uint]string{}},
+		audit:    &releaseAuditLog{},
+	}
+	notifier := &releaseNotifier{}
+	db := txOnlyDB(t)
+
+	total := 0
+	for replica := 0; replica < 2; replica++ {
+		service := NewResultsService(repo, db, slog.New(slog.DiscardHandler), nil, notifier)
+		released, err := service.ReleaseScheduledResults(context.Background(), time.Now())
+		if err != nil {
+			t.Fatalf("replica %d: %v", replica, err)
+		}
+		total += released
+	}
+
+	if total != 1 || repo.audit.entries != 1 || notifier.notified != 1 {
+		t.Errorf("released %d times with %d audit entries and %d notifications, want each once",
+			total, repo.audit.entries, notifier.notified)
+	}
+	if repo.settings.released[4] != resultsReleaseSystemActor {
+		t.Errorf("released by %q, want the scheduler", repo.settings.released[4])
+	}
+}
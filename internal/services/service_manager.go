@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"sync"
 	"time"
 
+	"github.com/SAP-F-2025/assessment-service/internal/events"
+	"github.com/SAP-F-2025/assessment-service/internal/models"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories"
 	"github.com/SAP-F-2025/assessment-service/internal/validator"
 	"gorm.io/gorm"
@@ -31,6 +35,30 @@ type ServiceManagerConfig struct {
 	MaxRetries        int
 	CircuitBreaker    bool
 	RateLimitingRules map[string]RateLimit
+
+	// How long autosaves are coalesced in Redis before being written; zero disables coalescing
+	AutosaveFlushInterval time.Duration
+
+	// Directory holding files students upload with answers
+	AttachmentStorageDir string
+	// Data residency regions, and the directory holding each region's uploads
+	ResidencyRegions            []string
+	RegionAttachmentStorageDirs map[string]string
+	// Base URL of the CDN serving question images; empty serves them from /media
+	MediaCDNBaseURL string
+	// Tesseract binary used to recognise text in uploads; empty disables text recognition
+	OCRCommand  string
+	OCRLanguage string
+
+	// Assessments given their own live metric series before the rest are reported as "other"
+	LiveMetricsMaxAssessments int
+
+	// Organization policy letting creators reuse an assessment title in another term
+	AllowDuplicateTitlesAcrossTerms bool
+	// Organization policy on skill tag spread, near-duplicates and difficulty mix
+	ExamPolicy ExamPolicy
+	// Proctoring providers assessments may name; publishing fails when the provider lacks a requested feature
+	ProctoringProviders ProctoringProviders
 }
 
 type ServiceConfig struct {
@@ -58,11 +86,12 @@ type RateLimit struct {
 // serviceManager implements ServiceManager interface
 type serviceManager struct {
 	// Dependencies
-	db        *gorm.DB
-	repo      repositories.Repository
-	logger    *slog.Logger
-	validator *validator.Validator
-	config    ServiceManagerConfig
+	db             *gorm.DB
+	repo           repositories.Repository
+	logger         *slog.Logger
+	validator      *validator.Validator
+	eventPublisher events.EventPublisher
+	config         ServiceManagerConfig
 
 	// Service instances
 	assessmentService   AssessmentService
@@ -70,9 +99,28 @@ type serviceManager struct {
 	questionBankService QuestionBankService
 	attemptService      AttemptService
 	gradingService      GradingService
+	resultsService      ResultsService
 	importExportService ImportExportService
 	// notificationService NotificationService
-	//analyticsService    AnalyticsService
+	analyticsService  AnalyticsService
+	reportService     ReportService
+	favoriteService   FavoriteService
+	attachmentService AttachmentService
+	gradebookService  GradebookService
+	extensionService  ExtensionService
+	shareLinkService  ShareLinkService
+
+	impersonationService     ImpersonationService
+	notificationEventService NotificationEventService
+	usageService             UsageService
+	warehouseService         WarehouseExportService
+	residencyService         ResidencyService
+	webhookService           WebhookService
+	customFieldService       CustomFieldService
+	jobService               JobService
+	practiceService          PracticeService
+
+	liveMetrics *LiveMetrics
 
 	// Utilities
 	//validationService *ValidationService
@@ -84,19 +132,29 @@ type serviceManager struct {
 }
 
 // NewServiceManager creates a new service manager with all dependencies
-func NewServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator, config ServiceManagerConfig) ServiceManager {
+func NewServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator, eventPublisher events.EventPublisher, config ServiceManagerConfig) ServiceManager {
+	if eventPublisher == nil {
+		eventPublisher = events.NewMockEventPublisher(logger)
+	}
+
 	return &serviceManager{
-		db:        db,
-		repo:      repo,
-		logger:    logger,
-		validator: validator,
-		config:    config,
+		db:             db,
+		repo:           repo,
+		logger:         logger,
+		validator:      validator,
+		eventPublisher: eventPublisher,
+		config:         config,
 	}
 }
 
 // NewDefaultServiceManager creates a service manager with default configuration
-func NewDefaultServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator) ServiceManager {
-	config := ServiceManagerConfig{
+func NewDefaultServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator, eventPublisher events.EventPublisher) ServiceManager {
+	return NewServiceManager(db, repo, logger, validator, eventPublisher, DefaultServiceManagerConfig())
+}
+
+// DefaultServiceManagerConfig returns the default configuration, for callers adjusting a few settings
+func DefaultServiceManagerConfig() ServiceManagerConfig {
+	return ServiceManagerConfig{
 		EnableDebugLogging: false,
 		EnableMetrics:      true,
 		LogLevel:           slog.LevelInfo,
@@ -146,9 +204,16 @@ func NewDefaultServiceManager(db *gorm.DB, repo repositories.Repository, logger
 		MaxRetries:        3,
 		CircuitBreaker:    true,
 		RateLimitingRules: make(map[string]RateLimit),
-	}
 
-	return NewServiceManager(db, repo, logger, validator, config)
+		AutosaveFlushInterval: 5 * time.Second,
+		AttachmentStorageDir:  filepath.Join(os.TempDir(), "assessment-attachments"),
+		OCRCommand:            "tesseract",
+		OCRLanguage:           "eng",
+
+		LiveMetricsMaxAssessments: DefaultLiveMetricsMaxAssessments,
+		ExamPolicy:                DefaultExamPolicy(),
+		ProctoringProviders:       DefaultProctoringProviders(),
+	}
 }
 
 // Initialize sets up all services and their dependencies
@@ -183,7 +248,7 @@ func (sm *serviceManager) initializeServices(ctx context.Context) error {
 
 	// Initialize AssessmentService
 	if sm.config.Assessment.Enabled {
-		sm.assessmentService = NewAssessmentService(sm.repo, sm.db, sm.logger, sm.validator)
+		sm.assessmentService = NewAssessmentServiceWithProctoring(sm.repo, sm.db, sm.logger, sm.validator, sm.config.AllowDuplicateTitlesAcrossTerms, sm.config.ExamPolicy, sm.config.ProctoringProviders)
 		sm.logger.Info("Assessment service initialized")
 	}
 
@@ -193,15 +258,31 @@ func (sm *serviceManager) initializeServices(ctx context.Context) error {
 		sm.logger.Info("Question service initialized")
 	}
 
+	// Initialize WebhookService; every published event is also queued for webhook subscribers
+	sm.webhookService = NewWebhookService(sm.repo, sm.db, sm.logger, sm.validator, nil)
+	sm.eventPublisher = NewWebhookEventPublisher(sm.eventPublisher, sm.webhookService, sm.logger)
+	sm.logger.Info("Webhook service initialized")
+
+	notifier := NewNotificationEventService(sm.repo, sm.eventPublisher, sm.logger, sm.validator)
+	sm.notificationEventService = notifier
+
 	// Initialize QuestionBankService
 	if sm.config.QuestionBank.Enabled {
-		sm.questionBankService = NewQuestionBankService(sm.repo, sm.db, sm.logger, sm.validator)
+		sm.questionBankService = NewQuestionBankService(sm.repo, sm.db, sm.logger, sm.validator, notifier)
 		sm.logger.Info("QuestionBank service initialized")
 	}
 
+	// Initialize live metrics
+	if sm.config.EnableMetrics {
+		sm.liveMetrics = NewLiveMetrics(sm.config.LiveMetricsMaxAssessments, func(ctx context.Context, now time.Time) (map[uint]int, error) {
+			return sm.repo.Attempt().CountActiveByAssessment(ctx, nil, now)
+		})
+		sm.logger.Info("Live metrics initialized")
+	}
+
 	// Initialize AttemptService
 	if sm.config.Attempt.Enabled {
-		sm.attemptService = NewAttemptService(sm.repo, sm.db, sm.logger, sm.validator)
+		sm.attemptService = NewAttemptService(sm.repo, sm.db, sm.logger, sm.validator, sm.config.AutosaveFlushInterval, sm.liveMetrics, notifier)
 		sm.logger.Info("Attempt service initialized")
 	}
 
@@ -211,10 +292,72 @@ func (sm *serviceManager) initializeServices(ctx context.Context) error {
 		sm.logger.Info("Grading service initialized")
 	}
 
+	// Initialize ResultsService
+	sm.resultsService = NewResultsService(sm.repo, sm.db, sm.logger, sm.validator, notifier)
+	sm.logger.Info("Results service initialized")
+
 	// Initialize ImportExportService
-	sm.importExportService = NewImportExportService(sm.repo, sm.logger, sm.validator)
+	sm.importExportService = NewImportExportService(sm.repo, sm.db, sm.logger, sm.validator)
 	sm.logger.Info("ImportExport service initialized")
 
+	// Initialize AnalyticsService and ReportService
+	sm.analyticsService = NewAnalyticsService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.reportService = NewReportService(sm.repo, sm.db, sm.logger, sm.validator, sm.analyticsService, NewReportRenderer(), sm.eventPublisher)
+	sm.logger.Info("Analytics and report services initialized")
+
+	// Initialize FavoriteService
+	sm.favoriteService = NewFavoriteService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.logger.Info("Favorite service initialized")
+
+	// Initialize AttachmentService
+	var ocr OCREngine
+	if sm.config.OCRCommand != "" {
+		ocr = NewTesseractOCREngine(sm.config.OCRCommand, sm.config.OCRLanguage)
+	}
+	sm.attachmentService = NewAttachmentService(sm.repo, sm.db, sm.logger, sm.validator, sm.attachmentStorage(), sm.config.MediaCDNBaseURL, ocr)
+	sm.logger.Info("Attachment service initialized")
+
+	// Initialize GradebookService
+	sm.gradebookService = NewGradebookService(sm.repo, sm.db, sm.logger, sm.validator, nil)
+	sm.logger.Info("Gradebook service initialized")
+
+	// Initialize ExtensionService
+	sm.extensionService = NewExtensionService(sm.repo, sm.db, sm.logger, sm.validator, sm.attachmentStorage(), notifier)
+	sm.logger.Info("Extension service initialized")
+
+	// Initialize ShareLinkService
+	sm.shareLinkService = NewShareLinkService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.logger.Info("Share link service initialized")
+
+	// Initialize ImpersonationService
+	sm.impersonationService = NewImpersonationService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.logger.Info("Impersonation service initialized")
+
+	// Initialize UsageService
+	sm.usageService = NewUsageService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.logger.Info("Usage service initialized")
+
+	// Initialize WarehouseExportService
+	sm.warehouseService = NewWarehouseExportService(sm.repo, sm.db, sm.logger, sm.validator, nil)
+	sm.logger.Info("Warehouse export service initialized")
+
+	// Initialize ResidencyService
+	sm.residencyService = NewResidencyService(sm.repo, sm.db, sm.logger, sm.validator, sm.config.ResidencyRegions)
+	sm.logger.Info("Residency service initialized")
+
+	// Initialize CustomFieldService
+	sm.customFieldService = NewCustomFieldService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.logger.Info("Custom field service initialized")
+
+	// Initialize JobService with a handler per job type
+	sm.jobService = NewJobService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.registerJobHandlers()
+	sm.logger.Info("Job service initialized")
+
+	// Initialize PracticeService
+	sm.practiceService = NewPracticeService(sm.repo, sm.db, sm.logger, sm.validator)
+	sm.logger.Info("Practice service initialized")
+
 	// Initialize NotificationService
 	//sm.notificationService = NewNotificationService(sm.repo, sm.logger, sm.validator)
 	// sm.logger.Info("Notification service initialized")
@@ -226,6 +369,29 @@ func (sm *serviceManager) initializeServices(ctx context.Context) error {
 	return nil
 }
 
+// registerJobHandlers routes each background job type to the service doing the work
+func (sm *serviceManager) registerJobHandlers() {
+	sm.jobService.Register(JobTypeImport, func(ctx context.Context, job *models.Job) error {
+		return sm.importExportService.RunImportJob(ctx, job.Ref)
+	})
+	sm.jobService.Register(JobTypeQuestionStats, func(ctx context.Context, job *models.Job) error {
+		id, err := jobRefID(job)
+		if err != nil {
+			return err
+		}
+		return sm.analyticsService.RunQuestionStatsJob(ctx, id)
+	})
+	if sm.gradingService != nil {
+		sm.jobService.Register(JobTypeRegrade, func(ctx context.Context, job *models.Job) error {
+			id, err := jobRefID(job)
+			if err != nil {
+				return err
+			}
+			return sm.gradingService.RunRegradeJob(ctx, id)
+		})
+	}
+}
+
 func (sm *serviceManager) validateServicesHealth(ctx context.Context) error {
 	// Perform basic health checks on all initialized services
 	// This could include checking database connections, cache availability, etc.
@@ -329,6 +495,269 @@ func (sm *serviceManager) ImportExport() ImportExportService {
 	panic("import/export service not initialized")
 }
 
+func (sm *serviceManager) Analytics() AnalyticsService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.analyticsService != nil {
+		return sm.analyticsService
+	}
+
+	panic("analytics service not initialized")
+}
+
+func (sm *serviceManager) Results() ResultsService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.resultsService != nil {
+		return sm.resultsService
+	}
+
+	panic("results service not initialized")
+}
+
+func (sm *serviceManager) Report() ReportService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.reportService != nil {
+		return sm.reportService
+	}
+
+	panic("report service not initialized")
+}
+
+func (sm *serviceManager) Favorite() FavoriteService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.favoriteService != nil {
+		return sm.favoriteService
+	}
+
+	panic("favorite service not initialized")
+}
+
+func (sm *serviceManager) Attachment() AttachmentService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.attachmentService != nil {
+		return sm.attachmentService
+	}
+
+	panic("attachment service not initialized")
+}
+
+func (sm *serviceManager) Gradebook() GradebookService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.gradebookService != nil {
+		return sm.gradebookService
+	}
+
+	panic("gradebook service not initialized")
+}
+
+func (sm *serviceManager) Extension() ExtensionService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.extensionService != nil {
+		return sm.extensionService
+	}
+
+	panic("extension service not initialized")
+}
+
+func (sm *serviceManager) ShareLink() ShareLinkService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.shareLinkService != nil {
+		return sm.shareLinkService
+	}
+
+	panic("share link service not initialized")
+}
+
+func (sm *serviceManager) Impersonation() ImpersonationService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.impersonationService != nil {
+		return sm.impersonationService
+	}
+
+	panic("impersonation service not initialized")
+}
+
+func (sm *serviceManager) NotificationEvents() NotificationEventService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.notificationEventService != nil {
+		return sm.notificationEventService
+	}
+
+	panic("notification event service not initialized")
+}
+
+func (sm *serviceManager) Usage() UsageService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.usageService != nil {
+		return sm.usageService
+	}
+
+	panic("usage service not initialized")
+}
+
+func (sm *serviceManager) Warehouse() WarehouseExportService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.warehouseService != nil {
+		return sm.warehouseService
+	}
+
+	panic("warehouse export service not initialized")
+}
+
+func (sm *serviceManager) Residency() ResidencyService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.residencyService != nil {
+		return sm.residencyService
+	}
+
+	panic("residency service not initialized")
+}
+
+func (sm *serviceManager) Webhook() WebhookService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.webhookService != nil {
+		return sm.webhookService
+	}
+
+	panic("webhook service not initialized")
+}
+
+func (sm *serviceManager) CustomField() CustomFieldService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.customFieldService != nil {
+		return sm.customFieldService
+	}
+
+	panic("custom field service not initialized")
+}
+
+func (sm *serviceManager) Jobs() JobService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.jobService != nil {
+		return sm.jobService
+	}
+
+	panic("job service not initialized")
+}
+
+func (sm *serviceManager) Practice() PracticeService {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if !sm.initialized {
+		panic("service manager not initialized")
+	}
+
+	if sm.practiceService != nil {
+		return sm.practiceService
+	}
+
+	panic("practice service not initialized")
+}
+
+// LiveMetrics returns the per-assessment metrics collector, nil when metrics are disabled
+func (sm *serviceManager) LiveMetrics() *LiveMetrics {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	return sm.liveMetrics
+}
+
 //func (sm *serviceManager) Notification() NotificationService {
 //	sm.mu.RLock()
 //	defer sm.mu.RUnlock()
@@ -384,6 +813,10 @@ func (sm *serviceManager) Shutdown(ctx context.Context) error {
 	// Services don't currently have explicit shutdown methods,
 	// but this is where we would call them
 
+	if err := sm.eventPublisher.Close(); err != nil {
+		sm.logger.Error("Failed to close event publisher", "error", err)
+	}
+
 	// Shutdown repository manager
 	if repoManager, ok := sm.repo.(repositories.RepositoryManager); ok {
 		if err := repoManager.Shutdown(ctx); err != nil {
@@ -407,6 +840,14 @@ func (sm *serviceManager) GetConfig() ServiceManagerConfig {
 	return sm.config
 }
 
+// attachmentStorage returns the upload directories; callers hold the lock
+func (sm *serviceManager) attachmentStorage() StorageDirs {
+	return StorageDirs{
+		Default: sm.config.AttachmentStorageDir,
+		Regions: sm.config.RegionAttachmentStorageDirs,
+	}
+}
+
 // IsInitialized returns whether the service manager has been initialized
 func (sm *serviceManager) IsInitialized() bool {
 	sm.mu.RLock()
@@ -550,7 +991,7 @@ func (sc *ServiceConfig) validate(serviceName string) error {
 // ===== FACTORY FUNCTIONS =====
 
 // CreateProductionServiceManager creates a service manager configured for production
-func CreateProductionServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator) ServiceManager {
+func CreateProductionServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator, eventPublisher events.EventPublisher) ServiceManager {
 	config := ServiceManagerConfig{
 		EnableDebugLogging: false,
 		EnableMetrics:      true,
@@ -597,13 +1038,22 @@ func CreateProductionServiceManager(db *gorm.DB, repo repositories.Repository, l
 			"attempt_start":     {RequestsPerMinute: 100, BurstSize: 20},
 			"grading_submit":    {RequestsPerMinute: 200, BurstSize: 50},
 		},
+
+		AutosaveFlushInterval: 5 * time.Second,
+		AttachmentStorageDir:  filepath.Join(os.TempDir(), "assessment-attachments"),
+		OCRCommand:            "tesseract",
+		OCRLanguage:           "eng",
+
+		LiveMetricsMaxAssessments: DefaultLiveMetricsMaxAssessments,
+		ExamPolicy:                DefaultExamPolicy(),
+		ProctoringProviders:       DefaultProctoringProviders(),
 	}
 
-	return NewServiceManager(db, repo, logger, validator, config)
+	return NewServiceManager(db, repo, logger, validator, eventPublisher, config)
 }
 
 // CreateDevelopmentServiceManager creates a service manager configured for development
-func CreateDevelopmentServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator) ServiceManager {
+func CreateDevelopmentServiceManager(db *gorm.DB, repo repositories.Repository, logger *slog.Logger, validator *validator.Validator, eventPublisher events.EventPublisher) ServiceManager {
 	config := ServiceManagerConfig{
 		EnableDebugLogging: true,
 		EnableMetrics:      false,
@@ -646,7 +1096,12 @@ func CreateDevelopmentServiceManager(db *gorm.DB, repo repositories.Repository,
 		MaxRetries:        1,
 		CircuitBreaker:    false,
 		RateLimitingRules: make(map[string]RateLimit),
+
+		AttachmentStorageDir: filepath.Join(os.TempDir(), "assessment-attachments"),
+
+		ExamPolicy:          DefaultExamPolicy(),
+		ProctoringProviders: DefaultProctoringProviders(),
 	}
 
-	return NewServiceManager(db, repo, logger, validator, config)
+	return NewServiceManager(db, repo, logger, validator, eventPublisher, config)
 }
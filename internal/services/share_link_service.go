@@ -0,0 +1,263 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+const defaultShareLinkDuration = 72 * time.Hour
+
+type shareLinkService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewShareLinkService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) ShareLinkService {
+	return &shareLinkService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// ===== LINK MANAGEMENT =====
+
+// CreateLink issues a read-only link to the assessment. The token is only returned here.
+func (s *shareLinkService) CreateLink(ctx context.Context, assessmentID uint, req *CreateShareLinkRequest, userID string) (*ShareLinkGrant, error) {
+	s.logger.Info("Creating share link", "assessment_id", assessmentID, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if _, err := s.getManagedAssessment(ctx, assessmentID, userID, "share"); err != nil {
+		return nil, err
+	}
+
+	token, err := newShareLinkToken()
+	if err != nil {
+		return nil, err
+	}
+
+	duration := defaultShareLinkDuration
+	if req.ExpiresInHours > 0 {
+		duration = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	link := &models.AssessmentShareLink{
+		AssessmentID: assessmentID,
+		TokenHash:    hashShareLinkToken(token),
+		Label:        req.Label,
+		CreatedBy:    userID,
+		ExpiresAt:    time.Now().Add(duration),
+	}
+	if err := s.repo.ShareLink().Create(ctx, nil, link); err != nil {
+		return nil, err
+	}
+
+	return &ShareLinkGrant{Link: link, Token: token}, nil
+}
+
+func (s *shareLinkService) ListLinks(ctx context.Context, assessmentID uint, userID string) ([]*models.AssessmentShareLink, error) {
+	if _, err := s.getManagedAssessment(ctx, assessmentID, userID, "list_share_links"); err != nil {
+		return nil, err
+	}
+	return s.repo.ShareLink().ListByAssessment(ctx, nil, assessmentID)
+}
+
+// RevokeLink closes a link before it expires; opening it afterwards is refused
+func (s *shareLinkService) RevokeLink(ctx context.Context, id uint, userID string) (*models.AssessmentShareLink, error) {
+	s.logger.Info("Revoking share link", "share_link_id", id, "user_id", userID)
+
+	link, err := s.getManagedLink(ctx, id, userID, "revoke_share_link")
+	if err != nil {
+		return nil, err
+	}
+	if link.RevokedAt != nil {
+		return nil, NewBusinessRuleError("share_link_revoked", "share link has already been revoked", map[string]interface{}{
+			"share_link_id": id,
+		})
+	}
+
+	link.RevokedAt = timePtr(time.Now())
+	link.RevokedBy = &userID
+	if err := s.repo.ShareLink().Update(ctx, nil, link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// GetViews lists every opening of a link, newest first
+func (s *shareLinkService) GetViews(ctx context.Context, id uint, userID string) ([]*models.AssessmentShareView, error) {
+	if _, err := s.getManagedLink(ctx, id, userID, "view_share_link_log"); err != nil {
+		return nil, err
+	}
+	return s.repo.ShareLink().GetViews(ctx, nil, id)
+}
+
+// ===== TOKEN ACCESS =====
+
+// Authorize resolves the link behind a token presented without an account
+func (s *shareLinkService) Authorize(ctx context.Context, token string) (*models.AssessmentShareLink, error) {
+	if token == "" {
+		return nil, ErrShareLinkInvalid
+	}
+	link, err := s.repo.ShareLink().GetByTokenHash(ctx, nil, hashShareLinkToken(token))
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrShareLinkInvalid
+		}
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+	if !link.IsActive(time.Now()) {
+		return nil, ErrShareLinkInvalid
+	}
+	return link, nil
+}
+
+// GetSharedAssessment logs the view and returns the assessment's content and settings.
+// A view that cannot be logged is not served.
+func (s *shareLinkService) GetSharedAssessment(ctx context.Context, link *models.AssessmentShareLink, viewer *ShareViewer) (*SharedAssessment, error) {
+	assessment, err := s.repo.Assessment().GetByIDWithDetails(ctx, s.db, link.AssessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrShareLinkInvalid
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	view := &models.AssessmentShareView{
+		ShareLinkID: link.ID,
+		IPAddress:   viewer.IPAddress,
+		UserAgent:   truncateUserAgent(viewer.UserAgent),
+		ViewedAt:    time.Now(),
+	}
+	if err := s.repo.ShareLink().RecordView(ctx, nil, view); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Shared assessment viewed",
+		"share_link_id", link.ID,
+		"assessment_id", link.AssessmentID,
+		"ip_address", viewer.IPAddress)
+
+	return buildSharedAssessment(assessment, link), nil
+}
+
+// ===== HELPER METHODS =====
+
+func (s *shareLinkService) getManagedAssessment(ctx context.Context, assessmentID uint, userID, action string) (*models.Assessment, error) {
+	assessment, err := s.repo.Assessment().GetByID(ctx, s.db, assessmentID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrAssessmentNotFound
+		}
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleAdmin && (user.Role != models.RoleTeacher || assessment.CreatedBy != userID) {
+		return nil, NewPermissionError(userID, assessmentID, "assessment", action, "not owner or insufficient permissions")
+	}
+
+	return assessment, nil
+}
+
+func (s *shareLinkService) getManagedLink(ctx context.Context, id uint, userID, action string) (*models.AssessmentShareLink, error) {
+	link, err := s.repo.ShareLink().GetByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+	if _, err := s.getManagedAssessment(ctx, link.AssessmentID, userID, action); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+// buildSharedAssessment copies what a reviewer may see, leaving out attempts, the creator's
+// profile and statistics computed from student answers
+func buildSharedAssessment(assessment *models.Assessment, link *models.AssessmentShareLink) *SharedAssessment {
+	shared := &SharedAssessment{
+		ID:            assessment.ID,
+		Title:         assessment.Title,
+		Description:   assessment.Description,
+		Duration:      assessment.Duration,
+		PassingScore:  assessment.PassingScore,
+		MaxAttempts:   assessment.MaxAttempts,
+		DueDate:       assessment.DueDate,
+		DueTimezone:   assessment.DueTimezone,
+		Term:          assessment.Term,
+		Version:       assessment.Version,
+		Settings:      assessment.Settings,
+		Questions:     make([]SharedQuestion, 0, len(assessment.Questions)),
+		LinkExpiresAt: link.ExpiresAt,
+	}
+	shared.Settings.ResultsReleasedBy = nil
+
+	for _, aq := range assessment.Questions {
+		points := aq.Question.Points
+		if aq.Points != nil {
+			points = *aq.Points
+		}
+		timeLimit := aq.Question.TimeLimit
+		if aq.TimeLimit != nil {
+			timeLimit = aq.TimeLimit
+		}
+		shared.TotalPoints += points
+		shared.Questions = append(shared.Questions, SharedQuestion{
+			ID:          aq.QuestionID,
+			Order:       aq.Order,
+			Type:        aq.Question.Type,
+			Text:        aq.Question.Text,
+			Points:      points,
+			TimeLimit:   timeLimit,
+			Required:    aq.Required,
+			Difficulty:  aq.Question.Difficulty,
+			Content:     json.RawMessage(aq.Question.Content),
+			Answer:      json.RawMessage(aq.Question.Answer),
+			Explanation: aq.Question.Explanation,
+		})
+	}
+	return shared
+}
+
+func truncateUserAgent(userAgent string) string {
+	if len(userAgent) > 500 {
+		return userAgent[:500]
+	}
+	return userAgent
+}
+
+func newShareLinkToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate share link token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func hashShareLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
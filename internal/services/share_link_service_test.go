@@ -0,0 +1,71 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"gorm.io/datatypes"
+)
+
+func TestBuildSharedAssessment(t *testing.T) {
+	override := 5
+	releasedBy := "t1"
+	expires := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	assessment := &models.Assessment{
+		ID:           42,
+		Title:        "Midterm",
+		Duration:     60,
+		AttemptCount: 30,
+		AvgScore:     71.5,
+		Settings:     models.AssessmentSettings{AssessmentID: 42, ShowResults: true, ResultsReleasedBy: &releasedBy},
+		Questions: []models.AssessmentQuestion{
+			{QuestionID: 1, Order: 1, Required: true, Question: models.Question{ID: 1, Type: models.MultipleChoice, Text: "2 + 2?", Points: 2, Content: datatypes.JSON(`{"options":[]}`), AvgScore: 0.8}},
+			{QuestionID: 2, Order: 2, Points: &override, Question: models.Question{ID: 2, Type: models.Essay, Text: "Discuss", Points: 10}},
+		},
+		Attempts: []models.AssessmentAttempt{{ID: 9, StudentID: "s1"}},
+	}
+
+	shared := buildSharedAssessment(assessment, &models.AssessmentShareLink{ExpiresAt: expires})
+
+	if shared.ID != 42 || shared.Title != "Midterm" || !shared.LinkExpiresAt.Equal(expires) {
+		t.Errorf("unexpected assessment %+v", shared)
+	}
+	if len(shared.Questions) != 2 || shared.Questions[1].Points != 5 || shared.TotalPoints != 7 {
+		t.Errorf("expected the assessment's point weights, got %+v (total %d)", shared.Questions, shared.TotalPoints)
+	}
+	if string(shared.Questions[0].Content) != `{"options":[]}` {
+		t.Errorf("expected the question content, got %s", shared.Questions[0].Content)
+	}
+	if shared.Settings.ResultsReleasedBy != nil || !shared.Settings.ShowResults {
+		t.Errorf("expected the settings without who released results, got %+v", shared.Settings)
+	}
+	if assessment.Settings.ResultsReleasedBy == nil {
+		t.Error("expected the loaded assessment to be left unchanged")
+	}
+}
+
+func TestShareLinkIsActive(t *testing.T) {
+	now := time.Now()
+	link := &models.AssessmentShareLink{ExpiresAt: now.Add(time.Hour)}
+	if !link.IsActive(now) {
+		t.Error("expected a fresh link to be active")
+	}
+	if link.IsActive(now.Add(2 * time.Hour)) {
+		t.Error("expected an expired link to be refused")
+	}
+	link.RevokedAt = &now
+	if link.IsActive(now) {
+		t.Error("expected a revoked link to be refused")
+	}
+}
+
+func TestShareLinkToken(t *testing.T) {
+	token, err := newShareLinkToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(token) != 64 || hashShareLinkToken(token) == token || hashShareLinkToken(token) != hashShareLinkToken(token) {
+		t.Errorf("unexpected token %q", token)
+	}
+}
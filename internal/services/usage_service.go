@@ -0,0 +1,324 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+const (
+	usagePeriodLayout = "2006-01"
+	// Usage of users without an affiliation is billed to no one until they get one
+	usageUnassignedOrganization = "unassigned"
+	defaultUsageReportMonths    = 12
+)
+
+// Metrics summed within their month; attachment storage is a running total instead
+var usageFlowMetrics = []models.UsageMetric{
+	models.UsageAttemptsStarted,
+	models.UsageAIGradingCalls,
+	models.UsageProctoringMinutes,
+}
+
+type usageService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+}
+
+func NewUsageService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator) UsageService {
+	return &usageService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+// ===== REPORTING =====
+
+func (s *usageService) GetReport(ctx context.Context, filter *UsageReportFilter, userID string) (*UsageReport, error) {
+	s.logger.Info("Building usage report", "from", filter.From, "to", filter.To, "organization", filter.Organization, "user_id", userID)
+
+	if err := s.requireAdmin(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	from, to, err := resolveUsageRange(filter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	rollups, err := s.repo.Usage().GetRollups(ctx, nil, from, to, filter.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UsageReport{
+		From:         from,
+		To:           to,
+		Organization: filter.Organization,
+		Rows:         buildUsageRows(rollups),
+		GeneratedAt:  time.Now(),
+	}, nil
+}
+
+func (s *usageService) ExportReport(ctx context.Context, filter *UsageReportFilter, userID string) (*ExportedDocument, error) {
+	report, err := s.GetReport(ctx, filter, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := usageCSV(report.Rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportedDocument{
+		FileName:    fmt.Sprintf("usage-%s-%s.csv", report.From, report.To),
+		ContentType: "text/csv",
+		Content:     content,
+	}, nil
+}
+
+// ===== ROLLUPS =====
+
+// RollupMonth recomputes the month's totals of every organization from the usage events
+func (s *usageService) RollupMonth(ctx context.Context, month time.Time) (int, error) {
+	start := monthStart(month)
+	end := start.AddDate(0, 1, 0)
+
+	flows, err := s.repo.Usage().SumEvents(ctx, nil, usageFlowMetrics, start, end)
+	if err != nil {
+		return 0, err
+	}
+	storage, err := s.repo.Usage().SumEvents(ctx, nil, []models.UsageMetric{models.UsageAttachmentStorage}, time.Time{}, end)
+	if err != nil {
+		return 0, err
+	}
+
+	rollups := buildUsageRollups(start.Format(usagePeriodLayout), append(flows, storage...), time.Now())
+	if err := s.repo.Usage().UpsertRollups(ctx, nil, rollups); err != nil {
+		return 0, err
+	}
+	return len(rollups), nil
+}
+
+// RunScheduler refreshes the current month's rollups every interval until the context is
+// cancelled. During the first day of a month the previous month is refreshed too, so events
+// recorded just before midnight are included.
+func (s *usageService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.Info("Usage rollup scheduler started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Usage rollup scheduler stopped")
+			return
+		case <-ticker.C:
+			now := time.Now().UTC()
+			months := []time.Time{now}
+			if now.Sub(monthStart(now)) < 24*time.Hour {
+				months = append(months, monthStart(now).AddDate(0, -1, 0))
+			}
+			for _, month := range months {
+				if _, err := s.RollupMonth(ctx, month); err != nil {
+					s.logger.Error("Failed to roll up usage", "period", month.Format(usagePeriodLayout), "error", err)
+				}
+			}
+		}
+	}
+}
+
+// ===== HELPER METHODS =====
+
+func (s *usageService) requireAdmin(ctx context.Context, userID string) error {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleAdmin {
+		return NewPermissionError(userID, 0, "usage", "read", "only admins may view usage")
+	}
+	return nil
+}
+
+// ===== METERING =====
+
+// recordUsage meters a billable event against the organization of the event's user.
+// Metering never fails the operation being metered; errors are logged.
+func recordUsage(ctx context.Context, repo repositories.Repository, logger *slog.Logger, event *models.UsageEvent) {
+	organization := usageUnassignedOrganization
+	if user, err := repo.User().GetByID(ctx, event.UserID); err != nil {
+		logger.Error("Failed to get user for usage metering", "user_id", event.UserID, "error", err)
+	} else {
+		organization = usageOrganization(user)
+	}
+
+	event.Organization = organization
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	if err := repo.Usage().CreateEvent(ctx, nil, event); err != nil {
+		logger.Error("Failed to record usage", "metric", event.Metric, "user_id", event.UserID, "error", err)
+	}
+}
+
+func usageOrganization(user *models.User) string {
+	if user.Organization == nil || strings.TrimSpace(*user.Organization) == "" {
+		return usageUnassignedOrganization
+	}
+	return strings.TrimSpace(*user.Organization)
+}
+
+// isProctored reports whether attempts on the assessment run under any proctoring control
+func isProctored(settings *models.AssessmentSettings) bool {
+	return settings.RequireWebcam ||
+		settings.RequireScreenRecording ||
+		settings.PreventTabSwitching ||
+		settings.PreventRightClick ||
+		settings.PreventCopyPaste ||
+		settings.RequireIdentityVerification ||
+		settings.RequireFullScreen
+}
+
+// proctoredMinutes is the time an attempt was taken, in started minutes. Attempts that did
+// not report their time spent are timed from start to completion.
+func proctoredMinutes(attempt *models.AssessmentAttempt) int64 {
+	seconds := int64(attempt.TimeSpent)
+	if seconds <= 0 && attempt.StartedAt != nil && attempt.CompletedAt != nil {
+		seconds = int64(attempt.CompletedAt.Sub(*attempt.StartedAt).Seconds())
+	}
+	if seconds <= 0 {
+		return 0
+	}
+	return (seconds + 59) / 60
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// resolveUsageRange validates a report's months, defaulting to the twelve months up to now
+func resolveUsageRange(filter *UsageReportFilter, now time.Time) (string, string, error) {
+	to := monthStart(now)
+	if filter.To != "" {
+		parsed, err := time.Parse(usagePeriodLayout, filter.To)
+		if err != nil {
+			return "", "", ValidationErrors{*NewValidationError("to", "must be a month in YYYY-MM format", filter.To)}
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 1-defaultUsageReportMonths, 0)
+	if filter.From != "" {
+		parsed, err := time.Parse(usagePeriodLayout, filter.From)
+		if err != nil {
+			return "", "", ValidationErrors{*NewValidationError("from", "must be a month in YYYY-MM format", filter.From)}
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		return "", "", ValidationErrors{*NewValidationError("from", "must not be after to", filter.From)}
+	}
+	return from.Format(usagePeriodLayout), to.Format(usagePeriodLayout), nil
+}
+
+func buildUsageRollups(period string, totals []repositories.UsageTotal, now time.Time) []*models.UsageRollup {
+	rollups := make([]*models.UsageRollup, 0, len(totals))
+	for _, total := range totals {
+		rollups = append(rollups, &models.UsageRollup{
+			Organization: total.Organization,
+			Period:       period,
+			Metric:       total.Metric,
+			Quantity:     total.Quantity,
+			Events:       total.Events,
+			CalculatedAt: now,
+		})
+	}
+	return rollups
+}
+
+// buildUsageRows turns rollups into one row per organization and month, ordered by both
+func buildUsageRows(rollups []*models.UsageRollup) []UsageReportRow {
+	type rowKey struct{ organization, period string }
+	byKey := make(map[rowKey]*UsageReportRow)
+	for _, rollup := range rollups {
+		key := rowKey{rollup.Organization, rollup.Period}
+		row, ok := byKey[key]
+		if !ok {
+			row = &UsageReportRow{Organization: rollup.Organization, Period: rollup.Period}
+			byKey[key] = row
+		}
+		switch rollup.Metric {
+		case models.UsageAttemptsStarted:
+			row.AttemptsStarted = rollup.Quantity
+		case models.UsageAIGradingCalls:
+			row.AIGradingCalls = rollup.Quantity
+		case models.UsageProctoringMinutes:
+			row.ProctoringMinutes = rollup.Quantity
+		case models.UsageAttachmentStorage:
+			row.AttachmentStorageBytes = rollup.Quantity
+		}
+	}
+
+	rows := make([]UsageReportRow, 0, len(byKey))
+	for _, row := range byKey {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Organization != rows[j].Organization {
+			return rows[i].Organization < rows[j].Organization
+		}
+		return rows[i].Period < rows[j].Period
+	})
+	return rows
+}
+
+func usageCSV(rows []UsageReportRow) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	header := []string{"organization", "period", "attempts_started", "ai_grading_calls", "proctoring_minutes", "attachment_storage_bytes"}
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, row := range rows {
+		record := []string{
+			row.Organization,
+			row.Period,
+			strconv.FormatInt(row.AttemptsStarted, 10),
+			strconv.FormatInt(row.AIGradingCalls, 10),
+			strconv.FormatInt(row.ProctoringMinutes, 10),
+			strconv.FormatInt(row.AttachmentStorageBytes, 10),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
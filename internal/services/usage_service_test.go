@@ -0,0 +1,129 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestResolveUsageRange(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		filter   UsageReportFilter
+		from, to string
+		wantErr  bool
+	}{
+		{"defaults to twelve months", UsageReportFilter{}, "2025-11", "2026-10", false},
+		{"explicit range", UsageReportFilter{From: "2026-01", To: "2026-03"}, "2026-01", "2026-03", false},
+		{"single month", UsageReportFilter{From: "2026-05", To: "2026-05"}, "2026-05", "2026-05", false},
+		{"from defaults relative to to", UsageReportFilter{To: "2026-03"}, "2025-04", "2026-03", false},
+		{"bad month", UsageReportFilter{From: "2026-13"}, "", "", true},
+		{"bad format", UsageReportFilter{To: "March"}, "", "", true},
+		{"reversed", UsageReportFilter{From: "2026-04", To: "2026-03"}, "", "", true},
+	}
+
+	for _, tt := range tests {
+		from, to, err := resolveUsageRange(&tt.filter, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+			continue
+		}
+		if from != tt.from || to != tt.to {
+			t.Errorf("%s: got %s..%s, want %s..%s", tt.name, from, to, tt.from, tt.to)
+		}
+	}
+}
+
+func TestBuildUsageRows(t *testing.T) {
+	rollups := []*models.UsageRollup{
+		{Organization: "north", Period: "2026-02", Metric: models.UsageAttemptsStarted, Quantity: 40},
+		{Organization: "north", Period: "2026-01", Metric: models.UsageProctoringMinutes, Quantity: 300},
+		{Organization: "east", Period: "2026-01", Metric: models.UsageAttachmentStorage, Quantity: 2048},
+		{Organization: "north", Period: "2026-01", Metric: models.UsageAttemptsStarted, Quantity: 12},
+	}
+
+	rows := buildUsageRows(rollups)
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(rows))
+	}
+	if rows[0].Organization != "east" || rows[0].AttachmentStorageBytes != 2048 {
+		t.Errorf("unexpected first row %+v", rows[0])
+	}
+	if rows[1].Period != "2026-01" || rows[1].AttemptsStarted != 12 || rows[1].ProctoringMinutes != 300 {
+		t.Errorf("unexpected second row %+v", rows[1])
+	}
+	if rows[2].Period != "2026-02" || rows[2].AttemptsStarted != 40 || rows[2].ProctoringMinutes != 0 {
+		t.Errorf("unexpected third row %+v", rows[2])
+	}
+}
+
+func TestUsageCSV(t *testing.T) {
+	content, err := usageCSV([]UsageReportRow{
+		{Organization: "Springfield, District 9", Period: "2026-01", AttemptsStarted: 3, ProctoringMinutes: 45, AttachmentStorageBytes: 1024},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected header and one row, got %q", lines)
+	}
+	if lines[0] != "organization,period,attempts_started,ai_grading_calls,proctoring_minutes,attachment_storage_bytes" {
+		t.Errorf("unexpected header %q", lines[0])
+	}
+	if lines[1] != `"Springfield, District 9",2026-01,3,0,45,1024` {
+		t.Errorf("unexpected row %q", lines[1])
+	}
+}
+
+func TestProctoredMinutes(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(30*time.Minute + 10*time.Second)
+
+	tests := []struct {
+		name    string
+		attempt models.AssessmentAttempt
+		want    int64
+	}{
+		{"reported time rounds up", models.AssessmentAttempt{TimeSpent: 61}, 2},
+		{"whole minutes", models.AssessmentAttempt{TimeSpent: 120}, 2},
+		{"timed from start to completion", models.AssessmentAttempt{StartedAt: &start, CompletedAt: &end}, 31},
+		{"no timing", models.AssessmentAttempt{}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := proctoredMinutes(&tt.attempt); got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIsProctored(t *testing.T) {
+	if isProctored(&models.AssessmentSettings{}) {
+		t.Error("assessment without proctoring controls should not be proctored")
+	}
+	if !isProctored(&models.AssessmentSettings{RequireWebcam: true}) {
+		t.Error("webcam requirement should count as proctoring")
+	}
+	if !isProctored(&models.AssessmentSettings{PreventTabSwitching: true}) {
+		t.Error("tab switching prevention should count as proctoring")
+	}
+}
+
+func TestUsageOrganization(t *testing.T) {
+	blank, district := "  ", " Springfield "
+	if got := usageOrganization(&models.User{}); got != usageUnassignedOrganization {
+		t.Errorf("user without affiliation: got %q", got)
+	}
+	if got := usageOrganization(&models.User{Organization: &blank}); got != usageUnassignedOrganization {
+		t.Errorf("blank affiliation: got %q", got)
+	}
+	if got := usageOrganization(&models.User{Organization: &district}); got != "Springfield" {
+		t.Errorf("affiliation should be trimmed, got %q", got)
+	}
+}
@@ -0,0 +1,574 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+const (
+	// Nightly exports start at this hour, UTC
+	warehouseExportHour = 2
+	// Exports stop this far short of now: rows written by transactions still open at the
+	// cutoff carry earlier timestamps, and would otherwise fall between two windows
+	warehouseSettleTime = 10 * time.Minute
+	// A run left running for this long is treated as orphaned by a crash
+	warehouseRunStaleAfter = 2 * time.Hour
+	warehouseBatchSize     = 5000
+	warehouseRunBatch      = 5
+	warehouseRunListLimit  = 100
+	warehouseTimeout       = 2 * time.Minute
+)
+
+type warehouseExportService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+	sinks     map[models.WarehouseTarget]WarehouseSink
+}
+
+func NewWarehouseExportService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, sinks map[models.WarehouseTarget]WarehouseSink) WarehouseExportService {
+	if sinks == nil {
+		sinks = NewWarehouseSinks(&http.Client{Timeout: warehouseTimeout})
+	}
+	return &warehouseExportService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+		sinks:     sinks,
+	}
+}
+
+// ===== CONNECTORS =====
+
+func (s *warehouseExportService) CreateConnector(ctx context.Context, req *CreateWarehouseConnectorRequest, userID string) (*models.WarehouseConnector, error) {
+	s.logger.Info("Creating warehouse connector", "organization", req.Organization, "target", req.Target, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(ctx, userID, "manage_warehouse"); err != nil {
+		return nil, err
+	}
+
+	connector := &models.WarehouseConnector{
+		Organization: strings.TrimSpace(req.Organization),
+		Target:       req.Target,
+		Enabled:      true,
+		Endpoint:     strings.TrimSpace(req.Endpoint),
+		AuthToken:    req.AuthToken,
+		AccessKeyID:  strings.TrimSpace(req.AccessKeyID),
+		Region:       strings.TrimSpace(req.Region),
+		Dataset:      strings.TrimSpace(req.Dataset),
+		CreatedBy:    userID,
+	}
+	if err := validateWarehouseConnector(connector); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Warehouse().CreateConnector(ctx, nil, connector); err != nil {
+		return nil, err
+	}
+	return connector, nil
+}
+
+func (s *warehouseExportService) ListConnectors(ctx context.Context, userID string) ([]*models.WarehouseConnector, error) {
+	if err := s.requireAdmin(ctx, userID, "view_warehouse"); err != nil {
+		return nil, err
+	}
+	return s.repo.Warehouse().ListConnectors(ctx, nil)
+}
+
+func (s *warehouseExportService) UpdateConnector(ctx context.Context, id uint, req *UpdateWarehouseConnectorRequest, userID string) (*models.WarehouseConnector, error) {
+	s.logger.Info("Updating warehouse connector", "connector_id", id, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(ctx, userID, "manage_warehouse"); err != nil {
+		return nil, err
+	}
+
+	connector, err := s.getConnector(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Enabled != nil {
+		connector.Enabled = *req.Enabled
+	}
+	if req.Endpoint != nil {
+		connector.Endpoint = strings.TrimSpace(*req.Endpoint)
+	}
+	if req.AuthToken != nil {
+		connector.AuthToken = *req.AuthToken
+	}
+	if req.AccessKeyID != nil {
+		connector.AccessKeyID = strings.TrimSpace(*req.AccessKeyID)
+	}
+	if req.Region != nil {
+		connector.Region = strings.TrimSpace(*req.Region)
+	}
+	if req.Dataset != nil {
+		connector.Dataset = strings.TrimSpace(*req.Dataset)
+	}
+	if err := validateWarehouseConnector(connector); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Warehouse().UpdateConnector(ctx, nil, connector); err != nil {
+		return nil, err
+	}
+	return connector, nil
+}
+
+func (s *warehouseExportService) DeleteConnector(ctx context.Context, id uint, userID string) error {
+	s.logger.Info("Deleting warehouse connector", "connector_id", id, "user_id", userID)
+
+	if err := s.requireAdmin(ctx, userID, "manage_warehouse"); err != nil {
+		return err
+	}
+	if _, err := s.getConnector(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Warehouse().DeleteConnector(ctx, nil, id)
+}
+
+// GetSchema describes the fact tables organizations create in their warehouse
+func (s *warehouseExportService) GetSchema() []WarehouseTable {
+	return warehouseTables()
+}
+
+// ===== RUNS =====
+
+func (s *warehouseExportService) ListRuns(ctx context.Context, connectorID uint, userID string) ([]*models.WarehouseExportRun, error) {
+	if err := s.requireAdmin(ctx, userID, "view_warehouse"); err != nil {
+		return nil, err
+	}
+	if _, err := s.getConnector(ctx, connectorID); err != nil {
+		return nil, err
+	}
+	return s.repo.Warehouse().ListRuns(ctx, nil, connectorID, warehouseRunListLimit)
+}
+
+func (s *warehouseExportService) RequestBackfill(ctx context.Context, connectorID uint, req *WarehouseBackfillRequest, userID string) (*models.WarehouseExportRun, error) {
+	s.logger.Info("Requesting warehouse backfill", "connector_id", connectorID, "from", req.From, "to", req.To, "user_id", userID)
+
+	if err := s.requireAdmin(ctx, userID, "manage_warehouse"); err != nil {
+		return nil, err
+	}
+	return s.queueBackfill(ctx, connectorID, req, userID)
+}
+
+// Backfill queues a backfill and runs it before returning
+func (s *warehouseExportService) Backfill(ctx context.Context, connectorID uint, req *WarehouseBackfillRequest, requestedBy string) (*models.WarehouseExportRun, error) {
+	s.logger.Info("Running warehouse backfill", "connector_id", connectorID, "from", req.From, "to", req.To, "requested_by", requestedBy)
+
+	run, err := s.queueBackfill(ctx, connectorID, req, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.processRun(ctx, run.ID); err != nil {
+		return nil, err
+	}
+	return s.repo.Warehouse().GetRunByID(ctx, nil, run.ID)
+}
+
+// ===== NIGHTLY EXPORT =====
+
+// QueueNightlyExports queues an incremental run for each enabled connector due one and
+// without one open already
+func (s *warehouseExportService) QueueNightlyExports(ctx context.Context, now time.Time) (int, error) {
+	connectors, err := s.repo.Warehouse().GetEnabledConnectors(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for _, connector := range connectors {
+		if !incrementalExportDue(connector, now) {
+			continue
+		}
+		run := &models.WarehouseExportRun{
+			ConnectorID:   connector.ID,
+			Kind:          models.WarehouseRunIncremental,
+			Status:        models.WarehouseRunQueued,
+			SchemaVersion: WarehouseSchemaVersion,
+			WindowStart:   incrementalWindowStart(connector),
+			WindowEnd:     now.Add(-warehouseSettleTime),
+			RequestedBy:   "system",
+		}
+		created, err := s.repo.Warehouse().CreateIncrementalRun(ctx, nil, run)
+		if err != nil {
+			return queued, err
+		}
+		if created {
+			queued++
+		}
+	}
+	return queued, nil
+}
+
+// ProcessQueuedRuns runs queued exports, and ones orphaned by a crash, oldest first
+func (s *warehouseExportService) ProcessQueuedRuns(ctx context.Context, limit int) (int, error) {
+	ids, err := s.repo.Warehouse().GetClaimableRuns(ctx, nil, time.Now().Add(-warehouseRunStaleAfter), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, id := range ids {
+		ran, err := s.processRun(ctx, id)
+		if err != nil {
+			s.logger.Error("Failed to process warehouse export run", "run_id", id, "error", err)
+			continue
+		}
+		if ran {
+			processed++
+		}
+	}
+	return processed, nil
+}
+
+// RunScheduler queues the nightly exports and runs queued exports every interval until the
+// context is cancelled
+func (s *warehouseExportService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.Info("Warehouse export scheduler started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Warehouse export scheduler stopped")
+			return
+		case <-ticker.C:
+			if _, err := s.QueueNightlyExports(ctx, time.Now()); err != nil {
+				s.logger.Error("Failed to queue nightly warehouse exports", "error", err)
+			}
+			if _, err := s.ProcessQueuedRuns(ctx, warehouseRunBatch); err != nil {
+				s.logger.Error("Failed to process warehouse export runs", "error", err)
+			}
+		}
+	}
+}
+
+// ===== HELPER METHODS =====
+
+func (s *warehouseExportService) queueBackfill(ctx context.Context, connectorID uint, req *WarehouseBackfillRequest, requestedBy string) (*models.WarehouseExportRun, error) {
+	connector, err := s.getConnector(ctx, connectorID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, err := backfillWindow(connector, req, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	run := &models.WarehouseExportRun{
+		ConnectorID:   connector.ID,
+		Kind:          models.WarehouseRunBackfill,
+		Status:        models.WarehouseRunQueued,
+		SchemaVersion: WarehouseSchemaVersion,
+		WindowStart:   start,
+		WindowEnd:     end,
+		RequestedBy:   requestedBy,
+	}
+	if err := s.repo.Warehouse().CreateRun(ctx, nil, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// processRun claims a run and exports its window. A failure is stored on the run and the
+// connector; a failed nightly run is queued again on the next tick, as the connector's
+// watermark has not moved. It reports false when another worker holds the run.
+func (s *warehouseExportService) processRun(ctx context.Context, id uint) (bool, error) {
+	now := time.Now()
+	claimed, err := s.repo.Warehouse().ClaimRun(ctx, nil, id, now, now.Add(-warehouseRunStaleAfter))
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	run, err := s.repo.Warehouse().GetRunByID(ctx, nil, id)
+	if err != nil {
+		return false, err
+	}
+	connector, err := s.repo.Warehouse().GetConnectorByID(ctx, nil, run.ConnectorID)
+	if err != nil {
+		return false, err
+	}
+
+	exportErr := s.export(ctx, connector, run)
+
+	completedAt := time.Now()
+	run.CompletedAt = &completedAt
+	connector.LastRunAt = &completedAt
+	if exportErr != nil {
+		message := exportErr.Error()
+		run.Status = models.WarehouseRunFailed
+		run.Error = &message
+		connector.LastError = &message
+		s.logger.Error("Warehouse export failed",
+			"run_id", run.ID,
+			"organization", connector.Organization,
+			"error", exportErr)
+	} else {
+		run.Status = models.WarehouseRunCompleted
+		connector.LastError = nil
+		if run.Kind == models.WarehouseRunIncremental {
+			connector.ExportedThrough = &run.WindowEnd
+			connector.SchemaVersion = run.SchemaVersion
+		}
+	}
+
+	if err := s.repo.Warehouse().UpdateRun(ctx, nil, run); err != nil {
+		return true, err
+	}
+	if err := s.repo.Warehouse().UpdateConnector(ctx, nil, connector); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// export writes the facts of the connector's organization changed within the run's window,
+// assessments first so answers never arrive before what they belong to
+func (s *warehouseExportService) export(ctx context.Context, connector *models.WarehouseConnector, run *models.WarehouseExportRun) error {
+	sink, ok := s.sinks[connector.Target]
+	if !ok {
+		return fmt.Errorf("unsupported warehouse target %q", connector.Target)
+	}
+
+	creatorIDs, err := s.organizationCreators(ctx, connector.Organization)
+	if err != nil {
+		return err
+	}
+
+	window := repositories.FactWindow{After: run.WindowStart, Through: run.WindowEnd}
+	meta := warehouseRowMeta{organization: connector.Organization, exportedAt: time.Now()}
+	facts := s.repo.Warehouse()
+
+	run.AssessmentFacts, err = s.exportTable(ctx, sink, connector, run, warehouseAssessmentFacts, meta, func(afterID uint) ([]WarehouseRow, uint, error) {
+		rows, err := facts.GetAssessmentFacts(ctx, nil, creatorIDs, window, afterID, warehouseBatchSize)
+		if err != nil || len(rows) == 0 {
+			return nil, 0, err
+		}
+		out := make([]WarehouseRow, len(rows))
+		for i, fact := range rows {
+			out[i] = assessmentFactRow(fact, meta)
+		}
+		return out, rows[len(rows)-1].AssessmentID, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	run.AttemptFacts, err = s.exportTable(ctx, sink, connector, run, warehouseAttemptFacts, meta, func(afterID uint) ([]WarehouseRow, uint, error) {
+		rows, err := facts.GetAttemptFacts(ctx, nil, creatorIDs, window, afterID, warehouseBatchSize)
+		if err != nil || len(rows) == 0 {
+			return nil, 0, err
+		}
+		out := make([]WarehouseRow, len(rows))
+		for i, fact := range rows {
+			out[i] = attemptFactRow(fact, meta)
+		}
+		return out, rows[len(rows)-1].AttemptID, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	run.AnswerFacts, err = s.exportTable(ctx, sink, connector, run, warehouseAnswerFacts, meta, func(afterID uint) ([]WarehouseRow, uint, error) {
+		rows, err := facts.GetAnswerFacts(ctx, nil, creatorIDs, window, afterID, warehouseBatchSize)
+		if err != nil || len(rows) == 0 {
+			return nil, 0, err
+		}
+		out := make([]WarehouseRow, len(rows))
+		for i, fact := range rows {
+			out[i] = answerFactRow(fact, meta)
+		}
+		return out, rows[len(rows)-1].AnswerID, nil
+	})
+	return err
+}
+
+// exportTable pages through a fact table by ID and writes each page as one batch
+func (s *warehouseExportService) exportTable(ctx context.Context, sink WarehouseSink, connector *models.WarehouseConnector, run *models.WarehouseExportRun, name string, meta warehouseRowMeta, fetch func(afterID uint) ([]WarehouseRow, uint, error)) (int, error) {
+	table := warehouseTable(name)
+	total := 0
+	var afterID uint
+	for part := 1; ; part++ {
+		rows, lastID, err := fetch(afterID)
+		if err != nil {
+			return total, err
+		}
+		if len(rows) == 0 {
+			return total, nil
+		}
+
+		batch := &WarehouseBatch{RunID: run.ID, Part: part, Table: table, Rows: rows, ExportedAt: meta.exportedAt}
+		if err := sink.WriteBatch(ctx, connector, batch); err != nil {
+			return total, fmt.Errorf("failed to write %s part %d: %w", table.Name, part, err)
+		}
+		total += len(rows)
+		afterID = lastID
+
+		if len(rows) < warehouseBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// organizationCreators returns the users of the organization who created assessments, as
+// facts belong to the organization of the assessment's creator
+func (s *warehouseExportService) organizationCreators(ctx context.Context, organization string) ([]string, error) {
+	ids, err := s.repo.Warehouse().GetAssessmentCreatorIDs(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	users, err := s.repo.User().GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment creators: %w", err)
+	}
+
+	var creators []string
+	for _, user := range users {
+		if usageOrganization(user) == organization {
+			creators = append(creators, user.ID)
+		}
+	}
+	return creators, nil
+}
+
+func (s *warehouseExportService) getConnector(ctx context.Context, id uint) (*models.WarehouseConnector, error) {
+	connector, err := s.repo.Warehouse().GetConnectorByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return connector, nil
+}
+
+func (s *warehouseExportService) requireAdmin(ctx context.Context, userID, action string) error {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleAdmin {
+		return NewPermissionError(userID, 0, "warehouse", action, "only admins may manage warehouse exports")
+	}
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func validateWarehouseConnector(connector *models.WarehouseConnector) error {
+	invalid := func(field, message string, value interface{}) error {
+		return ValidationErrors{*NewValidationError(field, message, value)}
+	}
+
+	switch connector.Target {
+	case models.WarehouseTargetBigQuery:
+		if !hasTwoParts(connector.Dataset, ".") {
+			return invalid("dataset", "dataset must be project.dataset for BigQuery", connector.Dataset)
+		}
+	case models.WarehouseTargetSnowflake:
+		if connector.Endpoint == "" {
+			return invalid("endpoint", "endpoint is the account URL and is required for Snowflake", connector.Endpoint)
+		}
+		if !hasTwoParts(connector.Dataset, ".") {
+			return invalid("dataset", "dataset must be database.schema for Snowflake", connector.Dataset)
+		}
+	case models.WarehouseTargetS3Parquet:
+		if connector.AccessKeyID == "" {
+			return invalid("access_key_id", "access_key_id is required for S3", connector.AccessKeyID)
+		}
+		if connector.Region == "" {
+			return invalid("region", "region is required for S3", connector.Region)
+		}
+		if bucket, _, _ := strings.Cut(connector.Dataset, "/"); bucket == "" {
+			return invalid("dataset", "dataset must be bucket or bucket/prefix for S3", connector.Dataset)
+		}
+	default:
+		return invalid("target", "unsupported warehouse target", connector.Target)
+	}
+
+	if connector.Endpoint != "" && !strings.HasPrefix(connector.Endpoint, "https://") && !strings.HasPrefix(connector.Endpoint, "http://") {
+		return invalid("endpoint", "endpoint must be an http or https URL", connector.Endpoint)
+	}
+	if connector.AuthToken == "" {
+		return invalid("auth_token", "auth_token is required", "")
+	}
+	return nil
+}
+
+func hasTwoParts(value, separator string) bool {
+	first, second, found := strings.Cut(value, separator)
+	return found && first != "" && second != "" && !strings.Contains(second, separator)
+}
+
+// incrementalExportDue reports whether the connector's nightly export has not run since the
+// last export hour. Connectors never exported, or exported with an older schema, are due
+// right away.
+func incrementalExportDue(connector *models.WarehouseConnector, now time.Time) bool {
+	if connector.ExportedThrough == nil || connector.SchemaVersion != WarehouseSchemaVersion {
+		return true
+	}
+
+	now = now.UTC()
+	cutoff := time.Date(now.Year(), now.Month(), now.Day(), warehouseExportHour, 0, 0, 0, time.UTC)
+	if now.Before(cutoff) {
+		cutoff = cutoff.AddDate(0, 0, -1)
+	}
+	return connector.ExportedThrough.Before(cutoff.Add(-warehouseSettleTime))
+}
+
+// incrementalWindowStart continues from the connector's watermark, or starts over when the
+// tables of the current schema version have not been written yet
+func incrementalWindowStart(connector *models.WarehouseConnector) *time.Time {
+	if connector.SchemaVersion != WarehouseSchemaVersion {
+		return nil
+	}
+	return connector.ExportedThrough
+}
+
+// backfillWindow resolves a backfill request. It ends by default where the nightly export
+// has reached, or now when nothing was exported yet.
+func backfillWindow(connector *models.WarehouseConnector, req *WarehouseBackfillRequest, now time.Time) (*time.Time, time.Time, error) {
+	end := now.Add(-warehouseSettleTime)
+	if connector.ExportedThrough != nil {
+		end = *connector.ExportedThrough
+	}
+	if req.To != nil {
+		end = *req.To
+	}
+
+	if end.After(now) {
+		return nil, time.Time{}, ValidationErrors{*NewValidationError("to", "must not be in the future", req.To)}
+	}
+	if req.From != nil && !req.From.Before(end) {
+		return nil, time.Time{}, ValidationErrors{*NewValidationError("from", "must be before to", req.From)}
+	}
+	return req.From, end, nil
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"gorm.io/gorm"
+)
+
+func TestWarehouseFactRowsMatchSchema(t *testing.T) {
+	now := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+	term := "2025 Fall"
+	correct := true
+	meta := warehouseRowMeta{organization: "acme", exportedAt: now}
+
+	rows := map[string]WarehouseRow{
+		warehouseAssessmentFacts: assessmentFactRow(repositories.AssessmentFact{AssessmentID: 1, Term: &term, CreatedAt: now, UpdatedAt: now}, meta),
+		warehouseAttemptFacts:    attemptFactRow(repositories.AttemptFact{AttemptID: 2, StartedAt: &now, UpdatedAt: now}, meta),
+		warehouseAnswerFacts:     answerFactRow(repositories.AnswerFact{AnswerID: 3, IsCorrect: &correct, UpdatedAt: now}, meta),
+	}
+
+	for name, row := range rows {
+		table := warehouseTable(name)
+		if table.Name != name+"_v2" {
+			t.Errorf("expected versioned table name, got %s", table.Name)
+		}
+		if len(row) != len(table.Columns) {
+			t.Fatalf("%s: row has %d values for %d columns", name, len(row), len(table.Columns))
+		}
+		for i, column := range table.Columns {
+			if row[i] == nil {
+				if !column.Nullable {
+					t.Errorf("%s.%s is null but not nullable", name, column.Name)
+				}
+				continue
+			}
+			var ok bool
+			switch column.Type {
+			case WarehouseInt64:
+				_, ok = row[i].(int64)
+			case WarehouseFloat64:
+				_, ok = row[i].(float64)
+			case WarehouseString:
+				_, ok = row[i].(string)
+			case WarehouseBool:
+				_, ok = row[i].(bool)
+			case WarehouseTimestamp:
+				_, ok = row[i].(time.Time)
+			}
+			if !ok {
+				t.Errorf("%s.%s is %s but holds %T", name, column.Name, column.Type, row[i])
+			}
+		}
+		if row[len(row)-3] != "acme" || row[len(row)-2] != int64(WarehouseSchemaVersion) {
+			t.Errorf("%s: expected organization and schema version last, got %v", name, row[len(row)-3:])
+		}
+	}
+}
+
+func TestValidateWarehouseConnector(t *testing.T) {
+	valid := []*models.WarehouseConnector{
+		{Target: models.WarehouseTargetBigQuery, Dataset: "project.analytics", AuthToken: "t"},
+		{Target: models.WarehouseTargetSnowflake, Endpoint: "https://acme.snowflakecomputing.com", Dataset: "DB.PUBLIC", AuthToken: "t"},
+		{Target: models.WarehouseTargetS3Parquet, AccessKeyID: "AKID", Region: "eu-west-1", Dataset: "bucket/exports", AuthToken: "t"},
+	}
+	for _, connector := range valid {
+		if err := validateWarehouseConnector(connector); err != nil {
+			t.Errorf("%s: unexpected error %v", connector.Target, err)
+		}
+	}
+
+	invalid := map[string]*models.WarehouseConnector{
+		"dataset":       {Target: models.WarehouseTargetBigQuery, Dataset: "analytics", AuthToken: "t"},
+		"endpoint":      {Target: models.WarehouseTargetSnowflake, Dataset: "DB.PUBLIC", AuthToken: "t"},
+		"region":        {Target: models.WarehouseTargetS3Parquet, AccessKeyID: "AKID", Dataset: "bucket", AuthToken: "t"},
+		"auth_token":    {Target: models.WarehouseTargetBigQuery, Dataset: "p.d"},
+		"target":        {Target: "redshift", Dataset: "p.d", AuthToken: "t"},
+		"access_key_id": {Target: models.WarehouseTargetS3Parquet, Region: "eu-west-1", Dataset: "bucket", AuthToken: "t"},
+	}
+	for field, connector := range invalid {
+		var validationErrors ValidationErrors
+		err := validateWarehouseConnector(connector)
+		if !errors.As(err, &validationErrors) || validationErrors[0].Field != field {
+			t.Errorf("expected a validation error on %s, got %v", field, err)
+		}
+	}
+}
+
+func TestIncrementalExportDue(t *testing.T) {
+	at := func(day, hour int) *time.Time {
+		t := time.Date(2025, 6, day, hour, 0, 0, 0, time.UTC)
+		return &t
+	}
+	current := func(exportedThrough *time.Time) *models.WarehouseConnector {
+		return &models.WarehouseConnector{ExportedThrough: exportedThrough, SchemaVersion: WarehouseSchemaVersion}
+	}
+
+	if !incrementalExportDue(current(nil), *at(1, 12)) {
+		t.Error("a connector never exported should be due right away")
+	}
+	if incrementalExportDue(current(at(2, 3)), *at(2, 23)) {
+		t.Error("a connector exported after tonight's export hour should not be due")
+	}
+	if incrementalExportDue(current(at(1, 23)), *at(2, 1)) {
+		t.Error("a connector exported last evening should wait for the export hour")
+	}
+	if !incrementalExportDue(current(at(1, 23)), *at(2, 3)) {
+		t.Error("a connector should be due after the export hour")
+	}
+
+	outdated := &models.WarehouseConnector{ExportedThrough: at(2, 3), SchemaVersion: WarehouseSchemaVersion - 1}
+	if !incrementalExportDue(outdated, *at(2, 4)) || incrementalWindowStart(outdated) != nil {
+		t.Error("a connector on an older schema should export everything right away")
+	}
+	if start := incrementalWindowStart(current(at(2, 3))); start == nil || !start.Equal(*at(2, 3)) {
+		t.Errorf("expected the window to continue from the watermark, got %v", start)
+	}
+}
+
+func TestBackfillWindow(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	watermark := now.Add(-10 * time.Hour)
+	connector := &models.WarehouseConnector{ExportedThrough: &watermark}
+
+	start, end, err := backfillWindow(connector, &WarehouseBackfillRequest{}, now)
+	if err != nil || start != nil || !end.Equal(watermark) {
+		t.Errorf("expected all history up to the watermark, got %v-%v (%v)", start, end, err)
+	}
+
+	start, end, err = backfillWindow(&models.WarehouseConnector{}, &WarehouseBackfillRequest{}, now)
+	if err != nil || start != nil || !end.Equal(now.Add(-warehouseSettleTime)) {
+		t.Errorf("expected the window to end just before now without a watermark, got %v-%v (%v)", start, end, err)
+	}
+
+	from := now.Add(-48 * time.Hour)
+	to := now.Add(-24 * time.Hour)
+	start, end, err = backfillWindow(connector, &WarehouseBackfillRequest{From: &from, To: &to}, now)
+	if err != nil || !start.Equal(from) || !end.Equal(to) {
+		t.Errorf("expected the requested window, got %v-%v (%v)", start, end, err)
+	}
+
+	future := now.Add(time.Hour)
+	if _, _, err := backfillWindow(connector, &WarehouseBackfillRequest{To: &future}, now); err == nil {
+		t.Error("expected a window ending in the future to be rejected")
+	}
+	if _, _, err := backfillWindow(connector, &WarehouseBackfillRequest{From: &to, To: &from}, now); err == nil {
+		t.Error("expected a window starting after its end to be rejected")
+	}
+}
+
+// openRunStore keeps one open incremental run per connector, as the partial unique index does
+type openRunStore struct {
+	repositories.WarehouseRepository
+	connectors []*models.WarehouseConnector
+	open       map[uint]bool
+}
+
+func (w *openRunStore) GetEnabledConnectors(ctx context.Context, tx *gorm.DB) ([]*models.WarehouseConnector, error) {
+	return w.connectors, nil
+}
+
+func (w *openRunStore) CreateIncrementalRun(ctx context.Context, tx *gorm.DB, run *models.WarehouseExportRun) (bool, error) {
+	if w.open[run.ConnectorID] {
+		return false, nil
+	}
+	w.open[run.ConnectorID] = true
+	return true, nil
+}
+
+type warehouseRepository struct {
+	MockNotificationRepository
+	warehouse *openRunStore
+}
+
+func (r *warehouseRepository) Warehouse() repositories.WarehouseRepository { return r.warehouse }
+
+func TestQueueNightlyExportsOnceAcrossReplicas(t *testing.T) {
+	repo := &warehouseRepository{warehouse: &openRunStore{
+		connectors: []*models.WarehouseConnector{{ID: 1}, {ID: 2}},
+		open:       map[uint]bool{},
+	}}
+
+	total := 0
+	for replica := 0; replica < 2; replica++ {
+		service := NewWarehouseExportService(repo, nil, slog.New(slog.DiscardHandler), nil, map[models.WarehouseTarget]WarehouseSink{})
+		queued, err := service.QueueNightlyExports(context.Background(), time.Now())
+		if err != nil {
+			t.Fatalf("replica %d: %v", replica, err)
+		}
+		total += queued
+	}
+
+	if total != 2 {
+		t.Errorf("expected one run per connector, got %d", total)
+	}
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+)
+
+// WarehouseSchemaVersion is the version of the fact tables this build writes. Bump it when
+// columns change: connectors on an older version export everything again into the new tables.
+const WarehouseSchemaVersion = 2
+
+// WarehouseColumnType is a column type, named as in BigQuery
+type WarehouseColumnType string
+
+const (
+	WarehouseInt64     WarehouseColumnType = "INT64"
+	WarehouseFloat64   WarehouseColumnType = "FLOAT64"
+	WarehouseString    WarehouseColumnType = "STRING"
+	WarehouseBool      WarehouseColumnType = "BOOL"
+	WarehouseTimestamp WarehouseColumnType = "TIMESTAMP" // UTC
+)
+
+// WarehouseRow holds a fact's values in its table's column order: int64, float64, string,
+// bool or time.Time, and nil for NULL
+type WarehouseRow []interface{}
+
+const (
+	warehouseAssessmentFacts = "assessment_facts"
+	warehouseAttemptFacts    = "attempt_facts"
+	warehouseAnswerFacts     = "answer_facts"
+)
+
+// Every fact ends with its organization and when and by which schema it was exported.
+// Facts are appended, never updated: the latest updated_at of an ID is its current state.
+var warehouseTrailingColumns = []WarehouseColumn{
+	{Name: "organization", Type: WarehouseString},
+	{Name: "schema_version", Type: WarehouseInt64},
+	{Name: "exported_at", Type: WarehouseTimestamp},
+}
+
+var warehouseFactColumns = map[string][]WarehouseColumn{
+	warehouseAssessmentFacts: {
+		{Name: "assessment_id", Type: WarehouseInt64},
+		{Name: "title", Type: WarehouseString},
+		{Name: "status", Type: WarehouseString},
+		{Name: "created_by", Type: WarehouseString},
+		{Name: "term", Type: WarehouseString, Nullable: true},
+		{Name: "duration_minutes", Type: WarehouseInt64},
+		{Name: "passing_score", Type: WarehouseInt64},
+		{Name: "max_attempts", Type: WarehouseInt64},
+		{Name: "due_date", Type: WarehouseTimestamp, Nullable: true},
+		{Name: "question_count", Type: WarehouseInt64},
+		{Name: "created_at", Type: WarehouseTimestamp},
+		{Name: "updated_at", Type: WarehouseTimestamp},
+		{Name: "deleted_at", Type: WarehouseTimestamp, Nullable: true},
+	},
+	warehouseAttemptFacts: {
+		{Name: "attempt_id", Type: WarehouseInt64},
+		{Name: "assessment_id", Type: WarehouseInt64},
+		{Name: "student_id", Type: WarehouseString},
+		{Name: "attempt_number", Type: WarehouseInt64},
+		{Name: "status", Type: WarehouseString},
+		{Name: "started_at", Type: WarehouseTimestamp, Nullable: true},
+		{Name: "completed_at", Type: WarehouseTimestamp, Nullable: true},
+		{Name: "time_spent_seconds", Type: WarehouseInt64},
+		{Name: "score", Type: WarehouseFloat64},
+		{Name: "max_score", Type: WarehouseInt64},
+		{Name: "percentage", Type: WarehouseFloat64},
+		{Name: "passed", Type: WarehouseBool},
+		{Name: "score_overridden", Type: WarehouseBool},
+		{Name: "updated_at", Type: WarehouseTimestamp},
+	},
+	warehouseAnswerFacts: {
+		{Name: "answer_id", Type: WarehouseInt64},
+		{Name: "attempt_id", Type: WarehouseInt64},
+		{Name: "assessment_id", Type: WarehouseInt64},
+		{Name: "student_id", Type: WarehouseString},
+		{Name: "question_id", Type: WarehouseInt64},
+		{Name: "score", Type: WarehouseFloat64},
+		{Name: "max_score", Type: WarehouseInt64},
+		{Name: "is_correct", Type: WarehouseBool, Nullable: true},
+		{Name: "grading_status", Type: WarehouseString},
+		{Name: "graded_by", Type: WarehouseString, Nullable: true},
+		{Name: "graded_at", Type: WarehouseTimestamp, Nullable: true},
+		{Name: "time_spent_seconds", Type: WarehouseInt64},
+		{Name: "change_count", Type: WarehouseInt64},
+		{Name: "spell_check_shown", Type: WarehouseBool},
+		{Name: "updated_at", Type: WarehouseTimestamp},
+	},
+}
+
+// warehouseTable returns the current version of a fact table
+func warehouseTable(name string) WarehouseTable {
+	columns := append(append([]WarehouseColumn{}, warehouseFactColumns[name]...), warehouseTrailingColumns...)
+	return WarehouseTable{
+		Name:    versionedTableName(name, WarehouseSchemaVersion),
+		Version: WarehouseSchemaVersion,
+		Columns: columns,
+	}
+}
+
+func warehouseTables() []WarehouseTable {
+	return []WarehouseTable{
+		warehouseTable(warehouseAssessmentFacts),
+		warehouseTable(warehouseAttemptFacts),
+		warehouseTable(warehouseAnswerFacts),
+	}
+}
+
+func versionedTableName(name string, version int) string {
+	return fmt.Sprintf("%s_v%d", name, version)
+}
+
+// warehouseRowMeta fills the trailing columns of every exported fact
+type warehouseRowMeta struct {
+	organization string
+	exportedAt   time.Time
+}
+
+func (m warehouseRowMeta) finish(row WarehouseRow) WarehouseRow {
+	return append(row, m.organization, int64(WarehouseSchemaVersion), m.exportedAt.UTC())
+}
+
+func assessmentFactRow(fact repositories.AssessmentFact, meta warehouseRowMeta) WarehouseRow {
+	return meta.finish(WarehouseRow{
+		int64(fact.AssessmentID),
+		fact.Title,
+		fact.Status,
+		fact.CreatedBy,
+		warehouseString(fact.Term),
+		int64(fact.Duration),
+		int64(fact.PassingScore),
+		int64(fact.MaxAttempts),
+		warehouseTime(fact.DueDate),
+		int64(fact.QuestionCount),
+		fact.CreatedAt.UTC(),
+		fact.UpdatedAt.UTC(),
+		warehouseTime(fact.DeletedAt),
+	})
+}
+
+func attemptFactRow(fact repositories.AttemptFact, meta warehouseRowMeta) WarehouseRow {
+	return meta.finish(WarehouseRow{
+		int64(fact.AttemptID),
+		int64(fact.AssessmentID),
+		fact.StudentID,
+		int64(fact.AttemptNumber),
+		fact.Status,
+		warehouseTime(fact.StartedAt),
+		warehouseTime(fact.CompletedAt),
+		int64(fact.TimeSpent),
+		fact.Score,
+		int64(fact.MaxScore),
+		fact.Percentage,
+		fact.Passed,
+		fact.ScoreOverridden,
+		fact.UpdatedAt.UTC(),
+	})
+}
+
+func answerFactRow(fact repositories.AnswerFact, meta warehouseRowMeta) WarehouseRow {
+	var isCorrect interface{}
+	if fact.IsCorrect != nil {
+		isCorrect = *fact.IsCorrect
+	}
+	return meta.finish(WarehouseRow{
+		int64(fact.AnswerID),
+		int64(fact.AttemptID),
+		int64(fact.AssessmentID),
+		fact.StudentID,
+		int64(fact.QuestionID),
+		fact.Score,
+		int64(fact.MaxScore),
+		isCorrect,
+		fact.GradingStatus,
+		warehouseString(fact.GradedBy),
+		warehouseTime(fact.GradedAt),
+		int64(fact.TimeSpent),
+		int64(fact.ChangeCount),
+		fact.SpellCheckShown,
+		fact.UpdatedAt.UTC(),
+	})
+}
+
+func warehouseString(value *string) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func warehouseTime(value *time.Time) interface{} {
+	if value == nil {
+		return nil
+	}
+	return value.UTC()
+}
@@ -0,0 +1,417 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+const (
+	bigQueryAPIBase         = "https://bigquery.googleapis.com/bigquery/v2"
+	warehouseErrorBodyLimit = 500
+	// Rows per streaming insert or SQL statement; Parquet files take a whole batch
+	warehouseInsertChunk = 500
+	// How often a Snowflake statement still executing is checked on
+	snowflakePollInterval = 2 * time.Second
+	// Timestamps sent as text: RFC 3339 in UTC with microseconds, which every target parses
+	warehouseTimeLayout = "2006-01-02T15:04:05.000000Z"
+)
+
+// WarehouseBatch is a page of one fact table written by an export run
+type WarehouseBatch struct {
+	RunID      uint
+	Part       int // Counts the run's batches of the table from 1
+	Table      WarehouseTable
+	Rows       []WarehouseRow
+	ExportedAt time.Time
+}
+
+// WarehouseSink appends fact batches to a data warehouse. The tables are created by the
+// organization from the published schema.
+type WarehouseSink interface {
+	WriteBatch(ctx context.Context, connector *models.WarehouseConnector, batch *WarehouseBatch) error
+}
+
+// NewWarehouseSinks returns a sink per supported target
+func NewWarehouseSinks(httpClient *http.Client) map[models.WarehouseTarget]WarehouseSink {
+	return map[models.WarehouseTarget]WarehouseSink{
+		models.WarehouseTargetBigQuery:  &bigQuerySink{http: httpClient},
+		models.WarehouseTargetSnowflake: &snowflakeSink{http: httpClient},
+		models.WarehouseTargetS3Parquet: &s3ParquetSink{http: httpClient},
+	}
+}
+
+// ===== BIGQUERY =====
+
+// bigQuerySink streams rows into the dataset's tables. Insert IDs let BigQuery drop rows
+// sent twice when a failed run is retried.
+type bigQuerySink struct {
+	http *http.Client
+}
+
+type bigQueryInsertRow struct {
+	InsertID string                 `json:"insertId"`
+	JSON     map[string]interface{} `json:"json"`
+}
+
+type bigQueryInsertResponse struct {
+	InsertErrors []struct {
+		Index  int `json:"index"`
+		Errors []struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"insertErrors"`
+}
+
+func (s *bigQuerySink) WriteBatch(ctx context.Context, connector *models.WarehouseConnector, batch *WarehouseBatch) error {
+	project, dataset, _ := strings.Cut(connector.Dataset, ".")
+	base := bigQueryAPIBase
+	if connector.Endpoint != "" {
+		base = strings.TrimRight(connector.Endpoint, "/")
+	}
+	target := fmt.Sprintf("%s/projects/%s/datasets/%s/tables/%s/insertAll",
+		base, url.PathEscape(project), url.PathEscape(dataset), url.PathEscape(batch.Table.Name))
+
+	for _, chunk := range chunkWarehouseRows(batch.Rows, warehouseInsertChunk) {
+		rows := make([]bigQueryInsertRow, 0, len(chunk))
+		for _, row := range chunk {
+			values := make(map[string]interface{}, len(row))
+			for i, column := range batch.Table.Columns {
+				values[column.Name] = warehouseJSONValue(row[i])
+			}
+			rows = append(rows, bigQueryInsertRow{InsertID: warehouseRowKey(batch.Table, row), JSON: values})
+		}
+
+		body, err := json.Marshal(map[string]interface{}{"rows": rows})
+		if err != nil {
+			return fmt.Errorf("failed to encode BigQuery rows: %w", err)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("invalid BigQuery request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+connector.AuthToken)
+
+		var response bigQueryInsertResponse
+		if err := doWarehouseRequest(s.http, req, "BigQuery", &response); err != nil {
+			return err
+		}
+		if len(response.InsertErrors) > 0 {
+			first := response.InsertErrors[0]
+			message := "rejected"
+			if len(first.Errors) > 0 {
+				message = first.Errors[0].Reason + ": " + first.Errors[0].Message
+			}
+			return fmt.Errorf("BigQuery rejected %d rows of %s, first at %d: %s",
+				len(response.InsertErrors), batch.Table.Name, first.Index, message)
+		}
+	}
+	return nil
+}
+
+// ===== SNOWFLAKE =====
+
+// snowflakeSink inserts rows through the Snowflake SQL API, authenticating with an OAuth token
+type snowflakeSink struct {
+	http *http.Client
+}
+
+type snowflakeBinding struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"` // String or null
+}
+
+type snowflakeResponse struct {
+	StatementHandle string `json:"statementHandle"`
+	Message         string `json:"message"`
+}
+
+func (s *snowflakeSink) WriteBatch(ctx context.Context, connector *models.WarehouseConnector, batch *WarehouseBatch) error {
+	database, schema, _ := strings.Cut(connector.Dataset, ".")
+	base := strings.TrimRight(connector.Endpoint, "/")
+
+	for _, chunk := range chunkWarehouseRows(batch.Rows, warehouseInsertChunk) {
+		statement, bindings := snowflakeInsert(batch.Table, chunk)
+		body, err := json.Marshal(map[string]interface{}{
+			"statement": statement,
+			"bindings":  bindings,
+			"database":  database,
+			"schema":    schema,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to encode Snowflake statement: %w", err)
+		}
+
+		req, err := s.newRequest(ctx, http.MethodPost, base+"/api/v2/statements", connector.AuthToken, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		var response snowflakeResponse
+		status, err := doWarehouseRequestStatus(s.http, req, "Snowflake", &response)
+		if err != nil {
+			return err
+		}
+
+		// Statements running longer than the API waits are finished asynchronously
+		for status == http.StatusAccepted {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(snowflakePollInterval):
+			}
+			req, err := s.newRequest(ctx, http.MethodGet, base+"/api/v2/statements/"+url.PathEscape(response.StatementHandle), connector.AuthToken, nil)
+			if err != nil {
+				return err
+			}
+			if status, err = doWarehouseRequestStatus(s.http, req, "Snowflake", &response); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *snowflakeSink) newRequest(ctx context.Context, method, target, token string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Snowflake request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("X-Snowflake-Authorization-Token-Type", "OAUTH")
+	return req, nil
+}
+
+// snowflakeInsert builds a multi-row INSERT with one positional binding per value.
+// Timestamps are bound as ISO 8601 text, which Snowflake casts to the column type.
+func snowflakeInsert(table WarehouseTable, rows []WarehouseRow) (string, map[string]snowflakeBinding) {
+	names := make([]string, len(table.Columns))
+	for i, column := range table.Columns {
+		names[i] = column.Name
+	}
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(table.Columns)), ", ") + ")"
+
+	bindings := make(map[string]snowflakeBinding, len(rows)*len(table.Columns))
+	values := make([]string, len(rows))
+	position := 1
+	for r, row := range rows {
+		values[r] = placeholders
+		for i, column := range table.Columns {
+			binding := snowflakeBinding{Type: snowflakeBindingType(column.Type)}
+			if row[i] != nil {
+				binding.Value = warehouseText(row[i])
+			}
+			bindings[fmt.Sprint(position)] = binding
+			position++
+		}
+	}
+
+	statement := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s",
+		table.Name, strings.Join(names, ", "), strings.Join(values, ", "))
+	return statement, bindings
+}
+
+func snowflakeBindingType(columnType WarehouseColumnType) string {
+	switch columnType {
+	case WarehouseInt64:
+		return "FIXED"
+	case WarehouseFloat64:
+		return "REAL"
+	case WarehouseBool:
+		return "BOOLEAN"
+	}
+	return "TEXT"
+}
+
+// ===== S3 PARQUET =====
+
+// s3ParquetSink uploads each batch as a Parquet file, partitioned by table and export day:
+// <prefix>/<table>/dt=<YYYY-MM-DD>/run-<id>-part-<n>.parquet. A custom endpoint is
+// addressed path-style, for S3-compatible stores.
+type s3ParquetSink struct {
+	http *http.Client
+}
+
+func (s *s3ParquetSink) WriteBatch(ctx context.Context, connector *models.WarehouseConnector, batch *WarehouseBatch) error {
+	content, err := writeParquet(batch.Table, batch.Rows)
+	if err != nil {
+		return fmt.Errorf("failed to encode Parquet file: %w", err)
+	}
+
+	bucket, prefix, _ := strings.Cut(connector.Dataset, "/")
+	key := s3ObjectKey(prefix, batch)
+	var target string
+	if connector.Endpoint != "" {
+		target = fmt.Sprintf("%s/%s/%s", strings.TrimRight(connector.Endpoint, "/"), awsURIEncode(bucket, false), awsURIEncode(key, true))
+	} else {
+		target = fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucket, connector.Region, awsURIEncode(key, true))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("invalid S3 request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/vnd.apache.parquet")
+	signS3Request(req, content, connector.AccessKeyID, connector.AuthToken, connector.Region, time.Now())
+
+	return doWarehouseRequest(s.http, req, "S3", nil)
+}
+
+func s3ObjectKey(prefix string, batch *WarehouseBatch) string {
+	key := fmt.Sprintf("%s/dt=%s/run-%d-part-%05d.parquet",
+		batch.Table.Name, batch.ExportedAt.UTC().Format("2006-01-02"), batch.RunID, batch.Part)
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		key = prefix + "/" + key
+	}
+	return key
+}
+
+// signS3Request signs the request with AWS Signature Version 4, including the payload hash
+func signS3Request(req *http.Request, payload []byte, accessKeyID, secretKey, region string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "content-type;host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "content-type:" + req.Header.Get("Content-Type") + "\n" +
+		"host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+	signature := hex.EncodeToString(hmacSHA256(awsSigningKey(secretKey, day, region, "s3"), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+func awsSigningKey(secretKey, day, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secretKey), day)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+// awsURIEncode percent-encodes everything but unreserved characters, and slashes when
+// encoding a path, as Signature Version 4 expects
+func awsURIEncode(value string, path bool) string {
+	var out strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case b >= 'A' && b <= 'Z', b >= 'a' && b <= 'z', b >= '0' && b <= '9',
+			b == '-', b == '_', b == '.', b == '~', path && b == '/':
+			out.WriteByte(b)
+		default:
+			fmt.Fprintf(&out, "%%%02X", b)
+		}
+	}
+	return out.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func doWarehouseRequest(client *http.Client, req *http.Request, target string, out interface{}) error {
+	_, err := doWarehouseRequestStatus(client, req, target, out)
+	return err
+}
+
+// doWarehouseRequestStatus sends the request and decodes a successful response into out
+// when given
+func doWarehouseRequestStatus(client *http.Client, req *http.Request, target string, out interface{}) (int, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reach %s: %w", target, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, warehouseErrorBodyLimit))
+		return resp.StatusCode, fmt.Errorf("%s returned %s: %s", target, resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode %s response: %w", target, err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+func chunkWarehouseRows(rows []WarehouseRow, size int) [][]WarehouseRow {
+	var chunks [][]WarehouseRow
+	for start := 0; start < len(rows); start += size {
+		end := start + size
+		if end > len(rows) {
+			end = len(rows)
+		}
+		chunks = append(chunks, rows[start:end])
+	}
+	return chunks
+}
+
+// warehouseRowKey identifies a version of a fact: its ID and when it last changed
+func warehouseRowKey(table WarehouseTable, row WarehouseRow) string {
+	key := fmt.Sprintf("%s:%v", table.Name, row[0])
+	for i, column := range table.Columns {
+		if column.Name == "updated_at" {
+			if updatedAt, ok := row[i].(time.Time); ok {
+				key += fmt.Sprintf(":%d", updatedAt.UnixNano())
+			}
+		}
+	}
+	return key
+}
+
+func warehouseJSONValue(value interface{}) interface{} {
+	if t, ok := value.(time.Time); ok {
+		return t.UTC().Format(warehouseTimeLayout)
+	}
+	return value
+}
+
+func warehouseText(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		return t.UTC().Format(warehouseTimeLayout)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAWSSigningKey(t *testing.T) {
+	// Example from the AWS Signature Version 4 documentation
+	key := awsSigningKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	if got := hex.EncodeToString(key); got != "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d" {
+		t.Errorf("unexpected signing key %s", got)
+	}
+}
+
+func TestAWSURIEncode(t *testing.T) {
+	if got := awsURIEncode("exports/attempt_facts_v1/dt=2025-06-01/run 1.parquet", true); got != "exports/attempt_facts_v1/dt%3D2025-06-01/run%201.parquet" {
+		t.Errorf("unexpected path encoding %s", got)
+	}
+	if got := awsURIEncode("a/b", false); got != "a%2Fb" {
+		t.Errorf("slashes should be encoded outside paths, got %s", got)
+	}
+}
+
+func TestS3ObjectKey(t *testing.T) {
+	batch := &WarehouseBatch{
+		RunID:      7,
+		Part:       2,
+		Table:      warehouseTable(warehouseAnswerFacts),
+		ExportedAt: time.Date(2025, 6, 1, 2, 30, 0, 0, time.UTC),
+	}
+	if got := s3ObjectKey("/exports/acme/", batch); got != "exports/acme/answer_facts_v2/dt=2025-06-01/run-7-part-00002.parquet" {
+		t.Errorf("unexpected key %s", got)
+	}
+	if got := s3ObjectKey("", batch); !strings.HasPrefix(got, "answer_facts_v2/") {
+		t.Errorf("expected no leading prefix, got %s", got)
+	}
+}
+
+func TestSnowflakeInsert(t *testing.T) {
+	table := WarehouseTable{Name: "t_v1", Columns: []WarehouseColumn{
+		{Name: "id", Type: WarehouseInt64},
+		{Name: "at", Type: WarehouseTimestamp, Nullable: true},
+	}}
+	at := time.Date(2025, 6, 1, 2, 30, 0, 0, time.UTC)
+
+	statement, bindings := snowflakeInsert(table, []WarehouseRow{{int64(1), at}, {int64(2), nil}})
+	if statement != "INSERT INTO t_v1 (id, at) VALUES (?, ?), (?, ?)" {
+		t.Errorf("unexpected statement %s", statement)
+	}
+	if len(bindings) != 4 {
+		t.Fatalf("expected 4 bindings, got %d", len(bindings))
+	}
+	if bindings["1"].Type != "FIXED" || bindings["1"].Value != "1" {
+		t.Errorf("unexpected integer binding %+v", bindings["1"])
+	}
+	if bindings["2"].Value != "2025-06-01T02:30:00.000000Z" {
+		t.Errorf("unexpected timestamp binding %+v", bindings["2"])
+	}
+	if bindings["4"].Value != nil {
+		t.Errorf("expected a null binding, got %+v", bindings["4"])
+	}
+}
+
+func TestChunkWarehouseRows(t *testing.T) {
+	rows := make([]WarehouseRow, 5)
+	chunks := chunkWarehouseRows(rows, 2)
+	if len(chunks) != 3 || len(chunks[2]) != 1 {
+		t.Errorf("expected chunks of 2, 2 and 1, got %d chunks", len(chunks))
+	}
+	if chunks := chunkWarehouseRows(nil, 2); len(chunks) != 0 {
+		t.Errorf("expected no chunks for no rows, got %d", len(chunks))
+	}
+}
@@ -0,0 +1,540 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/events"
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+	"github.com/SAP-F-2025/assessment-service/internal/repositories"
+	"github.com/SAP-F-2025/assessment-service/internal/validator"
+	"gorm.io/gorm"
+)
+
+const (
+	// A delivery left processing for this long is treated as orphaned by a crash
+	webhookStaleAfter        = 5 * time.Minute
+	webhookBatchSize         = 50
+	webhookDeliveryListLimit = 200
+	webhookTimeout           = 10 * time.Second
+	webhookResponseBodyLimit = 2000
+
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookSignatureHeader = "X-Webhook-Signature" // sha256=<hex HMAC of the body keyed with the subscription secret>
+)
+
+type webhookService struct {
+	repo      repositories.Repository
+	db        *gorm.DB
+	logger    *slog.Logger
+	validator *validator.Validator
+	http      *http.Client
+}
+
+func NewWebhookService(repo repositories.Repository, db *gorm.DB, logger *slog.Logger, validator *validator.Validator, httpClient *http.Client) WebhookService {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: webhookTimeout}
+	}
+	return &webhookService{
+		repo:      repo,
+		db:        db,
+		logger:    logger,
+		validator: validator,
+		http:      httpClient,
+	}
+}
+
+// ===== SUBSCRIPTIONS =====
+
+// CreateSubscription registers an endpoint for events. The signing secret is only returned here.
+func (s *webhookService) CreateSubscription(ctx context.Context, req *CreateWebhookSubscriptionRequest, userID string) (*WebhookSubscriptionGrant, error) {
+	s.logger.Info("Creating webhook subscription", "name", req.Name, "url", req.URL, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(ctx, userID, "manage_webhooks"); err != nil {
+		return nil, err
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	eventTypes, err := marshalWebhookEventTypes(req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &models.WebhookSubscription{
+		Name:       strings.TrimSpace(req.Name),
+		URL:        strings.TrimSpace(req.URL),
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Enabled:    true,
+		CreatedBy:  userID,
+	}
+	if err := s.repo.Webhook().CreateSubscription(ctx, nil, subscription); err != nil {
+		return nil, err
+	}
+
+	return &WebhookSubscriptionGrant{Subscription: subscription, Secret: secret}, nil
+}
+
+func (s *webhookService) ListSubscriptions(ctx context.Context, userID string) ([]*models.WebhookSubscription, error) {
+	if err := s.requireAdmin(ctx, userID, "view_webhooks"); err != nil {
+		return nil, err
+	}
+	return s.repo.Webhook().ListSubscriptions(ctx, nil)
+}
+
+func (s *webhookService) UpdateSubscription(ctx context.Context, id uint, req *UpdateWebhookSubscriptionRequest, userID string) (*models.WebhookSubscription, error) {
+	s.logger.Info("Updating webhook subscription", "subscription_id", id, "user_id", userID)
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(ctx, userID, "manage_webhooks"); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		subscription.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.URL != nil {
+		subscription.URL = strings.TrimSpace(*req.URL)
+	}
+	if req.EventTypes != nil {
+		eventTypes, err := marshalWebhookEventTypes(*req.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		subscription.EventTypes = eventTypes
+	}
+	if req.Enabled != nil {
+		subscription.Enabled = *req.Enabled
+	}
+
+	if err := s.repo.Webhook().UpdateSubscription(ctx, nil, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (s *webhookService) DeleteSubscription(ctx context.Context, id uint, userID string) error {
+	s.logger.Info("Deleting webhook subscription", "subscription_id", id, "user_id", userID)
+
+	if err := s.requireAdmin(ctx, userID, "manage_webhooks"); err != nil {
+		return err
+	}
+	if _, err := s.getSubscription(ctx, id); err != nil {
+		return err
+	}
+	return s.repo.Webhook().DeleteSubscription(ctx, nil, id)
+}
+
+// ===== DEBUGGING CONSOLE =====
+
+// SendTestEvent posts a test event to the subscription right away, even when it is disabled,
+// and returns the delivery with the endpoint's answer
+func (s *webhookService) SendTestEvent(ctx context.Context, subscriptionID uint, userID string) (*models.WebhookDelivery, error) {
+	s.logger.Info("Sending webhook test event", "subscription_id", subscriptionID, "user_id", userID)
+
+	if err := s.requireAdmin(ctx, userID, "test_webhook"); err != nil {
+		return nil, err
+	}
+	subscription, err := s.getSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &events.NotificationEvent{
+		ID:        events.GenerateEventID(),
+		Type:      events.EventWebhookTest,
+		Timestamp: time.Now(),
+		Source:    "assessment-service",
+		Version:   "1.0",
+		Data: map[string]interface{}{
+			"subscription_id": subscription.ID,
+			"message":         "Test event sent from the webhook console",
+		},
+	}
+	delivery, err := newWebhookDelivery(subscription.ID, event)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Test = true
+	delivery.Status = models.WebhookDeliveryProcessing
+	delivery.RequestedBy = &userID
+
+	return s.sendNow(ctx, subscription, delivery)
+}
+
+// ListDeliveries shows a subscription's recent deliveries with their payloads and the
+// endpoint's answers, newest first
+func (s *webhookService) ListDeliveries(ctx context.Context, subscriptionID uint, status *models.WebhookDeliveryStatus, userID string) ([]*models.WebhookDelivery, error) {
+	if err := s.requireAdmin(ctx, userID, "view_webhooks"); err != nil {
+		return nil, err
+	}
+	if _, err := s.getSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	return s.repo.Webhook().ListDeliveries(ctx, nil, subscriptionID, status, webhookDeliveryListLimit)
+}
+
+// ReplayDelivery posts a failed delivery's payload again, as a new delivery linked to the
+// original, and returns it with the endpoint's answer
+func (s *webhookService) ReplayDelivery(ctx context.Context, deliveryID uint, userID string) (*models.WebhookDelivery, error) {
+	s.logger.Info("Replaying webhook delivery", "delivery_id", deliveryID, "user_id", userID)
+
+	if err := s.requireAdmin(ctx, userID, "replay_webhook"); err != nil {
+		return nil, err
+	}
+
+	original, err := s.repo.Webhook().GetDeliveryByID(ctx, nil, deliveryID)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	if original.Status != models.WebhookDeliveryFailed {
+		return nil, NewBusinessRuleError("webhook_delivery_not_failed", "only failed deliveries can be replayed", map[string]interface{}{
+			"delivery_id": deliveryID,
+			"status":      original.Status,
+		})
+	}
+
+	subscription, err := s.getSubscription(ctx, original.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.sendNow(ctx, subscription, replayWebhookDelivery(original, userID))
+}
+
+// ===== BACKGROUND DELIVERY =====
+
+// QueueEvent queues a delivery of the event for every enabled subscription that wants its type
+func (s *webhookService) QueueEvent(ctx context.Context, event *events.NotificationEvent) (int, error) {
+	subscriptions, err := s.repo.Webhook().GetEnabledSubscriptions(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var deliveries []*models.WebhookDelivery
+	for _, subscription := range subscriptions {
+		if !webhookSubscribed(subscription, string(event.Type)) {
+			continue
+		}
+		delivery, err := newWebhookDelivery(subscription.ID, event)
+		if err != nil {
+			return 0, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := s.repo.Webhook().CreateDeliveries(ctx, nil, deliveries); err != nil {
+		return 0, err
+	}
+	return len(deliveries), nil
+}
+
+// DeliverPending posts queued deliveries, and ones orphaned by a crash, oldest first
+func (s *webhookService) DeliverPending(ctx context.Context, limit int) (int, error) {
+	ids, err := s.repo.Webhook().GetClaimableDeliveries(ctx, nil, time.Now().Add(-webhookStaleAfter), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, id := range ids {
+		ok, err := s.deliver(ctx, id)
+		if err != nil {
+			s.logger.Error("Failed to process webhook delivery", "delivery_id", id, "error", err)
+			continue
+		}
+		if ok {
+			delivered++
+		}
+	}
+
+	if delivered > 0 {
+		s.logger.Info("Webhook deliveries sent", "deliveries", delivered)
+	}
+	return delivered, nil
+}
+
+// RunScheduler delivers queued webhook events every interval until the context is cancelled
+func (s *webhookService) RunScheduler(ctx context.Context, interval time.Duration) {
+	s.logger.Info("Webhook delivery scheduler started", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Webhook delivery scheduler stopped")
+			return
+		case <-ticker.C:
+			if _, err := s.DeliverPending(ctx, webhookBatchSize); err != nil {
+				s.logger.Error("Failed to deliver webhook events", "error", err)
+			}
+		}
+	}
+}
+
+// ===== EVENT PUBLISHER =====
+
+// webhookEventPublisher publishes events as before and queues each published event for the
+// webhook subscriptions that want it
+type webhookEventPublisher struct {
+	events.EventPublisher
+	webhooks WebhookService
+	logger   *slog.Logger
+}
+
+func NewWebhookEventPublisher(publisher events.EventPublisher, webhooks WebhookService, logger *slog.Logger) events.EventPublisher {
+	return &webhookEventPublisher{
+		EventPublisher: publisher,
+		webhooks:       webhooks,
+		logger:         logger,
+	}
+}
+
+// PublishNotificationEvent does not fail when the event cannot be queued for webhooks, as it
+// has already been published
+func (p *webhookEventPublisher) PublishNotificationEvent(ctx context.Context, event *events.NotificationEvent) error {
+	if err := p.EventPublisher.PublishNotificationEvent(ctx, event); err != nil {
+		return err
+	}
+	if _, err := p.webhooks.QueueEvent(ctx, event); err != nil {
+		p.logger.Error("Failed to queue webhook deliveries", "event_id", event.ID, "event_type", event.Type, "error", err)
+	}
+	return nil
+}
+
+// ===== HELPER METHODS =====
+
+// deliver posts one queued delivery. A delivery that fails stays failed until it is replayed.
+// It reports false when another worker holds the delivery or the post failed.
+func (s *webhookService) deliver(ctx context.Context, id uint) (bool, error) {
+	now := time.Now()
+	claimed, err := s.repo.Webhook().ClaimDelivery(ctx, nil, id, now, now.Add(-webhookStaleAfter))
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	delivery, err := s.repo.Webhook().GetDeliveryByID(ctx, nil, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	subscription, err := s.getSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return false, err
+	}
+
+	if subscription.Enabled {
+		s.post(ctx, subscription, delivery)
+	} else {
+		recordWebhookResult(delivery, 0, "", fmt.Errorf("subscription is disabled"), 0, time.Now())
+	}
+
+	if err := s.repo.Webhook().UpdateDelivery(ctx, nil, delivery); err != nil {
+		return false, err
+	}
+	return delivery.Status == models.WebhookDeliverySucceeded, nil
+}
+
+// sendNow stores a console delivery, posts it and stores the endpoint's answer
+func (s *webhookService) sendNow(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) (*models.WebhookDelivery, error) {
+	if err := s.repo.Webhook().CreateDeliveries(ctx, nil, []*models.WebhookDelivery{delivery}); err != nil {
+		return nil, err
+	}
+	s.post(ctx, subscription, delivery)
+	if err := s.repo.Webhook().UpdateDelivery(ctx, nil, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// post sends the delivery's payload, signed with the subscription's secret, and records the answer
+func (s *webhookService) post(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) {
+	started := time.Now()
+	code, body, err := s.doPost(ctx, subscription, delivery)
+	recordWebhookResult(delivery, code, body, err, time.Since(started), time.Now())
+
+	if delivery.Status == models.WebhookDeliveryFailed {
+		s.logger.Warn("Webhook delivery failed",
+			"delivery_id", delivery.ID,
+			"subscription_id", subscription.ID,
+			"event_type", delivery.EventType,
+			"response_code", code,
+			"error", err)
+	}
+}
+
+func (s *webhookService) doPost(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(WebhookSignatureHeader, signWebhookPayload(subscription.Secret, delivery.Payload))
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to reach webhook endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseBodyLimit))
+	return resp.StatusCode, string(body), nil
+}
+
+func (s *webhookService) getSubscription(ctx context.Context, id uint) (*models.WebhookSubscription, error) {
+	subscription, err := s.repo.Webhook().GetSubscriptionByID(ctx, nil, id)
+	if err != nil {
+		if repositories.IsNotFoundError(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+func (s *webhookService) requireAdmin(ctx context.Context, userID, action string) error {
+	user, err := s.repo.User().GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user.Role != models.RoleAdmin {
+		return NewPermissionError(userID, 0, "webhook", action, "only admins may manage webhooks")
+	}
+	return nil
+}
+
+// ===== HELPER FUNCTIONS =====
+
+func newWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func marshalWebhookEventTypes(eventTypes []string) ([]byte, error) {
+	trimmed := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		trimmed = append(trimmed, strings.TrimSpace(eventType))
+	}
+	data, err := json.Marshal(trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event types: %w", err)
+	}
+	return data, nil
+}
+
+// webhookSubscribed reports whether the subscription wants events of the type; one without
+// event types wants them all
+func webhookSubscribed(subscription *models.WebhookSubscription, eventType string) bool {
+	var eventTypes []string
+	if len(subscription.EventTypes) > 0 {
+		if err := json.Unmarshal(subscription.EventTypes, &eventTypes); err != nil {
+			return false
+		}
+	}
+	if len(eventTypes) == 0 {
+		return true
+	}
+	for _, wanted := range eventTypes {
+		if wanted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func newWebhookDelivery(subscriptionID uint, event *events.NotificationEvent) (*models.WebhookDelivery, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	return &models.WebhookDelivery{
+		SubscriptionID: subscriptionID,
+		EventID:        event.ID,
+		EventType:      string(event.Type),
+		Payload:        payload,
+		Status:         models.WebhookDeliveryPending,
+	}, nil
+}
+
+func replayWebhookDelivery(original *models.WebhookDelivery, userID string) *models.WebhookDelivery {
+	return &models.WebhookDelivery{
+		SubscriptionID: original.SubscriptionID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		Test:           original.Test,
+		ReplayOf:       &original.ID,
+		Status:         models.WebhookDeliveryProcessing,
+		RequestedBy:    &userID,
+	}
+}
+
+// signWebhookPayload returns the signature header value receivers check the body against
+func signWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// recordWebhookResult stores the endpoint's answer on the delivery; only a 2xx answer succeeds
+func recordWebhookResult(delivery *models.WebhookDelivery, code int, body string, err error, duration time.Duration, now time.Time) {
+	delivery.DurationMs = int(duration.Milliseconds())
+	delivery.ResponseCode = nil
+	delivery.ResponseBody = nil
+	delivery.Error = nil
+
+	if code != 0 {
+		delivery.ResponseCode = &code
+		delivery.ResponseBody = &body
+	}
+
+	switch {
+	case err != nil:
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.Error = stringPtr(err.Error())
+	case code < 200 || code >= 300:
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.Error = stringPtr(fmt.Sprintf("endpoint answered %d", code))
+	default:
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+	}
+}
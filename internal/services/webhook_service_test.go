@@ -0,0 +1,102 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestWebhookSubscribed(t *testing.T) {
+	all := &models.WebhookSubscription{}
+	if !webhookSubscribed(all, "assessment.published") {
+		t.Error("a subscription without event types should get every event")
+	}
+
+	empty := &models.WebhookSubscription{EventTypes: []byte(`[]`)}
+	if !webhookSubscribed(empty, "assessment.published") {
+		t.Error("an empty event type list should get every event")
+	}
+
+	some := &models.WebhookSubscription{EventTypes: []byte(`["assessment.published","attempt.submitted"]`)}
+	if !webhookSubscribed(some, "attempt.submitted") {
+		t.Error("expected a listed event type to be delivered")
+	}
+	if webhookSubscribed(some, "grade.released") {
+		t.Error("expected an unlisted event type to be skipped")
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	payload := []byte(`{"id":"evt_1"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if got := signWebhookPayload("secret", payload); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+	if signWebhookPayload("other", payload) == want {
+		t.Error("expected a different secret to give a different signature")
+	}
+}
+
+func TestRecordWebhookResult(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	ok := &models.WebhookDelivery{Error: stringPtr("earlier failure")}
+	recordWebhookResult(ok, 204, "", nil, 150*time.Millisecond, now)
+	if ok.Status != models.WebhookDeliverySucceeded || ok.DeliveredAt == nil || ok.Error != nil {
+		t.Errorf("expected a 2xx answer to succeed, got %+v", ok)
+	}
+	if ok.DurationMs != 150 {
+		t.Errorf("expected 150ms, got %d", ok.DurationMs)
+	}
+
+	rejected := &models.WebhookDelivery{}
+	recordWebhookResult(rejected, 500, "boom", nil, time.Second, now)
+	if rejected.Status != models.WebhookDeliveryFailed || rejected.Error == nil {
+		t.Errorf("expected a 5xx answer to fail, got %+v", rejected)
+	}
+	if rejected.ResponseCode == nil || *rejected.ResponseCode != 500 || *rejected.ResponseBody != "boom" {
+		t.Errorf("expected the endpoint's answer to be kept, got %+v", rejected)
+	}
+
+	unreachable := &models.WebhookDelivery{}
+	recordWebhookResult(unreachable, 0, "", errors.New("connection refused"), time.Second, now)
+	if unreachable.Status != models.WebhookDeliveryFailed || unreachable.ResponseCode != nil {
+		t.Errorf("expected a transport error to fail without a response code, got %+v", unreachable)
+	}
+	if unreachable.Error == nil || *unreachable.Error != "connection refused" {
+		t.Errorf("expected the transport error to be kept, got %v", unreachable.Error)
+	}
+}
+
+func TestReplayWebhookDelivery(t *testing.T) {
+	original := &models.WebhookDelivery{
+		ID:             7,
+		SubscriptionID: 3,
+		EventID:        "evt_1",
+		EventType:      "attempt.submitted",
+		Payload:        []byte(`{"id":"evt_1"}`),
+		Status:         models.WebhookDeliveryFailed,
+		ResponseCode:   intPtr(500),
+	}
+
+	replay := replayWebhookDelivery(original, "admin-1")
+	if replay.ID != 0 || replay.ReplayOf == nil || *replay.ReplayOf != 7 {
+		t.Errorf("expected a new delivery linked to the original, got %+v", replay)
+	}
+	if replay.EventID != "evt_1" || string(replay.Payload) != `{"id":"evt_1"}` {
+		t.Errorf("expected the original event to be sent again, got %+v", replay)
+	}
+	if replay.ResponseCode != nil || replay.RequestedBy == nil || *replay.RequestedBy != "admin-1" {
+		t.Errorf("expected a fresh delivery requested by the admin, got %+v", replay)
+	}
+}
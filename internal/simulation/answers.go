@@ -0,0 +1,121 @@
+package simulation
+
+import (
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// Question is the part of an attempt question a virtual student needs to answer it
+type Question struct {
+	ID      uint                `json:"id"`
+	Type    models.QuestionType `json:"type"`
+	Content json.RawMessage     `json:"content"`
+}
+
+var essayWords = strings.Fields("the result follows from the definition because each step preserves " +
+	"the invariant and therefore the final value matches what we expected in the first place")
+
+// generateAnswer builds a plausible answer payload for a question. Choices are random, so
+// a run produces a realistic spread of correct, partial and wrong answers.
+func generateAnswer(q Question, rng *rand.Rand, timeSpent int) interface{} {
+	switch q.Type {
+	case models.MultipleChoice:
+		var content models.MultipleChoiceContent
+		_ = json.Unmarshal(q.Content, &content)
+		answer := models.MultipleChoiceAnswer{SelectedOptions: []string{}, TimeSpent: timeSpent}
+		if len(content.Options) == 0 {
+			return answer
+		}
+		perm := rng.Perm(len(content.Options))
+		picks := 1
+		if content.MultipleCorrect {
+			picks = 1 + rng.Intn(len(content.Options))
+		}
+		for _, i := range perm[:picks] {
+			answer.SelectedOptions = append(answer.SelectedOptions, content.Options[i].ID)
+		}
+		return answer
+
+	case models.TrueFalse:
+		return models.TrueFalseAnswer{Answer: rng.Intn(2) == 0, TimeSpent: timeSpent}
+
+	case models.Essay:
+		var content models.EssayContent
+		_ = json.Unmarshal(q.Content, &content)
+		words := 50 + rng.Intn(150)
+		if content.MinWords != nil && words < *content.MinWords {
+			words = *content.MinWords
+		}
+		if content.MaxWords != nil && words > *content.MaxWords {
+			words = *content.MaxWords
+		}
+		return models.EssayAnswer{Text: randomText(rng, words), WordCount: words, TimeSpent: timeSpent}
+
+	case models.FillInBlank:
+		var content models.FillBlankContent
+		_ = json.Unmarshal(q.Content, &content)
+		answers := make(map[string]string, len(content.Blanks))
+		for id, blank := range content.Blanks {
+			if len(blank.AcceptedAnswers) > 0 && rng.Intn(2) == 0 {
+				answers[id] = blank.AcceptedAnswers[rng.Intn(len(blank.AcceptedAnswers))]
+			} else {
+				answers[id] = randomText(rng, 1)
+			}
+		}
+		return models.FillBlankAnswer{Answers: answers, TimeSpent: timeSpent}
+
+	case models.Matching:
+		var content models.MatchingContent
+		_ = json.Unmarshal(q.Content, &content)
+		pairs := []models.MatchPair{}
+		perm := rng.Perm(len(content.RightItems))
+		for i, left := range content.LeftItems {
+			if i >= len(perm) {
+				break
+			}
+			pairs = append(pairs, models.MatchPair{LeftID: left.ID, RightID: content.RightItems[perm[i]].ID})
+		}
+		return models.MatchingAnswer{Pairs: pairs, TimeSpent: timeSpent}
+
+	case models.Ordering:
+		var content models.OrderingContent
+		_ = json.Unmarshal(q.Content, &content)
+		order := make([]string, 0, len(content.Items))
+		for _, i := range rng.Perm(len(content.Items)) {
+			order = append(order, content.Items[i].ID)
+		}
+		return models.OrderingAnswer{Order: order, TimeSpent: timeSpent}
+
+	case models.MultiPart:
+		var content models.MultiPartContent
+		_ = json.Unmarshal(q.Content, &content)
+		answers := make(map[string]interface{}, len(content.Parts))
+		for _, part := range content.Parts {
+			answers[part.ID] = generateAnswer(Question{ID: q.ID, Type: part.Type, Content: json.RawMessage(part.Content)}, rng, timeSpent)
+		}
+		return answers
+
+	default:
+		return models.ShortAnswers{Text: randomText(rng, 1+rng.Intn(5)), TimeSpent: timeSpent}
+	}
+}
+
+func randomText(rng *rand.Rand, words int) string {
+	parts := make([]string, words)
+	for i := range parts {
+		parts[i] = essayWords[rng.Intn(len(essayWords))]
+	}
+	return strings.Join(parts, " ")
+}
+
+// thinkTime picks how long a student pauses before answering
+func thinkTime(rng *rand.Rand, lo, hi time.Duration) time.Duration {
+	if hi <= lo {
+		return lo
+	}
+	return lo + time.Duration(rng.Int63n(int64(hi-lo)))
+}
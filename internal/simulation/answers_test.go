@@ -0,0 +1,45 @@
+package simulation
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+func TestGenerateAnswer(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	mc, _ := json.Marshal(models.MultipleChoiceContent{Options: []models.MCOption{{ID: "a"}, {ID: "b"}, {ID: "c"}}})
+	answer := generateAnswer(Question{ID: 1, Type: models.MultipleChoice, Content: mc}, rng, 5).(models.MultipleChoiceAnswer)
+	if len(answer.SelectedOptions) != 1 || answer.TimeSpent != 5 {
+		t.Errorf("single-answer question should pick one option, got %+v", answer)
+	}
+
+	maxWords := 30
+	essay, _ := json.Marshal(models.EssayContent{MaxWords: &maxWords})
+	essayAnswer := generateAnswer(Question{ID: 2, Type: models.Essay, Content: essay}, rng, 60).(models.EssayAnswer)
+	if essayAnswer.WordCount != 30 {
+		t.Errorf("essay should respect the word limit, got %d words", essayAnswer.WordCount)
+	}
+
+	ordering, _ := json.Marshal(models.OrderingContent{Items: []models.OrderItem{{ID: "x"}, {ID: "y"}, {ID: "z"}}})
+	orderAnswer := generateAnswer(Question{ID: 3, Type: models.Ordering, Content: ordering}, rng, 5).(models.OrderingAnswer)
+	if len(orderAnswer.Order) != 3 {
+		t.Errorf("ordering answer should place every item, got %v", orderAnswer.Order)
+	}
+}
+
+func TestThinkTime(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		if d := thinkTime(rng, time.Second, 3*time.Second); d < time.Second || d >= 3*time.Second {
+			t.Fatalf("think time %s outside range", d)
+		}
+	}
+	if d := thinkTime(rng, 0, 0); d != 0 {
+		t.Errorf("zero range should not pause, got %s", d)
+	}
+}
@@ -0,0 +1,64 @@
+package simulation
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Config describes a simulated exam run against a deployed assessment service
+type Config struct {
+	BaseURL      string   // e.g. http://localhost:8080/api/v1
+	AssessmentID uint     // Assessment every virtual student takes
+	Tokens       []string // One bearer token per virtual student
+
+	Concurrency int           // Students taking the assessment at the same time; 0 means all of them
+	RampUp      time.Duration // Spread over which students arrive
+	ThinkMin    time.Duration // Shortest pause before answering a question
+	ThinkMax    time.Duration // Longest pause before answering a question
+	Timeout     time.Duration // Per-request timeout
+	Seed        int64         // Seed for answer choices and pacing; 0 picks one from the clock
+
+	// Concurrency safety probes: fire the same request twice at once and
+	// record an anomaly when the service accepts both
+	DuplicateStart  bool
+	DuplicateSubmit bool
+}
+
+const (
+	defaultThinkMin = 2 * time.Second
+	defaultThinkMax = 20 * time.Second
+	defaultTimeout  = 30 * time.Second
+)
+
+func (c *Config) applyDefaults() {
+	c.BaseURL = strings.TrimRight(c.BaseURL, "/")
+	if c.Concurrency <= 0 || c.Concurrency > len(c.Tokens) {
+		c.Concurrency = len(c.Tokens)
+	}
+	if c.ThinkMin == 0 && c.ThinkMax == 0 {
+		c.ThinkMin, c.ThinkMax = defaultThinkMin, defaultThinkMax
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.Seed == 0 {
+		c.Seed = time.Now().UnixNano()
+	}
+}
+
+func (c *Config) validate() error {
+	switch {
+	case c.BaseURL == "":
+		return errors.New("base URL is required")
+	case c.AssessmentID == 0:
+		return errors.New("assessment ID is required")
+	case len(c.Tokens) == 0:
+		return errors.New("at least one student token is required")
+	case c.ThinkMin < 0 || c.ThinkMax < c.ThinkMin:
+		return errors.New("think time range is invalid")
+	case c.RampUp < 0:
+		return errors.New("ramp-up cannot be negative")
+	}
+	return nil
+}
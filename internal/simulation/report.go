@@ -0,0 +1,206 @@
+package simulation
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Steps of a virtual student's attempt
+const (
+	StepStart  = "start"
+	StepLoad   = "load_questions"
+	StepAnswer = "answer"
+	StepSubmit = "submit"
+)
+
+// Anomalies raised by the concurrency safety probes
+const (
+	AnomalyDuplicateStart  = "duplicate_attempt_started"
+	AnomalyDuplicateSubmit = "duplicate_submit_accepted"
+)
+
+// Report summarizes a simulation run
+type Report struct {
+	AssessmentID uint           `json:"assessment_id"`
+	Students     int            `json:"students"`
+	Completed    int            `json:"completed"`
+	Failed       int            `json:"failed"`
+	Duration     time.Duration  `json:"duration"`
+	Requests     int            `json:"requests"`
+	Throughput   float64        `json:"throughput"` // Requests per second
+	Steps        []StepReport   `json:"steps"`
+	StatusCodes  map[int]int    `json:"status_codes"`
+	Anomalies    map[string]int `json:"anomalies"`
+	Failures     map[string]int `json:"failures"` // First failing step and reason per student
+}
+
+// StepReport holds latency figures for one step of the flow
+type StepReport struct {
+	Name     string        `json:"name"`
+	Requests int           `json:"requests"`
+	Errors   int           `json:"errors"`
+	Mean     time.Duration `json:"mean"`
+	P50      time.Duration `json:"p50"`
+	P95      time.Duration `json:"p95"`
+	P99      time.Duration `json:"p99"`
+	Max      time.Duration `json:"max"`
+}
+
+// recorder collects measurements from all virtual students
+type recorder struct {
+	mu          sync.Mutex
+	latencies   map[string][]time.Duration
+	errors      map[string]int
+	statusCodes map[int]int
+	anomalies   map[string]int
+	failures    map[string]int
+	completed   int
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		latencies:   make(map[string][]time.Duration),
+		errors:      make(map[string]int),
+		statusCodes: make(map[int]int),
+		anomalies:   make(map[string]int),
+		failures:    make(map[string]int),
+	}
+}
+
+func (r *recorder) request(step string, status int, latency time.Duration, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.latencies[step] = append(r.latencies[step], latency)
+	if status != 0 {
+		r.statusCodes[status]++
+	}
+	if failed {
+		r.errors[step]++
+	}
+}
+
+func (r *recorder) anomaly(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.anomalies[name]++
+}
+
+func (r *recorder) studentDone(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err != nil {
+		r.failures[err.Error()]++
+		return
+	}
+	r.completed++
+}
+
+func (r *recorder) report(assessmentID uint, students int, duration time.Duration) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &Report{
+		AssessmentID: assessmentID,
+		Students:     students,
+		Completed:    r.completed,
+		Duration:     duration,
+		Steps:        []StepReport{},
+		StatusCodes:  r.statusCodes,
+		Anomalies:    r.anomalies,
+		Failures:     r.failures,
+	}
+	for _, count := range r.failures {
+		report.Failed += count
+	}
+
+	for _, step := range []string{StepStart, StepLoad, StepAnswer, StepSubmit} {
+		latencies := r.latencies[step]
+		if len(latencies) == 0 {
+			continue
+		}
+		stepReport := summarizeLatencies(latencies)
+		stepReport.Name = step
+		stepReport.Errors = r.errors[step]
+		report.Steps = append(report.Steps, stepReport)
+		report.Requests += stepReport.Requests
+	}
+
+	if duration > 0 {
+		report.Throughput = float64(report.Requests) / duration.Seconds()
+	}
+	return report
+}
+
+func summarizeLatencies(latencies []time.Duration) StepReport {
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+
+	return StepReport{
+		Requests: len(sorted),
+		Mean:     total / time.Duration(len(sorted)),
+		P50:      percentile(sorted, 50),
+		P95:      percentile(sorted, 95),
+		P99:      percentile(sorted, 99),
+		Max:      sorted[len(sorted)-1],
+	}
+}
+
+// percentile uses the nearest-rank method on already sorted latencies
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// WriteText prints the report in a human readable form
+func (r *Report) WriteText(w io.Writer) {
+	fmt.Fprintf(w, "Assessment %d: %d students, %d completed, %d failed in %s\n",
+		r.AssessmentID, r.Students, r.Completed, r.Failed, r.Duration.Round(time.Millisecond))
+	fmt.Fprintf(w, "%d requests, %.1f req/s\n\n", r.Requests, r.Throughput)
+
+	fmt.Fprintf(w, "%-16s %8s %7s %10s %10s %10s %10s %10s\n", "step", "requests", "errors", "mean", "p50", "p95", "p99", "max")
+	for _, step := range r.Steps {
+		fmt.Fprintf(w, "%-16s %8d %7d %10s %10s %10s %10s %10s\n", step.Name, step.Requests, step.Errors,
+			step.Mean.Round(time.Millisecond), step.P50.Round(time.Millisecond), step.P95.Round(time.Millisecond),
+			step.P99.Round(time.Millisecond), step.Max.Round(time.Millisecond))
+	}
+
+	if len(r.StatusCodes) > 0 {
+		fmt.Fprintln(w, "\nStatus codes:")
+		for _, code := range sortedKeys(r.StatusCodes) {
+			fmt.Fprintf(w, "  %d: %d\n", code, r.StatusCodes[code])
+		}
+	}
+	if len(r.Anomalies) > 0 {
+		fmt.Fprintln(w, "\nConcurrency anomalies:")
+		for name, count := range r.Anomalies {
+			fmt.Fprintf(w, "  %s: %d\n", name, count)
+		}
+	}
+	if len(r.Failures) > 0 {
+		fmt.Fprintln(w, "\nFailures:")
+		for reason, count := range r.Failures {
+			fmt.Fprintf(w, "  %s: %d\n", reason, count)
+		}
+	}
+}
+
+func sortedKeys(m map[int]int) []int {
+	keys := make([]int, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+	return keys
+}
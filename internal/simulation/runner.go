@@ -0,0 +1,286 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Runner drives virtual students through an assessment over the public HTTP API
+type Runner struct {
+	cfg      Config
+	client   *http.Client
+	logger   *slog.Logger
+	recorder *recorder
+}
+
+func NewRunner(cfg Config, logger *slog.Logger) (*Runner, error) {
+	cfg.applyDefaults()
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return &Runner{
+		cfg: cfg,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			Transport: &http.Transport{
+				MaxIdleConns:        cfg.Concurrency,
+				MaxIdleConnsPerHost: cfg.Concurrency,
+			},
+		},
+		logger: logger,
+	}, nil
+}
+
+// Run starts every virtual student, waits for all of them and reports the results
+func (r *Runner) Run(ctx context.Context) (*Report, error) {
+	students := len(r.cfg.Tokens)
+	r.recorder = newRecorder()
+	r.logger.Info("Starting simulation",
+		"assessment_id", r.cfg.AssessmentID,
+		"students", students,
+		"concurrency", r.cfg.Concurrency,
+		"ramp_up", r.cfg.RampUp)
+
+	slots := make(chan struct{}, r.cfg.Concurrency)
+	var wg sync.WaitGroup
+	started := time.Now()
+
+	for i, token := range r.cfg.Tokens {
+		// Students arrive evenly over the ramp-up period
+		arrival := time.Duration(0)
+		if students > 1 {
+			arrival = r.cfg.RampUp * time.Duration(i) / time.Duration(students-1)
+		}
+		if !sleep(ctx, time.Until(started.Add(arrival))) {
+			break
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(index int, token string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			student := &virtualStudent{
+				runner: r,
+				token:  token,
+				rng:    rand.New(rand.NewSource(r.cfg.Seed + int64(index))),
+			}
+			err := student.takeAssessment(ctx)
+			if err != nil {
+				r.logger.Debug("Virtual student failed", "student", index, "error", err)
+			}
+			r.recorder.studentDone(err)
+		}(i, token)
+	}
+
+	wg.Wait()
+	report := r.recorder.report(r.cfg.AssessmentID, students, time.Since(started))
+
+	r.logger.Info("Simulation finished",
+		"completed", report.Completed,
+		"failed", report.Failed,
+		"duration", report.Duration)
+
+	return report, ctx.Err()
+}
+
+// virtualStudent takes the assessment once: start, answer each question, submit
+type virtualStudent struct {
+	runner *Runner
+	token  string
+	rng    *rand.Rand
+}
+
+type attemptPayload struct {
+	ID        uint       `json:"id"`
+	Questions []Question `json:"questions"`
+}
+
+type answerPayload struct {
+	QuestionID uint        `json:"question_id"`
+	AnswerData interface{} `json:"answer_data"`
+	TimeSpent  *int        `json:"time_spent,omitempty"`
+}
+
+func (s *virtualStudent) takeAssessment(ctx context.Context) error {
+	cfg := s.runner.cfg
+	began := time.Now()
+
+	attemptID, err := s.start(ctx)
+	if err != nil {
+		return err
+	}
+
+	var attempt attemptPayload
+	if _, err := s.call(ctx, StepLoad, http.MethodGet, fmt.Sprintf("/attempts/%d/details", attemptID), nil, &attempt); err != nil {
+		return err
+	}
+
+	answers := make([]answerPayload, 0, len(attempt.Questions))
+	for _, question := range attempt.Questions {
+		pause := thinkTime(s.rng, cfg.ThinkMin, cfg.ThinkMax)
+		if !sleep(ctx, pause) {
+			return ctx.Err()
+		}
+
+		spent := int(pause.Seconds())
+		answer := answerPayload{
+			QuestionID: question.ID,
+			AnswerData: generateAnswer(question, s.rng, spent),
+			TimeSpent:  &spent,
+		}
+		if _, err := s.call(ctx, StepAnswer, http.MethodPost, fmt.Sprintf("/attempts/%d/answer", attemptID), answer, nil); err != nil {
+			return err
+		}
+		answers = append(answers, answer)
+	}
+
+	totalSpent := int(time.Since(began).Seconds())
+	submit := map[string]interface{}{
+		"attempt_id": attemptID,
+		"answers":    answers,
+		"time_spent": totalSpent,
+		"confirmed":  true,
+	}
+	return s.submit(ctx, submit)
+}
+
+// start begins the attempt, optionally racing a second start to check only one attempt opens
+func (s *virtualStudent) start(ctx context.Context) (uint, error) {
+	body := map[string]interface{}{"assessment_id": s.runner.cfg.AssessmentID}
+	if !s.runner.cfg.DuplicateStart {
+		var attempt attemptPayload
+		if _, err := s.call(ctx, StepStart, http.MethodPost, "/attempts/start", body, &attempt); err != nil {
+			return 0, err
+		}
+		return attempt.ID, nil
+	}
+
+	var attempts [2]attemptPayload
+	var errs [2]error
+	var wg sync.WaitGroup
+	for i := range attempts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = s.call(ctx, StepStart, http.MethodPost, "/attempts/start", body, &attempts[i])
+		}(i)
+	}
+	wg.Wait()
+
+	if errs[0] == nil && errs[1] == nil && attempts[0].ID != attempts[1].ID {
+		s.runner.recorder.anomaly(AnomalyDuplicateStart)
+	}
+	for i := range attempts {
+		if errs[i] == nil {
+			return attempts[i].ID, nil
+		}
+	}
+	return 0, errs[0]
+}
+
+// submit hands the attempt in, optionally racing a second submit to check it is accepted once
+func (s *virtualStudent) submit(ctx context.Context, body interface{}) error {
+	if !s.runner.cfg.DuplicateSubmit {
+		_, err := s.call(ctx, StepSubmit, http.MethodPost, "/attempts/submit", body, nil)
+		return err
+	}
+
+	var errs [2]error
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = s.call(ctx, StepSubmit, http.MethodPost, "/attempts/submit", body, nil)
+		}(i)
+	}
+	wg.Wait()
+
+	if errs[0] == nil && errs[1] == nil {
+		s.runner.recorder.anomaly(AnomalyDuplicateSubmit)
+	}
+	if errs[0] == nil || errs[1] == nil {
+		return nil
+	}
+	return errs[0]
+}
+
+// call sends one API request and records its latency. Any non-2xx response is an error
+// named after the step so failures group by where students got stuck.
+func (s *virtualStudent) call(ctx context.Context, step, method, path string, body, out interface{}) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return 0, fmt.Errorf("%s: failed to encode request: %w", step, err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.runner.cfg.BaseURL+path, reader)
+	if err != nil {
+		return 0, fmt.Errorf("%s: failed to build request: %w", step, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	began := time.Now()
+	resp, err := s.runner.client.Do(req)
+	latency := time.Since(began)
+	if err != nil {
+		s.runner.recorder.request(step, 0, latency, true)
+		return 0, fmt.Errorf("%s: request failed", step)
+	}
+	defer resp.Body.Close()
+
+	failed := resp.StatusCode < 200 || resp.StatusCode >= 300
+	s.runner.recorder.request(step, resp.StatusCode, latency, failed)
+	if failed {
+		io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, fmt.Errorf("%s: status %d", step, resp.StatusCode)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, fmt.Errorf("%s: invalid response body", step)
+		}
+	} else {
+		io.Copy(io.Discard, resp.Body)
+	}
+	return resp.StatusCode, nil
+}
+
+// sleep waits for d unless the context ends first
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
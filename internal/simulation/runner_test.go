@@ -0,0 +1,109 @@
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SAP-F-2025/assessment-service/internal/models"
+)
+
+// fakeService mimics the attempt endpoints; it accepts every submit, so racing
+// submits must show up as anomalies
+func fakeService(t *testing.T) *httptest.Server {
+	var mu sync.Mutex
+	nextID := 0
+	tf, _ := json.Marshal(models.TrueFalseContent{})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /attempts/start", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		nextID++
+		id := nextID
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": id})
+	})
+	mux.HandleFunc("GET /attempts/{id}/details", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"questions": []Question{{ID: 1, Type: models.TrueFalse, Content: tf}, {ID: 2, Type: models.ShortAnswer}},
+		})
+	})
+	mux.HandleFunc("POST /attempts/{id}/answer", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("POST /attempts/submit", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRunnerRun(t *testing.T) {
+	server := fakeService(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	runner, err := NewRunner(Config{
+		BaseURL:         server.URL + "/",
+		AssessmentID:    7,
+		Tokens:          []string{"s1", "s2", "s3", "s4", "s5"},
+		Concurrency:     2,
+		ThinkMin:        time.Millisecond,
+		ThinkMax:        2 * time.Millisecond,
+		Seed:            42,
+		DuplicateSubmit: true,
+	}, logger)
+	if err != nil {
+		t.Fatalf("unexpected config error: %v", err)
+	}
+
+	report, err := runner.Run(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected run error: %v", err)
+	}
+
+	if report.Completed != 5 || report.Failed != 0 {
+		t.Fatalf("expected every student to finish, got %+v", report)
+	}
+	if report.Anomalies[AnomalyDuplicateSubmit] != 5 {
+		t.Errorf("fake service accepts double submits, expected 5 anomalies, got %v", report.Anomalies)
+	}
+
+	requests := map[string]int{}
+	for _, step := range report.Steps {
+		requests[step.Name] = step.Requests
+	}
+	if requests[StepStart] != 5 || requests[StepAnswer] != 10 || requests[StepSubmit] != 10 {
+		t.Errorf("unexpected request counts: %v", requests)
+	}
+}
+
+func TestNewRunnerValidatesConfig(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	if _, err := NewRunner(Config{BaseURL: "http://localhost", AssessmentID: 1}, logger); err == nil {
+		t.Error("a run without student tokens should be rejected")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[i] = time.Duration(100-i) * time.Millisecond
+	}
+	summary := summarizeLatencies(latencies)
+	if summary.P50 != 50*time.Millisecond || summary.P95 != 95*time.Millisecond || summary.Max != 100*time.Millisecond {
+		t.Errorf("unexpected percentiles: %+v", summary)
+	}
+}
@@ -0,0 +1,282 @@
+package validator
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Content warning codes
+const (
+	WarningSpelling    = "spelling"
+	WarningReadability = "readability"
+)
+
+// Texts shorter than this give unreliable readability grades and are not flagged
+const readabilityMinWords = 10
+
+// ContentWarning is a non-blocking issue found while linting question content
+type ContentWarning struct {
+	Field      string `json:"field"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Value      string `json:"value"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// ReadabilityScore holds Flesch readability measures for a text
+type ReadabilityScore struct {
+	Words         int     `json:"words"`
+	Sentences     int     `json:"sentences"`
+	Syllables     int     `json:"syllables"`
+	ReadingEase   float64 `json:"reading_ease"` // 0-100, higher is easier
+	GradeLevel    float64 `json:"grade_level"`  // Flesch-Kincaid US school grade
+	TargetGrade   float64 `json:"target_grade,omitempty"`
+	AboveTarget   bool    `json:"above_target"`
+	ReliableScore bool    `json:"reliable_score"` // False for texts too short to grade
+}
+
+// ContentLintResult is the outcome of linting one question
+type ContentLintResult struct {
+	Readability *ReadabilityScore `json:"readability"`
+	Warnings    []ContentWarning  `json:"warnings"`
+}
+
+// ContentLinter flags spelling mistakes and scores readability of question content
+type ContentLinter struct {
+	misspellings map[string]string
+}
+
+// NewContentLinter creates a content linter using the built-in misspelling list
+func NewContentLinter() *ContentLinter {
+	return &ContentLinter{misspellings: commonMisspellings}
+}
+
+// LintQuestion checks the question text and explanation. Readability is graded on the
+// question text only; targetGrade of zero skips the grade level check.
+func (l *ContentLinter) LintQuestion(text string, explanation *string, targetGrade float64) *ContentLintResult {
+	result := &ContentLintResult{
+		Warnings: l.CheckSpelling("text", text),
+	}
+	if explanation != nil {
+		result.Warnings = append(result.Warnings, l.CheckSpelling("explanation", *explanation)...)
+	}
+
+	score := Readability(text)
+	score.TargetGrade = targetGrade
+	if targetGrade > 0 && score.ReliableScore && score.GradeLevel > targetGrade {
+		score.AboveTarget = true
+		result.Warnings = append(result.Warnings, ContentWarning{
+			Field:   "text",
+			Code:    WarningReadability,
+			Message: fmt.Sprintf("reading level is grade %.1f, above the target grade %.1f", score.GradeLevel, targetGrade),
+			Value:   fmt.Sprintf("%.1f", score.GradeLevel),
+		})
+	}
+	result.Readability = score
+
+	return result
+}
+
+// CheckSpelling flags known misspellings in text, once per distinct word
+func (l *ContentLinter) CheckSpelling(field, text string) []ContentWarning {
+	warnings := make([]ContentWarning, 0)
+	seen := make(map[string]bool)
+	for _, word := range splitWords(text) {
+		lower := strings.ToLower(word)
+		suggestion, ok := l.misspellings[lower]
+		if !ok || seen[lower] {
+			continue
+		}
+		seen[lower] = true
+		warnings = append(warnings, ContentWarning{
+			Field:      field,
+			Code:       WarningSpelling,
+			Message:    fmt.Sprintf("possible misspelling of %q", suggestion),
+			Value:      word,
+			Suggestion: suggestion,
+		})
+	}
+	return warnings
+}
+
+// Readability computes Flesch reading ease and Flesch-Kincaid grade level
+func Readability(text string) *ReadabilityScore {
+	words := splitWords(text)
+	score := &ReadabilityScore{
+		Words:     len(words),
+		Sentences: countSentences(text),
+	}
+	if score.Words == 0 {
+		return score
+	}
+
+	for _, word := range words {
+		score.Syllables += countSyllables(word)
+	}
+
+	wordsPerSentence := float64(score.Words) / float64(score.Sentences)
+	syllablesPerWord := float64(score.Syllables) / float64(score.Words)
+	score.ReadingEase = roundTenth(206.835 - 1.015*wordsPerSentence - 84.6*syllablesPerWord)
+	score.GradeLevel = roundTenth(math.Max(0, 0.39*wordsPerSentence+11.8*syllablesPerWord-15.59))
+	score.ReliableScore = score.Words >= readabilityMinWords
+
+	return score
+}
+
+// splitWords returns the alphabetic words of text, keeping inner apostrophes
+func splitWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+}
+
+// countSentences counts terminators followed by whitespace or the end of the text, so
+// decimals like 2.5 are not sentence breaks; text without one is a single sentence
+func countSentences(text string) int {
+	runes := []rune(strings.TrimSpace(text))
+	count := 0
+	for i, r := range runes {
+		if r != '.' && r != '!' && r != '?' {
+			continue
+		}
+		if i+1 == len(runes) || unicode.IsSpace(runes[i+1]) {
+			count++
+		}
+	}
+
+	if len(runes) > 0 {
+		last := runes[len(runes)-1]
+		if last != '.' && last != '!' && last != '?' {
+			count++
+		}
+	}
+	if count == 0 {
+		count = 1
+	}
+	return count
+}
+
+// countSyllables estimates syllables from vowel groups, ignoring a silent final e
+func countSyllables(word string) int {
+	word = strings.ToLower(strings.Trim(word, "'"))
+	if len(word) <= 3 {
+		return 1
+	}
+
+	count := 0
+	prevVowel := false
+	for _, r := range word {
+		isVowel := strings.ContainsRune("aeiouy", r)
+		if isVowel && !prevVowel {
+			count++
+		}
+		prevVowel = isVowel
+	}
+
+	if strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && count > 1 {
+		count--
+	}
+	if count == 0 {
+		count = 1
+	}
+	return count
+}
+
+func roundTenth(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+// commonMisspellings maps frequent English misspellings to their correction
+var commonMisspellings = map[string]string{
+	"accomodate":     "accommodate",
+	"acheive":        "achieve",
+	"accross":        "across",
+	"adress":         "address",
+	"agressive":      "aggressive",
+	"apparantly":     "apparently",
+	"arguement":      "argument",
+	"basicly":        "basically",
+	"begining":       "beginning",
+	"beleive":        "believe",
+	"calender":       "calendar",
+	"catagory":       "category",
+	"cemetary":       "cemetery",
+	"collegue":       "colleague",
+	"comming":        "coming",
+	"commited":       "committed",
+	"completly":      "completely",
+	"concious":       "conscious",
+	"definately":     "definitely",
+	"dependant":      "dependent",
+	"diffrent":       "different",
+	"dissapear":      "disappear",
+	"embarass":       "embarrass",
+	"enviroment":     "environment",
+	"equiptment":     "equipment",
+	"exagerate":      "exaggerate",
+	"existance":      "existence",
+	"experiance":     "experience",
+	"explaination":   "explanation",
+	"familar":        "familiar",
+	"finaly":         "finally",
+	"foriegn":        "foreign",
+	"fourty":         "forty",
+	"freind":         "friend",
+	"goverment":      "government",
+	"grammer":        "grammar",
+	"happend":        "happened",
+	"harrass":        "harass",
+	"hieght":         "height",
+	"immediatly":     "immediately",
+	"independant":    "independent",
+	"interupt":       "interrupt",
+	"knowlege":       "knowledge",
+	"lenght":         "length",
+	"libary":         "library",
+	"maintainance":   "maintenance",
+	"millenium":      "millennium",
+	"mispell":        "misspell",
+	"neccessary":     "necessary",
+	"noticable":      "noticeable",
+	"occassion":      "occasion",
+	"occured":        "occurred",
+	"occurence":      "occurrence",
+	"paralel":        "parallel",
+	"persistant":     "persistent",
+	"posession":      "possession",
+	"preceeding":     "preceding",
+	"prefered":       "preferred",
+	"probaly":        "probably",
+	"pronounciation": "pronunciation",
+	"publically":     "publicly",
+	"recieve":        "receive",
+	"reccomend":      "recommend",
+	"refered":        "referred",
+	"relevent":       "relevant",
+	"religous":       "religious",
+	"remeber":        "remember",
+	"resistence":     "resistance",
+	"responsability": "responsibility",
+	"rythm":          "rhythm",
+	"seperate":       "separate",
+	"similiar":       "similar",
+	"sucessful":      "successful",
+	"suprise":        "surprise",
+	"temperture":     "temperature",
+	"tendancy":       "tendency",
+	"therefor":       "therefore",
+	"threshhold":     "threshold",
+	"tommorow":       "tomorrow",
+	"tounge":         "tongue",
+	"truely":         "truly",
+	"untill":         "until",
+	"wierd":          "weird",
+	"wich":           "which",
+	"writting":       "writing",
+	"teh":            "the",
+	"thier":          "their",
+	"becuase":        "because",
+	"alot":           "a lot",
+}
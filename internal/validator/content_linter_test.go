@@ -0,0 +1,54 @@
+package validator
+
+import "testing"
+
+func TestReadability(t *testing.T) {
+	simple := Readability("The cat sat on the mat. The dog ran to the cat. It was fun.")
+	if simple.Sentences != 3 || simple.Words != 15 {
+		t.Fatalf("unexpected counts: %+v", simple)
+	}
+	if simple.GradeLevel > 2 || simple.ReadingEase < 90 {
+		t.Errorf("short words and sentences should read easily, got %+v", simple)
+	}
+
+	technical := Readability("Photosynthetic organisms utilize electromagnetic radiation to synthesize carbohydrates from atmospheric carbon dioxide, consequently generating molecular oxygen.")
+	if technical.GradeLevel <= simple.GradeLevel+10 {
+		t.Errorf("technical prose should score a much higher grade, got %+v", technical)
+	}
+
+	if decimal := Readability("What is 2.5 plus 3.75 in total"); decimal.Sentences != 1 {
+		t.Errorf("decimals must not split sentences, got %d", decimal.Sentences)
+	}
+}
+
+func TestLintQuestion(t *testing.T) {
+	linter := NewContentLinter()
+	explanation := "Plants recieve light. Plants recieve water."
+
+	result := linter.LintQuestion(
+		"Which process do plants definately use to produce glucose, consequently releasing considerable quantities of atmospheric oxygen?",
+		&explanation,
+		6,
+	)
+
+	var spelling, readability int
+	for _, warning := range result.Warnings {
+		switch warning.Code {
+		case WarningSpelling:
+			spelling++
+		case WarningReadability:
+			readability++
+		}
+	}
+	if spelling != 2 {
+		t.Errorf("expected one warning per misspelled word and field, got %+v", result.Warnings)
+	}
+	if readability != 1 || !result.Readability.AboveTarget {
+		t.Errorf("expected readability warning above grade 6, got %+v", result.Readability)
+	}
+
+	short := linter.LintQuestion("Define osmosis.", nil, 1)
+	if len(short.Warnings) != 0 || short.Readability.ReliableScore {
+		t.Errorf("short texts should not be graded against the target, got %+v", short)
+	}
+}
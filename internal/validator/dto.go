@@ -15,8 +15,14 @@ type AssessmentCreateRequest struct {
 	MaxAttempts  int                         `json:"max_attempts" validate:"required,max_attempts"`
 	TimeWarning  *int                        `json:"time_warning" validate:"omitempty,min=60,max=1800"`
 	DueDate      *time.Time                  `json:"due_date" validate:"omitempty,future_date"`
+	DueTimezone  *string                     `json:"due_timezone" validate:"omitempty,timezone"` // IANA zone, e.g. "Europe/Berlin"; defaults to UTC
+	Term         *string                     `json:"term" validate:"omitempty,min=1,max=50"`
 	Settings     *AssessmentSettingsRequest  `json:"settings"`
 	Questions    []AssessmentQuestionRequest `json:"questions"`
+	CustomFields map[string]interface{}      `json:"custom_fields"` // Values of the organization's custom fields, by key
+
+	// Rename a taken title to the first free "Title (n)" instead of rejecting it
+	AutoSuffixTitle bool `json:"auto_suffix_title"`
 }
 
 // AssessmentUpdateRequest represents the request structure for updating assessments
@@ -28,7 +34,13 @@ type AssessmentUpdateRequest struct {
 	MaxAttempts  *int                       `json:"max_attempts" validate:"omitempty,max_attempts"`
 	TimeWarning  *int                       `json:"time_warning" validate:"omitempty,min=60,max=1800"`
 	DueDate      *time.Time                 `json:"due_date" validate:"omitempty,future_date"`
+	DueTimezone  *string                    `json:"due_timezone" validate:"omitempty,timezone"` // IANA zone, e.g. "Europe/Berlin"; defaults to UTC
+	Term         *string                    `json:"term" validate:"omitempty,min=1,max=50"`
 	Settings     *AssessmentSettingsRequest `json:"settings"`
+	CustomFields map[string]interface{}     `json:"custom_fields"` // Changed fields only; null removes a field
+
+	// Rename a taken title to the first free "Title (n)" instead of rejecting it
+	AutoSuffixTitle bool `json:"auto_suffix_title"`
 }
 
 // AssessmentSettingsRequest represents assessment settings
@@ -40,11 +52,17 @@ type AssessmentSettingsRequest struct {
 	ShowResults                 *bool `json:"show_results"`
 	ShowCorrectAnswers          *bool `json:"show_correct_answers"`
 	ShowScoreBreakdown          *bool `json:"show_score_breakdown"`
+	ShowRank                    *bool `json:"show_rank"`
+	AnonymousGrading            *bool `json:"anonymous_grading"`
 	AllowRetake                 *bool `json:"allow_retake"`
 	RetakeDelay                 *int  `json:"retake_delay" validate:"omitempty,min=0,max=1440"`
+	RequireAllAnswered          *bool `json:"require_all_answered"`
+	RequireFlaggedResolved      *bool `json:"require_flagged_resolved"`
+	RequireSubmitConfirmation   *bool `json:"require_submit_confirmation"`
 	TimeLimitEnforced           *bool `json:"time_limit_enforced"`
 	AutoSubmitOnTimeout         *bool `json:"auto_submit_on_timeout"`
 	RequireWebcam               *bool `json:"require_webcam"`
+	RequireScreenRecording      *bool `json:"require_screen_recording"`
 	PreventTabSwitching         *bool `json:"prevent_tab_switching"`
 	PreventRightClick           *bool `json:"prevent_right_click"`
 	PreventCopyPaste            *bool `json:"prevent_copy_paste"`
@@ -53,6 +71,41 @@ type AssessmentSettingsRequest struct {
 	AllowScreenReader           *bool `json:"allow_screen_reader"`
 	FontSizeAdjustment          *int  `json:"font_size_adjustment" validate:"omitempty,min=-2,max=2"`
 	HighContrastMode            *bool `json:"high_contrast_mode"`
+
+	// Extra time for students with a timing accommodation, in percent of the time limit
+	AccommodationExtraTime *int `json:"accommodation_extra_time" validate:"omitempty,min=0,max=300"`
+
+	ResultsReleaseMode *models.ResultsReleaseMode `json:"results_release_mode" validate:"omitempty,oneof=immediate manual scheduled"`
+	ResultsReleaseAt   *time.Time                 `json:"results_release_at"`
+
+	TimingMode *models.TimingMode `json:"timing_mode" validate:"omitempty,oneof=total per_question"`
+
+	GradePolicy *models.GradePolicy `json:"grade_policy" validate:"omitempty,oneof=highest latest average"`
+
+	// Lets students leave an attempt and come back later; the clock stops while they are away
+	AllowSaveAndExit *bool `json:"allow_save_and_exit"`
+
+	// Makes the questions of a section read-only once the student moves on to a later one
+	LockCompletedSections *bool `json:"lock_completed_sections"`
+
+	// Asks students to rate their confidence in each answer, for calibration analytics
+	AskConfidence *bool `json:"ask_confidence"`
+
+	// Language essay answers are expected in, as an ISO 639-1 code; answers detected in
+	// another language are flagged to graders. An empty value turns the check off.
+	ExpectedLanguage *string `json:"expected_language" validate:"omitempty,oneof=en es fr de it pt nl vi ru uk el ar he zh ja ko th hi"`
+
+	// Attempts in progress at once; further students wait in a queue. 0 removes the cap.
+	MaxConcurrentAttempts *int `json:"max_concurrent_attempts" validate:"omitempty,min=0,max=10000"`
+
+	// Allowed resources; an empty formula_sheet_url removes the formula sheet
+	CalculatorType  *models.CalculatorType `json:"calculator_type" validate:"omitempty,oneof=none basic scientific graphing"`
+	FormulaSheetURL *string                `json:"formula_sheet_url" validate:"omitempty,max=500"`
+	AllowDictionary *bool                  `json:"allow_dictionary"`
+	AllowSpellCheck *bool                  `json:"allow_spell_check"`
+
+	// Proctoring provider that must support the requested proctoring features at publish time
+	ProctoringProvider *string `json:"proctoring_provider" validate:"omitempty,min=1,max=50"`
 }
 
 // AssessmentQuestionRequest represents adding questions to assessments
@@ -73,6 +126,13 @@ type QuestionCreateRequest struct {
 	CategoryID  *uint                  `json:"category_id"`
 	Tags        []string               `json:"tags" validate:"omitempty,max=10,dive,max=50"`
 	Explanation *string                `json:"explanation" validate:"omitempty,max=1000"`
+
+	ReviewIntervalMonths *int `json:"review_interval_months" validate:"omitempty,min=1,max=60"`
+	RequireManualReview  bool `json:"require_manual_review"`
+
+	// Optional content linting; warnings never block creation
+	Lint             bool     `json:"lint"`
+	TargetGradeLevel *float64 `json:"target_grade_level" validate:"omitempty,min=1,max=18"`
 }
 
 // QuestionUpdateRequest represents the request structure for updating questions
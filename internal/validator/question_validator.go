@@ -41,6 +41,8 @@ func (v *QuestionValidator) ValidateContent(questionType models.QuestionType, co
 		return v.validateOrderingContent(contentBytes)
 	case models.ShortAnswer:
 		return v.validateShortAnswerContent(contentBytes)
+	case models.MultiPart:
+		return v.validateMultiPartContent(contentBytes)
 	default:
 		return fmt.Errorf("unsupported question type: %s", questionType)
 	}
@@ -163,6 +165,23 @@ func (v *QuestionValidator) validateEssayContent(contentBytes []byte) error {
 		return fmt.Errorf("maximum word count cannot be negative")
 	}
 
+	if len(content.Rubric) > 0 && len(content.Rubric) != len(content.RubricCriteria) {
+		return fmt.Errorf("rubric must have levels for every rubric criterion")
+	}
+	for i, criterion := range content.Rubric {
+		if criterion.Name != content.RubricCriteria[i] {
+			return fmt.Errorf("rubric criterion %d must match rubric_criteria", i+1)
+		}
+		if len(criterion.Levels) == 0 {
+			return fmt.Errorf("rubric criterion %q must have at least 1 level", criterion.Name)
+		}
+		for _, level := range criterion.Levels {
+			if level.Points < 0 {
+				return fmt.Errorf("rubric criterion %q cannot have negative points", criterion.Name)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -263,6 +282,12 @@ func (v *QuestionValidator) validateOrderingContent(contentBytes []byte) error {
 		return fmt.Errorf("correct order must include all items exactly once")
 	}
 
+	switch content.Scoring {
+	case "", models.OrderingScoringPosition, models.OrderingScoringKendallTau, models.OrderingScoringAdjacentPairs:
+	default:
+		return fmt.Errorf("scoring must be position, kendall_tau or adjacent_pairs")
+	}
+
 	// Validate item IDs and text
 	itemIDs := make(map[string]bool)
 	for _, item := range content.Items {
@@ -316,3 +341,44 @@ func (v *QuestionValidator) validateShortAnswerContent(contentBytes []byte) erro
 
 	return nil
 }
+
+func (v *QuestionValidator) validateMultiPartContent(contentBytes []byte) error {
+	var content models.MultiPartContent
+	if err := json.Unmarshal(contentBytes, &content); err != nil {
+		return fmt.Errorf("invalid multi-part content: %w", err)
+	}
+
+	if len(content.Parts) < 2 {
+		return fmt.Errorf("must have at least 2 parts")
+	}
+
+	if len(content.Parts) > 10 {
+		return fmt.Errorf("cannot have more than 10 parts")
+	}
+
+	partIDs := make(map[string]bool)
+	for i, part := range content.Parts {
+		if part.ID == "" {
+			return fmt.Errorf("part %d must have an ID", i+1)
+		}
+		if partIDs[part.ID] {
+			return fmt.Errorf("duplicate part ID: %s", part.ID)
+		}
+		partIDs[part.ID] = true
+
+		if part.Type == models.MultiPart {
+			return fmt.Errorf("part '%s' cannot itself be a multi-part question", part.ID)
+		}
+		if part.Points < 1 {
+			return fmt.Errorf("part '%s' must be worth at least 1 point", part.ID)
+		}
+		if part.GradingMode != "" && part.GradingMode != models.PartGradingAuto && part.GradingMode != models.PartGradingManual {
+			return fmt.Errorf("part '%s' has invalid grading mode: %s", part.ID, part.GradingMode)
+		}
+		if err := v.ValidateContent(part.Type, part.Content); err != nil {
+			return fmt.Errorf("part '%s': %w", part.ID, err)
+		}
+	}
+
+	return nil
+}
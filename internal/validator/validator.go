@@ -13,6 +13,7 @@ type Validator struct {
 	structValidator   *validator.Validate
 	businessValidator *BusinessValidator
 	questionValidator *QuestionValidator
+	contentLinter     *ContentLinter
 }
 
 // New creates a new centralized validator instance
@@ -26,6 +27,7 @@ func New() *Validator {
 		structValidator:   structValidator,
 		businessValidator: NewBusinessValidator(),
 		questionValidator: NewQuestionValidator(),
+		contentLinter:     NewContentLinter(),
 	}
 }
 
@@ -73,6 +75,11 @@ func (v *Validator) GetQuestionValidator() *QuestionValidator {
 	return v.questionValidator
 }
 
+// ContentLinter returns the spelling and readability linter
+func (v *Validator) ContentLinter() *ContentLinter {
+	return v.contentLinter
+}
+
 // registerCustomValidators registers all custom validation functions
 func registerCustomValidators(validate *validator.Validate) {
 	// Question type validation
@@ -110,6 +117,7 @@ func validateQuestionType(fl validator.FieldLevel) bool {
 		models.Matching,
 		models.Ordering,
 		models.ShortAnswer,
+		models.MultiPart,
 	}
 
 	value := fl.Field().String()
@@ -12,12 +12,14 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/redis/go-redis/v9"
 
+	"github.com/SAP-F-2025/assessment-service/internal/cache"
 	"github.com/SAP-F-2025/assessment-service/internal/config"
+	"github.com/SAP-F-2025/assessment-service/internal/events"
 	"github.com/SAP-F-2025/assessment-service/internal/handlers"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories/casdoor"
 	"github.com/SAP-F-2025/assessment-service/internal/repositories/postgres"
+	"github.com/SAP-F-2025/assessment-service/internal/residency"
 	"github.com/SAP-F-2025/assessment-service/internal/services"
 	"github.com/SAP-F-2025/assessment-service/internal/utils"
 	"github.com/SAP-F-2025/assessment-service/internal/validator"
@@ -43,19 +45,40 @@ func main() {
 		log.Fatalf("Failed to initialize database: %v", err)
 	}
 
-	// Initialize Redis (if configured)
-	var redisClient *redis.Client
-	if cfg.RedisURL != "" {
-		redisClient, err = pkg.NewRedisClient(cfg)
+	// Organizations pinned to a data residency region are stored in the region's database
+	regionDBs, err := pkg.InitRegionDatabases(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize region databases: %v", err)
+	}
+	if err := postgres.EnableResidencyRouting(db, regionDBs); err != nil {
+		log.Fatalf("Failed to enable data residency routing: %v", err)
+	}
+
+	// Initialize Redis (if configured). Repositories skip the cache while it is unreachable.
+	var redisClient *cache.Client
+	if cfg.Redis.Enabled() {
+		rawClient, err := pkg.NewRedisClient(cfg)
 		if err != nil {
 			log.Printf("Warning: Failed to initialize Redis: %v", err)
+		} else {
+			redisClient = cache.NewClient(rawClient, slogLogger)
+			if err := redisClient.Settings().Apply(cfg.Cache); err != nil {
+				log.Fatalf("Failed to apply cache configuration: %v", err)
+			}
 		}
 	}
 
+	// Large answers and cached values are stored compressed; older uncompressed ones still read
+	compressor, err := cache.NewCompressor(cfg.CompressionThreshold)
+	if err != nil {
+		log.Fatalf("Failed to initialize compression: %v", err)
+	}
+
 	// Initialize repositories
 	repoConfig := postgres.RepositoryConfig{
 		DB:          db,
 		RedisClient: redisClient,
+		Compressor:  compressor,
 		CasdoorConfig: casdoor.CasdoorConfig{
 			Endpoint:         cfg.Casdoor.Endpoint,
 			ClientID:         cfg.Casdoor.ClientID,
@@ -73,14 +96,51 @@ func main() {
 	// Initialize validator
 	validator := validator.New()
 
+	// Initialize event publisher
+	eventPublisher, err := cfg.Events.CreateEventPublisher(slogLogger)
+	if err != nil {
+		log.Printf("Warning: Failed to create event publisher, falling back to mock: %v", err)
+		eventPublisher = events.NewMockEventPublisher(slogLogger)
+	}
+
 	// Initialize services
-	serviceManager := services.NewDefaultServiceManager(db, repoManager.GetRepository(), slogLogger, validator)
+	serviceConfig := services.DefaultServiceManagerConfig()
+	serviceConfig.ResidencyRegions = cfg.Residency.Names()
+	serviceConfig.RegionAttachmentStorageDirs = make(map[string]string, len(cfg.Residency.Regions))
+	for name, region := range cfg.Residency.Regions {
+		serviceConfig.RegionAttachmentStorageDirs[name] = region.AttachmentDir
+	}
+	serviceConfig.MediaCDNBaseURL = cfg.MediaCDNBaseURL
+	serviceManager := services.NewServiceManager(db, repoManager.GetRepository(), slogLogger, validator, eventPublisher, serviceConfig)
 	if err := serviceManager.Initialize(context.Background()); err != nil {
 		log.Fatalf("Failed to initialize services: %v", err)
 	}
 
+	// Start background schedulers, once for the primary database and once for each region
+	schedulerCtx, stopSchedulers := context.WithCancel(context.Background())
+	for _, region := range append([]string{""}, cfg.Residency.Names()...) {
+		regionCtx := residency.WithRegion(schedulerCtx, region)
+		go serviceManager.Assessment().RunScheduler(regionCtx, time.Minute)
+		go serviceManager.Report().RunScheduler(regionCtx, 15*time.Minute)
+		go serviceManager.Results().RunScheduler(regionCtx, time.Minute)
+		go serviceManager.ImportExport().RunScheduler(regionCtx, time.Minute)
+		go serviceManager.Analytics().RunScheduler(regionCtx, time.Hour)
+		go serviceManager.Attempt().RunScheduler(regionCtx, time.Second)
+		go serviceManager.Attachment().RunScheduler(regionCtx, time.Minute)
+		go serviceManager.Gradebook().RunScheduler(regionCtx, time.Minute)
+		go serviceManager.Grading().RunScheduler(regionCtx, time.Minute)
+		go serviceManager.Usage().RunScheduler(regionCtx, time.Hour)
+		go serviceManager.QuestionBank().RunScheduler(regionCtx, time.Hour)
+		go serviceManager.Warehouse().RunScheduler(regionCtx, 15*time.Minute)
+		go serviceManager.Webhook().RunScheduler(regionCtx, 30*time.Second)
+		go serviceManager.Jobs().RunScheduler(regionCtx, 5*time.Second)
+	}
+	if redisClient != nil {
+		go redisClient.Monitor(schedulerCtx, cfg.Redis.HealthCheckInterval)
+	}
+
 	// Initialize handlers
-	handlerManager := handlers.NewHandlerManager(serviceManager, validator, logger, cfg.Casdoor, repoManager.GetRepository().User())
+	handlerManager := handlers.NewHandlerManager(serviceManager, validator, logger, cfg.Casdoor, repoManager.GetRepository().User(), compressor, redisClient)
 
 	// Setup Gin router
 	if cfg.Environment == "production" {
@@ -118,6 +178,9 @@ func main() {
 
 	logger.Info("Shutting down server...")
 
+	// Stop background schedulers
+	stopSchedulers()
+
 	// Graceful shutdown with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -136,6 +199,11 @@ func main() {
 	if sqlDB, err := db.DB(); err == nil {
 		sqlDB.Close()
 	}
+	for _, regionDB := range regionDBs {
+		if sqlDB, err := regionDB.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
 
 	// Close Redis connection
 	if redisClient != nil {
@@ -10,16 +10,7 @@ import (
 )
 
 func InitDatabase(cfg *config.Config) (*gorm.DB, error) {
-	var logLevel logger.LogLevel
-	if cfg.Environment == "production" {
-		logLevel = logger.Info
-	} else {
-		logLevel = logger.Error
-	}
-
-	db, err := gorm.Open(postgres.Open(cfg.DatabaseURL), &gorm.Config{
-		Logger: logger.Default.LogMode(logLevel),
-	})
+	db, err := openDatabase(cfg, cfg.DatabaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -34,3 +25,29 @@ func InitDatabase(cfg *config.Config) (*gorm.DB, error) {
 
 	return db, nil
 }
+
+// InitRegionDatabases connects to the database of each data residency region
+func InitRegionDatabases(cfg *config.Config) (map[string]*gorm.DB, error) {
+	regions := make(map[string]*gorm.DB, len(cfg.Residency.Regions))
+	for name, region := range cfg.Residency.Regions {
+		db, err := openDatabase(cfg, region.DatabaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to database of region %s: %w", name, err)
+		}
+		regions[name] = db
+	}
+	return regions, nil
+}
+
+func openDatabase(cfg *config.Config, url string) (*gorm.DB, error) {
+	var logLevel logger.LogLevel
+	if cfg.Environment == "production" {
+		logLevel = logger.Info
+	} else {
+		logLevel = logger.Error
+	}
+
+	return gorm.Open(postgres.Open(url), &gorm.Config{
+		Logger: logger.Default.LogMode(logLevel),
+	})
+}
@@ -1,29 +1,56 @@
 package pkg
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/SAP-F-2025/assessment-service/internal/config"
 	"github.com/redis/go-redis/v9"
 )
 
-func NewRedisClient(cfg *config.Config) (*redis.Client, error) {
-	// Implementation for creating a new Redis client
+// NewRedisClient creates a standalone, cluster or sentinel client from configuration.
+// Connectivity is not checked here so that the service can start while Redis is down.
+func NewRedisClient(cfg *config.Config) (redis.UniversalClient, error) {
+	redisCfg := cfg.Redis
 
-	opt, err := redis.ParseURL(cfg.RedisURL)
-	if err != nil {
-		return nil, err
-	}
+	switch redisCfg.Mode {
+	case config.RedisModeCluster:
+		addrs := redisCfg.GetAddrs()
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("redis cluster mode requires REDIS_ADDRS")
+		}
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:    addrs,
+			Username: redisCfg.Username,
+			Password: redisCfg.Password,
+		}), nil
 
-	client := redis.NewClient(opt)
+	case config.RedisModeSentinel:
+		addrs := redisCfg.GetAddrs()
+		if len(addrs) == 0 || redisCfg.MasterName == "" {
+			return nil, fmt.Errorf("redis sentinel mode requires REDIS_ADDRS and REDIS_MASTER_NAME")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    redisCfg.MasterName,
+			SentinelAddrs: addrs,
+			Username:      redisCfg.Username,
+			Password:      redisCfg.Password,
+			DB:            redisCfg.DB,
+		}), nil
 
-	// Test connection
-	ctx := context.Background()
-	if err := client.Ping(ctx).Err(); err != nil {
-		client.Close()
-		return nil, fmt.Errorf("redis connection failed: %w", err)
-	}
+	case config.RedisModeStandalone, "":
+		opt, err := redis.ParseURL(redisCfg.URL)
+		if err != nil {
+			return nil, err
+		}
+		if redisCfg.Username != "" {
+			opt.Username = redisCfg.Username
+		}
+		if redisCfg.Password != "" {
+			opt.Password = redisCfg.Password
+		}
+		return redis.NewClient(opt), nil
 
-	return client, nil
+	default:
+		return nil, fmt.Errorf("unsupported redis mode: %s", redisCfg.Mode)
+	}
 }